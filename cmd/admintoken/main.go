@@ -0,0 +1,64 @@
+// Command admintoken issues short-lived admin API bearer tokens signed with
+// ADMIN_TOKEN_SECRET, for operators and for services without their own issuer.
+//
+// Usage:
+//
+//	go run ./cmd/admintoken -sub replay-bot -role admin:write -ttl 5m
+//	go run ./cmd/admintoken -sub oncall -role admin:write,admin:debug
+//
+// Pass the printed token as "Authorization: Bearer <token>".
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adminauth"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/config"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "admintoken:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses flags and prints a token.
+func run(args []string) error {
+	fs := flag.NewFlagSet("admintoken", flag.ContinueOnError)
+	subject := fs.String("sub", "", "calling service or operator (required)")
+	role := fs.String("role", string(adminauth.RoleRead), "comma-separated granted roles: admin:read, admin:write, admin:debug")
+	ttl := fs.Duration("ttl", 5*time.Minute, "token lifetime (at most ADMIN_TOKEN_MAX_TTL)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	authority, err := cfg.Admin.Authority()
+	if err != nil {
+		return err
+	}
+	if authority == nil {
+		return errors.New("ADMIN_TOKEN_SECRET is not set")
+	}
+
+	var roles []adminauth.Role
+	for _, r := range strings.Split(*role, ",") {
+		roles = append(roles, adminauth.Role(strings.TrimSpace(r)))
+	}
+
+	token, err := authority.Issue(*subject, *ttl, roles...)
+	if err != nil {
+		return err
+	}
+	fmt.Println(token)
+	return nil
+}
@@ -0,0 +1,90 @@
+// Command genadapter generates provider adapter code from a schema definition.
+//
+// Usage:
+//
+//	go run ./cmd/genadapter -schema internal/adapter/provider/garuda/schema.json
+//
+// It writes models.go next to the schema: the provider's request and response
+// structs. With -skeleton it also writes adapter.go and normalizer.go
+// skeletons for a new adapter; existing files are never overwritten.
+//
+// Existing adapters regenerate their models with go generate:
+//
+//	//go:generate go run ../../../../cmd/genadapter -schema schema.json
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adaptergen"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "genadapter:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses flags and writes the generated files.
+func run(args []string) error {
+	flags := flag.NewFlagSet("genadapter", flag.ContinueOnError)
+	schemaPath := flags.String("schema", "schema.json", "provider schema definition")
+	out := flags.String("out", "", "output directory (default: the schema's directory)")
+	skeleton := flags.Bool("skeleton", false, "also write adapter.go and normalizer.go skeletons if they don't exist")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	schema, err := adaptergen.Load(*schemaPath)
+	if err != nil {
+		return err
+	}
+	dir := *out
+	if dir == "" {
+		dir = filepath.Dir(*schemaPath)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	models, err := adaptergen.GenerateModels(schema, filepath.Base(*schemaPath))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), models, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s\n", filepath.Join(dir, "models.go"))
+
+	if !*skeleton {
+		return nil
+	}
+	for name, generate := range map[string]func(*adaptergen.Schema) ([]byte, error){
+		"adapter.go":    adaptergen.GenerateAdapter,
+		"normalizer.go": adaptergen.GenerateNormalizer,
+	} {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			fmt.Printf("skipped %s: file exists\n", path)
+			continue
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		src, err := generate(schema)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, src, 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %s\n", path)
+	}
+	return nil
+}
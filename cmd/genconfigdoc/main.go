@@ -0,0 +1,48 @@
+// Command genconfigdoc generates the descriptions of the configuration
+// options from the doc comments of the configuration struct fields.
+//
+// Usage:
+//
+//	go run ./cmd/genconfigdoc -src internal/config/config.go -out internal/config/descriptions_gen.go
+//
+// The config package regenerates its descriptions with go generate:
+//
+//	//go:generate go run ../../cmd/genconfigdoc -src config.go -out descriptions_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/configdoc"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "genconfigdoc:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses flags and writes the generated file.
+func run(args []string) error {
+	flags := flag.NewFlagSet("genconfigdoc", flag.ContinueOnError)
+	src := flags.String("src", "config.go", "Go source file declaring the configuration structs")
+	out := flags.String("out", "descriptions_gen.go", "generated file")
+	pkg := flags.String("package", "config", "package of the generated file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	descriptions, err := configdoc.Extract(*src)
+	if err != nil {
+		return err
+	}
+	code, err := configdoc.GenerateDescriptions(*pkg, filepath.Base(*src), descriptions)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(*out, code, 0o644)
+}
@@ -0,0 +1,108 @@
+// Command genmock generates large provider mock datasets in each provider's
+// native response schema, for load and pagination testing.
+//
+// Usage:
+//
+//	go run ./cmd/genmock -out tmp/mock -flights 10000 -routes all -days 14
+//
+// Point the demo store or provider adapters at the output directory to serve
+// the generated data; file names match docs/response-mock. With -publish the
+// dataset is also uploaded to the blob store configured by the BLOB_* variables,
+// from where demo instances load it via MOCK_DATA_BLOB_PREFIX.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/config"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/mockdata"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/storage/blob"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "genmock:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses flags and writes the generated datasets.
+func run(args []string) error {
+	defaults := mockdata.DefaultConfig()
+
+	fs := flag.NewFlagSet("genmock", flag.ContinueOnError)
+	out := fs.String("out", "generated-mock", "output directory")
+	providers := fs.String("providers", strings.Join(defaults.Providers, ","), "comma-separated providers to generate")
+	routes := fs.String("routes", "CGK-DPS", `comma-separated routes (e.g. "CGK-DPS,DPS-CGK") or "all"`)
+	start := fs.String("start", defaults.StartDate.Format("2006-01-02"), "first departure date (YYYY-MM-DD)")
+	days := fs.Int("days", defaults.Days, "number of departure dates to spread flights across")
+	flights := fs.Int("flights", defaults.FlightsPerProvider, "flights per provider")
+	dist := fs.String("price-dist", string(defaults.Distribution), "fare distribution: uniform, normal, lognormal")
+	spread := fs.Float64("price-spread", defaults.Spread, "relative fare variation (0-1)")
+	stops := fs.Float64("stop-ratio", defaults.StopRatio, "fraction of flights with one stop (0-1)")
+	seed := fs.Int64("seed", defaults.Seed, "random seed")
+	publish := fs.String("publish", "", `upload the dataset to the blob store under this prefix (e.g. "mock/2025-12/")`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	startDate, err := time.Parse("2006-01-02", *start)
+	if err != nil {
+		return fmt.Errorf("invalid -start: %w", err)
+	}
+	parsedRoutes, err := mockdata.ParseRoutes(*routes)
+	if err != nil {
+		return err
+	}
+
+	gen, err := mockdata.New(mockdata.Config{
+		Providers:          strings.Split(*providers, ","),
+		Routes:             parsedRoutes,
+		StartDate:          startDate,
+		Days:               *days,
+		FlightsPerProvider: *flights,
+		Distribution:       mockdata.PriceDistribution(*dist),
+		Spread:             *spread,
+		StopRatio:          *stops,
+		Seed:               *seed,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := gen.WriteDir(*out); err != nil {
+		return err
+	}
+
+	fmt.Printf("Generated %d flights per provider across %d routes and %d days into %s\n",
+		*flights, len(parsedRoutes), *days, *out)
+
+	if *publish != "" {
+		return publishDir(*out, *publish)
+	}
+	return nil
+}
+
+// publishDir uploads the generated files to the configured blob store.
+func publishDir(dir, prefix string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	store, err := cfg.Blob.Store()
+	if err != nil {
+		return err
+	}
+
+	n, err := blob.Upload(context.Background(), store, dir, prefix)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Published %d files to %s blob store under %s\n", n, cfg.Blob.Backend, prefix)
+	return nil
+}
@@ -0,0 +1,177 @@
+// Command mockprovider serves the provider mock data over HTTP, as the
+// airlines' APIs would, so integration and load tests can exercise the
+// providers' HTTP sources: connection pooling, timeouts, retries and
+// circuit breaking included.
+//
+// Usage:
+//
+//	go run ./cmd/mockprovider -addr :9090 -latency 150ms -jitter 100ms -error-rate 0.05
+//
+// Each provider's response is served at /<provider>/search, e.g.
+// /garuda_indonesia/search, so the server can be pointed at with:
+//
+//	PROVIDER_HTTP_BASE_URLS=garuda_indonesia=http://localhost:9090/garuda_indonesia/search,lion_air=http://localhost:9090/lion_air/search
+//
+// Responses are the whole mock data file of the provider, whatever the
+// search: like the mock data adapters, the HTTP sources filter the flights
+// themselves. Every response waits the configured latency plus a random
+// jitter, and a share of them fails with -error-status instead.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/demo"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "mockprovider:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses flags and serves the mock data until interrupted.
+func run(args []string) error {
+	fs := flag.NewFlagSet("mockprovider", flag.ContinueOnError)
+	addr := fs.String("addr", ":9090", "address to listen on")
+	dir := fs.String("dir", filepath.Join("docs", "response-mock"), "directory with the provider mock data files (e.g. the output of genmock)")
+	latency := fs.Duration("latency", 100*time.Millisecond, "time each response waits before being sent")
+	jitter := fs.Duration("jitter", 50*time.Millisecond, "maximum random delay added to -latency")
+	errorRate := fs.Float64("error-rate", 0, "fraction of requests failing with -error-status (0-1)")
+	errorStatus := fs.Int("error-status", http.StatusServiceUnavailable, "status of the failed requests")
+	auth := fs.String("auth", "", "Authorization header value required from clients (empty = none)")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "random seed of the jitter and failures")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *latency < 0 || *jitter < 0 {
+		return errors.New("-latency and -jitter must not be negative")
+	}
+	if *errorRate < 0 || *errorRate > 1 {
+		return fmt.Errorf("-error-rate must be between 0 and 1, got %g", *errorRate)
+	}
+	if *errorStatus < 400 || *errorStatus > 599 {
+		return fmt.Errorf("-error-status must be a 4xx or 5xx status, got %d", *errorStatus)
+	}
+
+	responses, err := loadResponses(*dir)
+	if err != nil {
+		return err
+	}
+	handler := &mockHandler{
+		responses:   responses,
+		latency:     *latency,
+		jitter:      *jitter,
+		errorRate:   *errorRate,
+		errorStatus: *errorStatus,
+		auth:        *auth,
+		rng:         rand.New(rand.NewSource(*seed)),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /{provider}/search", handler)
+	server := &http.Server{Addr: *addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	providers := slices.Sorted(maps.Keys(responses))
+	fmt.Fprintf(os.Stderr, "mockprovider: serving %s on %s (latency %s, jitter %s, error rate %g)\n",
+		strings.Join(providers, ", "), *addr, *latency, *jitter, *errorRate)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// loadResponses reads each provider's mock data file from dir. Providers
+// without a file in dir are not served.
+func loadResponses(dir string) (map[string][]byte, error) {
+	responses := make(map[string][]byte, len(demo.MockFiles))
+	for provider, name := range demo.MockFiles {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s mock data: %w", provider, err)
+		}
+		responses[provider] = data
+	}
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("no provider mock data file in %s", dir)
+	}
+	return responses, nil
+}
+
+// mockHandler answers searches with the mock data of the requested provider.
+type mockHandler struct {
+	responses   map[string][]byte
+	latency     time.Duration
+	jitter      time.Duration
+	errorRate   float64
+	errorStatus int
+	auth        string
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func (h *mockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.auth != "" && r.Header.Get("Authorization") != h.auth {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	body, ok := h.responses[r.PathValue("provider")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	delay, fail := h.draw()
+	select {
+	case <-time.After(delay):
+	case <-r.Context().Done():
+		// The client gave up, e.g. its timeout passed
+		return
+	}
+	if fail {
+		http.Error(w, http.StatusText(h.errorStatus), h.errorStatus)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// draw returns the delay of a response and whether it fails.
+func (h *mockHandler) draw() (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delay := h.latency
+	if h.jitter > 0 {
+		delay += time.Duration(h.rng.Int63n(int64(h.jitter) + 1))
+	}
+	return delay, h.rng.Float64() < h.errorRate
+}
@@ -0,0 +1,139 @@
+// Command providercheck dry-runs a provider adapter against a sample response
+// or the airline's API, and prints a readiness report: normalization,
+// validation, ranking compatibility and conformance checks.
+//
+// Usage:
+//
+//	go run ./cmd/providercheck -provider lion_air -payload sample.json
+//	go run ./cmd/providercheck -provider lion_air -endpoint https://api.example.com/v1/search -auth "Bearer abc" -search CGK-DPS:2025-12-15
+//
+// The command exits with status 1 when a check fails, so it can gate the
+// onboarding of a new airline in CI.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/airasia"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/batikair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/garuda"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/httpsource"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/lionair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/providercheck"
+)
+
+// adapters creates each provider's adapter from a sample response file, or
+// from the airline's API when source is set.
+var adapters = map[string]func(path string, source *httpsource.Client) domain.FlightProvider{
+	garuda.ProviderName: func(path string, source *httpsource.Client) domain.FlightProvider {
+		if source != nil {
+			return garuda.NewAdapter("", garuda.WithHTTPSource(source))
+		}
+		return garuda.NewAdapter(path)
+	},
+	lionair.ProviderName: func(path string, source *httpsource.Client) domain.FlightProvider {
+		if source != nil {
+			return lionair.NewAdapter("", lionair.WithHTTPSource(source))
+		}
+		return lionair.NewAdapter(path)
+	},
+	batikair.ProviderName: func(path string, source *httpsource.Client) domain.FlightProvider {
+		if source != nil {
+			return batikair.NewAdapter("", batikair.WithHTTPSource(source))
+		}
+		return batikair.NewAdapter(path)
+	},
+	airasia.ProviderName: func(path string, source *httpsource.Client) domain.FlightProvider {
+		if source != nil {
+			return airasia.NewAdapter("", airasia.WithHTTPSource(source))
+		}
+		return airasia.NewAdapter(path)
+	},
+}
+
+// errNotReady is returned when a check failed.
+var errNotReady = errors.New("provider is not ready")
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "providercheck:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses flags, checks the provider and prints the report.
+func run(args []string) error {
+	fs := flag.NewFlagSet("providercheck", flag.ContinueOnError)
+	provider := fs.String("provider", "", "provider name: garuda_indonesia, lion_air, batik_air, airasia (required)")
+	payloadPath := fs.String("payload", "", "sample response file (this or -endpoint is required)")
+	endpoint := fs.String("endpoint", "", "the airline's search endpoint, queried instead of a sample response")
+	auth := fs.String("auth", "", "value of the Authorization header sent to -endpoint")
+	search := fs.String("search", "", "ORIGIN-DESTINATION:DATE search (empty = all flights; required with -endpoint)")
+	class := fs.String("class", "", "travel class to search (empty = any)")
+	timeout := fs.Duration("timeout", 10*time.Second, "time limit of the checks")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	newAdapter, ok := adapters[*provider]
+	if !ok {
+		return fmt.Errorf("unknown provider %q", *provider)
+	}
+	if (*payloadPath == "") == (*endpoint == "") {
+		return errors.New("exactly one of -payload and -endpoint is required")
+	}
+
+	criteria, err := parseSearch(*search, *class)
+	if err != nil {
+		return err
+	}
+
+	var source *httpsource.Client
+	if *endpoint != "" {
+		if criteria.Origin == "" {
+			return errors.New("-search is required with -endpoint")
+		}
+		source, err = httpsource.New(httpsource.Config{BaseURL: *endpoint, AuthValue: *auth, Timeout: *timeout})
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	report := providercheck.Run(ctx, newAdapter(*payloadPath, source), criteria)
+	if err := report.Write(os.Stdout); err != nil {
+		return err
+	}
+	if !report.Ready() {
+		return errNotReady
+	}
+	return nil
+}
+
+// parseSearch parses "CGK-DPS:2025-12-15" into criteria. An empty search
+// matches every flight of the sample response.
+func parseSearch(s, class string) (domain.SearchCriteria, error) {
+	criteria := domain.SearchCriteria{Passengers: 1, Class: class}
+	if s == "" {
+		return criteria, nil
+	}
+
+	route, date, _ := strings.Cut(strings.TrimSpace(s), ":")
+	origin, destination, ok := strings.Cut(route, "-")
+	if !ok {
+		return criteria, fmt.Errorf("invalid search %q: want ORIGIN-DESTINATION:DATE", s)
+	}
+	criteria.Origin = strings.ToUpper(origin)
+	criteria.Destination = strings.ToUpper(destination)
+	criteria.DepartureDate = date
+	return criteria, nil
+}
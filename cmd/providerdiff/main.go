@@ -0,0 +1,112 @@
+// Command providerdiff compares a provider's mock data with a response
+// captured from the airline's real API, through the same adapter, and reports
+// structural and statistical differences in the normalized flights.
+//
+// Usage:
+//
+//	go run ./cmd/providerdiff -provider garuda_indonesia -live captured.json -search CGK-DPS:2025-12-15
+//
+// The mock defaults to the provider's file in docs/response-mock. The command
+// exits with status 1 when differences are found, so it can guard CI before
+// an adapter is switched from mock to live data.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/airasia"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/batikair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/garuda"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/lionair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/difftest"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// adapters creates each provider's adapter from a response file.
+var adapters = map[string]func(path string) domain.FlightProvider{
+	garuda.ProviderName:   func(path string) domain.FlightProvider { return garuda.NewAdapter(path) },
+	lionair.ProviderName:  func(path string) domain.FlightProvider { return lionair.NewAdapter(path) },
+	batikair.ProviderName: func(path string) domain.FlightProvider { return batikair.NewAdapter(path) },
+	airasia.ProviderName:  func(path string) domain.FlightProvider { return airasia.NewAdapter(path) },
+}
+
+// errDifferences is returned when the comparison found differences.
+var errDifferences = errors.New("mock and live output differ")
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "providerdiff:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses flags, compares both sides and prints the report.
+func run(args []string) error {
+	fs := flag.NewFlagSet("providerdiff", flag.ContinueOnError)
+	provider := fs.String("provider", "", "provider name: garuda_indonesia, lion_air, batik_air, airasia (required)")
+	mockPath := fs.String("mock", "", "mock data file (default docs/response-mock/<provider>_search_response.json)")
+	livePath := fs.String("live", "", "response captured from the provider's API (required)")
+	searches := fs.String("search", "", `comma-separated ORIGIN-DESTINATION:DATE searches (empty = all flights)`)
+	class := fs.String("class", "", "travel class to search (empty = any)")
+	tolerance := fs.Float64("tolerance", difftest.DefaultTolerance, "relative difference allowed between statistics")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	newAdapter, ok := adapters[*provider]
+	if !ok {
+		return fmt.Errorf("unknown provider %q", *provider)
+	}
+	if *livePath == "" {
+		return errors.New("-live is required")
+	}
+	if *mockPath == "" {
+		*mockPath = filepath.Join("docs", "response-mock", *provider+"_search_response.json")
+	}
+
+	criteria, err := parseSearches(*searches, *class)
+	if err != nil {
+		return err
+	}
+
+	report := difftest.New(newAdapter(*mockPath), newAdapter(*livePath), difftest.WithTolerance(*tolerance)).
+		Run(context.Background(), criteria)
+	if err := report.Write(os.Stdout); err != nil {
+		return err
+	}
+	if report.Differs() {
+		return errDifferences
+	}
+	return nil
+}
+
+// parseSearches parses "CGK-DPS:2025-12-15,DPS-CGK:2025-12-16" into criteria.
+// An empty list compares every flight of both files.
+func parseSearches(s, class string) ([]domain.SearchCriteria, error) {
+	if s == "" {
+		return []domain.SearchCriteria{{Passengers: 1, Class: class}}, nil
+	}
+
+	var criteria []domain.SearchCriteria
+	for _, search := range strings.Split(s, ",") {
+		route, date, _ := strings.Cut(strings.TrimSpace(search), ":")
+		origin, destination, ok := strings.Cut(route, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid search %q: want ORIGIN-DESTINATION:DATE", search)
+		}
+		criteria = append(criteria, domain.SearchCriteria{
+			Origin:        strings.ToUpper(origin),
+			Destination:   strings.ToUpper(destination),
+			DepartureDate: date,
+			Passengers:    1,
+			Class:         class,
+		})
+	}
+	return criteria, nil
+}
@@ -0,0 +1,161 @@
+// Command refdata refreshes the bundled airport and airline datasets of
+// internal/reference from public sources and reports what changed.
+//
+// Usage:
+//
+//	go run ./cmd/refdata -airports airports.csv -airlines optd_airlines.csv -write
+//
+// airports.csv is the OurAirports airports export (https://ourairports.com/data/)
+// and optd_airlines.csv the OpenTravelData airline list
+// (https://github.com/opentraveldata/opentraveldata). Either source may be
+// omitted to refresh one dataset only.
+//
+// Only the airports and airlines already in the datasets are refreshed; new
+// entries are added by hand, with their served city, timezone and
+// translations. The refreshed datasets are validated against the dataset
+// schema before anything is written. Without -write the datasets are left
+// untouched and the command exits with status 1 when they are out of date, so
+// it can flag stale reference data in CI.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/refimport"
+)
+
+// errOutdated is returned when the datasets differ from the sources and were not written.
+var errOutdated = errors.New("reference data is out of date (run with -write to update it)")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "refdata:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses flags, refreshes the datasets and prints the report.
+func run(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("refdata", flag.ContinueOnError)
+	airportsSource := fs.String("airports", "", "OurAirports airports.csv export")
+	airlinesSource := fs.String("airlines", "", "OpenTravelData optd_airlines.csv list")
+	dir := fs.String("dir", filepath.Join("internal", "reference"), "directory of the bundled datasets")
+	write := fs.Bool("write", false, "write the refreshed datasets")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *airportsSource == "" && *airlinesSource == "" {
+		return errors.New("at least one of -airports and -airlines is required")
+	}
+
+	report := &refimport.Report{}
+	var outputs []dataset
+	if *airportsSource != "" {
+		out, err := refreshAirports(filepath.Join(*dir, reference.AirportsFile), *airportsSource, report)
+		if err != nil {
+			return err
+		}
+		outputs = append(outputs, out)
+	}
+	if *airlinesSource != "" {
+		out, err := refreshAirlines(filepath.Join(*dir, reference.AirlinesFile), *airlinesSource, report)
+		if err != nil {
+			return err
+		}
+		outputs = append(outputs, out)
+	}
+
+	if err := report.Write(stdout); err != nil {
+		return err
+	}
+	if !report.Changed() {
+		return nil
+	}
+	if !*write {
+		return errOutdated
+	}
+	for _, out := range outputs {
+		if err := os.WriteFile(out.path, out.data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dataset is a refreshed dataset file.
+type dataset struct {
+	path string
+	data []byte
+}
+
+// refreshAirports refreshes the airports dataset at path from an OurAirports export.
+func refreshAirports(path, sourcePath string, report *refimport.Report) (dataset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return dataset{}, err
+	}
+	current, err := reference.ParseAirports(data)
+	if err != nil {
+		return dataset{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	source, err := readSource(sourcePath, refimport.ReadOurAirports)
+	if err != nil {
+		return dataset{}, err
+	}
+	refreshed, err := reference.MarshalAirports(refimport.RefreshAirports(current, source, report))
+	if err != nil {
+		return dataset{}, err
+	}
+	if _, err := reference.ParseAirports(refreshed); err != nil {
+		return dataset{}, fmt.Errorf("refreshed airports: %w", err)
+	}
+	return dataset{path: path, data: refreshed}, nil
+}
+
+// refreshAirlines refreshes the airlines dataset at path from an OpenTravelData list.
+func refreshAirlines(path, sourcePath string, report *refimport.Report) (dataset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return dataset{}, err
+	}
+	current, err := reference.ParseAirlines(data)
+	if err != nil {
+		return dataset{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	source, err := readSource(sourcePath, refimport.ReadOPTDAirlines)
+	if err != nil {
+		return dataset{}, err
+	}
+	refreshed, err := reference.MarshalAirlines(refimport.RefreshAirlines(current, source, report))
+	if err != nil {
+		return dataset{}, err
+	}
+	if _, err := reference.ParseAirlines(refreshed); err != nil {
+		return dataset{}, fmt.Errorf("refreshed airlines: %w", err)
+	}
+	return dataset{path: path, data: refreshed}, nil
+}
+
+// readSource opens a source file and reads it with read.
+func readSource[T any](path string, read func(io.Reader) (T, error)) (T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer f.Close()
+
+	source, err := read(f)
+	if err != nil {
+		return source, fmt.Errorf("%s: %w", path, err)
+	}
+	return source, nil
+}
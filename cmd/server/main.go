@@ -21,11 +21,17 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -37,23 +43,83 @@ import (
 	echoSwagger "github.com/swaggo/echo-swagger"
 
 	// Import generated docs for swagger
-	_ "github.com/flight-search/flight-search-and-aggregation-system/docs"
+	"github.com/flight-search/flight-search-and-aggregation-system/docs"
 
 	// Application layers
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/accessibility"
 	flighthttp "github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http"
+	appmiddleware "github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/middleware"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/airasia"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/batikair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/demo"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/garuda"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/groupquote"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/httpsource"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/lionair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/store/memory"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/store/sqlite"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/analytics"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/audit"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/duplicate"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/flightindex"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/health"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/cache"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/encryption"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/metrics"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/jobqueue"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/latency"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/migrate"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/quality"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/ratelimit"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/sanitize"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/scheduler"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/searchaudit"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/storage/blob"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/tenant"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/workerpool"
 )
 
 const (
-	shutdownTimeout = 10 * time.Second
+	shutdownTimeout  = 10 * time.Second
+	readinessTimeout = 2 * time.Second
 )
 
 func main() {
+	printSchema := flag.Bool("print-config-schema", false, "print the configuration options as JSON and exit")
+	flag.Parse()
+	if *printSchema {
+		if err := printConfigSchema(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// migrate subcommand: apply or inspect the database schema and exit
+	if flag.Arg(0) == "migrate" {
+		cfg := config.MustLoad()
+		setupLogger(cfg)
+		if err := runMigrate(context.Background(), cfg, flag.Args()[1:]); err != nil {
+			log.Fatal().Err(err).Msg("Migration failed")
+		}
+		return
+	}
+
+	// restore subcommand: replace the embedded store with a backup and exit
+	if flag.Arg(0) == "restore" {
+		cfg := config.MustLoad()
+		setupLogger(cfg)
+		if err := runRestore(context.Background(), cfg, flag.Args()[1:]); err != nil {
+			log.Fatal().Err(err).Msg("Restore failed")
+		}
+		return
+	}
+
 	// Load configuration
 	cfg := config.MustLoad()
 
@@ -73,12 +139,15 @@ func main() {
 	// Configure server timeouts from config
 	e.Server.ReadTimeout = cfg.Server.ReadTimeout
 	e.Server.WriteTimeout = cfg.Server.WriteTimeout
+	e.Server.ReadHeaderTimeout = cfg.Server.ReadHeaderTimeout
+	e.Server.IdleTimeout = cfg.Server.IdleTimeout
 
 	// Setup middleware
-	setupMiddleware(e)
+	setupMiddleware(e, cfg)
 
-	// Setup routes
-	setupRoutes(e, cfg)
+	// Setup routes and background work
+	bg := setupRoutes(e, cfg)
+	bg.start(context.Background())
 
 	// Start server with graceful shutdown
 	addr := fmt.Sprintf(":%d", cfg.Server.Port)
@@ -90,7 +159,15 @@ func main() {
 	}()
 
 	// Wait for interrupt signal
-	gracefulShutdown(e)
+	gracefulShutdown(e, bg)
+}
+
+// printConfigSchema writes every configuration option with its type, default
+// and description to w as JSON.
+func printConfigSchema(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(config.Schema())
 }
 
 // setupLogger configures the global zerolog logger based on config.
@@ -115,15 +192,24 @@ func setupLogger(cfg *config.Config) {
 	}
 }
 
-
 // setupMiddleware configures Echo middleware stack.
-func setupMiddleware(e *echo.Echo) {
+func setupMiddleware(e *echo.Echo, cfg *config.Config) {
 	// Recovery middleware - recover from panics
 	e.Use(middleware.Recover())
 
 	// Request ID middleware
 	e.Use(middleware.RequestID())
 
+	// Trace context - accept W3C traceparent / B3 headers and echo them back
+	e.Use(appmiddleware.TraceContext())
+
+	// Connection limits - reject with 503 + Retry-After when saturated
+	e.Use(appmiddleware.NewConnectionLimiter(log.Logger, appmiddleware.ConnectionLimitConfig{
+		MaxConnections: cfg.Server.MaxConnections,
+		MaxPerIP:       cfg.Server.MaxConnectionsPerIP,
+		RetryAfter:     cfg.Server.RetryAfter,
+	}).Middleware())
+
 	// Logger middleware with zerolog integration
 	e.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
 		LogURI:       true,
@@ -134,6 +220,7 @@ func setupMiddleware(e *echo.Echo) {
 		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
 			log.Info().
 				Str("request_id", v.RequestID).
+				Str("trace_id", appmiddleware.GetTraceID(c)).
 				Str("method", v.Method).
 				Str("uri", v.URI).
 				Int("status", v.Status).
@@ -142,51 +229,1052 @@ func setupMiddleware(e *echo.Echo) {
 			return nil
 		},
 	}))
+
+	// Request body size limit - reject oversized bodies with 413 before
+	// anything reads them
+	e.Use(appmiddleware.BodyLimit(cfg.Server.MaxRequestBodyBytes))
+
+	// Response compression - gzip large responses, such as long flight lists,
+	// for clients accepting it; registered before signing so signatures cover
+	// the uncompressed body
+	if cfg.Server.CompressionLevel > 0 {
+		e.Use(appmiddleware.Compression(appmiddleware.CompressionConfig{
+			Level:    cfg.Server.CompressionLevel,
+			MinBytes: cfg.Server.CompressionMinBytes,
+		}))
+	}
+
+	// OpenAPI contract validation (always on in staging)
+	if cfg.OpenAPIValidationEnabled() {
+		validator, err := appmiddleware.NewOpenAPIValidator(log.Logger, []byte(docs.SwaggerInfo.ReadDoc()),
+			appmiddleware.OpenAPIValidationConfig{
+				ValidateResponses: cfg.Validation.OpenAPIResponses,
+			})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to initialize OpenAPI validation")
+		}
+		e.Use(validator.Middleware())
+		log.Info().Bool("validate_responses", cfg.Validation.OpenAPIResponses).Msg("OpenAPI validation enabled")
+	}
+
+	// Response signing - detached JWS over JSON bodies so downstream caches
+	// and partners can verify them
+	signer, err := cfg.Signing.Signer()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid response signing key")
+	}
+	if signer != nil {
+		e.Use(appmiddleware.SignResponses(log.Logger, signer))
+		log.Info().Str("key_id", signer.KeyID()).Msg("Response signing enabled")
+	}
+
+	// Roles of anonymous callers and of API keys without roles of their own
+	policy, err := cfg.Tenants.RolePolicy()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid role configuration")
+	}
+
+	// OAuth2 bearer tokens instead of API keys: token scopes grant the roles
+	if cfg.Auth.Mode == config.AuthModeJWT {
+		verifier, err := cfg.Auth.Verifier()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Invalid bearer token configuration")
+		}
+		scopes, err := cfg.Auth.Scopes()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Invalid bearer token configuration")
+		}
+		e.Use(appmiddleware.JWTAuth(verifier, scopes, policy))
+		log.Info().Str("issuer", cfg.Auth.JWTIssuer).Str("audience", cfg.Auth.JWTAudience).Msg("Bearer token authentication enabled")
+		return
+	}
+
+	// Per-API-key partner defaults (sort, result limit, fields) and roles
+	var registry *tenant.Registry
+	if cfg.Tenants.APIKeysFile != "" {
+		registry, err = tenant.LoadFile(cfg.Tenants.APIKeysFile)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load API key file")
+		}
+		log.Info().Int("api_keys", registry.Len()).Msg("API key defaults enabled")
+	}
+	e.Use(appmiddleware.APIKey(registry, policy))
 }
 
-// setupRoutes configures the HTTP routes.
-func setupRoutes(e *echo.Echo, cfg *config.Config) {
-	// Health check endpoint (root level for load balancers)
-	e.GET("/health", healthCheckHandler)
+// setupRoutes configures the HTTP routes and returns the background work
+// backing them; the caller starts and stops it.
+func setupRoutes(e *echo.Echo, cfg *config.Config) *background {
+	jobs := scheduler.New(log.Logger)
+	trail := openAuditTrail(cfg)
+	blobs, err := cfg.Blob.Store()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to open blob store")
+	}
+
+	db, schema := openDatabase(cfg)
+	queue := openJobQueue(cfg, db)
+
+	// Readiness endpoint (root level for load balancers)
+	e.GET("/health/ready", readinessHandler(schema))
+
+	// Prometheus metrics endpoint
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
 
-	// Initialize providers with mock data paths
+	// Public key verifying signed responses
+	if signer, err := cfg.Signing.Signer(); err == nil && signer != nil {
+		jwks := signer.JWKS()
+		e.GET("/.well-known/jwks.json", func(c echo.Context) error {
+			return c.JSON(http.StatusOK, jwks)
+		})
+	}
+
+	// Initialize providers with mock data paths, or their HTTP API when configured
 	// Use WithSimulation to enable realistic API behavior with delays and failure rates
-	mockBasePath := "docs/response-mock"
+	mockBasePath := cfg.App.MockDataDir
 	providers := []domain.FlightProvider{
-		garuda.NewAdapterWithSimulation(mockBasePath + "/garuda_indonesia_search_response.json"),   // 50-100ms delay
-		lionair.NewAdapterWithSimulation(mockBasePath + "/lion_air_search_response.json"),          // 100-200ms delay
-		batikair.NewAdapterWithSimulation(mockBasePath + "/batik_air_search_response.json"),        // 200-400ms delay
-		airasia.NewAdapterWithSimulation(mockBasePath + "/airasia_search_response.json"),           // 50-150ms delay, 10% failure rate
+		garuda.NewAdapterWithSimulation(mockBasePath+"/garuda_indonesia_search_response.json",
+			adapterOptions(cfg, garuda.ProviderName, garuda.WithMaxResponseBytes, garuda.WithHTTPSource)...), // 50-100ms delay
+		lionair.NewAdapterWithSimulation(mockBasePath+"/lion_air_search_response.json",
+			adapterOptions(cfg, lionair.ProviderName, lionair.WithMaxResponseBytes, lionair.WithHTTPSource)...), // 100-200ms delay
+		batikair.NewAdapterWithSimulation(mockBasePath+"/batik_air_search_response.json",
+			adapterOptions(cfg, batikair.ProviderName, batikair.WithMaxResponseBytes, batikair.WithHTTPSource)...), // 200-400ms delay
+		airasia.NewAdapterWithSimulation(mockBasePath+"/airasia_search_response.json",
+			adapterOptions(cfg, airasia.ProviderName, airasia.WithMaxResponseBytes, airasia.WithHTTPSource)...), // 50-150ms delay, 10% failure rate
+	}
+
+	// Demo mode: serve all mock data from memory, shifted to the requested date
+	if cfg.App.DemoMode {
+		store, err := loadDemoStore(context.Background(), cfg, blobs)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load demo flight store")
+		}
+		providers = store.Providers()
+		log.Info().Int("flights", store.Len()).Msg("Demo mode enabled")
+
+		// Mock rotation: pick up regenerated mock data without a restart
+		if cfg.Scheduler.MockRotation != "" {
+			addJob(jobs, scheduler.Job{
+				Name:     "mock_rotation",
+				Schedule: scheduler.MustParse(cfg.Scheduler.MockRotation),
+				Jitter:   cfg.Scheduler.Jitter,
+				Run: func(ctx context.Context) error {
+					fresh, err := loadDemoStore(ctx, cfg, blobs)
+					if err != nil {
+						return err
+					}
+					store.Replace(fresh)
+					log.Info().Int("flights", store.Len()).Msg("Demo mock data reloaded")
+					return nil
+				},
+			})
+		}
+	} else if cfg.Scheduler.MockRotation != "" {
+		log.Warn().Msg("SCHEDULE_MOCK_ROTATION is ignored outside demo mode")
+	}
+
+	// Keep the searches sent to each provider under the rate its API allows
+	rateLimits, err := cfg.Providers.RateLimits(ratelimit.WithRejectionObserver(metrics.ObserveProviderRateLimitRejection))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid provider rate limit configuration")
+	}
+
+	// Check the providers themselves, not the instrumented providers, so
+	// checks do not count as searches in the provider statistics; checks
+	// still take their token from the provider's rate limit
+	providerHealth := health.NewMonitor(
+		health.WithThreshold(cfg.Providers.BreakerThreshold),
+		health.WithCooldown(cfg.Providers.BreakerCooldown),
+		health.WithCheckTimeout(cfg.Timeouts.PerProvider),
+	)
+	if interval := cfg.Providers.HealthCheckInterval; interval > 0 {
+		checked := providers
+		if rateLimits != nil {
+			checked = rateLimits.InstrumentAll(checked)
+		}
+		addJob(jobs, scheduler.Job{
+			Name:     "provider_health_check",
+			Schedule: scheduler.Every(interval),
+			Run: func(ctx context.Context) error {
+				providerHealth.Check(ctx, checked)
+				return nil
+			},
+		})
+	}
+
+	// Fill in the special assistance each airline publishes in its policies
+	accessibilityPolicy := accessibility.DefaultPolicy()
+	if path := cfg.Providers.AccessibilityPolicy; path != "" {
+		policy, err := accessibility.LoadPolicy(path)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load accessibility policy")
+		}
+		accessibilityPolicy = policy
+	}
+	providers = accessibilityPolicy.InstrumentAll(providers)
+
+	// Record provider latency, with trace exemplars for sampled requests
+	providers = metrics.InstrumentProviders(providers)
+
+	// Track provider data quality, shadow providers included
+	providerQuality := quality.NewTracker(quality.WithWindow(cfg.Providers.QualityWindow))
+	providers = providerQuality.InstrumentAll(providers)
+
+	// Track provider latency for the admin heatmaps and, optionally, so
+	// searches short on budget skip providers unlikely to answer in time
+	providerLatency := latency.NewTracker(
+		latency.WithWindow(cfg.Providers.LatencyWindow),
+		latency.WithHeatmapRetention(cfg.Providers.LatencyHeatmapRetention),
+	)
+	providers = providerLatency.InstrumentAll(providers)
+	var latencies usecase.LatencyHistory
+	if cfg.Providers.SkipOverBudget {
+		latencies = providerLatency
+	}
+
+	// Fail searches of failing providers at once while their breaker is open,
+	// and report the health of every provider at /health
+	providers = providerHealth.InstrumentAll(providers)
+	e.GET("/health", healthCheckHandler(providerHealth))
+
+	// Rate limit the searches let through by the breakers, so searches
+	// waiting for their turn are neither counted as provider failures nor
+	// timed as provider latency
+	if rateLimits != nil {
+		providers = rateLimits.InstrumentAll(providers)
+	}
+
+	// Give each provider its own worker pool, so a slow provider cannot tie
+	// up the capacity the others need
+	pools, err := cfg.Providers.WorkerPools(workerpool.WithRejectionObserver(metrics.ObserveProviderPoolRejection))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid provider worker pool configuration")
+	}
+	if pools != nil {
+		providers = pools.InstrumentAll(providers)
+		for _, p := range providers {
+			if err := metrics.RegisterProviderPool(p.Name(), pools.Pool(p.Name()).Stats); err != nil {
+				log.Fatal().Err(err).Msg("Failed to register provider pool metrics")
+			}
+		}
+	}
+
+	// Providers under evaluation are queried in shadow mode
+	providers, shadowProviders := splitShadowProviders(providers, cfg)
+
+	// Reject itineraries with absurd routings for their route
+	routing, err := cfg.Routing.Policy()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid routing configuration")
+	}
+
+	// Hold partial searches so their late provider results can be fetched
+	continuations := usecase.NewContinuationBuffer(cfg.Timeouts.ContinuationTTL, timeutil.NewRealClock())
+
+	// Answer repeated searches from the result cache
+	searchCache, err := cfg.Cache.SearchCache(cache.WithEvictionObserver(metrics.ObserveCacheEviction))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid cache configuration")
+	}
+	if memoryCache, ok := searchCache.(*cache.MemoryCache); ok {
+		if err := metrics.RegisterStoreSize("search_cache", metrics.StoreKindRecords, memoryCache.Len); err != nil {
+			log.Fatal().Err(err).Msg("Failed to register store metrics")
+		}
 	}
 
 	// Initialize use case with config
 	ucConfig := &usecase.Config{
-		GlobalTimeout:   cfg.Timeouts.GlobalSearch,
-		ProviderTimeout: cfg.Timeouts.PerProvider,
+		GlobalTimeout:        cfg.Timeouts.GlobalSearch,
+		ProviderTimeout:      cfg.Timeouts.PerProvider,
+		ProviderTimeouts:     cfg.Timeouts.PerProviderByProvider,
+		ClassTimeouts:        cfg.Timeouts.ClassTimeouts(),
+		ShadowProviders:      shadowProviders,
+		ShadowRecorder:       shadowRecorder{},
+		Routing:              routing,
+		SoftTimeout:          cfg.Timeouts.SoftDeadline,
+		Continuations:        continuations,
+		Latencies:            latencies,
+		Cache:                searchCache,
+		CacheTTL:             cfg.Cache.TTL,
+		CacheDriftSampleRate: cfg.Cache.DriftSampleRate,
+		CacheDriftObserver:   observeCacheDrift,
+		DisableCoalescing:    !cfg.Providers.CoalesceSearches,
+		Retry:                cfg.Providers.Retry(),
+		RetryAttempts:        cfg.Providers.RetryMaxAttemptsByProvider,
+		RetryObserver:        metrics.ObserveProviderRetry,
+		RankingObserver:      observeRankingViolation,
+
+		FanOutStrategy:        domain.FanOutStrategy(cfg.Providers.FanOutStrategy),
+		FastestFirstProviders: cfg.Providers.FastestFirstProviders,
+		FastestFirstResults:   cfg.Providers.FastestFirstResults,
+
+		PriceBasis: domain.PriceBasis(cfg.Pricing.Basis),
+	}
+	// Round trips search each leg as a one-way search, and flexible-date
+	// searches each departure date
+	searchUseCase := usecase.NewFlexibleDateUseCase(
+		usecase.NewRoundTripUseCase(usecase.NewFlightSearchUseCase(providers, ucConfig)),
+		cfg.Providers.FlexibleDateConcurrency,
+	)
+
+	// Record searches so they can be replayed from the admin API
+	searchStore := newSearchHistory(cfg, db)
+	flightUseCase := usecase.NewRecordingUseCase(searchUseCase, searchStore, timeutil.NewRealClock())
+	searchAudit := openSearchAudit(cfg, db)
+	if searchAudit != nil {
+		flightUseCase = usecase.NewAuditingUseCase(flightUseCase, searchAudit, timeutil.NewRealClock())
+	}
+	var flightIndex *flightindex.Index
+	if cfg.Cache.FlightDetailsTTL > 0 {
+		// Remember the flights of searches so their details can be looked up
+		flightIndex = flightindex.New(flightindex.WithTTL(cfg.Cache.FlightDetailsTTL))
+		flightUseCase = usecase.NewIndexingUseCase(flightUseCase, flightIndex)
+	}
+	replayUseCase := usecase.NewReplayUseCase(searchUseCase, searchStore, providers, ucConfig)
+	registerHistoryRetention(jobs, searchStore, cfg)
+	registerAnalyticsExport(jobs, searchStore, blobs, cfg)
+
+	// Initialize handlers
+	sanitizer := cfg.Sanitize.Sanitizer()
+	handlerOpts := []flighthttp.FlightHandlerOption{
+		flighthttp.WithSanitizer(sanitizer),
+		flighthttp.WithCodeValidation(flighthttp.CodeValidation(cfg.Validation.CodeMode)),
+	}
+	if cfg.Providers.GroupQuotes {
+		groupQuotes := usecase.NewGroupQuoteUseCase(groupquote.NewStubs(nil), ucConfig)
+		handlerOpts = append(handlerOpts, flighthttp.WithGroupQuotes(groupQuotes))
+	}
+	if cfg.Tenants.DuplicateWindow > 0 {
+		duplicates := duplicate.NewTracker(duplicate.WithWindow(cfg.Tenants.DuplicateWindow))
+		handlerOpts = append(handlerOpts, flighthttp.WithDuplicateTracking(duplicates))
+	}
+	if flightIndex != nil {
+		handlerOpts = append(handlerOpts, flighthttp.WithFlightDetails(flightIndex))
+	}
+	if async := openAsyncSearches(queue, flightUseCase, searchStore, sanitizer, cfg); async != nil {
+		handlerOpts = append(handlerOpts, flighthttp.WithAsyncSearches(async))
+	}
+	flightHandler := flighthttp.NewFlightHandler(flightUseCase, handlerOpts...)
+	adminOpts := []flighthttp.AdminHandlerOption{
+		flighthttp.WithConfigReporter(cfg),
+		flighthttp.WithLatencyHeatmaps(providerLatency),
+		flighthttp.WithAuditTrail(trail),
+		flighthttp.WithSimulation(usecase.NewSimulationUseCase(searchStore, ucConfig)),
+	}
+	if cfg.Database.Driver == config.SQLiteDriver {
+		adminOpts = append(adminOpts, flighthttp.WithBackup(func(ctx context.Context) (flighthttp.BackupSnapshot, error) {
+			snapshot, err := sqlite.Backup(ctx, db)
+			if err != nil {
+				return nil, err
+			}
+			return snapshot, nil
+		}))
+	}
+	adminHandler := flighthttp.NewAdminHandler(replayUseCase, jobs, queue, providerQuality, adminOpts...)
+
+	// Shed optional features in priority order when searches pile up or slow down
+	shedder, err := cfg.LoadShed.Shedder(loadshed.WithObserver(loadShedObserver{}))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid load shedding configuration")
+	}
+
+	// Cap the searches fanning out to the providers at once, rejecting the
+	// excess with 503 + Retry-After
+	var searchLimit []echo.MiddlewareFunc
+	if cfg.Server.MaxConcurrentSearches > 0 {
+		searchLimit = append(searchLimit, appmiddleware.NewSearchLimiter(log.Logger, cfg.Server.MaxConcurrentSearches, cfg.Server.RetryAfter).Middleware())
 	}
-	flightUseCase := usecase.NewFlightSearchUseCase(providers, ucConfig)
 
-	// Initialize handler
-	flightHandler := flighthttp.NewFlightHandler(flightUseCase)
+	// API v1 routes, each group requiring its role
+	flights := e.Group("/api/v1/flights", appmiddleware.RequireRole(rbac.RoleSearch))
+	if shedder != nil {
+		flights.Use(appmiddleware.LoadShedding(shedder))
+	}
+	flights.POST("/search", flightHandler.SearchFlights, searchLimit...)
+	flights.GET("/search/live", flightHandler.SearchFlightsLive, searchLimit...)
+	flights.POST("/search/stream", flightHandler.SearchFlightsStream, searchLimit...)
+	flights.GET("/:id", flightHandler.GetFlight)
+	flighthttp.RegisterContinuationRoutes(e, flighthttp.NewContinuationHandler(continuations, sanitizer), appmiddleware.RequireRole(rbac.RoleSearch))
+	registerShareRoutes(e, searchStore, sanitizer, cfg)
+	flighthttp.RegisterSearchHistoryRoutes(e, flighthttp.NewSearchHistoryHandler(usecase.NewSearchHistoryUseCase(searchStore), sanitizer), appmiddleware.RequireRole(rbac.RoleSearch))
+	flighthttp.RegisterRerunRoutes(e, flighthttp.NewRerunHandler(usecase.NewRerunUseCase(flightUseCase, searchStore), sanitizer), append([]echo.MiddlewareFunc{appmiddleware.RequireRole(rbac.RoleSearch)}, searchLimit...)...)
+	flighthttp.RegisterExportRoutes(e, flighthttp.NewExportHandler(blobs), appmiddleware.RequireRole(rbac.RoleExport))
+	flighthttp.RegisterReferenceRoutes(e, flighthttp.NewReferenceHandler(), appmiddleware.RequireRole(rbac.RoleSearch))
 
-	// API v1 routes
-	api := e.Group("/api/v1")
-	api.POST("/flights/search", flightHandler.SearchFlights)
+	// Admin v1 routes (internal)
+	registerAdminRoutes(e, adminHandler, cfg)
 
 	// Swagger documentation endpoint
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
+
+	return &background{jobs: jobs, queue: queue, audit: trail, searchAudit: searchAudit}
 }
 
-// healthCheckHandler returns the health status of the service.
-// Note: This endpoint is at the root level (/health), not under /api/v1
-func healthCheckHandler(c echo.Context) error {
-	return c.JSON(http.StatusOK, map[string]string{
-		"status": "ok",
+// adapterOptions returns the options of a provider adapter: its response
+// size limit, and its HTTP API when PROVIDER_HTTP_BASE_URLS configures one.
+func adapterOptions[O any](cfg *config.Config, provider string, withLimit func(int64) O, withHTTP func(*httpsource.Client) O) []O {
+	opts := []O{withLimit(cfg.Providers.ResponseLimit(provider))}
+	sourceCfg, ok := cfg.Providers.HTTPSource(provider)
+	if !ok {
+		return opts
+	}
+	source, err := httpsource.New(sourceCfg)
+	if err != nil {
+		log.Fatal().Err(err).Str("provider", provider).Msg("Invalid provider HTTP API configuration")
+	}
+	log.Info().Str("provider", provider).Str("base_url", sourceCfg.BaseURL).Msg("Provider queries its HTTP API")
+	return append(opts, withHTTP(source))
+}
+
+// registerShareRoutes registers the share link routes when SHARE_TOKEN_SECRET
+// is set. Opening a link requires no role: links are meant to be passed around.
+func registerShareRoutes(e *echo.Echo, store domain.SearchRecordStore, sanitizer *sanitize.Sanitizer, cfg *config.Config) {
+	issuer, err := cfg.Share.Issuer()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid share link configuration")
+	}
+	if issuer == nil {
+		return
+	}
+	handler := flighthttp.NewShareHandler(usecase.NewShareUseCase(store, issuer), sanitizer)
+	flighthttp.RegisterShareRoutes(e, handler, appmiddleware.RequireRole(rbac.RoleSearch))
+	log.Info().Dur("ttl", cfg.Share.TokenTTL).Msg("Search share links enabled")
+}
+
+// openAsyncSearches returns the runner of searches with a callbackUrl on the
+// job queue, delivering the results uc records in store, or nil when
+// SEARCH_CALLBACK_SECRET is not set.
+func openAsyncSearches(queue *jobqueue.Queue, uc usecase.FlightSearchUseCase, store domain.SearchRecordStore, sanitizer *sanitize.Sanitizer, cfg *config.Config) *flighthttp.AsyncSearches {
+	signer, err := cfg.Callback.Signer()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid search callback configuration")
+	}
+	if signer == nil {
+		return nil
+	}
+	async := flighthttp.NewAsyncSearches(queue, uc, store, signer,
+		flighthttp.WithCallbackClient(&http.Client{Timeout: cfg.Callback.Timeout}),
+		flighthttp.WithCallbackAttempts(cfg.Callback.MaxAttempts),
+		flighthttp.WithCallbackSanitizer(sanitizer),
+	)
+	log.Info().Dur("timeout", cfg.Callback.Timeout).Int("max_attempts", cfg.Callback.MaxAttempts).
+		Msg("Asynchronous searches enabled")
+	return async
+}
+
+// splitShadowProviders separates the providers configured in PROVIDERS_SHADOW
+// from the live providers, exiting if a shadow provider is unknown or no live
+// provider remains.
+func splitShadowProviders(providers []domain.FlightProvider, cfg *config.Config) (live, shadow []domain.FlightProvider) {
+	known := make(map[string]bool, len(providers))
+	for _, p := range providers {
+		known[p.Name()] = true
+		if cfg.Providers.IsShadow(p.Name()) {
+			shadow = append(shadow, p)
+		} else {
+			live = append(live, p)
+		}
+	}
+	for _, name := range cfg.Providers.Shadow {
+		if !known[strings.TrimSpace(name)] {
+			log.Fatal().Str("provider", name).Msg("Unknown provider in PROVIDERS_SHADOW")
+		}
+	}
+	if len(live) == 0 {
+		log.Fatal().Msg("PROVIDERS_SHADOW leaves no live provider")
+	}
+	for _, p := range shadow {
+		log.Info().Str("provider", p.Name()).Msg("Provider runs in shadow mode")
+	}
+	return live, shadow
+}
+
+// shadowRecorder logs shadow provider comparisons and exports them as metrics.
+type shadowRecorder struct{}
+
+// RecordShadow implements usecase.ShadowRecorder.
+func (shadowRecorder) RecordShadow(_ context.Context, r usecase.ShadowReport) {
+	metrics.ObserveShadow(r.Provider, r.Err, r.Flights, r.Matched, r.PriceDifference, r.Priced > 0)
+	log.Debug().
+		Err(r.Err).
+		Str("provider", r.Provider).
+		Str("route", r.Criteria.Origin+"-"+r.Criteria.Destination).
+		Dur("duration", r.Duration).
+		Int("flights", r.Flights).
+		Int("live_flights", r.LiveFlights).
+		Int("matched", r.Matched).
+		Float64("price_difference", r.PriceDifference).
+		Msg("Shadow provider search")
+}
+
+// observeCacheDrift logs how far sampled cached results drifted from a live
+// search and exports it as metrics.
+func observeCacheDrift(d usecase.CacheDrift) {
+	metrics.ObserveCacheDrift(d.Err, d.Age, d.CachedFlights, d.LiveFlights, d.Matched, d.PriceDrift, d.Priced > 0)
+	log.Debug().
+		Err(d.Err).
+		Str("route", d.Criteria.Origin+"-"+d.Criteria.Destination).
+		Dur("age", d.Age).
+		Strs("failed_providers", d.FailedProviders).
+		Int("cached_flights", d.CachedFlights).
+		Int("live_flights", d.LiveFlights).
+		Int("matched", d.Matched).
+		Int("repriced", d.Repriced).
+		Float64("price_drift", d.PriceDrift).
+		Msg("Cache drift")
+}
+
+// observeRankingViolation logs ranking scores clamped for breaking a ranking
+// invariant and exports them as metrics.
+func observeRankingViolation(v usecase.RankingViolation) {
+	metrics.ObserveRankingViolation(v.Provider, v.Invariant, v.Factor)
+	log.Warn().
+		Str("provider", v.Provider).
+		Str("flight_id", v.FlightID).
+		Str("invariant", v.Invariant).
+		Str("factor", v.Factor).
+		Float64("score", v.Score).
+		Float64("clamped", v.Clamped).
+		Msg("Ranking score clamped")
+}
+
+// loadShedObserver logs load shedding decisions and exports them as metrics.
+type loadShedObserver struct{}
+
+// FeatureShed implements loadshed.Observer.
+func (loadShedObserver) FeatureShed(feature string, shed bool, signals loadshed.Signals) {
+	metrics.ObserveLoadShed(feature, shed)
+	event := log.Info()
+	msg := "Optional feature restored"
+	if shed {
+		event = log.Warn()
+		msg = "Optional feature shed under load"
+	}
+	event.
+		Str("feature", feature).
+		Int("in_flight", signals.InFlight).
+		Dur("p95_latency", signals.P95Latency).
+		Msg(msg)
+}
+
+// registerAdminRoutes registers the admin API behind bearer token auth,
+// requiring the admin role and, for debug replays, the debug role.
+// In jwt auth mode the roles come from the scopes of the caller's token.
+// Otherwise, without ADMIN_TOKEN_SECRET the admin API is open outside
+// production and not served at all in production.
+func registerAdminRoutes(e *echo.Echo, h *flighthttp.AdminHandler, cfg *config.Config) {
+	authority, err := cfg.Admin.Authority()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure admin authentication")
+	}
+
+	switch {
+	case cfg.Auth.Mode == config.AuthModeJWT:
+		flighthttp.RegisterAdminRoutes(e, h,
+			appmiddleware.RequireRole(rbac.RoleAdmin),
+			appmiddleware.RequireRoleWhen(rbac.RoleDebug, flighthttp.DebugRequested))
+	case authority != nil:
+		flighthttp.RegisterAdminRoutes(e, h,
+			appmiddleware.AdminAuth(authority),
+			appmiddleware.RequireRole(rbac.RoleAdmin),
+			appmiddleware.RequireRoleWhen(rbac.RoleDebug, flighthttp.DebugRequested))
+	case cfg.IsProduction():
+		log.Warn().Msg("ADMIN_TOKEN_SECRET is not set, admin API disabled")
+	default:
+		log.Warn().Msg("ADMIN_TOKEN_SECRET is not set, admin API is unauthenticated")
+		flighthttp.RegisterAdminRoutes(e, h)
+	}
+}
+
+// background holds the subsystems that run outside request handling.
+type background struct {
+	jobs        *scheduler.Scheduler
+	queue       *jobqueue.Queue
+	audit       *audit.Trail
+	searchAudit *searchaudit.Logger
+}
+
+// start launches the scheduler and the job queue workers.
+func (b *background) start(ctx context.Context) {
+	b.queue.Start(ctx)
+	b.jobs.Start(ctx)
+}
+
+// stop stops scheduling new work and waits for in-progress work until ctx expires.
+func (b *background) stop(ctx context.Context) {
+	if err := b.jobs.Stop(ctx); err != nil {
+		log.Error().Err(err).Msg("Error stopping background jobs")
+	}
+	if err := b.queue.Stop(ctx); err != nil {
+		log.Error().Err(err).Msg("Error stopping job queue")
+	}
+	if err := b.audit.Close(); err != nil {
+		log.Error().Err(err).Msg("Error closing audit trail")
+	}
+	if b.searchAudit != nil {
+		if err := b.searchAudit.Close(ctx); err != nil {
+			log.Error().Err(err).Msg("Error closing search audit log")
+		}
+	}
+}
+
+// openDatabase opens the configured database, applying pending migrations
+// when DATABASE_MIGRATE_ON_STARTUP is set, and returns it with its schema
+// runner. It returns nils when no database is configured.
+func openDatabase(cfg *config.Config) (*sql.DB, *migrate.Runner) {
+	db, err := openSQL(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to open database")
+	}
+	if db == nil {
+		return nil, nil
+	}
+	schema := migrate.New(migrate.NewSQLDriver(db), migrate.Embedded())
+
+	ctx := context.Background()
+	if cfg.Database.MigrateOnStartup {
+		if err := migrateUp(ctx, schema); err != nil {
+			log.Fatal().Err(err).Msg("Failed to migrate database schema")
+		}
+	}
+	status, err := schema.Status(ctx)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to read database schema version")
+	}
+	if !status.Current() {
+		// Readiness reports the schema until the migrate command brings it up to date
+		log.Warn().Uint("version", status.Version).Uint("latest", status.Latest).Bool("dirty", status.Dirty).
+			Msg("Database schema is not up to date")
+	}
+	log.Info().Str("driver", cfg.Database.Driver).Uint("schema_version", status.Version).Msg("Database opened")
+	return db, schema
+}
+
+// openSQL returns a handle to the configured database, or nil if none is
+// configured. SQLite databases are tuned with the DATABASE_SQLITE_* settings.
+func openSQL(cfg *config.Config) (*sql.DB, error) {
+	if cfg.Database.Driver != config.SQLiteDriver {
+		return cfg.Database.Open()
+	}
+	return sqlite.Open(cfg.Database.DSN, sqlite.Options{
+		JournalMode: cfg.Database.SQLiteJournalMode,
+		Synchronous: cfg.Database.SQLiteSynchronous,
+		BusyTimeout: cfg.Database.SQLiteBusyTimeout,
 	})
 }
 
+// searchHistory is a search record store listing searches per client, with
+// retention and analytics, reporting its size.
+type searchHistory interface {
+	domain.SearchHistoryStore
+	domain.SearchHistoryCompactor
+	analytics.Source
+	Len() int
+	AggregateLen() int
+}
+
+// newSearchHistory returns the SEARCH_HISTORY_STORE search history: in memory,
+// or in the SQLite database db, encrypted when STORAGE_ENCRYPTION_KEYS is set.
+func newSearchHistory(cfg *config.Config, db *sql.DB) searchHistory {
+	if cfg.History.Store != config.HistoryStoreDatabase {
+		return memory.NewSearchStore(cfg.History.Capacity)
+	}
+
+	keyring := encryptionKeys(cfg)
+	if keyring == nil {
+		log.Info().Int("capacity", cfg.History.Capacity).Msg("Search history stored in the database")
+		return sqlite.NewSearchStore(db, cfg.History.Capacity)
+	}
+	store := sqlite.NewSearchStore(db, cfg.History.Capacity, sqlite.WithEncryption(keyring))
+	reencrypt("search history", store, keyring)
+	log.Info().Int("capacity", cfg.History.Capacity).Msg("Search history stored in the database, encrypted")
+	return store
+}
+
+// encryptionKeys returns the STORAGE_ENCRYPTION_KEYS keyring, or nil if no
+// keys are configured, exiting if the keys are invalid.
+func encryptionKeys(cfg *config.Config) *encryption.Keyring {
+	keyring, err := cfg.Encryption.Keyring()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load encryption keys")
+	}
+	return keyring
+}
+
+// keyRotator is an encrypted store that can rewrite its data under the
+// primary key.
+type keyRotator interface {
+	RotateKeys(ctx context.Context) (int, error)
+}
+
+// reencrypt rewrites the data of the named store written before encryption
+// was enabled or under an older key, so the older keys can be removed from
+// STORAGE_ENCRYPTION_KEYS once every store has started with the new one.
+// A failure is logged and the store is still used: data under a configured
+// key stays readable and is rewritten on the next start.
+func reencrypt(name string, store keyRotator, keyring *encryption.Keyring) {
+	rotated, err := store.RotateKeys(context.Background())
+	if err != nil {
+		log.Error().Err(err).Str("store", name).Msg("Failed to re-encrypt stored data")
+	}
+	log.Info().Str("store", name).Str("key_id", keyring.PrimaryKeyID()).Int("reencrypted", rotated).
+		Msg("Stored data encrypted")
+}
+
+// migrateUp applies pending migrations, logging each one applied.
+func migrateUp(ctx context.Context, schema *migrate.Runner) error {
+	applied, err := schema.Up(ctx)
+	for _, m := range applied {
+		log.Info().Uint("version", m.Version).Str("name", m.Name).Msg("Migration applied")
+	}
+	return err
+}
+
+// runMigrate runs the migrate subcommand: "up" (the default) applies pending
+// migrations, "version" reports the schema version and "force VERSION"
+// records VERSION as applied once a failed migration has been repaired by hand.
+func runMigrate(ctx context.Context, cfg *config.Config, args []string) error {
+	db, err := openSQL(cfg)
+	if err != nil {
+		return err
+	}
+	if db == nil {
+		return errors.New("DATABASE_DRIVER is not set")
+	}
+	defer db.Close()
+	schema := migrate.New(migrate.NewSQLDriver(db), migrate.Embedded())
+
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+	switch {
+	case command == "up" && len(args) <= 1:
+		if err := migrateUp(ctx, schema); err != nil {
+			return err
+		}
+	case command == "version" && len(args) == 1:
+	case command == "force" && len(args) == 2:
+		version, err := strconv.ParseUint(args[1], 10, 0)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := schema.Force(ctx, uint(version)); err != nil {
+			return err
+		}
+	default:
+		return errors.New("usage: migrate [up | version | force VERSION]")
+	}
+
+	status, err := schema.Status(ctx)
+	if err != nil {
+		return err
+	}
+	log.Info().Uint("version", status.Version).Uint("latest", status.Latest).Bool("dirty", status.Dirty).
+		Msg("Database schema version")
+	return nil
+}
+
+// runRestore runs the restore subcommand: "restore [-sha256 CHECKSUM] FILE"
+// replaces the SQLite database with the backup FILE ("-" = standard input)
+// downloaded from /admin/v1/backup, after verifying it against CHECKSUM, the
+// backup's X-Backup-SHA256 header, and SQLite's integrity check. The server
+// must be stopped.
+func runRestore(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	checksum := fs.String("sha256", "", "expected SHA-256 checksum of the backup, in hex (empty = not verified)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: restore [-sha256 CHECKSUM] FILE")
+	}
+	if cfg.Database.Driver != config.SQLiteDriver {
+		return fmt.Errorf("restore requires DATABASE_DRIVER=%s", config.SQLiteDriver)
+	}
+
+	var backup io.Reader = os.Stdin
+	if name := fs.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		backup = f
+	}
+	if *checksum == "" {
+		log.Warn().Msg("No checksum given, the backup is only checked for integrity")
+	}
+
+	sum, err := sqlite.Restore(ctx, cfg.Database.DSN, backup, *checksum)
+	if err != nil {
+		return err
+	}
+	log.Info().Str("database", sqlite.FilePath(cfg.Database.DSN)).Str("sha256", sum).Msg("Database restored")
+	return nil
+}
+
+// openJobQueue opens the persistent job queue, in the JOB_QUEUE_STORE store,
+// exiting if its jobs cannot be loaded.
+func openJobQueue(cfg *config.Config, db *sql.DB) *jobqueue.Queue {
+	var storage jobqueue.Storage = jobqueue.NewMemoryStorage()
+	source := cfg.Queue.File
+	switch {
+	case cfg.Queue.Store == config.QueueStoreDatabase:
+		source = "database"
+		storage = newJobStore(cfg, db)
+	case cfg.Queue.File != "":
+		var opts []jobqueue.FileOption
+		if keyring := encryptionKeys(cfg); keyring != nil {
+			opts = append(opts, jobqueue.WithEncryption(keyring))
+			log.Info().Str("key_id", keyring.PrimaryKeyID()).Msg("Job queue encryption enabled")
+		}
+		storage = jobqueue.NewFileStorage(cfg.Queue.File, opts...)
+	}
+
+	queue, err := jobqueue.Open(context.Background(), storage, jobqueue.Config{
+		MaxAttempts: cfg.Queue.MaxAttempts,
+		BaseBackoff: cfg.Queue.Backoff,
+		MaxBackoff:  cfg.Queue.MaxBackoff,
+		Workers:     cfg.Queue.Workers,
+		Retention:   cfg.Queue.Retention,
+	}, jobqueue.WithLogger(log.Logger))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to open job queue")
+	}
+
+	log.Info().Str("store", source).Int("pending", len(queue.List(jobqueue.StatusPending))).
+		Int("dead", len(queue.List(jobqueue.StatusDead))).Msg("Job queue opened")
+	return queue
+}
+
+// newJobStore returns the job queue store of the SQLite database db,
+// encrypted when STORAGE_ENCRYPTION_KEYS is set.
+func newJobStore(cfg *config.Config, db *sql.DB) *sqlite.JobStore {
+	keyring := encryptionKeys(cfg)
+	if keyring == nil {
+		return sqlite.NewJobStore(db)
+	}
+	store := sqlite.NewJobStore(db, sqlite.WithEncryption(keyring))
+	reencrypt("job queue", store, keyring)
+	return store
+}
+
+// openAuditTrail opens the admin audit trail, exiting if its file cannot be loaded.
+func openAuditTrail(cfg *config.Config) *audit.Trail {
+	if cfg.Admin.AuditFile == "" {
+		return audit.New()
+	}
+	trail, err := audit.Open(cfg.Admin.AuditFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to open audit trail")
+	}
+	log.Info().Str("file", cfg.Admin.AuditFile).Msg("Audit trail opened")
+	return trail
+}
+
+// openSearchAudit opens the SEARCH_AUDIT_SINK search audit log, encrypted
+// when STORAGE_ENCRYPTION_KEYS is set, exiting if its sink cannot be opened.
+// It returns nil when searches are not audited.
+func openSearchAudit(cfg *config.Config, db *sql.DB) *searchaudit.Logger {
+	var sink searchaudit.Sink
+	switch cfg.Audit.Sink {
+	case config.SearchAuditSinkFile:
+		var opts []searchaudit.FileOption
+		keyring := encryptionKeys(cfg)
+		if keyring != nil {
+			opts = append(opts, searchaudit.WithEncryption(keyring))
+		}
+		file, err := searchaudit.OpenFile(cfg.Audit.File, opts...)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to open search audit log")
+		}
+		log.Info().Str("file", cfg.Audit.File).Bool("encrypted", keyring != nil).Msg("Search audit log opened")
+		sink = file
+	case config.SearchAuditSinkDatabase:
+		keyring := encryptionKeys(cfg)
+		if keyring == nil {
+			log.Info().Msg("Search audit log stored in the database")
+			sink = sqlite.NewSearchAuditStore(db)
+			break
+		}
+		store := sqlite.NewSearchAuditStore(db, sqlite.WithEncryption(keyring))
+		reencrypt("search audit log", store, keyring)
+		log.Info().Msg("Search audit log stored in the database, encrypted")
+		sink = store
+	default:
+		return nil
+	}
+	return searchaudit.New(sink,
+		searchaudit.WithBuffer(cfg.Audit.Buffer),
+		searchaudit.WithDropObserver(func() {
+			metrics.ObserveSearchAuditDrop(metrics.SearchAuditQueueFull, 1)
+		}),
+		searchaudit.WithErrorObserver(func(err error, entries int) {
+			metrics.ObserveSearchAuditDrop(metrics.SearchAuditWriteError, entries)
+			log.Error().Err(err).Int("entries", entries).Msg("Failed to write search audit entries")
+		}),
+	)
+}
+
+// loadDemoStore loads the demo flight store from MOCK_DATA_DIR, or from the
+// blob store when MOCK_DATA_BLOB_PREFIX is set.
+func loadDemoStore(ctx context.Context, cfg *config.Config, blobs blob.Store) (*demo.Store, error) {
+	if cfg.App.MockDataBlobPrefix == "" {
+		return demo.LoadMockDir(ctx, cfg.App.MockDataDir, demo.WithDateShift())
+	}
+
+	dir, err := os.MkdirTemp("", "mock-data-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	n, err := blob.Download(ctx, blobs, cfg.App.MockDataBlobPrefix, dir)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Str("prefix", cfg.App.MockDataBlobPrefix).Int("files", n).Msg("Mock data downloaded")
+	return demo.LoadMockDir(ctx, dir, demo.WithDateShift())
+}
+
+// registerHistoryRetention exports the search history size and schedules its compaction.
+func registerHistoryRetention(jobs *scheduler.Scheduler, store searchHistory, cfg *config.Config) {
+	const storeName = "search_history"
+	if err := metrics.RegisterStoreSize(storeName, metrics.StoreKindRecords, store.Len); err != nil {
+		log.Fatal().Err(err).Msg("Failed to register store metrics")
+	}
+	if err := metrics.RegisterStoreSize(storeName, metrics.StoreKindAggregates, store.AggregateLen); err != nil {
+		log.Fatal().Err(err).Msg("Failed to register store metrics")
+	}
+
+	if cfg.Scheduler.HistoryCompaction == "" {
+		return
+	}
+	addJob(jobs, scheduler.Job{
+		Name:     "history_compaction",
+		Schedule: scheduler.MustParse(cfg.Scheduler.HistoryCompaction),
+		Jitter:   cfg.Scheduler.Jitter,
+		Run: func(ctx context.Context) error {
+			now := time.Now()
+			stats, err := store.Compact(ctx, now.Add(-cfg.History.Retention), now.Add(-cfg.History.AggregateRetention))
+			if err != nil {
+				return err
+			}
+			metrics.StoreCompacted.WithLabelValues(storeName, metrics.StoreKindRecords).Add(float64(stats.RecordsCompacted))
+			metrics.StoreCompacted.WithLabelValues(storeName, metrics.StoreKindAggregates).Add(float64(stats.AggregatesExpired))
+			log.Info().Int("records_compacted", stats.RecordsCompacted).Int("aggregates_expired", stats.AggregatesExpired).
+				Msg("Search history compacted")
+			return nil
+		},
+	})
+}
+
+// registerAnalyticsExport schedules the daily anonymized analytics export.
+func registerAnalyticsExport(jobs *scheduler.Scheduler, store searchHistory, blobs blob.Store, cfg *config.Config) {
+	if cfg.Scheduler.AnalyticsExport == "" {
+		return
+	}
+
+	exporter := analytics.NewExporter(store, blobs, analytics.WithMinSearches(cfg.Analytics.MinSearches))
+	addJob(jobs, scheduler.Job{
+		Name:     "analytics_export",
+		Schedule: scheduler.MustParse(cfg.Scheduler.AnalyticsExport),
+		Jitter:   cfg.Scheduler.Jitter,
+		Run: func(ctx context.Context) error {
+			result, err := exporter.ExportPreviousDay(ctx)
+			if err != nil {
+				return err
+			}
+			log.Info().Str("key", result.Key).Int("rows", result.Rows).Int("suppressed", result.Suppressed).
+				Msg("Search analytics exported")
+			return nil
+		},
+	})
+}
+
+// addJob registers a background job, exiting if its definition is invalid.
+func addJob(jobs *scheduler.Scheduler, job scheduler.Job) {
+	if err := jobs.Add(job); err != nil {
+		log.Fatal().Err(err).Msg("Failed to schedule job")
+	}
+	log.Info().Str("job", job.Name).Str("schedule", fmt.Sprint(job.Schedule)).Msg("Job scheduled")
+}
+
+// providerHealthResponse is the health of a provider reported by /health.
+type providerHealthResponse struct {
+	Name                string     `json:"name"`
+	Status              string     `json:"status"`
+	Breaker             string     `json:"breaker"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	LastFailure         *time.Time `json:"last_failure,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastCheck           *time.Time `json:"last_check,omitempty"`
+}
+
+// healthCheckHandler returns the health status of the service and of each
+// provider. The service is "degraded" while a provider is failing, but the
+// status code stays 200: the service still answers from the other providers,
+// and restarting it would not help.
+// Note: This endpoint is at the root level (/health), not under /api/v1
+func healthCheckHandler(monitor *health.Monitor) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		status := "ok"
+		statuses := monitor.Statuses()
+		providers := make([]providerHealthResponse, len(statuses))
+		for i, s := range statuses {
+			if s.Status == health.StatusDegraded || s.Status == health.StatusUnhealthy {
+				status = "degraded"
+			}
+			providers[i] = providerHealthResponse{
+				Name:                s.Provider,
+				Status:              string(s.Status),
+				Breaker:             string(s.Breaker),
+				ConsecutiveFailures: s.ConsecutiveFailures,
+				LastSuccess:         optionalTime(s.LastSuccess),
+				LastFailure:         optionalTime(s.LastFailure),
+				LastError:           s.LastError,
+				LastCheck:           optionalTime(s.LastCheck),
+			}
+		}
+		return c.JSON(http.StatusOK, map[string]any{"status": status, "providers": providers})
+	}
+}
+
+// optionalTime returns a pointer to t, or nil if t is zero.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// readinessHandler reports whether the service can serve traffic: the schema
+// of its database, if one is configured, must be reachable and up to date.
+func readinessHandler(schema *migrate.Runner) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if schema == nil {
+			return c.JSON(http.StatusOK, map[string]any{"status": "ready", "database": "disabled"})
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request().Context(), readinessTimeout)
+		defer cancel()
+		status, err := schema.Status(ctx)
+		if err != nil {
+			log.Warn().Err(err).Msg("Readiness check failed")
+			return c.JSON(http.StatusServiceUnavailable, map[string]any{
+				"status": "not_ready", "database": "unavailable",
+			})
+		}
+		if !status.Current() {
+			return c.JSON(http.StatusServiceUnavailable, map[string]any{"status": "not_ready", "schema": status})
+		}
+		return c.JSON(http.StatusOK, map[string]any{"status": "ready", "schema": status})
+	}
+}
+
 // gracefulShutdown handles graceful server shutdown on interrupt signals.
-func gracefulShutdown(e *echo.Echo) {
+func gracefulShutdown(e *echo.Echo, bg *background) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
@@ -200,5 +1288,7 @@ func gracefulShutdown(e *echo.Echo) {
 		log.Error().Err(err).Msg("Error during server shutdown")
 	}
 
+	bg.stop(ctx)
+
 	log.Info().Msg("Server stopped")
 }
@@ -36,6 +36,30 @@ const docTemplate = `{
                 ],
                 "summary": "Search for flights",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Locale for airline/airport display names (en, id). Overrides Accept-Language.",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred language for airline/airport display names",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Partner API key. Its configured defaults apply when sortBy, maxResults, or fields are omitted.",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OAuth2 bearer token, instead of X-API-Key when AUTH_MODE is jwt. Its scopes grant the caller's roles.",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
                     {
                         "description": "Search criteria with optional filters. Example with all filters: {\\",
                         "name": "request",
@@ -48,17 +72,29 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful search with flight results. Returns empty array if no flights match filters.",
+                        "description": "Successful search with flight results. Returns empty array if no flights match filters. Searches for more than 9 passengers return group quotes instead (see GroupQuoteResponseDTO).",
                         "schema": {
                             "$ref": "#/definitions/internal_adapter_http.SwaggerSearchResponse"
                         }
                     },
+                    "202": {
+                        "description": "Asynchronous search accepted - the request has a callbackUrl, and the results are posted to it under the returned search_id (see SearchCallbackDTO)",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_http.AsyncSearchAcceptedDTO"
+                        }
+                    },
                     "400": {
                         "description": "Validation error - invalid request parameters (e.g., invalid time format, minMinutes \u003e maxMinutes, missing required fields)",
                         "schema": {
                             "$ref": "#/definitions/internal_adapter_http.SwaggerErrorResponse"
                         }
                     },
+                    "413": {
+                        "description": "Request body too large - larger than SERVER_MAX_REQUEST_BODY_BYTES, reported in details.maxBytes",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_http.SwaggerErrorResponse"
+                        }
+                    },
                     "503": {
                         "description": "Service unavailable - all providers failed",
                         "schema": {
@@ -76,6 +112,19 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "internal_adapter_http.AsyncSearchAcceptedDTO": {
+            "type": "object",
+            "properties": {
+                "search_id": {
+                    "type": "string",
+                    "example": "0f8fad5b-d9cb-469f-a165-70867728950e"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "accepted"
+                }
+            }
+        },
         "internal_adapter_http.DurationRangeDTO": {
             "type": "object",
             "properties": {
@@ -113,6 +162,16 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "changeable": {
+                    "description": "Changeable only includes fares whose fare family allows changes",
+                    "type": "boolean",
+                    "example": true
+                },
+                "departureTimeBucket": {
+                    "description": "DepartureTimeBucket filters flights departing within a part of the day,\ninstead of DepartureTimeRange: early_morning, morning, afternoon,\nevening or night",
+                    "type": "string",
+                    "example": "morning"
+                },
                 "departureTimeRange": {
                     "description": "DepartureTimeRange filters flights departing within a time window",
                     "allOf": [
@@ -129,6 +188,22 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "fareFamilies": {
+                    "description": "FareFamilies filters to only include fares of these fare families, e.g.\n\"Flex\" (case-insensitive); flights without a fare family are filtered out",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Value",
+                        "Flex"
+                    ]
+                },
+                "includeHigherClasses": {
+                    "description": "IncludeHigherClasses keeps flights of a class above the one searched for,\ne.g. business class flights on an economy search; other flights of\nanother class than the one searched for are always filtered out",
+                    "type": "boolean",
+                    "example": true
+                },
                 "maxPrice": {
                     "description": "MaxPrice filters flights with price above this amount",
                     "type": "number",
@@ -138,16 +213,71 @@ const docTemplate = `{
                     "description": "MaxStops filters flights with more stops than this value (0 = direct only)",
                     "type": "integer",
                     "example": 0
+                },
+                "minPrice": {
+                    "description": "MinPrice filters flights with price below this amount, e.g. zero-price fares",
+                    "type": "number",
+                    "example": 100000
+                },
+                "minSeatsAvailable": {
+                    "description": "MinSeatsAvailable filters flights with fewer seats left than this, e.g.\nfor a group booking. Flights whose provider reports no seat count are\nexcluded.",
+                    "type": "integer",
+                    "example": 4
+                },
+                "refundable": {
+                    "description": "Refundable only includes fares whose fare family allows refunds",
+                    "type": "boolean",
+                    "example": true
+                },
+                "refundableOnly": {
+                    "description": "RefundableOnly only includes flights whose fare rules allow refunds,\nwith or without a fare family; flights whose provider reports no fare\nrules are filtered out",
+                    "type": "boolean",
+                    "example": true
+                },
+                "supportsWheelchair": {
+                    "description": "SupportsWheelchair only includes flights offering wheelchair assistance",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "internal_adapter_http.ReturnLegDTO": {
+            "type": "object",
+            "properties": {
+                "departureDate": {
+                    "description": "DepartureDate is the return departure date in YYYY-MM-DD format",
+                    "type": "string",
+                    "example": "2025-12-20"
+                },
+                "destination": {
+                    "description": "Destination is the IATA code of the return arrival airport",
+                    "type": "string",
+                    "example": "CGK"
+                },
+                "origin": {
+                    "description": "Origin is the IATA code of the return departure airport",
+                    "type": "string",
+                    "example": "SUB"
                 }
             }
         },
         "internal_adapter_http.SearchFlightsRequest": {
             "type": "object",
             "properties": {
+                "callbackUrl": {
+                    "description": "CallbackURL makes the search asynchronous (optional): the request is\nanswered at once with the search ID, and the results are POSTed to\nthis absolute http(s) URL once the search completes",
+                    "type": "string",
+                    "example": "https://partner.example.com/flight-results"
+                },
                 "class": {
                     "description": "Class is the travel class: economy, business, or first (optional)",
                     "type": "string"
                 },
+                "dateFlexibility": {
+                    "description": "DateFlexibility also searches departures up to this many days before\nand after departureDate (0-3, optional, one way only)",
+                    "type": "integer",
+                    "example": 3
+                },
                 "departureDate": {
                     "description": "DepartureDate is the desired departure date in YYYY-MM-DD format",
                     "type": "string"
@@ -156,6 +286,23 @@ const docTemplate = `{
                     "description": "Destination is the IATA code of the arrival airport (e.g., \"DPS\")",
                     "type": "string"
                 },
+                "fanOutStrategy": {
+                    "description": "FanOutStrategy is how the providers are queried: all-parallel,\nfastest-first-return or sequential-failover (optional, defaults to the\nserver's strategy)",
+                    "type": "string",
+                    "example": "fastest-first-return"
+                },
+                "fields": {
+                    "description": "Fields limits each flight to the listed fields (optional, \"id\" is always included)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "id",
+                        "provider",
+                        "price"
+                    ]
+                },
                 "filters": {
                     "description": "Filters contains optional filtering criteria",
                     "allOf": [
@@ -164,17 +311,68 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "maxResults": {
+                    "description": "MaxResults limits the number of flights returned (1-500, optional)",
+                    "type": "integer",
+                    "example": 20
+                },
+                "maxResultsPerProvider": {
+                    "description": "MaxResultsPerProvider limits the number of flights of each provider (1-500, optional)",
+                    "type": "integer",
+                    "example": 5
+                },
                 "origin": {
                     "description": "Origin is the IATA code of the departure airport (e.g., \"CGK\")",
                     "type": "string"
                 },
+                "partialResultsOk": {
+                    "description": "PartialResultsOK returns the providers' answers at the soft deadline instead of waiting for all of them (optional)",
+                    "type": "boolean",
+                    "example": true
+                },
                 "passengers": {
-                    "description": "Passengers is the number of passengers (1-9)",
+                    "description": "Passengers is the number of passengers (1-9, or 10-99 for a group quote)",
                     "type": "integer"
                 },
+                "preferredDepartureTime": {
+                    "description": "PreferredDepartureTime is the time of day the passengers prefer to\ndepart (optional). Best value ranking favors flights departing within\nit, without excluding the others",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_adapter_http.TimeRangeDTO"
+                        }
+                    ]
+                },
+                "return": {
+                    "description": "Return is the return leg of a round trip (optional). Its origin may differ\nfrom the outbound destination for open-jaw trips",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_adapter_http.ReturnLegDTO"
+                        }
+                    ]
+                },
+                "returnDate": {
+                    "description": "ReturnDate makes the search a round trip flying the outbound route back\non this date, in YYYY-MM-DD format (optional, shorthand for return)",
+                    "type": "string",
+                    "example": "2025-12-20"
+                },
                 "sortBy": {
-                    "description": "SortBy specifies how to sort results: best_value, price, duration, departure",
+                    "description": "SortBy specifies how to sort results: best_value, price, duration, departure, arrival",
                     "type": "string"
+                },
+                "sortOrder": {
+                    "description": "SortOrder is the direction of the sort: asc (default) or desc, e.g.\nmost expensive or latest departure first",
+                    "type": "string",
+                    "example": "desc"
+                },
+                "specialServices": {
+                    "description": "SpecialServices are the special assistance services the passengers need,\ne.g. \"wheelchair\" (optional). Flights supporting all of them are listed first",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "wheelchair"
+                    ]
                 }
             }
         },
@@ -182,6 +380,11 @@ const docTemplate = `{
             "description": "Airline information",
             "type": "object",
             "properties": {
+                "alliance": {
+                    "description": "Alliance is the airline alliance the airline belongs to, if any",
+                    "type": "string",
+                    "example": "SkyTeam"
+                },
                 "code": {
                     "description": "Code is the IATA airline code",
                     "type": "string",
@@ -371,6 +574,16 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Soekarno-Hatta International Airport"
                 },
+                "city": {
+                    "description": "City is the served city name",
+                    "type": "string",
+                    "example": "Jakarta"
+                },
+                "country": {
+                    "description": "Country is the ISO 3166-1 alpha-2 country code",
+                    "type": "string",
+                    "example": "ID"
+                },
                 "dateTime": {
                     "description": "DateTime is the scheduled departure or arrival time",
                     "type": "string",
@@ -397,6 +610,11 @@ const docTemplate = `{
                     "type": "number",
                     "example": 1250000
                 },
+                "baseFare": {
+                    "description": "BaseFare, Taxes and Fees break Amount down, when the provider reports it",
+                    "type": "number",
+                    "example": 1100000
+                },
                 "currency": {
                     "description": "Currency is the ISO 4217 currency code",
                     "type": "string",
@@ -406,6 +624,24 @@ const docTemplate = `{
                     "description": "Display is a formatted price string",
                     "type": "string",
                     "example": "IDR 1,250,000"
+                },
+                "fees": {
+                    "type": "number",
+                    "example": 30000
+                },
+                "passengers": {
+                    "description": "Passengers is the number of passengers Total is for",
+                    "type": "integer",
+                    "example": 2
+                },
+                "taxes": {
+                    "type": "number",
+                    "example": 120000
+                },
+                "total": {
+                    "description": "Total is the price for all the passengers, Amount being per passenger",
+                    "type": "number",
+                    "example": 2500000
                 }
             }
         },
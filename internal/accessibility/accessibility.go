@@ -0,0 +1,133 @@
+// Package accessibility fills in the special assistance flights support.
+//
+// Providers rarely send accessibility data with their flights, so a Policy
+// holds the special assistance services each airline publishes in its
+// policies. Wrapped providers annotate the flights they return with the
+// policy of the operating airline unless the provider supplied the
+// information itself.
+package accessibility
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// defaultPolicies is the policy file of the airlines served by the integrated providers.
+//
+//go:embed policies.json
+var defaultPolicies []byte
+
+// policyFile is the format of policy files: the services each airline
+// supports, by IATA airline code.
+type policyFile struct {
+	Airlines map[string][]domain.SpecialService `json:"airlines"`
+}
+
+// Policy holds the special assistance services airlines support.
+type Policy struct {
+	airlines map[string][]domain.SpecialService
+}
+
+// DefaultPolicy returns the policy of the airlines served by the integrated providers.
+func DefaultPolicy() *Policy {
+	p, err := ParsePolicy(defaultPolicies)
+	if err != nil {
+		panic(fmt.Sprintf("accessibility: invalid embedded policies: %v", err))
+	}
+	return p
+}
+
+// LoadPolicy reads a policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accessibility policy: %w", err)
+	}
+	p, err := ParsePolicy(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// ParsePolicy parses a policy file, rejecting unknown services.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var file policyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid accessibility policy: %w", err)
+	}
+
+	p := &Policy{airlines: make(map[string][]domain.SpecialService, len(file.Airlines))}
+	for code, services := range file.Airlines {
+		for _, s := range services {
+			if !s.IsValid() {
+				return nil, fmt.Errorf("invalid accessibility policy: airline %s: unknown service %q", code, s)
+			}
+		}
+		p.airlines[strings.ToUpper(code)] = slices.Clone(services)
+	}
+	return p, nil
+}
+
+// Services returns the services the airline supports; ok is false if the
+// policy does not cover the airline.
+func (p *Policy) Services(airlineCode string) (services []domain.SpecialService, ok bool) {
+	services, ok = p.airlines[strings.ToUpper(airlineCode)]
+	return slices.Clone(services), ok
+}
+
+// Annotate fills in the accessibility information of flights that have none
+// from the policy of their airline. Flights of airlines the policy does not
+// cover are left without.
+func (p *Policy) Annotate(flights []domain.Flight) {
+	for i := range flights {
+		if flights[i].Accessibility != nil {
+			continue
+		}
+		if services, ok := p.Services(flights[i].Airline.Code); ok {
+			flights[i].Accessibility = &domain.AccessibilityInfo{
+				Services: services,
+				Source:   domain.AccessibilitySourcePolicy,
+			}
+		}
+	}
+}
+
+// annotatedProvider annotates the flights of a provider with the policy.
+type annotatedProvider struct {
+	domain.FlightProvider
+	policy *Policy
+}
+
+// Instrument wraps a provider so its flights are annotated with the policy.
+func (p *Policy) Instrument(provider domain.FlightProvider) domain.FlightProvider {
+	return annotatedProvider{FlightProvider: provider, policy: p}
+}
+
+// InstrumentAll wraps each provider with Instrument.
+func (p *Policy) InstrumentAll(providers []domain.FlightProvider) []domain.FlightProvider {
+	wrapped := make([]domain.FlightProvider, len(providers))
+	for i, provider := range providers {
+		wrapped[i] = p.Instrument(provider)
+	}
+	return wrapped
+}
+
+// Search implements domain.FlightProvider.
+func (p annotatedProvider) Search(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	return p.SearchWithOptions(ctx, criteria, domain.ProviderSearchOptions{})
+}
+
+// SearchWithOptions implements domain.FilteringProvider.
+func (p annotatedProvider) SearchWithOptions(ctx context.Context, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) ([]domain.Flight, error) {
+	flights, err := domain.SearchWithOptions(ctx, p.FlightProvider, criteria, opts)
+	p.policy.Annotate(flights)
+	return flights, err
+}
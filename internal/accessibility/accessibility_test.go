@@ -0,0 +1,81 @@
+package accessibility
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// fixedProvider returns copies of its flights.
+type fixedProvider []domain.Flight
+
+func (p fixedProvider) Name() string { return "fixed_air" }
+
+func (p fixedProvider) Search(context.Context, domain.SearchCriteria) ([]domain.Flight, error) {
+	return append([]domain.Flight(nil), p...), nil
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	policy := DefaultPolicy()
+
+	services, ok := policy.Services("ga")
+	require.True(t, ok)
+	assert.Contains(t, services, domain.ServiceWheelchairOnboard)
+
+	services, ok = policy.Services("IW")
+	require.True(t, ok)
+	assert.Equal(t, []domain.SpecialService{domain.ServiceWheelchair}, services)
+
+	_, ok = policy.Services("XX")
+	assert.False(t, ok)
+}
+
+func TestParsePolicy_UnknownService(t *testing.T) {
+	_, err := ParsePolicy([]byte(`{"airlines": {"GA": ["wheelchair", "jetpack"]}}`))
+	assert.ErrorContains(t, err, `unknown service "jetpack"`)
+
+	_, err = ParsePolicy([]byte(`{`))
+	assert.Error(t, err)
+}
+
+func TestLoadPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"airlines": {"xx": ["service_animal"]}}`), 0o644))
+
+	policy, err := LoadPolicy(path)
+	require.NoError(t, err)
+	services, ok := policy.Services("XX")
+	require.True(t, ok)
+	assert.Equal(t, []domain.SpecialService{domain.ServiceServiceAnimal}, services)
+
+	_, err = LoadPolicy(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestPolicy_Instrument(t *testing.T) {
+	fromProvider := &domain.AccessibilityInfo{Services: []domain.SpecialService{domain.ServiceVisualAssistance}, Source: domain.AccessibilitySourceProvider}
+	provider := DefaultPolicy().Instrument(fixedProvider{
+		{ID: "1", Airline: domain.AirlineInfo{Code: "JT"}},
+		{ID: "2", Airline: domain.AirlineInfo{Code: "GA"}, Accessibility: fromProvider},
+		{ID: "3", Airline: domain.AirlineInfo{Code: "XX"}},
+	})
+
+	flights, err := provider.Search(context.Background(), domain.SearchCriteria{})
+	require.NoError(t, err)
+	require.Len(t, flights, 3)
+
+	require.NotNil(t, flights[0].Accessibility)
+	assert.Equal(t, domain.AccessibilitySourcePolicy, flights[0].Accessibility.Source)
+	assert.True(t, flights[0].Accessibility.Supports(domain.ServiceWheelchair))
+	assert.False(t, flights[0].Accessibility.Supports(domain.ServiceWheelchairOnboard))
+
+	assert.Same(t, fromProvider, flights[1].Accessibility, "provider data takes precedence")
+	assert.Nil(t, flights[2].Accessibility, "airline not covered")
+	assert.Equal(t, "fixed_air", provider.Name())
+}
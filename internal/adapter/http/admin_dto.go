@@ -0,0 +1,390 @@
+package http
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/audit"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/configdoc"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/jobqueue"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/latency"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/quality"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/scheduler"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// ReplayResponseDTO shows a stored search side by side with its re-execution.
+type ReplayResponseDTO struct {
+	SearchID   string            `json:"search_id"`
+	SearchedAt string            `json:"searched_at"`
+	Original   SearchResponseDTO `json:"original"`
+	Replayed   SearchResponseDTO `json:"replayed"`
+	Diff       ReplayDiffDTO     `json:"diff"`
+	Debug      *ReplayDebugDTO   `json:"debug,omitempty"`
+}
+
+// ReplayDiffDTO lists the flights that differ between the stored and replayed results.
+type ReplayDiffDTO struct {
+	Added          []FlightRefDTO    `json:"added"`
+	Removed        []FlightRefDTO    `json:"removed"`
+	Changed        []FlightChangeDTO `json:"changed"`
+	UnchangedCount int               `json:"unchanged_count"`
+}
+
+// FlightRefDTO identifies a flight in a diff.
+type FlightRefDTO struct {
+	ID           string `json:"id"`
+	Provider     string `json:"provider"`
+	FlightNumber string `json:"flight_number"`
+}
+
+// FlightChangeDTO describes a flight whose details changed.
+type FlightChangeDTO struct {
+	FlightRefDTO
+	Fields map[string]FieldChangeDTO `json:"fields"`
+}
+
+// FieldChangeDTO holds the stored and replayed value of a field.
+type FieldChangeDTO struct {
+	Original string `json:"original"`
+	Replayed string `json:"replayed"`
+}
+
+// ReplayDebugDTO contains per-provider details of a debug replay.
+type ReplayDebugDTO struct {
+	Providers []ProviderDebugDTO `json:"providers"`
+}
+
+// ProviderDebugDTO reports what a single provider returned during a debug replay.
+type ProviderDebugDTO struct {
+	Provider        string   `json:"provider"`
+	FlightsReturned int      `json:"flights_returned"`
+	FilteredOutIDs  []string `json:"filtered_out_ids"`
+	DurationMs      int64    `json:"duration_ms"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// ToReplayResponseDTO converts a replay result to its DTO.
+func ToReplayResponseDTO(result *usecase.ReplayResult) *ReplayResponseDTO {
+	if result == nil {
+		return nil
+	}
+
+	dto := &ReplayResponseDTO{
+		SearchID:   result.Record.ID,
+		SearchedAt: result.Record.CreatedAt.Format(time.RFC3339),
+		Original:   *ToSearchResponseDTO(&result.Record.Response),
+		Replayed:   *ToSearchResponseDTO(result.Replayed),
+		Diff: ReplayDiffDTO{
+			Added:          toFlightRefDTOs(result.Diff.Added),
+			Removed:        toFlightRefDTOs(result.Diff.Removed),
+			Changed:        make([]FlightChangeDTO, 0, len(result.Diff.Changed)),
+			UnchangedCount: result.Diff.Unchanged,
+		},
+	}
+
+	for _, change := range result.Diff.Changed {
+		fields := make(map[string]FieldChangeDTO, len(change.Fields))
+		for name, f := range change.Fields {
+			fields[name] = FieldChangeDTO{Original: f.Original, Replayed: f.Replayed}
+		}
+		dto.Diff.Changed = append(dto.Diff.Changed, FlightChangeDTO{
+			FlightRefDTO: toFlightRefDTO(change.Flight),
+			Fields:       fields,
+		})
+	}
+
+	if result.Providers != nil {
+		dto.Debug = &ReplayDebugDTO{Providers: make([]ProviderDebugDTO, 0, len(result.Providers))}
+		for _, p := range result.Providers {
+			pd := ProviderDebugDTO{
+				Provider:        p.Provider,
+				FlightsReturned: p.FlightsReturned,
+				FilteredOutIDs:  p.FilteredOut,
+				DurationMs:      p.Duration.Milliseconds(),
+			}
+			if pd.FilteredOutIDs == nil {
+				pd.FilteredOutIDs = []string{}
+			}
+			if p.Error != nil {
+				pd.Error = p.Error.Error()
+			}
+			dto.Debug.Providers = append(dto.Debug.Providers, pd)
+		}
+	}
+
+	return dto
+}
+
+// toFlightRefDTOs converts flights to flight references.
+func toFlightRefDTOs(flights []domain.Flight) []FlightRefDTO {
+	refs := make([]FlightRefDTO, 0, len(flights))
+	for _, f := range flights {
+		refs = append(refs, toFlightRefDTO(f))
+	}
+	return refs
+}
+
+// toFlightRefDTO converts a flight to a flight reference.
+func toFlightRefDTO(f domain.Flight) FlightRefDTO {
+	return FlightRefDTO{
+		ID:           f.ID,
+		Provider:     f.Provider,
+		FlightNumber: f.FlightNumber,
+	}
+}
+
+// JobsResponseDTO lists the scheduled background jobs.
+type JobsResponseDTO struct {
+	Jobs []JobStatusDTO `json:"jobs"`
+}
+
+// JobStatusDTO reports the state of a scheduled background job.
+type JobStatusDTO struct {
+	Name     string     `json:"name"`
+	Schedule string     `json:"schedule"`
+	Running  bool       `json:"running"`
+	NextRun  string     `json:"next_run,omitempty"`
+	LastRun  *JobRunDTO `json:"last_run,omitempty"`
+	Runs     int        `json:"runs"`
+	Failures int        `json:"failures"`
+	Skipped  int        `json:"skipped"`
+}
+
+// JobRunDTO describes the most recent run of a job.
+type JobRunDTO struct {
+	StartedAt  string `json:"started_at"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ToJobsResponseDTO converts job statuses to their DTO.
+func ToJobsResponseDTO(jobs []scheduler.JobStatus) *JobsResponseDTO {
+	dto := &JobsResponseDTO{Jobs: make([]JobStatusDTO, 0, len(jobs))}
+	for _, j := range jobs {
+		js := JobStatusDTO{
+			Name:     j.Name,
+			Schedule: j.Schedule,
+			Running:  j.Running,
+			Runs:     j.Runs,
+			Failures: j.Failures,
+			Skipped:  j.Skipped,
+		}
+		if !j.NextRun.IsZero() {
+			js.NextRun = j.NextRun.UTC().Format(time.RFC3339)
+		}
+		if j.LastRun != nil {
+			js.LastRun = &JobRunDTO{
+				StartedAt:  j.LastRun.StartedAt.UTC().Format(time.RFC3339),
+				DurationMs: j.LastRun.Duration.Milliseconds(),
+			}
+			if j.LastRun.Err != nil {
+				js.LastRun.Error = j.LastRun.Err.Error()
+			}
+		}
+		dto.Jobs = append(dto.Jobs, js)
+	}
+	return dto
+}
+
+// QueueJobsResponseDTO lists persisted queue jobs.
+type QueueJobsResponseDTO struct {
+	Jobs []QueueJobDTO `json:"jobs"`
+}
+
+// QueueJobDTO describes a persisted queue job.
+type QueueJobDTO struct {
+	ID          string          `json:"id"`
+	Kind        string          `json:"kind"`
+	Status      string          `json:"status"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       string          `json:"run_at"`
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   string          `json:"created_at"`
+	UpdatedAt   string          `json:"updated_at"`
+}
+
+// ToQueueJobsResponseDTO converts queue jobs to their DTO.
+func ToQueueJobsResponseDTO(jobs []jobqueue.Job) *QueueJobsResponseDTO {
+	dto := &QueueJobsResponseDTO{Jobs: make([]QueueJobDTO, 0, len(jobs))}
+	for _, j := range jobs {
+		dto.Jobs = append(dto.Jobs, *ToQueueJobDTO(j))
+	}
+	return dto
+}
+
+// ToQueueJobDTO converts a queue job to its DTO.
+func ToQueueJobDTO(j jobqueue.Job) *QueueJobDTO {
+	return &QueueJobDTO{
+		ID:          j.ID,
+		Kind:        j.Kind,
+		Status:      string(j.Status),
+		Payload:     j.Payload,
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		RunAt:       j.RunAt.UTC().Format(time.RFC3339),
+		LastError:   j.LastError,
+		CreatedAt:   j.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:   j.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// ProviderStatsResponseDTO lists provider data quality statistics.
+type ProviderStatsResponseDTO struct {
+	WindowHours int                `json:"window_hours"`
+	Providers   []ProviderStatsDTO `json:"providers"`
+}
+
+// ProviderStatsDTO reports a provider's data quality over the window.
+type ProviderStatsDTO struct {
+	Provider             string  `json:"provider"`
+	Searches             int     `json:"searches"`
+	FlightsReceived      int     `json:"flights_received"`
+	FlightsRejected      int     `json:"flights_rejected"`
+	RejectionRate        float64 `json:"rejection_rate"`
+	FlightsReturned      int     `json:"flights_returned"`
+	PriceAnomalies       int     `json:"price_anomalies"`
+	PriceAnomalyRate     float64 `json:"price_anomaly_rate"`
+	Duplicates           int     `json:"duplicates"`
+	DuplicateRate        float64 `json:"duplicate_rate"`
+	SchemaDriftIncidents int     `json:"schema_drift_incidents"`
+}
+
+// ToProviderStatsResponseDTO converts provider quality statistics to their DTO.
+func ToProviderStatsResponseDTO(stats []quality.Stats, window time.Duration) *ProviderStatsResponseDTO {
+	dto := &ProviderStatsResponseDTO{
+		WindowHours: int(window.Hours()),
+		Providers:   make([]ProviderStatsDTO, 0, len(stats)),
+	}
+	for _, s := range stats {
+		dto.Providers = append(dto.Providers, ProviderStatsDTO{
+			Provider:             s.Provider,
+			Searches:             s.Searches,
+			FlightsReceived:      s.Received,
+			FlightsRejected:      s.Rejected,
+			RejectionRate:        s.RejectionRate(),
+			FlightsReturned:      s.Flights,
+			PriceAnomalies:       s.PriceAnomalies,
+			PriceAnomalyRate:     s.PriceAnomalyRate(),
+			Duplicates:           s.Duplicates,
+			DuplicateRate:        s.DuplicateRate(),
+			SchemaDriftIncidents: s.SchemaDriftIncidents,
+		})
+	}
+	return dto
+}
+
+// ProviderLatencyResponseDTO lists provider latency heatmaps. Every provider
+// shares the same columns, starting at Start and spanning StepSeconds each.
+type ProviderLatencyResponseDTO struct {
+	WindowSeconds int        `json:"window_seconds"`
+	StepSeconds   int        `json:"step_seconds"`
+	Start         *time.Time `json:"start,omitempty"`
+
+	// BucketUpperBoundsMs are the inclusive upper bounds of the latency
+	// buckets; counts hold one more, unbounded bucket for slower searches
+	BucketUpperBoundsMs []int64              `json:"bucket_upper_bounds_ms"`
+	Providers           []ProviderLatencyDTO `json:"providers"`
+}
+
+// ProviderLatencyDTO is the latency heatmap of a provider.
+type ProviderLatencyDTO struct {
+	Provider string `json:"provider"`
+	Searches int    `json:"searches"`
+
+	// Counts holds, for each column (oldest first), the number of searches
+	// in each latency bucket
+	Counts [][]int `json:"counts"`
+}
+
+// ToProviderLatencyResponseDTO converts provider latency heatmaps to their DTO.
+func ToProviderLatencyResponseDTO(heatmaps []latency.Heatmap, window time.Duration) *ProviderLatencyResponseDTO {
+	dto := &ProviderLatencyResponseDTO{
+		WindowSeconds:       int(window.Seconds()),
+		BucketUpperBoundsMs: make([]int64, 0, len(latency.HeatmapBuckets)),
+		Providers:           make([]ProviderLatencyDTO, 0, len(heatmaps)),
+	}
+	for _, bound := range latency.HeatmapBuckets {
+		dto.BucketUpperBoundsMs = append(dto.BucketUpperBoundsMs, bound.Milliseconds())
+	}
+	for i, h := range heatmaps {
+		if i == 0 {
+			start := h.Start
+			dto.Start = &start
+			dto.StepSeconds = int(h.Step.Seconds())
+		}
+		dto.Providers = append(dto.Providers, ProviderLatencyDTO{
+			Provider: h.Provider,
+			Searches: h.Searches,
+			Counts:   h.Counts,
+		})
+	}
+	return dto
+}
+
+// ConfigResponseDTO lists the configuration options with their effective values.
+type ConfigResponseDTO struct {
+	Options []ConfigOptionDTO `json:"options"`
+}
+
+// ConfigOptionDTO describes a configuration option and its effective value.
+type ConfigOptionDTO struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Default     string `json:"default"`
+	Description string `json:"description"`
+	Secret      bool   `json:"secret"`
+	Value       string `json:"value"`
+}
+
+// ToConfigResponseDTO converts configuration settings to their DTO.
+func ToConfigResponseDTO(settings []configdoc.Setting) *ConfigResponseDTO {
+	dto := &ConfigResponseDTO{Options: make([]ConfigOptionDTO, 0, len(settings))}
+	for _, s := range settings {
+		dto.Options = append(dto.Options, ConfigOptionDTO{
+			Name:        s.Name,
+			Type:        s.Type,
+			Default:     s.Default,
+			Description: s.Description,
+			Secret:      s.Secret,
+			Value:       s.Value,
+		})
+	}
+	return dto
+}
+
+// AuditResponseDTO lists recorded admin mutations.
+type AuditResponseDTO struct {
+	Entries []AuditEntryDTO `json:"entries"`
+}
+
+// AuditEntryDTO is a recorded admin mutation.
+type AuditEntryDTO struct {
+	ID       string          `json:"id"`
+	At       string          `json:"at"`
+	Actor    string          `json:"actor"`
+	Action   string          `json:"action"`
+	Resource string          `json:"resource"`
+	Before   json.RawMessage `json:"before,omitempty"`
+	After    json.RawMessage `json:"after,omitempty"`
+}
+
+// ToAuditResponseDTO converts audit entries to their DTO.
+func ToAuditResponseDTO(entries []audit.Entry) *AuditResponseDTO {
+	dto := &AuditResponseDTO{Entries: make([]AuditEntryDTO, 0, len(entries))}
+	for _, e := range entries {
+		dto.Entries = append(dto.Entries, AuditEntryDTO{
+			ID:       e.ID,
+			At:       e.At.UTC().Format(time.RFC3339),
+			Actor:    e.Actor,
+			Action:   e.Action,
+			Resource: e.Resource,
+			Before:   e.Before,
+			After:    e.After,
+		})
+	}
+	return dto
+}
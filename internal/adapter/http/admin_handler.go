@@ -0,0 +1,427 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/audit"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/configdoc"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/jobqueue"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/latency"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/quality"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/scheduler"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// DebugQueryParam enables debug mode on admin endpoints that support it.
+const DebugQueryParam = "debug"
+
+// DebugRequested reports whether the request enables debug mode. Invalid
+// values report false; the handler rejects them.
+func DebugRequested(c echo.Context) bool {
+	debug, _ := strconv.ParseBool(c.QueryParam(DebugQueryParam))
+	return debug
+}
+
+// StatusQueryParam filters listings by status.
+const StatusQueryParam = "status"
+
+// JobLister reports the status of scheduled background jobs.
+type JobLister interface {
+	Jobs() []scheduler.JobStatus
+}
+
+// JobQueue exposes the persistent job queue to operators.
+type JobQueue interface {
+	Get(id string) (jobqueue.Job, error)
+	List(status jobqueue.Status) []jobqueue.Job
+	Requeue(ctx context.Context, id string) (jobqueue.Job, error)
+}
+
+// ProviderQuality reports provider data quality statistics.
+type ProviderQuality interface {
+	Stats() []quality.Stats
+	Window() time.Duration
+}
+
+// LatencyHeatmaps reports provider latency distributions over time.
+type LatencyHeatmaps interface {
+	Heatmaps(window, step time.Duration) ([]latency.Heatmap, error)
+	HeatmapRetention() time.Duration
+}
+
+// Query parameters of the provider latency endpoint.
+const (
+	WindowQueryParam = "window"
+	StepQueryParam   = "step"
+)
+
+// defaultLatencyWindow is the window of provider latency heatmaps without ?window=.
+const defaultLatencyWindow = time.Hour
+
+// AuditTrail records admin mutations and lists them.
+type AuditTrail interface {
+	Record(m audit.Mutation) (audit.Entry, error)
+	List(q audit.Query) []audit.Entry
+}
+
+// Audited admin actions.
+const (
+	ActionRequeueJob = "queue.job.requeue"
+)
+
+// Query parameters of the audit trail endpoint.
+const (
+	ActorQueryParam  = "actor"
+	ActionQueryParam = "action"
+	LimitQueryParam  = "limit"
+)
+
+// MaxAuditLimit is the largest accepted ?limit= of the audit trail endpoint.
+const MaxAuditLimit = 1000
+
+// ConfigReporter reports the effective configuration.
+type ConfigReporter interface {
+	// Settings returns every option with its value, credentials redacted
+	Settings() []configdoc.Setting
+}
+
+// BackupSnapshot is a consistent copy of the embedded store, discarded once
+// closed.
+type BackupSnapshot interface {
+	io.ReadCloser
+
+	// Size returns the length of the copy in bytes
+	Size() int64
+
+	// SHA256 returns the hex-encoded SHA-256 checksum of the copy
+	SHA256() string
+}
+
+// BackupChecksumHeader carries the hex-encoded SHA-256 checksum of a backup.
+const BackupChecksumHeader = "X-Backup-SHA256"
+
+// AdminHandler handles HTTP requests for internal admin endpoints.
+// Admin endpoints are served under /admin/v1 and are not part of the public API.
+type AdminHandler struct {
+	replay  usecase.ReplayUseCase
+	jobs    JobLister
+	queue   JobQueue
+	quality ProviderQuality
+	latency LatencyHeatmaps
+	config  ConfigReporter
+	audit   AuditTrail
+	backup  func(ctx context.Context) (BackupSnapshot, error)
+
+	simulation usecase.SimulationUseCase
+}
+
+// AdminHandlerOption configures an AdminHandler.
+type AdminHandlerOption func(*AdminHandler)
+
+// WithConfigReporter serves the effective configuration reported by r.
+// Without it, the configuration endpoint lists no options.
+func WithConfigReporter(r ConfigReporter) AdminHandlerOption {
+	return func(h *AdminHandler) {
+		h.config = r
+	}
+}
+
+// WithLatencyHeatmaps serves the provider latency heatmaps reported by l.
+// Without it, the provider latency endpoint lists no providers.
+func WithLatencyHeatmaps(l LatencyHeatmaps) AdminHandlerOption {
+	return func(h *AdminHandler) {
+		h.latency = l
+	}
+}
+
+// WithAuditTrail records the admin mutations in trail and serves it.
+// Without it, mutations are not recorded and the audit endpoint lists none.
+func WithAuditTrail(trail AuditTrail) AdminHandlerOption {
+	return func(h *AdminHandler) {
+		h.audit = trail
+	}
+}
+
+// WithBackup serves the backups of the embedded store taken by backup.
+// Without it, the backup endpoint reports that no embedded store is
+// configured.
+func WithBackup(backup func(ctx context.Context) (BackupSnapshot, error)) AdminHandlerOption {
+	return func(h *AdminHandler) {
+		h.backup = backup
+	}
+}
+
+// WithSimulation serves simulations of hypothetical settings run by s.
+// Without it, the simulation endpoint reports that simulation is not
+// configured.
+func WithSimulation(s usecase.SimulationUseCase) AdminHandlerOption {
+	return func(h *AdminHandler) {
+		h.simulation = s
+	}
+}
+
+// NewAdminHandler creates a new AdminHandler.
+// jobs, queue and quality may be nil when the corresponding subsystem is not running.
+func NewAdminHandler(replay usecase.ReplayUseCase, jobs JobLister, queue JobQueue, quality ProviderQuality, opts ...AdminHandlerOption) *AdminHandler {
+	h := &AdminHandler{
+		replay:  replay,
+		jobs:    jobs,
+		queue:   queue,
+		quality: quality,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ReplaySearch handles POST /admin/v1/searches/:id/replay
+// It re-executes a stored search with its original criteria and returns the
+// stored and replayed results side by side, with a diff.
+// With ?debug=true, each provider is also queried directly and the flights
+// removed by the original filters are reported.
+func (h *AdminHandler) ReplaySearch(c echo.Context) error {
+	var opts usecase.ReplayOptions
+	if raw := c.QueryParam(DebugQueryParam); raw != "" {
+		debug, err := strconv.ParseBool(raw)
+		if err != nil {
+			return response.ValidationError(c, map[string]string{
+				DebugQueryParam: "must be a boolean",
+			})
+		}
+		opts.Debug = debug
+	}
+
+	result, err := h.replay.Replay(c.Request().Context(), c.Param("id"), opts)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return response.OK(c, ToReplayResponseDTO(result))
+}
+
+// Simulate handles POST /admin/v1/simulate
+// It replays the recorded provider latencies and result counts of the body
+// through the search orchestrator, with the running settings and with the
+// hypothetical timeouts and ranking weights of the body, and reports the
+// provider success rates and ranking changes of both. It answers once the
+// slowest sample finished, at most after the longer global timeout.
+func (h *AdminHandler) Simulate(c echo.Context) error {
+	if h.simulation == nil {
+		return response.NotFound(c, response.MsgSimulationDisabled)
+	}
+
+	var req SimulateRequest
+	if err := c.Bind(&req); err != nil {
+		return response.InvalidRequestBody(c)
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, validationErrorDetail(err))
+	}
+
+	result, err := h.simulation.Simulate(c.Request().Context(), req.ToSimulationSamples(), req.ToSimulationSettings())
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+	return response.OK(c, ToSimulationResponseDTO(result))
+}
+
+// ListJobs handles GET /admin/v1/jobs
+// It returns the schedule, last run, and run counters of every background job.
+func (h *AdminHandler) ListJobs(c echo.Context) error {
+	var jobs []scheduler.JobStatus
+	if h.jobs != nil {
+		jobs = h.jobs.Jobs()
+	}
+	return response.OK(c, ToJobsResponseDTO(jobs))
+}
+
+// ListQueueJobs handles GET /admin/v1/queue/jobs
+// It returns the persisted queue jobs, optionally filtered with
+// ?status=pending|running|done|dead (dead = dead letter).
+func (h *AdminHandler) ListQueueJobs(c echo.Context) error {
+	status := jobqueue.Status(c.QueryParam(StatusQueryParam))
+	if status != "" && !status.IsValid() {
+		return response.ValidationError(c, map[string]string{
+			StatusQueryParam: "must be one of: pending, running, done, dead",
+		})
+	}
+
+	var jobs []jobqueue.Job
+	if h.queue != nil {
+		jobs = h.queue.List(status)
+	}
+	return response.OK(c, ToQueueJobsResponseDTO(jobs))
+}
+
+// RequeueJob handles POST /admin/v1/queue/jobs/:id/requeue
+// It moves a dead-letter job back to pending with a fresh set of attempts.
+func (h *AdminHandler) RequeueJob(c echo.Context) error {
+	if h.queue == nil {
+		return response.NotFound(c, response.MsgJobNotFound)
+	}
+
+	before, _ := h.queue.Get(c.Param("id"))
+	job, err := h.queue.Requeue(c.Request().Context(), c.Param("id"))
+	switch {
+	case errors.Is(err, jobqueue.ErrJobNotFound):
+		return response.NotFound(c, response.MsgJobNotFound)
+	case errors.Is(err, jobqueue.ErrNotDead):
+		return response.Conflict(c, response.MsgJobNotDead)
+	case err != nil:
+		return response.InternalServerError(c)
+	}
+	if err := h.recordMutation(c, ActionRequeueJob, "queue/jobs/"+job.ID, auditedJob(before), auditedJob(job)); err != nil {
+		return response.InternalServerError(c)
+	}
+	return response.OK(c, ToQueueJobDTO(job))
+}
+
+// auditedJob returns the state of a job recorded in the audit trail. Payloads
+// are left out: they may be encrypted at rest, the trail is not.
+func auditedJob(j jobqueue.Job) *QueueJobDTO {
+	dto := ToQueueJobDTO(j)
+	dto.Payload = nil
+	return dto
+}
+
+// recordMutation records a mutation by the request's caller in the audit
+// trail, if any. A mutation that cannot be recorded fails the request, even
+// though it was applied, so unaudited changes do not go unnoticed.
+func (h *AdminHandler) recordMutation(c echo.Context, action, resource string, before, after any) error {
+	if h.audit == nil {
+		return nil
+	}
+	actor := rbac.Anonymous
+	if p, ok := rbac.FromContext(c.Request().Context()); ok {
+		actor = p.Name
+	}
+	_, err := h.audit.Record(audit.Mutation{
+		Actor:    actor,
+		Action:   action,
+		Resource: resource,
+		Before:   before,
+		After:    after,
+	})
+	return err
+}
+
+// ListAudit handles GET /admin/v1/audit
+// It returns the recorded admin mutations, newest first, optionally filtered
+// with ?actor= and ?action=, up to ?limit= entries (default 100).
+func (h *AdminHandler) ListAudit(c echo.Context) error {
+	q := audit.Query{
+		Actor:  c.QueryParam(ActorQueryParam),
+		Action: c.QueryParam(ActionQueryParam),
+	}
+	if raw := c.QueryParam(LimitQueryParam); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > MaxAuditLimit {
+			return response.ValidationError(c, map[string]string{
+				LimitQueryParam: fmt.Sprintf("must be between 1 and %d", MaxAuditLimit),
+			})
+		}
+		q.Limit = limit
+	}
+
+	var entries []audit.Entry
+	if h.audit != nil {
+		entries = h.audit.List(q)
+	}
+	return response.OK(c, ToAuditResponseDTO(entries))
+}
+
+// ProviderStats handles GET /admin/v1/stats/providers
+// It returns each provider's data quality over the tracking window:
+// normalization rejections, price anomalies, duplicates and schema drift.
+func (h *AdminHandler) ProviderStats(c echo.Context) error {
+	if h.quality == nil {
+		return response.OK(c, ToProviderStatsResponseDTO(nil, 0))
+	}
+	return response.OK(c, ToProviderStatsResponseDTO(h.quality.Stats(), h.quality.Window()))
+}
+
+// ProviderLatency handles GET /admin/v1/providers/latency
+// It returns each provider's latency distribution over ?window= (default 1h),
+// bucketed by latency and by ?step= (default: up to 60 columns), to render
+// as heatmaps.
+func (h *AdminHandler) ProviderLatency(c echo.Context) error {
+	window := defaultLatencyWindow
+	if raw := c.QueryParam(WindowQueryParam); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return response.ValidationError(c, map[string]string{
+				WindowQueryParam: "must be a duration such as 1h",
+			})
+		}
+		window = d
+	}
+	var step time.Duration
+	if raw := c.QueryParam(StepQueryParam); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			return response.ValidationError(c, map[string]string{
+				StepQueryParam: "must be a positive duration such as 5m",
+			})
+		}
+		step = d
+	}
+
+	if h.latency == nil {
+		return response.OK(c, ToProviderLatencyResponseDTO(nil, window))
+	}
+	heatmaps, err := h.latency.Heatmaps(window, step)
+	if errors.Is(err, latency.ErrInvalidHeatmapRange) {
+		return response.ValidationError(c, map[string]string{
+			WindowQueryParam: fmt.Sprintf("must be positive and at most %s", h.latency.HeatmapRetention()),
+		})
+	}
+	if err != nil {
+		return response.InternalServerError(c)
+	}
+	return response.OK(c, ToProviderLatencyResponseDTO(heatmaps, window))
+}
+
+// Config handles GET /admin/v1/config
+// It returns every configuration option with its type, default, description
+// and effective value. Credentials are redacted.
+func (h *AdminHandler) Config(c echo.Context) error {
+	if h.config == nil {
+		return response.OK(c, ToConfigResponseDTO(nil))
+	}
+	return response.OK(c, ToConfigResponseDTO(h.config.Settings()))
+}
+
+// Backup handles GET /admin/v1/backup
+// It streams a consistent snapshot of the embedded store as a file download,
+// taken without blocking searches, with its SHA-256 checksum in the
+// X-Backup-SHA256 header. The restore command of the server verifies the
+// checksum and puts the snapshot back in place.
+func (h *AdminHandler) Backup(c echo.Context) error {
+	if h.backup == nil {
+		return response.NotFound(c, response.MsgBackupUnavailable)
+	}
+
+	backup, err := h.backup(c.Request().Context())
+	if err != nil {
+		return response.InternalServerError(c)
+	}
+	defer backup.Close()
+
+	filename := fmt.Sprintf("flight-search-%s.db", time.Now().UTC().Format("20060102T150405Z"))
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(backup.Size(), 10))
+	header.Set(BackupChecksumHeader, backup.SHA256())
+	return c.Stream(http.StatusOK, "application/vnd.sqlite3", backup)
+}
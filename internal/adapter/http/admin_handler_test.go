@@ -0,0 +1,690 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/audit"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/configdoc"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/jobqueue"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/latency"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/quality"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/scheduler"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// mockReplayUseCase is a mock implementation of ReplayUseCase for testing.
+type mockReplayUseCase struct {
+	replayFunc func(ctx context.Context, id string, opts usecase.ReplayOptions) (*usecase.ReplayResult, error)
+}
+
+func (m *mockReplayUseCase) Replay(ctx context.Context, id string, opts usecase.ReplayOptions) (*usecase.ReplayResult, error) {
+	return m.replayFunc(ctx, id, opts)
+}
+
+// mockJobLister is a mock implementation of JobLister for testing.
+type mockJobLister []scheduler.JobStatus
+
+func (m mockJobLister) Jobs() []scheduler.JobStatus {
+	return m
+}
+
+// mockJobQueue is a mock implementation of JobQueue for testing.
+type mockJobQueue struct {
+	getFunc     func(id string) (jobqueue.Job, error)
+	listFunc    func(status jobqueue.Status) []jobqueue.Job
+	requeueFunc func(ctx context.Context, id string) (jobqueue.Job, error)
+}
+
+func (m *mockJobQueue) Get(id string) (jobqueue.Job, error) {
+	if m.getFunc == nil {
+		return jobqueue.Job{}, jobqueue.ErrJobNotFound
+	}
+	return m.getFunc(id)
+}
+
+func (m *mockJobQueue) List(status jobqueue.Status) []jobqueue.Job {
+	return m.listFunc(status)
+}
+
+func (m *mockJobQueue) Requeue(ctx context.Context, id string) (jobqueue.Job, error) {
+	return m.requeueFunc(ctx, id)
+}
+
+// setupAdminHandler creates a test Echo instance with admin routes.
+func setupAdminHandler(uc usecase.ReplayUseCase) *echo.Echo {
+	return setupAdminHandlerWith(NewAdminHandler(uc, nil, nil, nil))
+}
+
+// setupAdminHandlerWith creates a test Echo instance serving the given admin handler.
+func setupAdminHandlerWith(h *AdminHandler) *echo.Echo {
+	e := echo.New()
+	RegisterAdminRoutes(e, h)
+	return e
+}
+
+func TestReplaySearch_Success(t *testing.T) {
+	stored := domain.Flight{ID: "GA400", Provider: "garuda_indonesia", FlightNumber: "GA400", Price: domain.PriceInfo{Amount: 1000000}}
+	replayed := stored
+	replayed.Price.Amount = 1200000
+
+	var gotOpts usecase.ReplayOptions
+	uc := &mockReplayUseCase{
+		replayFunc: func(_ context.Context, id string, opts usecase.ReplayOptions) (*usecase.ReplayResult, error) {
+			gotOpts = opts
+			assert.Equal(t, "search-1", id)
+			return &usecase.ReplayResult{
+				Record: &domain.SearchRecord{
+					ID:        id,
+					CreatedAt: time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC),
+					Response:  domain.SearchResponse{Flights: []domain.Flight{stored}},
+				},
+				Replayed: &domain.SearchResponse{Flights: []domain.Flight{replayed}},
+				Diff:     usecase.DiffFlights([]domain.Flight{stored}, []domain.Flight{replayed}),
+				Providers: []usecase.ProviderDebug{
+					{Provider: "garuda_indonesia", FlightsReturned: 2, FilteredOut: []string{"GA410"}, Duration: 5 * time.Millisecond},
+					{Provider: "airasia", Error: errors.New("timeout")},
+				},
+			}, nil
+		},
+	}
+
+	rec := makeRequest(setupAdminHandler(uc), http.MethodPost, "/admin/v1/searches/search-1/replay?debug=true", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, gotOpts.Debug)
+
+	var dto ReplayResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+	assert.Equal(t, "search-1", dto.SearchID)
+	assert.Equal(t, "2025-12-01T10:00:00Z", dto.SearchedAt)
+	assert.Len(t, dto.Original.Flights, 1)
+	assert.Len(t, dto.Replayed.Flights, 1)
+	assert.Empty(t, dto.Diff.Added)
+	assert.Empty(t, dto.Diff.Removed)
+	require.Len(t, dto.Diff.Changed, 1)
+	assert.Equal(t, FieldChangeDTO{Original: "1000000", Replayed: "1200000"}, dto.Diff.Changed[0].Fields["price"])
+
+	require.NotNil(t, dto.Debug)
+	require.Len(t, dto.Debug.Providers, 2)
+	assert.Equal(t, []string{"GA410"}, dto.Debug.Providers[0].FilteredOutIDs)
+	assert.Equal(t, int64(5), dto.Debug.Providers[0].DurationMs)
+	assert.Equal(t, "timeout", dto.Debug.Providers[1].Error)
+}
+
+func TestReplaySearch_NotFound(t *testing.T) {
+	uc := &mockReplayUseCase{
+		replayFunc: func(context.Context, string, usecase.ReplayOptions) (*usecase.ReplayResult, error) {
+			return nil, domain.ErrSearchNotFound
+		},
+	}
+
+	rec := makeRequest(setupAdminHandler(uc), http.MethodPost, "/admin/v1/searches/missing/replay", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var errResp response.ErrorDetail
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errResp))
+	assert.Equal(t, response.CodeNotFound, errResp.Code)
+}
+
+func TestReplaySearch_InvalidDebugFlag(t *testing.T) {
+	uc := &mockReplayUseCase{
+		replayFunc: func(context.Context, string, usecase.ReplayOptions) (*usecase.ReplayResult, error) {
+			t.Fatal("replay should not be called")
+			return nil, nil
+		},
+	}
+
+	rec := makeRequest(setupAdminHandler(uc), http.MethodPost, "/admin/v1/searches/search-1/replay?debug=maybe", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestReplaySearch_AllProvidersFailed(t *testing.T) {
+	uc := &mockReplayUseCase{
+		replayFunc: func(context.Context, string, usecase.ReplayOptions) (*usecase.ReplayResult, error) {
+			return nil, domain.ErrAllProvidersFailed
+		},
+	}
+
+	rec := makeRequest(setupAdminHandler(uc), http.MethodPost, "/admin/v1/searches/search-1/replay", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestListJobs(t *testing.T) {
+	started := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	jobs := mockJobLister{
+		{
+			Name:     "mock_rotation",
+			Schedule: "@hourly",
+			NextRun:  started.Add(time.Hour),
+			LastRun:  &scheduler.RunResult{StartedAt: started, Duration: 120 * time.Millisecond, Err: errors.New("read failed")},
+			Runs:     3,
+			Failures: 1,
+			Skipped:  2,
+		},
+		{Name: "pending", Schedule: "0 3 * * *"},
+	}
+
+	rec := makeRequest(setupAdminHandlerWith(NewAdminHandler(nil, jobs, nil, nil)), http.MethodGet, "/admin/v1/jobs", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var dto JobsResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+	require.Len(t, dto.Jobs, 2)
+	assert.Equal(t, JobStatusDTO{
+		Name:     "mock_rotation",
+		Schedule: "@hourly",
+		NextRun:  "2025-12-01T11:00:00Z",
+		LastRun:  &JobRunDTO{StartedAt: "2025-12-01T10:00:00Z", DurationMs: 120, Error: "read failed"},
+		Runs:     3,
+		Failures: 1,
+		Skipped:  2,
+	}, dto.Jobs[0])
+	assert.Nil(t, dto.Jobs[1].LastRun)
+	assert.Empty(t, dto.Jobs[1].NextRun)
+}
+
+func TestListJobs_NoScheduler(t *testing.T) {
+	rec := makeRequest(setupAdminHandler(nil), http.MethodGet, "/admin/v1/jobs", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"jobs":[]}`, rec.Body.String())
+}
+
+func TestListQueueJobs(t *testing.T) {
+	created := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	var gotStatus jobqueue.Status
+	queue := &mockJobQueue{
+		listFunc: func(status jobqueue.Status) []jobqueue.Job {
+			gotStatus = status
+			return []jobqueue.Job{{
+				ID: "job-1", Kind: "price_alert", Status: jobqueue.StatusDead, Payload: json.RawMessage(`{"alertId":"a1"}`),
+				Attempts: 5, MaxAttempts: 5, RunAt: created, LastError: "smtp down", CreatedAt: created, UpdatedAt: created.Add(time.Minute),
+			}}
+		},
+	}
+
+	rec := makeRequest(setupAdminHandlerWith(NewAdminHandler(nil, nil, queue, nil)), http.MethodGet, "/admin/v1/queue/jobs?status=dead", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, jobqueue.StatusDead, gotStatus)
+
+	var dto QueueJobsResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+	require.Len(t, dto.Jobs, 1)
+	assert.Equal(t, "dead", dto.Jobs[0].Status)
+	assert.Equal(t, "smtp down", dto.Jobs[0].LastError)
+	assert.Equal(t, "2025-12-01T10:01:00Z", dto.Jobs[0].UpdatedAt)
+	assert.JSONEq(t, `{"alertId":"a1"}`, string(dto.Jobs[0].Payload))
+}
+
+func TestListQueueJobs_InvalidStatus(t *testing.T) {
+	rec := makeRequest(setupAdminHandler(nil), http.MethodGet, "/admin/v1/queue/jobs?status=failed", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestListQueueJobs_NoQueue(t *testing.T) {
+	rec := makeRequest(setupAdminHandler(nil), http.MethodGet, "/admin/v1/queue/jobs", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"jobs":[]}`, rec.Body.String())
+}
+
+func TestRequeueJob(t *testing.T) {
+	queue := &mockJobQueue{
+		requeueFunc: func(_ context.Context, id string) (jobqueue.Job, error) {
+			switch id {
+			case "dead":
+				return jobqueue.Job{ID: id, Status: jobqueue.StatusPending}, nil
+			case "done":
+				return jobqueue.Job{}, jobqueue.ErrNotDead
+			case "broken":
+				return jobqueue.Job{}, errors.New("disk full")
+			default:
+				return jobqueue.Job{}, jobqueue.ErrJobNotFound
+			}
+		},
+	}
+	e := setupAdminHandlerWith(NewAdminHandler(nil, nil, queue, nil))
+
+	tests := []struct {
+		id         string
+		wantStatus int
+	}{
+		{"dead", http.StatusOK},
+		{"done", http.StatusConflict},
+		{"missing", http.StatusNotFound},
+		{"broken", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			rec := makeRequest(e, http.MethodPost, "/admin/v1/queue/jobs/"+tt.id+"/requeue", nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+
+	t.Run("no queue", func(t *testing.T) {
+		rec := makeRequest(setupAdminHandler(nil), http.MethodPost, "/admin/v1/queue/jobs/dead/requeue", nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+// failingAuditTrail is an audit trail that cannot record.
+type failingAuditTrail struct{ *audit.Trail }
+
+func (failingAuditTrail) Record(audit.Mutation) (audit.Entry, error) {
+	return audit.Entry{}, errors.New("disk full")
+}
+
+func TestRequeueJob_Audited(t *testing.T) {
+	runAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	queue := &mockJobQueue{
+		getFunc: func(id string) (jobqueue.Job, error) {
+			return jobqueue.Job{ID: id, Kind: "export", Status: jobqueue.StatusDead, Attempts: 5, Payload: []byte(`{"secret":1}`), RunAt: runAt}, nil
+		},
+		requeueFunc: func(_ context.Context, id string) (jobqueue.Job, error) {
+			return jobqueue.Job{ID: id, Kind: "export", Status: jobqueue.StatusPending, Payload: []byte(`{"secret":1}`), RunAt: runAt}, nil
+		},
+	}
+	trail := audit.New()
+	h := NewAdminHandler(nil, nil, queue, nil, WithAuditTrail(trail))
+	e := echo.New()
+	RegisterAdminRoutes(e, h, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := rbac.WithPrincipal(c.Request().Context(), rbac.Principal{Name: "ops-console"})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+
+	rec := makeRequest(e, http.MethodPost, "/admin/v1/queue/jobs/42/requeue", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = makeRequest(e, http.MethodGet, "/admin/v1/audit", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body AuditResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Entries, 1)
+	entry := body.Entries[0]
+	assert.Equal(t, "ops-console", entry.Actor)
+	assert.Equal(t, ActionRequeueJob, entry.Action)
+	assert.Equal(t, "queue/jobs/42", entry.Resource)
+	assert.Contains(t, string(entry.Before), `"status":"dead"`)
+	assert.Contains(t, string(entry.After), `"status":"pending"`)
+	assert.NotContains(t, string(entry.Before)+string(entry.After), "secret", "payloads are not recorded")
+
+	t.Run("unrecorded mutation", func(t *testing.T) {
+		h := NewAdminHandler(nil, nil, queue, nil, WithAuditTrail(failingAuditTrail{audit.New()}))
+		rec := makeRequest(setupAdminHandlerWith(h), http.MethodPost, "/admin/v1/queue/jobs/42/requeue", nil)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestListAudit(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	trail := audit.New(audit.WithClock(clock))
+	_, err := trail.Record(audit.Mutation{Actor: "ops-console", Action: ActionRequeueJob, Resource: "queue/jobs/1", After: map[string]string{"status": "pending"}})
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+	_, err = trail.Record(audit.Mutation{Actor: "deploy-bot", Action: ActionRequeueJob, Resource: "queue/jobs/2"})
+	require.NoError(t, err)
+	e := setupAdminHandlerWith(NewAdminHandler(nil, nil, nil, nil, WithAuditTrail(trail)))
+
+	rec := makeRequest(e, http.MethodGet, "/admin/v1/audit?actor=ops-console", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body AuditResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Entries, 1)
+	assert.Equal(t, "2025-12-01T10:00:00Z", body.Entries[0].At)
+	assert.JSONEq(t, `{"status":"pending"}`, string(body.Entries[0].After))
+	assert.Nil(t, body.Entries[0].Before)
+
+	rec = makeRequest(e, http.MethodGet, "/admin/v1/audit?limit=1", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Entries, 1)
+	assert.Equal(t, "deploy-bot", body.Entries[0].Actor, "newest first")
+
+	for _, limit := range []string{"0", "1001", "all"} {
+		rec = makeRequest(e, http.MethodGet, "/admin/v1/audit?limit="+limit, nil)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, limit)
+	}
+}
+
+func TestListAudit_NoTrail(t *testing.T) {
+	rec := makeRequest(setupAdminHandler(nil), http.MethodGet, "/admin/v1/audit", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"entries":[]}`, rec.Body.String())
+}
+
+// mockProviderQuality is a mock implementation of ProviderQuality for testing.
+type mockProviderQuality []quality.Stats
+
+func (m mockProviderQuality) Stats() []quality.Stats { return m }
+
+func (m mockProviderQuality) Window() time.Duration { return quality.DefaultWindow }
+
+func TestProviderStats(t *testing.T) {
+	stats := mockProviderQuality{{
+		Provider:             "garuda_indonesia",
+		Searches:             10,
+		Received:             40,
+		Rejected:             4,
+		Flights:              36,
+		PriceAnomalies:       9,
+		Duplicates:           3,
+		SchemaDriftIncidents: 1,
+	}}
+
+	rec := makeRequest(setupAdminHandlerWith(NewAdminHandler(nil, nil, nil, stats)), http.MethodGet, "/admin/v1/stats/providers", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"window_hours": 24,
+		"providers": [{
+			"provider": "garuda_indonesia",
+			"searches": 10,
+			"flights_received": 40,
+			"flights_rejected": 4,
+			"rejection_rate": 0.1,
+			"flights_returned": 36,
+			"price_anomalies": 9,
+			"price_anomaly_rate": 0.25,
+			"duplicates": 3,
+			"duplicate_rate": 0.08333333333333333,
+			"schema_drift_incidents": 1
+		}]
+	}`, rec.Body.String())
+}
+
+func TestProviderStats_NoTracker(t *testing.T) {
+	rec := makeRequest(setupAdminHandler(nil), http.MethodGet, "/admin/v1/stats/providers", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"window_hours":0,"providers":[]}`, rec.Body.String())
+}
+
+func TestProviderLatency(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 30, 0, time.UTC))
+	tracker := latency.NewTracker(latency.WithClock(clock), latency.WithHeatmapRetention(2*time.Hour))
+	tracker.Observe("garuda_indonesia", 80*time.Millisecond)
+	clock.Advance(time.Minute)
+	tracker.Observe("garuda_indonesia", 8*time.Second)
+
+	e := setupAdminHandlerWith(NewAdminHandler(nil, nil, nil, nil, WithLatencyHeatmaps(tracker)))
+	rec := makeRequest(e, http.MethodGet, "/admin/v1/providers/latency?window=3m", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"window_seconds": 180,
+		"step_seconds": 60,
+		"start": "2025-12-01T09:59:00Z",
+		"bucket_upper_bounds_ms": [50, 100, 200, 300, 500, 750, 1000, 1500, 2000, 3000, 5000],
+		"providers": [{
+			"provider": "garuda_indonesia",
+			"searches": 2,
+			"counts": [
+				[0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0],
+				[0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0],
+				[0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1]
+			]
+		}]
+	}`, rec.Body.String())
+
+	// Without a window, the last hour is split in one-minute columns
+	rec = makeRequest(e, http.MethodGet, "/admin/v1/providers/latency", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body ProviderLatencyResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, 3600, body.WindowSeconds)
+	assert.Len(t, body.Providers[0].Counts, 60)
+
+	rec = makeRequest(e, http.MethodGet, "/admin/v1/providers/latency?window=1h&step=10m", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, 600, body.StepSeconds)
+	assert.Len(t, body.Providers[0].Counts, 6)
+}
+
+func TestProviderLatency_InvalidRange(t *testing.T) {
+	tracker := latency.NewTracker(latency.WithHeatmapRetention(2 * time.Hour))
+	e := setupAdminHandlerWith(NewAdminHandler(nil, nil, nil, nil, WithLatencyHeatmaps(tracker)))
+
+	tests := []struct {
+		query string
+		field string
+	}{
+		{"window=soon", "window"},
+		{"window=3h", "window"},
+		{"window=-1h", "window"},
+		{"step=0s", "step"},
+		{"step=fast", "step"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := makeRequest(e, http.MethodGet, "/admin/v1/providers/latency?"+tt.query, nil)
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"`+tt.field+`"`)
+		})
+	}
+}
+
+func TestProviderLatency_NoTracker(t *testing.T) {
+	rec := makeRequest(setupAdminHandler(nil), http.MethodGet, "/admin/v1/providers/latency", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"window_seconds": 3600,
+		"step_seconds": 0,
+		"bucket_upper_bounds_ms": [50, 100, 200, 300, 500, 750, 1000, 1500, 2000, 3000, 5000],
+		"providers": []
+	}`, rec.Body.String())
+}
+
+// stubConfig reports fixed configuration settings.
+type stubConfig []configdoc.Setting
+
+func (s stubConfig) Settings() []configdoc.Setting { return s }
+
+func TestConfig(t *testing.T) {
+	settings := stubConfig{
+		{Option: configdoc.Option{Name: "SERVER_PORT", Type: "int", Default: "8080", Description: "The port the HTTP server listens on"}, Value: "9090"},
+		{Option: configdoc.Option{Name: "ADMIN_TOKEN_SECRET", Type: "string", Description: "Signs admin bearer tokens", Secret: true}, Value: configdoc.Redacted},
+	}
+
+	rec := makeRequest(setupAdminHandlerWith(NewAdminHandler(nil, nil, nil, nil, WithConfigReporter(settings))), http.MethodGet, "/admin/v1/config", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"options": [
+			{"name": "SERVER_PORT", "type": "int", "default": "8080", "description": "The port the HTTP server listens on", "secret": false, "value": "9090"},
+			{"name": "ADMIN_TOKEN_SECRET", "type": "string", "default": "", "description": "Signs admin bearer tokens", "secret": true, "value": "[REDACTED]"}
+		]
+	}`, rec.Body.String())
+}
+
+func TestConfig_NoReporter(t *testing.T) {
+	rec := makeRequest(setupAdminHandler(nil), http.MethodGet, "/admin/v1/config", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"options":[]}`, rec.Body.String())
+}
+
+// stubSnapshot is a backup snapshot of fixed content.
+type stubSnapshot struct {
+	*strings.Reader
+	closed bool
+}
+
+func (s *stubSnapshot) Close() error   { s.closed = true; return nil }
+func (s *stubSnapshot) SHA256() string { return "5e1f" }
+
+func TestBackup(t *testing.T) {
+	snapshot := &stubSnapshot{Reader: strings.NewReader("SQLite format 3\x00")}
+	backup := func(ctx context.Context) (BackupSnapshot, error) {
+		return snapshot, nil
+	}
+
+	rec := makeRequest(setupAdminHandlerWith(NewAdminHandler(nil, nil, nil, nil, WithBackup(backup))), http.MethodGet, "/admin/v1/backup", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/vnd.sqlite3", rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "attachment; filename=")
+	assert.Equal(t, "16", rec.Header().Get(echo.HeaderContentLength))
+	assert.Equal(t, "5e1f", rec.Header().Get(BackupChecksumHeader))
+	assert.Equal(t, "SQLite format 3\x00", rec.Body.String())
+	assert.True(t, snapshot.closed)
+}
+
+func TestBackup_Failed(t *testing.T) {
+	backup := func(ctx context.Context) (BackupSnapshot, error) {
+		return nil, errors.New("disk full")
+	}
+
+	rec := makeRequest(setupAdminHandlerWith(NewAdminHandler(nil, nil, nil, nil, WithBackup(backup))), http.MethodGet, "/admin/v1/backup", nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestBackup_NoEmbeddedStore(t *testing.T) {
+	rec := makeRequest(setupAdminHandler(nil), http.MethodGet, "/admin/v1/backup", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// mockSimulationUseCase is a mock implementation of SimulationUseCase for testing.
+type mockSimulationUseCase struct {
+	simulateFunc func(ctx context.Context, samples []usecase.SimulationSample, settings usecase.SimulationSettings) (*usecase.SimulationResult, error)
+}
+
+func (m *mockSimulationUseCase) Simulate(ctx context.Context, samples []usecase.SimulationSample, settings usecase.SimulationSettings) (*usecase.SimulationResult, error) {
+	return m.simulateFunc(ctx, samples, settings)
+}
+
+func TestSimulate(t *testing.T) {
+	cheap := domain.Flight{ID: "GA400", Provider: "garuda_indonesia", FlightNumber: "GA400"}
+	fast := domain.Flight{ID: "JT610", Provider: "lion_air", FlightNumber: "JT610"}
+
+	var gotSamples []usecase.SimulationSample
+	var gotSettings usecase.SimulationSettings
+	uc := &mockSimulationUseCase{
+		simulateFunc: func(_ context.Context, samples []usecase.SimulationSample, settings usecase.SimulationSettings) (*usecase.SimulationResult, error) {
+			gotSamples, gotSettings = samples, settings
+			return &usecase.SimulationResult{
+				Current: usecase.SimulationOutcome{
+					Settings:  usecase.SimulationSettings{GlobalTimeout: 5 * time.Second, ProviderTimeout: 2 * time.Second, Weights: usecase.DefaultRankingWeights()},
+					Searches:  1,
+					Results:   5,
+					Providers: []usecase.ProviderOutcome{{Provider: "lion_air", Queried: 1, Succeeded: 1, Results: 5}},
+				},
+				Simulated: usecase.SimulationOutcome{
+					Settings:       settings,
+					Searches:       1,
+					SearchesFailed: 1,
+					Providers:      []usecase.ProviderOutcome{{Provider: "lion_air", Queried: 1, TimedOut: 1}},
+				},
+				Ranking: []usecase.RankingChange{{SearchID: "search-1", CurrentBest: &cheap, SimulatedBest: &fast, Moved: 2}},
+			}, nil
+		},
+	}
+	h := NewAdminHandler(nil, nil, nil, nil, WithSimulation(uc))
+
+	body := map[string]any{
+		"samples": []map[string]any{{
+			"search_id": "search-1",
+			"providers": []map[string]any{{"provider": "lion_air", "latency_ms": 1800, "results": 5}},
+		}},
+		"settings": map[string]any{
+			"provider_timeout_ms": 1500,
+			"weights":             map[string]any{"price": 1, "duration": 1},
+		},
+	}
+	rec := makeRequest(setupAdminHandlerWith(h), http.MethodPost, "/admin/v1/simulate", body)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, []usecase.SimulationSample{{
+		SearchID:  "search-1",
+		Providers: []usecase.ProviderSample{{Provider: "lion_air", Latency: 1800 * time.Millisecond, Results: 5}},
+	}}, gotSamples)
+	assert.Equal(t, usecase.SimulationSettings{
+		ProviderTimeout: 1500 * time.Millisecond,
+		Weights:         usecase.RankingWeights{Price: 1, Duration: 1},
+	}, gotSettings)
+
+	var dto SimulationResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+	assert.Equal(t, int64(2000), dto.Current.Settings.ProviderTimeoutMs)
+	assert.Equal(t, 1.0, dto.Current.Providers[0].SuccessRate)
+	assert.Equal(t, 1, dto.Simulated.SearchesFailed)
+	assert.Equal(t, 1, dto.Simulated.Providers[0].TimedOut)
+	assert.Zero(t, dto.Simulated.Providers[0].SuccessRate)
+	assert.Equal(t, 1, dto.Ranking.Compared)
+	assert.Equal(t, 1, dto.Ranking.BestChanged)
+	require.Len(t, dto.Ranking.Searches, 1)
+	assert.Equal(t, "GA400", dto.Ranking.Searches[0].CurrentBest.ID)
+	assert.Equal(t, "JT610", dto.Ranking.Searches[0].SimulatedBest.ID)
+	assert.Equal(t, 2, dto.Ranking.Searches[0].Moved)
+}
+
+func TestSimulate_Invalid(t *testing.T) {
+	uc := &mockSimulationUseCase{
+		simulateFunc: func(context.Context, []usecase.SimulationSample, usecase.SimulationSettings) (*usecase.SimulationResult, error) {
+			t.Fatal("simulate should not be called")
+			return nil, nil
+		},
+	}
+	e := setupAdminHandlerWith(NewAdminHandler(nil, nil, nil, nil, WithSimulation(uc)))
+
+	tests := []struct {
+		name  string
+		body  map[string]any
+		field string
+	}{
+		{"no samples", map[string]any{}, "samples"},
+		{"no providers", map[string]any{"samples": []map[string]any{{}}}, "samples[0].providers"},
+		{
+			"negative latency",
+			map[string]any{"samples": []map[string]any{{"providers": []map[string]any{{"provider": "lion_air", "latency_ms": -1}}}}},
+			"samples[0].providers[0].latency_ms",
+		},
+		{
+			"zero weights",
+			map[string]any{
+				"samples":  []map[string]any{{"providers": []map[string]any{{"provider": "lion_air"}}}},
+				"settings": map[string]any{"weights": map[string]any{"price": 0}},
+			},
+			"settings.weights",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := makeRequest(e, http.MethodPost, "/admin/v1/simulate", tt.body)
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var errResp response.ErrorDetail
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errResp))
+			assert.Contains(t, errResp.Details, tt.field)
+		})
+	}
+}
+
+func TestSimulate_SearchNotFound(t *testing.T) {
+	uc := &mockSimulationUseCase{
+		simulateFunc: func(context.Context, []usecase.SimulationSample, usecase.SimulationSettings) (*usecase.SimulationResult, error) {
+			return nil, domain.ErrSearchNotFound
+		},
+	}
+	body := map[string]any{"samples": []map[string]any{{
+		"search_id": "missing",
+		"providers": []map[string]any{{"provider": "lion_air"}},
+	}}}
+
+	rec := makeRequest(setupAdminHandlerWith(NewAdminHandler(nil, nil, nil, nil, WithSimulation(uc))), http.MethodPost, "/admin/v1/simulate", body)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestSimulate_NotConfigured(t *testing.T) {
+	rec := makeRequest(setupAdminHandler(nil), http.MethodPost, "/admin/v1/simulate", map[string]any{})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
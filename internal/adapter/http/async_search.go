@@ -0,0 +1,219 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/metrics"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/jobqueue"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/sanitize"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/webhook"
+)
+
+// Job kinds of asynchronous searches.
+const (
+	// AsyncSearchJob runs an asynchronous search and queues its callback
+	AsyncSearchJob = "async_search"
+
+	// SearchCallbackJob posts the outcome of an asynchronous search to its callback URL
+	SearchCallbackJob = "search_callback"
+)
+
+// DefaultCallbackTimeout bounds each callback delivery attempt.
+const DefaultCallbackTimeout = 10 * time.Second
+
+// AsyncSearches runs searches in the background on the job queue and posts
+// their outcome to the callback URL of the request, signed by a
+// webhook.Signer. Both steps are jobs, so searches interrupted by a restart
+// run again, and rejected callbacks are retried with the queue's backoff
+// until their attempts are exhausted and they are moved to the dead letter.
+// Callback jobs refer to the results in the search history instead of
+// carrying them, so the queue stays small; results evicted from the history
+// before delivery are not delivered.
+type AsyncSearches struct {
+	queue       *jobqueue.Queue
+	useCase     usecase.FlightSearchUseCase
+	store       domain.SearchRecordStore
+	signer      *webhook.Signer
+	client      *http.Client
+	sanitizer   *sanitize.Sanitizer
+	maxAttempts int
+}
+
+// AsyncSearchOption configures AsyncSearches.
+type AsyncSearchOption func(*AsyncSearches)
+
+// WithCallbackClient sends callbacks with client instead of a client timing
+// out after DefaultCallbackTimeout.
+func WithCallbackClient(client *http.Client) AsyncSearchOption {
+	return func(a *AsyncSearches) {
+		a.client = client
+	}
+}
+
+// WithCallbackAttempts sets the number of delivery attempts of each callback
+// (default: the queue's).
+func WithCallbackAttempts(n int) AsyncSearchOption {
+	return func(a *AsyncSearches) {
+		a.maxAttempts = n
+	}
+}
+
+// WithCallbackSanitizer cleans the provider free text of callback results
+// with s instead of the sanitize.DefaultConfig sanitizer.
+func WithCallbackSanitizer(s *sanitize.Sanitizer) AsyncSearchOption {
+	return func(a *AsyncSearches) {
+		a.sanitizer = s
+	}
+}
+
+// NewAsyncSearches creates AsyncSearches searching with uc, which must
+// record searches in store, and registers their job handlers on queue,
+// which must not be started yet.
+func NewAsyncSearches(queue *jobqueue.Queue, uc usecase.FlightSearchUseCase, store domain.SearchRecordStore, signer *webhook.Signer, opts ...AsyncSearchOption) *AsyncSearches {
+	a := &AsyncSearches{
+		queue:     queue,
+		useCase:   uc,
+		store:     store,
+		signer:    signer,
+		client:    &http.Client{Timeout: DefaultCallbackTimeout},
+		sanitizer: sanitize.New(sanitize.DefaultConfig()),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	queue.Handle(AsyncSearchJob, a.runSearch)
+	queue.Handle(SearchCallbackJob, a.deliver)
+	return a
+}
+
+// asyncSearchPayload is the payload of an AsyncSearchJob.
+type asyncSearchPayload struct {
+	SearchID string               `json:"searchId"`
+	Client   string               `json:"client"`
+	Roles    []rbac.Role          `json:"roles,omitempty"`
+	Locale   reference.Locale     `json:"locale,omitempty"`
+	Request  SearchFlightsRequest `json:"request"`
+}
+
+// searchCallbackPayload is the payload of a SearchCallbackJob: the search
+// whose stored results are delivered, shaped as requested, or the error that
+// failed it.
+type searchCallbackPayload struct {
+	SearchID    string                `json:"searchId"`
+	CallbackURL string                `json:"callbackUrl"`
+	Locale      reference.Locale      `json:"locale,omitempty"`
+	Fields      []string              `json:"fields,omitempty"`
+	Error       *response.ErrorDetail `json:"error,omitempty"`
+}
+
+// Submit queues the validated search request req, whose CallbackURL is set,
+// for the caller of ctx, and returns the ID its results will be posted and
+// recorded under. The results are localized in locale.
+func (a *AsyncSearches) Submit(ctx context.Context, req *SearchFlightsRequest, locale reference.Locale) (string, error) {
+	payload := asyncSearchPayload{
+		SearchID: uuid.NewString(),
+		Client:   rbac.Anonymous,
+		Locale:   locale,
+		Request:  *req,
+	}
+	if principal, ok := rbac.FromContext(ctx); ok {
+		payload.Client = principal.Name
+		payload.Roles = principal.Roles
+	}
+	if _, err := a.queue.Enqueue(ctx, AsyncSearchJob, payload); err != nil {
+		return "", fmt.Errorf("queue async search: %w", err)
+	}
+	return payload.SearchID, nil
+}
+
+// runSearch runs an asynchronous search and queues the delivery of its
+// outcome. The callback URL must answer the webhook challenge first, so
+// searches cannot be used to post to endpoints that do not expect them.
+func (a *AsyncSearches) runSearch(ctx context.Context, job jobqueue.Job) error {
+	var payload asyncSearchPayload
+	if err := job.Decode(&payload); err != nil {
+		return fmt.Errorf("decode async search: %w", err)
+	}
+	callbackURL := payload.Request.CallbackURL
+	if err := a.signer.Challenge(ctx, a.client, callbackURL); err != nil {
+		return err
+	}
+
+	// No one waits on the search, so it gets the batch time budget
+	searchCtx := rbac.WithPrincipal(ctx, rbac.Principal{Name: payload.Client, Roles: payload.Roles})
+	searchCtx = usecase.WithRequestClass(searchCtx, domain.RequestClassBatch)
+	opts := ToSearchOptions(&payload.Request)
+	opts.SearchID = payload.SearchID
+	result, err := a.useCase.Search(searchCtx, ToDomainCriteria(&payload.Request), opts)
+	if err != nil && ctx.Err() != nil {
+		// Interrupted by shutdown: the job runs again after the restart
+		return err
+	}
+
+	callback := searchCallbackPayload{
+		SearchID:    payload.SearchID,
+		CallbackURL: callbackURL,
+		Locale:      payload.Locale,
+		Fields:      payload.Request.Fields,
+	}
+	if err != nil {
+		_, callback.Error = domainErrorDetail(err)
+	} else {
+		metrics.ObserveFilterRejections(result.Metadata.FilterRejections)
+	}
+	_, err = a.queue.Enqueue(ctx, SearchCallbackJob, callback, jobqueue.WithMaxAttempts(a.maxAttempts))
+	return err
+}
+
+// deliver posts the outcome of an asynchronous search to its callback URL.
+func (a *AsyncSearches) deliver(ctx context.Context, job jobqueue.Job) error {
+	var payload searchCallbackPayload
+	if err := job.Decode(&payload); err != nil {
+		return fmt.Errorf("decode search callback: %w", err)
+	}
+	callback, err := a.callback(ctx, &payload)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(callback)
+	if err != nil {
+		return fmt.Errorf("encode search callback: %w", err)
+	}
+	return a.signer.Post(ctx, a.client, payload.CallbackURL, body)
+}
+
+// callback returns the callback body reporting the outcome of a search:
+// its stored results, localized, sanitized and limited to the requested
+// fields like those of synchronous searches, or the error that failed it.
+func (a *AsyncSearches) callback(ctx context.Context, payload *searchCallbackPayload) (*SearchCallbackDTO, error) {
+	if payload.Error != nil {
+		return &SearchCallbackDTO{SearchID: payload.SearchID, Status: AsyncSearchFailed, Error: payload.Error}, nil
+	}
+	record, err := a.store.Get(ctx, payload.SearchID)
+	if err != nil {
+		return nil, fmt.Errorf("load search results: %w", err)
+	}
+
+	dto := ToSearchResponseDTOLocalized(&record.Response, payload.Locale)
+	SanitizeSearchResponseDTO(dto, a.sanitizer)
+	callback := &SearchCallbackDTO{SearchID: payload.SearchID, Status: AsyncSearchCompleted, Results: dto}
+	if len(payload.Fields) > 0 {
+		projected, err := ProjectSearchResponse(dto, payload.Fields)
+		if err != nil {
+			return nil, err
+		}
+		callback.Results = projected
+	}
+	return callback, nil
+}
@@ -0,0 +1,33 @@
+package http
+
+import "github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+
+// Statuses of asynchronous searches.
+const (
+	// AsyncSearchAccepted is the status of a search queued to run in the background
+	AsyncSearchAccepted = "accepted"
+
+	// AsyncSearchCompleted is the status of a search whose results are delivered
+	AsyncSearchCompleted = "completed"
+
+	// AsyncSearchFailed is the status of a search that failed, with its error
+	AsyncSearchFailed = "failed"
+)
+
+// AsyncSearchAcceptedDTO answers a search request with a callbackUrl: the
+// search runs in the background and its results are posted to the callback
+// under this search ID.
+type AsyncSearchAcceptedDTO struct {
+	SearchID string `json:"search_id" example:"0f8fad5b-d9cb-469f-a165-70867728950e"`
+	Status   string `json:"status" example:"accepted"`
+}
+
+// SearchCallbackDTO is the body posted to the callback URL of an
+// asynchronous search: its results, shaped like a search response, or the
+// error that failed it, shaped like an error response.
+type SearchCallbackDTO struct {
+	SearchID string                `json:"search_id"`
+	Status   string                `json:"status"`
+	Results  any                   `json:"results,omitempty"`
+	Error    *response.ErrorDetail `json:"error,omitempty"`
+}
@@ -0,0 +1,287 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/store/memory"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/jobqueue"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/webhook"
+)
+
+const testCallbackSecret = "0123456789abcdef0123456789abcdef"
+
+// callbackReceiver is a partner endpoint answering webhook challenges and
+// keeping the verified callbacks it accepts. It rejects the first reject
+// deliveries.
+type callbackReceiver struct {
+	verifier *webhook.Verifier
+	reject   int
+
+	mu        sync.Mutex
+	callbacks []SearchCallbackDTO
+}
+
+func (r *callbackReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodGet {
+		answer, err := r.verifier.AnswerChallenge(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(answer))
+		return
+	}
+
+	body, err := r.verifier.VerifyRequest(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reject > 0 {
+		r.reject--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	var callback SearchCallbackDTO
+	if err := json.Unmarshal(body, &callback); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.callbacks = append(r.callbacks, callback)
+}
+
+func (r *callbackReceiver) received() []SearchCallbackDTO {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]SearchCallbackDTO(nil), r.callbacks...)
+}
+
+// setupAsyncSearch returns a router searching with uc, asynchronously on the
+// returned queue when requests have a callbackUrl, and the search history
+// keeping the last search for its callback.
+func setupAsyncSearch(t *testing.T, uc usecase.FlightSearchUseCase, clock timeutil.Clock) (*echo.Echo, *jobqueue.Queue, *memory.SearchStore) {
+	t.Helper()
+	queue, err := jobqueue.Open(context.Background(), jobqueue.NewMemoryStorage(), jobqueue.Config{}, jobqueue.WithClock(clock))
+	require.NoError(t, err)
+	signer, err := webhook.NewSigner(testCallbackSecret)
+	require.NoError(t, err)
+	store := memory.NewSearchStore(1)
+
+	e := echo.New()
+	e.Use(principalFromHeader)
+	async := NewAsyncSearches(queue, usecase.NewRecordingUseCase(uc, store, clock), store, signer, WithCallbackAttempts(3))
+	RegisterRoutes(e, NewFlightHandler(uc, WithAsyncSearches(async)))
+	return e, queue, store
+}
+
+// submitAsyncSearch posts a search for client with callbackURL and returns
+// the accepted search ID.
+func submitAsyncSearch(t *testing.T, e *echo.Echo, client, callbackURL string, fields ...string) string {
+	t.Helper()
+	body, err := json.Marshal(SearchFlightsRequest{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: getFutureDate(),
+		Passengers:    1,
+		Fields:        fields,
+		CallbackURL:   callbackURL,
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/flights/search", strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Client", client)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+
+	var accepted AsyncSearchAcceptedDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &accepted))
+	assert.Equal(t, AsyncSearchAccepted, accepted.Status)
+	require.NotEmpty(t, accepted.SearchID)
+	return accepted.SearchID
+}
+
+func TestAsyncSearch_DeliversResults(t *testing.T) {
+	var searches []usecase.SearchOptions
+	uc := &mockUseCase{searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+		principal, _ := rbac.FromContext(ctx)
+		assert.Equal(t, "partner-app", principal.Name)
+		assert.Equal(t, domain.RequestClassBatch, usecase.RequestClassFromContext(ctx))
+		searches = append(searches, opts)
+		return &domain.SearchResponse{
+			Flights: []domain.Flight{{
+				ID:      "QZ520_AirAsia",
+				Airline: domain.AirlineInfo{Code: "QZ", Name: "AirAsia"},
+				Price:   domain.PriceInfo{Amount: 650000, Currency: "IDR"},
+			}},
+			Metadata: domain.SearchMetadata{TotalResults: 1, SearchID: opts.SearchID},
+		}, nil
+	}}
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	e, queue, _ := setupAsyncSearch(t, uc, clock)
+	receiver := &callbackReceiver{verifier: webhook.NewVerifier(testCallbackSecret), reject: 1}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	searchID := submitAsyncSearch(t, e, "partner-app", server.URL+"/results", "id", "price")
+	assert.Empty(t, searches, "the search runs in the background")
+
+	ctx := context.Background()
+	require.True(t, queue.RunOnce(ctx))
+	require.Len(t, searches, 1)
+	assert.Equal(t, searchID, searches[0].SearchID, "the search is recorded under the announced ID")
+	jobs := queue.List(jobqueue.StatusPending)
+	require.Len(t, jobs, 1)
+	assert.NotContains(t, string(jobs[0].Payload), "QZ520", "the callback job refers to the stored results")
+
+	// The first delivery is rejected and retried after the backoff
+	require.True(t, queue.RunOnce(ctx))
+	assert.Empty(t, receiver.received())
+	assert.False(t, queue.RunOnce(ctx))
+	clock.Advance(time.Minute)
+	require.True(t, queue.RunOnce(ctx))
+
+	callbacks := receiver.received()
+	require.Len(t, callbacks, 1)
+	assert.Equal(t, searchID, callbacks[0].SearchID)
+	assert.Equal(t, AsyncSearchCompleted, callbacks[0].Status)
+	assert.Nil(t, callbacks[0].Error)
+	results, err := json.Marshal(callbacks[0].Results)
+	require.NoError(t, err)
+	var projected ProjectedSearchResponseDTO
+	require.NoError(t, json.Unmarshal(results, &projected))
+	require.Len(t, projected.Flights, 1)
+	assert.Len(t, projected.Flights[0], 2, "results are limited to the requested fields")
+	assert.Equal(t, searchID, projected.Metadata.SearchID)
+}
+
+func TestAsyncSearch_DeliversFailures(t *testing.T) {
+	uc := &mockUseCase{searchFunc: func(context.Context, domain.SearchCriteria, usecase.SearchOptions) (*domain.SearchResponse, error) {
+		return nil, domain.ErrAllProvidersFailed
+	}}
+	e, queue, _ := setupAsyncSearch(t, uc, timeutil.NewMockClockFromString("2025-12-15T10:00:00Z"))
+	receiver := &callbackReceiver{verifier: webhook.NewVerifier(testCallbackSecret)}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	searchID := submitAsyncSearch(t, e, "partner-app", server.URL)
+	ctx := context.Background()
+	for queue.RunOnce(ctx) {
+	}
+
+	callbacks := receiver.received()
+	require.Len(t, callbacks, 1)
+	assert.Equal(t, searchID, callbacks[0].SearchID)
+	assert.Equal(t, AsyncSearchFailed, callbacks[0].Status)
+	assert.Nil(t, callbacks[0].Results)
+	require.NotNil(t, callbacks[0].Error)
+	assert.Equal(t, response.CodeServiceUnavailable, callbacks[0].Error.Code)
+}
+
+func TestAsyncSearch_ResultsEvictedBeforeDelivery(t *testing.T) {
+	uc := &mockUseCase{searchFunc: func(context.Context, domain.SearchCriteria, usecase.SearchOptions) (*domain.SearchResponse, error) {
+		return &domain.SearchResponse{}, nil
+	}}
+	e, queue, store := setupAsyncSearch(t, uc, timeutil.NewMockClockFromString("2025-12-15T10:00:00Z"))
+	receiver := &callbackReceiver{verifier: webhook.NewVerifier(testCallbackSecret)}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	submitAsyncSearch(t, e, "partner-app", server.URL)
+	ctx := context.Background()
+	require.True(t, queue.RunOnce(ctx))
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "newer"}))
+	require.True(t, queue.RunOnce(ctx))
+
+	assert.Empty(t, receiver.received())
+	jobs := queue.List(jobqueue.StatusPending)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, SearchCallbackJob, jobs[0].Kind)
+	assert.Contains(t, jobs[0].LastError, "search not found")
+}
+
+func TestAsyncSearch_RequiresChallenge(t *testing.T) {
+	searched := false
+	uc := &mockUseCase{searchFunc: func(context.Context, domain.SearchCriteria, usecase.SearchOptions) (*domain.SearchResponse, error) {
+		searched = true
+		return &domain.SearchResponse{}, nil
+	}}
+	e, queue, _ := setupAsyncSearch(t, uc, timeutil.NewMockClockFromString("2025-12-15T10:00:00Z"))
+	posted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posted = true
+		}
+		// Accepts anything without answering the challenge
+	}))
+	defer server.Close()
+
+	submitAsyncSearch(t, e, "partner-app", server.URL)
+	require.True(t, queue.RunOnce(context.Background()))
+
+	assert.False(t, searched, "searches do not run for unverified callback URLs")
+	assert.False(t, posted)
+	assert.Empty(t, queue.List(jobqueue.StatusDone))
+}
+
+func TestSearchFlights_CallbackURLRejections(t *testing.T) {
+	futureDate := getFutureDate()
+	tests := []struct {
+		name    string
+		async   bool
+		request SearchFlightsRequest
+		message string
+	}{
+		{
+			name:    "async searches disabled",
+			request: SearchFlightsRequest{Origin: "CGK", Destination: "DPS", DepartureDate: futureDate, Passengers: 1, CallbackURL: "https://partner.example.com/results"},
+			message: "asynchronous searches are not enabled",
+		},
+		{
+			name:    "group",
+			async:   true,
+			request: SearchFlightsRequest{Origin: "CGK", Destination: "DPS", DepartureDate: futureDate, Passengers: 12, CallbackURL: "https://partner.example.com/results"},
+			message: fmt.Sprintf("callbackUrl is not supported for more than %d passengers", domain.MaxPassengers),
+		},
+		{
+			name:    "relative URL",
+			async:   true,
+			request: SearchFlightsRequest{Origin: "CGK", Destination: "DPS", DepartureDate: futureDate, Passengers: 1, CallbackURL: "/results"},
+			message: "callbackUrl must be an absolute http(s) URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := setupTestHandler(&mockUseCase{})
+			if tt.async {
+				e, _, _ = setupAsyncSearch(t, &mockUseCase{}, timeutil.NewMockClockFromString("2025-12-15T10:00:00Z"))
+			}
+
+			rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", tt.request)
+			require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+			var detail response.ErrorDetail
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &detail))
+			assert.Equal(t, tt.message, detail.Details["callbackUrl"])
+		})
+	}
+}
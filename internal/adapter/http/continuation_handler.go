@@ -0,0 +1,41 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/sanitize"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// ContinuationHandler serves the late results of partial searches.
+type ContinuationHandler struct {
+	useCase   usecase.ContinuationUseCase
+	sanitizer *sanitize.Sanitizer
+}
+
+// NewContinuationHandler creates a new ContinuationHandler with the given use
+// case. Provider free text is cleaned with sanitizer (nil = not cleaned).
+func NewContinuationHandler(uc usecase.ContinuationUseCase, sanitizer *sanitize.Sanitizer) *ContinuationHandler {
+	return &ContinuationHandler{useCase: uc, sanitizer: sanitizer}
+}
+
+// ContinueSearch handles GET /api/v1/searches/:id/continue
+// The id is the continuation token of a partial search response. It returns
+// the partial results merged with those of the providers that answered since,
+// re-ranked with the original options, localized like search responses.
+func (h *ContinuationHandler) ContinueSearch(c echo.Context) error {
+	result, err := h.useCase.Continue(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	locale := requestLocale(c)
+	if !loadshed.Allowed(c.Request().Context(), loadshed.FeatureLocalization) {
+		locale = ""
+	}
+	dto := ToSearchResponseDTOLocalized(result, locale)
+	SanitizeSearchResponseDTO(dto, h.sanitizer)
+	return response.SearchResults(c, dto)
+}
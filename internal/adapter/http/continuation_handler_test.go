@@ -0,0 +1,60 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+type stubContinuations map[string]*domain.SearchResponse
+
+func (s stubContinuations) Continue(_ context.Context, token string) (*domain.SearchResponse, error) {
+	if resp, ok := s[token]; ok {
+		return resp, nil
+	}
+	return nil, domain.ErrContinuationNotFound
+}
+
+func TestContinuationHandler_ContinueSearch(t *testing.T) {
+	e := echo.New()
+	RegisterContinuationRoutes(e, NewContinuationHandler(stubContinuations{
+		"abc": {
+			Flights:  []domain.Flight{{ID: "1", Airline: domain.AirlineInfo{Code: "QZ", Name: "AirAsia"}}},
+			Metadata: domain.SearchMetadata{ProvidersQueried: 2, ProvidersSucceeded: 1, PendingProviders: []string{"lion_air"}, ContinuationToken: "abc"},
+		},
+	}, nil))
+
+	t.Run("held search", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/searches/abc/continue?locale=id", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var dto SearchResponseDTO
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+		require.Len(t, dto.Flights, 1)
+		assert.Equal(t, "AirAsia Indonesia", dto.Flights[0].Airline.Name)
+		assert.Equal(t, []string{"lion_air"}, dto.Metadata.PendingProviders)
+		assert.Equal(t, "abc", dto.Metadata.ContinuationToken)
+	})
+
+	t.Run("unknown or expired token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/searches/expired/continue", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		var result response.ErrorDetail
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.Equal(t, response.MsgContinuationNotFound, result.Message)
+	})
+}
@@ -21,13 +21,24 @@ func ToDomainCriteria(req *SearchFlightsRequest) domain.SearchCriteria {
 		passengers = 1
 	}
 
-	return domain.SearchCriteria{
-		Origin:        strings.ToUpper(req.Origin),
-		Destination:   strings.ToUpper(req.Destination),
-		DepartureDate: req.DepartureDate,
-		Passengers:    passengers,
-		Class:         class,
-	}
+	criteria := domain.SearchCriteria{
+		Origin:          strings.ToUpper(req.Origin),
+		Destination:     strings.ToUpper(req.Destination),
+		DepartureDate:   req.DepartureDate,
+		Passengers:      passengers,
+		Class:           class,
+		DateFlexibility: req.DateFlexibility,
+	}
+	if req.Return != nil {
+		criteria.Return = &domain.ReturnLeg{
+			Origin:        strings.ToUpper(req.Return.Origin),
+			Destination:   strings.ToUpper(req.Return.Destination),
+			DepartureDate: req.Return.DepartureDate,
+		}
+	} else if req.ReturnDate != "" {
+		criteria.Return = criteria.ReturnOn(req.ReturnDate)
+	}
+	return criteria
 }
 
 // ToDomainFilters converts a FilterDTO to domain.FilterOptions.
@@ -37,14 +48,24 @@ func ToDomainFilters(dto *FilterDTO) *domain.FilterOptions {
 	}
 
 	opts := &domain.FilterOptions{
-		MaxPrice: dto.MaxPrice,
-		MaxStops: dto.MaxStops,
-		Airlines: dto.Airlines,
-	}
-
-	// Convert time range if provided
+		MinPrice:             dto.MinPrice,
+		MaxPrice:             dto.MaxPrice,
+		MaxStops:             dto.MaxStops,
+		MinSeatsAvailable:    dto.MinSeatsAvailable,
+		Airlines:             dto.Airlines,
+		SupportsWheelchair:   dto.SupportsWheelchair != nil && *dto.SupportsWheelchair,
+		FareFamilies:         dto.FareFamilies,
+		Changeable:           dto.Changeable != nil && *dto.Changeable,
+		Refundable:           dto.Refundable != nil && *dto.Refundable,
+		RefundableOnly:       dto.RefundableOnly != nil && *dto.RefundableOnly,
+		IncludeHigherClasses: dto.IncludeHigherClasses != nil && *dto.IncludeHigherClasses,
+	}
+
+	// Convert time range if provided, or the time bucket standing for one
 	if dto.DepartureTimeRange != nil {
 		opts.DepartureTimeRange = toDomainTimeRange(dto.DepartureTimeRange)
+	} else if dto.DepartureTimeBucket != "" {
+		opts.DepartureTimeRange = domain.TimeBucket(strings.ToLower(dto.DepartureTimeBucket)).Range()
 	}
 
 	// Convert arrival time range if provided
@@ -112,15 +133,122 @@ func ToDomainSortOption(sortBy string) domain.SortOption {
 		return domain.SortByDuration
 	case "departure":
 		return domain.SortByDeparture
+	case "arrival":
+		return domain.SortByArrival
 	default:
 		return domain.SortByBestValue // Default to best value
 	}
 }
 
+// ToDomainSortOrder converts a sort order string to domain.SortOrder.
+func ToDomainSortOrder(sortOrder string) domain.SortOrder {
+	if strings.EqualFold(sortOrder, "desc") {
+		return domain.SortDescending
+	}
+	return domain.SortAscending // Default to ascending
+}
+
 // ToSearchOptions converts request fields to usecase.SearchOptions.
 func ToSearchOptions(req *SearchFlightsRequest) usecase.SearchOptions {
-	return usecase.SearchOptions{
-		Filters: ToDomainFilters(req.Filters),
-		SortBy:  ToDomainSortOption(req.SortBy),
+	opts := usecase.SearchOptions{
+		Filters:                ToDomainFilters(req.Filters),
+		SortBy:                 ToDomainSortOption(req.SortBy),
+		SortOrder:              ToDomainSortOrder(req.SortOrder),
+		PreferredDepartureTime: toDomainTimeRange(req.PreferredDepartureTime),
+		PartialResultsOK:       req.PartialResultsOK,
+		FanOutStrategy:         domain.FanOutStrategy(strings.ToLower(req.FanOutStrategy)),
+	}
+	if req.MaxResults != nil {
+		opts.MaxResults = *req.MaxResults
+	}
+	if req.MaxResultsPerProvider != nil {
+		opts.MaxResultsPerProvider = *req.MaxResultsPerProvider
+	}
+	for _, s := range req.SpecialServices {
+		opts.SpecialServices = append(opts.SpecialServices, domain.SpecialService(strings.ToLower(s)))
+	}
+	return opts
+}
+
+// ToSearchFlightsRequest converts a stored search back to a request running
+// it again: its criteria, filters, sort and result limits.
+func ToSearchFlightsRequest(record *domain.SearchRecord) SearchFlightsRequest {
+	c := record.Criteria
+	req := SearchFlightsRequest{
+		Origin:                 c.Origin,
+		Destination:            c.Destination,
+		DepartureDate:          c.DepartureDate,
+		Passengers:             c.Passengers,
+		Class:                  c.Class,
+		Filters:                toFilterDTO(record.Filters),
+		SortBy:                 toRequestSortBy(record.SortBy),
+		SortOrder:              string(record.SortOrder),
+		PreferredDepartureTime: toTimeRangeDTO(record.PreferredDepartureTime),
+		DateFlexibility:        c.DateFlexibility,
+	}
+	if c.Return != nil {
+		req.Return = &ReturnLegDTO{
+			Origin:        c.Return.Origin,
+			Destination:   c.Return.Destination,
+			DepartureDate: c.Return.DepartureDate,
+		}
+	}
+	if record.MaxResults > 0 {
+		req.MaxResults = &record.MaxResults
+	}
+	if record.MaxResultsPerProvider > 0 {
+		req.MaxResultsPerProvider = &record.MaxResultsPerProvider
+	}
+	return req
+}
+
+// toFilterDTO converts domain.FilterOptions back to a FilterDTO.
+func toFilterDTO(opts *domain.FilterOptions) *FilterDTO {
+	if opts == nil {
+		return nil
+	}
+
+	dto := &FilterDTO{
+		MinPrice:           opts.MinPrice,
+		MaxPrice:           opts.MaxPrice,
+		MaxStops:           opts.MaxStops,
+		MinSeatsAvailable:  opts.MinSeatsAvailable,
+		Airlines:           opts.Airlines,
+		DepartureTimeRange: toTimeRangeDTO(opts.DepartureTimeRange),
+		ArrivalTimeRange:   toTimeRangeDTO(opts.ArrivalTimeRange),
+		FareFamilies:       opts.FareFamilies,
+	}
+	if d := opts.DurationRange; d != nil {
+		dto.DurationRange = &DurationRangeDTO{MinMinutes: d.MinMinutes, MaxMinutes: d.MaxMinutes}
+	}
+
+	// Unset flags are omitted rather than sent as false
+	flag := func(set bool) *bool {
+		if !set {
+			return nil
+		}
+		return &set
+	}
+	dto.SupportsWheelchair = flag(opts.SupportsWheelchair)
+	dto.Changeable = flag(opts.Changeable)
+	dto.Refundable = flag(opts.Refundable)
+	dto.RefundableOnly = flag(opts.RefundableOnly)
+	dto.IncludeHigherClasses = flag(opts.IncludeHigherClasses)
+	return dto
+}
+
+// toTimeRangeDTO converts a domain.TimeRange back to a TimeRangeDTO.
+func toTimeRangeDTO(r *domain.TimeRange) *TimeRangeDTO {
+	if r == nil {
+		return nil
+	}
+	return &TimeRangeDTO{Start: r.Start.Format("15:04"), End: r.End.Format("15:04")}
+}
+
+// toRequestSortBy converts a domain.SortOption back to its sortBy value.
+func toRequestSortBy(sortBy domain.SortOption) string {
+	if sortBy == domain.SortByBestValue {
+		return "best_value"
 	}
+	return string(sortBy)
 }
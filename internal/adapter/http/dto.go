@@ -2,8 +2,13 @@ package http
 
 import (
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/flightindex"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/sanitize"
 )
 
 // SearchResponseDTO is the data transfer object for search responses.
@@ -12,25 +17,71 @@ type SearchResponseDTO struct {
 	SearchCriteria SearchCriteriaDTO `json:"search_criteria"`
 	Metadata       MetadataDTO       `json:"metadata"`
 	Flights        []FlightDTO       `json:"flights"`
+	ReturnFlights  []FlightDTO       `json:"return_flights,omitempty"`
+	Trip           *TripDTO          `json:"trip,omitempty"`
+	Itineraries    []ItineraryDTO    `json:"itineraries,omitempty"`
+	DateSummaries  []DateSummaryDTO  `json:"date_summaries,omitempty"`
 }
 
 // SearchCriteriaDTO represents the search criteria in the response.
 type SearchCriteriaDTO struct {
+	Origin          string                `json:"origin"`
+	Destination     string                `json:"destination"`
+	DepartureDate   string                `json:"departure_date"`
+	Passengers      int                   `json:"passengers"`
+	CabinClass      string                `json:"cabin_class"`
+	Return          *ReturnLegResponseDTO `json:"return,omitempty"`
+	DateFlexibility int                   `json:"date_flexibility,omitempty"`
+}
+
+// ReturnLegResponseDTO represents the return leg of a round trip in the response.
+type ReturnLegResponseDTO struct {
 	Origin        string `json:"origin"`
 	Destination   string `json:"destination"`
 	DepartureDate string `json:"departure_date"`
-	Passengers    int    `json:"passengers"`
-	CabinClass    string `json:"cabin_class"`
+}
+
+// TripDTO summarizes the prices of a round trip, its legs priced independently.
+type TripDTO struct {
+	OpenJaw        bool      `json:"open_jaw"`
+	LowestOutbound *PriceDTO `json:"lowest_outbound,omitempty"`
+	LowestReturn   *PriceDTO `json:"lowest_return,omitempty"`
+	LowestTotal    *PriceDTO `json:"lowest_total,omitempty"`
+}
+
+// ItineraryDTO pairs an outbound and a return flight of a round trip.
+type ItineraryDTO struct {
+	OutboundFlightID string   `json:"outbound_flight_id"`
+	ReturnFlightID   string   `json:"return_flight_id"`
+	TotalPrice       PriceDTO `json:"total_price"`
+	TotalMinutes     int      `json:"total_minutes"`
+}
+
+// DateSummaryDTO summarizes the flights of one departure date of a
+// flexible-date search.
+type DateSummaryDTO struct {
+	DepartureDate string    `json:"departure_date"`
+	LowestPrice   *PriceDTO `json:"lowest_price,omitempty"`
+	TotalResults  int       `json:"total_results"`
+	Failed        bool      `json:"failed,omitempty"`
 }
 
 // MetadataDTO contains metadata about the search execution.
 type MetadataDTO struct {
-	TotalResults       int   `json:"total_results"`
-	ProvidersQueried   int   `json:"providers_queried"`
-	ProvidersSucceeded int   `json:"providers_succeeded"`
-	ProvidersFailed    int   `json:"providers_failed"`
-	SearchTimeMs       int64 `json:"search_time_ms"`
-	CacheHit           bool  `json:"cache_hit"`
+	TotalResults       int            `json:"total_results"`
+	ProvidersQueried   int            `json:"providers_queried"`
+	ProvidersSucceeded int            `json:"providers_succeeded"`
+	ProvidersFailed    int            `json:"providers_failed"`
+	SearchTimeMs       int64          `json:"search_time_ms"`
+	CacheHit           bool           `json:"cache_hit"`
+	SearchID           string         `json:"search_id,omitempty"`
+	RejectedRoutings   int            `json:"rejected_routings"`
+	DuplicatesMerged   int            `json:"duplicates_merged"`
+	FilterRejections   map[string]int `json:"filter_rejections,omitempty"`
+	PendingProviders   []string       `json:"pending_providers,omitempty"`
+	SkippedProviders   []string       `json:"skipped_providers,omitempty"`
+	ContinuationToken  string         `json:"continuation_token,omitempty"`
+	Features           []string       `json:"features,omitempty"`
 }
 
 // FlightDTO is the data transfer object for flight responses.
@@ -49,18 +100,63 @@ type FlightDTO struct {
 	Aircraft       *string       `json:"aircraft"`
 	Amenities      []string      `json:"amenities"`
 	Baggage        BaggageDTO    `json:"baggage"`
+	Accessibility  *AccessibilityDTO `json:"accessibility,omitempty"`
+	Offers         []OfferDTO    `json:"offers,omitempty"`
+	FareFamily     *FareFamilyDTO `json:"fare_family,omitempty"`
+	FareRules      *FareRulesDTO  `json:"fare_rules,omitempty"`
+	FareOptions    []FareOptionDTO `json:"fare_options,omitempty"`
+}
+
+// FlightDetailsDTO is a flight of a recent search, looked up by ID.
+type FlightDetailsDTO struct {
+	Flight     FlightDTO `json:"flight"`
+	SearchedAt time.Time `json:"searched_at"`
+}
+
+// OfferDTO represents one provider's offer of a flight several providers returned.
+type OfferDTO struct {
+	FlightID string   `json:"flight_id"`
+	Provider string   `json:"provider"`
+	Price    PriceDTO `json:"price"`
+}
+
+// FareFamilyDTO represents an airline's branded fare, such as Lite, Value or Flex.
+type FareFamilyDTO struct {
+	Name       string `json:"name"`
+	Changeable bool   `json:"changeable"`
+	Refundable bool   `json:"refundable"`
+}
+
+// FareRulesDTO represents the refund and change conditions of a fare.
+type FareRulesDTO struct {
+	Refundable bool      `json:"refundable"`
+	Changeable bool      `json:"changeable"`
+	ChangeFee  *PriceDTO `json:"change_fee,omitempty"`
+}
+
+// FareOptionDTO represents one fare family of a flight sold in several.
+type FareOptionDTO struct {
+	FlightID   string        `json:"flight_id"`
+	FareFamily FareFamilyDTO `json:"fare_family"`
+	Price      PriceDTO      `json:"price"`
+	Baggage    BaggageDTO    `json:"baggage"`
 }
 
 // AirlineDTO represents airline information.
 type AirlineDTO struct {
-	Name string `json:"name"`
-	Code string `json:"code"`
+	Name     string `json:"name"`
+	Code     string `json:"code"`
+	Logo     string `json:"logo,omitempty"`
+	Alliance string `json:"alliance,omitempty"`
 }
 
 // FlightPointDTO represents a departure or arrival point.
 type FlightPointDTO struct {
-	Airport   string `json:"airport"`
-	City      string `json:"city,omitempty"`
+	Airport     string `json:"airport"`
+	AirportName string `json:"airport_name,omitempty"`
+	City        string `json:"city,omitempty"`
+	Country     string `json:"country,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
 	DateTime  string `json:"datetime"`
 	Timestamp int64  `json:"timestamp"`
 }
@@ -71,16 +167,30 @@ type DurationDTO struct {
 	Formatted    string `json:"formatted"`
 }
 
-// PriceDTO represents price information.
+// PriceDTO represents price information. Amount is per passenger, broken
+// down into BaseFare, Taxes and Fees when the provider reports it; Total,
+// when computed, is for all the passengers searched.
 type PriceDTO struct {
-	Amount   float64 `json:"amount"`
-	Currency string  `json:"currency"`
+	Amount     float64 `json:"amount"`
+	Currency   string  `json:"currency"`
+	BaseFare   float64 `json:"base_fare,omitempty"`
+	Taxes      float64 `json:"taxes,omitempty"`
+	Fees       float64 `json:"fees,omitempty"`
+	Passengers int     `json:"passengers,omitempty"`
+	Total      float64 `json:"total,omitempty"`
+}
+
+// AccessibilityDTO represents the special assistance available on a flight.
+type AccessibilityDTO struct {
+	Services []string `json:"services"`
+	Source   string   `json:"source"`
 }
 
 // BaggageDTO represents baggage information.
 type BaggageDTO struct {
 	CarryOn string `json:"carry_on,omitempty"`
 	Checked string `json:"checked,omitempty"`
+	Note    string `json:"note,omitempty"`
 }
 
 // ToSearchResponseDTO converts a domain SearchResponse to a SearchResponseDTO.
@@ -91,11 +201,12 @@ func ToSearchResponseDTO(resp *domain.SearchResponse) *SearchResponseDTO {
 
 	dto := &SearchResponseDTO{
 		SearchCriteria: SearchCriteriaDTO{
-			Origin:        resp.SearchCriteria.Origin,
-			Destination:   resp.SearchCriteria.Destination,
-			DepartureDate: resp.SearchCriteria.DepartureDate,
-			Passengers:    resp.SearchCriteria.Passengers,
-			CabinClass:    resp.SearchCriteria.CabinClass,
+			Origin:          resp.SearchCriteria.Origin,
+			Destination:     resp.SearchCriteria.Destination,
+			DepartureDate:   resp.SearchCriteria.DepartureDate,
+			Passengers:      resp.SearchCriteria.Passengers,
+			CabinClass:      resp.SearchCriteria.CabinClass,
+			DateFlexibility: resp.SearchCriteria.DateFlexibility,
 		},
 		Metadata: MetadataDTO{
 			TotalResults:       resp.Metadata.TotalResults,
@@ -104,6 +215,14 @@ func ToSearchResponseDTO(resp *domain.SearchResponse) *SearchResponseDTO {
 			ProvidersFailed:    resp.Metadata.ProvidersFailed,
 			SearchTimeMs:       resp.Metadata.SearchTimeMs,
 			CacheHit:           resp.Metadata.CacheHit,
+			SearchID:           resp.Metadata.SearchID,
+			RejectedRoutings:   resp.Metadata.RejectedRoutings,
+			DuplicatesMerged:   resp.Metadata.DuplicatesMerged,
+			FilterRejections:   resp.Metadata.FilterRejections,
+			PendingProviders:   resp.Metadata.PendingProviders,
+			SkippedProviders:   resp.Metadata.SkippedProviders,
+			ContinuationToken:  resp.Metadata.ContinuationToken,
+			Features:           resp.Metadata.Features,
 		},
 		Flights: make([]FlightDTO, len(resp.Flights)),
 	}
@@ -112,6 +231,144 @@ func ToSearchResponseDTO(resp *domain.SearchResponse) *SearchResponseDTO {
 		dto.Flights[i] = ToFlightDTO(&flight)
 	}
 
+	if ret := resp.SearchCriteria.Return; ret != nil {
+		dto.SearchCriteria.Return = &ReturnLegResponseDTO{
+			Origin:        ret.Origin,
+			Destination:   ret.Destination,
+			DepartureDate: ret.DepartureDate,
+		}
+	}
+	for _, flight := range resp.ReturnFlights {
+		dto.ReturnFlights = append(dto.ReturnFlights, ToFlightDTO(&flight))
+	}
+	if trip := resp.Trip; trip != nil {
+		dto.Trip = &TripDTO{
+			OpenJaw:        trip.OpenJaw,
+			LowestOutbound: toPriceDTO(trip.LowestOutbound),
+			LowestReturn:   toPriceDTO(trip.LowestReturn),
+			LowestTotal:    toPriceDTO(trip.LowestTotal),
+		}
+	}
+	for _, it := range resp.Itineraries {
+		dto.Itineraries = append(dto.Itineraries, ItineraryDTO{
+			OutboundFlightID: it.OutboundFlightID,
+			ReturnFlightID:   it.ReturnFlightID,
+			TotalPrice:       PriceDTO{Amount: it.TotalPrice.Amount, Currency: it.TotalPrice.Currency},
+			TotalMinutes:     it.TotalMinutes,
+		})
+	}
+	for _, summary := range resp.DateSummaries {
+		dto.DateSummaries = append(dto.DateSummaries, DateSummaryDTO{
+			DepartureDate: summary.DepartureDate,
+			LowestPrice:   toPriceDTO(summary.LowestPrice),
+			TotalResults:  summary.TotalResults,
+			Failed:        summary.Failed,
+		})
+	}
+
+	return dto
+}
+
+// toPriceDTO converts an optional price, returning nil for nil.
+func toPriceDTO(price *domain.PriceInfo) *PriceDTO {
+	if price == nil {
+		return nil
+	}
+	dto := newPriceDTO(*price)
+	return &dto
+}
+
+// newPriceDTO converts a domain PriceInfo to a PriceDTO.
+func newPriceDTO(price domain.PriceInfo) PriceDTO {
+	return PriceDTO{
+		Amount:     price.Amount,
+		Currency:   price.Currency,
+		BaseFare:   price.BaseFare,
+		Taxes:      price.Taxes,
+		Fees:       price.Fees,
+		Passengers: price.Passengers,
+		Total:      price.Total,
+	}
+}
+
+// ToSearchResponseDTOLocalized converts a domain SearchResponse to a SearchResponseDTO
+// and translates airline and airport display names into the given locale.
+// Names not found in the reference data keep the provider-supplied values.
+// An empty or unsupported locale leaves all names untouched.
+func ToSearchResponseDTOLocalized(resp *domain.SearchResponse, loc reference.Locale) *SearchResponseDTO {
+	dto := ToSearchResponseDTO(resp)
+	if dto == nil || !loc.IsValid() {
+		return dto
+	}
+
+	for i := range dto.Flights {
+		localizeFlightDTO(&dto.Flights[i], loc)
+	}
+	for i := range dto.ReturnFlights {
+		localizeFlightDTO(&dto.ReturnFlights[i], loc)
+	}
+	dto.Metadata.Features = append(slices.Clip(dto.Metadata.Features), domain.FeatureValue(domain.FeatureLocale, string(loc)))
+
+	return dto
+}
+
+// SanitizeSearchResponseDTO cleans the provider free text of the flights in dto
+// in place: airline and airport names, aircraft, amenities and baggage notes.
+func SanitizeSearchResponseDTO(dto *SearchResponseDTO, s *sanitize.Sanitizer) {
+	if dto == nil || s == nil {
+		return
+	}
+	for i := range dto.Flights {
+		sanitizeFlightDTO(&dto.Flights[i], s)
+	}
+	for i := range dto.ReturnFlights {
+		sanitizeFlightDTO(&dto.ReturnFlights[i], s)
+	}
+}
+
+// sanitizeFlightDTO cleans the free text of a flight in place.
+func sanitizeFlightDTO(dto *FlightDTO, s *sanitize.Sanitizer) {
+	dto.Airline.Name = s.Text(dto.Airline.Name)
+	dto.Departure.AirportName = s.Text(dto.Departure.AirportName)
+	dto.Arrival.AirportName = s.Text(dto.Arrival.AirportName)
+	if dto.Aircraft != nil {
+		if aircraft := s.Text(*dto.Aircraft); aircraft != "" {
+			dto.Aircraft = &aircraft
+		} else {
+			dto.Aircraft = nil
+		}
+	}
+	dto.Amenities = s.Texts(dto.Amenities)
+	dto.Baggage.Note = s.Text(dto.Baggage.Note)
+}
+
+// localizeFlightDTO translates the airline and airport names of a flight in place.
+func localizeFlightDTO(dto *FlightDTO, loc reference.Locale) {
+	if name := reference.AirlineName(dto.Airline.Code, loc); name != "" {
+		dto.Airline.Name = name
+	}
+	localizeFlightPointDTO(&dto.Departure, loc)
+	localizeFlightPointDTO(&dto.Arrival, loc)
+}
+
+// localizeFlightPointDTO translates the airport and city names of a flight point in place.
+func localizeFlightPointDTO(dto *FlightPointDTO, loc reference.Locale) {
+	if name := reference.AirportName(dto.Airport, loc); name != "" {
+		dto.AirportName = name
+	}
+	if city := reference.CityName(dto.Airport, loc); city != "" {
+		dto.City = city
+	}
+}
+
+// ToFlightDetailsDTO converts a remembered flight to a FlightDetailsDTO, with
+// airline and airport names translated into the given locale as in
+// ToSearchResponseDTOLocalized.
+func ToFlightDetailsDTO(entry flightindex.Entry, loc reference.Locale) *FlightDetailsDTO {
+	dto := &FlightDetailsDTO{Flight: ToFlightDTO(&entry.Flight), SearchedAt: entry.SearchedAt}
+	if loc.IsValid() {
+		localizeFlightDTO(&dto.Flight, loc)
+	}
 	return dto
 }
 
@@ -122,48 +379,99 @@ func ToFlightDTO(flight *domain.Flight) FlightDTO {
 		Provider:     flight.Provider,
 		FlightNumber: flight.FlightNumber,
 		Airline: AirlineDTO{
-			Name: flight.Airline.Name,
-			Code: flight.Airline.Code,
-		},
-		Departure: FlightPointDTO{
-			Airport:   flight.Departure.AirportCode,
-			DateTime:  flight.Departure.DateTime.Format("2006-01-02T15:04:05-07:00"),
-			Timestamp: flight.Departure.DateTime.Unix(),
-		},
-		Arrival: FlightPointDTO{
-			Airport:   flight.Arrival.AirportCode,
-			DateTime:  flight.Arrival.DateTime.Format("2006-01-02T15:04:05-07:00"),
-			Timestamp: flight.Arrival.DateTime.Unix(),
+			Name:     flight.Airline.Name,
+			Code:     flight.Airline.Code,
+			Logo:     flight.Airline.Logo,
+			Alliance: flight.Airline.Alliance,
 		},
+		Departure: toFlightPointDTO(flight.Departure),
+		Arrival:   toFlightPointDTO(flight.Arrival),
 		Duration: DurationDTO{
 			TotalMinutes: flight.Duration.TotalMinutes,
 			Formatted:    flight.Duration.Formatted,
 		},
 		Stops:      flight.Stops,
 		CabinClass: flight.Class,
-		Price: PriceDTO{
-			Amount:   flight.Price.Amount,
-			Currency: flight.Price.Currency,
-		},
-		Aircraft:  nil,
-		Amenities: []string{},
+		Price:      newPriceDTO(flight.Price),
+		Aircraft:   nil,
+		Amenities:  []string{},
 		Baggage: BaggageDTO{
 			CarryOn: formatBaggageKg(flight.Baggage.CabinKg),
 			Checked: formatBaggageKg(flight.Baggage.CheckedKg),
+			Note:    flight.Baggage.Note,
 		},
 	}
 
-	// Add city from airport name if available
-	if flight.Departure.AirportName != "" {
-		dto.Departure.City = extractCityFromAirportName(flight.Departure.AirportCode)
+	if flight.Aircraft != "" {
+		aircraft := flight.Aircraft
+		dto.Aircraft = &aircraft
+	}
+	dto.Amenities = append(dto.Amenities, flight.Amenities...)
+
+	if flight.SeatsAvailable > 0 {
+		seats := flight.SeatsAvailable
+		dto.AvailableSeats = &seats
+	}
+
+	if a := flight.Accessibility; a != nil {
+		dto.Accessibility = &AccessibilityDTO{Services: make([]string, len(a.Services)), Source: a.Source}
+		for i, s := range a.Services {
+			dto.Accessibility.Services[i] = string(s)
+		}
+	}
+
+	for _, o := range flight.Offers {
+		dto.Offers = append(dto.Offers, OfferDTO{
+			FlightID: o.FlightID,
+			Provider: o.Provider,
+			Price:    newPriceDTO(o.Price),
+		})
+	}
+
+	if flight.FareFamily != nil {
+		family := toFareFamilyDTO(*flight.FareFamily)
+		dto.FareFamily = &family
 	}
-	if flight.Arrival.AirportName != "" {
-		dto.Arrival.City = extractCityFromAirportName(flight.Arrival.AirportCode)
+	if r := flight.FareRules; r != nil {
+		dto.FareRules = &FareRulesDTO{Refundable: r.Refundable, Changeable: r.Changeable}
+		if r.ChangeFee != nil {
+			dto.FareRules.ChangeFee = &PriceDTO{Amount: r.ChangeFee.Amount, Currency: r.ChangeFee.Currency}
+		}
+	}
+	for _, o := range flight.FareOptions {
+		dto.FareOptions = append(dto.FareOptions, FareOptionDTO{
+			FlightID:   o.FlightID,
+			FareFamily: toFareFamilyDTO(o.FareFamily),
+			Price:      newPriceDTO(o.Price),
+			Baggage: BaggageDTO{
+				CarryOn: formatBaggageKg(o.Baggage.CabinKg),
+				Checked: formatBaggageKg(o.Baggage.CheckedKg),
+				Note:    o.Baggage.Note,
+			},
+		})
 	}
 
 	return dto
 }
 
+// toFlightPointDTO converts a domain FlightPoint to a FlightPointDTO.
+func toFlightPointDTO(p domain.FlightPoint) FlightPointDTO {
+	return FlightPointDTO{
+		Airport:     p.AirportCode,
+		AirportName: p.AirportName,
+		City:        p.City,
+		Country:     p.Country,
+		Timezone:    p.Timezone,
+		DateTime:    p.DateTime.Format("2006-01-02T15:04:05-07:00"),
+		Timestamp:   p.DateTime.Unix(),
+	}
+}
+
+// toFareFamilyDTO converts a domain FareFamily to a FareFamilyDTO.
+func toFareFamilyDTO(f domain.FareFamily) FareFamilyDTO {
+	return FareFamilyDTO{Name: f.Name, Changeable: f.Changeable, Refundable: f.Refundable}
+}
+
 // formatBaggageKg formats baggage weight in kg to a string.
 func formatBaggageKg(kg int) string {
 	if kg == 0 {
@@ -174,22 +482,3 @@ func formatBaggageKg(kg int) string {
 	}
 	return fmt.Sprintf("%d kg", kg)
 }
-
-// extractCityFromAirportName extracts city name from airport code.
-// This is a simple mapping for common Indonesian airports.
-func extractCityFromAirportName(code string) string {
-	cities := map[string]string{
-		"CGK": "Jakarta",
-		"DPS": "Denpasar",
-		"SUB": "Surabaya",
-		"JOG": "Yogyakarta",
-		"BDO": "Bandung",
-		"MDC": "Manado",
-		"UPG": "Makassar",
-		"BPN": "Balikpapan",
-	}
-	if city, ok := cities[code]; ok {
-		return city
-	}
-	return ""
-}
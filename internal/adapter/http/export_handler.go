@@ -0,0 +1,52 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/analytics"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/storage/blob"
+)
+
+// ExportReader reads stored exports. blob.Store implementations satisfy it.
+type ExportReader interface {
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// ExportHandler serves analytics exports to partners.
+type ExportHandler struct {
+	exports ExportReader
+}
+
+// NewExportHandler creates a new ExportHandler reading from exports.
+func NewExportHandler(exports ExportReader) *ExportHandler {
+	return &ExportHandler{exports: exports}
+}
+
+// GetSearchAnalytics handles GET /api/v1/exports/search-analytics/:date
+// It returns the anonymized search analytics CSV of a UTC day (YYYY-MM-DD).
+func (h *ExportHandler) GetSearchAnalytics(c echo.Context) error {
+	day, err := time.Parse(time.DateOnly, c.Param("date"))
+	if err != nil {
+		return response.ValidationError(c, map[string]string{
+			"date": "date must be in YYYY-MM-DD format",
+		})
+	}
+
+	r, err := h.exports.Get(c.Request().Context(), analytics.Key(day))
+	switch {
+	case errors.Is(err, blob.ErrNotFound):
+		return response.NotFound(c, response.MsgExportNotFound)
+	case err != nil:
+		return response.InternalServerError(c)
+	}
+	defer r.Close()
+
+	return c.Stream(http.StatusOK, "text/csv", r)
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/storage/blob"
+)
+
+func TestExportHandler_GetSearchAnalytics(t *testing.T) {
+	store := blob.NewFileStore(t.TempDir())
+	csv := "date,origin,destination\n2025-12-01,CGK,DPS\n"
+	require.NoError(t, store.Put(context.Background(), "exports/search_analytics/2025-12-01.csv", strings.NewReader(csv)))
+
+	e := echo.New()
+	RegisterExportRoutes(e, NewExportHandler(store))
+
+	tests := []struct {
+		name     string
+		date     string
+		wantCode int
+		wantBody string
+		wantErr  string
+	}{
+		{name: "stored export", date: "2025-12-01", wantCode: http.StatusOK, wantBody: csv},
+		{name: "missing export", date: "2025-12-02", wantCode: http.StatusNotFound, wantErr: response.MsgExportNotFound},
+		{name: "invalid date", date: "yesterday", wantCode: http.StatusBadRequest, wantErr: response.MsgValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/exports/search-analytics/"+tt.date, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantErr == "" {
+				assert.Equal(t, "text/csv", rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+				return
+			}
+			var result response.ErrorDetail
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			assert.Equal(t, tt.wantErr, result.Message)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// FlightFields lists the flight fields that can be selected with the fields
+// request parameter, using their JSON names.
+var FlightFields = []string{
+	"id",
+	"provider",
+	"airline",
+	"flight_number",
+	"departure",
+	"arrival",
+	"duration",
+	"stops",
+	"price",
+	"available_seats",
+	"cabin_class",
+	"aircraft",
+	"amenities",
+	"baggage",
+	"accessibility",
+	"offers",
+	"fare_family",
+	"fare_rules",
+	"fare_options",
+}
+
+// IsFlightField reports whether name is a selectable flight field.
+func IsFlightField(name string) bool {
+	for _, f := range FlightFields {
+		if f == strings.ToLower(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectedSearchResponseDTO is a search response whose flights contain only
+// the requested fields.
+type ProjectedSearchResponseDTO struct {
+	SearchCriteria SearchCriteriaDTO            `json:"search_criteria"`
+	Metadata       MetadataDTO                  `json:"metadata"`
+	Flights        []map[string]json.RawMessage `json:"flights"`
+	ReturnFlights  []map[string]json.RawMessage `json:"return_flights,omitempty"`
+	Trip           *TripDTO                     `json:"trip,omitempty"`
+	Itineraries    []ItineraryDTO               `json:"itineraries,omitempty"`
+	DateSummaries  []DateSummaryDTO             `json:"date_summaries,omitempty"`
+}
+
+// ProjectSearchResponse limits each flight in dto to the given fields.
+// The flight "id" is always included. Unknown field names are ignored.
+func ProjectSearchResponse(dto *SearchResponseDTO, fields []string) (*ProjectedSearchResponseDTO, error) {
+	keep := fieldSet(fields)
+
+	projected := &ProjectedSearchResponseDTO{
+		SearchCriteria: dto.SearchCriteria,
+		Metadata:       dto.Metadata,
+		Trip:           dto.Trip,
+		Itineraries:    dto.Itineraries,
+		DateSummaries:  dto.DateSummaries,
+	}
+
+	var err error
+	if projected.Flights, err = projectFlights(dto.Flights, keep); err != nil {
+		return nil, err
+	}
+	if len(dto.ReturnFlights) > 0 {
+		if projected.ReturnFlights, err = projectFlights(dto.ReturnFlights, keep); err != nil {
+			return nil, err
+		}
+	}
+
+	return projected, nil
+}
+
+// fieldSet returns the flight fields to keep: the given ones and "id".
+func fieldSet(fields []string) map[string]bool {
+	keep := map[string]bool{"id": true}
+	for _, f := range fields {
+		keep[strings.ToLower(f)] = true
+	}
+	return keep
+}
+
+// projectFlights limits each flight to the fields in keep.
+func projectFlights(flights []FlightDTO, keep map[string]bool) ([]map[string]json.RawMessage, error) {
+	projected := make([]map[string]json.RawMessage, 0, len(flights))
+	for _, flight := range flights {
+		data, err := json.Marshal(flight)
+		if err != nil {
+			return nil, err
+		}
+
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+
+		selected := make(map[string]json.RawMessage, len(keep))
+		for name, value := range all {
+			if keep[name] {
+				selected[name] = value
+			}
+		}
+		projected = append(projected, selected)
+	}
+	return projected, nil
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// GroupQuoteResponseDTO answers searches for parties too large for a regular
+// search with indicative group fares instead of flights.
+type GroupQuoteResponseDTO struct {
+	SearchCriteria SearchCriteriaDTO     `json:"search_criteria"`
+	Metadata       GroupQuoteMetadataDTO `json:"metadata"`
+	GroupQuotes    []GroupQuoteDTO       `json:"group_quotes"`
+}
+
+// GroupQuoteMetadataDTO contains metadata about a group quote request.
+type GroupQuoteMetadataDTO struct {
+	TotalResults       int   `json:"total_results"`
+	ProvidersQueried   int   `json:"providers_queried"`
+	ProvidersSucceeded int   `json:"providers_succeeded"`
+	ProvidersFailed    int   `json:"providers_failed"`
+	SearchTimeMs       int64 `json:"search_time_ms"`
+}
+
+// GroupQuoteDTO is an airline's indicative group fare and the group desk that
+// confirms it.
+type GroupQuoteDTO struct {
+	Provider         string          `json:"provider"`
+	Airline          AirlineDTO      `json:"airline"`
+	Reference        string          `json:"reference"`
+	FarePerPassenger PriceDTO        `json:"fare_per_passenger"`
+	TotalFare        PriceDTO        `json:"total_fare"`
+	ValidUntil       string          `json:"valid_until"`
+	Contact          GroupContactDTO `json:"contact"`
+}
+
+// GroupContactDTO is the follow-up contact of a group quote.
+type GroupContactDTO struct {
+	Email         string `json:"email"`
+	Phone         string `json:"phone,omitempty"`
+	ResponseHours int    `json:"response_hours"`
+}
+
+// ToGroupQuoteResponseDTO converts a domain GroupQuoteResponse to a GroupQuoteResponseDTO.
+func ToGroupQuoteResponseDTO(resp *domain.GroupQuoteResponse) *GroupQuoteResponseDTO {
+	if resp == nil {
+		return nil
+	}
+
+	dto := &GroupQuoteResponseDTO{
+		SearchCriteria: SearchCriteriaDTO{
+			Origin:        resp.SearchCriteria.Origin,
+			Destination:   resp.SearchCriteria.Destination,
+			DepartureDate: resp.SearchCriteria.DepartureDate,
+			Passengers:    resp.SearchCriteria.Passengers,
+			CabinClass:    resp.SearchCriteria.CabinClass,
+		},
+		Metadata: GroupQuoteMetadataDTO{
+			TotalResults:       len(resp.Quotes),
+			ProvidersQueried:   resp.Metadata.ProvidersQueried,
+			ProvidersSucceeded: resp.Metadata.ProvidersSucceeded,
+			ProvidersFailed:    resp.Metadata.ProvidersFailed,
+			SearchTimeMs:       resp.Metadata.SearchTimeMs,
+		},
+		GroupQuotes: make([]GroupQuoteDTO, len(resp.Quotes)),
+	}
+
+	for i, q := range resp.Quotes {
+		dto.GroupQuotes[i] = GroupQuoteDTO{
+			Provider:         q.Provider,
+			Airline:          AirlineDTO{Name: q.Airline.Name, Code: q.Airline.Code},
+			Reference:        q.Reference,
+			FarePerPassenger: PriceDTO{Amount: q.FarePerPassenger.Amount, Currency: q.FarePerPassenger.Currency},
+			TotalFare:        PriceDTO{Amount: q.TotalFare.Amount, Currency: q.TotalFare.Currency},
+			ValidUntil:       q.ValidUntil.UTC().Format(time.RFC3339),
+			Contact: GroupContactDTO{
+				Email:         q.Contact.Email,
+				Phone:         q.Contact.Phone,
+				ResponseHours: q.Contact.ResponseHours,
+			},
+		}
+	}
+
+	return dto
+}
@@ -5,24 +5,110 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 
 	"github.com/labstack/echo/v4"
 
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/duplicate"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/flightindex"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/metrics"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/sanitize"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/tenant"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
 )
 
+// LocaleQueryParam is the query parameter used to select the response locale.
+// It takes precedence over the Accept-Language header.
+const LocaleQueryParam = "locale"
+
+// SearchHintHeader is the response header carrying hints on how the client
+// could search more efficiently.
+const SearchHintHeader = "X-Search-Hint"
+
+// SearchHintReuseSession is the search hint sent when a tenant repeats an
+// identical search shortly after the previous one: the client should reuse
+// the results it already has rather than searching again.
+const SearchHintReuseSession = "reuse-session"
+
 // FlightHandler handles HTTP requests for flight-related endpoints.
 type FlightHandler struct {
-	useCase usecase.FlightSearchUseCase
+	useCase     usecase.FlightSearchUseCase
+	groupQuotes usecase.GroupQuoteUseCase
+	sanitizer   *sanitize.Sanitizer
+	codes       CodeValidation
+	duplicates  *duplicate.Tracker
+	flights     *flightindex.Index
+	async       *AsyncSearches
+}
+
+// FlightHandlerOption configures a FlightHandler.
+type FlightHandlerOption func(*FlightHandler)
+
+// WithGroupQuotes answers searches for more than domain.MaxPassengers
+// passengers with group quotes. Without it, such searches are rejected.
+func WithGroupQuotes(uc usecase.GroupQuoteUseCase) FlightHandlerOption {
+	return func(h *FlightHandler) {
+		h.groupQuotes = uc
+	}
+}
+
+// WithSanitizer cleans the provider free text of search responses with s
+// instead of the sanitize.DefaultConfig sanitizer.
+func WithSanitizer(s *sanitize.Sanitizer) FlightHandlerOption {
+	return func(h *FlightHandler) {
+		h.sanitizer = s
+	}
+}
+
+// WithCodeValidation validates the airport and airline codes of search
+// requests in the given mode instead of CodeValidationStrict.
+func WithCodeValidation(mode CodeValidation) FlightHandlerOption {
+	return func(h *FlightHandler) {
+		h.codes = mode
+	}
+}
+
+// WithDuplicateTracking counts the identical searches API key tenants repeat
+// within the tracker's window, and hints at reusing the results of the
+// previous search in responses to repeats.
+func WithDuplicateTracking(t *duplicate.Tracker) FlightHandlerOption {
+	return func(h *FlightHandler) {
+		h.duplicates = t
+	}
+}
+
+// WithFlightDetails serves the details of the flights of recent searches
+// remembered in index. Without it, flight details are never found.
+func WithFlightDetails(index *flightindex.Index) FlightHandlerOption {
+	return func(h *FlightHandler) {
+		h.flights = index
+	}
+}
+
+// WithAsyncSearches runs searches with a callbackUrl in the background with
+// a, answering them at once. Without it, such searches are rejected.
+func WithAsyncSearches(a *AsyncSearches) FlightHandlerOption {
+	return func(h *FlightHandler) {
+		h.async = a
+	}
 }
 
 // NewFlightHandler creates a new FlightHandler with the given use case.
-func NewFlightHandler(uc usecase.FlightSearchUseCase) *FlightHandler {
-	return &FlightHandler{
-		useCase: uc,
+func NewFlightHandler(uc usecase.FlightSearchUseCase, opts ...FlightHandlerOption) *FlightHandler {
+	h := &FlightHandler{
+		useCase:   uc,
+		sanitizer: sanitize.New(sanitize.DefaultConfig()),
+		codes:     CodeValidationStrict,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 // SearchFlights handles POST /api/v1/flights/search
@@ -32,9 +118,15 @@ func NewFlightHandler(uc usecase.FlightSearchUseCase) *FlightHandler {
 //	@Tags			flights
 //	@Accept			json
 //	@Produce		json
+//	@Param			locale			query		string					false	"Locale for airline/airport display names (en, id). Overrides Accept-Language."
+//	@Param			Accept-Language	header		string					false	"Preferred language for airline/airport display names"
+//	@Param			X-API-Key		header		string					false	"Partner API key. Its configured defaults apply when sortBy, maxResults, or fields are omitted."
+//	@Param			Authorization	header		string					false	"OAuth2 bearer token, instead of X-API-Key when AUTH_MODE is jwt. Its scopes grant the caller's roles."
 //	@Param			request	body		SearchFlightsRequest	true	"Search criteria with optional filters. Example with all filters: {\"origin\":\"CGK\",\"destination\":\"DPS\",\"departureDate\":\"2025-12-15\",\"passengers\":1,\"class\":\"economy\",\"filters\":{\"maxPrice\":1200000,\"maxStops\":1,\"airlines\":[\"GA\",\"JT\"],\"departureTimeRange\":{\"start\":\"06:00\",\"end\":\"18:00\"},\"arrivalTimeRange\":{\"start\":\"08:00\",\"end\":\"20:00\"},\"durationRange\":{\"minMinutes\":60,\"maxMinutes\":240}},\"sortBy\":\"best\"}"
-//	@Success		200		{object}	SwaggerSearchResponse	"Successful search with flight results. Returns empty array if no flights match filters."
+//	@Success		200		{object}	SwaggerSearchResponse	"Successful search with flight results. Returns empty array if no flights match filters. Searches for more than 9 passengers return group quotes instead (see GroupQuoteResponseDTO)."
+//	@Success		202		{object}	AsyncSearchAcceptedDTO	"Asynchronous search accepted - the request has a callbackUrl, and the results are posted to it under the returned search_id (see SearchCallbackDTO)"
 //	@Failure		400		{object}	SwaggerErrorResponse	"Validation error - invalid request parameters (e.g., invalid time format, minMinutes > maxMinutes, missing required fields)"
+//	@Failure		413		{object}	SwaggerErrorResponse	"Request body too large - larger than SERVER_MAX_REQUEST_BODY_BYTES, reported in details.maxBytes"
 //	@Failure		503		{object}	SwaggerErrorResponse	"Service unavailable - all providers failed"
 //	@Failure		504		{object}	SwaggerErrorResponse	"Gateway timeout - request took too long"
 //	@Router			/flights/search [post]
@@ -47,62 +139,251 @@ func (h *FlightHandler) SearchFlights(c echo.Context) error {
 	}
 
 	// Validate request
-	if err := req.Validate(); err != nil {
+	if err := req.ValidateWith(h.codes); err != nil {
 		return h.handleValidationError(c, err)
 	}
 
+	// Searches with a callback must be searches, run in the background
+	if req.CallbackURL != "" {
+		if h.async == nil {
+			return response.ValidationError(c, map[string]string{
+				"callbackUrl": "asynchronous searches are not enabled",
+			})
+		}
+		if req.IsGroup() {
+			return response.ValidationError(c, map[string]string{
+				"callbackUrl": fmt.Sprintf("callbackUrl is not supported for more than %d passengers", domain.MaxPassengers),
+			})
+		}
+	}
+
+	// Parties too large for a search get group quotes instead
+	if req.IsGroup() {
+		return h.quoteGroup(c, &req)
+	}
+
+	// Fill omitted parameters from the API key's defaults
+	profile, hasProfile := tenant.FromContext(c.Request().Context())
+	if hasProfile {
+		applyTenantDefaults(&req, profile.Defaults)
+	}
+	if req.CallbackURL != "" {
+		return h.submitAsync(c, &req)
+	}
+
 	// Convert to domain types
 	criteria := ToDomainCriteria(&req)
 	opts := ToSearchOptions(&req)
 
-	// Call use case with request context
+	// Count repeated searches before they are coalesced
+	if hasProfile {
+		h.observeDuplicate(c, profile, criteria, opts.Filters)
+	}
+
+	// Call use case with request context, so the search is aborted if the
+	// client disconnects
 	result, err := h.useCase.Search(c.Request().Context(), criteria, opts)
 	if err != nil {
+		if errors.Is(err, domain.ErrSearchAborted) {
+			metrics.SearchesAborted.Inc()
+		}
 		return h.handleError(c, err)
 	}
+	metrics.ObserveFilterRejections(result.Metadata.FilterRejections)
 
-	// Convert to DTO format matching expected output
-	dto := ToSearchResponseDTO(result)
+	// Convert to DTO format matching expected output, localized if requested
+	dto := ToSearchResponseDTOLocalized(result, responseLocale(c))
+	SanitizeSearchResponseDTO(dto, h.sanitizer)
+
+	// Limit flights to the requested fields
+	if len(req.Fields) > 0 {
+		projected, err := ProjectSearchResponse(dto, req.Fields)
+		if err != nil {
+			return response.InternalServerError(c)
+		}
+		return response.SearchResults(c, projected)
+	}
 
 	// Return successful response
 	return response.SearchResults(c, dto)
 }
 
+// GetFlight handles GET /api/v1/flights/:id
+// It returns the full details of a flight of a recent search by its ID, from
+// the most recent search returning it, localized like search results.
+func (h *FlightHandler) GetFlight(c echo.Context) error {
+	if h.flights == nil {
+		return response.NotFound(c, response.MsgFlightNotFound)
+	}
+	entry, ok := h.flights.Get(c.Param("id"))
+	if !ok {
+		return response.NotFound(c, response.MsgFlightNotFound)
+	}
+
+	dto := ToFlightDetailsDTO(entry, responseLocale(c))
+	sanitizeFlightDTO(&dto.Flight, h.sanitizer)
+	return response.OK(c, dto)
+}
+
+// observeDuplicate records a search of the tenant and, if it repeats an
+// identical search issued within the tracking window, hints at reusing the
+// previous results.
+func (h *FlightHandler) observeDuplicate(c echo.Context, profile tenant.Profile, criteria domain.SearchCriteria, filters *domain.FilterOptions) {
+	if h.duplicates == nil {
+		return
+	}
+	repeat := h.duplicates.Observe(profile.Key, domain.SearchHash(criteria, filters))
+	metrics.ObserveDuplicateSearch(tenantLabel(profile), repeat.Duplicate, repeat.Since)
+	if repeat.Duplicate {
+		c.Response().Header().Set(SearchHintHeader, SearchHintReuseSession)
+	}
+}
+
+// tenantLabel returns the metric label of a tenant: its name, never its API key.
+func tenantLabel(profile tenant.Profile) string {
+	if profile.Name == "" {
+		return "unnamed"
+	}
+	return profile.Name
+}
+
+// quoteGroup answers a validated search request for a group with the group
+// quote providers' indicative fares.
+func (h *FlightHandler) quoteGroup(c echo.Context, req *SearchFlightsRequest) error {
+	if h.groupQuotes == nil {
+		return response.ValidationError(c, map[string]string{
+			"passengers": fmt.Sprintf("passengers cannot exceed %d", domain.MaxPassengers),
+		})
+	}
+
+	result, err := h.groupQuotes.Quote(c.Request().Context(), ToDomainCriteria(req))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return response.OK(c, ToGroupQuoteResponseDTO(result))
+}
+
+// submitAsync queues a validated search request with a callbackUrl and
+// answers with the ID its results will be posted under.
+func (h *FlightHandler) submitAsync(c echo.Context, req *SearchFlightsRequest) error {
+	searchID, err := h.async.Submit(c.Request().Context(), req, requestLocale(c))
+	if err != nil {
+		return response.InternalServerError(c)
+	}
+	return response.Accepted(c, &AsyncSearchAcceptedDTO{SearchID: searchID, Status: AsyncSearchAccepted})
+}
+
+// applyTenantDefaults fills the sort option, result limit, and fields that the
+// request omitted from the API key's defaults. Explicit request values win.
+func applyTenantDefaults(req *SearchFlightsRequest, defaults tenant.Defaults) {
+	if req.SortBy == "" {
+		req.SortBy = defaults.SortBy
+	}
+	if req.MaxResults == nil && defaults.MaxResults > 0 {
+		limit := defaults.MaxResults
+		req.MaxResults = &limit
+	}
+	if req.FanOutStrategy == "" {
+		req.FanOutStrategy = defaults.FanOutStrategy
+	}
+	if len(req.Fields) == 0 {
+		for _, f := range defaults.Fields {
+			if IsFlightField(f) {
+				req.Fields = append(req.Fields, f)
+			}
+		}
+	}
+}
+
+// requestLocale determines the response locale from the locale query parameter
+// or the Accept-Language header. Returns an empty locale if none is supported.
+func requestLocale(c echo.Context) reference.Locale {
+	if loc, ok := reference.ParseLocale(c.QueryParam(LocaleQueryParam)); ok {
+		return loc
+	}
+	if loc, ok := reference.ParseAcceptLanguage(c.Request().Header.Get("Accept-Language")); ok {
+		return loc
+	}
+	return ""
+}
+
+// responseLocale returns the locale of the response: the requested one,
+// unless localization is shed under load.
+func responseLocale(c echo.Context) reference.Locale {
+	if !loadshed.Allowed(c.Request().Context(), loadshed.FeatureLocalization) {
+		return ""
+	}
+	return requestLocale(c)
+}
+
 // handleValidationError handles validation errors and returns a 400 response.
 func (h *FlightHandler) handleValidationError(c echo.Context, err error) error {
+	return c.JSON(http.StatusBadRequest, validationErrorDetail(err))
+}
+
+// validationErrorDetail returns the response body reporting a validation error.
+func validationErrorDetail(err error) *response.ErrorDetail {
 	var validationErrs *ValidationErrors
 	if errors.As(err, &validationErrs) {
-		return response.ValidationError(c, validationErrs.ToMap())
+		return &response.ErrorDetail{
+			Code:    response.CodeValidationError,
+			Message: response.MsgValidationFailed,
+			Details: validationErrs.ToMap(),
+		}
 	}
 
 	// Fallback for non-structured validation errors
-	return response.ValidationErrorWithMessage(c, err.Error())
+	return &response.ErrorDetail{Code: response.CodeValidationError, Message: err.Error()}
 }
 
 // handleError maps domain errors to appropriate HTTP responses.
 func (h *FlightHandler) handleError(c echo.Context, err error) error {
-	// Check for all providers failed
-	if errors.Is(err, domain.ErrAllProvidersFailed) {
-		return response.ServiceUnavailable(c)
-	}
+	return writeDomainError(c, err)
+}
 
-	// Check for context deadline exceeded (timeout)
-	if errors.Is(err, context.DeadlineExceeded) {
-		return response.GatewayTimeout(c)
-	}
+// writeDomainError maps domain errors to appropriate HTTP responses.
+// It is shared by all handlers so errors are reported consistently.
+func writeDomainError(c echo.Context, err error) error {
+	status, detail := domainErrorDetail(err)
+	return c.JSON(status, detail)
+}
 
-	// Check for context cancelled
-	if errors.Is(err, context.Canceled) {
-		return response.RequestCancelled(c)
-	}
+// domainErrorDetail maps a domain error to the status and body of its HTTP
+// response.
+func domainErrorDetail(err error) (int, *response.ErrorDetail) {
+	switch {
+	// Unknown stored search
+	case errors.Is(err, domain.ErrSearchNotFound):
+		return http.StatusNotFound, &response.ErrorDetail{Code: response.CodeNotFound, Message: response.MsgSearchNotFound}
 
-	// Check for invalid request (domain validation)
-	if errors.Is(err, domain.ErrInvalidRequest) {
-		return response.ValidationErrorWithMessage(c, err.Error())
-	}
+	// Unknown or expired continuation token
+	case errors.Is(err, domain.ErrContinuationNotFound):
+		return http.StatusNotFound, &response.ErrorDetail{Code: response.CodeNotFound, Message: response.MsgContinuationNotFound}
+
+	// Invalid or expired share token
+	case errors.Is(err, domain.ErrShareNotFound):
+		return http.StatusNotFound, &response.ErrorDetail{Code: response.CodeNotFound, Message: response.MsgShareNotFound}
+
+	// All providers failed
+	case errors.Is(err, domain.ErrAllProvidersFailed):
+		return http.StatusServiceUnavailable, &response.ErrorDetail{Code: response.CodeServiceUnavailable, Message: response.MsgServiceUnavailable}
+
+	// Context deadline exceeded (timeout)
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, &response.ErrorDetail{Code: response.CodeTimeout, Message: response.MsgTimeout}
+
+	// Context cancelled
+	case errors.Is(err, context.Canceled):
+		return http.StatusGatewayTimeout, &response.ErrorDetail{Code: response.CodeTimeout, Message: response.MsgRequestCancelled}
+
+	// Invalid request (domain validation)
+	case errors.Is(err, domain.ErrInvalidRequest):
+		return http.StatusBadRequest, &response.ErrorDetail{Code: response.CodeValidationError, Message: err.Error()}
 
 	// Default to internal server error
-	return response.InternalServerError(c)
+	default:
+		return http.StatusInternalServerError, &response.ErrorDetail{Code: response.CodeInternalError, Message: response.MsgInternalError}
+	}
 }
 
 // Health handles GET /health
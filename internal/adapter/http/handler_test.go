@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,11 +13,22 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/middleware"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/duplicate"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/flightindex"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/metrics"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/sanitize"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/tenant"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
 )
 
@@ -403,6 +415,78 @@ func TestSearchFlights_InvalidPassengers(t *testing.T) {
 	}
 }
 
+// groupQuoteFunc adapts a function to usecase.GroupQuoteUseCase.
+type groupQuoteFunc func(ctx context.Context, criteria domain.SearchCriteria) (*domain.GroupQuoteResponse, error)
+
+func (f groupQuoteFunc) Quote(ctx context.Context, criteria domain.SearchCriteria) (*domain.GroupQuoteResponse, error) {
+	return f(ctx, criteria)
+}
+
+func TestSearchFlights_GroupQuote(t *testing.T) {
+	validUntil := time.Date(2025, 12, 3, 10, 0, 0, 0, time.UTC)
+	quotes := groupQuoteFunc(func(_ context.Context, criteria domain.SearchCriteria) (*domain.GroupQuoteResponse, error) {
+		resp := domain.NewGroupQuoteResponse(&criteria, []domain.GroupQuote{{
+			Provider:         "garuda_indonesia",
+			Airline:          domain.AirlineInfo{Code: "GA", Name: "Garuda Indonesia"},
+			Reference:        "GA-GRP-CGKDPS-20251215-25",
+			FarePerPassenger: domain.PriceInfo{Amount: 1350000, Currency: "IDR"},
+			TotalFare:        domain.PriceInfo{Amount: 33750000, Currency: "IDR"},
+			ValidUntil:       validUntil,
+			Contact:          domain.GroupContact{Email: "groups@garuda-indonesia.example", ResponseHours: 24},
+		}}, domain.GroupQuoteMetadata{ProvidersQueried: 1, ProvidersSucceeded: 1})
+		return &resp, nil
+	})
+	searched := false
+	e := echo.New()
+	RegisterRoutes(e, NewFlightHandler(&mockUseCase{
+		searchFunc: func(context.Context, domain.SearchCriteria, usecase.SearchOptions) (*domain.SearchResponse, error) {
+			searched = true
+			return nil, errors.New("unexpected search")
+		},
+	}, WithGroupQuotes(quotes)))
+
+	req := SearchFlightsRequest{Origin: "CGK", Destination: "DPS", DepartureDate: getFutureDate(), Passengers: 25}
+	rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.False(t, searched, "groups are quoted, not searched")
+
+	var resp GroupQuoteResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 25, resp.SearchCriteria.Passengers)
+	assert.Equal(t, 1, resp.Metadata.TotalResults)
+	require.Len(t, resp.GroupQuotes, 1)
+	assert.Equal(t, "GA-GRP-CGKDPS-20251215-25", resp.GroupQuotes[0].Reference)
+	assert.Equal(t, "2025-12-03T10:00:00Z", resp.GroupQuotes[0].ValidUntil)
+	assert.Equal(t, "groups@garuda-indonesia.example", resp.GroupQuotes[0].Contact.Email)
+
+	t.Run("return leg rejected", func(t *testing.T) {
+		req.Return = &ReturnLegDTO{Origin: "DPS", Destination: "CGK", DepartureDate: getFutureDate()}
+		rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "return is not supported")
+	})
+
+	t.Run("above group limit", func(t *testing.T) {
+		req.Return = nil
+		req.Passengers = domain.MaxGroupPassengers + 1
+		rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "cannot exceed 99")
+	})
+}
+
+func TestSearchFlights_GroupQuoteProvidersFailed(t *testing.T) {
+	quotes := groupQuoteFunc(func(context.Context, domain.SearchCriteria) (*domain.GroupQuoteResponse, error) {
+		return nil, domain.ErrAllProvidersFailed
+	})
+	e := echo.New()
+	RegisterRoutes(e, NewFlightHandler(&mockUseCase{}, WithGroupQuotes(quotes)))
+
+	req := SearchFlightsRequest{Origin: "CGK", Destination: "DPS", DepartureDate: getFutureDate(), Passengers: 12}
+	rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
 func TestSearchFlights_InvalidClass(t *testing.T) {
 	e, _ := setupTestHandler(&mockUseCase{})
 
@@ -445,6 +529,48 @@ func TestSearchFlights_InvalidSortBy(t *testing.T) {
 	assert.Contains(t, errResp.Details, "sortBy")
 }
 
+func TestSearchFlights_InvalidSortOrder(t *testing.T) {
+	e, _ := setupTestHandler(&mockUseCase{})
+
+	req := SearchFlightsRequest{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: getFutureDate(),
+		Passengers:    1,
+		SortOrder:     "descending",
+	}
+
+	rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var errResp response.ErrorDetail
+	err := json.Unmarshal(rec.Body.Bytes(), &errResp)
+	require.NoError(t, err)
+	assert.Contains(t, errResp.Details, "sortOrder")
+}
+
+func TestSearchFlights_InvalidFanOutStrategy(t *testing.T) {
+	e, _ := setupTestHandler(&mockUseCase{})
+
+	req := SearchFlightsRequest{
+		Origin:         "CGK",
+		Destination:    "DPS",
+		DepartureDate:  getFutureDate(),
+		Passengers:     1,
+		FanOutStrategy: "round-robin",
+	}
+
+	rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var errResp response.ErrorDetail
+	err := json.Unmarshal(rec.Body.Bytes(), &errResp)
+	require.NoError(t, err)
+	assert.Contains(t, errResp.Details, "fanOutStrategy")
+}
+
 func TestSearchFlights_AllProvidersFailed(t *testing.T) {
 	mock := &mockUseCase{
 		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
@@ -497,6 +623,29 @@ func TestSearchFlights_Timeout(t *testing.T) {
 	assert.Equal(t, response.CodeTimeout, errResp.Code)
 }
 
+func TestSearchFlights_Aborted(t *testing.T) {
+	mock := &mockUseCase{
+		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			return nil, fmt.Errorf("%w: %w", domain.ErrSearchAborted, context.Canceled)
+		},
+	}
+
+	e, _ := setupTestHandler(mock)
+	before := testutil.ToFloat64(metrics.SearchesAborted)
+
+	req := SearchFlightsRequest{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: getFutureDate(),
+		Passengers:    1,
+	}
+
+	rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	assert.Equal(t, before+1, testutil.ToFloat64(metrics.SearchesAborted))
+}
+
 func TestSearchFlights_EmptyResults(t *testing.T) {
 	mock := &mockUseCase{
 		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
@@ -585,6 +734,33 @@ func TestSearchFlights_LowercaseOriginDestination(t *testing.T) {
 	assert.Equal(t, "DPS", capturedCriteria.Destination)
 }
 
+func TestSearchFlights_CharterCodeValidation(t *testing.T) {
+	var capturedCriteria domain.SearchCriteria
+	mock := &mockUseCase{
+		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			capturedCriteria = criteria
+			return &domain.SearchResponse{Flights: []domain.Flight{}}, nil
+		},
+	}
+	req := SearchFlightsRequest{
+		Origin:        "WIII", // ICAO code of CGK
+		Destination:   "DPS",
+		DepartureDate: getFutureDate(),
+		Passengers:    1,
+	}
+
+	// Rejected by default
+	e, _ := setupTestHandler(mock)
+	rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	e = echo.New()
+	RegisterRoutes(e, NewFlightHandler(mock, WithCodeValidation(CodeValidationCharter)))
+	rec = makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "CGK", capturedCriteria.Origin)
+}
+
 func TestHealth_Success(t *testing.T) {
 	e, _ := setupTestHandler(&mockUseCase{})
 
@@ -664,6 +840,31 @@ func TestSearchFlightsRequest_Validate(t *testing.T) {
 			wantErr:   true,
 			errFields: []string{"filters.maxPrice", "filters.maxStops"},
 		},
+		{
+			name: "invalid maxResults and fields",
+			request: SearchFlightsRequest{
+				Origin:        "CGK",
+				Destination:   "DPS",
+				DepartureDate: getFutureDate(),
+				Passengers:    1,
+				MaxResults:    intPtr(0),
+				Fields:        []string{"price", "seat_map"},
+			},
+			wantErr:   true,
+			errFields: []string{"maxResults", "fields[1]"},
+		},
+		{
+			name: "invalid maxResultsPerProvider",
+			request: SearchFlightsRequest{
+				Origin:                "CGK",
+				Destination:           "DPS",
+				DepartureDate:         getFutureDate(),
+				Passengers:            1,
+				MaxResultsPerProvider: intPtr(501),
+			},
+			wantErr:   true,
+			errFields: []string{"maxResultsPerProvider"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -729,22 +930,362 @@ func TestToDomainCriteria_Defaults(t *testing.T) {
 	assert.Equal(t, "economy", criteria.Class)
 }
 
+func TestToDomainCriteria_Return(t *testing.T) {
+	req := &SearchFlightsRequest{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: "2025-12-15",
+		Passengers:    1,
+		Return:        &ReturnLegDTO{Origin: "sub", Destination: "CGK", DepartureDate: "2025-12-20"},
+	}
+
+	criteria := ToDomainCriteria(req)
+
+	require.NotNil(t, criteria.Return)
+	assert.Equal(t, domain.ReturnLeg{Origin: "SUB", Destination: "CGK", DepartureDate: "2025-12-20"}, *criteria.Return)
+	assert.True(t, criteria.IsOpenJaw())
+}
+
+func TestToDomainCriteria_ReturnDate(t *testing.T) {
+	req := &SearchFlightsRequest{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: "2025-12-15",
+		Passengers:    1,
+		ReturnDate:    "2025-12-20",
+	}
+
+	criteria := ToDomainCriteria(req)
+
+	require.NotNil(t, criteria.Return)
+	assert.Equal(t, domain.ReturnLeg{Origin: "DPS", Destination: "CGK", DepartureDate: "2025-12-20"}, *criteria.Return)
+	assert.False(t, criteria.IsOpenJaw())
+}
+
+func TestToDomainCriteria_DateFlexibility(t *testing.T) {
+	req := &SearchFlightsRequest{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1, DateFlexibility: 3}
+
+	criteria := ToDomainCriteria(req)
+
+	assert.Equal(t, 3, criteria.DateFlexibility)
+	assert.Nil(t, criteria.Return)
+}
+
+func TestToSearchResponseDTO_FlexibleDates(t *testing.T) {
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1, DateFlexibility: 1}
+	resp := domain.NewSearchResponse(&criteria, nil, domain.SearchMetadata{})
+	resp.DateSummaries = []domain.DateSummary{
+		{DepartureDate: "2025-12-14", LowestPrice: &domain.PriceInfo{Amount: 900000, Currency: "IDR"}, TotalResults: 2},
+		{DepartureDate: "2025-12-15"},
+		{DepartureDate: "2025-12-16", Failed: true},
+	}
+
+	dto := ToSearchResponseDTO(&resp)
+
+	assert.Equal(t, 1, dto.SearchCriteria.DateFlexibility)
+	assert.Equal(t, []DateSummaryDTO{
+		{DepartureDate: "2025-12-14", LowestPrice: &PriceDTO{Amount: 900000, Currency: "IDR"}, TotalResults: 2},
+		{DepartureDate: "2025-12-15"},
+		{DepartureDate: "2025-12-16", Failed: true},
+	}, dto.DateSummaries)
+
+	projected, err := ProjectSearchResponse(dto, []string{"price"})
+	require.NoError(t, err)
+	assert.Equal(t, dto.DateSummaries, projected.DateSummaries)
+}
+
+func TestToSearchResponseDTO_RoundTrip(t *testing.T) {
+	criteria := domain.SearchCriteria{
+		Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1,
+		Return: &domain.ReturnLeg{Origin: "SUB", Destination: "CGK", DepartureDate: "2025-12-20"},
+	}
+	outbound := []domain.Flight{{ID: "GA400", Price: domain.PriceInfo{Amount: 1000000, Currency: "IDR"}}}
+	inbound := []domain.Flight{{ID: "GA311", Price: domain.PriceInfo{Amount: 800000, Currency: "IDR"}}}
+
+	resp := domain.NewSearchResponse(&criteria, outbound, domain.SearchMetadata{})
+	resp.ReturnFlights = inbound
+	resp.Trip = domain.NewTripSummary(&criteria, outbound, inbound)
+	resp.Itineraries = []domain.Itinerary{{
+		OutboundFlightID: "GA400",
+		ReturnFlightID:   "GA311",
+		TotalPrice:       domain.PriceInfo{Amount: 1800000, Currency: "IDR"},
+		TotalMinutes:     220,
+	}}
+
+	dto := ToSearchResponseDTO(&resp)
+
+	require.NotNil(t, dto.SearchCriteria.Return)
+	assert.Equal(t, "SUB", dto.SearchCriteria.Return.Origin)
+	require.Len(t, dto.ReturnFlights, 1)
+	assert.Equal(t, "GA311", dto.ReturnFlights[0].ID)
+	require.NotNil(t, dto.Trip)
+	assert.True(t, dto.Trip.OpenJaw)
+	assert.Equal(t, &PriceDTO{Amount: 1800000, Currency: "IDR"}, dto.Trip.LowestTotal)
+	assert.Equal(t, []ItineraryDTO{{
+		OutboundFlightID: "GA400",
+		ReturnFlightID:   "GA311",
+		TotalPrice:       PriceDTO{Amount: 1800000, Currency: "IDR"},
+		TotalMinutes:     220,
+	}}, dto.Itineraries)
+
+	projected, err := ProjectSearchResponse(dto, []string{"price"})
+	require.NoError(t, err)
+	require.Len(t, projected.ReturnFlights, 1)
+	assert.Contains(t, projected.ReturnFlights[0], "price")
+	assert.NotContains(t, projected.ReturnFlights[0], "airline")
+	assert.Equal(t, dto.Trip, projected.Trip)
+	assert.Equal(t, dto.Itineraries, projected.Itineraries)
+}
+
+func TestToFlightDTO_SeatsAvailable(t *testing.T) {
+	dto := ToFlightDTO(&domain.Flight{ID: "GA400", SeatsAvailable: 28})
+	require.NotNil(t, dto.AvailableSeats)
+	assert.Equal(t, 28, *dto.AvailableSeats)
+
+	dto = ToFlightDTO(&domain.Flight{ID: "GA400"})
+	assert.Nil(t, dto.AvailableSeats, "omitted when the provider did not report seats")
+	assert.Nil(t, dto.FareRules)
+}
+
+func TestGetFlight(t *testing.T) {
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	index := flightindex.New(flightindex.WithClock(clock))
+	index.Add(&domain.SearchResponse{Flights: []domain.Flight{{
+		ID:       "GA400",
+		Provider: "garuda_indonesia",
+		Airline:  domain.AirlineInfo{Code: "GA", Name: "Garuda Indonesia"},
+		Departure: domain.FlightPoint{
+			AirportCode: "CGK",
+			AirportName: "Soekarno-Hatta International Airport",
+			City:        "Jakarta",
+		},
+		Price:   domain.PriceInfo{Amount: 1000000, Currency: "IDR"}.WithBreakdown(850000, 100000),
+		Baggage: domain.BaggageInfo{CabinKg: 7, CheckedKg: 20},
+	}}})
+
+	e := echo.New()
+	RegisterRoutes(e, NewFlightHandler(&mockUseCase{}, WithFlightDetails(index)))
+
+	rec := makeRequest(e, http.MethodGet, "/api/v1/flights/GA400?locale=id", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var result FlightDetailsDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, "GA400", result.Flight.ID)
+	assert.Equal(t, 50000.0, result.Flight.Price.Fees)
+	assert.Equal(t, "20 kg", result.Flight.Baggage.Checked)
+	assert.Equal(t, "Bandar Udara Internasional Soekarno-Hatta", result.Flight.Departure.AirportName, "localized")
+	assert.True(t, clock.Now().Equal(result.SearchedAt))
+
+	rec = makeRequest(e, http.MethodGet, "/api/v1/flights/JT610", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	clock.Advance(flightindex.DefaultTTL)
+	rec = makeRequest(e, http.MethodGet, "/api/v1/flights/GA400", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code, "expired")
+}
+
+func TestGetFlight_Disabled(t *testing.T) {
+	e, _ := setupTestHandler(&mockUseCase{})
+
+	rec := makeRequest(e, http.MethodGet, "/api/v1/flights/GA400", nil)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	var result response.ErrorDetail
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, response.MsgFlightNotFound, result.Message)
+}
+
+func TestToFlightDTO_Airline(t *testing.T) {
+	airline := domain.AirlineInfo{Code: "GA", Name: "Garuda Indonesia", Logo: "https://pics.avs.io/200/200/GA.png", Alliance: "SkyTeam"}
+	dto := ToFlightDTO(&domain.Flight{ID: "GA400", Airline: airline})
+	assert.Equal(t, AirlineDTO{Name: "Garuda Indonesia", Code: "GA", Logo: "https://pics.avs.io/200/200/GA.png", Alliance: "SkyTeam"}, dto.Airline)
+}
+
+func TestToFlightDTO_Airports(t *testing.T) {
+	dto := ToFlightDTO(&domain.Flight{
+		ID: "ID7042",
+		Departure: domain.FlightPoint{
+			AirportCode: "CGK",
+			AirportName: "Soekarno-Hatta International Airport",
+			City:        "Jakarta",
+			Country:     "ID",
+			Timezone:    "Asia/Jakarta",
+		},
+		Arrival: domain.FlightPoint{AirportCode: "XXX"},
+	})
+	assert.Equal(t, "Soekarno-Hatta International Airport", dto.Departure.AirportName)
+	assert.Equal(t, "Jakarta", dto.Departure.City)
+	assert.Equal(t, "ID", dto.Departure.Country)
+	assert.Equal(t, "Asia/Jakarta", dto.Departure.Timezone)
+	assert.Equal(t, "XXX", dto.Arrival.Airport)
+	assert.Empty(t, dto.Arrival.City)
+}
+
+func TestToFlightDTO_TotalPrice(t *testing.T) {
+	price := domain.PriceInfo{Amount: 1000000, Currency: "IDR"}.WithBreakdown(850000, 100000).ForPassengers(2)
+	dto := ToFlightDTO(&domain.Flight{ID: "GA400", Price: price, Offers: []domain.Offer{{FlightID: "GA400", Price: price}}})
+	want := PriceDTO{
+		Amount:     1000000,
+		Currency:   "IDR",
+		BaseFare:   850000,
+		Taxes:      100000,
+		Fees:       50000,
+		Passengers: 2,
+		Total:      2000000,
+	}
+	assert.Equal(t, want, dto.Price)
+	assert.Equal(t, want, dto.Offers[0].Price)
+}
+
+func TestToFlightDTO_FareRules(t *testing.T) {
+	dto := ToFlightDTO(&domain.Flight{ID: "GA400", FareRules: &domain.FareRules{
+		Changeable: true,
+		ChangeFee:  &domain.PriceInfo{Amount: 150000, Currency: "IDR"},
+	}})
+	assert.Equal(t, &FareRulesDTO{Changeable: true, ChangeFee: &PriceDTO{Amount: 150000, Currency: "IDR"}}, dto.FareRules)
+}
+
 func TestToDomainFilters(t *testing.T) {
+	minPrice := float64(1)
 	maxPrice := float64(1500000)
 	maxStops := 1
+	minSeats := 4
+
+	changeable := true
+	higherClasses := true
 
 	dto := &FilterDTO{
-		MaxPrice: &maxPrice,
-		MaxStops: &maxStops,
-		Airlines: []string{"GA", "JT"},
+		MinPrice:             &minPrice,
+		MaxPrice:             &maxPrice,
+		MaxStops:             &maxStops,
+		MinSeatsAvailable:    &minSeats,
+		Airlines:             []string{"GA", "JT"},
+		FareFamilies:         []string{"Flex"},
+		Changeable:           &changeable,
+		RefundableOnly:       &changeable,
+		IncludeHigherClasses: &higherClasses,
 	}
 
 	filters := ToDomainFilters(dto)
 
 	require.NotNil(t, filters)
+	assert.Equal(t, &minPrice, filters.MinPrice)
 	assert.Equal(t, &maxPrice, filters.MaxPrice)
 	assert.Equal(t, &maxStops, filters.MaxStops)
+	assert.Equal(t, &minSeats, filters.MinSeatsAvailable)
 	assert.Equal(t, []string{"GA", "JT"}, filters.Airlines)
+	assert.Equal(t, []string{"Flex"}, filters.FareFamilies)
+	assert.True(t, filters.Changeable)
+	assert.False(t, filters.Refundable)
+	assert.True(t, filters.RefundableOnly)
+	assert.True(t, filters.IncludeHigherClasses)
+	assert.Empty(t, filters.Class, "the use case filters by the class searched for")
+}
+
+func TestSearchFlights_SpecialServices(t *testing.T) {
+	var gotOpts usecase.SearchOptions
+	e, _ := setupTestHandler(&mockUseCase{
+		searchFunc: func(_ context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			gotOpts = opts
+			resp := domain.NewSearchResponse(&criteria, []domain.Flight{{
+				ID: "GA400",
+				Accessibility: &domain.AccessibilityInfo{
+					Services: []domain.SpecialService{domain.ServiceWheelchair},
+					Source:   domain.AccessibilitySourcePolicy,
+				},
+			}}, domain.SearchMetadata{})
+			return &resp, nil
+		},
+	})
+
+	supports := true
+	req := SearchFlightsRequest{
+		Origin: "CGK", Destination: "DPS", DepartureDate: getFutureDate(), Passengers: 1,
+		SpecialServices: []string{"Wheelchair", "service_animal"},
+		Filters:         &FilterDTO{SupportsWheelchair: &supports},
+	}
+	rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	assert.Equal(t, []domain.SpecialService{domain.ServiceWheelchair, domain.ServiceServiceAnimal}, gotOpts.SpecialServices)
+	require.NotNil(t, gotOpts.Filters)
+	assert.True(t, gotOpts.Filters.SupportsWheelchair)
+
+	var resp SearchResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Flights, 1)
+	assert.Equal(t, &AccessibilityDTO{Services: []string{"wheelchair"}, Source: "policy"}, resp.Flights[0].Accessibility)
+
+	t.Run("unknown service", func(t *testing.T) {
+		req.SpecialServices = []string{"jetpack"}
+		rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "specialServices[0]")
+	})
+}
+
+func TestSearchFlights_Offers(t *testing.T) {
+	e, _ := setupTestHandler(&mockUseCase{
+		searchFunc: func(_ context.Context, criteria domain.SearchCriteria, _ usecase.SearchOptions) (*domain.SearchResponse, error) {
+			price := domain.PriceInfo{Amount: 1200000, Currency: "IDR"}
+			resp := domain.NewSearchResponse(&criteria, []domain.Flight{{
+				ID: "lion-1", Provider: "lionair", Price: price,
+				Offers: []domain.Offer{
+					{FlightID: "lion-1", Provider: "lionair", Price: price},
+					{FlightID: "ga-1", Provider: "garuda", Price: domain.PriceInfo{Amount: 1500000, Currency: "IDR"}},
+				},
+			}}, domain.SearchMetadata{DuplicatesMerged: 1})
+			return &resp, nil
+		},
+	})
+
+	req := SearchFlightsRequest{Origin: "CGK", Destination: "DPS", DepartureDate: getFutureDate(), Passengers: 1}
+	rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp SearchResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Metadata.DuplicatesMerged)
+	require.Len(t, resp.Flights, 1)
+	assert.Equal(t, []OfferDTO{
+		{FlightID: "lion-1", Provider: "lionair", Price: PriceDTO{Amount: 1200000, Currency: "IDR"}},
+		{FlightID: "ga-1", Provider: "garuda", Price: PriceDTO{Amount: 1500000, Currency: "IDR"}},
+	}, resp.Flights[0].Offers)
+}
+
+func TestSearchFlights_FareOptions(t *testing.T) {
+	e, _ := setupTestHandler(&mockUseCase{
+		searchFunc: func(_ context.Context, criteria domain.SearchCriteria, _ usecase.SearchOptions) (*domain.SearchResponse, error) {
+			lite := domain.FareFamily{Name: "Lite"}
+			flex := domain.FareFamily{Name: "Flex", Changeable: true, Refundable: true}
+			price := domain.PriceInfo{Amount: 1050000, Currency: "IDR"}
+			resp := domain.NewSearchResponse(&criteria, []domain.Flight{{
+				ID: "GA400-lite", Provider: "garuda", Price: price, FareFamily: &lite,
+				FareOptions: []domain.FareOption{
+					{FlightID: "GA400-lite", FareFamily: lite, Price: price, Baggage: domain.BaggageInfo{CabinKg: 10}},
+					{FlightID: "GA400-flex", FareFamily: flex, Price: domain.PriceInfo{Amount: 1650000, Currency: "IDR"}, Baggage: domain.BaggageInfo{CabinKg: 10, CheckedKg: 40}},
+				},
+			}}, domain.SearchMetadata{})
+			return &resp, nil
+		},
+	})
+
+	req := SearchFlightsRequest{Origin: "CGK", Destination: "DPS", DepartureDate: getFutureDate(), Passengers: 1}
+	rec := makeRequest(e, http.MethodPost, "/api/v1/flights/search", req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp SearchResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Flights, 1)
+	assert.Equal(t, &FareFamilyDTO{Name: "Lite"}, resp.Flights[0].FareFamily)
+	assert.Equal(t, []FareOptionDTO{
+		{FlightID: "GA400-lite", FareFamily: FareFamilyDTO{Name: "Lite"}, Price: PriceDTO{Amount: 1050000, Currency: "IDR"}, Baggage: BaggageDTO{CarryOn: "10 kg"}},
+		{
+			FlightID:   "GA400-flex",
+			FareFamily: FareFamilyDTO{Name: "Flex", Changeable: true, Refundable: true},
+			Price:      PriceDTO{Amount: 1650000, Currency: "IDR"},
+			Baggage:    BaggageDTO{CarryOn: "10 kg", Checked: "40 kg"},
+		},
+	}, resp.Flights[0].FareOptions)
 }
 
 func TestToDomainFilters_Nil(t *testing.T) {
@@ -771,6 +1312,15 @@ func TestToDomainFilters_WithTimeRanges(t *testing.T) {
 	assert.NotNil(t, filters.ArrivalTimeRange)
 }
 
+func TestToDomainFilters_WithTimeBucket(t *testing.T) {
+	filters := ToDomainFilters(&FilterDTO{DepartureTimeBucket: "Afternoon"})
+
+	require.NotNil(t, filters)
+	assert.Equal(t, domain.TimeBucketAfternoon.Range(), filters.DepartureTimeRange)
+	assert.True(t, filters.DepartureTimeRange.Contains(time.Date(2025, 12, 15, 17, 59, 0, 0, time.UTC)))
+	assert.False(t, filters.DepartureTimeRange.Contains(time.Date(2025, 12, 15, 18, 0, 0, 0, time.UTC)))
+}
+
 func TestToDomainFilters_WithDurationRange(t *testing.T) {
 	minMinutes := 60
 	maxMinutes := 180
@@ -802,6 +1352,7 @@ func TestToDomainSortOption(t *testing.T) {
 		{"price", domain.SortByPrice},
 		{"duration", domain.SortByDuration},
 		{"departure", domain.SortByDeparture},
+		{"arrival", domain.SortByArrival},
 		{"", domain.SortByBestValue},
 		{"invalid", domain.SortByBestValue},
 		{"PRICE", domain.SortByPrice}, // Case insensitive
@@ -815,6 +1366,25 @@ func TestToDomainSortOption(t *testing.T) {
 	}
 }
 
+func TestToDomainSortOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected domain.SortOrder
+	}{
+		{"asc", domain.SortAscending},
+		{"desc", domain.SortDescending},
+		{"DESC", domain.SortDescending}, // Case insensitive
+		{"", domain.SortAscending},
+		{"invalid", domain.SortAscending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ToDomainSortOrder(tt.input))
+		})
+	}
+}
+
 // =====================================================
 // Route Registration Tests
 // =====================================================
@@ -880,27 +1450,268 @@ func TestFormatBaggageKg(t *testing.T) {
 	}
 }
 
-func TestExtractCityFromAirportName(t *testing.T) {
+func TestSanitizeSearchResponseDTO(t *testing.T) {
+	resp := &domain.SearchResponse{
+		Flights: []domain.Flight{{
+			ID:        "ID7042",
+			Airline:   domain.AirlineInfo{Code: "ID", Name: "Batik <i>Air</i>"},
+			Aircraft:  "<img src=x onerror=alert(1)>",
+			Amenities: []string{"<b>wifi</b>", "meal"},
+			Baggage:   domain.BaggageInfo{CabinKg: 7, Note: "7kg cabin, call +62 21 2351 9999"},
+		}},
+	}
+	dto := ToSearchResponseDTO(resp)
+	require.NotNil(t, dto.Flights[0].Aircraft)
+
+	SanitizeSearchResponseDTO(dto, sanitize.New(sanitize.DefaultConfig()))
+
+	f := dto.Flights[0]
+	assert.Equal(t, "Batik Air", f.Airline.Name)
+	assert.Nil(t, f.Aircraft, "nothing left after stripping markup")
+	assert.Equal(t, []string{"wifi", "meal"}, f.Amenities)
+	assert.Equal(t, "7kg cabin, call [redacted]", f.Baggage.Note)
+}
+
+func TestToSearchResponseDTOLocalized(t *testing.T) {
+	resp := &domain.SearchResponse{
+		Flights: []domain.Flight{
+			{
+				ID:        "QZ7510",
+				Airline:   domain.AirlineInfo{Code: "QZ", Name: "AirAsia"},
+				Departure: domain.FlightPoint{AirportCode: "CGK"},
+				Arrival:   domain.FlightPoint{AirportCode: "SIN"},
+			},
+			{
+				ID:        "XX1",
+				Airline:   domain.AirlineInfo{Code: "XX", Name: "Unknown Air"},
+				Departure: domain.FlightPoint{AirportCode: "AAA"},
+				Arrival:   domain.FlightPoint{AirportCode: "BBB"},
+			},
+		},
+		Metadata: domain.SearchMetadata{Features: []string{domain.FeatureCache}},
+	}
+
+	t.Run("indonesian locale translates known names", func(t *testing.T) {
+		dto := ToSearchResponseDTOLocalized(resp, reference.LocaleIndonesian)
+		require.Len(t, dto.Flights, 2)
+		assert.Equal(t, []string{domain.FeatureCache, "locale:id"}, dto.Metadata.Features)
+		assert.Equal(t, []string{domain.FeatureCache}, resp.Metadata.Features, "the response is not modified")
+
+		f := dto.Flights[0]
+		assert.Equal(t, "AirAsia Indonesia", f.Airline.Name)
+		assert.Equal(t, "Bandar Udara Internasional Soekarno-Hatta", f.Departure.AirportName)
+		assert.Equal(t, "Singapura", f.Arrival.City)
+
+		// Unknown codes keep provider-supplied values
+		assert.Equal(t, "Unknown Air", dto.Flights[1].Airline.Name)
+		assert.Empty(t, dto.Flights[1].Departure.AirportName)
+	})
+
+	t.Run("empty locale leaves names untouched", func(t *testing.T) {
+		dto := ToSearchResponseDTOLocalized(resp, "")
+		assert.Equal(t, "AirAsia", dto.Flights[0].Airline.Name)
+		assert.Equal(t, []string{domain.FeatureCache}, dto.Metadata.Features)
+		assert.Empty(t, dto.Flights[0].Departure.AirportName)
+	})
+}
+
+func TestSearchFlights_Locale(t *testing.T) {
+	mock := &mockUseCase{
+		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			return &domain.SearchResponse{
+				Flights: []domain.Flight{
+					{ID: "1", Airline: domain.AirlineInfo{Code: "QZ", Name: "AirAsia"}},
+				},
+			}, nil
+		},
+	}
+	e, _ := setupTestHandler(mock)
+
+	body := `{"origin":"CGK","destination":"DPS","departureDate":"` + getFutureDate() + `","passengers":1}`
+
 	tests := []struct {
-		name     string
-		code     string
-		expected string
+		name           string
+		path           string
+		acceptLanguage string
+		expectedName   string
 	}{
-		{name: "Jakarta", code: "CGK", expected: "Jakarta"},
-		{name: "Denpasar", code: "DPS", expected: "Denpasar"},
-		{name: "Surabaya", code: "SUB", expected: "Surabaya"},
-		{name: "Yogyakarta", code: "JOG", expected: "Yogyakarta"},
-		{name: "Bandung", code: "BDO", expected: "Bandung"},
-		{name: "Manado", code: "MDC", expected: "Manado"},
-		{name: "Makassar", code: "UPG", expected: "Makassar"},
-		{name: "Balikpapan", code: "BPN", expected: "Balikpapan"},
-		{name: "unknown code", code: "XXX", expected: ""},
+		{name: "no locale", path: "/api/v1/flights/search", expectedName: "AirAsia"},
+		{name: "accept-language header", path: "/api/v1/flights/search", acceptLanguage: "id-ID,id;q=0.9", expectedName: "AirAsia Indonesia"},
+		{name: "query param overrides header", path: "/api/v1/flights/search?locale=en", acceptLanguage: "id", expectedName: "Indonesia AirAsia"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := extractCityFromAirportName(tt.code)
-			assert.Equal(t, tt.expected, result)
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			var dto SearchResponseDTO
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+			require.Len(t, dto.Flights, 1)
+			assert.Equal(t, tt.expectedName, dto.Flights[0].Airline.Name)
 		})
 	}
 }
+
+func TestSearchFlights_LocalizationShed(t *testing.T) {
+	mock := &mockUseCase{
+		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			return &domain.SearchResponse{
+				Flights: []domain.Flight{
+					{ID: "1", Airline: domain.AirlineInfo{Code: "QZ", Name: "AirAsia"}},
+				},
+			}, nil
+		},
+	}
+	e, _ := setupTestHandler(mock)
+
+	body := `{"origin":"CGK","destination":"DPS","departureDate":"` + getFutureDate() + `","passengers":1}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/flights/search?locale=id", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req = req.WithContext(loadshed.WithShed(req.Context(), []string{loadshed.FeatureLocalization}))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var dto SearchResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+	require.Len(t, dto.Flights, 1)
+	assert.Equal(t, "AirAsia", dto.Flights[0].Airline.Name, "provider name kept while localization is shed")
+}
+
+func TestSearchFlights_PartialResults(t *testing.T) {
+	var gotOpts usecase.SearchOptions
+	mock := &mockUseCase{
+		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			gotOpts = opts
+			return &domain.SearchResponse{
+				Flights:  []domain.Flight{{ID: "1", Provider: "garuda"}},
+				Metadata: domain.SearchMetadata{ProvidersQueried: 2, ProvidersSucceeded: 1, PendingProviders: []string{"lion_air"}},
+			}, nil
+		},
+	}
+	e, _ := setupTestHandler(mock)
+
+	body := `{"origin":"CGK","destination":"DPS","departureDate":"` + getFutureDate() + `","passengers":1,"partialResultsOk":true}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/flights/search", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, gotOpts.PartialResultsOK)
+
+	var dto SearchResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+	assert.Equal(t, []string{"lion_air"}, dto.Metadata.PendingProviders)
+}
+
+func TestSearchFlights_TenantDefaults(t *testing.T) {
+	var gotOpts usecase.SearchOptions
+	mock := &mockUseCase{
+		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			gotOpts = opts
+			return &domain.SearchResponse{
+				Flights: []domain.Flight{
+					{ID: "1", Provider: "garuda", FlightNumber: "GA400", Price: domain.PriceInfo{Amount: 1000000, Currency: "IDR"}},
+				},
+			}, nil
+		},
+	}
+
+	registry, err := tenant.NewRegistry(tenant.Profile{
+		Key:      "partner-key",
+		Name:     "partner",
+		Defaults: tenant.Defaults{SortBy: "price", MaxResults: 5, Fields: []string{"price", "unknown"}, FanOutStrategy: "sequential-failover"},
+	})
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(middleware.APIKey(registry, rbac.Policy{}))
+	RegisterRoutes(e, NewFlightHandler(mock))
+
+	search := func(t *testing.T, body, apiKey string) map[string]interface{} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/flights/search", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if apiKey != "" {
+			req.Header.Set(middleware.APIKeyHeader, apiKey)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp struct {
+			Flights []map[string]interface{} `json:"flights"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Flights, 1)
+		return resp.Flights[0]
+	}
+
+	base := `"origin":"CGK","destination":"DPS","departureDate":"` + getFutureDate() + `","passengers":1`
+
+	t.Run("defaults applied when omitted", func(t *testing.T) {
+		flight := search(t, "{"+base+"}", "partner-key")
+		assert.Equal(t, domain.SortByPrice, gotOpts.SortBy)
+		assert.Equal(t, 5, gotOpts.MaxResults)
+		assert.Equal(t, domain.FanOutSequentialFailover, gotOpts.FanOutStrategy)
+		assert.Len(t, flight, 2, "only id and price should be returned")
+		assert.Contains(t, flight, "id")
+		assert.Contains(t, flight, "price")
+	})
+
+	t.Run("request values win", func(t *testing.T) {
+		flight := search(t, "{"+base+`,"sortBy":"duration","maxResults":2,"fields":["flight_number"],"fanOutStrategy":"All-Parallel"}`, "partner-key")
+		assert.Equal(t, domain.SortByDuration, gotOpts.SortBy)
+		assert.Equal(t, domain.FanOutAllParallel, gotOpts.FanOutStrategy)
+		assert.Equal(t, 2, gotOpts.MaxResults)
+		assert.Len(t, flight, 2)
+		assert.Equal(t, "GA400", flight["flight_number"])
+	})
+
+	t.Run("unknown key uses global defaults", func(t *testing.T) {
+		flight := search(t, "{"+base+"}", "other-key")
+		assert.Equal(t, domain.SortByBestValue, gotOpts.SortBy)
+		assert.Zero(t, gotOpts.MaxResults)
+		assert.Contains(t, flight, "airline")
+	})
+}
+
+func TestSearchFlights_DuplicateHint(t *testing.T) {
+	registry, err := tenant.NewRegistry(tenant.Profile{Key: "partner-key", Name: "duplicates-partner"})
+	require.NoError(t, err)
+
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	e := echo.New()
+	e.Use(middleware.APIKey(registry, rbac.Policy{}))
+	RegisterRoutes(e, NewFlightHandler(&mockUseCase{}, WithDuplicateTracking(duplicate.NewTracker(duplicate.WithClock(clock)))))
+
+	search := func(t *testing.T, destination, apiKey string) string {
+		body := `{"origin":"CGK","destination":"` + destination + `","departureDate":"` + getFutureDate() + `","passengers":1}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/flights/search", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if apiKey != "" {
+			req.Header.Set(middleware.APIKeyHeader, apiKey)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Header().Get(SearchHintHeader)
+	}
+
+	assert.Empty(t, search(t, "DPS", "partner-key"), "first search")
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, SearchHintReuseSession, search(t, "DPS", "partner-key"), "repeated search")
+	assert.Empty(t, search(t, "SUB", "partner-key"), "another search")
+	assert.Empty(t, search(t, "DPS", ""), "searches without an API key are not tracked")
+	assert.Empty(t, search(t, "DPS", ""))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.DuplicateSearches.WithLabelValues("duplicates-partner", "false")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.DuplicateSearches.WithLabelValues("duplicates-partner", "true")))
+}
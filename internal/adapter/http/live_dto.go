@@ -0,0 +1,93 @@
+package http
+
+import (
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/sanitize"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// Live search message events.
+const (
+	// LiveEventFlights carries a provider's flights (LiveFlightsDTO)
+	LiveEventFlights = "flights"
+
+	// LiveEventProgress reports a provider's answer (LiveProgressDTO)
+	LiveEventProgress = "progress"
+
+	// LiveEventResult carries the final search response; it is the last message
+	LiveEventResult = "result"
+
+	// LiveEventError carries the error failing the search (response.ErrorDetail);
+	// it is the last message
+	LiveEventError = "error"
+)
+
+// Provider statuses reported by live search progress.
+const (
+	ProviderStatusSucceeded = "succeeded"
+	ProviderStatusFailed    = "failed"
+)
+
+// LiveProgressDTO reports a provider's answer to a live search.
+type LiveProgressDTO struct {
+	Provider           string `json:"provider"`
+	Status             string `json:"status"`
+	Flights            int    `json:"flights"`
+	ProvidersCompleted int    `json:"providers_completed"`
+	ProvidersTotal     int    `json:"providers_total"`
+	Return             bool   `json:"return,omitempty"`
+	DepartureDate      string `json:"departure_date,omitempty"`
+}
+
+// LiveFlightsDTO is a batch of flights returned by a provider to a live
+// search, unranked. Flights are FlightDTOs, limited to the requested fields
+// if any.
+type LiveFlightsDTO struct {
+	Provider      string      `json:"provider"`
+	Return        bool        `json:"return,omitempty"`
+	DepartureDate string      `json:"departure_date,omitempty"`
+	Flights       interface{} `json:"flights"`
+}
+
+// ToLiveProgressDTO converts a provider's answer to its progress update.
+func ToLiveProgressDTO(p usecase.ProviderProgress) LiveProgressDTO {
+	status := ProviderStatusSucceeded
+	if p.Err != nil {
+		status = ProviderStatusFailed
+	}
+	return LiveProgressDTO{
+		Provider:           p.Provider,
+		Status:             status,
+		Flights:            len(p.Flights),
+		ProvidersCompleted: p.Completed,
+		ProvidersTotal:     p.Total,
+		Return:             p.Return,
+		DepartureDate:      p.DepartureDate,
+	}
+}
+
+// ToLiveFlightsDTO converts a provider's flights to a batch, localized,
+// sanitized and limited to fields (all fields if empty) like the flights of
+// a search response.
+func ToLiveFlightsDTO(p usecase.ProviderProgress, loc reference.Locale, s *sanitize.Sanitizer, fields []string) (*LiveFlightsDTO, error) {
+	flights := make([]FlightDTO, len(p.Flights))
+	for i := range p.Flights {
+		flights[i] = ToFlightDTO(&p.Flights[i])
+		if loc.IsValid() {
+			localizeFlightDTO(&flights[i], loc)
+		}
+		if s != nil {
+			sanitizeFlightDTO(&flights[i], s)
+		}
+	}
+
+	dto := &LiveFlightsDTO{Provider: p.Provider, Return: p.Return, DepartureDate: p.DepartureDate, Flights: flights}
+	if len(fields) > 0 {
+		projected, err := projectFlights(flights, fieldSet(fields))
+		if err != nil {
+			return nil, err
+		}
+		dto.Flights = projected
+	}
+	return dto, nil
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/stream"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/metrics"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/tenant"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// liveRequestTimeout bounds the wait for the search request of a live search.
+const liveRequestTimeout = 10 * time.Second
+
+// SearchFlightsLive handles GET /api/v1/flights/search/live, the WebSocket
+// variant of SearchFlights. The client sends the search request as a JSON
+// text message. As providers answer, the server sends each one's flights as
+// a "flights" message and a "progress" message counting the providers that
+// answered; it then sends the complete response as a "result" message, or an
+// "error" message, and closes the connection.
+//
+// Batches hold a provider's flights passing the filters, unranked. Updates
+// are dropped for clients reading too slowly, and those still pending when
+// the search completes are superseded by the result, which is authoritative.
+// Searches for groups are rejected.
+func (h *FlightHandler) SearchFlightsLive(c echo.Context) error {
+	// Any origin may connect: like searches posted cross-origin, live
+	// searches carry no ambient credentials such as cookies
+	server := websocket.Server{Handler: func(conn *websocket.Conn) {
+		defer conn.Close()
+		h.liveSearch(c, conn)
+	}}
+	server.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// liveSearch runs a live search over conn.
+func (h *FlightHandler) liveSearch(c echo.Context, conn *websocket.Conn) {
+	enc := stream.NewWebSocketEncoder(conn, 0)
+
+	req, detail := h.receiveLiveRequest(conn)
+	if detail != nil {
+		_ = enc.Encode(stream.Update{Event: LiveEventError, Data: detail})
+		return
+	}
+
+	// The client sends nothing after its request: the connection closing or
+	// failing means it went away, and the search is aborted
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+	go func() {
+		var discard []byte
+		for websocket.Message.Receive(conn, &discard) == nil {
+		}
+		cancel()
+	}()
+
+	h.streamSearch(ctx, c, req, enc, stream.TransportWebSocket)
+}
+
+// SearchFlightsStream handles POST /api/v1/flights/search/stream, the
+// Server-Sent Events variant of SearchFlightsLive for clients that cannot
+// open a WebSocket. The search request is the body, as for SearchFlights,
+// and invalid requests are answered with 400 likewise. The updates of the
+// search are then sent as "flights" and "progress" events, followed by a
+// "result" or "error" event ending the response. The client closing the
+// connection aborts the search.
+func (h *FlightHandler) SearchFlightsStream(c echo.Context) error {
+	var req SearchFlightsRequest
+	if err := c.Bind(&req); err != nil {
+		return response.InvalidRequestBody(c)
+	}
+	if err := req.ValidateWith(h.codes); err != nil {
+		return h.handleValidationError(c, err)
+	}
+	if req.IsGroup() {
+		return response.ValidationError(c, map[string]string{
+			"passengers": fmt.Sprintf("passengers cannot exceed %d", domain.MaxPassengers),
+		})
+	}
+
+	// The search may outlast the server's write timeout
+	_ = http.NewResponseController(c.Response()).SetWriteDeadline(time.Time{})
+	enc, err := stream.NewSSEEncoder(c.Response())
+	if err != nil {
+		return response.InternalServerError(c)
+	}
+	h.streamSearch(c.Request().Context(), c, &req, enc, stream.TransportSSE)
+	return nil
+}
+
+// streamSearch runs the validated search request req, sending its updates
+// and final result to enc until ctx is done. The search is aborted if the
+// client cannot be written to.
+func (h *FlightHandler) streamSearch(ctx context.Context, c echo.Context, req *SearchFlightsRequest, enc stream.Encoder, transport string) {
+	// Fill omitted parameters from the API key's defaults
+	if profile, ok := tenant.FromContext(c.Request().Context()); ok {
+		applyTenantDefaults(req, profile.Defaults)
+	}
+	criteria := ToDomainCriteria(req)
+	opts := ToSearchOptions(req)
+	// Batches already deliver results as they come
+	opts.PartialResultsOK = false
+	locale := responseLocale(c)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	updates := stream.New(stream.Config{Transport: transport})
+	opts.Progress = func(p usecase.ProviderProgress) {
+		if len(p.Flights) > 0 {
+			if batch, err := ToLiveFlightsDTO(p, locale, h.sanitizer, req.Fields); err == nil {
+				updates.Publish(stream.Update{Event: LiveEventFlights, Data: batch})
+			}
+		}
+		updates.Publish(stream.Update{Event: LiveEventProgress, Data: ToLiveProgressDTO(p)})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		updates.Finish(h.liveResult(ctx, criteria, opts, locale, req.Fields))
+	}()
+
+	// Stop the search if the client cannot be written to
+	_ = updates.Run(ctx, enc)
+	cancel()
+	<-done
+}
+
+// receiveLiveRequest reads and validates the search request of a live search.
+// It returns the error to send instead if the request is invalid.
+func (h *FlightHandler) receiveLiveRequest(conn *websocket.Conn) (*SearchFlightsRequest, *response.ErrorDetail) {
+	if err := conn.SetReadDeadline(time.Now().Add(liveRequestTimeout)); err != nil {
+		return nil, &response.ErrorDetail{Code: response.CodeInternalError, Message: response.MsgInternalError}
+	}
+	var req SearchFlightsRequest
+	if err := websocket.JSON.Receive(conn, &req); err != nil {
+		return nil, &response.ErrorDetail{Code: response.CodeInvalidRequest, Message: response.MsgInvalidRequestBody}
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, &response.ErrorDetail{Code: response.CodeInternalError, Message: response.MsgInternalError}
+	}
+
+	if err := req.ValidateWith(h.codes); err != nil {
+		return nil, validationErrorDetail(err)
+	}
+	if req.IsGroup() {
+		return nil, &response.ErrorDetail{
+			Code:    response.CodeValidationError,
+			Message: response.MsgValidationFailed,
+			Details: map[string]string{"passengers": fmt.Sprintf("passengers cannot exceed %d", domain.MaxPassengers)},
+		}
+	}
+	return &req, nil
+}
+
+// liveResult runs the search and returns its final message: the response,
+// converted like that of SearchFlights, or the error.
+func (h *FlightHandler) liveResult(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions, locale reference.Locale, fields []string) stream.Update {
+	result, err := h.useCase.Search(ctx, criteria, opts)
+	if err != nil {
+		if errors.Is(err, domain.ErrSearchAborted) {
+			metrics.SearchesAborted.Inc()
+		}
+		_, detail := domainErrorDetail(err)
+		return stream.Update{Event: LiveEventError, Data: detail}
+	}
+	metrics.ObserveFilterRejections(result.Metadata.FilterRejections)
+
+	dto := ToSearchResponseDTOLocalized(result, locale)
+	SanitizeSearchResponseDTO(dto, h.sanitizer)
+	if len(fields) > 0 {
+		projected, err := ProjectSearchResponse(dto, fields)
+		if err != nil {
+			_, detail := domainErrorDetail(err)
+			return stream.Update{Event: LiveEventError, Data: detail}
+		}
+		return stream.Update{Event: LiveEventResult, Data: projected}
+	}
+	return stream.Update{Event: LiveEventResult, Data: dto}
+}
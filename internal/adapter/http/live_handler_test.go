@@ -0,0 +1,308 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// liveMessage is a live search message as received by a client.
+type liveMessage struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// dialLive starts a server for uc and opens a live search connection to it.
+func dialLive(t *testing.T, uc usecase.FlightSearchUseCase) *websocket.Conn {
+	t.Helper()
+	e := echo.New()
+	RegisterRoutes(e, NewFlightHandler(uc))
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/flights/search/live"
+	conn, err := websocket.Dial(url, "", "http://localhost/")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+	return conn
+}
+
+// receiveLive reads the next live search message.
+func receiveLive(t *testing.T, conn *websocket.Conn) liveMessage {
+	t.Helper()
+	var msg liveMessage
+	require.NoError(t, websocket.JSON.Receive(conn, &msg))
+	return msg
+}
+
+func liveRequest() SearchFlightsRequest {
+	return SearchFlightsRequest{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: getFutureDate(),
+		Passengers:    1,
+	}
+}
+
+func TestSearchFlightsLive_StreamsBatchesThenResult(t *testing.T) {
+	flight := domain.Flight{
+		ID:           "GA123_Garuda",
+		FlightNumber: "GA123",
+		Provider:     "garuda",
+		Airline:      domain.AirlineInfo{Code: "GA", Name: "Garuda Indonesia"},
+		Price:        domain.PriceInfo{Amount: 1000000, Currency: "IDR"},
+	}
+	received := make(chan struct{})
+	conn := dialLive(t, &mockUseCase{
+		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			require.NotNil(t, opts.Progress)
+			opts.Progress(usecase.ProviderProgress{
+				Provider: "garuda", Flights: []domain.Flight{flight}, Completed: 1, Total: 2,
+			})
+			// Keep the search running until the client saw the updates, which
+			// the result would otherwise supersede
+			<-received
+			return &domain.SearchResponse{
+				Flights:  []domain.Flight{flight},
+				Metadata: domain.SearchMetadata{TotalResults: 1, ProvidersQueried: 2, ProvidersSucceeded: 1, ProvidersFailed: 1},
+			}, nil
+		},
+	})
+	require.NoError(t, websocket.JSON.Send(conn, liveRequest()))
+
+	msg := receiveLive(t, conn)
+	require.Equal(t, LiveEventFlights, msg.Event)
+	var batch struct {
+		Provider string      `json:"provider"`
+		Flights  []FlightDTO `json:"flights"`
+	}
+	require.NoError(t, json.Unmarshal(msg.Data, &batch))
+	assert.Equal(t, "garuda", batch.Provider)
+	require.Len(t, batch.Flights, 1)
+	assert.Equal(t, "GA123", batch.Flights[0].FlightNumber)
+
+	msg = receiveLive(t, conn)
+	require.Equal(t, LiveEventProgress, msg.Event)
+	var progress LiveProgressDTO
+	require.NoError(t, json.Unmarshal(msg.Data, &progress))
+	assert.Equal(t, LiveProgressDTO{
+		Provider: "garuda", Status: ProviderStatusSucceeded, Flights: 1, ProvidersCompleted: 1, ProvidersTotal: 2,
+	}, progress)
+	close(received)
+
+	msg = receiveLive(t, conn)
+	require.Equal(t, LiveEventResult, msg.Event)
+	var result SearchResponseDTO
+	require.NoError(t, json.Unmarshal(msg.Data, &result))
+	assert.Equal(t, 1, result.Metadata.TotalResults)
+	assert.Len(t, result.Flights, 1)
+
+	// The result is the last message
+	var discard []byte
+	assert.ErrorIs(t, websocket.Message.Receive(conn, &discard), io.EOF)
+}
+
+func TestSearchFlightsLive_ReportsFailedProvider(t *testing.T) {
+	received := make(chan struct{})
+	conn := dialLive(t, &mockUseCase{
+		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			opts.Progress(usecase.ProviderProgress{
+				Provider: "lion", Err: errors.New("timeout"), Completed: 1, Total: 1,
+			})
+			<-received
+			return nil, domain.ErrAllProvidersFailed
+		},
+	})
+	require.NoError(t, websocket.JSON.Send(conn, liveRequest()))
+
+	// No flights batch for a provider without flights
+	msg := receiveLive(t, conn)
+	require.Equal(t, LiveEventProgress, msg.Event)
+	var progress LiveProgressDTO
+	require.NoError(t, json.Unmarshal(msg.Data, &progress))
+	assert.Equal(t, ProviderStatusFailed, progress.Status)
+	close(received)
+
+	msg = receiveLive(t, conn)
+	require.Equal(t, LiveEventError, msg.Event)
+	var detail response.ErrorDetail
+	require.NoError(t, json.Unmarshal(msg.Data, &detail))
+	assert.Equal(t, response.CodeServiceUnavailable, detail.Code)
+}
+
+func TestSearchFlightsLive_InvalidRequest(t *testing.T) {
+	conn := dialLive(t, &mockUseCase{
+		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			t.Error("invalid requests must not be searched")
+			return nil, nil
+		},
+	})
+	req := liveRequest()
+	req.Destination = "CGK"
+	require.NoError(t, websocket.JSON.Send(conn, req))
+
+	msg := receiveLive(t, conn)
+	require.Equal(t, LiveEventError, msg.Event)
+	var detail response.ErrorDetail
+	require.NoError(t, json.Unmarshal(msg.Data, &detail))
+	assert.Equal(t, response.CodeValidationError, detail.Code)
+}
+
+func TestSearchFlightsLive_MalformedRequest(t *testing.T) {
+	conn := dialLive(t, &mockUseCase{})
+	require.NoError(t, websocket.Message.Send(conn, "{not json"))
+
+	msg := receiveLive(t, conn)
+	require.Equal(t, LiveEventError, msg.Event)
+	var detail response.ErrorDetail
+	require.NoError(t, json.Unmarshal(msg.Data, &detail))
+	assert.Equal(t, response.CodeInvalidRequest, detail.Code)
+}
+
+func TestSearchFlightsLive_ClientDisconnectAbortsSearch(t *testing.T) {
+	aborted := make(chan struct{})
+	conn := dialLive(t, &mockUseCase{
+		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			<-ctx.Done()
+			close(aborted)
+			return nil, domain.ErrSearchAborted
+		},
+	})
+	require.NoError(t, websocket.JSON.Send(conn, liveRequest()))
+	require.NoError(t, conn.Close())
+
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("search not aborted after the client disconnected")
+	}
+}
+
+// postStream starts a server for uc and posts body to the streamed search
+// endpoint, returning the response.
+func postStream(t *testing.T, uc usecase.FlightSearchUseCase, body any) *http.Response {
+	t.Helper()
+	e := echo.New()
+	RegisterRoutes(e, NewFlightHandler(uc))
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	resp, err := http.Post(server.URL+"/api/v1/flights/search/stream", echo.MIMEApplicationJSON, bytes.NewReader(data))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+// readEvents reads n Server-Sent Events from scanner, or those left until
+// the response ends if n is negative.
+func readEvents(t *testing.T, scanner *bufio.Scanner, n int) []liveMessage {
+	t.Helper()
+	var events []liveMessage
+	var event liveMessage
+	for len(events) != n && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.Data = json.RawMessage(strings.TrimPrefix(line, "data: "))
+		case line == "":
+			events = append(events, event)
+			event = liveMessage{}
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestSearchFlightsStream_StreamsBatchesThenResult(t *testing.T) {
+	flight := domain.Flight{
+		ID:           "GA123_Garuda",
+		FlightNumber: "GA123",
+		Provider:     "garuda",
+		Airline:      domain.AirlineInfo{Code: "GA", Name: "Garuda Indonesia"},
+		Price:        domain.PriceInfo{Amount: 1000000, Currency: "IDR"},
+	}
+	received := make(chan struct{})
+	resp := postStream(t, &mockUseCase{
+		searchFunc: func(ctx context.Context, criteria domain.SearchCriteria, opts usecase.SearchOptions) (*domain.SearchResponse, error) {
+			require.NotNil(t, opts.Progress)
+			opts.Progress(usecase.ProviderProgress{
+				Provider: "garuda", Flights: []domain.Flight{flight}, Completed: 1, Total: 1,
+			})
+			// Keep the search running until the client saw the updates, which
+			// the result would otherwise supersede
+			<-received
+			return &domain.SearchResponse{
+				Flights:  []domain.Flight{flight},
+				Metadata: domain.SearchMetadata{TotalResults: 1, ProvidersQueried: 1, ProvidersSucceeded: 1},
+			}, nil
+		},
+	}, liveRequest())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get(echo.HeaderContentType))
+
+	scanner := bufio.NewScanner(resp.Body)
+	events := readEvents(t, scanner, 2)
+	require.Len(t, events, 2)
+	assert.Equal(t, LiveEventFlights, events[0].Event)
+	assert.Equal(t, LiveEventProgress, events[1].Event)
+	close(received)
+
+	events = readEvents(t, scanner, -1)
+	require.Len(t, events, 1)
+	require.Equal(t, LiveEventResult, events[0].Event, "the result ends the stream")
+	var result SearchResponseDTO
+	require.NoError(t, json.Unmarshal(events[0].Data, &result))
+	assert.Equal(t, 1, result.Metadata.TotalResults)
+	assert.Len(t, result.Flights, 1)
+}
+
+func TestSearchFlightsStream_ReportsError(t *testing.T) {
+	resp := postStream(t, &mockUseCase{
+		searchFunc: func(context.Context, domain.SearchCriteria, usecase.SearchOptions) (*domain.SearchResponse, error) {
+			return nil, domain.ErrAllProvidersFailed
+		},
+	}, liveRequest())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	events := readEvents(t, bufio.NewScanner(resp.Body), -1)
+	require.Len(t, events, 1)
+	require.Equal(t, LiveEventError, events[0].Event)
+	var detail response.ErrorDetail
+	require.NoError(t, json.Unmarshal(events[0].Data, &detail))
+	assert.Equal(t, response.CodeServiceUnavailable, detail.Code)
+}
+
+func TestSearchFlightsStream_InvalidRequest(t *testing.T) {
+	req := liveRequest()
+	req.Destination = "CGK"
+	resp := postStream(t, &mockUseCase{
+		searchFunc: func(context.Context, domain.SearchCriteria, usecase.SearchOptions) (*domain.SearchResponse, error) {
+			t.Error("invalid requests must not be searched")
+			return nil, nil
+		},
+	}, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "invalid requests are rejected before the stream starts")
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adminauth"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+// AdminAuth returns middleware that authenticates admin API callers with
+// short-lived bearer tokens and authorizes them by role: safe methods
+// (GET, HEAD, OPTIONS) require admin:read, all other methods admin:write.
+// Verified claims are attached to the request context, and the caller
+// becomes the request's RBAC principal with the admin role, plus the debug
+// role for admin:debug tokens.
+func AdminAuth(authority *adminauth.Authority) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token, ok := bearerToken(c.Request().Header.Get(echo.HeaderAuthorization))
+			if !ok {
+				return response.Unauthorized(c, response.MsgMissingToken)
+			}
+
+			claims, err := authority.Verify(token)
+			if err != nil {
+				return response.Unauthorized(c, response.MsgInvalidToken)
+			}
+
+			if isSafeMethod(c.Request().Method) {
+				if !claims.HasRole(adminauth.RoleRead) {
+					return response.Forbidden(c, response.MsgAdminReadRequired)
+				}
+			} else if !claims.HasRole(adminauth.RoleWrite) {
+				return response.Forbidden(c, response.MsgAdminWriteRequired)
+			}
+
+			principal := rbac.Principal{Name: claims.Subject, Roles: []rbac.Role{rbac.RoleAdmin}}
+			if claims.HasRole(adminauth.RoleDebug) {
+				principal.Roles = append(principal.Roles, rbac.RoleDebug)
+			}
+
+			req := c.Request()
+			ctx := adminauth.WithClaims(req.Context(), claims)
+			c.SetRequest(req.WithContext(rbac.WithPrincipal(ctx, principal)))
+			return next(c)
+		}
+	}
+}
+
+// bearerToken extracts the token from an Authorization header.
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// isSafeMethod reports whether the method does not modify state.
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adminauth"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+func TestAdminAuth(t *testing.T) {
+	authority, err := adminauth.NewAuthority([]byte(strings.Repeat("k", adminauth.MinSecretLength)), 15*time.Minute)
+	require.NoError(t, err)
+	issue := func(roles ...adminauth.Role) string {
+		token, err := authority.Issue("ops-bot", time.Minute, roles...)
+		require.NoError(t, err)
+		return "Bearer " + token
+	}
+
+	e := echo.New()
+	admin := e.Group("/admin/v1", AdminAuth(authority))
+	handler := func(c echo.Context) error {
+		claims, _ := adminauth.ClaimsFromContext(c.Request().Context())
+		principal, _ := rbac.FromContext(c.Request().Context())
+		return c.String(http.StatusOK, fmt.Sprintf("%s %v", claims.Subject, principal.Roles))
+	}
+	admin.GET("/jobs", handler)
+	admin.POST("/queue/jobs/:id/requeue", handler)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		auth     string
+		wantCode int
+		wantErr  string
+		wantBody string
+	}{
+		{"read with read role", http.MethodGet, "/admin/v1/jobs", issue(adminauth.RoleRead), http.StatusOK, "", "ops-bot [admin]"},
+		{"read with write role", http.MethodGet, "/admin/v1/jobs", issue(adminauth.RoleWrite), http.StatusOK, "", "ops-bot [admin]"},
+		{"mutate with write role", http.MethodPost, "/admin/v1/queue/jobs/1/requeue", issue(adminauth.RoleWrite), http.StatusOK, "", "ops-bot [admin]"},
+		{"debug role", http.MethodPost, "/admin/v1/queue/jobs/1/requeue", issue(adminauth.RoleWrite, adminauth.RoleDebug), http.StatusOK, "", "ops-bot [admin debug]"},
+		{"mutate with read role", http.MethodPost, "/admin/v1/queue/jobs/1/requeue", issue(adminauth.RoleRead), http.StatusForbidden, response.MsgAdminWriteRequired, ""},
+		{"debug role alone", http.MethodGet, "/admin/v1/jobs", issue(adminauth.RoleDebug), http.StatusForbidden, response.MsgAdminReadRequired, ""},
+		{"missing token", http.MethodGet, "/admin/v1/jobs", "", http.StatusUnauthorized, response.MsgMissingToken, ""},
+		{"wrong scheme", http.MethodGet, "/admin/v1/jobs", "Basic b3BzOmJvdA==", http.StatusUnauthorized, response.MsgMissingToken, ""},
+		{"invalid token", http.MethodGet, "/admin/v1/jobs", "Bearer not-a-token", http.StatusUnauthorized, response.MsgInvalidToken, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.auth != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantErr == "" {
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+				return
+			}
+			var result response.ErrorDetail
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			assert.Equal(t, tt.wantErr, result.Message)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/tenant"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// APIKeyHeader is the HTTP header carrying the partner API key.
+const APIKeyHeader = "X-API-Key"
+
+// APIKey returns middleware that resolves the X-API-Key header to a tenant
+// profile and attaches it to the request context, where handlers read the
+// partner's defaults. A profile with a request class also sets the class the
+// search use case picks its timeout budget from. Requests without a key or with an unknown key proceed
+// without a profile; this middleware does not reject requests.
+//
+// Every request is also given an RBAC principal: the partner with its profile
+// roles (or the policy's key roles), or the anonymous principal with the
+// policy's anonymous roles. RequireRole enforces them.
+func APIKey(registry *tenant.Registry, policy rbac.Policy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := req.Context()
+			principal := rbac.Principal{Name: rbac.Anonymous, Roles: policy.Anonymous}
+
+			profile, ok := registry.Lookup(req.Header.Get(APIKeyHeader))
+			if ok {
+				ctx = tenant.WithProfile(ctx, profile)
+				if profile.Class != "" {
+					ctx = usecase.WithRequestClass(ctx, profile.Class)
+				}
+				principal = rbac.Principal{Name: profile.Name, Roles: profile.Roles}
+				if len(principal.Roles) == 0 {
+					principal.Roles = policy.Keys
+				}
+			}
+
+			c.SetRequest(req.WithContext(rbac.WithPrincipal(ctx, principal)))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/tenant"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+func TestAPIKey(t *testing.T) {
+	registry, err := tenant.NewRegistry(
+		tenant.Profile{
+			Key:      "partner-key",
+			Name:     "partner",
+			Defaults: tenant.Defaults{SortBy: "price"},
+		},
+		tenant.Profile{
+			Key:   "bulk-key",
+			Name:  "bulk",
+			Class: domain.RequestClassBatch,
+		},
+	)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(APIKey(registry, rbac.Policy{}))
+	e.GET("/", func(c echo.Context) error {
+		profile, ok := tenant.FromContext(c.Request().Context())
+		if !ok {
+			return c.String(http.StatusOK, "none")
+		}
+		class := usecase.RequestClassFromContext(c.Request().Context())
+		return c.String(http.StatusOK, profile.Name+"/"+string(class))
+	})
+
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{name: "known key", key: "partner-key", want: "partner/interactive"},
+		{name: "key with class", key: "bulk-key", want: "bulk/batch"},
+		{name: "unknown key", key: "other", want: "none"},
+		{name: "no key", key: "", want: "none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.want, rec.Body.String())
+		})
+	}
+}
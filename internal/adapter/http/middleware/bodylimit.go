@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+)
+
+// BodyLimit rejects requests whose body is larger than maxBytes with a 413
+// reporting the limit. Requests declaring a larger Content-Length are
+// rejected before their body is read; bodies of unknown length are read up to
+// the limit first, so handlers and the OpenAPI validator never see a
+// truncated body.
+func BodyLimit(maxBytes int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.ContentLength > maxBytes {
+				return response.RequestTooLarge(c, maxBytes)
+			}
+			if req.ContentLength >= 0 || req.Body == nil {
+				// net/http never reads past a declared Content-Length
+				return next(c)
+			}
+
+			body, err := io.ReadAll(io.LimitReader(req.Body, maxBytes+1))
+			if err != nil {
+				return response.InvalidRequestBody(c)
+			}
+			if int64(len(body)) > maxBytes {
+				return response.RequestTooLarge(c, maxBytes)
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			req.ContentLength = int64(len(body))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+)
+
+func TestBodyLimit(t *testing.T) {
+	e := echo.New()
+	e.Use(BodyLimit(16))
+	e.POST("/search", func(c echo.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, string(body))
+	})
+
+	tests := []struct {
+		name     string
+		body     string
+		chunked  bool
+		wantCode int
+	}{
+		{"within the limit", `{"origin":"CGK"}`, false, http.StatusOK},
+		{"over the limit", `{"origin":"CGK","destination":"DPS"}`, false, http.StatusRequestEntityTooLarge},
+		{"chunked within the limit", `{"origin":"CGK"}`, true, http.StatusOK},
+		{"chunked over the limit", `{"origin":"CGK","destination":"DPS"}`, true, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/search", strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantCode == http.StatusOK {
+				assert.Equal(t, tt.body, rec.Body.String())
+				return
+			}
+			var result response.ErrorDetail
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			assert.Equal(t, response.CodeRequestTooLarge, result.Code)
+			assert.Equal(t, "16", result.Details["maxBytes"])
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Compression gzips the responses of clients accepting it once they reach
+// config.MinBytes; smaller responses are sent as is, as compressing them
+// would cost more than it saves. /metrics, which compresses on its own, and
+// WebSocket upgrades are skipped.
+func Compression(config CompressionConfig) echo.MiddlewareFunc {
+	return middleware.GzipWithConfig(middleware.GzipConfig{
+		Level:     config.Level,
+		MinLength: config.MinBytes,
+		Skipper: func(c echo.Context) bool {
+			req := c.Request()
+			return req.URL.Path == "/metrics" ||
+				strings.EqualFold(req.Header.Get(echo.HeaderUpgrade), "websocket")
+		},
+	})
+}
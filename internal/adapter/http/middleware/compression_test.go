@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompression(t *testing.T) {
+	large := strings.Repeat(`{"id":"GA400","price":1250000},`, 100)
+	e := echo.New()
+	e.Use(Compression(CompressionConfig{Level: 5, MinBytes: 1024}))
+	e.GET("/large", func(c echo.Context) error { return c.String(http.StatusOK, large) })
+	e.GET("/small", func(c echo.Context) error { return c.String(http.StatusOK, "ok") })
+	e.GET("/metrics", func(c echo.Context) error { return c.String(http.StatusOK, large) })
+
+	serve := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("large responses are compressed", func(t *testing.T) {
+		rec := serve("/large", "gzip, deflate")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "gzip", rec.Header().Get(echo.HeaderContentEncoding))
+		assert.Less(t, rec.Body.Len(), len(large))
+
+		reader, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, large, string(body))
+	})
+
+	t.Run("small responses are not", func(t *testing.T) {
+		rec := serve("/small", "gzip")
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+		assert.Equal(t, "ok", rec.Body.String())
+	})
+
+	t.Run("clients not accepting gzip", func(t *testing.T) {
+		rec := serve("/large", "")
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+		assert.Equal(t, large, rec.Body.String())
+	})
+
+	t.Run("metrics are skipped", func(t *testing.T) {
+		rec := serve("/metrics", "gzip")
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+		assert.Equal(t, large, rec.Body.String())
+	})
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/metrics"
+)
+
+// Limit names reported when a request is rejected.
+const (
+	LimitGlobal   = "global"
+	LimitPerIP    = "per_ip"
+	LimitSearches = "searches"
+)
+
+// ConnectionLimiter caps the number of requests served concurrently, both in
+// total and per client IP. Saturated requests are rejected with a 503 and a
+// Retry-After header instead of queueing, so slow or abusive clients cannot
+// exhaust server resources.
+type ConnectionLimiter struct {
+	log    zerolog.Logger
+	config ConnectionLimitConfig
+
+	mu     sync.Mutex
+	active int
+	perIP  map[string]int
+}
+
+// NewConnectionLimiter creates a connection limiter with the given configuration.
+func NewConnectionLimiter(log zerolog.Logger, config ConnectionLimitConfig) *ConnectionLimiter {
+	return &ConnectionLimiter{
+		log:    log,
+		config: config,
+		perIP:  make(map[string]int),
+	}
+}
+
+// Middleware returns the echo middleware enforcing the limits.
+func (l *ConnectionLimiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ip := c.RealIP()
+
+			if limit, ok := l.acquire(ip); !ok {
+				metrics.ConnectionsRejected.WithLabelValues(limit).Inc()
+				l.log.Warn().
+					Str("request_id", GetRequestID(c)).
+					Str("remote_ip", ip).
+					Str("limit", limit).
+					Msg("Connection limit reached")
+				return response.Overloaded(c, l.config.RetryAfter)
+			}
+			defer l.release(ip)
+
+			return next(c)
+		}
+	}
+}
+
+// Active returns the number of requests currently being served.
+func (l *ConnectionLimiter) Active() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.active
+}
+
+// acquire reserves a slot for ip. Returns the exceeded limit name if rejected.
+func (l *ConnectionLimiter) acquire(ip string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.config.MaxConnections > 0 && l.active >= l.config.MaxConnections {
+		return LimitGlobal, false
+	}
+	if l.config.MaxPerIP > 0 && l.perIP[ip] >= l.config.MaxPerIP {
+		return LimitPerIP, false
+	}
+
+	l.active++
+	l.perIP[ip]++
+	return "", true
+}
+
+// release frees the slot held for ip.
+func (l *ConnectionLimiter) release(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.active--
+	if l.perIP[ip] <= 1 {
+		delete(l.perIP, ip)
+	} else {
+		l.perIP[ip]--
+	}
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingServer starts an echo instance whose handler blocks until release is closed.
+func blockingServer(limiter *ConnectionLimiter) (*echo.Echo, chan struct{}, *sync.WaitGroup) {
+	e := echo.New()
+	e.Use(limiter.Middleware())
+
+	release := make(chan struct{})
+	var entered sync.WaitGroup
+	e.GET("/slow", func(c echo.Context) error {
+		entered.Done()
+		<-release
+		return c.String(http.StatusOK, "ok")
+	})
+	return e, release, &entered
+}
+
+func serveFrom(e *echo.Echo, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+	req.RemoteAddr = ip + ":12345"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestConnectionLimiter_GlobalLimit(t *testing.T) {
+	limiter := NewConnectionLimiter(zerolog.Nop(), ConnectionLimitConfig{
+		MaxConnections: 2,
+		RetryAfter:     3 * time.Second,
+	})
+	e, release, entered := blockingServer(limiter)
+
+	// Occupy both slots from different clients
+	entered.Add(2)
+	var done sync.WaitGroup
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+		done.Add(1)
+		go func(ip string) {
+			defer done.Done()
+			assert.Equal(t, http.StatusOK, serveFrom(e, ip).Code)
+		}(ip)
+	}
+	entered.Wait()
+
+	rec := serveFrom(e, "10.0.0.3")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "3", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "overloaded")
+
+	close(release)
+	done.Wait()
+	assert.Equal(t, 0, limiter.Active())
+}
+
+func TestConnectionLimiter_PerIPLimit(t *testing.T) {
+	limiter := NewConnectionLimiter(zerolog.Nop(), ConnectionLimitConfig{MaxPerIP: 1})
+	e, release, entered := blockingServer(limiter)
+
+	entered.Add(1)
+	done := make(chan int)
+	go func() { done <- serveFrom(e, "10.0.0.1").Code }()
+	entered.Wait()
+
+	// Same client is rejected, another client is served
+	assert.Equal(t, http.StatusServiceUnavailable, serveFrom(e, "10.0.0.1").Code)
+
+	entered.Add(1)
+	other := make(chan int)
+	go func() { other <- serveFrom(e, "10.0.0.2").Code }()
+	entered.Wait()
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-done)
+	assert.Equal(t, http.StatusOK, <-other)
+	assert.Empty(t, limiter.perIP, "slots should be released")
+}
+
+func TestConnectionLimiter_ZeroDisablesLimits(t *testing.T) {
+	limiter := NewConnectionLimiter(zerolog.Nop(), ConnectionLimitConfig{})
+
+	for i := 0; i < 100; i++ {
+		_, ok := limiter.acquire("10.0.0.1")
+		require.True(t, ok)
+	}
+	assert.Equal(t, 100, limiter.Active())
+}
+
+func TestSearchLimiter(t *testing.T) {
+	limiter := NewSearchLimiter(zerolog.Nop(), 2, 2*time.Second)
+	e := echo.New()
+	release := make(chan struct{})
+	var entered sync.WaitGroup
+	e.GET("/slow", func(c echo.Context) error {
+		entered.Done()
+		<-release
+		return c.String(http.StatusOK, "ok")
+	}, limiter.Middleware())
+	e.GET("/fast", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	entered.Add(2)
+	var done sync.WaitGroup
+	for range 2 {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			assert.Equal(t, http.StatusOK, serveFrom(e, "10.0.0.1").Code)
+		}()
+	}
+	entered.Wait()
+	assert.Equal(t, 2, limiter.Active())
+
+	rec := serveFrom(e, "10.0.0.2")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "overloaded")
+
+	req := httptest.NewRequest(http.MethodGet, "/fast", nil)
+	fast := httptest.NewRecorder()
+	e.ServeHTTP(fast, req)
+	assert.Equal(t, http.StatusOK, fast.Code, "routes without the limiter are served")
+
+	close(release)
+	done.Wait()
+	assert.Equal(t, 0, limiter.Active())
+
+	entered.Add(1)
+	require.Equal(t, http.StatusOK, serveFrom(e, "10.0.0.2").Code, "released slots are reused")
+}
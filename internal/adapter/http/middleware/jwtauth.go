@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/jwtauth"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+// JWTAuth returns middleware that authenticates callers with OAuth2 bearer
+// tokens, the alternative to APIKey and AdminAuth. The subject of a valid
+// token becomes the request's RBAC principal, with the roles its scopes
+// grant through scopes; scopes granting no role are ignored. Requests
+// without a token proceed as the anonymous principal with the policy's
+// anonymous roles, for RequireRole to enforce. Invalid or expired tokens
+// are rejected with 401, and tokens that cannot be verified because the
+// signing keys are unavailable with 503.
+func JWTAuth(verifier *jwtauth.Verifier, scopes map[string]rbac.Role, policy rbac.Policy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			principal := rbac.Principal{Name: rbac.Anonymous, Roles: policy.Anonymous}
+
+			if header := req.Header.Get(echo.HeaderAuthorization); header != "" {
+				token, ok := bearerToken(header)
+				if !ok {
+					return response.Unauthorized(c, response.MsgMissingToken)
+				}
+				claims, err := verifier.Verify(req.Context(), token)
+				switch {
+				case errors.Is(err, jwtauth.ErrKeysUnavailable):
+					return response.ServiceUnavailableWithMessage(c, response.MsgTokenKeysUnavailable)
+				case err != nil:
+					return response.Unauthorized(c, response.MsgInvalidToken)
+				}
+
+				principal = rbac.Principal{Name: claims.Subject}
+				for _, scope := range claims.Scopes {
+					if role, ok := scopes[scope]; ok && !slices.Contains(principal.Roles, role) {
+						principal.Roles = append(principal.Roles, role)
+					}
+				}
+			}
+
+			c.SetRequest(req.WithContext(rbac.WithPrincipal(req.Context(), principal)))
+			return next(c)
+		}
+	}
+}
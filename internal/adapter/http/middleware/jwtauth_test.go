@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/jwtauth"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+func TestJWTAuth(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "OKP", "crv": "Ed25519", "kid": "k1", "x": base64.RawURLEncoding.EncodeToString(public)},
+		}})
+	}))
+	defer jwks.Close()
+
+	verifier, err := jwtauth.NewVerifier(jwtauth.Config{Issuer: "https://auth.example.com/", Audience: "flight-search", JWKSURL: jwks.URL})
+	require.NoError(t, err)
+	issue := func(scope string) string {
+		header, _ := json.Marshal(map[string]string{"alg": "EdDSA", "kid": "k1"})
+		payload, _ := json.Marshal(map[string]any{
+			"iss": "https://auth.example.com/", "aud": "flight-search", "sub": "partner-app",
+			"scope": scope, "exp": time.Now().Add(time.Minute).Unix(),
+		})
+		signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+		return "Bearer " + signingInput + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(private, []byte(signingInput)))
+	}
+
+	scopes := map[string]rbac.Role{
+		"flights:search":     rbac.RoleSearch,
+		"flights:search:all": rbac.RoleSearch,
+		"flights:admin":      rbac.RoleAdmin,
+	}
+	e := echo.New()
+	e.Use(JWTAuth(verifier, scopes, rbac.Policy{Anonymous: []rbac.Role{rbac.RoleSearch}}))
+	handler := func(c echo.Context) error {
+		principal, _ := rbac.FromContext(c.Request().Context())
+		return c.String(http.StatusOK, fmt.Sprintf("%s %v", principal.Name, principal.Roles))
+	}
+	e.GET("/api/v1/flights/search", handler, RequireRole(rbac.RoleSearch))
+	e.GET("/admin/v1/jobs", handler, RequireRole(rbac.RoleAdmin))
+
+	tests := []struct {
+		name     string
+		path     string
+		auth     string
+		wantCode int
+		wantErr  string
+		wantBody string
+	}{
+		{"search scope", "/api/v1/flights/search", issue("flights:search flights:search:all openid"), http.StatusOK, "", "partner-app [search]"},
+		{"admin scope", "/admin/v1/jobs", issue("flights:admin"), http.StatusOK, "", "partner-app [admin]"},
+		{"search scope on admin API", "/admin/v1/jobs", issue("flights:search"), http.StatusForbidden, response.MsgRoleRequired, ""},
+		{"admin scope on search API", "/api/v1/flights/search", issue("flights:admin"), http.StatusForbidden, response.MsgRoleRequired, ""},
+		{"anonymous", "/api/v1/flights/search", "", http.StatusOK, "", "anonymous [search]"},
+		{"anonymous on admin API", "/admin/v1/jobs", "", http.StatusUnauthorized, response.MsgAPIKeyRequired, ""},
+		{"wrong scheme", "/api/v1/flights/search", "Basic b3BzOmJvdA==", http.StatusUnauthorized, response.MsgMissingToken, ""},
+		{"invalid token", "/api/v1/flights/search", "Bearer not-a-token", http.StatusUnauthorized, response.MsgInvalidToken, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.auth != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantErr == "" {
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+				return
+			}
+			var result response.ErrorDetail
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			assert.Equal(t, tt.wantErr, result.Message)
+		})
+	}
+
+	t.Run("signing keys unavailable", func(t *testing.T) {
+		down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer down.Close()
+		unreachable, err := jwtauth.NewVerifier(jwtauth.Config{Issuer: "https://auth.example.com/", Audience: "flight-search", JWKSURL: down.URL})
+		require.NoError(t, err)
+		e := echo.New()
+		e.Use(JWTAuth(unreachable, scopes, rbac.Policy{}))
+		e.GET("/api/v1/flights/search", handler)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/flights/search", nil)
+		req.Header.Set(echo.HeaderAuthorization, issue("flights:search"))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+)
+
+// LoadShedding tracks the searches served through it on the shedder and
+// stores the optional features shed for each request in its context, where
+// stages check them with loadshed.Allowed.
+func LoadShedding(s *loadshed.Shedder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			done := s.Begin()
+			defer done()
+
+			ctx := loadshed.WithShed(c.Request().Context(), s.Shed())
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+)
+
+func TestLoadShedding(t *testing.T) {
+	shedder := loadshed.New(loadshed.DefaultFeatures, loadshed.Thresholds{MaxInFlight: 2})
+
+	e := echo.New()
+	e.Use(LoadShedding(shedder))
+	e.GET("/search", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		return c.String(http.StatusOK, strconv.FormatBool(loadshed.Allowed(ctx, loadshed.FeatureShadow)))
+	})
+
+	serve := func() string {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search", nil))
+		return rec.Body.String()
+	}
+
+	assert.Equal(t, "true", serve(), "one search in flight is under the threshold")
+
+	// A search still in flight brings the next one to the threshold
+	done := shedder.Begin()
+	assert.Equal(t, "false", serve())
+	done()
+	assert.Equal(t, 0, shedder.Signals().InFlight)
+}
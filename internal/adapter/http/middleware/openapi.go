@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+)
+
+// OpenAPIValidator validates requests and responses against an OpenAPI document.
+// Requests violating the spec are rejected with a 400 before reaching handlers;
+// responses violating the spec are only logged, so contract drift is visible
+// without affecting clients.
+type OpenAPIValidator struct {
+	router routers.Router
+	log    zerolog.Logger
+	config OpenAPIValidationConfig
+}
+
+// NewOpenAPIValidator creates a validator from a Swagger 2.0 JSON document,
+// such as the one served by the swag-generated docs package.
+// Routes are matched on the document's basePath only, so the validator
+// works regardless of the host the service is reached through.
+func NewOpenAPIValidator(log zerolog.Logger, spec []byte, config OpenAPIValidationConfig) (*OpenAPIValidator, error) {
+	var doc2 openapi2.T
+	if err := json.Unmarshal(spec, &doc2); err != nil {
+		return nil, fmt.Errorf("parse openapi document: %w", err)
+	}
+
+	doc3, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("convert openapi document: %w", err)
+	}
+
+	// Match on path only; the documented host is not necessarily the serving host
+	doc3.Servers = openapi3.Servers{{URL: doc2.BasePath}}
+
+	router, err := legacy.NewRouter(doc3)
+	if err != nil {
+		return nil, fmt.Errorf("build openapi router: %w", err)
+	}
+
+	return &OpenAPIValidator{
+		router: router,
+		log:    log,
+		config: config,
+	}, nil
+}
+
+// Middleware returns the echo middleware performing the validation.
+// Requests to paths not described by the document (e.g., /health, /swagger)
+// pass through unvalidated.
+func (v *OpenAPIValidator) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			route, pathParams, err := v.router.FindRoute(req)
+			if err != nil {
+				// Not part of the documented contract
+				return next(c)
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+					MultiError:         true,
+				},
+			}
+
+			if err := openapi3filter.ValidateRequest(req.Context(), input); err != nil {
+				v.log.Warn().
+					Str("request_id", GetRequestID(c)).
+					Str("method", req.Method).
+					Str("path", req.URL.Path).
+					Err(err).
+					Msg("Request violates OpenAPI spec")
+				return response.SpecViolation(c, specViolationDetails(err))
+			}
+
+			if !v.config.ValidateResponses {
+				return next(c)
+			}
+
+			// Record the response body so it can be validated after the handler runs
+			rec := &bodyRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = rec
+
+			if err := next(c); err != nil {
+				return err
+			}
+
+			v.validateResponse(c, input, rec.body.Bytes())
+			return nil
+		}
+	}
+}
+
+// validateResponse checks the recorded response against the spec and logs any drift.
+func (v *OpenAPIValidator) validateResponse(c echo.Context, input *openapi3filter.RequestValidationInput, body []byte) {
+	res := c.Response()
+
+	err := openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 res.Status,
+		Header:                 res.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(body)),
+		Options: &openapi3filter.Options{
+			MultiError:            true,
+			IncludeResponseStatus: true,
+		},
+	})
+	if err == nil {
+		return
+	}
+
+	v.log.Warn().
+		Str("request_id", GetRequestID(c)).
+		Str("method", c.Request().Method).
+		Str("path", c.Request().URL.Path).
+		Int("status", res.Status).
+		Err(err).
+		Msg("Response drifts from OpenAPI spec")
+}
+
+// specViolationDetails converts a kin-openapi validation error into field-level details.
+// Field keys use the parameter name or the JSON pointer into the request body.
+func specViolationDetails(err error) map[string]string {
+	details := make(map[string]string)
+
+	var multi openapi3.MultiError
+	if !errors.As(err, &multi) {
+		multi = openapi3.MultiError{err}
+	}
+
+	for _, e := range multi {
+		field := "request"
+		reason := e.Error()
+
+		var reqErr *openapi3filter.RequestError
+		if errors.As(e, &reqErr) {
+			reason = reqErr.Reason
+			if reqErr.Parameter != nil {
+				field = reqErr.Parameter.Name
+			} else if reqErr.RequestBody != nil {
+				field = "body"
+			}
+		}
+
+		var schemaErr *openapi3.SchemaError
+		if errors.As(e, &schemaErr) {
+			if ptr := schemaErr.JSONPointer(); len(ptr) > 0 {
+				field = "body." + joinPointer(ptr)
+			}
+			reason = schemaErr.Reason
+		}
+
+		if reason == "" {
+			reason = e.Error()
+		}
+		details[field] = reason
+	}
+
+	return details
+}
+
+// joinPointer joins JSON pointer segments with dots (e.g., ["filters", "maxPrice"] -> "filters.maxPrice").
+func joinPointer(ptr []string) string {
+	var buf bytes.Buffer
+	for i, p := range ptr {
+		if i > 0 {
+			buf.WriteByte('.')
+		}
+		buf.WriteString(p)
+	}
+	return buf.String()
+}
+
+// bodyRecorder is an http.ResponseWriter that keeps a copy of the written body.
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write writes to the underlying writer and records the bytes.
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped writer, so http.ResponseController reaches
+// its Hijack method, e.g. for WebSocket upgrades.
+func (w *bodyRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush implements http.Flusher if the underlying writer supports it.
+func (w *bodyRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
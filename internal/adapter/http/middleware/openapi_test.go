@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/docs"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+)
+
+// setupOpenAPIEcho creates an Echo instance with the OpenAPI validator using the served docs.
+func setupOpenAPIEcho(t *testing.T, logBuf *bytes.Buffer, handler echo.HandlerFunc) *echo.Echo {
+	t.Helper()
+
+	log := zerolog.New(logBuf)
+	validator, err := NewOpenAPIValidator(log, []byte(docs.SwaggerInfo.ReadDoc()), OpenAPIValidationConfig{
+		ValidateResponses: true,
+	})
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(validator.Middleware())
+	e.POST("/api/v1/flights/search", handler)
+	e.GET("/health", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	return e
+}
+
+func TestOpenAPIValidator_ValidRequestPassesThrough(t *testing.T) {
+	var logBuf bytes.Buffer
+	called := false
+	e := setupOpenAPIEcho(t, &logBuf, func(c echo.Context) error {
+		called = true
+		return c.JSON(http.StatusOK, map[string]interface{}{"flights": []interface{}{}})
+	})
+
+	body := `{"origin":"CGK","destination":"DPS","departureDate":"2025-12-15","passengers":1}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/flights/search", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.True(t, called)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestOpenAPIValidator_InvalidRequestRejected(t *testing.T) {
+	var logBuf bytes.Buffer
+	called := false
+	e := setupOpenAPIEcho(t, &logBuf, func(c echo.Context) error {
+		called = true
+		return c.NoContent(http.StatusOK)
+	})
+
+	body := `{"origin":"CGK","destination":"DPS","departureDate":"2025-12-15","passengers":"one"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/flights/search", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.False(t, called, "handler should not be called for spec violations")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var result response.ErrorDetail
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, response.CodeSpecViolation, result.Code)
+	assert.Contains(t, result.Details, "body.passengers")
+	assert.Contains(t, logBuf.String(), "Request violates OpenAPI spec")
+}
+
+func TestOpenAPIValidator_ResponseDriftLogged(t *testing.T) {
+	var logBuf bytes.Buffer
+	e := setupOpenAPIEcho(t, &logBuf, func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]interface{}{"flights": "not-an-array"})
+	})
+
+	body := `{"origin":"CGK","destination":"DPS","departureDate":"2025-12-15","passengers":1}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/flights/search", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	// Drift is logged but the response is delivered unchanged
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "not-an-array")
+	assert.Contains(t, logBuf.String(), "Response drifts from OpenAPI spec")
+}
+
+func TestOpenAPIValidator_UndocumentedPathSkipped(t *testing.T) {
+	var logBuf bytes.Buffer
+	e := setupOpenAPIEcho(t, &logBuf, func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, logBuf.String())
+}
+
+func TestNewOpenAPIValidator_InvalidDocument(t *testing.T) {
+	_, err := NewOpenAPIValidator(zerolog.Nop(), []byte("{invalid"), OpenAPIValidationConfig{})
+	assert.Error(t, err)
+}
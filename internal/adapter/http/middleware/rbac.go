@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+// RequireRole returns middleware that rejects requests whose principal does
+// not hold role. It relies on APIKey (or AdminAuth) having attached the
+// principal; requests without one are treated as anonymous without roles.
+func RequireRole(role rbac.Role) echo.MiddlewareFunc {
+	return RequireRoleWhen(role, nil)
+}
+
+// RequireRoleWhen is like RequireRole but only checks requests for which
+// when returns true, e.g. requests asking for a debug option. A nil when
+// checks every request.
+func RequireRoleWhen(role rbac.Role, when func(echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if when != nil && !when(c) {
+				return next(c)
+			}
+
+			principal, ok := rbac.FromContext(c.Request().Context())
+			if !ok {
+				principal = rbac.Principal{Name: rbac.Anonymous}
+			}
+			if !principal.Has(role) {
+				return response.RoleRequired(c, string(role), principal.IsAnonymous())
+			}
+			return next(c)
+		}
+	}
+}
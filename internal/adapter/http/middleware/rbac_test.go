@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/tenant"
+)
+
+func TestRequireRole(t *testing.T) {
+	registry, err := tenant.NewRegistry(
+		tenant.Profile{Key: "search-key", Name: "search-partner"},
+		tenant.Profile{Key: "export-key", Name: "export-partner", Roles: []rbac.Role{rbac.RoleExport}},
+	)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(APIKey(registry, rbac.Policy{Keys: []rbac.Role{rbac.RoleSearch}}))
+	ok := func(c echo.Context) error { return c.String(http.StatusOK, "ok") }
+	e.GET("/search", ok, RequireRole(rbac.RoleSearch))
+	e.GET("/export", ok, RequireRole(rbac.RoleExport))
+	e.GET("/replay", ok, RequireRoleWhen(rbac.RoleDebug, func(c echo.Context) bool {
+		return c.QueryParam("debug") == "true"
+	}))
+
+	tests := []struct {
+		name     string
+		path     string
+		key      string
+		wantCode int
+		wantErr  string
+	}{
+		{"default key role", "/search", "search-key", http.StatusOK, ""},
+		{"profile roles replace defaults", "/search", "export-key", http.StatusForbidden, response.MsgRoleRequired},
+		{"profile role", "/export", "export-key", http.StatusOK, ""},
+		{"missing role", "/export", "search-key", http.StatusForbidden, response.MsgRoleRequired},
+		{"anonymous without role", "/search", "", http.StatusUnauthorized, response.MsgAPIKeyRequired},
+		{"unknown key is anonymous", "/search", "other", http.StatusUnauthorized, response.MsgAPIKeyRequired},
+		{"condition not met", "/replay", "search-key", http.StatusOK, ""},
+		{"condition met", "/replay?debug=true", "search-key", http.StatusForbidden, response.MsgRoleRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantErr == "" {
+				return
+			}
+			var result response.ErrorDetail
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			assert.Equal(t, tt.wantErr, result.Message)
+			assert.NotEmpty(t, result.Details["requiredRole"])
+		})
+	}
+}
+
+func TestRequireRole_NoPrincipal(t *testing.T) {
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, RequireRole(rbac.RoleSearch))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/metrics"
+)
+
+// SearchLimiter caps the number of searches served concurrently. Unlike the
+// ConnectionLimiter, it only guards the routes that fan out to the
+// providers, so a spike of searches cannot open more provider connections
+// or hold more results in memory than the server is sized for, while cheap
+// requests keep being served. Searches beyond the limit are rejected at
+// once with a 503 and a Retry-After header instead of queueing.
+type SearchLimiter struct {
+	log        zerolog.Logger
+	slots      chan struct{}
+	retryAfter time.Duration
+}
+
+// NewSearchLimiter creates a limiter serving up to max searches at once and
+// suggesting rejected clients retry after retryAfter.
+func NewSearchLimiter(log zerolog.Logger, max int, retryAfter time.Duration) *SearchLimiter {
+	return &SearchLimiter{
+		log:        log,
+		slots:      make(chan struct{}, max),
+		retryAfter: retryAfter,
+	}
+}
+
+// Middleware returns the echo middleware enforcing the limit.
+func (l *SearchLimiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			select {
+			case l.slots <- struct{}{}:
+			default:
+				metrics.ConnectionsRejected.WithLabelValues(LimitSearches).Inc()
+				l.log.Warn().
+					Str("request_id", GetRequestID(c)).
+					Int("limit", cap(l.slots)).
+					Msg("Concurrent search limit reached")
+				return response.Overloaded(c, l.retryAfter)
+			}
+			defer func() { <-l.slots }()
+
+			return next(c)
+		}
+	}
+}
+
+// Active returns the number of searches currently being served.
+func (l *SearchLimiter) Active() int {
+	return len(l.slots)
+}
@@ -1,8 +1,38 @@
 package middleware
 
+import "time"
+
 // RecoveryConfig holds configuration for the recovery middleware.
 type RecoveryConfig struct {
 	StackSize         int
 	DisableStackAll   bool
 	DisablePrintStack bool
 }
+
+// OpenAPIValidationConfig holds configuration for the OpenAPI validation middleware.
+type OpenAPIValidationConfig struct {
+	// ValidateResponses enables logging of responses that drift from the spec
+	ValidateResponses bool
+}
+
+// ConnectionLimitConfig holds configuration for the connection limit middleware.
+// A zero limit disables the corresponding check.
+type ConnectionLimitConfig struct {
+	// MaxConnections is the maximum number of requests served concurrently
+	MaxConnections int
+
+	// MaxPerIP is the maximum number of concurrent requests from a single client IP
+	MaxPerIP int
+
+	// RetryAfter is the delay suggested to rejected clients via the Retry-After header
+	RetryAfter time.Duration
+}
+
+// CompressionConfig holds configuration for the response compression middleware.
+type CompressionConfig struct {
+	// Level is the gzip compression level, from 1 (fastest) to 9 (smallest)
+	Level int
+
+	// MinBytes is the response size from which responses are compressed
+	MinBytes int
+}
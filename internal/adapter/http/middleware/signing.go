@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/signing"
+)
+
+// SignResponses signs JSON responses with signer, sending the detached JWS of
+// the body in the signing.Header header. JSON bodies are buffered until the
+// handler returns so the header can precede them; other responses, such as
+// event streams, pass through unsigned.
+func SignResponses(log zerolog.Logger, signer *signing.Signer) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res := c.Response()
+			w := &signingWriter{ResponseWriter: res.Writer}
+			res.Writer = w
+			defer func() { res.Writer = w.ResponseWriter }()
+
+			// Render errors here, while the body is still being buffered
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+			if !w.buffering {
+				return nil
+			}
+
+			// HEAD responses have no body to sign
+			if w.body.Len() > 0 {
+				signature, err := signer.Sign(w.body.Bytes())
+				if err != nil {
+					log.Warn().
+						Str("request_id", GetRequestID(c)).
+						Str("path", c.Request().URL.Path).
+						Err(err).
+						Msg("Response not signed")
+				} else {
+					w.Header().Set(signing.Header, signature)
+				}
+			}
+			w.ResponseWriter.WriteHeader(w.status)
+			_, err := w.ResponseWriter.Write(w.body.Bytes())
+			return err
+		}
+	}
+}
+
+// signingWriter is an http.ResponseWriter that holds back JSON responses so
+// they can be signed once complete.
+type signingWriter struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+	buffering   bool
+}
+
+// WriteHeader buffers JSON responses and forwards the others.
+func (w *signingWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	w.buffering = strings.HasPrefix(w.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+	if !w.buffering {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+// Write buffers the body of JSON responses and forwards the others.
+func (w *signingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffering {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped writer, so http.ResponseController reaches
+// its Hijack method, e.g. for WebSocket upgrades.
+func (w *signingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush implements http.Flusher for unbuffered responses if the underlying
+// writer supports it.
+func (w *signingWriter) Flush() {
+	if w.buffering {
+		return
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
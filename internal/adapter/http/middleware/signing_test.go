@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/signing"
+)
+
+// setupSigningEcho creates an Echo instance signing the responses of a few routes.
+func setupSigningEcho(t *testing.T) (*echo.Echo, *signing.Signer) {
+	t.Helper()
+
+	signer, err := signing.NewSigner("test", bytes.Repeat([]byte{1}, ed25519.SeedSize))
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(SignResponses(zerolog.Nop(), signer))
+	e.GET("/flights", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]any{"flights": []string{"GA400"}})
+	})
+	e.GET("/missing", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, "no such search")
+	})
+	e.GET("/text", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	return e, signer
+}
+
+func TestSignResponses(t *testing.T) {
+	e, signer := setupSigningEcho(t)
+
+	for _, path := range []string{"/flights", "/missing"} {
+		t.Run(path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+			signature := rec.Header().Get(signing.Header)
+			require.NotEmpty(t, signature, "JSON responses, errors included, are signed")
+			assert.NoError(t, signing.Verify(signature, rec.Body.Bytes(), signer.PublicKey()))
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/flights", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"flights":["GA400"]}`, rec.Body.String())
+}
+
+func TestSignResponses_SkipsNonJSON(t *testing.T) {
+	e, _ := setupSigningEcho(t)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/text", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ok", rec.Body.String())
+	assert.Empty(t, rec.Header().Get(signing.Header))
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/tracing"
+)
+
+// TraceContext returns middleware that reads W3C traceparent or B3 headers,
+// attaches the trace context to the request context, and echoes the headers
+// back on the response. Handlers and provider adapters read the trace context
+// with tracing.FromContext. Requests without valid tracing headers are unchanged.
+func TraceContext() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if tc, ok := tracing.Extract(req.Header); ok {
+				c.SetRequest(req.WithContext(tracing.WithTraceContext(req.Context(), tc)))
+				tracing.Inject(c.Response().Header(), tc)
+			}
+			return next(c)
+		}
+	}
+}
+
+// GetTraceID retrieves the trace ID from the request context.
+// Returns an empty string if the request carried no tracing headers.
+func GetTraceID(c echo.Context) string {
+	if tc, ok := tracing.FromContext(c.Request().Context()); ok {
+		return tc.TraceID
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/tracing"
+)
+
+func TestTraceContext(t *testing.T) {
+	e := echo.New()
+	e.Use(TraceContext())
+	e.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, GetTraceID(c))
+	})
+
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		wantTraceID string
+		wantEcho    map[string]string
+	}{
+		{
+			name: "w3c",
+			headers: map[string]string{
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				"tracestate":  "vendor=abc",
+			},
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantEcho: map[string]string{
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				"tracestate":  "vendor=abc",
+			},
+		},
+		{
+			name:        "b3 single",
+			headers:     map[string]string{"b3": "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1"},
+			wantTraceID: "80f198ee56343ba864fe8b2a57d3eff7",
+			wantEcho:    map[string]string{"b3": "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1"},
+		},
+		{
+			name: "b3 multi",
+			headers: map[string]string{
+				tracing.HeaderB3TraceID: "463ac35c9f6413ad",
+				tracing.HeaderB3SpanID:  "a2fb4a1d1a96d312",
+				tracing.HeaderB3Sampled: "1",
+			},
+			wantTraceID: "463ac35c9f6413ad",
+			wantEcho: map[string]string{
+				tracing.HeaderB3TraceID: "463ac35c9f6413ad",
+				tracing.HeaderB3SpanID:  "a2fb4a1d1a96d312",
+				tracing.HeaderB3Sampled: "1",
+			},
+		},
+		{
+			name:     "invalid header ignored",
+			headers:  map[string]string{"traceparent": "not-a-trace"},
+			wantEcho: map[string]string{"traceparent": ""},
+		},
+		{
+			name: "no headers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.wantTraceID, rec.Body.String())
+			for k, v := range tt.wantEcho {
+				assert.Equal(t, v, rec.Header().Get(k), "response header %s", k)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+// AirlinesResponseDTO lists the airlines of the reference data.
+type AirlinesResponseDTO struct {
+	Airlines []AirlineReferenceDTO `json:"airlines"`
+}
+
+// AirlineReferenceDTO is an airline of the reference data.
+type AirlineReferenceDTO struct {
+	Code     string `json:"code"`
+	ICAO     string `json:"icao,omitempty"`
+	Name     string `json:"name"`
+	Alliance string `json:"alliance,omitempty"`
+	Logo     string `json:"logo,omitempty"`
+}
+
+// ToAirlinesResponseDTO converts reference airlines to an AirlinesResponseDTO,
+// with names in the given locale (English if empty or unsupported).
+func ToAirlinesResponseDTO(airlines []reference.Airline, loc reference.Locale) *AirlinesResponseDTO {
+	dto := &AirlinesResponseDTO{Airlines: make([]AirlineReferenceDTO, 0, len(airlines))}
+	for _, a := range airlines {
+		dto.Airlines = append(dto.Airlines, AirlineReferenceDTO{
+			Code:     a.Code,
+			ICAO:     a.ICAO,
+			Name:     a.Name.In(loc),
+			Alliance: a.Alliance,
+			Logo:     a.Logo,
+		})
+	}
+	return dto
+}
+
+// AirportsResponseDTO lists the airports of the reference data matching a query.
+type AirportsResponseDTO struct {
+	Airports []AirportReferenceDTO `json:"airports"`
+}
+
+// AirportReferenceDTO is an airport of the reference data.
+type AirportReferenceDTO struct {
+	Code     string `json:"code"`
+	ICAO     string `json:"icao"`
+	Name     string `json:"name"`
+	City     string `json:"city"`
+	Country  string `json:"country"`
+	Timezone string `json:"timezone"`
+}
+
+// ToAirportsResponseDTO converts reference airports to an AirportsResponseDTO,
+// with names in the given locale (English if empty or unsupported).
+func ToAirportsResponseDTO(airports []reference.Airport, loc reference.Locale) *AirportsResponseDTO {
+	dto := &AirportsResponseDTO{Airports: make([]AirportReferenceDTO, 0, len(airports))}
+	for _, a := range airports {
+		dto.Airports = append(dto.Airports, AirportReferenceDTO{
+			Code:     a.Code,
+			ICAO:     a.ICAO,
+			Name:     a.Name.In(loc),
+			City:     a.City.In(loc),
+			Country:  a.Country,
+			Timezone: a.Timezone,
+		})
+	}
+	return dto
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+// AirportQueryParam is the query parameter holding the airport lookup's query.
+const AirportQueryParam = "q"
+
+// Limits of the airport lookup endpoint's ?limit=.
+const (
+	DefaultAirportLimit = 10
+	MaxAirportLimit     = 50
+)
+
+// ReferenceHandler serves the bundled reference data, so clients can show
+// airline names and logos and autocomplete airports without a lookup of
+// their own.
+type ReferenceHandler struct{}
+
+// NewReferenceHandler creates a new ReferenceHandler.
+func NewReferenceHandler() *ReferenceHandler {
+	return &ReferenceHandler{}
+}
+
+// ListAirlines handles GET /api/v1/airlines
+// It returns every airline of the reference data, sorted by code, with names
+// in the locale selected as for searches.
+func (h *ReferenceHandler) ListAirlines(c echo.Context) error {
+	return response.OK(c, ToAirlinesResponseDTO(reference.Airlines(), responseLocale(c)))
+}
+
+// ListAirports handles GET /api/v1/airports
+// It returns the airports of the reference data whose code, city or name
+// matches ?q=, best matches first, up to ?limit= airports (default 10), with
+// names in the locale selected as for searches.
+func (h *ReferenceHandler) ListAirports(c echo.Context) error {
+	query := c.QueryParam(AirportQueryParam)
+	if strings.TrimSpace(query) == "" {
+		return response.ValidationError(c, map[string]string{
+			AirportQueryParam: "is required",
+		})
+	}
+	limit := DefaultAirportLimit
+	if raw := c.QueryParam(LimitQueryParam); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > MaxAirportLimit {
+			return response.ValidationError(c, map[string]string{
+				LimitQueryParam: fmt.Sprintf("must be between 1 and %d", MaxAirportLimit),
+			})
+		}
+		limit = n
+	}
+	return response.OK(c, ToAirportsResponseDTO(reference.SearchAirports(query, limit), responseLocale(c)))
+}
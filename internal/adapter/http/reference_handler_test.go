@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+func TestReferenceHandler_ListAirlines(t *testing.T) {
+	e := echo.New()
+	RegisterReferenceRoutes(e, NewReferenceHandler())
+
+	tests := []struct {
+		name     string
+		query    string
+		wantName string
+	}{
+		{name: "default locale", wantName: "Indonesia AirAsia"},
+		{name: "indonesian", query: "?locale=id", wantName: "AirAsia Indonesia"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/airlines"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			var result AirlinesResponseDTO
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			require.Len(t, result.Airlines, len(reference.Airlines()))
+			assert.Equal(t, AirlineReferenceDTO{
+				Code:     "GA",
+				ICAO:     "GIA",
+				Name:     "Garuda Indonesia",
+				Alliance: reference.AllianceSkyTeam,
+				Logo:     "https://pics.avs.io/200/200/GA.png",
+			}, result.Airlines[0])
+
+			i := slices.IndexFunc(result.Airlines, func(a AirlineReferenceDTO) bool { return a.Code == "QZ" })
+			require.GreaterOrEqual(t, i, 0)
+			assert.Equal(t, tt.wantName, result.Airlines[i].Name)
+		})
+	}
+}
+
+func TestReferenceHandler_ListAirports(t *testing.T) {
+	e := echo.New()
+	RegisterReferenceRoutes(e, NewReferenceHandler())
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCode  int
+		wantCodes []string
+		wantField string
+	}{
+		{name: "code", query: "?q=dps", wantCode: http.StatusOK, wantCodes: []string{"DPS"}},
+		{name: "city", query: "?q=jak", wantCode: http.StatusOK, wantCodes: []string{"CGK"}},
+		{name: "limit", query: "?q=s&limit=2", wantCode: http.StatusOK, wantCodes: []string{"SIN", "SOC"}},
+		{name: "no match", query: "?q=zzz", wantCode: http.StatusOK, wantCodes: []string{}},
+		{name: "missing query", query: "", wantCode: http.StatusBadRequest, wantField: AirportQueryParam},
+		{name: "limit too large", query: "?q=s&limit=51", wantCode: http.StatusBadRequest, wantField: LimitQueryParam},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/airports"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantField != "" {
+				var result response.ErrorDetail
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+				assert.Contains(t, result.Details, tt.wantField)
+				return
+			}
+			var result AirportsResponseDTO
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			codes := make([]string, 0, len(result.Airports))
+			for _, a := range result.Airports {
+				codes = append(codes, a.Code)
+			}
+			assert.Equal(t, tt.wantCodes, codes)
+		})
+	}
+}
+
+func TestToAirportsResponseDTO(t *testing.T) {
+	dto := ToAirportsResponseDTO(reference.SearchAirports("SIN", 1), reference.LocaleIndonesian)
+	assert.Equal(t, []AirportReferenceDTO{{
+		Code:     "SIN",
+		ICAO:     "WSSS",
+		Name:     reference.AirportName("SIN", reference.LocaleIndonesian),
+		City:     "Singapura",
+		Country:  "SG",
+		Timezone: "Asia/Singapore",
+	}}, dto.Airports)
+}
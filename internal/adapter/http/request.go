@@ -7,6 +7,10 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/webhook"
 )
 
 // SearchFlightsRequest represents the request body for flight search.
@@ -20,7 +24,7 @@ type SearchFlightsRequest struct {
 	// DepartureDate is the desired departure date in YYYY-MM-DD format
 	DepartureDate string `json:"departureDate"`
 
-	// Passengers is the number of passengers (1-9)
+	// Passengers is the number of passengers (1-9, or 10-99 for a group quote)
 	Passengers int `json:"passengers"`
 
 	// Class is the travel class: economy, business, or first (optional)
@@ -29,30 +33,126 @@ type SearchFlightsRequest struct {
 	// Filters contains optional filtering criteria
 	Filters *FilterDTO `json:"filters,omitempty"`
 
-	// SortBy specifies how to sort results: best_value, price, duration, departure
+	// SortBy specifies how to sort results: best_value, price, duration, departure, arrival
 	SortBy string `json:"sortBy,omitempty"`
+
+	// SortOrder is the direction of the sort: asc (default) or desc, e.g.
+	// most expensive or latest departure first
+	SortOrder string `json:"sortOrder,omitempty" example:"desc"`
+
+	// PreferredDepartureTime is the time of day the passengers prefer to
+	// depart (optional). Best value ranking favors flights departing within
+	// it, without excluding the others
+	PreferredDepartureTime *TimeRangeDTO `json:"preferredDepartureTime,omitempty"`
+
+	// MaxResults limits the number of flights returned (1-500, optional)
+	MaxResults *int `json:"maxResults,omitempty" example:"20"`
+
+	// MaxResultsPerProvider limits the number of flights of each provider (1-500, optional)
+	MaxResultsPerProvider *int `json:"maxResultsPerProvider,omitempty" example:"5"`
+
+	// Fields limits each flight to the listed fields (optional, "id" is always included)
+	Fields []string `json:"fields,omitempty" example:"id,provider,price"`
+
+	// PartialResultsOK returns the providers' answers at the soft deadline instead of waiting for all of them (optional)
+	PartialResultsOK bool `json:"partialResultsOk,omitempty" example:"true"`
+
+	// FanOutStrategy is how the providers are queried: all-parallel,
+	// fastest-first-return or sequential-failover (optional, defaults to the
+	// server's strategy)
+	FanOutStrategy string `json:"fanOutStrategy,omitempty" example:"fastest-first-return"`
+
+	// SpecialServices are the special assistance services the passengers need,
+	// e.g. "wheelchair" (optional). Flights supporting all of them are listed first
+	SpecialServices []string `json:"specialServices,omitempty" example:"wheelchair"`
+
+	// ReturnDate makes the search a round trip flying the outbound route back
+	// on this date, in YYYY-MM-DD format (optional, shorthand for return)
+	ReturnDate string `json:"returnDate,omitempty" example:"2025-12-20"`
+
+	// Return is the return leg of a round trip (optional). Its origin may differ
+	// from the outbound destination for open-jaw trips
+	Return *ReturnLegDTO `json:"return,omitempty"`
+
+	// DateFlexibility also searches departures up to this many days before
+	// and after departureDate (0-3, optional, one way only)
+	DateFlexibility int `json:"dateFlexibility,omitempty" example:"3"`
+
+	// CallbackURL makes the search asynchronous (optional): the request is
+	// answered at once with the search ID, and the results are POSTed to
+	// this absolute http(s) URL once the search completes
+	CallbackURL string `json:"callbackUrl,omitempty" example:"https://partner.example.com/flight-results"`
+}
+
+// ReturnLegDTO represents the return leg of a round trip.
+// Example: {"origin": "SUB", "destination": "CGK", "departureDate": "2025-12-20"}
+type ReturnLegDTO struct {
+	// Origin is the IATA code of the return departure airport
+	Origin string `json:"origin" example:"SUB"`
+
+	// Destination is the IATA code of the return arrival airport
+	Destination string `json:"destination" example:"CGK"`
+
+	// DepartureDate is the return departure date in YYYY-MM-DD format
+	DepartureDate string `json:"departureDate" example:"2025-12-20"`
 }
 
 // FilterDTO represents optional filters for flight search.
 // Example: {"maxPrice": 1000000, "maxStops": 0, "departureTimeRange": {"start": "06:00", "end": "12:00"}, "arrivalTimeRange": {"start": "08:00", "end": "17:00"}}
 type FilterDTO struct {
+	// MinPrice filters flights with price below this amount, e.g. zero-price fares
+	MinPrice *float64 `json:"minPrice,omitempty" example:"100000"`
+
 	// MaxPrice filters flights with price above this amount
 	MaxPrice *float64 `json:"maxPrice,omitempty" example:"1000000"`
 
 	// MaxStops filters flights with more stops than this value (0 = direct only)
 	MaxStops *int `json:"maxStops,omitempty" example:"0"`
 
+	// MinSeatsAvailable filters flights with fewer seats left than this, e.g.
+	// for a group booking. Flights whose provider reports no seat count are
+	// excluded.
+	MinSeatsAvailable *int `json:"minSeatsAvailable,omitempty" example:"4"`
+
 	// Airlines filters to only include flights from these airline codes
 	Airlines []string `json:"airlines,omitempty" example:"GA,JT"`
 
 	// DepartureTimeRange filters flights departing within a time window
 	DepartureTimeRange *TimeRangeDTO `json:"departureTimeRange,omitempty"`
 
+	// DepartureTimeBucket filters flights departing within a part of the day,
+	// instead of DepartureTimeRange: early_morning, morning, afternoon,
+	// evening or night
+	DepartureTimeBucket string `json:"departureTimeBucket,omitempty" example:"morning"`
+
 	// ArrivalTimeRange filters flights arriving within a time window
 	ArrivalTimeRange *TimeRangeDTO `json:"arrivalTimeRange,omitempty"`
 
 	// DurationRange filters flights by total duration in minutes
 	DurationRange *DurationRangeDTO `json:"durationRange,omitempty"`
+
+	// SupportsWheelchair only includes flights offering wheelchair assistance
+	SupportsWheelchair *bool `json:"supportsWheelchair,omitempty" example:"true"`
+
+	// FareFamilies filters to only include fares of these fare families, e.g.
+	// "Flex" (case-insensitive); flights without a fare family are filtered out
+	FareFamilies []string `json:"fareFamilies,omitempty" example:"Value,Flex"`
+
+	// Changeable only includes fares whose fare family allows changes
+	Changeable *bool `json:"changeable,omitempty" example:"true"`
+
+	// Refundable only includes fares whose fare family allows refunds
+	Refundable *bool `json:"refundable,omitempty" example:"true"`
+
+	// RefundableOnly only includes flights whose fare rules allow refunds,
+	// with or without a fare family; flights whose provider reports no fare
+	// rules are filtered out
+	RefundableOnly *bool `json:"refundableOnly,omitempty" example:"true"`
+
+	// IncludeHigherClasses keeps flights of a class above the one searched for,
+	// e.g. business class flights on an economy search; other flights of
+	// another class than the one searched for are always filtered out
+	IncludeHigherClasses *bool `json:"includeHigherClasses,omitempty" example:"true"`
 }
 
 // TimeRangeDTO represents a time window for filtering.
@@ -78,11 +178,33 @@ type DurationRangeDTO struct {
 
 // Validation regex patterns.
 var (
-	airportCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
-	datePattern        = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
-	timePattern        = regexp.MustCompile(`^\d{2}:\d{2}$`)
+	airportCodePattern        = regexp.MustCompile(`^[A-Z]{3}$`)
+	icaoAirportCodePattern    = regexp.MustCompile(`^[A-Z]{4}$`)
+	airlineCodePattern        = regexp.MustCompile(`^(?:[A-Z0-9]{2}|[A-Z]{3})$`)
+	charterAirlineCodePattern = regexp.MustCompile(`^[A-Z]{2}[0-9]$`)
+	datePattern               = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	timePattern               = regexp.MustCompile(`^\d{2}:\d{2}$`)
 )
 
+// CodeValidation is how strictly airport and airline codes are validated.
+type CodeValidation string
+
+const (
+	// CodeValidationStrict accepts 3-letter IATA airport codes, and 2-character
+	// IATA or 3-letter ICAO airline codes
+	CodeValidationStrict CodeValidation = "strict"
+
+	// CodeValidationCharter also accepts the 4-letter ICAO codes of the
+	// airports in the reference dataset, mapped to their IATA codes, and
+	// charter airline codes made of two letters and a digit (e.g., "XY1")
+	CodeValidationCharter CodeValidation = "charter"
+)
+
+// IsValid reports whether m is a known validation mode.
+func (m CodeValidation) IsValid() bool {
+	return m == CodeValidationStrict || m == CodeValidationCharter
+}
+
 // Valid travel classes.
 var validClasses = map[string]bool{
 	"economy":  true,
@@ -97,9 +219,20 @@ var validSortOptions = map[string]bool{
 	"price":     true,
 	"duration":  true,
 	"departure": true,
+	"arrival":   true,
 	"":          true, // Empty is valid (defaults to best_value)
 }
 
+// Valid sort orders.
+var validSortOrders = map[string]bool{
+	"asc":  true,
+	"desc": true,
+	"":     true, // Empty is valid (defaults to asc)
+}
+
+// MaxResultsLimit is the largest accepted maxResults value.
+const MaxResultsLimit = 500
+
 // ValidationError represents a field-level validation error.
 type ValidationError struct {
 	Field   string `json:"field"`
@@ -141,15 +274,23 @@ func (v *ValidationErrors) ToMap() map[string]string {
 	return result
 }
 
-// Validate validates the search request and returns any validation errors.
+// Validate validates the search request with strict code validation and
+// returns any validation errors.
 func (r *SearchFlightsRequest) Validate() error {
+	return r.ValidateWith(CodeValidationStrict)
+}
+
+// ValidateWith validates the search request, checking airport and airline
+// codes according to mode, and returns any validation errors. Airport codes
+// are normalized to uppercase IATA codes.
+func (r *SearchFlightsRequest) ValidateWith(mode CodeValidation) error {
 	errs := &ValidationErrors{}
 
 	// Validate origin
-	r.validateOrigin(errs)
+	r.validateOrigin(errs, mode)
 
 	// Validate destination
-	r.validateDestination(errs)
+	r.validateDestination(errs, mode)
 
 	// Check origin != destination
 	r.validateOriginDestinationDifferent(errs)
@@ -157,17 +298,43 @@ func (r *SearchFlightsRequest) Validate() error {
 	// Validate departure date
 	r.validateDepartureDate(errs)
 
+	// Validate return leg
+	r.validateReturnDate(errs)
+	r.validateReturn(errs, mode)
+
 	// Validate passengers
 	r.validatePassengers(errs)
 
+	// Validate date flexibility
+	r.validateDateFlexibility(errs)
+
 	// Validate class
 	r.validateClass(errs)
 
-	// Validate sort option
+	// Validate sort option and order
 	r.validateSortBy(errs)
+	r.validateSortOrder(errs)
+
+	// Validate fan-out strategy
+	r.validateFanOutStrategy(errs)
+
+	// Validate departure time preference
+	r.validatePreferredDepartureTime(errs)
+
+	// Validate result limit
+	r.validateMaxResults(errs)
+
+	// Validate response fields
+	r.validateFields(errs)
+
+	// Validate special assistance services
+	r.validateSpecialServices(errs)
 
 	// Validate filters
-	r.validateFilters(errs)
+	r.validateFilters(errs, mode)
+
+	// Validate callback URL
+	r.validateCallbackURL(errs)
 
 	if errs.HasErrors() {
 		return errs
@@ -175,32 +342,46 @@ func (r *SearchFlightsRequest) Validate() error {
 	return nil
 }
 
-func (r *SearchFlightsRequest) validateOrigin(errs *ValidationErrors) {
+func (r *SearchFlightsRequest) validateOrigin(errs *ValidationErrors, mode CodeValidation) {
 	if r.Origin == "" {
 		errs.Add("origin", "origin is required")
 		return
 	}
+	validateAirportCode(errs, "origin", &r.Origin, mode)
+}
 
-	origin := strings.ToUpper(r.Origin)
-	if !airportCodePattern.MatchString(origin) {
-		errs.Add("origin", "origin must be a valid 3-letter IATA airport code")
+func (r *SearchFlightsRequest) validateDestination(errs *ValidationErrors, mode CodeValidation) {
+	if r.Destination == "" {
+		errs.Add("destination", "destination is required")
 		return
 	}
-	r.Origin = origin // Normalize to uppercase
+	validateAirportCode(errs, "destination", &r.Destination, mode)
 }
 
-func (r *SearchFlightsRequest) validateDestination(errs *ValidationErrors) {
-	if r.Destination == "" {
-		errs.Add("destination", "destination is required")
+// validateAirportCode checks the airport code of field and normalizes it to
+// an uppercase IATA code. In charter mode, the ICAO code of an airport in the
+// reference dataset is mapped to its IATA code.
+func validateAirportCode(errs *ValidationErrors, field string, code *string, mode CodeValidation) {
+	upper := strings.ToUpper(*code)
+	if airportCodePattern.MatchString(upper) {
+		*code = upper // Normalize to uppercase
 		return
 	}
 
-	dest := strings.ToUpper(r.Destination)
-	if !airportCodePattern.MatchString(dest) {
-		errs.Add("destination", "destination must be a valid 3-letter IATA airport code")
+	if mode != CodeValidationCharter {
+		errs.Add(field, field+" must be a valid 3-letter IATA airport code")
 		return
 	}
-	r.Destination = dest // Normalize to uppercase
+	if !icaoAirportCodePattern.MatchString(upper) {
+		errs.Add(field, field+" must be a valid 3-letter IATA or 4-letter ICAO airport code")
+		return
+	}
+	airport, ok := reference.LookupAirportByICAO(upper)
+	if !ok {
+		errs.Add(field, fmt.Sprintf("%s ICAO code %s has no known IATA equivalent", field, upper))
+		return
+	}
+	*code = airport.Code
 }
 
 func (r *SearchFlightsRequest) validateOriginDestinationDifferent(errs *ValidationErrors) {
@@ -228,16 +409,108 @@ func (r *SearchFlightsRequest) validateDepartureDate(errs *ValidationErrors) {
 	}
 }
 
+func (r *SearchFlightsRequest) validateReturn(errs *ValidationErrors, mode CodeValidation) {
+	if r.Return == nil {
+		return
+	}
+	ret := r.Return
+
+	for _, code := range []struct {
+		field string
+		value *string
+	}{
+		{"return.origin", &ret.Origin},
+		{"return.destination", &ret.Destination},
+	} {
+		if *code.value == "" {
+			errs.Add(code.field, code.field+" is required")
+			continue
+		}
+		validateAirportCode(errs, code.field, code.value, mode)
+	}
+	if ret.Origin != "" && strings.EqualFold(ret.Origin, ret.Destination) {
+		errs.Add("return.destination", "return origin and destination must be different")
+	}
+
+	if ret.DepartureDate == "" {
+		errs.Add("return.departureDate", "return.departureDate is required")
+		return
+	}
+	returnDate, err := time.Parse("2006-01-02", ret.DepartureDate)
+	if !datePattern.MatchString(ret.DepartureDate) || err != nil {
+		errs.Add("return.departureDate", "return.departureDate must be a valid date in YYYY-MM-DD format")
+		return
+	}
+	if departure, err := time.Parse("2006-01-02", r.DepartureDate); err == nil && returnDate.Before(departure) {
+		errs.Add("return.departureDate", "return.departureDate cannot be before departureDate")
+	}
+}
+
+func (r *SearchFlightsRequest) validateReturnDate(errs *ValidationErrors) {
+	if r.ReturnDate == "" {
+		return
+	}
+	if r.Return != nil {
+		errs.Add("returnDate", "returnDate cannot be combined with return")
+		return
+	}
+	returnDate, err := time.Parse("2006-01-02", r.ReturnDate)
+	if !datePattern.MatchString(r.ReturnDate) || err != nil {
+		errs.Add("returnDate", "returnDate must be a valid date in YYYY-MM-DD format")
+		return
+	}
+	if departure, err := time.Parse("2006-01-02", r.DepartureDate); err == nil && returnDate.Before(departure) {
+		errs.Add("returnDate", "returnDate cannot be before departureDate")
+	}
+}
+
+// IsRoundTrip reports whether the request has a return leg, given either as
+// returnDate or return.
+func (r *SearchFlightsRequest) IsRoundTrip() bool {
+	return r.Return != nil || r.ReturnDate != ""
+}
+
 func (r *SearchFlightsRequest) validatePassengers(errs *ValidationErrors) {
 	if r.Passengers < 1 {
 		errs.Add("passengers", "passengers must be at least 1")
 		return
 	}
-	if r.Passengers > 9 {
-		errs.Add("passengers", "passengers cannot exceed 9")
+	if r.Passengers > domain.MaxGroupPassengers {
+		errs.Add("passengers", fmt.Sprintf("passengers cannot exceed %d", domain.MaxGroupPassengers))
+		return
+	}
+
+	// Larger parties get a group quote, which covers one-way trips only
+	if r.IsGroup() && r.IsRoundTrip() {
+		errs.Add("return", fmt.Sprintf("return is not supported for more than %d passengers", domain.MaxPassengers))
 	}
 }
 
+func (r *SearchFlightsRequest) validateDateFlexibility(errs *ValidationErrors) {
+	if r.DateFlexibility < 0 || r.DateFlexibility > domain.MaxDateFlexibility {
+		errs.Add("dateFlexibility", fmt.Sprintf("dateFlexibility must be between 0 and %d", domain.MaxDateFlexibility))
+		return
+	}
+	if r.DateFlexibility == 0 {
+		return
+	}
+
+	// Flexible dates cover one-way searches only
+	if r.IsRoundTrip() {
+		errs.Add("dateFlexibility", "dateFlexibility cannot be combined with a return leg")
+		return
+	}
+	if r.IsGroup() {
+		errs.Add("dateFlexibility", fmt.Sprintf("dateFlexibility is not supported for more than %d passengers", domain.MaxPassengers))
+	}
+}
+
+// IsGroup reports whether the request is for a party too large for a regular
+// search, which is answered with group quotes instead.
+func (r *SearchFlightsRequest) IsGroup() bool {
+	return r.Passengers > domain.MaxPassengers
+}
+
 func (r *SearchFlightsRequest) validateClass(errs *ValidationErrors) {
 	if !validClasses[strings.ToLower(r.Class)] {
 		errs.Add("class", "class must be one of: economy, business, first")
@@ -246,40 +519,152 @@ func (r *SearchFlightsRequest) validateClass(errs *ValidationErrors) {
 
 func (r *SearchFlightsRequest) validateSortBy(errs *ValidationErrors) {
 	if !validSortOptions[strings.ToLower(r.SortBy)] {
-		errs.Add("sortBy", "sortBy must be one of: best, price, duration, departure")
+		errs.Add("sortBy", "sortBy must be one of: best, price, duration, departure, arrival")
+	}
+}
+
+func (r *SearchFlightsRequest) validateSortOrder(errs *ValidationErrors) {
+	if !validSortOrders[strings.ToLower(r.SortOrder)] {
+		errs.Add("sortOrder", "sortOrder must be one of: asc, desc")
+	}
+}
+
+func (r *SearchFlightsRequest) validateFanOutStrategy(errs *ValidationErrors) {
+	if r.FanOutStrategy != "" && !domain.FanOutStrategy(strings.ToLower(r.FanOutStrategy)).IsValid() {
+		errs.Add("fanOutStrategy", "fanOutStrategy must be one of: all-parallel, fastest-first-return, sequential-failover")
+	}
+}
+
+func (r *SearchFlightsRequest) validatePreferredDepartureTime(errs *ValidationErrors) {
+	tr := r.PreferredDepartureTime
+	if tr == nil {
+		return
+	}
+
+	startValid, endValid := false, false
+	if tr.Start == "" {
+		errs.Add("preferredDepartureTime.start", "start time is required when preferredDepartureTime is specified")
+	} else if !isValidTimeFormat(tr.Start) {
+		errs.Add("preferredDepartureTime.start", "start must be in HH:MM format with valid hours (00-23) and minutes (00-59)")
+	} else {
+		startValid = true
+	}
+
+	if tr.End == "" {
+		errs.Add("preferredDepartureTime.end", "end time is required when preferredDepartureTime is specified")
+	} else if !isValidTimeFormat(tr.End) {
+		errs.Add("preferredDepartureTime.end", "end must be in HH:MM format with valid hours (00-23) and minutes (00-59)")
+	} else {
+		endValid = true
+	}
+
+	// HH:MM times compare in lexical order
+	if startValid && endValid && tr.Start > tr.End {
+		errs.Add("preferredDepartureTime", "start must not be after end")
 	}
 }
 
-func (r *SearchFlightsRequest) validateFilters(errs *ValidationErrors) {
+func (r *SearchFlightsRequest) validateMaxResults(errs *ValidationErrors) {
+	if r.MaxResults != nil && (*r.MaxResults < 1 || *r.MaxResults > MaxResultsLimit) {
+		errs.Add("maxResults", fmt.Sprintf("maxResults must be between 1 and %d", MaxResultsLimit))
+	}
+	if r.MaxResultsPerProvider != nil && (*r.MaxResultsPerProvider < 1 || *r.MaxResultsPerProvider > MaxResultsLimit) {
+		errs.Add("maxResultsPerProvider", fmt.Sprintf("maxResultsPerProvider must be between 1 and %d", MaxResultsLimit))
+	}
+}
+
+func (r *SearchFlightsRequest) validateFields(errs *ValidationErrors) {
+	for i, field := range r.Fields {
+		if !IsFlightField(field) {
+			errs.Add(fmt.Sprintf("fields[%d]", i),
+				"field must be one of: "+strings.Join(FlightFields, ", "))
+		}
+	}
+}
+
+func (r *SearchFlightsRequest) validateSpecialServices(errs *ValidationErrors) {
+	for i, service := range r.SpecialServices {
+		if !domain.SpecialService(strings.ToLower(service)).IsValid() {
+			errs.Add(fmt.Sprintf("specialServices[%d]", i),
+				"service must be one of: "+strings.Join(specialServiceNames(), ", "))
+		}
+	}
+}
+
+func (r *SearchFlightsRequest) validateCallbackURL(errs *ValidationErrors) {
+	if r.CallbackURL == "" {
+		return
+	}
+	if err := webhook.ValidateURL(r.CallbackURL); err != nil {
+		errs.Add("callbackUrl", "callbackUrl must be an absolute http(s) URL")
+	}
+}
+
+// specialServiceNames returns the names of the known special assistance services.
+func specialServiceNames() []string {
+	names := make([]string, len(domain.SpecialServices))
+	for i, s := range domain.SpecialServices {
+		names[i] = string(s)
+	}
+	return names
+}
+
+func (r *SearchFlightsRequest) validateFilters(errs *ValidationErrors, mode CodeValidation) {
 	if r.Filters == nil {
 		return
 	}
 
-	// Validate maxPrice
+	// Validate minPrice and maxPrice
+	if r.Filters.MinPrice != nil && *r.Filters.MinPrice < 0 {
+		errs.Add("filters.minPrice", "minPrice must be a positive number")
+	}
 	if r.Filters.MaxPrice != nil && *r.Filters.MaxPrice < 0 {
 		errs.Add("filters.maxPrice", "maxPrice must be a positive number")
 	}
+	if r.Filters.MinPrice != nil && r.Filters.MaxPrice != nil && *r.Filters.MinPrice > *r.Filters.MaxPrice {
+		errs.Add("filters.minPrice", "minPrice must be less than or equal to maxPrice")
+	}
 
 	// Validate maxStops
 	if r.Filters.MaxStops != nil && *r.Filters.MaxStops < 0 {
 		errs.Add("filters.maxStops", "maxStops must be a non-negative number")
 	}
 
+	// Validate minSeatsAvailable
+	if r.Filters.MinSeatsAvailable != nil && *r.Filters.MinSeatsAvailable < 1 {
+		errs.Add("filters.minSeatsAvailable", "minSeatsAvailable must be at least 1")
+	}
+
 	// Validate airline codes
 	for i, airline := range r.Filters.Airlines {
 		normalized := strings.ToUpper(airline)
-		if len(normalized) < 2 || len(normalized) > 3 {
-			errs.Add(fmt.Sprintf("filters.airlines[%d]", i),
-				"airline code must be 2 or 3 characters")
+		if !isValidAirlineCode(normalized, mode) {
+			message := "airline code must be a 2-character IATA or 3-letter ICAO code"
+			if mode == CodeValidationCharter {
+				message = "airline code must be a 2-character IATA, 3-letter ICAO, or 2-letter+digit charter code"
+			}
+			errs.Add(fmt.Sprintf("filters.airlines[%d]", i), message)
 		}
 		r.Filters.Airlines[i] = normalized
 	}
 
+	// Validate fare family names
+	for i, family := range r.Filters.FareFamilies {
+		if strings.TrimSpace(family) == "" {
+			errs.Add(fmt.Sprintf("filters.fareFamilies[%d]", i), "fare family must not be empty")
+		}
+	}
+
 	// Validate departure time range
 	if r.Filters.DepartureTimeRange != nil {
 		r.validateDepartureTimeRange(errs)
 	}
 
+	// Validate departure time bucket
+	if r.Filters.DepartureTimeBucket != "" {
+		r.validateDepartureTimeBucket(errs)
+	}
+
 	// Validate arrival time range
 	if r.Filters.ArrivalTimeRange != nil {
 		r.validateArrivalTimeRange(errs)
@@ -307,6 +692,15 @@ func (r *SearchFlightsRequest) validateDepartureTimeRange(errs *ValidationErrors
 	}
 }
 
+func (r *SearchFlightsRequest) validateDepartureTimeBucket(errs *ValidationErrors) {
+	if !domain.TimeBucket(strings.ToLower(r.Filters.DepartureTimeBucket)).IsValid() {
+		errs.Add("filters.departureTimeBucket", "departureTimeBucket must be one of: early_morning, morning, afternoon, evening, night")
+	}
+	if r.Filters.DepartureTimeRange != nil {
+		errs.Add("filters.departureTimeBucket", "departureTimeBucket and departureTimeRange cannot be combined")
+	}
+}
+
 func (r *SearchFlightsRequest) validateArrivalTimeRange(errs *ValidationErrors) {
 	tr := r.Filters.ArrivalTimeRange
 
@@ -348,6 +742,15 @@ func (r *SearchFlightsRequest) validateDurationRange(errs *ValidationErrors) {
 	}
 }
 
+// isValidAirlineCode reports whether an uppercase airline code is valid in
+// the given validation mode.
+func isValidAirlineCode(code string, mode CodeValidation) bool {
+	if airlineCodePattern.MatchString(code) {
+		return true
+	}
+	return mode == CodeValidationCharter && charterAirlineCodePattern.MatchString(code)
+}
+
 // isValidTimeFormat validates that a time string is in HH:MM format with valid values.
 // Hours must be 00-23, minutes must be 00-59.
 func isValidTimeFormat(timeStr string) bool {
@@ -196,6 +196,66 @@ func TestValidateDepartureTimeRange(t *testing.T) {
 	}
 }
 
+func TestValidateDepartureTimeBucket(t *testing.T) {
+	tests := []struct {
+		name      string
+		filters   *FilterDTO
+		wantError bool
+	}{
+		{"valid bucket", &FilterDTO{DepartureTimeBucket: "early_morning"}, false},
+		{"case insensitive", &FilterDTO{DepartureTimeBucket: "Evening"}, false},
+		{"unknown bucket", &FilterDTO{DepartureTimeBucket: "midday"}, true},
+		{
+			name:      "combined with a range",
+			filters:   &FilterDTO{DepartureTimeBucket: "morning", DepartureTimeRange: &TimeRangeDTO{Start: "06:00", End: "12:00"}},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &SearchFlightsRequest{Filters: tt.filters}
+
+			errs := &ValidationErrors{}
+			req.validateDepartureTimeBucket(errs)
+
+			assert.Equal(t, tt.wantError, errs.HasErrors())
+			for _, e := range errs.Errors {
+				assert.Equal(t, "filters.departureTimeBucket", e.Field)
+			}
+		})
+	}
+}
+
+func TestValidatePreferredDepartureTime(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeRange   *TimeRangeDTO
+		errorFields []string
+	}{
+		{name: "no preference"},
+		{name: "valid window", timeRange: &TimeRangeDTO{Start: "06:00", End: "09:30"}},
+		{name: "missing end", timeRange: &TimeRangeDTO{Start: "06:00"}, errorFields: []string{"preferredDepartureTime.end"}},
+		{name: "invalid start", timeRange: &TimeRangeDTO{Start: "6am", End: "09:30"}, errorFields: []string{"preferredDepartureTime.start"}},
+		{name: "start after end", timeRange: &TimeRangeDTO{Start: "22:00", End: "02:00"}, errorFields: []string{"preferredDepartureTime"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &SearchFlightsRequest{PreferredDepartureTime: tt.timeRange}
+
+			errs := &ValidationErrors{}
+			req.validatePreferredDepartureTime(errs)
+
+			var fields []string
+			for _, e := range errs.Errors {
+				fields = append(fields, e.Field)
+			}
+			assert.Equal(t, tt.errorFields, fields)
+		})
+	}
+}
+
 // TestValidateDurationRange tests duration range validation.
 func TestValidateDurationRange(t *testing.T) {
 	intPtr := func(i int) *int { return &i }
@@ -343,6 +403,39 @@ func TestValidateFilters(t *testing.T) {
 			expectedError: true,
 			errorCount:    1,
 		},
+		{
+			name: "negative min price",
+			filters: &FilterDTO{
+				MinPrice: floatPtr(-1000),
+			},
+			expectedError: true,
+			errorCount:    1,
+		},
+		{
+			name: "min price above max price",
+			filters: &FilterDTO{
+				MinPrice: floatPtr(2000000),
+				MaxPrice: floatPtr(1000000),
+			},
+			expectedError: true,
+			errorCount:    1,
+		},
+		{
+			name: "min price equal to max price",
+			filters: &FilterDTO{
+				MinPrice: floatPtr(1000000),
+				MaxPrice: floatPtr(1000000),
+			},
+			expectedError: false,
+		},
+		{
+			name: "empty fare family",
+			filters: &FilterDTO{
+				FareFamilies: []string{"Flex", " "},
+			},
+			expectedError: true,
+			errorCount:    1,
+		},
 		{
 			name: "negative max stops",
 			filters: &FilterDTO{
@@ -351,6 +444,14 @@ func TestValidateFilters(t *testing.T) {
 			expectedError: true,
 			errorCount:    1,
 		},
+		{
+			name: "zero min seats",
+			filters: &FilterDTO{
+				MinSeatsAvailable: intPtr(0),
+			},
+			expectedError: true,
+			errorCount:    1,
+		},
 		{
 			name: "invalid airline code - too short",
 			filters: &FilterDTO{
@@ -381,7 +482,7 @@ func TestValidateFilters(t *testing.T) {
 			}
 
 			errs := &ValidationErrors{}
-			req.validateFilters(errs)
+			req.validateFilters(errs, CodeValidationStrict)
 
 			if tt.expectedError {
 				assert.True(t, errs.HasErrors(), "Expected validation errors")
@@ -393,6 +494,243 @@ func TestValidateFilters(t *testing.T) {
 	}
 }
 
+// TestValidateReturn tests return leg validation, open-jaw trips included.
+func TestValidateReturn(t *testing.T) {
+	tests := []struct {
+		name        string
+		ret         *ReturnLegDTO
+		errorFields []string
+	}{
+		{name: "nil return is valid", ret: nil},
+		{name: "mirrored return", ret: &ReturnLegDTO{Origin: "DPS", Destination: "CGK", DepartureDate: "2025-12-20"}},
+		{name: "open jaw", ret: &ReturnLegDTO{Origin: "SUB", Destination: "CGK", DepartureDate: "2025-12-20"}},
+		{name: "same day return", ret: &ReturnLegDTO{Origin: "DPS", Destination: "CGK", DepartureDate: "2025-12-15"}},
+		{
+			name:        "missing fields",
+			ret:         &ReturnLegDTO{},
+			errorFields: []string{"return.origin", "return.destination", "return.departureDate"},
+		},
+		{
+			name:        "invalid airport code",
+			ret:         &ReturnLegDTO{Origin: "SU", Destination: "CGK", DepartureDate: "2025-12-20"},
+			errorFields: []string{"return.origin"},
+		},
+		{
+			name:        "same origin and destination",
+			ret:         &ReturnLegDTO{Origin: "cgk", Destination: "CGK", DepartureDate: "2025-12-20"},
+			errorFields: []string{"return.destination"},
+		},
+		{
+			name:        "invalid date",
+			ret:         &ReturnLegDTO{Origin: "DPS", Destination: "CGK", DepartureDate: "2025-13-01"},
+			errorFields: []string{"return.departureDate"},
+		},
+		{
+			name:        "return before departure",
+			ret:         &ReturnLegDTO{Origin: "DPS", Destination: "CGK", DepartureDate: "2025-12-14"},
+			errorFields: []string{"return.departureDate"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &SearchFlightsRequest{DepartureDate: "2025-12-15", Return: tt.ret}
+
+			errs := &ValidationErrors{}
+			req.validateReturn(errs, CodeValidationStrict)
+
+			var fields []string
+			for _, e := range errs.Errors {
+				fields = append(fields, e.Field)
+			}
+			assert.Equal(t, tt.errorFields, fields)
+		})
+	}
+}
+
+func TestValidateReturn_NormalizesCodes(t *testing.T) {
+	req := &SearchFlightsRequest{
+		DepartureDate: "2025-12-15",
+		Return:        &ReturnLegDTO{Origin: "sub", Destination: "cgk", DepartureDate: "2025-12-20"},
+	}
+
+	errs := &ValidationErrors{}
+	req.validateReturn(errs, CodeValidationStrict)
+
+	require.False(t, errs.HasErrors())
+	assert.Equal(t, "SUB", req.Return.Origin)
+	assert.Equal(t, "CGK", req.Return.Destination)
+}
+
+func TestValidateReturnDate(t *testing.T) {
+	tests := []struct {
+		name        string
+		returnDate  string
+		ret         *ReturnLegDTO
+		errorFields []string
+	}{
+		{name: "no return date", returnDate: ""},
+		{name: "valid return date", returnDate: "2025-12-20"},
+		{name: "same day return", returnDate: "2025-12-15"},
+		{name: "invalid date", returnDate: "2025-12-32", errorFields: []string{"returnDate"}},
+		{name: "return before departure", returnDate: "2025-12-14", errorFields: []string{"returnDate"}},
+		{
+			name:        "combined with return",
+			returnDate:  "2025-12-20",
+			ret:         &ReturnLegDTO{Origin: "DPS", Destination: "CGK", DepartureDate: "2025-12-20"},
+			errorFields: []string{"returnDate"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &SearchFlightsRequest{DepartureDate: "2025-12-15", ReturnDate: tt.returnDate, Return: tt.ret}
+
+			errs := &ValidationErrors{}
+			req.validateReturnDate(errs)
+
+			var fields []string
+			for _, e := range errs.Errors {
+				fields = append(fields, e.Field)
+			}
+			assert.Equal(t, tt.errorFields, fields)
+		})
+	}
+}
+
+func TestValidateDateFlexibility(t *testing.T) {
+	tests := []struct {
+		name            string
+		dateFlexibility int
+		returnDate      string
+		passengers      int
+		errorFields     []string
+	}{
+		{name: "fixed date", passengers: 1},
+		{name: "flexible dates", dateFlexibility: 3, passengers: 1},
+		{name: "too flexible", dateFlexibility: 4, passengers: 1, errorFields: []string{"dateFlexibility"}},
+		{name: "negative", dateFlexibility: -1, passengers: 1, errorFields: []string{"dateFlexibility"}},
+		{name: "round trip", dateFlexibility: 1, returnDate: "2025-12-20", passengers: 1, errorFields: []string{"dateFlexibility"}},
+		{name: "fixed date round trip", returnDate: "2025-12-20", passengers: 1},
+		{name: "group", dateFlexibility: 1, passengers: 10, errorFields: []string{"dateFlexibility"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &SearchFlightsRequest{DepartureDate: "2025-12-15", ReturnDate: tt.returnDate, Passengers: tt.passengers, DateFlexibility: tt.dateFlexibility}
+
+			errs := &ValidationErrors{}
+			req.validateDateFlexibility(errs)
+
+			var fields []string
+			for _, e := range errs.Errors {
+				fields = append(fields, e.Field)
+			}
+			assert.Equal(t, tt.errorFields, fields)
+		})
+	}
+}
+
+func TestValidateCallbackURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		callbackURL string
+		wantErr     bool
+	}{
+		{name: "synchronous", callbackURL: ""},
+		{name: "https", callbackURL: "https://partner.example.com/flight-results"},
+		{name: "http", callbackURL: "http://localhost:9000/results"},
+		{name: "relative", callbackURL: "/results", wantErr: true},
+		{name: "other scheme", callbackURL: "ftp://partner.example.com/results", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &SearchFlightsRequest{CallbackURL: tt.callbackURL}
+
+			errs := &ValidationErrors{}
+			req.validateCallbackURL(errs)
+
+			assert.Equal(t, tt.wantErr, errs.HasErrors())
+		})
+	}
+}
+
+// TestValidateWith_CodeValidation tests airport and airline code validation
+// in each validation mode.
+func TestValidateWith_CodeValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        CodeValidation
+		origin      string
+		airline     string
+		wantOrigin  string
+		errorFields []string
+	}{
+		{name: "strict IATA", mode: CodeValidationStrict, origin: "cgk", airline: "ga", wantOrigin: "CGK"},
+		{name: "strict ICAO airline", mode: CodeValidationStrict, origin: "CGK", airline: "GIA", wantOrigin: "CGK"},
+		{name: "strict rejects ICAO airport", mode: CodeValidationStrict, origin: "WIII", airline: "GA", errorFields: []string{"origin"}},
+		{name: "strict rejects charter airline", mode: CodeValidationStrict, origin: "CGK", airline: "XY1", errorFields: []string{"filters.airlines[0]"}},
+		{name: "charter IATA", mode: CodeValidationCharter, origin: "CGK", airline: "GA", wantOrigin: "CGK"},
+		{name: "charter maps ICAO airport", mode: CodeValidationCharter, origin: "wiii", airline: "GA", wantOrigin: "CGK"},
+		{name: "charter airline", mode: CodeValidationCharter, origin: "CGK", airline: "xy1", wantOrigin: "CGK"},
+		{name: "charter unknown ICAO airport", mode: CodeValidationCharter, origin: "ZZZZ", airline: "GA", errorFields: []string{"origin"}},
+		{name: "charter invalid airport", mode: CodeValidationCharter, origin: "WII1", airline: "GA", errorFields: []string{"origin"}},
+		{name: "charter invalid airline", mode: CodeValidationCharter, origin: "CGK", airline: "X12", errorFields: []string{"filters.airlines[0]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &SearchFlightsRequest{
+				Origin:        tt.origin,
+				Destination:   "DPS",
+				DepartureDate: "2025-12-15",
+				Passengers:    1,
+				Filters:       &FilterDTO{Airlines: []string{tt.airline}},
+			}
+
+			err := req.ValidateWith(tt.mode)
+			if tt.errorFields == nil {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantOrigin, req.Origin)
+				return
+			}
+
+			var errs *ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			var fields []string
+			for _, e := range errs.Errors {
+				fields = append(fields, e.Field)
+			}
+			assert.Equal(t, tt.errorFields, fields)
+		})
+	}
+}
+
+func TestValidateWith_MapsICAOReturnAndSameAirport(t *testing.T) {
+	req := &SearchFlightsRequest{
+		Origin:        "CGK",
+		Destination:   "wiii",
+		DepartureDate: "2025-12-15",
+		Passengers:    1,
+	}
+	err := req.ValidateWith(CodeValidationCharter)
+	var errs *ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, "destination", errs.Errors[0].Field, "ICAO code of the origin airport")
+
+	req = &SearchFlightsRequest{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: "2025-12-15",
+		Passengers:    1,
+		Return:        &ReturnLegDTO{Origin: "WARR", Destination: "WIII", DepartureDate: "2025-12-20"},
+	}
+	require.NoError(t, req.ValidateWith(CodeValidationCharter))
+	assert.Equal(t, "SUB", req.Return.Origin)
+	assert.Equal(t, "CGK", req.Return.Destination)
+}
+
 // TestValidationErrorsError tests the Error() method.
 func TestValidationErrorsError(t *testing.T) {
 	errs := &ValidationErrors{}
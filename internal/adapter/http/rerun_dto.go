@@ -0,0 +1,45 @@
+package http
+
+import (
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// RerunResponseDTO contains the fresh results of a stored search and how
+// prices moved since it ran.
+type RerunResponseDTO struct {
+	PriceDelta PriceDeltaDTO `json:"price_delta"`
+	*SearchResponseDTO
+}
+
+// PriceDeltaDTO summarizes the price changes since a stored search.
+type PriceDeltaDTO struct {
+	PreviousSearchID   string    `json:"previous_search_id"`
+	PreviousSearchedAt time.Time `json:"previously_searched_at"`
+	PreviousLowest     *PriceDTO `json:"previous_lowest,omitempty"`
+	CurrentLowest      *PriceDTO `json:"current_lowest,omitempty"`
+	LowestChange       *float64  `json:"lowest_change,omitempty"`
+	Cheaper            int       `json:"cheaper"`
+	Pricier            int       `json:"pricier"`
+	Unchanged          int       `json:"unchanged"`
+	Added              int       `json:"added"`
+	Removed            int       `json:"removed"`
+}
+
+// ToPriceDeltaDTO converts the price delta of a rerun.
+func ToPriceDeltaDTO(result *usecase.RerunResult) PriceDeltaDTO {
+	d := result.Delta
+	return PriceDeltaDTO{
+		PreviousSearchID:   result.Previous.ID,
+		PreviousSearchedAt: result.Previous.CreatedAt.UTC(),
+		PreviousLowest:     toPriceDTO(d.PreviousLowest),
+		CurrentLowest:      toPriceDTO(d.CurrentLowest),
+		LowestChange:       d.LowestChange,
+		Cheaper:            d.Cheaper,
+		Pricier:            d.Pricier,
+		Unchanged:          d.Unchanged,
+		Added:              d.Added,
+		Removed:            d.Removed,
+	}
+}
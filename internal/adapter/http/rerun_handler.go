@@ -0,0 +1,46 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/sanitize"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// RerunHandler searches again with the criteria of stored searches.
+type RerunHandler struct {
+	useCase   usecase.RerunUseCase
+	sanitizer *sanitize.Sanitizer
+}
+
+// NewRerunHandler creates a new RerunHandler with the given use case.
+// Provider free text is cleaned with sanitizer (nil = not cleaned).
+func NewRerunHandler(uc usecase.RerunUseCase, sanitizer *sanitize.Sanitizer) *RerunHandler {
+	return &RerunHandler{useCase: uc, sanitizer: sanitizer}
+}
+
+// RerunSearch handles POST /api/v1/searches/:id/rerun
+// The id is the search_id of a search response. It searches again with the
+// stored search's criteria, filters, sort and limits, and returns the fresh
+// results, localized like search responses, with a summary of how prices
+// moved since. Searches of other callers are not found.
+func (h *RerunHandler) RerunSearch(c echo.Context) error {
+	result, err := h.useCase.Rerun(c.Request().Context(), searchClient(c), c.Param("id"))
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	locale := requestLocale(c)
+	if !loadshed.Allowed(c.Request().Context(), loadshed.FeatureLocalization) {
+		locale = ""
+	}
+	dto := ToSearchResponseDTOLocalized(result.Current, locale)
+	SanitizeSearchResponseDTO(dto, h.sanitizer)
+
+	return response.OK(c, &RerunResponseDTO{
+		PriceDelta:        ToPriceDeltaDTO(result),
+		SearchResponseDTO: dto,
+	})
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// stubRerun reruns search-1 and fails for other searches.
+type stubRerun struct {
+	result *usecase.RerunResult
+}
+
+func (s stubRerun) Rerun(_ context.Context, _, searchID string) (*usecase.RerunResult, error) {
+	if searchID != s.result.Previous.ID {
+		return nil, domain.ErrSearchNotFound
+	}
+	return s.result, nil
+}
+
+func TestRerunHandler(t *testing.T) {
+	change := -100000.0
+	e := echo.New()
+	RegisterRerunRoutes(e, NewRerunHandler(stubRerun{&usecase.RerunResult{
+		Previous: &domain.SearchRecord{ID: "search-1", CreatedAt: time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)},
+		Current: &domain.SearchResponse{
+			Flights:  []domain.Flight{{ID: "1", Price: domain.PriceInfo{Amount: 900000, Currency: "IDR"}}},
+			Metadata: domain.SearchMetadata{TotalResults: 1, SearchID: "search-2"},
+		},
+		Delta: usecase.PriceDelta{
+			PreviousLowest: &domain.PriceInfo{Amount: 1000000, Currency: "IDR"},
+			CurrentLowest:  &domain.PriceInfo{Amount: 900000, Currency: "IDR"},
+			LowestChange:   &change,
+			Cheaper:        1,
+			Removed:        2,
+		},
+	}}, nil))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/searches/search-1/rerun", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp RerunResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, PriceDeltaDTO{
+		PreviousSearchID:   "search-1",
+		PreviousSearchedAt: time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC),
+		PreviousLowest:     &PriceDTO{Amount: 1000000, Currency: "IDR"},
+		CurrentLowest:      &PriceDTO{Amount: 900000, Currency: "IDR"},
+		LowestChange:       &change,
+		Cheaper:            1,
+		Removed:            2,
+	}, resp.PriceDelta)
+	require.NotNil(t, resp.SearchResponseDTO)
+	assert.Equal(t, "search-2", resp.Metadata.SearchID)
+	require.Len(t, resp.Flights, 1)
+
+	t.Run("unknown search", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/searches/missing/rerun", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -3,6 +3,8 @@ package response
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -23,6 +25,16 @@ func InvalidRequestBody(c echo.Context) error {
 	})
 }
 
+// RequestTooLarge writes a 413 Request Entity Too Large response for request
+// bodies larger than maxBytes. The limit is reported in the details.
+func RequestTooLarge(c echo.Context, maxBytes int64) error {
+	return c.JSON(http.StatusRequestEntityTooLarge, &ErrorDetail{
+		Code:    CodeRequestTooLarge,
+		Message: MsgRequestTooLarge,
+		Details: map[string]string{"maxBytes": strconv.FormatInt(maxBytes, 10)},
+	})
+}
+
 // ValidationError writes a 400 Bad Request response with validation error details.
 func ValidationError(c echo.Context, details map[string]string) error {
 	return c.JSON(http.StatusBadRequest, &ErrorDetail{
@@ -40,6 +52,63 @@ func ValidationErrorWithMessage(c echo.Context, message string) error {
 	})
 }
 
+// SpecViolation writes a 400 Bad Request response for requests violating the OpenAPI spec.
+func SpecViolation(c echo.Context, details map[string]string) error {
+	return c.JSON(http.StatusBadRequest, &ErrorDetail{
+		Code:    CodeSpecViolation,
+		Message: MsgSpecViolation,
+		Details: details,
+	})
+}
+
+// NotFound writes a 404 Not Found response with the given error message.
+func NotFound(c echo.Context, message string) error {
+	return c.JSON(http.StatusNotFound, &ErrorDetail{
+		Code:    CodeNotFound,
+		Message: message,
+	})
+}
+
+// Conflict writes a 409 Conflict response with the given error message.
+func Conflict(c echo.Context, message string) error {
+	return c.JSON(http.StatusConflict, &ErrorDetail{
+		Code:    CodeConflict,
+		Message: message,
+	})
+}
+
+// Unauthorized writes a 401 Unauthorized response asking for a bearer token.
+func Unauthorized(c echo.Context, message string) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+	return c.JSON(http.StatusUnauthorized, &ErrorDetail{
+		Code:    CodeUnauthorized,
+		Message: message,
+	})
+}
+
+// Forbidden writes a 403 Forbidden response with the given denial reason.
+func Forbidden(c echo.Context, message string) error {
+	return c.JSON(http.StatusForbidden, &ErrorDetail{
+		Code:    CodeForbidden,
+		Message: message,
+	})
+}
+
+// RoleRequired writes a 403 Forbidden response for a caller lacking role.
+// Anonymous callers get a 401 Unauthorized response asking for an API key
+// instead. The required role is reported in the details.
+func RoleRequired(c echo.Context, role string, anonymous bool) error {
+	status, detail := http.StatusForbidden, &ErrorDetail{
+		Code:    CodeForbidden,
+		Message: MsgRoleRequired,
+		Details: map[string]string{"requiredRole": role},
+	}
+	if anonymous {
+		status, detail.Code, detail.Message = http.StatusUnauthorized, CodeUnauthorized, MsgAPIKeyRequired
+	}
+	return c.JSON(status, detail)
+}
+
 // ServiceUnavailable writes a 503 Service Unavailable response.
 func ServiceUnavailable(c echo.Context) error {
 	return c.JSON(http.StatusServiceUnavailable, &ErrorDetail{
@@ -56,6 +125,20 @@ func ServiceUnavailableWithMessage(c echo.Context, message string) error {
 	})
 }
 
+// Overloaded writes a 503 Service Unavailable response with a Retry-After header
+// for requests rejected because the server is saturated.
+func Overloaded(c echo.Context, retryAfter time.Duration) error {
+	seconds := int((retryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+	return c.JSON(http.StatusServiceUnavailable, &ErrorDetail{
+		Code:    CodeOverloaded,
+		Message: MsgOverloaded,
+	})
+}
+
 // GatewayTimeout writes a 504 Gateway Timeout response.
 func GatewayTimeout(c echo.Context) error {
 	return c.JSON(http.StatusGatewayTimeout, &ErrorDetail{
@@ -12,17 +12,44 @@ type ErrorDetail struct {
 const (
 	CodeInvalidRequest     = "invalid_request"
 	CodeValidationError    = "validation_error"
+	CodeSpecViolation      = "spec_violation"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeNotFound           = "not_found"
+	CodeConflict           = "conflict"
+	CodeRequestTooLarge    = "request_too_large"
 	CodeServiceUnavailable = "service_unavailable"
+	CodeOverloaded         = "overloaded"
 	CodeTimeout            = "timeout"
 	CodeInternalError      = "internal_error"
 )
 
 // Error messages used in API responses.
 const (
-	MsgInvalidRequestBody = "Failed to parse request body"
-	MsgValidationFailed   = "Request validation failed"
-	MsgServiceUnavailable = "All flight providers are currently unavailable"
-	MsgTimeout            = "Request timed out"
-	MsgRequestCancelled   = "Request was cancelled"
-	MsgInternalError      = "An unexpected error occurred"
+	MsgInvalidRequestBody   = "Failed to parse request body"
+	MsgRequestTooLarge      = "Request body exceeds the maximum size"
+	MsgValidationFailed     = "Request validation failed"
+	MsgSpecViolation        = "Request does not conform to the API specification"
+	MsgSearchNotFound       = "Search not found"
+	MsgContinuationNotFound = "Continuation not found or expired"
+	MsgShareNotFound        = "Shared search not found or expired"
+	MsgJobNotFound          = "Job not found"
+	MsgJobNotDead           = "Only dead jobs can be requeued"
+	MsgExportNotFound       = "Export not found"
+	MsgFlightNotFound       = "Flight not found or expired"
+	MsgBackupUnavailable    = "No embedded store is configured"
+	MsgSimulationDisabled   = "Simulation is not configured"
+	MsgMissingToken         = "Missing bearer token"
+	MsgInvalidToken         = "Invalid or expired bearer token"
+	MsgTokenKeysUnavailable = "Bearer tokens cannot be verified at the moment, please retry later"
+	MsgAdminWriteRequired   = "Role admin:write is required for this operation"
+	MsgAdminReadRequired    = "Role admin:read is required for this operation"
+	MsgRoleRequired         = "Caller is not granted the role required for this operation"
+	MsgAPIKeyRequired       = "An API key or bearer token granting the required role is needed for this operation"
+	MsgClientRequired       = "Search history is kept per API key or bearer token, and none was presented"
+	MsgServiceUnavailable   = "All flight providers are currently unavailable"
+	MsgOverloaded           = "Server is at capacity, please retry later"
+	MsgTimeout              = "Request timed out"
+	MsgRequestCancelled     = "Request was cancelled"
+	MsgInternalError        = "An unexpected error occurred"
 )
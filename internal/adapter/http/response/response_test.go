@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -94,6 +95,79 @@ func TestValidationErrorWithMessage(t *testing.T) {
 	assert.Equal(t, "Custom validation message", result.Message)
 }
 
+func TestNotFound(t *testing.T) {
+	_, c, rec := setupEcho()
+
+	err := NotFound(c, MsgSearchNotFound)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var result ErrorDetail
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, CodeNotFound, result.Code)
+	assert.Equal(t, MsgSearchNotFound, result.Message)
+}
+
+func TestUnauthorized(t *testing.T) {
+	_, c, rec := setupEcho()
+
+	err := Unauthorized(c, MsgMissingToken)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+
+	var result ErrorDetail
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, CodeUnauthorized, result.Code)
+	assert.Equal(t, MsgMissingToken, result.Message)
+}
+
+func TestForbidden(t *testing.T) {
+	_, c, rec := setupEcho()
+
+	err := Forbidden(c, MsgAdminWriteRequired)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	var result ErrorDetail
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, CodeForbidden, result.Code)
+	assert.Equal(t, MsgAdminWriteRequired, result.Message)
+}
+
+func TestRoleRequired(t *testing.T) {
+	tests := []struct {
+		name      string
+		anonymous bool
+		wantCode  int
+		wantErr   string
+		wantMsg   string
+	}{
+		{"identified caller", false, http.StatusForbidden, CodeForbidden, MsgRoleRequired},
+		{"anonymous caller", true, http.StatusUnauthorized, CodeUnauthorized, MsgAPIKeyRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c, rec := setupEcho()
+
+			err := RoleRequired(c, "export", tt.anonymous)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCode, rec.Code)
+
+			var result ErrorDetail
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			assert.Equal(t, tt.wantErr, result.Code)
+			assert.Equal(t, tt.wantMsg, result.Message)
+			assert.Equal(t, map[string]string{"requiredRole": "export"}, result.Details)
+		})
+	}
+}
+
 func TestServiceUnavailable(t *testing.T) {
 	_, c, rec := setupEcho()
 
@@ -122,6 +196,21 @@ func TestServiceUnavailableWithMessage(t *testing.T) {
 	assert.Equal(t, "Database is down", result.Message)
 }
 
+func TestOverloaded(t *testing.T) {
+	_, c, rec := setupEcho()
+
+	err := Overloaded(c, 1500*time.Millisecond)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+
+	var result ErrorDetail
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, CodeOverloaded, result.Code)
+	assert.Equal(t, MsgOverloaded, result.Message)
+}
+
 func TestGatewayTimeout(t *testing.T) {
 	_, c, rec := setupEcho()
 
@@ -188,3 +277,18 @@ func TestSearchResults(t *testing.T) {
 	assert.Equal(t, 3, resp.Total)
 	assert.Len(t, resp.Items, 3)
 }
+
+func TestRequestTooLarge(t *testing.T) {
+	_, c, rec := setupEcho()
+
+	err := RequestTooLarge(c, 1024)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	var result ErrorDetail
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, CodeRequestTooLarge, result.Code)
+	assert.Equal(t, MsgRequestTooLarge, result.Message)
+	assert.Equal(t, map[string]string{"maxBytes": "1024"}, result.Details)
+}
@@ -23,3 +23,18 @@ func Health(c echo.Context) error {
 func SearchResults(c echo.Context, results interface{}) error {
 	return c.JSON(http.StatusOK, results)
 }
+
+// Created writes a 201 Created response with the given body.
+func Created(c echo.Context, body interface{}) error {
+	return c.JSON(http.StatusCreated, body)
+}
+
+// Accepted writes a 202 Accepted response with the given body.
+func Accepted(c echo.Context, body interface{}) error {
+	return c.JSON(http.StatusAccepted, body)
+}
+
+// OK writes a 200 OK response with the given body.
+func OK(c echo.Context, body interface{}) error {
+	return c.JSON(http.StatusOK, body)
+}
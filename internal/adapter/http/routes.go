@@ -17,6 +17,9 @@ func RegisterRoutes(e *echo.Echo, h *FlightHandler) {
 	// Flights group
 	flights := api.Group("/flights")
 	flights.POST("/search", h.SearchFlights)
+	flights.GET("/search/live", h.SearchFlightsLive)
+	flights.POST("/search/stream", h.SearchFlightsStream)
+	flights.GET("/:id", h.GetFlight)
 }
 
 // RegisterRoutesWithMiddleware registers routes with custom middleware.
@@ -31,4 +34,77 @@ func RegisterRoutesWithMiddleware(e *echo.Echo, h *FlightHandler, middleware ...
 	// Flights group
 	flights := api.Group("/flights")
 	flights.POST("/search", h.SearchFlights)
+	flights.GET("/search/live", h.SearchFlightsLive)
+	flights.POST("/search/stream", h.SearchFlightsStream)
+	flights.GET("/:id", h.GetFlight)
+}
+
+// RegisterAdminRoutes registers the internal admin API routes under /admin/v1.
+func RegisterAdminRoutes(e *echo.Echo, h *AdminHandler, middleware ...echo.MiddlewareFunc) {
+	admin := e.Group("/admin/v1", middleware...)
+
+	searches := admin.Group("/searches")
+	searches.POST("/:id/replay", h.ReplaySearch)
+
+	admin.POST("/simulate", h.Simulate)
+
+	admin.GET("/jobs", h.ListJobs)
+
+	queue := admin.Group("/queue/jobs")
+	queue.GET("", h.ListQueueJobs)
+	queue.POST("/:id/requeue", h.RequeueJob)
+
+	admin.GET("/stats/providers", h.ProviderStats)
+	admin.GET("/providers/latency", h.ProviderLatency)
+
+	admin.GET("/config", h.Config)
+
+	admin.GET("/backup", h.Backup)
+
+	admin.GET("/audit", h.ListAudit)
+}
+
+// RegisterContinuationRoutes registers the partial search continuation routes under /api/v1/searches.
+func RegisterContinuationRoutes(e *echo.Echo, h *ContinuationHandler, middleware ...echo.MiddlewareFunc) {
+	searches := e.Group("/api/v1/searches", middleware...)
+	searches.GET("/:id/continue", h.ContinueSearch)
+}
+
+// RegisterShareRoutes registers the share link routes: issuing links under
+// /api/v1/searches, with middleware, and opening them under /share.
+func RegisterShareRoutes(e *echo.Echo, h *ShareHandler, middleware ...echo.MiddlewareFunc) {
+	searches := e.Group("/api/v1/searches", middleware...)
+	searches.POST("/:id/share", h.ShareSearch)
+
+	e.GET("/share/:token", h.OpenSharedSearch)
+}
+
+// RegisterRerunRoutes registers the stored search rerun routes under /api/v1/searches.
+func RegisterRerunRoutes(e *echo.Echo, h *RerunHandler, middleware ...echo.MiddlewareFunc) {
+	searches := e.Group("/api/v1/searches", middleware...)
+	searches.POST("/:id/rerun", h.RerunSearch)
+}
+
+// RegisterSearchHistoryRoutes registers the caller's search history routes
+// under /api/v1/searches.
+func RegisterSearchHistoryRoutes(e *echo.Echo, h *SearchHistoryHandler, middleware ...echo.MiddlewareFunc) {
+	searches := e.Group("/api/v1/searches", middleware...)
+	searches.GET("", h.ListSearches)
+	searches.GET("/:id", h.GetSearch)
+}
+
+// RegisterExportRoutes registers the partner analytics export routes under /api/v1/exports.
+func RegisterExportRoutes(e *echo.Echo, h *ExportHandler, middleware ...echo.MiddlewareFunc) {
+	exports := e.Group("/api/v1/exports", middleware...)
+	exports.GET("/search-analytics/:date", h.GetSearchAnalytics)
+}
+
+// RegisterReferenceRoutes registers the reference data routes under
+// /api/v1/airlines and /api/v1/airports.
+func RegisterReferenceRoutes(e *echo.Echo, h *ReferenceHandler, middleware ...echo.MiddlewareFunc) {
+	airlines := e.Group("/api/v1/airlines", middleware...)
+	airlines.GET("", h.ListAirlines)
+
+	airports := e.Group("/api/v1/airports", middleware...)
+	airports.GET("", h.ListAirports)
 }
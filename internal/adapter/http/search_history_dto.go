@@ -0,0 +1,49 @@
+package http
+
+import (
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// SearchHistoryDTO lists the caller's recent searches, newest first.
+type SearchHistoryDTO struct {
+	Searches []SearchSummaryDTO `json:"searches"`
+}
+
+// SearchSummaryDTO summarizes a stored search: the request that ran it and
+// what it found.
+type SearchSummaryDTO struct {
+	SearchID     string               `json:"search_id"`
+	SearchedAt   time.Time            `json:"searched_at"`
+	Request      SearchFlightsRequest `json:"request"`
+	TotalResults int                  `json:"total_results"`
+	LowestPrice  *PriceDTO            `json:"lowest_price,omitempty"`
+}
+
+// StoredSearchDTO is a stored search: the request that ran it, which can be
+// sent to POST /api/v1/flights/search to search again, and the results it
+// returned then.
+type StoredSearchDTO struct {
+	SearchID   string               `json:"search_id"`
+	SearchedAt time.Time            `json:"searched_at"`
+	Request    SearchFlightsRequest `json:"request"`
+	Results    *SearchResponseDTO   `json:"results"`
+}
+
+// ToSearchHistoryDTO converts stored searches to their summaries.
+func ToSearchHistoryDTO(records []domain.SearchRecord) *SearchHistoryDTO {
+	dto := &SearchHistoryDTO{Searches: make([]SearchSummaryDTO, 0, len(records))}
+	for i := range records {
+		record := &records[i]
+		dto.Searches = append(dto.Searches, SearchSummaryDTO{
+			SearchID:     record.ID,
+			SearchedAt:   record.CreatedAt.UTC(),
+			Request:      ToSearchFlightsRequest(record),
+			TotalResults: record.Response.Metadata.TotalResults,
+			LowestPrice:  toPriceDTO(usecase.LowestFare(&record.Response)),
+		})
+	}
+	return dto
+}
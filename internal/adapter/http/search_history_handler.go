@@ -0,0 +1,113 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/sanitize"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// SearchHistoryHandler serves the caller's recent searches.
+type SearchHistoryHandler struct {
+	useCase   usecase.SearchHistoryUseCase
+	sanitizer *sanitize.Sanitizer
+}
+
+// NewSearchHistoryHandler creates a new SearchHistoryHandler with the given
+// use case. Provider free text is cleaned with sanitizer (nil = not cleaned).
+func NewSearchHistoryHandler(uc usecase.SearchHistoryUseCase, sanitizer *sanitize.Sanitizer) *SearchHistoryHandler {
+	return &SearchHistoryHandler{useCase: uc, sanitizer: sanitizer}
+}
+
+// ListSearches handles GET /api/v1/searches
+// It lists the caller's most recent searches, newest first, up to ?limit=
+// (1-100, default 20). Anonymous callers have no history.
+func (h *SearchHistoryHandler) ListSearches(c echo.Context) error {
+	client, ok := historyClient(c)
+	if !ok {
+		return writeClientRequired(c)
+	}
+
+	limit := usecase.DefaultSearchHistoryLimit
+	if raw := c.QueryParam(LimitQueryParam); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > usecase.MaxSearchHistoryLimit {
+			return response.ValidationError(c, map[string]string{
+				LimitQueryParam: fmt.Sprintf("must be between 1 and %d", usecase.MaxSearchHistoryLimit),
+			})
+		}
+		limit = n
+	}
+
+	records, err := h.useCase.List(c.Request().Context(), client, limit)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+	return response.OK(c, ToSearchHistoryDTO(records))
+}
+
+// GetSearch handles GET /api/v1/searches/:id
+// The id is the search_id of one of the caller's search responses. It
+// returns the request that ran the search, to send again as is, and the
+// results it returned then, localized like search responses. Searches of
+// other callers are not found.
+func (h *SearchHistoryHandler) GetSearch(c echo.Context) error {
+	client, ok := historyClient(c)
+	if !ok {
+		return writeClientRequired(c)
+	}
+
+	record, err := h.useCase.Get(c.Request().Context(), client, c.Param("id"))
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	locale := requestLocale(c)
+	if !loadshed.Allowed(c.Request().Context(), loadshed.FeatureLocalization) {
+		locale = ""
+	}
+	results := ToSearchResponseDTOLocalized(&record.Response, locale)
+	SanitizeSearchResponseDTO(results, h.sanitizer)
+
+	return response.OK(c, &StoredSearchDTO{
+		SearchID:   record.ID,
+		SearchedAt: record.CreatedAt.UTC(),
+		Request:    ToSearchFlightsRequest(record),
+		Results:    results,
+	})
+}
+
+// historyClient returns the principal whose searches the caller may access,
+// or false for anonymous callers.
+func historyClient(c echo.Context) (string, bool) {
+	principal, ok := rbac.FromContext(c.Request().Context())
+	if !ok || principal.IsAnonymous() {
+		return "", false
+	}
+	return principal.Name, true
+}
+
+// searchClient returns the principal the caller's searches are recorded
+// with: its name, or rbac.Anonymous without one.
+func searchClient(c echo.Context) string {
+	if principal, ok := rbac.FromContext(c.Request().Context()); ok {
+		return principal.Name
+	}
+	return rbac.Anonymous
+}
+
+// writeClientRequired writes the 401 Unauthorized response of anonymous
+// callers, who have no search history.
+func writeClientRequired(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, &response.ErrorDetail{
+		Code:    response.CodeUnauthorized,
+		Message: response.MsgClientRequired,
+	})
+}
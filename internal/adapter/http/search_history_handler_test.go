@@ -0,0 +1,176 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/store/memory"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/share"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// principalFromHeader sets the request principal from the X-Client header,
+// anonymous if missing.
+func principalFromHeader(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		name := c.Request().Header.Get("X-Client")
+		if name == "" {
+			name = rbac.Anonymous
+		}
+		ctx := rbac.WithPrincipal(c.Request().Context(), rbac.Principal{Name: name})
+		c.SetRequest(c.Request().WithContext(ctx))
+		return next(c)
+	}
+}
+
+func TestSearchHistoryHandler(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewSearchStore(10)
+	searchedAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	maxStops := 0
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{
+		ID:        "search-1",
+		Client:    "partner-app",
+		Criteria:  domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1, Class: "economy"},
+		Filters:   &domain.FilterOptions{MaxStops: &maxStops},
+		SortBy:    domain.SortByPrice,
+		CreatedAt: searchedAt,
+		Response: domain.SearchResponse{
+			Flights:  []domain.Flight{{ID: "QZ520", Price: domain.PriceInfo{Amount: 650000, Currency: "IDR"}}},
+			Metadata: domain.SearchMetadata{TotalResults: 1, SearchID: "search-1"},
+		},
+	}))
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "search-2", Client: "partner-app", CreatedAt: searchedAt.Add(time.Minute)}))
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "ops-1", Client: "ops", CreatedAt: searchedAt}))
+
+	issuer, err := share.NewIssuer([]byte(strings.Repeat("s", share.MinSecretLength)), time.Hour)
+	require.NoError(t, err)
+	e := echo.New()
+	RegisterSearchHistoryRoutes(e, NewSearchHistoryHandler(usecase.NewSearchHistoryUseCase(store), nil), principalFromHeader)
+	RegisterRerunRoutes(e, NewRerunHandler(usecase.NewRerunUseCase(nil, store), nil), principalFromHeader)
+	RegisterShareRoutes(e, NewShareHandler(usecase.NewShareUseCase(store, issuer), nil), principalFromHeader)
+	serve := func(method, target, client string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if client != "" {
+			req.Header.Set("X-Client", client)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	get := func(target, client string) *httptest.ResponseRecorder {
+		return serve(http.MethodGet, target, client)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		rec := get("/api/v1/searches", "partner-app")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var dto SearchHistoryDTO
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+		require.Len(t, dto.Searches, 2)
+		assert.Equal(t, "search-2", dto.Searches[0].SearchID, "newest first")
+		first := dto.Searches[1]
+		assert.Equal(t, searchedAt, first.SearchedAt)
+		assert.Equal(t, "CGK", first.Request.Origin)
+		assert.Equal(t, "price", first.Request.SortBy)
+		assert.Equal(t, 1, first.TotalResults)
+		assert.Equal(t, &PriceDTO{Amount: 650000, Currency: "IDR"}, first.LowestPrice)
+	})
+
+	t.Run("list with limit", func(t *testing.T) {
+		rec := get("/api/v1/searches?limit=1", "partner-app")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var dto SearchHistoryDTO
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+		assert.Len(t, dto.Searches, 1)
+
+		rec = get("/api/v1/searches?limit=101", "partner-app")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		rec := get("/api/v1/searches/search-1", "partner-app")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var dto StoredSearchDTO
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+		assert.Equal(t, "search-1", dto.SearchID)
+		assert.Equal(t, "DPS", dto.Request.Destination)
+		require.NotNil(t, dto.Request.Filters)
+		assert.Equal(t, &maxStops, dto.Request.Filters.MaxStops)
+		require.NotNil(t, dto.Results)
+		require.Len(t, dto.Results.Flights, 1)
+		assert.Equal(t, "QZ520", dto.Results.Flights[0].ID)
+	})
+
+	t.Run("other clients' searches are not found", func(t *testing.T) {
+		rec := get("/api/v1/searches/ops-1", "partner-app")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		// Nor can they be rerun or shared
+		rec = serve(http.MethodPost, "/api/v1/searches/ops-1/rerun", "partner-app")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		rec = serve(http.MethodPost, "/api/v1/searches/ops-1/share", "partner-app")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		rec = serve(http.MethodPost, "/api/v1/searches/ops-1/share", "ops")
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("anonymous callers have no history", func(t *testing.T) {
+		for _, target := range []string{"/api/v1/searches", "/api/v1/searches/search-1"} {
+			rec := get(target, "")
+			require.Equal(t, http.StatusUnauthorized, rec.Code, target)
+			var result response.ErrorDetail
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			assert.Equal(t, response.MsgClientRequired, result.Message)
+		}
+	})
+}
+
+func TestToSearchFlightsRequest_RoundTrip(t *testing.T) {
+	maxResults, maxStops, minMinutes := 20, 1, 60
+	wheelchair := true
+	req := SearchFlightsRequest{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: "2025-12-15",
+		Passengers:    2,
+		Class:         "business",
+		Filters: &FilterDTO{
+			MaxStops:           &maxStops,
+			Airlines:           []string{"GA"},
+			DepartureTimeRange: &TimeRangeDTO{Start: "06:00", End: "12:00"},
+			DurationRange:      &DurationRangeDTO{MinMinutes: &minMinutes},
+			SupportsWheelchair: &wheelchair,
+		},
+		SortBy:                 "best_value",
+		SortOrder:              "desc",
+		PreferredDepartureTime: &TimeRangeDTO{Start: "08:00", End: "10:00"},
+		MaxResults:             &maxResults,
+		Return:                 &ReturnLegDTO{Origin: "DPS", Destination: "SUB", DepartureDate: "2025-12-20"},
+	}
+
+	opts := ToSearchOptions(&req)
+	record := &domain.SearchRecord{
+		Criteria:               ToDomainCriteria(&req),
+		Filters:                opts.Filters,
+		SortBy:                 opts.SortBy,
+		SortOrder:              opts.SortOrder,
+		MaxResults:             opts.MaxResults,
+		PreferredDepartureTime: opts.PreferredDepartureTime,
+	}
+	assert.Equal(t, req, ToSearchFlightsRequest(record))
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"time"
+)
+
+// SharedSearchNotice is the notice shown with shared search results.
+const SharedSearchNotice = "Prices may have changed since these results were found."
+
+// ShareLinkDTO is an issued share link.
+type ShareLinkDTO struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SharedSearchDTO is a stored search result opened through a share link: the
+// original results, without internal identifiers, and a notice that they
+// may be stale.
+type SharedSearchDTO struct {
+	Notice SharedSearchNoticeDTO `json:"notice"`
+	*SearchResponseDTO
+}
+
+// SharedSearchNoticeDTO tells the viewer how old shared results are.
+type SharedSearchNoticeDTO struct {
+	Message    string    `json:"message"`
+	SearchedAt time.Time `json:"searched_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/sanitize"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// ShareHandler issues and opens share links of stored searches.
+type ShareHandler struct {
+	useCase   usecase.ShareUseCase
+	sanitizer *sanitize.Sanitizer
+}
+
+// NewShareHandler creates a new ShareHandler with the given use case.
+// Provider free text is cleaned with sanitizer (nil = not cleaned).
+func NewShareHandler(uc usecase.ShareUseCase, sanitizer *sanitize.Sanitizer) *ShareHandler {
+	return &ShareHandler{useCase: uc, sanitizer: sanitizer}
+}
+
+// ShareSearch handles POST /api/v1/searches/:id/share
+// The id is the search_id of a search response. It returns a share link
+// re-rendering the search's results until it expires. Searches of other
+// callers are not found.
+func (h *ShareHandler) ShareSearch(c echo.Context) error {
+	link, err := h.useCase.Share(c.Request().Context(), searchClient(c), c.Param("id"))
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return response.Created(c, &ShareLinkDTO{
+		Token:     link.Token,
+		URL:       "/share/" + link.Token,
+		ExpiresAt: link.ExpiresAt.UTC(),
+	})
+}
+
+// OpenSharedSearch handles GET /share/:token
+// It returns the stored results of the shared search, localized like search
+// responses, without the search ID and with a notice that prices may have
+// changed since.
+func (h *ShareHandler) OpenSharedSearch(c echo.Context) error {
+	shared, err := h.useCase.Open(c.Request().Context(), c.Param("token"))
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	locale := requestLocale(c)
+	if !loadshed.Allowed(c.Request().Context(), loadshed.FeatureLocalization) {
+		locale = ""
+	}
+	dto := ToSearchResponseDTOLocalized(&shared.Record.Response, locale)
+	SanitizeSearchResponseDTO(dto, h.sanitizer)
+	dto.Metadata.SearchID = ""
+	dto.Metadata.ContinuationToken = ""
+
+	return response.OK(c, &SharedSearchDTO{
+		Notice: SharedSearchNoticeDTO{
+			Message:    SharedSearchNotice,
+			SearchedAt: shared.Record.CreatedAt.UTC(),
+			ExpiresAt:  shared.ExpiresAt.UTC(),
+		},
+		SearchResponseDTO: dto,
+	})
+}
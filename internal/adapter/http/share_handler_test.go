@@ -0,0 +1,98 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http/response"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+var shareExpiry = time.Date(2025, 12, 4, 10, 0, 0, 0, time.UTC)
+
+// stubShares shares the searches it holds with "tok-<search ID>" tokens.
+type stubShares map[string]*domain.SearchRecord
+
+func (s stubShares) Share(_ context.Context, _, searchID string) (*usecase.ShareLink, error) {
+	if _, ok := s[searchID]; !ok {
+		return nil, domain.ErrSearchNotFound
+	}
+	return &usecase.ShareLink{Token: "tok-" + searchID, ExpiresAt: shareExpiry}, nil
+}
+
+func (s stubShares) Open(_ context.Context, token string) (*usecase.SharedSearch, error) {
+	for id, record := range s {
+		if token == "tok-"+id {
+			return &usecase.SharedSearch{Record: record, ExpiresAt: shareExpiry}, nil
+		}
+	}
+	return nil, domain.ErrShareNotFound
+}
+
+func TestShareHandler(t *testing.T) {
+	searchedAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	e := echo.New()
+	RegisterShareRoutes(e, NewShareHandler(stubShares{
+		"search-1": {
+			ID:        "search-1",
+			CreatedAt: searchedAt,
+			Response: domain.SearchResponse{
+				Flights:  []domain.Flight{{ID: "1", Airline: domain.AirlineInfo{Code: "QZ", Name: "AirAsia"}}},
+				Metadata: domain.SearchMetadata{TotalResults: 1, SearchID: "search-1"},
+			},
+		},
+	}, nil))
+
+	t.Run("share", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/searches/search-1/share", nil))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var link ShareLinkDTO
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &link))
+		assert.Equal(t, ShareLinkDTO{Token: "tok-search-1", URL: "/share/tok-search-1", ExpiresAt: shareExpiry}, link)
+	})
+
+	t.Run("share unknown search", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/searches/unknown/share", nil))
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		var result response.ErrorDetail
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.Equal(t, response.MsgSearchNotFound, result.Message)
+	})
+
+	t.Run("open", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/share/tok-search-1?locale=id", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "search-1", "internal IDs are not exposed")
+		var dto SharedSearchDTO
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dto))
+		assert.Equal(t, SharedSearchNoticeDTO{Message: SharedSearchNotice, SearchedAt: searchedAt, ExpiresAt: shareExpiry}, dto.Notice)
+		require.Len(t, dto.Flights, 1)
+		assert.Equal(t, "AirAsia Indonesia", dto.Flights[0].Airline.Name)
+		assert.Equal(t, 1, dto.Metadata.TotalResults)
+	})
+
+	t.Run("open invalid or expired token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/share/expired", nil))
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		var result response.ErrorDetail
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.Equal(t, response.MsgShareNotFound, result.Message)
+	})
+}
@@ -0,0 +1,244 @@
+package http
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// SimulateRequest is the body of POST /admin/v1/simulate: recorded provider
+// latencies and result counts, replayed with hypothetical settings.
+type SimulateRequest struct {
+	Samples  []SimulationSampleDTO `json:"samples"`
+	Settings SimulationSettingsDTO `json:"settings"`
+}
+
+// SimulationSampleDTO is the recorded behaviour of the providers of one
+// search. SearchID optionally names a stored search whose criteria, filters
+// and flights are replayed, so rankings can be compared.
+type SimulationSampleDTO struct {
+	SearchID  string              `json:"search_id,omitempty"`
+	Providers []ProviderSampleDTO `json:"providers"`
+}
+
+// ProviderSampleDTO is how a provider answered one search.
+type ProviderSampleDTO struct {
+	Provider  string `json:"provider"`
+	LatencyMs int64  `json:"latency_ms"`
+	Results   int    `json:"results"`
+	Failed    bool   `json:"failed,omitempty"`
+}
+
+// SimulationSettingsDTO are the settings of a simulation. Omitted settings
+// keep the running value.
+type SimulationSettingsDTO struct {
+	GlobalTimeoutMs   int64              `json:"global_timeout_ms,omitempty"`
+	ProviderTimeoutMs int64              `json:"provider_timeout_ms,omitempty"`
+	Weights           *RankingWeightsDTO `json:"weights,omitempty"`
+}
+
+// RankingWeightsDTO are the relative weights of price, duration and stops in
+// ranking scores.
+type RankingWeightsDTO struct {
+	Price    float64 `json:"price"`
+	Duration float64 `json:"duration"`
+	Stops    float64 `json:"stops"`
+}
+
+// Validate validates the simulation request and returns any validation errors.
+func (r *SimulateRequest) Validate() error {
+	errs := &ValidationErrors{}
+
+	switch {
+	case len(r.Samples) == 0:
+		errs.Add("samples", "at least one sample is required")
+	case len(r.Samples) > usecase.MaxSimulationSamples:
+		errs.Add("samples", fmt.Sprintf("at most %d samples are accepted", usecase.MaxSimulationSamples))
+	}
+	for i, s := range r.Samples {
+		field := fmt.Sprintf("samples[%d]", i)
+		if len(s.Providers) == 0 {
+			errs.Add(field+".providers", "at least one provider is required")
+		}
+		for j, p := range s.Providers {
+			field := fmt.Sprintf("%s.providers[%d]", field, j)
+			if p.Provider == "" {
+				errs.Add(field+".provider", "is required")
+			}
+			if p.LatencyMs < 0 {
+				errs.Add(field+".latency_ms", "must not be negative")
+			}
+			if p.Results < 0 {
+				errs.Add(field+".results", "must not be negative")
+			}
+		}
+	}
+
+	if r.Settings.GlobalTimeoutMs < 0 {
+		errs.Add("settings.global_timeout_ms", "must not be negative")
+	}
+	if r.Settings.ProviderTimeoutMs < 0 {
+		errs.Add("settings.provider_timeout_ms", "must not be negative")
+	}
+	if w := r.Settings.Weights; w != nil {
+		if err := w.toDomain().Validate(); err != nil {
+			errs.Add("settings.weights", "must be non-negative numbers, not all zero")
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
+// ToSimulationSamples converts the request's samples to use case samples.
+func (r *SimulateRequest) ToSimulationSamples() []usecase.SimulationSample {
+	samples := make([]usecase.SimulationSample, len(r.Samples))
+	for i, s := range r.Samples {
+		samples[i].SearchID = s.SearchID
+		for _, p := range s.Providers {
+			samples[i].Providers = append(samples[i].Providers, usecase.ProviderSample{
+				Provider: p.Provider,
+				Latency:  time.Duration(p.LatencyMs) * time.Millisecond,
+				Results:  p.Results,
+				Failed:   p.Failed,
+			})
+		}
+	}
+	return samples
+}
+
+// ToSimulationSettings converts the request's settings to use case settings.
+func (r *SimulateRequest) ToSimulationSettings() usecase.SimulationSettings {
+	settings := usecase.SimulationSettings{
+		GlobalTimeout:   time.Duration(r.Settings.GlobalTimeoutMs) * time.Millisecond,
+		ProviderTimeout: time.Duration(r.Settings.ProviderTimeoutMs) * time.Millisecond,
+	}
+	if r.Settings.Weights != nil {
+		settings.Weights = r.Settings.Weights.toDomain()
+	}
+	return settings
+}
+
+// toDomain converts the weights to use case weights.
+func (w *RankingWeightsDTO) toDomain() usecase.RankingWeights {
+	return usecase.RankingWeights{Price: w.Price, Duration: w.Duration, Stops: w.Stops}
+}
+
+// SimulationResponseDTO compares the samples replayed with the running
+// settings and with the hypothetical ones.
+type SimulationResponseDTO struct {
+	Current   SimulationOutcomeDTO `json:"current"`
+	Simulated SimulationOutcomeDTO `json:"simulated"`
+	Ranking   SimulationRankingDTO `json:"ranking"`
+}
+
+// SimulationOutcomeDTO is the outcome of the samples replayed with one set
+// of settings.
+type SimulationOutcomeDTO struct {
+	Settings       SimulationSettingsDTO  `json:"settings"`
+	Searches       int                    `json:"searches"`
+	SearchesFailed int                    `json:"searches_failed"`
+	Results        int                    `json:"results"`
+	Providers      []SimulatedProviderDTO `json:"providers"`
+}
+
+// SimulatedProviderDTO counts how a provider's searches ended in a simulation.
+type SimulatedProviderDTO struct {
+	Provider    string  `json:"provider"`
+	Queried     int     `json:"queried"`
+	Succeeded   int     `json:"succeeded"`
+	TimedOut    int     `json:"timed_out"`
+	Failed      int     `json:"failed"`
+	SuccessRate float64 `json:"success_rate"`
+	Results     int     `json:"results"`
+}
+
+// SimulationRankingDTO compares the rankings of the stored searches replayed.
+type SimulationRankingDTO struct {
+	Compared    int                `json:"compared"`
+	BestChanged int                `json:"best_changed"`
+	Searches    []RankingChangeDTO `json:"searches"`
+}
+
+// RankingChangeDTO compares the ranking of a stored search replayed with
+// both settings.
+type RankingChangeDTO struct {
+	SearchID      string        `json:"search_id"`
+	CurrentBest   *FlightRefDTO `json:"current_best"`
+	SimulatedBest *FlightRefDTO `json:"simulated_best"`
+	BestChanged   bool          `json:"best_changed"`
+	Moved         int           `json:"moved"`
+	Added         int           `json:"added"`
+	Removed       int           `json:"removed"`
+}
+
+// ToSimulationResponseDTO converts a simulation result to its DTO.
+func ToSimulationResponseDTO(result *usecase.SimulationResult) *SimulationResponseDTO {
+	dto := &SimulationResponseDTO{
+		Current:   toSimulationOutcomeDTO(result.Current),
+		Simulated: toSimulationOutcomeDTO(result.Simulated),
+		Ranking: SimulationRankingDTO{
+			Compared: len(result.Ranking),
+			Searches: make([]RankingChangeDTO, 0, len(result.Ranking)),
+		},
+	}
+	for _, c := range result.Ranking {
+		if c.BestChanged() {
+			dto.Ranking.BestChanged++
+		}
+		dto.Ranking.Searches = append(dto.Ranking.Searches, RankingChangeDTO{
+			SearchID:      c.SearchID,
+			CurrentBest:   toOptionalFlightRefDTO(c.CurrentBest),
+			SimulatedBest: toOptionalFlightRefDTO(c.SimulatedBest),
+			BestChanged:   c.BestChanged(),
+			Moved:         c.Moved,
+			Added:         c.Added,
+			Removed:       c.Removed,
+		})
+	}
+	return dto
+}
+
+// toSimulationOutcomeDTO converts the outcome of one set of settings.
+func toSimulationOutcomeDTO(o usecase.SimulationOutcome) SimulationOutcomeDTO {
+	dto := SimulationOutcomeDTO{
+		Settings: SimulationSettingsDTO{
+			GlobalTimeoutMs:   o.Settings.GlobalTimeout.Milliseconds(),
+			ProviderTimeoutMs: o.Settings.ProviderTimeout.Milliseconds(),
+			Weights: &RankingWeightsDTO{
+				Price:    o.Settings.Weights.Price,
+				Duration: o.Settings.Weights.Duration,
+				Stops:    o.Settings.Weights.Stops,
+			},
+		},
+		Searches:       o.Searches,
+		SearchesFailed: o.SearchesFailed,
+		Results:        o.Results,
+		Providers:      make([]SimulatedProviderDTO, 0, len(o.Providers)),
+	}
+	for _, p := range o.Providers {
+		dto.Providers = append(dto.Providers, SimulatedProviderDTO{
+			Provider:    p.Provider,
+			Queried:     p.Queried,
+			Succeeded:   p.Succeeded,
+			TimedOut:    p.TimedOut,
+			Failed:      p.Failed,
+			SuccessRate: p.SuccessRate(),
+			Results:     p.Results,
+		})
+	}
+	return dto
+}
+
+// toOptionalFlightRefDTO converts a flight, if any, to a flight reference.
+func toOptionalFlightRefDTO(f *domain.Flight) *FlightRefDTO {
+	if f == nil {
+		return nil
+	}
+	ref := toFlightRefDTO(*f)
+	return &ref
+}
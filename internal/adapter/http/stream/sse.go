@@ -0,0 +1,54 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// TransportSSE is the metrics label for Server-Sent Events streams.
+const TransportSSE = "sse"
+
+// SSEEncoder writes updates as Server-Sent Events and flushes after each one.
+type SSEEncoder struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// NewSSEEncoder prepares w for an event stream by writing the SSE headers.
+// Returns an error if the writer does not support flushing.
+func NewSSEEncoder(w http.ResponseWriter) (*SSEEncoder, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, fmt.Errorf("response writer does not support flushing")
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &SSEEncoder{w: w, flusher: flusher}, nil
+}
+
+// Encode writes a single event with a JSON-encoded data line.
+func (e *SSEEncoder) Encode(u Update) error {
+	data, err := json.Marshal(u.Data)
+	if err != nil {
+		return fmt.Errorf("encode %s event: %w", u.Event, err)
+	}
+
+	if u.Event != "" {
+		if _, err := fmt.Fprintf(e.w, "event: %s\n", u.Event); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(e.w, "data: %s\n\n", data); err != nil {
+		return err
+	}
+
+	e.flusher.Flush()
+	return nil
+}
@@ -0,0 +1,212 @@
+// Package stream provides backpressure-aware delivery of incremental search
+// updates for streaming transports (SSE, WebSocket).
+//
+// Producers publish updates without ever blocking on the client. Each connection
+// holds a bounded buffer; when the client reads slower than updates arrive,
+// intermediate updates are coalesced or dropped, while the final authoritative
+// snapshot is always delivered.
+package stream
+
+import (
+	"context"
+	"sync"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/metrics"
+)
+
+// DefaultBufferSize is the number of pending intermediate updates kept per connection.
+const DefaultBufferSize = 16
+
+// Drop reasons reported in the dropped updates metric.
+const (
+	// DropReasonCoalesced means a pending update was replaced by a newer update of the same event
+	DropReasonCoalesced = "coalesced"
+
+	// DropReasonOverflow means the oldest pending update was discarded to make room
+	DropReasonOverflow = "overflow"
+
+	// DropReasonSuperseded means a pending update was discarded in favor of the final snapshot
+	DropReasonSuperseded = "superseded"
+)
+
+// Update is a single message delivered to a streaming client.
+type Update struct {
+	// Event names the update kind (e.g., "provider_result", "result")
+	Event string
+
+	// Data is the JSON-serializable payload
+	Data interface{}
+}
+
+// Encoder writes updates to a client connection.
+// Encode may block while the client is slow to read.
+type Encoder interface {
+	Encode(u Update) error
+}
+
+// Config holds the per-connection stream settings.
+type Config struct {
+	// BufferSize is the maximum number of pending intermediate updates.
+	// Values below 1 use DefaultBufferSize.
+	BufferSize int
+
+	// Transport labels the stream in metrics (e.g., "sse", "websocket")
+	Transport string
+}
+
+// Stream is a bounded, per-connection update queue.
+// It is safe for concurrent use by multiple publishers and a single Run loop.
+type Stream struct {
+	transport string
+	size      int
+	notify    chan struct{}
+
+	mu       sync.Mutex
+	pending  []Update
+	final    *Update
+	finished bool
+	dropped  int
+}
+
+// New creates a stream with the given configuration.
+func New(cfg Config) *Stream {
+	size := cfg.BufferSize
+	if size < 1 {
+		size = DefaultBufferSize
+	}
+
+	return &Stream{
+		transport: cfg.Transport,
+		size:      size,
+		notify:    make(chan struct{}, 1),
+		pending:   make([]Update, 0, size),
+	}
+}
+
+// Publish queues an intermediate update without blocking.
+// When the buffer is full, the most recent pending update with the same event is
+// replaced (newer data supersedes it); otherwise the oldest pending update is dropped.
+// Returns false if the stream is already finished or an update had to be dropped.
+func (s *Stream) Publish(u Update) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finished {
+		return false
+	}
+
+	accepted := true
+	if len(s.pending) >= s.size {
+		accepted = false
+		if i := s.lastPending(u.Event); i >= 0 {
+			s.pending[i] = u
+			s.drop(DropReasonCoalesced, 1)
+			s.signal()
+			return accepted
+		}
+		s.pending = append(s.pending[:0], s.pending[1:]...)
+		s.drop(DropReasonOverflow, 1)
+	}
+
+	s.pending = append(s.pending, u)
+	s.signal()
+	return accepted
+}
+
+// Finish queues the final authoritative snapshot.
+// Pending intermediate updates are discarded since the snapshot supersedes them,
+// and further Publish or Finish calls are ignored.
+func (s *Stream) Finish(final Update) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finished {
+		return
+	}
+
+	if n := len(s.pending); n > 0 {
+		s.drop(DropReasonSuperseded, n)
+		s.pending = s.pending[:0]
+	}
+	s.final = &final
+	s.finished = true
+	s.signal()
+}
+
+// Dropped returns the number of updates dropped or coalesced so far.
+func (s *Stream) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Run delivers queued updates to enc until the final snapshot has been written,
+// the encoder fails, or ctx is cancelled. Publishers never wait on Run.
+func (s *Stream) Run(ctx context.Context, enc Encoder) error {
+	metrics.StreamConnections.WithLabelValues(s.transport).Inc()
+	defer metrics.StreamConnections.WithLabelValues(s.transport).Dec()
+
+	for {
+		u, last, ok := s.next()
+		if ok {
+			if err := enc.Encode(u); err != nil {
+				return err
+			}
+			metrics.StreamUpdatesSent.WithLabelValues(s.transport).Inc()
+			if last {
+				return nil
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.notify:
+		}
+	}
+}
+
+// next pops the next update to deliver. last is true for the final snapshot.
+func (s *Stream) next() (u Update, last bool, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.pending) > 0 {
+		u = s.pending[0]
+		s.pending = append(s.pending[:0], s.pending[1:]...)
+		return u, false, true
+	}
+
+	if s.final != nil {
+		u = *s.final
+		s.final = nil
+		return u, true, true
+	}
+
+	return Update{}, false, false
+}
+
+// lastPending returns the index of the most recent pending update for event, or -1.
+func (s *Stream) lastPending(event string) int {
+	for i := len(s.pending) - 1; i >= 0; i-- {
+		if s.pending[i].Event == event {
+			return i
+		}
+	}
+	return -1
+}
+
+// drop records n dropped updates. Must be called with s.mu held.
+func (s *Stream) drop(reason string, n int) {
+	s.dropped += n
+	metrics.StreamUpdatesDropped.WithLabelValues(s.transport, reason).Add(float64(n))
+}
+
+// signal wakes the Run loop without blocking.
+func (s *Stream) signal() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
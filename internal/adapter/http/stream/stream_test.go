@@ -0,0 +1,171 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// recordingEncoder collects encoded updates, optionally blocking until released.
+type recordingEncoder struct {
+	mu      sync.Mutex
+	updates []Update
+	gate    chan struct{}
+	err     error
+}
+
+func (e *recordingEncoder) Encode(u Update) error {
+	if e.gate != nil {
+		<-e.gate
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return e.err
+	}
+	e.updates = append(e.updates, u)
+	return nil
+}
+
+func (e *recordingEncoder) events() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	events := make([]string, len(e.updates))
+	for i, u := range e.updates {
+		events[i] = u.Event
+	}
+	return events
+}
+
+func TestStream_DeliversInOrderThenFinal(t *testing.T) {
+	s := New(Config{BufferSize: 4, Transport: "test"})
+	s.Publish(Update{Event: "a", Data: 1})
+	s.Publish(Update{Event: "b", Data: 2})
+	s.Finish(Update{Event: "result", Data: 3})
+
+	// Finish supersedes pending updates that were never read
+	enc := &recordingEncoder{}
+	require.NoError(t, s.Run(context.Background(), enc))
+	assert.Equal(t, []string{"result"}, enc.events())
+	assert.Equal(t, 2, s.Dropped())
+}
+
+func TestStream_PublishNeverBlocksOnSlowClient(t *testing.T) {
+	s := New(Config{BufferSize: 2, Transport: "test"})
+	enc := &recordingEncoder{gate: make(chan struct{})}
+
+	done := make(chan error, 1)
+	go func() { done <- s.Run(context.Background(), enc) }()
+
+	published := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			s.Publish(Update{Event: "provider_result", Data: i})
+		}
+		close(published)
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a slow client")
+	}
+
+	s.Finish(Update{Event: "result", Data: "final"})
+	close(enc.gate)
+
+	require.NoError(t, <-done)
+	events := enc.events()
+	require.NotEmpty(t, events)
+	assert.Equal(t, "result", events[len(events)-1])
+	assert.LessOrEqual(t, len(events), 4, "at most the in-flight update, the buffer and the final snapshot")
+	assert.Positive(t, s.Dropped())
+}
+
+func TestStream_Publish_BufferFull(t *testing.T) {
+	t.Run("coalesces same event", func(t *testing.T) {
+		s := New(Config{BufferSize: 2})
+		assert.True(t, s.Publish(Update{Event: "progress", Data: 1}))
+		assert.True(t, s.Publish(Update{Event: "flights", Data: 2}))
+		assert.False(t, s.Publish(Update{Event: "progress", Data: 3}))
+
+		assert.Equal(t, []Update{{Event: "progress", Data: 3}, {Event: "flights", Data: 2}}, s.pending)
+		assert.Equal(t, 1, s.Dropped())
+	})
+
+	t.Run("drops oldest otherwise", func(t *testing.T) {
+		s := New(Config{BufferSize: 2})
+		s.Publish(Update{Event: "a"})
+		s.Publish(Update{Event: "b"})
+		assert.False(t, s.Publish(Update{Event: "c"}))
+
+		assert.Equal(t, []Update{{Event: "b"}, {Event: "c"}}, s.pending)
+		assert.Equal(t, 1, s.Dropped())
+	})
+}
+
+func TestStream_IgnoresUpdatesAfterFinish(t *testing.T) {
+	s := New(Config{})
+	s.Finish(Update{Event: "result", Data: 1})
+	assert.False(t, s.Publish(Update{Event: "late"}))
+	s.Finish(Update{Event: "result", Data: 2})
+
+	enc := &recordingEncoder{}
+	require.NoError(t, s.Run(context.Background(), enc))
+	require.Len(t, enc.updates, 1)
+	assert.Equal(t, 1, enc.updates[0].Data)
+}
+
+func TestStream_Run_ContextCancelled(t *testing.T) {
+	s := New(Config{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := s.Run(ctx, &recordingEncoder{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestStream_Run_EncoderError(t *testing.T) {
+	s := New(Config{})
+	s.Publish(Update{Event: "a"})
+
+	encErr := errors.New("broken pipe")
+	err := s.Run(context.Background(), &recordingEncoder{err: encErr})
+	assert.ErrorIs(t, err, encErr)
+}
+
+func TestSSEEncoder(t *testing.T) {
+	rec := httptest.NewRecorder()
+	enc, err := NewSSEEncoder(rec)
+	require.NoError(t, err)
+
+	require.NoError(t, enc.Encode(Update{Event: "result", Data: map[string]int{"total": 2}}))
+
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "event: result\ndata: {\"total\":2}\n\n", rec.Body.String())
+	assert.True(t, rec.Flushed)
+}
+
+func TestWebSocketEncoder(t *testing.T) {
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		enc := NewWebSocketEncoder(conn, 0)
+		_ = enc.Encode(Update{Event: "result", Data: map[string]int{"total": 2}})
+	}))
+	defer server.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http"), "", "http://localhost/")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var frame string
+	require.NoError(t, websocket.Message.Receive(conn, &frame))
+	assert.JSONEq(t, `{"event":"result","data":{"total":2}}`, frame)
+}
@@ -0,0 +1,50 @@
+package stream
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// TransportWebSocket is the metrics label for WebSocket streams.
+const TransportWebSocket = "websocket"
+
+// DefaultWriteTimeout bounds writing a single WebSocket message.
+const DefaultWriteTimeout = 10 * time.Second
+
+// Message is the JSON text frame carrying an update over a WebSocket.
+type Message struct {
+	// Event names the update kind
+	Event string `json:"event"`
+
+	// Data is the update payload
+	Data interface{} `json:"data"`
+}
+
+// WebSocketEncoder writes updates as JSON text frames. A client that does not
+// read a frame within the write timeout fails the stream instead of stalling it.
+type WebSocketEncoder struct {
+	conn    *websocket.Conn
+	timeout time.Duration
+}
+
+// NewWebSocketEncoder creates an encoder writing to conn. A timeout of zero
+// uses DefaultWriteTimeout.
+func NewWebSocketEncoder(conn *websocket.Conn, timeout time.Duration) *WebSocketEncoder {
+	if timeout <= 0 {
+		timeout = DefaultWriteTimeout
+	}
+	return &WebSocketEncoder{conn: conn, timeout: timeout}
+}
+
+// Encode writes a single update as a Message.
+func (e *WebSocketEncoder) Encode(u Update) error {
+	if err := e.conn.SetWriteDeadline(time.Now().Add(e.timeout)); err != nil {
+		return err
+	}
+	if err := websocket.JSON.Send(e.conn, Message{Event: u.Event, Data: u.Data}); err != nil {
+		return fmt.Errorf("send %s message: %w", u.Event, err)
+	}
+	return nil
+}
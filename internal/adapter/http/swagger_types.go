@@ -81,6 +81,9 @@ type SwaggerAirlineInfo struct {
 
 	// Logo is an optional URL to the airline's logo image
 	Logo string `json:"logo,omitempty" example:"https://example.com/ga-logo.png"`
+
+	// Alliance is the airline alliance the airline belongs to, if any
+	Alliance string `json:"alliance,omitempty" example:"SkyTeam"`
 }
 
 // SwaggerFlightPoint represents a point in a flight journey.
@@ -92,6 +95,12 @@ type SwaggerFlightPoint struct {
 	// AirportName is the full airport name
 	AirportName string `json:"airportName,omitempty" example:"Soekarno-Hatta International Airport"`
 
+	// City is the served city name
+	City string `json:"city,omitempty" example:"Jakarta"`
+
+	// Country is the ISO 3166-1 alpha-2 country code
+	Country string `json:"country,omitempty" example:"ID"`
+
 	// Terminal is the terminal identifier
 	Terminal string `json:"terminal,omitempty" example:"3"`
 
@@ -123,6 +132,17 @@ type SwaggerPriceInfo struct {
 
 	// Display is a formatted price string
 	Display string `json:"display,omitempty" example:"IDR 1,250,000"`
+
+	// BaseFare, Taxes and Fees break Amount down, when the provider reports it
+	BaseFare float64 `json:"baseFare,omitempty" example:"1100000"`
+	Taxes    float64 `json:"taxes,omitempty" example:"120000"`
+	Fees     float64 `json:"fees,omitempty" example:"30000"`
+
+	// Passengers is the number of passengers Total is for
+	Passengers int `json:"passengers,omitempty" example:"2"`
+
+	// Total is the price for all the passengers, Amount being per passenger
+	Total float64 `json:"total,omitempty" example:"2500000"`
 }
 
 // SwaggerBaggageInfo contains baggage allowance information.
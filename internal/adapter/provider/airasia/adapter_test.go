@@ -2,11 +2,13 @@ package airasia
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
-	"time"
 
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/httpsource"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -241,6 +243,24 @@ func TestAdapter_Search_FileNotFound(t *testing.T) {
 	require.ErrorAs(t, err, &providerErr)
 	assert.Equal(t, "airasia", providerErr.Provider)
 	assert.True(t, providerErr.Retryable, "File read errors should be retryable")
+	assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+}
+
+// TestAdapter_Search_ResponseTooLarge tests that oversized responses are rejected.
+func TestAdapter_Search_ResponseTooLarge(t *testing.T) {
+	mockPath := "../../../../docs/response-mock/airasia_search_response.json"
+
+	flights, err := NewAdapter(mockPath, WithMaxResponseBytes(1024)).Search(context.Background(), domain.SearchCriteria{})
+	require.Error(t, err)
+	assert.Empty(t, flights)
+
+	var providerErr *domain.ProviderError
+	require.ErrorAs(t, err, &providerErr)
+	assert.False(t, providerErr.Retryable, "oversized responses should not be retried")
+	assert.ErrorIs(t, err, domain.ErrResponseTooLarge)
+
+	_, err = NewAdapter(mockPath, WithMaxResponseBytes(1<<20)).Search(context.Background(), domain.SearchCriteria{})
+	assert.NoError(t, err)
 }
 
 // TestAdapter_Search_ContextCancellation tests context cancellation handling.
@@ -261,64 +281,6 @@ func TestAdapter_Search_ContextCancellation(t *testing.T) {
 	assert.ErrorIs(t, providerErr.Err, context.Canceled)
 }
 
-// TestHoursToMinutes tests the float to minutes conversion.
-func TestHoursToMinutes(t *testing.T) {
-	tests := []struct {
-		name     string
-		hours    float64
-		expected int
-	}{
-		{"1.75 hours", 1.75, 105},
-		{"2.5 hours", 2.5, 150},
-		{"0.5 hours", 0.5, 30},
-		{"1.0 hours", 1.0, 60},
-		{"0 hours", 0, 0},
-		{"1.67 hours", 1.67, 100}, // Rounds to nearest
-		{"2.25 hours", 2.25, 135},
-		{"3.33 hours", 3.33, 200}, // Rounds properly
-		{"4.33 hours", 4.33, 260},
-		{"0.25 hours", 0.25, 15},
-		{"0.1 hours", 0.1, 6},
-		{"5.99 hours", 5.99, 359},
-		{"negative hours", -1.5, -90}, // Edge case: negative
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := hoursToMinutes(tt.hours)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
-// TestFormatDurationFromHours tests duration formatting.
-func TestFormatDurationFromHours(t *testing.T) {
-	tests := []struct {
-		name     string
-		hours    float64
-		expected string
-	}{
-		{"1h 45m", 1.75, "1h 45m"},
-		{"2h 30m", 2.5, "2h 30m"},
-		{"30m only", 0.5, "30m"},
-		{"1h only", 1.0, "1h"},
-		{"0 minutes", 0, "0m"},
-		{"1h 40m", 1.67, "1h 40m"},
-		{"2h 15m", 2.25, "2h 15m"},
-		{"4h 20m", 4.33, "4h 20m"},
-		{"15m only", 0.25, "15m"},
-		{"3h only", 3.0, "3h"},
-		{"5h 59m", 5.99, "5h 59m"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := formatDurationFromHours(tt.hours)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
 // TestDirectFlightToStops tests the direct flight to stops conversion.
 func TestDirectFlightToStops(t *testing.T) {
 	tests := []struct {
@@ -367,58 +329,6 @@ func TestDirectFlightToStops(t *testing.T) {
 	}
 }
 
-// TestParseDateTime tests datetime parsing with various formats.
-func TestParseDateTime(t *testing.T) {
-	tests := []struct {
-		name        string
-		datetime    string
-		expectError bool
-		expectedUTC string
-	}{
-		{
-			name:        "RFC3339 with colon in offset",
-			datetime:    "2025-12-15T06:00:00+07:00",
-			expectError: false,
-			expectedUTC: "2025-12-14T23:00:00Z",
-		},
-		{
-			name:        "RFC3339 with UTC",
-			datetime:    "2025-12-15T06:00:00Z",
-			expectError: false,
-			expectedUTC: "2025-12-15T06:00:00Z",
-		},
-		{
-			name:        "with +08:00 offset",
-			datetime:    "2025-12-15T08:45:00+08:00",
-			expectError: false,
-			expectedUTC: "2025-12-15T00:45:00Z",
-		},
-		{
-			name:        "invalid format",
-			datetime:    "15-12-2025 06:00:00",
-			expectError: true,
-		},
-		{
-			name:        "empty string",
-			datetime:    "",
-			expectError: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := parseDateTime(tt.datetime)
-
-			if tt.expectError {
-				assert.Error(t, err)
-			} else {
-				require.NoError(t, err)
-				assert.Equal(t, tt.expectedUTC, result.UTC().Format(time.RFC3339))
-			}
-		})
-	}
-}
-
 // TestParseBaggageNote tests baggage note parsing.
 func TestParseBaggageNote(t *testing.T) {
 	tests := []struct {
@@ -507,6 +417,7 @@ func TestNormalize_FieldMapping(t *testing.T) {
 	assert.Equal(t, 0, f.Baggage.CheckedKg)
 	assert.Equal(t, "economy", f.Class) // Normalized to lowercase
 	assert.Equal(t, 0, f.Stops)
+	assert.Equal(t, 67, f.SeatsAvailable)
 	assert.Equal(t, "airasia", f.Provider)
 }
 
@@ -546,6 +457,32 @@ func TestNormalize_SkipsInvalidFlights(t *testing.T) {
 	assert.Equal(t, "QZ524", result[0].FlightNumber)
 }
 
+// TestNormalize_RejectsForeignFlightNumbers tests that flights whose number
+// belongs to another airline are rejected as data-quality errors.
+func TestNormalize_RejectsForeignFlightNumbers(t *testing.T) {
+	valid := AirAsiaFlight{
+		FlightCode:    "QZ520",
+		Airline:       "AirAsia",
+		FromAirport:   "CGK",
+		ToAirport:     "DPS",
+		DepartTime:    "2025-12-15T04:45:00+07:00",
+		ArriveTime:    "2025-12-15T07:25:00+08:00",
+		DurationHours: 1.67,
+		DirectFlight:  true,
+		PriceIDR:      650000,
+		CabinClass:    "economy",
+	}
+	foreign := valid
+	foreign.FlightCode = "AK520" // AirAsia Malaysia, not the QZ operator
+	malformed := valid
+	malformed.FlightCode = "AIRASIA-520"
+
+	result := normalize([]AirAsiaFlight{valid, foreign, malformed})
+
+	require.Len(t, result, 1)
+	assert.Equal(t, "QZ520", result[0].FlightNumber)
+}
+
 // TestNormalize_ConnectingFlight tests normalization of connecting flights.
 func TestNormalize_ConnectingFlight(t *testing.T) {
 	flights := []AirAsiaFlight{
@@ -624,3 +561,38 @@ func createTempFile(t *testing.T, content string) string {
 
 	return tmpFile.Name()
 }
+
+// TestAdapter_Search_HTTPSource tests that responses fetched over HTTP are
+// normalized like the mock data file.
+func TestAdapter_Search_HTTPSource(t *testing.T) {
+	mockPath := "../../../../docs/response-mock/airasia_search_response.json"
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		http.ServeFile(w, r, mockPath)
+	}))
+	defer server.Close()
+
+	source, err := httpsource.New(httpsource.Config{BaseURL: server.URL, AuthValue: "Bearer secret"})
+	require.NoError(t, err)
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15"}
+
+	want, err := NewAdapter(mockPath).Search(context.Background(), criteria)
+	require.NoError(t, err)
+	got, err := NewAdapterWithSimulation("", WithHTTPSource(source)).Search(context.Background(), criteria)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	for code, retryable := range map[int]bool{http.StatusServiceUnavailable: true, http.StatusUnauthorized: false} {
+		status = code
+		_, err := NewAdapter("", WithHTTPSource(source)).Search(context.Background(), criteria)
+		var providerErr *domain.ProviderError
+		require.ErrorAs(t, err, &providerErr)
+		assert.Equal(t, retryable, providerErr.Retryable, "status %d", code)
+		assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+	}
+}
@@ -0,0 +1,55 @@
+package airasia
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/test/testutil"
+)
+
+// FuzzAdapter_SchemaDrift mutates the provider mock payload with random schema
+// drift and checks the adapter never panics and always returns valid flights
+// or a categorized ProviderError.
+func FuzzAdapter_SchemaDrift(f *testing.F) {
+	payload := testutil.LoadMockJSON(f, "airasia_search_response.json")
+	for seed := int64(0); seed < 16; seed++ {
+		f.Add(seed, uint8(seed%4)+1)
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64, mutations uint8) {
+		data, err := testutil.MutateJSON(payload, rand.New(rand.NewSource(seed)), int(mutations%8)+1)
+		if err != nil {
+			t.Fatalf("mutate payload: %v", err)
+		}
+
+		path := filepath.Join(t.TempDir(), "response.json")
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("write payload: %v", err)
+		}
+
+		flights, err := NewAdapter(path).Search(context.Background(), domain.SearchCriteria{})
+		testutil.AssertProviderOutcome(t, ProviderName, flights, err)
+	})
+}
+
+// FuzzAdapter_RawPayload feeds arbitrary bytes to the adapter.
+func FuzzAdapter_RawPayload(f *testing.F) {
+	f.Add(testutil.LoadMockJSON(f, "airasia_search_response.json"))
+	f.Add([]byte("{}"))
+	f.Add([]byte("null"))
+	f.Add([]byte("[]"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		path := filepath.Join(t.TempDir(), "response.json")
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("write payload: %v", err)
+		}
+
+		flights, err := NewAdapter(path).Search(context.Background(), domain.SearchCriteria{})
+		testutil.AssertProviderOutcome(t, ProviderName, flights, err)
+	})
+}
@@ -2,11 +2,11 @@ package airasia
 
 import (
 	"fmt"
-	"math"
 	"strings"
-	"time"
 
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/durationfmt"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
 )
 
 // ProviderName is the unique identifier for the AirAsia provider.
@@ -55,15 +55,16 @@ func normalize(flights []AirAsiaFlight) []domain.Flight {
 
 // normalizeSingle converts a single AirAsiaFlight to a domain.Flight.
 // Returns false if the flight cannot be normalized (e.g., invalid datetime).
+// AirAsia reports no fare conditions, so the flight has no fare rules.
 func normalizeSingle(f AirAsiaFlight) (domain.Flight, bool) {
 	// Parse departure time
-	departureTime, err := parseDateTime(f.DepartTime)
+	departureTime, err := timeutil.ParseDateTime(f.DepartTime, timeutil.WithAirport(f.FromAirport))
 	if err != nil {
 		return domain.Flight{}, false
 	}
 
 	// Parse arrival time
-	arrivalTime, err := parseDateTime(f.ArriveTime)
+	arrivalTime, err := timeutil.ParseDateTime(f.ArriveTime, timeutil.WithAirport(f.ToAirport))
 	if err != nil {
 		return domain.Flight{}, false
 	}
@@ -93,8 +94,8 @@ func normalizeSingle(f AirAsiaFlight) (domain.Flight, bool) {
 			DateTime:    arrivalTime,
 		},
 		Duration: domain.DurationInfo{
-			TotalMinutes: hoursToMinutes(f.DurationHours),
-			Formatted:    formatDurationFromHours(f.DurationHours),
+			TotalMinutes: durationfmt.FromHours(f.DurationHours),
+			Formatted:    durationfmt.Format(durationfmt.FromHours(f.DurationHours)),
 		},
 		Price: domain.PriceInfo{
 			Amount:   f.PriceIDR,
@@ -103,10 +104,12 @@ func normalizeSingle(f AirAsiaFlight) (domain.Flight, bool) {
 		Baggage: domain.BaggageInfo{
 			CabinKg:   cabinKg,
 			CheckedKg: checkedKg,
+			Note:      f.BaggageNote,
 		},
-		Class:    strings.ToLower(f.CabinClass),
-		Stops:    stopsCount,
-		Provider: ProviderName,
+		Class:          strings.ToLower(f.CabinClass),
+		Stops:          stopsCount,
+		Provider:       ProviderName,
+		SeatsAvailable: f.Seats,
 	}, true
 }
 
@@ -115,28 +118,6 @@ func generateFlightID(f AirAsiaFlight) string {
 	return fmt.Sprintf("%s-%s-%s-%s", ProviderName, f.FlightCode, f.FromAirport, f.ToAirport)
 }
 
-// hoursToMinutes converts float hours to integer minutes with proper rounding.
-// Examples: 1.75 → 105, 2.5 → 150, 0.5 → 30
-func hoursToMinutes(hours float64) int {
-	return int(math.Round(hours * 60))
-}
-
-// formatDurationFromHours formats a float duration as "Xh Ym".
-// Examples: 1.75 → "1h 45m", 2.5 → "2h 30m", 0.5 → "30m"
-func formatDurationFromHours(hours float64) string {
-	totalMinutes := hoursToMinutes(hours)
-	h := totalMinutes / 60
-	m := totalMinutes % 60
-
-	if h == 0 {
-		return fmt.Sprintf("%dm", m)
-	}
-	if m == 0 {
-		return fmt.Sprintf("%dh", h)
-	}
-	return fmt.Sprintf("%dh %dm", h, m)
-}
-
 // directFlightToStops converts the direct_flight boolean to stops count.
 // If direct_flight is true, returns 0.
 // If direct_flight is false, returns the actual number of stops or 1 if unknown.
@@ -152,24 +133,6 @@ func directFlightToStops(isDirect bool, stops []AirAsiaStop) int {
 	return 1
 }
 
-// parseDateTime parses an ISO 8601 datetime string to time.Time.
-// Supports formats with timezone offset (e.g., "2025-12-15T06:00:00+07:00").
-func parseDateTime(datetime string) (time.Time, error) {
-	// Try standard RFC3339 format first
-	t, err := time.Parse(time.RFC3339, datetime)
-	if err == nil {
-		return t, nil
-	}
-
-	// Try without colon in timezone (e.g., +0700)
-	t, err = time.Parse("2006-01-02T15:04:05-0700", datetime)
-	if err == nil {
-		return t, nil
-	}
-
-	return time.Time{}, fmt.Errorf("unable to parse datetime: %s", datetime)
-}
-
 // parseBaggageNote extracts baggage weights from a baggage note string.
 // AirAsia typically provides a note like "Cabin baggage only, checked bags additional fee"
 // which means only cabin baggage is included (default 7kg), no checked baggage.
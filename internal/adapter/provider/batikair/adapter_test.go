@@ -2,11 +2,13 @@ package batikair
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
-	"time"
 
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/httpsource"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -83,10 +85,14 @@ func TestAdapter_Search(t *testing.T) {
 				assert.Equal(t, "1h 45m", f.Duration.Formatted)
 				assert.Equal(t, float64(1100000), f.Price.Amount)
 				assert.Equal(t, "IDR", f.Price.Currency)
+				assert.Equal(t, float64(980000), f.Price.BaseFare)
+				assert.Equal(t, float64(120000), f.Price.Taxes)
+				assert.Zero(t, f.Price.Fees)
 				assert.Equal(t, 7, f.Baggage.CabinKg)
 				assert.Equal(t, 20, f.Baggage.CheckedKg)
 				assert.Equal(t, "economy", f.Class)
 				assert.Equal(t, 0, f.Stops)
+				assert.Equal(t, 32, f.SeatsAvailable)
 				assert.Equal(t, "batik_air", f.Provider)
 			},
 		},
@@ -274,6 +280,24 @@ func TestAdapter_Search_FileNotFound(t *testing.T) {
 	require.True(t, ok, "Error should be ProviderError")
 	assert.Equal(t, ProviderName, providerErr.Provider)
 	assert.True(t, providerErr.Retryable, "File read errors should be retryable")
+	assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+}
+
+// TestAdapter_Search_ResponseTooLarge tests that oversized responses are rejected.
+func TestAdapter_Search_ResponseTooLarge(t *testing.T) {
+	mockPath := "../../../../docs/response-mock/batik_air_search_response.json"
+
+	flights, err := NewAdapter(mockPath, WithMaxResponseBytes(1024)).Search(context.Background(), domain.SearchCriteria{})
+	require.Error(t, err)
+	assert.Empty(t, flights)
+
+	var providerErr *domain.ProviderError
+	require.ErrorAs(t, err, &providerErr)
+	assert.False(t, providerErr.Retryable, "oversized responses should not be retried")
+	assert.ErrorIs(t, err, domain.ErrResponseTooLarge)
+
+	_, err = NewAdapter(mockPath, WithMaxResponseBytes(1<<20)).Search(context.Background(), domain.SearchCriteria{})
+	assert.NoError(t, err)
 }
 
 // TestAdapter_Search_ContextCancellation tests context cancellation handling.
@@ -294,42 +318,6 @@ func TestAdapter_Search_ContextCancellation(t *testing.T) {
 	assert.False(t, providerErr.Retryable)
 }
 
-// TestParseDurationString tests duration string parsing.
-func TestParseDurationString(t *testing.T) {
-	tests := []struct {
-		name        string
-		input       string
-		wantMinutes int
-		wantErr     bool
-	}{
-		{"2h 15m", "2h 15m", 135, false},
-		{"1h 45m", "1h 45m", 105, false},
-		{"3h 5m", "3h 5m", 185, false},
-		{"1h only", "1h", 60, false},
-		{"1h 0m", "1h 0m", 60, false},
-		{"45m only", "45m", 45, false},
-		{"0h 30m", "0h 30m", 30, false},
-		{"with spaces", "  2h  15m  ", 135, false},
-		{"12h 30m", "12h 30m", 750, false},
-		{"0h 0m", "0h 0m", 0, false},
-		{"empty string", "", 0, true},
-		{"invalid format", "invalid", 0, true},
-		{"no duration", "abc", 0, true},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := parseDurationString(tt.input)
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				require.NoError(t, err)
-				assert.Equal(t, tt.wantMinutes, result)
-			}
-		})
-	}
-}
-
 // TestMapCabinClass tests cabin class code mapping.
 func TestMapCabinClass(t *testing.T) {
 	tests := []struct {
@@ -386,78 +374,6 @@ func TestParseBaggageInfo(t *testing.T) {
 	}
 }
 
-// TestParseDateTime tests datetime parsing.
-func TestParseDateTime(t *testing.T) {
-	tests := []struct {
-		name      string
-		input     string
-		wantErr   bool
-		checkTime func(*testing.T, time.Time)
-	}{
-		{
-			name:    "timezone without colon",
-			input:   "2025-12-15T07:15:00+0700",
-			wantErr: false,
-			checkTime: func(t *testing.T, tm time.Time) {
-				assert.Equal(t, 2025, tm.Year())
-				assert.Equal(t, time.December, tm.Month())
-				assert.Equal(t, 15, tm.Day())
-				assert.Equal(t, 7, tm.Hour())
-				assert.Equal(t, 15, tm.Minute())
-			},
-		},
-		{
-			name:    "RFC3339 format",
-			input:   "2025-12-15T10:00:00+08:00",
-			wantErr: false,
-			checkTime: func(t *testing.T, tm time.Time) {
-				assert.Equal(t, 10, tm.Hour())
-			},
-		},
-		{
-			name:    "Z timezone",
-			input:   "2025-12-15T12:00:00Z",
-			wantErr: false,
-			checkTime: func(t *testing.T, tm time.Time) {
-				assert.Equal(t, 12, tm.Hour())
-			},
-		},
-		{
-			name:    "without timezone",
-			input:   "2025-12-15T14:30:00",
-			wantErr: false,
-			checkTime: func(t *testing.T, tm time.Time) {
-				assert.Equal(t, 14, tm.Hour())
-				assert.Equal(t, 30, tm.Minute())
-			},
-		},
-		{
-			name:    "invalid format",
-			input:   "not-a-date",
-			wantErr: true,
-		},
-		{
-			name:    "empty string",
-			input:   "",
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := parseDateTime(tt.input)
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				require.NoError(t, err)
-				if tt.checkTime != nil {
-					tt.checkTime(t, result)
-				}
-			}
-		})
-	}
-}
-
 // TestNormalize_SkipsInvalidFlights tests that invalid flights are skipped.
 func TestNormalize_SkipsInvalidFlights(t *testing.T) {
 	flights := []BatikAirFlight{
@@ -521,3 +437,38 @@ func TestAdapter_Search_WithRealMockFile(t *testing.T) {
 		assert.Greater(t, f.Price.Amount, float64(0))
 	}
 }
+
+// TestAdapter_Search_HTTPSource tests that responses fetched over HTTP are
+// normalized like the mock data file.
+func TestAdapter_Search_HTTPSource(t *testing.T) {
+	mockPath := "../../../../docs/response-mock/batik_air_search_response.json"
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		http.ServeFile(w, r, mockPath)
+	}))
+	defer server.Close()
+
+	source, err := httpsource.New(httpsource.Config{BaseURL: server.URL, AuthValue: "Bearer secret"})
+	require.NoError(t, err)
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15"}
+
+	want, err := NewAdapter(mockPath).Search(context.Background(), criteria)
+	require.NoError(t, err)
+	got, err := NewAdapterWithSimulation("", WithHTTPSource(source)).Search(context.Background(), criteria)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	for code, retryable := range map[int]bool{http.StatusServiceUnavailable: true, http.StatusUnauthorized: false} {
+		status = code
+		_, err := NewAdapter("", WithHTTPSource(source)).Search(context.Background(), criteria)
+		var providerErr *domain.ProviderError
+		require.ErrorAs(t, err, &providerErr)
+		assert.Equal(t, retryable, providerErr.Retryable, "status %d", code)
+		assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+	}
+}
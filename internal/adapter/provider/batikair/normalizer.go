@@ -5,17 +5,15 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/durationfmt"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
 )
 
 // ProviderName is the unique identifier for the Batik Air provider.
 const ProviderName = "batik_air"
 
-// durationRegex matches duration strings like "2h 15m", "1h", "45m"
-var durationRegex = regexp.MustCompile(`(?:(\d+)h)?\s*(?:(\d+)m)?`)
-
 // normalize converts a slice of Batik Air flights to domain Flight entities.
 func normalize(batikAirFlights []BatikAirFlight) []domain.Flight {
 	result := make([]domain.Flight, 0, len(batikAirFlights))
@@ -54,21 +52,22 @@ func normalize(batikAirFlights []BatikAirFlight) []domain.Flight {
 }
 
 // normalizeFlight converts a single Batik Air flight to a domain Flight entity.
+// Batik Air reports no fare conditions, so the flight has no fare rules.
 func normalizeFlight(f BatikAirFlight) (domain.Flight, error) {
 	// Parse departure time
-	departureTime, err := parseDateTime(f.DepartureDateTime)
+	departureTime, err := timeutil.ParseDateTime(f.DepartureDateTime, timeutil.WithAirport(f.Origin))
 	if err != nil {
 		return domain.Flight{}, fmt.Errorf("failed to parse departure time: %w", err)
 	}
 
 	// Parse arrival time
-	arrivalTime, err := parseDateTime(f.ArrivalDateTime)
+	arrivalTime, err := timeutil.ParseDateTime(f.ArrivalDateTime, timeutil.WithAirport(f.Destination))
 	if err != nil {
 		return domain.Flight{}, fmt.Errorf("failed to parse arrival time: %w", err)
 	}
 
 	// Parse duration from travel time string
-	durationMinutes, err := parseDurationString(f.TravelTime)
+	durationMinutes, err := durationfmt.Parse(f.TravelTime)
 	if err != nil {
 		return domain.Flight{}, fmt.Errorf("failed to parse travel time: %w", err)
 	}
@@ -97,72 +96,25 @@ func normalizeFlight(f BatikAirFlight) (domain.Flight, error) {
 			AirportCode: f.Destination,
 			DateTime:    arrivalTime,
 		},
-		Duration: domain.DurationInfo{
-			TotalMinutes: durationMinutes,
-			Formatted:    f.TravelTime,
-		},
+		Duration: domain.NewDurationInfo(durationMinutes),
 		Price: domain.PriceInfo{
 			Amount:   totalPrice,
 			Currency: f.Fare.CurrencyCode,
-		},
+		}.WithBreakdown(f.Fare.BasePrice, f.Fare.Taxes),
 		Baggage: domain.BaggageInfo{
 			CabinKg:   cabinKg,
 			CheckedKg: checkedKg,
+			Note:      f.BaggageInfo,
 		},
-		Class:    mapCabinClass(f.Fare.Class),
-		Stops:    f.NumberOfStops,
-		Provider: ProviderName,
+		Aircraft:       f.AircraftModel,
+		Amenities:      f.OnboardServices,
+		Class:          mapCabinClass(f.Fare.Class),
+		Stops:          f.NumberOfStops,
+		Provider:       ProviderName,
+		SeatsAvailable: f.SeatsAvailable,
 	}, nil
 }
 
-// parseDateTime parses an ISO 8601 datetime string to time.Time.
-// Supports formats: "2006-01-02T15:04:05+0700" and "2006-01-02T15:04:05Z07:00"
-func parseDateTime(datetime string) (time.Time, error) {
-	// Try RFC3339 format first (with colon in timezone)
-	t, err := time.Parse(time.RFC3339, datetime)
-	if err == nil {
-		return t, nil
-	}
-
-	// Try without colon in timezone offset (e.g., +0700)
-	t, err = time.Parse("2006-01-02T15:04:05-0700", datetime)
-	if err == nil {
-		return t, nil
-	}
-
-	// Try without timezone
-	t, err = time.Parse("2006-01-02T15:04:05", datetime)
-	if err == nil {
-		return t, nil
-	}
-
-	return time.Time{}, fmt.Errorf("unable to parse datetime %q", datetime)
-}
-
-// parseDurationString parses a duration string like "2h 15m" to total minutes.
-// Handles formats: "2h 15m", "1h", "45m", "0h 30m"
-func parseDurationString(duration string) (int, error) {
-	duration = strings.TrimSpace(duration)
-	if duration == "" {
-		return 0, fmt.Errorf("empty duration string")
-	}
-
-	matches := durationRegex.FindStringSubmatch(duration)
-	if matches == nil || (matches[1] == "" && matches[2] == "") {
-		return 0, fmt.Errorf("invalid duration format: %s", duration)
-	}
-
-	var hours, minutes int
-	if matches[1] != "" {
-		hours, _ = strconv.Atoi(matches[1])
-	}
-	if matches[2] != "" {
-		minutes, _ = strconv.Atoi(matches[2])
-	}
-
-	return hours*60 + minutes, nil
-}
-
 // parseBaggageInfo extracts cabin and checked baggage weights from a string.
 // Example: "7kg cabin, 20kg checked" -> 7, 20
 func parseBaggageInfo(baggageInfo string) (cabinKg, checkedKg int) {
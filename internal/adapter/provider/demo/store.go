@@ -0,0 +1,258 @@
+// Package demo provides an in-memory flight store preloaded with provider mock data.
+//
+// The store indexes flights by route and departure date so searches complete in
+// well under a millisecond, without simulated latency or failures. It backs the
+// server's demo mode, offline tooling and end-to-end tests.
+package demo
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/airasia"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/batikair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/garuda"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/lionair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// dateLayout is the departure date format used for indexing.
+const dateLayout = "2006-01-02"
+
+// MockFiles maps provider names to their mock data file names.
+var MockFiles = map[string]string{
+	garuda.ProviderName:   "garuda_indonesia_search_response.json",
+	lionair.ProviderName:  "lion_air_search_response.json",
+	batikair.ProviderName: "batik_air_search_response.json",
+	airasia.ProviderName:  "airasia_search_response.json",
+}
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithDateShift makes searches for dates without data return the route's
+// flights shifted to the requested date, so demo data never goes stale.
+func WithDateShift() Option {
+	return func(s *Store) {
+		s.shiftDates = true
+	}
+}
+
+// Store is an in-memory flight store indexed by route and departure date.
+// It is safe for concurrent use.
+type Store struct {
+	shiftDates bool
+
+	mu        sync.RWMutex
+	providers []string
+	routes    map[string]map[string][]domain.Flight
+	count     int
+}
+
+// NewStore creates an empty store.
+func NewStore(opts ...Option) *Store {
+	s := &Store{
+		routes: make(map[string]map[string][]domain.Flight),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Load creates a store holding every flight returned by the given providers.
+// Each provider is queried once with empty criteria, which returns its full data set.
+func Load(ctx context.Context, sources []domain.FlightProvider, opts ...Option) (*Store, error) {
+	s := NewStore(opts...)
+	for _, p := range sources {
+		flights, err := p.Search(ctx, domain.SearchCriteria{})
+		if err != nil {
+			return nil, fmt.Errorf("load %s flights: %w", p.Name(), err)
+		}
+		s.register(p.Name())
+		s.Add(flights...)
+	}
+	return s, nil
+}
+
+// LoadMockDir creates a store from the provider mock files in dir
+// (e.g., "docs/response-mock").
+func LoadMockDir(ctx context.Context, dir string, opts ...Option) (*Store, error) {
+	sources := []domain.FlightProvider{
+		garuda.NewAdapter(filepath.Join(dir, MockFiles[garuda.ProviderName])),
+		lionair.NewAdapter(filepath.Join(dir, MockFiles[lionair.ProviderName])),
+		batikair.NewAdapter(filepath.Join(dir, MockFiles[batikair.ProviderName])),
+		airasia.NewAdapter(filepath.Join(dir, MockFiles[airasia.ProviderName])),
+	}
+	return Load(ctx, sources, opts...)
+}
+
+// Add indexes flights in the store.
+func (s *Store) Add(flights ...domain.Flight) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, f := range flights {
+		key := routeKey(f.Departure.AirportCode, f.Arrival.AirportCode)
+		dates, ok := s.routes[key]
+		if !ok {
+			dates = make(map[string][]domain.Flight)
+			s.routes[key] = dates
+		}
+		date := f.Departure.DateTime.Format(dateLayout)
+		dates[date] = append(dates[date], f)
+		s.count++
+	}
+}
+
+// Len returns the number of flights in the store.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.count
+}
+
+// Search returns the flights matching the criteria's route, date and class
+// across all providers. Origin and destination are required.
+func (s *Store) Search(criteria domain.SearchCriteria) []domain.Flight {
+	return s.search(criteria, "")
+}
+
+// Providers returns one domain.FlightProvider per loaded provider, each serving
+// that provider's flights from the store. Result attribution is therefore
+// identical to querying the real adapters.
+func (s *Store) Providers() []domain.FlightProvider {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]domain.FlightProvider, 0, len(s.providers))
+	for _, name := range s.providers {
+		result = append(result, &storeProvider{store: s, name: name})
+	}
+	return result
+}
+
+// Replace swaps the store's contents for those of other, e.g. freshly reloaded
+// mock data. Providers returned earlier by Providers serve the new data; other
+// is left unchanged and should not be used afterwards.
+func (s *Store) Replace(other *Store) {
+	other.mu.RLock()
+	providers := append([]string(nil), other.providers...)
+	routes := other.routes
+	count := other.count
+	other.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.providers = providers
+	s.routes = routes
+	s.count = count
+}
+
+// register records a provider name in load order.
+func (s *Store) register(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.providers = append(s.providers, name)
+}
+
+// search looks up flights for the criteria, optionally restricted to one provider.
+func (s *Store) search(criteria domain.SearchCriteria, provider string) []domain.Flight {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	dates := s.routes[routeKey(criteria.Origin, criteria.Destination)]
+	if len(dates) == 0 {
+		return []domain.Flight{}
+	}
+
+	flights, ok := dates[criteria.DepartureDate]
+	shiftDays := 0
+	if !ok && s.shiftDates {
+		var template string
+		template, flights = templateDate(dates)
+		shiftDays = daysBetween(template, criteria.DepartureDate)
+	}
+
+	result := make([]domain.Flight, 0, len(flights))
+	for _, f := range flights {
+		if provider != "" && f.Provider != provider {
+			continue
+		}
+		if criteria.Class != "" && f.Class != criteria.Class {
+			continue
+		}
+		if shiftDays != 0 {
+			f = shiftFlight(f, shiftDays)
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// templateDate returns the earliest indexed date of a route and its flights.
+func templateDate(dates map[string][]domain.Flight) (string, []domain.Flight) {
+	keys := make([]string, 0, len(dates))
+	for d := range dates {
+		keys = append(keys, d)
+	}
+	sort.Strings(keys)
+	return keys[0], dates[keys[0]]
+}
+
+// daysBetween returns the number of calendar days from one date to another.
+// Returns 0 if either date is malformed.
+func daysBetween(from, to string) int {
+	f, err := time.Parse(dateLayout, from)
+	if err != nil {
+		return 0
+	}
+	t, err := time.Parse(dateLayout, to)
+	if err != nil {
+		return 0
+	}
+	return int(t.Sub(f).Hours() / 24)
+}
+
+// shiftFlight moves a flight's departure and arrival by the given number of days,
+// keeping local times in their original time zones.
+func shiftFlight(f domain.Flight, days int) domain.Flight {
+	f.Departure.DateTime = f.Departure.DateTime.AddDate(0, 0, days)
+	f.Arrival.DateTime = f.Arrival.DateTime.AddDate(0, 0, days)
+	return f
+}
+
+// routeKey builds the index key for a route.
+func routeKey(origin, destination string) string {
+	return origin + "-" + destination
+}
+
+// storeProvider serves a single provider's flights from the store.
+type storeProvider struct {
+	store *Store
+	name  string
+}
+
+// Name returns the original provider name.
+func (p *storeProvider) Name() string {
+	return p.name
+}
+
+// Search returns the provider's flights matching the criteria.
+func (p *storeProvider) Search(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, &domain.ProviderError{
+			Provider:  p.name,
+			Err:       err,
+			Retryable: false,
+		}
+	}
+	return p.store.search(criteria, p.name), nil
+}
+
+// Ensure storeProvider implements FlightProvider at compile time.
+var _ domain.FlightProvider = (*storeProvider)(nil)
@@ -0,0 +1,139 @@
+package demo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+const mockDir = "../../../../docs/response-mock"
+
+// mockDataDate is the departure date used throughout the provider mock files.
+const mockDataDate = "2025-12-15"
+
+func loadStore(t *testing.T, opts ...Option) *Store {
+	t.Helper()
+	s, err := LoadMockDir(context.Background(), mockDir, opts...)
+	require.NoError(t, err)
+	return s
+}
+
+func TestLoadMockDir(t *testing.T) {
+	s := loadStore(t)
+
+	assert.Positive(t, s.Len())
+
+	names := make([]string, 0)
+	for _, p := range s.Providers() {
+		names = append(names, p.Name())
+	}
+	assert.ElementsMatch(t, []string{"garuda_indonesia", "lion_air", "batik_air", "airasia"}, names)
+}
+
+func TestLoad_ProviderError(t *testing.T) {
+	_, err := LoadMockDir(context.Background(), "does-not-exist")
+	require.Error(t, err)
+
+	var provErr *domain.ProviderError
+	assert.True(t, errors.As(err, &provErr))
+}
+
+func TestStore_Search(t *testing.T) {
+	s := loadStore(t)
+
+	flights := s.Search(domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: mockDataDate})
+	require.NotEmpty(t, flights)
+	for _, f := range flights {
+		assert.Equal(t, "CGK", f.Departure.AirportCode)
+		assert.Equal(t, "DPS", f.Arrival.AirportCode)
+		assert.Equal(t, mockDataDate, f.Departure.DateTime.Format(dateLayout))
+	}
+
+	t.Run("unknown route", func(t *testing.T) {
+		assert.Empty(t, s.Search(domain.SearchCriteria{Origin: "DPS", Destination: "SIN", DepartureDate: mockDataDate}))
+	})
+
+	t.Run("other date without shift", func(t *testing.T) {
+		assert.Empty(t, s.Search(domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2026-01-10"}))
+	})
+
+	t.Run("class filter", func(t *testing.T) {
+		all := s.Search(domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: mockDataDate})
+		business := s.Search(domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: mockDataDate, Class: "business"})
+		assert.Less(t, len(business), len(all))
+	})
+}
+
+func TestStore_DateShift(t *testing.T) {
+	s := loadStore(t, WithDateShift())
+
+	original := s.Search(domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: mockDataDate})
+	shifted := s.Search(domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2026-01-10"})
+	require.Len(t, shifted, len(original))
+
+	for i, f := range shifted {
+		assert.Equal(t, "2026-01-10", f.Departure.DateTime.Format(dateLayout))
+		assert.Equal(t, original[i].Departure.DateTime.Format("15:04 -0700"), f.Departure.DateTime.Format("15:04 -0700"),
+			"local departure time should be preserved")
+		assert.Equal(t, original[i].Arrival.DateTime.Sub(original[i].Departure.DateTime),
+			f.Arrival.DateTime.Sub(f.Departure.DateTime), "duration should be preserved")
+	}
+}
+
+func TestStore_Replace(t *testing.T) {
+	s := NewStore()
+	providers := s.Providers()
+	assert.Empty(t, providers)
+
+	fresh := loadStore(t)
+	s.Replace(fresh)
+
+	assert.Equal(t, fresh.Len(), s.Len())
+	assert.Len(t, s.Providers(), 4)
+	assert.NotEmpty(t, s.Search(domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: mockDataDate}))
+
+	s.Replace(NewStore())
+	assert.Zero(t, s.Len())
+	assert.Empty(t, s.Search(domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: mockDataDate}))
+}
+
+func TestStoreProvider_Search(t *testing.T) {
+	s := loadStore(t)
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: mockDataDate}
+
+	total := 0
+	for _, p := range s.Providers() {
+		flights, err := p.Search(context.Background(), criteria)
+		require.NoError(t, err)
+		for _, f := range flights {
+			assert.Equal(t, p.Name(), f.Provider)
+		}
+		total += len(flights)
+	}
+	assert.Equal(t, len(s.Search(criteria)), total)
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := s.Providers()[0].Search(ctx, criteria)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestStore_SearchIsFast(t *testing.T) {
+	s := loadStore(t, WithDateShift())
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2026-01-10"}
+
+	start := time.Now()
+	for i := 0; i < 1000; i++ {
+		s.Search(criteria)
+	}
+	assert.Less(t, time.Since(start)/1000, time.Millisecond)
+}
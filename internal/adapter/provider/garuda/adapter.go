@@ -2,40 +2,79 @@ package garuda
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"time"
 
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/httpsource"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/payload"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
 )
 
+//go:generate go run ../../../../cmd/genadapter -schema schema.json
+
 // Adapter implements the domain.FlightProvider interface for Garuda Indonesia.
-// It reads from mock JSON data and normalizes it to the unified Flight domain model.
+// It reads from mock JSON data, or from the airline's HTTP API when configured
+// with WithHTTPSource, and normalizes it to the unified Flight domain model.
 type Adapter struct {
 	// mockDataPath is the path to the mock JSON data file.
 	mockDataPath string
 	// skipSimulation disables delay simulation for deterministic testing.
 	skipSimulation bool
+	// maxResponseBytes caps the size of a decoded response (0 = payload.DefaultMaxBytes).
+	maxResponseBytes int64
+	// source fetches responses from the HTTP API instead of the mock data file (optional).
+	source *httpsource.Client
+}
+
+// Option configures an Adapter.
+type Option func(*Adapter)
+
+// WithMaxResponseBytes rejects responses larger than n bytes instead of the
+// payload.DefaultMaxBytes limit.
+func WithMaxResponseBytes(n int64) Option {
+	return func(a *Adapter) {
+		a.maxResponseBytes = n
+	}
+}
+
+// WithHTTPSource fetches responses from the airline's HTTP API through c
+// instead of reading the mock data file. Simulation is disabled: latency and
+// failures are the API's own.
+func WithHTTPSource(c *httpsource.Client) Option {
+	return func(a *Adapter) {
+		a.source = c
+		a.skipSimulation = true
+	}
 }
 
 // NewAdapter creates a new Garuda Indonesia adapter.
 // The mockDataPath parameter specifies the path to the mock JSON data file.
-func NewAdapter(mockDataPath string) *Adapter {
-	return &Adapter{
+func NewAdapter(mockDataPath string, opts ...Option) *Adapter {
+	a := &Adapter{
 		mockDataPath:   mockDataPath,
 		skipSimulation: true, // Default to skipping simulation for tests
 	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 // NewAdapterWithSimulation creates a new Garuda Indonesia adapter with real-world simulation enabled.
 // Use this for production to simulate realistic API behavior.
-func NewAdapterWithSimulation(mockDataPath string) *Adapter {
-	return &Adapter{
+func NewAdapterWithSimulation(mockDataPath string, opts ...Option) *Adapter {
+	a := &Adapter{
 		mockDataPath:   mockDataPath,
 		skipSimulation: false,
 	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 // Name returns the unique identifier for this provider.
@@ -45,10 +84,18 @@ func (a *Adapter) Name() string {
 }
 
 // Search queries the provider for available flights matching the criteria.
-// It reads from mock JSON data and returns normalized flight entities.
+// It reads the mock JSON data or queries the HTTP API, and returns normalized
+// flight entities.
 // Simulates real-world conditions: Fast response (50-100ms delay).
 // Implements domain.FlightProvider.
 func (a *Adapter) Search(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	return a.SearchWithOptions(ctx, criteria, domain.ProviderSearchOptions{})
+}
+
+// SearchWithOptions is Search, passing the filtering hints of opts to the
+// HTTP API when one is configured; the mock data is not narrowed.
+// Implements domain.FilteringProvider.
+func (a *Adapter) SearchWithOptions(ctx context.Context, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) ([]domain.Flight, error) {
 	// Only simulate if not in test mode
 	if !a.skipSimulation {
 		// Simulate network latency: 50-100ms
@@ -79,23 +126,19 @@ func (a *Adapter) Search(ctx context.Context, criteria domain.SearchCriteria) ([
 	default:
 	}
 
-	// Read mock data file
-	data, err := os.ReadFile(a.mockDataPath)
+	body, err := a.open(ctx, criteria, opts)
 	if err != nil {
-		return nil, &domain.ProviderError{
-			Provider:  ProviderName,
-			Err:       fmt.Errorf("failed to read mock data: %w", err),
-			Retryable: true, // File read errors might be temporary
-		}
+		return nil, err
 	}
+	defer body.Close()
 
-	// Parse JSON
+	// Decode JSON as a stream, bounded by the response size limit
 	var response GarudaResponse
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := payload.DecodeJSON(body, a.maxResponseBytes, &response); err != nil {
 		return nil, &domain.ProviderError{
 			Provider:  ProviderName,
-			Err:       fmt.Errorf("failed to parse JSON: %w", err),
-			Retryable: false, // Parse errors are not retryable
+			Err:       err,
+			Retryable: false, // Parse errors and oversized responses are not retryable
 		}
 	}
 
@@ -106,6 +149,7 @@ func (a *Adapter) Search(ctx context.Context, criteria domain.SearchCriteria) ([
 
 	// Normalize flights to domain model
 	flights := normalize(response.Flights)
+	domain.ReportNormalization(ctx, len(response.Flights), len(response.Flights)-len(flights))
 
 	// Filter flights based on criteria (origin, destination, date)
 	filtered := filterFlights(flights, criteria)
@@ -113,6 +157,32 @@ func (a *Adapter) Search(ctx context.Context, criteria domain.SearchCriteria) ([
 	return filtered, nil
 }
 
+// open returns the search response: from the HTTP API when one is
+// configured, else from the mock data file.
+func (a *Adapter) open(ctx context.Context, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) (io.ReadCloser, error) {
+	if a.source != nil {
+		body, err := a.source.OpenWithOptions(ctx, criteria, opts)
+		if err != nil {
+			return nil, &domain.ProviderError{
+				Provider:  ProviderName,
+				Err:       err,
+				Retryable: ctx.Err() == nil && httpsource.Retryable(err),
+			}
+		}
+		return body, nil
+	}
+
+	file, err := os.Open(a.mockDataPath)
+	if err != nil {
+		return nil, &domain.ProviderError{
+			Provider:  ProviderName,
+			Err:       fmt.Errorf("%w: failed to read mock data: %w", domain.ErrProviderUnavailable, err),
+			Retryable: true, // File read errors might be temporary
+		}
+	}
+	return file, nil
+}
+
 // filterFlights filters normalized flights based on the search criteria.
 func filterFlights(flights []domain.Flight, criteria domain.SearchCriteria) []domain.Flight {
 	result := make([]domain.Flight, 0, len(flights))
@@ -128,9 +198,9 @@ func filterFlights(flights []domain.Flight, criteria domain.SearchCriteria) []do
 			continue
 		}
 
-		// Filter by departure date if specified
+		// Filter by departure date if specified, in the departure airport's timezone
 		if criteria.DepartureDate != "" {
-			flightDate := f.Departure.DateTime.Format("2006-01-02")
+			flightDate := departureDate(f)
 			if flightDate != criteria.DepartureDate {
 				continue
 			}
@@ -147,5 +217,12 @@ func filterFlights(flights []domain.Flight, criteria domain.SearchCriteria) []do
 	return result
 }
 
-// Ensure Adapter implements FlightProvider at compile time.
-var _ domain.FlightProvider = (*Adapter)(nil)
+// departureDate returns the local date a flight departs on: the date at its
+// departure airport, whatever timezone the provider gave the time in.
+func departureDate(f domain.Flight) string {
+	return timeutil.LocalDate(f.Departure.DateTime,
+		timeutil.WithAirport(f.Departure.AirportCode), timeutil.WithTimezone(f.Departure.Timezone))
+}
+
+// Ensure Adapter implements FilteringProvider at compile time.
+var _ domain.FilteringProvider = (*Adapter)(nil)
@@ -2,11 +2,14 @@ package garuda
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/httpsource"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -88,6 +91,7 @@ func TestAdapter_Search(t *testing.T) {
 				assert.Equal(t, "Garuda Indonesia", f.Airline.Name)
 				assert.Equal(t, "CGK", f.Departure.AirportCode)
 				assert.Equal(t, "3", f.Departure.Terminal)
+				assert.Equal(t, "Jakarta", f.Departure.City)
 				assert.Equal(t, "DPS", f.Arrival.AirportCode)
 				assert.Equal(t, 110, f.Duration.TotalMinutes)
 				assert.Equal(t, "1h 50m", f.Duration.Formatted)
@@ -97,6 +101,7 @@ func TestAdapter_Search(t *testing.T) {
 				assert.Equal(t, 40, f.Baggage.CheckedKg)
 				assert.Equal(t, "economy", f.Class)
 				assert.Equal(t, 0, f.Stops)
+				assert.Equal(t, 28, f.SeatsAvailable)
 				assert.Equal(t, "garuda_indonesia", f.Provider)
 			},
 		},
@@ -256,6 +261,24 @@ func TestAdapter_Search_FileNotFound(t *testing.T) {
 	require.True(t, ok, "Error should be ProviderError")
 	assert.Equal(t, ProviderName, providerErr.Provider)
 	assert.True(t, providerErr.Retryable, "File read errors should be retryable")
+	assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+}
+
+// TestAdapter_Search_ResponseTooLarge tests that oversized responses are rejected.
+func TestAdapter_Search_ResponseTooLarge(t *testing.T) {
+	mockPath := "../../../../docs/response-mock/garuda_indonesia_search_response.json"
+
+	flights, err := NewAdapter(mockPath, WithMaxResponseBytes(1024)).Search(context.Background(), domain.SearchCriteria{})
+	require.Error(t, err)
+	assert.Empty(t, flights)
+
+	var providerErr *domain.ProviderError
+	require.ErrorAs(t, err, &providerErr)
+	assert.False(t, providerErr.Retryable, "oversized responses should not be retried")
+	assert.ErrorIs(t, err, domain.ErrResponseTooLarge)
+
+	_, err = NewAdapter(mockPath, WithMaxResponseBytes(1<<20)).Search(context.Background(), domain.SearchCriteria{})
+	assert.NoError(t, err)
 }
 
 // TestAdapter_Search_ContextCancellation tests context cancellation handling.
@@ -308,70 +331,6 @@ func TestNormalizeClass(t *testing.T) {
 	}
 }
 
-// TestParseDateTime tests datetime parsing.
-func TestParseDateTime(t *testing.T) {
-	tests := []struct {
-		name      string
-		input     string
-		wantErr   bool
-		checkTime func(*testing.T, time.Time)
-	}{
-		{
-			name:    "RFC3339 with positive timezone",
-			input:   "2025-12-15T06:00:00+07:00",
-			wantErr: false,
-			checkTime: func(t *testing.T, tm time.Time) {
-				assert.Equal(t, 2025, tm.Year())
-				assert.Equal(t, time.December, tm.Month())
-				assert.Equal(t, 15, tm.Day())
-				assert.Equal(t, 6, tm.Hour())
-				assert.Equal(t, 0, tm.Minute())
-			},
-		},
-		{
-			name:    "RFC3339 with Z timezone",
-			input:   "2025-12-15T10:30:00Z",
-			wantErr: false,
-			checkTime: func(t *testing.T, tm time.Time) {
-				assert.Equal(t, 10, tm.Hour())
-				assert.Equal(t, 30, tm.Minute())
-			},
-		},
-		{
-			name:    "datetime without timezone",
-			input:   "2025-12-15T14:00:00",
-			wantErr: false,
-			checkTime: func(t *testing.T, tm time.Time) {
-				assert.Equal(t, 14, tm.Hour())
-			},
-		},
-		{
-			name:    "invalid datetime format",
-			input:   "not-a-date",
-			wantErr: true,
-		},
-		{
-			name:    "empty string",
-			input:   "",
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := parseDateTime(tt.input)
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				require.NoError(t, err)
-				if tt.checkTime != nil {
-					tt.checkTime(t, result)
-				}
-			}
-		})
-	}
-}
-
 // TestFormatAirportName tests airport name formatting.
 func TestFormatAirportName(t *testing.T) {
 	tests := []struct {
@@ -426,7 +385,97 @@ func TestNormalize_SkipsInvalidFlights(t *testing.T) {
 	assert.Equal(t, "GA400", result[0].ID)
 }
 
+// TestNormalize_FareFamilies tests that fares of a branded fare family are
+// returned as flights of their own.
+func TestNormalize_FareFamilies(t *testing.T) {
+	lite := GarudaFlight{
+		FlightID:        "GA400",
+		Airline:         "Garuda Indonesia",
+		AirlineCode:     "GA",
+		Departure:       GarudaEndpoint{Airport: "CGK", City: "Jakarta", Time: "2025-12-15T06:00:00+07:00"},
+		Arrival:         GarudaEndpoint{Airport: "DPS", City: "Denpasar", Time: "2025-12-15T08:50:00+08:00"},
+		DurationMinutes: 110,
+		Price:           GarudaPrice{Amount: 1050000, Currency: "IDR"},
+		FareClass:       "economy",
+		FareFamily:      &GarudaFareFamily{Name: "Lite"},
+		Baggage:         GarudaBaggage{CarryOn: 1},
+	}
+	flex := lite
+	flex.Price.Amount = 1650000
+	changeFee := 150000.0
+	flex.FareFamily = &GarudaFareFamily{Name: "Flex", Changeable: true, Refundable: true, ChangeFee: &changeFee}
+	flex.Baggage.Checked = 2
+
+	result := normalize([]GarudaFlight{lite, flex})
+
+	require.Len(t, result, 2)
+	assert.Equal(t, "GA400-lite", result[0].ID)
+	assert.Equal(t, &domain.FareFamily{Name: "Lite"}, result[0].FareFamily)
+	assert.Equal(t, &domain.FareRules{}, result[0].FareRules)
+	assert.Equal(t, "GA400-flex", result[1].ID)
+	assert.Equal(t, "GA400", result[1].FlightNumber)
+	assert.Equal(t, &domain.FareFamily{Name: "Flex", Changeable: true, Refundable: true}, result[1].FareFamily)
+	assert.Equal(t, &domain.FareRules{
+		Refundable: true,
+		Changeable: true,
+		ChangeFee:  &domain.PriceInfo{Amount: 150000, Currency: "IDR"},
+	}, result[1].FareRules)
+	assert.Equal(t, 40, result[1].Baggage.CheckedKg)
+}
+
 // TestAdapter_Search_WithRealMockFile tests with the actual mock file.
+// TestNormalize_NaiveTimesUseAirportTimezone tests that datetimes without offset
+// are interpreted in the timezone of their airport.
+func TestNormalize_NaiveTimesUseAirportTimezone(t *testing.T) {
+	flights := []GarudaFlight{
+		{
+			FlightID:        "GA400",
+			Airline:         "Garuda Indonesia",
+			AirlineCode:     "GA",
+			Departure:       GarudaEndpoint{Airport: "CGK", City: "Jakarta", Time: "2025-12-15T06:00:00"},
+			Arrival:         GarudaEndpoint{Airport: "DPS", City: "Denpasar", Time: "2025-12-15T08:50:00"},
+			DurationMinutes: 110,
+			Price:           GarudaPrice{Amount: 1250000, Currency: "IDR"},
+			FareClass:       "economy",
+			Baggage:         GarudaBaggage{CarryOn: 1, Checked: 2},
+		},
+	}
+
+	result := normalize(flights)
+
+	require.Len(t, result, 1)
+	assert.Equal(t, "2025-12-14T23:00:00Z", result[0].Departure.DateTime.UTC().Format(time.RFC3339))
+	assert.Equal(t, "2025-12-15T00:50:00Z", result[0].Arrival.DateTime.UTC().Format(time.RFC3339))
+}
+
+func TestFilterFlights_DepartureDateAtMidnight(t *testing.T) {
+	flight := func(id, departure string) GarudaFlight {
+		return GarudaFlight{
+			FlightID: id, Airline: "Garuda Indonesia", AirlineCode: "GA",
+			Departure:       GarudaEndpoint{Airport: "CGK", City: "Jakarta", Time: departure},
+			Arrival:         GarudaEndpoint{Airport: "DPS", City: "Denpasar", Time: "2025-12-16T12:00:00+08:00"},
+			DurationMinutes: 110,
+			Price:           GarudaPrice{Amount: 1250000, Currency: "IDR"},
+			FareClass:       "economy",
+		}
+	}
+	flights := normalize([]GarudaFlight{
+		flight("GA401", "2025-12-14T17:30:00Z"),      // 00:30 in Jakarta on Dec 15
+		flight("GA402", "1765733400000"),             // 00:30 in Jakarta on Dec 15, as epoch millis
+		flight("GA403", "2025-12-15T00:30:00+07:00"), // 00:30 in Jakarta on Dec 15
+		flight("GA404", "2025-12-14T16:59:00Z"),      // 23:59 in Jakarta on Dec 14
+		flight("GA405", "2025-12-15T23:59:00"),       // 23:59 in Jakarta on Dec 15
+		flight("GA406", "2025-12-15T17:00:00Z"),      // 00:00 in Jakarta on Dec 16
+	})
+	require.Len(t, flights, 6)
+
+	var ids []string
+	for _, f := range filterFlights(flights, domain.SearchCriteria{DepartureDate: "2025-12-15"}) {
+		ids = append(ids, f.ID)
+	}
+	assert.Equal(t, []string{"GA401", "GA402", "GA403", "GA405"}, ids)
+}
+
 func TestAdapter_Search_WithRealMockFile(t *testing.T) {
 	// Path to the actual mock file
 	mockPath := "../../../../docs/response-mock/garuda_indonesia_search_response.json"
@@ -457,3 +506,38 @@ func TestAdapter_Search_WithRealMockFile(t *testing.T) {
 		assert.Greater(t, f.Price.Amount, float64(0))
 	}
 }
+
+// TestAdapter_Search_HTTPSource tests that responses fetched over HTTP are
+// normalized like the mock data file.
+func TestAdapter_Search_HTTPSource(t *testing.T) {
+	mockPath := "../../../../docs/response-mock/garuda_indonesia_search_response.json"
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		http.ServeFile(w, r, mockPath)
+	}))
+	defer server.Close()
+
+	source, err := httpsource.New(httpsource.Config{BaseURL: server.URL, AuthValue: "Bearer secret"})
+	require.NoError(t, err)
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15"}
+
+	want, err := NewAdapter(mockPath).Search(context.Background(), criteria)
+	require.NoError(t, err)
+	got, err := NewAdapterWithSimulation("", WithHTTPSource(source)).Search(context.Background(), criteria)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	for code, retryable := range map[int]bool{http.StatusServiceUnavailable: true, http.StatusUnauthorized: false} {
+		status = code
+		_, err := NewAdapter("", WithHTTPSource(source)).Search(context.Background(), criteria)
+		var providerErr *domain.ProviderError
+		require.ErrorAs(t, err, &providerErr)
+		assert.Equal(t, retryable, providerErr.Retryable, "status %d", code)
+		assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+	}
+}
@@ -3,9 +3,9 @@ package garuda
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
 )
 
 // ProviderName is the unique identifier for the Garuda Indonesia provider.
@@ -57,13 +57,13 @@ func normalize(garudaFlights []GarudaFlight) []domain.Flight {
 // normalizeFlight converts a single Garuda flight to a domain Flight entity.
 func normalizeFlight(f GarudaFlight) (domain.Flight, error) {
 	// Parse departure time
-	departureTime, err := parseDateTime(f.Departure.Time)
+	departureTime, err := timeutil.ParseDateTime(f.Departure.Time, timeutil.WithAirport(f.Departure.Airport))
 	if err != nil {
 		return domain.Flight{}, fmt.Errorf("failed to parse departure time: %w", err)
 	}
 
 	// Parse arrival time
-	arrivalTime, err := parseDateTime(f.Arrival.Time)
+	arrivalTime, err := timeutil.ParseDateTime(f.Arrival.Time, timeutil.WithAirport(f.Arrival.Airport))
 	if err != nil {
 		return domain.Flight{}, fmt.Errorf("failed to parse arrival time: %w", err)
 	}
@@ -74,7 +74,7 @@ func normalizeFlight(f GarudaFlight) (domain.Flight, error) {
 		stops = len(f.Segments) - 1
 	}
 
-	return domain.Flight{
+	flight := domain.Flight{
 		ID:           f.FlightID,
 		FlightNumber: f.FlightID, // Use flight_id as flight number since it contains the flight identifier
 		Airline: domain.AirlineInfo{
@@ -84,12 +84,14 @@ func normalizeFlight(f GarudaFlight) (domain.Flight, error) {
 		Departure: domain.FlightPoint{
 			AirportCode: f.Departure.Airport,
 			AirportName: formatAirportName(f.Departure.Airport, f.Departure.City),
+			City:        f.Departure.City,
 			Terminal:    f.Departure.Terminal,
 			DateTime:    departureTime,
 		},
 		Arrival: domain.FlightPoint{
 			AirportCode: f.Arrival.Airport,
 			AirportName: formatAirportName(f.Arrival.Airport, f.Arrival.City),
+			City:        f.Arrival.City,
 			Terminal:    f.Arrival.Terminal,
 			DateTime:    arrivalTime,
 		},
@@ -102,28 +104,32 @@ func normalizeFlight(f GarudaFlight) (domain.Flight, error) {
 			CabinKg:   f.Baggage.CarryOn * DefaultCabinBaggageKg,
 			CheckedKg: f.Baggage.Checked * DefaultCheckedBaggageKg,
 		},
-		Class:    normalizeClass(f.FareClass),
-		Stops:    stops,
-		Provider: ProviderName,
-	}, nil
-}
-
-// parseDateTime parses an ISO 8601 datetime string to time.Time.
-// Supports formats: "2006-01-02T15:04:05Z07:00" and "2006-01-02T15:04:05"
-func parseDateTime(dateTime string) (time.Time, error) {
-	// Try RFC3339 format first (with timezone)
-	t, err := time.Parse(time.RFC3339, dateTime)
-	if err == nil {
-		return t, nil
+		Aircraft:       f.Aircraft,
+		Amenities:      f.Amenities,
+		Class:          normalizeClass(f.FareClass),
+		Stops:          stops,
+		Provider:       ProviderName,
+		SeatsAvailable: f.AvailableSeats,
 	}
 
-	// Try without timezone
-	t, err = time.Parse("2006-01-02T15:04:05", dateTime)
-	if err == nil {
-		return t, nil
+	family := f.FareFamily
+	if family == nil {
+		return flight, nil
 	}
 
-	return time.Time{}, fmt.Errorf("unable to parse datetime %q", dateTime)
+	// The fare family carries the fare's conditions, even when unnamed
+	flight.FareRules = domain.NewFareRules(family.Refundable, family.Changeable, family.ChangeFee, f.Price.Currency)
+
+	// Fares of a branded fare family are returned as flights of their own
+	if name := strings.TrimSpace(family.Name); name != "" {
+		flight.ID = domain.FareFlightID(f.FlightID, name)
+		flight.FareFamily = &domain.FareFamily{
+			Name:       name,
+			Changeable: family.Changeable,
+			Refundable: family.Refundable,
+		}
+	}
+	return flight, nil
 }
 
 // formatAirportName creates a formatted airport name from code and city.
@@ -0,0 +1,146 @@
+// Package groupquote provides group quote providers for parties too large for
+// a regular search.
+//
+// The airlines' group desks have no API yet, so the providers are stubs
+// computing indicative fares from a per-airline base fare, the travel class
+// and a group discount. The follow-up contact is the airline's group desk.
+package groupquote
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/airasia"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/batikair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/garuda"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/lionair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// QuoteValidity is how long stub quotes are valid.
+const QuoteValidity = 48 * time.Hour
+
+// classMultipliers scale the economy base fare to the travel class.
+var classMultipliers = map[string]float64{
+	"economy":  1,
+	"business": 2.5,
+	"first":    4,
+}
+
+// Airline describes the group fares and group desk of an airline.
+type Airline struct {
+	// Provider is the name of the airline's flight provider
+	Provider string
+
+	// Airline is the airline's code and name
+	Airline domain.AirlineInfo
+
+	// BaseFare is the indicative economy fare per passenger
+	BaseFare domain.PriceInfo
+
+	// Contact is the airline's group desk
+	Contact domain.GroupContact
+}
+
+// DefaultAirlines are the airlines of the flight providers.
+var DefaultAirlines = []Airline{
+	{
+		Provider: garuda.ProviderName,
+		Airline:  domain.AirlineInfo{Code: "GA", Name: "Garuda Indonesia"},
+		BaseFare: domain.PriceInfo{Amount: 1500000, Currency: "IDR"},
+		Contact:  domain.GroupContact{Email: "groups@garuda-indonesia.example", Phone: "+62-21-2351-9999", ResponseHours: 24},
+	},
+	{
+		Provider: lionair.ProviderName,
+		Airline:  domain.AirlineInfo{Code: "JT", Name: "Lion Air"},
+		BaseFare: domain.PriceInfo{Amount: 850000, Currency: "IDR"},
+		Contact:  domain.GroupContact{Email: "groups@lionair.example", Phone: "+62-21-6379-8000", ResponseHours: 48},
+	},
+	{
+		Provider: batikair.ProviderName,
+		Airline:  domain.AirlineInfo{Code: "ID", Name: "Batik Air"},
+		BaseFare: domain.PriceInfo{Amount: 1100000, Currency: "IDR"},
+		Contact:  domain.GroupContact{Email: "groups@batikair.example", ResponseHours: 48},
+	},
+	{
+		Provider: airasia.ProviderName,
+		Airline:  domain.AirlineInfo{Code: "QZ", Name: "AirAsia"},
+		BaseFare: domain.PriceInfo{Amount: 750000, Currency: "IDR"},
+		Contact:  domain.GroupContact{Email: "groups@airasia.example", ResponseHours: 72},
+	},
+}
+
+// Stub is a domain.GroupQuoteProvider quoting indicative fares for one airline.
+type Stub struct {
+	airline Airline
+	clock   timeutil.Clock
+}
+
+// NewStub creates a Stub for the airline. A nil clock uses the system time.
+func NewStub(airline Airline, clock timeutil.Clock) *Stub {
+	if clock == nil {
+		clock = timeutil.NewRealClock()
+	}
+	return &Stub{airline: airline, clock: clock}
+}
+
+// NewStubs creates a Stub for each of the DefaultAirlines.
+func NewStubs(clock timeutil.Clock) []domain.GroupQuoteProvider {
+	stubs := make([]domain.GroupQuoteProvider, len(DefaultAirlines))
+	for i, a := range DefaultAirlines {
+		stubs[i] = NewStub(a, clock)
+	}
+	return stubs
+}
+
+// Name implements domain.GroupQuoteProvider.
+func (s *Stub) Name() string {
+	return s.airline.Provider
+}
+
+// Quote implements domain.GroupQuoteProvider. The fare per passenger is the
+// base fare scaled to the travel class, less the group discount.
+func (s *Stub) Quote(ctx context.Context, criteria domain.SearchCriteria) (*domain.GroupQuote, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	multiplier, ok := classMultipliers[criteria.Class]
+	if !ok {
+		multiplier = classMultipliers["economy"]
+	}
+	fare := s.airline.BaseFare.Amount * multiplier * (1 - Discount(criteria.Passengers))
+	currency := s.airline.BaseFare.Currency
+
+	return &domain.GroupQuote{
+		Provider: s.airline.Provider,
+		Airline:  s.airline.Airline,
+		Reference: fmt.Sprintf("%s-GRP-%s%s-%s-%d", s.airline.Airline.Code, criteria.Origin, criteria.Destination,
+			strings.ReplaceAll(criteria.DepartureDate, "-", ""), criteria.Passengers),
+		FarePerPassenger: domain.PriceInfo{Amount: fare, Currency: currency},
+		TotalFare:        domain.PriceInfo{Amount: fare * float64(criteria.Passengers), Currency: currency},
+		ValidUntil:       s.clock.Now().Add(QuoteValidity),
+		Contact:          s.airline.Contact,
+	}, nil
+}
+
+// Discount returns the group discount for the number of passengers, as a
+// fraction of the fare.
+func Discount(passengers int) float64 {
+	switch {
+	case passengers >= 50:
+		return 0.15
+	case passengers >= 20:
+		return 0.10
+	case passengers > domain.MaxPassengers:
+		return 0.05
+	default:
+		return 0
+	}
+}
+
+// Ensure Stub implements domain.GroupQuoteProvider at compile time.
+var _ domain.GroupQuoteProvider = (*Stub)(nil)
@@ -0,0 +1,53 @@
+package groupquote
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+func TestStub_Quote(t *testing.T) {
+	now := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	stub := NewStub(DefaultAirlines[0], timeutil.NewMockClock(now))
+
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 25, Class: "business"}
+	quote, err := stub.Quote(context.Background(), criteria)
+	require.NoError(t, err)
+
+	assert.Equal(t, "garuda_indonesia", quote.Provider)
+	assert.Equal(t, "GA-GRP-CGKDPS-20251215-25", quote.Reference)
+	assert.Equal(t, domain.PriceInfo{Amount: 3375000, Currency: "IDR"}, quote.FarePerPassenger, "1.5M x 2.5 less 10%")
+	assert.Equal(t, domain.PriceInfo{Amount: 84375000, Currency: "IDR"}, quote.TotalFare)
+	assert.Equal(t, now.Add(QuoteValidity), quote.ValidUntil)
+	assert.Equal(t, "groups@garuda-indonesia.example", quote.Contact.Email)
+}
+
+func TestStub_QuoteCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewStub(DefaultAirlines[0], nil).Quote(ctx, domain.SearchCriteria{Passengers: 10})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewStubs(t *testing.T) {
+	stubs := NewStubs(nil)
+	require.Len(t, stubs, len(DefaultAirlines))
+	for i, s := range stubs {
+		assert.Equal(t, DefaultAirlines[i].Provider, s.Name())
+	}
+}
+
+func TestDiscount(t *testing.T) {
+	assert.Zero(t, Discount(9))
+	assert.Equal(t, 0.05, Discount(10))
+	assert.Equal(t, 0.05, Discount(19))
+	assert.Equal(t, 0.10, Discount(20))
+	assert.Equal(t, 0.15, Discount(50))
+}
@@ -0,0 +1,206 @@
+// Package httpsource fetches provider search responses from the airlines'
+// HTTP APIs, so a provider adapter can switch from its mock data file to the
+// live API through configuration.
+//
+// A Client only transports the response: it sends the search request and
+// hands the response body back to the adapter, which decodes and normalizes
+// it exactly as it does its mock data. The request itself is built by a
+// RequestMapper, QueryRequest by default. Each Client has its own transport,
+// sized for a busy API (see TransportConfig), optionally with a DNS cache.
+package httpsource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/tracing"
+)
+
+// Default client settings.
+const (
+	DefaultAuthHeader = "Authorization"
+	DefaultTimeout    = 5 * time.Second
+)
+
+// ErrInvalidConfig indicates a Client configuration is not usable.
+var ErrInvalidConfig = errors.New("invalid HTTP source configuration")
+
+// Config configures the HTTP API of a provider.
+type Config struct {
+	// BaseURL is the provider's search endpoint, e.g. "https://api.example.com/v1/search"
+	BaseURL string
+
+	// AuthHeader is the header carrying AuthValue (empty = DefaultAuthHeader)
+	AuthHeader string
+
+	// AuthValue is sent in AuthHeader with every request, e.g. "Bearer abc"
+	// (empty = no authentication)
+	AuthValue string
+
+	// Timeout bounds each request, on top of the search's own deadline
+	// (0 = DefaultTimeout)
+	Timeout time.Duration
+
+	// Transport tunes the connection pool and DNS caching of the client
+	Transport TransportConfig
+}
+
+// RequestMapper builds the HTTP request searching baseURL for criteria,
+// narrowed by the filtering hints of opts the API supports.
+type RequestMapper func(ctx context.Context, baseURL string, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) (*http.Request, error)
+
+// Client fetches search responses from a provider's HTTP API.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+	mapRequest RequestMapper
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sends requests with c instead of a client built from the
+// Config timeout and transport settings.
+func WithHTTPClient(c *http.Client) Option {
+	return func(cl *Client) {
+		cl.httpClient = c
+	}
+}
+
+// WithRequestMapper builds requests with m instead of QueryRequest, for APIs
+// expecting the criteria in another shape.
+func WithRequestMapper(m RequestMapper) Option {
+	return func(cl *Client) {
+		cl.mapRequest = m
+	}
+}
+
+// New creates a Client for the API described by cfg. It returns an error
+// wrapping ErrInvalidConfig if the base URL is not an absolute HTTP(S) URL.
+func New(cfg Config, opts ...Option) (*Client, error) {
+	u, err := url.Parse(cfg.BaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: base URL %q must be an absolute http or https URL", ErrInvalidConfig, cfg.BaseURL)
+	}
+	if cfg.Timeout < 0 {
+		return nil, fmt.Errorf("%w: timeout must not be negative", ErrInvalidConfig)
+	}
+	if err := cfg.Transport.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	if cfg.AuthHeader == "" {
+		cfg.AuthHeader = DefaultAuthHeader
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	c := &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout, Transport: NewTransport(cfg.Transport)},
+		mapRequest: QueryRequest,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Open sends the search request for criteria and returns the body of the
+// successful response; the caller closes it. Failures wrap
+// domain.ErrProviderUnavailable, with a *StatusError for responses with a
+// non-2xx status; Retryable tells the transient ones apart. The trace
+// context carried by ctx is propagated to the provider.
+func (c *Client) Open(ctx context.Context, criteria domain.SearchCriteria) (io.ReadCloser, error) {
+	return c.OpenWithOptions(ctx, criteria, domain.ProviderSearchOptions{})
+}
+
+// OpenWithOptions is Open, passing the filtering hints of opts to the API.
+func (c *Client) OpenWithOptions(ctx context.Context, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) (io.ReadCloser, error) {
+	req, err := c.mapRequest(ctx, c.cfg.BaseURL, criteria, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: building request: %w", domain.ErrProviderUnavailable, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.AuthValue != "" {
+		req.Header.Set(c.cfg.AuthHeader, c.cfg.AuthValue)
+	}
+	tracing.InjectContext(ctx, req.Header)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrProviderUnavailable, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Drain a little of the body so the connection can be reused
+		_, _ = io.CopyN(io.Discard, resp.Body, 4<<10)
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %w", domain.ErrProviderUnavailable, &StatusError{StatusCode: resp.StatusCode})
+	}
+	return resp.Body, nil
+}
+
+// QueryRequest is the default RequestMapper: a GET of baseURL with the
+// criteria as query parameters (origin, destination, departure_date,
+// passengers and class, plus return_origin, return_destination and
+// return_date for round trips) and the hints of opts (max_price).
+// Parameters already in baseURL are kept.
+func QueryRequest(ctx context.Context, baseURL string, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) (*http.Request, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	setQuery(q, "origin", criteria.Origin)
+	setQuery(q, "destination", criteria.Destination)
+	setQuery(q, "departure_date", criteria.DepartureDate)
+	if criteria.Passengers > 0 {
+		q.Set("passengers", strconv.Itoa(criteria.Passengers))
+	}
+	setQuery(q, "class", criteria.Class)
+	if r := criteria.Return; r != nil {
+		setQuery(q, "return_origin", r.Origin)
+		setQuery(q, "return_destination", r.Destination)
+		setQuery(q, "return_date", r.DepartureDate)
+	}
+	if opts.MaxPrice > 0 {
+		q.Set("max_price", strconv.FormatFloat(opts.MaxPrice, 'f', -1, 64))
+	}
+	u.RawQuery = q.Encode()
+	return http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+}
+
+// setQuery sets a query parameter, skipping empty values.
+func setQuery(q url.Values, key, value string) {
+	if value != "" {
+		q.Set(key, value)
+	}
+}
+
+// StatusError reports a provider API response with a non-2xx status.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Retryable reports whether an Open error is transient: network failures,
+// request timeouts, rate limiting and server errors are; client errors are
+// not. Errors of a search whose context is done are never worth retrying,
+// which the caller checks.
+func Retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package httpsource
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/tracing"
+)
+
+func TestNew_InvalidConfig(t *testing.T) {
+	for _, cfg := range []Config{
+		{},
+		{BaseURL: "api.example.com/search"},
+		{BaseURL: "ftp://api.example.com/search"},
+		{BaseURL: "https://api.example.com/search", Timeout: -time.Second},
+	} {
+		_, err := New(cfg)
+		assert.ErrorIs(t, err, ErrInvalidConfig, "%+v", cfg)
+	}
+}
+
+func TestClient_Open(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v1/search", r.URL.Path)
+		assert.Equal(t, url.Values{
+			"key":                {"1"},
+			"origin":             {"CGK"},
+			"destination":        {"DPS"},
+			"departure_date":     {"2025-12-15"},
+			"passengers":         {"2"},
+			"class":              {"economy"},
+			"return_origin":      {"DPS"},
+			"return_destination": {"CGK"},
+			"return_date":        {"2025-12-20"},
+		}, r.URL.Query())
+		assert.Equal(t, "secret", r.Header.Get("X-API-Key"))
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		_, _ = io.WriteString(w, `{"flights":[]}`)
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL + "/v1/search?key=1", AuthHeader: "X-API-Key", AuthValue: "secret"})
+	require.NoError(t, err)
+
+	body, err := c.Open(context.Background(), domain.SearchCriteria{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: "2025-12-15",
+		Passengers:    2,
+		Class:         "economy",
+		Return:        &domain.ReturnLeg{Origin: "DPS", Destination: "CGK", DepartureDate: "2025-12-20"},
+	})
+	require.NoError(t, err)
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"flights":[]}`, string(data))
+}
+
+func TestClient_Open_PropagatesTraceContext(t *testing.T) {
+	traceparent := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent <- r.Header.Get(tracing.HeaderTraceparent)
+		_, _ = io.WriteString(w, `{"flights":[]}`)
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL})
+	require.NoError(t, err)
+
+	tc := tracing.TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Sampled: true, Format: tracing.FormatW3C}
+	body, err := c.Open(tracing.WithTraceContext(context.Background(), tc), domain.SearchCriteria{})
+	require.NoError(t, err)
+	body.Close()
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", <-traceparent)
+}
+
+func TestClient_OpenWithOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1500000.5", r.URL.Query().Get("max_price"))
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL})
+	require.NoError(t, err)
+
+	body, err := c.OpenWithOptions(context.Background(), domain.SearchCriteria{Origin: "CGK"}, domain.ProviderSearchOptions{MaxPrice: 1500000.5})
+	require.NoError(t, err)
+	body.Close()
+}
+
+func TestClient_Open_Errors(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL})
+	require.NoError(t, err)
+
+	tests := []struct {
+		status    int
+		retryable bool
+	}{
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+		{http.StatusBadRequest, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		status = tt.status
+		_, err := c.Open(context.Background(), domain.SearchCriteria{})
+		var statusErr *StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, tt.status, statusErr.StatusCode)
+		assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+		assert.Equal(t, tt.retryable, Retryable(err), "status %d", tt.status)
+	}
+
+	// Unreachable APIs are worth retrying
+	server.Close()
+	_, err = c.Open(context.Background(), domain.SearchCriteria{})
+	assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+	assert.True(t, Retryable(err))
+}
+
+func TestClient_Open_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c, err := New(Config{BaseURL: server.URL, Timeout: 20 * time.Millisecond})
+	require.NoError(t, err)
+
+	_, err = c.Open(context.Background(), domain.SearchCriteria{})
+	assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+	assert.True(t, Retryable(err), "timed out requests are retried while the search has time left")
+}
+
+func TestWithRequestMapper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		data, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "CGK-DPS", string(data))
+	}))
+	defer server.Close()
+
+	mapper := func(ctx context.Context, baseURL string, criteria domain.SearchCriteria, _ domain.ProviderSearchOptions) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodPost, baseURL,
+			strings.NewReader(criteria.Origin+"-"+criteria.Destination))
+	}
+	c, err := New(Config{BaseURL: server.URL}, WithRequestMapper(mapper))
+	require.NoError(t, err)
+
+	body, err := c.Open(context.Background(), domain.SearchCriteria{Origin: "CGK", Destination: "DPS"})
+	require.NoError(t, err)
+	body.Close()
+
+	failing := func(context.Context, string, domain.SearchCriteria, domain.ProviderSearchOptions) (*http.Request, error) {
+		return nil, errors.New("unsupported criteria")
+	}
+	c, err = New(Config{BaseURL: server.URL}, WithRequestMapper(failing))
+	require.NoError(t, err)
+	_, err = c.Open(context.Background(), domain.SearchCriteria{})
+	assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+}
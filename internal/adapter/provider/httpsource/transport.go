@@ -0,0 +1,194 @@
+package httpsource
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// Default transport settings. http.DefaultTransport keeps only 2 idle
+// connections per host, which caps the throughput of a busy provider API at
+// a couple of reused connections and a stream of new TLS handshakes.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 32
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultTLSSessionCacheSize = 64
+	DefaultDialTimeout         = 5 * time.Second
+)
+
+// TransportConfig tunes the connection pool of a provider's HTTP client.
+// Zero values select the defaults.
+type TransportConfig struct {
+	// MaxIdleConns caps the idle connections kept open (0 = DefaultMaxIdleConns)
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost caps the idle connections kept open per host
+	// (0 = DefaultMaxIdleConnsPerHost)
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost caps the connections per host, idle or not; requests
+	// beyond it wait for a connection (0 = no limit)
+	MaxConnsPerHost int
+
+	// IdleConnTimeout closes connections idle for longer (0 = DefaultIdleConnTimeout)
+	IdleConnTimeout time.Duration
+
+	// TLSSessionCacheSize is the number of TLS sessions kept for resumption,
+	// sparing full handshakes on new connections (0 = DefaultTLSSessionCacheSize)
+	TLSSessionCacheSize int
+
+	// DNSCacheTTL is how long resolved host addresses are reused (0 = every
+	// new connection resolves its host)
+	DNSCacheTTL time.Duration
+}
+
+// validate reports settings that cannot be used.
+func (t TransportConfig) validate() error {
+	if t.MaxIdleConns < 0 || t.MaxIdleConnsPerHost < 0 || t.MaxConnsPerHost < 0 || t.TLSSessionCacheSize < 0 {
+		return errors.New("connection limits and TLS session cache size must not be negative")
+	}
+	if t.IdleConnTimeout < 0 || t.DNSCacheTTL < 0 {
+		return errors.New("idle connection timeout and DNS cache TTL must not be negative")
+	}
+	return nil
+}
+
+// NewTransport returns an HTTP transport tuned by cfg.
+func NewTransport(cfg TransportConfig) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = orDefault(cfg.MaxIdleConns, DefaultMaxIdleConns)
+	transport.MaxIdleConnsPerHost = orDefault(cfg.MaxIdleConnsPerHost, DefaultMaxIdleConnsPerHost)
+	transport.MaxConnsPerHost = cfg.MaxConnsPerHost
+	transport.IdleConnTimeout = orDefault(cfg.IdleConnTimeout, DefaultIdleConnTimeout)
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ClientSessionCache: tls.NewLRUClientSessionCache(orDefault(cfg.TLSSessionCacheSize, DefaultTLSSessionCacheSize)),
+	}
+	if cfg.DNSCacheTTL > 0 {
+		transport.DialContext = NewDNSCache(cfg.DNSCacheTTL).DialContext
+	}
+	return transport
+}
+
+// orDefault returns v, or def if v is zero.
+func orDefault[T int | time.Duration](v, def T) T {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// Resolver looks up the addresses of a host. *net.Resolver implements it.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// dnsEntry is the resolved addresses of a host.
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+// DNSCache dials connections to hosts resolved at most once per TTL. It is
+// safe for concurrent use.
+type DNSCache struct {
+	ttl      time.Duration
+	resolver Resolver
+	dialer   *net.Dialer
+	clock    timeutil.Clock
+
+	mu      sync.Mutex
+	entries map[string]dnsEntry
+}
+
+// DNSCacheOption configures a DNSCache.
+type DNSCacheOption func(*DNSCache)
+
+// WithResolver resolves hosts with r instead of net.DefaultResolver.
+func WithResolver(r Resolver) DNSCacheOption {
+	return func(c *DNSCache) {
+		c.resolver = r
+	}
+}
+
+// WithDNSClock sets the clock expiring cached addresses.
+func WithDNSClock(clock timeutil.Clock) DNSCacheOption {
+	return func(c *DNSCache) {
+		c.clock = clock
+	}
+}
+
+// NewDNSCache creates a DNS cache keeping addresses for ttl.
+func NewDNSCache(ttl time.Duration, opts ...DNSCacheOption) *DNSCache {
+	c := &DNSCache{
+		ttl:      ttl,
+		resolver: net.DefaultResolver,
+		dialer:   &net.Dialer{Timeout: DefaultDialTimeout, KeepAlive: 30 * time.Second},
+		clock:    timeutil.NewRealClock(),
+		entries:  make(map[string]dnsEntry),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Lookup returns the addresses of host, resolving it if its cached addresses
+// are missing or expired. Failed lookups are not cached.
+func (c *DNSCache) Lookup(ctx context.Context, host string) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	c.mu.Unlock()
+	if ok && c.clock.Now().Before(entry.expires) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := c.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no addresses", Name: host, IsNotFound: true}
+	}
+
+	c.mu.Lock()
+	c.entries[host] = dnsEntry{addrs: addrs, expires: c.clock.Now().Add(c.ttl)}
+	c.mu.Unlock()
+	return addrs, nil
+}
+
+// DialContext dials addr, a host:port address, trying the cached addresses of
+// its host in turn. It can be used as http.Transport.DialContext.
+func (c *DNSCache) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if net.ParseIP(host) != nil {
+		return c.dialer.DialContext(ctx, network, addr)
+	}
+
+	addrs, err := c.Lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var dialErr error
+	for _, ip := range addrs {
+		conn, err := c.dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		dialErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, dialErr
+}
@@ -0,0 +1,116 @@
+package httpsource
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// stubResolver resolves hosts from a fixed table, counting lookups.
+type stubResolver struct {
+	mu      sync.Mutex
+	hosts   map[string][]string
+	lookups int
+}
+
+func (r *stubResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	addrs, ok := r.hosts[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return addrs, nil
+}
+
+func TestNewTransport(t *testing.T) {
+	transport := NewTransport(TransportConfig{})
+	assert.Equal(t, DefaultMaxIdleConns, transport.MaxIdleConns)
+	assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	assert.Zero(t, transport.MaxConnsPerHost)
+	assert.Equal(t, DefaultIdleConnTimeout, transport.IdleConnTimeout)
+	require.NotNil(t, transport.TLSClientConfig)
+	assert.NotNil(t, transport.TLSClientConfig.ClientSessionCache)
+
+	transport = NewTransport(TransportConfig{
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 5,
+		MaxConnsPerHost:     8,
+		IdleConnTimeout:     time.Minute,
+		DNSCacheTTL:         time.Minute,
+	})
+	assert.Equal(t, 10, transport.MaxIdleConns)
+	assert.Equal(t, 5, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 8, transport.MaxConnsPerHost)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+	assert.NotNil(t, transport.DialContext)
+
+	_, err := New(Config{BaseURL: "https://api.example.com/search", Transport: TransportConfig{MaxConnsPerHost: -1}})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestDNSCache_Lookup(t *testing.T) {
+	resolver := &stubResolver{hosts: map[string][]string{"api.lionair.example": {"10.0.0.1", "10.0.0.2"}}}
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	cache := NewDNSCache(30*time.Second, WithResolver(resolver), WithDNSClock(clock))
+
+	for range 3 {
+		addrs, err := cache.Lookup(context.Background(), "api.lionair.example")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, addrs)
+	}
+	assert.Equal(t, 1, resolver.lookups, "addresses are reused within the TTL")
+
+	clock.Advance(30 * time.Second)
+	_, err := cache.Lookup(context.Background(), "api.lionair.example")
+	require.NoError(t, err)
+	assert.Equal(t, 2, resolver.lookups, "expired addresses are resolved again")
+
+	for range 2 {
+		_, err = cache.Lookup(context.Background(), "unknown.example")
+		var dnsErr *net.DNSError
+		assert.True(t, errors.As(err, &dnsErr))
+	}
+	assert.Equal(t, 4, resolver.lookups, "failed lookups are not cached")
+}
+
+func TestDNSCache_Client(t *testing.T) {
+	var host string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, host, r.Host)
+		_, _ = io.WriteString(w, `{"flights":[]}`)
+	}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	host = net.JoinHostPort("api.garuda.example", port)
+	resolver := &stubResolver{hosts: map[string][]string{"api.garuda.example": {"127.0.0.1"}}}
+
+	transport := NewTransport(TransportConfig{})
+	transport.DialContext = NewDNSCache(time.Minute, WithResolver(resolver)).DialContext
+	c, err := New(Config{BaseURL: "http://" + host + "/search"}, WithHTTPClient(&http.Client{Transport: transport}))
+	require.NoError(t, err)
+
+	// New connections reuse the resolved address
+	for range 2 {
+		body, err := c.Open(context.Background(), domain.SearchCriteria{Origin: "CGK"})
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, body)
+		body.Close()
+		transport.CloseIdleConnections()
+	}
+	assert.Equal(t, 1, resolver.lookups)
+}
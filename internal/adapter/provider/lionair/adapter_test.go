@@ -2,11 +2,14 @@ package lionair
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/httpsource"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -83,6 +86,7 @@ func TestAdapter_Search(t *testing.T) {
 				assert.Equal(t, "Lion Air", f.Airline.Name)
 				assert.Equal(t, "CGK", f.Departure.AirportCode)
 				assert.Equal(t, "Soekarno-Hatta", f.Departure.AirportName)
+				assert.Equal(t, "Jakarta", f.Departure.City)
 				assert.Equal(t, "Asia/Jakarta", f.Departure.Timezone)
 				assert.Equal(t, "DPS", f.Arrival.AirportCode)
 				assert.Equal(t, "Asia/Makassar", f.Arrival.Timezone)
@@ -94,6 +98,7 @@ func TestAdapter_Search(t *testing.T) {
 				assert.Equal(t, 20, f.Baggage.CheckedKg)
 				assert.Equal(t, "economy", f.Class)
 				assert.Equal(t, 0, f.Stops)
+				assert.Equal(t, 45, f.SeatsAvailable)
 				assert.Equal(t, "lion_air", f.Provider)
 			},
 		},
@@ -264,6 +269,24 @@ func TestAdapter_Search_FileNotFound(t *testing.T) {
 	require.True(t, ok, "Error should be ProviderError")
 	assert.Equal(t, ProviderName, providerErr.Provider)
 	assert.True(t, providerErr.Retryable, "File read errors should be retryable")
+	assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+}
+
+// TestAdapter_Search_ResponseTooLarge tests that oversized responses are rejected.
+func TestAdapter_Search_ResponseTooLarge(t *testing.T) {
+	mockPath := "../../../../docs/response-mock/lion_air_search_response.json"
+
+	flights, err := NewAdapter(mockPath, WithMaxResponseBytes(1024)).Search(context.Background(), domain.SearchCriteria{})
+	require.Error(t, err)
+	assert.Empty(t, flights)
+
+	var providerErr *domain.ProviderError
+	require.ErrorAs(t, err, &providerErr)
+	assert.False(t, providerErr.Retryable, "oversized responses should not be retried")
+	assert.ErrorIs(t, err, domain.ErrResponseTooLarge)
+
+	_, err = NewAdapter(mockPath, WithMaxResponseBytes(1<<20)).Search(context.Background(), domain.SearchCriteria{})
+	assert.NoError(t, err)
 }
 
 // TestAdapter_Search_ContextCancellation tests context cancellation handling.
@@ -284,87 +307,6 @@ func TestAdapter_Search_ContextCancellation(t *testing.T) {
 	assert.False(t, providerErr.Retryable)
 }
 
-// TestParseDateTimeWithTimezone tests datetime parsing with various timezone formats.
-func TestParseDateTimeWithTimezone(t *testing.T) {
-	tests := []struct {
-		name      string
-		datetime  string
-		timezone  string
-		wantErr   bool
-		checkTime func(*testing.T, time.Time)
-	}{
-		{
-			name:     "valid datetime with Asia/Jakarta timezone",
-			datetime: "2025-12-15T05:30:00",
-			timezone: "Asia/Jakarta",
-			wantErr:  false,
-			checkTime: func(t *testing.T, tm time.Time) {
-				assert.Equal(t, 2025, tm.Year())
-				assert.Equal(t, time.December, tm.Month())
-				assert.Equal(t, 15, tm.Day())
-				assert.Equal(t, 5, tm.Hour())
-				assert.Equal(t, 30, tm.Minute())
-				assert.Equal(t, "Asia/Jakarta", tm.Location().String())
-			},
-		},
-		{
-			name:     "datetime with space separator",
-			datetime: "2025-12-15 10:00:00",
-			timezone: "Asia/Makassar",
-			wantErr:  false,
-			checkTime: func(t *testing.T, tm time.Time) {
-				assert.Equal(t, 10, tm.Hour())
-				assert.Equal(t, "Asia/Makassar", tm.Location().String())
-			},
-		},
-		{
-			name:     "invalid timezone falls back to UTC",
-			datetime: "2025-12-15T12:00:00",
-			timezone: "Invalid/Timezone",
-			wantErr:  false,
-			checkTime: func(t *testing.T, tm time.Time) {
-				assert.Equal(t, 12, tm.Hour())
-				assert.Equal(t, "UTC", tm.Location().String())
-			},
-		},
-		{
-			name:     "empty timezone falls back to UTC",
-			datetime: "2025-12-15T12:00:00",
-			timezone: "",
-			wantErr:  false,
-			checkTime: func(t *testing.T, tm time.Time) {
-				assert.Equal(t, "UTC", tm.Location().String())
-			},
-		},
-		{
-			name:     "invalid datetime format",
-			datetime: "not-a-date",
-			timezone: "Asia/Jakarta",
-			wantErr:  true,
-		},
-		{
-			name:     "empty datetime",
-			datetime: "",
-			timezone: "Asia/Jakarta",
-			wantErr:  true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := parseDateTimeWithTimezone(tt.datetime, tt.timezone)
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				require.NoError(t, err)
-				if tt.checkTime != nil {
-					tt.checkTime(t, result)
-				}
-			}
-		})
-	}
-}
-
 // TestParseBaggageWeight tests baggage weight parsing.
 func TestParseBaggageWeight(t *testing.T) {
 	tests := []struct {
@@ -469,6 +411,63 @@ func TestNormalize_SkipsInvalidFlights(t *testing.T) {
 	assert.Equal(t, "JT740", result[0].ID)
 }
 
+// TestNormalize_FareFamilies tests that fares of a branded fare family are
+// returned as flights of their own.
+func TestNormalize_FareFamilies(t *testing.T) {
+	flight := LionAirFlight{
+		ID:      "JT740",
+		Carrier: LionAirCarrier{Name: "Lion Air", IATA: "JT"},
+		Route: LionAirRoute{
+			From: LionAirAirport{Code: "CGK"},
+			To:   LionAirAirport{Code: "DPS"},
+		},
+		Schedule: LionAirSchedule{
+			Departure:         "2025-12-15T05:30:00",
+			DepartureTimezone: "Asia/Jakarta",
+			Arrival:           "2025-12-15T08:15:00",
+			ArrivalTimezone:   "Asia/Makassar",
+		},
+		FlightTime: 105,
+		IsDirect:   true,
+		Pricing:    LionAirPricing{Total: 950000, Currency: "IDR", FareType: "ECONOMY"},
+	}
+	flex := flight
+	flex.Pricing = LionAirPricing{Total: 1350000, Currency: "IDR", FareType: "ECONOMY", FareFamily: " Flex ", Changeable: true}
+	refundable := flight
+	refundable.Pricing = LionAirPricing{Total: 1500000, Currency: "IDR", FareType: "ECONOMY", Refundable: true}
+
+	result := normalize([]LionAirFlight{flight, flex, refundable})
+
+	require.Len(t, result, 3)
+	assert.Equal(t, "JT740", result[0].ID)
+	assert.Nil(t, result[0].FareFamily)
+	assert.Nil(t, result[0].FareRules, "no conditions reported")
+	assert.Equal(t, "JT740-flex", result[1].ID)
+	assert.Equal(t, "JT740", result[1].FlightNumber)
+	assert.Equal(t, &domain.FareFamily{Name: "Flex", Changeable: true}, result[1].FareFamily)
+	assert.Equal(t, &domain.FareRules{Changeable: true}, result[1].FareRules)
+	assert.Nil(t, result[2].FareFamily)
+	assert.Equal(t, &domain.FareRules{Refundable: true}, result[2].FareRules)
+}
+
+// TestFilterFlights_DepartureDateAtMidnight tests that flights are matched on
+// their departure date in the timezone of the departure airport, falling back
+// to the provider's timezone for airports missing from the reference data.
+func TestFilterFlights_DepartureDateAtMidnight(t *testing.T) {
+	// 00:15 on Dec 15 in Jakarta and in Singapore (UTC+8), still Dec 14 in UTC
+	flights := []domain.Flight{
+		{ID: "JT740", Departure: domain.FlightPoint{AirportCode: "CGK", DateTime: time.Date(2025, 12, 14, 17, 15, 0, 0, time.UTC)}},
+		{ID: "JT741", Departure: domain.FlightPoint{AirportCode: "XSP", Timezone: "Asia/Singapore", DateTime: time.Date(2025, 12, 14, 16, 15, 0, 0, time.UTC)}},
+		{ID: "JT742", Departure: domain.FlightPoint{AirportCode: "CGK", DateTime: time.Date(2025, 12, 15, 17, 15, 0, 0, time.UTC)}},
+	}
+
+	var ids []string
+	for _, f := range filterFlights(flights, domain.SearchCriteria{DepartureDate: "2025-12-15"}) {
+		ids = append(ids, f.ID)
+	}
+	assert.Equal(t, []string{"JT740", "JT741"}, ids)
+}
+
 // TestAdapter_Search_WithRealMockFile tests with the actual mock file.
 func TestAdapter_Search_WithRealMockFile(t *testing.T) {
 	mockPath := "../../../../docs/response-mock/lion_air_search_response.json"
@@ -522,3 +521,38 @@ func TestDurationFormatting(t *testing.T) {
 		})
 	}
 }
+
+// TestAdapter_Search_HTTPSource tests that responses fetched over HTTP are
+// normalized like the mock data file.
+func TestAdapter_Search_HTTPSource(t *testing.T) {
+	mockPath := "../../../../docs/response-mock/lion_air_search_response.json"
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		http.ServeFile(w, r, mockPath)
+	}))
+	defer server.Close()
+
+	source, err := httpsource.New(httpsource.Config{BaseURL: server.URL, AuthValue: "Bearer secret"})
+	require.NoError(t, err)
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15"}
+
+	want, err := NewAdapter(mockPath).Search(context.Background(), criteria)
+	require.NoError(t, err)
+	got, err := NewAdapterWithSimulation("", WithHTTPSource(source)).Search(context.Background(), criteria)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	for code, retryable := range map[int]bool{http.StatusServiceUnavailable: true, http.StatusUnauthorized: false} {
+		status = code
+		_, err := NewAdapter("", WithHTTPSource(source)).Search(context.Background(), criteria)
+		var providerErr *domain.ProviderError
+		require.ErrorAs(t, err, &providerErr)
+		assert.Equal(t, retryable, providerErr.Retryable, "status %d", code)
+		assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
 )
 
 // ProviderName is the unique identifier for the Lion Air provider.
@@ -52,13 +52,15 @@ func normalize(lionAirFlights []LionAirFlight) []domain.Flight {
 // normalizeFlight converts a single Lion Air flight to a domain Flight entity.
 func normalizeFlight(f LionAirFlight) (domain.Flight, error) {
 	// Parse departure time with timezone
-	departureTime, err := parseDateTimeWithTimezone(f.Schedule.Departure, f.Schedule.DepartureTimezone)
+	departureTime, err := timeutil.ParseDateTime(f.Schedule.Departure,
+		timeutil.WithTimezone(f.Schedule.DepartureTimezone), timeutil.WithAirport(f.Route.From.Code))
 	if err != nil {
 		return domain.Flight{}, fmt.Errorf("failed to parse departure time: %w", err)
 	}
 
 	// Parse arrival time with timezone
-	arrivalTime, err := parseDateTimeWithTimezone(f.Schedule.Arrival, f.Schedule.ArrivalTimezone)
+	arrivalTime, err := timeutil.ParseDateTime(f.Schedule.Arrival,
+		timeutil.WithTimezone(f.Schedule.ArrivalTimezone), timeutil.WithAirport(f.Route.To.Code))
 	if err != nil {
 		return domain.Flight{}, fmt.Errorf("failed to parse arrival time: %w", err)
 	}
@@ -76,7 +78,7 @@ func normalizeFlight(f LionAirFlight) (domain.Flight, error) {
 		}
 	}
 
-	return domain.Flight{
+	flight := domain.Flight{
 		ID:           f.ID,
 		FlightNumber: f.ID,
 		Airline: domain.AirlineInfo{
@@ -86,12 +88,14 @@ func normalizeFlight(f LionAirFlight) (domain.Flight, error) {
 		Departure: domain.FlightPoint{
 			AirportCode: f.Route.From.Code,
 			AirportName: f.Route.From.Name,
+			City:        f.Route.From.City,
 			DateTime:    departureTime,
 			Timezone:    f.Schedule.DepartureTimezone,
 		},
 		Arrival: domain.FlightPoint{
 			AirportCode: f.Route.To.Code,
 			AirportName: f.Route.To.Name,
+			City:        f.Route.To.City,
 			DateTime:    arrivalTime,
 			Timezone:    f.Schedule.ArrivalTimezone,
 		},
@@ -104,40 +108,30 @@ func normalizeFlight(f LionAirFlight) (domain.Flight, error) {
 			CabinKg:   cabinKg,
 			CheckedKg: checkedKg,
 		},
-		Class:    normalizeClass(f.Pricing.FareType),
-		Stops:    stops,
-		Provider: ProviderName,
-	}, nil
-}
-
-// parseDateTimeWithTimezone parses a datetime string with a separate timezone.
-// The datetime format is "2006-01-02T15:04:05" (ISO 8601 without offset).
-func parseDateTimeWithTimezone(datetime, timezone string) (time.Time, error) {
-	// Try parsing with T separator (ISO 8601 format)
-	layout := "2006-01-02T15:04:05"
-	t, err := time.Parse(layout, datetime)
-	if err != nil {
-		// Try with space separator as fallback
-		layout = "2006-01-02 15:04:05"
-		t, err = time.Parse(layout, datetime)
-		if err != nil {
-			return time.Time{}, fmt.Errorf("unable to parse datetime %q", datetime)
-		}
+		Aircraft:       f.PlaneType,
+		Class:          normalizeClass(f.Pricing.FareType),
+		Stops:          stops,
+		Provider:       ProviderName,
+		SeatsAvailable: f.SeatsLeft,
 	}
 
-	// Load the timezone location
-	loc, err := time.LoadLocation(timezone)
-	if err != nil {
-		// Fallback to UTC if timezone is invalid
-		return t.UTC(), nil
+	// Fare conditions are known for fares of a fare family or with any
+	// condition reported; a condition they omit is not allowed
+	p := f.Pricing
+	if strings.TrimSpace(p.FareFamily) != "" || p.Changeable || p.Refundable || p.ChangeFee != nil {
+		flight.FareRules = domain.NewFareRules(p.Refundable, p.Changeable, p.ChangeFee, p.Currency)
 	}
 
-	// Create time in the specified timezone
-	return time.Date(
-		t.Year(), t.Month(), t.Day(),
-		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(),
-		loc,
-	), nil
+	// Fares of a branded fare family are returned as flights of their own
+	if name := strings.TrimSpace(f.Pricing.FareFamily); name != "" {
+		flight.ID = domain.FareFlightID(f.ID, name)
+		flight.FareFamily = &domain.FareFamily{
+			Name:       name,
+			Changeable: f.Pricing.Changeable,
+			Refundable: f.Pricing.Refundable,
+		}
+	}
+	return flight, nil
 }
 
 // parseBaggageWeight extracts the weight in kg from a baggage string like "7 kg".
@@ -0,0 +1,55 @@
+// Package payload decodes provider responses with a bounded memory footprint.
+//
+// Responses are decoded as a stream straight from the source instead of being
+// read into memory first, and decoding stops as soon as a response exceeds
+// its size limit, so a misbehaving provider cannot exhaust the server's memory.
+package payload
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// DefaultMaxBytes is the default provider response size limit (10 MiB).
+const DefaultMaxBytes int64 = 10 << 20
+
+// DecodeJSON decodes the JSON value read from r into v. It reads at most
+// maxBytes (DefaultMaxBytes if not positive) and returns an error wrapping
+// domain.ErrResponseTooLarge for larger responses, or domain.ErrMalformedResponse
+// for responses that are not valid JSON for v.
+func DecodeJSON(r io.Reader, maxBytes int64, v any) error {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+
+	// Allow one byte over the limit to tell a response of exactly maxBytes
+	// from a larger one
+	counter := &countingReader{r: io.LimitReader(r, maxBytes+1)}
+	err := json.NewDecoder(counter).Decode(v)
+	if counter.n > maxBytes {
+		return fmt.Errorf("%w: exceeds %d bytes", domain.ErrResponseTooLarge, maxBytes)
+	}
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("%w: failed to parse JSON: %w", domain.ErrMalformedResponse, err)
+	}
+	return nil
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,45 @@
+package payload
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+type response struct {
+	Flights []string `json:"flights"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	body := `{"flights":["GA400","GA410"]}`
+
+	var got response
+	require.NoError(t, DecodeJSON(strings.NewReader(body), int64(len(body)), &got))
+	assert.Equal(t, []string{"GA400", "GA410"}, got.Flights)
+
+	err := DecodeJSON(strings.NewReader(body), int64(len(body))-1, &response{})
+	assert.ErrorIs(t, err, domain.ErrResponseTooLarge)
+	assert.NotErrorIs(t, err, domain.ErrMalformedResponse)
+}
+
+func TestDecodeJSON_DefaultLimit(t *testing.T) {
+	body := `{"flights":["` + strings.Repeat("x", int(DefaultMaxBytes)) + `"]}`
+	assert.ErrorIs(t, DecodeJSON(strings.NewReader(body), 0, &response{}), domain.ErrResponseTooLarge)
+}
+
+func TestDecodeJSON_Malformed(t *testing.T) {
+	for name, body := range map[string]string{
+		"empty":      "",
+		"truncated":  `{"flights":["GA400"`,
+		"wrong type": `{"flights":"GA400"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := DecodeJSON(strings.NewReader(body), 1024, &response{})
+			assert.ErrorIs(t, err, domain.ErrMalformedResponse)
+		})
+	}
+}
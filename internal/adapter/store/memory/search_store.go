@@ -0,0 +1,234 @@
+// Package memory provides in-memory implementations of the domain stores.
+// Data is lost on restart; stores are bounded so memory usage stays predictable.
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// DefaultSearchStoreCapacity is the number of records kept when no capacity is given.
+const DefaultSearchStoreCapacity = 1000
+
+// SearchStore is a bounded in-memory domain.SearchRecordStore.
+// When full, the oldest record is evicted. Evicted and compacted records are
+// rolled up into daily aggregates. It is safe for concurrent use.
+type SearchStore struct {
+	capacity int
+
+	mu         sync.RWMutex
+	records    map[string]domain.SearchRecord
+	order      []string
+	aggregates map[domain.AggregateKey]*domain.SearchAggregate
+}
+
+// NewSearchStore creates a store holding at most capacity records.
+// Values below 1 use DefaultSearchStoreCapacity.
+func NewSearchStore(capacity int) *SearchStore {
+	if capacity < 1 {
+		capacity = DefaultSearchStoreCapacity
+	}
+	return &SearchStore{
+		capacity:   capacity,
+		records:    make(map[string]domain.SearchRecord, capacity),
+		order:      make([]string, 0, capacity),
+		aggregates: make(map[domain.AggregateKey]*domain.SearchAggregate),
+	}
+}
+
+// Save stores a record, evicting the oldest record if the store is full.
+func (s *SearchStore) Save(ctx context.Context, record domain.SearchRecord) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.records[record.ID]; !exists {
+		if len(s.order) >= s.capacity {
+			oldest := s.order[0]
+			s.order = s.order[1:]
+			s.rollupLocked(s.records[oldest])
+			delete(s.records, oldest)
+		}
+		s.order = append(s.order, record.ID)
+	}
+	s.records[record.ID] = record
+	return nil
+}
+
+// Get returns the record with the given ID, or domain.ErrSearchNotFound.
+func (s *SearchStore) Get(ctx context.Context, id string) (*domain.SearchRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, ok := s.records[id]
+	if !ok {
+		return nil, domain.ErrSearchNotFound
+	}
+	return &record, nil
+}
+
+// ListByClient returns up to limit records of client, newest first.
+func (s *SearchStore) ListByClient(ctx context.Context, client string, limit int) ([]domain.SearchRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var records []domain.SearchRecord
+	for i := len(s.order) - 1; i >= 0 && len(records) < limit; i-- {
+		if record := s.records[s.order[i]]; record.Client == client {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// Len returns the number of stored records.
+func (s *SearchStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.records)
+}
+
+// Compact rolls records created before recordsBefore into daily aggregates
+// and removes them, then drops aggregates for days before aggregatesBefore.
+// A zero cutoff disables the corresponding step.
+func (s *SearchStore) Compact(ctx context.Context, recordsBefore, aggregatesBefore time.Time) (domain.CompactionStats, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.CompactionStats{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stats domain.CompactionStats
+	if !recordsBefore.IsZero() {
+		kept := s.order[:0]
+		for _, id := range s.order {
+			record := s.records[id]
+			if record.CreatedAt.Before(recordsBefore) {
+				s.rollupLocked(record)
+				delete(s.records, id)
+				stats.RecordsCompacted++
+				continue
+			}
+			kept = append(kept, id)
+		}
+		s.order = kept
+	}
+
+	if !aggregatesBefore.IsZero() {
+		cutoff := aggregatesBefore.UTC().Format("2006-01-02")
+		for key := range s.aggregates {
+			if key.Date < cutoff {
+				delete(s.aggregates, key)
+				stats.AggregatesExpired++
+			}
+		}
+	}
+	return stats, nil
+}
+
+// Aggregates returns the daily aggregates ordered by date, origin and destination.
+func (s *SearchStore) Aggregates() []domain.SearchAggregate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]domain.SearchAggregate, 0, len(s.aggregates))
+	for _, agg := range s.aggregates {
+		result = append(result, *agg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.Origin != b.Origin {
+			return a.Origin < b.Origin
+		}
+		return a.Destination < b.Destination
+	})
+	return result
+}
+
+// DailySummary returns the aggregates for the UTC day containing day,
+// combining rolled-up aggregates with records still held individually.
+// Results are ordered by origin and destination.
+func (s *SearchStore) DailySummary(ctx context.Context, day time.Time) ([]domain.SearchAggregate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	date := day.UTC().Format("2006-01-02")
+
+	s.mu.RLock()
+	summary := make(map[domain.AggregateKey]*domain.SearchAggregate)
+	for key, agg := range s.aggregates {
+		if key.Date == date {
+			merged := *agg
+			summary[key] = &merged
+		}
+	}
+	for _, record := range s.records {
+		key := domain.AggregateKeyFor(record)
+		if key.Date != date {
+			continue
+		}
+		agg, ok := summary[key]
+		if !ok {
+			agg = &domain.SearchAggregate{}
+			summary[key] = agg
+		}
+		agg.Add(record)
+	}
+	s.mu.RUnlock()
+
+	result := make([]domain.SearchAggregate, 0, len(summary))
+	for _, agg := range summary {
+		result = append(result, *agg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Origin != result[j].Origin {
+			return result[i].Origin < result[j].Origin
+		}
+		return result[i].Destination < result[j].Destination
+	})
+	return result, nil
+}
+
+// AggregateLen returns the number of daily aggregates.
+func (s *SearchStore) AggregateLen() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.aggregates)
+}
+
+// rollupLocked adds a record to its daily aggregate. The caller must hold s.mu.
+func (s *SearchStore) rollupLocked(record domain.SearchRecord) {
+	key := domain.AggregateKeyFor(record)
+	agg, ok := s.aggregates[key]
+	if !ok {
+		agg = &domain.SearchAggregate{}
+		s.aggregates[key] = agg
+	}
+	agg.Add(record)
+}
+
+// Ensure SearchStore implements the domain store interfaces at compile time.
+var (
+	_ domain.SearchRecordStore      = (*SearchStore)(nil)
+	_ domain.SearchRecordLister     = (*SearchStore)(nil)
+	_ domain.SearchHistoryCompactor = (*SearchStore)(nil)
+)
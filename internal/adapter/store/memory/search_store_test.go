@@ -0,0 +1,200 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+func TestSearchStore_SaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(10)
+
+	record := domain.SearchRecord{
+		ID:       "search-1",
+		Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"},
+	}
+	require.NoError(t, store.Save(ctx, record))
+
+	got, err := store.Get(ctx, "search-1")
+	require.NoError(t, err)
+	assert.Equal(t, "CGK", got.Criteria.Origin)
+
+	_, err = store.Get(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+}
+
+func TestSearchStore_EvictsOldest(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(2)
+
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: id}))
+	}
+
+	assert.Equal(t, 2, store.Len())
+	_, err := store.Get(ctx, "a")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+	_, err = store.Get(ctx, "c")
+	assert.NoError(t, err)
+}
+
+func TestSearchStore_ListByClient(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(3)
+	start := time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC)
+
+	for i, r := range []struct{ id, client string }{
+		{"evicted", "partner-app"}, {"a", "partner-app"}, {"b", "ops"}, {"c", "partner-app"},
+	} {
+		require.NoError(t, store.Save(ctx, domain.SearchRecord{
+			ID: r.id, Client: r.client, CreatedAt: start.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+
+	records, err := store.ListByClient(ctx, "partner-app", 10)
+	require.NoError(t, err)
+	require.Len(t, records, 2, "evicted searches are not listed")
+	assert.Equal(t, "c", records[0].ID, "newest first")
+	assert.Equal(t, "a", records[1].ID)
+
+	records, err = store.ListByClient(ctx, "partner-app", 1)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "c", records[0].ID)
+
+	records, err = store.ListByClient(ctx, "unknown", 10)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestSearchStore_ReplaceDoesNotEvict(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(2)
+
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "a"}))
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "b"}))
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "a", SortBy: domain.SortByPrice}))
+
+	assert.Equal(t, 2, store.Len())
+	got, err := store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, domain.SortByPrice, got.SortBy)
+}
+
+func TestSearchStore_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	store := NewSearchStore(0)
+	assert.ErrorIs(t, store.Save(ctx, domain.SearchRecord{ID: "a"}), context.Canceled)
+	_, err := store.Get(ctx, "a")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSearchStore_Compact(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(10)
+	day1 := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	flights := func(prices ...float64) domain.SearchResponse {
+		var resp domain.SearchResponse
+		for _, p := range prices {
+			resp.Flights = append(resp.Flights, domain.Flight{Price: domain.PriceInfo{Amount: p, Currency: "IDR"}})
+		}
+		return resp
+	}
+	cgkDps := domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}
+
+	records := []domain.SearchRecord{
+		{ID: "a", Criteria: cgkDps, CreatedAt: day1, Response: flights(900000, 1200000)},
+		{ID: "b", Criteria: cgkDps, CreatedAt: day1.Add(time.Hour), Response: flights(800000)},
+		{ID: "c", Criteria: cgkDps, CreatedAt: day1.Add(2 * time.Hour)},
+		{ID: "d", Criteria: domain.SearchCriteria{Origin: "SUB", Destination: "CGK"}, CreatedAt: day2, Response: flights(500000)},
+		{ID: "recent", Criteria: cgkDps, CreatedAt: day2.AddDate(0, 0, 90)},
+	}
+	for _, r := range records {
+		require.NoError(t, store.Save(ctx, r))
+	}
+
+	stats, err := store.Compact(ctx, day2.AddDate(0, 0, 30), time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.CompactionStats{RecordsCompacted: 4}, stats)
+	assert.Equal(t, 1, store.Len())
+	_, err = store.Get(ctx, "recent")
+	assert.NoError(t, err)
+	_, err = store.Get(ctx, "a")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+
+	aggregates := store.Aggregates()
+	require.Len(t, aggregates, 2)
+	assert.Equal(t, domain.SearchAggregate{
+		Date: "2025-09-01", Origin: "CGK", Destination: "DPS",
+		Searches: 3, EmptySearches: 1, TotalResults: 3,
+		MinPrice: 800000, MaxPrice: 1200000, Currency: "IDR",
+	}, aggregates[0])
+	assert.Equal(t, 1.0, aggregates[0].AverageResults())
+	assert.Equal(t, "2025-09-02", aggregates[1].Date)
+
+	// Aggregate retention drops days before the cutoff
+	stats, err = store.Compact(ctx, time.Time{}, day2)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CompactionStats{AggregatesExpired: 1}, stats)
+	assert.Equal(t, 1, store.AggregateLen())
+
+	// New saves still work after compaction
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "e", CreatedAt: day2.AddDate(0, 0, 91)}))
+	assert.Equal(t, 2, store.Len())
+}
+
+func TestSearchStore_EvictionRollsUp(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(1)
+	created := time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC)
+
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "a", CreatedAt: created}))
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "b", CreatedAt: created}))
+
+	aggregates := store.Aggregates()
+	require.Len(t, aggregates, 1)
+	assert.Equal(t, 1, aggregates[0].Searches)
+}
+
+func TestSearchStore_DailySummary(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(10)
+	day := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	flight := domain.SearchResponse{Flights: []domain.Flight{{Price: domain.PriceInfo{Amount: 750000, Currency: "IDR"}}}}
+
+	for i, r := range []domain.SearchRecord{
+		{Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}, CreatedAt: day.Add(time.Hour), Response: flight},
+		{Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}, CreatedAt: day.Add(20 * time.Hour)},
+		{Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "SUB"}, CreatedAt: day.Add(2 * time.Hour)},
+		{Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}, CreatedAt: day.AddDate(0, 0, 1)},
+	} {
+		r.ID = string(rune('a' + i))
+		require.NoError(t, store.Save(ctx, r))
+	}
+
+	// Roll the first search up so the summary has to merge both sources
+	_, err := store.Compact(ctx, day.Add(90*time.Minute), time.Time{})
+	require.NoError(t, err)
+
+	summary, err := store.DailySummary(ctx, day.Add(12*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, summary, 2)
+	assert.Equal(t, "DPS", summary[0].Destination)
+	assert.Equal(t, 2, summary[0].Searches)
+	assert.Equal(t, 1, summary[0].EmptySearches)
+	assert.Equal(t, 750000.0, summary[0].MinPrice)
+	assert.Equal(t, "SUB", summary[1].Destination)
+
+	// The stored aggregate is not modified by the summary
+	assert.Equal(t, 1, store.Aggregates()[0].Searches)
+}
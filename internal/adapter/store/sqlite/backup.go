@@ -0,0 +1,160 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrChecksumMismatch is returned by Restore when a backup does not match its
+// checksum.
+var ErrChecksumMismatch = errors.New("sqlite: backup checksum mismatch")
+
+// Snapshot is a consistent copy of a database, removed once closed.
+type Snapshot struct {
+	*os.File
+	dir    string
+	size   int64
+	sha256 string
+}
+
+// Size returns the length of the copy in bytes.
+func (s *Snapshot) Size() int64 {
+	return s.size
+}
+
+// SHA256 returns the hex-encoded SHA-256 checksum of the copy.
+func (s *Snapshot) SHA256() string {
+	return s.sha256
+}
+
+// Close closes and removes the copy.
+func (s *Snapshot) Close() error {
+	err := s.File.Close()
+	if rmErr := os.RemoveAll(s.dir); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// Backup returns a consistent copy of db, taken without blocking writers.
+// The copy is compacted and, whatever the journal mode of db, self-contained
+// in a single file that Restore puts back in place.
+func Backup(ctx context.Context, db *sql.DB) (*Snapshot, error) {
+	dir, err := os.MkdirTemp("", "sqlite-backup-")
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, "backup.db")
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, fmt.Errorf("sqlite: backup: %w", err)
+	}
+
+	snapshot, err := openSnapshot(dir, path)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// openSnapshot opens the copy at path, in dir, computing its checksum.
+func openSnapshot(dir, path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &Snapshot{File: f, dir: dir, size: size, sha256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// Restore replaces the database at dsn, as given to Open, with the backup
+// read from r, and returns the backup's checksum. If checksum is not empty,
+// the backup must match it (ErrChecksumMismatch). The backup must also pass
+// SQLite's integrity check. The database is only replaced once the backup is
+// verified, and atomically, discarding its write-ahead log.
+//
+// No process may use the database during the restore: stop the server first.
+func Restore(ctx context.Context, dsn string, r io.Reader, checksum string) (string, error) {
+	path := FilePath(dsn)
+	if path == "" || path == ":memory:" {
+		return "", fmt.Errorf("sqlite: cannot restore into %q", dsn)
+	}
+
+	// Write next to the database, so the final rename is atomic
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".restore-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), r)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("sqlite: write backup: %w", err)
+	}
+
+	sum := hex.EncodeToString(h.Sum(nil))
+	if checksum != "" && !strings.EqualFold(checksum, sum) {
+		return sum, fmt.Errorf("%w: got %s, want %s", ErrChecksumMismatch, sum, checksum)
+	}
+	if err := checkIntegrity(ctx, tmp.Name()); err != nil {
+		return sum, err
+	}
+
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return sum, err
+		}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return sum, err
+	}
+	return sum, nil
+}
+
+// checkIntegrity runs SQLite's integrity check on the database at path.
+func checkIntegrity(ctx context.Context, path string) error {
+	db, err := sql.Open(DriverName, "file:"+path+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&result); err != nil {
+		return fmt.Errorf("sqlite: backup is not a valid database: %w", err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("sqlite: backup failed the integrity check: %s", result)
+	}
+	return nil
+}
+
+// FilePath returns the file of the database at dsn, as given to Open.
+func FilePath(dsn string) string {
+	path, _, _ := strings.Cut(dsn, "?")
+	return strings.TrimPrefix(path, "file:")
+}
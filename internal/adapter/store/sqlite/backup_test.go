@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// takeBackup returns a backup of a database holding record "a", and its checksum.
+func takeBackup(t *testing.T) ([]byte, string) {
+	t.Helper()
+	ctx := context.Background()
+	db := openTestDB(t)
+	require.NoError(t, NewSearchStore(db, 10).Save(ctx, domain.SearchRecord{ID: "a"}))
+
+	snapshot, err := Backup(ctx, db)
+	require.NoError(t, err)
+	data, err := io.ReadAll(snapshot)
+	require.NoError(t, err)
+	require.NoError(t, snapshot.Close())
+
+	assert.Equal(t, int64(len(data)), snapshot.Size())
+	sum := sha256.Sum256(data)
+	assert.Equal(t, hex.EncodeToString(sum[:]), snapshot.SHA256())
+
+	// Closing the snapshot removes the temporary copy
+	_, err = os.Stat(snapshot.Name())
+	assert.True(t, os.IsNotExist(err))
+	return data, snapshot.SHA256()
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	ctx := context.Background()
+	backup, checksum := takeBackup(t)
+
+	// Restore over a database that lost the record
+	path := filepath.Join(t.TempDir(), "restored.db")
+	db, err := Open(path, Options{JournalMode: "WAL"})
+	require.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE lost (id INTEGER)")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	sum, err := Restore(ctx, "file:"+path+"?_journal_mode=WAL", bytes.NewReader(backup), strings.ToUpper(checksum))
+	require.NoError(t, err)
+	assert.Equal(t, checksum, sum)
+
+	db, err = Open(path, Options{})
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = NewSearchStore(db, 10).Get(ctx, "a")
+	assert.NoError(t, err)
+
+	// No temporary file is left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.NotContains(t, e.Name(), ".restore-")
+	}
+}
+
+func TestRestore_ChecksumMismatch(t *testing.T) {
+	backup, _ := takeBackup(t)
+	path := filepath.Join(t.TempDir(), "db")
+	require.NoError(t, os.WriteFile(path, []byte("current"), 0o600))
+
+	_, err := Restore(context.Background(), path, bytes.NewReader(backup), strings.Repeat("0", 64))
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+	// The database is left untouched
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "current", string(current))
+}
+
+func TestRestore_Corrupt(t *testing.T) {
+	backup, _ := takeBackup(t)
+	path := filepath.Join(t.TempDir(), "db")
+
+	// Truncated backups are not valid databases
+	_, err := Restore(context.Background(), path, bytes.NewReader(backup[:len(backup)/2]), "")
+	assert.Error(t, err)
+	_, err = Restore(context.Background(), path, strings.NewReader("not a database"), "")
+	assert.Error(t, err)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRestore_InMemory(t *testing.T) {
+	_, err := Restore(context.Background(), ":memory:", strings.NewReader(""), "")
+	assert.Error(t, err)
+}
+
+func TestFilePath(t *testing.T) {
+	assert.Equal(t, "data/history.db", FilePath("data/history.db"))
+	assert.Equal(t, "/var/lib/fs.db", FilePath("file:/var/lib/fs.db?cache=shared"))
+}
@@ -0,0 +1,7 @@
+//go:build cgo
+
+package sqlite
+
+// cgoEnabled reports whether the binary was built with cgo, which the
+// driver needs to be more than a stub.
+const cgoEnabled = true
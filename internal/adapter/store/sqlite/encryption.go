@@ -0,0 +1,140 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/encryption"
+)
+
+// Option configures a store.
+type Option func(*payloadCipher)
+
+// WithEncryption encrypts the JSON payloads a store writes with the
+// keyring's primary key. The columns the store queries by stay in plain
+// text. Payloads written before encryption was enabled and payloads
+// encrypted with an older key are still read.
+func WithEncryption(keyring *encryption.Keyring) Option {
+	return func(c *payloadCipher) {
+		c.keyring = keyring
+	}
+}
+
+// payloadCipher seals the payloads of a store when it has a keyring.
+type payloadCipher struct {
+	keyring *encryption.Keyring
+}
+
+// newPayloadCipher applies opts to a cipher leaving payloads in plain text.
+func newPayloadCipher(opts []Option) payloadCipher {
+	var c payloadCipher
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// seal returns data encrypted for the column identified by additionalData,
+// or data itself without a keyring.
+func (c payloadCipher) seal(data, additionalData []byte) ([]byte, error) {
+	if c.keyring == nil {
+		return data, nil
+	}
+	sealed, err := c.keyring.Encrypt(data, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt %s: %w", additionalData, err)
+	}
+	return sealed, nil
+}
+
+// open returns the plaintext of a payload written by seal. Plaintext
+// payloads are returned as they are.
+func (c payloadCipher) open(data, additionalData []byte) ([]byte, error) {
+	if !encryption.IsEncrypted(data) {
+		return data, nil
+	}
+	if c.keyring == nil {
+		return nil, fmt.Errorf("%s is encrypted but no encryption keys are configured", additionalData)
+	}
+	plaintext, err := c.keyring.Decrypt(data, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w", additionalData, err)
+	}
+	return plaintext, nil
+}
+
+// rotationBatch is the number of payloads re-encrypted per transaction.
+const rotationBatch = 500
+
+// rotate re-encrypts the payloads of column in table that are in plain text
+// or encrypted with an older key, in batches so the writes of other
+// connections are not held up, and returns how many it rewrote.
+func (c payloadCipher) rotate(ctx context.Context, db *sql.DB, table, column string, additionalData []byte) (int, error) {
+	if c.keyring == nil {
+		return 0, nil
+	}
+
+	rotated := 0
+	var after int64
+	for {
+		n, last, err := c.rotateBatch(ctx, db, table, column, additionalData, after)
+		rotated += n
+		if err != nil || last == after {
+			return rotated, err
+		}
+		after = last
+	}
+}
+
+// rotateBatch re-encrypts the payloads of the rotationBatch rows following
+// rowid after, and returns how many it rewrote and the last rowid it read.
+func (c payloadCipher) rotateBatch(ctx context.Context, db *sql.DB, table, column string, additionalData []byte, after int64) (int, int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, after, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx,
+		fmt.Sprintf("SELECT rowid, %s FROM %s WHERE rowid > ? ORDER BY rowid LIMIT ?", column, table),
+		after, rotationBatch)
+	if err != nil {
+		return 0, after, err
+	}
+	stale := make(map[int64][]byte)
+	last := after
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&last, &data); err != nil {
+			rows.Close()
+			return 0, after, err
+		}
+		if c.keyring.NeedsRotation(data) {
+			stale[last] = data
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, after, err
+	}
+
+	for rowid, data := range stale {
+		plaintext, err := c.open(data, additionalData)
+		if err != nil {
+			return 0, after, err
+		}
+		sealed, err := c.seal(plaintext, additionalData)
+		if err != nil {
+			return 0, after, err
+		}
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf("UPDATE %s SET %s = ? WHERE rowid = ?", table, column), string(sealed), rowid); err != nil {
+			return 0, after, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, after, err
+	}
+	return len(stale), last, nil
+}
@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/jobqueue"
+)
+
+// jobAdditionalData binds encrypted jobs to their column.
+var jobAdditionalData = []byte("jobs.job")
+
+// JobStore is a jobqueue.JobStorage keeping each job in a row of the jobs
+// table, so the queue's state changes update one row instead of rewriting
+// every job. Jobs are stored as JSON, encrypted WithEncryption; their kind,
+// status and times get columns of their own for inspection.
+type JobStore struct {
+	db     *sql.DB
+	cipher payloadCipher
+}
+
+// NewJobStore creates a store keeping jobs in db, which must have been
+// opened with Open and migrated.
+func NewJobStore(db *sql.DB, opts ...Option) *JobStore {
+	return &JobStore{db: db, cipher: newPayloadCipher(opts)}
+}
+
+// Load returns all jobs, oldest first.
+func (s *JobStore) Load(ctx context.Context) ([]jobqueue.Job, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT job FROM jobs ORDER BY created_at, rowid")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []jobqueue.Job
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		if data, err = s.cipher.open(data, jobAdditionalData); err != nil {
+			return nil, err
+		}
+		var job jobqueue.Job
+		if err := json.Unmarshal(data, &job); err != nil {
+			return nil, fmt.Errorf("decode job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// Save replaces all jobs with jobs.
+func (s *JobStore) Save(ctx context.Context, jobs []jobqueue.Job) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM jobs"); err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if err := s.saveJob(ctx, tx, job); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// SaveJob inserts or replaces the row of job.
+func (s *JobStore) SaveJob(ctx context.Context, job jobqueue.Job) error {
+	return s.saveJob(ctx, s.db, job)
+}
+
+// DeleteJobs removes the rows of the jobs with the given IDs.
+func (s *JobStore) DeleteJobs(ctx context.Context, ids []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM jobs WHERE id = ?", id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// RotateKeys rewrites the jobs stored in plain text or encrypted with an
+// older key under the primary key of the store's keyring, so the older keys
+// can be retired, and returns how many it rewrote. It does nothing without
+// encryption.
+func (s *JobStore) RotateKeys(ctx context.Context) (int, error) {
+	rotated, err := s.cipher.rotate(ctx, s.db, "jobs", "job", jobAdditionalData)
+	if err != nil {
+		return rotated, fmt.Errorf("rotate jobs: %w", err)
+	}
+	return rotated, nil
+}
+
+// execer is implemented by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// saveJob upserts the row of job.
+func (s *JobStore) saveJob(ctx context.Context, e execer, job jobqueue.Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("encode job: %w", err)
+	}
+	if data, err = s.cipher.seal(data, jobAdditionalData); err != nil {
+		return err
+	}
+	_, err = e.ExecContext(ctx,
+		`INSERT INTO jobs (id, kind, status, run_at, created_at, job) VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (id) DO UPDATE SET kind = excluded.kind, status = excluded.status,
+		 run_at = excluded.run_at, job = excluded.job`,
+		job.ID, job.Kind, string(job.Status), job.RunAt.UTC().Format(timeFormat),
+		job.CreatedAt.UTC().Format(timeFormat), string(data))
+	return err
+}
+
+// Ensure JobStore implements jobqueue.JobStorage at compile time.
+var _ jobqueue.JobStorage = (*JobStore)(nil)
@@ -0,0 +1,99 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/jobqueue"
+)
+
+func TestJobStore_Queue(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	open := func() *jobqueue.Queue {
+		q, err := jobqueue.Open(ctx, NewJobStore(db), jobqueue.Config{MaxAttempts: 1, Retention: time.Hour}, jobqueue.WithClock(clock))
+		require.NoError(t, err)
+		q.Handle("async_search", func(context.Context, jobqueue.Job) error { return nil })
+		return q
+	}
+
+	q := open()
+	done, err := q.Enqueue(ctx, "async_search", map[string]string{"origin": "CGK"})
+	require.NoError(t, err)
+	clock.Advance(time.Second)
+	pending, err := q.Enqueue(ctx, "async_search", map[string]string{"origin": "DPS"})
+	require.NoError(t, err)
+	require.True(t, q.RunOnce(ctx))
+
+	var status string
+	require.NoError(t, db.QueryRow("SELECT status FROM jobs WHERE id = ?", done.ID).Scan(&status))
+	assert.Equal(t, string(jobqueue.StatusDone), status, "each job has a row")
+
+	restarted := open()
+	jobs := restarted.List("")
+	require.Len(t, jobs, 2)
+	assert.Equal(t, done.ID, jobs[0].ID)
+	assert.Equal(t, jobqueue.StatusDone, jobs[0].Status)
+	assert.Equal(t, pending.ID, jobs[1].ID)
+	assert.Equal(t, jobqueue.StatusPending, jobs[1].Status)
+	assert.JSONEq(t, `{"origin":"DPS"}`, string(jobs[1].Payload))
+
+	// Finished jobs past the retention lose their row
+	clock.Advance(2 * time.Hour)
+	require.True(t, restarted.RunOnce(ctx))
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM jobs WHERE id = ?", done.ID).Scan(&count))
+	assert.Zero(t, count)
+	assert.Len(t, restarted.List(""), 1)
+}
+
+func TestJobStore_Save(t *testing.T) {
+	ctx := context.Background()
+	store := NewJobStore(openTestDB(t))
+	require.NoError(t, store.SaveJob(ctx, jobqueue.Job{ID: "a", Kind: "k", Status: jobqueue.StatusPending}))
+
+	// Snapshots replace every job
+	require.NoError(t, store.Save(ctx, []jobqueue.Job{{ID: "b", Kind: "k", Status: jobqueue.StatusDead}}))
+	jobs, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "b", jobs[0].ID)
+	assert.Equal(t, jobqueue.StatusDead, jobs[0].Status)
+}
+
+func TestJobStore_Encryption(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	job := jobqueue.Job{ID: "a", Kind: "async_search", Status: jobqueue.StatusPending, Payload: []byte(`{"origin":"CGK"}`)}
+
+	require.NoError(t, NewJobStore(db).SaveJob(ctx, job))
+	require.NoError(t, NewJobStore(db, WithEncryption(testKeyring(t, "old"))).SaveJob(ctx, jobqueue.Job{ID: "b", Kind: "async_search"}))
+	stored := storedColumn(t, db, "SELECT job FROM jobs WHERE id = 'b'")
+	require.Len(t, stored, 1)
+	assert.True(t, strings.HasPrefix(stored[0], "enc:v1:old:"), "jobs are stored encrypted")
+
+	// Rotation rewrites the plaintext job and the one under the old key
+	store := NewJobStore(db, WithEncryption(testKeyring(t, "new", "old")))
+	rotated, err := store.RotateKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, rotated)
+	for _, value := range storedColumn(t, db, "SELECT job FROM jobs") {
+		assert.True(t, strings.HasPrefix(value, "enc:v1:new:"))
+		assert.NotContains(t, value, "CGK")
+	}
+
+	jobs, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.JSONEq(t, string(job.Payload), string(jobs[0].Payload))
+
+	_, err = NewJobStore(db).Load(ctx)
+	assert.ErrorContains(t, err, "no encryption keys are configured")
+}
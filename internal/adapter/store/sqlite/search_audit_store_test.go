@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/encryption"
+)
+
+func TestSearchAuditStore_Write(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	store := NewSearchAuditStore(db)
+
+	at := time.Date(2025, 12, 15, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Write(ctx, []domain.SearchAuditEntry{
+		{At: at, Client: "partner-app", Outcome: domain.AuditOutcomeSuccess, LatencyMs: 120,
+			Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}, FailedProviders: []string{"lion_air"}},
+		{At: at.Add(time.Second), Client: "anonymous", Outcome: domain.AuditOutcomeError, LatencyMs: 5000, Error: "all providers failed"},
+	}))
+	require.NoError(t, store.Write(ctx, []domain.SearchAuditEntry{
+		{At: at.Add(2 * time.Second), Client: "partner-app", Outcome: domain.AuditOutcomeSuccess, LatencyMs: 80},
+	}))
+	require.NoError(t, store.Close())
+
+	var slow int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM search_audit WHERE client = ? AND latency_ms >= 100", "partner-app").Scan(&slow))
+	assert.Equal(t, 1, slow)
+
+	var first time.Time
+	var outcome string
+	var data []byte
+	require.NoError(t, db.QueryRow("SELECT at, outcome, entry FROM search_audit ORDER BY id LIMIT 1").Scan(&first, &outcome, &data))
+	assert.True(t, at.Equal(first))
+	assert.Equal(t, domain.AuditOutcomeSuccess, outcome)
+	var entry domain.SearchAuditEntry
+	require.NoError(t, json.Unmarshal(data, &entry))
+	assert.Equal(t, "CGK", entry.Criteria.Origin)
+	assert.Equal(t, []string{"lion_air"}, entry.FailedProviders)
+}
+
+func TestSearchAuditStore_Encryption(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	oldKeys := testKeyring(t, "old")
+	entry := domain.SearchAuditEntry{
+		At: time.Date(2025, 12, 15, 10, 0, 0, 0, time.UTC), Client: "partner-app", Outcome: domain.AuditOutcomeSuccess,
+		Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"},
+	}
+
+	require.NoError(t, NewSearchAuditStore(db).Write(ctx, []domain.SearchAuditEntry{entry}))
+	require.NoError(t, NewSearchAuditStore(db, WithEncryption(oldKeys)).Write(ctx, []domain.SearchAuditEntry{entry}))
+	stored := storedColumn(t, db, "SELECT entry FROM search_audit ORDER BY id")
+	require.Len(t, stored, 2)
+	assert.Contains(t, stored[0], "CGK", "entries written before encryption was enabled")
+	assert.True(t, strings.HasPrefix(stored[1], "enc:v1:old:"), "entries are stored encrypted")
+	assert.NotContains(t, stored[1], "CGK")
+	var client string
+	require.NoError(t, db.QueryRow("SELECT client FROM search_audit WHERE id = 2").Scan(&client))
+	assert.Equal(t, "partner-app", client, "queried columns stay in plain text")
+
+	// Rotation rewrites the plaintext entry and those under the old key
+	rotated, err := NewSearchAuditStore(db, WithEncryption(testKeyring(t, "new", "old"))).RotateKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, rotated)
+	newOnly := testKeyring(t, "new")
+	for _, value := range storedColumn(t, db, "SELECT entry FROM search_audit") {
+		data, err := newOnly.Decrypt([]byte(value), auditAdditionalData)
+		require.NoError(t, err)
+		var got domain.SearchAuditEntry
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, "CGK", got.Criteria.Origin)
+	}
+
+	_, err = NewSearchAuditStore(db, WithEncryption(oldKeys)).RotateKeys(ctx)
+	assert.ErrorIs(t, err, encryption.ErrUnknownKey)
+}
@@ -0,0 +1,369 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// DefaultSearchStoreCapacity is the number of records kept when no capacity is given.
+const DefaultSearchStoreCapacity = 1000
+
+// timeFormat stores timestamps as fixed-width UTC text, so they sort
+// chronologically when compared as strings.
+const timeFormat = "2006-01-02 15:04:05.000000000"
+
+// dateFormat is the format of aggregate dates.
+const dateFormat = "2006-01-02"
+
+// Additional data binding encrypted payloads to their column.
+var (
+	recordAdditionalData    = []byte("search_records.record")
+	aggregateAdditionalData = []byte("search_aggregates.aggregate")
+)
+
+// SearchStore is a bounded SQLite domain.SearchRecordStore, behaving like
+// memory.SearchStore but surviving restarts: when full, the oldest record is
+// evicted, and evicted and compacted records are rolled up into daily
+// aggregates. Records and aggregates are stored as JSON, encrypted
+// WithEncryption. It is safe for concurrent use, including by several
+// processes sharing the database file.
+type SearchStore struct {
+	db       *sql.DB
+	capacity int
+	cipher   payloadCipher
+}
+
+// NewSearchStore creates a store holding at most capacity records in db,
+// which must have been opened with Open and migrated. Values below 1 use
+// DefaultSearchStoreCapacity.
+func NewSearchStore(db *sql.DB, capacity int, opts ...Option) *SearchStore {
+	if capacity < 1 {
+		capacity = DefaultSearchStoreCapacity
+	}
+	return &SearchStore{db: db, capacity: capacity, cipher: newPayloadCipher(opts)}
+}
+
+// Save stores a record, evicting the oldest record if the store is full.
+func (s *SearchStore) Save(ctx context.Context, record domain.SearchRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode search record: %w", err)
+	}
+	if data, err = s.cipher.seal(data, recordAdditionalData); err != nil {
+		return err
+	}
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		// Replacing a record keeps its row, and so its place in the eviction order
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO search_records (id, created_at, client, record) VALUES (?, ?, ?, ?)
+			 ON CONFLICT (id) DO UPDATE SET created_at = excluded.created_at, client = excluded.client,
+			 record = excluded.record`,
+			record.ID, record.CreatedAt.UTC().Format(timeFormat), record.Client, string(data)); err != nil {
+			return err
+		}
+
+		var count int
+		if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM search_records").Scan(&count); err != nil {
+			return err
+		}
+		if count <= s.capacity {
+			return nil
+		}
+		evicted, err := s.queryRecords(ctx, tx,
+			"SELECT record FROM search_records ORDER BY rowid LIMIT ?", count-s.capacity)
+		if err != nil {
+			return err
+		}
+		return s.rollup(ctx, tx, evicted)
+	})
+}
+
+// Get returns the record with the given ID, or domain.ErrSearchNotFound.
+func (s *SearchStore) Get(ctx context.Context, id string) (*domain.SearchRecord, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, "SELECT record FROM search_records WHERE id = ?", id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrSearchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.decodeRecord(data)
+	if err != nil {
+		return nil, fmt.Errorf("search record %s: %w", id, err)
+	}
+	return &record, nil
+}
+
+// ListByClient returns up to limit records of client, newest first.
+func (s *SearchStore) ListByClient(ctx context.Context, client string, limit int) ([]domain.SearchRecord, error) {
+	return s.queryRecords(ctx, s.db,
+		"SELECT record FROM search_records WHERE client = ? ORDER BY created_at DESC, rowid DESC LIMIT ?",
+		client, limit)
+}
+
+// Len returns the number of stored records, or 0 if they cannot be counted.
+func (s *SearchStore) Len() int {
+	return s.count("search_records")
+}
+
+// AggregateLen returns the number of daily aggregates, or 0 if they cannot be counted.
+func (s *SearchStore) AggregateLen() int {
+	return s.count("search_aggregates")
+}
+
+// Compact rolls records created before recordsBefore into daily aggregates
+// and removes them, then drops aggregates for days before aggregatesBefore.
+// A zero cutoff disables the corresponding step.
+func (s *SearchStore) Compact(ctx context.Context, recordsBefore, aggregatesBefore time.Time) (domain.CompactionStats, error) {
+	var stats domain.CompactionStats
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		stats = domain.CompactionStats{}
+		if !recordsBefore.IsZero() {
+			compacted, err := s.queryRecords(ctx, tx,
+				"SELECT record FROM search_records WHERE created_at < ?", recordsBefore.UTC().Format(timeFormat))
+			if err != nil {
+				return err
+			}
+			if err := s.rollup(ctx, tx, compacted); err != nil {
+				return err
+			}
+			stats.RecordsCompacted = len(compacted)
+		}
+
+		if !aggregatesBefore.IsZero() {
+			result, err := tx.ExecContext(ctx, "DELETE FROM search_aggregates WHERE date < ?",
+				aggregatesBefore.UTC().Format(dateFormat))
+			if err != nil {
+				return err
+			}
+			expired, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			stats.AggregatesExpired = int(expired)
+		}
+		return nil
+	})
+	return stats, err
+}
+
+// DailySummary returns the aggregates for the UTC day containing day,
+// combining rolled-up aggregates with records still held individually.
+// Results are ordered by origin and destination.
+func (s *SearchStore) DailySummary(ctx context.Context, day time.Time) ([]domain.SearchAggregate, error) {
+	date := day.UTC().Format(dateFormat)
+	start, err := time.Parse(dateFormat, date)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := make(map[domain.AggregateKey]*domain.SearchAggregate)
+	rows, err := s.db.QueryContext(ctx, "SELECT aggregate FROM search_aggregates WHERE date = ?", date)
+	if err != nil {
+		return nil, err
+	}
+	aggregates, err := s.scanAggregates(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, agg := range aggregates {
+		summary[domain.AggregateKey{Date: agg.Date, Origin: agg.Origin, Destination: agg.Destination}] = agg
+	}
+
+	records, err := s.queryRecords(ctx, s.db,
+		"SELECT record FROM search_records WHERE created_at >= ? AND created_at < ?",
+		start.Format(timeFormat), start.AddDate(0, 0, 1).Format(timeFormat))
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		key := domain.AggregateKeyFor(record)
+		agg, ok := summary[key]
+		if !ok {
+			agg = &domain.SearchAggregate{}
+			summary[key] = agg
+		}
+		agg.Add(record)
+	}
+
+	result := make([]domain.SearchAggregate, 0, len(summary))
+	for _, agg := range summary {
+		result = append(result, *agg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Origin != result[j].Origin {
+			return result[i].Origin < result[j].Origin
+		}
+		return result[i].Destination < result[j].Destination
+	})
+	return result, nil
+}
+
+// RotateKeys rewrites the records and aggregates stored in plain text or
+// encrypted with an older key under the primary key of the store's keyring,
+// so the older keys can be retired, and returns how many it rewrote. It
+// does nothing without encryption.
+func (s *SearchStore) RotateKeys(ctx context.Context) (int, error) {
+	records, err := s.cipher.rotate(ctx, s.db, "search_records", "record", recordAdditionalData)
+	if err != nil {
+		return records, fmt.Errorf("rotate search records: %w", err)
+	}
+	aggregates, err := s.cipher.rotate(ctx, s.db, "search_aggregates", "aggregate", aggregateAdditionalData)
+	if err != nil {
+		return records + aggregates, fmt.Errorf("rotate search aggregates: %w", err)
+	}
+	return records + aggregates, nil
+}
+
+// inTx runs fn in a transaction, committed if fn succeeds.
+func (s *SearchStore) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// count returns the number of rows of table, or 0 if they cannot be counted.
+func (s *SearchStore) count(table string) int {
+	var n int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+		return 0
+	}
+	return n
+}
+
+// querier is implemented by *sql.DB and *sql.Tx.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// queryRecords returns the records selected by query, which selects the
+// record column.
+func (s *SearchStore) queryRecords(ctx context.Context, q querier, query string, args ...any) ([]domain.SearchRecord, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []domain.SearchRecord
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		record, err := s.decodeRecord(data)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// decodeRecord decodes the record column.
+func (s *SearchStore) decodeRecord(data []byte) (domain.SearchRecord, error) {
+	var record domain.SearchRecord
+	data, err := s.cipher.open(data, recordAdditionalData)
+	if err != nil {
+		return record, err
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return record, fmt.Errorf("decode search record: %w", err)
+	}
+	return record, nil
+}
+
+// scanAggregates decodes and closes rows selecting the aggregate column.
+func (s *SearchStore) scanAggregates(rows *sql.Rows) ([]*domain.SearchAggregate, error) {
+	defer rows.Close()
+
+	var aggregates []*domain.SearchAggregate
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		data, err := s.cipher.open(data, aggregateAdditionalData)
+		if err != nil {
+			return nil, err
+		}
+		agg := &domain.SearchAggregate{}
+		if err := json.Unmarshal(data, agg); err != nil {
+			return nil, fmt.Errorf("decode search aggregate: %w", err)
+		}
+		aggregates = append(aggregates, agg)
+	}
+	return aggregates, rows.Err()
+}
+
+// rollup adds records to their daily aggregates and removes them.
+func (s *SearchStore) rollup(ctx context.Context, tx *sql.Tx, records []domain.SearchRecord) error {
+	aggregates := make(map[domain.AggregateKey]*domain.SearchAggregate)
+	for _, record := range records {
+		key := domain.AggregateKeyFor(record)
+		agg, ok := aggregates[key]
+		if !ok {
+			rows, err := tx.QueryContext(ctx,
+				"SELECT aggregate FROM search_aggregates WHERE date = ? AND origin = ? AND destination = ?",
+				key.Date, key.Origin, key.Destination)
+			if err != nil {
+				return err
+			}
+			stored, err := s.scanAggregates(rows)
+			if err != nil {
+				return err
+			}
+			agg = &domain.SearchAggregate{}
+			if len(stored) > 0 {
+				agg = stored[0]
+			}
+			aggregates[key] = agg
+		}
+		agg.Add(record)
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM search_records WHERE id = ?", record.ID); err != nil {
+			return err
+		}
+	}
+
+	for key, agg := range aggregates {
+		data, err := json.Marshal(agg)
+		if err != nil {
+			return fmt.Errorf("encode search aggregate: %w", err)
+		}
+		if data, err = s.cipher.seal(data, aggregateAdditionalData); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO search_aggregates (date, origin, destination, aggregate) VALUES (?, ?, ?, ?)
+			 ON CONFLICT (date, origin, destination) DO UPDATE SET aggregate = excluded.aggregate`,
+			key.Date, key.Origin, key.Destination, string(data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Ensure SearchStore implements the domain store interfaces at compile time.
+var (
+	_ domain.SearchRecordStore      = (*SearchStore)(nil)
+	_ domain.SearchRecordLister     = (*SearchStore)(nil)
+	_ domain.SearchHistoryCompactor = (*SearchStore)(nil)
+)
@@ -0,0 +1,344 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/encryption"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/migrate"
+)
+
+// openTestDB opens a migrated database in a temporary directory.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"), Options{
+		JournalMode: "WAL", Synchronous: "NORMAL", BusyTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = migrate.New(migrate.NewSQLDriver(db), migrate.Embedded()).Up(context.Background())
+	require.NoError(t, err)
+	return db
+}
+
+func TestSearchStore_SaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(openTestDB(t), 10)
+
+	record := domain.SearchRecord{
+		ID:        "search-1",
+		Criteria:  domain.SearchCriteria{Origin: "CGK", Destination: "DPS"},
+		SortBy:    domain.SortByPrice,
+		CreatedAt: time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, store.Save(ctx, record))
+
+	got, err := store.Get(ctx, "search-1")
+	require.NoError(t, err)
+	assert.Equal(t, record, *got)
+
+	_, err = store.Get(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+}
+
+func TestSearchStore_PersistsAcrossReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "history.db")
+
+	db, err := Open(path, Options{JournalMode: "WAL"})
+	require.NoError(t, err)
+	_, err = migrate.New(migrate.NewSQLDriver(db), migrate.Embedded()).Up(ctx)
+	require.NoError(t, err)
+	require.NoError(t, NewSearchStore(db, 10).Save(ctx, domain.SearchRecord{ID: "a"}))
+	require.NoError(t, db.Close())
+
+	db, err = Open(path, Options{JournalMode: "WAL"})
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = NewSearchStore(db, 10).Get(ctx, "a")
+	assert.NoError(t, err)
+}
+
+func TestSearchStore_EvictsOldest(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(openTestDB(t), 2)
+
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: id}))
+	}
+
+	assert.Equal(t, 2, store.Len())
+	_, err := store.Get(ctx, "a")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+	_, err = store.Get(ctx, "c")
+	assert.NoError(t, err)
+
+	// The evicted record is rolled up
+	assert.Equal(t, 1, store.AggregateLen())
+}
+
+func TestSearchStore_ListByClient(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(openTestDB(t), 3)
+	start := time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC)
+
+	for i, r := range []struct{ id, client string }{
+		{"evicted", "partner-app"}, {"a", "partner-app"}, {"b", "ops"}, {"c", "partner-app"},
+	} {
+		require.NoError(t, store.Save(ctx, domain.SearchRecord{
+			ID: r.id, Client: r.client, CreatedAt: start.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+
+	records, err := store.ListByClient(ctx, "partner-app", 10)
+	require.NoError(t, err)
+	require.Len(t, records, 2, "evicted searches are not listed")
+	assert.Equal(t, "c", records[0].ID, "newest first")
+	assert.Equal(t, "a", records[1].ID)
+
+	records, err = store.ListByClient(ctx, "partner-app", 1)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "c", records[0].ID)
+
+	records, err = store.ListByClient(ctx, "unknown", 10)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestSearchStore_ReplaceDoesNotEvict(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(openTestDB(t), 2)
+
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "a"}))
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "b"}))
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "a", SortBy: domain.SortByPrice}))
+
+	assert.Equal(t, 2, store.Len())
+	got, err := store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, domain.SortByPrice, got.SortBy)
+
+	// Replacing keeps the record's place: it is still evicted first
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "c"}))
+	_, err = store.Get(ctx, "a")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+}
+
+func TestSearchStore_ConcurrentSaves(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(openTestDB(t), 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, store.Save(ctx, domain.SearchRecord{ID: string(rune('a' + i))}))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, store.Len())
+}
+
+func TestSearchStore_Compact(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(openTestDB(t), 10)
+	day1 := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	flights := func(prices ...float64) domain.SearchResponse {
+		var resp domain.SearchResponse
+		for _, p := range prices {
+			resp.Flights = append(resp.Flights, domain.Flight{Price: domain.PriceInfo{Amount: p, Currency: "IDR"}})
+		}
+		return resp
+	}
+	cgkDps := domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}
+
+	records := []domain.SearchRecord{
+		{ID: "a", Criteria: cgkDps, CreatedAt: day1, Response: flights(900000, 1200000)},
+		{ID: "b", Criteria: cgkDps, CreatedAt: day1.Add(time.Hour), Response: flights(800000)},
+		{ID: "c", Criteria: cgkDps, CreatedAt: day1.Add(2 * time.Hour)},
+		{ID: "d", Criteria: domain.SearchCriteria{Origin: "SUB", Destination: "CGK"}, CreatedAt: day2, Response: flights(500000)},
+		{ID: "recent", Criteria: cgkDps, CreatedAt: day2.AddDate(0, 0, 90)},
+	}
+	for _, r := range records {
+		require.NoError(t, store.Save(ctx, r))
+	}
+
+	stats, err := store.Compact(ctx, day2.AddDate(0, 0, 30), time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.CompactionStats{RecordsCompacted: 4}, stats)
+	assert.Equal(t, 1, store.Len())
+	assert.Equal(t, 2, store.AggregateLen())
+	_, err = store.Get(ctx, "recent")
+	assert.NoError(t, err)
+	_, err = store.Get(ctx, "a")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+
+	summary, err := store.DailySummary(ctx, day1)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.SearchAggregate{{
+		Date: "2025-09-01", Origin: "CGK", Destination: "DPS",
+		Searches: 3, EmptySearches: 1, TotalResults: 3,
+		MinPrice: 800000, MaxPrice: 1200000, Currency: "IDR",
+	}}, summary)
+
+	// Aggregate retention drops days before the cutoff
+	stats, err = store.Compact(ctx, time.Time{}, day2)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CompactionStats{AggregatesExpired: 1}, stats)
+	assert.Equal(t, 1, store.AggregateLen())
+
+	// New saves still work after compaction
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "e", CreatedAt: day2.AddDate(0, 0, 91)}))
+	assert.Equal(t, 2, store.Len())
+}
+
+func TestSearchStore_DailySummary(t *testing.T) {
+	ctx := context.Background()
+	store := NewSearchStore(openTestDB(t), 10)
+	day := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	flight := domain.SearchResponse{Flights: []domain.Flight{{Price: domain.PriceInfo{Amount: 750000, Currency: "IDR"}}}}
+
+	for i, r := range []domain.SearchRecord{
+		{Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}, CreatedAt: day.Add(time.Hour), Response: flight},
+		{Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}, CreatedAt: day.Add(20 * time.Hour)},
+		{Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "SUB"}, CreatedAt: day.Add(2 * time.Hour)},
+		{Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}, CreatedAt: day.AddDate(0, 0, 1)},
+	} {
+		r.ID = string(rune('a' + i))
+		require.NoError(t, store.Save(ctx, r))
+	}
+
+	// Roll the first search up so the summary has to merge both sources
+	_, err := store.Compact(ctx, day.Add(90*time.Minute), time.Time{})
+	require.NoError(t, err)
+
+	summary, err := store.DailySummary(ctx, day.Add(12*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, summary, 2)
+	assert.Equal(t, "DPS", summary[0].Destination)
+	assert.Equal(t, 2, summary[0].Searches)
+	assert.Equal(t, 1, summary[0].EmptySearches)
+	assert.Equal(t, 750000.0, summary[0].MinPrice)
+	assert.Equal(t, "SUB", summary[1].Destination)
+
+	// The stored aggregate is not modified by the summary
+	summary, err = store.DailySummary(ctx, day.Add(12*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary[0].Searches)
+}
+
+func TestSearchStore_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	store := NewSearchStore(openTestDB(t), 0)
+	assert.ErrorIs(t, store.Save(ctx, domain.SearchRecord{ID: "a"}), context.Canceled)
+	_, err := store.Get(ctx, "a")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// testKeyring returns a keyring whose keys are named ids, the first primary.
+func testKeyring(t *testing.T, ids ...string) *encryption.Keyring {
+	t.Helper()
+	var keys []encryption.Key
+	for _, id := range ids {
+		keys = append(keys, encryption.Key{ID: id, Secret: bytes.Repeat([]byte(id[:1]), encryption.KeySize)})
+	}
+	keyring, err := encryption.NewKeyring(keys...)
+	require.NoError(t, err)
+	return keyring
+}
+
+// storedColumn returns the raw values selected by query.
+func storedColumn(t *testing.T, db *sql.DB, query string) []string {
+	t.Helper()
+	rows, err := db.Query(query)
+	require.NoError(t, err)
+	defer rows.Close()
+	var values []string
+	for rows.Next() {
+		var value string
+		require.NoError(t, rows.Scan(&value))
+		values = append(values, value)
+	}
+	require.NoError(t, rows.Err())
+	return values
+}
+
+func TestSearchStore_Encryption(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	oldKeys := testKeyring(t, "old")
+	newKeys := testKeyring(t, "new", "old")
+	day := time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC)
+	record := func(id string) domain.SearchRecord {
+		return domain.SearchRecord{
+			ID:        id,
+			Criteria:  domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-20"},
+			CreatedAt: day,
+		}
+	}
+
+	// Records stored before encryption was enabled are still read
+	require.NoError(t, NewSearchStore(db, 3).Save(ctx, record("plain")))
+	store := NewSearchStore(db, 3, WithEncryption(oldKeys))
+	require.NoError(t, store.Save(ctx, record("a")))
+	got, err := store.Get(ctx, "plain")
+	require.NoError(t, err)
+	assert.Equal(t, record("plain"), *got)
+
+	stored := storedColumn(t, db, "SELECT record FROM search_records WHERE id = 'a'")
+	require.Len(t, stored, 1)
+	assert.True(t, strings.HasPrefix(stored[0], "enc:v1:old:"), "records are stored encrypted")
+	assert.NotContains(t, stored[0], "CGK")
+	got, err = store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, record("a"), *got)
+
+	// Evicted records are rolled up into encrypted aggregates
+	require.NoError(t, store.Save(ctx, record("b")))
+	require.NoError(t, store.Save(ctx, record("c")))
+	stored = storedColumn(t, db, "SELECT aggregate FROM search_aggregates")
+	require.Len(t, stored, 1)
+	assert.True(t, strings.HasPrefix(stored[0], "enc:v1:old:"), "aggregates are stored encrypted")
+	summary, err := store.DailySummary(ctx, day)
+	require.NoError(t, err)
+	require.Len(t, summary, 1)
+	assert.Equal(t, 4, summary[0].Searches)
+
+	// Rotation rewrites the records and aggregates under the new primary key
+	store = NewSearchStore(db, 3, WithEncryption(newKeys))
+	rotated, err := store.RotateKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, rotated)
+	for _, value := range storedColumn(t, db, "SELECT record FROM search_records UNION ALL SELECT aggregate FROM search_aggregates") {
+		assert.True(t, strings.HasPrefix(value, "enc:v1:new:"))
+	}
+	rotated, err = store.RotateKeys(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, rotated)
+	_, err = store.Get(ctx, "a")
+	require.NoError(t, err)
+
+	// Encrypted records cannot be read without the keys
+	_, err = NewSearchStore(db, 3).Get(ctx, "a")
+	assert.ErrorContains(t, err, "no encryption keys are configured")
+	_, err = NewSearchStore(db, 3, WithEncryption(oldKeys)).Get(ctx, "a")
+	assert.ErrorIs(t, err, encryption.ErrUnknownKey)
+}
@@ -0,0 +1,68 @@
+// Package sqlite provides SQLite implementations of the domain stores, so
+// single-binary deployments get persistence without a database server.
+// The schema is created by the migrations of package migrate.
+//
+// The driver requires cgo: in a binary built with CGO_ENABLED=0, Open fails
+// instead of returning a database whose every query fails.
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	// Registers the sqlite3 database/sql driver
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// DriverName is the database/sql driver name of SQLite.
+const DriverName = "sqlite3"
+
+// Options tune an SQLite database for concurrent use.
+type Options struct {
+	// JournalMode is the journal mode (https://sqlite.org/pragma.html#pragma_journal_mode);
+	// WAL lets readers proceed while a write is in progress (empty = SQLite's default)
+	JournalMode string
+
+	// Synchronous is how often SQLite syncs to disk (https://sqlite.org/pragma.html#pragma_synchronous);
+	// NORMAL is safe in WAL mode and only risks the last transactions on
+	// power loss (empty = SQLite's default)
+	Synchronous string
+
+	// BusyTimeout is how long a connection waits for another to release a
+	// lock before failing with SQLITE_BUSY (0 = fail immediately)
+	BusyTimeout time.Duration
+}
+
+// Open returns a handle to the SQLite database at path, created if missing,
+// with opts applied to every connection. path may be a file: URI with
+// parameters of its own; opts take precedence. Transactions take the write
+// lock when they begin, so concurrent writers wait for each other instead of
+// failing when upgrading a read lock.
+func Open(path string, opts Options) (*sql.DB, error) {
+	if !cgoEnabled {
+		return nil, fmt.Errorf("sqlite: the binary was built without cgo; rebuild it with CGO_ENABLED=1 and a C compiler")
+	}
+	if path == "" {
+		return nil, fmt.Errorf("sqlite: database path is required")
+	}
+	name, query, _ := strings.Cut(path, "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: invalid parameters: %w", err)
+	}
+	if opts.JournalMode != "" {
+		params.Set("_journal_mode", opts.JournalMode)
+	}
+	if opts.Synchronous != "" {
+		params.Set("_synchronous", opts.Synchronous)
+	}
+	params.Set("_busy_timeout", fmt.Sprint(opts.BusyTimeout.Milliseconds()))
+	params.Set("_txlock", "immediate")
+	if !strings.HasPrefix(name, "file:") {
+		name = "file:" + name
+	}
+	return sql.Open(DriverName, name+"?"+params.Encode())
+}
@@ -0,0 +1,33 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen_AppliesOptions(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "test.db")+"?cache=shared", Options{
+		JournalMode: "WAL", Synchronous: "NORMAL", BusyTimeout: 2 * time.Second,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	var journalMode string
+	require.NoError(t, db.QueryRow("PRAGMA journal_mode").Scan(&journalMode))
+	assert.Equal(t, "wal", journalMode)
+
+	var synchronous, busyTimeout int
+	require.NoError(t, db.QueryRow("PRAGMA synchronous").Scan(&synchronous))
+	assert.Equal(t, 1, synchronous, "NORMAL")
+	require.NoError(t, db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout))
+	assert.Equal(t, 2000, busyTimeout)
+}
+
+func TestOpen_RequiresPath(t *testing.T) {
+	_, err := Open("", Options{})
+	assert.Error(t, err)
+}
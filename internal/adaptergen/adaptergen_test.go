@@ -0,0 +1,136 @@
+package adaptergen
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var adapters = []string{"airasia", "batikair", "garuda", "lionair"}
+
+func adapterDir(name string) string {
+	return filepath.Join("..", "adapter", "provider", name)
+}
+
+// TestGenerateModels_UpToDate fails when an adapter's models.go was edited by
+// hand or its schema changed without running go generate.
+func TestGenerateModels_UpToDate(t *testing.T) {
+	for _, name := range adapters {
+		t.Run(name, func(t *testing.T) {
+			schema, err := Load(filepath.Join(adapterDir(name), "schema.json"))
+			require.NoError(t, err)
+
+			want, err := os.ReadFile(filepath.Join(adapterDir(name), "models.go"))
+			require.NoError(t, err)
+
+			got, err := GenerateModels(schema, "schema.json")
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got), "run go generate ./internal/adapter/provider/...")
+		})
+	}
+}
+
+func TestGenerateSkeletons(t *testing.T) {
+	for _, name := range adapters {
+		t.Run(name, func(t *testing.T) {
+			schema, err := Load(filepath.Join(adapterDir(name), "schema.json"))
+			require.NoError(t, err)
+
+			adapter, err := GenerateAdapter(schema)
+			require.NoError(t, err)
+			assertParses(t, adapter)
+			assert.Contains(t, string(adapter), "var response "+schema.Response)
+
+			normalizer, err := GenerateNormalizer(schema)
+			require.NoError(t, err)
+			assertParses(t, normalizer)
+			assert.Contains(t, string(normalizer), "func normalizeFlight(f "+schema.FlightType()+")")
+		})
+	}
+}
+
+func TestGenerateNormalizer_Mapping(t *testing.T) {
+	schema, err := Load(filepath.Join(adapterDir("lionair"), "schema.json"))
+	require.NoError(t, err)
+
+	src, err := GenerateNormalizer(schema)
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "timeutil.ParseDateTime(f.Schedule.Departure, timeutil.WithTimezone(f.Schedule.DepartureTimezone), timeutil.WithAirport(f.Route.From.Code))")
+	assert.Contains(t, string(src), "durationMinutes := f.FlightTime")
+	assert.Contains(t, string(src), "Class:    strings.ToLower(f.Pricing.FareType)")
+	assert.NotContains(t, string(src), "durationfmt", "minute durations need no conversion")
+
+	schema.Mapping.Stops = ""
+	schema.Mapping.Duration = ""
+	src, err = GenerateNormalizer(schema)
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "// TODO: map Stops")
+	assert.Contains(t, string(src), "// TODO: map Duration")
+}
+
+func TestValidate(t *testing.T) {
+	base := func() *Schema {
+		return &Schema{
+			Package:     "acme",
+			Provider:    "acme_air",
+			DisplayName: "Acme Air",
+			Response:    "AcmeResponse",
+			Flights:     "Flights",
+			Types: []TypeDef{
+				{Name: "AcmeResponse", Fields: []FieldDef{{Name: "Flights", Type: "[]AcmeFlight", JSON: "flights"}}},
+				{Name: "AcmeFlight", Fields: []FieldDef{
+					{Name: "Number", Type: "string", JSON: "number"},
+					{Name: "From", Type: "string", JSON: "from"},
+					{Name: "To", Type: "string", JSON: "to"},
+					{Name: "Departs", Type: "string", JSON: "departs"},
+					{Name: "Arrives", Type: "string", JSON: "arrives"},
+					{Name: "Stops", Type: "int", JSON: "stops"},
+				}},
+			},
+			Mapping: Mapping{
+				FlightNumber:     "Number",
+				DepartureAirport: "From",
+				DepartureTime:    "Departs",
+				ArrivalAirport:   "To",
+				ArrivalTime:      "Arrives",
+			},
+		}
+	}
+	require.NoError(t, base().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*Schema)
+	}{
+		{"invalid package", func(s *Schema) { s.Package = "acme-air" }},
+		{"missing provider", func(s *Schema) { s.Provider = "" }},
+		{"unexported type", func(s *Schema) { s.Types[1].Name = "acmeFlight" }},
+		{"unknown field type", func(s *Schema) { s.Types[1].Fields[0].Type = "AcmeNumber" }},
+		{"missing json tag", func(s *Schema) { s.Types[1].Fields[0].JSON = "" }},
+		{"unknown response", func(s *Schema) { s.Response = "Missing" }},
+		{"flights not a slice", func(s *Schema) { s.Types[0].Fields[0].Type = "AcmeFlight" }},
+		{"unknown flights path", func(s *Schema) { s.Flights = "Data.Flights" }},
+		{"missing required mapping", func(s *Schema) { s.Mapping.DepartureTime = "" }},
+		{"unknown mapped field", func(s *Schema) { s.Mapping.Class = "Cabin" }},
+		{"mapped field of wrong type", func(s *Schema) { s.Mapping.Currency = "Stops" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := base()
+			tt.modify(s)
+			assert.ErrorIs(t, s.Validate(), ErrInvalidSchema)
+		})
+	}
+}
+
+func assertParses(t *testing.T, src []byte) {
+	t.Helper()
+	_, err := parser.ParseFile(token.NewFileSet(), "", src, parser.AllErrors)
+	assert.NoError(t, err)
+}
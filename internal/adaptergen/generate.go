@@ -0,0 +1,162 @@
+package adaptergen
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// modulePath is the import path prefix of the packages used by generated code.
+const modulePath = "github.com/flight-search/flight-search-and-aggregation-system"
+
+// GenerateModels returns the source of models.go: the package documentation
+// and every struct of the schema. source names the schema file in the
+// "Code generated" header.
+func GenerateModels(s *Schema, source string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by genadapter from %s. DO NOT EDIT.\n\n", source)
+	writeComment(&b, "", s.Doc)
+	fmt.Fprintf(&b, "package %s\n", s.Package)
+
+	for _, t := range s.Types {
+		b.WriteString("\n")
+		writeComment(&b, "", t.Doc)
+		fmt.Fprintf(&b, "type %s struct {\n", t.Name)
+		documented := hasFieldDocs(t)
+		for i, f := range t.Fields {
+			if documented && i > 0 {
+				b.WriteString("\n")
+			}
+			writeComment(&b, "\t", f.Doc)
+			fmt.Fprintf(&b, "\t%s %s `json:%q`\n", f.Name, f.Type, f.JSON)
+		}
+		b.WriteString("}\n")
+	}
+	return formatSource("models.go", b.Bytes())
+}
+
+// GenerateAdapter returns the source of an adapter.go skeleton reading the
+// provider's mock JSON data.
+func GenerateAdapter(s *Schema) ([]byte, error) {
+	return execute("adapter.go.tmpl", s, s)
+}
+
+// GenerateNormalizer returns the source of a normalizer.go skeleton mapping
+// provider flights to domain Flights according to the schema mapping.
+// Unmapped fields are left as TODO comments.
+func GenerateNormalizer(s *Schema) ([]byte, error) {
+	return execute("normalizer.go.tmpl", s, newNormalizerData(s))
+}
+
+// normalizerData holds the Go expressions used by the normalizer template.
+type normalizerData struct {
+	*Schema
+	Imports       []string
+	FlightType    string
+	DepartureTime string
+	ArrivalTime   string
+
+	// DurationKind is "int", "float64", "string" or empty if unmapped
+	DurationKind string
+
+	Fields map[string]string
+}
+
+func newNormalizerData(s *Schema) normalizerData {
+	m := s.Mapping
+	flight := s.FlightType()
+	d := normalizerData{
+		Schema:        s,
+		FlightType:    flight,
+		DepartureTime: parseTimeExpr(m.DepartureTime, m.DepartureTimezone, m.DepartureAirport),
+		ArrivalTime:   parseTimeExpr(m.ArrivalTime, m.ArrivalTimezone, m.ArrivalAirport),
+		Fields:        make(map[string]string),
+	}
+
+	imports := []string{"fmt"}
+	if m.Class != "" {
+		imports = append(imports, "strings")
+	}
+	imports = append(imports, "", modulePath+"/internal/domain")
+	if m.Duration != "" {
+		d.DurationKind, _ = s.resolve(flight, m.Duration)
+		if d.DurationKind != "int" {
+			imports = append(imports, modulePath+"/internal/durationfmt")
+		}
+	}
+	d.Imports = append(imports, modulePath+"/internal/infrastructure/timeutil")
+
+	for name, path := range map[string]string{
+		"FlightNumber": m.FlightNumber, "AirlineCode": m.AirlineCode, "AirlineName": m.AirlineName,
+		"DepartureAirport": m.DepartureAirport, "DepartureTimezone": m.DepartureTimezone,
+		"ArrivalAirport": m.ArrivalAirport, "ArrivalTimezone": m.ArrivalTimezone,
+		"Duration": m.Duration, "Currency": m.Currency, "Stops": m.Stops,
+	} {
+		if path != "" {
+			d.Fields[name] = "f." + path
+		}
+	}
+	if m.Price != "" {
+		d.Fields["Price"] = "f." + m.Price
+		if t, _ := s.resolve(flight, m.Price); t != "float64" {
+			d.Fields["Price"] = "float64(f." + m.Price + ")"
+		}
+	}
+	if m.Class != "" {
+		d.Fields["Class"] = "strings.ToLower(f." + m.Class + ")"
+	}
+	return d
+}
+
+// parseTimeExpr returns the timeutil.ParseDateTime call for a flight time.
+func parseTimeExpr(timePath, timezonePath, airportPath string) string {
+	args := []string{"f." + timePath}
+	if timezonePath != "" {
+		args = append(args, "timeutil.WithTimezone(f."+timezonePath+")")
+	}
+	args = append(args, "timeutil.WithAirport(f."+airportPath+")")
+	return "timeutil.ParseDateTime(" + strings.Join(args, ", ") + ")"
+}
+
+func execute(name string, s *Schema, data any) ([]byte, error) {
+	var b bytes.Buffer
+	if err := templates.ExecuteTemplate(&b, name, data); err != nil {
+		return nil, fmt.Errorf("failed to execute %s: %w", name, err)
+	}
+	return formatSource(strings.TrimSuffix(name, ".tmpl"), b.Bytes())
+}
+
+func formatSource(name string, src []byte) ([]byte, error) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("generated %s does not compile: %w", name, err)
+	}
+	return formatted, nil
+}
+
+func writeComment(b *bytes.Buffer, indent string, lines []string) {
+	for _, line := range lines {
+		if line == "" {
+			fmt.Fprintf(b, "%s//\n", indent)
+			continue
+		}
+		fmt.Fprintf(b, "%s// %s\n", indent, line)
+	}
+}
+
+func hasFieldDocs(t TypeDef) bool {
+	for _, f := range t.Fields {
+		if len(f.Doc) > 0 {
+			return true
+		}
+	}
+	return false
+}
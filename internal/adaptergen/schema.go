@@ -0,0 +1,262 @@
+// Package adaptergen generates provider adapter code from schema definitions.
+//
+// A schema describes a provider's response structs and how its flight fields
+// map to the domain Flight. From it, the generator emits the models.go of an
+// adapter and, for new adapters, skeletons of adapter.go and normalizer.go,
+// so that every adapter shares the same structure.
+package adaptergen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"strings"
+)
+
+// ErrInvalidSchema indicates a schema definition is incomplete or inconsistent.
+var ErrInvalidSchema = errors.New("invalid adapter schema")
+
+// Schema describes a provider adapter.
+type Schema struct {
+	// Package is the Go package name of the adapter (e.g., "garuda")
+	Package string `json:"package"`
+
+	// Doc is the package documentation, one line per entry
+	Doc []string `json:"doc,omitempty"`
+
+	// Provider is the unique provider identifier (e.g., "garuda_indonesia")
+	Provider string `json:"provider"`
+
+	// DisplayName is the human-readable provider name (e.g., "Garuda Indonesia")
+	DisplayName string `json:"display_name"`
+
+	// Response is the name of the root response type
+	Response string `json:"response"`
+
+	// Flights is the path of the flights slice in the response (e.g., "Data.AvailableFlights")
+	Flights string `json:"flights"`
+
+	// Types are the request and response structs, in output order
+	Types []TypeDef `json:"types"`
+
+	// Mapping maps domain Flight fields to paths in the provider flight
+	Mapping Mapping `json:"mapping"`
+}
+
+// TypeDef describes a struct type.
+type TypeDef struct {
+	Name   string     `json:"name"`
+	Doc    []string   `json:"doc,omitempty"`
+	Fields []FieldDef `json:"fields"`
+}
+
+// FieldDef describes a struct field.
+type FieldDef struct {
+	Name string `json:"name"`
+
+	// Type is a Go type: a builtin, a type of the schema, or a slice or
+	// pointer of those (e.g., "[]GarudaSegment")
+	Type string `json:"type"`
+
+	// JSON is the json struct tag value (e.g., "amenities,omitempty")
+	JSON string `json:"json"`
+
+	Doc []string `json:"doc,omitempty"`
+}
+
+// Mapping maps domain Flight fields to field paths in the provider flight,
+// such as "Departure.Time". Empty paths are left as TODOs in the skeleton.
+type Mapping struct {
+	FlightNumber      string `json:"flight_number"`
+	AirlineCode       string `json:"airline_code,omitempty"`
+	AirlineName       string `json:"airline_name,omitempty"`
+	DepartureAirport  string `json:"departure_airport"`
+	DepartureTime     string `json:"departure_time"`
+	DepartureTimezone string `json:"departure_timezone,omitempty"`
+	ArrivalAirport    string `json:"arrival_airport"`
+	ArrivalTime       string `json:"arrival_time"`
+	ArrivalTimezone   string `json:"arrival_timezone,omitempty"`
+
+	// Duration may be minutes (int), hours (float64) or a duration string
+	Duration string `json:"duration,omitempty"`
+
+	Price    string `json:"price,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	Class    string `json:"class,omitempty"`
+	Stops    string `json:"stops,omitempty"`
+}
+
+// builtinTypes are the non-struct field types a schema may use.
+var builtinTypes = map[string]bool{
+	"string": true, "bool": true, "int": true, "int64": true, "float64": true,
+}
+
+// Load reads and validates a schema file.
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate checks that the schema is complete, that every field type is known
+// and that the flights path and mapping resolve to fields of the right type.
+func (s *Schema) Validate() error {
+	if !token.IsIdentifier(s.Package) {
+		return fmt.Errorf("%w: package %q is not a valid identifier", ErrInvalidSchema, s.Package)
+	}
+	if s.Provider == "" || s.DisplayName == "" {
+		return fmt.Errorf("%w: provider and display_name are required", ErrInvalidSchema)
+	}
+
+	types := make(map[string]bool, len(s.Types))
+	for _, t := range s.Types {
+		if !token.IsExported(t.Name) || types[t.Name] {
+			return fmt.Errorf("%w: type name %q must be exported and unique", ErrInvalidSchema, t.Name)
+		}
+		types[t.Name] = true
+	}
+	for _, t := range s.Types {
+		fields := make(map[string]bool, len(t.Fields))
+		for _, f := range t.Fields {
+			if !token.IsExported(f.Name) || fields[f.Name] {
+				return fmt.Errorf("%w: %s field %q must be exported and unique", ErrInvalidSchema, t.Name, f.Name)
+			}
+			fields[f.Name] = true
+			if base := elemType(f.Type); !builtinTypes[base] && !types[base] {
+				return fmt.Errorf("%w: %s.%s has unknown type %q", ErrInvalidSchema, t.Name, f.Name, f.Type)
+			}
+			if f.JSON == "" {
+				return fmt.Errorf("%w: %s.%s has no json tag", ErrInvalidSchema, t.Name, f.Name)
+			}
+		}
+	}
+
+	if !types[s.Response] {
+		return fmt.Errorf("%w: response type %q is not defined", ErrInvalidSchema, s.Response)
+	}
+	flightsType, err := s.resolve(s.Response, s.Flights)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(flightsType, "[]") || !types[flightsType[2:]] {
+		return fmt.Errorf("%w: flights path %q must be a slice of a schema type, got %q", ErrInvalidSchema, s.Flights, flightsType)
+	}
+	return s.validateMapping()
+}
+
+// FlightType returns the name of the provider flight type.
+func (s *Schema) FlightType() string {
+	t, _ := s.resolve(s.Response, s.Flights)
+	return strings.TrimPrefix(t, "[]")
+}
+
+// validateMapping checks the mapped paths against the flight type.
+func (s *Schema) validateMapping() error {
+	m := s.Mapping
+	checks := []struct {
+		name     string
+		path     string
+		required bool
+		types    []string
+	}{
+		{"flight_number", m.FlightNumber, true, []string{"string"}},
+		{"airline_code", m.AirlineCode, false, []string{"string"}},
+		{"airline_name", m.AirlineName, false, []string{"string"}},
+		{"departure_airport", m.DepartureAirport, true, []string{"string"}},
+		{"departure_time", m.DepartureTime, true, []string{"string"}},
+		{"departure_timezone", m.DepartureTimezone, false, []string{"string"}},
+		{"arrival_airport", m.ArrivalAirport, true, []string{"string"}},
+		{"arrival_time", m.ArrivalTime, true, []string{"string"}},
+		{"arrival_timezone", m.ArrivalTimezone, false, []string{"string"}},
+		{"duration", m.Duration, false, []string{"int", "float64", "string"}},
+		{"price", m.Price, false, []string{"float64", "int", "int64"}},
+		{"currency", m.Currency, false, []string{"string"}},
+		{"class", m.Class, false, []string{"string"}},
+		{"stops", m.Stops, false, []string{"int"}},
+	}
+
+	flight := s.FlightType()
+	for _, c := range checks {
+		if c.path == "" {
+			if c.required {
+				return fmt.Errorf("%w: mapping %s is required", ErrInvalidSchema, c.name)
+			}
+			continue
+		}
+		t, err := s.resolve(flight, c.path)
+		if err != nil {
+			return fmt.Errorf("mapping %s: %w", c.name, err)
+		}
+		if !contains(c.types, t) {
+			return fmt.Errorf("%w: mapping %s must be %s, %s is %s",
+				ErrInvalidSchema, c.name, strings.Join(c.types, " or "), c.path, t)
+		}
+	}
+	return nil
+}
+
+// resolve returns the type of a dotted field path starting at a schema type.
+func (s *Schema) resolve(typeName, path string) (string, error) {
+	current := typeName
+	for _, name := range strings.Split(path, ".") {
+		t, ok := s.typeDef(current)
+		if !ok {
+			return "", fmt.Errorf("%w: path %q goes through non-struct type %q", ErrInvalidSchema, path, current)
+		}
+		field, ok := t.field(name)
+		if !ok {
+			return "", fmt.Errorf("%w: %s has no field %q (path %q)", ErrInvalidSchema, t.Name, name, path)
+		}
+		current = field.Type
+	}
+	return current, nil
+}
+
+func (s *Schema) typeDef(name string) (TypeDef, bool) {
+	for _, t := range s.Types {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return TypeDef{}, false
+}
+
+func (t TypeDef) field(name string) (FieldDef, bool) {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return FieldDef{}, false
+}
+
+// elemType strips slice and pointer prefixes from a type.
+func elemType(t string) string {
+	for {
+		trimmed := strings.TrimPrefix(strings.TrimPrefix(t, "[]"), "*")
+		if trimmed == t {
+			return t
+		}
+		t = trimmed
+	}
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
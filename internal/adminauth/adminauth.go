@@ -0,0 +1,207 @@
+// Package adminauth authenticates service-to-service calls to the admin API.
+//
+// Callers present short-lived bearer tokens signed with a shared secret that
+// is separate from the partner API keys. Tokens use the JWT compact format
+// with HS256 signatures and carry role claims: admin:read allows inspecting
+// state, admin:write additionally allows mutations, and admin:debug allows
+// debug options that expose provider internals.
+package adminauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// Audience is the audience claim of admin tokens, so tokens minted for
+// other services with the same secret are not accepted.
+const Audience = "flight-search-admin"
+
+// MinSecretLength is the minimum signing secret length in bytes.
+const MinSecretLength = 32
+
+// clockSkew is the tolerated difference between the issuer's and verifier's clocks.
+const clockSkew = 30 * time.Second
+
+// Role is an admin permission carried by a token.
+type Role string
+
+// Admin roles.
+const (
+	// RoleRead allows read-only admin operations
+	RoleRead Role = "admin:read"
+
+	// RoleWrite allows mutating admin operations; it implies RoleRead
+	RoleWrite Role = "admin:write"
+
+	// RoleDebug allows debug options such as debug replays; it implies nothing else
+	RoleDebug Role = "admin:debug"
+)
+
+// IsValid checks if the role is a valid value.
+func (r Role) IsValid() bool {
+	return r == RoleRead || r == RoleWrite || r == RoleDebug
+}
+
+// Sentinel errors returned by Verify.
+var (
+	// ErrInvalidToken is returned for malformed tokens and bad signatures
+	ErrInvalidToken = errors.New("invalid admin token")
+
+	// ErrTokenExpired is returned for tokens past their expiry
+	ErrTokenExpired = errors.New("admin token expired")
+
+	// ErrTokenLifetime is returned for tokens valid for longer than the verifier allows
+	ErrTokenLifetime = errors.New("admin token lifetime exceeds maximum")
+)
+
+// Claims are the verified contents of a token.
+type Claims struct {
+	// Subject identifies the calling service
+	Subject   string `json:"sub"`
+	Audience  string `json:"aud"`
+	Roles     []Role `json:"roles"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// HasRole reports whether the claims grant role. RoleWrite implies RoleRead.
+func (c Claims) HasRole(role Role) bool {
+	if slices.Contains(c.Roles, role) {
+		return true
+	}
+	return role == RoleRead && slices.Contains(c.Roles, RoleWrite)
+}
+
+// header is the fixed JWT header of admin tokens.
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Authority issues and verifies admin tokens.
+type Authority struct {
+	secret []byte
+	maxTTL time.Duration
+	clock  timeutil.Clock
+}
+
+// Option configures an Authority.
+type Option func(*Authority)
+
+// WithClock sets the clock used for issuing and expiry checks.
+func WithClock(clock timeutil.Clock) Option {
+	return func(a *Authority) {
+		a.clock = clock
+	}
+}
+
+// NewAuthority creates an authority signing with secret. Tokens valid for
+// longer than maxTTL are neither issued nor accepted.
+func NewAuthority(secret []byte, maxTTL time.Duration, opts ...Option) (*Authority, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("secret must be at least %d bytes", MinSecretLength)
+	}
+	if maxTTL <= 0 {
+		return nil, errors.New("maximum token lifetime must be positive")
+	}
+
+	a := &Authority{secret: secret, maxTTL: maxTTL, clock: timeutil.NewRealClock()}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a, nil
+}
+
+// Issue returns a token for subject granting roles, valid for ttl.
+func (a *Authority) Issue(subject string, ttl time.Duration, roles ...Role) (string, error) {
+	if subject == "" {
+		return "", errors.New("subject is required")
+	}
+	if ttl <= 0 || ttl > a.maxTTL {
+		return "", fmt.Errorf("ttl must be between 0 and %s", a.maxTTL)
+	}
+	if len(roles) == 0 {
+		return "", errors.New("at least one role is required")
+	}
+	for _, role := range roles {
+		if !role.IsValid() {
+			return "", fmt.Errorf("invalid role %q", role)
+		}
+	}
+
+	now := a.clock.Now()
+	payload, err := json.Marshal(Claims{
+		Subject:   subject,
+		Audience:  Audience,
+		Roles:     roles,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signingInput + "." + a.sign(signingInput), nil
+}
+
+// Verify checks a token's signature, audience and validity window and returns its claims.
+func (a *Authority) Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return Claims{}, ErrInvalidToken
+	}
+	signingInput := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(a.sign(signingInput))) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Subject == "" || claims.Audience != Audience {
+		return Claims{}, ErrInvalidToken
+	}
+
+	now := a.clock.Now()
+	issued, expires := time.Unix(claims.IssuedAt, 0), time.Unix(claims.ExpiresAt, 0)
+	switch {
+	case issued.After(now.Add(clockSkew)):
+		return Claims{}, ErrInvalidToken
+	case !now.Before(expires.Add(clockSkew)):
+		return Claims{}, ErrTokenExpired
+	case expires.Sub(issued) > a.maxTTL:
+		return Claims{}, ErrTokenLifetime
+	}
+	return claims, nil
+}
+
+// sign returns the encoded HS256 signature of the signing input.
+func (a *Authority) sign(signingInput string) string {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// claimsKey is the context key for verified claims.
+type claimsKey struct{}
+
+// WithClaims returns a context carrying the verified claims of the caller.
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the caller's claims, if the request was authenticated.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
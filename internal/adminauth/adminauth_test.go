@@ -0,0 +1,111 @@
+package adminauth
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+var testSecret = []byte(strings.Repeat("s", MinSecretLength))
+
+func newAuthority(t *testing.T, clock *timeutil.MockClock) *Authority {
+	t.Helper()
+	a, err := NewAuthority(testSecret, 15*time.Minute, WithClock(clock))
+	require.NoError(t, err)
+	return a
+}
+
+func TestAuthority_IssueAndVerify(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	a := newAuthority(t, clock)
+
+	token, err := a.Issue("replay-bot", 5*time.Minute, RoleWrite)
+	require.NoError(t, err)
+
+	claims, err := a.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "replay-bot", claims.Subject)
+	assert.True(t, claims.HasRole(RoleWrite))
+	assert.True(t, claims.HasRole(RoleRead), "write implies read")
+
+	readOnly := Claims{Roles: []Role{RoleRead}}
+	assert.False(t, readOnly.HasRole(RoleWrite))
+
+	clock.Advance(5*time.Minute + clockSkew)
+	_, err = a.Verify(token)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestAuthority_VerifyRejects(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	a := newAuthority(t, clock)
+	token, err := a.Issue("dashboard", time.Minute, RoleRead)
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+
+	other, err := NewAuthority([]byte(strings.Repeat("x", MinSecretLength)), 15*time.Minute, WithClock(clock))
+	require.NoError(t, err)
+	foreign, err := other.Issue("dashboard", time.Minute, RoleRead)
+	require.NoError(t, err)
+
+	// A token from a more permissive issuer sharing the secret
+	lenient, err := NewAuthority(testSecret, 24*time.Hour, WithClock(clock))
+	require.NoError(t, err)
+	longLived, err := lenient.Issue("dashboard", 24*time.Hour, RoleRead)
+	require.NoError(t, err)
+
+	forged := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"x","aud":"flight-search-admin","roles":["admin:write"],"iat":0,"exp":9999999999}`)) + "." + parts[2]
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"empty", "", ErrInvalidToken},
+		{"not a JWT", "opaque-token", ErrInvalidToken},
+		{"other algorithm", base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + parts[1] + ".", ErrInvalidToken},
+		{"wrong secret", foreign, ErrInvalidToken},
+		{"forged claims", forged, ErrInvalidToken},
+		{"lifetime too long", longLived, ErrTokenLifetime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.Verify(tt.token)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAuthority_IssueErrors(t *testing.T) {
+	a := newAuthority(t, timeutil.NewMockClock(time.Now()))
+
+	_, err := a.Issue("", time.Minute, RoleRead)
+	assert.ErrorContains(t, err, "subject is required")
+	_, err = a.Issue("bot", time.Hour, RoleRead)
+	assert.ErrorContains(t, err, "ttl must be between 0 and 15m0s")
+	_, err = a.Issue("bot", time.Minute)
+	assert.ErrorContains(t, err, "at least one role is required")
+	_, err = a.Issue("bot", time.Minute, "admin")
+	assert.ErrorContains(t, err, `invalid role "admin"`)
+
+	_, err = NewAuthority([]byte("short"), time.Minute)
+	assert.ErrorContains(t, err, "secret must be at least 32 bytes")
+}
+
+func TestClaimsContext(t *testing.T) {
+	_, ok := ClaimsFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithClaims(context.Background(), Claims{Subject: "bot"})
+	claims, ok := ClaimsFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "bot", claims.Subject)
+}
@@ -0,0 +1,149 @@
+// Package analytics exports anonymized search analytics.
+//
+// Exports contain one row per route and UTC day with aggregate result
+// statistics only: no search IDs, client identifiers or individual
+// searches. Routes searched fewer than a minimum number of times can be
+// suppressed so rare searches cannot be traced back to a single client.
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// Source provides the daily search aggregates to export.
+type Source interface {
+	// DailySummary returns the aggregates for the UTC day containing day.
+	DailySummary(ctx context.Context, day time.Time) ([]domain.SearchAggregate, error)
+}
+
+// Sink stores exported files. blob.Store implementations satisfy it.
+type Sink interface {
+	// Put stores the content read from r under key, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader) error
+}
+
+// KeyPrefix is the prefix of exported object keys.
+const KeyPrefix = "exports/search_analytics/"
+
+// Key returns the object key of the export for the UTC day containing day.
+func Key(day time.Time) string {
+	return KeyPrefix + day.UTC().Format("2006-01-02") + ".csv"
+}
+
+// csvHeader lists the exported columns.
+var csvHeader = []string{
+	"date", "origin", "destination", "searches", "empty_searches",
+	"avg_results", "min_price", "max_price", "currency",
+}
+
+// Result describes a completed export.
+type Result struct {
+	// Key is the object key the export was stored under
+	Key string
+
+	// Rows is the number of exported routes
+	Rows int
+
+	// Suppressed is the number of routes left out by the minimum search threshold
+	Suppressed int
+}
+
+// Exporter writes daily analytics exports to a sink.
+type Exporter struct {
+	source      Source
+	sink        Sink
+	clock       timeutil.Clock
+	minSearches int
+}
+
+// Option configures an Exporter.
+type Option func(*Exporter)
+
+// WithClock sets the clock used to determine the previous day.
+func WithClock(clock timeutil.Clock) Option {
+	return func(e *Exporter) {
+		e.clock = clock
+	}
+}
+
+// WithMinSearches suppresses routes searched fewer than n times on a day.
+func WithMinSearches(n int) Option {
+	return func(e *Exporter) {
+		e.minSearches = n
+	}
+}
+
+// NewExporter creates an exporter reading from source and writing to sink.
+func NewExporter(source Source, sink Sink, opts ...Option) *Exporter {
+	e := &Exporter{
+		source:      source,
+		sink:        sink,
+		clock:       timeutil.NewRealClock(),
+		minSearches: 1,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// ExportPreviousDay exports the last complete UTC day.
+func (e *Exporter) ExportPreviousDay(ctx context.Context) (Result, error) {
+	return e.Export(ctx, e.clock.Now().UTC().AddDate(0, 0, -1))
+}
+
+// Export writes the analytics for the UTC day containing day as CSV,
+// replacing any earlier export of the same day.
+func (e *Exporter) Export(ctx context.Context, day time.Time) (Result, error) {
+	aggregates, err := e.source.DailySummary(ctx, day)
+	if err != nil {
+		return Result{}, fmt.Errorf("summarize searches: %w", err)
+	}
+
+	result := Result{Key: Key(day)}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(csvHeader)
+	for _, agg := range aggregates {
+		if agg.Searches < e.minSearches {
+			result.Suppressed++
+			continue
+		}
+		_ = w.Write(csvRow(agg))
+		result.Rows++
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return Result{}, fmt.Errorf("encode analytics: %w", err)
+	}
+
+	if err := e.sink.Put(ctx, result.Key, &buf); err != nil {
+		return Result{}, fmt.Errorf("store analytics export: %w", err)
+	}
+	return result, nil
+}
+
+// csvRow formats an aggregate as a CSV record.
+func csvRow(agg domain.SearchAggregate) []string {
+	return []string{
+		agg.Date,
+		agg.Origin,
+		agg.Destination,
+		strconv.Itoa(agg.Searches),
+		strconv.Itoa(agg.EmptySearches),
+		strconv.FormatFloat(agg.AverageResults(), 'f', 2, 64),
+		strconv.FormatFloat(agg.MinPrice, 'f', -1, 64),
+		strconv.FormatFloat(agg.MaxPrice, 'f', -1, 64),
+		agg.Currency,
+	}
+}
@@ -0,0 +1,67 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/storage/blob"
+)
+
+type stubSource struct {
+	day        time.Time
+	aggregates []domain.SearchAggregate
+	err        error
+}
+
+func (s *stubSource) DailySummary(_ context.Context, day time.Time) ([]domain.SearchAggregate, error) {
+	s.day = day
+	return s.aggregates, s.err
+}
+
+func TestExporter_ExportPreviousDay(t *testing.T) {
+	dir := t.TempDir()
+	source := &stubSource{aggregates: []domain.SearchAggregate{
+		{Date: "2025-12-01", Origin: "CGK", Destination: "DPS", Searches: 12, EmptySearches: 2, TotalResults: 30, MinPrice: 750000, MaxPrice: 1450000.5, Currency: "IDR"},
+		{Date: "2025-12-01", Origin: "CGK", Destination: "KNO", Searches: 2, TotalResults: 4, MinPrice: 900000, MaxPrice: 900000, Currency: "IDR"},
+		{Date: "2025-12-01", Origin: "SUB", Destination: "CGK", Searches: 5, EmptySearches: 5},
+	}}
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 2, 0, 5, 0, 0, time.UTC))
+	exporter := NewExporter(source, blob.NewFileStore(dir), WithClock(clock), WithMinSearches(5))
+
+	result, err := exporter.ExportPreviousDay(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Result{Key: "exports/search_analytics/2025-12-01.csv", Rows: 2, Suppressed: 1}, result)
+	assert.Equal(t, "2025-12-01", source.day.Format("2006-01-02"))
+
+	data, err := os.ReadFile(filepath.Join(dir, "exports", "search_analytics", "2025-12-01.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"date,origin,destination,searches,empty_searches,avg_results,min_price,max_price,currency\n"+
+		"2025-12-01,CGK,DPS,12,2,2.50,750000,1450000.5,IDR\n"+
+		"2025-12-01,SUB,CGK,5,5,0.00,0,0,\n",
+		string(data))
+
+	// Re-exporting the same day replaces the file
+	source.aggregates = nil
+	_, err = exporter.ExportPreviousDay(context.Background())
+	require.NoError(t, err)
+	data, err = os.ReadFile(filepath.Join(dir, "exports", "search_analytics", "2025-12-01.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "date,origin,destination,searches,empty_searches,avg_results,min_price,max_price,currency\n", string(data))
+}
+
+func TestExporter_SourceError(t *testing.T) {
+	exporter := NewExporter(&stubSource{err: errors.New("store unavailable")}, blob.NewFileStore(t.TempDir()))
+
+	_, err := exporter.Export(context.Background(), time.Now())
+	assert.ErrorContains(t, err, "summarize searches: store unavailable")
+}
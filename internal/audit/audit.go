@@ -0,0 +1,236 @@
+// Package audit records the mutations made through the admin API in an
+// append-only trail: who changed what, when, and the values before and after.
+//
+// Entries are never modified or removed. A trail backed by a file appends
+// each entry as a JSON line and syncs it before the entry is reported as
+// recorded; the whole trail is also kept in memory to serve queries, which
+// suits the low rate of admin mutations.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// ErrClosed is returned when recording to a closed trail.
+var ErrClosed = errors.New("audit: trail is closed")
+
+// DefaultLimit is the number of entries List returns when the query sets no limit.
+const DefaultLimit = 100
+
+// Entry is a recorded admin mutation.
+type Entry struct {
+	ID string `json:"id"`
+
+	// At is when the mutation was recorded
+	At time.Time `json:"at"`
+
+	// Actor identifies the caller that made the mutation
+	Actor string `json:"actor"`
+
+	// Action names the mutation, e.g. "queue.job.requeue"
+	Action string `json:"action"`
+
+	// Resource identifies what was mutated, e.g. "queue/jobs/<id>"
+	Resource string `json:"resource"`
+
+	// Before and After are the JSON encodings of the resource before and
+	// after the mutation (absent for creations and deletions, respectively)
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// Mutation describes an admin mutation to record.
+type Mutation struct {
+	Actor    string
+	Action   string
+	Resource string
+
+	// Before and After are encoded as JSON; nil values are omitted
+	Before any
+	After  any
+}
+
+// Query selects entries. Zero values select everything.
+type Query struct {
+	Actor  string
+	Action string
+
+	// Limit is the maximum number of entries returned (0 = DefaultLimit)
+	Limit int
+}
+
+// Trail is an append-only audit trail. It is safe for concurrent use.
+type Trail struct {
+	mu      sync.Mutex
+	entries []Entry
+	file    *os.File
+	closed  bool
+	clock   timeutil.Clock
+}
+
+// Option configures a Trail.
+type Option func(*Trail)
+
+// WithClock sets the clock timestamping entries (default: real time).
+func WithClock(clock timeutil.Clock) Option {
+	return func(t *Trail) {
+		t.clock = clock
+	}
+}
+
+// New creates an empty trail kept in memory only.
+func New(opts ...Option) *Trail {
+	t := &Trail{clock: timeutil.NewRealClock()}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Open loads the trail persisted in the JSON Lines file at path, created
+// with its directory if missing, and appends new entries to it. An entry
+// left incomplete by a crash is discarded.
+func Open(path string, opts ...Option) (*Trail, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create audit trail directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit trail: %w", err)
+	}
+
+	t := New(opts...)
+	if err := t.load(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	t.file = f
+	return t, nil
+}
+
+// load reads the entries of f and positions it for appending.
+func (t *Trail) load(f *os.File) error {
+	var complete int64
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// A line without its newline was not fully written
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read audit trail: %w", err)
+		}
+		complete += int64(len(line))
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return fmt.Errorf("parse audit trail entry %d: %w", len(t.entries)+1, err)
+		}
+		t.entries = append(t.entries, e)
+	}
+
+	if err := f.Truncate(complete); err != nil {
+		return fmt.Errorf("truncate incomplete audit trail entry: %w", err)
+	}
+	if _, err := f.Seek(complete, io.SeekStart); err != nil {
+		return fmt.Errorf("seek audit trail: %w", err)
+	}
+	return nil
+}
+
+// Record appends the mutation to the trail and returns its entry.
+func (t *Trail) Record(m Mutation) (Entry, error) {
+	e := Entry{
+		ID:       uuid.NewString(),
+		Actor:    m.Actor,
+		Action:   m.Action,
+		Resource: m.Resource,
+	}
+	var err error
+	if e.Before, err = encode(m.Before); err != nil {
+		return Entry{}, fmt.Errorf("encode value before %s: %w", m.Action, err)
+	}
+	if e.After, err = encode(m.After); err != nil {
+		return Entry{}, fmt.Errorf("encode value after %s: %w", m.Action, err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return Entry{}, ErrClosed
+	}
+	e.At = t.clock.Now().UTC()
+	if t.file != nil {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return Entry{}, fmt.Errorf("encode audit entry: %w", err)
+		}
+		if _, err := t.file.Write(append(line, '\n')); err != nil {
+			return Entry{}, fmt.Errorf("write audit entry: %w", err)
+		}
+		if err := t.file.Sync(); err != nil {
+			return Entry{}, fmt.Errorf("sync audit trail: %w", err)
+		}
+	}
+	t.entries = append(t.entries, e)
+	return e, nil
+}
+
+// List returns the entries matching q, newest first.
+func (t *Trail) List(q Query) []Entry {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var result []Entry
+	for i := len(t.entries) - 1; i >= 0 && len(result) < limit; i-- {
+		e := t.entries[i]
+		if (q.Actor == "" || e.Actor == q.Actor) && (q.Action == "" || e.Action == q.Action) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Close closes the trail's file, if any. Closed trails can still be listed
+// but no longer record.
+func (t *Trail) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = true
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+// encode returns the JSON encoding of v, or nil for a nil v.
+func encode(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
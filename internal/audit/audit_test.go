@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+func TestTrail_RecordAndList(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	trail := New(WithClock(clock))
+
+	first, err := trail.Record(Mutation{
+		Actor: "ops-console", Action: "queue.job.requeue", Resource: "queue/jobs/1",
+		Before: map[string]string{"status": "dead"}, After: map[string]string{"status": "pending"},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, first.ID)
+	assert.Equal(t, clock.Now(), first.At)
+	assert.JSONEq(t, `{"status":"dead"}`, string(first.Before))
+	assert.JSONEq(t, `{"status":"pending"}`, string(first.After))
+
+	clock.Advance(time.Minute)
+	_, err = trail.Record(Mutation{Actor: "deploy-bot", Action: "queue.job.requeue", Resource: "queue/jobs/2"})
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+	_, err = trail.Record(Mutation{Actor: "ops-console", Action: "cache.purge", Resource: "cache", Before: nil})
+	require.NoError(t, err)
+
+	actions := func(entries []Entry) []string {
+		var actions []string
+		for _, e := range entries {
+			actions = append(actions, e.Actor+" "+e.Action)
+		}
+		return actions
+	}
+	assert.Equal(t, []string{"ops-console cache.purge", "deploy-bot queue.job.requeue", "ops-console queue.job.requeue"},
+		actions(trail.List(Query{})), "newest first")
+	assert.Equal(t, []string{"ops-console cache.purge", "ops-console queue.job.requeue"},
+		actions(trail.List(Query{Actor: "ops-console"})))
+	assert.Equal(t, []string{"deploy-bot queue.job.requeue"},
+		actions(trail.List(Query{Action: "queue.job.requeue", Limit: 1})))
+	assert.Nil(t, trail.List(Query{})[0].Before, "nil values are omitted")
+}
+
+func TestTrail_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "trail.jsonl")
+
+	trail, err := Open(path)
+	require.NoError(t, err)
+	recorded, err := trail.Record(Mutation{Actor: "ops-console", Action: "queue.job.requeue", Resource: "queue/jobs/1", After: 1})
+	require.NoError(t, err)
+	require.NoError(t, trail.Close())
+
+	_, err = trail.Record(Mutation{Action: "queue.job.requeue"})
+	assert.ErrorIs(t, err, ErrClosed)
+
+	// Simulate a crash in the middle of writing an entry
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"id":"partial","act`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	trail, err = Open(path)
+	require.NoError(t, err)
+	defer trail.Close()
+	entries := trail.List(Query{})
+	require.Len(t, entries, 1, "the incomplete entry is discarded")
+	assert.Equal(t, recorded.ID, entries[0].ID)
+	assert.True(t, recorded.At.Equal(entries[0].At))
+
+	_, err = trail.Record(Mutation{Actor: "ops-console", Action: "cache.purge", Resource: "cache"})
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "partial")
+	assert.Len(t, trail.List(Query{}), 2)
+}
+
+func TestOpen_InvalidEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trail.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+
+	_, err := Open(path)
+	assert.ErrorContains(t, err, "parse audit trail entry 1")
+}
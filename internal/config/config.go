@@ -3,44 +3,790 @@
 package config
 
 import (
+	"database/sql"
 	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v10"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog/log"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/airasia"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/batikair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/garuda"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/httpsource"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/lionair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adminauth"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/cache"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/encryption"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/retry"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/signing"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/jwtauth"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/ratelimit"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/sanitize"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/scheduler"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/share"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/storage/blob"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/webhook"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/workerpool"
 )
 
 // Config holds all application configuration.
 type Config struct {
-	Server   ServerConfig
-	Timeouts TimeoutConfig
-	Logging  LoggingConfig
-	App      AppConfig
+	Server     ServerConfig
+	Timeouts   TimeoutConfig
+	Logging    LoggingConfig
+	App        AppConfig
+	Providers  ProviderConfig
+	Routing    RoutingConfig
+	Pricing    PricingConfig
+	LoadShed   LoadShedConfig
+	Validation ValidationConfig
+	Sanitize   SanitizeConfig
+	History    HistoryConfig
+	Audit      SearchAuditConfig
+	Tenants    TenantConfig
+	Scheduler  SchedulerConfig
+	Queue      QueueConfig
+	Encryption EncryptionConfig
+	Signing    SigningConfig
+	Analytics  AnalyticsConfig
+	Blob       BlobConfig
+	Cache      CacheConfig
+	Admin      AdminConfig
+	Auth       AuthConfig
+	Share      ShareConfig
+	Callback   CallbackConfig
+	Database   DatabaseConfig
 }
 
 // ServerConfig holds HTTP server settings.
 type ServerConfig struct {
-	Port         int           `env:"SERVER_PORT" envDefault:"8080"`
-	ReadTimeout  time.Duration `env:"SERVER_READ_TIMEOUT" envDefault:"10s"`
+	// Port is the port the HTTP server listens on
+	Port int `env:"SERVER_PORT" envDefault:"8080"`
+
+	// ReadTimeout bounds how long a client may take to send a request
+	ReadTimeout time.Duration `env:"SERVER_READ_TIMEOUT" envDefault:"10s"`
+
+	// WriteTimeout bounds how long writing a response may take
 	WriteTimeout time.Duration `env:"SERVER_WRITE_TIMEOUT" envDefault:"10s"`
+
+	// ReadHeaderTimeout bounds how long a client may take to send request headers
+	ReadHeaderTimeout time.Duration `env:"SERVER_READ_HEADER_TIMEOUT" envDefault:"5s"`
+
+	// IdleTimeout bounds how long keep-alive connections may stay idle
+	IdleTimeout time.Duration `env:"SERVER_IDLE_TIMEOUT" envDefault:"60s"`
+
+	// MaxConnections caps concurrently served requests (0 = unlimited)
+	MaxConnections int `env:"SERVER_MAX_CONNECTIONS" envDefault:"1000"`
+
+	// MaxConnectionsPerIP caps concurrently served requests per client IP (0 = unlimited)
+	MaxConnectionsPerIP int `env:"SERVER_MAX_CONNECTIONS_PER_IP" envDefault:"50"`
+
+	// MaxConcurrentSearches caps searches served concurrently, bounding the
+	// provider connections and memory they hold (0 = unlimited)
+	MaxConcurrentSearches int `env:"SERVER_MAX_CONCURRENT_SEARCHES" envDefault:"100"`
+
+	// RetryAfter is suggested to clients rejected because the server is saturated
+	RetryAfter time.Duration `env:"SERVER_RETRY_AFTER" envDefault:"1s"`
+
+	// MaxRequestBodyBytes rejects request bodies larger than this many bytes with a 413
+	MaxRequestBodyBytes int64 `env:"SERVER_MAX_REQUEST_BODY_BYTES" envDefault:"1048576"`
+
+	// CompressionLevel is the gzip level of responses to clients accepting
+	// gzip, from 1 (fastest) to 9 (smallest) (0 = no compression)
+	CompressionLevel int `env:"SERVER_COMPRESSION_LEVEL" envDefault:"5"`
+
+	// CompressionMinBytes is the response size from which responses are compressed
+	CompressionMinBytes int `env:"SERVER_COMPRESSION_MIN_BYTES" envDefault:"1024"`
 }
 
 // TimeoutConfig holds timeout settings for flight search operations.
 type TimeoutConfig struct {
+	// GlobalSearch is the search budget for interactive requests
 	GlobalSearch time.Duration `env:"TIMEOUT_GLOBAL_SEARCH" envDefault:"5s"`
-	PerProvider  time.Duration `env:"TIMEOUT_PER_PROVIDER" envDefault:"2s"`
+
+	// PerProvider bounds each provider search, retries included
+	PerProvider time.Duration `env:"TIMEOUT_PER_PROVIDER" envDefault:"2s"`
+
+	// PerProviderByProvider overrides PerProvider per provider, e.g.
+	// "lion_air=3s"
+	PerProviderByProvider map[string]time.Duration `env:"TIMEOUT_PER_PROVIDER_BY_PROVIDER" envKeyValSeparator:"="`
+
+	// GlobalSearchBatch is the search budget for batch/bulk requests
+	GlobalSearchBatch time.Duration `env:"TIMEOUT_GLOBAL_SEARCH_BATCH" envDefault:"30s"`
+
+	// SoftDeadline is when searches accepting partial results return the
+	// answers received so far
+	SoftDeadline time.Duration `env:"TIMEOUT_SOFT_DEADLINE" envDefault:"1500ms"`
+
+	// ContinuationTTL is how long partial searches are held for their late
+	// provider results to be fetched with the continuation token
+	ContinuationTTL time.Duration `env:"TIMEOUT_CONTINUATION_TTL" envDefault:"30s"`
 }
 
 // LoggingConfig holds logging settings.
 type LoggingConfig struct {
-	Level  string `env:"LOG_LEVEL" envDefault:"info"`
+	// Level is the minimum level logged: debug, info, warn or error
+	Level string `env:"LOG_LEVEL" envDefault:"info"`
+
+	// Format is the log format: json, or console for human-readable logs
 	Format string `env:"LOG_FORMAT" envDefault:"json"`
 }
 
 // AppConfig holds general application settings.
 type AppConfig struct {
+	// Env is the deployment environment: development, staging or production
 	Env string `env:"APP_ENV" envDefault:"development"`
+
+	// DemoMode serves searches from an in-memory store preloaded with the
+	// provider mock data instead of the simulated provider adapters
+	DemoMode bool `env:"DEMO_MODE" envDefault:"false"`
+
+	// MockDataDir is the directory holding the provider mock data files
+	MockDataDir string `env:"MOCK_DATA_DIR" envDefault:"docs/response-mock"`
+
+	// MockDataBlobPrefix loads the demo mock data from the blob store instead of MockDataDir (empty = disabled)
+	MockDataBlobPrefix string `env:"MOCK_DATA_BLOB_PREFIX"`
+}
+
+// ProviderConfig holds flight provider settings.
+type ProviderConfig struct {
+	// Shadow lists providers queried in shadow mode: their results are only
+	// compared with the live results, never returned (comma-separated names)
+	Shadow []string `env:"PROVIDERS_SHADOW" envSeparator:","`
+
+	// QualityWindow is the period provider quality statistics cover
+	QualityWindow time.Duration `env:"PROVIDER_QUALITY_WINDOW" envDefault:"24h"`
+
+	// SkipOverBudget skips providers whose p95 latency exceeds the budget a
+	// search has left, instead of starting calls doomed to time out
+	SkipOverBudget bool `env:"PROVIDER_SKIP_OVER_BUDGET" envDefault:"true"`
+
+	// CoalesceSearches makes concurrent identical searches share one provider
+	// fan-out instead of querying the providers once each
+	CoalesceSearches bool `env:"PROVIDER_COALESCE_SEARCHES" envDefault:"true"`
+
+	// FanOutStrategy is how searches query the providers: all-parallel,
+	// fastest-first-return or sequential-failover (in the order of the
+	// provider list); requests and API keys may select another
+	FanOutStrategy string `env:"PROVIDER_FAN_OUT_STRATEGY" envDefault:"all-parallel"`
+
+	// FastestFirstProviders is the number of providers answering successfully
+	// after which fastest-first-return searches respond
+	FastestFirstProviders int `env:"PROVIDER_FASTEST_FIRST_PROVIDERS" envDefault:"2"`
+
+	// FastestFirstResults is the number of flights after which
+	// fastest-first-return searches respond
+	FastestFirstResults int `env:"PROVIDER_FASTEST_FIRST_RESULTS" envDefault:"50"`
+
+	// LatencyWindow is the period provider p95 latencies are computed over
+	LatencyWindow time.Duration `env:"PROVIDER_LATENCY_WINDOW" envDefault:"15m"`
+
+	// LatencyHeatmapRetention is how long per-minute provider latency
+	// distributions are kept for the admin latency heatmaps
+	LatencyHeatmapRetention time.Duration `env:"PROVIDER_LATENCY_HEATMAP_RETENTION" envDefault:"24h"`
+
+	// GroupQuotes answers searches for more than 9 passengers with indicative
+	// group fares instead of rejecting them
+	GroupQuotes bool `env:"PROVIDER_GROUP_QUOTES" envDefault:"true"`
+
+	// AccessibilityPolicy is a policy file listing the special assistance each
+	// airline supports (empty = built-in policies)
+	AccessibilityPolicy string `env:"PROVIDER_ACCESSIBILITY_POLICY"`
+
+	// MaxResponseBytes rejects provider responses larger than this many bytes
+	MaxResponseBytes int64 `env:"PROVIDER_MAX_RESPONSE_BYTES" envDefault:"10485760"`
+
+	// MaxResponseBytesByProvider overrides MaxResponseBytes per provider,
+	// e.g. "garuda_indonesia=1048576,lion_air=2097152"
+	MaxResponseBytesByProvider map[string]int64 `env:"PROVIDER_MAX_RESPONSE_BYTES_BY_PROVIDER" envKeyValSeparator:"="`
+
+	// RetryMaxAttempts is the number of attempts of a provider search failing
+	// with a transient error, the first one included (1 = no retries)
+	RetryMaxAttempts int `env:"PROVIDER_RETRY_MAX_ATTEMPTS" envDefault:"2"`
+
+	// RetryMaxAttemptsByProvider overrides RetryMaxAttempts per provider,
+	// e.g. "lion_air=3,airasia=1"
+	RetryMaxAttemptsByProvider map[string]int `env:"PROVIDER_RETRY_MAX_ATTEMPTS_BY_PROVIDER" envKeyValSeparator:"="`
+
+	// RetryBackoff is the wait before the first retry; it doubles per retry up to RetryMaxBackoff
+	RetryBackoff time.Duration `env:"PROVIDER_RETRY_BACKOFF" envDefault:"50ms"`
+
+	// RetryMaxBackoff caps the wait between retries
+	RetryMaxBackoff time.Duration `env:"PROVIDER_RETRY_MAX_BACKOFF" envDefault:"500ms"`
+
+	// HTTPBaseURLs switches providers from their mock data file to their HTTP
+	// API, e.g. "garuda_indonesia=https://api.garuda.example/v1/search"
+	HTTPBaseURLs map[string]string `env:"PROVIDER_HTTP_BASE_URLS" envKeyValSeparator:"="`
+
+	// HTTPAuthHeader is the header carrying the provider API credentials
+	HTTPAuthHeader string `env:"PROVIDER_HTTP_AUTH_HEADER" envDefault:"Authorization"`
+
+	// HTTPAuthValues are the credentials sent to each provider API,
+	// e.g. "garuda_indonesia=Bearer abc,lion_air=Bearer def"
+	HTTPAuthValues map[string]string `env:"PROVIDER_HTTP_AUTH_VALUES" envKeyValSeparator:"=" secret:"true"`
+
+	// HTTPTimeout bounds each provider API request
+	HTTPTimeout time.Duration `env:"PROVIDER_HTTP_TIMEOUT" envDefault:"5s"`
+
+	// HTTPMaxIdleConns caps the idle connections each provider API client keeps
+	HTTPMaxIdleConns int `env:"PROVIDER_HTTP_MAX_IDLE_CONNS" envDefault:"100"`
+
+	// HTTPMaxIdleConnsPerHost caps the idle connections kept per API host
+	HTTPMaxIdleConnsPerHost int `env:"PROVIDER_HTTP_MAX_IDLE_CONNS_PER_HOST" envDefault:"32"`
+
+	// HTTPMaxConnsPerHost caps the connections per API host (0 = no limit)
+	HTTPMaxConnsPerHost int `env:"PROVIDER_HTTP_MAX_CONNS_PER_HOST" envDefault:"0"`
+
+	// HTTPMaxConnsPerHostByProvider overrides HTTPMaxConnsPerHost per
+	// provider, e.g. "lion_air=16"
+	HTTPMaxConnsPerHostByProvider map[string]int `env:"PROVIDER_HTTP_MAX_CONNS_PER_HOST_BY_PROVIDER" envKeyValSeparator:"="`
+
+	// HTTPIdleConnTimeout closes API connections idle for longer
+	HTTPIdleConnTimeout time.Duration `env:"PROVIDER_HTTP_IDLE_CONN_TIMEOUT" envDefault:"90s"`
+
+	// HTTPTLSSessionCacheSize is the number of TLS sessions each client keeps
+	// for resumption
+	HTTPTLSSessionCacheSize int `env:"PROVIDER_HTTP_TLS_SESSION_CACHE_SIZE" envDefault:"64"`
+
+	// HTTPDNSCacheTTL is how long resolved API host addresses are reused
+	// (0 = resolve on every new connection)
+	HTTPDNSCacheTTL time.Duration `env:"PROVIDER_HTTP_DNS_CACHE_TTL" envDefault:"30s"`
+
+	// PoolSize is the number of searches each provider runs at once, retries
+	// included; further searches wait in the provider's queue (0 = unbounded)
+	PoolSize int `env:"PROVIDER_POOL_SIZE" envDefault:"16"`
+
+	// PoolQueue is the number of searches waiting for a provider worker beyond
+	// which searches of the provider fail at once
+	PoolQueue int `env:"PROVIDER_POOL_QUEUE" envDefault:"32"`
+
+	// PoolSizeByProvider overrides PoolSize per provider, e.g. "lion_air=8"
+	PoolSizeByProvider map[string]int `env:"PROVIDER_POOL_SIZE_BY_PROVIDER" envKeyValSeparator:"="`
+
+	// RateLimit caps the searches sent to each provider per second, retries
+	// and health checks included (0 = unlimited)
+	RateLimit float64 `env:"PROVIDER_RATE_LIMIT" envDefault:"0"`
+
+	// RateLimitByProvider overrides RateLimit per provider, e.g.
+	// "lion_air=20,airasia=5.5" (0 = unlimited)
+	RateLimitByProvider map[string]float64 `env:"PROVIDER_RATE_LIMIT_BY_PROVIDER" envKeyValSeparator:"="`
+
+	// RateLimitBurst is the number of searches a rate limited provider may
+	// be sent at once after it was idle
+	RateLimitBurst int `env:"PROVIDER_RATE_LIMIT_BURST" envDefault:"1"`
+
+	// RateLimitBurstByProvider overrides RateLimitBurst per provider, e.g. "lion_air=10"
+	RateLimitBurstByProvider map[string]int `env:"PROVIDER_RATE_LIMIT_BURST_BY_PROVIDER" envKeyValSeparator:"="`
+
+	// FlexibleDateConcurrency is the number of departure dates of a
+	// flexible-date search searched at once, each querying the providers
+	FlexibleDateConcurrency int `env:"PROVIDER_FLEXIBLE_DATE_CONCURRENCY" envDefault:"3"`
+
+	// HealthCheckInterval is how often every provider is checked in the
+	// background, for /health and to close breakers early (0 = never)
+	HealthCheckInterval time.Duration `env:"PROVIDER_HEALTH_CHECK_INTERVAL" envDefault:"30s"`
+
+	// BreakerThreshold is the number of failures in a row after which a
+	// provider's breaker opens and its searches fail at once (0 = no breaker)
+	BreakerThreshold int `env:"PROVIDER_BREAKER_THRESHOLD" envDefault:"5"`
+
+	// BreakerCooldown is how long a breaker stays open before one search is
+	// let through to try the provider again
+	BreakerCooldown time.Duration `env:"PROVIDER_BREAKER_COOLDOWN" envDefault:"30s"`
+}
+
+// ResponseLimit returns the response size limit of the named provider.
+func (p ProviderConfig) ResponseLimit(provider string) int64 {
+	if limit, ok := p.MaxResponseBytesByProvider[provider]; ok {
+		return limit
+	}
+	return p.MaxResponseBytes
+}
+
+// Retry returns how provider searches failing with a transient error are
+// retried. Per-provider attempts are in RetryMaxAttemptsByProvider.
+func (p ProviderConfig) Retry() retry.Config {
+	return retry.Config{
+		MaxAttempts:    p.RetryMaxAttempts,
+		InitialBackoff: p.RetryBackoff,
+		MaxBackoff:     p.RetryMaxBackoff,
+	}
+}
+
+// HTTPSource returns the HTTP API settings of the named provider, and false
+// if it reads its mock data file.
+func (p ProviderConfig) HTTPSource(provider string) (httpsource.Config, bool) {
+	baseURL, ok := p.HTTPBaseURLs[provider]
+	if !ok {
+		return httpsource.Config{}, false
+	}
+	return httpsource.Config{
+		BaseURL:    baseURL,
+		AuthHeader: p.HTTPAuthHeader,
+		AuthValue:  p.HTTPAuthValues[provider],
+		Timeout:    p.HTTPTimeout,
+		Transport:  p.httpTransport(provider),
+	}, true
+}
+
+// httpTransport returns the connection settings of the named provider's API client.
+func (p ProviderConfig) httpTransport(provider string) httpsource.TransportConfig {
+	maxConns := p.HTTPMaxConnsPerHost
+	if override, ok := p.HTTPMaxConnsPerHostByProvider[provider]; ok {
+		maxConns = override
+	}
+	return httpsource.TransportConfig{
+		MaxIdleConns:        p.HTTPMaxIdleConns,
+		MaxIdleConnsPerHost: p.HTTPMaxIdleConnsPerHost,
+		MaxConnsPerHost:     maxConns,
+		IdleConnTimeout:     p.HTTPIdleConnTimeout,
+		TLSSessionCacheSize: p.HTTPTLSSessionCacheSize,
+		DNSCacheTTL:         p.HTTPDNSCacheTTL,
+	}
+}
+
+// WorkerPools returns the per-provider worker pools, or nil if PoolSize is 0.
+func (p ProviderConfig) WorkerPools(opts ...workerpool.Option) (*workerpool.Set, error) {
+	if p.PoolSize < 0 {
+		return nil, fmt.Errorf("PROVIDER_POOL_SIZE must not be negative, got %d", p.PoolSize)
+	}
+	if p.PoolQueue < 0 {
+		return nil, fmt.Errorf("PROVIDER_POOL_QUEUE must not be negative, got %d", p.PoolQueue)
+	}
+	for provider, size := range p.PoolSizeByProvider {
+		if size <= 0 {
+			return nil, fmt.Errorf("PROVIDER_POOL_SIZE_BY_PROVIDER: size of %s must be positive, got %d", provider, size)
+		}
+	}
+	if p.PoolSize == 0 {
+		return nil, nil
+	}
+	return workerpool.NewSet(p.PoolSize, p.PoolQueue, append(opts, workerpool.WithSizes(p.PoolSizeByProvider))...)
+}
+
+// RateLimits returns the per-provider rate limits, or nil if no provider is
+// rate limited.
+func (p ProviderConfig) RateLimits(opts ...ratelimit.Option) (*ratelimit.Set, error) {
+	if p.RateLimit < 0 {
+		return nil, fmt.Errorf("PROVIDER_RATE_LIMIT must not be negative, got %g", p.RateLimit)
+	}
+	if p.RateLimitBurst < 1 {
+		return nil, fmt.Errorf("PROVIDER_RATE_LIMIT_BURST must be at least 1, got %d", p.RateLimitBurst)
+	}
+	for provider, burst := range p.RateLimitBurstByProvider {
+		if burst < 1 {
+			return nil, fmt.Errorf("PROVIDER_RATE_LIMIT_BURST_BY_PROVIDER: burst of %s must be at least 1, got %d", provider, burst)
+		}
+	}
+
+	limited := p.RateLimit > 0
+	limits := make(map[string]ratelimit.Limit)
+	for provider, rate := range p.RateLimitByProvider {
+		if rate < 0 {
+			return nil, fmt.Errorf("PROVIDER_RATE_LIMIT_BY_PROVIDER: rate of %s must not be negative, got %g", provider, rate)
+		}
+		limits[provider] = ratelimit.Limit{Rate: rate, Burst: p.rateLimitBurst(provider)}
+		limited = limited || rate > 0
+	}
+	for provider := range p.RateLimitBurstByProvider {
+		if _, ok := limits[provider]; !ok {
+			limits[provider] = ratelimit.Limit{Rate: p.RateLimit, Burst: p.rateLimitBurst(provider)}
+		}
+	}
+	if !limited {
+		return nil, nil
+	}
+	limit := ratelimit.Limit{Rate: p.RateLimit, Burst: p.RateLimitBurst}
+	return ratelimit.NewSet(limit, append(opts, ratelimit.WithLimits(limits))...)
+}
+
+// rateLimitBurst returns the rate limit burst of the named provider.
+func (p ProviderConfig) rateLimitBurst(provider string) int {
+	if burst, ok := p.RateLimitBurstByProvider[provider]; ok {
+		return burst
+	}
+	return p.RateLimitBurst
+}
+
+// RoutingConfig holds the settings rejecting itineraries with absurd routings.
+type RoutingConfig struct {
+	// MaxDurationFactor rejects itineraries longer than this multiple of the
+	// route's nonstop flight time (0 = no limit)
+	MaxDurationFactor float64 `env:"ROUTING_MAX_DURATION_FACTOR" envDefault:"3"`
+
+	// MaxStops caps the stops by nonstop flight time, e.g. "2h=1,6h=2"
+	// ("none" = no limit)
+	MaxStops string `env:"ROUTING_MAX_STOPS" envDefault:"2h=1,6h=2"`
+}
+
+// PricingConfig holds the settings of how flight prices are compared.
+type PricingConfig struct {
+	// Basis is the price the price filters compare: "per_person" or "total"
+	// for all the passengers searched
+	Basis string `env:"PRICE_BASIS" envDefault:"per_person"`
+}
+
+// LoadShedConfig holds the settings shedding optional features under high load.
+type LoadShedConfig struct {
+	// Features are shed in this order, e.g. "shadow,localization"
+	// ("none" = never shed)
+	Features string `env:"LOAD_SHED_FEATURES" envDefault:"shadow,localization"`
+
+	// MaxInFlight is the number of searches in flight at which shedding starts (0 = not used)
+	MaxInFlight int `env:"LOAD_SHED_MAX_IN_FLIGHT" envDefault:"200"`
+
+	// P95Latency is the p95 search latency at which shedding starts (0 = not used)
+	P95Latency time.Duration `env:"LOAD_SHED_P95_LATENCY" envDefault:"2s"`
+
+	// Window is the period the p95 latency is computed over
+	Window time.Duration `env:"LOAD_SHED_WINDOW" envDefault:"30s"`
+
+	// Cooldown is how long load must stay low before the next feature is restored
+	Cooldown time.Duration `env:"LOAD_SHED_COOLDOWN" envDefault:"30s"`
+}
+
+// ValidationConfig holds API contract validation settings.
+type ValidationConfig struct {
+	// OpenAPIEnabled validates requests/responses against the served OpenAPI document.
+	// Validation is always enabled in staging regardless of this flag.
+	OpenAPIEnabled bool `env:"OPENAPI_VALIDATION_ENABLED" envDefault:"false"`
+
+	// OpenAPIResponses logs responses that drift from the OpenAPI document
+	OpenAPIResponses bool `env:"OPENAPI_VALIDATE_RESPONSES" envDefault:"true"`
+
+	// CodeMode is how strictly search request codes are validated: "strict"
+	// accepts IATA airport codes only, "charter" also accepts the ICAO codes of
+	// known airports (mapped to IATA) and 2-letter+digit charter airline codes
+	CodeMode string `env:"CODE_VALIDATION_MODE" envDefault:"strict"`
+}
+
+// SanitizeConfig holds the settings cleaning provider free text (baggage
+// notes, amenities, aircraft) before it is returned to clients. Markup and
+// control characters are always stripped.
+type SanitizeConfig struct {
+	// MaxLength caps each free-text field, in characters (0 = no cap)
+	MaxLength int `env:"SANITIZE_MAX_LENGTH" envDefault:"200"`
+
+	// BlockedTerms are masked wherever they appear as words (comma-separated, case-insensitive)
+	BlockedTerms []string `env:"SANITIZE_BLOCKED_TERMS" envSeparator:","`
+
+	// RedactPII replaces e-mail addresses and phone numbers
+	RedactPII bool `env:"SANITIZE_REDACT_PII" envDefault:"true"`
+}
+
+// HistoryConfig holds settings for stored searches.
+type HistoryConfig struct {
+	// Capacity is the number of searches kept for replay; the oldest are evicted first
+	Capacity int `env:"SEARCH_HISTORY_CAPACITY" envDefault:"1000"`
+
+	// Retention is how long individual searches are kept before being rolled up into daily aggregates
+	Retention time.Duration `env:"SEARCH_HISTORY_RETENTION" envDefault:"2160h"`
+
+	// AggregateRetention is how long daily aggregates are kept
+	AggregateRetention time.Duration `env:"SEARCH_AGGREGATE_RETENTION" envDefault:"8760h"`
+
+	// Store selects where searches are kept: "memory" (lost on restart) or
+	// "database" (the SQLite database of DATABASE_DSN)
+	Store string `env:"SEARCH_HISTORY_STORE" envDefault:"memory"`
+}
+
+// SearchAuditConfig holds settings for the search audit log.
+type SearchAuditConfig struct {
+	// Sink selects where every search is logged: "none", "file" (File)
+	// or "database" (the SQLite database of DATABASE_DSN)
+	Sink string `env:"SEARCH_AUDIT_SINK" envDefault:"none"`
+
+	// File is the JSON Lines file the "file" sink appends to
+	File string `env:"SEARCH_AUDIT_FILE" envDefault:"data/search_audit.jsonl"`
+
+	// Buffer is the number of entries queued for the sink, beyond which
+	// entries are dropped rather than delaying searches
+	Buffer int `env:"SEARCH_AUDIT_BUFFER" envDefault:"1024"`
+}
+
+// TenantConfig holds per-API-key partner settings.
+type TenantConfig struct {
+	// APIKeysFile is a JSON file mapping API keys to partner defaults (empty = none)
+	APIKeysFile string `env:"API_KEYS_FILE"`
+
+	// AnonymousRoles are the roles of callers without a known API key
+	// (comma-separated; "none" requires an API key for every operation)
+	AnonymousRoles string `env:"RBAC_ANONYMOUS_ROLES" envDefault:"search"`
+
+	// DefaultKeyRoles are the roles of API keys that do not list their own
+	DefaultKeyRoles string `env:"RBAC_DEFAULT_KEY_ROLES" envDefault:"search"`
+
+	// DuplicateWindow is how long after an API key's search an identical
+	// search counts as a duplicate, answered with a hint to reuse the
+	// previous results (0 = duplicates are not tracked)
+	DuplicateWindow time.Duration `env:"TENANT_DUPLICATE_SEARCH_WINDOW" envDefault:"1m"`
+}
+
+// SchedulerConfig holds background job schedules.
+// Schedules are cron expressions, descriptors (@hourly) or intervals (@every 10m).
+type SchedulerConfig struct {
+	// Jitter is the maximum random delay added to each job run
+	Jitter time.Duration `env:"SCHEDULER_JITTER" envDefault:"0s"`
+
+	// MockRotation reloads the demo mock data from MOCK_DATA_DIR (empty = disabled)
+	MockRotation string `env:"SCHEDULE_MOCK_ROTATION"`
+
+	// HistoryCompaction applies search history retention (empty = disabled)
+	HistoryCompaction string `env:"SCHEDULE_HISTORY_COMPACTION" envDefault:"@hourly"`
+
+	// AnalyticsExport exports the previous day's search analytics (empty = disabled)
+	AnalyticsExport string `env:"SCHEDULE_ANALYTICS_EXPORT"`
+}
+
+// QueueConfig holds persistent job queue settings.
+type QueueConfig struct {
+	// Store is where queued jobs are persisted: "file" (File) or "database"
+	// (the SQLite database, one row per job)
+	Store string `env:"JOB_QUEUE_STORE" envDefault:"file"`
+
+	// File persists queued jobs across restarts (empty = in memory only)
+	File string `env:"JOB_QUEUE_FILE" envDefault:"data/jobs.json"`
+
+	// Workers is the number of jobs processed concurrently
+	Workers int `env:"JOB_QUEUE_WORKERS" envDefault:"2"`
+
+	// MaxAttempts is the number of attempts before a job is moved to the dead letter
+	MaxAttempts int `env:"JOB_QUEUE_MAX_ATTEMPTS" envDefault:"5"`
+
+	// Backoff is the first retry delay; it doubles per attempt up to MaxBackoff
+	Backoff time.Duration `env:"JOB_QUEUE_BACKOFF" envDefault:"1s"`
+
+	// MaxBackoff caps the delay between job attempts
+	MaxBackoff time.Duration `env:"JOB_QUEUE_MAX_BACKOFF" envDefault:"5m"`
+
+	// Retention is how long done and dead jobs are kept before they are purged
+	Retention time.Duration `env:"JOB_QUEUE_RETENTION" envDefault:"168h"`
+}
+
+// AnalyticsConfig holds anonymized analytics export settings.
+// Exports are written to the blob store.
+type AnalyticsConfig struct {
+	// MinSearches suppresses routes searched fewer times on a day
+	MinSearches int `env:"ANALYTICS_EXPORT_MIN_SEARCHES" envDefault:"1"`
+}
+
+// AdminConfig holds admin API authentication settings.
+type AdminConfig struct {
+	// TokenSecret signs and verifies admin bearer tokens. Without it the admin
+	// API is unauthenticated, or not served at all in production.
+	TokenSecret string `env:"ADMIN_TOKEN_SECRET" secret:"true"`
+
+	// TokenMaxTTL is the longest token lifetime accepted
+	TokenMaxTTL time.Duration `env:"ADMIN_TOKEN_MAX_TTL" envDefault:"15m"`
+
+	// AuditFile is the append-only JSON Lines file recording admin mutations
+	// (empty = in memory only)
+	AuditFile string `env:"ADMIN_AUDIT_FILE" envDefault:"data/audit.jsonl"`
+}
+
+// Authentication modes.
+const (
+	// AuthModeAPIKey identifies partners by API key and admin callers by
+	// admin tokens
+	AuthModeAPIKey = "api_key"
+
+	// AuthModeJWT authenticates every caller with OAuth2 bearer tokens,
+	// whose scopes grant roles
+	AuthModeJWT = "jwt"
+)
+
+// AuthConfig holds how API callers authenticate.
+type AuthConfig struct {
+	// Mode is the authentication mode: api_key or jwt
+	Mode string `env:"AUTH_MODE" envDefault:"api_key"`
+
+	// JWTIssuer is the issuer ("iss") bearer tokens must be issued by
+	JWTIssuer string `env:"JWT_ISSUER"`
+
+	// JWTAudience is the audience ("aud") bearer tokens must be issued for
+	JWTAudience string `env:"JWT_AUDIENCE"`
+
+	// JWKSURL is where the issuer publishes its token signing keys
+	JWKSURL string `env:"JWT_JWKS_URL"`
+
+	// JWKSRefreshInterval is how long fetched signing keys are used before
+	// they are fetched again
+	JWKSRefreshInterval time.Duration `env:"JWT_JWKS_REFRESH_INTERVAL" envDefault:"1h"`
+
+	// ScopeRoles maps token scopes to the roles they grant, e.g.
+	// "flights:search=search,flights:admin=admin"
+	ScopeRoles map[string]string `env:"JWT_SCOPE_ROLES" envKeyValSeparator:"=" envDefault:"flights:search=search,flights:export=export,flights:admin=admin,flights:debug=debug"`
+}
+
+// ShareConfig holds the settings of search result share links.
+type ShareConfig struct {
+	// TokenSecret seals share tokens. Without it searches cannot be shared.
+	TokenSecret string `env:"SHARE_TOKEN_SECRET" secret:"true"`
+
+	// TokenTTL is how long a share link stays valid
+	TokenTTL time.Duration `env:"SHARE_TOKEN_TTL" envDefault:"72h"`
+}
+
+// CallbackConfig holds the settings of asynchronous searches, whose results
+// are posted to the callback URL of the request.
+type CallbackConfig struct {
+	// Secret signs the callbacks. Without it searches cannot be asynchronous.
+	Secret string `env:"SEARCH_CALLBACK_SECRET" secret:"true"`
+
+	// Timeout bounds each callback delivery attempt
+	Timeout time.Duration `env:"SEARCH_CALLBACK_TIMEOUT" envDefault:"10s"`
+
+	// MaxAttempts is the number of delivery attempts before a callback is
+	// moved to the job queue's dead letter
+	MaxAttempts int `env:"SEARCH_CALLBACK_MAX_ATTEMPTS" envDefault:"5"`
+}
+
+// DatabaseConfig holds the SQL database backing persistent features.
+// Drivers are registered with database/sql by the storage backends linked
+// into the binary.
+type DatabaseConfig struct {
+	// Driver is the database/sql driver name (empty = no database)
+	Driver string `env:"DATABASE_DRIVER"`
+
+	// DSN is the data source name the driver connects to
+	DSN string `env:"DATABASE_DSN" secret:"true"`
+
+	// MigrateOnStartup applies pending schema migrations when the server
+	// starts; disable it to run them with the migrate command instead
+	MigrateOnStartup bool `env:"DATABASE_MIGRATE_ON_STARTUP" envDefault:"true"`
+
+	// SQLiteJournalMode is the journal mode of SQLite databases; WAL lets
+	// searches read while another writes
+	SQLiteJournalMode string `env:"DATABASE_SQLITE_JOURNAL_MODE" envDefault:"WAL"`
+
+	// SQLiteSynchronous is how often SQLite databases sync to disk; NORMAL is
+	// safe with WAL, only losing the last writes on power loss
+	SQLiteSynchronous string `env:"DATABASE_SQLITE_SYNCHRONOUS" envDefault:"NORMAL"`
+
+	// SQLiteBusyTimeout is how long SQLite writes wait for each other before failing
+	SQLiteBusyTimeout time.Duration `env:"DATABASE_SQLITE_BUSY_TIMEOUT" envDefault:"5s"`
+}
+
+// SQLiteDriver is the database/sql driver name of SQLite.
+const SQLiteDriver = "sqlite3"
+
+// History stores.
+const (
+	HistoryStoreMemory   = "memory"
+	HistoryStoreDatabase = "database"
+)
+
+// Job queue stores.
+const (
+	QueueStoreFile     = "file"
+	QueueStoreDatabase = "database"
+)
+
+// providerNames are the names of the providers the service searches, which
+// per-provider settings are keyed by.
+var providerNames = []string{garuda.ProviderName, lionair.ProviderName, batikair.ProviderName, airasia.ProviderName}
+
+// Search audit sinks.
+const (
+	SearchAuditSinkNone     = "none"
+	SearchAuditSinkFile     = "file"
+	SearchAuditSinkDatabase = "database"
+)
+
+// BlobConfig holds object storage settings for exports and mock datasets.
+type BlobConfig struct {
+	// Backend selects the store: "file" or "s3"
+	Backend string `env:"BLOB_BACKEND" envDefault:"file"`
+
+	// Dir is the root directory of the file backend
+	Dir string `env:"BLOB_DIR" envDefault:"data/blobs"`
+
+	// S3Endpoint is the endpoint of the s3 backend; any S3-compatible service can be used
+	S3Endpoint string `env:"BLOB_S3_ENDPOINT" envDefault:"https://s3.amazonaws.com"`
+
+	// S3Region is the region requests to the s3 backend are signed for
+	S3Region string `env:"BLOB_S3_REGION" envDefault:"us-east-1"`
+
+	// S3Bucket is the bucket of the s3 backend
+	S3Bucket string `env:"BLOB_S3_BUCKET"`
+
+	// S3Prefix is prepended to every key, to share a bucket
+	S3Prefix string `env:"BLOB_S3_PREFIX"`
+
+	// S3AccessKeyID is the access key ID of the s3 backend
+	S3AccessKeyID string `env:"BLOB_S3_ACCESS_KEY_ID" secret:"true"`
+
+	// S3SecretAccessKey is the secret access key of the s3 backend
+	S3SecretAccessKey string `env:"BLOB_S3_SECRET_ACCESS_KEY" secret:"true"`
+
+	// S3PathStyle addresses the bucket in the URL path instead of the host name
+	S3PathStyle bool `env:"BLOB_S3_PATH_STYLE" envDefault:"false"`
+}
+
+// CacheConfig holds search result cache settings.
+type CacheConfig struct {
+	// Backend selects the cache: "none", "memory" or "redis"
+	Backend string `env:"CACHE_BACKEND" envDefault:"none"`
+
+	// TTL is how long provider results are served from the cache
+	TTL time.Duration `env:"CACHE_TTL" envDefault:"5m"`
+
+	// MemoryMaxEntries caps the searches held by the memory backend; the least
+	// recently used one is evicted to make room
+	MemoryMaxEntries int `env:"CACHE_MEMORY_MAX_ENTRIES" envDefault:"1000"`
+
+	// FlightDetailsTTL is how long the flights of a search can be looked up by
+	// ID with GET /api/v1/flights/{id} (0 = disabled)
+	FlightDetailsTTL time.Duration `env:"CACHE_FLIGHT_DETAILS_TTL" envDefault:"15m"`
+
+	// DriftSampleRate is the share of cache hits also searched live in the
+	// background to measure cache drift (0 = never)
+	DriftSampleRate float64 `env:"CACHE_DRIFT_SAMPLE_RATE" envDefault:"0.01"`
+
+	// RedisAddr is the host:port of the redis backend
+	RedisAddr string `env:"CACHE_REDIS_ADDR" envDefault:"localhost:6379"`
+
+	// RedisPassword authenticates with Redis (empty = no authentication)
+	RedisPassword string `env:"CACHE_REDIS_PASSWORD" secret:"true"`
+
+	// RedisDB is the Redis database number
+	RedisDB int `env:"CACHE_REDIS_DB" envDefault:"0"`
+
+	// RedisPrefix is prepended to every cache key
+	RedisPrefix string `env:"CACHE_REDIS_PREFIX" envDefault:"flight-search:search:"`
+
+	// RedisTimeout bounds connecting to Redis and each command
+	RedisTimeout time.Duration `env:"CACHE_REDIS_TIMEOUT" envDefault:"500ms"`
+}
+
+// EncryptionConfig holds at-rest encryption keys for persisted data.
+// Keys are comma-separated id:base64 entries (32-byte AES-256 keys); the first
+// key encrypts, the others only decrypt data written before a rotation.
+type EncryptionConfig struct {
+	// Keys is the key list (empty = persisted data is not encrypted)
+	Keys string `env:"STORAGE_ENCRYPTION_KEYS" secret:"true"`
+
+	// KeysFile reads the key list from a file, e.g. a mounted secret
+	KeysFile string `env:"STORAGE_ENCRYPTION_KEYS_FILE"`
+}
+
+// SigningConfig holds the key signing API responses. The key is an id:base64
+// entry (32-byte Ed25519 seed); the ID names the key in signatures.
+type SigningConfig struct {
+	// Key is the signing key (empty = responses are not signed)
+	Key string `env:"RESPONSE_SIGNING_KEY" secret:"true"`
+
+	// KeyFile reads the signing key from a file, e.g. a mounted secret
+	KeyFile string `env:"RESPONSE_SIGNING_KEY_FILE"`
 }
 
 // Load reads configuration from environment variables.
@@ -87,18 +833,259 @@ func validate(cfg *Config) error {
 	if cfg.Server.WriteTimeout <= 0 {
 		return fmt.Errorf("SERVER_WRITE_TIMEOUT must be positive")
 	}
+	if cfg.Server.ReadHeaderTimeout <= 0 {
+		return fmt.Errorf("SERVER_READ_HEADER_TIMEOUT must be positive")
+	}
+	if cfg.Server.IdleTimeout <= 0 {
+		return fmt.Errorf("SERVER_IDLE_TIMEOUT must be positive")
+	}
+	if cfg.Server.RetryAfter <= 0 {
+		return fmt.Errorf("SERVER_RETRY_AFTER must be positive")
+	}
+
+	// Validate connection limits (0 disables a limit)
+	if cfg.Server.MaxConnections < 0 {
+		return fmt.Errorf("SERVER_MAX_CONNECTIONS must not be negative, got %d", cfg.Server.MaxConnections)
+	}
+	if cfg.Server.MaxConnectionsPerIP < 0 {
+		return fmt.Errorf("SERVER_MAX_CONNECTIONS_PER_IP must not be negative, got %d", cfg.Server.MaxConnectionsPerIP)
+	}
+	if cfg.Server.MaxConcurrentSearches < 0 {
+		return fmt.Errorf("SERVER_MAX_CONCURRENT_SEARCHES must not be negative, got %d", cfg.Server.MaxConcurrentSearches)
+	}
+
+	// Validate body size limit and compression
+	if cfg.Server.MaxRequestBodyBytes <= 0 {
+		return fmt.Errorf("SERVER_MAX_REQUEST_BODY_BYTES must be positive, got %d", cfg.Server.MaxRequestBodyBytes)
+	}
+	if cfg.Server.CompressionLevel < 0 || cfg.Server.CompressionLevel > 9 {
+		return fmt.Errorf("SERVER_COMPRESSION_LEVEL must be between 0 and 9, got %d", cfg.Server.CompressionLevel)
+	}
+	if cfg.Server.CompressionMinBytes < 0 {
+		return fmt.Errorf("SERVER_COMPRESSION_MIN_BYTES must not be negative, got %d", cfg.Server.CompressionMinBytes)
+	}
 	if cfg.Timeouts.GlobalSearch <= 0 {
 		return fmt.Errorf("TIMEOUT_GLOBAL_SEARCH must be positive")
 	}
 	if cfg.Timeouts.PerProvider <= 0 {
 		return fmt.Errorf("TIMEOUT_PER_PROVIDER must be positive")
 	}
+	if cfg.Timeouts.GlobalSearchBatch <= 0 {
+		return fmt.Errorf("TIMEOUT_GLOBAL_SEARCH_BATCH must be positive")
+	}
+	if cfg.Timeouts.SoftDeadline <= 0 {
+		return fmt.Errorf("TIMEOUT_SOFT_DEADLINE must be positive")
+	}
+	if cfg.Timeouts.ContinuationTTL <= 0 {
+		return fmt.Errorf("TIMEOUT_CONTINUATION_TTL must be positive")
+	}
 
 	// Validate per-provider timeout is less than global timeout
 	if cfg.Timeouts.PerProvider >= cfg.Timeouts.GlobalSearch {
 		return fmt.Errorf("TIMEOUT_PER_PROVIDER (%s) should be less than TIMEOUT_GLOBAL_SEARCH (%s)",
 			cfg.Timeouts.PerProvider, cfg.Timeouts.GlobalSearch)
 	}
+	for provider, timeout := range cfg.Timeouts.PerProviderByProvider {
+		if !slices.Contains(providerNames, provider) {
+			return fmt.Errorf("TIMEOUT_PER_PROVIDER_BY_PROVIDER: unknown provider %q, must be one of: %s",
+				provider, strings.Join(providerNames, ", "))
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("TIMEOUT_PER_PROVIDER_BY_PROVIDER: timeout of %s must be positive", provider)
+		}
+		if timeout >= cfg.Timeouts.GlobalSearch {
+			return fmt.Errorf("TIMEOUT_PER_PROVIDER_BY_PROVIDER: timeout of %s (%s) should be less than TIMEOUT_GLOBAL_SEARCH (%s)",
+				provider, timeout, cfg.Timeouts.GlobalSearch)
+		}
+	}
+
+	// Validate search history capacity
+	if cfg.History.Capacity < 1 {
+		return fmt.Errorf("SEARCH_HISTORY_CAPACITY must be at least 1, got %d", cfg.History.Capacity)
+	}
+	if cfg.Providers.QualityWindow < time.Hour {
+		return fmt.Errorf("PROVIDER_QUALITY_WINDOW must be at least 1h")
+	}
+	if cfg.Providers.LatencyWindow <= 0 {
+		return fmt.Errorf("PROVIDER_LATENCY_WINDOW must be positive")
+	}
+	if cfg.Providers.LatencyHeatmapRetention < time.Minute {
+		return fmt.Errorf("PROVIDER_LATENCY_HEATMAP_RETENTION must be at least 1m")
+	}
+	if !domain.FanOutStrategy(cfg.Providers.FanOutStrategy).IsValid() {
+		return fmt.Errorf("PROVIDER_FAN_OUT_STRATEGY must be one of: all-parallel, fastest-first-return, sequential-failover; got %q", cfg.Providers.FanOutStrategy)
+	}
+	if cfg.Providers.FastestFirstProviders < 1 || cfg.Providers.FastestFirstResults < 1 {
+		return fmt.Errorf("PROVIDER_FASTEST_FIRST_PROVIDERS and PROVIDER_FASTEST_FIRST_RESULTS must be at least 1")
+	}
+	if cfg.Providers.MaxResponseBytes <= 0 {
+		return fmt.Errorf("PROVIDER_MAX_RESPONSE_BYTES must be positive")
+	}
+	for provider, limit := range cfg.Providers.MaxResponseBytesByProvider {
+		if limit <= 0 {
+			return fmt.Errorf("PROVIDER_MAX_RESPONSE_BYTES_BY_PROVIDER: limit of %s must be positive", provider)
+		}
+	}
+	if cfg.Providers.RetryMaxAttempts < 1 {
+		return fmt.Errorf("PROVIDER_RETRY_MAX_ATTEMPTS must be at least 1, got %d", cfg.Providers.RetryMaxAttempts)
+	}
+	for provider, attempts := range cfg.Providers.RetryMaxAttemptsByProvider {
+		if attempts < 1 {
+			return fmt.Errorf("PROVIDER_RETRY_MAX_ATTEMPTS_BY_PROVIDER: attempts of %s must be at least 1, got %d", provider, attempts)
+		}
+	}
+	if cfg.Providers.RetryBackoff < 0 || cfg.Providers.RetryMaxBackoff < cfg.Providers.RetryBackoff {
+		return fmt.Errorf("PROVIDER_RETRY_BACKOFF must not be negative or exceed PROVIDER_RETRY_MAX_BACKOFF")
+	}
+	if cfg.Providers.HTTPTimeout <= 0 {
+		return fmt.Errorf("PROVIDER_HTTP_TIMEOUT must be positive")
+	}
+	if cfg.Providers.HTTPMaxIdleConns <= 0 || cfg.Providers.HTTPMaxIdleConnsPerHost <= 0 {
+		return fmt.Errorf("PROVIDER_HTTP_MAX_IDLE_CONNS and PROVIDER_HTTP_MAX_IDLE_CONNS_PER_HOST must be positive")
+	}
+	if cfg.Providers.HTTPMaxConnsPerHost < 0 {
+		return fmt.Errorf("PROVIDER_HTTP_MAX_CONNS_PER_HOST must not be negative, got %d", cfg.Providers.HTTPMaxConnsPerHost)
+	}
+	for provider, limit := range cfg.Providers.HTTPMaxConnsPerHostByProvider {
+		if limit < 0 {
+			return fmt.Errorf("PROVIDER_HTTP_MAX_CONNS_PER_HOST_BY_PROVIDER: limit of %s must not be negative, got %d", provider, limit)
+		}
+	}
+	if cfg.Providers.HTTPIdleConnTimeout <= 0 {
+		return fmt.Errorf("PROVIDER_HTTP_IDLE_CONN_TIMEOUT must be positive")
+	}
+	if cfg.Providers.HTTPTLSSessionCacheSize <= 0 {
+		return fmt.Errorf("PROVIDER_HTTP_TLS_SESSION_CACHE_SIZE must be positive")
+	}
+	if cfg.Providers.HTTPDNSCacheTTL < 0 {
+		return fmt.Errorf("PROVIDER_HTTP_DNS_CACHE_TTL must not be negative")
+	}
+	for provider := range cfg.Providers.HTTPBaseURLs {
+		source, _ := cfg.Providers.HTTPSource(provider)
+		if _, err := httpsource.New(source); err != nil {
+			return fmt.Errorf("PROVIDER_HTTP_BASE_URLS: %s: %w", provider, err)
+		}
+	}
+	if _, err := cfg.Providers.WorkerPools(); err != nil {
+		return err
+	}
+	if _, err := cfg.Providers.RateLimits(); err != nil {
+		return err
+	}
+	if cfg.Providers.FlexibleDateConcurrency < 1 {
+		return fmt.Errorf("PROVIDER_FLEXIBLE_DATE_CONCURRENCY must be at least 1, got %d", cfg.Providers.FlexibleDateConcurrency)
+	}
+	if cfg.Providers.HealthCheckInterval < 0 {
+		return fmt.Errorf("PROVIDER_HEALTH_CHECK_INTERVAL must not be negative")
+	}
+	if cfg.Providers.BreakerThreshold < 0 {
+		return fmt.Errorf("PROVIDER_BREAKER_THRESHOLD must not be negative, got %d", cfg.Providers.BreakerThreshold)
+	}
+	if cfg.Providers.BreakerCooldown <= 0 {
+		return fmt.Errorf("PROVIDER_BREAKER_COOLDOWN must be positive")
+	}
+	for provider := range cfg.Providers.HTTPAuthValues {
+		if _, ok := cfg.Providers.HTTPBaseURLs[provider]; !ok {
+			return fmt.Errorf("PROVIDER_HTTP_AUTH_VALUES: %s has no base URL in PROVIDER_HTTP_BASE_URLS", provider)
+		}
+	}
+	if cfg.History.Retention <= 0 {
+		return fmt.Errorf("SEARCH_HISTORY_RETENTION must be positive")
+	}
+	if cfg.History.AggregateRetention < cfg.History.Retention {
+		return fmt.Errorf("SEARCH_AGGREGATE_RETENTION (%s) must not be less than SEARCH_HISTORY_RETENTION (%s)",
+			cfg.History.AggregateRetention, cfg.History.Retention)
+	}
+
+	// Validate free text sanitization
+	if cfg.Sanitize.MaxLength < 0 {
+		return fmt.Errorf("SANITIZE_MAX_LENGTH must not be negative, got %d", cfg.Sanitize.MaxLength)
+	}
+
+	// Validate job schedules
+	if cfg.Scheduler.Jitter < 0 {
+		return fmt.Errorf("SCHEDULER_JITTER must not be negative")
+	}
+	if cfg.Scheduler.MockRotation != "" {
+		if _, err := scheduler.Parse(cfg.Scheduler.MockRotation); err != nil {
+			return fmt.Errorf("SCHEDULE_MOCK_ROTATION: %w", err)
+		}
+	}
+	if cfg.Scheduler.HistoryCompaction != "" {
+		if _, err := scheduler.Parse(cfg.Scheduler.HistoryCompaction); err != nil {
+			return fmt.Errorf("SCHEDULE_HISTORY_COMPACTION: %w", err)
+		}
+	}
+	if cfg.Scheduler.AnalyticsExport != "" {
+		if _, err := scheduler.Parse(cfg.Scheduler.AnalyticsExport); err != nil {
+			return fmt.Errorf("SCHEDULE_ANALYTICS_EXPORT: %w", err)
+		}
+	}
+	if cfg.Analytics.MinSearches < 1 {
+		return fmt.Errorf("ANALYTICS_EXPORT_MIN_SEARCHES must be at least 1, got %d", cfg.Analytics.MinSearches)
+	}
+
+	// Validate job queue
+	if cfg.Queue.Workers < 1 {
+		return fmt.Errorf("JOB_QUEUE_WORKERS must be at least 1, got %d", cfg.Queue.Workers)
+	}
+	if cfg.Queue.MaxAttempts < 1 {
+		return fmt.Errorf("JOB_QUEUE_MAX_ATTEMPTS must be at least 1, got %d", cfg.Queue.MaxAttempts)
+	}
+	if cfg.Queue.Backoff <= 0 {
+		return fmt.Errorf("JOB_QUEUE_BACKOFF must be positive")
+	}
+	if cfg.Queue.MaxBackoff < cfg.Queue.Backoff {
+		return fmt.Errorf("JOB_QUEUE_MAX_BACKOFF (%s) must not be less than JOB_QUEUE_BACKOFF (%s)",
+			cfg.Queue.MaxBackoff, cfg.Queue.Backoff)
+	}
+	if cfg.Queue.Retention <= 0 {
+		return fmt.Errorf("JOB_QUEUE_RETENTION must be positive")
+	}
+
+	// Validate blob storage
+	if _, err := cfg.Blob.Store(); err != nil {
+		return err
+	}
+
+	// Validate search cache
+	if cfg.Cache.TTL <= 0 {
+		return fmt.Errorf("CACHE_TTL must be positive")
+	}
+	if cfg.Cache.MemoryMaxEntries < 1 {
+		return fmt.Errorf("CACHE_MEMORY_MAX_ENTRIES must be at least 1, got %d", cfg.Cache.MemoryMaxEntries)
+	}
+	if cfg.Cache.FlightDetailsTTL < 0 {
+		return fmt.Errorf("CACHE_FLIGHT_DETAILS_TTL must not be negative")
+	}
+	if r := cfg.Cache.DriftSampleRate; math.IsNaN(r) || r < 0 || r > 1 {
+		return fmt.Errorf("CACHE_DRIFT_SAMPLE_RATE must be between 0 and 1, got %v", r)
+	}
+	if cfg.Cache.RedisDB < 0 {
+		return fmt.Errorf("CACHE_REDIS_DB must not be negative, got %d", cfg.Cache.RedisDB)
+	}
+	if cfg.Cache.RedisTimeout <= 0 {
+		return fmt.Errorf("CACHE_REDIS_TIMEOUT must be positive")
+	}
+	if _, err := cfg.Cache.SearchCache(); err != nil {
+		return err
+	}
+
+	// Validate encryption keys
+	if cfg.Encryption.Keys != "" && cfg.Encryption.KeysFile != "" {
+		return fmt.Errorf("STORAGE_ENCRYPTION_KEYS and STORAGE_ENCRYPTION_KEYS_FILE are mutually exclusive")
+	}
+	if _, err := cfg.Encryption.Keyring(); err != nil {
+		return err
+	}
+
+	// Validate response signing key
+	if cfg.Signing.Key != "" && cfg.Signing.KeyFile != "" {
+		return fmt.Errorf("RESPONSE_SIGNING_KEY and RESPONSE_SIGNING_KEY_FILE are mutually exclusive")
+	}
+	if _, err := cfg.Signing.Signer(); err != nil {
+		return err
+	}
 
 	// Validate log level
 	validLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
@@ -118,14 +1105,429 @@ func validate(cfg *Config) error {
 		return fmt.Errorf("APP_ENV must be one of: development, staging, production; got %q", cfg.App.Env)
 	}
 
+	// Validate code validation mode
+	validCodeModes := map[string]bool{"strict": true, "charter": true}
+	if !validCodeModes[cfg.Validation.CodeMode] {
+		return fmt.Errorf("CODE_VALIDATION_MODE must be one of: strict, charter; got %q", cfg.Validation.CodeMode)
+	}
+
+	// Validate routing limits
+	if _, err := cfg.Routing.Policy(); err != nil {
+		return err
+	}
+
+	// Validate price basis
+	if !domain.PriceBasis(cfg.Pricing.Basis).IsValid() {
+		return fmt.Errorf("PRICE_BASIS must be one of: per_person, total; got %q", cfg.Pricing.Basis)
+	}
+
+	// Validate load shedding
+	if _, err := cfg.LoadShed.Shedder(); err != nil {
+		return err
+	}
+
+	// Validate role definitions
+	if _, err := cfg.Tenants.RolePolicy(); err != nil {
+		return err
+	}
+	if cfg.Tenants.DuplicateWindow < 0 {
+		return fmt.Errorf("TENANT_DUPLICATE_SEARCH_WINDOW must not be negative")
+	}
+
+	// Validate admin authentication
+	if cfg.Admin.TokenMaxTTL <= 0 {
+		return fmt.Errorf("ADMIN_TOKEN_MAX_TTL must be positive")
+	}
+	if _, err := cfg.Admin.Authority(); err != nil {
+		return err
+	}
+
+	// Validate bearer token authentication
+	if cfg.Auth.Mode != AuthModeAPIKey && cfg.Auth.Mode != AuthModeJWT {
+		return fmt.Errorf("AUTH_MODE must be one of: api_key, jwt, got %q", cfg.Auth.Mode)
+	}
+	if cfg.Auth.JWKSRefreshInterval <= 0 {
+		return fmt.Errorf("JWT_JWKS_REFRESH_INTERVAL must be positive")
+	}
+	if _, err := cfg.Auth.Verifier(); err != nil {
+		return err
+	}
+	if _, err := cfg.Auth.Scopes(); err != nil {
+		return err
+	}
+
+	// Validate share links
+	if cfg.Share.TokenTTL <= 0 {
+		return fmt.Errorf("SHARE_TOKEN_TTL must be positive")
+	}
+	if _, err := cfg.Share.Issuer(); err != nil {
+		return err
+	}
+
+	// Validate asynchronous search callbacks
+	if _, err := cfg.Callback.Signer(); err != nil {
+		return err
+	}
+	if cfg.Callback.Timeout <= 0 {
+		return fmt.Errorf("SEARCH_CALLBACK_TIMEOUT must be positive")
+	}
+	if cfg.Callback.MaxAttempts < 1 {
+		return fmt.Errorf("SEARCH_CALLBACK_MAX_ATTEMPTS must be at least 1, got %d", cfg.Callback.MaxAttempts)
+	}
+
+	// Validate database
+	if cfg.Database.Driver != "" {
+		if !slices.Contains(sql.Drivers(), cfg.Database.Driver) {
+			return fmt.Errorf("DATABASE_DRIVER %q is not available; available drivers: %v",
+				cfg.Database.Driver, sql.Drivers())
+		}
+		if cfg.Database.DSN == "" {
+			return fmt.Errorf("DATABASE_DSN is required when DATABASE_DRIVER is set")
+		}
+	}
+	validJournalModes := map[string]bool{"DELETE": true, "TRUNCATE": true, "PERSIST": true, "MEMORY": true, "WAL": true, "OFF": true}
+	if !validJournalModes[cfg.Database.SQLiteJournalMode] {
+		return fmt.Errorf("DATABASE_SQLITE_JOURNAL_MODE must be one of: DELETE, TRUNCATE, PERSIST, MEMORY, WAL, OFF; got %q",
+			cfg.Database.SQLiteJournalMode)
+	}
+	validSynchronous := map[string]bool{"OFF": true, "NORMAL": true, "FULL": true, "EXTRA": true}
+	if !validSynchronous[cfg.Database.SQLiteSynchronous] {
+		return fmt.Errorf("DATABASE_SQLITE_SYNCHRONOUS must be one of: OFF, NORMAL, FULL, EXTRA; got %q",
+			cfg.Database.SQLiteSynchronous)
+	}
+	if cfg.Database.SQLiteBusyTimeout < 0 {
+		return fmt.Errorf("DATABASE_SQLITE_BUSY_TIMEOUT must not be negative")
+	}
+
+	// Validate search history store
+	switch cfg.History.Store {
+	case HistoryStoreMemory:
+	case HistoryStoreDatabase:
+		if cfg.Database.Driver != SQLiteDriver {
+			return fmt.Errorf("SEARCH_HISTORY_STORE=database requires DATABASE_DRIVER=%s", SQLiteDriver)
+		}
+	default:
+		return fmt.Errorf("SEARCH_HISTORY_STORE must be one of: memory, database; got %q", cfg.History.Store)
+	}
+
+	// Validate job queue store
+	switch cfg.Queue.Store {
+	case QueueStoreFile:
+	case QueueStoreDatabase:
+		if cfg.Database.Driver != SQLiteDriver {
+			return fmt.Errorf("JOB_QUEUE_STORE=database requires DATABASE_DRIVER=%s", SQLiteDriver)
+		}
+	default:
+		return fmt.Errorf("JOB_QUEUE_STORE must be one of: file, database; got %q", cfg.Queue.Store)
+	}
+
+	// Validate search audit log
+	switch cfg.Audit.Sink {
+	case SearchAuditSinkNone, SearchAuditSinkFile:
+	case SearchAuditSinkDatabase:
+		if cfg.Database.Driver != SQLiteDriver {
+			return fmt.Errorf("SEARCH_AUDIT_SINK=database requires DATABASE_DRIVER=%s", SQLiteDriver)
+		}
+	default:
+		return fmt.Errorf("SEARCH_AUDIT_SINK must be one of: none, file, database; got %q", cfg.Audit.Sink)
+	}
+	if cfg.Audit.Buffer < 1 {
+		return fmt.Errorf("SEARCH_AUDIT_BUFFER must be at least 1")
+	}
+
 	return nil
 }
 
+// IsShadow reports whether the named provider runs in shadow mode.
+func (p ProviderConfig) IsShadow(name string) bool {
+	for _, shadow := range p.Shadow {
+		if strings.TrimSpace(shadow) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy returns the routing policy applied to search results.
+func (r RoutingConfig) Policy() (domain.RoutingPolicy, error) {
+	if r.MaxDurationFactor != 0 && r.MaxDurationFactor < 1 {
+		return domain.RoutingPolicy{}, fmt.Errorf("ROUTING_MAX_DURATION_FACTOR must be 0 or at least 1")
+	}
+	limits, err := domain.ParseStopLimits(r.MaxStops)
+	if err != nil {
+		return domain.RoutingPolicy{}, fmt.Errorf("ROUTING_MAX_STOPS: %w", err)
+	}
+	return domain.RoutingPolicy{MaxDurationFactor: r.MaxDurationFactor, StopLimits: limits}, nil
+}
+
+// Sanitizer returns the sanitizer cleaning provider free text.
+func (s SanitizeConfig) Sanitizer() *sanitize.Sanitizer {
+	return sanitize.New(sanitize.Config{MaxLength: s.MaxLength, BlockedTerms: s.BlockedTerms, RedactPII: s.RedactPII})
+}
+
+// Shedder returns the load shedder, or nil if no feature is to be shed or no
+// threshold is set.
+func (l LoadShedConfig) Shedder(opts ...loadshed.Option) (*loadshed.Shedder, error) {
+	features, err := loadshed.ParseFeatures(l.Features)
+	if err != nil {
+		return nil, fmt.Errorf("LOAD_SHED_FEATURES: %w", err)
+	}
+	if l.MaxInFlight < 0 {
+		return nil, fmt.Errorf("LOAD_SHED_MAX_IN_FLIGHT must not be negative, got %d", l.MaxInFlight)
+	}
+	if l.P95Latency < 0 {
+		return nil, fmt.Errorf("LOAD_SHED_P95_LATENCY must not be negative")
+	}
+	if l.Window <= 0 {
+		return nil, fmt.Errorf("LOAD_SHED_WINDOW must be positive")
+	}
+	if l.Cooldown < 0 {
+		return nil, fmt.Errorf("LOAD_SHED_COOLDOWN must not be negative")
+	}
+	if len(features) == 0 || (l.MaxInFlight == 0 && l.P95Latency == 0) {
+		return nil, nil
+	}
+
+	thresholds := loadshed.Thresholds{MaxInFlight: l.MaxInFlight, P95Latency: l.P95Latency}
+	opts = append([]loadshed.Option{loadshed.WithWindow(l.Window), loadshed.WithCooldown(l.Cooldown)}, opts...)
+	return loadshed.New(features, thresholds, opts...), nil
+}
+
+// RolePolicy returns the roles granted to anonymous callers and to API keys
+// without their own role list.
+func (t TenantConfig) RolePolicy() (rbac.Policy, error) {
+	anonymous, err := parsePartnerRoles("RBAC_ANONYMOUS_ROLES", t.AnonymousRoles)
+	if err != nil {
+		return rbac.Policy{}, err
+	}
+	keys, err := parsePartnerRoles("RBAC_DEFAULT_KEY_ROLES", t.DefaultKeyRoles)
+	if err != nil {
+		return rbac.Policy{}, err
+	}
+	return rbac.Policy{Anonymous: anonymous, Keys: keys}, nil
+}
+
+// parsePartnerRoles parses a role list of an environment variable, where
+// "none" stands for no roles.
+func parsePartnerRoles(name, value string) ([]rbac.Role, error) {
+	if strings.TrimSpace(value) == "none" {
+		return nil, nil
+	}
+	roles, err := rbac.ParseRoles(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	for _, role := range roles {
+		if !role.IsPartnerRole() {
+			return nil, fmt.Errorf("%s: role %q is only granted by admin tokens", name, role)
+		}
+	}
+	return roles, nil
+}
+
+// Authority returns the admin token authority, or nil if no secret is configured.
+func (a AdminConfig) Authority() (*adminauth.Authority, error) {
+	if a.TokenSecret == "" {
+		return nil, nil
+	}
+	authority, err := adminauth.NewAuthority([]byte(a.TokenSecret), a.TokenMaxTTL)
+	if err != nil {
+		return nil, fmt.Errorf("ADMIN_TOKEN_SECRET: %w", err)
+	}
+	return authority, nil
+}
+
+// Verifier returns the bearer token verifier, or nil outside jwt mode.
+func (a AuthConfig) Verifier() (*jwtauth.Verifier, error) {
+	if a.Mode != AuthModeJWT {
+		return nil, nil
+	}
+	switch {
+	case a.JWTIssuer == "":
+		return nil, fmt.Errorf("JWT_ISSUER is required when AUTH_MODE is jwt")
+	case a.JWTAudience == "":
+		return nil, fmt.Errorf("JWT_AUDIENCE is required when AUTH_MODE is jwt")
+	case a.JWKSURL == "":
+		return nil, fmt.Errorf("JWT_JWKS_URL is required when AUTH_MODE is jwt")
+	}
+	verifier, err := jwtauth.NewVerifier(jwtauth.Config{
+		Issuer:          a.JWTIssuer,
+		Audience:        a.JWTAudience,
+		JWKSURL:         a.JWKSURL,
+		RefreshInterval: a.JWKSRefreshInterval,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("JWT_JWKS_URL: %w", err)
+	}
+	return verifier, nil
+}
+
+// Scopes returns the role granted by each token scope.
+func (a AuthConfig) Scopes() (map[string]rbac.Role, error) {
+	scopes := make(map[string]rbac.Role, len(a.ScopeRoles))
+	for scope, value := range a.ScopeRoles {
+		role := rbac.Role(strings.TrimSpace(value))
+		if !role.IsValid() {
+			return nil, fmt.Errorf("JWT_SCOPE_ROLES: scope %q: invalid role %q", scope, role)
+		}
+		scopes[strings.TrimSpace(scope)] = role
+	}
+	return scopes, nil
+}
+
+// Issuer returns the share token issuer, or nil if no secret is configured.
+func (s ShareConfig) Issuer() (*share.Issuer, error) {
+	if s.TokenSecret == "" {
+		return nil, nil
+	}
+	issuer, err := share.NewIssuer([]byte(s.TokenSecret), s.TokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("SHARE_TOKEN_SECRET: %w", err)
+	}
+	return issuer, nil
+}
+
+// Signer returns the signer of asynchronous search callbacks, or nil if no
+// secret is configured.
+func (c CallbackConfig) Signer() (*webhook.Signer, error) {
+	if c.Secret == "" {
+		return nil, nil
+	}
+	signer, err := webhook.NewSigner(c.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("SEARCH_CALLBACK_SECRET: %w", err)
+	}
+	return signer, nil
+}
+
+// Open returns a handle to the configured database, or nil if no driver is
+// configured. It does not connect: connections are made on first use.
+func (d DatabaseConfig) Open() (*sql.DB, error) {
+	if d.Driver == "" {
+		return nil, nil
+	}
+	db, err := sql.Open(d.Driver, d.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("DATABASE_DRIVER: %w", err)
+	}
+	return db, nil
+}
+
+// Store returns the configured blob store.
+func (b BlobConfig) Store() (blob.Store, error) {
+	switch b.Backend {
+	case "file":
+		return blob.NewFileStore(b.Dir), nil
+	case "s3":
+		store, err := blob.NewS3Store(blob.S3Config{
+			Endpoint:        b.S3Endpoint,
+			Region:          b.S3Region,
+			Bucket:          b.S3Bucket,
+			Prefix:          b.S3Prefix,
+			AccessKeyID:     b.S3AccessKeyID,
+			SecretAccessKey: b.S3SecretAccessKey,
+			PathStyle:       b.S3PathStyle,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("BLOB_S3: %w", err)
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("BLOB_BACKEND must be one of: file, s3, got %q", b.Backend)
+	}
+}
+
+// SearchCache returns the configured search result cache, or nil if caching
+// is disabled. opts configure the memory backend.
+func (c CacheConfig) SearchCache(opts ...cache.MemoryOption) (domain.SearchCache, error) {
+	switch c.Backend {
+	case "none":
+		return nil, nil
+	case "memory":
+		return cache.NewMemoryCache(c.MemoryMaxEntries, opts...), nil
+	case "redis":
+		redis, err := cache.NewRedisCache(cache.RedisConfig{
+			Addr:     c.RedisAddr,
+			Password: c.RedisPassword,
+			DB:       c.RedisDB,
+			Prefix:   c.RedisPrefix,
+			Timeout:  c.RedisTimeout,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("CACHE_REDIS: %w", err)
+		}
+		return redis, nil
+	default:
+		return nil, fmt.Errorf("CACHE_BACKEND must be one of: none, memory, redis, got %q", c.Backend)
+	}
+}
+
+// Keyring returns the configured encryption keyring, or nil if no keys are configured.
+func (e EncryptionConfig) Keyring() (*encryption.Keyring, error) {
+	spec, source := e.Keys, "STORAGE_ENCRYPTION_KEYS"
+	if e.KeysFile != "" {
+		data, err := os.ReadFile(e.KeysFile)
+		if err != nil {
+			return nil, fmt.Errorf("STORAGE_ENCRYPTION_KEYS_FILE: %w", err)
+		}
+		spec, source = strings.TrimSpace(string(data)), "STORAGE_ENCRYPTION_KEYS_FILE"
+	}
+	if spec == "" {
+		return nil, nil
+	}
+
+	keyring, err := encryption.ParseKeyring(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return keyring, nil
+}
+
+// Signer returns the configured response signer, or nil if no key is configured.
+func (s SigningConfig) Signer() (*signing.Signer, error) {
+	spec, source := s.Key, "RESPONSE_SIGNING_KEY"
+	if s.KeyFile != "" {
+		data, err := os.ReadFile(s.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("RESPONSE_SIGNING_KEY_FILE: %w", err)
+		}
+		spec, source = strings.TrimSpace(string(data)), "RESPONSE_SIGNING_KEY_FILE"
+	}
+	if spec == "" {
+		return nil, nil
+	}
+
+	signer, err := signing.ParseSigner(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return signer, nil
+}
+
+// ClassTimeouts returns the global search timeout budget for each request class.
+func (t TimeoutConfig) ClassTimeouts() map[domain.RequestClass]time.Duration {
+	return map[domain.RequestClass]time.Duration{
+		domain.RequestClassInteractive: t.GlobalSearch,
+		domain.RequestClassBatch:       t.GlobalSearchBatch,
+	}
+}
+
 // IsDevelopment returns true if running in development mode.
 func (c *Config) IsDevelopment() bool {
 	return c.App.Env == "development"
 }
 
+// IsStaging returns true if running in staging mode.
+func (c *Config) IsStaging() bool {
+	return c.App.Env == "staging"
+}
+
+// OpenAPIValidationEnabled returns true if OpenAPI contract validation should run.
+// It is enabled explicitly via OPENAPI_VALIDATION_ENABLED or implicitly in staging.
+func (c *Config) OpenAPIValidationEnabled() bool {
+	return c.Validation.OpenAPIEnabled || c.IsStaging()
+}
+
 // IsProduction returns true if running in production mode.
 func (c *Config) IsProduction() bool {
 	return c.App.Env == "production"
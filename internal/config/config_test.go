@@ -1,11 +1,29 @@
 package config
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"maps"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/httpsource"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/configdoc"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/cache"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/retry"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/ratelimit"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/storage/blob"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/workerpool"
 )
 
 // TestLoad_Defaults tests that all default values load correctly without any env vars.
@@ -20,10 +38,30 @@ func TestLoad_Defaults(t *testing.T) {
 	assert.Equal(t, 8080, cfg.Server.Port, "default server port")
 	assert.Equal(t, "10s", cfg.Server.ReadTimeout.String(), "default read timeout")
 	assert.Equal(t, "10s", cfg.Server.WriteTimeout.String(), "default write timeout")
+	assert.Equal(t, "5s", cfg.Server.ReadHeaderTimeout.String(), "default read header timeout")
+	assert.Equal(t, "1m0s", cfg.Server.IdleTimeout.String(), "default idle timeout")
+	assert.Equal(t, 1000, cfg.Server.MaxConnections, "default max connections")
+	assert.Equal(t, 50, cfg.Server.MaxConnectionsPerIP, "default max connections per IP")
+	assert.Equal(t, 100, cfg.Server.MaxConcurrentSearches, "default max concurrent searches")
+	assert.Equal(t, int64(1048576), cfg.Server.MaxRequestBodyBytes, "default max request body bytes")
+	assert.Equal(t, 5, cfg.Server.CompressionLevel, "default compression level")
+	assert.Equal(t, 1024, cfg.Server.CompressionMinBytes, "default compression min bytes")
+	assert.Equal(t, "1s", cfg.Server.RetryAfter.String(), "default retry after")
 
 	// Timeout defaults
 	assert.Equal(t, "5s", cfg.Timeouts.GlobalSearch.String(), "default global search timeout")
 	assert.Equal(t, "2s", cfg.Timeouts.PerProvider.String(), "default per-provider timeout")
+	assert.Equal(t, "30s", cfg.Timeouts.GlobalSearchBatch.String(), "default batch search timeout")
+	assert.Equal(t, "1.5s", cfg.Timeouts.SoftDeadline.String(), "default soft deadline")
+	assert.Equal(t, "30s", cfg.Timeouts.ContinuationTTL.String(), "default continuation TTL")
+
+	// Job queue defaults
+	assert.Equal(t, "data/jobs.json", cfg.Queue.File)
+	assert.Equal(t, 2, cfg.Queue.Workers)
+	assert.Equal(t, 5, cfg.Queue.MaxAttempts)
+	assert.Equal(t, time.Second, cfg.Queue.Backoff)
+	assert.Equal(t, 5*time.Minute, cfg.Queue.MaxBackoff)
+	assert.Equal(t, 7*24*time.Hour, cfg.Queue.Retention)
 
 	// Logging defaults
 	assert.Equal(t, "info", cfg.Logging.Level, "default log level")
@@ -31,6 +69,17 @@ func TestLoad_Defaults(t *testing.T) {
 
 	// App defaults
 	assert.Equal(t, "development", cfg.App.Env, "default app environment")
+	assert.False(t, cfg.App.DemoMode, "demo mode disabled by default")
+	assert.Equal(t, "docs/response-mock", cfg.App.MockDataDir, "default mock data directory")
+
+	// History defaults
+	assert.Equal(t, 1000, cfg.History.Capacity, "default search history capacity")
+	assert.Equal(t, 90*24*time.Hour, cfg.History.Retention, "default search history retention")
+	assert.Equal(t, 365*24*time.Hour, cfg.History.AggregateRetention, "default search aggregate retention")
+	assert.Equal(t, "@hourly", cfg.Scheduler.HistoryCompaction, "default history compaction schedule")
+
+	// Tenant defaults
+	assert.Empty(t, cfg.Tenants.APIKeysFile, "no API key file by default")
 }
 
 // TestLoad_EnvironmentOverrides tests that environment variables override defaults.
@@ -126,10 +175,84 @@ func TestLoad_Validation_PositiveTimeouts(t *testing.T) {
 		{"negative read timeout", "SERVER_READ_TIMEOUT", "-1s", "SERVER_READ_TIMEOUT must be positive"},
 		{"zero write timeout", "SERVER_WRITE_TIMEOUT", "0s", "SERVER_WRITE_TIMEOUT must be positive"},
 		{"negative write timeout", "SERVER_WRITE_TIMEOUT", "-1s", "SERVER_WRITE_TIMEOUT must be positive"},
+		{"zero read header timeout", "SERVER_READ_HEADER_TIMEOUT", "0s", "SERVER_READ_HEADER_TIMEOUT must be positive"},
+		{"zero idle timeout", "SERVER_IDLE_TIMEOUT", "0s", "SERVER_IDLE_TIMEOUT must be positive"},
+		{"zero retry after", "SERVER_RETRY_AFTER", "0s", "SERVER_RETRY_AFTER must be positive"},
 		{"zero global search timeout", "TIMEOUT_GLOBAL_SEARCH", "0s", "TIMEOUT_GLOBAL_SEARCH must be positive"},
 		{"negative global search timeout", "TIMEOUT_GLOBAL_SEARCH", "-1s", "TIMEOUT_GLOBAL_SEARCH must be positive"},
 		{"zero per-provider timeout", "TIMEOUT_PER_PROVIDER", "0s", "TIMEOUT_PER_PROVIDER must be positive"},
 		{"negative per-provider timeout", "TIMEOUT_PER_PROVIDER", "-1s", "TIMEOUT_PER_PROVIDER must be positive"},
+		{"zero batch search timeout", "TIMEOUT_GLOBAL_SEARCH_BATCH", "0s", "TIMEOUT_GLOBAL_SEARCH_BATCH must be positive"},
+		{"zero soft deadline", "TIMEOUT_SOFT_DEADLINE", "0s", "TIMEOUT_SOFT_DEADLINE must be positive"},
+		{"zero continuation TTL", "TIMEOUT_CONTINUATION_TTL", "0s", "TIMEOUT_CONTINUATION_TTL must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, map[string]string{tt.envVar: tt.value})
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+}
+
+// TestLoad_Validation_ConnectionLimits tests that connection limits must not be negative.
+func TestLoad_Validation_ConnectionLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		envVar string
+		value  string
+		errMsg string
+	}{
+		{"negative max connections", "SERVER_MAX_CONNECTIONS", "-1", "SERVER_MAX_CONNECTIONS must not be negative"},
+		{"negative max per IP", "SERVER_MAX_CONNECTIONS_PER_IP", "-1", "SERVER_MAX_CONNECTIONS_PER_IP must not be negative"},
+		{"negative max concurrent searches", "SERVER_MAX_CONCURRENT_SEARCHES", "-1", "SERVER_MAX_CONCURRENT_SEARCHES must not be negative"},
+		{"zero history capacity", "SEARCH_HISTORY_CAPACITY", "0", "SEARCH_HISTORY_CAPACITY must be at least 1"},
+		{"zero history retention", "SEARCH_HISTORY_RETENTION", "0s", "SEARCH_HISTORY_RETENTION must be positive"},
+		{"aggregate retention below record retention", "SEARCH_AGGREGATE_RETENTION", "24h", "SEARCH_AGGREGATE_RETENTION (24h0m0s) must not be less than SEARCH_HISTORY_RETENTION (2160h0m0s)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, map[string]string{tt.envVar: tt.value})
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+
+	t.Run("zero disables limits", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{"SERVER_MAX_CONNECTIONS": "0", "SERVER_MAX_CONNECTIONS_PER_IP": "0", "SERVER_MAX_CONCURRENT_SEARCHES": "0"})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Zero(t, cfg.Server.MaxConnections)
+		assert.Zero(t, cfg.Server.MaxConnectionsPerIP)
+		assert.Zero(t, cfg.Server.MaxConcurrentSearches)
+	})
+}
+
+// TestLoad_Validation_BodyLimitAndCompression tests the request body limit and
+// response compression settings.
+func TestLoad_Validation_BodyLimitAndCompression(t *testing.T) {
+	tests := []struct {
+		name   string
+		envVar string
+		value  string
+		errMsg string
+	}{
+		{"zero max body", "SERVER_MAX_REQUEST_BODY_BYTES", "0", "SERVER_MAX_REQUEST_BODY_BYTES must be positive"},
+		{"negative compression level", "SERVER_COMPRESSION_LEVEL", "-1", "SERVER_COMPRESSION_LEVEL must be between 0 and 9"},
+		{"compression level above 9", "SERVER_COMPRESSION_LEVEL", "10", "SERVER_COMPRESSION_LEVEL must be between 0 and 9"},
+		{"negative compression min bytes", "SERVER_COMPRESSION_MIN_BYTES", "-1", "SERVER_COMPRESSION_MIN_BYTES must not be negative"},
 	}
 
 	for _, tt := range tests {
@@ -143,6 +266,15 @@ func TestLoad_Validation_PositiveTimeouts(t *testing.T) {
 			assert.Nil(t, cfg)
 		})
 	}
+
+	t.Run("zero disables compression", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{"SERVER_COMPRESSION_LEVEL": "0"})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Zero(t, cfg.Server.CompressionLevel)
+	})
 }
 
 // TestLoad_Validation_PerProviderLessThanGlobal tests that per-provider timeout must be less than global.
@@ -174,6 +306,38 @@ func TestLoad_Validation_PerProviderLessThanGlobal(t *testing.T) {
 	assert.Nil(t, cfg)
 }
 
+// TestLoad_PerProviderTimeoutOverrides tests per-provider timeout overrides.
+func TestLoad_PerProviderTimeoutOverrides(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Timeouts.PerProviderByProvider)
+
+	setEnvVars(t, map[string]string{"TIMEOUT_PER_PROVIDER_BY_PROVIDER": "lion_air=3s,airasia=500ms"})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"lion_air": 3 * time.Second, "airasia": 500 * time.Millisecond}, cfg.Timeouts.PerProviderByProvider)
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"zero timeout", "lion_air=0s", "TIMEOUT_PER_PROVIDER_BY_PROVIDER: timeout of lion_air must be positive"},
+		{"unknown provider", "lionair=3s", `TIMEOUT_PER_PROVIDER_BY_PROVIDER: unknown provider "lionair", must be one of: garuda_indonesia, lion_air, batik_air, airasia`},
+		{"not below the global timeout", "lion_air=5s", "TIMEOUT_PER_PROVIDER_BY_PROVIDER: timeout of lion_air (5s) should be less than TIMEOUT_GLOBAL_SEARCH (5s)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, map[string]string{"TIMEOUT_PER_PROVIDER_BY_PROVIDER": tt.value})
+			_, err := Load()
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
 // TestLoad_Validation_LogLevel tests log level validation.
 func TestLoad_Validation_LogLevel(t *testing.T) {
 	tests := []struct {
@@ -241,6 +405,38 @@ func TestLoad_Validation_LogFormat(t *testing.T) {
 	}
 }
 
+// TestLoad_Validation_CodeMode tests code validation mode validation.
+func TestLoad_Validation_CodeMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		want    string
+		wantErr bool
+	}{
+		{"default", "", "strict", false},
+		{"valid strict", "strict", "strict", false},
+		{"valid charter", "charter", "charter", false},
+		{"invalid icao", "icao", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, map[string]string{"CODE_VALIDATION_MODE": tt.mode})
+
+			cfg, err := Load()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "CODE_VALIDATION_MODE must be one of")
+				assert.Nil(t, cfg)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, cfg.Validation.CodeMode)
+			}
+		})
+	}
+}
+
 // TestLoad_Validation_AppEnv tests app environment validation.
 func TestLoad_Validation_AppEnv(t *testing.T) {
 	tests := []struct {
@@ -360,6 +556,1423 @@ func TestConfig_IsProduction(t *testing.T) {
 	}
 }
 
+// TestConfig_OpenAPIValidationEnabled tests that validation is enabled explicitly or in staging.
+func TestConfig_OpenAPIValidationEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected bool
+	}{
+		{name: "disabled by default", env: map[string]string{"APP_ENV": "development"}, expected: false},
+		{name: "enabled in staging", env: map[string]string{"APP_ENV": "staging"}, expected: true},
+		{name: "enabled explicitly", env: map[string]string{"APP_ENV": "production", "OPENAPI_VALIDATION_ENABLED": "true"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.env)
+
+			cfg, err := Load()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.OpenAPIValidationEnabled())
+			assert.True(t, cfg.Validation.OpenAPIResponses)
+		})
+	}
+}
+
+func TestTimeoutConfig_ClassTimeouts(t *testing.T) {
+	timeouts := TimeoutConfig{GlobalSearch: 5 * time.Second, GlobalSearchBatch: 30 * time.Second}
+
+	assert.Equal(t, map[domain.RequestClass]time.Duration{
+		domain.RequestClassInteractive: 5 * time.Second,
+		domain.RequestClassBatch:       30 * time.Second,
+	}, timeouts.ClassTimeouts())
+}
+
+// TestLoad_Validation_Scheduler tests that job schedules must be valid.
+func TestLoad_Validation_Scheduler(t *testing.T) {
+	tests := []struct {
+		name   string
+		envVar string
+		value  string
+		errMsg string
+	}{
+		{"negative jitter", "SCHEDULER_JITTER", "-1s", "SCHEDULER_JITTER must not be negative"},
+		{"invalid cron", "SCHEDULE_MOCK_ROTATION", "61 * * * *", "SCHEDULE_MOCK_ROTATION"},
+		{"invalid interval", "SCHEDULE_MOCK_ROTATION", "@every soon", "SCHEDULE_MOCK_ROTATION"},
+		{"invalid compaction schedule", "SCHEDULE_HISTORY_COMPACTION", "@fortnightly", "SCHEDULE_HISTORY_COMPACTION"},
+		{"invalid export schedule", "SCHEDULE_ANALYTICS_EXPORT", "daily", "SCHEDULE_ANALYTICS_EXPORT"},
+		{"zero export min searches", "ANALYTICS_EXPORT_MIN_SEARCHES", "0", "ANALYTICS_EXPORT_MIN_SEARCHES must be at least 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, map[string]string{tt.envVar: tt.value})
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+
+	t.Run("valid schedule", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{"SCHEDULE_MOCK_ROTATION": "*/30 * * * *", "SCHEDULER_JITTER": "10s"})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, "*/30 * * * *", cfg.Scheduler.MockRotation)
+		assert.Equal(t, 10*time.Second, cfg.Scheduler.Jitter)
+	})
+}
+
+// TestLoad_AnalyticsExport tests analytics export defaults.
+func TestLoad_AnalyticsExport(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Scheduler.AnalyticsExport, "export disabled by default")
+	assert.Equal(t, 1, cfg.Analytics.MinSearches)
+}
+
+// TestLoad_Validation_Queue tests job queue settings.
+func TestLoad_Validation_Queue(t *testing.T) {
+	tests := []struct {
+		name   string
+		vars   map[string]string
+		errMsg string
+	}{
+		{"zero workers", map[string]string{"JOB_QUEUE_WORKERS": "0"}, "JOB_QUEUE_WORKERS must be at least 1"},
+		{"zero attempts", map[string]string{"JOB_QUEUE_MAX_ATTEMPTS": "0"}, "JOB_QUEUE_MAX_ATTEMPTS must be at least 1"},
+		{"zero backoff", map[string]string{"JOB_QUEUE_BACKOFF": "0s"}, "JOB_QUEUE_BACKOFF must be positive"},
+		{"max below base", map[string]string{"JOB_QUEUE_BACKOFF": "10s", "JOB_QUEUE_MAX_BACKOFF": "5s"}, "JOB_QUEUE_MAX_BACKOFF (5s) must not be less than JOB_QUEUE_BACKOFF (10s)"},
+		{"zero retention", map[string]string{"JOB_QUEUE_RETENTION": "0s"}, "JOB_QUEUE_RETENTION must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+}
+
+// TestLoad_Validation_Encryption tests at-rest encryption key settings.
+func TestLoad_Validation_Encryption(t *testing.T) {
+	key := "k1:" + strings.Repeat("A", 43) + "="
+
+	tests := []struct {
+		name   string
+		vars   map[string]string
+		errMsg string
+	}{
+		{"both sources", map[string]string{"STORAGE_ENCRYPTION_KEYS": key, "STORAGE_ENCRYPTION_KEYS_FILE": "keys"}, "mutually exclusive"},
+		{"short key", map[string]string{"STORAGE_ENCRYPTION_KEYS": "k1:c2hvcnQ="}, "STORAGE_ENCRYPTION_KEYS: key k1: must be 32 bytes"},
+		{"missing file", map[string]string{"STORAGE_ENCRYPTION_KEYS_FILE": filepath.Join(t.TempDir(), "missing")}, "STORAGE_ENCRYPTION_KEYS_FILE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		clearEnvVars(t)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		keyring, err := cfg.Encryption.Keyring()
+		require.NoError(t, err)
+		assert.Nil(t, keyring)
+	})
+
+	t.Run("keys from file", func(t *testing.T) {
+		clearEnvVars(t)
+		path := filepath.Join(t.TempDir(), "keys")
+		require.NoError(t, os.WriteFile(path, []byte(key+"\n"), 0o600))
+		setEnvVars(t, map[string]string{"STORAGE_ENCRYPTION_KEYS_FILE": path})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		keyring, err := cfg.Encryption.Keyring()
+		require.NoError(t, err)
+		assert.Equal(t, "k1", keyring.PrimaryKeyID())
+	})
+}
+
+// TestLoad_Validation_Signing tests response signing key settings.
+func TestLoad_Validation_Signing(t *testing.T) {
+	key := "2025-12:" + strings.Repeat("A", 43) + "="
+
+	tests := []struct {
+		name   string
+		vars   map[string]string
+		errMsg string
+	}{
+		{"both sources", map[string]string{"RESPONSE_SIGNING_KEY": key, "RESPONSE_SIGNING_KEY_FILE": "key"}, "mutually exclusive"},
+		{"short key", map[string]string{"RESPONSE_SIGNING_KEY": "k1:c2hvcnQ="}, "RESPONSE_SIGNING_KEY: invalid signing key: key k1: seed must be 32 bytes"},
+		{"missing file", map[string]string{"RESPONSE_SIGNING_KEY_FILE": filepath.Join(t.TempDir(), "missing")}, "RESPONSE_SIGNING_KEY_FILE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		clearEnvVars(t)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		signer, err := cfg.Signing.Signer()
+		require.NoError(t, err)
+		assert.Nil(t, signer)
+	})
+
+	t.Run("key from file", func(t *testing.T) {
+		clearEnvVars(t)
+		path := filepath.Join(t.TempDir(), "key")
+		require.NoError(t, os.WriteFile(path, []byte(key+"\n"), 0o600))
+		setEnvVars(t, map[string]string{"RESPONSE_SIGNING_KEY_FILE": path})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		signer, err := cfg.Signing.Signer()
+		require.NoError(t, err)
+		assert.Equal(t, "2025-12", signer.KeyID())
+	})
+}
+
+// TestLoad_Blob tests blob storage settings.
+func TestLoad_Blob(t *testing.T) {
+	tests := []struct {
+		name   string
+		vars   map[string]string
+		errMsg string
+	}{
+		{"unknown backend", map[string]string{"BLOB_BACKEND": "gcs"}, `BLOB_BACKEND must be one of: file, s3, got "gcs"`},
+		{"s3 without bucket", map[string]string{"BLOB_BACKEND": "s3"}, "BLOB_S3: bucket is required"},
+		{"s3 without credentials", map[string]string{"BLOB_BACKEND": "s3", "BLOB_S3_BUCKET": "exports"}, "BLOB_S3: access key ID and secret access key are required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+
+	t.Run("file backend by default", func(t *testing.T) {
+		clearEnvVars(t)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		store, err := cfg.Blob.Store()
+		require.NoError(t, err)
+		assert.IsType(t, &blob.FileStore{}, store)
+	})
+
+	t.Run("s3 backend", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{
+			"BLOB_BACKEND":              "s3",
+			"BLOB_S3_ENDPOINT":          "http://localhost:9000",
+			"BLOB_S3_BUCKET":            "flight-search",
+			"BLOB_S3_ACCESS_KEY_ID":     "minio",
+			"BLOB_S3_SECRET_ACCESS_KEY": "minio-secret",
+			"BLOB_S3_PATH_STYLE":        "true",
+		})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		store, err := cfg.Blob.Store()
+		require.NoError(t, err)
+		assert.IsType(t, &blob.S3Store{}, store)
+	})
+}
+
+// TestLoad_Cache tests search result cache settings.
+func TestLoad_Cache(t *testing.T) {
+	tests := []struct {
+		name   string
+		vars   map[string]string
+		errMsg string
+	}{
+		{"unknown backend", map[string]string{"CACHE_BACKEND": "memcached"}, `CACHE_BACKEND must be one of: none, memory, redis, got "memcached"`},
+		{"zero memory capacity", map[string]string{"CACHE_BACKEND": "memory", "CACHE_MEMORY_MAX_ENTRIES": "0"}, "CACHE_MEMORY_MAX_ENTRIES must be at least 1"},
+		{"zero ttl", map[string]string{"CACHE_TTL": "0s"}, "CACHE_TTL must be positive"},
+		{"negative flight details ttl", map[string]string{"CACHE_FLIGHT_DETAILS_TTL": "-1s"}, "CACHE_FLIGHT_DETAILS_TTL must not be negative"},
+		{"negative drift sample rate", map[string]string{"CACHE_DRIFT_SAMPLE_RATE": "-0.1"}, "CACHE_DRIFT_SAMPLE_RATE must be between 0 and 1"},
+		{"drift sample rate above 1", map[string]string{"CACHE_DRIFT_SAMPLE_RATE": "1.5"}, "CACHE_DRIFT_SAMPLE_RATE must be between 0 and 1"},
+		{"negative db", map[string]string{"CACHE_REDIS_DB": "-1"}, "CACHE_REDIS_DB must not be negative"},
+		{"zero redis timeout", map[string]string{"CACHE_BACKEND": "redis", "CACHE_REDIS_TIMEOUT": "0s"}, "CACHE_REDIS_TIMEOUT must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		clearEnvVars(t)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Minute, cfg.Cache.TTL)
+		assert.Equal(t, 0.01, cfg.Cache.DriftSampleRate)
+		searchCache, err := cfg.Cache.SearchCache()
+		require.NoError(t, err)
+		assert.Nil(t, searchCache)
+	})
+
+	t.Run("memory backend", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{"CACHE_BACKEND": "memory", "CACHE_MEMORY_MAX_ENTRIES": "50"})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, 50, cfg.Cache.MemoryMaxEntries)
+		searchCache, err := cfg.Cache.SearchCache()
+		require.NoError(t, err)
+		assert.IsType(t, &cache.MemoryCache{}, searchCache)
+	})
+
+	t.Run("redis backend", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{
+			"CACHE_BACKEND":    "redis",
+			"CACHE_TTL":        "90s",
+			"CACHE_REDIS_ADDR": "redis:6379",
+			"CACHE_REDIS_DB":   "2",
+		})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Second, cfg.Cache.TTL)
+		searchCache, err := cfg.Cache.SearchCache()
+		require.NoError(t, err)
+		assert.IsType(t, &cache.RedisCache{}, searchCache)
+	})
+}
+
+// TestLoad_Admin tests admin API authentication settings.
+func TestLoad_Admin(t *testing.T) {
+	secret := strings.Repeat("s", 32)
+
+	tests := []struct {
+		name   string
+		vars   map[string]string
+		errMsg string
+	}{
+		{"short secret", map[string]string{"ADMIN_TOKEN_SECRET": "short"}, "ADMIN_TOKEN_SECRET: secret must be at least 32 bytes"},
+		{"zero max TTL", map[string]string{"ADMIN_TOKEN_SECRET": secret, "ADMIN_TOKEN_MAX_TTL": "0s"}, "ADMIN_TOKEN_MAX_TTL must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+
+	t.Run("unauthenticated outside production", func(t *testing.T) {
+		clearEnvVars(t)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Minute, cfg.Admin.TokenMaxTTL)
+		assert.Equal(t, "data/audit.jsonl", cfg.Admin.AuditFile)
+		authority, err := cfg.Admin.Authority()
+		require.NoError(t, err)
+		assert.Nil(t, authority)
+	})
+
+	t.Run("with secret", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{"ADMIN_TOKEN_SECRET": secret})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		authority, err := cfg.Admin.Authority()
+		require.NoError(t, err)
+		assert.NotNil(t, authority)
+	})
+}
+
+func TestLoad_Auth(t *testing.T) {
+	jwt := map[string]string{
+		"AUTH_MODE":    "jwt",
+		"JWT_ISSUER":   "https://auth.example.com/",
+		"JWT_AUDIENCE": "flight-search",
+		"JWT_JWKS_URL": "https://auth.example.com/.well-known/jwks.json",
+	}
+	with := func(key, value string) map[string]string {
+		vars := maps.Clone(jwt)
+		vars[key] = value
+		return vars
+	}
+
+	tests := []struct {
+		name   string
+		vars   map[string]string
+		errMsg string
+	}{
+		{"unknown mode", map[string]string{"AUTH_MODE": "basic"}, "AUTH_MODE must be one of: api_key, jwt"},
+		{"no issuer", with("JWT_ISSUER", ""), "JWT_ISSUER is required when AUTH_MODE is jwt"},
+		{"no audience", with("JWT_AUDIENCE", ""), "JWT_AUDIENCE is required when AUTH_MODE is jwt"},
+		{"no JWKS URL", with("JWT_JWKS_URL", ""), "JWT_JWKS_URL is required when AUTH_MODE is jwt"},
+		{"relative JWKS URL", with("JWT_JWKS_URL", "/jwks.json"), "JWT_JWKS_URL: JWKS URL"},
+		{"zero refresh interval", with("JWT_JWKS_REFRESH_INTERVAL", "0s"), "JWT_JWKS_REFRESH_INTERVAL must be positive"},
+		{"invalid role", with("JWT_SCOPE_ROLES", "flights:search=searcher"), `JWT_SCOPE_ROLES: scope "flights:search": invalid role "searcher"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+
+	t.Run("api keys by default", func(t *testing.T) {
+		clearEnvVars(t)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, AuthModeAPIKey, cfg.Auth.Mode)
+		verifier, err := cfg.Auth.Verifier()
+		require.NoError(t, err)
+		assert.Nil(t, verifier)
+		scopes, err := cfg.Auth.Scopes()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]rbac.Role{
+			"flights:search": rbac.RoleSearch,
+			"flights:export": rbac.RoleExport,
+			"flights:admin":  rbac.RoleAdmin,
+			"flights:debug":  rbac.RoleDebug,
+		}, scopes)
+	})
+
+	t.Run("jwt", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, with("JWT_SCOPE_ROLES", "search=search,ops=admin"))
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, cfg.Auth.JWKSRefreshInterval)
+		verifier, err := cfg.Auth.Verifier()
+		require.NoError(t, err)
+		assert.NotNil(t, verifier)
+		scopes, err := cfg.Auth.Scopes()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]rbac.Role{"search": rbac.RoleSearch, "ops": rbac.RoleAdmin}, scopes)
+	})
+}
+
+// TestSchema tests that every option is documented and credentials are redacted.
+func TestSchema(t *testing.T) {
+	descriptions, err := configdoc.Extract("config.go")
+	require.NoError(t, err)
+	assert.Equal(t, descriptions, optionDescriptions, "descriptions_gen.go is stale, run go generate ./internal/config")
+
+	credential := regexp.MustCompile(`SECRET|PASSWORD|ACCESS_KEY|AUTH_VALUES|_KEYS?$`)
+	for _, option := range Schema() {
+		assert.NotEmpty(t, option.Description, "%s has no doc comment", option.Name)
+		if credential.MatchString(option.Name) {
+			assert.True(t, option.Secret, "%s must be tagged secret", option.Name)
+		}
+	}
+
+	clearEnvVars(t)
+	setEnvVars(t, map[string]string{
+		"ADMIN_TOKEN_SECRET":        strings.Repeat("s", 32),
+		"PROVIDER_HTTP_BASE_URLS":   "lion_air=https://api.lionair.example/search",
+		"PROVIDER_HTTP_AUTH_VALUES": "lion_air=Bearer abc",
+	})
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	values := map[string]string{}
+	for _, setting := range cfg.Settings() {
+		values[setting.Name] = setting.Value
+	}
+	assert.Len(t, values, len(Schema()))
+	assert.Equal(t, configdoc.Redacted, values["ADMIN_TOKEN_SECRET"])
+	assert.Equal(t, configdoc.Redacted, values["PROVIDER_HTTP_AUTH_VALUES"])
+	assert.Empty(t, values["SHARE_TOKEN_SECRET"], "unset secrets show as unset")
+	assert.Equal(t, "lion_air=https://api.lionair.example/search", values["PROVIDER_HTTP_BASE_URLS"])
+	assert.Equal(t, "5s", values["TIMEOUT_GLOBAL_SEARCH"])
+}
+
+// TestLoad_Share tests share link settings.
+func TestLoad_Share(t *testing.T) {
+	secret := strings.Repeat("s", 32)
+
+	tests := []struct {
+		name   string
+		vars   map[string]string
+		errMsg string
+	}{
+		{"short secret", map[string]string{"SHARE_TOKEN_SECRET": "short"}, "SHARE_TOKEN_SECRET: secret must be at least 32 bytes"},
+		{"zero TTL", map[string]string{"SHARE_TOKEN_SECRET": secret, "SHARE_TOKEN_TTL": "0s"}, "SHARE_TOKEN_TTL must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		clearEnvVars(t)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, 72*time.Hour, cfg.Share.TokenTTL)
+		issuer, err := cfg.Share.Issuer()
+		require.NoError(t, err)
+		assert.Nil(t, issuer)
+	})
+
+	t.Run("with secret", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{"SHARE_TOKEN_SECRET": secret})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		issuer, err := cfg.Share.Issuer()
+		require.NoError(t, err)
+		assert.NotNil(t, issuer)
+	})
+}
+
+func TestLoad_SearchCallback(t *testing.T) {
+	secret := strings.Repeat("s", 32)
+
+	tests := []struct {
+		name   string
+		vars   map[string]string
+		errMsg string
+	}{
+		{"short secret", map[string]string{"SEARCH_CALLBACK_SECRET": "short"}, "SEARCH_CALLBACK_SECRET: secret must be at least 32 bytes"},
+		{"zero timeout", map[string]string{"SEARCH_CALLBACK_TIMEOUT": "0s"}, "SEARCH_CALLBACK_TIMEOUT must be positive"},
+		{"zero attempts", map[string]string{"SEARCH_CALLBACK_MAX_ATTEMPTS": "0"}, "SEARCH_CALLBACK_MAX_ATTEMPTS must be at least 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		clearEnvVars(t)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Second, cfg.Callback.Timeout)
+		assert.Equal(t, 5, cfg.Callback.MaxAttempts)
+		signer, err := cfg.Callback.Signer()
+		require.NoError(t, err)
+		assert.Nil(t, signer)
+	})
+
+	t.Run("with secret", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{"SEARCH_CALLBACK_SECRET": secret})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		signer, err := cfg.Callback.Signer()
+		require.NoError(t, err)
+		assert.NotNil(t, signer)
+	})
+}
+
+// stubDriver is a database/sql driver that never connects.
+type stubDriver struct{}
+
+func (stubDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("stub driver does not connect")
+}
+
+func init() {
+	sql.Register("configtest", stubDriver{})
+}
+
+// TestLoad_Database tests database settings.
+func TestLoad_Database(t *testing.T) {
+	tests := []struct {
+		name   string
+		vars   map[string]string
+		errMsg string
+	}{
+		{"unknown driver", map[string]string{"DATABASE_DRIVER": "oracle", "DATABASE_DSN": "x"}, `DATABASE_DRIVER "oracle" is not available`},
+		{"missing DSN", map[string]string{"DATABASE_DRIVER": "configtest"}, "DATABASE_DSN is required"},
+		{"invalid journal mode", map[string]string{"DATABASE_SQLITE_JOURNAL_MODE": "wal2"}, "DATABASE_SQLITE_JOURNAL_MODE must be one of"},
+		{"invalid synchronous", map[string]string{"DATABASE_SQLITE_SYNCHRONOUS": "SOMETIMES"}, "DATABASE_SQLITE_SYNCHRONOUS must be one of"},
+		{"negative busy timeout", map[string]string{"DATABASE_SQLITE_BUSY_TIMEOUT": "-1s"}, "DATABASE_SQLITE_BUSY_TIMEOUT must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, cfg)
+		})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		clearEnvVars(t)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.True(t, cfg.Database.MigrateOnStartup)
+		assert.Equal(t, "WAL", cfg.Database.SQLiteJournalMode)
+		assert.Equal(t, "NORMAL", cfg.Database.SQLiteSynchronous)
+		assert.Equal(t, 5*time.Second, cfg.Database.SQLiteBusyTimeout)
+		db, err := cfg.Database.Open()
+		require.NoError(t, err)
+		assert.Nil(t, db)
+	})
+
+	t.Run("with driver", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{
+			"DATABASE_DRIVER":             "configtest",
+			"DATABASE_DSN":                "file:test.db",
+			"DATABASE_MIGRATE_ON_STARTUP": "false",
+		})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.False(t, cfg.Database.MigrateOnStartup)
+		db, err := cfg.Database.Open()
+		require.NoError(t, err)
+		require.NotNil(t, db)
+		assert.NoError(t, db.Close())
+	})
+}
+
+// TestLoad_HistoryStore tests the search history store selection.
+func TestLoad_HistoryStore(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, HistoryStoreMemory, cfg.History.Store)
+
+	setEnvVars(t, map[string]string{"SEARCH_HISTORY_STORE": "redis"})
+	_, err = Load()
+	assert.ErrorContains(t, err, "SEARCH_HISTORY_STORE must be one of")
+
+	// Only SQLite databases can hold the search history
+	setEnvVars(t, map[string]string{
+		"SEARCH_HISTORY_STORE": "database",
+		"DATABASE_DRIVER":      "configtest",
+		"DATABASE_DSN":         "file:test.db",
+	})
+	_, err = Load()
+	assert.ErrorContains(t, err, "SEARCH_HISTORY_STORE=database requires DATABASE_DRIVER=sqlite3")
+}
+
+// TestLoad_QueueStore tests the job queue store selection.
+func TestLoad_QueueStore(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, QueueStoreFile, cfg.Queue.Store)
+
+	setEnvVars(t, map[string]string{"JOB_QUEUE_STORE": "redis"})
+	_, err = Load()
+	assert.ErrorContains(t, err, "JOB_QUEUE_STORE must be one of")
+
+	// Only SQLite databases can hold the jobs
+	setEnvVars(t, map[string]string{
+		"JOB_QUEUE_STORE": "database",
+		"DATABASE_DRIVER": "configtest",
+		"DATABASE_DSN":    "file:test.db",
+	})
+	_, err = Load()
+	assert.ErrorContains(t, err, "JOB_QUEUE_STORE=database requires DATABASE_DRIVER=sqlite3")
+}
+
+// TestLoad_SearchAudit tests the search audit log settings.
+func TestLoad_SearchAudit(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, SearchAuditSinkNone, cfg.Audit.Sink)
+	assert.Equal(t, "data/search_audit.jsonl", cfg.Audit.File)
+	assert.Equal(t, 1024, cfg.Audit.Buffer)
+
+	setEnvVars(t, map[string]string{"SEARCH_AUDIT_SINK": "file", "SEARCH_AUDIT_FILE": "/var/log/searches.jsonl"})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, SearchAuditSinkFile, cfg.Audit.Sink)
+	assert.Equal(t, "/var/log/searches.jsonl", cfg.Audit.File)
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{"unknown sink", map[string]string{"SEARCH_AUDIT_SINK": "kafka"}, "SEARCH_AUDIT_SINK must be one of"},
+		{"database sink without sqlite", map[string]string{"SEARCH_AUDIT_SINK": "database", "DATABASE_DRIVER": "configtest", "DATABASE_DSN": "file:test.db"}, "SEARCH_AUDIT_SINK=database requires DATABASE_DRIVER=sqlite3"},
+		{"zero buffer", map[string]string{"SEARCH_AUDIT_BUFFER": "0"}, "SEARCH_AUDIT_BUFFER must be at least 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.env)
+			_, err := Load()
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+// TestLoad_ShadowProviders tests the shadow provider list.
+func TestLoad_ShadowProviders(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Providers.Shadow)
+	assert.False(t, cfg.Providers.IsShadow("airasia"))
+
+	setEnvVars(t, map[string]string{"PROVIDERS_SHADOW": "airasia, batik_air"})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.Providers.IsShadow("airasia"))
+	assert.True(t, cfg.Providers.IsShadow("batik_air"))
+	assert.False(t, cfg.Providers.IsShadow("lion_air"))
+}
+
+// TestLoad_ProviderQualityWindow tests the provider quality window.
+func TestLoad_ProviderQualityWindow(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, cfg.Providers.QualityWindow)
+
+	setEnvVars(t, map[string]string{"PROVIDER_QUALITY_WINDOW": "30m"})
+	_, err = Load()
+	assert.ErrorContains(t, err, "PROVIDER_QUALITY_WINDOW must be at least 1h")
+}
+
+// TestLoad_ProviderLatency tests the skipping of providers over the search budget.
+func TestLoad_ProviderLatency(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.Providers.SkipOverBudget)
+	assert.True(t, cfg.Providers.CoalesceSearches)
+	assert.Equal(t, 15*time.Minute, cfg.Providers.LatencyWindow)
+
+	setEnvVars(t, map[string]string{"PROVIDER_SKIP_OVER_BUDGET": "false", "PROVIDER_COALESCE_SEARCHES": "false", "PROVIDER_LATENCY_WINDOW": "5m"})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.Providers.SkipOverBudget)
+	assert.False(t, cfg.Providers.CoalesceSearches)
+	assert.Equal(t, 5*time.Minute, cfg.Providers.LatencyWindow)
+
+	setEnvVars(t, map[string]string{"PROVIDER_LATENCY_WINDOW": "0s"})
+	_, err = Load()
+	assert.ErrorContains(t, err, "PROVIDER_LATENCY_WINDOW must be positive")
+}
+
+// TestLoad_FanOutStrategy tests the provider fan-out strategy settings.
+func TestLoad_FanOutStrategy(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "all-parallel", cfg.Providers.FanOutStrategy)
+	assert.Equal(t, 2, cfg.Providers.FastestFirstProviders)
+	assert.Equal(t, 50, cfg.Providers.FastestFirstResults)
+
+	setEnvVars(t, map[string]string{
+		"PROVIDER_FAN_OUT_STRATEGY":        "fastest-first-return",
+		"PROVIDER_FASTEST_FIRST_PROVIDERS": "3",
+		"PROVIDER_FASTEST_FIRST_RESULTS":   "20",
+	})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "fastest-first-return", cfg.Providers.FanOutStrategy)
+	assert.Equal(t, 3, cfg.Providers.FastestFirstProviders)
+	assert.Equal(t, 20, cfg.Providers.FastestFirstResults)
+
+	setEnvVars(t, map[string]string{"PROVIDER_FAN_OUT_STRATEGY": "round-robin"})
+	_, err = Load()
+	assert.ErrorContains(t, err, "PROVIDER_FAN_OUT_STRATEGY must be one of")
+
+	setEnvVars(t, map[string]string{"PROVIDER_FAN_OUT_STRATEGY": "all-parallel", "PROVIDER_FASTEST_FIRST_RESULTS": "0"})
+	_, err = Load()
+	assert.ErrorContains(t, err, "PROVIDER_FASTEST_FIRST_RESULTS must be at least 1")
+}
+
+// TestLoad_FlexibleDateConcurrency tests how many dates of a flexible-date
+// search are searched at once.
+func TestLoad_FlexibleDateConcurrency(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 3, cfg.Providers.FlexibleDateConcurrency)
+
+	setEnvVars(t, map[string]string{"PROVIDER_FLEXIBLE_DATE_CONCURRENCY": "7"})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 7, cfg.Providers.FlexibleDateConcurrency)
+
+	setEnvVars(t, map[string]string{"PROVIDER_FLEXIBLE_DATE_CONCURRENCY": "0"})
+	_, err = Load()
+	assert.ErrorContains(t, err, "PROVIDER_FLEXIBLE_DATE_CONCURRENCY must be at least 1")
+}
+
+// TestLoad_ProviderHealth tests the provider health checks and breakers.
+func TestLoad_ProviderHealth(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, cfg.Providers.HealthCheckInterval)
+	assert.Equal(t, 5, cfg.Providers.BreakerThreshold)
+	assert.Equal(t, 30*time.Second, cfg.Providers.BreakerCooldown)
+
+	setEnvVars(t, map[string]string{
+		"PROVIDER_HEALTH_CHECK_INTERVAL": "0s",
+		"PROVIDER_BREAKER_THRESHOLD":     "0",
+		"PROVIDER_BREAKER_COOLDOWN":      "1m",
+	})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Zero(t, cfg.Providers.HealthCheckInterval)
+	assert.Zero(t, cfg.Providers.BreakerThreshold)
+	assert.Equal(t, time.Minute, cfg.Providers.BreakerCooldown)
+
+	tests := []struct {
+		name    string
+		envVars map[string]string
+		wantErr string
+	}{
+		{"negative interval", map[string]string{"PROVIDER_HEALTH_CHECK_INTERVAL": "-1s"}, "PROVIDER_HEALTH_CHECK_INTERVAL must not be negative"},
+		{"negative threshold", map[string]string{"PROVIDER_BREAKER_THRESHOLD": "-1"}, "PROVIDER_BREAKER_THRESHOLD must not be negative"},
+		{"zero cooldown", map[string]string{"PROVIDER_BREAKER_COOLDOWN": "0s"}, "PROVIDER_BREAKER_COOLDOWN must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.envVars)
+			_, err := Load()
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+// TestLoad_ProviderLatencyHeatmap tests the retention of provider latency heatmaps.
+func TestLoad_ProviderLatencyHeatmap(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, cfg.Providers.LatencyHeatmapRetention)
+
+	setEnvVars(t, map[string]string{"PROVIDER_LATENCY_HEATMAP_RETENTION": "6h"})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 6*time.Hour, cfg.Providers.LatencyHeatmapRetention)
+
+	setEnvVars(t, map[string]string{"PROVIDER_LATENCY_HEATMAP_RETENTION": "30s"})
+	_, err = Load()
+	assert.ErrorContains(t, err, "PROVIDER_LATENCY_HEATMAP_RETENTION must be at least 1m")
+}
+
+// TestLoad_ProviderRetry tests provider search retry settings.
+func TestLoad_ProviderRetry(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, retry.Config{MaxAttempts: 2, InitialBackoff: 50 * time.Millisecond, MaxBackoff: 500 * time.Millisecond}, cfg.Providers.Retry())
+	assert.Empty(t, cfg.Providers.RetryMaxAttemptsByProvider)
+
+	setEnvVars(t, map[string]string{
+		"PROVIDER_RETRY_MAX_ATTEMPTS":             "3",
+		"PROVIDER_RETRY_MAX_ATTEMPTS_BY_PROVIDER": "lion_air=4,airasia=1",
+		"PROVIDER_RETRY_BACKOFF":                  "10ms",
+		"PROVIDER_RETRY_MAX_BACKOFF":              "100ms",
+	})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, retry.Config{MaxAttempts: 3, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 100 * time.Millisecond}, cfg.Providers.Retry())
+	assert.Equal(t, map[string]int{"lion_air": 4, "airasia": 1}, cfg.Providers.RetryMaxAttemptsByProvider)
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"zero attempts", map[string]string{"PROVIDER_RETRY_MAX_ATTEMPTS": "0"}, "PROVIDER_RETRY_MAX_ATTEMPTS must be at least 1"},
+		{"zero provider attempts", map[string]string{"PROVIDER_RETRY_MAX_ATTEMPTS_BY_PROVIDER": "lion_air=0"}, "attempts of lion_air must be at least 1"},
+		{"negative backoff", map[string]string{"PROVIDER_RETRY_BACKOFF": "-1ms"}, "PROVIDER_RETRY_BACKOFF must not be negative"},
+		{"backoff over max", map[string]string{"PROVIDER_RETRY_BACKOFF": "1s", "PROVIDER_RETRY_MAX_BACKOFF": "500ms"}, "exceed PROVIDER_RETRY_MAX_BACKOFF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.env)
+			_, err := Load()
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+// TestLoad_ProviderHTTPSource tests switching providers to their HTTP API.
+func TestLoad_ProviderHTTPSource(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	_, ok := cfg.Providers.HTTPSource("garuda_indonesia")
+	assert.False(t, ok, "providers read their mock data by default")
+
+	setEnvVars(t, map[string]string{
+		"PROVIDER_HTTP_BASE_URLS":   "garuda_indonesia=https://api.garuda.example/v1/search,lion_air=http://localhost:9090/search",
+		"PROVIDER_HTTP_AUTH_HEADER": "X-API-Key",
+		"PROVIDER_HTTP_AUTH_VALUES": "garuda_indonesia=secret",
+		"PROVIDER_HTTP_TIMEOUT":     "2s",
+	})
+	cfg, err = Load()
+	require.NoError(t, err)
+	source, ok := cfg.Providers.HTTPSource("garuda_indonesia")
+	assert.True(t, ok)
+	assert.Equal(t, httpsource.Config{
+		BaseURL:    "https://api.garuda.example/v1/search",
+		AuthHeader: "X-API-Key",
+		AuthValue:  "secret",
+		Timeout:    2 * time.Second,
+		Transport: httpsource.TransportConfig{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 32,
+			IdleConnTimeout:     90 * time.Second,
+			TLSSessionCacheSize: 64,
+			DNSCacheTTL:         30 * time.Second,
+		},
+	}, source)
+	source, ok = cfg.Providers.HTTPSource("lion_air")
+	assert.True(t, ok)
+	assert.Empty(t, source.AuthValue)
+	_, ok = cfg.Providers.HTTPSource("airasia")
+	assert.False(t, ok)
+
+	setEnvVars(t, map[string]string{
+		"PROVIDER_HTTP_MAX_CONNS_PER_HOST":             "8",
+		"PROVIDER_HTTP_MAX_CONNS_PER_HOST_BY_PROVIDER": "lion_air=16",
+		"PROVIDER_HTTP_DNS_CACHE_TTL":                  "0s",
+	})
+	cfg, err = Load()
+	require.NoError(t, err)
+	source, _ = cfg.Providers.HTTPSource("garuda_indonesia")
+	assert.Equal(t, 8, source.Transport.MaxConnsPerHost)
+	assert.Zero(t, source.Transport.DNSCacheTTL)
+	source, _ = cfg.Providers.HTTPSource("lion_air")
+	assert.Equal(t, 16, source.Transport.MaxConnsPerHost)
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"relative URL", map[string]string{"PROVIDER_HTTP_BASE_URLS": "lion_air=/search"}, "PROVIDER_HTTP_BASE_URLS: lion_air"},
+		{"credentials without URL", map[string]string{"PROVIDER_HTTP_AUTH_VALUES": "airasia=secret"}, "airasia has no base URL"},
+		{"zero timeout", map[string]string{"PROVIDER_HTTP_TIMEOUT": "0s"}, "PROVIDER_HTTP_TIMEOUT must be positive"},
+		{"zero idle conns", map[string]string{"PROVIDER_HTTP_MAX_IDLE_CONNS_PER_HOST": "0"}, "PROVIDER_HTTP_MAX_IDLE_CONNS_PER_HOST must be positive"},
+		{"negative provider conns", map[string]string{"PROVIDER_HTTP_MAX_CONNS_PER_HOST_BY_PROVIDER": "lion_air=-1"}, "limit of lion_air must not be negative"},
+		{"zero idle timeout", map[string]string{"PROVIDER_HTTP_IDLE_CONN_TIMEOUT": "0s"}, "PROVIDER_HTTP_IDLE_CONN_TIMEOUT must be positive"},
+		{"zero TLS session cache", map[string]string{"PROVIDER_HTTP_TLS_SESSION_CACHE_SIZE": "0"}, "PROVIDER_HTTP_TLS_SESSION_CACHE_SIZE must be positive"},
+		{"negative DNS cache TTL", map[string]string{"PROVIDER_HTTP_DNS_CACHE_TTL": "-1s"}, "PROVIDER_HTTP_DNS_CACHE_TTL must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.env)
+			_, err := Load()
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+// TestLoad_ProviderRateLimits tests per-provider rate limit settings.
+func TestLoad_ProviderRateLimits(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	limits, err := cfg.Providers.RateLimits()
+	require.NoError(t, err)
+	assert.Nil(t, limits, "providers are not rate limited by default")
+
+	setEnvVars(t, map[string]string{
+		"PROVIDER_RATE_LIMIT_BY_PROVIDER":       "lion_air=20,airasia=5.5",
+		"PROVIDER_RATE_LIMIT_BURST_BY_PROVIDER": "lion_air=10",
+	})
+	cfg, err = Load()
+	require.NoError(t, err)
+	limits, err = cfg.Providers.RateLimits()
+	require.NoError(t, err)
+	require.NotNil(t, limits)
+	assert.Equal(t, ratelimit.Limit{Rate: 20, Burst: 10}, limits.Limit("lion_air"))
+	assert.Equal(t, ratelimit.Limit{Rate: 5.5, Burst: 1}, limits.Limit("airasia"))
+	assert.Nil(t, limits.Bucket("garuda_indonesia"), "other providers are not rate limited")
+
+	setEnvVars(t, map[string]string{
+		"PROVIDER_RATE_LIMIT":             "50",
+		"PROVIDER_RATE_LIMIT_BURST":       "5",
+		"PROVIDER_RATE_LIMIT_BY_PROVIDER": "airasia=0",
+	})
+	cfg, err = Load()
+	require.NoError(t, err)
+	limits, err = cfg.Providers.RateLimits()
+	require.NoError(t, err)
+	assert.Equal(t, ratelimit.Limit{Rate: 50, Burst: 5}, limits.Limit("garuda_indonesia"))
+	assert.Equal(t, ratelimit.Limit{Rate: 50, Burst: 10}, limits.Limit("lion_air"))
+	assert.Nil(t, limits.Bucket("airasia"), "0 lifts the limit of a provider")
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"negative rate", map[string]string{"PROVIDER_RATE_LIMIT": "-1"}, "PROVIDER_RATE_LIMIT must not be negative"},
+		{"zero burst", map[string]string{"PROVIDER_RATE_LIMIT_BURST": "0"}, "PROVIDER_RATE_LIMIT_BURST must be at least 1"},
+		{"negative provider rate", map[string]string{"PROVIDER_RATE_LIMIT_BY_PROVIDER": "airasia=-2"}, "rate of airasia must not be negative"},
+		{"zero provider burst", map[string]string{"PROVIDER_RATE_LIMIT_BURST_BY_PROVIDER": "airasia=0"}, "burst of airasia must be at least 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.env)
+			_, err := Load()
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+// TestLoad_ProviderWorkerPools tests per-provider worker pool settings.
+func TestLoad_ProviderWorkerPools(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	pools, err := cfg.Providers.WorkerPools()
+	require.NoError(t, err)
+	require.NotNil(t, pools)
+	assert.Equal(t, workerpool.Stats{Size: 16}, pools.Pool("garuda_indonesia").Stats())
+
+	setEnvVars(t, map[string]string{
+		"PROVIDER_POOL_SIZE":             "4",
+		"PROVIDER_POOL_QUEUE":            "0",
+		"PROVIDER_POOL_SIZE_BY_PROVIDER": "lion_air=2",
+	})
+	cfg, err = Load()
+	require.NoError(t, err)
+	pools, err = cfg.Providers.WorkerPools()
+	require.NoError(t, err)
+	assert.Equal(t, 4, pools.Pool("garuda_indonesia").Stats().Size)
+	assert.Equal(t, 2, pools.Pool("lion_air").Stats().Size)
+
+	setEnvVars(t, map[string]string{"PROVIDER_POOL_SIZE": "0"})
+	cfg, err = Load()
+	require.NoError(t, err)
+	pools, err = cfg.Providers.WorkerPools()
+	require.NoError(t, err)
+	assert.Nil(t, pools, "0 disables the pools")
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"negative size", map[string]string{"PROVIDER_POOL_SIZE": "-1"}, "PROVIDER_POOL_SIZE must not be negative"},
+		{"negative queue", map[string]string{"PROVIDER_POOL_QUEUE": "-1"}, "PROVIDER_POOL_QUEUE must not be negative"},
+		{"zero provider size", map[string]string{"PROVIDER_POOL_SIZE_BY_PROVIDER": "airasia=0"}, "size of airasia must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.env)
+			_, err := Load()
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+// TestLoad_GroupQuotes tests the group quote toggle.
+func TestLoad_GroupQuotes(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.Providers.GroupQuotes)
+
+	setEnvVars(t, map[string]string{"PROVIDER_GROUP_QUOTES": "false"})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.Providers.GroupQuotes)
+}
+
+// TestLoad_AccessibilityPolicy tests the accessibility policy file setting.
+func TestLoad_AccessibilityPolicy(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Providers.AccessibilityPolicy, "built-in policies")
+
+	setEnvVars(t, map[string]string{"PROVIDER_ACCESSIBILITY_POLICY": "/etc/flight-search/accessibility.json"})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/flight-search/accessibility.json", cfg.Providers.AccessibilityPolicy)
+}
+
+func TestLoad_ProviderResponseLimits(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, int64(10<<20), cfg.Providers.ResponseLimit("garuda_indonesia"))
+
+	setEnvVars(t, map[string]string{
+		"PROVIDER_MAX_RESPONSE_BYTES":             "2097152",
+		"PROVIDER_MAX_RESPONSE_BYTES_BY_PROVIDER": "garuda_indonesia=1048576,airasia=524288",
+	})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1<<20), cfg.Providers.ResponseLimit("garuda_indonesia"))
+	assert.Equal(t, int64(512<<10), cfg.Providers.ResponseLimit("airasia"))
+	assert.Equal(t, int64(2<<20), cfg.Providers.ResponseLimit("lion_air"))
+
+	setEnvVars(t, map[string]string{"PROVIDER_MAX_RESPONSE_BYTES_BY_PROVIDER": "garuda_indonesia=0"})
+	_, err = Load()
+	assert.ErrorContains(t, err, "limit of garuda_indonesia must be positive")
+
+	setEnvVars(t, map[string]string{"PROVIDER_MAX_RESPONSE_BYTES": "0", "PROVIDER_MAX_RESPONSE_BYTES_BY_PROVIDER": ""})
+	_, err = Load()
+	assert.ErrorContains(t, err, "PROVIDER_MAX_RESPONSE_BYTES must be positive")
+}
+
+func TestLoad_Sanitize(t *testing.T) {
+	clearEnvVars(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 200, cfg.Sanitize.MaxLength)
+	assert.Empty(t, cfg.Sanitize.BlockedTerms)
+	assert.True(t, cfg.Sanitize.RedactPII)
+
+	setEnvVars(t, map[string]string{
+		"SANITIZE_MAX_LENGTH":    "20",
+		"SANITIZE_BLOCKED_TERMS": "damn,scam",
+		"SANITIZE_REDACT_PII":    "false",
+	})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"damn", "scam"}, cfg.Sanitize.BlockedTerms)
+	assert.Equal(t, "**** deal", cfg.Sanitize.Sanitizer().Text("<b>damn deal</b>"))
+	assert.Equal(t, "Call ops@airline.ex…", cfg.Sanitize.Sanitizer().Text("Call ops@airline.example"), "PII kept, length capped")
+
+	setEnvVars(t, map[string]string{"SANITIZE_MAX_LENGTH": "-1"})
+	_, err = Load()
+	assert.ErrorContains(t, err, "SANITIZE_MAX_LENGTH must not be negative")
+}
+
+// TestLoad_RoutingPolicy tests the absurd routing limits.
+func TestLoad_RoutingPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    map[string]string
+		want    domain.RoutingPolicy
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: domain.RoutingPolicy{
+				MaxDurationFactor: 3,
+				StopLimits:        []domain.StopLimit{{UpTo: 2 * time.Hour, MaxStops: 1}, {UpTo: 6 * time.Hour, MaxStops: 2}},
+			},
+		},
+		{
+			name: "disabled",
+			vars: map[string]string{"ROUTING_MAX_DURATION_FACTOR": "0", "ROUTING_MAX_STOPS": "none"},
+			want: domain.RoutingPolicy{},
+		},
+		{
+			name:    "factor below 1",
+			vars:    map[string]string{"ROUTING_MAX_DURATION_FACTOR": "0.5"},
+			wantErr: "ROUTING_MAX_DURATION_FACTOR must be 0 or at least 1",
+		},
+		{
+			name:    "invalid stop limit",
+			vars:    map[string]string{"ROUTING_MAX_STOPS": "2h"},
+			wantErr: "ROUTING_MAX_STOPS: invalid stop limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			policy, err := cfg.Routing.Policy()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, policy)
+		})
+	}
+}
+
+// TestLoad_LoadShed tests the load shedding settings.
+func TestLoad_LoadShed(t *testing.T) {
+	tests := []struct {
+		name     string
+		vars     map[string]string
+		wantShed []string // features shed with all searches in flight over the threshold
+		disabled bool
+		wantErr  string
+	}{
+		{
+			name:     "defaults",
+			wantShed: []string{"shadow", "localization"},
+		},
+		{
+			name:     "custom order",
+			vars:     map[string]string{"LOAD_SHED_FEATURES": "localization,shadow"},
+			wantShed: []string{"localization", "shadow"},
+		},
+		{
+			name:     "no features",
+			vars:     map[string]string{"LOAD_SHED_FEATURES": "none"},
+			disabled: true,
+		},
+		{
+			name:     "no thresholds",
+			vars:     map[string]string{"LOAD_SHED_MAX_IN_FLIGHT": "0", "LOAD_SHED_P95_LATENCY": "0s"},
+			disabled: true,
+		},
+		{
+			name:    "unknown feature",
+			vars:    map[string]string{"LOAD_SHED_FEATURES": "shadow,emissions"},
+			wantErr: `LOAD_SHED_FEATURES: unknown feature "emissions"`,
+		},
+		{
+			name:    "negative in-flight threshold",
+			vars:    map[string]string{"LOAD_SHED_MAX_IN_FLIGHT": "-1"},
+			wantErr: "LOAD_SHED_MAX_IN_FLIGHT must not be negative",
+		},
+		{
+			name:    "zero window",
+			vars:    map[string]string{"LOAD_SHED_WINDOW": "0s"},
+			wantErr: "LOAD_SHED_WINDOW must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			shedder, err := cfg.LoadShed.Shedder()
+			require.NoError(t, err)
+			if tt.disabled {
+				assert.Nil(t, shedder)
+				return
+			}
+			require.NotNil(t, shedder)
+			for range 2 * cfg.LoadShed.MaxInFlight {
+				shedder.Begin()
+			}
+			assert.Equal(t, tt.wantShed, shedder.Shed())
+		})
+	}
+}
+
+// TestLoad_RolePolicy tests the RBAC role definitions.
+func TestLoad_RolePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		vars          map[string]string
+		wantAnonymous []rbac.Role
+		wantKeys      []rbac.Role
+		errMsg        string
+	}{
+		{
+			name:          "defaults",
+			wantAnonymous: []rbac.Role{rbac.RoleSearch},
+			wantKeys:      []rbac.Role{rbac.RoleSearch},
+		},
+		{
+			name:          "keys required",
+			vars:          map[string]string{"RBAC_ANONYMOUS_ROLES": "none", "RBAC_DEFAULT_KEY_ROLES": "search,export"},
+			wantAnonymous: nil,
+			wantKeys:      []rbac.Role{rbac.RoleSearch, rbac.RoleExport},
+		},
+		{
+			name:   "unknown role",
+			vars:   map[string]string{"RBAC_DEFAULT_KEY_ROLES": "search,reports"},
+			errMsg: `RBAC_DEFAULT_KEY_ROLES: invalid role "reports"`,
+		},
+		{
+			name:   "admin role",
+			vars:   map[string]string{"RBAC_ANONYMOUS_ROLES": "admin"},
+			errMsg: `RBAC_ANONYMOUS_ROLES: role "admin" is only granted by admin tokens`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars(t)
+			setEnvVars(t, tt.vars)
+
+			cfg, err := Load()
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			policy, err := cfg.Tenants.RolePolicy()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAnonymous, policy.Anonymous)
+			assert.Equal(t, tt.wantKeys, policy.Keys)
+		})
+	}
+}
+
+// TestLoad_DuplicateWindow tests the duplicate search tracking window.
+func TestLoad_DuplicateWindow(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		clearEnvVars(t)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, cfg.Tenants.DuplicateWindow)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{"TENANT_DUPLICATE_SEARCH_WINDOW": "-1s"})
+
+		_, err := Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TENANT_DUPLICATE_SEARCH_WINDOW must not be negative")
+	})
+}
+
+// TestLoad_PriceBasis tests the price basis of the price filters.
+func TestLoad_PriceBasis(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		clearEnvVars(t)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, "per_person", cfg.Pricing.Basis)
+	})
+
+	t.Run("total", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{"PRICE_BASIS": "total"})
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, "total", cfg.Pricing.Basis)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		clearEnvVars(t)
+		setEnvVars(t, map[string]string{"PRICE_BASIS": "per_seat"})
+
+		_, err := Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PRICE_BASIS must be one of")
+	})
+}
+
 // Helper functions
 
 // clearEnvVars clears all config-related environment variables.
@@ -369,11 +1982,146 @@ func clearEnvVars(t *testing.T) {
 		"SERVER_PORT",
 		"SERVER_READ_TIMEOUT",
 		"SERVER_WRITE_TIMEOUT",
+		"SERVER_READ_HEADER_TIMEOUT",
+		"SERVER_IDLE_TIMEOUT",
+		"SERVER_MAX_CONNECTIONS",
+		"SERVER_MAX_CONNECTIONS_PER_IP",
+		"SERVER_MAX_CONCURRENT_SEARCHES",
+		"SERVER_MAX_REQUEST_BODY_BYTES",
+		"SERVER_COMPRESSION_LEVEL",
+		"SERVER_COMPRESSION_MIN_BYTES",
+		"PROVIDER_RATE_LIMIT",
+		"PROVIDER_RATE_LIMIT_BY_PROVIDER",
+		"PROVIDER_RATE_LIMIT_BURST",
+		"PROVIDER_RATE_LIMIT_BURST_BY_PROVIDER",
+		"SERVER_RETRY_AFTER",
 		"TIMEOUT_GLOBAL_SEARCH",
 		"TIMEOUT_PER_PROVIDER",
+		"TIMEOUT_PER_PROVIDER_BY_PROVIDER",
+		"TIMEOUT_GLOBAL_SEARCH_BATCH",
+		"TIMEOUT_SOFT_DEADLINE",
+		"TIMEOUT_CONTINUATION_TTL",
 		"LOG_LEVEL",
 		"LOG_FORMAT",
 		"APP_ENV",
+		"DEMO_MODE",
+		"MOCK_DATA_DIR",
+		"SEARCH_HISTORY_CAPACITY",
+		"SEARCH_HISTORY_RETENTION",
+		"SEARCH_AGGREGATE_RETENTION",
+		"API_KEYS_FILE",
+		"TENANT_DUPLICATE_SEARCH_WINDOW",
+		"SCHEDULER_JITTER",
+		"SCHEDULE_MOCK_ROTATION",
+		"SCHEDULE_HISTORY_COMPACTION",
+		"SCHEDULE_ANALYTICS_EXPORT",
+		"ANALYTICS_EXPORT_MIN_SEARCHES",
+		"JOB_QUEUE_STORE",
+		"JOB_QUEUE_FILE",
+		"JOB_QUEUE_WORKERS",
+		"JOB_QUEUE_MAX_ATTEMPTS",
+		"JOB_QUEUE_BACKOFF",
+		"JOB_QUEUE_MAX_BACKOFF",
+		"JOB_QUEUE_RETENTION",
+		"STORAGE_ENCRYPTION_KEYS",
+		"STORAGE_ENCRYPTION_KEYS_FILE",
+		"RESPONSE_SIGNING_KEY",
+		"RESPONSE_SIGNING_KEY_FILE",
+		"BLOB_BACKEND",
+		"BLOB_DIR",
+		"BLOB_S3_ENDPOINT",
+		"BLOB_S3_REGION",
+		"BLOB_S3_BUCKET",
+		"BLOB_S3_PREFIX",
+		"BLOB_S3_ACCESS_KEY_ID",
+		"BLOB_S3_SECRET_ACCESS_KEY",
+		"BLOB_S3_PATH_STYLE",
+		"CACHE_BACKEND",
+		"CACHE_TTL",
+		"CACHE_MEMORY_MAX_ENTRIES",
+		"CACHE_FLIGHT_DETAILS_TTL",
+		"CACHE_DRIFT_SAMPLE_RATE",
+		"CACHE_REDIS_ADDR",
+		"CACHE_REDIS_PASSWORD",
+		"CACHE_REDIS_DB",
+		"CACHE_REDIS_PREFIX",
+		"CACHE_REDIS_TIMEOUT",
+		"MOCK_DATA_BLOB_PREFIX",
+		"ADMIN_TOKEN_SECRET",
+		"ADMIN_TOKEN_MAX_TTL",
+		"AUTH_MODE",
+		"JWT_ISSUER",
+		"JWT_AUDIENCE",
+		"JWT_JWKS_URL",
+		"JWT_JWKS_REFRESH_INTERVAL",
+		"JWT_SCOPE_ROLES",
+		"ADMIN_AUDIT_FILE",
+		"SHARE_TOKEN_SECRET",
+		"SHARE_TOKEN_TTL",
+		"DATABASE_DRIVER",
+		"DATABASE_DSN",
+		"DATABASE_MIGRATE_ON_STARTUP",
+		"DATABASE_SQLITE_JOURNAL_MODE",
+		"DATABASE_SQLITE_SYNCHRONOUS",
+		"DATABASE_SQLITE_BUSY_TIMEOUT",
+		"SEARCH_HISTORY_STORE",
+		"SEARCH_AUDIT_SINK",
+		"SEARCH_AUDIT_FILE",
+		"SEARCH_AUDIT_BUFFER",
+		"SEARCH_CALLBACK_SECRET",
+		"SEARCH_CALLBACK_TIMEOUT",
+		"SEARCH_CALLBACK_MAX_ATTEMPTS",
+		"RBAC_ANONYMOUS_ROLES",
+		"PROVIDERS_SHADOW",
+		"PROVIDER_QUALITY_WINDOW",
+		"PROVIDER_SKIP_OVER_BUDGET",
+		"PROVIDER_COALESCE_SEARCHES",
+		"PROVIDER_FAN_OUT_STRATEGY",
+		"PROVIDER_FASTEST_FIRST_PROVIDERS",
+		"PROVIDER_FASTEST_FIRST_RESULTS",
+		"PROVIDER_LATENCY_WINDOW",
+		"PROVIDER_LATENCY_HEATMAP_RETENTION",
+		"PROVIDER_GROUP_QUOTES",
+		"PROVIDER_ACCESSIBILITY_POLICY",
+		"PROVIDER_MAX_RESPONSE_BYTES",
+		"PROVIDER_MAX_RESPONSE_BYTES_BY_PROVIDER",
+		"ROUTING_MAX_DURATION_FACTOR",
+		"ROUTING_MAX_STOPS",
+		"PRICE_BASIS",
+		"LOAD_SHED_FEATURES",
+		"LOAD_SHED_MAX_IN_FLIGHT",
+		"LOAD_SHED_P95_LATENCY",
+		"LOAD_SHED_WINDOW",
+		"LOAD_SHED_COOLDOWN",
+		"RBAC_DEFAULT_KEY_ROLES",
+		"OPENAPI_VALIDATION_ENABLED",
+		"OPENAPI_VALIDATE_RESPONSES",
+		"PROVIDER_RETRY_MAX_ATTEMPTS",
+		"PROVIDER_RETRY_MAX_ATTEMPTS_BY_PROVIDER",
+		"PROVIDER_RETRY_BACKOFF",
+		"PROVIDER_RETRY_MAX_BACKOFF",
+		"PROVIDER_HTTP_BASE_URLS",
+		"PROVIDER_HTTP_AUTH_HEADER",
+		"PROVIDER_HTTP_AUTH_VALUES",
+		"PROVIDER_HTTP_TIMEOUT",
+		"PROVIDER_HTTP_MAX_IDLE_CONNS",
+		"PROVIDER_HTTP_MAX_IDLE_CONNS_PER_HOST",
+		"PROVIDER_HTTP_MAX_CONNS_PER_HOST",
+		"PROVIDER_HTTP_MAX_CONNS_PER_HOST_BY_PROVIDER",
+		"PROVIDER_HTTP_IDLE_CONN_TIMEOUT",
+		"PROVIDER_HTTP_TLS_SESSION_CACHE_SIZE",
+		"PROVIDER_HTTP_DNS_CACHE_TTL",
+		"PROVIDER_POOL_SIZE",
+		"PROVIDER_POOL_QUEUE",
+		"PROVIDER_POOL_SIZE_BY_PROVIDER",
+		"PROVIDER_FLEXIBLE_DATE_CONCURRENCY",
+		"PROVIDER_HEALTH_CHECK_INTERVAL",
+		"PROVIDER_BREAKER_THRESHOLD",
+		"PROVIDER_BREAKER_COOLDOWN",
+		"CODE_VALIDATION_MODE",
+		"SANITIZE_MAX_LENGTH",
+		"SANITIZE_BLOCKED_TERMS",
+		"SANITIZE_REDACT_PII",
 	}
 	for _, v := range envVars {
 		os.Unsetenv(v)
@@ -0,0 +1,150 @@
+// Code generated by genconfigdoc from config.go. DO NOT EDIT.
+
+package config
+
+// optionDescriptions are the descriptions of the configuration options, by name.
+var optionDescriptions = map[string]string{
+	"ADMIN_AUDIT_FILE":                             "The append-only JSON Lines file recording admin mutations (empty = in memory only)",
+	"ADMIN_TOKEN_MAX_TTL":                          "The longest token lifetime accepted",
+	"ADMIN_TOKEN_SECRET":                           "Signs and verifies admin bearer tokens. Without it the admin API is unauthenticated, or not served at all in production.",
+	"ANALYTICS_EXPORT_MIN_SEARCHES":                "Suppresses routes searched fewer times on a day",
+	"API_KEYS_FILE":                                "A JSON file mapping API keys to partner defaults (empty = none)",
+	"APP_ENV":                                      "The deployment environment: development, staging or production",
+	"AUTH_MODE":                                    "The authentication mode: api_key or jwt",
+	"BLOB_BACKEND":                                 "Selects the store: \"file\" or \"s3\"",
+	"BLOB_DIR":                                     "The root directory of the file backend",
+	"BLOB_S3_ACCESS_KEY_ID":                        "The access key ID of the s3 backend",
+	"BLOB_S3_BUCKET":                               "The bucket of the s3 backend",
+	"BLOB_S3_ENDPOINT":                             "The endpoint of the s3 backend; any S3-compatible service can be used",
+	"BLOB_S3_PATH_STYLE":                           "Addresses the bucket in the URL path instead of the host name",
+	"BLOB_S3_PREFIX":                               "Prepended to every key, to share a bucket",
+	"BLOB_S3_REGION":                               "The region requests to the s3 backend are signed for",
+	"BLOB_S3_SECRET_ACCESS_KEY":                    "The secret access key of the s3 backend",
+	"CACHE_BACKEND":                                "Selects the cache: \"none\", \"memory\" or \"redis\"",
+	"CACHE_DRIFT_SAMPLE_RATE":                      "The share of cache hits also searched live in the background to measure cache drift (0 = never)",
+	"CACHE_FLIGHT_DETAILS_TTL":                     "How long the flights of a search can be looked up by ID with GET /api/v1/flights/{id} (0 = disabled)",
+	"CACHE_MEMORY_MAX_ENTRIES":                     "Caps the searches held by the memory backend; the least recently used one is evicted to make room",
+	"CACHE_REDIS_ADDR":                             "The host:port of the redis backend",
+	"CACHE_REDIS_DB":                               "The Redis database number",
+	"CACHE_REDIS_PASSWORD":                         "Authenticates with Redis (empty = no authentication)",
+	"CACHE_REDIS_PREFIX":                           "Prepended to every cache key",
+	"CACHE_REDIS_TIMEOUT":                          "Bounds connecting to Redis and each command",
+	"CACHE_TTL":                                    "How long provider results are served from the cache",
+	"CODE_VALIDATION_MODE":                         "How strictly search request codes are validated: \"strict\" accepts IATA airport codes only, \"charter\" also accepts the ICAO codes of known airports (mapped to IATA) and 2-letter+digit charter airline codes",
+	"DATABASE_DRIVER":                              "The database/sql driver name (empty = no database)",
+	"DATABASE_DSN":                                 "The data source name the driver connects to",
+	"DATABASE_MIGRATE_ON_STARTUP":                  "Applies pending schema migrations when the server starts; disable it to run them with the migrate command instead",
+	"DATABASE_SQLITE_BUSY_TIMEOUT":                 "How long SQLite writes wait for each other before failing",
+	"DATABASE_SQLITE_JOURNAL_MODE":                 "The journal mode of SQLite databases; WAL lets searches read while another writes",
+	"DATABASE_SQLITE_SYNCHRONOUS":                  "How often SQLite databases sync to disk; NORMAL is safe with WAL, only losing the last writes on power loss",
+	"DEMO_MODE":                                    "Serves searches from an in-memory store preloaded with the provider mock data instead of the simulated provider adapters",
+	"JOB_QUEUE_BACKOFF":                            "The first retry delay; it doubles per attempt up to MaxBackoff",
+	"JOB_QUEUE_FILE":                               "Persists queued jobs across restarts (empty = in memory only)",
+	"JOB_QUEUE_MAX_ATTEMPTS":                       "The number of attempts before a job is moved to the dead letter",
+	"JOB_QUEUE_MAX_BACKOFF":                        "Caps the delay between job attempts",
+	"JOB_QUEUE_RETENTION":                          "How long done and dead jobs are kept before they are purged",
+	"JOB_QUEUE_STORE":                              "Where queued jobs are persisted: \"file\" (File) or \"database\" (the SQLite database, one row per job)",
+	"JOB_QUEUE_WORKERS":                            "The number of jobs processed concurrently",
+	"JWT_AUDIENCE":                                 "The audience (\"aud\") bearer tokens must be issued for",
+	"JWT_ISSUER":                                   "The issuer (\"iss\") bearer tokens must be issued by",
+	"JWT_JWKS_REFRESH_INTERVAL":                    "How long fetched signing keys are used before they are fetched again",
+	"JWT_JWKS_URL":                                 "Where the issuer publishes its token signing keys",
+	"JWT_SCOPE_ROLES":                              "Maps token scopes to the roles they grant, e.g. \"flights:search=search,flights:admin=admin\"",
+	"LOAD_SHED_COOLDOWN":                           "How long load must stay low before the next feature is restored",
+	"LOAD_SHED_FEATURES":                           "Shed in this order, e.g. \"shadow,localization\" (\"none\" = never shed)",
+	"LOAD_SHED_MAX_IN_FLIGHT":                      "The number of searches in flight at which shedding starts (0 = not used)",
+	"LOAD_SHED_P95_LATENCY":                        "The p95 search latency at which shedding starts (0 = not used)",
+	"LOAD_SHED_WINDOW":                             "The period the p95 latency is computed over",
+	"LOG_FORMAT":                                   "The log format: json, or console for human-readable logs",
+	"LOG_LEVEL":                                    "The minimum level logged: debug, info, warn or error",
+	"MOCK_DATA_BLOB_PREFIX":                        "Loads the demo mock data from the blob store instead of MockDataDir (empty = disabled)",
+	"MOCK_DATA_DIR":                                "The directory holding the provider mock data files",
+	"OPENAPI_VALIDATE_RESPONSES":                   "Logs responses that drift from the OpenAPI document",
+	"OPENAPI_VALIDATION_ENABLED":                   "Validates requests/responses against the served OpenAPI document. Validation is always enabled in staging regardless of this flag.",
+	"PRICE_BASIS":                                  "The price the price filters compare: \"per_person\" or \"total\" for all the passengers searched",
+	"PROVIDERS_SHADOW":                             "Lists providers queried in shadow mode: their results are only compared with the live results, never returned (comma-separated names)",
+	"PROVIDER_ACCESSIBILITY_POLICY":                "A policy file listing the special assistance each airline supports (empty = built-in policies)",
+	"PROVIDER_BREAKER_COOLDOWN":                    "How long a breaker stays open before one search is let through to try the provider again",
+	"PROVIDER_BREAKER_THRESHOLD":                   "The number of failures in a row after which a provider's breaker opens and its searches fail at once (0 = no breaker)",
+	"PROVIDER_COALESCE_SEARCHES":                   "Makes concurrent identical searches share one provider fan-out instead of querying the providers once each",
+	"PROVIDER_FAN_OUT_STRATEGY":                    "How searches query the providers: all-parallel, fastest-first-return or sequential-failover (in the order of the provider list); requests and API keys may select another",
+	"PROVIDER_FASTEST_FIRST_PROVIDERS":             "The number of providers answering successfully after which fastest-first-return searches respond",
+	"PROVIDER_FASTEST_FIRST_RESULTS":               "The number of flights after which fastest-first-return searches respond",
+	"PROVIDER_FLEXIBLE_DATE_CONCURRENCY":           "The number of departure dates of a flexible-date search searched at once, each querying the providers",
+	"PROVIDER_GROUP_QUOTES":                        "Answers searches for more than 9 passengers with indicative group fares instead of rejecting them",
+	"PROVIDER_HEALTH_CHECK_INTERVAL":               "How often every provider is checked in the background, for /health and to close breakers early (0 = never)",
+	"PROVIDER_HTTP_AUTH_HEADER":                    "The header carrying the provider API credentials",
+	"PROVIDER_HTTP_AUTH_VALUES":                    "The credentials sent to each provider API, e.g. \"garuda_indonesia=Bearer abc,lion_air=Bearer def\"",
+	"PROVIDER_HTTP_BASE_URLS":                      "Switches providers from their mock data file to their HTTP API, e.g. \"garuda_indonesia=https://api.garuda.example/v1/search\"",
+	"PROVIDER_HTTP_DNS_CACHE_TTL":                  "How long resolved API host addresses are reused (0 = resolve on every new connection)",
+	"PROVIDER_HTTP_IDLE_CONN_TIMEOUT":              "Closes API connections idle for longer",
+	"PROVIDER_HTTP_MAX_CONNS_PER_HOST":             "Caps the connections per API host (0 = no limit)",
+	"PROVIDER_HTTP_MAX_CONNS_PER_HOST_BY_PROVIDER": "Overrides HTTPMaxConnsPerHost per provider, e.g. \"lion_air=16\"",
+	"PROVIDER_HTTP_MAX_IDLE_CONNS":                 "Caps the idle connections each provider API client keeps",
+	"PROVIDER_HTTP_MAX_IDLE_CONNS_PER_HOST":        "Caps the idle connections kept per API host",
+	"PROVIDER_HTTP_TIMEOUT":                        "Bounds each provider API request",
+	"PROVIDER_HTTP_TLS_SESSION_CACHE_SIZE":         "The number of TLS sessions each client keeps for resumption",
+	"PROVIDER_LATENCY_HEATMAP_RETENTION":           "How long per-minute provider latency distributions are kept for the admin latency heatmaps",
+	"PROVIDER_LATENCY_WINDOW":                      "The period provider p95 latencies are computed over",
+	"PROVIDER_MAX_RESPONSE_BYTES":                  "Rejects provider responses larger than this many bytes",
+	"PROVIDER_MAX_RESPONSE_BYTES_BY_PROVIDER":      "Overrides MaxResponseBytes per provider, e.g. \"garuda_indonesia=1048576,lion_air=2097152\"",
+	"PROVIDER_POOL_QUEUE":                          "The number of searches waiting for a provider worker beyond which searches of the provider fail at once",
+	"PROVIDER_POOL_SIZE":                           "The number of searches each provider runs at once, retries included; further searches wait in the provider's queue (0 = unbounded)",
+	"PROVIDER_POOL_SIZE_BY_PROVIDER":               "Overrides PoolSize per provider, e.g. \"lion_air=8\"",
+	"PROVIDER_QUALITY_WINDOW":                      "The period provider quality statistics cover",
+	"PROVIDER_RATE_LIMIT":                          "Caps the searches sent to each provider per second, retries and health checks included (0 = unlimited)",
+	"PROVIDER_RATE_LIMIT_BURST":                    "The number of searches a rate limited provider may be sent at once after it was idle",
+	"PROVIDER_RATE_LIMIT_BURST_BY_PROVIDER":        "Overrides RateLimitBurst per provider, e.g. \"lion_air=10\"",
+	"PROVIDER_RATE_LIMIT_BY_PROVIDER":              "Overrides RateLimit per provider, e.g. \"lion_air=20,airasia=5.5\" (0 = unlimited)",
+	"PROVIDER_RETRY_BACKOFF":                       "The wait before the first retry; it doubles per retry up to RetryMaxBackoff",
+	"PROVIDER_RETRY_MAX_ATTEMPTS":                  "The number of attempts of a provider search failing with a transient error, the first one included (1 = no retries)",
+	"PROVIDER_RETRY_MAX_ATTEMPTS_BY_PROVIDER":      "Overrides RetryMaxAttempts per provider, e.g. \"lion_air=3,airasia=1\"",
+	"PROVIDER_RETRY_MAX_BACKOFF":                   "Caps the wait between retries",
+	"PROVIDER_SKIP_OVER_BUDGET":                    "Skips providers whose p95 latency exceeds the budget a search has left, instead of starting calls doomed to time out",
+	"RBAC_ANONYMOUS_ROLES":                         "The roles of callers without a known API key (comma-separated; \"none\" requires an API key for every operation)",
+	"RBAC_DEFAULT_KEY_ROLES":                       "The roles of API keys that do not list their own",
+	"RESPONSE_SIGNING_KEY":                         "The signing key (empty = responses are not signed)",
+	"RESPONSE_SIGNING_KEY_FILE":                    "Reads the signing key from a file, e.g. a mounted secret",
+	"ROUTING_MAX_DURATION_FACTOR":                  "Rejects itineraries longer than this multiple of the route's nonstop flight time (0 = no limit)",
+	"ROUTING_MAX_STOPS":                            "Caps the stops by nonstop flight time, e.g. \"2h=1,6h=2\" (\"none\" = no limit)",
+	"SANITIZE_BLOCKED_TERMS":                       "Masked wherever they appear as words (comma-separated, case-insensitive)",
+	"SANITIZE_MAX_LENGTH":                          "Caps each free-text field, in characters (0 = no cap)",
+	"SANITIZE_REDACT_PII":                          "Replaces e-mail addresses and phone numbers",
+	"SCHEDULER_JITTER":                             "The maximum random delay added to each job run",
+	"SCHEDULE_ANALYTICS_EXPORT":                    "Exports the previous day's search analytics (empty = disabled)",
+	"SCHEDULE_HISTORY_COMPACTION":                  "Applies search history retention (empty = disabled)",
+	"SCHEDULE_MOCK_ROTATION":                       "Reloads the demo mock data from MOCK_DATA_DIR (empty = disabled)",
+	"SEARCH_AGGREGATE_RETENTION":                   "How long daily aggregates are kept",
+	"SEARCH_AUDIT_BUFFER":                          "The number of entries queued for the sink, beyond which entries are dropped rather than delaying searches",
+	"SEARCH_AUDIT_FILE":                            "The JSON Lines file the \"file\" sink appends to",
+	"SEARCH_AUDIT_SINK":                            "Selects where every search is logged: \"none\", \"file\" (File) or \"database\" (the SQLite database of DATABASE_DSN)",
+	"SEARCH_CALLBACK_MAX_ATTEMPTS":                 "The number of delivery attempts before a callback is moved to the job queue's dead letter",
+	"SEARCH_CALLBACK_SECRET":                       "Signs the callbacks. Without it searches cannot be asynchronous.",
+	"SEARCH_CALLBACK_TIMEOUT":                      "Bounds each callback delivery attempt",
+	"SEARCH_HISTORY_CAPACITY":                      "The number of searches kept for replay; the oldest are evicted first",
+	"SEARCH_HISTORY_RETENTION":                     "How long individual searches are kept before being rolled up into daily aggregates",
+	"SEARCH_HISTORY_STORE":                         "Selects where searches are kept: \"memory\" (lost on restart) or \"database\" (the SQLite database of DATABASE_DSN)",
+	"SERVER_COMPRESSION_LEVEL":                     "The gzip level of responses to clients accepting gzip, from 1 (fastest) to 9 (smallest) (0 = no compression)",
+	"SERVER_COMPRESSION_MIN_BYTES":                 "The response size from which responses are compressed",
+	"SERVER_IDLE_TIMEOUT":                          "Bounds how long keep-alive connections may stay idle",
+	"SERVER_MAX_CONCURRENT_SEARCHES":               "Caps searches served concurrently, bounding the provider connections and memory they hold (0 = unlimited)",
+	"SERVER_MAX_CONNECTIONS":                       "Caps concurrently served requests (0 = unlimited)",
+	"SERVER_MAX_CONNECTIONS_PER_IP":                "Caps concurrently served requests per client IP (0 = unlimited)",
+	"SERVER_MAX_REQUEST_BODY_BYTES":                "Rejects request bodies larger than this many bytes with a 413",
+	"SERVER_PORT":                                  "The port the HTTP server listens on",
+	"SERVER_READ_HEADER_TIMEOUT":                   "Bounds how long a client may take to send request headers",
+	"SERVER_READ_TIMEOUT":                          "Bounds how long a client may take to send a request",
+	"SERVER_RETRY_AFTER":                           "Suggested to clients rejected because the server is saturated",
+	"SERVER_WRITE_TIMEOUT":                         "Bounds how long writing a response may take",
+	"SHARE_TOKEN_SECRET":                           "Seals share tokens. Without it searches cannot be shared.",
+	"SHARE_TOKEN_TTL":                              "How long a share link stays valid",
+	"STORAGE_ENCRYPTION_KEYS":                      "The key list (empty = persisted data is not encrypted)",
+	"STORAGE_ENCRYPTION_KEYS_FILE":                 "Reads the key list from a file, e.g. a mounted secret",
+	"TENANT_DUPLICATE_SEARCH_WINDOW":               "How long after an API key's search an identical search counts as a duplicate, answered with a hint to reuse the previous results (0 = duplicates are not tracked)",
+	"TIMEOUT_CONTINUATION_TTL":                     "How long partial searches are held for their late provider results to be fetched with the continuation token",
+	"TIMEOUT_GLOBAL_SEARCH":                        "The search budget for interactive requests",
+	"TIMEOUT_GLOBAL_SEARCH_BATCH":                  "The search budget for batch/bulk requests",
+	"TIMEOUT_PER_PROVIDER":                         "Bounds each provider search, retries included",
+	"TIMEOUT_PER_PROVIDER_BY_PROVIDER":             "Overrides PerProvider per provider, e.g. \"lion_air=3s\"",
+	"TIMEOUT_SOFT_DEADLINE":                        "When searches accepting partial results return the answers received so far",
+}
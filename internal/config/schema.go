@@ -0,0 +1,17 @@
+package config
+
+import "github.com/flight-search/flight-search-and-aggregation-system/internal/configdoc"
+
+//go:generate go run ../../cmd/genconfigdoc -src config.go -out descriptions_gen.go
+
+// Schema returns every configuration option with its type, default and
+// description, in declaration order.
+func Schema() []configdoc.Option {
+	return configdoc.Describe(Config{}, optionDescriptions)
+}
+
+// Settings returns every configuration option with its effective value.
+// Credentials are redacted.
+func (c *Config) Settings() []configdoc.Setting {
+	return configdoc.Settings(c, optionDescriptions)
+}
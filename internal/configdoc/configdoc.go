@@ -0,0 +1,151 @@
+// Package configdoc documents configuration structs loaded from environment
+// variables.
+//
+// Options are read from the env and envDefault struct tags of a configuration
+// struct and its nested structs. Their descriptions come from the fields' doc
+// comments: Extract collects them from the source, and GenerateDescriptions
+// writes them into a generated file of the configuration package, so the
+// documentation served at run time cannot drift from the code.
+//
+// Fields tagged secret:"true" hold credentials: Settings reports whether they
+// are set, never their value.
+package configdoc
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Redacted replaces the value of secret options that are set.
+const Redacted = "[REDACTED]"
+
+// Option describes a configuration option.
+type Option struct {
+	// Name is the environment variable setting the option
+	Name string `json:"name"`
+
+	// Type is the option's value type: string, int, float, bool, duration,
+	// list of <type> or map of <type>
+	Type string `json:"type"`
+
+	// Default is the value used when the variable is not set
+	Default string `json:"default"`
+
+	// Description is the doc comment of the option's field
+	Description string `json:"description"`
+
+	// Secret reports whether the option holds a credential
+	Secret bool `json:"secret,omitempty"`
+}
+
+// Setting is an option with its effective value.
+type Setting struct {
+	Option
+
+	// Value is the effective value, Redacted for secrets that are set
+	Value string `json:"value"`
+}
+
+// Describe returns the options of cfg, a configuration struct or a pointer to
+// one, in declaration order. descriptions maps option names to their
+// description.
+func Describe(cfg any, descriptions map[string]string) []Option {
+	settings := Settings(cfg, descriptions)
+	options := make([]Option, len(settings))
+	for i, s := range settings {
+		options[i] = s.Option
+	}
+	return options
+}
+
+// Settings returns the options of cfg with their values in cfg, in
+// declaration order. The values of secrets are redacted.
+func Settings(cfg any, descriptions map[string]string) []Setting {
+	var settings []Setting
+	walk(reflect.Indirect(reflect.ValueOf(cfg)), func(field reflect.StructField, v reflect.Value) {
+		name := field.Tag.Get("env")
+		s := Setting{
+			Option: Option{
+				Name:        name,
+				Type:        typeName(field.Type),
+				Default:     field.Tag.Get("envDefault"),
+				Description: descriptions[name],
+				Secret:      field.Tag.Get("secret") == "true",
+			},
+			Value: formatValue(v),
+		}
+		if s.Secret && s.Value != "" {
+			s.Value = Redacted
+		}
+		settings = append(settings, s)
+	})
+	return settings
+}
+
+// walk calls fn for each env-tagged field of the struct v, descending into
+// untagged struct fields.
+func walk(v reflect.Value, fn func(reflect.StructField, reflect.Value)) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if _, ok := field.Tag.Lookup("env"); ok {
+			fn(field, v.Field(i))
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct {
+			walk(v.Field(i), fn)
+		}
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// typeName returns the documented name of an option type.
+func typeName(t reflect.Type) string {
+	if t == durationType {
+		return "duration"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Slice:
+		return "list of " + typeName(t.Elem())
+	case reflect.Map:
+		return "map of " + typeName(t.Elem())
+	default:
+		return "string"
+	}
+}
+
+// formatValue renders a value the way it is written in the environment: lists
+// comma-separated and maps as comma-separated key=value pairs sorted by key.
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Slice:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatValue(v.Index(i))
+		}
+		return strings.Join(items, ",")
+	case reflect.Map:
+		items := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			items = append(items, formatValue(key)+"="+formatValue(v.MapIndex(key)))
+		}
+		slices.Sort(items)
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
@@ -0,0 +1,125 @@
+package configdoc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Server struct {
+		Port    int           `env:"PORT" envDefault:"8080"`
+		Timeout time.Duration `env:"TIMEOUT" envDefault:"5s"`
+	}
+	Auth struct {
+		Secret string `env:"SECRET" secret:"true"`
+		Token  string `env:"TOKEN" secret:"true"`
+	}
+	Hosts    []string          `env:"HOSTS" envSeparator:","`
+	Limits   map[string]int    `env:"LIMITS" envKeyValSeparator:"="`
+	Ratio    float64           `env:"RATIO" envDefault:"1.5"`
+	Enabled  bool              `env:"ENABLED"`
+	internal string            // unexported fields are skipped
+	Labels   map[string]string // untagged fields are skipped
+}
+
+func TestSettings(t *testing.T) {
+	var cfg testConfig
+	cfg.Server.Port = 9090
+	cfg.Server.Timeout = 2 * time.Second
+	cfg.Auth.Secret = "hunter2"
+	cfg.Hosts = []string{"a", "b"}
+	cfg.Limits = map[string]int{"lion_air": 2, "airasia": 1}
+	cfg.Enabled = true
+
+	settings := Settings(&cfg, map[string]string{"PORT": "The port"})
+
+	values := map[string]string{}
+	var names []string
+	for _, s := range settings {
+		names = append(names, s.Name)
+		values[s.Name] = s.Value
+	}
+	assert.Equal(t, []string{"PORT", "TIMEOUT", "SECRET", "TOKEN", "HOSTS", "LIMITS", "RATIO", "ENABLED"}, names)
+	assert.Equal(t, map[string]string{
+		"PORT":    "9090",
+		"TIMEOUT": "2s",
+		"SECRET":  Redacted,
+		"TOKEN":   "",
+		"HOSTS":   "a,b",
+		"LIMITS":  "airasia=1,lion_air=2",
+		"RATIO":   "0",
+		"ENABLED": "true",
+	}, values)
+	assert.Equal(t, Option{Name: "PORT", Type: "int", Default: "8080", Description: "The port"}, settings[0].Option)
+}
+
+func TestDescribe(t *testing.T) {
+	types := map[string]string{}
+	for _, o := range Describe(testConfig{}, nil) {
+		types[o.Name] = o.Type
+		if o.Name == "SECRET" {
+			assert.True(t, o.Secret)
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"PORT":    "int",
+		"TIMEOUT": "duration",
+		"SECRET":  "string",
+		"TOKEN":   "string",
+		"HOSTS":   "list of string",
+		"LIMITS":  "map of int",
+		"RATIO":   "float",
+		"ENABLED": "bool",
+	}, types)
+}
+
+func TestExtract(t *testing.T) {
+	src := `package config
+
+type ServerConfig struct {
+	// Port is the port the server
+	// listens on
+	Port int ` + "`env:\"PORT\"`" + `
+
+	// MaxConns caps connections (0 = unlimited)
+	MaxConns int ` + "`env:\"MAX_CONNS\"`" + `
+
+	Debug bool ` + "`env:\"DEBUG\"`" + ` // Enables debug logging
+
+	Plain string
+}
+`
+	path := filepath.Join(t.TempDir(), "config.go")
+	require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+
+	descriptions, err := Extract(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"PORT":      "The port the server listens on",
+		"MAX_CONNS": "Caps connections (0 = unlimited)",
+		"DEBUG":     "Enables debug logging",
+	}, descriptions)
+
+	_, err = Extract(filepath.Join(t.TempDir(), "missing.go"))
+	assert.Error(t, err)
+}
+
+func TestGenerateDescriptions(t *testing.T) {
+	code, err := GenerateDescriptions("config", "config.go", map[string]string{"B": `Say "hi"`, "A": "First"})
+	require.NoError(t, err)
+	assert.Equal(t, `// Code generated by genconfigdoc from config.go. DO NOT EDIT.
+
+package config
+
+// optionDescriptions are the descriptions of the configuration options, by name.
+var optionDescriptions = map[string]string{
+	"A": "First",
+	"B": "Say \"hi\"",
+}
+`, string(code))
+}
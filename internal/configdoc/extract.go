@@ -0,0 +1,87 @@
+package configdoc
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Extract returns the descriptions of the options declared in the Go source
+// files at paths, keyed by option name: the doc comments of the env-tagged
+// struct fields, on one line, without the leading field name.
+func Extract(paths ...string) (map[string]string, error) {
+	descriptions := make(map[string]string)
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			st, ok := n.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			for _, field := range st.Fields.List {
+				if field.Tag == nil || len(field.Names) != 1 {
+					continue
+				}
+				tag, err := strconv.Unquote(field.Tag.Value)
+				if err != nil {
+					continue
+				}
+				name := reflect.StructTag(tag).Get("env")
+				if name == "" {
+					continue
+				}
+				doc := field.Doc
+				if doc == nil {
+					doc = field.Comment
+				}
+				descriptions[name] = describe(field.Names[0].Name, doc.Text())
+			}
+			return true
+		})
+	}
+	return descriptions, nil
+}
+
+// describe turns a field's doc comment into an option description: "Port is
+// the port the server listens on" becomes "The port the server listens on".
+func describe(fieldName, doc string) string {
+	text := strings.Join(strings.Fields(doc), " ")
+	if rest, ok := strings.CutPrefix(text, fieldName+" "); ok {
+		text = rest
+		for _, verb := range []string{"is ", "are "} {
+			text = strings.TrimPrefix(text, verb)
+		}
+	}
+	r, size := utf8.DecodeRuneInString(text)
+	return string(unicode.ToUpper(r)) + text[size:]
+}
+
+// GenerateDescriptions returns the source of a file of package pkg declaring
+// the descriptions as the optionDescriptions map. source names the files the
+// descriptions were extracted from in the "Code generated" header.
+func GenerateDescriptions(pkg, source string, descriptions map[string]string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by genconfigdoc from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("// optionDescriptions are the descriptions of the configuration options, by name.\n")
+	b.WriteString("var optionDescriptions = map[string]string{\n")
+	for _, name := range slices.Sorted(maps.Keys(descriptions)) {
+		fmt.Fprintf(&b, "\t%q: %q,\n", name, descriptions[name])
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,339 @@
+// Package difftest compares the normalized output of two implementations of
+// the same flight provider, typically the file-based mock adapter and the
+// adapter talking to the airline's real API.
+//
+// For each search criteria both providers are queried and their flights are
+// compared structurally (flights present on one side only, fields populated on
+// one side only, fields disagreeing on the same flight) and statistically
+// (price and duration distributions, share of direct flights). A report with
+// no differences means the mock is a faithful stand-in for the live API.
+package difftest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// DefaultTolerance is the default relative difference allowed between
+// statistics of the two sides, 0.1 being 10%.
+const DefaultTolerance = 0.1
+
+// field extracts a comparable value from a flight. The zero string means the
+// field is not populated.
+type field struct {
+	name  string
+	value func(f domain.Flight) string
+}
+
+// fields are the flight fields compared between both sides.
+var fields = []field{
+	{"airline.code", func(f domain.Flight) string { return f.Airline.Code }},
+	{"airline.name", func(f domain.Flight) string { return f.Airline.Name }},
+	{"departure.airportCode", func(f domain.Flight) string { return f.Departure.AirportCode }},
+	{"departure.terminal", func(f domain.Flight) string { return f.Departure.Terminal }},
+	{"departure.timezone", func(f domain.Flight) string { return f.Departure.Timezone }},
+	{"arrival.airportCode", func(f domain.Flight) string { return f.Arrival.AirportCode }},
+	{"arrival.terminal", func(f domain.Flight) string { return f.Arrival.Terminal }},
+	{"arrival.dateTime", func(f domain.Flight) string { return formatTime(f.Arrival.DateTime) }},
+	{"arrival.timezone", func(f domain.Flight) string { return f.Arrival.Timezone }},
+	{"duration.totalMinutes", func(f domain.Flight) string { return formatInt(f.Duration.TotalMinutes) }},
+	{"price.currency", func(f domain.Flight) string { return f.Price.Currency }},
+	{"baggage.cabinKg", func(f domain.Flight) string { return formatInt(f.Baggage.CabinKg) }},
+	{"baggage.checkedKg", func(f domain.Flight) string { return formatInt(f.Baggage.CheckedKg) }},
+	{"class", func(f domain.Flight) string { return f.Class }},
+	{"stops", func(f domain.Flight) string { return fmt.Sprint(f.Stops) }},
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprint(n)
+}
+
+// flightKey identifies the same flight on both sides.
+func flightKey(f domain.Flight) string {
+	return f.FlightNumber + "@" + f.Departure.DateTime.UTC().Format(time.RFC3339)
+}
+
+// FieldDiff is a field disagreeing between both sides on the same flight.
+type FieldDiff struct {
+	Flight string
+	Field  string
+	Mock   string
+	Live   string
+}
+
+// Summary describes the distribution of one side's flights.
+type Summary struct {
+	Flights       int
+	MinPrice      float64
+	MedianPrice   float64
+	MaxPrice      float64
+	MeanDuration  float64
+	DirectShare   float64
+	FieldCoverage map[string]float64
+}
+
+// summarize computes the summary of flights.
+func summarize(flights []domain.Flight) Summary {
+	s := Summary{Flights: len(flights), FieldCoverage: make(map[string]float64, len(fields))}
+	if len(flights) == 0 {
+		return s
+	}
+
+	prices := make([]float64, len(flights))
+	var durations float64
+	direct := 0
+	for i, f := range flights {
+		prices[i] = f.Price.Amount
+		durations += float64(f.Duration.TotalMinutes)
+		if f.Stops == 0 {
+			direct++
+		}
+		for _, fd := range fields {
+			if fd.value(f) != "" {
+				s.FieldCoverage[fd.name]++
+			}
+		}
+	}
+	for name, n := range s.FieldCoverage {
+		s.FieldCoverage[name] = n / float64(len(flights))
+	}
+
+	sort.Float64s(prices)
+	s.MinPrice = prices[0]
+	s.MaxPrice = prices[len(prices)-1]
+	if mid := len(prices) / 2; len(prices)%2 == 0 {
+		s.MedianPrice = (prices[mid-1] + prices[mid]) / 2
+	} else {
+		s.MedianPrice = prices[mid]
+	}
+	s.MeanDuration = durations / float64(len(flights))
+	s.DirectShare = float64(direct) / float64(len(flights))
+	return s
+}
+
+// Case is the comparison of both sides for one search criteria.
+type Case struct {
+	Criteria domain.SearchCriteria
+
+	// MockErr and LiveErr are the errors of failed searches
+	MockErr error
+	LiveErr error
+
+	Mock Summary
+	Live Summary
+
+	// OnlyInMock and OnlyInLive are the flights returned by one side only
+	OnlyInMock []string
+	OnlyInLive []string
+
+	// FieldDiffs are the fields disagreeing on flights returned by both sides
+	FieldDiffs []FieldDiff
+
+	// Drift lists the statistics that differ beyond the tolerance
+	Drift []string
+}
+
+// Differs reports whether the comparison found any difference.
+func (c Case) Differs() bool {
+	return (c.MockErr == nil) != (c.LiveErr == nil) ||
+		len(c.OnlyInMock) > 0 || len(c.OnlyInLive) > 0 ||
+		len(c.FieldDiffs) > 0 || len(c.Drift) > 0
+}
+
+// Report is the result of a comparison.
+type Report struct {
+	Provider string
+	Cases    []Case
+}
+
+// Differs reports whether any case found a difference.
+func (r *Report) Differs() bool {
+	for _, c := range r.Cases {
+		if c.Differs() {
+			return true
+		}
+	}
+	return false
+}
+
+// Harness compares a mock and a live provider.
+type Harness struct {
+	mock      domain.FlightProvider
+	live      domain.FlightProvider
+	tolerance float64
+}
+
+// Option configures a Harness.
+type Option func(*Harness)
+
+// WithTolerance sets the relative difference allowed between statistics.
+func WithTolerance(tolerance float64) Option {
+	return func(h *Harness) {
+		h.tolerance = tolerance
+	}
+}
+
+// New creates a harness comparing the mock provider with the live provider.
+func New(mock, live domain.FlightProvider, opts ...Option) *Harness {
+	h := &Harness{mock: mock, live: live, tolerance: DefaultTolerance}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Run searches both providers with each criteria and compares the results.
+func (h *Harness) Run(ctx context.Context, criteria []domain.SearchCriteria) *Report {
+	report := &Report{Provider: h.live.Name(), Cases: make([]Case, 0, len(criteria))}
+	for _, c := range criteria {
+		report.Cases = append(report.Cases, h.compare(ctx, c))
+	}
+	return report
+}
+
+// compare runs one criteria against both providers.
+func (h *Harness) compare(ctx context.Context, criteria domain.SearchCriteria) Case {
+	mockFlights, mockErr := h.mock.Search(ctx, criteria)
+	liveFlights, liveErr := h.live.Search(ctx, criteria)
+
+	c := Case{
+		Criteria: criteria,
+		MockErr:  mockErr,
+		LiveErr:  liveErr,
+		Mock:     summarize(mockFlights),
+		Live:     summarize(liveFlights),
+	}
+	if mockErr != nil || liveErr != nil {
+		return c
+	}
+
+	mockByKey := make(map[string]domain.Flight, len(mockFlights))
+	for _, f := range mockFlights {
+		mockByKey[flightKey(f)] = f
+	}
+	liveByKey := make(map[string]domain.Flight, len(liveFlights))
+	for _, f := range liveFlights {
+		liveByKey[flightKey(f)] = f
+	}
+
+	for key, m := range mockByKey {
+		l, ok := liveByKey[key]
+		if !ok {
+			c.OnlyInMock = append(c.OnlyInMock, key)
+			continue
+		}
+		for _, fd := range fields {
+			if mv, lv := fd.value(m), fd.value(l); mv != lv {
+				c.FieldDiffs = append(c.FieldDiffs, FieldDiff{Flight: key, Field: fd.name, Mock: mv, Live: lv})
+			}
+		}
+	}
+	for key := range liveByKey {
+		if _, ok := mockByKey[key]; !ok {
+			c.OnlyInLive = append(c.OnlyInLive, key)
+		}
+	}
+	sort.Strings(c.OnlyInMock)
+	sort.Strings(c.OnlyInLive)
+	sort.Slice(c.FieldDiffs, func(i, j int) bool {
+		if c.FieldDiffs[i].Flight != c.FieldDiffs[j].Flight {
+			return c.FieldDiffs[i].Flight < c.FieldDiffs[j].Flight
+		}
+		return c.FieldDiffs[i].Field < c.FieldDiffs[j].Field
+	})
+
+	c.Drift = h.drift(c.Mock, c.Live)
+	return c
+}
+
+// drift lists the statistics of both summaries differing beyond the tolerance.
+func (h *Harness) drift(mock, live Summary) []string {
+	var drift []string
+	check := func(name string, m, l float64) {
+		if relativeDifference(m, l) > h.tolerance {
+			drift = append(drift, fmt.Sprintf("%s: mock %.4g, live %.4g", name, m, l))
+		}
+	}
+
+	check("flights", float64(mock.Flights), float64(live.Flights))
+	check("median price", mock.MedianPrice, live.MedianPrice)
+	check("mean duration", mock.MeanDuration, live.MeanDuration)
+	check("direct share", mock.DirectShare, live.DirectShare)
+
+	for _, fd := range fields {
+		check(fd.name+" coverage", mock.FieldCoverage[fd.name], live.FieldCoverage[fd.name])
+	}
+	return drift
+}
+
+// relativeDifference returns |a-b| relative to the larger magnitude, 0 when
+// both are 0.
+func relativeDifference(a, b float64) float64 {
+	scale := math.Max(math.Abs(a), math.Abs(b))
+	if scale == 0 {
+		return 0
+	}
+	return math.Abs(a-b) / scale
+}
+
+// Write prints the report in a human-readable form.
+func (r *Report) Write(w io.Writer) error {
+	ew := &errWriter{w: w}
+	ew.printf("Provider %s: %d criteria compared\n", r.Provider, len(r.Cases))
+	for _, c := range r.Cases {
+		ew.printf("\n%s-%s %s (class %q, %d pax): ",
+			c.Criteria.Origin, c.Criteria.Destination, c.Criteria.DepartureDate, c.Criteria.Class, c.Criteria.Passengers)
+		if !c.Differs() {
+			ew.printf("identical (%d flights)\n", c.Live.Flights)
+			continue
+		}
+		ew.printf("%d mock flights, %d live flights\n", c.Mock.Flights, c.Live.Flights)
+		if c.MockErr != nil {
+			ew.printf("  mock error: %v\n", c.MockErr)
+		}
+		if c.LiveErr != nil {
+			ew.printf("  live error: %v\n", c.LiveErr)
+		}
+		for _, key := range c.OnlyInMock {
+			ew.printf("  only in mock: %s\n", key)
+		}
+		for _, key := range c.OnlyInLive {
+			ew.printf("  only in live: %s\n", key)
+		}
+		for _, d := range c.FieldDiffs {
+			ew.printf("  %s %s: mock %q, live %q\n", d.Flight, d.Field, d.Mock, d.Live)
+		}
+		for _, d := range c.Drift {
+			ew.printf("  drift %s\n", d)
+		}
+	}
+	return ew.err
+}
+
+// errWriter keeps the first write error.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err == nil {
+		_, ew.err = fmt.Fprintf(ew.w, format, args...)
+	}
+}
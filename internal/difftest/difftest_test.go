@@ -0,0 +1,106 @@
+package difftest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+func provider(ctrl *gomock.Controller, name string, flights []domain.Flight, err error) *domain.MockFlightProvider {
+	mock := domain.NewMockFlightProvider(ctrl)
+	mock.EXPECT().Name().Return(name).AnyTimes()
+	mock.EXPECT().Search(gomock.Any(), gomock.Any()).Return(flights, err).AnyTimes()
+	return mock
+}
+
+func flight(number string, hour int, price float64) domain.Flight {
+	departure := time.Date(2025, 12, 15, hour, 0, 0, 0, time.UTC)
+	return domain.Flight{
+		FlightNumber: number,
+		Airline:      domain.AirlineInfo{Code: "GA", Name: "Garuda Indonesia"},
+		Departure:    domain.FlightPoint{AirportCode: "CGK", DateTime: departure, Timezone: "Asia/Jakarta"},
+		Arrival:      domain.FlightPoint{AirportCode: "DPS", DateTime: departure.Add(2 * time.Hour), Timezone: "Asia/Makassar"},
+		Duration:     domain.DurationInfo{TotalMinutes: 120},
+		Price:        domain.PriceInfo{Amount: price, Currency: "IDR"},
+		Baggage:      domain.BaggageInfo{CabinKg: 7, CheckedKg: 20},
+		Class:        "economy",
+	}
+}
+
+func TestHarness_Identical(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	flights := []domain.Flight{flight("GA-1", 8, 1000000), flight("GA-2", 12, 1200000)}
+
+	report := New(provider(ctrl, "garuda", flights, nil), provider(ctrl, "garuda", flights, nil)).
+		Run(context.Background(), []domain.SearchCriteria{{Origin: "CGK", Destination: "DPS"}})
+
+	assert.False(t, report.Differs())
+	require.Len(t, report.Cases, 1)
+	assert.Equal(t, 2, report.Cases[0].Live.Flights)
+	assert.InDelta(t, 1100000, report.Cases[0].Live.MedianPrice, 1e-9)
+	assert.Equal(t, 1.0, report.Cases[0].Live.FieldCoverage["baggage.checkedKg"])
+}
+
+func TestHarness_Differences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	liveGA1 := flight("GA-1", 8, 1000000)
+	liveGA1.Baggage.CheckedKg = 0
+	liveGA1.Arrival.Terminal = "D"
+	mock := []domain.Flight{flight("GA-1", 8, 1000000), flight("GA-2", 12, 1200000)}
+	live := []domain.Flight{liveGA1, flight("GA-3", 18, 2000000)}
+
+	report := New(provider(ctrl, "garuda", mock, nil), provider(ctrl, "garuda", live, nil)).
+		Run(context.Background(), []domain.SearchCriteria{{Origin: "CGK", Destination: "DPS"}})
+	require.True(t, report.Differs())
+
+	c := report.Cases[0]
+	assert.Equal(t, []string{"GA-2@2025-12-15T12:00:00Z"}, c.OnlyInMock)
+	assert.Equal(t, []string{"GA-3@2025-12-15T18:00:00Z"}, c.OnlyInLive)
+	assert.Equal(t, []FieldDiff{
+		{Flight: "GA-1@2025-12-15T08:00:00Z", Field: "arrival.terminal", Mock: "", Live: "D"},
+		{Flight: "GA-1@2025-12-15T08:00:00Z", Field: "baggage.checkedKg", Mock: "20", Live: ""},
+	}, c.FieldDiffs)
+	assert.Contains(t, c.Drift, "median price: mock 1.1e+06, live 1.5e+06")
+	assert.Contains(t, c.Drift, "baggage.checkedKg coverage: mock 1, live 0.5")
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+	assert.Contains(t, out.String(), "only in live: GA-3@2025-12-15T18:00:00Z")
+	assert.Contains(t, out.String(), `GA-1@2025-12-15T08:00:00Z baggage.checkedKg: mock "20", live ""`)
+}
+
+func TestHarness_Tolerance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := []domain.Flight{flight("GA-1", 8, 1000000)}
+	live := []domain.Flight{flight("GA-1", 8, 1050000)}
+
+	report := New(provider(ctrl, "garuda", mock, nil), provider(ctrl, "garuda", live, nil)).
+		Run(context.Background(), []domain.SearchCriteria{{}})
+	assert.False(t, report.Differs(), "5% price difference is within the default tolerance")
+
+	report = New(provider(ctrl, "garuda", mock, nil), provider(ctrl, "garuda", live, nil), WithTolerance(0.01)).
+		Run(context.Background(), []domain.SearchCriteria{{}})
+	assert.True(t, report.Differs())
+}
+
+func TestHarness_OneSideFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	report := New(
+		provider(ctrl, "garuda", []domain.Flight{flight("GA-1", 8, 1000000)}, nil),
+		provider(ctrl, "garuda", nil, errors.New("unexpected field type")),
+	).Run(context.Background(), []domain.SearchCriteria{{}})
+
+	assert.True(t, report.Differs())
+	assert.EqualError(t, report.Cases[0].LiveErr, "unexpected field type")
+	assert.Empty(t, report.Cases[0].OnlyInMock, "flights are not compared when a side failed")
+}
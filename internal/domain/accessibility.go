@@ -0,0 +1,72 @@
+package domain
+
+import "slices"
+
+// SpecialService is a special assistance service a passenger may request.
+type SpecialService string
+
+// Special assistance services.
+const (
+	// ServiceWheelchair is wheelchair assistance between the terminal and the aircraft door
+	ServiceWheelchair SpecialService = "wheelchair"
+
+	// ServiceWheelchairOnboard is an aisle wheelchair to and from the seat
+	ServiceWheelchairOnboard SpecialService = "wheelchair_onboard"
+
+	// ServiceVisualAssistance is escort and briefing for blind or low-vision passengers
+	ServiceVisualAssistance SpecialService = "visual_assistance"
+
+	// ServiceHearingAssistance is written briefing and alerts for deaf or hard-of-hearing passengers
+	ServiceHearingAssistance SpecialService = "hearing_assistance"
+
+	// ServiceServiceAnimal is carriage of a trained assistance animal in the cabin
+	ServiceServiceAnimal SpecialService = "service_animal"
+)
+
+// SpecialServices lists the known special assistance services.
+var SpecialServices = []SpecialService{
+	ServiceWheelchair,
+	ServiceWheelchairOnboard,
+	ServiceVisualAssistance,
+	ServiceHearingAssistance,
+	ServiceServiceAnimal,
+}
+
+// IsValid reports whether s is a known special assistance service.
+func (s SpecialService) IsValid() bool {
+	return slices.Contains(SpecialServices, s)
+}
+
+// Sources of accessibility information.
+const (
+	// AccessibilitySourceProvider marks information supplied with the provider's flight data
+	AccessibilitySourceProvider = "provider"
+
+	// AccessibilitySourcePolicy marks information taken from the airline's published policy
+	AccessibilitySourcePolicy = "policy"
+)
+
+// AccessibilityInfo describes the special assistance available on a flight.
+type AccessibilityInfo struct {
+	// Services are the special assistance services the flight supports
+	Services []SpecialService `json:"services"`
+
+	// Source tells where the information comes from: "provider" or "policy"
+	Source string `json:"source"`
+}
+
+// Supports reports whether the flight supports the service. Flights without
+// accessibility information support none.
+func (a *AccessibilityInfo) Supports(service SpecialService) bool {
+	return a != nil && slices.Contains(a.Services, service)
+}
+
+// SupportsAll reports whether the flight supports every one of the services.
+func (a *AccessibilityInfo) SupportsAll(services []SpecialService) bool {
+	for _, s := range services {
+		if !a.Supports(s) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecialService_IsValid(t *testing.T) {
+	for _, s := range SpecialServices {
+		assert.True(t, s.IsValid(), s)
+	}
+	assert.False(t, SpecialService("jetpack").IsValid())
+	assert.False(t, SpecialService("").IsValid())
+}
+
+func TestAccessibilityInfo_Supports(t *testing.T) {
+	var unknown *AccessibilityInfo
+	assert.False(t, unknown.Supports(ServiceWheelchair))
+	assert.False(t, unknown.SupportsAll([]SpecialService{ServiceWheelchair}))
+	assert.True(t, unknown.SupportsAll(nil), "nothing requested")
+
+	info := &AccessibilityInfo{Services: []SpecialService{ServiceWheelchair, ServiceVisualAssistance}}
+	assert.True(t, info.Supports(ServiceWheelchair))
+	assert.False(t, info.Supports(ServiceServiceAnimal))
+	assert.True(t, info.SupportsAll([]SpecialService{ServiceWheelchair, ServiceVisualAssistance}))
+	assert.False(t, info.SupportsAll([]SpecialService{ServiceWheelchair, ServiceServiceAnimal}))
+}
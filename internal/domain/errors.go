@@ -23,6 +23,14 @@ var (
 	// ErrProviderUnavailable indicates a provider is not reachable.
 	ErrProviderUnavailable = errors.New("provider unavailable")
 
+	// ErrMalformedResponse indicates a provider response could not be decoded,
+	// typically because the provider's schema changed. It is not retryable.
+	ErrMalformedResponse = errors.New("malformed provider response")
+
+	// ErrResponseTooLarge indicates a provider response exceeded its size limit
+	// and was not decoded. It is not retryable.
+	ErrResponseTooLarge = errors.New("provider response too large")
+
 	// ErrNoFlightsFound indicates no flights matched the search criteria.
 	// This is not necessarily an error but useful for explicit handling.
 	ErrNoFlightsFound = errors.New("no flights found")
@@ -34,6 +42,33 @@ var (
 	// ErrMissingRequiredField indicates a required field is missing from flight data.
 	// This represents incomplete data from a provider.
 	ErrMissingRequiredField = errors.New("missing required field")
+
+	// ErrInvalidFlightNumber indicates a flight number is not a valid IATA flight number.
+	// This represents invalid data from a provider.
+	ErrInvalidFlightNumber = errors.New("invalid flight number")
+
+	// ErrFlightNumberMismatch indicates a flight number's airline designator differs
+	// from the flight's airline code, usually an adapter mapping bug.
+	ErrFlightNumberMismatch = errors.New("flight number does not match airline")
+
+	// ErrSearchNotFound indicates a stored search does not exist or has been evicted (HTTP 404).
+	ErrSearchNotFound = errors.New("search not found")
+
+	// ErrSearchAborted indicates a search was abandoned because the client
+	// cancelled the request, e.g. by disconnecting. Search errors carrying it
+	// also wrap context.Canceled.
+	ErrSearchAborted = errors.New("search aborted")
+
+	// ErrContinuationNotFound indicates a continuation token is unknown or has expired (HTTP 404).
+	ErrContinuationNotFound = errors.New("continuation not found")
+
+	// ErrShareNotFound indicates a share token is invalid or has expired, or
+	// the shared search has been evicted (HTTP 404).
+	ErrShareNotFound = errors.New("shared search not found")
+
+	// ErrCurrencyMismatch indicates prices in different currencies were
+	// compared or combined. Prices must be converted to a common currency first.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
 )
 
 // ProviderError wraps an error with provider context.
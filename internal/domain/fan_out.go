@@ -0,0 +1,30 @@
+package domain
+
+// FanOutStrategy names how the providers of a search are queried.
+type FanOutStrategy string
+
+// Available fan-out strategies.
+const (
+	// FanOutAllParallel queries every provider at once and waits for all of
+	// them (default)
+	FanOutAllParallel FanOutStrategy = "all-parallel"
+
+	// FanOutFastestFirst queries every provider at once and responds as soon
+	// as enough providers answered or enough flights arrived
+	FanOutFastestFirst FanOutStrategy = "fastest-first-return"
+
+	// FanOutSequentialFailover queries the providers one at a time, in their
+	// configured order, until one returns flights, so only the providers
+	// needed are called
+	FanOutSequentialFailover FanOutStrategy = "sequential-failover"
+)
+
+// IsValid checks if the fan-out strategy is a valid value.
+func (s FanOutStrategy) IsValid() bool {
+	switch s {
+	case FanOutAllParallel, FanOutFastestFirst, FanOutSequentialFailover:
+		return true
+	default:
+		return false
+	}
+}
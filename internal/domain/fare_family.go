@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// FareFamily is an airline's branded fare, such as Lite, Value or Flex: the
+// same seat sold with different conditions.
+type FareFamily struct {
+	// Name is the airline's name for the fare family (e.g., "Flex")
+	Name string `json:"name"`
+
+	// Changeable reports whether the ticket can be changed after booking
+	Changeable bool `json:"changeable"`
+
+	// Refundable reports whether the ticket can be refunded
+	Refundable bool `json:"refundable"`
+}
+
+// FareRules are the conditions of a fare, normalized from whatever fare
+// metadata the provider reports.
+type FareRules struct {
+	// Refundable reports whether the ticket can be refunded
+	Refundable bool `json:"refundable"`
+
+	// Changeable reports whether the ticket can be changed after booking
+	Changeable bool `json:"changeable"`
+
+	// ChangeFee is the fee charged for a change, if the ticket is changeable
+	// and the provider reports it (nil otherwise; zero means free changes)
+	ChangeFee *PriceInfo `json:"changeFee,omitempty"`
+}
+
+// NewFareRules returns the rules of a fare from the conditions a provider
+// reports. The change fee, if any, is in the currency of the fare and is
+// dropped for fares that cannot be changed.
+func NewFareRules(refundable, changeable bool, changeFee *float64, currency string) *FareRules {
+	rules := &FareRules{Refundable: refundable, Changeable: changeable}
+	if changeable && changeFee != nil {
+		rules.ChangeFee = &PriceInfo{Amount: *changeFee, Currency: currency}
+	}
+	return rules
+}
+
+// FareOption is one fare family of a flight a provider sells in several.
+type FareOption struct {
+	// FlightID is the ID of the flight result the provider returned for the fare
+	FlightID string `json:"flightId"`
+
+	// FareFamily is the fare's family and conditions
+	FareFamily FareFamily `json:"fareFamily"`
+
+	// Price is the fare's price
+	Price PriceInfo `json:"price"`
+
+	// Baggage is the fare's baggage allowance
+	Baggage BaggageInfo `json:"baggage"`
+}
+
+// NewFareOption returns the fare of f, which must have a fare family.
+func NewFareOption(f Flight) FareOption {
+	return FareOption{FlightID: f.ID, FareFamily: *f.FareFamily, Price: f.Price, Baggage: f.Baggage}
+}
+
+// InFareFamily reports whether the flight's fare belongs to one of the named
+// fare families, compared case-insensitively. Flights without a fare family
+// belong to none.
+func (f *Flight) InFareFamily(names []string) bool {
+	return f.FareFamily != nil && slices.ContainsFunc(names, func(name string) bool {
+		return strings.EqualFold(strings.TrimSpace(name), f.FareFamily.Name)
+	})
+}
+
+// FareFlightID returns the ID of the fare of flight id in a fare family, so
+// the fares of a flight sold in several fare families have IDs of their own,
+// e.g. "GA400-flex". Adapters use it for flights with a fare family.
+func FareFlightID(id, family string) string {
+	return id + "-" + strings.Join(strings.Fields(strings.ToLower(family)), "-")
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFareFlightID(t *testing.T) {
+	assert.Equal(t, "GA400-flex", FareFlightID("GA400", "Flex"))
+	assert.Equal(t, "JT740-super-saver", FareFlightID("JT740", " Super  Saver "))
+}
+
+func TestNewFareRules(t *testing.T) {
+	fee := 250000.0
+	assert.Equal(t, &FareRules{Changeable: true, ChangeFee: &PriceInfo{Amount: 250000, Currency: "IDR"}},
+		NewFareRules(false, true, &fee, "IDR"))
+	assert.Equal(t, &FareRules{Refundable: true}, NewFareRules(true, false, &fee, "IDR"),
+		"no change fee for fares that cannot be changed")
+	assert.Equal(t, &FareRules{Changeable: true}, NewFareRules(false, true, nil, "IDR"))
+}
@@ -0,0 +1,56 @@
+package domain
+
+// Features listed in SearchMetadata.Features: the optional behaviors active
+// for a search, so support can tell how a search ran from its response.
+// Features taking a value are listed as "name:value" (see FeatureValue).
+const (
+	// FeatureSort is the sort option ranking the results, e.g. "sort:best"
+	FeatureSort = "sort"
+
+	// FeatureDeparturePreference favors flights departing within the
+	// preferred departure time in best value ranking
+	FeatureDeparturePreference = "departure_preference"
+
+	// FeatureRequestClass is the request class selecting the timeout budget,
+	// e.g. "request_class:batch"
+	FeatureRequestClass = "request_class"
+
+	// FeatureCache answers repeated searches from the cache and caches
+	// complete results
+	FeatureCache = "cache"
+
+	// FeatureCacheDrift searches a cache hit live as well, in the background,
+	// to measure how far the cached results drifted
+	FeatureCacheDrift = "cache_drift"
+
+	// FeatureFanOut is the strategy querying the providers, e.g.
+	// "fan_out:all-parallel"
+	FeatureFanOut = "fan_out"
+
+	// FeatureCoalescing shares one provider fan-out with concurrent identical searches
+	FeatureCoalescing = "coalescing"
+
+	// FeaturePartialResults returns the results of the providers that
+	// answered by the soft timeout
+	FeaturePartialResults = "partial_results"
+
+	// FeatureLatencyBudget skips providers whose p95 latency exceeds the
+	// budget the search has left
+	FeatureLatencyBudget = "latency_budget"
+
+	// FeatureRetry retries provider searches failing with a retryable error
+	FeatureRetry = "retry"
+
+	// FeatureShadow queries shadow providers alongside, for comparison only
+	FeatureShadow = "shadow"
+
+	// FeatureLocale is the locale airline and airport names are localized
+	// in, e.g. "locale:id"
+	FeatureLocale = "locale"
+)
+
+// FeatureValue returns the listing of a feature taking a value, e.g.
+// FeatureValue(FeatureFanOut, "all-parallel") is "fan_out:all-parallel".
+func FeatureValue(feature, value string) string {
+	return feature + ":" + value
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"slices"
 	"strings"
 	"time"
 )
@@ -21,23 +22,56 @@ const (
 
 	// SortByDeparture sorts by departure time ascending (earliest first)
 	SortByDeparture SortOption = "departure"
+
+	// SortByArrival sorts by arrival time ascending (earliest first)
+	SortByArrival SortOption = "arrival"
 )
 
 // IsValid checks if the sort option is a valid value.
 func (s SortOption) IsValid() bool {
 	switch s {
-	case SortByBestValue, SortByPrice, SortByDuration, SortByDeparture:
+	case SortByBestValue, SortByPrice, SortByDuration, SortByDeparture, SortByArrival:
 		return true
 	default:
 		return false
 	}
 }
 
+// SortOrder defines the direction of a sort.
+type SortOrder string
+
+// Available sort orders.
+const (
+	// SortAscending sorts from the lowest value: cheapest, shortest, earliest
+	// or best value first (default)
+	SortAscending SortOrder = "asc"
+
+	// SortDescending sorts from the highest value: most expensive, longest,
+	// latest or worst value first
+	SortDescending SortOrder = "desc"
+)
+
+// IsValid checks if the sort order is a valid value.
+func (o SortOrder) IsValid() bool {
+	return o == SortAscending || o == SortDescending
+}
+
 // FilterOptions defines optional filters to apply to flight results.
 type FilterOptions struct {
-	// MaxPrice filters out flights with price above this amount (in the search currency)
+	// MinPrice filters out flights with price below this amount, in
+	// FilterCurrency, such as zero-price fares sent by mistake. Flights priced
+	// in another currency cannot be compared and are filtered out.
+	MinPrice *float64 `json:"minPrice,omitempty"`
+
+	// MaxPrice filters out flights with price above this amount, in FilterCurrency.
+	// Flights priced in another currency cannot be compared and are filtered out.
 	MaxPrice *float64 `json:"maxPrice,omitempty"`
 
+	// PriceBasis is the price MinPrice and MaxPrice compare: the price per
+	// passenger (empty or PriceBasisPerPerson) or the total for all the
+	// passengers (PriceBasisTotal)
+	PriceBasis PriceBasis `json:"priceBasis,omitempty"`
+
 	// MaxStops filters out flights with more stops than this value
 	// 0 = direct flights only, 1 = max 1 stop, etc.
 	MaxStops *int `json:"maxStops,omitempty"`
@@ -54,6 +88,88 @@ type FilterOptions struct {
 
 	// DurationRange filters flights by total duration in minutes
 	DurationRange *DurationRange `json:"durationRange,omitempty"`
+
+	// SupportsWheelchair only includes flights offering wheelchair assistance
+	SupportsWheelchair bool `json:"supportsWheelchair,omitempty"`
+
+	// FareFamilies filters to only include fares of these fare families
+	// (case-insensitive names, e.g. "Flex"). Empty slice means no filtering
+	// by fare family; otherwise flights without a fare family are filtered out.
+	FareFamilies []string `json:"fareFamilies,omitempty"`
+
+	// Changeable only includes fares whose fare family allows changes
+	Changeable bool `json:"changeable,omitempty"`
+
+	// Refundable only includes fares whose fare family allows refunds
+	Refundable bool `json:"refundable,omitempty"`
+
+	// RefundableOnly only includes flights whose fare rules allow refunds,
+	// whether or not the fare belongs to a fare family. Flights whose
+	// provider reports no fare rules are filtered out.
+	RefundableOnly bool `json:"refundableOnly,omitempty"`
+
+	// Class filters out flights of another travel class than this one, as
+	// some providers ignore the class searched for. Empty means no filtering
+	// by class; flights of an unknown (empty) class are kept.
+	Class string `json:"class,omitempty"`
+
+	// IncludeHigherClasses keeps flights of a class above Class as well, e.g.
+	// business class flights on an economy search
+	IncludeHigherClasses bool `json:"includeHigherClasses,omitempty"`
+
+	// MinSeatsAvailable filters out flights with fewer seats left, e.g. so
+	// groups only see flights with a seat for everyone. Flights whose
+	// provider did not report the seats left are filtered out.
+	MinSeatsAvailable *int `json:"minSeatsAvailable,omitempty"`
+}
+
+// classRanks orders the travel classes, from economy up.
+var classRanks = map[string]int{
+	"economy":         0,
+	"premium_economy": 1,
+	"business":        2,
+	"first":           3,
+}
+
+// WithClass returns a copy of f filtering flights by the class searched
+// for, or f itself if class is empty or f already filters by class. A nil f
+// only filters by class.
+func (f *FilterOptions) WithClass(class string) *FilterOptions {
+	if class == "" || (f != nil && f.Class != "") {
+		return f
+	}
+	var filters FilterOptions
+	if f != nil {
+		filters = *f
+	}
+	filters.Class = class
+	return &filters
+}
+
+// WithPriceBasis returns a copy of f comparing prices on the basis, or f
+// itself if f is nil or already compares prices on it.
+func (f *FilterOptions) WithPriceBasis(basis PriceBasis) *FilterOptions {
+	if f == nil || f.PriceBasis == basis {
+		return f
+	}
+	filters := *f
+	filters.PriceBasis = basis
+	return &filters
+}
+
+// matchesClass reports whether a flight of travel class flightClass passes
+// the class filter. Flights of an unknown class pass, and flights of a
+// higher class pass when they are included.
+func (f *FilterOptions) matchesClass(flightClass string) bool {
+	if flightClass == "" || strings.EqualFold(flightClass, f.Class) {
+		return true
+	}
+	if !f.IncludeHigherClasses {
+		return false
+	}
+	have, known := classRanks[strings.ToLower(flightClass)]
+	want, wanted := classRanks[strings.ToLower(f.Class)]
+	return known && wanted && have > want
 }
 
 // TimeRange represents a time window for filtering.
@@ -65,6 +181,55 @@ type TimeRange struct {
 	End time.Time `json:"end"`
 }
 
+// TimeBucket is a named part of the day, as most flight search UIs present
+// departure times.
+type TimeBucket string
+
+// Available time buckets, covering the day without overlapping.
+const (
+	// TimeBucketEarlyMorning is 00:00-05:59
+	TimeBucketEarlyMorning TimeBucket = "early_morning"
+
+	// TimeBucketMorning is 06:00-11:59
+	TimeBucketMorning TimeBucket = "morning"
+
+	// TimeBucketAfternoon is 12:00-17:59
+	TimeBucketAfternoon TimeBucket = "afternoon"
+
+	// TimeBucketEvening is 18:00-20:59
+	TimeBucketEvening TimeBucket = "evening"
+
+	// TimeBucketNight is 21:00-23:59
+	TimeBucketNight TimeBucket = "night"
+)
+
+// timeBucketHours are the first and last hours of each time bucket.
+var timeBucketHours = map[TimeBucket][2]int{
+	TimeBucketEarlyMorning: {0, 5},
+	TimeBucketMorning:      {6, 11},
+	TimeBucketAfternoon:    {12, 17},
+	TimeBucketEvening:      {18, 20},
+	TimeBucketNight:        {21, 23},
+}
+
+// IsValid checks if the time bucket is a valid value.
+func (b TimeBucket) IsValid() bool {
+	_, ok := timeBucketHours[b]
+	return ok
+}
+
+// Range returns the time range the bucket covers, or nil for an invalid bucket.
+func (b TimeBucket) Range() *TimeRange {
+	hours, ok := timeBucketHours[b]
+	if !ok {
+		return nil
+	}
+	return &TimeRange{
+		Start: time.Date(0, 1, 1, hours[0], 0, 0, 0, time.UTC),
+		End:   time.Date(0, 1, 1, hours[1], 59, 0, 0, time.UTC),
+	}
+}
+
 // DurationRange represents a duration range filter for flights.
 type DurationRange struct {
 	// MinMinutes is the minimum acceptable flight duration in minutes (inclusive)
@@ -136,53 +301,131 @@ func (tr *TimeRange) Contains(t time.Time) bool {
 	return tMinutes >= startMinutes && tMinutes <= endMinutes
 }
 
+// MinutesOutside returns how many minutes the time of day of t lies outside
+// the time range: 0 within it, otherwise the distance to the nearest bound
+// on a 24-hour clock, so 23:30 is 60 minutes from a range starting at 00:30.
+func (tr *TimeRange) MinutesOutside(t time.Time) int {
+	if tr == nil || tr.Contains(t) {
+		return 0
+	}
+	tMinutes := t.Hour()*60 + t.Minute()
+	startMinutes := tr.Start.Hour()*60 + tr.Start.Minute()
+	endMinutes := tr.End.Hour()*60 + tr.End.Minute()
+
+	return min(clockDistance(tMinutes, startMinutes), clockDistance(tMinutes, endMinutes))
+}
+
+// clockDistance returns the number of minutes between two times of day,
+// going around midnight if shorter.
+func clockDistance(a, b int) int {
+	const day = 24 * 60
+	d := (a - b + day) % day
+	return min(d, day-d)
+}
+
+// Filter names, reported by RejectedBy and used to count rejections per filter.
+const (
+	FilterMinPrice       = "min_price"
+	FilterMaxPrice       = "max_price"
+	FilterMaxStops       = "max_stops"
+	FilterAirlines       = "airlines"
+	FilterDepartureTime  = "departure_time"
+	FilterArrivalTime    = "arrival_time"
+	FilterDuration       = "duration"
+	FilterWheelchair     = "wheelchair"
+	FilterFareFamily     = "fare_family"
+	FilterChangeable     = "changeable"
+	FilterRefundable     = "refundable"
+	FilterRefundableOnly = "refundable_only"
+	FilterClass          = "class"
+	FilterMinSeats       = "min_seats"
+)
+
 // MatchesFlight checks if a flight matches all the filter criteria.
 func (f *FilterOptions) MatchesFlight(flight Flight) bool {
+	return f.RejectedBy(flight) == ""
+}
+
+// RejectedBy returns the name of the first filter the flight fails, or ""
+// if it matches all the filter criteria. It is the single implementation of
+// the filters: filters are checked in the order of the Filter constants.
+func (f *FilterOptions) RejectedBy(flight Flight) string {
 	if f == nil {
-		return true
+		return ""
 	}
 
-	// Check price filter
-	if f.MaxPrice != nil && flight.Price.Amount > *f.MaxPrice {
-		return false
+	// Check price filters; prices in another currency cannot be compared
+	price := flight.Price.OnBasis(f.PriceBasis)
+	if f.MinPrice != nil {
+		if c, err := price.Compare(PriceInfo{Amount: *f.MinPrice, Currency: FilterCurrency}); err != nil || c < 0 {
+			return FilterMinPrice
+		}
+	}
+	if f.MaxPrice != nil {
+		if c, err := price.Compare(PriceInfo{Amount: *f.MaxPrice, Currency: FilterCurrency}); err != nil || c > 0 {
+			return FilterMaxPrice
+		}
 	}
 
 	// Check stops filter
 	if f.MaxStops != nil && flight.Stops > *f.MaxStops {
-		return false
+		return FilterMaxStops
 	}
 
 	// Check airline filter (case-insensitive matching)
-	if len(f.Airlines) > 0 {
-		found := false
-		flightCode := strings.ToUpper(flight.Airline.Code)
-		for _, code := range f.Airlines {
-			if strings.ToUpper(code) == flightCode {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
+	if len(f.Airlines) > 0 && !slices.ContainsFunc(f.Airlines, func(code string) bool {
+		return strings.EqualFold(code, flight.Airline.Code)
+	}) {
+		return FilterAirlines
 	}
 
 	// Check departure time range filter
 	if f.DepartureTimeRange != nil && !f.DepartureTimeRange.Contains(flight.Departure.DateTime) {
-		return false
+		return FilterDepartureTime
 	}
 
 	// Check arrival time range filter
 	if f.ArrivalTimeRange != nil && !f.ArrivalTimeRange.Contains(flight.Arrival.DateTime) {
-		return false
+		return FilterArrivalTime
 	}
 
 	// Check duration range filter
 	if f.DurationRange != nil && !f.DurationRange.Contains(flight.Duration.TotalMinutes) {
-		return false
+		return FilterDuration
 	}
 
-	return true
+	// Check wheelchair assistance filter
+	if f.SupportsWheelchair && !flight.Accessibility.Supports(ServiceWheelchair) {
+		return FilterWheelchair
+	}
+
+	// Check fare family filters; fares without a fare family have unknown conditions
+	if len(f.FareFamilies) > 0 && !flight.InFareFamily(f.FareFamilies) {
+		return FilterFareFamily
+	}
+	if f.Changeable && (flight.FareFamily == nil || !flight.FareFamily.Changeable) {
+		return FilterChangeable
+	}
+	if f.Refundable && (flight.FareFamily == nil || !flight.FareFamily.Refundable) {
+		return FilterRefundable
+	}
+
+	// Check fare rules filter; flights without fare rules have unknown conditions
+	if f.RefundableOnly && (flight.FareRules == nil || !flight.FareRules.Refundable) {
+		return FilterRefundableOnly
+	}
+
+	// Check class filter
+	if f.Class != "" && !f.matchesClass(flight.Class) {
+		return FilterClass
+	}
+
+	// Check seats filter
+	if f.MinSeatsAvailable != nil && flight.SeatsAvailable < *f.MinSeatsAvailable {
+		return FilterMinSeats
+	}
+
+	return ""
 }
 
 // ParseSortOption converts a string to a SortOption.
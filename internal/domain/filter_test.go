@@ -106,6 +106,34 @@ func TestTimeRange_Contains(t *testing.T) {
 	}
 }
 
+func TestTimeRange_MinutesOutside(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 6, 15, hour, minute, 0, 0, time.UTC)
+	}
+	morning := &TimeRange{Start: at(8, 0), End: at(12, 0)}
+	night := &TimeRange{Start: at(0, 30), End: at(5, 0)}
+
+	tests := []struct {
+		name      string
+		timeRange *TimeRange
+		testTime  time.Time
+		want      int
+	}{
+		{name: "within range", timeRange: morning, testTime: at(10, 30), want: 0},
+		{name: "at end boundary", timeRange: morning, testTime: at(12, 0), want: 0},
+		{name: "before range", timeRange: morning, testTime: at(7, 15), want: 45},
+		{name: "after range", timeRange: morning, testTime: at(14, 0), want: 120},
+		{name: "across midnight", timeRange: night, testTime: at(23, 30), want: 60},
+		{name: "nil time range", timeRange: nil, testTime: at(23, 30), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.timeRange.MinutesOutside(tt.testTime))
+		})
+	}
+}
+
 func TestFilterOptions_MatchesFlight(t *testing.T) {
 	baseFlight := Flight{
 		ID:           "test-1",
@@ -153,6 +181,16 @@ func TestFilterOptions_MatchesFlight(t *testing.T) {
 			flight: baseFlight,
 			want:   false,
 		},
+		{
+			name:   "max price filter fails for another currency",
+			filter: &FilterOptions{MaxPrice: floatPtr(2000000.0)},
+			flight: func() Flight {
+				f := baseFlight
+				f.Price = PriceInfo{Amount: 100, Currency: "USD"}
+				return f
+			}(),
+			want: false,
+		},
 		{
 			name:   "max stops filter passes when equal",
 			filter: &FilterOptions{MaxStops: intPtr(1)},
@@ -334,6 +372,122 @@ func TestFilterOptions_MatchesFlight(t *testing.T) {
 	}
 }
 
+func TestFilterOptions_RejectedBy(t *testing.T) {
+	flight := Flight{
+		Airline:   AirlineInfo{Code: "GA"},
+		Price:     PriceInfo{Amount: 1500000, Currency: "IDR"},
+		Stops:     1,
+		Departure: FlightPoint{DateTime: time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)},
+		Arrival:   FlightPoint{DateTime: time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)},
+		Duration:  DurationInfo{TotalMinutes: 120},
+	}
+	minPrice, maxPrice, maxStops, maxMinutes := float64(2000000), float64(1000000), 0, 90
+	minSeats := 5
+	morning := &TimeRange{
+		Start: time.Date(0, 1, 1, 6, 0, 0, 0, time.UTC),
+		End:   time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name   string
+		filter *FilterOptions
+		want   string
+	}{
+		{"nil filter", nil, ""},
+		{"empty filter", &FilterOptions{}, ""},
+		{"min price", &FilterOptions{MinPrice: &minPrice}, FilterMinPrice},
+		{"max price", &FilterOptions{MaxPrice: &maxPrice}, FilterMaxPrice},
+		{"max stops", &FilterOptions{MaxStops: &maxStops}, FilterMaxStops},
+		{"airlines", &FilterOptions{Airlines: []string{"jt", "id"}}, FilterAirlines},
+		{"airlines case-insensitive", &FilterOptions{Airlines: []string{"ga"}}, ""},
+		{"departure time", &FilterOptions{DepartureTimeRange: morning}, FilterDepartureTime},
+		{"arrival time", &FilterOptions{ArrivalTimeRange: morning}, FilterArrivalTime},
+		{"duration", &FilterOptions{DurationRange: &DurationRange{MaxMinutes: &maxMinutes}}, FilterDuration},
+		{"wheelchair", &FilterOptions{SupportsWheelchair: true}, FilterWheelchair},
+		{"fare family unknown", &FilterOptions{FareFamilies: []string{"Flex"}}, FilterFareFamily},
+		{"changeable unknown", &FilterOptions{Changeable: true}, FilterChangeable},
+		{"refundable unknown", &FilterOptions{Refundable: true}, FilterRefundable},
+		{"fare rules unknown", &FilterOptions{RefundableOnly: true}, FilterRefundableOnly},
+		{"min seats", &FilterOptions{MinSeatsAvailable: &minSeats}, FilterMinSeats},
+		{"zero min seats", &FilterOptions{MinSeatsAvailable: &maxStops}, ""},
+		{"first failing filter", &FilterOptions{MaxStops: &maxStops, MaxPrice: &maxPrice}, FilterMaxPrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.RejectedBy(flight))
+			assert.Equal(t, tt.want == "", tt.filter.MatchesFlight(flight))
+		})
+	}
+
+	accessible := flight
+	accessible.Accessibility = &AccessibilityInfo{Services: []SpecialService{ServiceWheelchair}}
+	assert.True(t, (&FilterOptions{SupportsWheelchair: true}).MatchesFlight(accessible))
+
+	flex := flight
+	flex.FareFamily = &FareFamily{Name: "Flex", Changeable: true}
+	assert.True(t, (&FilterOptions{FareFamilies: []string{"lite", " flex "}, Changeable: true}).MatchesFlight(flex))
+	assert.Equal(t, FilterFareFamily, (&FilterOptions{FareFamilies: []string{"Lite"}}).RejectedBy(flex))
+	assert.Equal(t, FilterRefundable, (&FilterOptions{Refundable: true}).RejectedBy(flex))
+
+	party := flight
+	party.Price = party.Price.ForPassengers(4)
+	perPerson := &FilterOptions{MaxPrice: &party.Price.Amount}
+	assert.True(t, perPerson.MatchesFlight(party))
+	assert.Equal(t, FilterMaxPrice, perPerson.WithPriceBasis(PriceBasisTotal).RejectedBy(party))
+	assert.Empty(t, perPerson.PriceBasis, "WithPriceBasis returns a copy")
+	assert.Nil(t, (*FilterOptions)(nil).WithPriceBasis(PriceBasisTotal))
+	assert.True(t, (&FilterOptions{MinPrice: &party.Price.Total, PriceBasis: PriceBasisTotal}).MatchesFlight(party))
+
+	refundable := flight
+	refundable.FareRules = &FareRules{Refundable: true}
+	assert.True(t, (&FilterOptions{RefundableOnly: true}).MatchesFlight(refundable), "no fare family needed")
+	refundable.FareRules.Refundable = false
+	assert.Equal(t, FilterRefundableOnly, (&FilterOptions{RefundableOnly: true}).RejectedBy(refundable))
+
+	seated := flight
+	seated.SeatsAvailable = 5
+	assert.True(t, (&FilterOptions{MinSeatsAvailable: &minSeats}).MatchesFlight(seated))
+}
+
+func TestFilterOptions_Class(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *FilterOptions
+		class  string
+		want   string
+	}{
+		{"same class", &FilterOptions{Class: "economy"}, "economy", ""},
+		{"case-insensitive", &FilterOptions{Class: "Business"}, "business", ""},
+		{"unknown class kept", &FilterOptions{Class: "economy"}, "", ""},
+		{"other class", &FilterOptions{Class: "economy"}, "business", FilterClass},
+		{"lower class", &FilterOptions{Class: "business"}, "premium_economy", FilterClass},
+		{"higher class included", &FilterOptions{Class: "economy", IncludeHigherClasses: true}, "first", ""},
+		{"lower class with higher included", &FilterOptions{Class: "business", IncludeHigherClasses: true}, "economy", FilterClass},
+		{"unranked class with higher included", &FilterOptions{Class: "economy", IncludeHigherClasses: true}, "suite", FilterClass},
+		{"no class filter", &FilterOptions{IncludeHigherClasses: true}, "first", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.RejectedBy(Flight{Class: tt.class}))
+		})
+	}
+}
+
+func TestFilterOptions_WithClass(t *testing.T) {
+	assert.Nil(t, (*FilterOptions)(nil).WithClass(""))
+	assert.Equal(t, &FilterOptions{Class: "economy"}, (*FilterOptions)(nil).WithClass("economy"))
+
+	maxStops := 0
+	filters := &FilterOptions{MaxStops: &maxStops, IncludeHigherClasses: true}
+	assert.Equal(t, &FilterOptions{MaxStops: &maxStops, IncludeHigherClasses: true, Class: "business"}, filters.WithClass("business"))
+	assert.Empty(t, filters.Class, "the filters are not modified")
+
+	own := &FilterOptions{Class: "first"}
+	assert.Same(t, own, own.WithClass("economy"), "an explicit class filter wins")
+}
+
 func TestDurationRange_IsValid(t *testing.T) {
 	intPtr := func(i int) *int { return &i }
 
@@ -490,3 +644,40 @@ func TestDurationRange_Contains(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeBucket_Range(t *testing.T) {
+	tests := []struct {
+		bucket     TimeBucket
+		start, end string
+	}{
+		{TimeBucketEarlyMorning, "00:00", "05:59"},
+		{TimeBucketMorning, "06:00", "11:59"},
+		{TimeBucketAfternoon, "12:00", "17:59"},
+		{TimeBucketEvening, "18:00", "20:59"},
+		{TimeBucketNight, "21:00", "23:59"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.bucket), func(t *testing.T) {
+			assert.True(t, tt.bucket.IsValid())
+			tr := tt.bucket.Range()
+			assert.Equal(t, tt.start, tr.Start.Format("15:04"))
+			assert.Equal(t, tt.end, tr.End.Format("15:04"))
+		})
+	}
+
+	// Every minute of the day is in exactly one bucket
+	for m := 0; m < 24*60; m++ {
+		at := time.Date(2025, 12, 15, m/60, m%60, 0, 0, time.UTC)
+		n := 0
+		for _, tt := range tests {
+			if tt.bucket.Range().Contains(at) {
+				n++
+			}
+		}
+		assert.Equal(t, 1, n, "minute %s", at.Format("15:04"))
+	}
+
+	assert.False(t, TimeBucket("midday").IsValid())
+	assert.Nil(t, TimeBucket("midday").Range())
+}
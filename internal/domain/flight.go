@@ -4,7 +4,11 @@ package domain
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/durationfmt"
 )
 
 // Flight represents a single flight offering from a provider.
@@ -34,20 +38,62 @@ type Flight struct {
 	// Baggage contains baggage allowance information
 	Baggage BaggageInfo `json:"baggage"`
 
+	// Aircraft is the provider's aircraft description (free text, e.g. "Boeing 737-800")
+	Aircraft string `json:"aircraft,omitempty"`
+
+	// Amenities are the provider's onboard amenities (free text, e.g. "wifi")
+	Amenities []string `json:"amenities,omitempty"`
+
 	// Class is the travel class (economy, business, first)
 	Class string `json:"class"`
 
+	// FareFamily is the airline's branded fare, when the provider exposes it
+	// (nil if unknown)
+	FareFamily *FareFamily `json:"fareFamily,omitempty"`
+
+	// FareRules are the fare's refund and change conditions, when the
+	// provider reports them (nil if unknown)
+	FareRules *FareRules `json:"fareRules,omitempty"`
+
 	// Stops is the number of stops (0 = direct flight)
 	Stops int `json:"stops"`
 
+	// SeatsAvailable is the number of seats the provider reports left at this
+	// fare (0 if not reported)
+	SeatsAvailable int `json:"seatsAvailable,omitempty"`
+
 	// Provider identifies which flight provider this result came from
 	Provider string `json:"provider"`
 
+	// Accessibility describes the special assistance available (nil if unknown)
+	Accessibility *AccessibilityInfo `json:"accessibility,omitempty"`
+
+	// Offers lists every provider offering this same flight, cheapest first,
+	// when several did (nil otherwise). The flight itself is the cheapest offer.
+	Offers []Offer `json:"offers,omitempty"`
+
+	// FareOptions lists every fare family the provider sells this flight in,
+	// cheapest first, when it sells several (nil otherwise). The flight
+	// itself is the cheapest fare.
+	FareOptions []FareOption `json:"fareOptions,omitempty"`
+
 	// RankingScore is the calculated score for sorting by "best value"
 	// Higher scores indicate better value (considers price, duration, stops)
 	RankingScore float64 `json:"rankingScore,omitempty"`
 }
 
+// Offer is one provider's offer of a flight several providers returned.
+type Offer struct {
+	// FlightID is the ID of the flight result the provider returned
+	FlightID string `json:"flightId"`
+
+	// Provider identifies the offering provider
+	Provider string `json:"provider"`
+
+	// Price is the provider's price for the flight
+	Price PriceInfo `json:"price"`
+}
+
 // AirlineInfo contains information about an airline.
 type AirlineInfo struct {
 	// Code is the IATA airline code (e.g., "GA" for Garuda Indonesia)
@@ -58,6 +104,10 @@ type AirlineInfo struct {
 
 	// Logo is an optional URL to the airline's logo image
 	Logo string `json:"logo,omitempty"`
+
+	// Alliance is the airline alliance the airline belongs to, if any
+	// (e.g., "SkyTeam")
+	Alliance string `json:"alliance,omitempty"`
 }
 
 // FlightPoint represents a point in a flight journey (departure or arrival).
@@ -68,6 +118,12 @@ type FlightPoint struct {
 	// AirportName is the full airport name (e.g., "Soekarno-Hatta International Airport")
 	AirportName string `json:"airportName,omitempty"`
 
+	// City is the served city name (e.g., "Jakarta")
+	City string `json:"city,omitempty"`
+
+	// Country is the ISO 3166-1 alpha-2 country code (e.g., "ID")
+	Country string `json:"country,omitempty"`
+
 	// Terminal is the terminal identifier (e.g., "3")
 	Terminal string `json:"terminal,omitempty"`
 
@@ -97,6 +153,20 @@ type PriceInfo struct {
 
 	// Formatted is an optional human-readable price string (e.g., "IDR 1,500,000")
 	Formatted string `json:"formatted,omitempty"`
+
+	// BaseFare, Taxes and Fees break Amount down into the fare itself, the
+	// taxes, and the other charges, when the provider reports a breakdown
+	// (all 0 otherwise)
+	BaseFare float64 `json:"baseFare,omitempty"`
+	Taxes    float64 `json:"taxes,omitempty"`
+	Fees     float64 `json:"fees,omitempty"`
+
+	// Passengers is the number of passengers Total is for (0 if not computed)
+	Passengers int `json:"passengers,omitempty"`
+
+	// Total is the price for all the passengers, Amount being the price per
+	// passenger, as providers price fares per passenger (0 if not computed)
+	Total float64 `json:"total,omitempty"`
 }
 
 // BaggageInfo contains baggage allowance information.
@@ -106,57 +176,19 @@ type BaggageInfo struct {
 
 	// CheckedKg is the checked baggage allowance in kilograms
 	CheckedKg int `json:"checkedKg"`
+
+	// Note is the provider's baggage note (free text, empty if none)
+	Note string `json:"note,omitempty"`
 }
 
 // NewDurationInfo creates a DurationInfo from total minutes and formats it.
 func NewDurationInfo(totalMinutes int) DurationInfo {
-	hours := totalMinutes / 60
-	mins := totalMinutes % 60
-
-	var formatted string
-	if hours > 0 && mins > 0 {
-		formatted = formatDuration(hours, mins)
-	} else if hours > 0 {
-		formatted = formatHoursOnly(hours)
-	} else {
-		formatted = formatMinutesOnly(mins)
-	}
-
 	return DurationInfo{
 		TotalMinutes: totalMinutes,
-		Formatted:    formatted,
+		Formatted:    durationfmt.Format(totalMinutes),
 	}
 }
 
-// formatDuration formats hours and minutes as "Xh Ym".
-func formatDuration(hours, mins int) string {
-	return intToString(hours) + "h " + intToString(mins) + "m"
-}
-
-// formatHoursOnly formats hours as "Xh".
-func formatHoursOnly(hours int) string {
-	return intToString(hours) + "h"
-}
-
-// formatMinutesOnly formats minutes as "Xm".
-func formatMinutesOnly(mins int) string {
-	return intToString(mins) + "m"
-}
-
-// intToString converts an integer to a string without importing strconv.
-func intToString(n int) string {
-	if n == 0 {
-		return "0"
-	}
-
-	var digits []byte
-	for n > 0 {
-		digits = append([]byte{byte('0' + n%10)}, digits...)
-		n /= 10
-	}
-	return string(digits)
-}
-
 // Validate checks if the flight data is valid and consistent.
 // It returns an error if:
 //   - Arrival time is not after departure time
@@ -192,8 +224,37 @@ func (f *Flight) Validate() error {
 		return fmt.Errorf("%w: Arrival.AirportCode", ErrMissingRequiredField)
 	}
 
+	// Check the flight number belongs to the airline
+	designator, ok := FlightNumberDesignator(f.FlightNumber)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidFlightNumber, f.FlightNumber)
+	}
+	if !strings.EqualFold(designator, f.Airline.Code) {
+		return fmt.Errorf("%w: flight %s, airline %s", ErrFlightNumberMismatch, f.FlightNumber, f.Airline.Code)
+	}
+
 	// Note: Duration mismatch is logged as a warning in the provider adapters
 	// but doesn't fail validation, as providers may calculate it differently
 
 	return nil
 }
+
+// flightNumberRegex matches IATA flight numbers: a two-character airline
+// designator, an optional "-" or space, 1-4 digits and an optional suffix
+// letter (e.g., "GA400", "GA-400", "QZ 7250", "3K123A").
+var flightNumberRegex = regexp.MustCompile(`^([A-Z0-9]{2})[- ]?[0-9]{1,4}[A-Z]?$`)
+
+// FlightNumberDesignator returns the airline designator of a flight number
+// (e.g., "GA" for "GA-400"), and false if the flight number is not a valid
+// IATA flight number. Matching is case-insensitive; the designator is uppercase.
+func FlightNumberDesignator(number string) (string, bool) {
+	m := flightNumberRegex.FindStringSubmatch(strings.ToUpper(number))
+	if m == nil {
+		return "", false
+	}
+	// Designators have at least one letter; "12" would be ambiguous with the number
+	if strings.IndexFunc(m[1], func(r rune) bool { return r >= 'A' && r <= 'Z' }) < 0 {
+		return "", false
+	}
+	return m[1], true
+}
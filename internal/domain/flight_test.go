@@ -65,27 +65,6 @@ func TestNewDurationInfo(t *testing.T) {
 	}
 }
 
-func TestIntToString(t *testing.T) {
-	tests := []struct {
-		name  string
-		input int
-		want  string
-	}{
-		{name: "zero", input: 0, want: "0"},
-		{name: "single digit", input: 1, want: "1"},
-		{name: "two digits", input: 10, want: "10"},
-		{name: "three digits", input: 123, want: "123"},
-		{name: "four digits", input: 9999, want: "9999"},
-		{name: "large number", input: 12345, want: "12345"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.want, intToString(tt.input))
-		})
-	}
-}
-
 func TestFlight_Validate(t *testing.T) {
 	// Base times for testing
 	departureTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
@@ -296,6 +275,26 @@ func TestFlight_Validate(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "malformed flight number - should fail",
+			flight: Flight{
+				FlightNumber: "GARUDA123",
+				Airline:      AirlineInfo{Code: "GA"},
+				Departure:    FlightPoint{AirportCode: "CGK", DateTime: departureTime},
+				Arrival:      FlightPoint{AirportCode: "DPS", DateTime: arrivalTime},
+			},
+			wantErr: ErrInvalidFlightNumber,
+		},
+		{
+			name: "flight number of another airline - should fail",
+			flight: Flight{
+				FlightNumber: "JT-650",
+				Airline:      AirlineInfo{Code: "GA"},
+				Departure:    FlightPoint{AirportCode: "CGK", DateTime: departureTime},
+				Arrival:      FlightPoint{AirportCode: "DPS", DateTime: arrivalTime},
+			},
+			wantErr: ErrFlightNumberMismatch,
+		},
 		{
 			name: "minimal valid flight - only required fields",
 			flight: Flight{
@@ -330,3 +329,30 @@ func TestFlight_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestFlightNumberDesignator(t *testing.T) {
+	tests := []struct {
+		number string
+		want   string
+		wantOK bool
+	}{
+		{number: "GA400", want: "GA", wantOK: true},
+		{number: "GA-400", want: "GA", wantOK: true},
+		{number: "qz 7250", want: "QZ", wantOK: true},
+		{number: "3K123A", want: "3K", wantOK: true},
+		{number: "ID6514", want: "ID", wantOK: true},
+		{number: "GA12345", wantOK: false},
+		{number: "GA", wantOK: false},
+		{number: "1234", wantOK: false},
+		{number: "GARUDA123", wantOK: false},
+		{number: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			got, ok := FlightNumberDesignator(tt.number)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// GroupQuoteProvider quotes fares for parties too large for a regular search.
+// Airlines price groups on request, so a quote is indicative: the final fare
+// is agreed with the airline's group desk, reached through the quote's contact.
+//
+// Implementations should:
+//   - Respect context cancellation and timeout
+//   - Return ErrNoFlightsFound if the airline does not serve the route
+//   - Only return other errors for operational failures
+type GroupQuoteProvider interface {
+	// Name returns the unique identifier for this provider, matching the name
+	// of the airline's FlightProvider.
+	Name() string
+
+	// Quote returns an indicative fare for the group described by criteria.
+	// The criteria will already be validated with ValidateGroup.
+	Quote(ctx context.Context, criteria SearchCriteria) (*GroupQuote, error)
+}
+
+// GroupQuote is an airline's indicative fare for a group.
+type GroupQuote struct {
+	// Provider is the name of the quoting provider
+	Provider string `json:"provider"`
+
+	// Airline is the quoting airline
+	Airline AirlineInfo `json:"airline"`
+
+	// Reference identifies the quote when contacting the group desk
+	Reference string `json:"reference"`
+
+	// FarePerPassenger is the indicative fare for one passenger
+	FarePerPassenger PriceInfo `json:"fare_per_passenger"`
+
+	// TotalFare is the indicative fare for the whole group
+	TotalFare PriceInfo `json:"total_fare"`
+
+	// ValidUntil is when the indicative fare expires
+	ValidUntil time.Time `json:"valid_until"`
+
+	// Contact is the group desk that confirms the fare and books the group
+	Contact GroupContact `json:"contact"`
+}
+
+// GroupContact is the follow-up contact of a group quote.
+type GroupContact struct {
+	// Email is the group desk's email address
+	Email string `json:"email"`
+
+	// Phone is the group desk's phone number (optional)
+	Phone string `json:"phone,omitempty"`
+
+	// ResponseHours is how soon the group desk answers, in hours
+	ResponseHours int `json:"response_hours"`
+}
+
+// GroupQuoteResponse is the result of a group quote request.
+type GroupQuoteResponse struct {
+	// SearchCriteria echoes the quoted criteria
+	SearchCriteria SearchCriteriaResponse `json:"search_criteria"`
+
+	// Quotes are the providers' quotes, cheapest total first
+	Quotes []GroupQuote `json:"quotes"`
+
+	// Metadata describes the providers queried
+	Metadata GroupQuoteMetadata `json:"metadata"`
+}
+
+// NewGroupQuoteResponse creates a GroupQuoteResponse, ensuring Quotes is
+// never nil.
+func NewGroupQuoteResponse(criteria *SearchCriteria, quotes []GroupQuote, metadata GroupQuoteMetadata) GroupQuoteResponse {
+	if quotes == nil {
+		quotes = []GroupQuote{}
+	}
+	return GroupQuoteResponse{
+		SearchCriteria: newSearchCriteriaResponse(criteria),
+		Quotes:         quotes,
+		Metadata:       metadata,
+	}
+}
+
+// GroupQuoteMetadata contains information about a group quote request.
+type GroupQuoteMetadata struct {
+	ProvidersQueried   int   `json:"providers_queried"`
+	ProvidersSucceeded int   `json:"providers_succeeded"`
+	ProvidersFailed    int   `json:"providers_failed"`
+	SearchTimeMs       int64 `json:"search_time_ms"`
+}
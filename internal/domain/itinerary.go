@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+)
+
+// MaxItineraries caps the combined itineraries returned for a round trip.
+const MaxItineraries = 50
+
+// Itinerary pairs an outbound flight with a return flight of a round trip.
+type Itinerary struct {
+	// OutboundFlightID is the ID of the outbound flight
+	OutboundFlightID string `json:"outbound_flight_id"`
+
+	// ReturnFlightID is the ID of the return flight
+	ReturnFlightID string `json:"return_flight_id"`
+
+	// TotalPrice is the sum of both flight prices
+	TotalPrice PriceInfo `json:"total_price"`
+
+	// TotalMinutes is the sum of both flight durations
+	TotalMinutes int `json:"total_minutes"`
+}
+
+// CombineItineraries pairs every outbound flight with every return flight
+// departing after it lands, skipping pairs priced in different currencies.
+// Itineraries are ordered by total price, then total duration, and at most
+// limit are returned (MaxItineraries if limit is not positive).
+func CombineItineraries(outbound, inbound []Flight, limit int) []Itinerary {
+	if limit <= 0 || limit > MaxItineraries {
+		limit = MaxItineraries
+	}
+
+	var itineraries []Itinerary
+	for _, out := range outbound {
+		for _, in := range inbound {
+			if out.Price.Currency != in.Price.Currency || !in.Departure.DateTime.After(out.Arrival.DateTime) {
+				continue
+			}
+			itineraries = append(itineraries, Itinerary{
+				OutboundFlightID: out.ID,
+				ReturnFlightID:   in.ID,
+				TotalPrice:       PriceInfo{Amount: out.Price.Amount + in.Price.Amount, Currency: out.Price.Currency},
+				TotalMinutes:     out.Duration.TotalMinutes + in.Duration.TotalMinutes,
+			})
+		}
+	}
+
+	slices.SortStableFunc(itineraries, func(a, b Itinerary) int {
+		return cmp.Or(
+			cmp.Compare(a.TotalPrice.Amount, b.TotalPrice.Amount),
+			cmp.Compare(a.TotalMinutes, b.TotalMinutes),
+		)
+	})
+	if len(itineraries) > limit {
+		itineraries = itineraries[:limit]
+	}
+	return itineraries
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombineItineraries(t *testing.T) {
+	day := time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)
+	flight := func(id string, departHour, minutes int, amount float64, currency string) Flight {
+		departure := day.Add(time.Duration(departHour) * time.Hour)
+		return Flight{
+			ID:        id,
+			Departure: FlightPoint{DateTime: departure},
+			Arrival:   FlightPoint{DateTime: departure.Add(time.Duration(minutes) * time.Minute)},
+			Duration:  DurationInfo{TotalMinutes: minutes},
+			Price:     PriceInfo{Amount: amount, Currency: currency},
+		}
+	}
+
+	outbound := []Flight{
+		flight("out-early", 6, 110, 1000000, "IDR"),
+		flight("out-late", 18, 110, 800000, "IDR"),
+	}
+	inbound := []Flight{
+		flight("in-noon", 12, 120, 900000, "IDR"),
+		flight("in-night", 22, 100, 900000, "IDR"),
+		flight("in-sgd", 23, 100, 60, "SGD"),
+	}
+
+	itineraries := CombineItineraries(outbound, inbound, 0)
+	assert.Equal(t, []Itinerary{
+		{OutboundFlightID: "out-late", ReturnFlightID: "in-night", TotalPrice: PriceInfo{Amount: 1700000, Currency: "IDR"}, TotalMinutes: 210},
+		{OutboundFlightID: "out-early", ReturnFlightID: "in-night", TotalPrice: PriceInfo{Amount: 1900000, Currency: "IDR"}, TotalMinutes: 210},
+		{OutboundFlightID: "out-early", ReturnFlightID: "in-noon", TotalPrice: PriceInfo{Amount: 1900000, Currency: "IDR"}, TotalMinutes: 230},
+	}, itineraries, "returns departing before the outbound lands and currency mismatches are skipped")
+
+	assert.Len(t, CombineItineraries(outbound, inbound, 1), 1)
+	assert.Empty(t, CombineItineraries(outbound, nil, 0))
+}
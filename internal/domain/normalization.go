@@ -0,0 +1,25 @@
+package domain
+
+import "context"
+
+// NormalizationObserver is told how many flights a provider response
+// contained and how many of them were rejected during normalization
+// (unparseable or failing Flight.Validate).
+type NormalizationObserver func(received, rejected int)
+
+// normalizationKey is the context key for the normalization observer.
+type normalizationKey struct{}
+
+// WithNormalizationObserver returns a copy of ctx carrying observer.
+// Provider decorators use it to learn about rejections inside adapters.
+func WithNormalizationObserver(ctx context.Context, observer NormalizationObserver) context.Context {
+	return context.WithValue(ctx, normalizationKey{}, observer)
+}
+
+// ReportNormalization notifies the observer carried by ctx, if any.
+// Provider adapters call it once per response, before filtering by criteria.
+func ReportNormalization(ctx context.Context, received, rejected int) {
+	if observer, ok := ctx.Value(normalizationKey{}).(NormalizationObserver); ok {
+		observer(received, rejected)
+	}
+}
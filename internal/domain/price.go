@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FilterCurrency is the currency of the price filters (filters.minPrice and
+// filters.maxPrice) and of the price hints passed to providers.
+const FilterCurrency = "IDR"
+
+// PriceBasis is the price the price filters compare: the price per passenger
+// or the total for all the passengers.
+type PriceBasis string
+
+const (
+	// PriceBasisPerPerson compares the price per passenger (the default)
+	PriceBasisPerPerson PriceBasis = "per_person"
+
+	// PriceBasisTotal compares the total for all the passengers
+	PriceBasisTotal PriceBasis = "total"
+)
+
+// IsValid reports whether b is a known price basis.
+func (b PriceBasis) IsValid() bool {
+	return b == PriceBasisPerPerson || b == PriceBasisTotal
+}
+
+// WithBreakdown returns p broken down into the base fare and taxes a
+// provider reports, the rest of Amount being fees. A price without a base
+// fare is not broken down.
+func (p PriceInfo) WithBreakdown(baseFare, taxes float64) PriceInfo {
+	if baseFare <= 0 {
+		return p
+	}
+	p.BaseFare = baseFare
+	p.Taxes = taxes
+	p.Fees = max(p.Amount-baseFare-taxes, 0)
+	return p
+}
+
+// ForPassengers returns p with its total for the number of passengers, p
+// being the price per passenger. Fewer than 1 passenger count as 1.
+func (p PriceInfo) ForPassengers(passengers int) PriceInfo {
+	passengers = max(passengers, 1)
+	p.Passengers = passengers
+	p.Total = p.Amount * float64(passengers)
+	return p
+}
+
+// OnBasis returns the price to compare on the basis: the total for all the
+// passengers on PriceBasisTotal, if computed (see ForPassengers), and the
+// price per passenger otherwise.
+func (p PriceInfo) OnBasis(basis PriceBasis) PriceInfo {
+	if basis == PriceBasisTotal && p.Passengers > 0 {
+		return PriceInfo{Amount: p.Total, Currency: p.Currency}
+	}
+	return p
+}
+
+// SameCurrency reports whether p and other are in the same currency.
+// Currency codes are compared case-insensitively.
+func (p PriceInfo) SameCurrency(other PriceInfo) bool {
+	return strings.EqualFold(p.Currency, other.Currency)
+}
+
+// Compare returns -1, 0 or +1 depending on whether p is cheaper than, as
+// expensive as, or pricier than other. Returns an error wrapping
+// ErrCurrencyMismatch if the prices are in different currencies.
+func (p PriceInfo) Compare(other PriceInfo) (int, error) {
+	if err := p.checkCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case p.Amount < other.Amount:
+		return -1, nil
+	case p.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Less reports whether p is cheaper than other. Returns an error wrapping
+// ErrCurrencyMismatch if the prices are in different currencies.
+func (p PriceInfo) Less(other PriceInfo) (bool, error) {
+	c, err := p.Compare(other)
+	return c < 0, err
+}
+
+// Add returns the sum of p and other, in p's currency. Returns an error
+// wrapping ErrCurrencyMismatch if the prices are in different currencies.
+func (p PriceInfo) Add(other PriceInfo) (PriceInfo, error) {
+	if err := p.checkCurrency(other); err != nil {
+		return PriceInfo{}, err
+	}
+	return PriceInfo{Amount: p.Amount + other.Amount, Currency: p.Currency}, nil
+}
+
+// Sub returns p minus other, in p's currency. Returns an error wrapping
+// ErrCurrencyMismatch if the prices are in different currencies.
+func (p PriceInfo) Sub(other PriceInfo) (PriceInfo, error) {
+	if err := p.checkCurrency(other); err != nil {
+		return PriceInfo{}, err
+	}
+	return PriceInfo{Amount: p.Amount - other.Amount, Currency: p.Currency}, nil
+}
+
+// checkCurrency returns an error wrapping ErrCurrencyMismatch if p and other
+// are in different currencies.
+func (p PriceInfo) checkCurrency(other PriceInfo) error {
+	if !p.SameCurrency(other) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, p.Currency, other.Currency)
+	}
+	return nil
+}
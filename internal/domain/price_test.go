@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceInfo_Compare(t *testing.T) {
+	idr := func(amount float64) PriceInfo { return PriceInfo{Amount: amount, Currency: "IDR"} }
+
+	tests := []struct {
+		name  string
+		a, b  PriceInfo
+		want  int
+		wantE bool
+	}{
+		{name: "cheaper", a: idr(500000), b: idr(900000), want: -1},
+		{name: "pricier", a: idr(900000), b: idr(500000), want: 1},
+		{name: "equal", a: idr(500000), b: idr(500000), want: 0},
+		{name: "case-insensitive currency", a: idr(500000), b: PriceInfo{Amount: 500000, Currency: "idr"}, want: 0},
+		{name: "currency mismatch", a: idr(500000), b: PriceInfo{Amount: 40, Currency: "USD"}, wantE: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.a.Compare(tt.b)
+			if tt.wantE {
+				assert.ErrorIs(t, err, ErrCurrencyMismatch)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			less, err := tt.a.Less(tt.b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want < 0, less)
+		})
+	}
+}
+
+func TestPriceInfo_Arithmetic(t *testing.T) {
+	a := PriceInfo{Amount: 900000, Currency: "IDR", Formatted: "IDR 900,000"}
+	b := PriceInfo{Amount: 250000, Currency: "IDR"}
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	assert.Equal(t, PriceInfo{Amount: 1150000, Currency: "IDR"}, sum, "the formatted price is dropped")
+
+	diff, err := b.Sub(a)
+	require.NoError(t, err)
+	assert.Equal(t, PriceInfo{Amount: -650000, Currency: "IDR"}, diff)
+
+	usd := PriceInfo{Amount: 40, Currency: "USD"}
+	_, err = a.Add(usd)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+	assert.ErrorContains(t, err, "IDR and USD")
+	_, err = a.Sub(usd)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestPriceInfo_ForPassengers(t *testing.T) {
+	p := PriceInfo{Amount: 500000, Currency: "IDR"}
+
+	total := p.ForPassengers(3)
+	assert.Equal(t, PriceInfo{Amount: 500000, Currency: "IDR", Passengers: 3, Total: 1500000}, total)
+	assert.Equal(t, PriceInfo{Amount: 1500000, Currency: "IDR"}, total.OnBasis(PriceBasisTotal))
+	assert.Equal(t, total, total.OnBasis(PriceBasisPerPerson))
+	assert.Equal(t, total, total.OnBasis(""))
+
+	assert.Equal(t, 1, p.ForPassengers(0).Passengers, "at least one passenger")
+	assert.Equal(t, p, p.OnBasis(PriceBasisTotal), "per passenger until the total is computed")
+}
+
+func TestPriceInfo_WithBreakdown(t *testing.T) {
+	p := PriceInfo{Amount: 1150000, Currency: "IDR"}
+
+	assert.Equal(t, PriceInfo{Amount: 1150000, Currency: "IDR", BaseFare: 980000, Taxes: 120000, Fees: 50000},
+		p.WithBreakdown(980000, 120000), "the rest of the price is fees")
+	assert.Zero(t, p.WithBreakdown(1100000, 120000).Fees, "never negative fees")
+	assert.Equal(t, p, p.WithBreakdown(0, 120000), "no breakdown without a base fare")
+}
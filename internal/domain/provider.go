@@ -48,6 +48,48 @@ type FlightProvider interface {
 	Search(ctx context.Context, criteria SearchCriteria) ([]Flight, error)
 }
 
+// HealthChecker is a FlightProvider able to check its own health more cheaply
+// than by searching, e.g. by calling a status endpoint of its API. Providers
+// that do not implement it are checked with a canary search.
+type HealthChecker interface {
+	// HealthCheck returns nil if the provider can serve searches, or the
+	// error a search would fail with otherwise.
+	HealthCheck(ctx context.Context) error
+}
+
+// ProviderSearchOptions are filtering hints a provider may apply on its side,
+// so that fewer flights cross the wire. Hints only narrow the results and
+// providers may ignore them: the search use case re-applies its filters to
+// whatever the provider returns. The cabin is already part of the criteria.
+type ProviderSearchOptions struct {
+	// MaxPrice is the highest price of interest (0 = no limit)
+	MaxPrice float64
+}
+
+// IsZero reports whether the options carry no hint.
+func (o ProviderSearchOptions) IsZero() bool {
+	return o == ProviderSearchOptions{}
+}
+
+// FilteringProvider is a FlightProvider able to filter its results on its
+// side. Providers wrapping another provider implement it by forwarding the
+// options with SearchWithOptions.
+type FilteringProvider interface {
+	FlightProvider
+
+	// SearchWithOptions is Search, narrowed by the hints the provider supports.
+	SearchWithOptions(ctx context.Context, criteria SearchCriteria, opts ProviderSearchOptions) ([]Flight, error)
+}
+
+// SearchWithOptions searches provider with the hints of opts if it is a
+// FilteringProvider, and with Search otherwise or when opts carries no hint.
+func SearchWithOptions(ctx context.Context, provider FlightProvider, criteria SearchCriteria, opts ProviderSearchOptions) ([]Flight, error) {
+	if fp, ok := provider.(FilteringProvider); ok && !opts.IsZero() {
+		return fp.SearchWithOptions(ctx, criteria, opts)
+	}
+	return provider.Search(ctx, criteria)
+}
+
 // ProviderRegistry manages the collection of available flight providers.
 // This is used by the use case layer to discover and query all registered providers.
 type ProviderRegistry interface {
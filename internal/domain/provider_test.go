@@ -171,3 +171,34 @@ func TestMockFlightProvider_Search(t *testing.T) {
 		assert.Nil(t, flights)
 	})
 }
+
+// hintedProvider is a FilteringProvider returning the hints it was given.
+type hintedProvider struct {
+	FlightProvider
+}
+
+func (hintedProvider) SearchWithOptions(_ context.Context, _ SearchCriteria, opts ProviderSearchOptions) ([]Flight, error) {
+	return []Flight{{ID: "hinted", Price: PriceInfo{Amount: opts.MaxPrice}}}, nil
+}
+
+func TestSearchWithOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	plain := NewMockFlightProvider(ctrl)
+	plain.EXPECT().Search(gomock.Any(), gomock.Any()).Return([]Flight{{ID: "plain"}}, nil).Times(2)
+	hinted := hintedProvider{FlightProvider: plain}
+	hints := ProviderSearchOptions{MaxPrice: 1500000}
+
+	flights, err := SearchWithOptions(context.Background(), plain, SearchCriteria{}, hints)
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", flights[0].ID, "providers without filtering ignore hints")
+
+	flights, err = SearchWithOptions(context.Background(), hinted, SearchCriteria{}, hints)
+	assert.NoError(t, err)
+	assert.Equal(t, "hinted", flights[0].ID)
+	assert.Equal(t, 1500000.0, flights[0].Price.Amount)
+
+	flights, err = SearchWithOptions(context.Background(), hinted, SearchCriteria{}, ProviderSearchOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", flights[0].ID, "without hints the plain search is used")
+}
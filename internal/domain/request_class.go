@@ -0,0 +1,24 @@
+package domain
+
+// RequestClass identifies the kind of caller a search is made for.
+// Each class has its own global search timeout budget.
+type RequestClass string
+
+// Available request classes.
+const (
+	// RequestClassInteractive is a user waiting on the response (default)
+	RequestClassInteractive RequestClass = "interactive"
+
+	// RequestClassBatch is a bulk or batch integration that tolerates slower responses
+	RequestClassBatch RequestClass = "batch"
+)
+
+// IsValid checks if the request class is a valid value.
+func (c RequestClass) IsValid() bool {
+	switch c {
+	case RequestClassInteractive, RequestClassBatch:
+		return true
+	default:
+		return false
+	}
+}
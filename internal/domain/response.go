@@ -10,6 +10,21 @@ type SearchResponse struct {
 
 	// Flights contains the list of flight results after filtering and sorting
 	Flights []Flight `json:"flights"`
+
+	// ReturnFlights contains the return leg results of round-trip searches,
+	// filtered and sorted like Flights
+	ReturnFlights []Flight `json:"return_flights,omitempty"`
+
+	// Trip prices the legs of a round trip together (nil for one-way searches)
+	Trip *TripSummary `json:"trip,omitempty"`
+
+	// Itineraries pairs outbound and return flights of round-trip searches,
+	// cheapest total first
+	Itineraries []Itinerary `json:"itineraries,omitempty"`
+
+	// DateSummaries summarizes the flights of each departure date of
+	// flexible-date searches, in date order
+	DateSummaries []DateSummary `json:"date_summaries,omitempty"`
 }
 
 // SearchCriteriaResponse represents the search criteria in the response.
@@ -28,6 +43,76 @@ type SearchCriteriaResponse struct {
 
 	// CabinClass is the travel class
 	CabinClass string `json:"cabin_class"`
+
+	// Return is the return leg of round-trip searches
+	Return *ReturnLegResponse `json:"return,omitempty"`
+
+	// DateFlexibility is the days searched either side of DepartureDate
+	DateFlexibility int `json:"date_flexibility,omitempty"`
+}
+
+// ReturnLegResponse represents the return leg criteria in the response.
+type ReturnLegResponse struct {
+	Origin        string `json:"origin"`
+	Destination   string `json:"destination"`
+	DepartureDate string `json:"departure_date"`
+}
+
+// TripSummary prices the legs of a round trip. Legs are searched and priced
+// independently; the total combines the cheapest flight of each leg.
+type TripSummary struct {
+	// OpenJaw is true when the return leg does not mirror the outbound route
+	OpenJaw bool `json:"open_jaw"`
+
+	// LowestOutbound is the cheapest outbound flight price (nil if none)
+	LowestOutbound *PriceInfo `json:"lowest_outbound,omitempty"`
+
+	// LowestReturn is the cheapest return flight price (nil if none)
+	LowestReturn *PriceInfo `json:"lowest_return,omitempty"`
+
+	// LowestTotal is the sum of the lowest leg prices, set only when both legs
+	// have flights and their cheapest flights share a currency
+	LowestTotal *PriceInfo `json:"lowest_total,omitempty"`
+}
+
+// NewTripSummary prices the outbound and return flights of a round trip.
+func NewTripSummary(criteria *SearchCriteria, outbound, inbound []Flight) *TripSummary {
+	trip := &TripSummary{
+		OpenJaw:        criteria.IsOpenJaw(),
+		LowestOutbound: LowestPrice(outbound),
+		LowestReturn:   LowestPrice(inbound),
+	}
+	if out, in := trip.LowestOutbound, trip.LowestReturn; out != nil && in != nil && out.Currency == in.Currency {
+		trip.LowestTotal = &PriceInfo{Amount: out.Amount + in.Amount, Currency: out.Currency}
+	}
+	return trip
+}
+
+// DateSummary summarizes the flights departing on one date of a
+// flexible-date search.
+type DateSummary struct {
+	// DepartureDate is the departure date in YYYY-MM-DD format
+	DepartureDate string `json:"departure_date"`
+
+	// LowestPrice is the cheapest flight price of the date (nil if none)
+	LowestPrice *PriceInfo `json:"lowest_price,omitempty"`
+
+	// TotalResults is the number of flights returned for the date
+	TotalResults int `json:"total_results"`
+
+	// Failed reports that the search of the date failed, so it has no flights
+	Failed bool `json:"failed,omitempty"`
+}
+
+// LowestPrice returns the price of the cheapest flight, or nil if there are none.
+func LowestPrice(flights []Flight) *PriceInfo {
+	var lowest *PriceInfo
+	for _, f := range flights {
+		if lowest == nil || f.Price.Amount < lowest.Amount {
+			lowest = &PriceInfo{Amount: f.Price.Amount, Currency: f.Price.Currency}
+		}
+	}
+	return lowest
 }
 
 // SearchMetadata contains metadata about the search execution.
@@ -49,6 +134,40 @@ type SearchMetadata struct {
 
 	// CacheHit indicates whether the results came from cache
 	CacheHit bool `json:"cache_hit"`
+
+	// SearchID identifies the stored search, if the search was recorded
+	SearchID string `json:"search_id,omitempty"`
+
+	// RejectedRoutings is the number of itineraries dropped for absurd routings
+	RejectedRoutings int `json:"rejected_routings"`
+
+	// DuplicatesMerged is the number of flights merged into the offer of
+	// another provider for the same flight
+	DuplicatesMerged int `json:"duplicates_merged"`
+
+	// FilterRejections is the number of flights each filter removed, keyed by
+	// filter name (see the Filter constants)
+	FilterRejections map[string]int `json:"filter_rejections,omitempty"`
+
+	// FailedProviders are the providers that failed or timed out
+	FailedProviders []string `json:"failed_providers,omitempty"`
+
+	// PendingProviders are the providers that had not answered when a partial
+	// response was returned at the soft deadline, or once the fan-out
+	// strategy had enough answers
+	PendingProviders []string `json:"pending_providers,omitempty"`
+
+	// SkippedProviders are the providers not queried because their p95 latency
+	// exceeded the remaining search budget
+	SkippedProviders []string `json:"skipped_providers,omitempty"`
+
+	// ContinuationToken fetches the results of the pending providers later
+	// (GET /api/v1/searches/{token}/continue)
+	ContinuationToken string `json:"continuation_token,omitempty"`
+
+	// Features are the optional behaviors active for the search (see the
+	// Feature constants), e.g. "cache" or "fan_out:all-parallel"
+	Features []string `json:"features,omitempty"`
 }
 
 // NewSearchResponse creates a new SearchResponse with the given criteria, flights, and metadata.
@@ -58,22 +177,33 @@ func NewSearchResponse(criteria *SearchCriteria, flights []Flight, metadata Sear
 	}
 	metadata.TotalResults = len(flights)
 
-	// Convert SearchCriteria to SearchCriteriaResponse
-	criteriaResp := SearchCriteriaResponse{
-		Origin:        criteria.Origin,
-		Destination:   criteria.Destination,
-		DepartureDate: criteria.DepartureDate,
-		Passengers:    criteria.Passengers,
-		CabinClass:    criteria.Class,
-	}
-
 	return SearchResponse{
-		SearchCriteria: criteriaResp,
+		SearchCriteria: newSearchCriteriaResponse(criteria),
 		Metadata:       metadata,
 		Flights:        flights,
 	}
 }
 
+// newSearchCriteriaResponse converts SearchCriteria to SearchCriteriaResponse.
+func newSearchCriteriaResponse(criteria *SearchCriteria) SearchCriteriaResponse {
+	resp := SearchCriteriaResponse{
+		Origin:          criteria.Origin,
+		Destination:     criteria.Destination,
+		DepartureDate:   criteria.DepartureDate,
+		Passengers:      criteria.Passengers,
+		CabinClass:      criteria.Class,
+		DateFlexibility: criteria.DateFlexibility,
+	}
+	if r := criteria.Return; r != nil {
+		resp.Return = &ReturnLegResponse{
+			Origin:        r.Origin,
+			Destination:   r.Destination,
+			DepartureDate: r.DepartureDate,
+		}
+	}
+	return resp
+}
+
 // ProviderResult represents the result from a single provider query.
 // This is used internally for aggregating results.
 type ProviderResult struct {
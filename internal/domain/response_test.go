@@ -88,6 +88,47 @@ func TestNewSearchResponse(t *testing.T) {
 	}
 }
 
+func TestNewSearchResponse_ReturnLeg(t *testing.T) {
+	criteria := &SearchCriteria{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: "2025-12-15",
+		Return:        &ReturnLeg{Origin: "SUB", Destination: "CGK", DepartureDate: "2025-12-20"},
+	}
+	response := NewSearchResponse(criteria, nil, SearchMetadata{})
+	assert.Equal(t, &ReturnLegResponse{Origin: "SUB", Destination: "CGK", DepartureDate: "2025-12-20"}, response.SearchCriteria.Return)
+
+	criteria.Return = nil
+	response = NewSearchResponse(criteria, nil, SearchMetadata{})
+	assert.Nil(t, response.SearchCriteria.Return)
+}
+
+func TestNewTripSummary(t *testing.T) {
+	criteria := &SearchCriteria{
+		Origin:      "CGK",
+		Destination: "DPS",
+		Return:      &ReturnLeg{Origin: "SUB", Destination: "CGK"},
+	}
+	priced := func(amount float64, currency string) Flight {
+		return Flight{Price: PriceInfo{Amount: amount, Currency: currency}}
+	}
+
+	trip := NewTripSummary(criteria,
+		[]Flight{priced(1500000, "IDR"), priced(1200000, "IDR")},
+		[]Flight{priced(800000, "IDR"), priced(950000, "IDR")})
+	assert.True(t, trip.OpenJaw)
+	assert.Equal(t, &PriceInfo{Amount: 1200000, Currency: "IDR"}, trip.LowestOutbound)
+	assert.Equal(t, &PriceInfo{Amount: 800000, Currency: "IDR"}, trip.LowestReturn)
+	assert.Equal(t, &PriceInfo{Amount: 2000000, Currency: "IDR"}, trip.LowestTotal)
+
+	trip = NewTripSummary(criteria, []Flight{priced(1200000, "IDR")}, []Flight{priced(60, "SGD")})
+	assert.Nil(t, trip.LowestTotal, "currencies differ")
+
+	trip = NewTripSummary(criteria, []Flight{priced(1200000, "IDR")}, nil)
+	assert.Nil(t, trip.LowestReturn)
+	assert.Nil(t, trip.LowestTotal, "no return flights")
+}
+
 func TestProviderResult_IsSuccess(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StopLimit caps the number of stops on routes whose nonstop flight time is
+// at most UpTo.
+type StopLimit struct {
+	UpTo     time.Duration
+	MaxStops int
+}
+
+// RoutingPolicy describes which itineraries are absurd for their route and
+// must be rejected from search results. The zero value rejects nothing.
+type RoutingPolicy struct {
+	// MaxDurationFactor rejects itineraries taking longer than this multiple
+	// of the route's nonstop flight time (0 = no limit)
+	MaxDurationFactor float64
+
+	// StopLimits cap the stops by nonstop flight time, sorted by UpTo.
+	// Routes longer than the last limit allow any number of stops.
+	StopLimits []StopLimit
+}
+
+// Enabled reports whether the policy rejects anything.
+func (p RoutingPolicy) Enabled() bool {
+	return p.MaxDurationFactor > 0 || len(p.StopLimits) > 0
+}
+
+// MaxStops returns the maximum number of stops for a route with the given
+// nonstop flight time, and false if the number is not limited.
+func (p RoutingPolicy) MaxStops(nonstop time.Duration) (int, bool) {
+	for _, limit := range p.StopLimits {
+		if nonstop <= limit.UpTo {
+			return limit.MaxStops, true
+		}
+	}
+	return 0, false
+}
+
+// ParseStopLimits parses "2h=1,6h=2" into stop limits: routes up to 2h
+// nonstop allow 1 stop, routes up to 6h allow 2. "none" disables the limits.
+func ParseStopLimits(s string) ([]StopLimit, error) {
+	if strings.TrimSpace(s) == "none" {
+		return nil, nil
+	}
+
+	var limits []StopLimit
+	for _, entry := range strings.Split(s, ",") {
+		upTo, stops, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid stop limit %q: want DURATION=STOPS", entry)
+		}
+		d, err := time.ParseDuration(upTo)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid stop limit %q: duration must be positive", entry)
+		}
+		n, err := strconv.Atoi(stops)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid stop limit %q: stops must be a non-negative integer", entry)
+		}
+		limits = append(limits, StopLimit{UpTo: d, MaxStops: n})
+	}
+	sort.Slice(limits, func(i, j int) bool { return limits[i].UpTo < limits[j].UpTo })
+	return limits, nil
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStopLimits(t *testing.T) {
+	limits, err := ParseStopLimits("6h=2, 2h=1")
+	require.NoError(t, err)
+	assert.Equal(t, []StopLimit{{UpTo: 2 * time.Hour, MaxStops: 1}, {UpTo: 6 * time.Hour, MaxStops: 2}}, limits)
+
+	limits, err = ParseStopLimits("none")
+	require.NoError(t, err)
+	assert.Nil(t, limits)
+
+	for _, invalid := range []string{"2h", "x=1", "0s=1", "2h=-1", "2h=one", ""} {
+		_, err := ParseStopLimits(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRoutingPolicy_MaxStops(t *testing.T) {
+	policy := RoutingPolicy{StopLimits: []StopLimit{{UpTo: 2 * time.Hour, MaxStops: 1}, {UpTo: 6 * time.Hour, MaxStops: 2}}}
+
+	stops, ok := policy.MaxStops(time.Hour)
+	assert.True(t, ok)
+	assert.Equal(t, 1, stops)
+
+	stops, ok = policy.MaxStops(2 * time.Hour)
+	assert.True(t, ok)
+	assert.Equal(t, 1, stops)
+
+	stops, ok = policy.MaxStops(5 * time.Hour)
+	assert.True(t, ok)
+	assert.Equal(t, 2, stops)
+
+	_, ok = policy.MaxStops(12 * time.Hour)
+	assert.False(t, ok)
+
+	assert.True(t, policy.Enabled())
+	assert.False(t, RoutingPolicy{}.Enabled())
+}
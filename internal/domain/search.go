@@ -22,6 +22,96 @@ type SearchCriteria struct {
 
 	// Class is the travel class: economy, business, or first (default: economy)
 	Class string `json:"class,omitempty"`
+
+	// Return is the return leg of a round trip (nil = one way)
+	Return *ReturnLeg `json:"return,omitempty"`
+
+	// DateFlexibility also searches departures up to this many days before
+	// and after DepartureDate (0 = DepartureDate only). One way only.
+	DateFlexibility int `json:"dateFlexibility,omitempty"`
+}
+
+// ReturnLeg defines the return leg of a round trip. Its origin may differ from
+// the outbound destination and its destination from the outbound origin
+// (open jaw), e.g. CGK→DPS out and SUB→CGK back.
+type ReturnLeg struct {
+	// Origin is the IATA code of the return departure airport
+	Origin string `json:"origin"`
+
+	// Destination is the IATA code of the return arrival airport
+	Destination string `json:"destination"`
+
+	// DepartureDate is the return departure date in YYYY-MM-DD format
+	DepartureDate string `json:"departureDate"`
+}
+
+// IsOpenJaw reports whether the return leg does not mirror the outbound route.
+func (s *SearchCriteria) IsOpenJaw() bool {
+	return s.Return != nil && (s.Return.Origin != s.Destination || s.Return.Destination != s.Origin)
+}
+
+// ReturnOn returns a return leg flying the outbound route back on date.
+func (s *SearchCriteria) ReturnOn(date string) *ReturnLeg {
+	return &ReturnLeg{Origin: s.Destination, Destination: s.Origin, DepartureDate: date}
+}
+
+// Outbound returns the one-way criteria of the outbound leg.
+func (s SearchCriteria) Outbound() SearchCriteria {
+	s.Return = nil
+	return s
+}
+
+// Inbound returns the one-way criteria of the return leg, searched with the
+// same passengers and class as the outbound leg. It panics without a return leg.
+func (s SearchCriteria) Inbound() SearchCriteria {
+	return SearchCriteria{
+		Origin:        s.Return.Origin,
+		Destination:   s.Return.Destination,
+		DepartureDate: s.Return.DepartureDate,
+		Passengers:    s.Passengers,
+		Class:         s.Class,
+	}
+}
+
+// MaxDateFlexibility is the most days a flexible-date search may move the
+// departure date either way.
+const MaxDateFlexibility = 3
+
+// FlexibleDates returns the departure dates of a flexible-date search in
+// order, from DateFlexibility days before DepartureDate to as many days after
+// it. It returns DepartureDate alone if the search is not flexible or the
+// date is not valid.
+func (s *SearchCriteria) FlexibleDates() []string {
+	departure, err := time.Parse("2006-01-02", s.DepartureDate)
+	if s.DateFlexibility <= 0 || err != nil {
+		return []string{s.DepartureDate}
+	}
+	dates := make([]string, 0, 2*s.DateFlexibility+1)
+	for days := -s.DateFlexibility; days <= s.DateFlexibility; days++ {
+		dates = append(dates, departure.AddDate(0, 0, days).Format("2006-01-02"))
+	}
+	return dates
+}
+
+// OnDate returns the criteria of the search departing on date, without date
+// flexibility.
+func (s SearchCriteria) OnDate(date string) SearchCriteria {
+	s.DepartureDate = date
+	s.DateFlexibility = 0
+	return s
+}
+
+// Passenger limits. Searches take up to MaxPassengers; larger parties, up to
+// MaxGroupPassengers, are quoted as groups.
+const (
+	MaxPassengers      = 9
+	MaxGroupPassengers = 99
+)
+
+// IsGroup reports whether the party is too large for a regular search and
+// needs a group quote.
+func (s *SearchCriteria) IsGroup() bool {
+	return s.Passengers > MaxPassengers
 }
 
 // airportCodeRegex matches valid IATA airport codes (3 uppercase letters).
@@ -40,6 +130,25 @@ var validClasses = map[string]bool{
 // Validate checks if the search criteria is valid.
 // Returns a wrapped ErrInvalidRequest error if validation fails.
 func (s *SearchCriteria) Validate() error {
+	return s.validate(1, MaxPassengers)
+}
+
+// ValidateGroup checks if the criteria are valid for a group quote: a one-way
+// trip for more than MaxPassengers passengers.
+// Returns a wrapped ErrInvalidRequest error if validation fails.
+func (s *SearchCriteria) ValidateGroup() error {
+	if s.Return != nil {
+		return fmt.Errorf("%w: group quotes do not support return legs", ErrInvalidRequest)
+	}
+	if s.DateFlexibility > 0 {
+		return fmt.Errorf("%w: group quotes do not support dateFlexibility", ErrInvalidRequest)
+	}
+	return s.validate(MaxPassengers+1, MaxGroupPassengers)
+}
+
+// validate checks the criteria, accepting between minPassengers and
+// maxPassengers passengers.
+func (s *SearchCriteria) validate(minPassengers, maxPassengers int) error {
 	// Validate origin
 	if s.Origin == "" {
 		return fmt.Errorf("%w: origin is required", ErrInvalidRequest)
@@ -70,17 +179,32 @@ func (s *SearchCriteria) Validate() error {
 	}
 
 	// Parse and validate the date is valid
-	_, err := time.Parse("2006-01-02", s.DepartureDate)
+	departure, err := time.Parse("2006-01-02", s.DepartureDate)
 	if err != nil {
 		return fmt.Errorf("%w: departureDate is not a valid date: %s", ErrInvalidRequest, s.DepartureDate)
 	}
 
+	// Validate the return leg (if provided)
+	if s.Return != nil {
+		if err := s.Return.validate(departure); err != nil {
+			return err
+		}
+	}
+
+	// Validate date flexibility, which one-way searches only support
+	if s.DateFlexibility < 0 || s.DateFlexibility > MaxDateFlexibility {
+		return fmt.Errorf("%w: dateFlexibility must be between 0 and %d", ErrInvalidRequest, MaxDateFlexibility)
+	}
+	if s.DateFlexibility > 0 && s.Return != nil {
+		return fmt.Errorf("%w: dateFlexibility cannot be combined with a return leg", ErrInvalidRequest)
+	}
+
 	// Validate passengers
-	if s.Passengers < 1 {
-		return fmt.Errorf("%w: passengers must be at least 1", ErrInvalidRequest)
+	if s.Passengers < minPassengers {
+		return fmt.Errorf("%w: passengers must be at least %d", ErrInvalidRequest, minPassengers)
 	}
-	if s.Passengers > 9 {
-		return fmt.Errorf("%w: passengers cannot exceed 9", ErrInvalidRequest)
+	if s.Passengers > maxPassengers {
+		return fmt.Errorf("%w: passengers cannot exceed %d", ErrInvalidRequest, maxPassengers)
 	}
 
 	// Validate class (if provided)
@@ -91,6 +215,30 @@ func (s *SearchCriteria) Validate() error {
 	return nil
 }
 
+// validate checks the return leg, which may not depart before the outbound leg.
+func (r *ReturnLeg) validate(outboundDate time.Time) error {
+	if !airportCodeRegex.MatchString(r.Origin) {
+		return fmt.Errorf("%w: return origin must be a valid 3-letter IATA code, got %q", ErrInvalidRequest, r.Origin)
+	}
+	if !airportCodeRegex.MatchString(r.Destination) {
+		return fmt.Errorf("%w: return destination must be a valid 3-letter IATA code, got %q", ErrInvalidRequest, r.Destination)
+	}
+	if r.Origin == r.Destination {
+		return fmt.Errorf("%w: return origin and destination must be different", ErrInvalidRequest)
+	}
+	if !dateRegex.MatchString(r.DepartureDate) {
+		return fmt.Errorf("%w: return departureDate must be in YYYY-MM-DD format, got %q", ErrInvalidRequest, r.DepartureDate)
+	}
+	date, err := time.Parse("2006-01-02", r.DepartureDate)
+	if err != nil {
+		return fmt.Errorf("%w: return departureDate is not a valid date: %s", ErrInvalidRequest, r.DepartureDate)
+	}
+	if date.Before(outboundDate) {
+		return fmt.Errorf("%w: return departureDate must not be before departureDate", ErrInvalidRequest)
+	}
+	return nil
+}
+
 // SetDefaults applies default values to empty optional fields.
 func (s *SearchCriteria) SetDefaults() {
 	if s.Passengers == 0 {
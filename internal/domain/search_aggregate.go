@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// SearchAggregate summarizes the searches for one route on one day.
+// Stores roll expired search records up into aggregates so long-term
+// statistics survive while individual records are discarded.
+type SearchAggregate struct {
+	// Date is the UTC day the searches were executed (YYYY-MM-DD)
+	Date string `json:"date"`
+
+	Origin      string `json:"origin"`
+	Destination string `json:"destination"`
+
+	// Searches is the number of searches rolled up
+	Searches int `json:"searches"`
+
+	// EmptySearches is the number of searches that returned no flights
+	EmptySearches int `json:"emptySearches"`
+
+	// TotalResults is the number of flights returned across all searches
+	TotalResults int `json:"totalResults"`
+
+	// MinPrice and MaxPrice are the lowest and highest flight prices returned (0 if none)
+	MinPrice float64 `json:"minPrice"`
+	MaxPrice float64 `json:"maxPrice"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+// AggregateKey identifies the aggregate a record rolls up into.
+type AggregateKey struct {
+	Date        string
+	Origin      string
+	Destination string
+}
+
+// AggregateKeyFor returns the aggregate key for a record.
+func AggregateKeyFor(record SearchRecord) AggregateKey {
+	return AggregateKey{
+		Date:        record.CreatedAt.UTC().Format("2006-01-02"),
+		Origin:      record.Criteria.Origin,
+		Destination: record.Criteria.Destination,
+	}
+}
+
+// Add rolls a search record into the aggregate.
+func (a *SearchAggregate) Add(record SearchRecord) {
+	if a.Searches == 0 {
+		key := AggregateKeyFor(record)
+		a.Date, a.Origin, a.Destination = key.Date, key.Origin, key.Destination
+	}
+
+	a.Searches++
+	flights := record.Response.Flights
+	if len(flights) == 0 {
+		a.EmptySearches++
+		return
+	}
+	a.TotalResults += len(flights)
+
+	for _, f := range flights {
+		if a.Currency == "" {
+			a.Currency = f.Price.Currency
+		}
+		if a.MinPrice == 0 || f.Price.Amount < a.MinPrice {
+			a.MinPrice = f.Price.Amount
+		}
+		if f.Price.Amount > a.MaxPrice {
+			a.MaxPrice = f.Price.Amount
+		}
+	}
+}
+
+// Merge adds the counts of another aggregate for the same key.
+func (a *SearchAggregate) Merge(other SearchAggregate) {
+	if other.Searches == 0 {
+		return
+	}
+	if a.Searches == 0 {
+		*a = other
+		return
+	}
+
+	a.Searches += other.Searches
+	a.EmptySearches += other.EmptySearches
+	a.TotalResults += other.TotalResults
+	if a.Currency == "" {
+		a.Currency = other.Currency
+	}
+	if other.MinPrice > 0 && (a.MinPrice == 0 || other.MinPrice < a.MinPrice) {
+		a.MinPrice = other.MinPrice
+	}
+	if other.MaxPrice > a.MaxPrice {
+		a.MaxPrice = other.MaxPrice
+	}
+}
+
+// AverageResults returns the mean number of flights per search.
+func (a SearchAggregate) AverageResults() float64 {
+	if a.Searches == 0 {
+		return 0
+	}
+	return float64(a.TotalResults) / float64(a.Searches)
+}
+
+// CompactionStats reports the outcome of a compaction run.
+type CompactionStats struct {
+	// RecordsCompacted is the number of records rolled up and removed
+	RecordsCompacted int
+
+	// AggregatesExpired is the number of aggregates removed by retention
+	AggregatesExpired int
+}
+
+// SearchHistoryCompactor is implemented by search record stores that support
+// retention. Compact rolls records created before recordsBefore into daily
+// aggregates and removes them, then removes aggregates for days before
+// aggregatesBefore.
+type SearchHistoryCompactor interface {
+	Compact(ctx context.Context, recordsBefore, aggregatesBefore time.Time) (CompactionStats, error)
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchAggregate_Add(t *testing.T) {
+	// 06:30 in Jakarta is still the previous day in UTC
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	criteria := SearchCriteria{Origin: "CGK", Destination: "DPS"}
+
+	var agg SearchAggregate
+	agg.Add(SearchRecord{
+		Criteria:  criteria,
+		CreatedAt: time.Date(2025, 12, 2, 6, 30, 0, 0, jakarta),
+		Response: SearchResponse{Flights: []Flight{
+			{Price: PriceInfo{Amount: 1200000, Currency: "IDR"}},
+			{Price: PriceInfo{Amount: 950000, Currency: "IDR"}},
+		}},
+	})
+	agg.Add(SearchRecord{Criteria: criteria, CreatedAt: time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)})
+
+	assert.Equal(t, SearchAggregate{
+		Date:          "2025-12-01",
+		Origin:        "CGK",
+		Destination:   "DPS",
+		Searches:      2,
+		EmptySearches: 1,
+		TotalResults:  2,
+		MinPrice:      950000,
+		MaxPrice:      1200000,
+		Currency:      "IDR",
+	}, agg)
+	assert.Equal(t, 1.0, agg.AverageResults())
+	assert.Zero(t, SearchAggregate{}.AverageResults())
+}
+
+func TestSearchAggregate_Merge(t *testing.T) {
+	a := SearchAggregate{Date: "2025-12-01", Origin: "CGK", Destination: "DPS", Searches: 2, EmptySearches: 1, TotalResults: 3, MinPrice: 900000, MaxPrice: 1100000, Currency: "IDR"}
+	b := SearchAggregate{Date: "2025-12-01", Origin: "CGK", Destination: "DPS", Searches: 1, TotalResults: 2, MinPrice: 800000, MaxPrice: 1000000, Currency: "IDR"}
+
+	merged := a
+	merged.Merge(b)
+	assert.Equal(t, SearchAggregate{
+		Date: "2025-12-01", Origin: "CGK", Destination: "DPS",
+		Searches: 3, EmptySearches: 1, TotalResults: 5,
+		MinPrice: 800000, MaxPrice: 1100000, Currency: "IDR",
+	}, merged)
+
+	var empty SearchAggregate
+	empty.Merge(a)
+	assert.Equal(t, a, empty)
+
+	merged.Merge(SearchAggregate{})
+	assert.Equal(t, 3, merged.Searches)
+
+	// Aggregates of only empty searches carry no prices
+	merged.Merge(SearchAggregate{Searches: 1, EmptySearches: 1})
+	assert.Equal(t, 800000.0, merged.MinPrice)
+}
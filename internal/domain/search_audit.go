@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Search audit outcomes.
+const (
+	// AuditOutcomeSuccess is the outcome of a search that returned results,
+	// possibly partial
+	AuditOutcomeSuccess = "success"
+
+	// AuditOutcomeError is the outcome of a search that failed
+	AuditOutcomeError = "error"
+)
+
+// SearchAuditEntry describes a search for the search audit log: who searched
+// for what, how long it took and how the providers fared. Unlike
+// SearchRecord, it is written for failed searches too, and holds no results.
+type SearchAuditEntry struct {
+	// At is when the search started
+	At time.Time `json:"at"`
+
+	// Client is the principal that searched: the API key or bearer token
+	// subject, or "anonymous"
+	Client string `json:"client"`
+
+	// TraceID is the trace the search request belongs to, if any
+	TraceID string `json:"traceId,omitempty"`
+
+	// SearchID identifies the stored search, if the search was recorded
+	SearchID string `json:"searchId,omitempty"`
+
+	// Criteria and Filters are the search parameters
+	Criteria SearchCriteria `json:"criteria"`
+	Filters  *FilterOptions `json:"filters,omitempty"`
+
+	// SortBy is the requested sort option
+	SortBy SortOption `json:"sortBy,omitempty"`
+
+	// Outcome is AuditOutcomeSuccess or AuditOutcomeError, with the error in Error
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+
+	// LatencyMs is how long the search took, in milliseconds
+	LatencyMs int64 `json:"latencyMs"`
+
+	// Results is the number of flights returned
+	Results int `json:"results"`
+
+	// CacheHit reports whether the results came from the cache
+	CacheHit bool `json:"cacheHit,omitempty"`
+
+	// ProvidersQueried and ProvidersSucceeded count the providers queried and
+	// those that answered
+	ProvidersQueried   int `json:"providersQueried"`
+	ProvidersSucceeded int `json:"providersSucceeded"`
+
+	// FailedProviders, PendingProviders and SkippedProviders name the
+	// providers that failed, had not answered by the soft deadline, or were
+	// not queried for lack of time
+	FailedProviders  []string `json:"failedProviders,omitempty"`
+	PendingProviders []string `json:"pendingProviders,omitempty"`
+	SkippedProviders []string `json:"skippedProviders,omitempty"`
+}
+
+// AuditLogger records search audit entries. Log is called on the search
+// path and must not block it: implementations write entries asynchronously,
+// and may drop them when they cannot keep up.
+type AuditLogger interface {
+	Log(ctx context.Context, entry SearchAuditEntry)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// SearchCache stores what the providers returned for a search, so repeated
+// searches with the same criteria are answered without querying them again.
+// Entries are keyed on ProviderSearchHash: filters, sorting and limits are
+// applied to cached results like to live ones.
+type SearchCache interface {
+	// Get returns the entry stored under key; ok is false on a miss
+	Get(ctx context.Context, key string) (entry *CachedSearch, ok bool, err error)
+
+	// Set stores entry under key, expiring after ttl
+	Set(ctx context.Context, key string, entry *CachedSearch, ttl time.Duration) error
+}
+
+// CachedSearch is the cached outcome of a search every provider answered.
+type CachedSearch struct {
+	// Flights are the flights returned by all the providers
+	Flights []Flight `json:"flights"`
+
+	// Providers is the number of providers queried, all of which succeeded
+	Providers int `json:"providers"`
+
+	// CachedAt is when the providers were queried
+	CachedAt time.Time `json:"cached_at"`
+}
@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// searchKeyVersion prefixes canonical forms so the format can evolve without
+// colliding with keys persisted by an older version.
+const searchKeyVersion = "v1"
+
+// CanonicalCriteria returns a stable serialization of the search criteria.
+// Equivalent criteria produce identical output: airport codes are upper-cased,
+// the class is lower-cased, and unset passengers/class take their defaults.
+func CanonicalCriteria(criteria SearchCriteria) string {
+	var b strings.Builder
+	writeCriteria(&b, criteria)
+	return b.String()
+}
+
+// CanonicalSearch returns a stable serialization of the criteria and filters.
+// A nil filter and an empty filter are equivalent, as are nil and empty airline
+// lists; airline codes are upper-cased, de-duplicated and sorted.
+func CanonicalSearch(criteria SearchCriteria, filters *FilterOptions) string {
+	var b strings.Builder
+	writeCriteria(&b, criteria)
+	writeFilters(&b, filters)
+	return b.String()
+}
+
+// CriteriaHash returns the hex SHA-256 of CanonicalCriteria.
+// Use it for keys that only depend on what providers are asked (e.g., request coalescing).
+func CriteriaHash(criteria SearchCriteria) string {
+	return hashString(CanonicalCriteria(criteria))
+}
+
+// ProviderSearchHash returns the hex SHA-256 of the canonical criteria and
+// provider filtering hints: the key of what providers are asked when they may
+// filter on their side. Without hints, it equals CriteriaHash.
+func ProviderSearchHash(criteria SearchCriteria, opts ProviderSearchOptions) string {
+	var b strings.Builder
+	writeCriteria(&b, criteria)
+	if opts.MaxPrice > 0 {
+		writeField(&b, "hint_max_price", strconv.FormatFloat(opts.MaxPrice, 'f', -1, 64))
+	}
+	return hashString(b.String())
+}
+
+// SearchHash returns the hex SHA-256 of CanonicalSearch.
+// All subsystems keying on a search (cache, idempotency, history) must use it so keys are shared.
+func SearchHash(criteria SearchCriteria, filters *FilterOptions) string {
+	return hashString(CanonicalSearch(criteria, filters))
+}
+
+// writeCriteria appends the canonical criteria fields.
+func writeCriteria(b *strings.Builder, c SearchCriteria) {
+	passengers := c.Passengers
+	if passengers == 0 {
+		passengers = 1
+	}
+	class := strings.ToLower(strings.TrimSpace(c.Class))
+	if class == "" {
+		class = "economy"
+	}
+
+	b.WriteString(searchKeyVersion)
+	writeField(b, "origin", strings.ToUpper(strings.TrimSpace(c.Origin)))
+	writeField(b, "destination", strings.ToUpper(strings.TrimSpace(c.Destination)))
+	writeField(b, "date", strings.TrimSpace(c.DepartureDate))
+	writeField(b, "passengers", strconv.Itoa(passengers))
+	writeField(b, "class", class)
+	if r := c.Return; r != nil {
+		writeField(b, "return_origin", strings.ToUpper(strings.TrimSpace(r.Origin)))
+		writeField(b, "return_destination", strings.ToUpper(strings.TrimSpace(r.Destination)))
+		writeField(b, "return_date", strings.TrimSpace(r.DepartureDate))
+	}
+	if c.DateFlexibility > 0 {
+		writeField(b, "date_flexibility", strconv.Itoa(c.DateFlexibility))
+	}
+}
+
+// writeFilters appends the canonical filter fields. Unset filters are omitted.
+func writeFilters(b *strings.Builder, f *FilterOptions) {
+	if f == nil {
+		return
+	}
+
+	if f.MinPrice != nil {
+		writeField(b, "min_price", strconv.FormatFloat(*f.MinPrice, 'f', -1, 64))
+	}
+	if f.MaxPrice != nil {
+		writeField(b, "max_price", strconv.FormatFloat(*f.MaxPrice, 'f', -1, 64))
+	}
+	if f.PriceBasis == PriceBasisTotal {
+		writeField(b, "price_basis", string(f.PriceBasis))
+	}
+	if f.MaxStops != nil {
+		writeField(b, "max_stops", strconv.Itoa(*f.MaxStops))
+	}
+	if airlines := canonicalAirlines(f.Airlines); airlines != "" {
+		writeField(b, "airlines", airlines)
+	}
+	if f.DepartureTimeRange != nil {
+		writeField(b, "departure_time", canonicalTimeRange(f.DepartureTimeRange))
+	}
+	if f.ArrivalTimeRange != nil {
+		writeField(b, "arrival_time", canonicalTimeRange(f.ArrivalTimeRange))
+	}
+	if dr := f.DurationRange; dr != nil && (dr.MinMinutes != nil || dr.MaxMinutes != nil) {
+		writeField(b, "duration", optionalInt(dr.MinMinutes)+"-"+optionalInt(dr.MaxMinutes))
+	}
+	if f.SupportsWheelchair {
+		writeField(b, "supports_wheelchair", "true")
+	}
+	if families := canonicalFareFamilies(f.FareFamilies); families != "" {
+		writeField(b, "fare_families", families)
+	}
+	if f.Changeable {
+		writeField(b, "changeable", "true")
+	}
+	if f.Refundable {
+		writeField(b, "refundable", "true")
+	}
+	if f.RefundableOnly {
+		writeField(b, "refundable_only", "true")
+	}
+	if f.Class != "" {
+		writeField(b, "filter_class", strings.ToLower(f.Class))
+	}
+	if f.IncludeHigherClasses {
+		writeField(b, "include_higher_classes", "true")
+	}
+	if f.MinSeatsAvailable != nil {
+		writeField(b, "min_seats", strconv.Itoa(*f.MinSeatsAvailable))
+	}
+}
+
+// canonicalAirlines upper-cases, de-duplicates and sorts airline codes.
+func canonicalAirlines(airlines []string) string {
+	seen := make(map[string]struct{}, len(airlines))
+	codes := make([]string, 0, len(airlines))
+	for _, a := range airlines {
+		code := strings.ToUpper(strings.TrimSpace(a))
+		if code == "" {
+			continue
+		}
+		if _, ok := seen[code]; ok {
+			continue
+		}
+		seen[code] = struct{}{}
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return strings.Join(codes, ",")
+}
+
+// canonicalFareFamilies lower-cases, de-duplicates and sorts fare family names.
+func canonicalFareFamilies(families []string) string {
+	names := make([]string, 0, len(families))
+	for _, f := range families {
+		if name := strings.ToLower(strings.TrimSpace(f)); name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// canonicalTimeRange formats a time-of-day range as "HH:MM-HH:MM";
+// only the time of day is significant when matching flights.
+func canonicalTimeRange(tr *TimeRange) string {
+	return tr.Start.Format("15:04") + "-" + tr.End.Format("15:04")
+}
+
+// optionalInt formats an optional bound, using an empty string when unset.
+func optionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+// writeField appends a "|name=value" pair.
+func writeField(b *strings.Builder, name, value string) {
+	b.WriteByte('|')
+	b.WriteString(name)
+	b.WriteByte('=')
+	b.WriteString(value)
+}
+
+// hashString returns the hex-encoded SHA-256 of s.
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func baseKeyCriteria() SearchCriteria {
+	return SearchCriteria{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: "2025-12-15",
+		Passengers:    1,
+		Class:         "economy",
+	}
+}
+
+func TestCanonicalCriteria(t *testing.T) {
+	assert.Equal(t,
+		"v1|origin=CGK|destination=DPS|date=2025-12-15|passengers=1|class=economy",
+		CanonicalCriteria(baseKeyCriteria()))
+}
+
+func TestCanonicalCriteria_ReturnLeg(t *testing.T) {
+	c := baseKeyCriteria()
+	c.Return = &ReturnLeg{Origin: "sub", Destination: "CGK", DepartureDate: "2025-12-20"}
+	assert.Equal(t,
+		"v1|origin=CGK|destination=DPS|date=2025-12-15|passengers=1|class=economy|return_origin=SUB|return_destination=CGK|return_date=2025-12-20",
+		CanonicalCriteria(c))
+}
+
+func TestCriteriaHash_EquivalentCriteria(t *testing.T) {
+	want := CriteriaHash(baseKeyCriteria())
+
+	tests := []struct {
+		name     string
+		criteria SearchCriteria
+	}{
+		{"lowercase airports", SearchCriteria{Origin: "cgk", Destination: "dps", DepartureDate: "2025-12-15", Passengers: 1, Class: "economy"}},
+		{"uppercase class", SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1, Class: "ECONOMY"}},
+		{"defaults unset", SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15"}},
+		{"surrounding whitespace", SearchCriteria{Origin: " CGK", Destination: "DPS ", DepartureDate: "2025-12-15", Passengers: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, want, CriteriaHash(tt.criteria))
+		})
+	}
+}
+
+func TestProviderSearchHash(t *testing.T) {
+	criteria := baseKeyCriteria()
+
+	assert.Equal(t, CriteriaHash(criteria), ProviderSearchHash(criteria, ProviderSearchOptions{}))
+	assert.NotEqual(t, CriteriaHash(criteria), ProviderSearchHash(criteria, ProviderSearchOptions{MaxPrice: 1500000}))
+	assert.NotEqual(t,
+		ProviderSearchHash(criteria, ProviderSearchOptions{MaxPrice: 1500000}),
+		ProviderSearchHash(criteria, ProviderSearchOptions{MaxPrice: 2000000}))
+}
+
+func TestCriteriaHash_DifferentCriteria(t *testing.T) {
+	base := baseKeyCriteria()
+	changed := []func(c *SearchCriteria){
+		func(c *SearchCriteria) { c.Origin = "SUB" },
+		func(c *SearchCriteria) { c.Destination = "SUB" },
+		func(c *SearchCriteria) { c.DepartureDate = "2025-12-16" },
+		func(c *SearchCriteria) { c.Passengers = 2 },
+		func(c *SearchCriteria) { c.Class = "business" },
+		func(c *SearchCriteria) {
+			c.Return = &ReturnLeg{Origin: "DPS", Destination: "CGK", DepartureDate: "2025-12-20"}
+		},
+		func(c *SearchCriteria) {
+			c.Return = &ReturnLeg{Origin: "SUB", Destination: "CGK", DepartureDate: "2025-12-20"}
+		},
+		func(c *SearchCriteria) { c.DateFlexibility = 2 },
+	}
+
+	for _, change := range changed {
+		c := base
+		change(&c)
+		assert.NotEqual(t, CriteriaHash(base), CriteriaHash(c), CanonicalCriteria(c))
+	}
+}
+
+func TestSearchHash_EquivalentFilters(t *testing.T) {
+	criteria := baseKeyCriteria()
+	zero := 0
+	maxPrice := 1500000.0
+	samePrice := 1500000.0
+
+	t.Run("nil and empty filters", func(t *testing.T) {
+		assert.Equal(t, SearchHash(criteria, nil), SearchHash(criteria, &FilterOptions{}))
+		assert.Equal(t, SearchHash(criteria, nil), SearchHash(criteria, &FilterOptions{Airlines: []string{}}))
+		assert.Equal(t, SearchHash(criteria, nil), SearchHash(criteria, &FilterOptions{DurationRange: &DurationRange{}}))
+	})
+
+	t.Run("airline order, case and duplicates", func(t *testing.T) {
+		a := &FilterOptions{Airlines: []string{"JT", "ga"}}
+		b := &FilterOptions{Airlines: []string{"GA", "JT", "jt"}}
+		assert.Equal(t, SearchHash(criteria, a), SearchHash(criteria, b))
+	})
+
+	t.Run("distinct pointers to equal values", func(t *testing.T) {
+		a := &FilterOptions{MaxPrice: &maxPrice}
+		b := &FilterOptions{MaxPrice: &samePrice}
+		assert.Equal(t, SearchHash(criteria, a), SearchHash(criteria, b))
+	})
+
+	t.Run("only time of day matters", func(t *testing.T) {
+		a := &FilterOptions{DepartureTimeRange: &TimeRange{
+			Start: time.Date(0, 1, 1, 6, 0, 0, 0, time.UTC),
+			End:   time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC),
+		}}
+		b := &FilterOptions{DepartureTimeRange: &TimeRange{
+			Start: time.Date(2025, 12, 15, 6, 0, 0, 0, time.UTC),
+			End:   time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC),
+		}}
+		assert.Equal(t, SearchHash(criteria, a), SearchHash(criteria, b))
+	})
+
+	t.Run("supports wheelchair", func(t *testing.T) {
+		assert.NotEqual(t, SearchHash(criteria, nil), SearchHash(criteria, &FilterOptions{SupportsWheelchair: true}))
+	})
+
+	t.Run("fare family order, case and duplicates", func(t *testing.T) {
+		a := &FilterOptions{FareFamilies: []string{"Value", "flex"}}
+		b := &FilterOptions{FareFamilies: []string{"FLEX", "value", "Flex"}}
+		assert.Equal(t, SearchHash(criteria, a), SearchHash(criteria, b))
+		assert.NotEqual(t, SearchHash(criteria, a), SearchHash(criteria, &FilterOptions{FareFamilies: a.FareFamilies, Changeable: true}))
+	})
+
+	t.Run("class filter case", func(t *testing.T) {
+		a := &FilterOptions{Class: "Business", IncludeHigherClasses: true}
+		b := &FilterOptions{Class: "business", IncludeHigherClasses: true}
+		assert.Equal(t, SearchHash(criteria, a), SearchHash(criteria, b))
+		assert.NotEqual(t, SearchHash(criteria, a), SearchHash(criteria, &FilterOptions{Class: "business"}))
+	})
+
+	t.Run("min seats", func(t *testing.T) {
+		two, three := 2, 3
+		assert.Equal(t, SearchHash(criteria, &FilterOptions{MinSeatsAvailable: &two}), SearchHash(criteria, &FilterOptions{MinSeatsAvailable: &two}))
+		assert.NotEqual(t, SearchHash(criteria, &FilterOptions{MinSeatsAvailable: &two}), SearchHash(criteria, &FilterOptions{MinSeatsAvailable: &three}))
+	})
+
+	t.Run("price basis", func(t *testing.T) {
+		assert.Equal(t, SearchHash(criteria, &FilterOptions{}), SearchHash(criteria, &FilterOptions{PriceBasis: PriceBasisPerPerson}))
+		assert.NotEqual(t, SearchHash(criteria, &FilterOptions{}), SearchHash(criteria, &FilterOptions{PriceBasis: PriceBasisTotal}))
+	})
+
+	t.Run("refundable only", func(t *testing.T) {
+		assert.NotEqual(t, SearchHash(criteria, nil), SearchHash(criteria, &FilterOptions{RefundableOnly: true}))
+		assert.NotEqual(t, SearchHash(criteria, &FilterOptions{Refundable: true}), SearchHash(criteria, &FilterOptions{RefundableOnly: true}))
+	})
+
+	t.Run("zero max stops is a filter", func(t *testing.T) {
+		assert.NotEqual(t, SearchHash(criteria, nil), SearchHash(criteria, &FilterOptions{MaxStops: &zero}))
+	})
+}
+
+func TestCanonicalSearch(t *testing.T) {
+	maxPrice := 1500000.0
+	maxStops := 1
+	minDur := 60
+
+	filters := &FilterOptions{
+		MaxPrice: &maxPrice,
+		MaxStops: &maxStops,
+		Airlines: []string{"qz", "GA"},
+		ArrivalTimeRange: &TimeRange{
+			Start: time.Date(0, 1, 1, 18, 0, 0, 0, time.UTC),
+			End:   time.Date(0, 1, 1, 23, 59, 0, 0, time.UTC),
+		},
+		DurationRange: &DurationRange{MinMinutes: &minDur},
+	}
+
+	assert.Equal(t,
+		"v1|origin=CGK|destination=DPS|date=2025-12-15|passengers=1|class=economy"+
+			"|max_price=1500000|max_stops=1|airlines=GA,QZ|arrival_time=18:00-23:59|duration=60-",
+		CanonicalSearch(baseKeyCriteria(), filters))
+}
+
+func TestSearchHash_Format(t *testing.T) {
+	hash := SearchHash(baseKeyCriteria(), nil)
+	assert.Len(t, hash, 64)
+	assert.NotEqual(t, CriteriaHash(baseKeyCriteria()), SearchHash(baseKeyCriteria(), &FilterOptions{Airlines: []string{"GA"}}))
+}
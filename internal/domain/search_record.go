@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// SearchRecord is a stored search: the parameters it was executed with and the
+// result returned to the client. Records allow a search to be inspected or
+// re-executed later, e.g. when investigating customer complaints.
+type SearchRecord struct {
+	// ID uniquely identifies the search (returned to clients as metadata.search_id)
+	ID string `json:"id"`
+
+	// Client is the principal that ran the search: the API key or bearer
+	// token subject, or "anonymous". Searches are listed per client.
+	Client string `json:"client,omitempty"`
+
+	// Criteria contains the original search parameters
+	Criteria SearchCriteria `json:"criteria"`
+
+	// Filters contains the original filter options, if any
+	Filters *FilterOptions `json:"filters,omitempty"`
+
+	// SortBy is the original sort option
+	SortBy SortOption `json:"sortBy"`
+
+	// SortOrder is the original sort order (empty = ascending)
+	SortOrder SortOrder `json:"sortOrder,omitempty"`
+
+	// MaxResults is the original result limit (0 = unlimited)
+	MaxResults int `json:"maxResults,omitempty"`
+
+	// MaxResultsPerProvider is the original per-provider result limit (0 = unlimited)
+	MaxResultsPerProvider int `json:"maxResultsPerProvider,omitempty"`
+
+	// PreferredDepartureTime is the original departure time-of-day preference, if any
+	PreferredDepartureTime *TimeRange `json:"preferredDepartureTime,omitempty"`
+
+	// Response is the result returned to the client
+	Response SearchResponse `json:"response"`
+
+	// CreatedAt is when the search was executed
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SearchRecordStore persists search records.
+// Implementations may evict old records; Get returns ErrSearchNotFound for
+// unknown or evicted IDs.
+type SearchRecordStore interface {
+	// Save stores a record, replacing any record with the same ID.
+	Save(ctx context.Context, record SearchRecord) error
+
+	// Get returns the record with the given ID.
+	Get(ctx context.Context, id string) (*SearchRecord, error)
+}
+
+// SearchRecordLister lists the stored searches of a client.
+type SearchRecordLister interface {
+	// ListByClient returns up to limit records of client, newest first.
+	ListByClient(ctx context.Context, client string, limit int) ([]SearchRecord, error)
+}
+
+// SearchHistoryStore stores searches and lists them per client.
+type SearchHistoryStore interface {
+	SearchRecordStore
+	SearchRecordLister
+}
@@ -127,6 +127,62 @@ func TestSearchCriteria_Validate(t *testing.T) {
 			modify:  func(c *SearchCriteria) { c.Class = "first" },
 			wantErr: false,
 		},
+		{
+			name: "open-jaw return leg passes",
+			modify: func(c *SearchCriteria) {
+				c.Return = &ReturnLeg{Origin: "SUB", Destination: "CGK", DepartureDate: c.DepartureDate}
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid return origin fails",
+			modify: func(c *SearchCriteria) {
+				c.Return = &ReturnLeg{Origin: "SU", Destination: "CGK", DepartureDate: c.DepartureDate}
+			},
+			wantErr:      true,
+			errContains:  "return origin must be a valid 3-letter IATA code",
+			isInvalidReq: true,
+		},
+		{
+			name: "same return origin and destination fails",
+			modify: func(c *SearchCriteria) {
+				c.Return = &ReturnLeg{Origin: "CGK", Destination: "CGK", DepartureDate: c.DepartureDate}
+			},
+			wantErr:      true,
+			errContains:  "return origin and destination must be different",
+			isInvalidReq: true,
+		},
+		{
+			name: "return before departure fails",
+			modify: func(c *SearchCriteria) {
+				c.Return = &ReturnLeg{Origin: "DPS", Destination: "CGK", DepartureDate: time.Now().Format("2006-01-02")}
+			},
+			wantErr:      true,
+			errContains:  "return departureDate must not be before departureDate",
+			isInvalidReq: true,
+		},
+		{
+			name:    "flexible dates pass",
+			modify:  func(c *SearchCriteria) { c.DateFlexibility = MaxDateFlexibility },
+			wantErr: false,
+		},
+		{
+			name:         "too much date flexibility fails",
+			modify:       func(c *SearchCriteria) { c.DateFlexibility = MaxDateFlexibility + 1 },
+			wantErr:      true,
+			errContains:  "dateFlexibility must be between 0 and 3",
+			isInvalidReq: true,
+		},
+		{
+			name: "flexible dates with a return leg fail",
+			modify: func(c *SearchCriteria) {
+				c.DateFlexibility = 1
+				c.Return = c.ReturnOn(c.DepartureDate)
+			},
+			wantErr:      true,
+			errContains:  "dateFlexibility cannot be combined with a return leg",
+			isInvalidReq: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -151,6 +207,64 @@ func TestSearchCriteria_Validate(t *testing.T) {
 	}
 }
 
+func TestSearchCriteria_Legs(t *testing.T) {
+	c := SearchCriteria{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: "2025-12-15",
+		Passengers:    2,
+		Class:         "business",
+		Return:        &ReturnLeg{Origin: "SUB", Destination: "CGK", DepartureDate: "2025-12-20"},
+	}
+
+	assert.True(t, c.IsOpenJaw())
+	assert.Nil(t, c.Outbound().Return)
+	assert.NotNil(t, c.Return, "Outbound does not modify the criteria")
+	assert.Equal(t, SearchCriteria{Origin: "SUB", Destination: "CGK", DepartureDate: "2025-12-20", Passengers: 2, Class: "business"}, c.Inbound())
+
+	c.Return.Origin = "DPS"
+	assert.False(t, c.IsOpenJaw(), "return mirrors the outbound route")
+	c.Return = nil
+	assert.False(t, c.IsOpenJaw(), "one way")
+
+	c.Return = c.ReturnOn("2025-12-22")
+	assert.Equal(t, &ReturnLeg{Origin: "DPS", Destination: "CGK", DepartureDate: "2025-12-22"}, c.Return)
+	assert.False(t, c.IsOpenJaw())
+}
+
+func TestSearchCriteria_FlexibleDates(t *testing.T) {
+	c := SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-31", Passengers: 1, DateFlexibility: 2}
+	assert.Equal(t, []string{"2025-12-29", "2025-12-30", "2025-12-31", "2026-01-01", "2026-01-02"}, c.FlexibleDates())
+	assert.Equal(t, SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2026-01-01", Passengers: 1}, c.OnDate("2026-01-01"))
+	assert.Equal(t, 2, c.DateFlexibility, "OnDate does not modify the criteria")
+
+	c.DateFlexibility = 0
+	assert.Equal(t, []string{"2025-12-31"}, c.FlexibleDates(), "not flexible")
+}
+
+func TestSearchCriteria_ValidateGroup(t *testing.T) {
+	c := SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 25}
+	assert.True(t, c.IsGroup())
+	assert.NoError(t, c.ValidateGroup())
+	assert.ErrorIs(t, c.Validate(), ErrInvalidRequest, "too many passengers for a search")
+
+	c.Passengers = MaxPassengers
+	assert.False(t, c.IsGroup())
+
+	for _, passengers := range []int{MaxPassengers, MaxGroupPassengers + 1} {
+		c.Passengers = passengers
+		assert.ErrorIs(t, c.ValidateGroup(), ErrInvalidRequest, "%d passengers", passengers)
+	}
+
+	c.Passengers = 10
+	c.Return = &ReturnLeg{Origin: "DPS", Destination: "CGK", DepartureDate: "2025-12-20"}
+	assert.ErrorContains(t, c.ValidateGroup(), "return legs")
+
+	c.Return = nil
+	c.DateFlexibility = 1
+	assert.ErrorContains(t, c.ValidateGroup(), "dateFlexibility")
+}
+
 func TestSearchCriteria_SetDefaults(t *testing.T) {
 	tests := []struct {
 		name           string
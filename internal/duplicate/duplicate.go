@@ -0,0 +1,134 @@
+// Package duplicate detects identical searches a tenant issues within a short
+// window of each other.
+//
+// Searches are counted as they arrive, before concurrent identical searches
+// are coalesced into one provider fan-out, so the counts show how often
+// partners repeat a search instead of reusing or refining its results. The
+// intervals between repeats are evidence for the cache TTL.
+package duplicate
+
+import (
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// DefaultWindow is how long a search is remembered by default: an identical
+// search within it is a duplicate.
+const DefaultWindow = time.Minute
+
+// maxSearches caps the searches remembered; the expired ones are dropped when
+// it is reached, and then the oldest.
+const maxSearches = 10000
+
+// searchKey identifies a search of a tenant.
+type searchKey struct {
+	tenant string
+	search string
+}
+
+// seen is a search remembered within the window.
+type seen struct {
+	last    time.Time
+	repeats int
+}
+
+// Repeat describes how a search repeats earlier identical searches.
+type Repeat struct {
+	// Duplicate reports whether an identical search was issued within the window
+	Duplicate bool
+
+	// Since is the time since the previous identical search, if a duplicate
+	Since time.Duration
+
+	// Repeats is the number of identical searches in a row issued within the
+	// window of each other before this one
+	Repeats int
+}
+
+// Tracker remembers the recent searches of each tenant. It is safe for
+// concurrent use.
+type Tracker struct {
+	clock  timeutil.Clock
+	window time.Duration
+
+	mu       sync.Mutex
+	searches map[searchKey]seen
+}
+
+// Option configures a Tracker.
+type Option func(*Tracker)
+
+// WithClock sets the clock used to time searches.
+func WithClock(clock timeutil.Clock) Option {
+	return func(t *Tracker) {
+		t.clock = clock
+	}
+}
+
+// WithWindow sets how long a search is remembered.
+func WithWindow(window time.Duration) Option {
+	return func(t *Tracker) {
+		t.window = window
+	}
+}
+
+// NewTracker creates a tracker remembering no searches.
+func NewTracker(opts ...Option) *Tracker {
+	t := &Tracker{
+		clock:    timeutil.NewRealClock(),
+		window:   DefaultWindow,
+		searches: make(map[searchKey]seen),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Window returns how long a search is remembered.
+func (t *Tracker) Window() time.Duration {
+	return t.window
+}
+
+// Observe records a search of the tenant, identified by a key equal for
+// identical searches (e.g., domain.SearchHash), and reports whether it
+// repeats one issued within the window.
+func (t *Tracker) Observe(tenant, search string) Repeat {
+	now := t.clock.Now()
+	key := searchKey{tenant: tenant, search: search}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var r Repeat
+	prev, ok := t.searches[key]
+	if ok && now.Sub(prev.last) < t.window {
+		r = Repeat{Duplicate: true, Since: now.Sub(prev.last), Repeats: prev.repeats + 1}
+	}
+	if !ok && len(t.searches) >= maxSearches {
+		t.evict(now)
+	}
+	t.searches[key] = seen{last: now, repeats: r.Repeats}
+	return r
+}
+
+// evict drops the expired searches, or the oldest one if none expired. The
+// caller must hold t.mu.
+func (t *Tracker) evict(now time.Time) {
+	var oldest searchKey
+	var oldestAt time.Time
+	for key, s := range t.searches {
+		if now.Sub(s.last) >= t.window {
+			delete(t.searches, key)
+			continue
+		}
+		if oldestAt.IsZero() || s.last.Before(oldestAt) {
+			oldest, oldestAt = key, s.last
+		}
+	}
+	if len(t.searches) >= maxSearches {
+		delete(t.searches, oldest)
+	}
+}
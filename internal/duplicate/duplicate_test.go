@@ -0,0 +1,49 @@
+package duplicate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+func TestTracker_Observe(t *testing.T) {
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	tracker := NewTracker(WithClock(clock), WithWindow(time.Minute))
+
+	assert.Equal(t, Repeat{}, tracker.Observe("acme", "search1"), "first search")
+
+	clock.Advance(10 * time.Second)
+	assert.Equal(t, Repeat{Duplicate: true, Since: 10 * time.Second, Repeats: 1}, tracker.Observe("acme", "search1"))
+
+	clock.Advance(50 * time.Second)
+	assert.Equal(t, Repeat{Duplicate: true, Since: 50 * time.Second, Repeats: 2}, tracker.Observe("acme", "search1"),
+		"the window runs from the latest repeat")
+
+	assert.Equal(t, Repeat{}, tracker.Observe("acme", "search2"), "another search")
+	assert.Equal(t, Repeat{}, tracker.Observe("globex", "search1"), "another tenant")
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, Repeat{}, tracker.Observe("acme", "search1"), "outside the window")
+}
+
+func TestTracker_Evict(t *testing.T) {
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	tracker := NewTracker(WithClock(clock))
+
+	for i := range maxSearches {
+		tracker.Observe("acme", fmt.Sprint(i))
+		clock.Advance(time.Millisecond)
+	}
+	tracker.Observe("acme", "new")
+	assert.Len(t, tracker.searches, maxSearches)
+	assert.False(t, tracker.Observe("acme", "0").Duplicate, "the oldest search is dropped")
+	assert.True(t, tracker.Observe("acme", "new").Duplicate)
+
+	clock.Advance(DefaultWindow)
+	tracker.Observe("acme", "newer")
+	assert.Len(t, tracker.searches, 1, "expired searches are dropped")
+}
@@ -0,0 +1,119 @@
+// Package durationfmt parses and formats flight durations.
+//
+// Providers express durations in many shapes: minute counts, fractional
+// hours, "1h 45m" strings, ISO 8601 "PT1H45M" and Indonesian "1 jam 45 menit".
+// All of them are converted to whole minutes, and formatted back in a single
+// canonical form ("1h 45m", "2h", "45m").
+package durationfmt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidDuration indicates a duration string could not be parsed.
+var ErrInvalidDuration = errors.New("invalid duration")
+
+// Format formats a number of minutes as "Xh Ym", omitting a zero part:
+// 105 → "1h 45m", 120 → "2h", 45 → "45m", 0 → "0m".
+func Format(minutes int) string {
+	if minutes < 0 {
+		return "-" + Format(-minutes)
+	}
+
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return strconv.Itoa(m) + "m"
+	case m == 0:
+		return strconv.Itoa(h) + "h"
+	default:
+		return strconv.Itoa(h) + "h " + strconv.Itoa(m) + "m"
+	}
+}
+
+// FromHours converts fractional hours to minutes, rounded to the nearest
+// minute: 1.75 → 105, 1.67 → 100.
+func FromHours(hours float64) int {
+	return int(math.Round(hours * 60))
+}
+
+// unitMinutes maps duration units, in English and Indonesian, to minutes.
+var unitMinutes = map[string]float64{
+	"h": 60, "hr": 60, "hrs": 60, "hour": 60, "hours": 60, "jam": 60,
+	"m": 1, "min": 1, "mins": 1, "minute": 1, "minutes": 1, "menit": 1, "mnt": 1,
+}
+
+// partRegex matches one "<number><unit>" part of a duration, e.g. "1h", "45 menit", "1.5 hours".
+var partRegex = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)\s*([a-z]+)`)
+
+// clockRegex matches "H:MM" durations.
+var clockRegex = regexp.MustCompile(`^(\d+):([0-5]\d)$`)
+
+// isoRegex matches ISO 8601 durations limited to hours and minutes.
+var isoRegex = regexp.MustCompile(`^pt(?:(\d+)h)?(?:(\d+)m)?$`)
+
+// Parse parses a duration string to whole minutes. It accepts:
+//   - unit strings: "2h 15m", "2h15m", "1h", "45m", "1.5 hours", "1 hr 5 min"
+//   - Indonesian strings: "1 jam 45 menit", "2 jam", "45 menit"
+//   - ISO 8601: "PT1H45M"
+//   - clock notation: "1:45"
+//
+// Parts may be separated by spaces, commas, "and" or "dan". Matching is
+// case-insensitive.
+func Parse(s string) (int, error) {
+	input := strings.ToLower(strings.TrimSpace(s))
+	if input == "" {
+		return 0, fmt.Errorf("%w: empty string", ErrInvalidDuration)
+	}
+
+	if m := clockRegex.FindStringSubmatch(input); m != nil {
+		h, _ := strconv.Atoi(m[1])
+		min, _ := strconv.Atoi(m[2])
+		return h*60 + min, nil
+	}
+
+	if m := isoRegex.FindStringSubmatch(input); m != nil && (m[1] != "" || m[2] != "") {
+		h, _ := strconv.Atoi(m[1])
+		min, _ := strconv.Atoi(m[2])
+		return h*60 + min, nil
+	}
+
+	var total float64
+	seen := make(map[float64]bool, 2)
+	rest := input
+	for rest != "" {
+		m := partRegex.FindStringSubmatch(rest)
+		if m == nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, s)
+		}
+		unit, ok := unitMinutes[m[2]]
+		if !ok || seen[unit] {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, s)
+		}
+		seen[unit] = true
+
+		value, _ := strconv.ParseFloat(strings.Replace(m[1], ",", ".", 1), 64)
+		total += value * unit
+		rest = trimSeparators(rest[len(m[0]):])
+	}
+	return int(math.Round(total)), nil
+}
+
+// trimSeparators removes the separators allowed between duration parts.
+func trimSeparators(s string) string {
+	for {
+		trimmed := strings.TrimLeft(s, " \t,")
+		for _, word := range []string{"and ", "dan "} {
+			trimmed = strings.TrimPrefix(trimmed, word)
+		}
+		if trimmed == s {
+			return s
+		}
+		s = trimmed
+	}
+}
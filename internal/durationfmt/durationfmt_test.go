@@ -0,0 +1,114 @@
+package durationfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		minutes int
+		want    string
+	}{
+		{0, "0m"},
+		{1, "1m"},
+		{45, "45m"},
+		{60, "1h"},
+		{65, "1h 5m"},
+		{105, "1h 45m"},
+		{180, "3h"},
+		{725, "12h 5m"},
+		{6000, "100h"},
+		{-90, "-1h 30m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, Format(tt.minutes))
+		})
+	}
+}
+
+func TestFromHours(t *testing.T) {
+	tests := []struct {
+		name  string
+		hours float64
+		want  int
+	}{
+		{"1.75 hours", 1.75, 105},
+		{"2.5 hours", 2.5, 150},
+		{"0.5 hours", 0.5, 30},
+		{"0 hours", 0, 0},
+		{"1.67 hours rounds to nearest", 1.67, 100},
+		{"3.33 hours", 3.33, 200},
+		{"4.33 hours", 4.33, 260},
+		{"0.1 hours", 0.1, 6},
+		{"5.99 hours", 5.99, 359},
+		{"negative hours", -1.5, -90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FromHours(tt.hours))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"2h 15m", 135},
+		{"2h15m", 135},
+		{"1h", 60},
+		{"1h 0m", 60},
+		{"45m", 45},
+		{"0h 30m", 30},
+		{"0h 0m", 0},
+		{"  2h  15m  ", 135},
+		{"12h 30m", 750},
+		{"1H 45M", 105},
+		{"1 hr 5 min", 65},
+		{"2 hours and 10 minutes", 130},
+		{"1.5 hours", 90},
+		{"1 jam 45 menit", 105},
+		{"1 jam dan 5 menit", 65},
+		{"2 jam", 120},
+		{"45 menit", 45},
+		{"1,5 jam", 90},
+		{"1jam45menit", 105},
+		{"PT1H45M", 105},
+		{"PT2H", 120},
+		{"PT30M", 30},
+		{"1:45", 105},
+		{"12:05", 725},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{"", "   ", "invalid", "abc", "45", "1h 2h", "1 day", "1:75", "PT", "1h 45m ago"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := Parse(input)
+			assert.ErrorIs(t, err, ErrInvalidDuration)
+		})
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	for _, minutes := range []int{0, 5, 60, 105, 725} {
+		got, err := Parse(Format(minutes))
+		require.NoError(t, err)
+		assert.Equal(t, minutes, got)
+	}
+}
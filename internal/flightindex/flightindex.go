@@ -0,0 +1,126 @@
+// Package flightindex remembers the flights of recent searches by ID, so the
+// details of a flight in a result can be looked up without searching again.
+//
+// Flight IDs are assigned by the providers and are only unique within a
+// search, so an ID resolves to the flight of the most recent search that
+// returned it.
+package flightindex
+
+import (
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// DefaultTTL is how long a flight is remembered by default.
+const DefaultTTL = 15 * time.Minute
+
+// maxFlights caps the flights remembered; the expired ones are dropped when it
+// is reached, and then the oldest.
+const maxFlights = 50000
+
+// Entry is a remembered flight.
+type Entry struct {
+	// Flight is the flight as returned in the search response
+	Flight domain.Flight
+
+	// SearchedAt is when the search returning the flight completed
+	SearchedAt time.Time
+}
+
+// Index remembers the flights of recent searches. It is safe for concurrent
+// use.
+type Index struct {
+	clock timeutil.Clock
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	flights map[string]Entry
+}
+
+// Option configures an Index.
+type Option func(*Index)
+
+// WithClock sets the clock used to time searches.
+func WithClock(clock timeutil.Clock) Option {
+	return func(i *Index) {
+		i.clock = clock
+	}
+}
+
+// WithTTL sets how long a flight is remembered.
+func WithTTL(ttl time.Duration) Option {
+	return func(i *Index) {
+		i.ttl = ttl
+	}
+}
+
+// New creates an index remembering no flights.
+func New(opts ...Option) *Index {
+	i := &Index{
+		clock:   timeutil.NewRealClock(),
+		ttl:     DefaultTTL,
+		flights: make(map[string]Entry),
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// Add remembers the flights of a search response, outbound and return,
+// replacing the flights of earlier searches with the same IDs.
+func (i *Index) Add(resp *domain.SearchResponse) {
+	if resp == nil {
+		return
+	}
+	now := i.clock.Now()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, flights := range [][]domain.Flight{resp.Flights, resp.ReturnFlights} {
+		for _, f := range flights {
+			if _, ok := i.flights[f.ID]; !ok && len(i.flights) >= maxFlights {
+				i.evict(now)
+			}
+			i.flights[f.ID] = Entry{Flight: f, SearchedAt: now}
+		}
+	}
+}
+
+// Get returns the flight with the given ID from the most recent search that
+// returned it, and false if no search within the TTL did.
+func (i *Index) Get(id string) (Entry, bool) {
+	now := i.clock.Now()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	entry, ok := i.flights[id]
+	if !ok || now.Sub(entry.SearchedAt) >= i.ttl {
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+// evict drops the expired flights, or the oldest one if none expired. The
+// caller must hold i.mu.
+func (i *Index) evict(now time.Time) {
+	var oldest string
+	var oldestAt time.Time
+	for id, entry := range i.flights {
+		if now.Sub(entry.SearchedAt) >= i.ttl {
+			delete(i.flights, id)
+			continue
+		}
+		if oldestAt.IsZero() || entry.SearchedAt.Before(oldestAt) {
+			oldest, oldestAt = id, entry.SearchedAt
+		}
+	}
+	if len(i.flights) >= maxFlights {
+		delete(i.flights, oldest)
+	}
+}
@@ -0,0 +1,63 @@
+package flightindex
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+func TestIndex_Get(t *testing.T) {
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	index := New(WithClock(clock), WithTTL(time.Minute))
+
+	index.Add(&domain.SearchResponse{
+		Flights:       []domain.Flight{{ID: "GA400", Price: domain.PriceInfo{Amount: 1000000}}},
+		ReturnFlights: []domain.Flight{{ID: "GA401"}},
+	})
+	entry, ok := index.Get("GA400")
+	require.True(t, ok)
+	assert.Equal(t, 1000000.0, entry.Flight.Price.Amount)
+	assert.Equal(t, clock.Now(), entry.SearchedAt)
+	_, ok = index.Get("GA401")
+	assert.True(t, ok, "return flights are remembered")
+	_, ok = index.Get("JT610")
+	assert.False(t, ok)
+
+	clock.Advance(30 * time.Second)
+	index.Add(&domain.SearchResponse{Flights: []domain.Flight{{ID: "GA400", Price: domain.PriceInfo{Amount: 1100000}}}})
+	entry, ok = index.Get("GA400")
+	require.True(t, ok)
+	assert.Equal(t, 1100000.0, entry.Flight.Price.Amount, "the most recent search wins")
+
+	clock.Advance(30 * time.Second)
+	_, ok = index.Get("GA401")
+	assert.False(t, ok, "outside the TTL")
+	_, ok = index.Get("GA400")
+	assert.True(t, ok)
+
+	index.Add(nil)
+}
+
+func TestIndex_Evict(t *testing.T) {
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	index := New(WithClock(clock))
+
+	for i := range maxFlights {
+		index.Add(&domain.SearchResponse{Flights: []domain.Flight{{ID: fmt.Sprint(i)}}})
+		clock.Advance(time.Millisecond)
+	}
+	index.Add(&domain.SearchResponse{Flights: []domain.Flight{{ID: "new"}}})
+	assert.Len(t, index.flights, maxFlights)
+	_, ok := index.Get("0")
+	assert.False(t, ok, "the oldest flight is dropped")
+
+	clock.Advance(DefaultTTL)
+	index.Add(&domain.SearchResponse{Flights: []domain.Flight{{ID: "newer"}}})
+	assert.Len(t, index.flights, 1, "expired flights are dropped")
+}
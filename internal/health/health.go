@@ -0,0 +1,358 @@
+// Package health tracks whether flight providers can serve searches.
+//
+// A Monitor wraps providers and records the outcome of every search made
+// through them, and checks every provider in the background: with its
+// HealthCheck if it implements domain.HealthChecker, with a cheap canary
+// search otherwise. Each provider has a circuit breaker: after a number of
+// failures in a row the breaker opens and searches of the provider fail at
+// once, without waiting for it to time out, until the cooldown has passed
+// and one trial search is let through (half open) or a background check
+// succeeds. The state of each provider is reported by /health.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// Defaults of a Monitor.
+const (
+	DefaultThreshold    = 5
+	DefaultCooldown     = 30 * time.Second
+	DefaultCheckTimeout = 5 * time.Second
+)
+
+// canaryLeadTime is how far ahead the canary search departs, so it is always
+// for a bookable date.
+const canaryLeadTime = 7 * 24 * time.Hour
+
+// ErrBreakerOpen indicates a search was not sent to a provider because its
+// breaker is open. Errors wrapping it also wrap domain.ErrProviderUnavailable.
+var ErrBreakerOpen = errors.New("circuit breaker open")
+
+// Breaker is the state of a provider's circuit breaker.
+type Breaker string
+
+// Breaker states.
+const (
+	// BreakerClosed lets every search through
+	BreakerClosed Breaker = "closed"
+
+	// BreakerOpen fails every search at once
+	BreakerOpen Breaker = "open"
+
+	// BreakerHalfOpen lets one trial search through, which closes the
+	// breaker if it succeeds and opens it again otherwise
+	BreakerHalfOpen Breaker = "half_open"
+)
+
+// Status summarizes the health of a provider.
+type Status string
+
+// Provider statuses.
+const (
+	// StatusUnknown is the status of a provider neither searched nor checked yet
+	StatusUnknown Status = "unknown"
+
+	// StatusHealthy is the status of a provider whose last search succeeded
+	StatusHealthy Status = "healthy"
+
+	// StatusDegraded is the status of a provider whose last searches failed,
+	// not enough of them in a row to open its breaker
+	StatusDegraded Status = "degraded"
+
+	// StatusUnhealthy is the status of a provider whose breaker is not closed
+	StatusUnhealthy Status = "unhealthy"
+)
+
+// ProviderStatus is a snapshot of a provider's health.
+type ProviderStatus struct {
+	Provider string
+	Status   Status
+	Breaker  Breaker
+
+	// ConsecutiveFailures is the number of failed searches and checks since
+	// the last successful one
+	ConsecutiveFailures int
+
+	// LastSuccess and LastFailure are the times of the last successful and
+	// failed search or check (zero if none)
+	LastSuccess time.Time
+	LastFailure time.Time
+
+	// LastError is the error of the last failed search or check
+	LastError string
+
+	// LastCheck is the time of the last background check (zero if none)
+	LastCheck time.Time
+}
+
+// state is the health of a provider.
+type state struct {
+	consecutiveFailures int
+	lastSuccess         time.Time
+	lastFailure         time.Time
+	lastError           string
+	lastCheck           time.Time
+
+	// open reports whether the breaker opened, at openedAt
+	open     bool
+	openedAt time.Time
+
+	// trial reports whether the trial search of the half-open breaker is in flight
+	trial bool
+}
+
+// Monitor tracks the health of providers. It is safe for concurrent use.
+type Monitor struct {
+	clock        timeutil.Clock
+	threshold    int
+	cooldown     time.Duration
+	checkTimeout time.Duration
+
+	mu     sync.Mutex
+	states map[string]*state
+}
+
+// Option configures a Monitor.
+type Option func(*Monitor)
+
+// WithClock sets the clock used to time searches and breakers.
+func WithClock(clock timeutil.Clock) Option {
+	return func(m *Monitor) {
+		m.clock = clock
+	}
+}
+
+// WithThreshold sets the number of failures in a row opening a breaker; 0
+// disables the breakers, leaving the failures reported only.
+func WithThreshold(n int) Option {
+	return func(m *Monitor) {
+		m.threshold = n
+	}
+}
+
+// WithCooldown sets how long a breaker stays open before a trial search.
+func WithCooldown(cooldown time.Duration) Option {
+	return func(m *Monitor) {
+		m.cooldown = cooldown
+	}
+}
+
+// WithCheckTimeout sets how long a background check of a provider may take.
+func WithCheckTimeout(timeout time.Duration) Option {
+	return func(m *Monitor) {
+		m.checkTimeout = timeout
+	}
+}
+
+// NewMonitor creates a monitor tracking no provider.
+func NewMonitor(opts ...Option) *Monitor {
+	m := &Monitor{
+		clock:        timeutil.NewRealClock(),
+		threshold:    DefaultThreshold,
+		cooldown:     DefaultCooldown,
+		checkTimeout: DefaultCheckTimeout,
+		states:       make(map[string]*state),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Statuses returns the health of every tracked provider, sorted by provider name.
+func (m *Monitor) Statuses() []ProviderStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	result := make([]ProviderStatus, 0, len(m.states))
+	for provider, s := range m.states {
+		status := ProviderStatus{
+			Provider:            provider,
+			Breaker:             m.breaker(s, now),
+			ConsecutiveFailures: s.consecutiveFailures,
+			LastSuccess:         s.lastSuccess,
+			LastFailure:         s.lastFailure,
+			LastError:           s.lastError,
+			LastCheck:           s.lastCheck,
+		}
+		switch {
+		case status.Breaker != BreakerClosed:
+			status.Status = StatusUnhealthy
+		case s.consecutiveFailures > 0:
+			status.Status = StatusDegraded
+		case s.lastSuccess.IsZero():
+			status.Status = StatusUnknown
+		default:
+			status.Status = StatusHealthy
+		}
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Provider < result[j].Provider })
+	return result
+}
+
+// Check checks every provider concurrently and records the outcomes like
+// searches: a failed check counts towards opening the breaker, and a
+// successful one closes it. It returns when every check is done.
+func (m *Monitor) Check(ctx context.Context, providers []domain.FlightProvider) {
+	var wg sync.WaitGroup
+	for _, p := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, m.checkTimeout)
+			defer cancel()
+
+			err := m.probe(ctx, p)
+
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			s := m.state(p.Name())
+			if !errors.Is(err, context.Canceled) {
+				s.lastCheck = m.clock.Now()
+			}
+			m.record(s, err)
+		}()
+	}
+	wg.Wait()
+}
+
+// probe checks provider with its HealthCheck, or with a canary search.
+func (m *Monitor) probe(ctx context.Context, provider domain.FlightProvider) error {
+	if checker, ok := provider.(domain.HealthChecker); ok {
+		return checker.HealthCheck(ctx)
+	}
+	_, err := provider.Search(ctx, domain.SearchCriteria{
+		Origin:        "CGK",
+		Destination:   "DPS",
+		DepartureDate: m.clock.Now().Add(canaryLeadTime).Format(time.DateOnly),
+		Passengers:    1,
+		Class:         "economy",
+	})
+	return err
+}
+
+// state returns the provider's state, creating it if needed. The caller must
+// hold m.mu.
+func (m *Monitor) state(provider string) *state {
+	s, ok := m.states[provider]
+	if !ok {
+		s = &state{}
+		m.states[provider] = s
+	}
+	return s
+}
+
+// breaker returns the state of the breaker of s at now. The caller must hold m.mu.
+func (m *Monitor) breaker(s *state, now time.Time) Breaker {
+	switch {
+	case !s.open:
+		return BreakerClosed
+	case now.Sub(s.openedAt) >= m.cooldown:
+		return BreakerHalfOpen
+	default:
+		return BreakerOpen
+	}
+}
+
+// allow reports whether a search may be sent to provider: always if its
+// breaker is closed, never if it is open, and once at a time if it is half
+// open.
+func (m *Monitor) allow(provider string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.state(provider)
+	switch m.breaker(s, m.clock.Now()) {
+	case BreakerClosed:
+		return true
+	case BreakerHalfOpen:
+		if s.trial {
+			return false
+		}
+		s.trial = true
+		return true
+	default:
+		return false
+	}
+}
+
+// record records the outcome of a search or check in s. Cancelled searches
+// say nothing about the provider and are not counted. The caller must hold
+// m.mu.
+func (m *Monitor) record(s *state, err error) {
+	s.trial = false
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	now := m.clock.Now()
+	if err == nil {
+		s.consecutiveFailures = 0
+		s.lastSuccess = now
+		s.open = false
+		return
+	}
+	s.consecutiveFailures++
+	s.lastFailure = now
+	s.lastError = err.Error()
+	if m.threshold > 0 && (s.open || s.consecutiveFailures >= m.threshold) {
+		s.open = true
+		s.openedAt = now
+	}
+}
+
+// guardedProvider records the outcome of every search made through the
+// wrapped provider, and fails searches at once while its breaker is open.
+type guardedProvider struct {
+	domain.FlightProvider
+	monitor *Monitor
+}
+
+// Instrument wraps a provider so its health is tracked and its searches go
+// through its breaker.
+func (m *Monitor) Instrument(p domain.FlightProvider) domain.FlightProvider {
+	m.mu.Lock()
+	m.state(p.Name())
+	m.mu.Unlock()
+	return guardedProvider{FlightProvider: p, monitor: m}
+}
+
+// InstrumentAll wraps each provider with Instrument.
+func (m *Monitor) InstrumentAll(providers []domain.FlightProvider) []domain.FlightProvider {
+	wrapped := make([]domain.FlightProvider, len(providers))
+	for i, p := range providers {
+		wrapped[i] = m.Instrument(p)
+	}
+	return wrapped
+}
+
+// Search implements domain.FlightProvider.
+func (p guardedProvider) Search(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	return p.SearchWithOptions(ctx, criteria, domain.ProviderSearchOptions{})
+}
+
+// SearchWithOptions implements domain.FilteringProvider.
+func (p guardedProvider) SearchWithOptions(ctx context.Context, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) ([]domain.Flight, error) {
+	name := p.Name()
+	if !p.monitor.allow(name) {
+		return nil, domain.NewProviderError(name, fmt.Errorf("%w: %w", domain.ErrProviderUnavailable, ErrBreakerOpen))
+	}
+
+	flights, err := domain.SearchWithOptions(ctx, p.FlightProvider, criteria, opts)
+
+	p.monitor.mu.Lock()
+	defer p.monitor.mu.Unlock()
+	p.monitor.record(p.monitor.state(name), err)
+	return flights, err
+}
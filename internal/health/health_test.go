@@ -0,0 +1,172 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// stubProvider fails its searches with err, counting them.
+type stubProvider struct {
+	name     string
+	err      error
+	searches int
+	criteria domain.SearchCriteria
+}
+
+func (p *stubProvider) Name() string { return p.name }
+
+func (p *stubProvider) Search(_ context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	p.searches++
+	p.criteria = criteria
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []domain.Flight{{ID: "GA400", Provider: p.name}}, nil
+}
+
+// checkedProvider implements domain.HealthChecker.
+type checkedProvider struct {
+	stubProvider
+	healthErr error
+}
+
+func (p *checkedProvider) HealthCheck(context.Context) error { return p.healthErr }
+
+func TestMonitor_Breaker(t *testing.T) {
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	monitor := NewMonitor(WithClock(clock), WithThreshold(2), WithCooldown(time.Minute))
+	stub := &stubProvider{name: "garuda", err: domain.ErrProviderTimeout}
+	provider := monitor.Instrument(stub)
+	ctx := context.Background()
+
+	status := monitor.Statuses()
+	require.Len(t, status, 1)
+	assert.Equal(t, ProviderStatus{Provider: "garuda", Status: StatusUnknown, Breaker: BreakerClosed}, status[0],
+		"instrumented providers are reported before their first search")
+
+	_, err := provider.Search(ctx, domain.SearchCriteria{})
+	assert.ErrorIs(t, err, domain.ErrProviderTimeout)
+	status = monitor.Statuses()
+	assert.Equal(t, StatusDegraded, status[0].Status)
+	assert.Equal(t, 1, status[0].ConsecutiveFailures)
+	assert.Equal(t, "provider timeout", status[0].LastError)
+
+	_, err = provider.Search(ctx, domain.SearchCriteria{})
+	assert.ErrorIs(t, err, domain.ErrProviderTimeout)
+	status = monitor.Statuses()
+	assert.Equal(t, StatusUnhealthy, status[0].Status)
+	assert.Equal(t, BreakerOpen, status[0].Breaker)
+
+	_, err = provider.Search(ctx, domain.SearchCriteria{})
+	assert.ErrorIs(t, err, ErrBreakerOpen)
+	assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+	var providerErr *domain.ProviderError
+	require.ErrorAs(t, err, &providerErr)
+	assert.Equal(t, "garuda", providerErr.Provider)
+	assert.Equal(t, 2, stub.searches, "searches fail at once while the breaker is open")
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, BreakerHalfOpen, monitor.Statuses()[0].Breaker)
+	_, err = provider.Search(ctx, domain.SearchCriteria{})
+	assert.ErrorIs(t, err, domain.ErrProviderTimeout)
+	assert.Equal(t, 3, stub.searches, "a trial search is let through")
+	assert.Equal(t, BreakerOpen, monitor.Statuses()[0].Breaker, "a failed trial opens the breaker again")
+
+	clock.Advance(time.Minute)
+	stub.err = nil
+	flights, err := provider.Search(ctx, domain.SearchCriteria{})
+	require.NoError(t, err)
+	assert.Len(t, flights, 1)
+	status = monitor.Statuses()
+	assert.Equal(t, StatusHealthy, status[0].Status)
+	assert.Equal(t, BreakerClosed, status[0].Breaker)
+	assert.Zero(t, status[0].ConsecutiveFailures)
+	assert.Equal(t, clock.Now(), status[0].LastSuccess)
+	assert.Equal(t, "provider timeout", status[0].LastError, "the last error is kept")
+}
+
+func TestMonitor_HalfOpenAllowsOneTrial(t *testing.T) {
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	monitor := NewMonitor(WithClock(clock), WithThreshold(1), WithCooldown(time.Minute))
+	monitor.Instrument(&stubProvider{name: "garuda"})
+
+	monitor.mu.Lock()
+	monitor.record(monitor.state("garuda"), errors.New("bad gateway"))
+	monitor.mu.Unlock()
+	assert.False(t, monitor.allow("garuda"))
+
+	clock.Advance(time.Minute)
+	assert.True(t, monitor.allow("garuda"), "the trial search")
+	assert.False(t, monitor.allow("garuda"), "while the trial is in flight")
+}
+
+func TestMonitor_NoBreaker(t *testing.T) {
+	monitor := NewMonitor(WithThreshold(0))
+	stub := &stubProvider{name: "garuda", err: domain.ErrProviderUnavailable}
+	provider := monitor.Instrument(stub)
+
+	for range 10 {
+		_, err := provider.Search(context.Background(), domain.SearchCriteria{})
+		assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+	}
+	assert.Equal(t, 10, stub.searches)
+	status := monitor.Statuses()
+	assert.Equal(t, StatusDegraded, status[0].Status)
+	assert.Equal(t, BreakerClosed, status[0].Breaker)
+	assert.Equal(t, 10, status[0].ConsecutiveFailures)
+}
+
+func TestMonitor_CancelledSearchesNotCounted(t *testing.T) {
+	monitor := NewMonitor(WithThreshold(1))
+	provider := monitor.Instrument(&stubProvider{name: "garuda", err: context.Canceled})
+
+	_, err := provider.Search(context.Background(), domain.SearchCriteria{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StatusUnknown, monitor.Statuses()[0].Status)
+}
+
+func TestMonitor_Check(t *testing.T) {
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	monitor := NewMonitor(WithClock(clock), WithThreshold(1), WithCooldown(time.Hour))
+
+	canary := &stubProvider{name: "garuda"}
+	checked := &checkedProvider{stubProvider: stubProvider{name: "lion_air"}, healthErr: errors.New("status 503")}
+	broken := &stubProvider{name: "batik_air", err: domain.ErrProviderUnavailable}
+	providers := []domain.FlightProvider{canary, checked, broken}
+
+	// garuda's breaker opened on a failed search
+	monitor.mu.Lock()
+	monitor.record(monitor.state("garuda"), domain.ErrProviderTimeout)
+	monitor.mu.Unlock()
+
+	monitor.Check(context.Background(), providers)
+
+	assert.Equal(t, 1, canary.searches, "providers are checked with a canary search")
+	assert.Equal(t, domain.SearchCriteria{
+		Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-22", Passengers: 1, Class: "economy",
+	}, canary.criteria)
+	assert.Zero(t, checked.searches, "health checkers are not searched")
+
+	status := monitor.Statuses()
+	require.Len(t, status, 3)
+	assert.Equal(t, "batik_air", status[0].Provider)
+	assert.Equal(t, StatusUnhealthy, status[0].Status)
+	assert.Equal(t, clock.Now(), status[0].LastCheck)
+
+	assert.Equal(t, "garuda", status[1].Provider)
+	assert.Equal(t, StatusHealthy, status[1].Status, "a successful check closes the breaker")
+	assert.Equal(t, BreakerClosed, status[1].Breaker)
+	assert.Equal(t, clock.Now(), status[1].LastSuccess)
+
+	assert.Equal(t, "lion_air", status[2].Provider)
+	assert.Equal(t, StatusUnhealthy, status[2].Status)
+	assert.Equal(t, "status 503", status[2].LastError)
+}
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// DefaultMemoryMaxEntries is the default capacity of a MemoryCache.
+const DefaultMemoryMaxEntries = 1000
+
+// Eviction reasons reported to an EvictionObserver.
+const (
+	// EvictionCapacity is the least recently used entry making room for a new one
+	EvictionCapacity = "capacity"
+
+	// EvictionExpired is an entry found past its TTL
+	EvictionExpired = "expired"
+)
+
+// EvictionObserver is notified of every entry evicted from a MemoryCache.
+// It is called with the cache locked, so it must not call back into the cache.
+type EvictionObserver func(reason string)
+
+// MemoryOption configures a MemoryCache.
+type MemoryOption func(*MemoryCache)
+
+// WithMemoryClock sets the clock used to expire entries.
+func WithMemoryClock(clock timeutil.Clock) MemoryOption {
+	return func(c *MemoryCache) {
+		c.clock = clock
+	}
+}
+
+// WithEvictionObserver sets the observer notified of evictions.
+func WithEvictionObserver(observer EvictionObserver) MemoryOption {
+	return func(c *MemoryCache) {
+		c.observer = observer
+	}
+}
+
+// MemoryCache is an in-process LRU cache with per-entry TTLs, for
+// single-instance deployments. It holds at most maxEntries entries, evicting
+// the least recently used one to make room. Expired entries are evicted when
+// they are looked up or reach the back of the LRU list. It is safe for
+// concurrent use.
+type MemoryCache struct {
+	maxEntries int
+	clock      timeutil.Clock
+	observer   EvictionObserver
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // front = most recently used
+}
+
+// memoryEntry is an element of the LRU list.
+type memoryEntry struct {
+	key       string
+	value     *domain.CachedSearch
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates an in-memory cache holding at most maxEntries
+// entries (DefaultMemoryMaxEntries if not positive).
+func NewMemoryCache(maxEntries int, opts ...MemoryOption) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryMaxEntries
+	}
+	c := &MemoryCache{
+		maxEntries: maxEntries,
+		clock:      timeutil.NewRealClock(),
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get implements domain.SearchCache.
+func (c *MemoryCache) Get(_ context.Context, key string) (*domain.CachedSearch, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*memoryEntry)
+	if !c.clock.Now().Before(entry.expiresAt) {
+		c.evict(elem, EvictionExpired)
+		return nil, false, nil
+	}
+	c.lru.MoveToFront(elem)
+	return entry.value, true, nil
+}
+
+// Set implements domain.SearchCache. The entry is stored as is, so callers
+// must not modify it afterwards.
+func (c *MemoryCache) Set(_ context.Context, key string, value *domain.CachedSearch, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.clock.Now().Add(ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.lru.MoveToFront(elem)
+		return nil
+	}
+
+	for c.lru.Len() >= c.maxEntries {
+		oldest := c.lru.Back()
+		reason := EvictionCapacity
+		if !c.clock.Now().Before(oldest.Value.(*memoryEntry).expiresAt) {
+			reason = EvictionExpired
+		}
+		c.evict(oldest, reason)
+	}
+	c.entries[key] = c.lru.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	return nil
+}
+
+// Len returns the number of entries held, expired ones included until they
+// are evicted.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// evict removes elem and notifies the observer. Callers must hold c.mu.
+func (c *MemoryCache) evict(elem *list.Element, reason string) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*memoryEntry).key)
+	if c.observer != nil {
+		c.observer(reason)
+	}
+}
+
+// Ensure MemoryCache implements domain.SearchCache at compile time.
+var _ domain.SearchCache = (*MemoryCache)(nil)
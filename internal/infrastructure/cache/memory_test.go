@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+func TestMemoryCache_SetGet(t *testing.T) {
+	c := NewMemoryCache(10)
+	ctx := context.Background()
+
+	_, ok, err := c.Get(ctx, "abc")
+	require.NoError(t, err)
+	assert.False(t, ok, "miss before Set")
+
+	entry := &domain.CachedSearch{Providers: 4}
+	require.NoError(t, c.Set(ctx, "abc", entry, time.Minute))
+	got, ok, err := c.Get(ctx, "abc")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Same(t, entry, got)
+
+	replaced := &domain.CachedSearch{Providers: 3}
+	require.NoError(t, c.Set(ctx, "abc", replaced, time.Minute))
+	got, _, _ = c.Get(ctx, "abc")
+	assert.Same(t, replaced, got)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestMemoryCache_LRUEviction(t *testing.T) {
+	var evictions []string
+	c := NewMemoryCache(2, WithEvictionObserver(func(reason string) { evictions = append(evictions, reason) }))
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "a", &domain.CachedSearch{}, time.Minute))
+	require.NoError(t, c.Set(ctx, "b", &domain.CachedSearch{}, time.Minute))
+	_, ok, _ := c.Get(ctx, "a") // b becomes the least recently used
+	require.True(t, ok)
+	require.NoError(t, c.Set(ctx, "c", &domain.CachedSearch{}, time.Minute))
+
+	_, ok, _ = c.Get(ctx, "b")
+	assert.False(t, ok, "least recently used entry evicted")
+	_, ok, _ = c.Get(ctx, "a")
+	assert.True(t, ok)
+	_, ok, _ = c.Get(ctx, "c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, []string{EvictionCapacity}, evictions)
+}
+
+func TestMemoryCache_TTL(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 15, 8, 0, 0, 0, time.UTC))
+	var evictions []string
+	c := NewMemoryCache(2,
+		WithMemoryClock(clock),
+		WithEvictionObserver(func(reason string) { evictions = append(evictions, reason) }),
+	)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "short", &domain.CachedSearch{}, time.Minute))
+	require.NoError(t, c.Set(ctx, "long", &domain.CachedSearch{}, time.Hour))
+
+	clock.Advance(59 * time.Second)
+	_, ok, _ := c.Get(ctx, "short")
+	assert.True(t, ok, "fresh until the TTL elapses")
+
+	clock.Advance(time.Second)
+	_, ok, _ = c.Get(ctx, "short")
+	assert.False(t, ok, "expired")
+	assert.Equal(t, 1, c.Len(), "expired entry evicted on lookup")
+
+	// An expired entry pushed out of the LRU list counts as expired
+	require.NoError(t, c.Set(ctx, "other", &domain.CachedSearch{}, time.Minute))
+	clock.Advance(2 * time.Hour)
+	require.NoError(t, c.Set(ctx, "new", &domain.CachedSearch{}, time.Minute))
+	assert.Equal(t, []string{EvictionExpired, EvictionExpired}, evictions)
+}
+
+func TestNewMemoryCache_DefaultCapacity(t *testing.T) {
+	assert.Equal(t, DefaultMemoryMaxEntries, NewMemoryCache(0).maxEntries)
+}
@@ -0,0 +1,253 @@
+// Package cache provides search result caches implementing domain.SearchCache.
+package cache
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// Redis defaults.
+const (
+	DefaultRedisPrefix      = "flight-search:search:"
+	DefaultRedisTimeout     = 500 * time.Millisecond
+	DefaultRedisMaxIdleConn = 8
+)
+
+// RedisConfig configures a RedisCache.
+type RedisConfig struct {
+	// Addr is the server address, e.g. localhost:6379
+	Addr string
+
+	// Password authenticates with AUTH (empty = no authentication)
+	Password string
+
+	// DB is the database selected with SELECT
+	DB int
+
+	// Prefix is prepended to every key (empty = DefaultRedisPrefix)
+	Prefix string
+
+	// Timeout bounds dialing and each command (0 = DefaultRedisTimeout)
+	Timeout time.Duration
+
+	// MaxIdleConns is the number of connections kept open between commands
+	// (0 = DefaultRedisMaxIdleConn)
+	MaxIdleConns int
+}
+
+// RedisCache stores search results in Redis as JSON values with a TTL. It
+// speaks the Redis protocol (RESP) directly and keeps a small pool of idle
+// connections. It is safe for concurrent use.
+type RedisCache struct {
+	cfg  RedisConfig
+	idle chan *redisConn
+}
+
+// NewRedisCache creates a Redis-backed cache. Connections are opened lazily,
+// so an unreachable server only makes lookups fail (and searches miss).
+func NewRedisCache(cfg RedisConfig) (*RedisCache, error) {
+	if cfg.Addr == "" {
+		return nil, errors.New("address is required")
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = DefaultRedisPrefix
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultRedisTimeout
+	}
+	if cfg.MaxIdleConns <= 0 {
+		cfg.MaxIdleConns = DefaultRedisMaxIdleConn
+	}
+	return &RedisCache{cfg: cfg, idle: make(chan *redisConn, cfg.MaxIdleConns)}, nil
+}
+
+// Get implements domain.SearchCache.
+func (c *RedisCache) Get(ctx context.Context, key string) (*domain.CachedSearch, bool, error) {
+	reply, err := c.do(ctx, "GET", c.cfg.Prefix+key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	data, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis GET: unexpected reply %v", reply)
+	}
+
+	var entry domain.CachedSearch
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false, fmt.Errorf("redis GET: decode entry: %w", err)
+	}
+	return &entry, true, nil
+}
+
+// Set implements domain.SearchCache.
+func (c *RedisCache) Set(ctx context.Context, key string, entry *domain.CachedSearch, ttl time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("redis SET: encode entry: %w", err)
+	}
+	ms := max(ttl.Milliseconds(), 1)
+	_, err = c.do(ctx, "SET", c.cfg.Prefix+key, string(data), "PX", strconv.FormatInt(ms, 10))
+	return err
+}
+
+// Close closes the idle connections.
+func (c *RedisCache) Close() error {
+	for {
+		select {
+		case conn := <-c.idle:
+			conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do runs a command on a pooled connection. Connections that fail are
+// discarded rather than returned to the pool.
+func (c *RedisCache) do(ctx context.Context, args ...string) (any, error) {
+	conn, err := c.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(ctx, c.cfg.Timeout, args...)
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		conn.Close()
+		return nil, fmt.Errorf("redis %s: %w", args[0], err)
+	}
+	c.release(conn)
+	if err != nil {
+		return nil, fmt.Errorf("redis %s: %w", args[0], err)
+	}
+	return reply, nil
+}
+
+// conn returns an idle connection, or dials and prepares a new one.
+func (c *RedisCache) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-c.idle:
+		return conn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: c.cfg.Timeout}
+	nc, err := dialer.DialContext(ctx, "tcp", c.cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("redis dial: %w", err)
+	}
+	conn := &redisConn{Conn: nc, r: bufio.NewReader(nc)}
+
+	if c.cfg.Password != "" {
+		if _, err := conn.do(ctx, c.cfg.Timeout, "AUTH", c.cfg.Password); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("redis AUTH: %w", err)
+		}
+	}
+	if c.cfg.DB != 0 {
+		if _, err := conn.do(ctx, c.cfg.Timeout, "SELECT", strconv.Itoa(c.cfg.DB)); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("redis SELECT: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// release returns a connection to the pool, closing it if the pool is full.
+func (c *RedisCache) release(conn *redisConn) {
+	select {
+	case c.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+// redisConn is a connection speaking RESP.
+type redisConn struct {
+	net.Conn
+	r  *bufio.Reader
+	mu sync.Mutex
+}
+
+// redisError is an error reply from the server.
+type redisError string
+
+func (e redisError) Error() string { return string(e) }
+
+// do writes a command and reads its reply, within timeout and ctx's deadline.
+func (c *redisConn) do(ctx context.Context, timeout time.Duration, args ...string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.Conn, b.String()); err != nil {
+		return nil, err
+	}
+	return readReply(c.r)
+}
+
+// readReply reads a RESP reply: a string, an integer, a bulk string ([]byte,
+// nil for a null bulk string) or an error reply (redisError).
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid bulk length %q", line[1:])
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	default:
+		return nil, fmt.Errorf("unsupported reply %q", line)
+	}
+}
+
+// Ensure RedisCache implements domain.SearchCache at compile time.
+var _ domain.SearchCache = (*RedisCache)(nil)
@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// fakeRedis is a minimal RESP server supporting AUTH, SELECT, GET and SET PX.
+type fakeRedis struct {
+	password string
+
+	mu       sync.Mutex
+	data     map[string]string
+	ttls     map[string]string
+	commands []string
+	dials    int
+}
+
+func startFakeRedis(t *testing.T, password string) (*fakeRedis, string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	srv := &fakeRedis{password: password, data: map[string]string{}, ttls: map[string]string{}}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			srv.mu.Lock()
+			srv.dials++
+			srv.mu.Unlock()
+			go srv.serve(conn)
+		}
+	}()
+	return srv, ln.Addr().String()
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authed := s.password == ""
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.commands = append(s.commands, args[0])
+		var reply string
+		switch {
+		case args[0] == "AUTH":
+			authed = args[1] == s.password
+			reply = "+OK\r\n"
+			if !authed {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authed:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case args[0] == "SELECT":
+			reply = "+OK\r\n"
+		case args[0] == "GET":
+			if v, ok := s.data[args[1]]; ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case args[0] == "SET" && len(args) == 5 && args[3] == "PX":
+			s.data[args[1]] = args[2]
+			s.ttls[args[1]] = args[4]
+			reply = "+OK\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		s.mu.Unlock()
+
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if _, err := r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+func TestRedisCache_SetGet(t *testing.T) {
+	srv, addr := startFakeRedis(t, "secret")
+	c, err := NewRedisCache(RedisConfig{Addr: addr, Password: "secret", DB: 3, Prefix: "test:"})
+	require.NoError(t, err)
+	defer c.Close()
+	ctx := context.Background()
+
+	_, ok, err := c.Get(ctx, "abc")
+	require.NoError(t, err)
+	assert.False(t, ok, "miss before Set")
+
+	entry := &domain.CachedSearch{
+		Flights:   []domain.Flight{{ID: "GA400", Provider: "garuda_indonesia", Price: domain.PriceInfo{Amount: 1250000, Currency: "IDR"}}},
+		Providers: 4,
+		CachedAt:  time.Date(2025, 12, 15, 8, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, c.Set(ctx, "abc", entry, 90*time.Second))
+
+	got, ok, err := c.Get(ctx, "abc")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, entry.Flights[0].ID, got.Flights[0].ID)
+	assert.Equal(t, entry.Flights[0].Price, got.Flights[0].Price)
+	assert.Equal(t, 4, got.Providers)
+	assert.True(t, entry.CachedAt.Equal(got.CachedAt))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Equal(t, "90000", srv.ttls["test:abc"], "TTL sent in milliseconds")
+	assert.Equal(t, []string{"AUTH", "SELECT", "GET", "SET", "GET"}, srv.commands)
+	assert.Equal(t, 1, srv.dials, "connection reused")
+}
+
+func TestRedisCache_Errors(t *testing.T) {
+	_, err := NewRedisCache(RedisConfig{})
+	assert.EqualError(t, err, "address is required")
+
+	_, addr := startFakeRedis(t, "secret")
+	c, err := NewRedisCache(RedisConfig{Addr: addr, Password: "wrong"})
+	require.NoError(t, err)
+	_, _, err = c.Get(context.Background(), "abc")
+	assert.ErrorContains(t, err, "redis AUTH: WRONGPASS")
+
+	// Nothing listens on a closed listener's address
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := ln.Addr().String()
+	ln.Close()
+	c, err = NewRedisCache(RedisConfig{Addr: closedAddr, Timeout: 100 * time.Millisecond})
+	require.NoError(t, err)
+	err = c.Set(context.Background(), "abc", &domain.CachedSearch{}, time.Minute)
+	assert.ErrorContains(t, err, "redis dial")
+}
+
+func TestRedisCache_CorruptEntry(t *testing.T) {
+	srv, addr := startFakeRedis(t, "")
+	srv.data[DefaultRedisPrefix+"abc"] = "not json"
+
+	c, err := NewRedisCache(RedisConfig{Addr: addr})
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, ok, err := c.Get(context.Background(), "abc")
+	assert.False(t, ok)
+	assert.ErrorContains(t, err, "decode entry")
+}
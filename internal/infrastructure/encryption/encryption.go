@@ -0,0 +1,185 @@
+// Package encryption provides AES-256-GCM encryption for data at rest.
+//
+// Data is encrypted with the keyring's primary key and tagged with that key's
+// ID, so rotating keys only requires adding a new primary key: data written
+// under older keys stays readable while those keys remain in the keyring, and
+// is re-encrypted under the primary key the next time it is written.
+//
+// Encrypted values are text envelopes of the form
+//
+//	enc:v1:<key id>:<base64url(nonce || ciphertext)>
+//
+// so they can be stored wherever plain text can.
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeySize is the required key length in bytes (AES-256).
+const KeySize = 32
+
+// envelopePrefix marks encrypted data.
+const envelopePrefix = "enc:v1:"
+
+// Sentinel errors returned by Decrypt.
+var (
+	// ErrNotEncrypted is returned when the data is not an encryption envelope
+	ErrNotEncrypted = errors.New("data is not encrypted")
+
+	// ErrUnknownKey is returned when the data was encrypted with a key that is not in the keyring
+	ErrUnknownKey = errors.New("unknown encryption key")
+
+	// ErrDecrypt is returned when the data is corrupt or was tampered with
+	ErrDecrypt = errors.New("decrypt data")
+)
+
+// Key is a named AES-256 key.
+type Key struct {
+	// ID identifies the key in encrypted data; it must not contain ':'
+	ID string
+
+	// Secret is the raw key material (KeySize bytes)
+	Secret []byte
+}
+
+// Keyring encrypts with a primary key and decrypts with any of its keys.
+// It is safe for concurrent use.
+type Keyring struct {
+	primary string
+	aeads   map[string]cipher.AEAD
+}
+
+// NewKeyring creates a keyring. The first key is the primary key used for
+// encryption; the remaining keys are only used to decrypt existing data.
+func NewKeyring(keys ...Key) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one key is required")
+	}
+
+	k := &Keyring{primary: keys[0].ID, aeads: make(map[string]cipher.AEAD, len(keys))}
+	for _, key := range keys {
+		if key.ID == "" || strings.Contains(key.ID, ":") {
+			return nil, fmt.Errorf("invalid key ID %q", key.ID)
+		}
+		if len(key.Secret) != KeySize {
+			return nil, fmt.Errorf("key %s: must be %d bytes, got %d", key.ID, KeySize, len(key.Secret))
+		}
+		if _, exists := k.aeads[key.ID]; exists {
+			return nil, fmt.Errorf("key %s: duplicate ID", key.ID)
+		}
+
+		block, err := aes.NewCipher(key.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key.ID, err)
+		}
+		k.aeads[key.ID] = aead
+	}
+	return k, nil
+}
+
+// ParseKeyring creates a keyring from a comma-separated list of id:base64
+// entries (standard base64 of KeySize bytes). The first entry is the primary key.
+func ParseKeyring(spec string) (*Keyring, error) {
+	var keys []Key
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid key entry %q: expected id:base64", id)
+		}
+		secret, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: invalid base64: %w", id, err)
+		}
+		keys = append(keys, Key{ID: id, Secret: secret})
+	}
+	return NewKeyring(keys...)
+}
+
+// PrimaryKeyID returns the ID of the key used for encryption.
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primary
+}
+
+// Encrypt encrypts plaintext with the primary key. additionalData is
+// authenticated but not encrypted; the same value must be passed to Decrypt.
+func (k *Keyring) Encrypt(plaintext, additionalData []byte) ([]byte, error) {
+	aead := k.aeads[k.primary]
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, additionalData)
+
+	out := make([]byte, 0, len(envelopePrefix)+len(k.primary)+1+base64.RawURLEncoding.EncodedLen(len(sealed)))
+	out = append(out, envelopePrefix...)
+	out = append(out, k.primary...)
+	out = append(out, ':')
+	return base64.RawURLEncoding.AppendEncode(out, sealed), nil
+}
+
+// Decrypt decrypts data produced by Encrypt with any key in the keyring.
+func (k *Keyring) Decrypt(data, additionalData []byte) ([]byte, error) {
+	id, payload, err := splitEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, ok := k.aeads[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(string(payload))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// NeedsRotation reports whether data is not encrypted with the primary key,
+// i.e. whether it should be rewritten.
+func (k *Keyring) NeedsRotation(data []byte) bool {
+	id, _, err := splitEnvelope(data)
+	return err != nil || id != k.primary
+}
+
+// IsEncrypted reports whether data is an encryption envelope.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(envelopePrefix))
+}
+
+// splitEnvelope returns the key ID and encoded payload of an envelope.
+func splitEnvelope(data []byte) (string, []byte, error) {
+	if !IsEncrypted(data) {
+		return "", nil, ErrNotEncrypted
+	}
+	id, payload, ok := bytes.Cut(data[len(envelopePrefix):], []byte(":"))
+	if !ok {
+		return "", nil, ErrDecrypt
+	}
+	return string(id), payload, nil
+}
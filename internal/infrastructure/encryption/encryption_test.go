@@ -0,0 +1,132 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(id string, fill byte) Key {
+	return Key{ID: id, Secret: bytes.Repeat([]byte{fill}, KeySize)}
+}
+
+func TestKeyring_RoundTrip(t *testing.T) {
+	k, err := NewKeyring(testKey("k1", 1))
+	require.NoError(t, err)
+
+	plaintext := []byte(`{"email":"traveller@example.com"}`)
+	data, err := k.Encrypt(plaintext, []byte("jobs"))
+	require.NoError(t, err)
+
+	assert.True(t, IsEncrypted(data))
+	assert.True(t, strings.HasPrefix(string(data), "enc:v1:k1:"))
+	assert.NotContains(t, string(data), "traveller")
+
+	got, err := k.Decrypt(data, []byte("jobs"))
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, got)
+
+	again, err := k.Encrypt(plaintext, []byte("jobs"))
+	require.NoError(t, err)
+	assert.NotEqual(t, data, again, "nonces are random")
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old, err := NewKeyring(testKey("2024", 1))
+	require.NoError(t, err)
+	data, err := old.Encrypt([]byte("secret"), nil)
+	require.NoError(t, err)
+
+	rotated, err := NewKeyring(testKey("2025", 2), testKey("2024", 1))
+	require.NoError(t, err)
+	assert.Equal(t, "2025", rotated.PrimaryKeyID())
+	assert.True(t, rotated.NeedsRotation(data))
+
+	got, err := rotated.Decrypt(data, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(got))
+
+	fresh, err := rotated.Encrypt(got, nil)
+	require.NoError(t, err)
+	assert.False(t, rotated.NeedsRotation(fresh))
+	assert.True(t, rotated.NeedsRotation([]byte("plain")))
+
+	// Once the old key is retired, old data can no longer be read
+	retired, err := NewKeyring(testKey("2025", 2))
+	require.NoError(t, err)
+	_, err = retired.Decrypt(data, nil)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyring_DecryptErrors(t *testing.T) {
+	k, err := NewKeyring(testKey("k1", 1))
+	require.NoError(t, err)
+	data, err := k.Encrypt([]byte("secret"), []byte("jobs"))
+	require.NoError(t, err)
+
+	tampered := append([]byte(nil), data...)
+	tampered[len(tampered)-2] ^= 'A' ^ 'B'
+
+	tests := []struct {
+		name    string
+		data    []byte
+		aad     []byte
+		wantErr error
+	}{
+		{"plaintext", []byte(`[]`), nil, ErrNotEncrypted},
+		{"missing key ID", []byte("enc:v1:abc"), nil, ErrDecrypt},
+		{"invalid base64", []byte("enc:v1:k1:!!"), nil, ErrDecrypt},
+		{"truncated", []byte("enc:v1:k1:AAAA"), nil, ErrDecrypt},
+		{"tampered", tampered, []byte("jobs"), ErrDecrypt},
+		{"wrong additional data", data, []byte("alerts"), ErrDecrypt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := k.Decrypt(tt.data, tt.aad)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewKeyring_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []Key
+		errMsg string
+	}{
+		{"no keys", nil, "at least one key is required"},
+		{"empty ID", []Key{testKey("", 1)}, "invalid key ID"},
+		{"colon in ID", []Key{testKey("a:b", 1)}, "invalid key ID"},
+		{"short key", []Key{{ID: "k1", Secret: []byte("short")}}, "must be 32 bytes, got 5"},
+		{"duplicate ID", []Key{testKey("k1", 1), testKey("k1", 2)}, "duplicate ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyring(tt.keys...)
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, KeySize))
+
+	k, err := ParseKeyring("new:" + secret + ", old:" + secret)
+	require.NoError(t, err)
+	assert.Equal(t, "new", k.PrimaryKeyID())
+
+	_, err = ParseKeyring("")
+	assert.ErrorContains(t, err, "at least one key is required")
+
+	_, err = ParseKeyring("nocolon")
+	assert.ErrorContains(t, err, "expected id:base64")
+
+	_, err = ParseKeyring("k1:not-base64!")
+	assert.ErrorContains(t, err, "invalid base64")
+}
@@ -0,0 +1,20 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// CacheEvictions counts entries evicted from the in-memory search cache, by reason.
+var CacheEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "cache",
+	Name:      "evictions_total",
+	Help:      "Number of entries evicted from the in-memory search cache.",
+}, []string{"reason"})
+
+func init() {
+	Registry.MustRegister(CacheEvictions)
+}
+
+// ObserveCacheEviction records an entry evicted from the in-memory search cache.
+func ObserveCacheEviction(reason string) {
+	CacheEvictions.WithLabelValues(reason).Inc()
+}
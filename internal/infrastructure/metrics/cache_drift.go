@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Cache drift flight status label values.
+const (
+	CacheDriftMatched = "matched"
+	CacheDriftStale   = "stale"
+	CacheDriftMissing = "missing"
+)
+
+// Cache drift metrics, comparing cached results with a live search.
+var (
+	// CacheDriftChecks counts cache hits searched live as well, by outcome.
+	CacheDriftChecks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "cache_drift",
+		Name:      "checks_total",
+		Help:      "Number of cache hits searched live as well to measure drift.",
+	}, []string{"outcome"})
+
+	// CacheDriftFlights counts the flights compared: cached flights found
+	// live, cached flights no longer found live, and live flights missing from
+	// the cache.
+	CacheDriftFlights = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "cache_drift",
+		Name:      "flights_total",
+		Help:      "Number of flights compared between cached and live results, by status.",
+	}, []string{"status"})
+
+	// CacheDriftPrice observes the mean relative price drift of a check's
+	// matched flights ((live - cached) / cached).
+	CacheDriftPrice = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "cache_drift",
+		Name:      "price_ratio",
+		Help:      "Mean relative price drift of live flights from the cached ones.",
+		Buckets:   []float64{-0.5, -0.2, -0.1, -0.05, -0.01, 0, 0.01, 0.05, 0.1, 0.2, 0.5},
+	})
+
+	// CacheDriftCoverage observes the share of live flights the cached
+	// results offered.
+	CacheDriftCoverage = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "cache_drift",
+		Name:      "coverage_ratio",
+		Help:      "Share of live flights offered by the cached results.",
+		Buckets:   []float64{0.5, 0.75, 0.9, 0.95, 0.99, 1},
+	})
+
+	// CacheDriftAge observes the age of the cached results checked, so
+	// drift can be related to CACHE_TTL.
+	CacheDriftAge = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "cache_drift",
+		Name:      "age_seconds",
+		Help:      "Age of the cached results checked for drift.",
+		Buckets:   []float64{15, 30, 60, 120, 300, 600, 1800, 3600},
+	})
+)
+
+func init() {
+	Registry.MustRegister(CacheDriftChecks, CacheDriftFlights, CacheDriftPrice, CacheDriftCoverage, CacheDriftAge)
+}
+
+// ObserveCacheDrift records the comparison of cached results aged age with a
+// live search. priceDrift is only observed when at least one matched flight
+// could be priced.
+func ObserveCacheDrift(err error, age time.Duration, cached, live, matched int, priceDrift float64, priced bool) {
+	CacheDriftChecks.WithLabelValues(ProviderOutcome(err)).Inc()
+	if err != nil {
+		return
+	}
+	CacheDriftAge.Observe(age.Seconds())
+	CacheDriftFlights.WithLabelValues(CacheDriftMatched).Add(float64(matched))
+	CacheDriftFlights.WithLabelValues(CacheDriftStale).Add(float64(cached - matched))
+	CacheDriftFlights.WithLabelValues(CacheDriftMissing).Add(float64(live - matched))
+	if live > 0 {
+		CacheDriftCoverage.Observe(float64(matched) / float64(live))
+	}
+	if priced {
+		CacheDriftPrice.Observe(priceDrift)
+	}
+}
@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveCacheDrift(t *testing.T) {
+	ObserveCacheDrift(nil, time.Minute, 4, 5, 3, 0.02, true)
+	ObserveCacheDrift(errors.New("all providers failed"), time.Minute, 0, 0, 0, 0, false)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(CacheDriftChecks.WithLabelValues(OutcomeSuccess)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(CacheDriftChecks.WithLabelValues(OutcomeError)))
+	assert.Equal(t, 3.0, testutil.ToFloat64(CacheDriftFlights.WithLabelValues(CacheDriftMatched)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(CacheDriftFlights.WithLabelValues(CacheDriftStale)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(CacheDriftFlights.WithLabelValues(CacheDriftMissing)))
+	assert.Equal(t, 1, testutil.CollectAndCount(CacheDriftPrice))
+	assert.Equal(t, 1, testutil.CollectAndCount(CacheDriftCoverage))
+	assert.Equal(t, 1, testutil.CollectAndCount(CacheDriftAge))
+}
@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveCacheEviction(t *testing.T) {
+	before := testutil.ToFloat64(CacheEvictions.WithLabelValues("cache_test"))
+	ObserveCacheEviction("cache_test")
+	ObserveCacheEviction("cache_test")
+	assert.Equal(t, before+2, testutil.ToFloat64(CacheEvictions.WithLabelValues("cache_test")))
+}
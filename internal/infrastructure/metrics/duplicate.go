@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Duplicate search metrics.
+var (
+	// DuplicateSearches counts the searches of API key tenants as they arrive,
+	// before coalescing, by whether they repeat an identical search of the
+	// tenant issued shortly before.
+	DuplicateSearches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "duplicate",
+		Name:      "searches_total",
+		Help:      "Number of tenant searches, by whether they repeat a recent identical search.",
+	}, []string{"tenant", "duplicate"})
+
+	// DuplicateSearchInterval observes the time between a duplicate search
+	// and the previous identical search of the tenant.
+	DuplicateSearchInterval = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "duplicate",
+		Name:      "interval_seconds",
+		Help:      "Time since the previous identical search of duplicate tenant searches.",
+		Buckets:   []float64{0.1, 0.5, 1, 2, 5, 10, 15, 30, 60, 120, 300},
+	}, []string{"tenant"})
+)
+
+func init() {
+	Registry.MustRegister(DuplicateSearches, DuplicateSearchInterval)
+}
+
+// ObserveDuplicateSearch records a search of the tenant and, for duplicates,
+// the time since the previous identical search.
+func ObserveDuplicateSearch(tenant string, duplicate bool, since time.Duration) {
+	DuplicateSearches.WithLabelValues(tenant, strconv.FormatBool(duplicate)).Inc()
+	if duplicate {
+		DuplicateSearchInterval.WithLabelValues(tenant).Observe(since.Seconds())
+	}
+}
@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveDuplicateSearch(t *testing.T) {
+	ObserveDuplicateSearch("acme", false, 0)
+	ObserveDuplicateSearch("acme", true, 5*time.Second)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(DuplicateSearches.WithLabelValues("acme", "false")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(DuplicateSearches.WithLabelValues("acme", "true")))
+	assert.Equal(t, 1, testutil.CollectAndCount(DuplicateSearchInterval))
+}
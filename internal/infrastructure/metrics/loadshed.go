@@ -0,0 +1,43 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Load shedding transition label values.
+const (
+	LoadShedShed    = "shed"
+	LoadShedRestore = "restore"
+)
+
+// Load shedding metrics.
+var (
+	// LoadShedFeatureDisabled is 1 for each optional feature currently shed.
+	LoadShedFeatureDisabled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "loadshed",
+		Name:      "feature_disabled",
+		Help:      "Whether an optional feature is currently shed under load (1) or enabled (0).",
+	}, []string{"feature"})
+
+	// LoadShedTransitions counts optional features shed or restored.
+	LoadShedTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "loadshed",
+		Name:      "transitions_total",
+		Help:      "Number of times an optional feature was shed or restored.",
+	}, []string{"feature", "action"})
+)
+
+func init() {
+	Registry.MustRegister(LoadShedFeatureDisabled, LoadShedTransitions)
+}
+
+// ObserveLoadShed records an optional feature being shed or restored.
+func ObserveLoadShed(feature string, shed bool) {
+	if shed {
+		LoadShedFeatureDisabled.WithLabelValues(feature).Set(1)
+		LoadShedTransitions.WithLabelValues(feature, LoadShedShed).Inc()
+		return
+	}
+	LoadShedFeatureDisabled.WithLabelValues(feature).Set(0)
+	LoadShedTransitions.WithLabelValues(feature, LoadShedRestore).Inc()
+}
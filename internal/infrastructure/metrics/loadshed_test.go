@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveLoadShed(t *testing.T) {
+	ObserveLoadShed("loadshed_test", true)
+	assert.Equal(t, 1.0, testutil.ToFloat64(LoadShedFeatureDisabled.WithLabelValues("loadshed_test")))
+
+	ObserveLoadShed("loadshed_test", false)
+	assert.Equal(t, 0.0, testutil.ToFloat64(LoadShedFeatureDisabled.WithLabelValues("loadshed_test")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(LoadShedTransitions.WithLabelValues("loadshed_test", LoadShedShed)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(LoadShedTransitions.WithLabelValues("loadshed_test", LoadShedRestore)))
+}
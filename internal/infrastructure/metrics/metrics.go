@@ -0,0 +1,129 @@
+// Package metrics provides the Prometheus collectors exported by the service.
+// All collectors are registered on a dedicated registry exposed via Handler,
+// so tests and tools can inspect them without touching the global default registry.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace is the common prefix for all service metrics.
+const Namespace = "flight_search"
+
+// Registry is the registry all service collectors are registered on.
+var Registry = prometheus.NewRegistry()
+
+// Streaming metrics.
+var (
+	// StreamConnections tracks the number of open streaming connections by transport.
+	StreamConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "stream",
+		Name:      "connections",
+		Help:      "Number of open streaming connections.",
+	}, []string{"transport"})
+
+	// StreamUpdatesSent counts updates delivered to streaming clients.
+	StreamUpdatesSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "stream",
+		Name:      "updates_sent_total",
+		Help:      "Number of updates delivered to streaming clients.",
+	}, []string{"transport"})
+
+	// StreamUpdatesDropped counts intermediate updates discarded because the client read too slowly.
+	StreamUpdatesDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "stream",
+		Name:      "updates_dropped_total",
+		Help:      "Number of intermediate updates dropped or coalesced due to slow clients.",
+	}, []string{"transport", "reason"})
+)
+
+// Provider metrics.
+var (
+	// ProviderLatency observes how long each provider search took, by outcome.
+	// Observations made while serving a sampled trace carry a trace_id exemplar.
+	ProviderLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "provider",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of provider search requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider", "outcome"})
+
+	// ProviderRetries counts provider searches retried after a transient error.
+	ProviderRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "provider",
+		Name:      "retries_total",
+		Help:      "Number of provider searches retried after a transient error.",
+	}, []string{"provider"})
+)
+
+// Server metrics.
+var (
+	// ConnectionsRejected counts requests rejected because a connection or
+	// concurrent search limit was reached.
+	ConnectionsRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "server",
+		Name:      "connections_rejected_total",
+		Help:      "Number of requests rejected with 503 because a connection or concurrent search limit was reached.",
+	}, []string{"limit"})
+)
+
+// Search metrics.
+var (
+	// SearchesAborted counts searches abandoned because the client disconnected.
+	SearchesAborted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "search",
+		Name:      "aborted_total",
+		Help:      "Number of searches aborted because the client cancelled the request.",
+	})
+
+	// FilterRejections counts flights removed from search results, by filter.
+	FilterRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "search",
+		Name:      "filter_rejections_total",
+		Help:      "Number of flights removed from search results by each filter.",
+	}, []string{"filter"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		StreamConnections,
+		StreamUpdatesSent,
+		StreamUpdatesDropped,
+		ConnectionsRejected,
+		SearchesAborted,
+		FilterRejections,
+		ProviderLatency,
+		ProviderRetries,
+	)
+}
+
+// ObserveFilterRejections records the flights each filter removed from a
+// search's results.
+func ObserveFilterRejections(rejections map[string]int) {
+	for filter, n := range rejections {
+		FilterRejections.WithLabelValues(filter).Add(float64(n))
+	}
+}
+
+// Handler returns an HTTP handler serving the registry in the Prometheus exposition format.
+// Scrapers that negotiate OpenMetrics also receive exemplars.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		Registry:          Registry,
+		EnableOpenMetrics: true,
+	})
+}
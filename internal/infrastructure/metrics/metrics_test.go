@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ExposesServiceMetrics(t *testing.T) {
+	StreamUpdatesDropped.WithLabelValues("sse", "coalesced").Inc()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `flight_search_stream_updates_dropped_total{reason="coalesced",transport="sse"}`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestObserveFilterRejections(t *testing.T) {
+	before := testutil.ToFloat64(FilterRejections.WithLabelValues("metrics_test"))
+	ObserveFilterRejections(map[string]int{"metrics_test": 3})
+	ObserveFilterRejections(nil)
+	assert.Equal(t, before+3, testutil.ToFloat64(FilterRejections.WithLabelValues("metrics_test")))
+}
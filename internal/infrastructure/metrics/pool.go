@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/workerpool"
+)
+
+// ProviderPoolRejected counts provider searches rejected because the
+// provider's worker pool queue was full.
+var ProviderPoolRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "provider_pool",
+	Name:      "rejected_total",
+	Help:      "Number of provider searches rejected because the provider's worker pool queue was full.",
+}, []string{"provider"})
+
+func init() {
+	Registry.MustRegister(ProviderPoolRejected)
+}
+
+// ObserveProviderPoolRejection records a provider search rejected by its worker pool.
+func ObserveProviderPoolRejection(provider string) {
+	ProviderPoolRejected.WithLabelValues(provider).Inc()
+}
+
+// RegisterProviderPool exports the utilization of a provider's worker pool as
+// the flight_search_provider_pool_workers, _busy_workers and _queued gauges.
+// stats is called on every scrape.
+func RegisterProviderPool(provider string, stats func() workerpool.Stats) error {
+	gauges := []struct {
+		name  string
+		help  string
+		value func(workerpool.Stats) int
+	}{
+		{"workers", "Number of workers in the provider's worker pool.", func(s workerpool.Stats) int { return s.Size }},
+		{"busy_workers", "Number of workers of the provider's pool running a search.", func(s workerpool.Stats) int { return s.Busy }},
+		{"queued", "Number of provider searches waiting for a worker.", func(s workerpool.Stats) int { return s.Queued }},
+	}
+	for _, g := range gauges {
+		err := Registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   Namespace,
+			Subsystem:   "provider_pool",
+			Name:        g.name,
+			Help:        g.help,
+			ConstLabels: prometheus.Labels{"provider": provider},
+		}, func() float64 {
+			return float64(g.value(stats()))
+		}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
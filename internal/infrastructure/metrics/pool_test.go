@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/workerpool"
+)
+
+func TestRegisterProviderPool(t *testing.T) {
+	stats := workerpool.Stats{Size: 4, Busy: 3, Queued: 2}
+	require.NoError(t, RegisterProviderPool("pool_test", func() workerpool.Stats { return stats }))
+	assert.Error(t, RegisterProviderPool("pool_test", func() workerpool.Stats { return stats }), "duplicate registration")
+
+	stats.Busy = 4
+	families, err := Registry.Gather()
+	require.NoError(t, err)
+
+	got := map[string]float64{}
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "provider" && label.GetValue() == "pool_test" && m.GetGauge() != nil {
+					got[mf.GetName()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"flight_search_provider_pool_workers":      4,
+		"flight_search_provider_pool_busy_workers": 4,
+		"flight_search_provider_pool_queued":       2,
+	}, got)
+}
+
+func TestObserveProviderPoolRejection(t *testing.T) {
+	ObserveProviderPoolRejection("pool_rejected")
+	assert.Equal(t, 1.0, testutil.ToFloat64(ProviderPoolRejected.WithLabelValues("pool_rejected")))
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/tracing"
+)
+
+// Provider outcome label values.
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+	OutcomeTimeout = "timeout"
+)
+
+// ExemplarTraceIDLabel is the exemplar label holding the trace ID.
+const ExemplarTraceIDLabel = "trace_id"
+
+// ObserveProviderLatency records a provider search duration. When ctx carries a
+// sampled trace context, the observation is stored with a trace_id exemplar so
+// dashboards can link a latency spike to an example trace.
+func ObserveProviderLatency(ctx context.Context, provider, outcome string, d time.Duration) {
+	observer := ProviderLatency.WithLabelValues(provider, outcome)
+
+	if tc, ok := tracing.FromContext(ctx); ok && tc.Sampled {
+		if eo, ok := observer.(prometheus.ExemplarObserver); ok {
+			eo.ObserveWithExemplar(d.Seconds(), prometheus.Labels{ExemplarTraceIDLabel: tc.TraceID})
+			return
+		}
+	}
+	observer.Observe(d.Seconds())
+}
+
+// ObserveProviderRetry records a retry of a provider search.
+func ObserveProviderRetry(provider string) {
+	ProviderRetries.WithLabelValues(provider).Inc()
+}
+
+// ProviderOutcome classifies a provider search error as an outcome label value.
+func ProviderOutcome(err error) string {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, domain.ErrProviderTimeout):
+		return OutcomeTimeout
+	default:
+		return OutcomeError
+	}
+}
+
+// instrumentedProvider records the latency of every search made through the wrapped provider.
+type instrumentedProvider struct {
+	domain.FlightProvider
+}
+
+// InstrumentProvider wraps a provider so its search latency is recorded in ProviderLatency.
+func InstrumentProvider(p domain.FlightProvider) domain.FlightProvider {
+	return instrumentedProvider{FlightProvider: p}
+}
+
+// InstrumentProviders wraps each provider with InstrumentProvider.
+func InstrumentProviders(providers []domain.FlightProvider) []domain.FlightProvider {
+	wrapped := make([]domain.FlightProvider, len(providers))
+	for i, p := range providers {
+		wrapped[i] = InstrumentProvider(p)
+	}
+	return wrapped
+}
+
+// Search implements domain.FlightProvider.
+func (p instrumentedProvider) Search(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	return p.SearchWithOptions(ctx, criteria, domain.ProviderSearchOptions{})
+}
+
+// SearchWithOptions implements domain.FilteringProvider.
+func (p instrumentedProvider) SearchWithOptions(ctx context.Context, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) ([]domain.Flight, error) {
+	start := time.Now()
+	flights, err := domain.SearchWithOptions(ctx, p.FlightProvider, criteria, opts)
+	ObserveProviderLatency(ctx, p.Name(), ProviderOutcome(err), time.Since(start))
+	return flights, err
+}
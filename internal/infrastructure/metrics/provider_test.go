@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/tracing"
+)
+
+// stubProvider is a minimal FlightProvider for instrumentation tests.
+type stubProvider struct {
+	name string
+	err  error
+}
+
+func (p stubProvider) Name() string { return p.name }
+
+func (p stubProvider) Search(context.Context, domain.SearchCriteria) ([]domain.Flight, error) {
+	return nil, p.err
+}
+
+func scrapeOpenMetrics(t *testing.T) string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestInstrumentProvider_ExemplarForSampledTrace(t *testing.T) {
+	ctx := tracing.WithTraceContext(context.Background(), tracing.TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+		Sampled: true,
+	})
+
+	p := InstrumentProvider(stubProvider{name: "exemplar_sampled"})
+	_, err := p.Search(ctx, domain.SearchCriteria{})
+	require.NoError(t, err)
+	assert.Equal(t, "exemplar_sampled", p.Name())
+
+	body := scrapeOpenMetrics(t)
+	assert.Contains(t, body, `flight_search_provider_request_duration_seconds_count{outcome="success",provider="exemplar_sampled"} 1`)
+	assert.Contains(t, body, `# {trace_id="4bf92f3577b34da6a3ce929d0e0e4736"}`)
+}
+
+func TestInstrumentProvider_NoExemplarWithoutSampledTrace(t *testing.T) {
+	ctx := tracing.WithTraceContext(context.Background(), tracing.TraceContext{
+		TraceID: "80f198ee56343ba864fe8b2a57d3eff7",
+		SpanID:  "e457b5a2e4d86bd1",
+	})
+
+	providers := InstrumentProviders([]domain.FlightProvider{
+		stubProvider{name: "exemplar_unsampled", err: errors.New("down")},
+	})
+	_, err := providers[0].Search(ctx, domain.SearchCriteria{})
+	require.Error(t, err)
+
+	body := scrapeOpenMetrics(t)
+	assert.Contains(t, body, `flight_search_provider_request_duration_seconds_count{outcome="error",provider="exemplar_unsampled"} 1`)
+	assert.NotContains(t, body, "80f198ee56343ba864fe8b2a57d3eff7")
+}
+
+func TestProviderOutcome(t *testing.T) {
+	assert.Equal(t, OutcomeSuccess, ProviderOutcome(nil))
+	assert.Equal(t, OutcomeTimeout, ProviderOutcome(context.DeadlineExceeded))
+	assert.Equal(t, OutcomeTimeout, ProviderOutcome(domain.ErrProviderTimeout))
+	assert.Equal(t, OutcomeError, ProviderOutcome(errors.New("boom")))
+}
+
+func TestObserveProviderLatency_WithoutTrace(t *testing.T) {
+	ObserveProviderLatency(context.Background(), "exemplar_plain", OutcomeTimeout, 2*time.Second)
+
+	body := scrapeOpenMetrics(t)
+	assert.Contains(t, body, `flight_search_provider_request_duration_seconds_count{outcome="timeout",provider="exemplar_plain"} 1`)
+}
+
+func TestObserveProviderRetry(t *testing.T) {
+	before := testutil.ToFloat64(ProviderRetries.WithLabelValues("retry_test"))
+	ObserveProviderRetry("retry_test")
+	ObserveProviderRetry("retry_test")
+	assert.Equal(t, before+2, testutil.ToFloat64(ProviderRetries.WithLabelValues("retry_test")))
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Ranking metrics.
+var (
+	// RankingViolations counts ranking scores clamped because they broke a
+	// ranking invariant, by the provider of the flight, the invariant and,
+	// for monotonicity violations, the ranking factor.
+	RankingViolations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "ranking",
+		Name:      "violations_total",
+		Help:      "Number of ranking scores clamped for breaking a ranking invariant.",
+	}, []string{"provider", "invariant", "factor"})
+)
+
+func init() {
+	Registry.MustRegister(RankingViolations)
+}
+
+// ObserveRankingViolation records a ranking score clamped for breaking an
+// invariant. factor is empty for invariants other than monotonicity.
+func ObserveRankingViolation(provider, invariant, factor string) {
+	RankingViolations.WithLabelValues(provider, invariant, factor).Inc()
+}
@@ -0,0 +1,17 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveRankingViolation(t *testing.T) {
+	ObserveRankingViolation("ranking_test", "monotonic", "price")
+	ObserveRankingViolation("ranking_test", "monotonic", "price")
+	ObserveRankingViolation("ranking_test", "finite", "")
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(RankingViolations.WithLabelValues("ranking_test", "monotonic", "price")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(RankingViolations.WithLabelValues("ranking_test", "finite", "")))
+}
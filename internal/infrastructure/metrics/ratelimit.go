@@ -0,0 +1,21 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// ProviderRateLimited counts provider searches rejected because the
+// provider's rate limit would not let them through before their deadline.
+var ProviderRateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "provider_rate_limit",
+	Name:      "rejected_total",
+	Help:      "Number of provider searches rejected because the provider's rate limit would not let them through in time.",
+}, []string{"provider"})
+
+func init() {
+	Registry.MustRegister(ProviderRateLimited)
+}
+
+// ObserveProviderRateLimitRejection records a provider search rejected by its rate limit.
+func ObserveProviderRateLimitRejection(provider string) {
+	ProviderRateLimited.WithLabelValues(provider).Inc()
+}
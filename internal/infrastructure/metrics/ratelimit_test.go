@@ -0,0 +1,13 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveProviderRateLimitRejection(t *testing.T) {
+	ObserveProviderRateLimitRejection("rate_limited")
+	assert.Equal(t, 1.0, testutil.ToFloat64(ProviderRateLimited.WithLabelValues("rate_limited")))
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// SearchAuditDropped counts search audit entries lost, by reason: "queue_full"
+// when the queue was full, "write_error" when the sink failed to store them.
+var SearchAuditDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "search_audit",
+	Name:      "dropped_total",
+	Help:      "Number of search audit entries lost because the queue was full or the sink failed.",
+}, []string{"reason"})
+
+// Reasons of lost search audit entries.
+const (
+	SearchAuditQueueFull  = "queue_full"
+	SearchAuditWriteError = "write_error"
+)
+
+func init() {
+	Registry.MustRegister(SearchAuditDropped)
+}
+
+// ObserveSearchAuditDrop records n search audit entries lost for reason.
+func ObserveSearchAuditDrop(reason string, n int) {
+	SearchAuditDropped.WithLabelValues(reason).Add(float64(n))
+}
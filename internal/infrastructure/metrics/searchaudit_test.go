@@ -0,0 +1,14 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveSearchAuditDrop(t *testing.T) {
+	before := testutil.ToFloat64(SearchAuditDropped.WithLabelValues(SearchAuditWriteError))
+	ObserveSearchAuditDrop(SearchAuditWriteError, 3)
+	assert.Equal(t, before+3, testutil.ToFloat64(SearchAuditDropped.WithLabelValues(SearchAuditWriteError)))
+}
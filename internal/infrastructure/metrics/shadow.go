@@ -0,0 +1,57 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Shadow flight match label values.
+const (
+	ShadowMatched   = "matched"
+	ShadowUnmatched = "unmatched"
+)
+
+// Shadow provider metrics.
+var (
+	// ShadowSearches counts shadow provider searches, by outcome.
+	ShadowSearches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "shadow",
+		Name:      "searches_total",
+		Help:      "Number of searches sent to shadow providers.",
+	}, []string{"provider", "outcome"})
+
+	// ShadowFlights counts flights returned by shadow providers, by whether a
+	// live provider offered the same flight.
+	ShadowFlights = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "shadow",
+		Name:      "flights_total",
+		Help:      "Number of flights returned by shadow providers, by match with live results.",
+	}, []string{"provider", "match"})
+
+	// ShadowPriceDifference observes the mean relative price difference of a
+	// shadow search's matched flights ((shadow - live) / live).
+	ShadowPriceDifference = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "shadow",
+		Name:      "price_difference_ratio",
+		Help:      "Mean relative price difference of shadow flights matching live flights.",
+		Buckets:   []float64{-0.5, -0.2, -0.1, -0.05, -0.01, 0, 0.01, 0.05, 0.1, 0.2, 0.5},
+	}, []string{"provider"})
+)
+
+func init() {
+	Registry.MustRegister(ShadowSearches, ShadowFlights, ShadowPriceDifference)
+}
+
+// ObserveShadow records the comparison of one shadow search. priceDifference
+// is only observed when at least one matched flight could be priced.
+func ObserveShadow(provider string, err error, flights, matched int, priceDifference float64, priced bool) {
+	ShadowSearches.WithLabelValues(provider, ProviderOutcome(err)).Inc()
+	if err != nil {
+		return
+	}
+	ShadowFlights.WithLabelValues(provider, ShadowMatched).Add(float64(matched))
+	ShadowFlights.WithLabelValues(provider, ShadowUnmatched).Add(float64(flights - matched))
+	if priced {
+		ShadowPriceDifference.WithLabelValues(provider).Observe(priceDifference)
+	}
+}
@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveShadow(t *testing.T) {
+	ObserveShadow("shadow_test", nil, 5, 3, 0.04, true)
+	ObserveShadow("shadow_test", errors.New("bad gateway"), 0, 0, 0, false)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(ShadowSearches.WithLabelValues("shadow_test", OutcomeSuccess)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(ShadowSearches.WithLabelValues("shadow_test", OutcomeError)))
+	assert.Equal(t, 3.0, testutil.ToFloat64(ShadowFlights.WithLabelValues("shadow_test", ShadowMatched)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(ShadowFlights.WithLabelValues("shadow_test", ShadowUnmatched)))
+	assert.Equal(t, 1, testutil.CollectAndCount(ShadowPriceDifference))
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Store size kinds.
+const (
+	// StoreKindRecords counts individual stored entries
+	StoreKindRecords = "records"
+
+	// StoreKindAggregates counts rolled-up daily aggregates
+	StoreKindAggregates = "aggregates"
+)
+
+// StoreCompacted counts entries removed from stores by retention, by store and kind.
+var StoreCompacted = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "store",
+	Name:      "compacted_total",
+	Help:      "Number of store entries removed by retention.",
+}, []string{"store", "kind"})
+
+func init() {
+	Registry.MustRegister(StoreCompacted)
+}
+
+// RegisterStoreSize exports the current size of a store as the
+// flight_search_store_size gauge. size is called on every scrape,
+// so it must be cheap and safe for concurrent use.
+func RegisterStoreSize(store, kind string, size func() int) error {
+	return Registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   Namespace,
+		Subsystem:   "store",
+		Name:        "size",
+		Help:        "Number of entries held by a store.",
+		ConstLabels: prometheus.Labels{"store": store, "kind": kind},
+	}, func() float64 {
+		return float64(size())
+	}))
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterStoreSize(t *testing.T) {
+	size := 3
+	require.NoError(t, RegisterStoreSize("test_store", StoreKindRecords, func() int { return size }))
+	require.NoError(t, RegisterStoreSize("test_store", StoreKindAggregates, func() int { return 1 }))
+	assert.Error(t, RegisterStoreSize("test_store", StoreKindRecords, func() int { return 0 }), "duplicate registration")
+
+	size = 5
+	families, err := Registry.Gather()
+	require.NoError(t, err)
+
+	got := map[string]float64{}
+	for _, mf := range families {
+		if mf.GetName() != "flight_search_store_size" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "kind" {
+					got[label.GetValue()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{StoreKindRecords: 5, StoreKindAggregates: 1}, got)
+}
+
+func TestStoreCompacted(t *testing.T) {
+	StoreCompacted.WithLabelValues("test_store", StoreKindRecords).Add(4)
+	assert.Equal(t, 4.0, testutil.ToFloat64(StoreCompacted.WithLabelValues("test_store", StoreKindRecords)))
+}
@@ -0,0 +1,89 @@
+// Package retry retries operations that fail with transient errors, waiting
+// with exponential backoff between attempts.
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// Default retry settings.
+const (
+	DefaultMaxAttempts    = 2
+	DefaultInitialBackoff = 50 * time.Millisecond
+	DefaultMaxBackoff     = 500 * time.Millisecond
+)
+
+// Config configures how an operation is retried.
+type Config struct {
+	// MaxAttempts is the number of attempts, the first one included
+	// (1 = no retries; values below 1 are treated as 1)
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry; it doubles with each
+	// retry up to MaxBackoff
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait between attempts (0 = no cap)
+	MaxBackoff time.Duration
+
+	// RetryIf reports whether a failed attempt is worth retrying
+	// (nil = every error is retried)
+	RetryIf func(err error) bool
+
+	// OnRetry is called before each retry with the number of the attempt
+	// that failed and its error (optional)
+	OnRetry func(attempt int, err error)
+}
+
+// DefaultConfig returns a Config with the default settings, retrying every error.
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts:    DefaultMaxAttempts,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+	}
+}
+
+// Do calls op until it succeeds, fails with an error cfg.RetryIf rejects, or
+// cfg.MaxAttempts attempts were made, and returns the error of the last
+// attempt. Retries stop when ctx is done: the last attempt's error is
+// returned without waiting for the backoff to elapse.
+func Do(ctx context.Context, cfg Config, op func(ctx context.Context) error) error {
+	backoff := cfg.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := op(ctx)
+		if err == nil || attempt >= cfg.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+		if cfg.RetryIf != nil && !cfg.RetryIf(err) {
+			return err
+		}
+
+		if !sleep(ctx, backoff) {
+			return err
+		}
+		if cfg.OnRetry != nil {
+			cfg.OnRetry(attempt, err)
+		}
+		backoff *= 2
+		if cfg.MaxBackoff > 0 && backoff > cfg.MaxBackoff {
+			backoff = cfg.MaxBackoff
+		}
+	}
+}
+
+// sleep waits for d, returning false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
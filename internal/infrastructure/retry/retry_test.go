@@ -0,0 +1,111 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errTransient = errors.New("transient")
+	errPermanent = errors.New("permanent")
+)
+
+// failing returns an operation failing with errs in turn, then succeeding,
+// and the number of calls made.
+func failing(errs ...error) (func(context.Context) error, *int) {
+	calls := 0
+	return func(context.Context) error {
+		calls++
+		if calls <= len(errs) {
+			return errs[calls-1]
+		}
+		return nil
+	}, &calls
+}
+
+func TestDo(t *testing.T) {
+	retryTransient := func(err error) bool { return errors.Is(err, errTransient) }
+
+	tests := []struct {
+		name      string
+		cfg       Config
+		errs      []error
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "success", cfg: Config{MaxAttempts: 3}, wantCalls: 1},
+		{name: "succeeds on retry", cfg: Config{MaxAttempts: 3}, errs: []error{errTransient, errTransient}, wantCalls: 3},
+		{name: "attempts exhausted", cfg: Config{MaxAttempts: 2}, errs: []error{errTransient, errTransient, errTransient}, wantErr: errTransient, wantCalls: 2},
+		{name: "no retries", cfg: Config{MaxAttempts: 1}, errs: []error{errTransient}, wantErr: errTransient, wantCalls: 1},
+		{name: "zero attempts is one", cfg: Config{}, errs: []error{errTransient}, wantErr: errTransient, wantCalls: 1},
+		{
+			name:      "not retryable",
+			cfg:       Config{MaxAttempts: 3, RetryIf: retryTransient},
+			errs:      []error{errPermanent},
+			wantErr:   errPermanent,
+			wantCalls: 1,
+		},
+		{
+			name:      "retryable then not",
+			cfg:       Config{MaxAttempts: 3, RetryIf: retryTransient},
+			errs:      []error{errTransient, errPermanent},
+			wantErr:   errPermanent,
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, calls := failing(tt.errs...)
+			err := Do(context.Background(), tt.cfg, op)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, *calls)
+		})
+	}
+}
+
+func TestDo_Backoff(t *testing.T) {
+	var retried []int
+	cfg := Config{
+		MaxAttempts:    4,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     15 * time.Millisecond,
+		OnRetry:        func(attempt int, _ error) { retried = append(retried, attempt) },
+	}
+	op, calls := failing(errTransient, errTransient, errTransient)
+
+	start := time.Now()
+	assert.NoError(t, Do(context.Background(), cfg, op))
+
+	assert.Equal(t, 4, *calls)
+	assert.Equal(t, []int{1, 2, 3}, retried)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "10ms, then 15ms twice")
+}
+
+func TestDo_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	op := func(context.Context) error {
+		cancel()
+		return errTransient
+	}
+
+	start := time.Now()
+	err := Do(ctx, Config{MaxAttempts: 3, InitialBackoff: time.Second}, op)
+	assert.ErrorIs(t, err, errTransient, "last attempt's error")
+	assert.Less(t, time.Since(start), time.Second, "no wait once the context is done")
+
+	// The backoff is cut short too
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	op, calls := failing(errTransient, errTransient)
+	err = Do(ctx, Config{MaxAttempts: 3, InitialBackoff: time.Second}, op)
+	assert.ErrorIs(t, err, errTransient)
+	assert.Equal(t, 1, *calls)
+}
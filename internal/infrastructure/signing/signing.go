@@ -0,0 +1,192 @@
+// Package signing signs API responses with detached JSON Web Signatures
+// (RFC 7515, Appendix F), so downstream caches and partners can verify
+// results were not altered in transit through intermediaries.
+//
+// A signature covers the canonical form of the JSON body: object keys sorted,
+// insignificant whitespace removed, strings re-encoded in a normalized form
+// and numbers kept as written. Intermediaries re-serializing the body thus
+// keep the signature valid as long as they preserve its content. Signatures
+// use Ed25519 (JWS algorithm "EdDSA") and are sent in compact serialization
+// with the payload left out:
+//
+//	<base64url(header)>..<base64url(signature)>
+//
+// The header names the signing key ("kid"); its public key is published as a
+// JSON Web Key Set (RFC 7517) for verifiers.
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Algorithm is the JWS algorithm of the signatures.
+const Algorithm = "EdDSA"
+
+// Header is the response header carrying the signature.
+const Header = "X-JWS-Signature"
+
+// Sentinel errors.
+var (
+	// ErrInvalidKey is returned when a signing key is malformed
+	ErrInvalidKey = errors.New("invalid signing key")
+
+	// ErrInvalidSignature is returned when a signature is malformed or does
+	// not match the body
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// header is the JWS protected header.
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// Signer signs response bodies with an Ed25519 key. It is safe for concurrent use.
+type Signer struct {
+	keyID string
+	key   ed25519.PrivateKey
+}
+
+// NewSigner creates a signer from a key ID and a 32-byte Ed25519 seed.
+func NewSigner(keyID string, seed []byte) (*Signer, error) {
+	if keyID == "" || strings.Contains(keyID, ":") {
+		return nil, fmt.Errorf("%w: invalid key ID %q", ErrInvalidKey, keyID)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%w: key %s: seed must be %d bytes, got %d", ErrInvalidKey, keyID, ed25519.SeedSize, len(seed))
+	}
+	return &Signer{keyID: keyID, key: ed25519.NewKeyFromSeed(seed)}, nil
+}
+
+// ParseSigner creates a signer from an id:base64 key, the base64 part being
+// the standard encoding of a 32-byte Ed25519 seed.
+func ParseSigner(spec string) (*Signer, error) {
+	id, encoded, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if !ok {
+		return nil, fmt.Errorf("%w: expected id:base64", ErrInvalidKey)
+	}
+	seed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: key %s: invalid base64: %w", ErrInvalidKey, id, err)
+	}
+	return NewSigner(id, seed)
+}
+
+// KeyID returns the ID of the signing key.
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// PublicKey returns the public key verifying the signatures.
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// Sign returns the detached JWS of a JSON body.
+func (s *Signer) Sign(body []byte) (string, error) {
+	payload, err := Canonicalize(body)
+	if err != nil {
+		return "", err
+	}
+	protected, err := json.Marshal(header{Algorithm: Algorithm, KeyID: s.keyID})
+	if err != nil {
+		return "", err
+	}
+
+	encodedHeader := base64.RawURLEncoding.EncodeToString(protected)
+	signature := ed25519.Sign(s.key, signingInput(encodedHeader, payload))
+	return encodedHeader + ".." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify checks that signature is a valid detached JWS of body by key.
+// It returns an error wrapping ErrInvalidSignature otherwise.
+func Verify(signature string, body []byte, key ed25519.PublicKey) error {
+	encodedHeader, encodedSignature, ok := strings.Cut(signature, "..")
+	if !ok || strings.Contains(encodedSignature, ".") {
+		return fmt.Errorf("%w: not a detached compact JWS", ErrInvalidSignature)
+	}
+	protected, err := base64.RawURLEncoding.DecodeString(encodedHeader)
+	if err != nil {
+		return fmt.Errorf("%w: header: %w", ErrInvalidSignature, err)
+	}
+	var h header
+	if err := json.Unmarshal(protected, &h); err != nil {
+		return fmt.Errorf("%w: header: %w", ErrInvalidSignature, err)
+	}
+	if h.Algorithm != Algorithm {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, h.Algorithm)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return fmt.Errorf("%w: signature: %w", ErrInvalidSignature, err)
+	}
+
+	payload, err := Canonicalize(body)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if !ed25519.Verify(key, signingInput(encodedHeader, payload), sig) {
+		return fmt.Errorf("%w: signature does not match the body", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// signingInput returns the JWS signing input of a payload.
+func signingInput(encodedHeader string, payload []byte) []byte {
+	return []byte(encodedHeader + "." + base64.RawURLEncoding.EncodeToString(payload))
+}
+
+// Canonicalize returns the canonical form of a JSON document.
+func Canonicalize(body []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("canonicalize: %w", err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("canonicalize: unexpected data after the JSON value")
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("canonicalize: %w", err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// JWK is an Ed25519 public key in JSON Web Key form (RFC 8037).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+}
+
+// JWKSet is a JSON Web Key Set.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the key set publishing the signer's public key.
+func (s *Signer) JWKS() JWKSet {
+	return JWKSet{Keys: []JWK{{
+		KeyType:   "OKP",
+		Curve:     "Ed25519",
+		X:         base64.RawURLEncoding.EncodeToString(s.PublicKey()),
+		KeyID:     s.keyID,
+		Use:       "sig",
+		Algorithm: Algorithm,
+	}}}
+}
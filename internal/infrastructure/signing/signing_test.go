@@ -0,0 +1,133 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSigner returns a signer with a fixed key.
+func testSigner(t *testing.T) *Signer {
+	t.Helper()
+	signer, err := NewSigner("2025-12", bytes.Repeat([]byte{7}, ed25519.SeedSize))
+	require.NoError(t, err)
+	return signer
+}
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"sorted keys", `{"b":1,"a":{"d":true,"c":null}}`, `{"a":{"c":null,"d":true},"b":1}`},
+		{"whitespace", "{\n  \"flights\": [ 1, 2 ]\n}\n", `{"flights":[1,2]}`},
+		{"numbers as written", `{"price":1500000.50,"big":12345678901234567890}`, `{"big":12345678901234567890,"price":1500000.50}`},
+		{"normalized strings", `{"name":"Caf\u00e9 <A&B>"}`, `{"name":"Café <A&B>"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Canonicalize([]byte(tt.in))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+
+	for _, in := range []string{"", `{"a":`, `{} {}`} {
+		_, err := Canonicalize([]byte(in))
+		assert.Error(t, err, in)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	signer := testSigner(t)
+	body := []byte(`{"success":true,"data":{"flights":[{"id":"GA400","price":1500000}]}}`)
+
+	signature, err := signer.Sign(body)
+	require.NoError(t, err)
+
+	encodedHeader, _, ok := strings.Cut(signature, "..")
+	require.True(t, ok, "detached payload")
+	protected, err := base64.RawURLEncoding.DecodeString(encodedHeader)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"alg":"EdDSA","kid":"2025-12"}`, string(protected))
+
+	require.NoError(t, Verify(signature, body, signer.PublicKey()))
+
+	// Re-serialized by an intermediary: still valid
+	var v any
+	require.NoError(t, json.Unmarshal(body, &v))
+	indented, err := json.MarshalIndent(v, "", "  ")
+	require.NoError(t, err)
+	assert.NoError(t, Verify(signature, indented, signer.PublicKey()))
+
+	// Tampered with: invalid
+	tampered := bytes.Replace(body, []byte("1500000"), []byte("150000"), 1)
+	assert.ErrorIs(t, Verify(signature, tampered, signer.PublicKey()), ErrInvalidSignature)
+
+	other, err := NewSigner("other", bytes.Repeat([]byte{8}, ed25519.SeedSize))
+	require.NoError(t, err)
+	assert.ErrorIs(t, Verify(signature, body, other.PublicKey()), ErrInvalidSignature)
+}
+
+func TestVerify_Malformed(t *testing.T) {
+	signer := testSigner(t)
+	body := []byte(`{}`)
+	signature, err := signer.Sign(body)
+	require.NoError(t, err)
+	encodedHeader, encodedSignature, _ := strings.Cut(signature, "..")
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"2025-12"}`))
+
+	for name, sig := range map[string]string{
+		"empty":            "",
+		"attached payload": encodedHeader + ".e30." + encodedSignature,
+		"bad header":       "!!.." + encodedSignature,
+		"bad signature":    encodedHeader + "..!!",
+		"other algorithm":  noneHeader + ".." + encodedSignature,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, Verify(sig, body, signer.PublicKey()), ErrInvalidSignature)
+		})
+	}
+}
+
+func TestParseSigner(t *testing.T) {
+	seed := bytes.Repeat([]byte{7}, ed25519.SeedSize)
+	signer, err := ParseSigner("2025-12:" + base64.StdEncoding.EncodeToString(seed))
+	require.NoError(t, err)
+	assert.Equal(t, "2025-12", signer.KeyID())
+	assert.Equal(t, testSigner(t).PublicKey(), signer.PublicKey())
+
+	for _, spec := range []string{
+		"",
+		base64.StdEncoding.EncodeToString(seed),
+		"2025-12:not base64",
+		"2025-12:" + base64.StdEncoding.EncodeToString(seed[:16]),
+		":" + base64.StdEncoding.EncodeToString(seed),
+	} {
+		_, err := ParseSigner(spec)
+		assert.ErrorIs(t, err, ErrInvalidKey, spec)
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	signer := testSigner(t)
+	set := signer.JWKS()
+
+	require.Len(t, set.Keys, 1)
+	key := set.Keys[0]
+	assert.Equal(t, "OKP", key.KeyType)
+	assert.Equal(t, "Ed25519", key.Curve)
+	assert.Equal(t, "2025-12", key.KeyID)
+	assert.Equal(t, Algorithm, key.Algorithm)
+
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(signer.PublicKey()), x)
+}
@@ -0,0 +1,159 @@
+package timeutil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+// Datetime parsing errors.
+var (
+	// ErrInvalidDateTime indicates a value matches none of the supported datetime formats.
+	ErrInvalidDateTime = errors.New("invalid datetime")
+
+	// ErrMissingTimezone indicates a datetime without offset could not be resolved
+	// to a timezone and RequireTimezone was set.
+	ErrMissingTimezone = errors.New("datetime has no offset and no timezone could be resolved")
+)
+
+// DateTimeError describes a datetime value that could not be parsed.
+type DateTimeError struct {
+	Value string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *DateTimeError) Error() string {
+	return fmt.Sprintf("parse datetime %q: %v", e.Value, e.Err)
+}
+
+// Unwrap returns the underlying error (ErrInvalidDateTime or ErrMissingTimezone).
+func (e *DateTimeError) Unwrap() error {
+	return e.Err
+}
+
+// zonedLayouts are datetime layouts carrying their own UTC offset.
+var zonedLayouts = []string{
+	time.RFC3339Nano,                      // 2025-12-15T06:00:00+07:00, 2025-12-15T06:00:00Z
+	"2006-01-02T15:04:05.999999999-0700",  // 2025-12-15T06:00:00+0700
+	"2006-01-02 15:04:05.999999999Z07:00", // 2025-12-15 06:00:00+07:00
+	"2006-01-02 15:04:05.999999999-0700",  // 2025-12-15 06:00:00+0700
+}
+
+// naiveLayouts are datetime layouts without a UTC offset, interpreted in the
+// resolved timezone.
+var naiveLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// epochMillisDigits is the number of digits of epoch milliseconds between
+// 2001 and 2286, which rules out dates such as "20251215".
+const epochMillisDigits = 13
+
+// ParseOption configures ParseDateTime.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	timezones       []string
+	requireTimezone bool
+}
+
+// WithTimezone adds an IANA timezone for datetimes without offset.
+// Empty or unknown names are skipped in favour of the next candidate.
+func WithTimezone(name string) ParseOption {
+	return func(o *parseOptions) {
+		o.timezones = append(o.timezones, name)
+	}
+}
+
+// WithAirport adds the timezone of an airport for datetimes without offset.
+// Unknown airports are skipped in favour of the next candidate.
+func WithAirport(code string) ParseOption {
+	return WithTimezone(reference.AirportTimezone(code))
+}
+
+// RequireTimezone makes ParseDateTime fail with ErrMissingTimezone instead of
+// assuming UTC when a datetime without offset cannot be resolved to a timezone.
+func RequireTimezone() ParseOption {
+	return func(o *parseOptions) {
+		o.requireTimezone = true
+	}
+}
+
+// ParseDateTime parses a provider datetime, trying in order:
+//  1. layouts with an offset: RFC3339 ("+07:00" or "Z") and offsets without colon ("+0700")
+//  2. epoch milliseconds ("1765753200000"), returned in UTC
+//  3. layouts without an offset ("2025-12-15T06:00:00", "2025-12-15 06:00"),
+//     interpreted in the first loadable timezone from WithTimezone and
+//     WithAirport, in the order given, or UTC if none resolves
+//
+// Errors are *DateTimeError wrapping ErrInvalidDateTime or ErrMissingTimezone.
+func ParseDateTime(value string, opts ...ParseOption) (time.Time, error) {
+	var o parseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := strings.TrimSpace(value)
+	if s == "" {
+		return time.Time{}, &DateTimeError{Value: value, Err: ErrInvalidDateTime}
+	}
+
+	for _, layout := range zonedLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	if t, ok := parseEpochMillis(s); ok {
+		return t, nil
+	}
+
+	for _, layout := range naiveLayouts {
+		if _, err := time.Parse(layout, s); err != nil {
+			continue
+		}
+		loc, ok := o.resolveLocation()
+		if !ok {
+			if o.requireTimezone {
+				return time.Time{}, &DateTimeError{Value: value, Err: ErrMissingTimezone}
+			}
+			loc = time.UTC
+		}
+		return time.ParseInLocation(layout, s, loc)
+	}
+
+	return time.Time{}, &DateTimeError{Value: value, Err: ErrInvalidDateTime}
+}
+
+// resolveLocation returns the first timezone candidate that loads.
+func (o parseOptions) resolveLocation() (*time.Location, bool) {
+	for _, name := range o.timezones {
+		if name == "" {
+			continue
+		}
+		if loc, err := GetLocation(name); err == nil {
+			return loc, true
+		}
+	}
+	return nil, false
+}
+
+// parseEpochMillis parses a string of epoch milliseconds.
+func parseEpochMillis(s string) (time.Time, bool) {
+	if len(s) != epochMillisDigits {
+		return time.Time{}, false
+	}
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || ms < 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms).UTC(), true
+}
@@ -0,0 +1,92 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDateTime_WithOffset(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectedUTC string
+	}{
+		{"RFC3339 with colon in offset", "2025-12-15T06:00:00+07:00", "2025-12-14T23:00:00Z"},
+		{"RFC3339 with Z", "2025-12-15T06:00:00Z", "2025-12-15T06:00:00Z"},
+		{"RFC3339 with +08:00", "2025-12-15T08:45:00+08:00", "2025-12-15T00:45:00Z"},
+		{"RFC3339 with fraction", "2025-12-15T06:00:00.500+07:00", "2025-12-14T23:00:00.5Z"},
+		{"offset without colon", "2025-12-15T07:15:00+0700", "2025-12-15T00:15:00Z"},
+		{"space separator", "2025-12-15 07:15:00+07:00", "2025-12-15T00:15:00Z"},
+		{"space separator without colon", "2025-12-15 07:15:00+0700", "2025-12-15T00:15:00Z"},
+		{"surrounding whitespace", "  2025-12-15T06:00:00Z ", "2025-12-15T06:00:00Z"},
+		{"epoch millis", "1765753200000", "2025-12-14T23:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The airport is ignored when the value carries its own offset
+			result, err := ParseDateTime(tt.input, WithAirport("SIN"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedUTC, result.UTC().Format(time.RFC3339Nano))
+		})
+	}
+}
+
+func TestParseDateTime_Naive(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		opts         []ParseOption
+		expectedHour int
+		expectedLoc  string
+	}{
+		{"explicit timezone", "2025-12-15T05:30:00", []ParseOption{WithTimezone("Asia/Jakarta")}, 5, "Asia/Jakarta"},
+		{"space separator", "2025-12-15 10:00:00", []ParseOption{WithTimezone("Asia/Makassar")}, 10, "Asia/Makassar"},
+		{"without seconds", "2025-12-15T10:00", []ParseOption{WithTimezone("Asia/Makassar")}, 10, "Asia/Makassar"},
+		{"airport timezone", "2025-12-15T10:00:00", []ParseOption{WithAirport("DPS")}, 10, "Asia/Makassar"},
+		{"timezone before airport", "2025-12-15T10:00:00", []ParseOption{WithTimezone("Asia/Singapore"), WithAirport("DPS")}, 10, "Asia/Singapore"},
+		{"invalid timezone falls back to airport", "2025-12-15T10:00:00", []ParseOption{WithTimezone("Invalid/Timezone"), WithAirport("CGK")}, 10, "Asia/Jakarta"},
+		{"empty timezone falls back to airport", "2025-12-15T10:00:00", []ParseOption{WithTimezone(""), WithAirport("CGK")}, 10, "Asia/Jakarta"},
+		{"invalid timezone falls back to UTC", "2025-12-15T12:00:00", []ParseOption{WithTimezone("Invalid/Timezone")}, 12, "UTC"},
+		{"unknown airport falls back to UTC", "2025-12-15T12:00:00", []ParseOption{WithAirport("XXX")}, 12, "UTC"},
+		{"no timezone falls back to UTC", "2025-12-15T12:00:00", nil, 12, "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseDateTime(tt.input, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHour, result.Hour())
+			assert.Equal(t, tt.expectedLoc, result.Location().String())
+		})
+	}
+}
+
+func TestParseDateTime_RequireTimezone(t *testing.T) {
+	_, err := ParseDateTime("2025-12-15T12:00:00", WithAirport("XXX"), RequireTimezone())
+	assert.ErrorIs(t, err, ErrMissingTimezone)
+
+	var dtErr *DateTimeError
+	require.ErrorAs(t, err, &dtErr)
+	assert.Equal(t, "2025-12-15T12:00:00", dtErr.Value)
+
+	// Values with an offset don't need a timezone
+	_, err = ParseDateTime("2025-12-15T12:00:00Z", RequireTimezone())
+	assert.NoError(t, err)
+
+	result, err := ParseDateTime("2025-12-15T12:00:00", WithAirport("CGK"), RequireTimezone())
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Jakarta", result.Location().String())
+}
+
+func TestParseDateTime_Invalid(t *testing.T) {
+	for _, input := range []string{"", "   ", "not-a-date", "15-12-2025 06:00:00", "2025-12-15", "20251215", "2025-13-15T06:00:00Z", "-1765753200000"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseDateTime(input, WithTimezone("Asia/Jakarta"))
+			assert.ErrorIs(t, err, ErrInvalidDateTime)
+		})
+	}
+}
@@ -99,6 +99,22 @@ func FormatDate(t time.Time) string {
 	return t.Format("2006-01-02")
 }
 
+// LocalDate formats t as YYYY-MM-DD in the first loadable timezone from
+// WithTimezone and WithAirport, in the order given, or in t's own location if
+// none resolves. Flights are searched by their local departure date: a flight
+// leaving Jakarta at 00:30 on Dec 15 departs on the 15th, although it is still
+// the 14th in UTC.
+func LocalDate(t time.Time, opts ...ParseOption) string {
+	var o parseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if loc, ok := o.resolveLocation(); ok {
+		t = t.In(loc)
+	}
+	return FormatDate(t)
+}
+
 // FormatTime formats a time as HH:MM.
 func FormatTime(t time.Time) string {
 	return t.Format("15:04")
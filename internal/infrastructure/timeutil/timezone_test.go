@@ -154,6 +154,33 @@ func TestFormatDate(t *testing.T) {
 	assert.Equal(t, "2025-12-15", FormatDate(tm))
 }
 
+func TestLocalDate(t *testing.T) {
+	// 00:30 in Jakarta (UTC+7) on Dec 15, 23:30 in Bali (UTC+8) on Dec 14
+	jakartaMidnight := time.Date(2025, 12, 14, 17, 30, 0, 0, time.UTC)
+	baliLate := time.Date(2025, 12, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		opts []ParseOption
+		want string
+	}{
+		{"airport after UTC midnight", jakartaMidnight, []ParseOption{WithAirport("CGK")}, "2025-12-15"},
+		{"airport before midnight", baliLate, []ParseOption{WithAirport("DPS")}, "2025-12-14"},
+		{"same instant at another airport", jakartaMidnight, []ParseOption{WithAirport("DPS")}, "2025-12-15"},
+		{"timezone", jakartaMidnight, []ParseOption{WithTimezone(WIB)}, "2025-12-15"},
+		{"first resolvable candidate", jakartaMidnight, []ParseOption{WithAirport("ZZZ"), WithTimezone("Asia/Kolkata")}, "2025-12-14"},
+		{"unresolved keeps location", jakartaMidnight, []ParseOption{WithAirport("ZZZ")}, "2025-12-14"},
+		{"unresolved offset time", jakartaMidnight.In(time.FixedZone("", 7*3600)), nil, "2025-12-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LocalDate(tt.t, tt.opts...))
+		})
+	}
+}
+
 func TestFormatTime(t *testing.T) {
 	tm := time.Date(2025, 12, 15, 10, 30, 45, 0, time.UTC)
 
@@ -0,0 +1,253 @@
+// Package tracing propagates distributed tracing headers through the service.
+// It understands W3C Trace Context (traceparent/tracestate) and Zipkin B3
+// (single "b3" header and multi X-B3-* headers), so the aggregator can take
+// part in existing traces without a full tracing SDK.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Header names.
+const (
+	HeaderTraceparent  = "traceparent"
+	HeaderTracestate   = "tracestate"
+	HeaderB3           = "b3"
+	HeaderB3TraceID    = "X-B3-TraceId"
+	HeaderB3SpanID     = "X-B3-SpanId"
+	HeaderB3ParentSpan = "X-B3-ParentSpanId"
+	HeaderB3Sampled    = "X-B3-Sampled"
+	HeaderB3Flags      = "X-B3-Flags"
+)
+
+// Format identifies the header format a trace context was received in.
+type Format string
+
+const (
+	// FormatW3C is the W3C Trace Context format
+	FormatW3C Format = "w3c"
+
+	// FormatB3Single is the single-header B3 format
+	FormatB3Single Format = "b3_single"
+
+	// FormatB3Multi is the multi-header B3 format
+	FormatB3Multi Format = "b3_multi"
+)
+
+// TraceContext identifies the trace and span a request belongs to.
+type TraceContext struct {
+	// TraceID is the 32 (W3C, B3) or 16 (B3) hex character trace identifier
+	TraceID string
+
+	// SpanID is the 16 hex character identifier of the caller's span
+	SpanID string
+
+	// ParentSpanID is the B3 parent span, if given
+	ParentSpanID string
+
+	// Sampled reports whether the caller recorded the trace
+	Sampled bool
+
+	// Debug reports whether the B3 debug flag was set
+	Debug bool
+
+	// TraceState carries W3C vendor-specific data, passed through unchanged
+	TraceState string
+
+	// Format is the header format the context was received in
+	Format Format
+}
+
+var (
+	traceparentPattern = regexp.MustCompile(`^([0-9a-f]{2})-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})(-.*)?$`)
+	traceIDPattern     = regexp.MustCompile(`^([0-9a-f]{16}|[0-9a-f]{32})$`)
+	spanIDPattern      = regexp.MustCompile(`^[0-9a-f]{16}$`)
+)
+
+// ParseTraceparent parses a W3C traceparent header value.
+// Invalid values, the forbidden version "ff", and all-zero IDs are rejected.
+func ParseTraceparent(value string) (TraceContext, bool) {
+	m := traceparentPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return TraceContext{}, false
+	}
+
+	version, traceID, spanID := m[1], m[2], m[3]
+	if version == "ff" || (version == "00" && m[5] != "") {
+		return TraceContext{}, false
+	}
+	if isZero(traceID) || isZero(spanID) {
+		return TraceContext{}, false
+	}
+	flags, _ := strconv.ParseUint(m[4], 16, 8)
+
+	return TraceContext{
+		TraceID: traceID,
+		SpanID:  spanID,
+		Sampled: flags&0x01 == 0x01,
+		Format:  FormatW3C,
+	}, true
+}
+
+// ParseB3Single parses a single-header B3 value:
+// {TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}, where the last two are optional.
+// A bare sampling state ("0", "1", "d") carries no IDs and is rejected.
+func ParseB3Single(value string) (TraceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 2 || len(parts) > 4 {
+		return TraceContext{}, false
+	}
+
+	tc := TraceContext{TraceID: parts[0], SpanID: parts[1], Format: FormatB3Single}
+	if !validB3IDs(tc.TraceID, tc.SpanID) {
+		return TraceContext{}, false
+	}
+
+	if len(parts) >= 3 {
+		switch parts[2] {
+		case "1":
+			tc.Sampled = true
+		case "d":
+			tc.Sampled, tc.Debug = true, true
+		case "0":
+		default:
+			return TraceContext{}, false
+		}
+	}
+	if len(parts) == 4 {
+		if !spanIDPattern.MatchString(parts[3]) {
+			return TraceContext{}, false
+		}
+		tc.ParentSpanID = parts[3]
+	}
+	return tc, true
+}
+
+// ParseB3Multi parses the multi-header B3 format.
+func ParseB3Multi(h http.Header) (TraceContext, bool) {
+	tc := TraceContext{
+		TraceID:      strings.ToLower(strings.TrimSpace(h.Get(HeaderB3TraceID))),
+		SpanID:       strings.ToLower(strings.TrimSpace(h.Get(HeaderB3SpanID))),
+		ParentSpanID: strings.ToLower(strings.TrimSpace(h.Get(HeaderB3ParentSpan))),
+		Format:       FormatB3Multi,
+	}
+	if !validB3IDs(tc.TraceID, tc.SpanID) {
+		return TraceContext{}, false
+	}
+	if tc.ParentSpanID != "" && !spanIDPattern.MatchString(tc.ParentSpanID) {
+		tc.ParentSpanID = ""
+	}
+
+	switch strings.ToLower(h.Get(HeaderB3Sampled)) {
+	case "1", "true":
+		tc.Sampled = true
+	}
+	if h.Get(HeaderB3Flags) == "1" {
+		tc.Sampled, tc.Debug = true, true
+	}
+	return tc, true
+}
+
+// Extract reads a trace context from request headers.
+// W3C traceparent takes precedence over B3, and single-header B3 over multi-header B3.
+func Extract(h http.Header) (TraceContext, bool) {
+	if tc, ok := ParseTraceparent(h.Get(HeaderTraceparent)); ok {
+		tc.TraceState = h.Get(HeaderTracestate)
+		return tc, true
+	}
+	if tc, ok := ParseB3Single(h.Get(HeaderB3)); ok {
+		return tc, true
+	}
+	return ParseB3Multi(h)
+}
+
+// Inject writes the trace context to headers in the format it was received in,
+// so downstream systems see the same headers the caller sent.
+func Inject(h http.Header, tc TraceContext) {
+	switch tc.Format {
+	case FormatW3C:
+		h.Set(HeaderTraceparent, tc.Traceparent())
+		if tc.TraceState != "" {
+			h.Set(HeaderTracestate, tc.TraceState)
+		}
+	case FormatB3Single:
+		h.Set(HeaderB3, tc.B3())
+	case FormatB3Multi:
+		h.Set(HeaderB3TraceID, tc.TraceID)
+		h.Set(HeaderB3SpanID, tc.SpanID)
+		if tc.ParentSpanID != "" {
+			h.Set(HeaderB3ParentSpan, tc.ParentSpanID)
+		}
+		if tc.Debug {
+			h.Set(HeaderB3Flags, "1")
+		} else if tc.Sampled {
+			h.Set(HeaderB3Sampled, "1")
+		} else {
+			h.Set(HeaderB3Sampled, "0")
+		}
+	}
+}
+
+// Traceparent formats the context as a version 00 W3C traceparent value.
+// 64-bit B3 trace IDs are left-padded with zeros to 128 bits.
+func (tc TraceContext) Traceparent() string {
+	flags := "00"
+	if tc.Sampled {
+		flags = "01"
+	}
+	return "00-" + strings.Repeat("0", 32-len(tc.TraceID)) + tc.TraceID + "-" + tc.SpanID + "-" + flags
+}
+
+// B3 formats the context as a single-header B3 value.
+func (tc TraceContext) B3() string {
+	state := "0"
+	switch {
+	case tc.Debug:
+		state = "d"
+	case tc.Sampled:
+		state = "1"
+	}
+
+	value := tc.TraceID + "-" + tc.SpanID + "-" + state
+	if tc.ParentSpanID != "" {
+		value += "-" + tc.ParentSpanID
+	}
+	return value
+}
+
+// contextKey is the context key for the trace context.
+type contextKey struct{}
+
+// WithTraceContext returns a copy of ctx carrying the trace context.
+func WithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, contextKey{}, tc)
+}
+
+// FromContext returns the trace context carried by ctx, if any.
+func FromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(contextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// InjectContext writes the trace context carried by ctx, if any, to outbound
+// request headers. Provider adapters making HTTP calls use it to propagate traces.
+func InjectContext(ctx context.Context, h http.Header) {
+	if tc, ok := FromContext(ctx); ok {
+		Inject(h, tc)
+	}
+}
+
+// validB3IDs checks B3 trace and span IDs.
+func validB3IDs(traceID, spanID string) bool {
+	return traceIDPattern.MatchString(traceID) && spanIDPattern.MatchString(spanID) &&
+		!isZero(traceID) && !isZero(spanID)
+}
+
+// isZero reports whether a hex ID consists only of zeros.
+func isZero(id string) bool {
+	return strings.Trim(id, "0") == ""
+}
@@ -0,0 +1,177 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	w3cTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	w3cSpanID  = "00f067aa0ba902b7"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantOK      bool
+		wantSampled bool
+	}{
+		{name: "sampled", value: "00-" + w3cTraceID + "-" + w3cSpanID + "-01", wantOK: true, wantSampled: true},
+		{name: "not sampled", value: "00-" + w3cTraceID + "-" + w3cSpanID + "-00", wantOK: true},
+		{name: "future version with extra fields", value: "01-" + w3cTraceID + "-" + w3cSpanID + "-01-extra", wantOK: true, wantSampled: true},
+		{name: "version 00 with extra fields", value: "00-" + w3cTraceID + "-" + w3cSpanID + "-01-extra"},
+		{name: "forbidden version", value: "ff-" + w3cTraceID + "-" + w3cSpanID + "-01"},
+		{name: "zero trace id", value: "00-00000000000000000000000000000000-" + w3cSpanID + "-01"},
+		{name: "zero span id", value: "00-" + w3cTraceID + "-0000000000000000-01"},
+		{name: "uppercase", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-" + w3cSpanID + "-01"},
+		{name: "short trace id", value: "00-4bf92f35-" + w3cSpanID + "-01"},
+		{name: "empty", value: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc, ok := ParseTraceparent(tt.value)
+			assert.Equal(t, tt.wantOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, w3cTraceID, tc.TraceID)
+			assert.Equal(t, w3cSpanID, tc.SpanID)
+			assert.Equal(t, tt.wantSampled, tc.Sampled)
+			assert.Equal(t, FormatW3C, tc.Format)
+		})
+	}
+}
+
+func TestParseB3Single(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   TraceContext
+		wantOK bool
+	}{
+		{
+			name:   "ids only",
+			value:  "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1",
+			want:   TraceContext{TraceID: "80f198ee56343ba864fe8b2a57d3eff7", SpanID: "e457b5a2e4d86bd1", Format: FormatB3Single},
+			wantOK: true,
+		},
+		{
+			name:  "sampled with parent",
+			value: "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1-05e3ac9a4f6e3b90",
+			want: TraceContext{
+				TraceID: "80f198ee56343ba864fe8b2a57d3eff7", SpanID: "e457b5a2e4d86bd1",
+				ParentSpanID: "05e3ac9a4f6e3b90", Sampled: true, Format: FormatB3Single,
+			},
+			wantOK: true,
+		},
+		{
+			name:   "debug with 64-bit trace id",
+			value:  "463ac35c9f6413ad-a2fb4a1d1a96d312-d",
+			want:   TraceContext{TraceID: "463ac35c9f6413ad", SpanID: "a2fb4a1d1a96d312", Sampled: true, Debug: true, Format: FormatB3Single},
+			wantOK: true,
+		},
+		{name: "sampling state only", value: "1"},
+		{name: "invalid sampling state", value: "463ac35c9f6413ad-a2fb4a1d1a96d312-x"},
+		{name: "invalid span id", value: "463ac35c9f6413ad-xyz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc, ok := ParseB3Single(tt.value)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, tc)
+			}
+		})
+	}
+}
+
+func TestParseB3Multi(t *testing.T) {
+	h := http.Header{}
+	h.Set(HeaderB3TraceID, "463AC35C9F6413AD")
+	h.Set(HeaderB3SpanID, "a2fb4a1d1a96d312")
+	h.Set(HeaderB3ParentSpan, "0020000000000001")
+	h.Set(HeaderB3Sampled, "1")
+
+	tc, ok := ParseB3Multi(h)
+	require.True(t, ok)
+	assert.Equal(t, TraceContext{
+		TraceID: "463ac35c9f6413ad", SpanID: "a2fb4a1d1a96d312", ParentSpanID: "0020000000000001",
+		Sampled: true, Format: FormatB3Multi,
+	}, tc)
+
+	h.Del(HeaderB3Sampled)
+	h.Set(HeaderB3Flags, "1")
+	tc, ok = ParseB3Multi(h)
+	require.True(t, ok)
+	assert.True(t, tc.Debug)
+	assert.True(t, tc.Sampled)
+
+	_, ok = ParseB3Multi(http.Header{})
+	assert.False(t, ok)
+}
+
+func TestExtract_Precedence(t *testing.T) {
+	h := http.Header{}
+	h.Set(HeaderB3, "463ac35c9f6413ad-a2fb4a1d1a96d312-1")
+	h.Set(HeaderTraceparent, "00-"+w3cTraceID+"-"+w3cSpanID+"-01")
+	h.Set(HeaderTracestate, "congo=t61rcWkgMzE")
+
+	tc, ok := Extract(h)
+	require.True(t, ok)
+	assert.Equal(t, FormatW3C, tc.Format)
+	assert.Equal(t, "congo=t61rcWkgMzE", tc.TraceState)
+
+	h.Set(HeaderTraceparent, "garbage")
+	tc, ok = Extract(h)
+	require.True(t, ok)
+	assert.Equal(t, FormatB3Single, tc.Format)
+}
+
+func TestInject_RoundTrip(t *testing.T) {
+	inputs := []http.Header{
+		{"Traceparent": {"00-" + w3cTraceID + "-" + w3cSpanID + "-01"}, "Tracestate": {"a=1"}},
+		{"B3": {"463ac35c9f6413ad-a2fb4a1d1a96d312-d-0020000000000001"}},
+		{"X-B3-Traceid": {"463ac35c9f6413ad"}, "X-B3-Spanid": {"a2fb4a1d1a96d312"}, "X-B3-Sampled": {"0"}},
+	}
+
+	for _, in := range inputs {
+		tc, ok := Extract(in)
+		require.True(t, ok)
+
+		out := http.Header{}
+		Inject(out, tc)
+		assert.Equal(t, in, out)
+	}
+}
+
+func TestTraceparent_FromB3(t *testing.T) {
+	tc := TraceContext{TraceID: "463ac35c9f6413ad", SpanID: "a2fb4a1d1a96d312", Sampled: true}
+	assert.Equal(t, "00-0000000000000000463ac35c9f6413ad-a2fb4a1d1a96d312-01", tc.Traceparent())
+}
+
+func TestContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	tc := TraceContext{TraceID: w3cTraceID, SpanID: w3cSpanID, Format: FormatW3C}
+	ctx := WithTraceContext(context.Background(), tc)
+
+	got, ok := FromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, tc, got)
+
+	h := http.Header{}
+	InjectContext(ctx, h)
+	assert.Equal(t, tc.Traceparent(), h.Get(HeaderTraceparent))
+
+	empty := http.Header{}
+	InjectContext(context.Background(), empty)
+	assert.Empty(t, empty)
+}
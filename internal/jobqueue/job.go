@@ -0,0 +1,77 @@
+// Package jobqueue provides a durable queue for background work.
+//
+// Jobs are persisted through a Storage so pending work survives restarts.
+// Failed jobs are retried with exponential backoff; jobs that exhaust their
+// attempts are moved to the dead-letter state, where they stay until an
+// operator requeues them. Done and dead jobs are purged once they are older
+// than the queue's retention.
+package jobqueue
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Status is the lifecycle state of a job.
+type Status string
+
+// Job statuses.
+const (
+	// StatusPending jobs wait for their RunAt time
+	StatusPending Status = "pending"
+
+	// StatusRunning jobs are being processed by a worker
+	StatusRunning Status = "running"
+
+	// StatusDone jobs completed successfully
+	StatusDone Status = "done"
+
+	// StatusDead jobs exhausted their attempts (dead letter)
+	StatusDead Status = "dead"
+)
+
+// IsValid checks if the status is a valid value.
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusRunning, StatusDone, StatusDead:
+		return true
+	default:
+		return false
+	}
+}
+
+// Sentinel errors returned by the queue.
+var (
+	// ErrJobNotFound is returned when no job has the requested ID
+	ErrJobNotFound = errors.New("job not found")
+
+	// ErrNotDead is returned when requeueing a job that is not in the dead-letter state
+	ErrNotDead = errors.New("job is not dead")
+)
+
+// Job is a persisted unit of background work.
+type Job struct {
+	ID      string          `json:"id"`
+	Kind    string          `json:"kind"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Status  Status          `json:"status"`
+
+	// Attempts is the number of times the job has been started
+	Attempts    int `json:"attempts"`
+	MaxAttempts int `json:"maxAttempts"`
+
+	// RunAt is the earliest time the job may run
+	RunAt time.Time `json:"runAt"`
+
+	// LastError is the error of the most recent failed attempt
+	LastError string `json:"lastError,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Decode unmarshals the job payload into v.
+func (j Job) Decode(v any) error {
+	return json.Unmarshal(j.Payload, v)
+}
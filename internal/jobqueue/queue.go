@@ -0,0 +1,509 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// Default queue settings.
+const (
+	DefaultMaxAttempts  = 5
+	DefaultBaseBackoff  = time.Second
+	DefaultMaxBackoff   = 5 * time.Minute
+	DefaultPollInterval = time.Second
+	DefaultWorkers      = 2
+	DefaultRetention    = 7 * 24 * time.Hour
+)
+
+// Handler processes a job. Returning an error schedules a retry, or moves the
+// job to the dead-letter state once its attempts are exhausted.
+type Handler func(ctx context.Context, job Job) error
+
+// Config contains queue settings. Zero values use the defaults.
+type Config struct {
+	// MaxAttempts is the default number of attempts before a job is dead
+	MaxAttempts int
+
+	// BaseBackoff is the delay before the first retry; it doubles per attempt up to MaxBackoff
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+
+	// PollInterval is how often idle workers check for due jobs
+	PollInterval time.Duration
+
+	// Workers is the number of jobs processed concurrently
+	Workers int
+
+	// Retention is how long done and dead jobs are kept after they finish
+	Retention time.Duration
+}
+
+// withDefaults fills zero values with the defaults.
+func (c Config) withDefaults() Config {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefaultMaxAttempts
+	}
+	if c.BaseBackoff <= 0 {
+		c.BaseBackoff = DefaultBaseBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = DefaultMaxBackoff
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = DefaultPollInterval
+	}
+	if c.Workers <= 0 {
+		c.Workers = DefaultWorkers
+	}
+	if c.Retention <= 0 {
+		c.Retention = DefaultRetention
+	}
+	return c
+}
+
+// EnqueueOption customizes an enqueued job.
+type EnqueueOption func(*Job)
+
+// WithMaxAttempts overrides the queue's default number of attempts.
+func WithMaxAttempts(n int) EnqueueOption {
+	return func(j *Job) {
+		if n > 0 {
+			j.MaxAttempts = n
+		}
+	}
+}
+
+// WithRunAt delays the job until t.
+func WithRunAt(t time.Time) EnqueueOption {
+	return func(j *Job) {
+		j.RunAt = t
+	}
+}
+
+// Queue is a durable job queue. It is safe for concurrent use.
+type Queue struct {
+	storage Storage
+	cfg     Config
+	clock   timeutil.Clock
+	logger  zerolog.Logger
+
+	mu       sync.Mutex
+	jobs     map[string]*Job
+	handlers map[string]Handler
+
+	cancel  context.CancelFunc
+	workers sync.WaitGroup
+}
+
+// Option configures a Queue.
+type Option func(*Queue)
+
+// WithClock sets the clock used for scheduling (default: real time).
+func WithClock(clock timeutil.Clock) Option {
+	return func(q *Queue) {
+		q.clock = clock
+	}
+}
+
+// WithLogger sets the logger for job outcomes (default: disabled).
+func WithLogger(logger zerolog.Logger) Option {
+	return func(q *Queue) {
+		q.logger = logger.With().Str("component", "jobqueue").Logger()
+	}
+}
+
+// Open loads the persisted jobs from storage and returns a queue ready to start.
+// Jobs that were running when the process stopped are returned to pending so
+// they are retried.
+func Open(ctx context.Context, storage Storage, cfg Config, opts ...Option) (*Queue, error) {
+	q := &Queue{
+		storage:  storage,
+		cfg:      cfg.withDefaults(),
+		clock:    timeutil.NewRealClock(),
+		logger:   zerolog.Nop(),
+		jobs:     make(map[string]*Job),
+		handlers: make(map[string]Handler),
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+
+	jobs, err := storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load jobs: %w", err)
+	}
+
+	var interrupted []*Job
+	for i := range jobs {
+		job := jobs[i]
+		if job.Status == StatusRunning {
+			job.Status = StatusPending
+			interrupted = append(interrupted, &job)
+		}
+		q.jobs[job.ID] = &job
+	}
+
+	if len(interrupted) > 0 {
+		if err := q.persistLocked(ctx, interrupted...); err != nil {
+			return nil, err
+		}
+		q.logger.Warn().Int("jobs", len(interrupted)).Msg("Requeued jobs interrupted by shutdown")
+	}
+	return q, nil
+}
+
+// Handle registers the handler for a job kind. Handlers must be registered before Start.
+func (q *Queue) Handle(kind string, h Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[kind] = h
+}
+
+// Enqueue persists a new job whose payload is the JSON encoding of payload.
+func (q *Queue) Enqueue(ctx context.Context, kind string, payload any, opts ...EnqueueOption) (Job, error) {
+	if kind == "" {
+		return Job{}, errors.New("job kind is required")
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Job{}, fmt.Errorf("encode payload: %w", err)
+	}
+
+	now := q.clock.Now()
+	job := Job{
+		ID:          uuid.NewString(),
+		Kind:        kind,
+		Payload:     data,
+		Status:      StatusPending,
+		MaxAttempts: q.cfg.MaxAttempts,
+		RunAt:       now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for _, opt := range opts {
+		opt(&job)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.jobs[job.ID] = &job
+	if err := q.persistLocked(ctx, &job); err != nil {
+		delete(q.jobs, job.ID)
+		return Job{}, err
+	}
+	return job, nil
+}
+
+// Get returns the job with the given ID.
+func (q *Queue) Get(id string) (Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	return *job, nil
+}
+
+// List returns the jobs with the given status, or all jobs if status is empty,
+// ordered by creation time.
+func (q *Queue) List(status Status) []Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	jobs := make([]Job, 0, len(q.jobs))
+	for _, job := range q.jobs {
+		if status == "" || job.Status == status {
+			jobs = append(jobs, *job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].CreatedAt.Equal(jobs[j].CreatedAt) {
+			return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+		}
+		return jobs[i].ID < jobs[j].ID
+	})
+	return jobs
+}
+
+// Requeue moves a dead job back to pending with a fresh set of attempts.
+func (q *Queue) Requeue(ctx context.Context, id string) (Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	if job.Status != StatusDead {
+		return Job{}, ErrNotDead
+	}
+
+	prev := *job
+	now := q.clock.Now()
+	job.Status = StatusPending
+	job.Attempts = 0
+	job.RunAt = now
+	job.UpdatedAt = now
+	if err := q.persistLocked(ctx, job); err != nil {
+		*job = prev
+		return Job{}, err
+	}
+	return *job, nil
+}
+
+// Start launches the workers. They stop when ctx is cancelled or Stop is called.
+func (q *Queue) Start(ctx context.Context) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.cancel != nil {
+		return
+	}
+	ctx, q.cancel = context.WithCancel(ctx)
+
+	for i := 0; i < q.cfg.Workers; i++ {
+		q.workers.Add(1)
+		go q.work(ctx)
+	}
+}
+
+// Stop cancels the workers and waits for in-progress jobs to return or ctx to expire.
+// Jobs interrupted by Stop are retried on the next Open.
+func (q *Queue) Stop(ctx context.Context) error {
+	q.mu.Lock()
+	cancel := q.cancel
+	q.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		q.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work processes due jobs until ctx is cancelled.
+func (q *Queue) work(ctx context.Context) {
+	defer q.workers.Done()
+
+	ticker := time.NewTicker(q.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain due jobs before waiting for the next poll
+		for ctx.Err() == nil && q.RunOnce(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce processes the oldest due job, if any. It returns false when no job is due.
+// Workers call it on every poll; tests call it directly for deterministic processing.
+func (q *Queue) RunOnce(ctx context.Context) bool {
+	job, handler, ok := q.claim(ctx)
+	if !ok {
+		return false
+	}
+
+	err := q.invoke(ctx, handler, job)
+	if ctx.Err() != nil && err != nil {
+		// Shutdown interrupted the job; leave it running so Open requeues it
+		// without counting the interruption as a failure.
+		return true
+	}
+	q.complete(ctx, job.ID, err)
+	return true
+}
+
+// claim marks the oldest due pending job as running. Finished jobs past
+// their retention are purged first.
+func (q *Queue) claim(ctx context.Context) (Job, Handler, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.clock.Now()
+	q.purgeLocked(ctx, now)
+
+	var next *Job
+	for _, job := range q.jobs {
+		if job.Status != StatusPending || job.RunAt.After(now) {
+			continue
+		}
+		if next == nil || job.RunAt.Before(next.RunAt) ||
+			(job.RunAt.Equal(next.RunAt) && job.CreatedAt.Before(next.CreatedAt)) {
+			next = job
+		}
+	}
+	if next == nil {
+		return Job{}, nil, false
+	}
+
+	next.Status = StatusRunning
+	next.Attempts++
+	next.UpdatedAt = now
+	if err := q.persistLocked(ctx, next); err != nil {
+		q.logger.Error().Err(err).Str("job_id", next.ID).Msg("Failed to persist claimed job")
+	}
+	return *next, q.handlers[next.Kind], true
+}
+
+// invoke runs the handler, converting panics into errors.
+func (q *Queue) invoke(ctx context.Context, handler Handler, job Job) (err error) {
+	if handler == nil {
+		return fmt.Errorf("no handler registered for kind %q", job.Kind)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panic: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// complete records the outcome of a job attempt.
+func (q *Queue) complete(ctx context.Context, id string, runErr error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok {
+		return
+	}
+
+	now := q.clock.Now()
+	job.UpdatedAt = now
+
+	switch {
+	case runErr == nil:
+		job.Status = StatusDone
+		job.LastError = ""
+	case job.Attempts >= job.MaxAttempts:
+		job.Status = StatusDead
+		job.LastError = runErr.Error()
+		q.logger.Error().Err(runErr).Str("job_id", id).Str("kind", job.Kind).Int("attempts", job.Attempts).
+			Msg("Job moved to dead letter")
+	default:
+		job.Status = StatusPending
+		job.LastError = runErr.Error()
+		job.RunAt = now.Add(q.backoff(job.Attempts))
+		q.logger.Warn().Err(runErr).Str("job_id", id).Str("kind", job.Kind).Int("attempts", job.Attempts).
+			Time("retry_at", job.RunAt).Msg("Job failed, retrying")
+	}
+
+	if err := q.persistLocked(ctx, job); err != nil {
+		q.logger.Error().Err(err).Str("job_id", id).Msg("Failed to persist job result")
+	}
+}
+
+// backoff returns the retry delay after the given number of attempts.
+func (q *Queue) backoff(attempts int) time.Duration {
+	d := q.cfg.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= q.cfg.MaxBackoff {
+			return q.cfg.MaxBackoff
+		}
+	}
+	return d
+}
+
+// purgeLocked removes the done and dead jobs that finished more than the
+// retention before now from memory and storage. The caller must hold q.mu.
+func (q *Queue) purgeLocked(ctx context.Context, now time.Time) {
+	cutoff := now.Add(-q.cfg.Retention)
+	expired := make(map[string]*Job)
+	for id, job := range q.jobs {
+		if (job.Status == StatusDone || job.Status == StatusDead) && job.UpdatedAt.Before(cutoff) {
+			expired[id] = job
+		}
+	}
+	if len(expired) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(expired))
+	for id := range expired {
+		ids = append(ids, id)
+		delete(q.jobs, id)
+	}
+	sort.Strings(ids)
+
+	if err := q.deleteLocked(ctx, ids); err != nil {
+		for id, job := range expired {
+			q.jobs[id] = job
+		}
+		q.logger.Error().Err(err).Int("jobs", len(ids)).Msg("Failed to purge finished jobs")
+		return
+	}
+	q.logger.Info().Int("jobs", len(ids)).Msg("Purged finished jobs")
+}
+
+// deleteLocked removes the jobs with the given IDs from storage, which have
+// already been removed from q.jobs. The caller must hold q.mu.
+func (q *Queue) deleteLocked(ctx context.Context, ids []string) error {
+	if storage, ok := q.storage.(JobStorage); ok {
+		if err := storage.DeleteJobs(context.WithoutCancel(ctx), ids); err != nil {
+			return fmt.Errorf("delete jobs: %w", err)
+		}
+		return nil
+	}
+	return q.persistLocked(ctx)
+}
+
+// persistLocked saves the changed jobs, or a snapshot of all jobs if the
+// storage is not a JobStorage. The caller must hold q.mu. A cancelled context
+// does not prevent the save, so shutdown never loses state.
+func (q *Queue) persistLocked(ctx context.Context, changed ...*Job) error {
+	ctx = context.WithoutCancel(ctx)
+	if storage, ok := q.storage.(JobStorage); ok {
+		for _, job := range changed {
+			if err := storage.SaveJob(ctx, *job); err != nil {
+				return fmt.Errorf("save job %s: %w", job.ID, err)
+			}
+		}
+		return nil
+	}
+
+	jobs := make([]Job, 0, len(q.jobs))
+	for _, job := range q.jobs {
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+	})
+
+	if err := q.storage.Save(ctx, jobs); err != nil {
+		return fmt.Errorf("save jobs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+package jobqueue
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/encryption"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+type alertPayload struct {
+	AlertID string `json:"alertId"`
+}
+
+func openQueue(t *testing.T, storage Storage, clock *timeutil.MockClock) *Queue {
+	t.Helper()
+	q, err := Open(context.Background(), storage, Config{MaxAttempts: 3, BaseBackoff: time.Second, MaxBackoff: 3 * time.Second}, WithClock(clock))
+	require.NoError(t, err)
+	return q
+}
+
+func TestQueue_EnqueueAndRun(t *testing.T) {
+	ctx := context.Background()
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	q := openQueue(t, NewMemoryStorage(), clock)
+
+	var got alertPayload
+	q.Handle("price_alert", func(_ context.Context, job Job) error {
+		return job.Decode(&got)
+	})
+
+	job, err := q.Enqueue(ctx, "price_alert", alertPayload{AlertID: "alert-1"})
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, job.Status)
+	assert.Equal(t, 3, job.MaxAttempts)
+
+	require.True(t, q.RunOnce(ctx))
+	assert.False(t, q.RunOnce(ctx), "no more due jobs")
+	assert.Equal(t, "alert-1", got.AlertID)
+
+	done, err := q.Get(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusDone, done.Status)
+	assert.Equal(t, 1, done.Attempts)
+}
+
+func TestQueue_RetryWithBackoffThenDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	q := openQueue(t, NewMemoryStorage(), clock)
+
+	q.Handle("flaky", func(context.Context, Job) error { return errors.New("provider down") })
+	job, err := q.Enqueue(ctx, "flaky", nil)
+	require.NoError(t, err)
+
+	// Attempt 1 fails, retry after 1s
+	require.True(t, q.RunOnce(ctx))
+	job, _ = q.Get(job.ID)
+	assert.Equal(t, StatusPending, job.Status)
+	assert.Equal(t, "provider down", job.LastError)
+	assert.Equal(t, clock.Now().Add(time.Second), job.RunAt)
+	assert.False(t, q.RunOnce(ctx), "job is backing off")
+
+	// Attempt 2 fails, backoff doubles
+	clock.Advance(time.Second)
+	require.True(t, q.RunOnce(ctx))
+	job, _ = q.Get(job.ID)
+	assert.Equal(t, clock.Now().Add(2*time.Second), job.RunAt)
+
+	// Attempt 3 exhausts the attempts
+	clock.Advance(2 * time.Second)
+	require.True(t, q.RunOnce(ctx))
+	job, _ = q.Get(job.ID)
+	assert.Equal(t, StatusDead, job.Status)
+	assert.Equal(t, 3, job.Attempts)
+	assert.Equal(t, []Job{job}, q.List(StatusDead))
+
+	// Requeue from the dead letter
+	_, err = q.Requeue(ctx, "missing")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+
+	requeued, err := q.Requeue(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, requeued.Status)
+	assert.Zero(t, requeued.Attempts)
+
+	_, err = q.Requeue(ctx, job.ID)
+	assert.ErrorIs(t, err, ErrNotDead)
+}
+
+func TestQueue_BackoffIsCapped(t *testing.T) {
+	q := &Queue{cfg: Config{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second}}
+	assert.Equal(t, time.Second, q.backoff(1))
+	assert.Equal(t, 2*time.Second, q.backoff(2))
+	assert.Equal(t, 4*time.Second, q.backoff(3))
+	assert.Equal(t, 5*time.Second, q.backoff(4))
+	assert.Equal(t, 5*time.Second, q.backoff(30))
+}
+
+func TestQueue_PanicsAndUnknownKindsFail(t *testing.T) {
+	ctx := context.Background()
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	q := openQueue(t, NewMemoryStorage(), clock)
+	q.Handle("panics", func(context.Context, Job) error { panic("boom") })
+
+	panics, err := q.Enqueue(ctx, "panics", nil, WithMaxAttempts(1))
+	require.NoError(t, err)
+	unknown, err := q.Enqueue(ctx, "unknown", nil, WithMaxAttempts(1))
+	require.NoError(t, err)
+
+	for q.RunOnce(ctx) {
+	}
+
+	panics, _ = q.Get(panics.ID)
+	assert.Equal(t, StatusDead, panics.Status)
+	assert.Equal(t, "job panic: boom", panics.LastError)
+
+	unknown, _ = q.Get(unknown.ID)
+	assert.Equal(t, StatusDead, unknown.Status)
+	assert.Contains(t, unknown.LastError, "no handler registered")
+}
+
+func TestQueue_RunAt(t *testing.T) {
+	ctx := context.Background()
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	q := openQueue(t, NewMemoryStorage(), clock)
+	q.Handle("later", func(context.Context, Job) error { return nil })
+
+	_, err := q.Enqueue(ctx, "later", nil, WithRunAt(clock.Now().Add(time.Hour)))
+	require.NoError(t, err)
+
+	assert.False(t, q.RunOnce(ctx))
+	clock.Advance(time.Hour)
+	assert.True(t, q.RunOnce(ctx))
+}
+
+func TestQueue_SurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	storage := NewFileStorage(filepath.Join(t.TempDir(), "queue", "jobs.json"))
+
+	q := openQueue(t, storage, clock)
+	pending, err := q.Enqueue(ctx, "async_search", map[string]string{"origin": "CGK"})
+	require.NoError(t, err)
+	clock.Advance(time.Second)
+	interrupted, err := q.Enqueue(ctx, "async_search", map[string]string{"origin": "DPS"})
+	require.NoError(t, err)
+
+	// Simulate a crash while the second job was running
+	q.mu.Lock()
+	q.jobs[interrupted.ID].Status = StatusRunning
+	require.NoError(t, q.persistLocked(ctx))
+	q.mu.Unlock()
+
+	restarted := openQueue(t, storage, clock)
+	jobs := restarted.List(StatusPending)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, pending.ID, jobs[0].ID)
+	assert.Equal(t, interrupted.ID, jobs[1].ID)
+	assert.JSONEq(t, `{"origin":"DPS"}`, string(jobs[1].Payload))
+}
+
+// rowStorage is a JobStorage keeping jobs in memory, recording the IDs of
+// the jobs saved and deleted one at a time and counting full snapshots.
+type rowStorage struct {
+	*MemoryStorage
+	saved     []string
+	deleted   []string
+	snapshots int
+}
+
+func (s *rowStorage) Save(ctx context.Context, jobs []Job) error {
+	s.snapshots++
+	return s.MemoryStorage.Save(ctx, jobs)
+}
+
+func (s *rowStorage) SaveJob(ctx context.Context, job Job) error {
+	s.saved = append(s.saved, job.ID)
+	jobs, _ := s.Load(ctx)
+	for i := range jobs {
+		if jobs[i].ID == job.ID {
+			jobs[i] = job
+			return s.MemoryStorage.Save(ctx, jobs)
+		}
+	}
+	return s.MemoryStorage.Save(ctx, append(jobs, job))
+}
+
+func (s *rowStorage) DeleteJobs(ctx context.Context, ids []string) error {
+	s.deleted = append(s.deleted, ids...)
+	jobs, _ := s.Load(ctx)
+	kept := jobs[:0]
+	for _, job := range jobs {
+		if !slices.Contains(ids, job.ID) {
+			kept = append(kept, job)
+		}
+	}
+	return s.MemoryStorage.Save(ctx, kept)
+}
+
+func TestQueue_SavesChangedJobsOnly(t *testing.T) {
+	ctx := context.Background()
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	storage := &rowStorage{MemoryStorage: NewMemoryStorage()}
+
+	q := openQueue(t, storage, clock)
+	q.Handle("async_search", func(context.Context, Job) error { return nil })
+	first, err := q.Enqueue(ctx, "async_search", nil)
+	require.NoError(t, err)
+	clock.Advance(time.Second)
+	second, err := q.Enqueue(ctx, "async_search", nil)
+	require.NoError(t, err)
+	require.True(t, q.RunOnce(ctx))
+
+	// Enqueued, enqueued, then claimed and completed
+	assert.Equal(t, []string{first.ID, second.ID, first.ID, first.ID}, storage.saved)
+	assert.Zero(t, storage.snapshots)
+
+	restarted := openQueue(t, storage, clock)
+	assert.Len(t, restarted.List(StatusDone), 1)
+	assert.Len(t, restarted.List(StatusPending), 1)
+}
+
+func TestQueue_PurgesFinishedJobsAfterRetention(t *testing.T) {
+	storages := map[string]func() Storage{
+		"snapshot": func() Storage { return NewMemoryStorage() },
+		"per job":  func() Storage { return &rowStorage{MemoryStorage: NewMemoryStorage()} },
+	}
+	for name, newStorage := range storages {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+			storage := newStorage()
+			q, err := Open(ctx, storage, Config{MaxAttempts: 1, Retention: time.Hour}, WithClock(clock))
+			require.NoError(t, err)
+			q.Handle("async_search", func(context.Context, Job) error { return nil })
+			q.Handle("flaky", func(context.Context, Job) error { return errors.New("provider down") })
+
+			done, err := q.Enqueue(ctx, "async_search", nil)
+			require.NoError(t, err)
+			dead, err := q.Enqueue(ctx, "flaky", nil)
+			require.NoError(t, err)
+			require.True(t, q.RunOnce(ctx))
+			require.True(t, q.RunOnce(ctx))
+			pending, err := q.Enqueue(ctx, "async_search", nil, WithRunAt(clock.Now().Add(2*time.Hour)))
+			require.NoError(t, err)
+
+			// Within the retention finished jobs are kept
+			clock.Advance(time.Hour)
+			assert.False(t, q.RunOnce(ctx))
+			assert.Len(t, q.List(""), 3)
+
+			clock.Advance(time.Second)
+			assert.False(t, q.RunOnce(ctx))
+			_, err = q.Get(done.ID)
+			assert.ErrorIs(t, err, ErrJobNotFound)
+			_, err = q.Get(dead.ID)
+			assert.ErrorIs(t, err, ErrJobNotFound)
+
+			stored, err := storage.Load(ctx)
+			require.NoError(t, err)
+			require.Len(t, stored, 1, "purged jobs are removed from storage")
+			assert.Equal(t, pending.ID, stored[0].ID)
+		})
+	}
+}
+
+func TestQueue_Workers(t *testing.T) {
+	q, err := Open(context.Background(), NewMemoryStorage(), Config{PollInterval: 5 * time.Millisecond, Workers: 2})
+	require.NoError(t, err)
+
+	var processed atomic.Int32
+	q.Handle("count", func(context.Context, Job) error {
+		processed.Add(1)
+		return nil
+	})
+
+	q.Start(context.Background())
+	for i := 0; i < 10; i++ {
+		_, err := q.Enqueue(context.Background(), "count", i)
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool { return processed.Load() == 10 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, q.Stop(context.Background()))
+	assert.Len(t, q.List(StatusDone), 10)
+}
+
+func TestQueue_StopLeavesInterruptedJobForRestart(t *testing.T) {
+	storage := NewMemoryStorage()
+	q, err := Open(context.Background(), storage, Config{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	q.Handle("blocking", func(ctx context.Context, _ Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	job, err := q.Enqueue(context.Background(), "blocking", nil)
+	require.NoError(t, err)
+
+	q.Start(context.Background())
+	<-started
+	require.NoError(t, q.Stop(context.Background()))
+
+	restarted, err := Open(context.Background(), storage, Config{})
+	require.NoError(t, err)
+	got, err := restarted.Get(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, got.Status)
+	assert.Empty(t, got.LastError, "interruption is not a failure")
+}
+
+func TestFileStorage_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	jobs, err := NewFileStorage(filepath.Join(dir, "missing.json")).Load(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	corrupt := filepath.Join(dir, "corrupt.json")
+	require.NoError(t, NewFileStorage(corrupt).Save(context.Background(), nil))
+	require.NoError(t, os.WriteFile(corrupt, []byte("{"), 0o600))
+
+	_, err = Open(context.Background(), NewFileStorage(corrupt), Config{})
+	assert.ErrorContains(t, err, "parse job queue file")
+}
+
+func TestFileStorage_Encryption(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	key := func(id string, fill byte) encryption.Key {
+		return encryption.Key{ID: id, Secret: bytes.Repeat([]byte{fill}, encryption.KeySize)}
+	}
+	oldKeys, err := encryption.NewKeyring(key("old", 1))
+	require.NoError(t, err)
+	newKeys, err := encryption.NewKeyring(key("new", 2), key("old", 1))
+	require.NoError(t, err)
+	jobs := []Job{{ID: "job-1", Kind: "price_alert", Payload: []byte(`{"email":"traveller@example.com"}`)}}
+
+	// Plaintext files are encrypted on first load
+	require.NoError(t, NewFileStorage(path).Save(ctx, jobs))
+	loaded, err := NewFileStorage(path, WithEncryption(oldKeys)).Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, jobs[0].ID, loaded[0].ID)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("enc:v1:old:")))
+	assert.NotContains(t, string(data), "traveller")
+
+	// Files are re-encrypted under a rotated primary key
+	loaded, err = NewFileStorage(path, WithEncryption(newKeys)).Load(ctx)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(jobs[0].Payload), string(loaded[0].Payload))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("enc:v1:new:")))
+
+	// Encrypted files cannot be read without the keys
+	_, err = NewFileStorage(path).Load(ctx)
+	assert.ErrorContains(t, err, "no encryption keys are configured")
+	_, err = NewFileStorage(path, WithEncryption(oldKeys)).Load(ctx)
+	assert.ErrorIs(t, err, encryption.ErrUnknownKey)
+}
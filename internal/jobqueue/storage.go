@@ -0,0 +1,184 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/encryption"
+)
+
+// Storage persists the queue's jobs. The queue saves a full snapshot after
+// every state change, so implementations only need atomic replace semantics.
+type Storage interface {
+	// Load returns all persisted jobs. An empty store returns no jobs and no error.
+	Load(ctx context.Context) ([]Job, error)
+
+	// Save atomically replaces the persisted jobs.
+	Save(ctx context.Context, jobs []Job) error
+}
+
+// JobStorage is a Storage that can save a single job. The queue saves only
+// the job that changed through it, so the cost of a state change does not
+// grow with the number of jobs kept, dead-lettered ones included.
+type JobStorage interface {
+	Storage
+
+	// SaveJob inserts or replaces the persisted job with the ID of job.
+	SaveJob(ctx context.Context, job Job) error
+
+	// DeleteJobs removes the persisted jobs with the given IDs. Unknown IDs
+	// are ignored.
+	DeleteJobs(ctx context.Context, ids []string) error
+}
+
+// MemoryStorage keeps jobs in memory. Jobs do not survive restarts;
+// it is intended for tests and for running without a data directory.
+type MemoryStorage struct {
+	mu   sync.Mutex
+	jobs []Job
+}
+
+// NewMemoryStorage creates an empty in-memory storage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{}
+}
+
+// Load implements Storage.
+func (s *MemoryStorage) Load(context.Context) ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Job(nil), s.jobs...), nil
+}
+
+// Save implements Storage.
+func (s *MemoryStorage) Save(_ context.Context, jobs []Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append([]Job(nil), jobs...)
+	return nil
+}
+
+// fileAdditionalData binds encrypted job queue files to their purpose.
+var fileAdditionalData = []byte("jobqueue")
+
+// FileStorage persists jobs as a JSON file. Writes go to a temporary file that
+// is renamed over the original, so a crash never leaves a partial snapshot.
+type FileStorage struct {
+	path    string
+	keyring *encryption.Keyring
+	mu      sync.Mutex
+}
+
+// FileOption configures a FileStorage.
+type FileOption func(*FileStorage)
+
+// WithEncryption encrypts the file with the keyring's primary key.
+// Existing plaintext files and files encrypted with an older key are still
+// read, and are rewritten under the primary key when loaded.
+func WithEncryption(keyring *encryption.Keyring) FileOption {
+	return func(s *FileStorage) {
+		s.keyring = keyring
+	}
+}
+
+// NewFileStorage creates a storage backed by the JSON file at path.
+// The file and its directory are created on first save.
+func NewFileStorage(path string, opts ...FileOption) *FileStorage {
+	s := &FileStorage{path: path}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Load implements Storage.
+func (s *FileStorage) Load(context.Context) ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read job queue file: %w", err)
+	}
+
+	plaintext := data
+	switch {
+	case encryption.IsEncrypted(data) && s.keyring == nil:
+		return nil, errors.New("job queue file is encrypted but no encryption keys are configured")
+	case encryption.IsEncrypted(data):
+		if plaintext, err = s.keyring.Decrypt(data, fileAdditionalData); err != nil {
+			return nil, fmt.Errorf("decrypt job queue file: %w", err)
+		}
+	}
+
+	var jobs []Job
+	if err := json.Unmarshal(plaintext, &jobs); err != nil {
+		return nil, fmt.Errorf("parse job queue file: %w", err)
+	}
+
+	// Migrate plaintext files and files written under a rotated key
+	if s.keyring != nil && s.keyring.NeedsRotation(data) {
+		if err := s.saveLocked(jobs); err != nil {
+			return nil, err
+		}
+	}
+	return jobs, nil
+}
+
+// Save implements Storage.
+func (s *FileStorage) Save(_ context.Context, jobs []Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.saveLocked(jobs)
+}
+
+// saveLocked writes the snapshot. Caller must hold s.mu.
+func (s *FileStorage) saveLocked(jobs []Job) error {
+	if jobs == nil {
+		jobs = []Job{}
+	}
+	data, err := json.Marshal(jobs)
+	if err != nil {
+		return fmt.Errorf("encode jobs: %w", err)
+	}
+	if s.keyring != nil {
+		if data, err = s.keyring.Encrypt(data, fileAdditionalData); err != nil {
+			return fmt.Errorf("encrypt jobs: %w", err)
+		}
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create job queue directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary job queue file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write job queue file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync job queue file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close job queue file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replace job queue file: %w", err)
+	}
+	return nil
+}
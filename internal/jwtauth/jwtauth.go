@@ -0,0 +1,449 @@
+// Package jwtauth authenticates API callers with OAuth2 access tokens.
+//
+// Tokens are JWTs issued by an external authorization server and signed
+// with one of the keys it publishes at its JWKS URL. A Verifier checks the
+// signature (RS256, ES256 or EdDSA), the issuer, the audience and the
+// validity window of a token, and returns its subject and scopes; the HTTP
+// layer maps the scopes to RBAC roles. The key set is fetched on first use,
+// refreshed periodically, and refetched early when a token is signed with a
+// key it does not know, so the authorization server can rotate keys.
+package jwtauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// DefaultRefreshInterval is how long a fetched key set is used by default
+// before it is fetched again.
+const DefaultRefreshInterval = time.Hour
+
+// minRefetchInterval is how soon after a fetch the key set may be fetched
+// again for a token signed with an unknown key, so tokens with made-up key
+// IDs cannot hammer the authorization server.
+const minRefetchInterval = time.Minute
+
+// retryInterval is how soon after a failed fetch the key set may be fetched
+// again, so an unreachable authorization server is not asked on every request.
+const retryInterval = 5 * time.Second
+
+// fetchTimeout bounds fetching the key set.
+const fetchTimeout = 5 * time.Second
+
+// maxKeySetBytes caps the size of the key set document.
+const maxKeySetBytes = 1 << 20
+
+// clockSkew is the tolerated difference between the issuer's and verifier's clocks.
+const clockSkew = 30 * time.Second
+
+// Supported signature algorithms.
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// Sentinel errors returned by Verify.
+var (
+	// ErrInvalidToken is returned for malformed tokens, bad signatures and
+	// tokens of another issuer or audience
+	ErrInvalidToken = errors.New("invalid bearer token")
+
+	// ErrTokenExpired is returned for tokens past their expiry
+	ErrTokenExpired = errors.New("bearer token expired")
+
+	// ErrKeysUnavailable is returned when the key set cannot be fetched, so
+	// no token can be verified
+	ErrKeysUnavailable = errors.New("token signing keys unavailable")
+)
+
+// Claims are the verified contents of a token.
+type Claims struct {
+	// Subject identifies the caller
+	Subject string
+
+	// Scopes are the scopes granted to the caller, from the space-separated
+	// "scope" claim or the "scp" claim
+	Scopes []string
+
+	// ExpiresAt is when the token expires
+	ExpiresAt time.Time
+}
+
+// HasScope reports whether the claims grant scope.
+func (c Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// Config configures a Verifier.
+type Config struct {
+	// Issuer is the required "iss" claim
+	Issuer string
+
+	// Audience must be one of the "aud" claim's values
+	Audience string
+
+	// JWKSURL is where the authorization server publishes its signing keys
+	JWKSURL string
+
+	// RefreshInterval is how long a fetched key set is used before it is
+	// fetched again (0 = DefaultRefreshInterval)
+	RefreshInterval time.Duration
+}
+
+// Verifier verifies access tokens. It is safe for concurrent use.
+type Verifier struct {
+	cfg    Config
+	client *http.Client
+	clock  timeutil.Clock
+
+	mu        sync.Mutex
+	keys      map[string]jwk
+	fetchedAt time.Time
+	fetchErr  error
+
+	// attemptedAt is the time of the last fetch, successful or not
+	attemptedAt time.Time
+}
+
+// Option configures a Verifier.
+type Option func(*Verifier)
+
+// WithClock sets the clock used for expiry checks and key set refreshes.
+func WithClock(clock timeutil.Clock) Option {
+	return func(v *Verifier) {
+		v.clock = clock
+	}
+}
+
+// WithHTTPClient fetches the key set with c.
+func WithHTTPClient(c *http.Client) Option {
+	return func(v *Verifier) {
+		v.client = c
+	}
+}
+
+// NewVerifier creates a verifier of the tokens described by cfg. It does
+// not fetch the key set: keys are fetched on the first verification.
+func NewVerifier(cfg Config, opts ...Option) (*Verifier, error) {
+	if cfg.Issuer == "" {
+		return nil, errors.New("issuer is required")
+	}
+	if cfg.Audience == "" {
+		return nil, errors.New("audience is required")
+	}
+	u, err := url.Parse(cfg.JWKSURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("JWKS URL %q must be an absolute http or https URL", cfg.JWKSURL)
+	}
+	if cfg.RefreshInterval < 0 {
+		return nil, errors.New("refresh interval must not be negative")
+	}
+	if cfg.RefreshInterval == 0 {
+		cfg.RefreshInterval = DefaultRefreshInterval
+	}
+
+	v := &Verifier{
+		cfg:    cfg,
+		client: &http.Client{Timeout: fetchTimeout},
+		clock:  timeutil.NewRealClock(),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v, nil
+}
+
+// header is the JOSE header of a token.
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// payload is the claims set of a token.
+type payload struct {
+	Issuer    string     `json:"iss"`
+	Subject   string     `json:"sub"`
+	Audience  stringList `json:"aud"`
+	Scope     string     `json:"scope"`
+	Scp       stringList `json:"scp"`
+	ExpiresAt float64    `json:"exp"`
+	NotBefore float64    `json:"nbf"`
+}
+
+// stringList is a claim holding a string or an array of strings.
+type stringList []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*l = strings.Fields(s)
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// Verify checks a token's signature, issuer, audience and validity window
+// and returns its claims.
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrInvalidToken
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	key, err := v.key(ctx, h.KeyID)
+	if err != nil {
+		return Claims{}, err
+	}
+	if !key.verify(h.Algorithm, []byte(parts[0]+"."+parts[1]), signature) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var p payload
+	if err := decodeSegment(parts[1], &p); err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+	if p.Subject == "" || p.Issuer != v.cfg.Issuer || !slices.Contains(p.Audience, v.cfg.Audience) || p.ExpiresAt == 0 {
+		return Claims{}, ErrInvalidToken
+	}
+
+	now := v.clock.Now()
+	expires := numericDate(p.ExpiresAt)
+	switch {
+	case p.NotBefore != 0 && numericDate(p.NotBefore).After(now.Add(clockSkew)):
+		return Claims{}, ErrInvalidToken
+	case !now.Before(expires.Add(clockSkew)):
+		return Claims{}, ErrTokenExpired
+	}
+
+	scopes := strings.Fields(p.Scope)
+	for _, scope := range p.Scp {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return Claims{Subject: p.Subject, Scopes: scopes, ExpiresAt: expires}, nil
+}
+
+// decodeSegment decodes a base64url-encoded JSON segment of a token into v.
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// numericDate converts a JWT NumericDate (seconds since the epoch) to a time.
+func numericDate(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
+
+// key returns the signing key with the given ID, fetching the key set if it
+// is stale or does not have the key. A token without a key ID is verified
+// with the only key of a single-key set.
+func (v *Verifier) key(ctx context.Context, keyID string) (jwk, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.clock.Now()
+	key, ok := v.lookup(keyID)
+	sinceAttempt := now.Sub(v.attemptedAt)
+	stale := v.keys == nil || now.Sub(v.fetchedAt) >= v.cfg.RefreshInterval
+	if (stale && sinceAttempt >= retryInterval) || (!ok && sinceAttempt >= minRefetchInterval) {
+		v.attemptedAt = now
+		keys, err := v.fetch(ctx)
+		v.fetchErr = err
+		if err == nil {
+			v.keys, v.fetchedAt = keys, now
+			key, ok = v.lookup(keyID)
+		}
+		// On a failed refresh the keys fetched before stay in use
+	}
+	if v.keys == nil {
+		return jwk{}, fmt.Errorf("%w: %w", ErrKeysUnavailable, v.fetchErr)
+	}
+	if !ok {
+		return jwk{}, ErrInvalidToken
+	}
+	return key, nil
+}
+
+// lookup returns the key with the given ID from the fetched set. The caller
+// must hold v.mu.
+func (v *Verifier) lookup(keyID string) (jwk, bool) {
+	if keyID == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[keyID]
+	return key, ok
+}
+
+// fetch fetches the key set, keeping the signing keys of supported types.
+// The fetch serves every waiting request, so it is not cancelled with the
+// request that started it.
+func (v *Verifier) fetch(ctx context.Context) (map[string]jwk, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.cfg.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: status %d", v.cfg.JWKSURL, resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxKeySetBytes)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", v.cfg.JWKSURL, err)
+	}
+	keys := make(map[string]jwk, len(set.Keys))
+	for _, raw := range set.Keys {
+		if key, err := parseJWK(raw); err == nil {
+			keys[key.id] = key
+		}
+	}
+	return keys, nil
+}
+
+// jwk is a public signing key of the key set.
+type jwk struct {
+	id string
+
+	// algorithm is the algorithm the key is restricted to (empty = any its type supports)
+	algorithm string
+
+	public crypto.PublicKey
+}
+
+// rawJWK is a JSON Web Key (RFC 7517) of one of the supported types.
+type rawJWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+}
+
+// parseJWK parses an RSA, P-256 or Ed25519 signing key.
+func parseJWK(data []byte) (jwk, error) {
+	var raw rawJWK
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return jwk{}, err
+	}
+	if raw.Use != "" && raw.Use != "sig" {
+		return jwk{}, fmt.Errorf("key %q is not a signing key", raw.KeyID)
+	}
+	key := jwk{id: raw.KeyID, algorithm: raw.Algorithm}
+
+	switch {
+	case raw.KeyType == "RSA":
+		n, err1 := decodeInt(raw.N)
+		e, err2 := decodeInt(raw.E)
+		if err := errors.Join(err1, err2); err != nil || !e.IsInt64() {
+			return jwk{}, fmt.Errorf("key %q: invalid RSA key", raw.KeyID)
+		}
+		key.public = &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case raw.KeyType == "EC" && raw.Curve == "P-256":
+		x, err1 := base64.RawURLEncoding.DecodeString(raw.X)
+		y, err2 := base64.RawURLEncoding.DecodeString(raw.Y)
+		if err := errors.Join(err1, err2); err != nil || len(x) != 32 || len(y) != 32 {
+			return jwk{}, fmt.Errorf("key %q: invalid P-256 key", raw.KeyID)
+		}
+		public, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return jwk{}, fmt.Errorf("key %q: %w", raw.KeyID, err)
+		}
+		key.public = public
+	case raw.KeyType == "OKP" && raw.Curve == "Ed25519":
+		x, err := base64.RawURLEncoding.DecodeString(raw.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return jwk{}, fmt.Errorf("key %q: invalid Ed25519 key", raw.KeyID)
+		}
+		key.public = ed25519.PublicKey(x)
+	default:
+		return jwk{}, fmt.Errorf("key %q: unsupported key type %q", raw.KeyID, raw.KeyType)
+	}
+	return key, nil
+}
+
+// decodeInt decodes a base64url-encoded big-endian unsigned integer.
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("invalid integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// verify reports whether signature is a valid signature of signingInput
+// with the key and algorithm.
+func (k jwk) verify(algorithm string, signingInput, signature []byte) bool {
+	if k.algorithm != "" && k.algorithm != algorithm {
+		return false
+	}
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		if algorithm != AlgorithmRS256 {
+			return false
+		}
+		digest := sha256.Sum256(signingInput)
+		return rsa.VerifyPKCS1v15(public, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		if algorithm != AlgorithmES256 || len(signature) != 64 {
+			return false
+		}
+		digest := sha256.Sum256(signingInput)
+		r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(public, digest[:], r, s)
+	case ed25519.PublicKey:
+		return algorithm == AlgorithmEdDSA && ed25519.Verify(public, signingInput, signature)
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+const (
+	testIssuer   = "https://auth.example.com/"
+	testAudience = "flight-search"
+)
+
+// testKeys are the signing keys of the test authorization server.
+type testKeys struct {
+	rsa     *rsa.PrivateKey
+	ec      *ecdsa.PrivateKey
+	ed25519 ed25519.PrivateKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return testKeys{rsa: rsaKey, ec: ecKey, ed25519: edKey}
+}
+
+// jwks returns the key set publishing the keys.
+func (k testKeys) jwks() map[string]any {
+	b64 := base64.RawURLEncoding.EncodeToString
+	ecPublic, err := k.ec.PublicKey.Bytes()
+	if err != nil {
+		panic(err)
+	}
+	return map[string]any{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa", "use": "sig", "alg": "RS256", "n": b64(k.rsa.N.Bytes()), "e": b64(big.NewInt(int64(k.rsa.E)).Bytes())},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": b64(ecPublic[1:33]), "y": b64(ecPublic[33:])},
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": b64(k.ed25519.Public().(ed25519.PublicKey))},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": b64(k.rsa.N.Bytes()), "e": "AQAB"},
+		{"kty": "oct", "kid": "secret", "k": "c2VjcmV0"},
+	}}
+}
+
+// sign returns a token with the claims, signed with the key and algorithm.
+func (k testKeys) sign(t *testing.T, alg, kid string, claims map[string]any) string {
+	t.Helper()
+	h, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+	p, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signingInput := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(p)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch kid {
+	case "rsa":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k.rsa, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case "ec":
+		r, s, err := ecdsa.Sign(rand.Reader, k.ec, digest[:])
+		require.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	default:
+		signature = ed25519.Sign(k.ed25519, []byte(signingInput))
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// serveJWKS serves the key set, counting the fetches.
+func serveJWKS(t *testing.T, jwks func() any) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(jwks())
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &fetches
+}
+
+func validClaims(now time.Time) map[string]any {
+	return map[string]any{
+		"iss":   testIssuer,
+		"sub":   "partner-app",
+		"aud":   []string{"other", testAudience},
+		"scope": "flights:search flights:export",
+		"exp":   now.Add(5 * time.Minute).Unix(),
+		"nbf":   now.Unix(),
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	keys := newTestKeys(t)
+	srv, _ := serveJWKS(t, func() any { return keys.jwks() })
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	verifier, err := NewVerifier(Config{Issuer: testIssuer, Audience: testAudience, JWKSURL: srv.URL}, WithClock(clock))
+	require.NoError(t, err)
+	now := clock.Now()
+
+	with := func(key string, value any) map[string]any {
+		claims := validClaims(now)
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantErr    error
+		wantScopes []string
+	}{
+		{"RS256", keys.sign(t, "RS256", "rsa", validClaims(now)), nil, []string{"flights:search", "flights:export"}},
+		{"ES256", keys.sign(t, "ES256", "ec", validClaims(now)), nil, []string{"flights:search", "flights:export"}},
+		{"EdDSA", keys.sign(t, "EdDSA", "ed", validClaims(now)), nil, []string{"flights:search", "flights:export"}},
+		{"no scope", keys.sign(t, "EdDSA", "ed", with("scope", nil)), nil, []string{}},
+		{"scp array", keys.sign(t, "EdDSA", "ed", func() map[string]any {
+			claims := with("scope", "flights:search")
+			claims["scp"] = []string{"flights:admin", "flights:search"}
+			return claims
+		}()), nil, []string{"flights:search", "flights:admin"}},
+		{"audience string", keys.sign(t, "EdDSA", "ed", with("aud", testAudience)), nil, []string{"flights:search", "flights:export"}},
+		{"expired within clock skew", keys.sign(t, "EdDSA", "ed", with("exp", now.Add(-10*time.Second).Unix())), nil, []string{"flights:search", "flights:export"}},
+		{"expired", keys.sign(t, "EdDSA", "ed", with("exp", now.Add(-time.Minute).Unix())), ErrTokenExpired, nil},
+		{"no expiry", keys.sign(t, "EdDSA", "ed", with("exp", nil)), ErrInvalidToken, nil},
+		{"not yet valid", keys.sign(t, "EdDSA", "ed", with("nbf", now.Add(time.Minute).Unix())), ErrInvalidToken, nil},
+		{"other issuer", keys.sign(t, "EdDSA", "ed", with("iss", "https://evil.example.com/")), ErrInvalidToken, nil},
+		{"other audience", keys.sign(t, "EdDSA", "ed", with("aud", "other")), ErrInvalidToken, nil},
+		{"no subject", keys.sign(t, "EdDSA", "ed", with("sub", "")), ErrInvalidToken, nil},
+		{"algorithm of another key type", keys.sign(t, "RS256", "ed", validClaims(now)), ErrInvalidToken, nil},
+		{"algorithm the key is not restricted to", keys.sign(t, "RS512", "rsa", validClaims(now)), ErrInvalidToken, nil},
+		{"none algorithm", keys.sign(t, "none", "ed", validClaims(now)), ErrInvalidToken, nil},
+		{"encryption key", keys.sign(t, "RS256", "enc", validClaims(now)), ErrInvalidToken, nil},
+		{"malformed", "not.a.token", ErrInvalidToken, nil},
+		{"two segments", "a.b", ErrInvalidToken, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "partner-app", claims.Subject)
+			assert.Equal(t, tt.wantScopes, claims.Scopes)
+		})
+	}
+
+	t.Run("tampered payload", func(t *testing.T) {
+		token := keys.sign(t, "EdDSA", "ed", validClaims(now))
+		other := keys.sign(t, "EdDSA", "ed", with("sub", "someone-else"))
+		parts, otherParts := strings.Split(token, "."), strings.Split(other, ".")
+		_, err := verifier.Verify(context.Background(), parts[0]+"."+otherParts[1]+"."+parts[2])
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestVerifier_KeyRotation(t *testing.T) {
+	oldKeys, newKeys := newTestKeys(t), newTestKeys(t)
+	var rotated atomic.Bool
+	srv, fetches := serveJWKS(t, func() any {
+		if !rotated.Load() {
+			return oldKeys.jwks()
+		}
+		// The rotated key has a new ID
+		jwks := newKeys.jwks()
+		jwks["keys"].([]map[string]string)[2]["kid"] = "ed2"
+		return jwks
+	})
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	verifier, err := NewVerifier(Config{Issuer: testIssuer, Audience: testAudience, JWKSURL: srv.URL}, WithClock(clock))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = verifier.Verify(ctx, oldKeys.sign(t, "EdDSA", "ed", validClaims(clock.Now())))
+	require.NoError(t, err)
+	_, err = verifier.Verify(ctx, oldKeys.sign(t, "EdDSA", "ed", validClaims(clock.Now())))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), fetches.Load(), "the key set is cached")
+
+	rotated.Store(true)
+	token := newKeys.sign(t, "EdDSA", "ed2", validClaims(clock.Now()))
+	_, err = verifier.Verify(ctx, token)
+	assert.ErrorIs(t, err, ErrInvalidToken, "unknown keys are not refetched right after a fetch")
+	assert.Equal(t, int32(1), fetches.Load())
+
+	clock.Advance(minRefetchInterval)
+	_, err = verifier.Verify(ctx, newKeys.sign(t, "EdDSA", "ed2", validClaims(clock.Now())))
+	require.NoError(t, err, "a token signed with an unknown key refetches the key set")
+	assert.Equal(t, int32(2), fetches.Load())
+
+	clock.Advance(DefaultRefreshInterval)
+	_, err = verifier.Verify(ctx, newKeys.sign(t, "EdDSA", "ed2", validClaims(clock.Now())))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), fetches.Load(), "the key set is refreshed periodically")
+}
+
+func TestVerifier_KeysUnavailable(t *testing.T) {
+	keys := newTestKeys(t)
+	var available atomic.Bool
+	srv, fetches := serveJWKS(t, func() any {
+		if !available.Load() {
+			return map[string]any{"keys": "not a list"}
+		}
+		return keys.jwks()
+	})
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	verifier, err := NewVerifier(Config{Issuer: testIssuer, Audience: testAudience, JWKSURL: srv.URL}, WithClock(clock))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = verifier.Verify(ctx, keys.sign(t, "EdDSA", "ed", validClaims(clock.Now())))
+	assert.ErrorIs(t, err, ErrKeysUnavailable)
+	_, err = verifier.Verify(ctx, keys.sign(t, "EdDSA", "ed", validClaims(clock.Now())))
+	assert.ErrorIs(t, err, ErrKeysUnavailable)
+	assert.Equal(t, int32(1), fetches.Load(), "failed fetches are not retried on every request")
+
+	available.Store(true)
+	clock.Advance(retryInterval)
+	_, err = verifier.Verify(ctx, keys.sign(t, "EdDSA", "ed", validClaims(clock.Now())))
+	require.NoError(t, err)
+
+	available.Store(false)
+	clock.Advance(DefaultRefreshInterval)
+	_, err = verifier.Verify(ctx, keys.sign(t, "EdDSA", "ed", validClaims(clock.Now())))
+	require.NoError(t, err, "the keys fetched before stay in use when a refresh fails")
+}
+
+func TestNewVerifier_InvalidConfig(t *testing.T) {
+	valid := Config{Issuer: testIssuer, Audience: testAudience, JWKSURL: "https://auth.example.com/.well-known/jwks.json"}
+	_, err := NewVerifier(valid)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"no issuer", func(c *Config) { c.Issuer = "" }},
+		{"no audience", func(c *Config) { c.Audience = "" }},
+		{"relative JWKS URL", func(c *Config) { c.JWKSURL = "/jwks.json" }},
+		{"non-HTTP JWKS URL", func(c *Config) { c.JWKSURL = "file:///etc/jwks.json" }},
+		{"negative refresh", func(c *Config) { c.RefreshInterval = -time.Second }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			_, err := NewVerifier(cfg)
+			assert.Error(t, err)
+		})
+	}
+}
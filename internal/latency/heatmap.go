@@ -0,0 +1,159 @@
+package latency
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// HeatmapResolution is the period latencies are aggregated over for heatmaps;
+// heatmap columns span a multiple of it.
+const HeatmapResolution = time.Minute
+
+// DefaultHeatmapRetention is how long heatmap latencies are kept by default.
+const DefaultHeatmapRetention = 24 * time.Hour
+
+// maxHeatmapColumns is the number of columns of heatmaps without an explicit step.
+const maxHeatmapColumns = 60
+
+// HeatmapBuckets are the upper bounds of the latency buckets of heatmaps. A
+// last, unbounded bucket counts the slower searches.
+var HeatmapBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	300 * time.Millisecond,
+	500 * time.Millisecond,
+	750 * time.Millisecond,
+	time.Second,
+	1500 * time.Millisecond,
+	2 * time.Second,
+	3 * time.Second,
+	5 * time.Second,
+}
+
+// ErrInvalidHeatmapRange is returned for heatmap windows that are not positive
+// or exceed the retention, and for negative steps.
+var ErrInvalidHeatmapRange = errors.New("latency: heatmap window must be positive and within the retention")
+
+// Heatmap is the latency distribution of a provider's searches over time.
+type Heatmap struct {
+	Provider string
+
+	// Start is the start of the first column
+	Start time.Time
+
+	// Step is the period each column spans
+	Step time.Duration
+
+	// Counts holds the number of searches of each column (oldest first) in
+	// each of the HeatmapBuckets, plus the unbounded bucket
+	Counts [][]int
+
+	// Searches is the number of searches in the heatmap
+	Searches int
+}
+
+// histogram counts the latencies of one HeatmapResolution period.
+type histogram struct {
+	// period is the index of the period since the Unix epoch
+	period int64
+	counts []int
+}
+
+// WithHeatmapRetention sets how long latencies are kept for heatmaps.
+// Periods shorter than HeatmapResolution keep one period.
+func WithHeatmapRetention(retention time.Duration) Option {
+	return func(t *Tracker) {
+		t.heatmapRetention = retention
+	}
+}
+
+// HeatmapRetention returns how long latencies are kept for heatmaps.
+func (t *Tracker) HeatmapRetention() time.Duration {
+	return time.Duration(t.periods()) * HeatmapResolution
+}
+
+// Heatmaps returns the latency heatmap of every provider searched since the
+// tracker was created, ordered by provider, over the window ending with the
+// current HeatmapResolution period. Each column spans step, rounded up to a
+// multiple of HeatmapResolution; a zero step splits the window in up to 60
+// columns.
+func (t *Tracker) Heatmaps(window, step time.Duration) ([]Heatmap, error) {
+	if window <= 0 || window > t.HeatmapRetention() || step < 0 {
+		return nil, ErrInvalidHeatmapRange
+	}
+	windowPeriods := periodsIn(window)
+	stepPeriods := periodsIn(step)
+	if step == 0 {
+		stepPeriods = (windowPeriods + maxHeatmapColumns - 1) / maxHeatmapColumns
+	}
+	columns := (windowPeriods + stepPeriods - 1) / stepPeriods
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	end := periodOf(t.clock.Now()) + 1
+	first := end - columns*stepPeriods
+	retained := end - t.periods()
+
+	heatmaps := make([]Heatmap, 0, len(t.histograms))
+	for provider, ring := range t.histograms {
+		h := Heatmap{
+			Provider: provider,
+			Start:    time.Unix(0, first*int64(HeatmapResolution)).UTC(),
+			Step:     time.Duration(stepPeriods) * HeatmapResolution,
+			Counts:   make([][]int, columns),
+		}
+		for i := range h.Counts {
+			h.Counts[i] = make([]int, len(HeatmapBuckets)+1)
+		}
+		for _, hist := range ring {
+			if hist.counts == nil || hist.period < first || hist.period < retained || hist.period >= end {
+				continue
+			}
+			column := h.Counts[(hist.period-first)/stepPeriods]
+			for i, n := range hist.counts {
+				column[i] += n
+				h.Searches += n
+			}
+		}
+		heatmaps = append(heatmaps, h)
+	}
+	sort.Slice(heatmaps, func(i, j int) bool { return heatmaps[i].Provider < heatmaps[j].Provider })
+	return heatmaps, nil
+}
+
+// observeHeatmap counts a latency in the provider's histogram of the current
+// period. The caller must hold t.mu.
+func (t *Tracker) observeHeatmap(provider string, at time.Time, latency time.Duration) {
+	ring, ok := t.histograms[provider]
+	if !ok {
+		ring = make([]histogram, t.periods())
+		t.histograms[provider] = ring
+	}
+
+	period := periodOf(at)
+	hist := &ring[period%int64(len(ring))]
+	if hist.counts == nil || hist.period != period {
+		// Reuse the slot of a period that left the retention
+		hist.period = period
+		hist.counts = make([]int, len(HeatmapBuckets)+1)
+	}
+	hist.counts[sort.Search(len(HeatmapBuckets), func(i int) bool { return latency <= HeatmapBuckets[i] })]++
+}
+
+// periods returns the number of HeatmapResolution periods kept.
+func (t *Tracker) periods() int64 {
+	return max(periodsIn(t.heatmapRetention), 1)
+}
+
+// periodOf returns the index of the HeatmapResolution period containing at.
+func periodOf(at time.Time) int64 {
+	return at.UnixNano() / int64(HeatmapResolution)
+}
+
+// periodsIn returns the number of HeatmapResolution periods d spans, rounded up.
+func periodsIn(d time.Duration) int64 {
+	return int64((d + HeatmapResolution - 1) / HeatmapResolution)
+}
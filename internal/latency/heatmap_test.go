@@ -0,0 +1,94 @@
+package latency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// buckets returns heatmap bucket counts with the given counts at the given bucket indexes.
+func buckets(counts map[int]int) []int {
+	b := make([]int, len(HeatmapBuckets)+1)
+	for i, n := range counts {
+		b[i] = n
+	}
+	return b
+}
+
+func TestTracker_Heatmaps(t *testing.T) {
+	start := time.Date(2025, 12, 1, 10, 0, 30, 0, time.UTC)
+	clock := timeutil.NewMockClock(start)
+	tracker := NewTracker(WithClock(clock))
+
+	tracker.Observe("lion_air", 40*time.Millisecond)
+	tracker.Observe("lion_air", 50*time.Millisecond)
+	tracker.Observe("garuda", 250*time.Millisecond)
+	clock.Advance(2 * time.Minute)
+	tracker.Observe("lion_air", 10*time.Second)
+
+	heatmaps, err := tracker.Heatmaps(5*time.Minute, 0)
+	require.NoError(t, err)
+	require.Len(t, heatmaps, 2)
+
+	garuda := heatmaps[0]
+	assert.Equal(t, "garuda", garuda.Provider)
+	assert.Equal(t, time.Date(2025, 12, 1, 9, 58, 0, 0, time.UTC), garuda.Start)
+	assert.Equal(t, time.Minute, garuda.Step)
+	require.Len(t, garuda.Counts, 5)
+	assert.Equal(t, buckets(map[int]int{3: 1}), garuda.Counts[2])
+	assert.Equal(t, 1, garuda.Searches)
+
+	lion := heatmaps[1]
+	assert.Equal(t, "lion_air", lion.Provider)
+	assert.Equal(t, buckets(map[int]int{0: 2}), lion.Counts[2], "bucket bounds are inclusive")
+	assert.Equal(t, buckets(nil), lion.Counts[3])
+	assert.Equal(t, buckets(map[int]int{len(HeatmapBuckets): 1}), lion.Counts[4], "slower than the last bound")
+	assert.Equal(t, 3, lion.Searches)
+}
+
+func TestTracker_HeatmapsStep(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	tracker := NewTracker(WithClock(clock))
+	for i := 0; i < 120; i++ {
+		tracker.Observe("garuda", 100*time.Millisecond)
+		clock.Advance(time.Minute)
+	}
+
+	// Without a step, windows are split in up to 60 columns
+	heatmaps, err := tracker.Heatmaps(2*time.Hour, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, heatmaps[0].Step)
+	assert.Len(t, heatmaps[0].Counts, 60)
+
+	// Steps are rounded up to whole minutes
+	heatmaps, err = tracker.Heatmaps(time.Hour, 90*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, heatmaps[0].Step)
+	assert.Len(t, heatmaps[0].Counts, 30)
+	assert.Equal(t, 59, heatmaps[0].Searches, "the window includes the current minute, without searches yet")
+}
+
+func TestTracker_HeatmapsRetention(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	tracker := NewTracker(WithClock(clock), WithHeatmapRetention(10*time.Minute))
+	assert.Equal(t, 10*time.Minute, tracker.HeatmapRetention())
+
+	tracker.Observe("garuda", time.Second)
+	clock.Advance(10 * time.Minute)
+	tracker.Observe("garuda", time.Second)
+
+	heatmaps, err := tracker.Heatmaps(10*time.Minute, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, heatmaps[0].Searches, "the first search left the retention")
+
+	_, err = tracker.Heatmaps(11*time.Minute, 0)
+	assert.ErrorIs(t, err, ErrInvalidHeatmapRange)
+	_, err = tracker.Heatmaps(0, 0)
+	assert.ErrorIs(t, err, ErrInvalidHeatmapRange)
+	_, err = tracker.Heatmaps(time.Minute, -time.Minute)
+	assert.ErrorIs(t, err, ErrInvalidHeatmapRange)
+}
@@ -0,0 +1,162 @@
+// Package latency keeps a short history of provider search latencies.
+//
+// A Tracker wraps providers and records how long each of their searches took,
+// timeouts included. The p95 latency over a sliding window tells the search
+// use case which providers cannot answer within the budget a search has left.
+// Latencies are also counted in per-minute histograms, kept longer, from which
+// operators get heatmaps of each provider's latency distribution over time.
+package latency
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// Default settings.
+const (
+	DefaultWindow     = 15 * time.Minute
+	DefaultMinSamples = 20
+)
+
+// maxSamples caps the latencies kept per provider.
+const maxSamples = 512
+
+// sample is the latency of one provider search.
+type sample struct {
+	at      time.Time
+	latency time.Duration
+}
+
+// Tracker records provider latencies. It is safe for concurrent use.
+type Tracker struct {
+	clock      timeutil.Clock
+	window     time.Duration
+	minSamples int
+
+	heatmapRetention time.Duration
+
+	mu         sync.Mutex
+	samples    map[string][]sample
+	histograms map[string][]histogram
+}
+
+// Option configures a Tracker.
+type Option func(*Tracker)
+
+// WithClock sets the clock used to time searches.
+func WithClock(clock timeutil.Clock) Option {
+	return func(t *Tracker) {
+		t.clock = clock
+	}
+}
+
+// WithWindow sets the period the p95 latency is computed over.
+func WithWindow(window time.Duration) Option {
+	return func(t *Tracker) {
+		t.window = window
+	}
+}
+
+// WithMinSamples sets the number of searches in the window below which a
+// provider's p95 latency is unknown.
+func WithMinSamples(n int) Option {
+	return func(t *Tracker) {
+		t.minSamples = n
+	}
+}
+
+// NewTracker creates an empty tracker.
+func NewTracker(opts ...Option) *Tracker {
+	t := &Tracker{
+		clock:            timeutil.NewRealClock(),
+		window:           DefaultWindow,
+		minSamples:       DefaultMinSamples,
+		heatmapRetention: DefaultHeatmapRetention,
+		samples:          make(map[string][]sample),
+		histograms:       make(map[string][]histogram),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Observe records the latency of a provider search.
+func (t *Tracker) Observe(provider string, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock.Now()
+	samples := append(t.samples[provider], sample{at: now, latency: latency})
+	if len(samples) > maxSamples {
+		samples = samples[len(samples)-maxSamples:]
+	}
+	t.samples[provider] = samples
+	t.observeHeatmap(provider, now, latency)
+}
+
+// P95 returns the provider's p95 latency over the window. ok is false when the
+// provider made fewer searches than the minimum sample count in the window.
+func (t *Tracker) P95(provider string) (p95 time.Duration, ok bool) {
+	t.mu.Lock()
+	cutoff := t.clock.Now().Add(-t.window)
+	samples := t.samples[provider]
+	i := sort.Search(len(samples), func(i int) bool { return samples[i].at.After(cutoff) })
+	samples = samples[i:]
+	t.samples[provider] = samples
+	if len(samples) == 0 || len(samples) < t.minSamples {
+		t.mu.Unlock()
+		return 0, false
+	}
+	latencies := make([]time.Duration, len(samples))
+	for i, s := range samples {
+		latencies[i] = s.latency
+	}
+	t.mu.Unlock()
+
+	slices.Sort(latencies)
+	return latencies[(len(latencies)*95+99)/100-1], true
+}
+
+// trackedProvider records the latency of every search made through the wrapped provider.
+type trackedProvider struct {
+	domain.FlightProvider
+	tracker *Tracker
+}
+
+// Instrument wraps a provider so the latency of its searches is recorded.
+// Searches cancelled by the caller are not recorded.
+func (t *Tracker) Instrument(p domain.FlightProvider) domain.FlightProvider {
+	return trackedProvider{FlightProvider: p, tracker: t}
+}
+
+// InstrumentAll wraps each provider with Instrument.
+func (t *Tracker) InstrumentAll(providers []domain.FlightProvider) []domain.FlightProvider {
+	wrapped := make([]domain.FlightProvider, len(providers))
+	for i, p := range providers {
+		wrapped[i] = t.Instrument(p)
+	}
+	return wrapped
+}
+
+// Search implements domain.FlightProvider.
+func (p trackedProvider) Search(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	return p.SearchWithOptions(ctx, criteria, domain.ProviderSearchOptions{})
+}
+
+// SearchWithOptions implements domain.FilteringProvider.
+func (p trackedProvider) SearchWithOptions(ctx context.Context, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) ([]domain.Flight, error) {
+	start := p.tracker.clock.Now()
+	flights, err := domain.SearchWithOptions(ctx, p.FlightProvider, criteria, opts)
+	if !errors.Is(err, context.Canceled) {
+		p.tracker.Observe(p.Name(), p.tracker.clock.Now().Sub(start))
+	}
+	return flights, err
+}
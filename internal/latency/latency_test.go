@@ -0,0 +1,68 @@
+package latency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// slowProvider advances the clock by its latency and returns err.
+type slowProvider struct {
+	clock   *timeutil.MockClock
+	latency time.Duration
+	err     error
+}
+
+func (p slowProvider) Name() string { return "slow_air" }
+
+func (p slowProvider) Search(context.Context, domain.SearchCriteria) ([]domain.Flight, error) {
+	p.clock.Advance(p.latency)
+	return nil, p.err
+}
+
+func TestTracker_P95(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	tracker := NewTracker(WithClock(clock), WithWindow(time.Minute), WithMinSamples(10))
+
+	for i := 1; i <= 9; i++ {
+		tracker.Observe("slow_air", time.Duration(i)*100*time.Millisecond)
+	}
+	_, ok := tracker.P95("slow_air")
+	assert.False(t, ok, "too few samples")
+
+	tracker.Observe("slow_air", 2*time.Second)
+	p95, ok := tracker.P95("slow_air")
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, p95)
+
+	_, ok = tracker.P95("unknown_air")
+	assert.False(t, ok)
+
+	clock.Advance(time.Minute)
+	_, ok = tracker.P95("slow_air")
+	assert.False(t, ok, "samples left the window")
+}
+
+func TestTracker_Instrument(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	tracker := NewTracker(WithClock(clock), WithMinSamples(2))
+
+	providers := tracker.InstrumentAll([]domain.FlightProvider{
+		slowProvider{clock: clock, latency: time.Second},
+		slowProvider{clock: clock, latency: 3 * time.Second, err: context.DeadlineExceeded},
+		slowProvider{clock: clock, latency: 5 * time.Second, err: context.Canceled},
+	})
+	for _, p := range providers {
+		_, _ = p.Search(context.Background(), domain.SearchCriteria{})
+	}
+
+	// Timeouts count, searches cancelled by the caller don't
+	p95, ok := tracker.P95("slow_air")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, p95)
+}
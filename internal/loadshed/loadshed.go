@@ -0,0 +1,304 @@
+// Package loadshed disables optional search features under high load.
+//
+// A Shedder tracks the number of searches in flight and their p95 latency.
+// When either crosses its threshold, optional features are shed one after
+// another in priority order, protecting the latency of the core search.
+// Features are restored in reverse order, one per cooldown period, once the
+// load is back under the thresholds.
+//
+// Only features whose loss leaves the search results intact are sheddable:
+// shadow searches and cache drift checks, whose results are never returned,
+// and localization, which falls back to the provider's own names. Search
+// recording is not, since a search missing from history cannot be replayed or
+// shared and the response would not tell. The service does not compute emissions, on-time data or
+// facets, so there are no features for them.
+package loadshed
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// Optional features that can be shed.
+const (
+	// FeatureShadow queries shadow providers alongside live searches, and the
+	// live providers behind the cache hits sampled for drift checks
+	FeatureShadow = "shadow"
+
+	// FeatureLocalization translates airline and airport names in responses
+	FeatureLocalization = "localization"
+)
+
+// DefaultFeatures is the default shedding order, least valuable first.
+var DefaultFeatures = []string{FeatureShadow, FeatureLocalization}
+
+// Default settings.
+const (
+	DefaultWindow   = 30 * time.Second
+	DefaultCooldown = 30 * time.Second
+)
+
+// pressureStep is the pressure increase, above 1, that sheds one more feature:
+// pressure 1 sheds the first feature, 1.25 the first two, and so on.
+const pressureStep = 0.25
+
+// minSamples is the minimum number of latencies in the window for the p95
+// latency to be taken into account.
+const minSamples = 20
+
+// maxSamples caps the latencies kept in the window.
+const maxSamples = 1024
+
+// p95Interval is how often the p95 latency is recomputed.
+const p95Interval = time.Second
+
+// Thresholds are the load levels at which shedding starts. Zero disables a threshold.
+type Thresholds struct {
+	// MaxInFlight is the number of searches in flight
+	MaxInFlight int
+
+	// P95Latency is the p95 search latency over the window
+	P95Latency time.Duration
+}
+
+// Signals are the load measurements a shedding decision is based on.
+type Signals struct {
+	InFlight   int
+	P95Latency time.Duration
+}
+
+// Observer is notified when a feature is shed or restored. It is called
+// without locks held, from the goroutine of the search that caused the change.
+type Observer interface {
+	FeatureShed(feature string, shed bool, signals Signals)
+}
+
+// sample is the latency of one completed search.
+type sample struct {
+	at      time.Time
+	latency time.Duration
+}
+
+// Shedder decides which optional features are shed. It is safe for concurrent use.
+type Shedder struct {
+	features   []string
+	thresholds Thresholds
+	window     time.Duration
+	cooldown   time.Duration
+	clock      timeutil.Clock
+	observer   Observer
+
+	mu        sync.Mutex
+	inFlight  int
+	samples   []sample
+	p95       time.Duration
+	p95At     time.Time
+	level     int
+	changedAt time.Time
+}
+
+// Option configures a Shedder.
+type Option func(*Shedder)
+
+// WithWindow sets the period the p95 latency is computed over.
+func WithWindow(window time.Duration) Option {
+	return func(s *Shedder) {
+		s.window = window
+	}
+}
+
+// WithCooldown sets how long the load must stay under the thresholds before
+// the next shed feature is restored.
+func WithCooldown(cooldown time.Duration) Option {
+	return func(s *Shedder) {
+		s.cooldown = cooldown
+	}
+}
+
+// WithClock sets the clock used to time searches.
+func WithClock(clock timeutil.Clock) Option {
+	return func(s *Shedder) {
+		s.clock = clock
+	}
+}
+
+// WithObserver sets the observer notified of shedding decisions.
+func WithObserver(observer Observer) Option {
+	return func(s *Shedder) {
+		s.observer = observer
+	}
+}
+
+// New creates a Shedder shedding features in the given order under the thresholds.
+func New(features []string, thresholds Thresholds, opts ...Option) *Shedder {
+	s := &Shedder{
+		features:   features,
+		thresholds: thresholds,
+		window:     DefaultWindow,
+		cooldown:   DefaultCooldown,
+		clock:      timeutil.NewRealClock(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Begin records the start of a search and returns the function to call when
+// it completes.
+func (s *Shedder) Begin() (done func()) {
+	start := s.clock.Now()
+
+	s.mu.Lock()
+	s.inFlight++
+	changes := s.evaluateLocked(start)
+	s.mu.Unlock()
+	s.notify(changes)
+
+	return func() {
+		now := s.clock.Now()
+
+		s.mu.Lock()
+		s.inFlight--
+		s.samples = append(s.samples, sample{at: now, latency: now.Sub(start)})
+		if len(s.samples) > maxSamples {
+			s.samples = s.samples[len(s.samples)-maxSamples:]
+		}
+		changes := s.evaluateLocked(now)
+		s.mu.Unlock()
+		s.notify(changes)
+	}
+}
+
+// Shed returns the features currently shed, in shedding order.
+func (s *Shedder) Shed() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.features[:s.level])
+}
+
+// Signals returns the current load measurements.
+func (s *Shedder) Signals() Signals {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Signals{InFlight: s.inFlight, P95Latency: s.p95}
+}
+
+// change is a feature shed or restored by an evaluation.
+type change struct {
+	feature string
+	shed    bool
+	signals Signals
+}
+
+// evaluateLocked updates the shedding level from the current load and returns
+// the resulting changes. Shedding takes effect immediately; restoring happens
+// one feature per cooldown period.
+func (s *Shedder) evaluateLocked(now time.Time) []change {
+	if now.Sub(s.p95At) >= p95Interval {
+		s.p95 = s.p95Locked(now)
+		s.p95At = now
+	}
+	signals := Signals{InFlight: s.inFlight, P95Latency: s.p95}
+	target := s.levelFor(signals)
+
+	var changes []change
+	switch {
+	case target > s.level:
+		for _, f := range s.features[s.level:target] {
+			changes = append(changes, change{feature: f, shed: true, signals: signals})
+		}
+		s.level = target
+		s.changedAt = now
+	case target < s.level && now.Sub(s.changedAt) >= s.cooldown:
+		s.level--
+		s.changedAt = now
+		changes = append(changes, change{feature: s.features[s.level], shed: false, signals: signals})
+	}
+	return changes
+}
+
+// levelFor returns the number of features to shed under the given load.
+func (s *Shedder) levelFor(signals Signals) int {
+	var pressure float64
+	if s.thresholds.MaxInFlight > 0 {
+		pressure = float64(signals.InFlight) / float64(s.thresholds.MaxInFlight)
+	}
+	if s.thresholds.P95Latency > 0 {
+		pressure = max(pressure, float64(signals.P95Latency)/float64(s.thresholds.P95Latency))
+	}
+	if pressure < 1 {
+		return 0
+	}
+	return min(len(s.features), 1+int((pressure-1)/pressureStep))
+}
+
+// p95Locked drops samples older than the window and returns the p95 latency
+// of the rest, or 0 if there are too few.
+func (s *Shedder) p95Locked(now time.Time) time.Duration {
+	cutoff := now.Add(-s.window)
+	i := sort.Search(len(s.samples), func(i int) bool { return !s.samples[i].at.Before(cutoff) })
+	s.samples = s.samples[i:]
+	if len(s.samples) < minSamples {
+		return 0
+	}
+
+	latencies := make([]time.Duration, len(s.samples))
+	for i, smp := range s.samples {
+		latencies[i] = smp.latency
+	}
+	slices.Sort(latencies)
+	return latencies[(len(latencies)*95+99)/100-1]
+}
+
+func (s *Shedder) notify(changes []change) {
+	if s.observer == nil {
+		return
+	}
+	for _, c := range changes {
+		s.observer.FeatureShed(c.feature, c.shed, c.signals)
+	}
+}
+
+// ParseFeatures parses a comma-separated shedding order, e.g.
+// "shadow,localization". "none" disables shedding.
+func ParseFeatures(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "none" {
+		return nil, nil
+	}
+
+	var features []string
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if !slices.Contains(DefaultFeatures, f) {
+			return nil, fmt.Errorf("unknown feature %q (want %s)", f, strings.Join(DefaultFeatures, ", "))
+		}
+		if slices.Contains(features, f) {
+			return nil, fmt.Errorf("duplicate feature %q", f)
+		}
+		features = append(features, f)
+	}
+	return features, nil
+}
+
+// shedKey is the context key for the features shed for a request.
+type shedKey struct{}
+
+// WithShed returns a copy of ctx carrying the features shed for the request.
+func WithShed(ctx context.Context, features []string) context.Context {
+	return context.WithValue(ctx, shedKey{}, features)
+}
+
+// Allowed reports whether an optional feature may run for the request carried
+// by ctx. Features are allowed unless shed.
+func Allowed(ctx context.Context, feature string) bool {
+	shed, _ := ctx.Value(shedKey{}).([]string)
+	return !slices.Contains(shed, feature)
+}
@@ -0,0 +1,135 @@
+package loadshed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+type recordedChange struct {
+	feature string
+	shed    bool
+}
+
+type recorder struct {
+	changes []recordedChange
+}
+
+func (r *recorder) FeatureShed(feature string, shed bool, _ Signals) {
+	r.changes = append(r.changes, recordedChange{feature, shed})
+}
+
+func newShedder(thresholds Thresholds) (*Shedder, *timeutil.MockClock, *recorder) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	obs := &recorder{}
+	s := New(DefaultFeatures, thresholds, WithClock(clock), WithObserver(obs), WithCooldown(10*time.Second))
+	return s, clock, obs
+}
+
+func TestShedder_InFlight(t *testing.T) {
+	s, clock, obs := newShedder(Thresholds{MaxInFlight: 4})
+
+	var done []func()
+	for range 3 {
+		done = append(done, s.Begin())
+	}
+	assert.Empty(t, s.Shed(), "under the threshold")
+
+	done = append(done, s.Begin())
+	assert.Equal(t, []string{FeatureShadow}, s.Shed(), "pressure 1 sheds the first feature")
+
+	done = append(done, s.Begin())
+	assert.Equal(t, []string{FeatureShadow, FeatureLocalization}, s.Shed(), "pressure 1.25 sheds two")
+
+	// Load drops: features come back one per cooldown period, last shed first
+	for _, d := range done {
+		d()
+	}
+	assert.Len(t, s.Shed(), 2, "cooldown not elapsed")
+
+	clock.Advance(10 * time.Second)
+	s.Begin()()
+	assert.Equal(t, []string{FeatureShadow}, s.Shed())
+
+	clock.Advance(10 * time.Second)
+	s.Begin()()
+	assert.Empty(t, s.Shed())
+
+	assert.Equal(t, []recordedChange{
+		{FeatureShadow, true},
+		{FeatureLocalization, true},
+		{FeatureLocalization, false},
+		{FeatureShadow, false},
+	}, obs.changes)
+}
+
+func TestShedder_P95Latency(t *testing.T) {
+	s, clock, _ := newShedder(Thresholds{P95Latency: time.Second})
+
+	search := func(latency time.Duration) {
+		done := s.Begin()
+		clock.Advance(latency)
+		done()
+	}
+
+	for range minSamples {
+		search(100 * time.Millisecond)
+	}
+	clock.Advance(p95Interval)
+	s.Begin()()
+	assert.Empty(t, s.Shed())
+	assert.Equal(t, 100*time.Millisecond, s.Signals().P95Latency)
+
+	// Slow searches push the p95 over the threshold
+	for range minSamples {
+		search(1200 * time.Millisecond)
+	}
+	clock.Advance(p95Interval)
+	s.Begin()()
+	assert.Equal(t, []string{FeatureShadow}, s.Shed())
+
+	// Slow samples leave the window
+	clock.Advance(DefaultWindow)
+	s.Begin()()
+	assert.Equal(t, time.Duration(0), s.Signals().P95Latency, "too few samples")
+	assert.Empty(t, s.Shed())
+}
+
+func TestShedder_NoThresholds(t *testing.T) {
+	s, _, obs := newShedder(Thresholds{})
+	for range 100 {
+		s.Begin()
+	}
+	assert.Empty(t, s.Shed())
+	assert.Empty(t, obs.changes)
+}
+
+func TestParseFeatures(t *testing.T) {
+	features, err := ParseFeatures(" localization , shadow")
+	require.NoError(t, err)
+	assert.Equal(t, []string{FeatureLocalization, FeatureShadow}, features)
+
+	features, err = ParseFeatures("none")
+	require.NoError(t, err)
+	assert.Empty(t, features)
+
+	_, err = ParseFeatures("shadow,recording")
+	assert.ErrorContains(t, err, `unknown feature "recording"`)
+
+	_, err = ParseFeatures("shadow,shadow")
+	assert.ErrorContains(t, err, "duplicate")
+}
+
+func TestAllowed(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, Allowed(ctx, FeatureLocalization))
+
+	ctx = WithShed(ctx, []string{FeatureShadow})
+	assert.False(t, Allowed(ctx, FeatureShadow))
+	assert.True(t, Allowed(ctx, FeatureLocalization))
+}
@@ -0,0 +1,203 @@
+// Package migrate applies versioned schema migrations to the SQL database
+// backing persistent features.
+//
+// Migrations follow the golang-migrate layout: one file per migration named
+// <version>_<name>.up.sql, applied in version order. The database records the
+// last applied version and whether its migration failed part-way ("dirty");
+// a dirty schema blocks further migrations until it is repaired by hand and
+// the version forced. Migrations only go forward: down files are ignored, and
+// a bad migration is undone by restoring a backup.
+//
+// The service's own migrations are embedded in the binary (see Embedded), so
+// a deployment needs nothing but the binary to bring its schema up to date.
+package migrate
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrDirty is returned when the last migration failed part-way.
+var ErrDirty = errors.New("schema is dirty")
+
+// Migration is a schema change.
+type Migration struct {
+	// Version orders migrations; versions start at 1
+	Version uint
+
+	// Name describes the change
+	Name string
+
+	// Up is the SQL applying the change
+	Up string
+}
+
+// Status is the schema version of a database.
+type Status struct {
+	// Version is the last applied migration (0 = none)
+	Version uint `json:"version"`
+
+	// Latest is the newest migration known to the runner
+	Latest uint `json:"latest"`
+
+	// Dirty reports whether the last migration failed part-way
+	Dirty bool `json:"dirty"`
+}
+
+// Current reports whether the schema is usable: clean and at least at the
+// latest version. A newer schema, migrated by a newer release during a
+// rolling deployment, is considered current.
+func (s Status) Current() bool {
+	return !s.Dirty && s.Version >= s.Latest
+}
+
+// Driver runs migrations against a database and records its version.
+type Driver interface {
+	// Version returns the last applied version (0 = none) and whether its
+	// migration failed part-way.
+	Version(ctx context.Context) (version uint, dirty bool, err error)
+
+	// SetVersion records the applied version.
+	SetVersion(ctx context.Context, version uint, dirty bool) error
+
+	// Run executes the statements of a migration.
+	Run(ctx context.Context, statements string) error
+}
+
+// fileName matches migration files; the version may be zero-padded.
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load reads the migrations in the root directory of fsys, sorted by version.
+// Down migrations are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		m := fileName.FindStringSubmatch(entry.Name())
+		if m == nil {
+			return nil, fmt.Errorf("migration %s: name must be <version>_<name>.up.sql", entry.Name())
+		}
+		if m[3] == "down" {
+			continue
+		}
+		version, err := strconv.ParseUint(m[1], 10, 0)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("migration %s: version must be a positive integer", entry.Name())
+		}
+		up, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, Migration{Version: uint(version), Name: m[2], Up: string(up)})
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int { return int(a.Version) - int(b.Version) })
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("migrations %s and %s have the same version",
+				migrations[i-1].Name, migrations[i].Name)
+		}
+	}
+	return migrations, nil
+}
+
+//go:embed migrations/*.sql
+var embedded embed.FS
+
+// Embedded returns the service's migrations.
+func Embedded() []Migration {
+	dir, err := fs.Sub(embedded, "migrations")
+	if err != nil {
+		panic(err)
+	}
+	migrations, err := Load(dir)
+	if err != nil {
+		// The embedded files are checked by the package tests
+		panic(err)
+	}
+	return migrations
+}
+
+// Runner applies migrations to a database.
+type Runner struct {
+	driver     Driver
+	migrations []Migration
+}
+
+// New creates a runner applying migrations, sorted by version as returned by
+// Load, through driver.
+func New(driver Driver, migrations []Migration) *Runner {
+	return &Runner{driver: driver, migrations: migrations}
+}
+
+// Latest returns the newest migration version (0 = no migrations).
+func (r *Runner) Latest() uint {
+	if len(r.migrations) == 0 {
+		return 0
+	}
+	return r.migrations[len(r.migrations)-1].Version
+}
+
+// Status returns the schema version of the database.
+func (r *Runner) Status(ctx context.Context) (Status, error) {
+	version, dirty, err := r.driver.Version(ctx)
+	if err != nil {
+		return Status{}, fmt.Errorf("read schema version: %w", err)
+	}
+	return Status{Version: version, Latest: r.Latest(), Dirty: dirty}, nil
+}
+
+// Up applies the migrations newer than the database's version in order and
+// returns those applied. A migration that fails leaves the schema dirty at
+// its version; Up then returns the migrations applied before it and the
+// error.
+func (r *Runner) Up(ctx context.Context) ([]Migration, error) {
+	version, dirty, err := r.driver.Version(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read schema version: %w", err)
+	}
+	if dirty {
+		return nil, fmt.Errorf("%w at version %d: repair it and force the version", ErrDirty, version)
+	}
+
+	var applied []Migration
+	for _, m := range r.migrations {
+		if m.Version <= version {
+			continue
+		}
+		if err := r.driver.SetVersion(ctx, m.Version, true); err != nil {
+			return applied, fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+		}
+		if err := r.driver.Run(ctx, m.Up); err != nil {
+			return applied, fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+		}
+		if err := r.driver.SetVersion(ctx, m.Version, false); err != nil {
+			return applied, fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+		}
+		applied = append(applied, m)
+	}
+	return applied, nil
+}
+
+// Force records version as applied and clean without running anything, once
+// a dirty schema has been repaired by hand.
+func (r *Runner) Force(ctx context.Context, version uint) error {
+	if version != 0 && !slices.ContainsFunc(r.migrations, func(m Migration) bool { return m.Version == version }) {
+		return fmt.Errorf("no migration has version %d", version)
+	}
+	return r.driver.SetVersion(ctx, version, false)
+}
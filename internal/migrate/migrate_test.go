@@ -0,0 +1,134 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver records the statements run and the versions set.
+type fakeDriver struct {
+	version uint
+	dirty   bool
+	ran     []string
+	failOn  string
+}
+
+func (d *fakeDriver) Version(context.Context) (uint, bool, error) {
+	return d.version, d.dirty, nil
+}
+
+func (d *fakeDriver) SetVersion(_ context.Context, version uint, dirty bool) error {
+	d.version, d.dirty = version, dirty
+	return nil
+}
+
+func (d *fakeDriver) Run(_ context.Context, statements string) error {
+	if statements == d.failOn {
+		return errors.New("syntax error")
+	}
+	d.ran = append(d.ran, statements)
+	return nil
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(fstest.MapFS{
+		"0002_add_index.up.sql":      {Data: []byte("CREATE INDEX i ON t (c);")},
+		"0001_create_table.up.sql":   {Data: []byte("CREATE TABLE t (c INT);")},
+		"0001_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		"README.md":                  {Data: []byte("not a migration")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 1, Name: "create_table", Up: "CREATE TABLE t (c INT);"},
+		{Version: 2, Name: "add_index", Up: "CREATE INDEX i ON t (c);"},
+	}, migrations)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"bad name":          {"create_table.sql": {}},
+		"zero version":      {"0_create_table.up.sql": {}},
+		"duplicate version": {"1_a.up.sql": {}, "0001_b.up.sql": {}},
+	}
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	migrations := Embedded()
+	require.NotEmpty(t, migrations)
+	for i, m := range migrations {
+		assert.Equal(t, uint(i+1), m.Version, "embedded versions must be consecutive")
+		assert.NotEmpty(t, m.Up)
+	}
+}
+
+func TestRunner_Up(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "one", Up: "ONE"},
+		{Version: 2, Name: "two", Up: "TWO"},
+		{Version: 3, Name: "three", Up: "THREE"},
+	}
+	driver := &fakeDriver{version: 1}
+	runner := New(driver, migrations)
+
+	applied, err := runner.Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, migrations[1:], applied)
+	assert.Equal(t, []string{"TWO", "THREE"}, driver.ran)
+
+	status, err := runner.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Status{Version: 3, Latest: 3}, status)
+	assert.True(t, status.Current())
+
+	// Nothing left to apply
+	applied, err = runner.Up(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+}
+
+func TestRunner_UpFailureLeavesSchemaDirty(t *testing.T) {
+	driver := &fakeDriver{failOn: "TWO"}
+	runner := New(driver, []Migration{
+		{Version: 1, Name: "one", Up: "ONE"},
+		{Version: 2, Name: "two", Up: "TWO"},
+		{Version: 3, Name: "three", Up: "THREE"},
+	})
+
+	applied, err := runner.Up(context.Background())
+	assert.ErrorContains(t, err, "2_two")
+	assert.Len(t, applied, 1)
+	assert.Equal(t, []string{"ONE"}, driver.ran)
+
+	status, err := runner.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Status{Version: 2, Latest: 3, Dirty: true}, status)
+	assert.False(t, status.Current())
+
+	// A dirty schema blocks further migrations until forced
+	_, err = runner.Up(context.Background())
+	assert.ErrorIs(t, err, ErrDirty)
+
+	driver.failOn = ""
+	assert.Error(t, runner.Force(context.Background(), 7))
+	require.NoError(t, runner.Force(context.Background(), 1))
+	_, err = runner.Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ONE", "TWO", "THREE"}, driver.ran)
+}
+
+func TestStatus_Current(t *testing.T) {
+	assert.False(t, Status{Version: 1, Latest: 2}.Current())
+	assert.True(t, Status{Version: 3, Latest: 2}.Current(), "newer schema from a rolling deployment")
+	assert.True(t, Status{}.Current())
+}
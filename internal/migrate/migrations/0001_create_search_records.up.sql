@@ -0,0 +1,9 @@
+-- Stored searches, replayed and shared by ID and compacted by age.
+-- The record column holds the domain.SearchRecord as JSON.
+CREATE TABLE IF NOT EXISTS search_records (
+    id         VARCHAR(64) PRIMARY KEY,
+    created_at TIMESTAMP   NOT NULL,
+    record     TEXT        NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS search_records_created_at ON search_records (created_at);
@@ -0,0 +1,9 @@
+-- Daily search statistics per route, rolled up from evicted and compacted
+-- search records. The aggregate column holds the domain.SearchAggregate as JSON.
+CREATE TABLE IF NOT EXISTS search_aggregates (
+    date        VARCHAR(10) NOT NULL,
+    origin      VARCHAR(8)  NOT NULL,
+    destination VARCHAR(8)  NOT NULL,
+    aggregate   TEXT        NOT NULL,
+    PRIMARY KEY (date, origin, destination)
+);
@@ -0,0 +1,13 @@
+-- Background jobs of the job queue, one row per job, so a state change only
+-- rewrites its job. The job column holds the jobqueue.Job as JSON; status
+-- and run_at are copied out of it for inspection.
+CREATE TABLE IF NOT EXISTS jobs (
+    id         VARCHAR(64) PRIMARY KEY,
+    kind       VARCHAR(64) NOT NULL,
+    status     VARCHAR(16) NOT NULL,
+    run_at     TIMESTAMP   NOT NULL,
+    created_at TIMESTAMP   NOT NULL,
+    job        TEXT        NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS jobs_status_run_at ON jobs (status, run_at);
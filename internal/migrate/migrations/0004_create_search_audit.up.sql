@@ -0,0 +1,14 @@
+-- Search audit log: one row per search, failed ones included, appended by
+-- the search audit logger. The entry column holds the
+-- domain.SearchAuditEntry as JSON.
+CREATE TABLE IF NOT EXISTS search_audit (
+    id         INTEGER      PRIMARY KEY AUTOINCREMENT,
+    at         TIMESTAMP    NOT NULL,
+    client     VARCHAR(255) NOT NULL,
+    outcome    VARCHAR(16)  NOT NULL,
+    latency_ms INTEGER      NOT NULL,
+    entry      TEXT         NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS search_audit_at ON search_audit (at);
+CREATE INDEX IF NOT EXISTS search_audit_client_at ON search_audit (client, at);
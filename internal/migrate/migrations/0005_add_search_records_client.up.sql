@@ -0,0 +1,5 @@
+-- The client (API key or bearer token subject) that ran each stored search,
+-- so clients can list their recent searches. Searches stored before have none.
+ALTER TABLE search_records ADD COLUMN client VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS search_records_client ON search_records (client, created_at);
@@ -0,0 +1,87 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// VersionTable is the table recording the schema version.
+const VersionTable = "schema_migrations"
+
+// SQLDriver runs migrations on a database/sql database. The version is kept in
+// a one-row VersionTable, created on first use. It is safe for concurrent use.
+//
+// Migrations are executed as a single Exec call: drivers that do not accept
+// several statements per call by default (e.g. MySQL without
+// multiStatements=true) need it enabled in the DSN.
+type SQLDriver struct {
+	db      *sql.DB
+	created atomic.Bool
+}
+
+// NewSQLDriver creates a driver migrating db.
+func NewSQLDriver(db *sql.DB) *SQLDriver {
+	return &SQLDriver{db: db}
+}
+
+// Version implements Driver.
+func (d *SQLDriver) Version(ctx context.Context) (uint, bool, error) {
+	if err := d.createTable(ctx); err != nil {
+		return 0, false, err
+	}
+
+	var version int64
+	var dirty bool
+	err := d.db.QueryRowContext(ctx, "SELECT version, dirty FROM "+VersionTable).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
+
+// SetVersion implements Driver.
+func (d *SQLDriver) SetVersion(ctx context.Context, version uint, dirty bool) error {
+	if err := d.createTable(ctx); err != nil {
+		return err
+	}
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+VersionTable); err != nil {
+		return err
+	}
+	// Literal values avoid the drivers' differing placeholder syntax
+	if _, err := tx.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s (version, dirty) VALUES (%d, %t)", VersionTable, version, dirty)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Run implements Driver.
+func (d *SQLDriver) Run(ctx context.Context, statements string) error {
+	_, err := d.db.ExecContext(ctx, statements)
+	return err
+}
+
+// createTable creates the version table unless this driver already did.
+func (d *SQLDriver) createTable(ctx context.Context) error {
+	if d.created.Load() {
+		return nil
+	}
+	if _, err := d.db.ExecContext(ctx,
+		"CREATE TABLE IF NOT EXISTS "+VersionTable+" (version BIGINT NOT NULL, dirty BOOLEAN NOT NULL)"); err != nil {
+		return err
+	}
+	d.created.Store(true)
+	return nil
+}
@@ -0,0 +1,72 @@
+package mockdata
+
+import (
+	"math"
+	"sort"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+// airport holds the geographic data needed to build realistic schedules.
+type airport struct {
+	Code     string
+	Lat      float64
+	Lon      float64
+	Timezone string
+}
+
+// airports lists the airports routes can be generated between.
+var airports = map[string]airport{
+	"CGK": {Code: "CGK", Lat: -6.1256, Lon: 106.6559, Timezone: "Asia/Jakarta"},
+	"DPS": {Code: "DPS", Lat: -8.7482, Lon: 115.1675, Timezone: "Asia/Makassar"},
+	"SUB": {Code: "SUB", Lat: -7.3798, Lon: 112.7868, Timezone: "Asia/Jakarta"},
+	"JOG": {Code: "JOG", Lat: -7.9007, Lon: 110.0571, Timezone: "Asia/Jakarta"},
+	"BDO": {Code: "BDO", Lat: -6.9006, Lon: 107.5763, Timezone: "Asia/Jakarta"},
+	"MDC": {Code: "MDC", Lat: 1.5493, Lon: 124.9259, Timezone: "Asia/Makassar"},
+	"UPG": {Code: "UPG", Lat: -5.0617, Lon: 119.5540, Timezone: "Asia/Makassar"},
+	"BPN": {Code: "BPN", Lat: -1.2683, Lon: 116.8945, Timezone: "Asia/Makassar"},
+	"SOC": {Code: "SOC", Lat: -7.5161, Lon: 110.7569, Timezone: "Asia/Jakarta"},
+	"SIN": {Code: "SIN", Lat: 1.3644, Lon: 103.9915, Timezone: "Asia/Singapore"},
+}
+
+// hubs are the airports used as connection points for flights with stops.
+var hubs = []string{"CGK", "SUB", "UPG"}
+
+// AirportCodes returns the codes of all airports routes can be generated for, sorted.
+func AirportCodes() []string {
+	codes := make([]string, 0, len(airports))
+	for code := range airports {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// airportName returns the English airport name.
+func airportName(code string) string {
+	return reference.AirportName(code, reference.LocaleEnglish)
+}
+
+// cityName returns the English name of the city an airport serves.
+func cityName(code string) string {
+	return reference.CityName(code, reference.LocaleEnglish)
+}
+
+// distanceKm returns the great-circle distance between two airports.
+func distanceKm(from, to airport) float64 {
+	const earthRadiusKm = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(to.Lat - from.Lat)
+	dLon := toRad(to.Lon - from.Lon)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(from.Lat))*math.Cos(toRad(to.Lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// blockMinutes estimates the scheduled gate-to-gate time of a direct leg:
+// 30 minutes of taxi and climb plus cruise at roughly 750 km/h, rounded to 5 minutes.
+func blockMinutes(from, to airport) int {
+	minutes := 30 + distanceKm(from, to)/12.5
+	return int(math.Round(minutes/5) * 5)
+}
@@ -0,0 +1,423 @@
+// Package mockdata generates large, realistic provider mock datasets in each
+// provider's native response schema. The output can replace the files in
+// docs/response-mock for load and pagination testing.
+package mockdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/airasia"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/batikair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/demo"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/garuda"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/lionair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// Timestamp layouts used by the provider schemas.
+const (
+	timeCompactOffset = "2006-01-02T15:04:05-0700"
+	timeLocal         = "2006-01-02T15:04:05"
+)
+
+// minutes converts a minute count to a time.Duration.
+func minutes(n int) time.Duration {
+	return time.Duration(n) * time.Minute
+}
+
+// PriceDistribution selects how fares are spread around a route's base fare.
+type PriceDistribution string
+
+const (
+	// PriceUniform spreads fares evenly within ±Spread of the base fare
+	PriceUniform PriceDistribution = "uniform"
+
+	// PriceNormal draws fares from a normal distribution with relative standard deviation Spread
+	PriceNormal PriceDistribution = "normal"
+
+	// PriceLogNormal draws fares from a log-normal distribution, producing a long tail of expensive fares
+	PriceLogNormal PriceDistribution = "lognormal"
+)
+
+// IsValid checks if the price distribution is a supported value.
+func (d PriceDistribution) IsValid() bool {
+	switch d {
+	case PriceUniform, PriceNormal, PriceLogNormal:
+		return true
+	default:
+		return false
+	}
+}
+
+// Route is a directed origin-destination pair.
+type Route struct {
+	Origin      string
+	Destination string
+}
+
+// String returns the route as "ORIGIN-DESTINATION".
+func (r Route) String() string {
+	return r.Origin + "-" + r.Destination
+}
+
+// ParseRoutes parses a comma-separated list of routes such as "CGK-DPS,DPS-CGK".
+// The special value "all" expands to every pair of known airports.
+func ParseRoutes(s string) ([]Route, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "all") {
+		return AllRoutes(), nil
+	}
+
+	var routes []Route
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		origin, destination, ok := strings.Cut(strings.ToUpper(part), "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid route %q: expected ORIGIN-DESTINATION", part)
+		}
+		routes = append(routes, Route{Origin: origin, Destination: destination})
+	}
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("no routes given")
+	}
+	return routes, nil
+}
+
+// AllRoutes returns every directed route between known airports.
+func AllRoutes() []Route {
+	codes := AirportCodes()
+	routes := make([]Route, 0, len(codes)*(len(codes)-1))
+	for _, origin := range codes {
+		for _, destination := range codes {
+			if origin != destination {
+				routes = append(routes, Route{Origin: origin, Destination: destination})
+			}
+		}
+	}
+	return routes
+}
+
+// Config controls dataset generation.
+type Config struct {
+	// Providers lists the provider names to generate data for (default: all)
+	Providers []string
+
+	// Routes are the routes flights are spread across
+	Routes []Route
+
+	// StartDate is the first departure date
+	StartDate time.Time
+
+	// Days is the number of consecutive departure dates flights are spread across
+	Days int
+
+	// FlightsPerProvider is the number of flights generated for each provider
+	FlightsPerProvider int
+
+	// Distribution selects how fares vary around each route's base fare
+	Distribution PriceDistribution
+
+	// Spread is the relative fare variation (e.g., 0.25 = ±25% for uniform)
+	Spread float64
+
+	// StopRatio is the fraction of schedules with one stop (0-1)
+	StopRatio float64
+
+	// Seed makes generation deterministic
+	Seed int64
+}
+
+// DefaultConfig returns a configuration matching the bundled mock data's date.
+func DefaultConfig() Config {
+	return Config{
+		Providers:          Providers(),
+		Routes:             []Route{{Origin: "CGK", Destination: "DPS"}},
+		StartDate:          time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC),
+		Days:               7,
+		FlightsPerProvider: 1000,
+		Distribution:       PriceLogNormal,
+		Spread:             0.25,
+		StopRatio:          0.2,
+		Seed:               1,
+	}
+}
+
+// Providers returns the names of all providers data can be generated for.
+func Providers() []string {
+	return []string{garuda.ProviderName, lionair.ProviderName, batikair.ProviderName, airasia.ProviderName}
+}
+
+// maxFlightNumber is the highest numeric part of an IATA flight number.
+const maxFlightNumber = 9999
+
+// validate checks the configuration for correctness.
+func (c Config) validate() error {
+	if len(c.Providers) == 0 {
+		return fmt.Errorf("at least one provider is required")
+	}
+	for _, p := range c.Providers {
+		if _, ok := profiles[p]; !ok {
+			return fmt.Errorf("unknown provider %q", p)
+		}
+	}
+	if len(c.Routes) == 0 {
+		return fmt.Errorf("at least one route is required")
+	}
+	for _, r := range c.Routes {
+		if _, ok := airports[r.Origin]; !ok {
+			return fmt.Errorf("route %s: unknown airport %q", r, r.Origin)
+		}
+		if _, ok := airports[r.Destination]; !ok {
+			return fmt.Errorf("route %s: unknown airport %q", r, r.Destination)
+		}
+		if r.Origin == r.Destination {
+			return fmt.Errorf("route %s: origin and destination must differ", r)
+		}
+	}
+	if c.Days < 1 {
+		return fmt.Errorf("days must be at least 1, got %d", c.Days)
+	}
+	if c.FlightsPerProvider < 1 {
+		return fmt.Errorf("flights per provider must be at least 1, got %d", c.FlightsPerProvider)
+	}
+	if !c.Distribution.IsValid() {
+		return fmt.Errorf("unknown price distribution %q", c.Distribution)
+	}
+	if c.Spread < 0 || c.Spread > 1 {
+		return fmt.Errorf("spread must be between 0 and 1, got %g", c.Spread)
+	}
+	if c.StopRatio < 0 || c.StopRatio > 1 {
+		return fmt.Errorf("stop ratio must be between 0 and 1, got %g", c.StopRatio)
+	}
+	for _, p := range c.Providers {
+		// Each schedule gets its own flight number, which must stay within 4 digits
+		schedules := (c.FlightsPerProvider + c.Days - 1) / c.Days
+		if profiles[p].numberBase+schedules > maxFlightNumber {
+			return fmt.Errorf("too many flights per day for %s: flight numbers would exceed %d, spread them over more days", p, maxFlightNumber)
+		}
+	}
+	return nil
+}
+
+// Generator produces provider datasets.
+type Generator struct {
+	config Config
+}
+
+// New creates a generator, validating the configuration.
+func New(config Config) (*Generator, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return &Generator{config: config}, nil
+}
+
+// flight is a provider-neutral generated flight, rendered into each native schema.
+type flight struct {
+	Number    string
+	Origin    airport
+	Dest      airport
+	Departure time.Time
+	Arrival   time.Time
+	Via       *airport
+	Legs      [2]int // block minutes of each leg; Legs[1] is zero for direct flights
+	Layover   int
+	Price     float64
+	Seats     int
+	Class     string
+	Aircraft  string
+}
+
+// Duration returns the total elapsed minutes including layovers.
+func (f flight) Duration() int {
+	return f.Legs[0] + f.Layover + f.Legs[1]
+}
+
+// schedule is a recurring flight repeated on every generated date.
+type schedule struct {
+	number    string
+	route     Route
+	depMinute int
+	via       string
+	layover   int
+	aircraft  string
+	class     string
+}
+
+// Generate produces the native response document for a provider.
+// The result marshals to JSON accepted by the provider's adapter.
+func (g *Generator) Generate(provider string) (interface{}, error) {
+	profile, ok := profiles[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q", provider)
+	}
+
+	flights, err := g.flights(provider, profile)
+	if err != nil {
+		return nil, err
+	}
+	return profile.render(flights), nil
+}
+
+// WriteDir generates every configured provider's dataset into dir, using the
+// same file names as docs/response-mock.
+func (g *Generator) WriteDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+
+	for _, provider := range g.config.Providers {
+		doc, err := g.Generate(provider)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", provider, err)
+		}
+
+		path := filepath.Join(dir, demo.MockFiles[provider])
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// flights generates the provider-neutral flights for a provider.
+// Flights are spread round-robin across route-days; each slot within a route
+// keeps its flight number, departure time and routing across dates, while
+// fares and seat availability vary per date.
+func (g *Generator) flights(provider string, profile providerProfile) ([]flight, error) {
+	cfg := g.config
+	rng := rand.New(rand.NewSource(cfg.Seed + int64(len(provider))*7919 + int64(profile.numberBase)))
+
+	routeDays := len(cfg.Routes) * cfg.Days
+	schedules := make(map[int]schedule)
+	flights := make([]flight, 0, cfg.FlightsPerProvider)
+
+	for i := 0; i < cfg.FlightsPerProvider; i++ {
+		routeIdx := i % len(cfg.Routes)
+		day := (i / len(cfg.Routes)) % cfg.Days
+		slot := i / routeDays
+		key := slot*len(cfg.Routes) + routeIdx
+
+		s, ok := schedules[key]
+		if !ok {
+			s = g.newSchedule(rng, profile, cfg.Routes[routeIdx], key)
+			schedules[key] = s
+		}
+
+		f, err := g.newFlight(rng, profile, s, cfg.StartDate.AddDate(0, 0, day))
+		if err != nil {
+			return nil, err
+		}
+		flights = append(flights, f)
+	}
+	return flights, nil
+}
+
+// newSchedule creates the recurring properties of a flight.
+func (g *Generator) newSchedule(rng *rand.Rand, profile providerProfile, route Route, key int) schedule {
+	s := schedule{
+		number: fmt.Sprintf("%s%d", profile.airlineCode, profile.numberBase+key),
+		route:  route,
+		// Departures between 05:00 and 22:00 in 5-minute steps
+		depMinute: 5*60 + rng.Intn(17*12)*5,
+		aircraft:  profile.aircraft[rng.Intn(len(profile.aircraft))],
+		class:     "economy",
+	}
+
+	if rng.Float64() < g.config.StopRatio {
+		candidates := make([]string, 0, len(hubs))
+		for _, hub := range hubs {
+			if hub != route.Origin && hub != route.Destination {
+				candidates = append(candidates, hub)
+			}
+		}
+		if len(candidates) > 0 {
+			s.via = candidates[rng.Intn(len(candidates))]
+			s.layover = 45 + rng.Intn(20)*5
+		}
+	}
+
+	if rng.Float64() < profile.businessRatio {
+		s.class = "business"
+	}
+	return s
+}
+
+// newFlight creates a dated flight from a schedule.
+func (g *Generator) newFlight(rng *rand.Rand, profile providerProfile, s schedule, date time.Time) (flight, error) {
+	origin := airports[s.route.Origin]
+	dest := airports[s.route.Destination]
+
+	loc, err := timeutil.GetLocation(origin.Timezone)
+	if err != nil {
+		return flight{}, fmt.Errorf("load timezone %s: %w", origin.Timezone, err)
+	}
+	destLoc, err := timeutil.GetLocation(dest.Timezone)
+	if err != nil {
+		return flight{}, fmt.Errorf("load timezone %s: %w", dest.Timezone, err)
+	}
+
+	f := flight{
+		Number:    s.number,
+		Origin:    origin,
+		Dest:      dest,
+		Departure: time.Date(date.Year(), date.Month(), date.Day(), 0, s.depMinute, 0, 0, loc),
+		Aircraft:  s.aircraft,
+		Class:     s.class,
+		Seats:     1 + rng.Intn(profile.maxSeats),
+	}
+
+	if s.via != "" {
+		via := airports[s.via]
+		f.Via = &via
+		f.Legs = [2]int{blockMinutes(origin, via), blockMinutes(via, dest)}
+		f.Layover = s.layover
+	} else {
+		f.Legs[0] = blockMinutes(origin, dest)
+	}
+	f.Arrival = f.Departure.Add(minutes(f.Duration())).In(destLoc)
+	f.Price = g.price(rng, profile, f, distanceKm(origin, dest))
+
+	return f, nil
+}
+
+// price draws a fare for a flight, rounded to the nearest IDR 1,000.
+func (g *Generator) price(rng *rand.Rand, profile providerProfile, f flight, km float64) float64 {
+	base := (350000 + km*900) * profile.fareFactor
+	if f.Class == "business" {
+		base *= 2.8
+	}
+	if f.Via != nil {
+		// Connections sell at a discount to direct flights
+		base *= 0.85
+	}
+
+	var factor float64
+	switch g.config.Distribution {
+	case PriceUniform:
+		factor = 1 + (rng.Float64()*2-1)*g.config.Spread
+	case PriceNormal:
+		factor = 1 + rng.NormFloat64()*g.config.Spread
+	default:
+		factor = math.Exp(rng.NormFloat64() * g.config.Spread)
+	}
+	// Never sell below 30% of the base fare
+	factor = math.Max(factor, 0.3)
+
+	return math.Round(base*factor/1000) * 1000
+}
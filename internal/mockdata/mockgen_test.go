@@ -0,0 +1,201 @@
+package mockdata
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/demo"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+func TestParseRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Route
+		wantErr bool
+	}{
+		{name: "single", input: "CGK-DPS", want: []Route{{Origin: "CGK", Destination: "DPS"}}},
+		{name: "multiple lowercase", input: "cgk-dps, dps-cgk", want: []Route{{"CGK", "DPS"}, {"DPS", "CGK"}}},
+		{name: "missing separator", input: "CGKDPS", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRoutes(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseRoutes_All(t *testing.T) {
+	routes, err := ParseRoutes("all")
+	require.NoError(t, err)
+
+	n := len(AirportCodes())
+	assert.Len(t, routes, n*(n-1))
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{name: "unknown provider", modify: func(c *Config) { c.Providers = []string{"unknown"} }},
+		{name: "unknown airport", modify: func(c *Config) { c.Routes = []Route{{"CGK", "XXX"}} }},
+		{name: "same origin and destination", modify: func(c *Config) { c.Routes = []Route{{"CGK", "CGK"}} }},
+		{name: "zero days", modify: func(c *Config) { c.Days = 0 }},
+		{name: "zero flights", modify: func(c *Config) { c.FlightsPerProvider = 0 }},
+		{name: "unknown distribution", modify: func(c *Config) { c.Distribution = "pareto" }},
+		{name: "spread out of range", modify: func(c *Config) { c.Spread = 2 }},
+		{name: "stop ratio out of range", modify: func(c *Config) { c.StopRatio = -0.1 }},
+		{name: "flight numbers beyond 4 digits", modify: func(c *Config) { c.Days = 1; c.FlightsPerProvider = 5000 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg)
+			_, err := New(cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// writeAndLoad generates a dataset and loads it back through the provider adapters.
+func writeAndLoad(t *testing.T, cfg Config) *demo.Store {
+	t.Helper()
+	gen, err := New(cfg)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, gen.WriteDir(dir))
+
+	store, err := demo.LoadMockDir(context.Background(), dir)
+	require.NoError(t, err)
+	return store
+}
+
+func TestWriteDir_RoundTripsThroughAdapters(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Routes = []Route{{"CGK", "DPS"}, {"DPS", "CGK"}}
+	cfg.Days = 3
+	cfg.FlightsPerProvider = 300
+
+	store := writeAndLoad(t, cfg)
+	assert.Equal(t, 4*300, store.Len(), "every generated flight should normalize")
+
+	for day := 0; day < cfg.Days; day++ {
+		date := cfg.StartDate.AddDate(0, 0, day).Format("2006-01-02")
+		for _, route := range cfg.Routes {
+			flights := store.Search(domain.SearchCriteria{Origin: route.Origin, Destination: route.Destination, DepartureDate: date})
+			assert.Equal(t, 4*50, len(flights), "flights should be spread evenly across %s on %s", route, date)
+
+			for _, f := range flights {
+				assert.Positive(t, f.Price.Amount)
+				assert.Zero(t, int(f.Price.Amount)%1000, "fares are rounded to IDR 1,000")
+				assert.True(t, f.Arrival.DateTime.After(f.Departure.DateTime))
+				assert.Equal(t, int(f.Arrival.DateTime.Sub(f.Departure.DateTime).Minutes()), f.Duration.TotalMinutes)
+			}
+		}
+	}
+}
+
+func TestWriteDir_StopRatio(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FlightsPerProvider = 50
+
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15"}
+
+	cfg.StopRatio = 0
+	direct := writeAndLoad(t, cfg).Search(criteria)
+	require.NotEmpty(t, direct)
+	for _, f := range direct {
+		assert.Zero(t, f.Stops)
+	}
+
+	cfg.StopRatio = 1
+	connecting := writeAndLoad(t, cfg).Search(criteria)
+	require.NotEmpty(t, connecting)
+	for _, f := range connecting {
+		assert.Equal(t, 1, f.Stops, "%s/%s should connect", f.Provider, f.ID)
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FlightsPerProvider = 100
+
+	encode := func() []byte {
+		gen, err := New(cfg)
+		require.NoError(t, err)
+		doc, err := gen.Generate("garuda_indonesia")
+		require.NoError(t, err)
+		data, err := json.Marshal(doc)
+		require.NoError(t, err)
+		return data
+	}
+
+	assert.Equal(t, encode(), encode())
+
+	cfg.Seed = 2
+	other := encode()
+	cfg.Seed = 1
+	assert.NotEqual(t, encode(), other)
+}
+
+func TestGenerate_UniformPricesWithinSpread(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Providers = []string{"airasia"}
+	cfg.Distribution = PriceUniform
+	cfg.Spread = 0.1
+	cfg.StopRatio = 0
+
+	gen, err := New(cfg)
+	require.NoError(t, err)
+	doc, err := gen.Generate("airasia")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var resp struct {
+		Flights []struct {
+			PriceIDR float64 `json:"price_idr"`
+		} `json:"flights"`
+	}
+	require.NoError(t, json.Unmarshal(data, &resp))
+
+	base := (350000 + distanceKm(airports["CGK"], airports["DPS"])*900) * profiles["airasia"].fareFactor
+	for _, f := range resp.Flights {
+		assert.InDelta(t, base, f.PriceIDR, base*0.1+1000)
+	}
+}
+
+func TestWriteDir_FileNames(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Providers = []string{"lion_air"}
+	cfg.FlightsPerProvider = 1
+
+	gen, err := New(cfg)
+	require.NoError(t, err)
+
+	dir := filepath.Join(t.TempDir(), "nested")
+	require.NoError(t, gen.WriteDir(dir))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, demo.MockFiles["lion_air"], entries[0].Name())
+}
@@ -0,0 +1,237 @@
+package mockdata
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/airasia"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/batikair"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/garuda"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/lionair"
+)
+
+// providerProfile describes how a provider's flights look and are rendered.
+type providerProfile struct {
+	airlineCode   string
+	numberBase    int
+	aircraft      []string
+	businessRatio float64
+	maxSeats      int
+	fareFactor    float64
+	render        func([]flight) interface{}
+}
+
+// profiles maps provider names to their generation profiles.
+// Full-service carriers price higher and sell business class; low-cost carriers do not.
+var profiles = map[string]providerProfile{
+	garuda.ProviderName: {
+		airlineCode:   "GA",
+		numberBase:    400,
+		aircraft:      []string{"Boeing 737-800", "Airbus A330-300", "Boeing 777-300ER"},
+		businessRatio: 0.15,
+		maxSeats:      40,
+		fareFactor:    1.25,
+		render:        renderGaruda,
+	},
+	lionair.ProviderName: {
+		airlineCode: "JT",
+		numberBase:  700,
+		aircraft:    []string{"Boeing 737-900ER", "Boeing 737-800", "Airbus A330-300"},
+		maxSeats:    60,
+		fareFactor:  0.85,
+		render:      renderLionAir,
+	},
+	batikair.ProviderName: {
+		airlineCode:   "ID",
+		numberBase:    6500,
+		aircraft:      []string{"Airbus A320", "Boeing 737-800", "Airbus A330-300"},
+		businessRatio: 0.1,
+		maxSeats:      40,
+		fareFactor:    1.1,
+		render:        renderBatikAir,
+	},
+	airasia.ProviderName: {
+		airlineCode: "QZ",
+		numberBase:  500,
+		aircraft:    []string{"Airbus A320"},
+		maxSeats:    80,
+		fareFactor:  0.7,
+		render:      renderAirAsia,
+	},
+}
+
+// isWidebody reports whether an aircraft is a widebody, which offers more amenities.
+func isWidebody(aircraft string) bool {
+	return strings.Contains(aircraft, "A330") || strings.Contains(aircraft, "777")
+}
+
+// formatHoursMinutes formats minutes as "Xh Ym", as used by Batik Air.
+func formatHoursMinutes(minutes int) string {
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
+
+func renderGaruda(flights []flight) interface{} {
+	resp := garuda.GarudaResponse{Status: "success", Flights: make([]garuda.GarudaFlight, 0, len(flights))}
+	for _, f := range flights {
+		gf := garuda.GarudaFlight{
+			FlightID:    f.Number,
+			Airline:     "Garuda Indonesia",
+			AirlineCode: "GA",
+			Departure: garuda.GarudaEndpoint{
+				Airport:  f.Origin.Code,
+				City:     cityName(f.Origin.Code),
+				Time:     f.Departure.Format(time.RFC3339),
+				Terminal: "3",
+			},
+			Arrival: garuda.GarudaEndpoint{
+				Airport: f.Dest.Code,
+				City:    cityName(f.Dest.Code),
+				Time:    f.Arrival.Format(time.RFC3339),
+			},
+			DurationMinutes: f.Duration(),
+			Aircraft:        f.Aircraft,
+			Price:           garuda.GarudaPrice{Amount: f.Price, Currency: "IDR"},
+			AvailableSeats:  f.Seats,
+			FareClass:       f.Class,
+			Baggage:         garuda.GarudaBaggage{CarryOn: 1, Checked: 1},
+			Amenities:       []string{"meal"},
+		}
+		if f.Class == "business" {
+			gf.Baggage.Checked = 2
+		}
+		if isWidebody(f.Aircraft) {
+			gf.Amenities = append(gf.Amenities, "wifi", "entertainment")
+		}
+		if f.Via != nil {
+			gf.Stops = 1
+			connect := f.Departure.Add(minutes(f.Legs[0]))
+			gf.Segments = []garuda.GarudaSegment{
+				{
+					FlightNumber:    f.Number,
+					Departure:       garuda.GarudaSegmentPoint{Airport: f.Origin.Code, Time: f.Departure.Format(time.RFC3339)},
+					Arrival:         garuda.GarudaSegmentPoint{Airport: f.Via.Code, Time: connect.Format(time.RFC3339)},
+					DurationMinutes: f.Legs[0],
+					LayoverMinutes:  f.Layover,
+				},
+				{
+					FlightNumber:    f.Number,
+					Departure:       garuda.GarudaSegmentPoint{Airport: f.Via.Code, Time: connect.Add(minutes(f.Layover)).Format(time.RFC3339)},
+					Arrival:         garuda.GarudaSegmentPoint{Airport: f.Dest.Code, Time: f.Arrival.Format(time.RFC3339)},
+					DurationMinutes: f.Legs[1],
+				},
+			}
+		}
+		resp.Flights = append(resp.Flights, gf)
+	}
+	return resp
+}
+
+func renderLionAir(flights []flight) interface{} {
+	resp := lionair.LionAirResponse{
+		Success: true,
+		Data:    lionair.LionAirData{AvailableFlights: make([]lionair.LionAirFlight, 0, len(flights))},
+	}
+	for _, f := range flights {
+		lf := lionair.LionAirFlight{
+			ID:      f.Number,
+			Carrier: lionair.LionAirCarrier{Name: "Lion Air", IATA: "JT"},
+			Route: lionair.LionAirRoute{
+				From: lionair.LionAirAirport{Code: f.Origin.Code, Name: airportName(f.Origin.Code), City: cityName(f.Origin.Code)},
+				To:   lionair.LionAirAirport{Code: f.Dest.Code, Name: airportName(f.Dest.Code), City: cityName(f.Dest.Code)},
+			},
+			Schedule: lionair.LionAirSchedule{
+				Departure:         f.Departure.Format(timeLocal),
+				DepartureTimezone: f.Origin.Timezone,
+				Arrival:           f.Arrival.Format(timeLocal),
+				ArrivalTimezone:   f.Dest.Timezone,
+			},
+			FlightTime: f.Duration(),
+			IsDirect:   f.Via == nil,
+			Pricing:    lionair.LionAirPricing{Total: f.Price, Currency: "IDR", FareType: strings.ToUpper(f.Class)},
+			SeatsLeft:  f.Seats,
+			PlaneType:  f.Aircraft,
+			Services: lionair.LionAirServices{
+				WiFiAvailable:    isWidebody(f.Aircraft),
+				MealsIncluded:    f.Duration() >= 150,
+				BaggageAllowance: lionair.LionAirBaggageAllowance{Cabin: "7 kg", Hold: "20 kg"},
+			},
+		}
+		if f.Via != nil {
+			lf.StopCount = 1
+			lf.Layovers = []lionair.LionAirLayover{{Airport: f.Via.Code, DurationMinutes: f.Layover}}
+		}
+		resp.Data.AvailableFlights = append(resp.Data.AvailableFlights, lf)
+	}
+	return resp
+}
+
+func renderBatikAir(flights []flight) interface{} {
+	resp := batikair.BatikAirResponse{Code: 200, Message: "OK", Results: make([]batikair.BatikAirFlight, 0, len(flights))}
+	for _, f := range flights {
+		// Taxes are roughly 11% of the total fare
+		taxes := math.Round(f.Price*0.11/1000) * 1000
+		class := "Y"
+		if f.Class == "business" {
+			class = "C"
+		}
+
+		bf := batikair.BatikAirFlight{
+			FlightNumber:      f.Number,
+			AirlineName:       "Batik Air",
+			AirlineIATA:       "ID",
+			Origin:            f.Origin.Code,
+			Destination:       f.Dest.Code,
+			DepartureDateTime: f.Departure.Format(timeCompactOffset),
+			ArrivalDateTime:   f.Arrival.Format(timeCompactOffset),
+			TravelTime:        formatHoursMinutes(f.Duration()),
+			Fare: batikair.BatikAirFare{
+				BasePrice:    f.Price - taxes,
+				Taxes:        taxes,
+				TotalPrice:   f.Price,
+				CurrencyCode: "IDR",
+				Class:        class,
+			},
+			SeatsAvailable:  f.Seats,
+			AircraftModel:   f.Aircraft,
+			BaggageInfo:     "7kg cabin, 20kg checked",
+			OnboardServices: []string{"Snack", "Beverage"},
+		}
+		if f.Class == "business" {
+			bf.BaggageInfo = "7kg cabin, 30kg checked"
+			bf.OnboardServices = []string{"Meal", "Beverage", "Entertainment"}
+		}
+		if f.Via != nil {
+			bf.NumberOfStops = 1
+			bf.Connections = []batikair.BatikAirConnection{{StopAirport: f.Via.Code, StopDuration: formatHoursMinutes(f.Layover)}}
+		}
+		resp.Results = append(resp.Results, bf)
+	}
+	return resp
+}
+
+func renderAirAsia(flights []flight) interface{} {
+	resp := airasia.AirAsiaResponse{Status: "ok", Flights: make([]airasia.AirAsiaFlight, 0, len(flights))}
+	for _, f := range flights {
+		af := airasia.AirAsiaFlight{
+			FlightCode:    f.Number,
+			Airline:       "AirAsia",
+			FromAirport:   f.Origin.Code,
+			ToAirport:     f.Dest.Code,
+			DepartTime:    f.Departure.Format(time.RFC3339),
+			ArriveTime:    f.Arrival.Format(time.RFC3339),
+			DurationHours: math.Round(float64(f.Duration())/60*100) / 100,
+			DirectFlight:  f.Via == nil,
+			PriceIDR:      f.Price,
+			Seats:         f.Seats,
+			CabinClass:    f.Class,
+			BaggageNote:   "Cabin baggage only, checked bags additional fee",
+		}
+		if f.Via != nil {
+			af.Stops = []airasia.AirAsiaStop{{Airport: f.Via.Code, WaitTimeMinutes: f.Layover}}
+		}
+		resp.Flights = append(resp.Flights, af)
+	}
+	return resp
+}
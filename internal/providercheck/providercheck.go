@@ -0,0 +1,304 @@
+// Package providercheck dry-runs a provider adapter against a sample response
+// before the provider goes live, and reports whether it is ready.
+//
+// The adapter is searched like the server searches it, and its output goes
+// through the checks a new airline integration must pass: the response is
+// normalized without rejections, the flights are valid domain flights, they
+// can be ranked alongside the other providers' flights, and the adapter
+// behaves as the search use case expects (it is named consistently, narrows
+// results to the criteria, is deterministic and honours cancellation).
+package providercheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
+)
+
+// Check names, in the order they run.
+const (
+	CheckNormalization = "normalization"
+	CheckValidation    = "validation"
+	CheckRanking       = "ranking"
+	CheckConformance   = "conformance"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+// Check outcomes.
+const (
+	// StatusPass means the check found nothing to report
+	StatusPass Status = "pass"
+
+	// StatusWarn means the provider can go live, but its findings deserve a look
+	StatusWarn Status = "warn"
+
+	// StatusFail means the provider is not ready
+	StatusFail Status = "fail"
+)
+
+// Result is the outcome of one check.
+type Result struct {
+	Check  string
+	Status Status
+
+	// Findings explain a warning or failure, one per line
+	Findings []string
+}
+
+// Report is the readiness report of a provider.
+type Report struct {
+	Provider string
+
+	// Criteria is the search the adapter was checked with
+	Criteria domain.SearchCriteria
+
+	// Received, Rejected and Flights count the flights of the response, those
+	// rejected during normalization and those returned for the criteria
+	Received int
+	Rejected int
+	Flights  int
+
+	Results []Result
+}
+
+// Ready reports whether no check failed.
+func (r *Report) Ready() bool {
+	for _, res := range r.Results {
+		if res.Status == StatusFail {
+			return false
+		}
+	}
+	return true
+}
+
+// checker accumulates the findings of one check.
+type checker struct {
+	result Result
+}
+
+func newChecker(name string) *checker {
+	return &checker{result: Result{Check: name, Status: StatusPass}}
+}
+
+func (c *checker) warn(format string, args ...any) {
+	if c.result.Status == StatusPass {
+		c.result.Status = StatusWarn
+	}
+	c.result.Findings = append(c.result.Findings, fmt.Sprintf(format, args...))
+}
+
+func (c *checker) fail(format string, args ...any) {
+	c.result.Status = StatusFail
+	c.result.Findings = append(c.result.Findings, fmt.Sprintf(format, args...))
+}
+
+// Run checks provider with a search for criteria. An empty criteria origin,
+// destination or date matches every flight of the sample response.
+func Run(ctx context.Context, provider domain.FlightProvider, criteria domain.SearchCriteria) *Report {
+	report := &Report{Provider: provider.Name(), Criteria: criteria}
+
+	observed := false
+	searchCtx := domain.WithNormalizationObserver(ctx, func(received, rejected int) {
+		observed = true
+		report.Received += received
+		report.Rejected += rejected
+	})
+	flights, err := provider.Search(searchCtx, criteria)
+	report.Flights = len(flights)
+
+	normalization := newChecker(CheckNormalization)
+	switch {
+	case err != nil:
+		normalization.fail("search failed: %v", err)
+	case len(flights) == 0:
+		normalization.fail("no flights returned; check the sample response and the search criteria")
+	}
+	if !observed && err == nil {
+		normalization.warn("adapter does not report normalization (call domain.ReportNormalization)")
+	}
+	if report.Rejected > 0 {
+		normalization.warn("%d of %d flights rejected during normalization", report.Rejected, report.Received)
+	}
+	report.Results = append(report.Results, normalization.result)
+	if err != nil {
+		// The other checks need flights
+		return report
+	}
+
+	report.Results = append(report.Results,
+		checkValidation(flights),
+		checkRanking(flights),
+		checkConformance(ctx, provider, criteria, flights),
+	)
+	return report
+}
+
+// checkValidation checks the flights are valid domain flights with usable
+// prices and durations.
+func checkValidation(flights []domain.Flight) Result {
+	c := newChecker(CheckValidation)
+	for _, f := range flights {
+		name := flightName(f)
+		if err := f.Validate(); err != nil {
+			c.fail("%s: %v", name, err)
+		}
+		if f.ID == "" {
+			c.fail("%s: missing ID", name)
+		}
+		if math.IsNaN(f.Price.Amount) || math.IsInf(f.Price.Amount, 0) || f.Price.Amount <= 0 {
+			c.fail("%s: price %v must be a positive amount", name, f.Price.Amount)
+		}
+		if f.Price.Currency == "" {
+			c.fail("%s: missing price currency", name)
+		}
+		if f.Duration.TotalMinutes <= 0 {
+			c.fail("%s: duration %d minutes must be positive", name, f.Duration.TotalMinutes)
+		} else if elapsed := int(f.Arrival.DateTime.Sub(f.Departure.DateTime).Minutes()); elapsed != f.Duration.TotalMinutes {
+			c.warn("%s: duration %d minutes differs from the %d minutes between departure and arrival",
+				name, f.Duration.TotalMinutes, elapsed)
+		}
+		if f.Stops < 0 {
+			c.fail("%s: negative stops %d", name, f.Stops)
+		}
+		if f.Class == "" {
+			c.warn("%s: missing class", name)
+		}
+	}
+	return c.result
+}
+
+// checkRanking checks the flights can be ranked without clamped scores and
+// in the currency the filters use.
+func checkRanking(flights []domain.Flight) Result {
+	c := newChecker(CheckRanking)
+
+	ranked := usecase.CalculateRankingScores(flights)
+	_, violations := usecase.EnforceRankingInvariants(ranked)
+	for _, v := range violations {
+		invariant := v.Invariant
+		if v.Factor != "" {
+			invariant += " (" + v.Factor + ")"
+		}
+		c.fail("flight %s: ranking score %v breaks the %s invariant", v.FlightID, v.Score, invariant)
+	}
+
+	currencies := make(map[string]int)
+	for _, f := range flights {
+		if !strings.EqualFold(f.Price.Currency, domain.FilterCurrency) {
+			currencies[strings.ToUpper(f.Price.Currency)]++
+		}
+	}
+	for currency, n := range currencies {
+		c.warn("%d flights priced in %s, not %s: maxPrice filters them out and price sorts list them last",
+			n, currency, domain.FilterCurrency)
+	}
+	return c.result
+}
+
+// checkConformance checks the adapter behaves as the search use case expects.
+func checkConformance(ctx context.Context, provider domain.FlightProvider, criteria domain.SearchCriteria, flights []domain.Flight) Result {
+	c := newChecker(CheckConformance)
+
+	if provider.Name() == "" {
+		c.fail("provider name is empty")
+	}
+	for _, f := range flights {
+		name := flightName(f)
+		if f.Provider != provider.Name() {
+			c.fail("%s: provider %q differs from the adapter name %q", name, f.Provider, provider.Name())
+		}
+		if criteria.Origin != "" && !strings.EqualFold(f.Departure.AirportCode, criteria.Origin) {
+			c.fail("%s: departs from %s, searched %s", name, f.Departure.AirportCode, criteria.Origin)
+		}
+		if criteria.Destination != "" && !strings.EqualFold(f.Arrival.AirportCode, criteria.Destination) {
+			c.fail("%s: arrives at %s, searched %s", name, f.Arrival.AirportCode, criteria.Destination)
+		}
+	}
+
+	again, err := provider.Search(ctx, criteria)
+	if err != nil {
+		c.fail("second search failed: %v", err)
+	} else if !sameFlights(flights, again) {
+		c.warn("a second search returned different flights; results must not depend on the call")
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := provider.Search(cancelled, criteria); err == nil {
+		c.fail("search with a cancelled context succeeded; adapters must honour cancellation")
+	} else if !errors.Is(err, context.Canceled) {
+		c.warn("search with a cancelled context failed with %v, which does not wrap context.Canceled", err)
+	}
+	return c.result
+}
+
+// sameFlights reports whether both searches returned the same flights, in
+// the same order. NaN prices, already reported by validation, are equal.
+func sameFlights(a, b []domain.Flight) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		pa, pb := a[i].Price, b[i].Price
+		samePrice := pa.Amount == pb.Amount || (math.IsNaN(pa.Amount) && math.IsNaN(pb.Amount))
+		if a[i].ID != b[i].ID || pa.Currency != pb.Currency || !samePrice {
+			return false
+		}
+	}
+	return true
+}
+
+// flightName identifies a flight in findings.
+func flightName(f domain.Flight) string {
+	if f.ID != "" {
+		return "flight " + f.ID
+	}
+	return "flight " + f.FlightNumber
+}
+
+// Write prints the report in a human-readable form.
+func (r *Report) Write(w io.Writer) error {
+	ew := &errWriter{w: w}
+	ew.printf("Provider %s: %d flights in the response, %d rejected, %d returned for %s\n\n",
+		r.Provider, r.Received, r.Rejected, r.Flights, describeCriteria(r.Criteria))
+	for _, res := range r.Results {
+		ew.printf("[%s] %s\n", strings.ToUpper(string(res.Status)), res.Check)
+		for _, finding := range res.Findings {
+			ew.printf("  %s\n", finding)
+		}
+	}
+	if r.Ready() {
+		ew.printf("\nReady to go live.\n")
+	} else {
+		ew.printf("\nNot ready: fix the failed checks.\n")
+	}
+	return ew.err
+}
+
+// describeCriteria describes the searched route and date.
+func describeCriteria(c domain.SearchCriteria) string {
+	if c.Origin == "" && c.Destination == "" && c.DepartureDate == "" {
+		return "all routes"
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s-%s %s", c.Origin, c.Destination, c.DepartureDate))
+}
+
+// errWriter keeps the first write error.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) printf(format string, args ...any) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.w, format, args...)
+	}
+}
@@ -0,0 +1,156 @@
+package providercheck
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// sampleProvider returns its flights like an adapter reading a sample response.
+type sampleProvider struct {
+	name             string
+	flights          []domain.Flight
+	rejected         int
+	err              error
+	ignoreCancel     bool
+	skipNormalReport bool
+}
+
+func (p *sampleProvider) Name() string { return p.name }
+
+func (p *sampleProvider) Search(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	if err := ctx.Err(); err != nil && !p.ignoreCancel {
+		return nil, &domain.ProviderError{Provider: p.name, Err: err}
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if !p.skipNormalReport {
+		domain.ReportNormalization(ctx, len(p.flights)+p.rejected, p.rejected)
+	}
+	var flights []domain.Flight
+	for _, f := range p.flights {
+		if criteria.Origin == "" || f.Departure.AirportCode == criteria.Origin {
+			flights = append(flights, f)
+		}
+	}
+	return flights, nil
+}
+
+func flight(id string, hour int, price float64) domain.Flight {
+	departure := time.Date(2025, 12, 15, hour, 0, 0, 0, time.UTC)
+	return domain.Flight{
+		ID:           id,
+		FlightNumber: "GA-" + id,
+		Airline:      domain.AirlineInfo{Code: "GA", Name: "Garuda Indonesia"},
+		Departure:    domain.FlightPoint{AirportCode: "CGK", DateTime: departure},
+		Arrival:      domain.FlightPoint{AirportCode: "DPS", DateTime: departure.Add(2 * time.Hour)},
+		Duration:     domain.DurationInfo{TotalMinutes: 120},
+		Price:        domain.PriceInfo{Amount: price, Currency: "IDR"},
+		Class:        "economy",
+		Provider:     "garuda",
+	}
+}
+
+func statuses(r *Report) map[string]Status {
+	s := make(map[string]Status, len(r.Results))
+	for _, res := range r.Results {
+		s[res.Check] = res.Status
+	}
+	return s
+}
+
+func TestRun_Ready(t *testing.T) {
+	p := &sampleProvider{name: "garuda", flights: []domain.Flight{flight("100", 8, 1000000), flight("200", 12, 1500000)}}
+
+	report := Run(context.Background(), p, domain.SearchCriteria{Origin: "CGK", Destination: "DPS"})
+
+	assert.True(t, report.Ready())
+	assert.Equal(t, 2, report.Received)
+	assert.Equal(t, 2, report.Flights)
+	assert.Equal(t, map[string]Status{
+		CheckNormalization: StatusPass,
+		CheckValidation:    StatusPass,
+		CheckRanking:       StatusPass,
+		CheckConformance:   StatusPass,
+	}, statuses(report))
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+	assert.Contains(t, out.String(), "Provider garuda: 2 flights in the response, 0 rejected, 2 returned for CGK-DPS")
+	assert.Contains(t, out.String(), "[PASS] conformance")
+	assert.Contains(t, out.String(), "Ready to go live.")
+}
+
+func TestRun_Findings(t *testing.T) {
+	noNumber := flight("300", 9, 900000)
+	noNumber.FlightNumber = ""
+	badPrice := flight("400", 10, math.NaN())
+	usd := flight("500", 11, 80)
+	usd.Price.Currency = "USD"
+	otherName := flight("600", 13, 1200000)
+	otherName.Provider = "garuda_indonesia"
+	elsewhere := flight("700", 14, 1100000)
+	elsewhere.Arrival.AirportCode = "SUB"
+
+	p := &sampleProvider{
+		name:         "garuda",
+		flights:      []domain.Flight{flight("100", 8, 1000000), noNumber, badPrice, usd, otherName, elsewhere},
+		rejected:     1,
+		ignoreCancel: true,
+	}
+
+	report := Run(context.Background(), p, domain.SearchCriteria{Origin: "CGK", Destination: "DPS"})
+
+	assert.False(t, report.Ready())
+	assert.Equal(t, map[string]Status{
+		CheckNormalization: StatusWarn,
+		CheckValidation:    StatusFail,
+		CheckRanking:       StatusFail,
+		CheckConformance:   StatusFail,
+	}, statuses(report))
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+	assert.NotContains(t, out.String(), "a second search returned different flights")
+	for _, finding := range []string{
+		"1 of 7 flights rejected during normalization",
+		"flight 300: missing required field: FlightNumber",
+		"flight 400: price NaN must be a positive amount",
+		"flight 400: ranking score NaN breaks the finite invariant",
+		"1 flights priced in USD, not IDR",
+		`flight 600: provider "garuda_indonesia" differs from the adapter name "garuda"`,
+		"flight 700: arrives at SUB, searched DPS",
+		"search with a cancelled context succeeded",
+		"Not ready: fix the failed checks.",
+	} {
+		assert.Contains(t, out.String(), finding)
+	}
+}
+
+func TestRun_SearchFails(t *testing.T) {
+	p := &sampleProvider{name: "garuda", err: errors.New("unexpected end of JSON input")}
+
+	report := Run(context.Background(), p, domain.SearchCriteria{})
+
+	assert.False(t, report.Ready())
+	require.Len(t, report.Results, 1, "the other checks need flights")
+	assert.Equal(t, []string{"search failed: unexpected end of JSON input"}, report.Results[0].Findings)
+}
+
+func TestRun_NoNormalizationReport(t *testing.T) {
+	p := &sampleProvider{name: "garuda", flights: []domain.Flight{flight("100", 8, 1000000)}, skipNormalReport: true}
+
+	report := Run(context.Background(), p, domain.SearchCriteria{})
+
+	assert.True(t, report.Ready())
+	assert.Equal(t, StatusWarn, statuses(report)[CheckNormalization])
+}
@@ -0,0 +1,300 @@
+// Package quality tracks the data quality of flight providers over time.
+//
+// A Tracker wraps providers and records, per provider and hour, how many
+// flights were rejected during normalization, how many returned flights have
+// anomalous prices or are duplicates, and how many responses could not be
+// decoded at all (schema drift). Operators read the rates over a sliding
+// window from the admin API to decide which providers to promote, demote or
+// put in shadow mode.
+package quality
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// DefaultWindow is the default period quality statistics cover.
+const DefaultWindow = 24 * time.Hour
+
+// bucketSize is the granularity of the sliding window.
+const bucketSize = time.Hour
+
+// AnomalyFactor is how far a price may deviate from the median price of its
+// response, in either direction, before it counts as anomalous.
+const AnomalyFactor = 3
+
+// minAnomalySample is the minimum number of same-currency flights in a
+// response for prices to be checked for anomalies.
+const minAnomalySample = 3
+
+// Stats are a provider's quality counters over a period.
+type Stats struct {
+	Provider string
+
+	// Searches is the number of searches answered or failed by the provider
+	Searches int
+
+	// Received is the number of flights in provider responses, before normalization
+	Received int
+
+	// Rejected is the number of received flights dropped during normalization
+	Rejected int
+
+	// Flights is the number of flights returned for searches
+	Flights int
+
+	// PriceAnomalies is the number of returned flights priced more than
+	// AnomalyFactor times above or below the median of their response
+	PriceAnomalies int
+
+	// Duplicates is the number of returned flights repeating an earlier flight
+	// of the same response (same flight number and departure time)
+	Duplicates int
+
+	// SchemaDriftIncidents is the number of responses that could not be decoded
+	SchemaDriftIncidents int
+}
+
+// RejectionRate is the share of received flights rejected during normalization.
+func (s Stats) RejectionRate() float64 {
+	return rate(s.Rejected, s.Received)
+}
+
+// PriceAnomalyRate is the share of returned flights with anomalous prices.
+func (s Stats) PriceAnomalyRate() float64 {
+	return rate(s.PriceAnomalies, s.Flights)
+}
+
+// DuplicateRate is the share of returned flights that are duplicates.
+func (s Stats) DuplicateRate() float64 {
+	return rate(s.Duplicates, s.Flights)
+}
+
+// add accumulates other into s.
+func (s *Stats) add(other Stats) {
+	s.Searches += other.Searches
+	s.Received += other.Received
+	s.Rejected += other.Rejected
+	s.Flights += other.Flights
+	s.PriceAnomalies += other.PriceAnomalies
+	s.Duplicates += other.Duplicates
+	s.SchemaDriftIncidents += other.SchemaDriftIncidents
+}
+
+// rate returns n/total, or 0 when total is 0.
+func rate(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// bucket holds the counters of one hour.
+type bucket struct {
+	start time.Time
+	stats Stats
+}
+
+// Tracker records provider quality. It is safe for concurrent use.
+type Tracker struct {
+	clock  timeutil.Clock
+	window time.Duration
+
+	mu      sync.Mutex
+	buckets map[string][]bucket
+}
+
+// Option configures a Tracker.
+type Option func(*Tracker)
+
+// WithClock sets the clock used to assign observations to buckets.
+func WithClock(clock timeutil.Clock) Option {
+	return func(t *Tracker) {
+		t.clock = clock
+	}
+}
+
+// WithWindow sets the period statistics cover, in whole hours.
+func WithWindow(window time.Duration) Option {
+	return func(t *Tracker) {
+		t.window = window
+	}
+}
+
+// NewTracker creates an empty tracker.
+func NewTracker(opts ...Option) *Tracker {
+	t := &Tracker{
+		clock:   timeutil.NewRealClock(),
+		window:  DefaultWindow,
+		buckets: make(map[string][]bucket),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Window returns the period statistics cover.
+func (t *Tracker) Window() time.Duration {
+	return t.window
+}
+
+// Stats returns the statistics of every observed provider over the window,
+// sorted by provider name.
+func (t *Tracker) Stats() []Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock.Now()
+	result := make([]Stats, 0, len(t.buckets))
+	for provider := range t.buckets {
+		t.prune(provider, now)
+		total := Stats{Provider: provider}
+		for _, b := range t.buckets[provider] {
+			total.add(b.stats)
+		}
+		result = append(result, total)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Provider < result[j].Provider })
+	return result
+}
+
+// record adds an observation to the provider's current bucket.
+func (t *Tracker) record(provider string, stats Stats) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock.Now()
+	start := now.Truncate(bucketSize)
+	buckets := t.buckets[provider]
+	if n := len(buckets); n == 0 || !buckets[n-1].start.Equal(start) {
+		buckets = append(buckets, bucket{start: start})
+	}
+	buckets[len(buckets)-1].stats.add(stats)
+	t.buckets[provider] = buckets
+	t.prune(provider, now)
+}
+
+// prune drops the provider's buckets that started before the window, so the
+// window covers the current hour and the whole hours before it.
+func (t *Tracker) prune(provider string, now time.Time) {
+	buckets := t.buckets[provider]
+	cutoff := now.Add(-t.window)
+	i := 0
+	for i < len(buckets) && !buckets[i].start.After(cutoff) {
+		i++
+	}
+	t.buckets[provider] = buckets[i:]
+}
+
+// trackedProvider records the quality of every search made through the wrapped provider.
+type trackedProvider struct {
+	domain.FlightProvider
+	tracker *Tracker
+}
+
+// Instrument wraps a provider so the quality of its responses is recorded.
+func (t *Tracker) Instrument(p domain.FlightProvider) domain.FlightProvider {
+	return trackedProvider{FlightProvider: p, tracker: t}
+}
+
+// InstrumentAll wraps each provider with Instrument.
+func (t *Tracker) InstrumentAll(providers []domain.FlightProvider) []domain.FlightProvider {
+	wrapped := make([]domain.FlightProvider, len(providers))
+	for i, p := range providers {
+		wrapped[i] = t.Instrument(p)
+	}
+	return wrapped
+}
+
+// Search implements domain.FlightProvider.
+func (p trackedProvider) Search(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	return p.SearchWithOptions(ctx, criteria, domain.ProviderSearchOptions{})
+}
+
+// SearchWithOptions implements domain.FilteringProvider.
+func (p trackedProvider) SearchWithOptions(ctx context.Context, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) ([]domain.Flight, error) {
+	var stats Stats
+	var mu sync.Mutex
+	ctx = domain.WithNormalizationObserver(ctx, func(received, rejected int) {
+		mu.Lock()
+		defer mu.Unlock()
+		stats.Received += received
+		stats.Rejected += rejected
+	})
+
+	flights, err := domain.SearchWithOptions(ctx, p.FlightProvider, criteria, opts)
+
+	mu.Lock()
+	defer mu.Unlock()
+	stats.Searches = 1
+	if errors.Is(err, domain.ErrMalformedResponse) {
+		stats.SchemaDriftIncidents = 1
+	}
+	if err == nil {
+		stats.Flights = len(flights)
+		stats.Duplicates = countDuplicates(flights)
+		stats.PriceAnomalies = countPriceAnomalies(flights)
+	}
+	p.tracker.record(p.Name(), stats)
+	return flights, err
+}
+
+// flightKey identifies a flight within a response.
+type flightKey struct {
+	flightNumber string
+	departure    int64
+}
+
+// countDuplicates counts flights repeating an earlier flight of the response.
+func countDuplicates(flights []domain.Flight) int {
+	seen := make(map[flightKey]bool, len(flights))
+	duplicates := 0
+	for _, f := range flights {
+		key := flightKey{f.FlightNumber, f.Departure.DateTime.Unix()}
+		if seen[key] {
+			duplicates++
+		}
+		seen[key] = true
+	}
+	return duplicates
+}
+
+// countPriceAnomalies counts flights priced more than AnomalyFactor times
+// above or below the median price of the same-currency flights of the response.
+func countPriceAnomalies(flights []domain.Flight) int {
+	byCurrency := make(map[string][]float64)
+	for _, f := range flights {
+		byCurrency[f.Price.Currency] = append(byCurrency[f.Price.Currency], f.Price.Amount)
+	}
+
+	anomalies := 0
+	for _, prices := range byCurrency {
+		if len(prices) < minAnomalySample {
+			continue
+		}
+		median := medianOf(prices)
+		for _, price := range prices {
+			if price > median*AnomalyFactor || price*AnomalyFactor < median {
+				anomalies++
+			}
+		}
+	}
+	return anomalies
+}
+
+// medianOf returns the median of values, reordering them.
+func medianOf(values []float64) float64 {
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
@@ -0,0 +1,121 @@
+package quality
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// stubProvider returns fixed results and reports a fixed normalization outcome.
+type stubProvider struct {
+	name     string
+	flights  []domain.Flight
+	err      error
+	received int
+	rejected int
+}
+
+func (p stubProvider) Name() string { return p.name }
+
+func (p stubProvider) Search(ctx context.Context, _ domain.SearchCriteria) ([]domain.Flight, error) {
+	if p.received > 0 {
+		domain.ReportNormalization(ctx, p.received, p.rejected)
+	}
+	return p.flights, p.err
+}
+
+func flight(number string, hour int, price float64) domain.Flight {
+	return domain.Flight{
+		FlightNumber: number,
+		Departure:    domain.FlightPoint{DateTime: time.Date(2025, 12, 15, hour, 0, 0, 0, time.UTC)},
+		Price:        domain.PriceInfo{Amount: price, Currency: "IDR"},
+	}
+}
+
+func TestTracker_Stats(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 30, 0, 0, time.UTC))
+	tracker := NewTracker(WithClock(clock), WithWindow(2*time.Hour))
+
+	good := tracker.Instrument(stubProvider{
+		name:     "good_air",
+		flights:  []domain.Flight{flight("GA-1", 8, 1000000), flight("GA-2", 9, 1100000), flight("GA-3", 10, 900000)},
+		received: 4,
+		rejected: 1,
+	})
+	messy := tracker.Instrument(stubProvider{
+		name: "messy_air",
+		flights: []domain.Flight{
+			flight("MA-1", 8, 1000000),
+			flight("MA-1", 8, 1000000),
+			flight("MA-2", 9, 1000000),
+			flight("MA-3", 10, 50000),
+		},
+	})
+	drifted := tracker.Instrument(stubProvider{
+		name: "drifted_air",
+		err:  &domain.ProviderError{Provider: "drifted_air", Err: fmt.Errorf("%w: unexpected field type", domain.ErrMalformedResponse)},
+	})
+
+	for _, p := range []domain.FlightProvider{good, good, messy, drifted} {
+		_, _ = p.Search(context.Background(), domain.SearchCriteria{})
+	}
+
+	stats := tracker.Stats()
+	require.Len(t, stats, 3)
+
+	assert.Equal(t, Stats{Provider: "drifted_air", Searches: 1, SchemaDriftIncidents: 1}, stats[0])
+
+	assert.Equal(t, Stats{Provider: "good_air", Searches: 2, Received: 8, Rejected: 2, Flights: 6}, stats[1])
+	assert.InDelta(t, 0.25, stats[1].RejectionRate(), 1e-9)
+
+	assert.Equal(t, "messy_air", stats[2].Provider)
+	assert.Equal(t, 1, stats[2].Duplicates)
+	assert.Equal(t, 1, stats[2].PriceAnomalies)
+	assert.InDelta(t, 0.25, stats[2].DuplicateRate(), 1e-9)
+	assert.InDelta(t, 0.25, stats[2].PriceAnomalyRate(), 1e-9)
+	assert.Zero(t, stats[2].RejectionRate(), "no normalization reported")
+}
+
+func TestTracker_Window(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 30, 0, 0, time.UTC))
+	tracker := NewTracker(WithClock(clock), WithWindow(2*time.Hour))
+	provider := tracker.Instrument(stubProvider{name: "air", flights: []domain.Flight{flight("A-1", 8, 1)}})
+
+	_, _ = provider.Search(context.Background(), domain.SearchCriteria{})
+	clock.Advance(time.Hour)
+	_, _ = provider.Search(context.Background(), domain.SearchCriteria{})
+	assert.Equal(t, 2, tracker.Stats()[0].Searches)
+
+	// The 10:00 bucket leaves the window once it started more than 2h ago
+	clock.Advance(time.Hour)
+	assert.Equal(t, 1, tracker.Stats()[0].Searches)
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, 0, tracker.Stats()[0].Searches)
+	assert.Equal(t, 2*time.Hour, tracker.Window())
+}
+
+func TestCountPriceAnomalies(t *testing.T) {
+	tests := []struct {
+		name    string
+		flights []domain.Flight
+		want    int
+	}{
+		{"too few to judge", []domain.Flight{flight("A", 8, 1), flight("B", 8, 100)}, 0},
+		{"uniform", []domain.Flight{flight("A", 8, 100), flight("B", 8, 120), flight("C", 8, 90)}, 0},
+		{"too cheap and too expensive", []domain.Flight{flight("A", 8, 100), flight("B", 8, 110), flight("C", 8, 10), flight("D", 8, 400), flight("E", 8, 105)}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, countPriceAnomalies(tt.flights))
+		})
+	}
+}
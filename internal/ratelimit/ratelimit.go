@@ -0,0 +1,257 @@
+// Package ratelimit keeps the searches sent to each provider under the rate
+// its API allows.
+//
+// Each rate limited provider gets a token bucket: tokens accrue at the
+// provider's rate, up to its burst, and every search sent to the provider,
+// retries and health checks included, takes one. A search finding the bucket
+// empty waits for its token, or fails at once with ErrRateLimited if its
+// context would expire first, so a spike of inbound searches queues up in
+// front of a provider instead of breaching its QPS cap.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// ErrRateLimited is returned for searches rejected because the provider's
+// rate limit would not let them through before their deadline.
+var ErrRateLimited = errors.New("provider rate limit exceeded")
+
+// Limit is the rate limit of a provider.
+type Limit struct {
+	// Rate is the number of searches per second (0 = unlimited)
+	Rate float64
+
+	// Burst is the number of searches that may be sent at once after the
+	// provider was idle
+	Burst int
+}
+
+// validate reports whether the limit is usable.
+func (l Limit) validate() error {
+	if l.Rate < 0 {
+		return fmt.Errorf("rate must not be negative")
+	}
+	if l.Rate > 0 && l.Burst < 1 {
+		return fmt.Errorf("burst must be at least 1")
+	}
+	return nil
+}
+
+// Bucket is a token bucket. It is safe for concurrent use.
+type Bucket struct {
+	limit Limit
+	clock timeutil.Clock
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newBucket creates a full bucket.
+func newBucket(limit Limit, clock timeutil.Clock, sleep func(context.Context, time.Duration) error) *Bucket {
+	return &Bucket{
+		limit:  limit,
+		clock:  clock,
+		sleep:  sleep,
+		tokens: float64(limit.Burst),
+		last:   clock.Now(),
+	}
+}
+
+// Wait takes a token, waiting for one if the bucket is empty. It returns
+// ErrRateLimited without waiting if ctx's deadline comes before the token,
+// and ctx's error if ctx is done while waiting.
+func (b *Bucket) Wait(ctx context.Context) error {
+	delay, ok := b.reserve(ctx)
+	if !ok {
+		return ErrRateLimited
+	}
+	if delay <= 0 {
+		return nil
+	}
+	if err := b.sleep(ctx, delay); err != nil {
+		b.cancel()
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token, which may not have accrued yet, and returns how long
+// to wait for it. It takes nothing and returns false if ctx's deadline comes
+// first.
+func (b *Bucket) reserve(ctx context.Context) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(float64(b.limit.Burst), b.tokens+elapsed.Seconds()*b.limit.Rate)
+		b.last = now
+	}
+
+	var delay time.Duration
+	if b.tokens < 1 {
+		delay = time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+	}
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		return 0, false
+	}
+	b.tokens--
+	return delay, true
+}
+
+// cancel gives back the token of a search that gave up waiting for it.
+func (b *Bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(float64(b.limit.Burst), b.tokens+1)
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Set holds one bucket per rate limited provider.
+type Set struct {
+	limit    Limit
+	limits   map[string]Limit
+	clock    timeutil.Clock
+	onReject func(provider string)
+
+	mu      sync.Mutex
+	buckets map[string]*Bucket
+}
+
+// Option configures a Set.
+type Option func(*Set)
+
+// WithLimits overrides the limit of the named providers.
+func WithLimits(limits map[string]Limit) Option {
+	return func(s *Set) {
+		s.limits = limits
+	}
+}
+
+// WithClock sets the clock used to accrue tokens.
+func WithClock(clock timeutil.Clock) Option {
+	return func(s *Set) {
+		s.clock = clock
+	}
+}
+
+// WithRejectionObserver sets a function notified of each search rejected
+// with ErrRateLimited.
+func WithRejectionObserver(fn func(provider string)) Option {
+	return func(s *Set) {
+		s.onReject = fn
+	}
+}
+
+// NewSet creates a set limiting each provider to limit, unless overridden.
+func NewSet(limit Limit, opts ...Option) (*Set, error) {
+	s := &Set{limit: limit, clock: timeutil.NewRealClock(), buckets: make(map[string]*Bucket)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if err := limit.validate(); err != nil {
+		return nil, err
+	}
+	for provider, l := range s.limits {
+		if err := l.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", provider, err)
+		}
+	}
+	return s, nil
+}
+
+// Limit returns the named provider's limit.
+func (s *Set) Limit(provider string) Limit {
+	if l, ok := s.limits[provider]; ok {
+		return l
+	}
+	return s.limit
+}
+
+// Bucket returns the named provider's bucket, creating it on first use, or
+// nil if the provider is not rate limited.
+func (s *Set) Bucket(provider string) *Bucket {
+	limit := s.Limit(provider)
+	if limit.Rate == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if b, ok := s.buckets[provider]; ok {
+		return b
+	}
+	b := newBucket(limit, s.clock, sleep)
+	s.buckets[provider] = b
+	return b
+}
+
+// limitedProvider takes a token of its bucket for every search of the
+// wrapped provider.
+type limitedProvider struct {
+	domain.FlightProvider
+	bucket   *Bucket
+	onReject func(provider string)
+}
+
+// Instrument wraps a provider so its searches are rate limited. Providers
+// wrapped more than once share their bucket. Providers without a limit are
+// returned as is.
+func (s *Set) Instrument(p domain.FlightProvider) domain.FlightProvider {
+	bucket := s.Bucket(p.Name())
+	if bucket == nil {
+		return p
+	}
+	return limitedProvider{FlightProvider: p, bucket: bucket, onReject: s.onReject}
+}
+
+// InstrumentAll wraps each provider with Instrument.
+func (s *Set) InstrumentAll(providers []domain.FlightProvider) []domain.FlightProvider {
+	wrapped := make([]domain.FlightProvider, len(providers))
+	for i, p := range providers {
+		wrapped[i] = s.Instrument(p)
+	}
+	return wrapped
+}
+
+// Search implements domain.FlightProvider. Rejected searches fail with a
+// non-retryable ProviderError wrapping ErrRateLimited: retrying would only
+// wait for a token again.
+func (p limitedProvider) Search(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	return p.SearchWithOptions(ctx, criteria, domain.ProviderSearchOptions{})
+}
+
+// SearchWithOptions implements domain.FilteringProvider, like Search.
+func (p limitedProvider) SearchWithOptions(ctx context.Context, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) ([]domain.Flight, error) {
+	if err := p.bucket.Wait(ctx); err != nil {
+		if errors.Is(err, ErrRateLimited) {
+			if p.onReject != nil {
+				p.onReject(p.Name())
+			}
+			return nil, domain.NewProviderError(p.Name(), err)
+		}
+		return nil, err
+	}
+	return domain.SearchWithOptions(ctx, p.FlightProvider, criteria, opts)
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// countingProvider counts its searches.
+type countingProvider struct {
+	name     string
+	searches int
+}
+
+func (p *countingProvider) Name() string { return p.name }
+
+func (p *countingProvider) Search(context.Context, domain.SearchCriteria) ([]domain.Flight, error) {
+	p.searches++
+	return []domain.Flight{{ID: "GA400"}}, nil
+}
+
+// testBucket returns a bucket on clock whose waits advance the clock
+// instead of sleeping, recording them.
+func testBucket(limit Limit, clock *timeutil.MockClock, waits *[]time.Duration) *Bucket {
+	return newBucket(limit, clock, func(ctx context.Context, d time.Duration) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		*waits = append(*waits, d)
+		clock.Advance(d)
+		return nil
+	})
+}
+
+func TestBucket_Wait(t *testing.T) {
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	var waits []time.Duration
+	bucket := testBucket(Limit{Rate: 2, Burst: 2}, clock, &waits)
+	ctx := context.Background()
+
+	require.NoError(t, bucket.Wait(ctx))
+	require.NoError(t, bucket.Wait(ctx))
+	assert.Empty(t, waits, "the burst is sent at once")
+
+	require.NoError(t, bucket.Wait(ctx))
+	require.NoError(t, bucket.Wait(ctx))
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, 500 * time.Millisecond}, waits,
+		"further searches are spaced at the rate")
+
+	clock.Advance(time.Hour)
+	waits = nil
+	for range 3 {
+		require.NoError(t, bucket.Wait(ctx))
+	}
+	assert.Equal(t, []time.Duration{500 * time.Millisecond}, waits, "tokens accrue up to the burst")
+}
+
+func TestBucket_RejectsPastDeadline(t *testing.T) {
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	var waits []time.Duration
+	bucket := testBucket(Limit{Rate: 1, Burst: 1}, clock, &waits)
+	require.NoError(t, bucket.Wait(context.Background()))
+
+	ctx, cancel := context.WithDeadline(context.Background(), clock.Now().Add(500*time.Millisecond))
+	defer cancel()
+	assert.ErrorIs(t, bucket.Wait(ctx), ErrRateLimited)
+	assert.Empty(t, waits, "rejected searches do not wait")
+
+	require.NoError(t, bucket.Wait(context.Background()))
+	assert.Equal(t, []time.Duration{time.Second}, waits, "rejected searches take no token")
+}
+
+func TestBucket_CancelledWaitGivesTokenBack(t *testing.T) {
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	var waits []time.Duration
+	bucket := testBucket(Limit{Rate: 1, Burst: 1}, clock, &waits)
+	require.NoError(t, bucket.Wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, bucket.Wait(ctx), context.Canceled)
+
+	require.NoError(t, bucket.Wait(context.Background()))
+	assert.Equal(t, []time.Duration{time.Second}, waits)
+}
+
+func TestNewSet_Validation(t *testing.T) {
+	_, err := NewSet(Limit{Rate: -1})
+	assert.Error(t, err)
+
+	_, err = NewSet(Limit{Rate: 1, Burst: 0})
+	assert.Error(t, err)
+
+	_, err = NewSet(Limit{}, WithLimits(map[string]Limit{"lion_air": {Rate: 5}}))
+	assert.ErrorContains(t, err, "lion_air")
+}
+
+func TestSet_Instrument(t *testing.T) {
+	var rejected []string
+	set, err := NewSet(Limit{},
+		WithLimits(map[string]Limit{"lion_air": {Rate: 1, Burst: 1}}),
+		WithRejectionObserver(func(provider string) { rejected = append(rejected, provider) }),
+	)
+	require.NoError(t, err)
+
+	limited := &countingProvider{name: "lion_air"}
+	unlimited := &countingProvider{name: "garuda_indonesia"}
+	providers := set.InstrumentAll([]domain.FlightProvider{limited, unlimited})
+	assert.Same(t, unlimited, providers[1], "providers without a limit are not wrapped")
+	assert.Nil(t, set.Bucket("garuda_indonesia"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	flights, err := providers[0].Search(ctx, domain.SearchCriteria{})
+	require.NoError(t, err)
+	assert.Len(t, flights, 1)
+
+	_, err = providers[0].Search(ctx, domain.SearchCriteria{})
+	assert.ErrorIs(t, err, ErrRateLimited)
+	var providerErr *domain.ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, "lion_air", providerErr.Provider)
+	assert.False(t, providerErr.Retryable)
+	assert.Equal(t, 1, limited.searches)
+	assert.Equal(t, []string{"lion_air"}, rejected)
+
+	// Providers wrapped again, e.g. for health checks, share the bucket
+	_, err = set.Instrument(limited).Search(ctx, domain.SearchCriteria{})
+	assert.ErrorIs(t, err, ErrRateLimited)
+
+	for range 5 {
+		_, err := providers[1].Search(ctx, domain.SearchCriteria{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 5, unlimited.searches)
+}
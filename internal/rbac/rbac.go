@@ -0,0 +1,110 @@
+// Package rbac implements role-based access control for API operations.
+//
+// Every request is made by a principal: a partner identified by its API
+// key, an admin token holder, or an anonymous caller. Principals hold roles,
+// and each route group requires one role. Partner roles come from the API
+// key file, falling back to configured defaults; admin and debug are only
+// granted by admin tokens.
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Role is a permission to use a group of API operations.
+type Role string
+
+// Available roles.
+const (
+	// RoleSearch allows flight searches
+	RoleSearch Role = "search"
+
+	// RoleExport allows downloading analytics exports
+	RoleExport Role = "export"
+
+	// RoleAdmin allows using the admin API
+	RoleAdmin Role = "admin"
+
+	// RoleDebug allows debug options that expose provider internals
+	RoleDebug Role = "debug"
+)
+
+// IsValid checks if the role is a valid value.
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleSearch, RoleExport, RoleAdmin, RoleDebug:
+		return true
+	}
+	return false
+}
+
+// IsPartnerRole reports whether the role can be granted to API keys.
+func (r Role) IsPartnerRole() bool {
+	return r == RoleSearch || r == RoleExport
+}
+
+// ParseRoles parses a comma-separated role list. An empty string yields no roles.
+func ParseRoles(s string) ([]Role, error) {
+	var roles []Role
+	for _, part := range strings.Split(s, ",") {
+		role := Role(strings.TrimSpace(part))
+		if role == "" {
+			continue
+		}
+		if !role.IsValid() {
+			return nil, fmt.Errorf("invalid role %q", role)
+		}
+		if !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+// Policy holds the roles of partners that do not have roles of their own.
+type Policy struct {
+	// Anonymous are the roles of callers without a known API key
+	Anonymous []Role
+
+	// Keys are the roles of API keys that do not list their own
+	Keys []Role
+}
+
+// Anonymous is the principal name of callers without credentials.
+const Anonymous = "anonymous"
+
+// Principal is the caller of a request.
+type Principal struct {
+	// Name identifies the caller in logs and denial reasons
+	Name string
+
+	// Roles are the roles granted to the caller
+	Roles []Role
+}
+
+// Has reports whether the principal holds role.
+func (p Principal) Has(role Role) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// IsAnonymous reports whether the caller presented no credentials.
+func (p Principal) IsAnonymous() bool {
+	return p.Name == Anonymous
+}
+
+// principalKey is the context key for the request's principal.
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal carried by ctx, if any.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,55 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Role
+		wantErr string
+	}{
+		{name: "empty", input: "", want: nil},
+		{name: "single", input: "search", want: []Role{RoleSearch}},
+		{name: "list with spaces and duplicates", input: "search, export,search", want: []Role{RoleSearch, RoleExport}},
+		{name: "all roles", input: "search,export,admin,debug", want: []Role{RoleSearch, RoleExport, RoleAdmin, RoleDebug}},
+		{name: "unknown role", input: "search,superuser", wantErr: `invalid role "superuser"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRoles(tt.input)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRole_IsPartnerRole(t *testing.T) {
+	assert.True(t, RoleSearch.IsPartnerRole())
+	assert.True(t, RoleExport.IsPartnerRole())
+	assert.False(t, RoleAdmin.IsPartnerRole())
+	assert.False(t, RoleDebug.IsPartnerRole())
+}
+
+func TestPrincipalContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithPrincipal(context.Background(), Principal{Name: "partner", Roles: []Role{RoleSearch}})
+	p, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.True(t, p.Has(RoleSearch))
+	assert.False(t, p.Has(RoleExport))
+	assert.False(t, p.IsAnonymous())
+	assert.True(t, Principal{Name: Anonymous}.IsAnonymous())
+}
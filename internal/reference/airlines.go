@@ -0,0 +1,76 @@
+package reference
+
+import (
+	_ "embed"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Airline alliances.
+const (
+	AllianceOneworld     = "oneworld"
+	AllianceSkyTeam      = "SkyTeam"
+	AllianceStarAlliance = "Star Alliance"
+)
+
+// Airline contains reference information about an airline.
+type Airline struct {
+	// Code is the IATA airline code (e.g., "GA")
+	Code string `json:"code"`
+
+	// ICAO is the ICAO airline code (e.g., "GIA")
+	ICAO string `json:"icao,omitempty"`
+
+	// Name is the airline display name in each supported locale
+	Name LocalizedName `json:"name"`
+
+	// Alliance is the airline alliance the airline belongs to, if any
+	// (e.g., AllianceSkyTeam)
+	Alliance string `json:"alliance,omitempty"`
+
+	// Logo is the URL of the airline's logo image, if known
+	Logo string `json:"logo,omitempty"`
+}
+
+// airlinesData is the dataset of the airlines served by the integrated
+// providers, refreshed with cmd/refdata.
+//
+//go:embed airlines.json
+var airlinesData []byte
+
+// airlines is the translation table for airlines served by the integrated providers.
+var airlines = func() map[string]Airline {
+	list, err := ParseAirlines(airlinesData)
+	if err != nil {
+		panic(fmt.Sprintf("reference: invalid embedded airlines: %v", err))
+	}
+	index := make(map[string]Airline, len(list))
+	for _, airline := range list {
+		index[airline.Code] = airline
+	}
+	return index
+}()
+
+// LookupAirline returns the reference data for an airline code (case-insensitive).
+func LookupAirline(code string) (Airline, bool) {
+	airline, ok := airlines[strings.ToUpper(code)]
+	return airline, ok
+}
+
+// Airlines returns the reference data of every airline, sorted by code.
+func Airlines() []Airline {
+	return slices.SortedFunc(maps.Values(airlines), func(a, b Airline) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+}
+
+// AirlineName returns the localized airline name, or an empty string if unknown.
+func AirlineName(code string, loc Locale) string {
+	airline, ok := LookupAirline(code)
+	if !ok {
+		return ""
+	}
+	return airline.Name.In(loc)
+}
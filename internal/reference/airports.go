@@ -0,0 +1,212 @@
+package reference
+
+import (
+	"cmp"
+	_ "embed"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Airport contains reference information about an airport.
+type Airport struct {
+	// Code is the IATA airport code (e.g., "CGK")
+	Code string `json:"code"`
+
+	// ICAO is the ICAO airport code (e.g., "WIII")
+	ICAO string `json:"icao"`
+
+	// Name is the airport name in each supported locale
+	Name LocalizedName `json:"name"`
+
+	// City is the served city name in each supported locale
+	City LocalizedName `json:"city"`
+
+	// Country is the ISO 3166-1 alpha-2 code of the airport's country (e.g., "ID")
+	Country string `json:"country"`
+
+	// Lat and Lon are the airport's coordinates in decimal degrees
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+
+	// Timezone is the IANA timezone of the airport (e.g., "Asia/Jakarta")
+	Timezone string `json:"timezone"`
+}
+
+// airportsData is the dataset of the airports served by the integrated
+// providers, refreshed with cmd/refdata.
+//
+//go:embed airports.json
+var airportsData []byte
+
+// airports is the translation table for airports served by the integrated providers.
+var airports = func() map[string]Airport {
+	list, err := ParseAirports(airportsData)
+	if err != nil {
+		panic(fmt.Sprintf("reference: invalid embedded airports: %v", err))
+	}
+	index := make(map[string]Airport, len(list))
+	for _, airport := range list {
+		index[airport.Code] = airport
+	}
+	return index
+}()
+
+// airportsByICAO indexes the airports by ICAO code.
+var airportsByICAO = func() map[string]string {
+	index := make(map[string]string, len(airports))
+	for code, airport := range airports {
+		index[airport.ICAO] = code
+	}
+	return index
+}()
+
+// LookupAirport returns the reference data for an airport code (case-insensitive).
+func LookupAirport(code string) (Airport, bool) {
+	airport, ok := airports[strings.ToUpper(code)]
+	return airport, ok
+}
+
+// LookupAirportByICAO returns the reference data for an ICAO airport code
+// (case-insensitive).
+func LookupAirportByICAO(icao string) (Airport, bool) {
+	code, ok := airportsByICAO[strings.ToUpper(icao)]
+	if !ok {
+		return Airport{}, false
+	}
+	return airports[code], true
+}
+
+// Match ranks of SearchAirports, best first.
+const (
+	matchCode = iota
+	matchCodePrefix
+	matchCity
+	matchName
+	noMatch
+)
+
+// SearchAirports returns up to limit airports matching query, for
+// autocomplete: airports whose IATA code, served city or name in any locale
+// starts with the query, or has a word starting with it, compared
+// case-insensitively. The exact code comes first, then code prefixes, city
+// matches and name matches, each by code. An empty query matches nothing.
+func SearchAirports(query string, limit int) []Airport {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" || limit <= 0 {
+		return nil
+	}
+
+	type match struct {
+		airport Airport
+		rank    int
+	}
+	var matches []match
+	for _, airport := range airports {
+		if rank := airportMatch(airport, query); rank != noMatch {
+			matches = append(matches, match{airport: airport, rank: rank})
+		}
+	}
+	slices.SortFunc(matches, func(a, b match) int {
+		return cmp.Or(cmp.Compare(a.rank, b.rank), strings.Compare(a.airport.Code, b.airport.Code))
+	})
+
+	result := make([]Airport, 0, min(limit, len(matches)))
+	for _, m := range matches[:min(limit, len(matches))] {
+		result = append(result, m.airport)
+	}
+	return result
+}
+
+// airportMatch returns how well airport matches a lowercase query.
+func airportMatch(airport Airport, query string) int {
+	code := strings.ToLower(airport.Code)
+	switch {
+	case code == query:
+		return matchCode
+	case strings.HasPrefix(code, query):
+		return matchCodePrefix
+	case namesMatch(airport.City, query):
+		return matchCity
+	case namesMatch(airport.Name, query):
+		return matchName
+	}
+	return noMatch
+}
+
+// namesMatch reports whether a name in any locale, or one of its words, starts
+// with a lowercase query.
+func namesMatch(names LocalizedName, query string) bool {
+	for _, name := range names {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, query) {
+			return true
+		}
+		for _, word := range strings.Fields(name) {
+			if strings.HasPrefix(word, query) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AirportTimezone returns the IANA timezone of an airport, or an empty string if unknown.
+func AirportTimezone(code string) string {
+	airport, ok := LookupAirport(code)
+	if !ok {
+		return ""
+	}
+	return airport.Timezone
+}
+
+// AirportName returns the localized airport name, or an empty string if unknown.
+func AirportName(code string, loc Locale) string {
+	airport, ok := LookupAirport(code)
+	if !ok {
+		return ""
+	}
+	return airport.Name.In(loc)
+}
+
+// CityName returns the localized city name for an airport, or an empty string if unknown.
+func CityName(code string, loc Locale) string {
+	airport, ok := LookupAirport(code)
+	if !ok {
+		return ""
+	}
+	return airport.City.In(loc)
+}
+
+// AirportCountry returns the ISO 3166-1 alpha-2 country code of an airport,
+// or an empty string if unknown.
+func AirportCountry(code string) string {
+	airport, ok := LookupAirport(code)
+	if !ok {
+		return ""
+	}
+	return airport.Country
+}
+
+// DistanceKm returns the great-circle distance between two airports, and false
+// if either airport is unknown.
+func DistanceKm(from, to string) (float64, bool) {
+	a, ok := LookupAirport(from)
+	if !ok {
+		return 0, false
+	}
+	b, ok := LookupAirport(to)
+	if !ok {
+		return 0, false
+	}
+
+	const earthRadiusKm = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(b.Lat - a.Lat)
+	dLon := toRad(b.Lon - a.Lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(a.Lat))*math.Cos(toRad(b.Lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h)), true
+}
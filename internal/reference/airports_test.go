@@ -0,0 +1,121 @@
+package reference
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupAirport(t *testing.T) {
+	airport, ok := LookupAirport("cgk")
+	assert.True(t, ok)
+	assert.Equal(t, "CGK", airport.Code)
+
+	_, ok = LookupAirport("XXX")
+	assert.False(t, ok)
+}
+
+func TestLookupAirportByICAO(t *testing.T) {
+	airport, ok := LookupAirportByICAO("wadd")
+	assert.True(t, ok)
+	assert.Equal(t, "DPS", airport.Code)
+
+	_, ok = LookupAirportByICAO("CGK")
+	assert.False(t, ok, "IATA codes are not ICAO codes")
+	_, ok = LookupAirportByICAO("")
+	assert.False(t, ok)
+}
+
+func TestDistanceKm(t *testing.T) {
+	km, ok := DistanceKm("CGK", "dps")
+	assert.True(t, ok)
+	assert.InDelta(t, 983, km, 5)
+
+	km, ok = DistanceKm("SIN", "SIN")
+	assert.True(t, ok)
+	assert.Zero(t, km)
+
+	_, ok = DistanceKm("CGK", "XXX")
+	assert.False(t, ok)
+}
+
+func TestSearchAirports(t *testing.T) {
+	codes := func(list []Airport) []string {
+		result := make([]string, len(list))
+		for i, airport := range list {
+			result[i] = airport.Code
+		}
+		return result
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{name: "exact code first", query: "sub", limit: 10, want: []string{"SUB"}},
+		{name: "code prefix before names", query: "so", limit: 10, want: []string{"SOC", "CGK"}},
+		{name: "city", query: "Jakarta", limit: 10, want: []string{"CGK"}},
+		{name: "indonesian city", query: "singapura", limit: 10, want: []string{"SIN"}},
+		{name: "name word", query: "ngurah", limit: 10, want: []string{"DPS"}},
+		{name: "limit", query: "s", limit: 2, want: []string{"SIN", "SOC"}},
+		{name: "empty query", query: " ", limit: 10, want: []string{}},
+		{name: "no match", query: "zzz", limit: 10, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, codes(SearchAirports(tt.query, tt.limit)))
+		})
+	}
+}
+
+func TestAirportTimezone(t *testing.T) {
+	assert.Equal(t, "Asia/Jakarta", AirportTimezone("cgk"))
+	assert.Equal(t, "Asia/Makassar", AirportTimezone("DPS"))
+	assert.Empty(t, AirportTimezone("XXX"))
+}
+
+func TestAirportCountry(t *testing.T) {
+	assert.Equal(t, "ID", AirportCountry("cgk"))
+	assert.Equal(t, "SG", AirportCountry("SIN"))
+	assert.Empty(t, AirportCountry("XXX"))
+}
+
+func TestAirportName(t *testing.T) {
+	assert.Equal(t, "Soekarno-Hatta International Airport", AirportName("CGK", LocaleEnglish))
+	assert.Equal(t, "Bandar Udara Internasional Soekarno-Hatta", AirportName("CGK", LocaleIndonesian))
+	assert.Empty(t, AirportName("XXX", LocaleEnglish))
+}
+
+func TestCityName(t *testing.T) {
+	assert.Equal(t, "Singapore", CityName("SIN", LocaleEnglish))
+	assert.Equal(t, "Singapura", CityName("SIN", LocaleIndonesian))
+	assert.Empty(t, CityName("XXX", LocaleIndonesian))
+}
+
+func TestAirlineName(t *testing.T) {
+	assert.Equal(t, "Indonesia AirAsia", AirlineName("QZ", LocaleEnglish))
+	assert.Equal(t, "AirAsia Indonesia", AirlineName("qz", LocaleIndonesian))
+	assert.Empty(t, AirlineName("XX", LocaleEnglish))
+}
+
+func TestLookupAirline(t *testing.T) {
+	airline, ok := LookupAirline("ga")
+	assert.True(t, ok)
+	assert.Equal(t, AllianceSkyTeam, airline.Alliance)
+	assert.Equal(t, "https://pics.avs.io/200/200/GA.png", airline.Logo)
+
+	airline, ok = LookupAirline("JT")
+	assert.True(t, ok)
+	assert.Empty(t, airline.Alliance, "not in an alliance")
+}
+
+func TestAirlines(t *testing.T) {
+	list := Airlines()
+	assert.Len(t, list, len(airlines))
+	assert.True(t, slices.IsSortedFunc(list, func(a, b Airline) int { return strings.Compare(a.Code, b.Code) }))
+}
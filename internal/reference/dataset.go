@@ -0,0 +1,183 @@
+package reference
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Dataset file names, relative to the package directory.
+const (
+	AirportsFile = "airports.json"
+	AirlinesFile = "airlines.json"
+)
+
+// ErrInvalidDataset indicates a dataset does not match its schema.
+var ErrInvalidDataset = errors.New("invalid reference dataset")
+
+// Code formats accepted in the datasets.
+var (
+	iataAirportPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	icaoAirportPattern = regexp.MustCompile(`^[A-Z]{4}$`)
+	iataAirlinePattern = regexp.MustCompile(`^[A-Z0-9]{2}$`)
+	icaoAirlinePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	countryPattern     = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// alliances are the airline alliances accepted in the airlines dataset.
+var alliances = []string{AllianceOneworld, AllianceSkyTeam, AllianceStarAlliance}
+
+// airportsFile is the format of the airports dataset.
+type airportsFile struct {
+	Airports []Airport `json:"airports"`
+}
+
+// airlinesFile is the format of the airlines dataset.
+type airlinesFile struct {
+	Airlines []Airline `json:"airlines"`
+}
+
+// ParseAirports parses and validates an airports dataset.
+func ParseAirports(data []byte) ([]Airport, error) {
+	var file airportsFile
+	if err := decodeStrict(data, &file); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(file.Airports))
+	for i, airport := range file.Airports {
+		if err := airport.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: airports[%d]: %w", ErrInvalidDataset, i, err)
+		}
+		if seen[airport.Code] {
+			return nil, fmt.Errorf("%w: airports[%d]: duplicate code %s", ErrInvalidDataset, i, airport.Code)
+		}
+		seen[airport.Code] = true
+	}
+	return file.Airports, nil
+}
+
+// ParseAirlines parses and validates an airlines dataset.
+func ParseAirlines(data []byte) ([]Airline, error) {
+	var file airlinesFile
+	if err := decodeStrict(data, &file); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(file.Airlines))
+	for i, airline := range file.Airlines {
+		if err := airline.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: airlines[%d]: %w", ErrInvalidDataset, i, err)
+		}
+		if seen[airline.Code] {
+			return nil, fmt.Errorf("%w: airlines[%d]: duplicate code %s", ErrInvalidDataset, i, airline.Code)
+		}
+		seen[airline.Code] = true
+	}
+	return file.Airlines, nil
+}
+
+// MarshalAirports encodes airports as a dataset, sorted by code.
+func MarshalAirports(airports []Airport) ([]byte, error) {
+	sorted := slices.SortedFunc(slices.Values(airports), func(a, b Airport) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return marshalIndent(airportsFile{Airports: sorted})
+}
+
+// MarshalAirlines encodes airlines as a dataset, sorted by code.
+func MarshalAirlines(airlines []Airline) ([]byte, error) {
+	sorted := slices.SortedFunc(slices.Values(airlines), func(a, b Airline) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return marshalIndent(airlinesFile{Airlines: sorted})
+}
+
+// Validate checks the airport against the dataset schema.
+func (a Airport) Validate() error {
+	if !iataAirportPattern.MatchString(a.Code) {
+		return fmt.Errorf("code %q must be a 3-letter IATA code", a.Code)
+	}
+	if !icaoAirportPattern.MatchString(a.ICAO) {
+		return fmt.Errorf("%s: icao %q must be a 4-letter ICAO code", a.Code, a.ICAO)
+	}
+	if a.Name[LocaleEnglish] == "" {
+		return fmt.Errorf("%s: name.en is required", a.Code)
+	}
+	if a.City[LocaleEnglish] == "" {
+		return fmt.Errorf("%s: city.en is required", a.Code)
+	}
+	if !countryPattern.MatchString(a.Country) {
+		return fmt.Errorf("%s: country %q must be a 2-letter ISO 3166-1 code", a.Code, a.Country)
+	}
+	if a.Lat < -90 || a.Lat > 90 || a.Lon < -180 || a.Lon > 180 {
+		return fmt.Errorf("%s: coordinates %v,%v out of range", a.Code, a.Lat, a.Lon)
+	}
+	if a.Timezone == "" {
+		return fmt.Errorf("%s: timezone is required", a.Code)
+	}
+	if _, err := time.LoadLocation(a.Timezone); err != nil {
+		return fmt.Errorf("%s: unknown timezone %q", a.Code, a.Timezone)
+	}
+	return validateLocales(a.Code, a.Name, a.City)
+}
+
+// Validate checks the airline against the dataset schema.
+func (a Airline) Validate() error {
+	if !iataAirlinePattern.MatchString(a.Code) {
+		return fmt.Errorf("code %q must be a 2-character IATA code", a.Code)
+	}
+	if a.ICAO != "" && !icaoAirlinePattern.MatchString(a.ICAO) {
+		return fmt.Errorf("%s: icao %q must be a 3-letter ICAO code", a.Code, a.ICAO)
+	}
+	if a.Name[LocaleEnglish] == "" {
+		return fmt.Errorf("%s: name.en is required", a.Code)
+	}
+	if a.Alliance != "" && !slices.Contains(alliances, a.Alliance) {
+		return fmt.Errorf("%s: alliance %q must be one of: %s", a.Code, a.Alliance, strings.Join(alliances, ", "))
+	}
+	if a.Logo != "" {
+		if u, err := url.Parse(a.Logo); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("%s: logo %q must be an https URL", a.Code, a.Logo)
+		}
+	}
+	return validateLocales(a.Code, a.Name)
+}
+
+// validateLocales rejects names in unsupported locales.
+func validateLocales(code string, names ...LocalizedName) error {
+	for _, name := range names {
+		for loc := range name {
+			if !loc.IsValid() {
+				return fmt.Errorf("%s: unsupported locale %q", code, loc)
+			}
+		}
+	}
+	return nil
+}
+
+// decodeStrict decodes a dataset, rejecting unknown fields.
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidDataset, err)
+	}
+	return nil
+}
+
+// marshalIndent encodes a dataset the way it is stored in the repository.
+func marshalIndent(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,102 @@
+package reference
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedDatasets_RoundTrip(t *testing.T) {
+	airportList, err := ParseAirports(airportsData)
+	require.NoError(t, err)
+	data, err := MarshalAirports(airportList)
+	require.NoError(t, err)
+	assert.Equal(t, string(airportsData), string(data), "airports.json is stored as MarshalAirports writes it")
+
+	airlineList, err := ParseAirlines(airlinesData)
+	require.NoError(t, err)
+	data, err = MarshalAirlines(airlineList)
+	require.NoError(t, err)
+	assert.Equal(t, string(airlinesData), string(data), "airlines.json is stored as MarshalAirlines writes it")
+}
+
+func TestParseAirports_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "not json", data: `[`, want: "invalid reference dataset"},
+		{name: "unknown field", data: `{"airports": [{"code": "CGK", "iata": "CGK"}]}`, want: `unknown field "iata"`},
+		{name: "bad code", data: `{"airports": [{"code": "CG"}]}`, want: "3-letter IATA"},
+		{name: "bad icao", data: `{"airports": [{"code": "CGK", "icao": "WII"}]}`, want: "4-letter ICAO"},
+		{
+			name: "missing name",
+			data: `{"airports": [{"code": "CGK", "icao": "WIII", "city": {"en": "Jakarta"}, "country": "ID", "timezone": "Asia/Jakarta"}]}`,
+			want: "name.en is required",
+		},
+		{
+			name: "bad country",
+			data: `{"airports": [{"code": "CGK", "icao": "WIII", "name": {"en": "A"}, "city": {"en": "B"}, "country": "IDN", "timezone": "Asia/Jakarta"}]}`,
+			want: "2-letter ISO 3166-1",
+		},
+		{
+			name: "coordinates out of range",
+			data: `{"airports": [{"code": "CGK", "icao": "WIII", "name": {"en": "A"}, "city": {"en": "B"}, "country": "ID", "lat": 91, "timezone": "Asia/Jakarta"}]}`,
+			want: "out of range",
+		},
+		{
+			name: "unknown timezone",
+			data: `{"airports": [{"code": "CGK", "icao": "WIII", "name": {"en": "A"}, "city": {"en": "B"}, "country": "ID", "timezone": "Asia/Nowhere"}]}`,
+			want: "unknown timezone",
+		},
+		{
+			name: "unsupported locale",
+			data: `{"airports": [{"code": "CGK", "icao": "WIII", "name": {"en": "A", "fr": "B"}, "city": {"en": "C"}, "country": "ID", "timezone": "Asia/Jakarta"}]}`,
+			want: `unsupported locale "fr"`,
+		},
+		{
+			name: "duplicate",
+			data: `{"airports": [
+				{"code": "CGK", "icao": "WIII", "name": {"en": "A"}, "city": {"en": "B"}, "country": "ID", "timezone": "Asia/Jakarta"},
+				{"code": "CGK", "icao": "WIII", "name": {"en": "A"}, "city": {"en": "B"}, "country": "ID", "timezone": "Asia/Jakarta"}
+			]}`,
+			want: "duplicate code CGK",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAirports([]byte(tt.data))
+			require.ErrorIs(t, err, ErrInvalidDataset)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestParseAirlines_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "bad code", data: `{"airlines": [{"code": "GIA", "name": {"en": "A"}}]}`, want: "2-character IATA"},
+		{name: "bad icao", data: `{"airlines": [{"code": "GA", "icao": "GI", "name": {"en": "A"}}]}`, want: "3-letter ICAO"},
+		{name: "missing name", data: `{"airlines": [{"code": "GA"}]}`, want: "name.en is required"},
+		{name: "unknown alliance", data: `{"airlines": [{"code": "GA", "name": {"en": "A"}, "alliance": "Skyteam"}]}`, want: "alliance"},
+		{name: "bad logo", data: `{"airlines": [{"code": "GA", "name": {"en": "A"}, "logo": "ga.png"}]}`, want: "https URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAirlines([]byte(tt.data))
+			require.ErrorIs(t, err, ErrInvalidDataset)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+
+	airlineList, err := ParseAirlines([]byte(`{"airlines": [{"code": "XY", "name": {"en": "Charter"}}]}`))
+	require.NoError(t, err, "icao is optional")
+	assert.Len(t, airlineList, 1)
+}
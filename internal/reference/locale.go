@@ -0,0 +1,76 @@
+// Package reference contains static reference data (airports, airlines) used to
+// enrich and localize flight results independently of the provider that returned them.
+package reference
+
+import "strings"
+
+// Locale identifies the language used for display names in API responses.
+type Locale string
+
+// Supported locales.
+const (
+	// LocaleEnglish returns display names in English (default)
+	LocaleEnglish Locale = "en"
+
+	// LocaleIndonesian returns display names in Bahasa Indonesia
+	LocaleIndonesian Locale = "id"
+)
+
+// DefaultLocale is used when no supported locale is requested.
+const DefaultLocale = LocaleEnglish
+
+// IsValid checks if the locale is supported.
+func (l Locale) IsValid() bool {
+	switch l {
+	case LocaleEnglish, LocaleIndonesian:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseLocale converts a language tag (e.g., "id", "id-ID", "en_US") to a Locale.
+// The legacy "in" tag for Indonesian is also accepted.
+// Returns false if the language is not supported.
+func ParseLocale(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	switch tag {
+	case "en":
+		return LocaleEnglish, true
+	case "id", "in":
+		return LocaleIndonesian, true
+	default:
+		return "", false
+	}
+}
+
+// ParseAcceptLanguage picks the first supported locale from an Accept-Language header.
+// Quality values are ignored; languages are considered in the order listed.
+// Returns false if the header contains no supported language.
+func ParseAcceptLanguage(header string) (Locale, bool) {
+	for _, part := range strings.Split(header, ",") {
+		tag := part
+		if i := strings.Index(part, ";"); i >= 0 {
+			tag = part[:i]
+		}
+		if loc, ok := ParseLocale(tag); ok {
+			return loc, true
+		}
+	}
+	return "", false
+}
+
+// LocalizedName holds a display name in each supported locale.
+type LocalizedName map[Locale]string
+
+// In returns the name for the given locale, falling back to English.
+func (n LocalizedName) In(loc Locale) string {
+	if name, ok := n[loc]; ok && name != "" {
+		return name
+	}
+	return n[LocaleEnglish]
+}
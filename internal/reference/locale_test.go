@@ -0,0 +1,65 @@
+package reference
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected Locale
+		ok       bool
+	}{
+		{tag: "en", expected: LocaleEnglish, ok: true},
+		{tag: "en-US", expected: LocaleEnglish, ok: true},
+		{tag: "EN_gb", expected: LocaleEnglish, ok: true},
+		{tag: "id", expected: LocaleIndonesian, ok: true},
+		{tag: "id-ID", expected: LocaleIndonesian, ok: true},
+		{tag: "in", expected: LocaleIndonesian, ok: true},
+		{tag: " id ", expected: LocaleIndonesian, ok: true},
+		{tag: "fr", ok: false},
+		{tag: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			loc, ok := ParseLocale(tt.tag)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, loc)
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected Locale
+		ok       bool
+	}{
+		{name: "single language", header: "id", expected: LocaleIndonesian, ok: true},
+		{name: "with region and quality", header: "id-ID,id;q=0.9,en;q=0.8", expected: LocaleIndonesian, ok: true},
+		{name: "skips unsupported", header: "fr-FR,en-US;q=0.7", expected: LocaleEnglish, ok: true},
+		{name: "no supported language", header: "fr,de", ok: false},
+		{name: "empty header", header: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, ok := ParseAcceptLanguage(tt.header)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, loc)
+		})
+	}
+}
+
+func TestLocalizedName_In(t *testing.T) {
+	name := LocalizedName{LocaleEnglish: "Singapore", LocaleIndonesian: "Singapura"}
+	assert.Equal(t, "Singapore", name.In(LocaleEnglish))
+	assert.Equal(t, "Singapura", name.In(LocaleIndonesian))
+
+	englishOnly := LocalizedName{LocaleEnglish: "Jakarta"}
+	assert.Equal(t, "Jakarta", englishOnly.In(LocaleIndonesian), "should fall back to English")
+}
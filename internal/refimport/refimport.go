@@ -0,0 +1,255 @@
+// Package refimport refreshes the bundled reference datasets of package
+// reference from public sources: airports from the OurAirports export and
+// airlines from the OpenTravelData airline list.
+//
+// Only the entries already in a dataset are refreshed, and only with the
+// fields the source is authoritative for: codes, English names and airport
+// coordinates. Served cities, countries, timezones, airline alliances and logos,
+// and translations are curated by hand and kept as they are. Every change is
+// recorded in a Report.
+package refimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+// ErrInvalidSource indicates a source file does not have the expected columns.
+var ErrInvalidSource = errors.New("invalid reference source")
+
+// SourceAirport is an airport as read from the OurAirports export.
+type SourceAirport struct {
+	IATA string
+	ICAO string
+	Name string
+	Lat  float64
+	Lon  float64
+}
+
+// SourceAirline is an airline as read from the OpenTravelData airline list.
+type SourceAirline struct {
+	IATA string
+	ICAO string
+	Name string
+}
+
+// icaoAirportPattern matches the ICAO airport codes OurAirports uses as identifiers.
+var icaoAirportPattern = regexp.MustCompile(`^[A-Z]{4}$`)
+
+// coordinatePrecision rounds coordinates to the 4 decimals of the datasets.
+const coordinatePrecision = 1e4
+
+// ReadOurAirports reads the airports with an IATA code from the OurAirports
+// airports.csv export, by IATA code. Closed airports are skipped; when several
+// airports share a code, the one with scheduled service wins.
+func ReadOurAirports(r io.Reader) (map[string]SourceAirport, error) {
+	rows, err := readTable(r, ',', []string{"ident", "type", "name", "latitude_deg", "longitude_deg", "iata_code"})
+	if err != nil {
+		return nil, err
+	}
+
+	airports := make(map[string]SourceAirport)
+	scheduled := make(map[string]bool)
+	for line, row := range rows {
+		code := strings.ToUpper(row.get("iata_code"))
+		if code == "" || row.get("type") == "closed" {
+			continue
+		}
+		if _, ok := airports[code]; ok && (scheduled[code] || row.get("scheduled_service") != "yes") {
+			continue
+		}
+
+		lat, err := strconv.ParseFloat(row.get("latitude_deg"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: latitude_deg: %w", ErrInvalidSource, line+2, err)
+		}
+		lon, err := strconv.ParseFloat(row.get("longitude_deg"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: longitude_deg: %w", ErrInvalidSource, line+2, err)
+		}
+		airports[code] = SourceAirport{
+			IATA: code,
+			ICAO: airportICAO(row),
+			Name: row.get("name"),
+			Lat:  roundCoordinate(lat),
+			Lon:  roundCoordinate(lon),
+		}
+		scheduled[code] = row.get("scheduled_service") == "yes"
+	}
+	return airports, nil
+}
+
+// airportICAO returns the ICAO code of an OurAirports row: the icao_code
+// column of recent exports, else the GPS code or identifier when they are
+// ICAO-shaped.
+func airportICAO(row tableRow) string {
+	for _, column := range []string{"icao_code", "gps_code", "ident"} {
+		if code := strings.ToUpper(row.get(column)); icaoAirportPattern.MatchString(code) {
+			return code
+		}
+	}
+	return ""
+}
+
+// ReadOPTDAirlines reads the active airlines with an IATA code from the
+// OpenTravelData optd_airlines.csv list, by IATA code.
+func ReadOPTDAirlines(r io.Reader) (map[string]SourceAirline, error) {
+	rows, err := readTable(r, '^', []string{"2char_code", "3char_code", "name"})
+	if err != nil {
+		return nil, err
+	}
+
+	airlines := make(map[string]SourceAirline)
+	for _, row := range rows {
+		code := strings.ToUpper(row.get("2char_code"))
+		// Entries with an end of validity or an envelope are superseded
+		if code == "" || row.get("validity_to") != "" || row.get("env_id") != "" {
+			continue
+		}
+		airlines[code] = SourceAirline{
+			IATA: code,
+			ICAO: strings.ToUpper(row.get("3char_code")),
+			Name: row.get("name"),
+		}
+	}
+	return airlines, nil
+}
+
+// RefreshAirports returns current refreshed from source, recording the
+// changes and the airports source does not list in report.
+func RefreshAirports(current []reference.Airport, source map[string]SourceAirport, report *Report) []reference.Airport {
+	refreshed := make([]reference.Airport, len(current))
+	for i, airport := range current {
+		refreshed[i] = airport
+		src, ok := source[airport.Code]
+		if !ok {
+			report.missing(DatasetAirports, airport.Code)
+			continue
+		}
+
+		updated := airport
+		updated.Name = refreshName(airport.Name, src.Name)
+		if src.ICAO != "" {
+			updated.ICAO = src.ICAO
+		}
+		updated.Lat, updated.Lon = src.Lat, src.Lon
+
+		report.compare(DatasetAirports, airport.Code, "icao", airport.ICAO, updated.ICAO)
+		report.compareNames(DatasetAirports, airport.Code, airport.Name, updated.Name)
+		report.compare(DatasetAirports, airport.Code, "lat", formatCoordinate(airport.Lat), formatCoordinate(updated.Lat))
+		report.compare(DatasetAirports, airport.Code, "lon", formatCoordinate(airport.Lon), formatCoordinate(updated.Lon))
+		refreshed[i] = updated
+	}
+	return refreshed
+}
+
+// RefreshAirlines returns current refreshed from source, recording the
+// changes and the airlines source does not list in report.
+func RefreshAirlines(current []reference.Airline, source map[string]SourceAirline, report *Report) []reference.Airline {
+	refreshed := make([]reference.Airline, len(current))
+	for i, airline := range current {
+		refreshed[i] = airline
+		src, ok := source[airline.Code]
+		if !ok {
+			report.missing(DatasetAirlines, airline.Code)
+			continue
+		}
+
+		updated := airline
+		updated.Name = refreshName(airline.Name, src.Name)
+		if src.ICAO != "" {
+			updated.ICAO = src.ICAO
+		}
+
+		report.compare(DatasetAirlines, airline.Code, "icao", airline.ICAO, updated.ICAO)
+		report.compareNames(DatasetAirlines, airline.Code, airline.Name, updated.Name)
+		refreshed[i] = updated
+	}
+	return refreshed
+}
+
+// refreshName returns names with the English name replaced by name.
+// Translations identical to the English name are names that are not
+// translated, so they follow it.
+func refreshName(names reference.LocalizedName, name string) reference.LocalizedName {
+	old := names[reference.LocaleEnglish]
+	if name == "" || name == old {
+		return names
+	}
+	updated := make(reference.LocalizedName, len(names))
+	for loc, n := range names {
+		if n == old {
+			n = name
+		}
+		updated[loc] = n
+	}
+	return updated
+}
+
+// roundCoordinate rounds a coordinate to the precision of the datasets.
+func roundCoordinate(deg float64) float64 {
+	return math.Round(deg*coordinatePrecision) / coordinatePrecision
+}
+
+// formatCoordinate formats a coordinate for the report.
+func formatCoordinate(deg float64) string {
+	return strconv.FormatFloat(deg, 'f', 4, 64)
+}
+
+// tableRow is a row of a source table, by column name.
+type tableRow struct {
+	columns map[string]int
+	values  []string
+}
+
+// get returns the value of column, or an empty string if the table has no such column.
+func (r tableRow) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.values) {
+		return ""
+	}
+	return strings.TrimSpace(r.values[i])
+}
+
+// readTable reads a delimited table with a header row, checking it has the
+// required columns.
+func readTable(r io.Reader, comma rune, required []string) ([]tableRow, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading header: %w", ErrInvalidSource, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidSource, name)
+		}
+	}
+
+	var rows []tableRow
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSource, err)
+		}
+		rows = append(rows, tableRow{columns: columns, values: values})
+	}
+}
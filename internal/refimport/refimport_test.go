@@ -0,0 +1,135 @@
+package refimport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+const ourAirportsCSV = `"id","ident","type","name","latitude_deg","longitude_deg","elevation_ft","scheduled_service","gps_code","iata_code"
+26434,"WIII","large_airport","Soekarno-Hatta International Airport",-6.1255698204,106.6559982299,34,"yes","WIII","CGK"
+26435,"WADD","large_airport","I Gusti Ngurah Rai Bali International Airport",-8.74817,115.1675,14,"yes","WADD","DPS"
+1,"ID-0001","heliport","Denpasar Heliport",-8.6,115.2,0,"no","","DPS"
+2,"WXXX","closed","Old Kemayoran Airport",-6.15,106.85,0,"no","","KMY"
+`
+
+const optdAirlinesCSV = `pk^env_id^validity_from^validity_to^3char_code^2char_code^num_code^name^name2
+air-garuda^^1949-01-01^^GIA^GA^126^Garuda Indonesia^Garuda
+air-lion-old^^2000-01-01^2010-01-01^LNX^JT^990^Lion Airlines^
+air-lion^^2010-01-02^^LNI^JT^990^Lion Air^
+`
+
+func TestReadOurAirports(t *testing.T) {
+	airports, err := ReadOurAirports(strings.NewReader(ourAirportsCSV))
+	require.NoError(t, err)
+
+	assert.Equal(t, SourceAirport{IATA: "CGK", ICAO: "WIII", Name: "Soekarno-Hatta International Airport", Lat: -6.1256, Lon: 106.656}, airports["CGK"])
+	assert.Equal(t, "I Gusti Ngurah Rai Bali International Airport", airports["DPS"].Name, "scheduled airport wins")
+	assert.NotContains(t, airports, "KMY", "closed airports skipped")
+}
+
+func TestReadOPTDAirlines(t *testing.T) {
+	airlines, err := ReadOPTDAirlines(strings.NewReader(optdAirlinesCSV))
+	require.NoError(t, err)
+
+	assert.Equal(t, SourceAirline{IATA: "GA", ICAO: "GIA", Name: "Garuda Indonesia"}, airlines["GA"])
+	assert.Equal(t, SourceAirline{IATA: "JT", ICAO: "LNI", Name: "Lion Air"}, airlines["JT"], "superseded entries skipped")
+}
+
+func TestReadSources_Invalid(t *testing.T) {
+	_, err := ReadOurAirports(strings.NewReader("id,ident,name\n1,WIII,Soekarno-Hatta\n"))
+	require.ErrorIs(t, err, ErrInvalidSource)
+	assert.Contains(t, err.Error(), `missing column "type"`)
+
+	_, err = ReadOurAirports(strings.NewReader(`ident,type,name,latitude_deg,longitude_deg,iata_code
+WIII,large_airport,Soekarno-Hatta,north,106.6,CGK
+`))
+	require.ErrorIs(t, err, ErrInvalidSource)
+	assert.Contains(t, err.Error(), "row 2: latitude_deg")
+
+	_, err = ReadOPTDAirlines(strings.NewReader(""))
+	require.ErrorIs(t, err, ErrInvalidSource)
+}
+
+func TestRefreshAirports(t *testing.T) {
+	current := []reference.Airport{
+		{
+			Code: "CGK", ICAO: "WIII", Lat: -6.1256, Lon: 106.6559, Timezone: "Asia/Jakarta",
+			Name: reference.LocalizedName{reference.LocaleEnglish: "Soekarno-Hatta International Airport", reference.LocaleIndonesian: "Bandar Udara Internasional Soekarno-Hatta"},
+			City: reference.LocalizedName{reference.LocaleEnglish: "Jakarta"},
+		},
+		{
+			Code: "DPS", ICAO: "WADD", Lat: -8.7482, Lon: 115.1675, Timezone: "Asia/Makassar",
+			Name: reference.LocalizedName{reference.LocaleEnglish: "I Gusti Ngurah Rai International Airport", reference.LocaleIndonesian: "Bandar Udara Internasional I Gusti Ngurah Rai"},
+			City: reference.LocalizedName{reference.LocaleEnglish: "Denpasar"},
+		},
+		{
+			Code: "SIN", ICAO: "WSSS", Timezone: "Asia/Singapore",
+			Name: reference.LocalizedName{reference.LocaleEnglish: "Singapore Changi Airport"},
+			City: reference.LocalizedName{reference.LocaleEnglish: "Singapore"},
+		},
+	}
+	source, err := ReadOurAirports(strings.NewReader(ourAirportsCSV))
+	require.NoError(t, err)
+
+	report := &Report{}
+	refreshed := RefreshAirports(current, source, report)
+
+	require.Len(t, refreshed, 3)
+	assert.Equal(t, 106.656, refreshed[0].Lon)
+	assert.Equal(t, "I Gusti Ngurah Rai Bali International Airport", refreshed[1].Name.In(reference.LocaleEnglish))
+	assert.Equal(t, "Bandar Udara Internasional I Gusti Ngurah Rai", refreshed[1].Name.In(reference.LocaleIndonesian), "translation kept")
+	assert.Equal(t, "Asia/Makassar", refreshed[1].Timezone, "curated fields kept")
+	assert.Equal(t, current[2], refreshed[2], "airport missing from the source kept")
+	assert.Equal(t, "Soekarno-Hatta International Airport", current[0].Name.In(reference.LocaleEnglish), "current left untouched")
+
+	assert.Equal(t, []Change{
+		{Dataset: DatasetAirports, Code: "CGK", Field: "lon", Old: "106.6559", New: "106.6560"},
+		{Dataset: DatasetAirports, Code: "DPS", Field: "name.en", Old: "I Gusti Ngurah Rai International Airport", New: "I Gusti Ngurah Rai Bali International Airport"},
+	}, report.Changes)
+	assert.Equal(t, []string{"airports SIN"}, report.Missing)
+}
+
+func TestRefreshAirlines(t *testing.T) {
+	current := []reference.Airline{
+		{Code: "GA", ICAO: "GIA", Name: reference.LocalizedName{reference.LocaleEnglish: "Garuda Indonesia", reference.LocaleIndonesian: "Garuda Indonesia"}},
+		{Code: "JT", Name: reference.LocalizedName{reference.LocaleEnglish: "Lion Airlines", reference.LocaleIndonesian: "Lion Airlines"}},
+	}
+	source, err := ReadOPTDAirlines(strings.NewReader(optdAirlinesCSV))
+	require.NoError(t, err)
+
+	report := &Report{}
+	refreshed := RefreshAirlines(current, source, report)
+
+	assert.Equal(t, current[0], refreshed[0])
+	assert.Equal(t, reference.Airline{
+		Code: "JT", ICAO: "LNI",
+		Name: reference.LocalizedName{reference.LocaleEnglish: "Lion Air", reference.LocaleIndonesian: "Lion Air"},
+	}, refreshed[1], "untranslated names follow the English name")
+	assert.Equal(t, []Change{
+		{Dataset: DatasetAirlines, Code: "JT", Field: "icao", Old: "", New: "LNI"},
+		{Dataset: DatasetAirlines, Code: "JT", Field: "name.en", Old: "Lion Airlines", New: "Lion Air"},
+		{Dataset: DatasetAirlines, Code: "JT", Field: "name.id", Old: "Lion Airlines", New: "Lion Air"},
+	}, report.Changes)
+	assert.Empty(t, report.Missing)
+}
+
+func TestReport_Write(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&Report{}).Write(&buf))
+	assert.Equal(t, "reference data is up to date\n", buf.String())
+
+	buf.Reset()
+	report := &Report{
+		Changes: []Change{{Dataset: DatasetAirports, Code: "CGK", Field: "lon", Old: "106.6559", New: "106.6560"}},
+		Missing: []string{"airports SIN"},
+	}
+	require.NoError(t, report.Write(&buf))
+	assert.Equal(t, "~ airports CGK lon: \"106.6559\" -> \"106.6560\"\n? airports SIN: not in source, kept unchanged\n", buf.String())
+	assert.True(t, report.Changed())
+}
@@ -0,0 +1,80 @@
+package refimport
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+// Dataset names used in reports.
+const (
+	DatasetAirports = "airports"
+	DatasetAirlines = "airlines"
+)
+
+// Change is a field of a dataset entry changed by a refresh.
+type Change struct {
+	Dataset string
+	Code    string
+	Field   string
+	Old     string
+	New     string
+}
+
+// Report is the diff of a refresh against the current datasets.
+type Report struct {
+	// Changes are the fields changed, in dataset order
+	Changes []Change
+
+	// Missing are the entries the source does not list, as "dataset code";
+	// they are kept unchanged
+	Missing []string
+}
+
+// Changed reports whether the refresh changed any dataset.
+func (r *Report) Changed() bool {
+	return len(r.Changes) > 0
+}
+
+// Write writes a human-readable report.
+func (r *Report) Write(w io.Writer) error {
+	var b strings.Builder
+	if !r.Changed() {
+		b.WriteString("reference data is up to date\n")
+	}
+	for _, c := range r.Changes {
+		fmt.Fprintf(&b, "~ %s %s %s: %q -> %q\n", c.Dataset, c.Code, c.Field, c.Old, c.New)
+	}
+	for _, m := range r.Missing {
+		fmt.Fprintf(&b, "? %s: not in source, kept unchanged\n", m)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// compare records a change of field if old and updated differ.
+func (r *Report) compare(dataset, code, field, old, updated string) {
+	if old != updated {
+		r.Changes = append(r.Changes, Change{Dataset: dataset, Code: code, Field: field, Old: old, New: updated})
+	}
+}
+
+// compareNames records the changes between two localized names.
+func (r *Report) compareNames(dataset, code string, old, updated reference.LocalizedName) {
+	locales := make([]reference.Locale, 0, len(updated))
+	for loc := range updated {
+		locales = append(locales, loc)
+	}
+	slices.Sort(locales)
+	for _, loc := range locales {
+		r.compare(dataset, code, "name."+string(loc), old[loc], updated[loc])
+	}
+}
+
+// missing records an entry the source does not list.
+func (r *Report) missing(dataset, code string) {
+	r.Missing = append(r.Missing, dataset+" "+code)
+}
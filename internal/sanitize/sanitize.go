@@ -0,0 +1,122 @@
+// Package sanitize cleans provider-supplied free text before it reaches clients.
+//
+// Provider fields such as baggage notes and amenities are free text that
+// downstream UIs may render as-is. A Sanitizer strips markup and control
+// characters, optionally redacts e-mail addresses and phone numbers, masks
+// blocked terms and caps the text length.
+package sanitize
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultMaxLength is the default cap on sanitized text, in characters.
+const DefaultMaxLength = 200
+
+// Redacted replaces e-mail addresses and phone numbers.
+const Redacted = "[redacted]"
+
+var (
+	tagPattern   = regexp.MustCompile(`(?s)<[^<>]*>`)
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`\+?\d[\d ().-]{7,}\d`)
+)
+
+// Config configures a Sanitizer.
+type Config struct {
+	// MaxLength caps the text length in characters (0 = no cap)
+	MaxLength int
+
+	// BlockedTerms are masked wherever they appear as whole words, ignoring case
+	BlockedTerms []string
+
+	// RedactPII replaces e-mail addresses and phone numbers with Redacted
+	RedactPII bool
+}
+
+// DefaultConfig returns the default configuration: length capped at
+// DefaultMaxLength, PII redacted and no blocked terms.
+func DefaultConfig() Config {
+	return Config{MaxLength: DefaultMaxLength, RedactPII: true}
+}
+
+// Sanitizer cleans free text. It is safe for concurrent use.
+type Sanitizer struct {
+	maxLength int
+	redactPII bool
+	blocked   *regexp.Regexp
+}
+
+// New creates a Sanitizer. Empty blocked terms are ignored.
+func New(cfg Config) *Sanitizer {
+	s := &Sanitizer{maxLength: cfg.MaxLength, redactPII: cfg.RedactPII}
+
+	var terms []string
+	for _, term := range cfg.BlockedTerms {
+		if term = strings.TrimSpace(term); term != "" {
+			terms = append(terms, regexp.QuoteMeta(term))
+		}
+	}
+	if len(terms) > 0 {
+		s.blocked = regexp.MustCompile(`(?i)\b(?:` + strings.Join(terms, "|") + `)\b`)
+	}
+	return s
+}
+
+// Text returns text without markup, control characters and redundant
+// whitespace, with PII redacted and blocked terms masked as configured,
+// truncated to the maximum length with a trailing ellipsis.
+// A nil Sanitizer returns text unchanged.
+func (s *Sanitizer) Text(text string) string {
+	if s == nil || text == "" {
+		return text
+	}
+
+	// Strip tags before and after unescaping so that escaped markup such as
+	// "&lt;script&gt;" cannot survive as a tag
+	text = tagPattern.ReplaceAllString(text, " ")
+	text = html.UnescapeString(text)
+	text = tagPattern.ReplaceAllString(text, " ")
+	text = strings.ToValidUTF8(text, "")
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, text)
+	text = strings.Join(strings.Fields(text), " ")
+
+	if s.redactPII {
+		text = emailPattern.ReplaceAllString(text, Redacted)
+		text = phonePattern.ReplaceAllString(text, Redacted)
+	}
+	if s.blocked != nil {
+		text = s.blocked.ReplaceAllStringFunc(text, func(term string) string {
+			return strings.Repeat("*", utf8.RuneCountInString(term))
+		})
+	}
+
+	if s.maxLength > 0 && utf8.RuneCountInString(text) > s.maxLength {
+		runes := []rune(text)
+		text = strings.TrimSpace(string(runes[:s.maxLength-1])) + "…"
+	}
+	return text
+}
+
+// Texts sanitizes each text, dropping those left empty.
+func (s *Sanitizer) Texts(texts []string) []string {
+	if s == nil || texts == nil {
+		return texts
+	}
+	cleaned := make([]string, 0, len(texts))
+	for _, text := range texts {
+		if text = s.Text(text); text != "" {
+			cleaned = append(cleaned, text)
+		}
+	}
+	return cleaned
+}
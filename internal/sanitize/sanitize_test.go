@@ -0,0 +1,56 @@
+package sanitize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizer_Text(t *testing.T) {
+	s := New(Config{MaxLength: 60, BlockedTerms: []string{"damn", " ", "cheap trick"}, RedactPII: true})
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain text", in: "Cabin baggage only", want: "Cabin baggage only"},
+		{name: "empty", in: "", want: ""},
+		{name: "tags stripped", in: "<b>20kg</b> checked<script>alert(1)</script>", want: "20kg checked alert(1)"},
+		{name: "escaped markup stripped", in: "&lt;img src=x onerror=alert(1)&gt;7kg cabin", want: "7kg cabin"},
+		{name: "entities unescaped", in: "Meals &amp; drinks", want: "Meals & drinks"},
+		{name: "control characters and whitespace", in: "Wi-Fi\x00\n\n  onboard\t", want: "Wi-Fi onboard"},
+		{name: "email redacted", in: "Ask groups@airline.example for bags", want: "Ask [redacted] for bags"},
+		{name: "phone redacted", in: "Call +62 21 2351 9999 now", want: "Call [redacted] now"},
+		{name: "short numbers kept", in: "Flight GA-410, 2 bags of 23kg", want: "Flight GA-410, 2 bags of 23kg"},
+		{name: "blocked terms masked", in: "Damn good seats, no cheap trick", want: "**** good seats, no ***********"},
+		{name: "blocked terms whole words only", in: "Amsterdamned", want: "Amsterdamned"},
+		{name: "truncated", in: strings.Repeat("a", 80), want: strings.Repeat("a", 59) + "…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.Text(tt.in))
+		})
+	}
+}
+
+func TestSanitizer_Options(t *testing.T) {
+	s := New(Config{})
+	long := strings.Repeat("a", 500)
+	assert.Equal(t, long, s.Text(long), "no length cap")
+	assert.Equal(t, "mail ops@airline.example", s.Text("mail ops@airline.example"), "PII kept")
+
+	var nilSanitizer *Sanitizer
+	assert.Equal(t, "<b>x</b>", nilSanitizer.Text("<b>x</b>"))
+
+	assert.Equal(t, DefaultMaxLength, DefaultConfig().MaxLength)
+	assert.True(t, DefaultConfig().RedactPII)
+}
+
+func TestSanitizer_Texts(t *testing.T) {
+	s := New(DefaultConfig())
+	assert.Equal(t, []string{"wifi", "meal"}, s.Texts([]string{"<i>wifi</i>", "<br/>", "meal"}))
+	assert.Nil(t, s.Texts(nil))
+}
@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Next(t *testing.T) {
+	// Monday 2025-12-15 10:07:30 UTC
+	from := time.Date(2025, 12, 15, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 12, 15, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 12, 15, 10, 15, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2025, 12, 15, 11, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2025, 12, 16, 2, 30, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2025, 12, 15, 13, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * SAT,SUN", time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 feb *", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either may match
+		{"0 0 1 * MON", time.Date(2025, 12, 22, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 12, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 12, 16, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC)},
+		{"@every 1m30s", from.Add(90 * time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(from))
+			assert.Equal(t, tt.spec, fmt.Sprint(s))
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{"", "empty schedule"},
+		{"* * * *", "must have 5 fields"},
+		{"60 * * * *", "out of range"},
+		{"* 24 * * *", "out of range"},
+		{"* * 0 * *", "out of range"},
+		{"* * * 13 *", "out of range"},
+		{"* * * * 8", "out of range"},
+		{"5-1 * * * *", "range start 5 is after end 1"},
+		{"*/0 * * * *", "invalid step"},
+		{"a * * * *", "invalid value"},
+		{"@fortnightly", "unknown descriptor"},
+		{"@every soon", "invalid interval"},
+		{"@every 10ms", "must be at least 1s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := Parse(tt.spec)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCron_NeverMatches(t *testing.T) {
+	s := MustParse("0 0 30 2 *")
+	assert.True(t, s.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero())
+}
+
+func TestCron_UsesLocationOfTime(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	from := time.Date(2025, 12, 15, 1, 0, 0, 0, jakarta)
+	next := MustParse("0 6 * * *").Next(from)
+	assert.Equal(t, time.Date(2025, 12, 15, 6, 0, 0, 0, jakarta), next)
+}
+
+func TestMustParse_Panics(t *testing.T) {
+	assert.Panics(t, func() { MustParse("bogus") })
+}
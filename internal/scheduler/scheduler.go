@@ -0,0 +1,303 @@
+// Package scheduler runs background jobs on cron-style schedules.
+// Each job runs in its own goroutine with optional random jitter; a run that
+// is still in progress when the next one is due causes that activation to be
+// skipped, and a panicking job is recovered and recorded as a failed run
+// without affecting other jobs.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Job is a unit of background work.
+type Job struct {
+	// Name uniquely identifies the job in logs and status reports
+	Name string
+
+	// Schedule determines when the job runs
+	Schedule Schedule
+
+	// Jitter delays each run by a random duration in [0, Jitter) so that
+	// instances sharing a schedule do not hit dependencies at the same moment
+	Jitter time.Duration
+
+	// Run performs the work. The context is cancelled when the scheduler stops.
+	Run func(ctx context.Context) error
+}
+
+// RunResult describes a completed job run.
+type RunResult struct {
+	StartedAt time.Time
+	Duration  time.Duration
+	Err       error
+}
+
+// JobStatus is a snapshot of a job's state.
+type JobStatus struct {
+	Name     string
+	Schedule string
+	Running  bool
+
+	// NextRun is the next scheduled activation (zero if not scheduled)
+	NextRun time.Time
+
+	// LastRun is the most recent completed run (nil if the job has not run yet)
+	LastRun *RunResult
+
+	Runs     int
+	Failures int
+
+	// Skipped counts activations skipped because the previous run was still in progress
+	Skipped int
+}
+
+// jobState tracks a registered job.
+type jobState struct {
+	job Job
+
+	mu       sync.Mutex
+	running  bool
+	nextRun  time.Time
+	lastRun  *RunResult
+	runs     int
+	failures int
+	skipped  int
+}
+
+// Scheduler runs registered jobs on their schedules.
+type Scheduler struct {
+	logger zerolog.Logger
+
+	mu      sync.Mutex
+	jobs    map[string]*jobState
+	started bool
+	cancel  context.CancelFunc
+	loops   sync.WaitGroup
+	runs    sync.WaitGroup
+}
+
+// New creates a scheduler that logs job outcomes to logger.
+func New(logger zerolog.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger.With().Str("component", "scheduler").Logger(),
+		jobs:   make(map[string]*jobState),
+	}
+}
+
+// Add registers a job. Jobs must be added before Start.
+func (s *Scheduler) Add(job Job) error {
+	if job.Name == "" {
+		return errors.New("job name is required")
+	}
+	if job.Schedule == nil {
+		return fmt.Errorf("job %s: schedule is required", job.Name)
+	}
+	if job.Run == nil {
+		return fmt.Errorf("job %s: run function is required", job.Name)
+	}
+	if job.Jitter < 0 {
+		return fmt.Errorf("job %s: jitter must not be negative", job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("job %s: scheduler already started", job.Name)
+	}
+	if _, exists := s.jobs[job.Name]; exists {
+		return fmt.Errorf("job %s: duplicate name", job.Name)
+	}
+	s.jobs[job.Name] = &jobState{job: job}
+	return nil
+}
+
+// Start launches all registered jobs. Jobs stop when ctx is cancelled or Stop is called.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, state := range s.jobs {
+		s.loops.Add(1)
+		go s.loop(ctx, state)
+	}
+	s.logger.Info().Int("jobs", len(s.jobs)).Msg("Scheduler started")
+}
+
+// Stop cancels all jobs and waits for in-progress runs to return or ctx to expire.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.loops.Wait()
+		s.runs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Jobs returns the status of every registered job, sorted by name.
+func (s *Scheduler) Jobs() []JobStatus {
+	s.mu.Lock()
+	states := make([]*jobState, 0, len(s.jobs))
+	for _, state := range s.jobs {
+		states = append(states, state)
+	}
+	s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(states))
+	for _, state := range states {
+		statuses = append(statuses, state.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// loop waits for each activation of a job and starts a run.
+func (s *Scheduler) loop(ctx context.Context, state *jobState) {
+	defer s.loops.Done()
+
+	last := time.Now()
+	for {
+		next := state.job.Schedule.Next(last)
+		if next.IsZero() {
+			s.logger.Warn().Str("job", state.job.Name).Msg("Job schedule has no further activations")
+			state.setNextRun(time.Time{})
+			return
+		}
+		state.setNextRun(next)
+
+		timer := time.NewTimer(time.Until(next) + jitter(state.job.Jitter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		last = next
+
+		if !state.begin() {
+			s.logger.Warn().Str("job", state.job.Name).Msg("Previous run still in progress, skipping")
+			continue
+		}
+		s.runs.Add(1)
+		go func() {
+			defer s.runs.Done()
+			s.run(ctx, state)
+		}()
+	}
+}
+
+// run executes one run of a job, recovering from panics.
+func (s *Scheduler) run(ctx context.Context, state *jobState) {
+	start := time.Now()
+	var err error
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panic: %v", r)
+		}
+		result := RunResult{StartedAt: start, Duration: time.Since(start), Err: err}
+		state.finish(result)
+
+		if err != nil {
+			s.logger.Error().Err(err).Str("job", state.job.Name).Dur("duration", result.Duration).Msg("Job failed")
+			return
+		}
+		s.logger.Debug().Str("job", state.job.Name).Dur("duration", result.Duration).Msg("Job completed")
+	}()
+
+	err = state.job.Run(ctx)
+}
+
+// begin marks the job as running. Returns false if a run is already in progress.
+func (j *jobState) begin() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.running {
+		j.skipped++
+		return false
+	}
+	j.running = true
+	return true
+}
+
+// finish records the result of a run.
+func (j *jobState) finish(result RunResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.running = false
+	j.lastRun = &result
+	j.runs++
+	if result.Err != nil {
+		j.failures++
+	}
+}
+
+// setNextRun records the next scheduled activation.
+func (j *jobState) setNextRun(t time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.nextRun = t
+}
+
+// status returns a snapshot of the job state.
+func (j *jobState) status() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	st := JobStatus{
+		Name:     j.job.Name,
+		Schedule: fmt.Sprint(j.job.Schedule),
+		Running:  j.running,
+		NextRun:  j.nextRun,
+		Runs:     j.runs,
+		Failures: j.failures,
+		Skipped:  j.skipped,
+	}
+	if j.lastRun != nil {
+		last := *j.lastRun
+		st.LastRun = &last
+	}
+	return st
+}
+
+// jitter returns a random duration in [0, max).
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return rand.N(max)
+}
@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_Add(t *testing.T) {
+	s := New(zerolog.Nop())
+	noop := func(context.Context) error { return nil }
+
+	require.NoError(t, s.Add(Job{Name: "a", Schedule: Every(time.Hour), Run: noop}))
+	assert.ErrorContains(t, s.Add(Job{Name: "a", Schedule: Every(time.Hour), Run: noop}), "duplicate name")
+	assert.ErrorContains(t, s.Add(Job{Schedule: Every(time.Hour), Run: noop}), "name is required")
+	assert.ErrorContains(t, s.Add(Job{Name: "b", Run: noop}), "schedule is required")
+	assert.ErrorContains(t, s.Add(Job{Name: "b", Schedule: Every(time.Hour)}), "run function is required")
+	assert.ErrorContains(t, s.Add(Job{Name: "b", Schedule: Every(time.Hour), Run: noop, Jitter: -time.Second}), "jitter")
+
+	s.Start(context.Background())
+	defer s.Stop(context.Background())
+	assert.ErrorContains(t, s.Add(Job{Name: "c", Schedule: Every(time.Hour), Run: noop}), "already started")
+}
+
+func TestScheduler_RunsJobs(t *testing.T) {
+	s := New(zerolog.Nop())
+
+	var runs atomic.Int32
+	require.NoError(t, s.Add(Job{
+		Name:     "tick",
+		Schedule: Every(10 * time.Millisecond),
+		Jitter:   time.Millisecond,
+		Run: func(context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	}))
+
+	s.Start(context.Background())
+	require.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, s.Stop(context.Background()))
+
+	jobs := s.Jobs()
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "tick", jobs[0].Name)
+	assert.Equal(t, "@every 10ms", jobs[0].Schedule)
+	assert.GreaterOrEqual(t, jobs[0].Runs, 3)
+	assert.Zero(t, jobs[0].Failures)
+	require.NotNil(t, jobs[0].LastRun)
+	assert.NoError(t, jobs[0].LastRun.Err)
+}
+
+func TestScheduler_PreventsOverlap(t *testing.T) {
+	s := New(zerolog.Nop())
+
+	var concurrent, maxConcurrent atomic.Int32
+	require.NoError(t, s.Add(Job{
+		Name:     "slow",
+		Schedule: Every(5 * time.Millisecond),
+		Run: func(ctx context.Context) error {
+			n := concurrent.Add(1)
+			defer concurrent.Add(-1)
+			if n > maxConcurrent.Load() {
+				maxConcurrent.Store(n)
+			}
+			select {
+			case <-time.After(50 * time.Millisecond):
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	}))
+
+	s.Start(context.Background())
+	require.Eventually(t, func() bool { return s.Jobs()[0].Skipped > 0 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, s.Stop(context.Background()))
+
+	assert.Equal(t, int32(1), maxConcurrent.Load())
+}
+
+func TestScheduler_IsolatesPanicsAndErrors(t *testing.T) {
+	s := New(zerolog.Nop())
+
+	var healthy atomic.Int32
+	require.NoError(t, s.Add(Job{
+		Name:     "panics",
+		Schedule: Every(5 * time.Millisecond),
+		Run:      func(context.Context) error { panic("boom") },
+	}))
+	require.NoError(t, s.Add(Job{
+		Name:     "fails",
+		Schedule: Every(5 * time.Millisecond),
+		Run:      func(context.Context) error { return errors.New("upstream down") },
+	}))
+	require.NoError(t, s.Add(Job{
+		Name:     "healthy",
+		Schedule: Every(5 * time.Millisecond),
+		Run: func(context.Context) error {
+			healthy.Add(1)
+			return nil
+		},
+	}))
+
+	s.Start(context.Background())
+	require.Eventually(t, func() bool {
+		for _, j := range s.Jobs() {
+			if j.Runs < 2 {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, s.Stop(context.Background()))
+
+	jobs := s.Jobs()
+	require.Len(t, jobs, 3)
+	assert.Equal(t, "fails", jobs[0].Name)
+	assert.Equal(t, jobs[0].Runs, jobs[0].Failures)
+	assert.EqualError(t, jobs[0].LastRun.Err, "upstream down")
+
+	assert.Equal(t, "healthy", jobs[1].Name)
+	assert.Zero(t, jobs[1].Failures)
+	assert.GreaterOrEqual(t, healthy.Load(), int32(2))
+
+	assert.Equal(t, "panics", jobs[2].Name)
+	assert.Equal(t, jobs[2].Runs, jobs[2].Failures)
+	assert.EqualError(t, jobs[2].LastRun.Err, "job panic: boom")
+}
+
+func TestScheduler_StopCancelsRuns(t *testing.T) {
+	s := New(zerolog.Nop())
+
+	started := make(chan struct{})
+	require.NoError(t, s.Add(Job{
+		Name:     "blocking",
+		Schedule: Every(time.Millisecond),
+		Run: func(ctx context.Context) error {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}))
+
+	s.Start(context.Background())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, s.Stop(ctx))
+	assert.False(t, s.Jobs()[0].Running)
+}
+
+func TestScheduler_StopWithoutStart(t *testing.T) {
+	assert.NoError(t, New(zerolog.Nop()).Stop(context.Background()))
+}
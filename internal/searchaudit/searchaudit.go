@@ -0,0 +1,347 @@
+// Package searchaudit writes the search audit log: one entry per search,
+// with its caller, parameters, latency and provider outcomes, for analytics
+// and abuse investigation.
+//
+// A Logger takes entries on the search path without blocking it: entries
+// are queued and written to a Sink in batches by a background goroutine.
+// When the queue is full, because the sink is slow or failing, entries are
+// dropped and counted rather than delaying searches. Sinks are pluggable;
+// FileSink appends JSON lines to a file, and package sqlite provides a
+// database sink. Both can encrypt the entries, which hold the search
+// criteria.
+package searchaudit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/encryption"
+)
+
+// Defaults of a Logger.
+const (
+	DefaultBuffer    = 1024
+	DefaultBatchSize = 100
+)
+
+// Sink stores search audit entries.
+type Sink interface {
+	// Write stores entries, oldest first.
+	Write(ctx context.Context, entries []domain.SearchAuditEntry) error
+
+	// Close releases the sink once the Logger writes no more entries.
+	Close() error
+}
+
+// Logger is an asynchronous domain.AuditLogger writing to a Sink. It is safe
+// for concurrent use.
+type Logger struct {
+	sink      Sink
+	batchSize int
+	onDrop    func()
+	onError   func(err error, entries int)
+
+	mu      sync.RWMutex
+	closed  bool
+	entries chan domain.SearchAuditEntry
+	done    chan struct{}
+}
+
+// Option configures a Logger.
+type Option func(*Logger)
+
+// WithBuffer sets the number of entries queued for the sink, beyond which
+// entries are dropped.
+func WithBuffer(n int) Option {
+	return func(l *Logger) {
+		l.entries = make(chan domain.SearchAuditEntry, n)
+	}
+}
+
+// WithBatchSize sets the maximum number of entries written to the sink at once.
+func WithBatchSize(n int) Option {
+	return func(l *Logger) {
+		l.batchSize = n
+	}
+}
+
+// WithDropObserver sets a function notified of each entry dropped because
+// the queue was full.
+func WithDropObserver(fn func()) Option {
+	return func(l *Logger) {
+		l.onDrop = fn
+	}
+}
+
+// WithErrorObserver sets a function notified of each failed write to the
+// sink, with the number of entries it held, which are lost.
+func WithErrorObserver(fn func(err error, entries int)) Option {
+	return func(l *Logger) {
+		l.onError = fn
+	}
+}
+
+// New creates a logger writing to sink and starts its writer.
+func New(sink Sink, opts ...Option) *Logger {
+	l := &Logger{
+		sink:      sink,
+		batchSize: DefaultBatchSize,
+		entries:   make(chan domain.SearchAuditEntry, DefaultBuffer),
+		done:      make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.batchSize < 1 {
+		l.batchSize = 1
+	}
+	go l.run()
+	return l
+}
+
+// Log queues entry for the sink, or drops it if the queue is full or the
+// logger is closed. It never blocks.
+func (l *Logger) Log(_ context.Context, entry domain.SearchAuditEntry) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		return
+	}
+	select {
+	case l.entries <- entry:
+	default:
+		if l.onDrop != nil {
+			l.onDrop()
+		}
+	}
+}
+
+// Close writes the queued entries, waiting until ctx is done at most, and
+// closes the sink.
+func (l *Logger) Close(ctx context.Context) error {
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.entries)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-l.done:
+	case <-ctx.Done():
+		return fmt.Errorf("search audit log: %d entries not written: %w", len(l.entries), ctx.Err())
+	}
+	return l.sink.Close()
+}
+
+// run writes the queued entries until the logger is closed, batching those
+// queued while the previous batch was written.
+func (l *Logger) run() {
+	defer close(l.done)
+	batch := make([]domain.SearchAuditEntry, 0, l.batchSize)
+	for entry := range l.entries {
+		batch = append(batch[:0], entry)
+	fill:
+		for len(batch) < l.batchSize {
+			select {
+			case entry, ok := <-l.entries:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, entry)
+			default:
+				break fill
+			}
+		}
+		if err := l.sink.Write(context.Background(), batch); err != nil && l.onError != nil {
+			l.onError(err, len(batch))
+		}
+	}
+}
+
+// fileAdditionalData binds encrypted search audit lines to their purpose.
+var fileAdditionalData = []byte("searchaudit")
+
+// maxLineSize bounds the lines of a search audit file read for rotation.
+const maxLineSize = 1 << 20
+
+// FileSink appends entries to a file as JSON lines.
+type FileSink struct {
+	keyring *encryption.Keyring
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// FileOption configures a FileSink.
+type FileOption func(*FileSink)
+
+// WithEncryption writes each line encrypted with the keyring's primary key,
+// as an encryption envelope. Lines written in plain text or under an older
+// key are rewritten under the primary key when the file is opened.
+func WithEncryption(keyring *encryption.Keyring) FileOption {
+	return func(s *FileSink) {
+		s.keyring = keyring
+	}
+}
+
+// OpenFile opens the file at path for appending, creating it and its
+// directory if needed.
+func OpenFile(path string, opts ...FileOption) (*FileSink, error) {
+	s := &FileSink{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create search audit log directory: %w", err)
+	}
+	if s.keyring != nil {
+		if err := s.rotate(path); err != nil {
+			return nil, err
+		}
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open search audit log: %w", err)
+	}
+	s.file = file
+	return s, nil
+}
+
+// Write appends entries, one line each, in a single write.
+func (s *FileSink) Write(_ context.Context, entries []domain.SearchAuditEntry) error {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("encode search audit entry: %w", err)
+		}
+		if s.keyring != nil {
+			if data, err = s.keyring.Encrypt(data, fileAdditionalData); err != nil {
+				return fmt.Errorf("encrypt search audit entry: %w", err)
+			}
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.file.Write(buf.Bytes())
+	return err
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// rotate rewrites the file at path if some of its lines are in plain text or
+// encrypted with an older key, so that all are encrypted with the primary
+// key. The new file is written next to it and renamed over it.
+func (s *FileSink) rotate(path string) error {
+	err := s.scanLines(path, func(line []byte) error {
+		if s.keyring.NeedsRotation(line) {
+			return errStaleLine
+		}
+		return nil
+	})
+	if !errors.Is(err, errStaleLine) {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("rotate search audit log: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	w := bufio.NewWriter(tmp)
+	if err := s.scanLines(path, func(line []byte) error {
+		line, err := s.reseal(line)
+		if err != nil {
+			return err
+		}
+		_, _ = w.Write(line)
+		return w.WriteByte('\n')
+	}); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("rotate search audit log: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("rotate search audit log: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("rotate search audit log: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rotate search audit log: %w", err)
+	}
+	return nil
+}
+
+// reseal returns line encrypted with the primary key.
+func (s *FileSink) reseal(line []byte) ([]byte, error) {
+	if !s.keyring.NeedsRotation(line) {
+		return line, nil
+	}
+	plaintext := line
+	if encryption.IsEncrypted(line) {
+		var err error
+		if plaintext, err = s.keyring.Decrypt(line, fileAdditionalData); err != nil {
+			return nil, fmt.Errorf("decrypt search audit log: %w", err)
+		}
+	}
+	sealed, err := s.keyring.Encrypt(plaintext, fileAdditionalData)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt search audit log: %w", err)
+	}
+	return sealed, nil
+}
+
+// errStaleLine stops the scan of a file at its first line needing rotation.
+var errStaleLine = errors.New("line needs rotation")
+
+// scanLines calls fn with each non-empty line of the file at path, if it
+// exists, until fn fails.
+func (s *FileSink) scanLines(path string, fn func(line []byte) error) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read search audit log: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := fn(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read search audit log: %w", err)
+	}
+	return nil
+}
+
+// Ensure Logger implements domain.AuditLogger at compile time.
+var _ domain.AuditLogger = (*Logger)(nil)
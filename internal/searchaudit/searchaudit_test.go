@@ -0,0 +1,215 @@
+package searchaudit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/encryption"
+)
+
+// memorySink keeps the batches written to it, blocking writes until released
+// if release is set.
+type memorySink struct {
+	release chan struct{}
+	err     error
+
+	mu      sync.Mutex
+	batches [][]domain.SearchAuditEntry
+	closed  bool
+}
+
+func (s *memorySink) Write(_ context.Context, entries []domain.SearchAuditEntry) error {
+	if s.release != nil {
+		<-s.release
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, append([]domain.SearchAuditEntry(nil), entries...))
+	return s.err
+}
+
+func (s *memorySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *memorySink) clients() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var clients []string
+	for _, batch := range s.batches {
+		for _, entry := range batch {
+			clients = append(clients, entry.Client)
+		}
+	}
+	return clients
+}
+
+func TestLogger_WritesAndCloses(t *testing.T) {
+	sink := &memorySink{}
+	logger := New(sink)
+	for _, client := range []string{"a", "b", "c"} {
+		logger.Log(context.Background(), domain.SearchAuditEntry{Client: client})
+	}
+
+	require.NoError(t, logger.Close(context.Background()))
+	assert.Equal(t, []string{"a", "b", "c"}, sink.clients(), "queued entries are written on close")
+	assert.True(t, sink.closed)
+
+	logger.Log(context.Background(), domain.SearchAuditEntry{Client: "late"})
+	assert.Equal(t, []string{"a", "b", "c"}, sink.clients(), "entries logged after close are dropped")
+}
+
+func TestLogger_BatchesAndDrops(t *testing.T) {
+	sink := &memorySink{release: make(chan struct{})}
+	dropped := 0
+	var mu sync.Mutex
+	logger := New(sink, WithBuffer(2), WithBatchSize(2), WithDropObserver(func() {
+		mu.Lock()
+		dropped++
+		mu.Unlock()
+	}))
+
+	// The first entry is taken by the writer, which blocks on the sink...
+	logger.Log(context.Background(), domain.SearchAuditEntry{Client: "first"})
+	require.Eventually(t, func() bool { return len(logger.entries) == 0 }, time.Second, time.Millisecond)
+
+	// ...so the next ones fill the queue, and the last is dropped
+	for _, client := range []string{"second", "third", "fourth"} {
+		logger.Log(context.Background(), domain.SearchAuditEntry{Client: client})
+	}
+	mu.Lock()
+	assert.Equal(t, 1, dropped)
+	mu.Unlock()
+
+	close(sink.release)
+	require.NoError(t, logger.Close(context.Background()))
+	assert.Equal(t, []string{"first", "second", "third"}, sink.clients())
+	assert.Len(t, sink.batches, 2, "entries queued meanwhile are written together")
+}
+
+func TestLogger_ReportsWriteErrors(t *testing.T) {
+	var reported []error
+	lost := 0
+	sink := &memorySink{err: errors.New("disk full")}
+	logger := New(sink, WithErrorObserver(func(err error, entries int) {
+		reported = append(reported, err)
+		lost += entries
+	}))
+
+	logger.Log(context.Background(), domain.SearchAuditEntry{Client: "a"})
+	require.NoError(t, logger.Close(context.Background()))
+	require.Len(t, reported, 1)
+	assert.EqualError(t, reported[0], "disk full")
+	assert.Equal(t, 1, lost)
+}
+
+func TestLogger_CloseTimesOut(t *testing.T) {
+	sink := &memorySink{release: make(chan struct{})}
+	defer close(sink.release)
+	logger := New(sink)
+	logger.Log(context.Background(), domain.SearchAuditEntry{Client: "a"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, logger.Close(ctx), context.DeadlineExceeded)
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "searches.jsonl")
+	sink, err := OpenFile(path)
+	require.NoError(t, err)
+
+	at := time.Date(2025, 12, 15, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, sink.Write(context.Background(), []domain.SearchAuditEntry{
+		{At: at, Client: "partner-app", Outcome: domain.AuditOutcomeSuccess, LatencyMs: 120, FailedProviders: []string{"lion_air"}},
+		{At: at, Client: "anonymous", Outcome: domain.AuditOutcomeError, Error: "all providers failed"},
+	}))
+	require.NoError(t, sink.Close())
+
+	// Reopening appends
+	sink, err = OpenFile(path)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(context.Background(), []domain.SearchAuditEntry{{At: at, Client: "ops"}}))
+	require.NoError(t, sink.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var entries []domain.SearchAuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry domain.SearchAuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.Len(t, entries, 3)
+	assert.Equal(t, "partner-app", entries[0].Client)
+	assert.Equal(t, []string{"lion_air"}, entries[0].FailedProviders)
+	assert.Equal(t, "all providers failed", entries[1].Error)
+	assert.Equal(t, "ops", entries[2].Client)
+}
+
+func TestFileSink_Encryption(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "searches.jsonl")
+	key := func(id string, fill byte) encryption.Key {
+		return encryption.Key{ID: id, Secret: bytes.Repeat([]byte{fill}, encryption.KeySize)}
+	}
+	oldKeys, err := encryption.NewKeyring(key("old", 1))
+	require.NoError(t, err)
+	newKeys, err := encryption.NewKeyring(key("new", 2), key("old", 1))
+	require.NoError(t, err)
+	entry := domain.SearchAuditEntry{Client: "partner-app", Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}}
+	lines := func() []string {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	}
+	write := func(opts ...FileOption) {
+		sink, err := OpenFile(path, opts...)
+		require.NoError(t, err)
+		require.NoError(t, sink.Write(ctx, []domain.SearchAuditEntry{entry}))
+		require.NoError(t, sink.Close())
+	}
+
+	// Plaintext lines are encrypted when the file is opened with keys
+	write()
+	write(WithEncryption(oldKeys))
+	for _, line := range lines() {
+		assert.True(t, strings.HasPrefix(line, "enc:v1:old:"))
+		assert.NotContains(t, line, "CGK")
+	}
+
+	// Lines are re-encrypted under a rotated primary key
+	write(WithEncryption(newKeys))
+	got := lines()
+	require.Len(t, got, 3)
+	for _, line := range got {
+		require.True(t, strings.HasPrefix(line, "enc:v1:new:"))
+		data, err := newKeys.Decrypt([]byte(line), fileAdditionalData)
+		require.NoError(t, err)
+		var decoded domain.SearchAuditEntry
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, entry.Criteria, decoded.Criteria)
+	}
+
+	// Files written under a retired key cannot be opened
+	_, err = OpenFile(path, WithEncryption(oldKeys))
+	assert.ErrorIs(t, err, encryption.ErrUnknownKey)
+}
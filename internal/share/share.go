@@ -0,0 +1,158 @@
+// Package share issues share tokens: expiring references to stored searches
+// that users can pass around without exposing the searches' internal IDs.
+//
+// A token is the search ID and the token's validity window sealed with
+// AES-256-GCM under a key derived from a server secret, base64url-encoded.
+// Sealing both hides the ID and authenticates the token: a token cannot be
+// forged, pointed at another search or extended, and an expired token cannot
+// be replayed, without the secret.
+package share
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// MinSecretLength is the minimum token secret length in bytes.
+const MinSecretLength = 32
+
+// purpose is authenticated with every token, so values sealed with the same
+// secret for other purposes are not accepted as share tokens.
+var purpose = []byte("flight-search-share:v1")
+
+// Sentinel errors returned by Open.
+var (
+	// ErrInvalidToken is returned for malformed, forged or tampered tokens
+	ErrInvalidToken = errors.New("invalid share token")
+
+	// ErrTokenExpired is returned for tokens past their expiry
+	ErrTokenExpired = errors.New("share token expired")
+)
+
+// Grant is the verified contents of a token.
+type Grant struct {
+	// SearchID is the shared search
+	SearchID string
+
+	// IssuedAt is when the token was issued
+	IssuedAt time.Time
+
+	// ExpiresAt is when the token stops being accepted
+	ExpiresAt time.Time
+}
+
+// claims is the sealed form of a Grant.
+type claims struct {
+	SearchID  string `json:"sid"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Issuer issues and opens share tokens. It is safe for concurrent use.
+type Issuer struct {
+	aead  cipher.AEAD
+	ttl   time.Duration
+	clock timeutil.Clock
+}
+
+// Option configures an Issuer.
+type Option func(*Issuer)
+
+// WithClock sets the clock used for issuing and expiry checks.
+func WithClock(clock timeutil.Clock) Option {
+	return func(i *Issuer) {
+		i.clock = clock
+	}
+}
+
+// NewIssuer creates an issuer sealing tokens with secret. Tokens are valid
+// for ttl.
+func NewIssuer(secret []byte, ttl time.Duration, opts ...Option) (*Issuer, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("secret must be at least %d bytes", MinSecretLength)
+	}
+	if ttl <= 0 {
+		return nil, errors.New("token lifetime must be positive")
+	}
+
+	key := sha256.Sum256(secret)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &Issuer{aead: aead, ttl: ttl, clock: timeutil.NewRealClock()}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i, nil
+}
+
+// Issue returns a token sharing the search with the given ID.
+func (i *Issuer) Issue(searchID string) (string, Grant, error) {
+	if searchID == "" {
+		return "", Grant{}, errors.New("search ID is required")
+	}
+
+	now := i.clock.Now()
+	grant := Grant{
+		SearchID:  searchID,
+		IssuedAt:  time.Unix(now.Unix(), 0),
+		ExpiresAt: time.Unix(now.Add(i.ttl).Unix(), 0),
+	}
+	payload, err := json.Marshal(claims{
+		SearchID:  grant.SearchID,
+		IssuedAt:  grant.IssuedAt.Unix(),
+		ExpiresAt: grant.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", Grant{}, err
+	}
+
+	nonce := make([]byte, i.aead.NonceSize(), i.aead.NonceSize()+len(payload)+i.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", Grant{}, fmt.Errorf("generate nonce: %w", err)
+	}
+	sealed := i.aead.Seal(nonce, nonce, payload, purpose)
+	return base64.RawURLEncoding.EncodeToString(sealed), grant, nil
+}
+
+// Open checks a token and returns its grant.
+func (i *Issuer) Open(token string) (Grant, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(sealed) < i.aead.NonceSize() {
+		return Grant{}, ErrInvalidToken
+	}
+	nonce, ciphertext := sealed[:i.aead.NonceSize()], sealed[i.aead.NonceSize():]
+	payload, err := i.aead.Open(nil, nonce, ciphertext, purpose)
+	if err != nil {
+		return Grant{}, ErrInvalidToken
+	}
+
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil || c.SearchID == "" {
+		return Grant{}, ErrInvalidToken
+	}
+	grant := Grant{
+		SearchID:  c.SearchID,
+		IssuedAt:  time.Unix(c.IssuedAt, 0),
+		ExpiresAt: time.Unix(c.ExpiresAt, 0),
+	}
+	if !i.clock.Now().Before(grant.ExpiresAt) {
+		return Grant{}, ErrTokenExpired
+	}
+	return grant, nil
+}
@@ -0,0 +1,90 @@
+package share
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+var testSecret = []byte(strings.Repeat("s", MinSecretLength))
+
+func newIssuer(t *testing.T, clock *timeutil.MockClock) *Issuer {
+	t.Helper()
+	i, err := NewIssuer(testSecret, 72*time.Hour, WithClock(clock))
+	require.NoError(t, err)
+	return i
+}
+
+func TestIssuer_IssueAndOpen(t *testing.T) {
+	now := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	clock := timeutil.NewMockClock(now)
+	i := newIssuer(t, clock)
+
+	token, issued, err := i.Issue("search-123")
+	require.NoError(t, err)
+	assert.NotContains(t, token, "search-123", "the search ID is not exposed")
+	assert.True(t, now.Add(72*time.Hour).Equal(issued.ExpiresAt))
+
+	grant, err := i.Open(token)
+	require.NoError(t, err)
+	assert.Equal(t, "search-123", grant.SearchID)
+	assert.True(t, now.Equal(grant.IssuedAt))
+	assert.True(t, issued.ExpiresAt.Equal(grant.ExpiresAt))
+
+	other, _, err := i.Issue("search-123")
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other, "tokens are not linkable")
+
+	clock.Advance(72 * time.Hour)
+	_, err = i.Open(token)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestIssuer_OpenRejects(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	i := newIssuer(t, clock)
+	token, _, err := i.Issue("search-123")
+	require.NoError(t, err)
+
+	other, err := NewIssuer([]byte(strings.Repeat("x", MinSecretLength)), time.Hour, WithClock(clock))
+	require.NoError(t, err)
+	foreign, _, err := other.Issue("search-123")
+	require.NoError(t, err)
+
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	sealed[len(sealed)-1] ^= 1
+	tampered := base64.RawURLEncoding.EncodeToString(sealed)
+
+	for name, tok := range map[string]string{
+		"empty":       "",
+		"not base64":  "!!!",
+		"too short":   "AAAA",
+		"wrong key":   foreign,
+		"tampered":    tampered,
+		"search ID":   "search-123",
+		"extra bytes": token + "AA",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := i.Open(tok)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestNewIssuer_Invalid(t *testing.T) {
+	_, err := NewIssuer([]byte("short"), time.Hour)
+	assert.Error(t, err)
+
+	_, err = NewIssuer(testSecret, 0)
+	assert.Error(t, err)
+
+	_, _, err = newIssuer(t, timeutil.NewMockClock(time.Now())).Issue("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+// Package blob provides object storage behind a small interface, with a
+// local filesystem implementation and an S3-compatible implementation
+// (AWS S3, MinIO, Cloudflare R2, ...).
+//
+// Keys are slash-separated paths such as "exports/search_analytics/2025-12-01.csv".
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Sentinel errors returned by stores.
+var (
+	// ErrNotFound is returned when no object exists for a key
+	ErrNotFound = errors.New("blob not found")
+
+	// ErrInvalidKey is returned for empty keys and keys that escape the store
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Store is an object store.
+type Store interface {
+	// Put stores the content read from r under key, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Get opens the object stored under key. The caller must close the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the object stored under key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// List returns the keys starting with prefix, in lexical order.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// ValidateKey checks that key is a relative slash-separated path without
+// empty, "." or ".." segments.
+func ValidateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("%w: empty key", ErrInvalidKey)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." || strings.Contains(segment, `\`) {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
+
+// Upload stores every regular file below dir under prefix + its relative path.
+// It returns the number of files uploaded.
+func Upload(ctx context.Context, store Store, dir, prefix string) (int, error) {
+	uploaded := 0
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if err := store.Put(ctx, prefix+filepath.ToSlash(rel), f); err != nil {
+			return err
+		}
+		uploaded++
+		return nil
+	})
+	if err != nil {
+		return uploaded, fmt.Errorf("upload %s: %w", dir, err)
+	}
+	return uploaded, nil
+}
+
+// Download writes every object under prefix to dir, named by the key with
+// the prefix removed. It returns the number of objects downloaded.
+func Download(ctx context.Context, store Store, prefix, dir string) (int, error) {
+	keys, err := store.List(ctx, prefix)
+	if err != nil {
+		return 0, fmt.Errorf("list %s: %w", prefix, err)
+	}
+
+	for i, key := range keys {
+		rel := strings.TrimPrefix(key, prefix)
+		if err := ValidateKey(rel); err != nil {
+			return i, err
+		}
+		if err := downloadFile(ctx, store, key, filepath.Join(dir, filepath.FromSlash(rel))); err != nil {
+			return i, fmt.Errorf("download %s: %w", key, err)
+		}
+	}
+	return len(keys), nil
+}
+
+// downloadFile copies one object to path.
+func downloadFile(ctx context.Context, store Store, key, path string) error {
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,80 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readBlob(t *testing.T, store Store, key string) string {
+	t.Helper()
+	r, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileStore(filepath.Join(t.TempDir(), "blobs"))
+
+	keys, err := store.List(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, keys, "missing root lists nothing")
+
+	require.NoError(t, store.Put(ctx, "exports/2025-12-01.csv", strings.NewReader("v1")))
+	require.NoError(t, store.Put(ctx, "exports/2025-12-01.csv", strings.NewReader("v2")))
+	require.NoError(t, store.Put(ctx, "mock/garuda.json", strings.NewReader("{}")))
+	assert.Equal(t, "v2", readBlob(t, store, "exports/2025-12-01.csv"))
+
+	keys, err = store.List(ctx, "exports/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exports/2025-12-01.csv"}, keys)
+
+	require.NoError(t, store.Delete(ctx, "exports/2025-12-01.csv"))
+	require.NoError(t, store.Delete(ctx, "exports/2025-12-01.csv"), "deleting a missing blob is not an error")
+	_, err = store.Get(ctx, "exports/2025-12-01.csv")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"a.csv", "exports/a.csv", "a/b/c"} {
+		assert.NoError(t, ValidateKey(key), key)
+	}
+	for _, key := range []string{"", "/abs", "a//b", "../escape", "a/./b", "dir/", `a\b`} {
+		assert.ErrorIs(t, ValidateKey(key), ErrInvalidKey, key)
+	}
+
+	store := NewFileStore(t.TempDir())
+	assert.ErrorIs(t, store.Put(context.Background(), "../escape", strings.NewReader("")), ErrInvalidKey)
+}
+
+func TestUploadDownload(t *testing.T) {
+	ctx := context.Background()
+	src := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "garuda.json"), []byte(`{"g":1}`), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "extra"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "extra", "lion.json"), []byte(`{"l":1}`), 0o600))
+
+	store := NewFileStore(t.TempDir())
+	n, err := Upload(ctx, store, src, "mock/v1/")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, `{"l":1}`, readBlob(t, store, "mock/v1/extra/lion.json"))
+
+	dst := t.TempDir()
+	n, err = Download(ctx, store, "mock/v1/", dst)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	data, err := os.ReadFile(filepath.Join(dst, "extra", "lion.json"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"l":1}`, string(data))
+}
@@ -0,0 +1,149 @@
+// Package tenant holds per-API-key configuration for partner integrations.
+// Each API key maps to a Profile whose defaults are applied to requests that
+// omit the corresponding parameters.
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+// Defaults are applied to search requests that omit the corresponding fields.
+type Defaults struct {
+	// SortBy is the default sort option (e.g., "price")
+	SortBy string `json:"sortBy,omitempty"`
+
+	// MaxResults is the default maximum number of flights returned (0 = unlimited)
+	MaxResults int `json:"maxResults,omitempty"`
+
+	// Fields is the default list of flight fields included in responses (empty = all)
+	Fields []string `json:"fields,omitempty"`
+
+	// FanOutStrategy is the default provider fan-out strategy, e.g.
+	// "sequential-failover" for partners paying per provider call
+	FanOutStrategy string `json:"fanOutStrategy,omitempty"`
+}
+
+// Profile is the configuration associated with an API key.
+type Profile struct {
+	// Key is the API key sent by the partner in the X-API-Key header
+	Key string `json:"key"`
+
+	// Name is a human-readable partner name used in logs
+	Name string `json:"name"`
+
+	// Class is the request class of the partner's searches, selecting their
+	// timeout budget (e.g., "batch"; empty = interactive)
+	Class domain.RequestClass `json:"class,omitempty"`
+
+	// Roles are the API roles granted to the key (empty = the configured default roles)
+	Roles []rbac.Role `json:"roles,omitempty"`
+
+	// Defaults are applied when a request omits them
+	Defaults Defaults `json:"defaults"`
+}
+
+// Registry resolves API keys to profiles.
+type Registry struct {
+	profiles map[string]Profile
+}
+
+// NewRegistry creates a registry from the given profiles.
+// Returns an error if a key is empty or duplicated, or if defaults are invalid.
+func NewRegistry(profiles ...Profile) (*Registry, error) {
+	r := &Registry{profiles: make(map[string]Profile, len(profiles))}
+	for i, p := range profiles {
+		if p.Key == "" {
+			return nil, fmt.Errorf("profile %d: key is required", i)
+		}
+		if _, exists := r.profiles[p.Key]; exists {
+			return nil, fmt.Errorf("profile %d (%s): duplicate key", i, p.Name)
+		}
+		if p.Class != "" && !p.Class.IsValid() {
+			return nil, fmt.Errorf("profile %d (%s): invalid class %q", i, p.Name, p.Class)
+		}
+		for _, role := range p.Roles {
+			if !role.IsValid() {
+				return nil, fmt.Errorf("profile %d (%s): invalid role %q", i, p.Name, role)
+			}
+			if !role.IsPartnerRole() {
+				return nil, fmt.Errorf("profile %d (%s): role %q cannot be granted to API keys", i, p.Name, role)
+			}
+		}
+		if err := p.Defaults.validate(); err != nil {
+			return nil, fmt.Errorf("profile %d (%s): %w", i, p.Name, err)
+		}
+		r.profiles[p.Key] = p
+	}
+	return r, nil
+}
+
+// fileFormat is the JSON layout of an API key configuration file.
+type fileFormat struct {
+	Keys []Profile `json:"keys"`
+}
+
+// LoadFile reads a registry from a JSON file of the form {"keys": [Profile, ...]}.
+func LoadFile(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read api key file: %w", err)
+	}
+
+	var f fileFormat
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse api key file: %w", err)
+	}
+
+	return NewRegistry(f.Keys...)
+}
+
+// Lookup returns the profile for an API key.
+func (r *Registry) Lookup(key string) (Profile, bool) {
+	if r == nil || key == "" {
+		return Profile{}, false
+	}
+	p, ok := r.profiles[key]
+	return p, ok
+}
+
+// Len returns the number of registered API keys.
+func (r *Registry) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.profiles)
+}
+
+// validate checks the defaults for correctness.
+func (d Defaults) validate() error {
+	if d.SortBy != "" && !domain.SortOption(d.SortBy).IsValid() {
+		return fmt.Errorf("invalid default sortBy %q", d.SortBy)
+	}
+	if d.FanOutStrategy != "" && !domain.FanOutStrategy(d.FanOutStrategy).IsValid() {
+		return fmt.Errorf("invalid default fanOutStrategy %q", d.FanOutStrategy)
+	}
+	if d.MaxResults < 0 {
+		return fmt.Errorf("default maxResults must not be negative, got %d", d.MaxResults)
+	}
+	return nil
+}
+
+// profileKey is the context key for the request's profile.
+type profileKey struct{}
+
+// WithProfile returns a copy of ctx carrying the profile.
+func WithProfile(ctx context.Context, p Profile) context.Context {
+	return context.WithValue(ctx, profileKey{}, p)
+}
+
+// FromContext returns the profile carried by ctx, if any.
+func FromContext(ctx context.Context) (Profile, bool) {
+	p, ok := ctx.Value(profileKey{}).(Profile)
+	return p, ok
+}
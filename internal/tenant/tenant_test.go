@@ -0,0 +1,125 @@
+package tenant
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+func TestNewRegistry(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles []Profile
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			profiles: []Profile{{Key: "a", Defaults: Defaults{SortBy: "price", MaxResults: 10}}, {Key: "b", Class: "batch", Roles: []rbac.Role{rbac.RoleSearch, rbac.RoleExport}}},
+		},
+		{
+			name:     "empty key",
+			profiles: []Profile{{Name: "partner"}},
+			wantErr:  "key is required",
+		},
+		{
+			name:     "duplicate key",
+			profiles: []Profile{{Key: "a"}, {Key: "a"}},
+			wantErr:  "duplicate key",
+		},
+		{
+			name:     "invalid sort",
+			profiles: []Profile{{Key: "a", Defaults: Defaults{SortBy: "cheapest"}}},
+			wantErr:  "invalid default sortBy",
+		},
+		{
+			name:     "invalid fan-out strategy",
+			profiles: []Profile{{Key: "a", Defaults: Defaults{FanOutStrategy: "cheapest-first"}}},
+			wantErr:  "invalid default fanOutStrategy",
+		},
+		{
+			name:     "invalid class",
+			profiles: []Profile{{Key: "a", Class: "bulk"}},
+			wantErr:  "invalid class",
+		},
+		{
+			name:     "invalid role",
+			profiles: []Profile{{Key: "a", Roles: []rbac.Role{"superuser"}}},
+			wantErr:  `invalid role "superuser"`,
+		},
+		{
+			name:     "admin role",
+			profiles: []Profile{{Key: "a", Roles: []rbac.Role{rbac.RoleAdmin}}},
+			wantErr:  `role "admin" cannot be granted to API keys`,
+		},
+		{
+			name:     "negative max results",
+			profiles: []Profile{{Key: "a", Defaults: Defaults{MaxResults: -1}}},
+			wantErr:  "must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := NewRegistry(tt.profiles...)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.profiles), registry.Len())
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	content := `{"keys": [{"key": "k1", "name": "travel-partner", "class": "batch", "roles": ["search", "export"], "defaults": {"sortBy": "price", "maxResults": 5, "fields": ["price"]}}]}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	registry, err := LoadFile(path)
+	require.NoError(t, err)
+
+	profile, ok := registry.Lookup("k1")
+	require.True(t, ok)
+	assert.Equal(t, "travel-partner", profile.Name)
+	assert.Equal(t, domain.RequestClassBatch, profile.Class)
+	assert.Equal(t, []rbac.Role{rbac.RoleSearch, rbac.RoleExport}, profile.Roles)
+	assert.Equal(t, Defaults{SortBy: "price", MaxResults: 5, Fields: []string{"price"}}, profile.Defaults)
+
+	_, ok = registry.Lookup("unknown")
+	assert.False(t, ok)
+}
+
+func TestLoadFile_Errors(t *testing.T) {
+	_, err := LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "read api key file")
+
+	path := filepath.Join(t.TempDir(), "bad.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = LoadFile(path)
+	assert.ErrorContains(t, err, "parse api key file")
+}
+
+func TestLookup_NilRegistry(t *testing.T) {
+	var registry *Registry
+	_, ok := registry.Lookup("k1")
+	assert.False(t, ok)
+	assert.Zero(t, registry.Len())
+}
+
+func TestProfileContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithProfile(context.Background(), Profile{Key: "k1"})
+	profile, ok := FromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "k1", profile.Key)
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+// EnrichAirlines fills the airline name, logo and alliance of each flight
+// from the airline reference data, in English, where the provider left them
+// blank, so clients need no airline lookup of their own. Airlines missing
+// from the reference data keep what the provider reported.
+// Does NOT mutate the original flights slice.
+func EnrichAirlines(flights []domain.Flight) []domain.Flight {
+	result := make([]domain.Flight, len(flights))
+	for i, f := range flights {
+		f.Airline = enrichAirline(f.Airline)
+		result[i] = f
+	}
+	return result
+}
+
+// enrichAirline fills the blank airline details of a from the airline
+// reference data.
+func enrichAirline(a domain.AirlineInfo) domain.AirlineInfo {
+	airline, ok := reference.LookupAirline(a.Code)
+	if !ok {
+		return a
+	}
+	if a.Name == "" {
+		a.Name = airline.Name.In(reference.LocaleEnglish)
+	}
+	if a.Logo == "" {
+		a.Logo = airline.Logo
+	}
+	if a.Alliance == "" {
+		a.Alliance = airline.Alliance
+	}
+	return a
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+func TestEnrichAirlines(t *testing.T) {
+	garuda := createTestFlight("1", "garuda", 1000000, 120, 0)
+	garuda.Airline = domain.AirlineInfo{Code: "GA", Name: "Garuda"}
+	unnamed := createTestFlight("2", "lionair", 1000000, 120, 0)
+	unnamed.Airline = domain.AirlineInfo{Code: "JT"}
+	unknown := createTestFlight("3", "batik", 1000000, 120, 0)
+	flights := []domain.Flight{garuda, unnamed, unknown}
+
+	enriched := EnrichAirlines(flights)
+
+	assert.Equal(t, domain.AirlineInfo{
+		Code:     "GA",
+		Name:     "Garuda",
+		Logo:     "https://pics.avs.io/200/200/GA.png",
+		Alliance: reference.AllianceSkyTeam,
+	}, enriched[0].Airline, "provider name kept")
+	assert.Equal(t, "Lion Air", enriched[1].Airline.Name)
+	assert.Empty(t, enriched[1].Airline.Alliance)
+	assert.Equal(t, unknown.Airline, enriched[2].Airline, "unknown airlines left as reported")
+
+	assert.Empty(t, flights[0].Airline.Logo, "original flights untouched")
+}
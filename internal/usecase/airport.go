@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+// EnrichAirports fills the airport name, city, country and timezone of the
+// departure and arrival of each flight from the airport reference data, in
+// English, where the provider left them blank. Providers such as Batik Air
+// and AirAsia report only IATA codes. Airports missing from the reference
+// data keep what the provider reported.
+// Does NOT mutate the original flights slice.
+func EnrichAirports(flights []domain.Flight) []domain.Flight {
+	result := make([]domain.Flight, len(flights))
+	for i, f := range flights {
+		f.Departure = enrichFlightPoint(f.Departure)
+		f.Arrival = enrichFlightPoint(f.Arrival)
+		result[i] = f
+	}
+	return result
+}
+
+// enrichFlightPoint fills the blank airport details of p from the airport
+// reference data.
+func enrichFlightPoint(p domain.FlightPoint) domain.FlightPoint {
+	airport, ok := reference.LookupAirport(p.AirportCode)
+	if !ok {
+		return p
+	}
+	if p.AirportName == "" {
+		p.AirportName = airport.Name.In(reference.LocaleEnglish)
+	}
+	if p.City == "" {
+		p.City = airport.City.In(reference.LocaleEnglish)
+	}
+	if p.Country == "" {
+		p.Country = airport.Country
+	}
+	if p.Timezone == "" {
+		p.Timezone = airport.Timezone
+	}
+	return p
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+func TestEnrichAirports(t *testing.T) {
+	codesOnly := createTestFlight("1", "batik", 1000000, 120, 0)
+	reported := createTestFlight("2", "garuda", 1000000, 120, 0)
+	reported.Departure.AirportName = "Jakarta (CGK)"
+	reported.Departure.City = "Jakarta"
+	reported.Departure.Timezone = "Asia/Jakarta"
+	unknown := createTestFlight("3", "lionair", 1000000, 120, 0)
+	unknown.Arrival.AirportCode = "XXX"
+	flights := []domain.Flight{codesOnly, reported, unknown}
+
+	enriched := EnrichAirports(flights)
+
+	assert.Equal(t, domain.FlightPoint{
+		AirportCode: "CGK",
+		AirportName: "Soekarno-Hatta International Airport",
+		City:        "Jakarta",
+		Country:     "ID",
+		DateTime:    codesOnly.Departure.DateTime,
+		Timezone:    "Asia/Jakarta",
+	}, enriched[0].Departure)
+	assert.Equal(t, "I Gusti Ngurah Rai International Airport", enriched[0].Arrival.AirportName)
+	assert.Equal(t, "Asia/Makassar", enriched[0].Arrival.Timezone)
+
+	assert.Equal(t, "Jakarta (CGK)", enriched[1].Departure.AirportName, "provider details kept")
+	assert.Equal(t, "ID", enriched[1].Departure.Country, "blank details filled")
+
+	assert.Equal(t, unknown.Arrival, enriched[2].Arrival, "unknown airports left as reported")
+
+	assert.Empty(t, flights[0].Departure.AirportName, "original flights untouched")
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/tracing"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+// auditingUseCase decorates a FlightSearchUseCase, logging every search,
+// failed ones included, to the search audit log.
+type auditingUseCase struct {
+	next   FlightSearchUseCase
+	logger domain.AuditLogger
+	clock  timeutil.Clock
+}
+
+// NewAuditingUseCase wraps next so every search is logged to logger with its
+// caller, parameters, latency and provider outcomes. Logging never delays
+// nor fails the search.
+func NewAuditingUseCase(next FlightSearchUseCase, logger domain.AuditLogger, clock timeutil.Clock) FlightSearchUseCase {
+	if clock == nil {
+		clock = timeutil.NewRealClock()
+	}
+	return &auditingUseCase{
+		next:   next,
+		logger: logger,
+		clock:  clock,
+	}
+}
+
+// Search executes the search and logs it.
+func (uc *auditingUseCase) Search(ctx context.Context, criteria domain.SearchCriteria, opts SearchOptions) (*domain.SearchResponse, error) {
+	started := uc.clock.Now()
+	result, err := uc.next.Search(ctx, criteria, opts)
+
+	entry := domain.SearchAuditEntry{
+		At:        started,
+		Client:    rbac.Anonymous,
+		Criteria:  criteria,
+		Filters:   opts.Filters,
+		SortBy:    opts.SortBy,
+		Outcome:   domain.AuditOutcomeSuccess,
+		LatencyMs: uc.clock.Now().Sub(started).Milliseconds(),
+	}
+	if principal, ok := rbac.FromContext(ctx); ok {
+		entry.Client = principal.Name
+	}
+	if tc, ok := tracing.FromContext(ctx); ok {
+		entry.TraceID = tc.TraceID
+	}
+	if err != nil {
+		entry.Outcome = domain.AuditOutcomeError
+		entry.Error = err.Error()
+	} else {
+		metadata := result.Metadata
+		entry.SearchID = metadata.SearchID
+		entry.Results = metadata.TotalResults
+		entry.CacheHit = metadata.CacheHit
+		entry.ProvidersQueried = metadata.ProvidersQueried
+		entry.ProvidersSucceeded = metadata.ProvidersSucceeded
+		entry.FailedProviders = metadata.FailedProviders
+		entry.PendingProviders = metadata.PendingProviders
+		entry.SkippedProviders = metadata.SkippedProviders
+	}
+	uc.logger.Log(context.WithoutCancel(ctx), entry)
+
+	return result, err
+}
+
+// Ensure auditingUseCase implements FlightSearchUseCase at compile time.
+var _ FlightSearchUseCase = (*auditingUseCase)(nil)
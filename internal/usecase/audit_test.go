@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/tracing"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+// recordingAuditLogger keeps the entries logged to it.
+type recordingAuditLogger struct {
+	entries []domain.SearchAuditEntry
+}
+
+func (l *recordingAuditLogger) Log(_ context.Context, entry domain.SearchAuditEntry) {
+	l.entries = append(l.entries, entry)
+}
+
+// advancingSearch returns a search advancing clock by d before answering.
+func advancingSearch(clock *timeutil.MockClock, d time.Duration, response *domain.SearchResponse, err error) func(context.Context, domain.SearchCriteria, SearchOptions) (*domain.SearchResponse, error) {
+	return func(context.Context, domain.SearchCriteria, SearchOptions) (*domain.SearchResponse, error) {
+		clock.Advance(d)
+		return response, err
+	}
+}
+
+func TestAuditingUseCase_LogsSearches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := NewMockFlightSearchUseCase(ctrl)
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	logger := &recordingAuditLogger{}
+	uc := NewAuditingUseCase(next, logger, clock)
+
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-20", Passengers: 2}
+	opts := SearchOptions{SortBy: domain.SortByPrice, Filters: &domain.FilterOptions{MaxStops: new(int)}}
+	response := domain.NewSearchResponse(&criteria, []domain.Flight{createTestFlight("1", "garuda", 1000000, 120, 0)}, domain.SearchMetadata{
+		ProvidersQueried: 3, ProvidersSucceeded: 1, ProvidersFailed: 1, SearchID: "search-1",
+		FailedProviders: []string{"lion_air"}, PendingProviders: []string{"airasia"},
+	})
+	next.EXPECT().Search(gomock.Any(), criteria, opts).DoAndReturn(advancingSearch(clock, 250*time.Millisecond, &response, nil))
+
+	ctx := rbac.WithPrincipal(context.Background(), rbac.Principal{Name: "partner-app"})
+	ctx = tracing.WithTraceContext(ctx, tracing.TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"})
+	result, err := uc.Search(ctx, criteria, opts)
+	require.NoError(t, err)
+	assert.Same(t, &response, result)
+
+	require.Len(t, logger.entries, 1)
+	assert.Equal(t, domain.SearchAuditEntry{
+		At:                 time.Date(2025, 12, 15, 10, 0, 0, 0, time.UTC),
+		Client:             "partner-app",
+		TraceID:            "4bf92f3577b34da6a3ce929d0e0e4736",
+		SearchID:           "search-1",
+		Criteria:           criteria,
+		Filters:            opts.Filters,
+		SortBy:             domain.SortByPrice,
+		Outcome:            domain.AuditOutcomeSuccess,
+		LatencyMs:          250,
+		Results:            1,
+		ProvidersQueried:   3,
+		ProvidersSucceeded: 1,
+		FailedProviders:    []string{"lion_air"},
+		PendingProviders:   []string{"airasia"},
+	}, logger.entries[0])
+}
+
+func TestAuditingUseCase_LogsFailedSearches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := NewMockFlightSearchUseCase(ctrl)
+	clock := timeutil.NewMockClockFromString("2025-12-15T10:00:00Z")
+	logger := &recordingAuditLogger{}
+	uc := NewAuditingUseCase(next, logger, clock)
+
+	next.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(advancingSearch(clock, 2*time.Second, nil, domain.ErrAllProvidersFailed))
+
+	_, err := uc.Search(context.Background(), domain.SearchCriteria{Origin: "CGK"}, SearchOptions{})
+	assert.True(t, errors.Is(err, domain.ErrAllProvidersFailed))
+
+	require.Len(t, logger.entries, 1)
+	entry := logger.entries[0]
+	assert.Equal(t, rbac.Anonymous, entry.Client)
+	assert.Equal(t, domain.AuditOutcomeError, entry.Outcome)
+	assert.Equal(t, "all providers failed", entry.Error)
+	assert.Equal(t, int64(2000), entry.LatencyMs)
+	assert.Empty(t, entry.SearchID)
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+)
+
+// CacheDrift compares the cached results served for a search with the
+// results the providers return for it live.
+type CacheDrift struct {
+	// Criteria are the search criteria
+	Criteria domain.SearchCriteria
+
+	// Age is how old the cached results were when served
+	Age time.Duration
+
+	// Err is domain.ErrAllProvidersFailed when no provider answered the live search
+	Err error
+
+	// FailedProviders are the providers failing the live search; their
+	// cached flights are left out of the comparison
+	FailedProviders []string
+
+	// CachedFlights is the number of cached flights compared
+	CachedFlights int
+
+	// LiveFlights is the number of flights the live search returned
+	LiveFlights int
+
+	// Matched is the number of live flights also in the cached results
+	// (same provider and flight ID)
+	Matched int
+
+	// Priced is the number of matched flights priced in the same currency
+	// in both results
+	Priced int
+
+	// Repriced is the number of priced flights whose price changed
+	Repriced int
+
+	// PriceDrift is the mean relative price drift of the priced flights,
+	// (live - cached) / cached; 0.05 means 5% more expensive live
+	PriceDrift float64
+}
+
+// Coverage is the share of live flights the cached results offered, 1 when
+// the live search returned no flights.
+func (d CacheDrift) Coverage() float64 {
+	if d.LiveFlights == 0 {
+		return 1
+	}
+	return float64(d.Matched) / float64(d.LiveFlights)
+}
+
+// Stale is the number of cached flights the live search no longer returned.
+func (d CacheDrift) Stale() int {
+	return d.CachedFlights - d.Matched
+}
+
+// checkCacheDrift runs a sample of the searches answered from the cache live
+// as well, in the background, and reports how far the cached results drifted
+// from the live ones. The live results are not cached and the response is not
+// held up. Drift checks are skipped while the shadow feature is shed under load.
+// It reports whether the search was sampled.
+func (uc *flightSearchUseCase) checkCacheDrift(ctx context.Context, criteria domain.SearchCriteria, hints domain.ProviderSearchOptions, cached *domain.CachedSearch) bool {
+	if uc.cacheDriftObserver == nil || uc.cacheDriftSampleRate <= 0 || rand.Float64() >= uc.cacheDriftSampleRate {
+		return false
+	}
+	if !loadshed.Allowed(ctx, loadshed.FeatureShadow) {
+		return false
+	}
+
+	age := time.Since(cached.CachedAt)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), uc.globalTimeout)
+	results := make(chan providerResult, len(uc.providers))
+	for _, provider := range uc.providers {
+		go uc.queryProvider(ctx, provider, criteria, hints, results)
+	}
+
+	go func() {
+		defer cancel()
+		live := make([]providerResult, 0, len(uc.providers))
+		for range uc.providers {
+			live = append(live, <-results)
+		}
+		drift := compareCacheDrift(criteria, cached.Flights, live)
+		drift.Age = age
+		uc.cacheDriftObserver(drift)
+	}()
+	return true
+}
+
+// cacheDriftKey identifies a flight of a provider.
+type cacheDriftKey struct {
+	provider string
+	id       string
+}
+
+// compareCacheDrift compares cached flights with the live results of each provider.
+func compareCacheDrift(criteria domain.SearchCriteria, cached []domain.Flight, live []providerResult) CacheDrift {
+	drift := CacheDrift{Criteria: criteria}
+
+	failed := make(map[string]bool)
+	for _, r := range live {
+		if r.Error != nil {
+			failed[r.Provider] = true
+			drift.FailedProviders = append(drift.FailedProviders, r.Provider)
+		}
+	}
+	if len(failed) == len(live) {
+		drift.Err = domain.ErrAllProvidersFailed
+		return drift
+	}
+
+	cachedByKey := make(map[cacheDriftKey]domain.Flight, len(cached))
+	for _, f := range cached {
+		if failed[f.Provider] {
+			continue
+		}
+		cachedByKey[cacheDriftKey{f.Provider, f.ID}] = f
+	}
+	drift.CachedFlights = len(cachedByKey)
+
+	var driftSum float64
+	for _, r := range live {
+		if r.Error != nil {
+			continue
+		}
+		drift.LiveFlights += len(r.Flights)
+		for _, f := range r.Flights {
+			key := cacheDriftKey{f.Provider, f.ID}
+			match, ok := cachedByKey[key]
+			if !ok {
+				continue
+			}
+			// A flight listed twice live matches once
+			delete(cachedByKey, key)
+			drift.Matched++
+			if match.Price.Currency != f.Price.Currency || match.Price.Amount <= 0 {
+				continue
+			}
+			drift.Priced++
+			if f.Price.Amount != match.Price.Amount {
+				drift.Repriced++
+				driftSum += (f.Price.Amount - match.Price.Amount) / match.Price.Amount
+			}
+		}
+	}
+	if drift.Priced > 0 {
+		drift.PriceDrift = driftSum / float64(drift.Priced)
+	}
+	return drift
+}
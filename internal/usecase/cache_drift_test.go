@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+)
+
+func TestSearch_CacheDrift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15"}
+	cache := &searchCache{entries: map[string]*domain.CachedSearch{
+		domain.CriteriaHash(criteria): {
+			Flights: []domain.Flight{
+				createTestFlight("1", "provider1", 1000000, 120, 0),
+				createTestFlight("2", "provider1", 2000000, 120, 0),
+				createTestFlight("3", "provider2", 500000, 120, 0),
+			},
+			Providers: 2,
+			CachedAt:  time.Now().Add(-time.Minute),
+		},
+	}}
+	providers := []domain.FlightProvider{
+		setupMockProviderWithDelay(ctrl, "provider1", []domain.Flight{
+			createTestFlight("1", "provider1", 1100000, 120, 0),
+			createTestFlight("4", "provider1", 900000, 120, 0),
+		}, 200*time.Millisecond),
+		setupMockProvider(ctrl, "provider2", nil, errors.New("bad gateway")),
+	}
+
+	drifts := make(chan CacheDrift, 1)
+	uc := NewFlightSearchUseCase(providers, &Config{
+		Cache:                cache,
+		CacheDriftSampleRate: 1,
+		CacheDriftObserver:   func(d CacheDrift) { drifts <- d },
+	})
+
+	start := time.Now()
+	response, err := uc.Search(context.Background(), criteria, SearchOptions{})
+	require.NoError(t, err)
+	assert.True(t, response.Metadata.CacheHit)
+	assert.Less(t, time.Since(start), 200*time.Millisecond, "the live search does not hold up the response")
+	assert.Len(t, response.Flights, 3, "cached results are served")
+
+	var drift CacheDrift
+	select {
+	case drift = <-drifts:
+	case <-time.After(time.Second):
+		t.Fatal("cache drift not observed")
+	}
+	assert.Equal(t, criteria, drift.Criteria)
+	assert.GreaterOrEqual(t, drift.Age, time.Minute)
+	assert.NoError(t, drift.Err)
+	assert.Equal(t, []string{"provider2"}, drift.FailedProviders)
+	assert.Equal(t, 2, drift.CachedFlights, "flights of failed providers are not compared")
+	assert.Equal(t, 2, drift.LiveFlights)
+	assert.Equal(t, 1, drift.Matched)
+	assert.Equal(t, 1, drift.Stale())
+	assert.Equal(t, 0.5, drift.Coverage())
+	assert.Equal(t, 1, drift.Priced)
+	assert.Equal(t, 1, drift.Repriced)
+	assert.InDelta(t, 0.1, drift.PriceDrift, 1e-9)
+
+	assert.Empty(t, cache.ttls, "live results are not cached")
+}
+
+func TestSearch_CacheDriftNotSampled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	criteria := domain.SearchCriteria{Origin: "CGK"}
+	newCache := func() *searchCache {
+		return &searchCache{entries: map[string]*domain.CachedSearch{
+			domain.CriteriaHash(criteria): {Flights: []domain.Flight{createTestFlight("1", "provider1", 1000000, 120, 0)}, Providers: 1},
+		}}
+	}
+
+	// The provider must not be queried
+	provider := domain.NewMockFlightProvider(ctrl)
+	provider.EXPECT().Name().Return("provider1").AnyTimes()
+
+	drifts := make(chan CacheDrift, 1)
+	observe := func(d CacheDrift) { drifts <- d }
+	tests := []struct {
+		name   string
+		config *Config
+		ctx    context.Context
+	}{
+		{"zero rate", &Config{Cache: newCache(), CacheDriftObserver: observe}, context.Background()},
+		{"no observer", &Config{Cache: newCache(), CacheDriftSampleRate: 1}, context.Background()},
+		{
+			"shed under load",
+			&Config{Cache: newCache(), CacheDriftSampleRate: 1, CacheDriftObserver: observe},
+			loadshed.WithShed(context.Background(), []string{loadshed.FeatureShadow}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, tt.config)
+			response, err := uc.Search(tt.ctx, criteria, SearchOptions{})
+			require.NoError(t, err)
+			assert.True(t, response.Metadata.CacheHit)
+		})
+	}
+	assert.Empty(t, drifts)
+}
+
+func TestCompareCacheDrift_AllProvidersFailed(t *testing.T) {
+	drift := compareCacheDrift(domain.SearchCriteria{}, []domain.Flight{createTestFlight("1", "provider1", 1000000, 120, 0)}, []providerResult{
+		{Provider: "provider1", Error: errors.New("timeout")},
+	})
+	assert.ErrorIs(t, drift.Err, domain.ErrAllProvidersFailed)
+	assert.Zero(t, drift.CachedFlights)
+	assert.Equal(t, 1.0, drift.Coverage())
+}
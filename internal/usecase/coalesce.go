@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// searchGroup coalesces concurrent identical searches, singleflight style:
+// the first search of a key runs the provider fan-out, and searches of the
+// same key arriving while it runs wait for its result instead of querying the
+// providers again. It is safe for concurrent use.
+//
+// Unlike a plain singleflight, the fan-out does not belong to the search that
+// started it: it runs detached from the callers and is only cancelled once
+// every waiting search has gone away.
+type searchGroup struct {
+	mu    sync.Mutex
+	calls map[string]*sharedFanOut
+}
+
+// sharedFanOut is a fan-out in flight and the searches waiting for it.
+type sharedFanOut struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int // guarded by the group's mutex
+
+	// result and err are set before done is closed
+	result fanOutResult
+	err    error
+}
+
+// newSearchGroup creates an empty search group.
+func newSearchGroup() *searchGroup {
+	return &searchGroup{calls: make(map[string]*sharedFanOut)}
+}
+
+// do returns the result of the fan-out in flight for key, starting one with
+// run if there is none. Each caller gets its own copy of the outcome. A caller
+// whose context ends stops waiting; the fan-out is cancelled when the last
+// caller stops waiting.
+func (g *searchGroup) do(ctx context.Context, key string, run func(ctx context.Context) (fanOutResult, error)) (fanOutResult, error) {
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if !ok {
+		// Keep the context values (request class, tracing) of the first search
+		runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &sharedFanOut{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go func() {
+			defer cancel()
+			call.result, call.err = run(runCtx)
+			g.mu.Lock()
+			g.forgetLocked(key, call)
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.err != nil {
+			return fanOutResult{}, call.err
+		}
+		result := call.result
+		result.outcome = result.outcome.clone()
+		return result, nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Nobody is waiting any more: stop querying the providers, and
+			// let the next identical search start afresh
+			call.cancel()
+			g.forgetLocked(key, call)
+		}
+		g.mu.Unlock()
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return fanOutResult{}, fmt.Errorf("%w: %w", domain.ErrSearchAborted, ctx.Err())
+		}
+		return fanOutResult{}, ctx.Err()
+	}
+}
+
+// forgetLocked removes call from the group if it is still the call of key.
+// The caller must hold g.mu.
+func (g *searchGroup) forgetLocked(key string, call *sharedFanOut) {
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// gatedProvider answers once release is closed, counting its calls.
+type gatedProvider struct {
+	calls     atomic.Int32
+	release   chan struct{}
+	cancelled chan struct{}
+	once      sync.Once
+}
+
+func newGatedProvider() *gatedProvider {
+	return &gatedProvider{release: make(chan struct{}), cancelled: make(chan struct{})}
+}
+
+func (p *gatedProvider) Name() string { return "gated" }
+
+func (p *gatedProvider) Search(ctx context.Context, _ domain.SearchCriteria) ([]domain.Flight, error) {
+	p.calls.Add(1)
+	select {
+	case <-p.release:
+		return []domain.Flight{
+			createTestFlight("1", "gated", 1000000, 120, 0),
+			createTestFlight("2", "gated", 800000, 90, 0),
+		}, nil
+	case <-ctx.Done():
+		p.once.Do(func() { close(p.cancelled) })
+		return nil, ctx.Err()
+	}
+}
+
+// waitForCalls waits until the provider has been called n times.
+func waitForCalls(t *testing.T, p *gatedProvider, n int32) {
+	t.Helper()
+	require.Eventually(t, func() bool { return p.calls.Load() >= n }, time.Second, time.Millisecond)
+}
+
+func TestSearch_CoalescesIdenticalSearches(t *testing.T) {
+	provider := newGatedProvider()
+	uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, nil)
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1}
+
+	const searches = 5
+	responses := make([]*domain.SearchResponse, searches)
+	var wg sync.WaitGroup
+	for i := range searches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Options differ per search: only the provider fan-out is shared
+			response, err := uc.Search(context.Background(), criteria, SearchOptions{MaxResults: 1 + i%2})
+			assert.NoError(t, err)
+			responses[i] = response
+		}()
+	}
+
+	waitForCalls(t, provider, 1)
+	time.Sleep(20 * time.Millisecond) // let the other searches join
+	close(provider.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), provider.calls.Load(), "one upstream call for identical searches")
+	for i, response := range responses {
+		require.NotNil(t, response)
+		assert.Len(t, response.Flights, 1+i%2)
+		assert.Equal(t, 1, response.Metadata.ProvidersSucceeded)
+	}
+
+	// Once the shared search is over, the next search queries the providers again
+	_, err := uc.Search(context.Background(), criteria, SearchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), provider.calls.Load())
+}
+
+func TestSearch_CoalescingNotShared(t *testing.T) {
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1}
+	other := criteria
+	other.DepartureDate = "2025-12-16"
+
+	tests := []struct {
+		name   string
+		config *Config
+		second domain.SearchCriteria
+		opts   SearchOptions
+	}{
+		{name: "different criteria", second: other},
+		{name: "partial results accepted", second: criteria, opts: SearchOptions{PartialResultsOK: true}},
+		{name: "coalescing disabled", config: &Config{DisableCoalescing: true}, second: criteria},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newGatedProvider()
+			uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, tt.config)
+
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := uc.Search(context.Background(), criteria, tt.opts)
+				assert.NoError(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := uc.Search(context.Background(), tt.second, tt.opts)
+				assert.NoError(t, err)
+			}()
+
+			waitForCalls(t, provider, 2)
+			close(provider.release)
+			wg.Wait()
+			assert.Equal(t, int32(2), provider.calls.Load())
+		})
+	}
+}
+
+func TestSearch_CoalescedFanOutCancelledWithLastSearch(t *testing.T) {
+	provider := newGatedProvider()
+	uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, nil)
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() {
+		_, err := uc.Search(first, criteria, SearchOptions{})
+		errs <- err
+	}()
+	waitForCalls(t, provider, 1)
+	go func() {
+		_, err := uc.Search(second, criteria, SearchOptions{})
+		errs <- err
+	}()
+	time.Sleep(20 * time.Millisecond) // let the second search join
+
+	// The search that started the fan-out leaves: the other one still waits
+	cancelFirst()
+	assert.ErrorIs(t, <-errs, domain.ErrSearchAborted)
+	select {
+	case <-provider.cancelled:
+		t.Fatal("shared fan-out cancelled while a search still waits for it")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancelSecond()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-provider.cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("shared fan-out still running after every search left")
+	}
+	assert.Equal(t, int32(1), provider.calls.Load())
+}
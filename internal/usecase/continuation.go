@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// DefaultContinuationTTL is how long partial searches are held when no TTL is given.
+const DefaultContinuationTTL = 30 * time.Second
+
+// ContinuationUseCase returns the results of providers that answered after a
+// partial response.
+type ContinuationUseCase interface {
+	// Continue returns the partial search's results merged with the results
+	// received since, re-ranked with the original options. Providers still
+	// running are reported as pending. Returns domain.ErrContinuationNotFound
+	// for unknown or expired tokens.
+	Continue(ctx context.Context, token string) (*domain.SearchResponse, error)
+}
+
+// ContinuationBuffer holds partial searches while their pending providers
+// finish, collecting the late results. Searches are held for the TTL after
+// the partial response. It is safe for concurrent use.
+type ContinuationBuffer struct {
+	ttl   time.Duration
+	clock timeutil.Clock
+
+	mu      sync.Mutex
+	entries map[string]*continuation
+}
+
+// continuation is a held partial search.
+type continuation struct {
+	criteria  domain.SearchCriteria
+	opts      SearchOptions
+	routing   domain.RoutingPolicy
+	ranking   func(RankingViolation)
+	providers int
+	features  []string
+	started   time.Time
+	expiresAt time.Time
+
+	// outcome is updated as pending providers answer; guarded by the buffer's mutex
+	outcome searchOutcome
+}
+
+// NewContinuationBuffer creates a buffer holding partial searches for ttl.
+// A ttl of 0 uses DefaultContinuationTTL.
+func NewContinuationBuffer(ttl time.Duration, clock timeutil.Clock) *ContinuationBuffer {
+	if ttl <= 0 {
+		ttl = DefaultContinuationTTL
+	}
+	if clock == nil {
+		clock = timeutil.NewRealClock()
+	}
+	return &ContinuationBuffer{
+		ttl:     ttl,
+		clock:   clock,
+		entries: make(map[string]*continuation),
+	}
+}
+
+// hold stores a partial search and returns its continuation token. The late
+// results are read from results until it is closed, then done is called.
+func (b *ContinuationBuffer) hold(c *continuation, results <-chan providerResult, done func()) string {
+	token := uuid.NewString()
+	now := b.clock.Now()
+	c.expiresAt = now.Add(b.ttl)
+
+	b.mu.Lock()
+	b.expireLocked(now)
+	b.entries[token] = c
+	b.mu.Unlock()
+
+	go func() {
+		defer done()
+		for result := range results {
+			b.mu.Lock()
+			c.outcome.pending = slices.DeleteFunc(c.outcome.pending, func(p string) bool { return p == result.Provider })
+			if result.Error != nil {
+				c.outcome.failed = append(c.outcome.failed, result.Provider)
+			} else {
+				c.outcome.flights = append(c.outcome.flights, result.Flights...)
+			}
+			b.mu.Unlock()
+		}
+	}()
+	return token
+}
+
+// Continue implements ContinuationUseCase.Continue. It does not wait for
+// pending providers; the token is returned again while some are pending.
+func (b *ContinuationBuffer) Continue(ctx context.Context, token string) (*domain.SearchResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	b.expireLocked(b.clock.Now())
+	c, ok := b.entries[token]
+	if !ok {
+		b.mu.Unlock()
+		return nil, domain.ErrContinuationNotFound
+	}
+	outcome := c.outcome.clone()
+	b.mu.Unlock()
+
+	outcome.elapsed = time.Since(c.started)
+	response := buildResponse(c.criteria, c.opts, c.routing, c.ranking, c.providers, outcome)
+	if len(outcome.pending) > 0 {
+		response.Metadata.ContinuationToken = token
+	}
+	response.Metadata.Features = c.features
+	return response, nil
+}
+
+// Len returns the number of held searches, including expired ones not yet removed.
+func (b *ContinuationBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.entries)
+}
+
+// expireLocked removes searches held past their TTL. The caller must hold b.mu.
+func (b *ContinuationBuffer) expireLocked(now time.Time) {
+	for token, c := range b.entries {
+		if !now.Before(c.expiresAt) {
+			delete(b.entries, token)
+		}
+	}
+}
+
+// Ensure ContinuationBuffer implements ContinuationUseCase at compile time.
+var _ ContinuationUseCase = (*ContinuationBuffer)(nil)
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// newContinuableSearch returns a use case with a fast and a late provider,
+// returning partial results before the late one answers.
+func newContinuableSearch(t *testing.T, buffer *ContinuationBuffer) FlightSearchUseCase {
+	ctrl := gomock.NewController(t)
+	providers := []domain.FlightProvider{
+		setupMockProviderWithDelay(ctrl, "fast", []domain.Flight{createTestFlight("1", "fast", 1500000, 120, 0)}, 10*time.Millisecond),
+		setupMockProviderWithDelay(ctrl, "late", []domain.Flight{createTestFlight("2", "late", 900000, 120, 0)}, 150*time.Millisecond),
+	}
+	return NewFlightSearchUseCase(providers, &Config{
+		GlobalTimeout:   3 * time.Second,
+		ProviderTimeout: 2 * time.Second,
+		SoftTimeout:     50 * time.Millisecond,
+		Continuations:   buffer,
+	})
+}
+
+func TestContinuationBuffer_Continue(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	buffer := NewContinuationBuffer(time.Minute, clock)
+	uc := newContinuableSearch(t, buffer)
+
+	// The request ends with the partial response; the late provider keeps running
+	ctx, cancel := context.WithCancel(context.Background())
+	partial, err := uc.Search(ctx, domain.SearchCriteria{}, SearchOptions{SortBy: domain.SortByPrice, PartialResultsOK: true})
+	cancel()
+	require.NoError(t, err)
+	require.Len(t, partial.Flights, 1)
+	assert.Equal(t, []string{"late"}, partial.Metadata.PendingProviders)
+	token := partial.Metadata.ContinuationToken
+	require.NotEmpty(t, token)
+
+	var continued *domain.SearchResponse
+	require.Eventually(t, func() bool {
+		continued, err = buffer.Continue(context.Background(), token)
+		require.NoError(t, err)
+		return len(continued.Metadata.PendingProviders) == 0
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// Late results are merged and re-ranked with the original options
+	require.Len(t, continued.Flights, 2)
+	assert.Equal(t, "2", continued.Flights[0].ID, "cheaper late flight sorted first")
+	assert.Equal(t, 2, continued.Metadata.ProvidersSucceeded)
+	assert.Zero(t, continued.Metadata.ProvidersFailed)
+	assert.Empty(t, continued.Metadata.ContinuationToken, "nothing left to continue")
+	assert.Equal(t, partial.Metadata.Features, continued.Metadata.Features)
+	assert.Contains(t, continued.Metadata.Features, domain.FeaturePartialResults)
+}
+
+func TestContinuationBuffer_Pending(t *testing.T) {
+	buffer := NewContinuationBuffer(time.Minute, nil)
+	uc := newContinuableSearch(t, buffer)
+
+	partial, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{PartialResultsOK: true})
+	require.NoError(t, err)
+
+	continued, err := buffer.Continue(context.Background(), partial.Metadata.ContinuationToken)
+	require.NoError(t, err)
+	assert.Len(t, continued.Flights, 1)
+	assert.Equal(t, []string{"late"}, continued.Metadata.PendingProviders)
+	assert.Equal(t, partial.Metadata.ContinuationToken, continued.Metadata.ContinuationToken)
+}
+
+func TestContinuationBuffer_Expired(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	buffer := NewContinuationBuffer(30*time.Second, clock)
+	uc := newContinuableSearch(t, buffer)
+
+	partial, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{PartialResultsOK: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, buffer.Len())
+
+	clock.Advance(30 * time.Second)
+	_, err = buffer.Continue(context.Background(), partial.Metadata.ContinuationToken)
+	assert.ErrorIs(t, err, domain.ErrContinuationNotFound)
+	assert.Zero(t, buffer.Len())
+
+	_, err = buffer.Continue(context.Background(), "unknown")
+	assert.ErrorIs(t, err, domain.ErrContinuationNotFound)
+}
+
+func TestSearch_NotContinuedWithoutPartialResults(t *testing.T) {
+	buffer := NewContinuationBuffer(time.Minute, nil)
+	uc := newContinuableSearch(t, buffer)
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+	require.NoError(t, err)
+	assert.Len(t, response.Flights, 2)
+	assert.Empty(t, response.Metadata.ContinuationToken)
+	assert.Zero(t, buffer.Len())
+}
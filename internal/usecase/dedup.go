@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// duplicateKey identifies a physical flight across providers. Offers in
+// different classes or currencies are not comparable and are kept apart.
+type duplicateKey struct {
+	airline      string
+	flightNumber string
+	departure    int64
+	arrival      int64
+	class        string
+	currency     string
+}
+
+// newDuplicateKey returns the key of f. Flight numbers are compared without
+// their separator, so "GA-400" and "GA400" are the same flight.
+func newDuplicateKey(f domain.Flight) duplicateKey {
+	number := strings.NewReplacer("-", "", " ", "").Replace(strings.ToUpper(f.FlightNumber))
+	return duplicateKey{
+		airline:      strings.ToUpper(f.Airline.Code),
+		flightNumber: number,
+		departure:    f.Departure.DateTime.Unix(),
+		arrival:      f.Arrival.DateTime.Unix(),
+		class:        strings.ToLower(f.Class),
+		currency:     strings.ToUpper(f.Price.Currency),
+	}
+}
+
+// MergeDuplicates merges the flights several providers returned for the same
+// physical flight (same airline, flight number, departure and arrival times)
+// into the cheapest offer, and returns the remaining flights and the number
+// merged away. Merged flights list every provider's offer in Offers, cheapest
+// first; the order of the flights is otherwise kept. Flights a provider
+// returned more than once are different fares and are not merged.
+// Does NOT mutate the original flights slice.
+func MergeDuplicates(flights []domain.Flight) ([]domain.Flight, int) {
+	if len(flights) < 2 {
+		return flights, 0
+	}
+
+	result := make([]domain.Flight, 0, len(flights))
+	index := make(map[duplicateKey]int, len(flights))
+	for _, f := range flights {
+		key := newDuplicateKey(f)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(result)
+		}
+		if !ok || offeredBy(result[i], f.Provider) {
+			result = append(result, f)
+			continue
+		}
+
+		merged := &result[i]
+		offers := merged.Offers
+		if offers == nil {
+			offers = []domain.Offer{newOffer(*merged)}
+		}
+		offers = append(offers, newOffer(f))
+		if f.Price.Amount < merged.Price.Amount {
+			*merged = f
+		}
+		merged.Offers = offers
+	}
+	if len(result) == len(flights) {
+		return flights, 0
+	}
+
+	for i := range result {
+		slices.SortStableFunc(result[i].Offers, func(a, b domain.Offer) int {
+			return cmp.Compare(a.Price.Amount, b.Price.Amount)
+		})
+	}
+	return result, len(flights) - len(result)
+}
+
+// offeredBy reports whether provider offers the (possibly merged) flight f.
+func offeredBy(f domain.Flight, provider string) bool {
+	if f.Offers == nil {
+		return f.Provider == provider
+	}
+	return slices.ContainsFunc(f.Offers, func(o domain.Offer) bool { return o.Provider == provider })
+}
+
+// newOffer returns f as a provider offer.
+func newOffer(f domain.Flight) domain.Offer {
+	return domain.Offer{FlightID: f.ID, Provider: f.Provider, Price: f.Price}
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// offeredFlight creates flight GA-400 as offered by provider.
+func offeredFlight(id, provider string, price float64) domain.Flight {
+	f := createTestFlight(id, provider, price, 120, 0)
+	f.FlightNumber = "GA-400"
+	f.Airline.Code = "GA"
+	return f
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	garuda := offeredFlight("ga-1", "garuda", 1500000)
+	lionair := offeredFlight("lion-1", "lionair", 1200000)
+	lionair.FlightNumber = "GA400"
+	airasia := offeredFlight("aa-1", "airasia", 1300000)
+	other := createTestFlight("other", "garuda", 900000, 120, 0)
+
+	merged, n := MergeDuplicates([]domain.Flight{garuda, other, lionair, airasia})
+	require.Len(t, merged, 2)
+	assert.Equal(t, 2, n)
+
+	cheapest := merged[0]
+	assert.Equal(t, "lion-1", cheapest.ID, "the cheapest offer is kept")
+	assert.Equal(t, []domain.Offer{
+		{FlightID: "lion-1", Provider: "lionair", Price: lionair.Price},
+		{FlightID: "aa-1", Provider: "airasia", Price: airasia.Price},
+		{FlightID: "ga-1", Provider: "garuda", Price: garuda.Price},
+	}, cheapest.Offers)
+	assert.Equal(t, "other", merged[1].ID)
+	assert.Nil(t, merged[1].Offers, "flights of a single provider have no offers")
+	assert.Nil(t, garuda.Offers, "input is not mutated")
+}
+
+func TestMergeDuplicates_DistinctFlights(t *testing.T) {
+	base := offeredFlight("ga-1", "garuda", 1500000)
+
+	tests := []struct {
+		name   string
+		modify func(f *domain.Flight)
+	}{
+		{"same provider", func(f *domain.Flight) {}},
+		{"other flight number", func(f *domain.Flight) { f.Provider, f.FlightNumber = "lionair", "GA-401" }},
+		{"other departure", func(f *domain.Flight) {
+			f.Provider = "lionair"
+			f.Departure.DateTime = f.Departure.DateTime.Add(time.Hour)
+		}},
+		{"other class", func(f *domain.Flight) { f.Provider, f.Class = "lionair", "business" }},
+		{"other currency", func(f *domain.Flight) { f.Provider, f.Price.Currency = "lionair", "USD" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := offeredFlight("ga-2", "garuda", 1000000)
+			tt.modify(&f)
+			merged, n := MergeDuplicates([]domain.Flight{base, f})
+			assert.Len(t, merged, 2)
+			assert.Zero(t, n)
+		})
+	}
+}
+
+func TestSearch_MergesDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	uc := NewFlightSearchUseCase([]domain.FlightProvider{
+		setupMockProvider(ctrl, "garuda", []domain.Flight{offeredFlight("ga-1", "garuda", 1500000)}, nil),
+		setupMockProvider(ctrl, "lionair", []domain.Flight{offeredFlight("lion-1", "lionair", 1200000)}, nil),
+	}, nil)
+
+	maxPrice := 1300000.0
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{Filters: &domain.FilterOptions{MaxPrice: &maxPrice}})
+	require.NoError(t, err)
+	require.Len(t, response.Flights, 1, "filters apply to the cheapest offer")
+	assert.Equal(t, "lion-1", response.Flights[0].ID)
+	assert.Len(t, response.Flights[0].Offers, 2)
+	assert.Equal(t, 1, response.Metadata.DuplicatesMerged)
+}
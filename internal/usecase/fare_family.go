@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// fareKey identifies a provider's fares of a physical flight.
+type fareKey struct {
+	provider string
+	flight   duplicateKey
+}
+
+// GroupFareFamilies groups the fares a provider returned for the same
+// physical flight in different fare families (e.g. Lite, Value and Flex) into
+// one flight entry, the cheapest fare, listing every fare in FareOptions,
+// cheapest first. Flights without a fare family are not grouped, and the
+// order of the flights is otherwise kept. It returns the remaining flights
+// and the number of fares grouped away.
+// Does NOT mutate the original flights slice.
+func GroupFareFamilies(flights []domain.Flight) ([]domain.Flight, int) {
+	if len(flights) < 2 {
+		return flights, 0
+	}
+
+	result := make([]domain.Flight, 0, len(flights))
+	index := make(map[fareKey]int, len(flights))
+	for _, f := range flights {
+		if f.FareFamily == nil {
+			result = append(result, f)
+			continue
+		}
+		key := fareKey{provider: f.Provider, flight: newDuplicateKey(f)}
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(result)
+			result = append(result, f)
+			continue
+		}
+
+		grouped := &result[i]
+		options := grouped.FareOptions
+		if options == nil {
+			options = []domain.FareOption{domain.NewFareOption(*grouped)}
+		}
+		options = append(options, domain.NewFareOption(f))
+		if f.Price.Amount < grouped.Price.Amount {
+			*grouped = f
+		}
+		grouped.FareOptions = options
+	}
+	if len(result) == len(flights) {
+		return flights, 0
+	}
+
+	for i := range result {
+		slices.SortStableFunc(result[i].FareOptions, func(a, b domain.FareOption) int {
+			return cmp.Compare(a.Price.Amount, b.Price.Amount)
+		})
+	}
+	return result, len(flights) - len(result)
+}
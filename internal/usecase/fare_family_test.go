@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// fareFlight creates fare family of flight GA-400 as offered by provider.
+func fareFlight(provider, family string, price float64, changeable bool) domain.Flight {
+	f := offeredFlight(domain.FareFlightID("GA400", family), provider, price)
+	f.FareFamily = &domain.FareFamily{Name: family, Changeable: changeable}
+	return f
+}
+
+func TestGroupFareFamilies(t *testing.T) {
+	flex := fareFlight("garuda", "Flex", 1800000, true)
+	lite := fareFlight("garuda", "Lite", 1200000, false)
+	value := fareFlight("garuda", "Value", 1500000, true)
+	other := createTestFlight("other", "garuda", 900000, 120, 0)
+	otherProvider := fareFlight("lionair", "Lite", 1100000, false)
+
+	grouped, n := GroupFareFamilies([]domain.Flight{flex, other, lite, otherProvider, value})
+	require.Len(t, grouped, 3)
+	assert.Equal(t, 2, n)
+
+	cheapest := grouped[0]
+	assert.Equal(t, "GA400-lite", cheapest.ID, "the cheapest fare is kept")
+	assert.Equal(t, []domain.FareOption{
+		domain.NewFareOption(lite),
+		domain.NewFareOption(value),
+		domain.NewFareOption(flex),
+	}, cheapest.FareOptions)
+	assert.Equal(t, "other", grouped[1].ID)
+	assert.Equal(t, "lionair", grouped[2].Provider, "fares of different providers are not grouped")
+	assert.Nil(t, grouped[2].FareOptions)
+	assert.Nil(t, flex.FareOptions, "input is not mutated")
+
+	// Flights without a fare family are not grouped
+	withoutFamily := offeredFlight("ga-2", "garuda", 1000000)
+	grouped, n = GroupFareFamilies([]domain.Flight{offeredFlight("ga-1", "garuda", 1500000), withoutFamily})
+	assert.Len(t, grouped, 2)
+	assert.Zero(t, n)
+}
+
+func TestSearch_GroupsFareFamilies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	fares := []domain.Flight{
+		fareFlight("garuda", "Lite", 1200000, false),
+		fareFlight("garuda", "Value", 1500000, true),
+		fareFlight("garuda", "Flex", 1800000, true),
+	}
+	uc := NewFlightSearchUseCase([]domain.FlightProvider{setupMockProvider(ctrl, "garuda", fares, nil)}, nil)
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+	require.NoError(t, err)
+	require.Len(t, response.Flights, 1)
+	assert.Equal(t, "GA400-lite", response.Flights[0].ID)
+	assert.Len(t, response.Flights[0].FareOptions, 3)
+
+	// Filters apply to each fare, and fare options only list matching fares
+	response, err = uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{
+		Filters: &domain.FilterOptions{Changeable: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, response.Flights, 1)
+	assert.Equal(t, "GA400-value", response.Flights[0].ID, "the cheapest changeable fare")
+	require.Len(t, response.Flights[0].FareOptions, 2)
+	assert.Equal(t, "GA400-flex", response.Flights[0].FareOptions[1].FlightID)
+	assert.Equal(t, 1, response.Metadata.FilterRejections[domain.FilterChangeable])
+}
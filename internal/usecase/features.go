@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+)
+
+// features lists the optional behaviors active for a search (see the
+// domain Feature constants): how it is ranked and, for searches fanned out to
+// the providers rather than answered from the cache, how they are queried.
+func (uc *flightSearchUseCase) features(ctx context.Context, opts SearchOptions, fannedOut bool) []string {
+	features := []string{
+		domain.FeatureValue(domain.FeatureSort, string(domain.ParseSortOption(string(opts.SortBy)))),
+	}
+	if opts.PreferredDepartureTime != nil {
+		features = append(features, domain.FeatureDeparturePreference)
+	}
+	features = append(features, domain.FeatureValue(domain.FeatureRequestClass, string(RequestClassFromContext(ctx))))
+	if uc.cache != nil {
+		features = append(features, domain.FeatureCache)
+	}
+	if !fannedOut {
+		return features
+	}
+
+	features = append(features, domain.FeatureValue(domain.FeatureFanOut, string(uc.strategyFor(opts).Name())))
+	if opts.PartialResultsOK {
+		features = append(features, domain.FeaturePartialResults)
+	}
+	if uc.latencies != nil {
+		features = append(features, domain.FeatureLatencyBudget)
+	}
+	if uc.retries() {
+		features = append(features, domain.FeatureRetry)
+	}
+	if len(uc.shadowProviders) > 0 && loadshed.Allowed(ctx, loadshed.FeatureShadow) {
+		features = append(features, domain.FeatureShadow)
+	}
+	return features
+}
+
+// retries reports whether provider searches may be retried.
+func (uc *flightSearchUseCase) retries() bool {
+	if uc.retry.MaxAttempts > 1 {
+		return true
+	}
+	for _, attempts := range uc.retryAttempts {
+		if attempts > 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/retry"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+)
+
+func TestSearch_Features(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	provider := setupMockProvider(ctrl, "provider1", []domain.Flight{createTestFlight("1", "provider1", 1000000, 120, 0)}, nil)
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15"}
+
+	t.Run("defaults", func(t *testing.T) {
+		uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, nil)
+		response, err := uc.Search(context.Background(), criteria, SearchOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sort:best", "request_class:interactive", "fan_out:all-parallel", domain.FeatureCoalescing}, response.Metadata.Features)
+	})
+
+	t.Run("configured behaviors", func(t *testing.T) {
+		uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, &Config{
+			DisableCoalescing: true,
+			Retry:             retry.Config{MaxAttempts: 2},
+			ShadowProviders:   []domain.FlightProvider{setupMockProvider(ctrl, "shadow", nil, nil)},
+		})
+		opts := SearchOptions{
+			SortBy:                 domain.SortByPrice,
+			PreferredDepartureTime: &domain.TimeRange{},
+			FanOutStrategy:         domain.FanOutSequentialFailover,
+		}
+		response, err := uc.Search(WithRequestClass(context.Background(), domain.RequestClassBatch), criteria, opts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"sort:price", domain.FeatureDeparturePreference, "request_class:batch",
+			"fan_out:sequential-failover", domain.FeatureRetry, domain.FeatureShadow,
+		}, response.Metadata.Features)
+
+		// Shadow searches shed under load are not listed
+		response, err = uc.Search(loadshed.WithShed(context.Background(), []string{loadshed.FeatureShadow}), criteria, SearchOptions{})
+		require.NoError(t, err)
+		assert.NotContains(t, response.Metadata.Features, domain.FeatureShadow)
+	})
+
+	t.Run("cache hit", func(t *testing.T) {
+		cache := &searchCache{entries: map[string]*domain.CachedSearch{
+			domain.CriteriaHash(criteria): {Flights: []domain.Flight{createTestFlight("1", "provider1", 1000000, 120, 0)}, Providers: 1, CachedAt: time.Now()},
+		}}
+		drifts := make(chan CacheDrift, 1)
+		uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, &Config{
+			Cache:                cache,
+			CacheDriftSampleRate: 1,
+			CacheDriftObserver:   func(d CacheDrift) { drifts <- d },
+		})
+		response, err := uc.Search(context.Background(), criteria, SearchOptions{})
+		require.NoError(t, err)
+		assert.True(t, response.Metadata.CacheHit)
+		assert.Equal(t, []string{"sort:best", "request_class:interactive", domain.FeatureCache, domain.FeatureCacheDrift}, response.Metadata.Features)
+		<-drifts
+	})
+}
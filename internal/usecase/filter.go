@@ -2,8 +2,6 @@
 package usecase
 
 import (
-	"strings"
-
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
 )
 
@@ -12,7 +10,8 @@ import (
 //
 // Behavior:
 //   - Returns the original slice if opts is nil (no filtering)
-//   - Filters are applied in sequence (price -> stops -> airlines -> departure time)
+//   - Each flight is matched with domain.FilterOptions.RejectedBy, the single
+//     implementation of the filters
 //   - Nil/empty filter values are skipped (no filtering on that criterion)
 //   - Does NOT mutate the original flights slice
 //   - Performance is O(n) where n = number of flights
@@ -23,178 +22,92 @@ import (
 //	opts := &domain.FilterOptions{MaxPrice: &maxPrice}
 //	filtered := ApplyFilters(flights, opts)
 func ApplyFilters(flights []domain.Flight, opts *domain.FilterOptions) []domain.Flight {
-	if opts == nil {
-		return flights
-	}
+	filtered, _ := FilterFlights(flights, opts)
+	return filtered
+}
 
-	// Pre-build airline set for O(1) lookup if airlines filter is provided
-	var airlineSet map[string]struct{}
-	if len(opts.Airlines) > 0 {
-		airlineSet = buildAirlineSet(opts.Airlines)
+// FilterFlights applies the given filter options like ApplyFilters, and also
+// returns the number of flights each filter rejected, keyed by filter name
+// (nil if no flight was rejected). A flight failing several filters is
+// counted against the first one only.
+func FilterFlights(flights []domain.Flight, opts *domain.FilterOptions) ([]domain.Flight, map[string]int) {
+	if opts == nil {
+		return flights, nil
 	}
 
 	// Pre-allocate with estimated capacity
 	result := make([]domain.Flight, 0, len(flights))
+	var rejections map[string]int
 
 	for _, f := range flights {
-		if passesAllFilters(f, opts, airlineSet) {
+		filter := opts.RejectedBy(f)
+		if filter == "" {
 			result = append(result, f)
+			continue
 		}
+		if rejections == nil {
+			rejections = make(map[string]int)
+		}
+		rejections[filter]++
 	}
 
-	return result
-}
-
-// passesAllFilters checks if a flight passes all filter criteria.
-// This is an internal function that performs the actual filter checks.
-func passesAllFilters(f domain.Flight, opts *domain.FilterOptions, airlineSet map[string]struct{}) bool {
-	// Price filter: include flights where price <= maxPrice
-	if opts.MaxPrice != nil && f.Price.Amount > *opts.MaxPrice {
-		return false
-	}
-
-	// Stops filter: include flights where stops <= maxStops
-	if opts.MaxStops != nil && f.Stops > *opts.MaxStops {
-		return false
-	}
-
-	// Airlines filter: include flights where airline code is in whitelist
-	if len(opts.Airlines) > 0 && !isAirlineInSet(f.Airline.Code, airlineSet) {
-		return false
-	}
-
-	// Departure time range filter: include flights departing within the range
-	if opts.DepartureTimeRange != nil && !opts.DepartureTimeRange.Contains(f.Departure.DateTime) {
-		return false
-	}
-
-	// Arrival time range filter: include flights arriving within the range
-	if opts.ArrivalTimeRange != nil && !opts.ArrivalTimeRange.Contains(f.Arrival.DateTime) {
-		return false
-	}
-
-	// Duration range filter: include flights with duration within the range
-	if opts.DurationRange != nil && !opts.DurationRange.Contains(f.Duration.TotalMinutes) {
-		return false
-	}
-
-	return true
+	return result, rejections
 }
 
-// buildAirlineSet creates a case-insensitive lookup set from a list of airline codes.
-// This provides O(1) lookup performance for the airlines filter.
-func buildAirlineSet(airlines []string) map[string]struct{} {
-	set := make(map[string]struct{}, len(airlines))
-	for _, code := range airlines {
-		// Store uppercase for case-insensitive matching
-		set[strings.ToUpper(code)] = struct{}{}
-	}
-	return set
-}
-
-// isAirlineInSet checks if an airline code is in the allowed set (case-insensitive).
-func isAirlineInSet(code string, set map[string]struct{}) bool {
-	_, exists := set[strings.ToUpper(code)]
-	return exists
+// FilterByMinPrice filters flights by minimum price, e.g. to drop zero-price
+// fares. Returns all flights if minPrice is nil.
+//
+// Deprecated: Use ApplyFilters with domain.FilterOptions{MinPrice: minPrice}.
+func FilterByMinPrice(flights []domain.Flight, minPrice *float64) []domain.Flight {
+	return ApplyFilters(flights, &domain.FilterOptions{MinPrice: minPrice})
 }
 
 // FilterByMaxPrice filters flights by maximum price.
 // Returns all flights if maxPrice is nil.
-// This is an exported helper for direct filter application.
+//
+// Deprecated: Use ApplyFilters with domain.FilterOptions{MaxPrice: maxPrice}.
 func FilterByMaxPrice(flights []domain.Flight, maxPrice *float64) []domain.Flight {
-	if maxPrice == nil {
-		return flights
-	}
-
-	result := make([]domain.Flight, 0, len(flights))
-	for _, f := range flights {
-		if f.Price.Amount <= *maxPrice {
-			result = append(result, f)
-		}
-	}
-	return result
+	return ApplyFilters(flights, &domain.FilterOptions{MaxPrice: maxPrice})
 }
 
 // FilterByMaxStops filters flights by maximum number of stops.
 // Returns all flights if maxStops is nil.
 // Common values: 0 (direct only), 1 (max 1 stop), 2 (max 2 stops)
+//
+// Deprecated: Use ApplyFilters with domain.FilterOptions{MaxStops: maxStops}.
 func FilterByMaxStops(flights []domain.Flight, maxStops *int) []domain.Flight {
-	if maxStops == nil {
-		return flights
-	}
-
-	result := make([]domain.Flight, 0, len(flights))
-	for _, f := range flights {
-		if f.Stops <= *maxStops {
-			result = append(result, f)
-		}
-	}
-	return result
+	return ApplyFilters(flights, &domain.FilterOptions{MaxStops: maxStops})
 }
 
 // FilterByAirlines filters flights to only include those from specified airlines.
 // Returns all flights if airlines slice is nil or empty.
 // Matching is case-insensitive.
+//
+// Deprecated: Use ApplyFilters with domain.FilterOptions{Airlines: airlines}.
 func FilterByAirlines(flights []domain.Flight, airlines []string) []domain.Flight {
-	if len(airlines) == 0 {
-		return flights
-	}
-
-	airlineSet := buildAirlineSet(airlines)
-	result := make([]domain.Flight, 0, len(flights))
-
-	for _, f := range flights {
-		if isAirlineInSet(f.Airline.Code, airlineSet) {
-			result = append(result, f)
-		}
-	}
-	return result
+	return ApplyFilters(flights, &domain.FilterOptions{Airlines: airlines})
 }
 
 // FilterByDepartureTime filters flights by departure time range.
 // Returns all flights if timeRange is nil.
+//
+// Deprecated: Use ApplyFilters with domain.FilterOptions{DepartureTimeRange: timeRange}.
 func FilterByDepartureTime(flights []domain.Flight, timeRange *domain.TimeRange) []domain.Flight {
-	if timeRange == nil {
-		return flights
-	}
-
-	result := make([]domain.Flight, 0, len(flights))
-	for _, f := range flights {
-		if timeRange.Contains(f.Departure.DateTime) {
-			result = append(result, f)
-		}
-	}
-	return result
+	return ApplyFilters(flights, &domain.FilterOptions{DepartureTimeRange: timeRange})
 }
 
 // FilterByArrivalTime filters flights by arrival time range.
 // Returns all flights if timeRange is nil.
+//
+// Deprecated: Use ApplyFilters with domain.FilterOptions{ArrivalTimeRange: timeRange}.
 func FilterByArrivalTime(flights []domain.Flight, timeRange *domain.TimeRange) []domain.Flight {
-	if timeRange == nil {
-		return flights
-	}
-
-	result := make([]domain.Flight, 0, len(flights))
-	for _, f := range flights {
-		if timeRange.Contains(f.Arrival.DateTime) {
-			result = append(result, f)
-		}
-	}
-	return result
+	return ApplyFilters(flights, &domain.FilterOptions{ArrivalTimeRange: timeRange})
 }
 
 // FilterByDuration filters flights by duration range in minutes.
 // Returns all flights if durationRange is nil.
+//
+// Deprecated: Use ApplyFilters with domain.FilterOptions{DurationRange: durationRange}.
 func FilterByDuration(flights []domain.Flight, durationRange *domain.DurationRange) []domain.Flight {
-	if durationRange == nil {
-		return flights
-	}
-
-	result := make([]domain.Flight, 0, len(flights))
-	for _, f := range flights {
-		if durationRange.Contains(f.Duration.TotalMinutes) {
-			result = append(result, f)
-		}
-	}
-	return result
+	return ApplyFilters(flights, &domain.FilterOptions{DurationRange: durationRange})
 }
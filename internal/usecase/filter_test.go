@@ -204,6 +204,34 @@ func TestApplyFilters_MaxPriceExact(t *testing.T) {
 	assert.Len(t, result, 1, "Flight at exact price boundary should be included")
 }
 
+// TestApplyFilters_MinPrice tests minimum price filtering, including the
+// zero-price fares it is meant to drop and fares in another currency.
+func TestApplyFilters_MinPrice(t *testing.T) {
+	usd := createFilterTestFlight("usd", 900, 0, "SQ", 12)
+	usd.Price.Currency = "USD"
+	flights := []domain.Flight{
+		createFilterTestFlight("free", 0, 0, "GA", 8),
+		createFilterTestFlight("cheap", 500000, 0, "JT", 10),
+		createFilterTestFlight("pricey", 1500000, 0, "ID", 14),
+		usd,
+	}
+
+	minPrice, maxPrice := float64(1), float64(1000000)
+	result, rejections := FilterFlights(flights, &domain.FilterOptions{MinPrice: &minPrice})
+	require.Len(t, result, 2)
+	assert.Equal(t, "cheap", result[0].ID)
+	assert.Equal(t, "pricey", result[1].ID)
+	assert.Equal(t, map[string]int{domain.FilterMinPrice: 2}, rejections)
+
+	result = ApplyFilters(flights, &domain.FilterOptions{MinPrice: &minPrice, MaxPrice: &maxPrice})
+	require.Len(t, result, 1)
+	assert.Equal(t, "cheap", result[0].ID)
+
+	exact := float64(500000)
+	assert.Len(t, FilterByMinPrice(flights, &exact), 2, "flight at exact price boundary should be included")
+	assert.Len(t, FilterByMinPrice(flights, nil), 4)
+}
+
 // TestApplyFilters_MaxStops tests stops filtering.
 func TestApplyFilters_MaxStops(t *testing.T) {
 	flights := []domain.Flight{
@@ -249,6 +277,23 @@ func TestApplyFilters_AirlinesSingle(t *testing.T) {
 	assert.Equal(t, "GA", result[0].Airline.Code)
 }
 
+// TestApplyFilters_SupportsWheelchair tests filtering by wheelchair assistance.
+func TestApplyFilters_SupportsWheelchair(t *testing.T) {
+	accessible := createFilterTestFlight("1", 1000000, 0, "GA", 8)
+	accessible.Accessibility = &domain.AccessibilityInfo{Services: []domain.SpecialService{domain.ServiceWheelchair}}
+	other := createFilterTestFlight("2", 900000, 0, "JT", 10)
+	other.Accessibility = &domain.AccessibilityInfo{Services: []domain.SpecialService{domain.ServiceVisualAssistance}}
+	unknown := createFilterTestFlight("3", 800000, 0, "XX", 14)
+
+	flights := []domain.Flight{accessible, other, unknown}
+
+	result := ApplyFilters(flights, &domain.FilterOptions{SupportsWheelchair: true})
+	require.Len(t, result, 1)
+	assert.Equal(t, "1", result[0].ID)
+
+	assert.Len(t, ApplyFilters(flights, &domain.FilterOptions{}), 3)
+}
+
 // TestApplyFilters_AirlinesMultiple tests filtering by multiple airlines.
 func TestApplyFilters_AirlinesMultiple(t *testing.T) {
 	flights := []domain.Flight{
@@ -675,58 +720,26 @@ func TestFilterByArrivalTime(t *testing.T) {
 	})
 }
 
-// TestBuildAirlineSet tests the airline set builder.
-func TestBuildAirlineSet(t *testing.T) {
-	t.Run("empty list", func(t *testing.T) {
-		set := buildAirlineSet([]string{})
-		assert.Empty(t, set)
-	})
-
-	t.Run("single airline", func(t *testing.T) {
-		set := buildAirlineSet([]string{"GA"})
-		assert.Len(t, set, 1)
-		_, exists := set["GA"]
-		assert.True(t, exists)
-	})
-
-	t.Run("multiple airlines uppercase", func(t *testing.T) {
-		set := buildAirlineSet([]string{"ga", "jt", "id"})
-		assert.Len(t, set, 3)
-		_, exists := set["GA"]
-		assert.True(t, exists)
-		_, exists = set["JT"]
-		assert.True(t, exists)
-		_, exists = set["ID"]
-		assert.True(t, exists)
-	})
+// TestFilterFlights_Rejections tests the rejection counts per filter.
+func TestFilterFlights_Rejections(t *testing.T) {
+	flights := []domain.Flight{
+		createFilterTestFlight("1", 500000, 0, "GA", 8),
+		createFilterTestFlight("2", 1500000, 0, "GA", 9),  // too expensive
+		createFilterTestFlight("3", 800000, 0, "JT", 10),  // wrong airline
+		createFilterTestFlight("4", 2000000, 0, "JT", 11), // both, counted against price
+	}
+	opts := &domain.FilterOptions{MaxPrice: floatPtr(1000000), Airlines: []string{"ga"}}
 
-	t.Run("duplicates handled", func(t *testing.T) {
-		set := buildAirlineSet([]string{"GA", "ga", "Ga"})
-		assert.Len(t, set, 1)
-	})
-}
+	filtered, rejections := FilterFlights(flights, opts)
 
-// TestIsAirlineInSet tests the airline set lookup.
-func TestIsAirlineInSet(t *testing.T) {
-	set := buildAirlineSet([]string{"GA", "JT"})
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "1", filtered[0].ID)
+	assert.Equal(t, map[string]int{domain.FilterMaxPrice: 2, domain.FilterAirlines: 1}, rejections)
 
-	tests := []struct {
-		name     string
-		code     string
-		expected bool
-	}{
-		{"exact match uppercase", "GA", true},
-		{"lowercase matches", "ga", true},
-		{"mixed case matches", "Ga", true},
-		{"not in set", "ID", false},
-		{"empty string", "", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.expected, isAirlineInSet(tt.code, set))
-		})
-	}
+	_, rejections = FilterFlights(flights, nil)
+	assert.Nil(t, rejections)
+	_, rejections = FilterFlights(flights, &domain.FilterOptions{})
+	assert.Nil(t, rejections, "nothing rejected")
 }
 
 // TestFilterByDuration tests the duration filter function.
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// flexibleDateUseCase decorates a FlightSearchUseCase, searching each
+// departure date of flexible-date searches as a search of its own.
+type flexibleDateUseCase struct {
+	next        FlightSearchUseCase
+	concurrency int
+}
+
+// NewFlexibleDateUseCase wraps next so searches with date flexibility query
+// every departure date within it, at most concurrency dates at once. Each date
+// is filtered, sorted and truncated with the search options on its own; the
+// response lists the flights grouped by departure date, in date order, and
+// summarizes each date with its cheapest price. The search fails only if
+// every date failed. Searches without date flexibility are passed through
+// unchanged.
+func NewFlexibleDateUseCase(next FlightSearchUseCase, concurrency int) FlightSearchUseCase {
+	return &flexibleDateUseCase{next: next, concurrency: max(concurrency, 1)}
+}
+
+// Search executes the search, one departure date at a time for flexible-date
+// searches.
+func (uc *flexibleDateUseCase) Search(ctx context.Context, criteria domain.SearchCriteria, opts SearchOptions) (*domain.SearchResponse, error) {
+	if criteria.DateFlexibility <= 0 {
+		return uc.next.Search(ctx, criteria, opts)
+	}
+
+	dates := criteria.FlexibleDates()
+	results := make([]*domain.SearchResponse, len(dates))
+	errs := make([]error, len(dates))
+	workers := make(chan struct{}, uc.concurrency)
+	var wg sync.WaitGroup
+	for i, date := range dates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			// Progress of each date is reported as such
+			dateOpts := opts
+			if progress := opts.Progress; progress != nil {
+				dateOpts.Progress = func(p ProviderProgress) {
+					p.DepartureDate = date
+					progress(p)
+				}
+			}
+			results[i], errs[i] = uc.next.Search(ctx, criteria.OnDate(date), dateOpts)
+		}()
+	}
+	wg.Wait()
+
+	var flights []domain.Flight
+	var metadata domain.SearchMetadata
+	succeeded := 0
+	summaries := make([]domain.DateSummary, len(dates))
+	for i, date := range dates {
+		summaries[i] = domain.DateSummary{DepartureDate: date, Failed: errs[i] != nil}
+		if errs[i] != nil {
+			continue
+		}
+		result := results[i]
+		flights = append(flights, result.Flights...)
+		summaries[i].LowestPrice = domain.LowestPrice(result.Flights)
+		summaries[i].TotalResults = len(result.Flights)
+		if succeeded == 0 {
+			metadata = result.Metadata
+		} else {
+			metadata = mergeLegMetadata(metadata, result.Metadata)
+		}
+		succeeded++
+	}
+	if succeeded == 0 {
+		return nil, errs[slices.Index(dates, criteria.DepartureDate)]
+	}
+
+	// Continuation tokens only cover the date they were issued for
+	metadata.ContinuationToken = ""
+	response := domain.NewSearchResponse(&criteria, flights, metadata)
+	response.DateSummaries = summaries
+	return &response, nil
+}
+
+// Ensure flexibleDateUseCase implements FlightSearchUseCase at compile time.
+var _ FlightSearchUseCase = (*flexibleDateUseCase)(nil)
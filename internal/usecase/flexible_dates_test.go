@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+func TestFlexibleDateUseCase_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := NewMockFlightSearchUseCase(ctrl)
+
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1, DateFlexibility: 1}
+	opts := DefaultSearchOptions()
+
+	before := domain.NewSearchResponse(&domain.SearchCriteria{}, []domain.Flight{
+		createTestFlight("14-1", "garuda", 1500000, 120, 0),
+		createTestFlight("14-2", "lion_air", 900000, 130, 0),
+	}, domain.SearchMetadata{ProvidersQueried: 2, ProvidersSucceeded: 2, SearchTimeMs: 120, ContinuationToken: "token"})
+	requested := domain.NewSearchResponse(&domain.SearchCriteria{}, []domain.Flight{
+		createTestFlight("15-1", "garuda", 1200000, 120, 0),
+	}, domain.SearchMetadata{ProvidersQueried: 2, ProvidersSucceeded: 1, ProvidersFailed: 1, SearchTimeMs: 300})
+
+	next.EXPECT().Search(gomock.Any(), criteria.OnDate("2025-12-14"), opts).Return(&before, nil)
+	next.EXPECT().Search(gomock.Any(), criteria.OnDate("2025-12-15"), opts).Return(&requested, nil)
+	next.EXPECT().Search(gomock.Any(), criteria.OnDate("2025-12-16"), opts).Return(nil, domain.ErrAllProvidersFailed)
+
+	result, err := NewFlexibleDateUseCase(next, 2).Search(context.Background(), criteria, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2025-12-15", result.SearchCriteria.DepartureDate)
+	assert.Equal(t, 1, result.SearchCriteria.DateFlexibility)
+	ids := make([]string, len(result.Flights))
+	for i, f := range result.Flights {
+		ids[i] = f.ID
+	}
+	assert.Equal(t, []string{"14-1", "14-2", "15-1"}, ids, "flights are grouped by date")
+
+	assert.Equal(t, []domain.DateSummary{
+		{DepartureDate: "2025-12-14", LowestPrice: &domain.PriceInfo{Amount: 900000, Currency: "IDR"}, TotalResults: 2},
+		{DepartureDate: "2025-12-15", LowestPrice: &domain.PriceInfo{Amount: 1200000, Currency: "IDR"}, TotalResults: 1},
+		{DepartureDate: "2025-12-16", Failed: true},
+	}, result.DateSummaries)
+
+	assert.Equal(t, 3, result.Metadata.TotalResults)
+	assert.Equal(t, 4, result.Metadata.ProvidersQueried)
+	assert.Equal(t, 3, result.Metadata.ProvidersSucceeded)
+	assert.Equal(t, 1, result.Metadata.ProvidersFailed)
+	assert.Equal(t, int64(300), result.Metadata.SearchTimeMs)
+	assert.Empty(t, result.Metadata.ContinuationToken)
+}
+
+func TestFlexibleDateUseCase_AllDatesFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := NewMockFlightSearchUseCase(ctrl)
+
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1, DateFlexibility: 1}
+	next.EXPECT().Search(gomock.Any(), criteria.OnDate("2025-12-15"), gomock.Any()).Return(nil, domain.ErrAllProvidersFailed)
+	next.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout")).Times(2)
+
+	_, err := NewFlexibleDateUseCase(next, 3).Search(context.Background(), criteria, DefaultSearchOptions())
+	assert.ErrorIs(t, err, domain.ErrAllProvidersFailed, "the error of the requested date")
+}
+
+func TestFlexibleDateUseCase_Concurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := NewMockFlightSearchUseCase(ctrl)
+
+	var running, peak atomic.Int32
+	next.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, criteria domain.SearchCriteria, _ SearchOptions) (*domain.SearchResponse, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			response := domain.NewSearchResponse(&criteria, nil, domain.SearchMetadata{})
+			return &response, nil
+		}).Times(7)
+
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1, DateFlexibility: 3}
+	result, err := NewFlexibleDateUseCase(next, 2).Search(context.Background(), criteria, DefaultSearchOptions())
+	require.NoError(t, err)
+	assert.Len(t, result.DateSummaries, 7)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestFlexibleDateUseCase_Progress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := NewMockFlightSearchUseCase(ctrl)
+
+	next.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, criteria domain.SearchCriteria, opts SearchOptions) (*domain.SearchResponse, error) {
+			opts.Progress(ProviderProgress{Provider: "garuda", Completed: 1, Total: 1})
+			response := domain.NewSearchResponse(&criteria, nil, domain.SearchMetadata{})
+			return &response, nil
+		}).Times(3)
+
+	progress := make(chan ProviderProgress, 3)
+	opts := DefaultSearchOptions()
+	opts.Progress = func(p ProviderProgress) { progress <- p }
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1, DateFlexibility: 1}
+	_, err := NewFlexibleDateUseCase(next, 1).Search(context.Background(), criteria, opts)
+	require.NoError(t, err)
+
+	close(progress)
+	var dates []string
+	for p := range progress {
+		dates = append(dates, p.DepartureDate)
+	}
+	assert.ElementsMatch(t, []string{"2025-12-14", "2025-12-15", "2025-12-16"}, dates)
+}
+
+func TestFlexibleDateUseCase_FixedDatePassesThrough(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := NewMockFlightSearchUseCase(ctrl)
+
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1}
+	want := domain.NewSearchResponse(&criteria, nil, domain.SearchMetadata{})
+	next.EXPECT().Search(gomock.Any(), criteria, gomock.Any()).Return(&want, nil)
+
+	result, err := NewFlexibleDateUseCase(next, 3).Search(context.Background(), criteria, DefaultSearchOptions())
+	require.NoError(t, err)
+	assert.Same(t, &want, result)
+	assert.Nil(t, result.DateSummaries)
+}
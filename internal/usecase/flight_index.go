@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// FlightIndex remembers the flights of search responses by ID.
+// flightindex.Index implements it.
+type FlightIndex interface {
+	Add(resp *domain.SearchResponse)
+}
+
+// indexingUseCase decorates a FlightSearchUseCase, remembering the flights of
+// every successful search so their details can be looked up by ID.
+type indexingUseCase struct {
+	next  FlightSearchUseCase
+	index FlightIndex
+}
+
+// NewIndexingUseCase wraps next so the flights of successful searches are
+// added to index.
+func NewIndexingUseCase(next FlightSearchUseCase, index FlightIndex) FlightSearchUseCase {
+	return &indexingUseCase{next: next, index: index}
+}
+
+// Search executes the search and remembers its flights.
+func (uc *indexingUseCase) Search(ctx context.Context, criteria domain.SearchCriteria, opts SearchOptions) (*domain.SearchResponse, error) {
+	result, err := uc.next.Search(ctx, criteria, opts)
+	if err != nil {
+		return nil, err
+	}
+	uc.index.Add(result)
+	return result, nil
+}
+
+// Ensure indexingUseCase implements FlightSearchUseCase at compile time.
+var _ FlightSearchUseCase = (*indexingUseCase)(nil)
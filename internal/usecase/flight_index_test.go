@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/flightindex"
+)
+
+func TestIndexingUseCase_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	provider := setupMockProvider(ctrl, "provider1", []domain.Flight{createTestFlight("GA400", "provider1", 1000000, 120, 0)}, nil)
+	index := flightindex.New()
+	uc := NewIndexingUseCase(NewFlightSearchUseCase([]domain.FlightProvider{provider}, nil), index)
+
+	_, err := uc.Search(context.Background(), domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}, SearchOptions{})
+	require.NoError(t, err)
+
+	entry, ok := index.Get("GA400")
+	require.True(t, ok)
+	assert.Equal(t, "provider1", entry.Flight.Provider)
+	assert.Equal(t, "Soekarno-Hatta International Airport", entry.Flight.Departure.AirportName, "flights are remembered as returned")
+}
+
+func TestIndexingUseCase_SearchFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	provider := setupMockProvider(ctrl, "provider1", nil, errors.New("timeout"))
+	index := flightindex.New()
+	uc := NewIndexingUseCase(NewFlightSearchUseCase([]domain.FlightProvider{provider}, nil), index)
+
+	_, err := uc.Search(context.Background(), domain.SearchCriteria{Origin: "CGK"}, SearchOptions{})
+	assert.ErrorIs(t, err, domain.ErrAllProvidersFailed)
+}
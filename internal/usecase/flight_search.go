@@ -2,11 +2,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"sync"
+	"slices"
 	"time"
 
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/retry"
 )
 
 //go:generate mockgen -destination=flight_search_mock.go -package=usecase github.com/flight-search/flight-search-and-aggregation-system/internal/usecase FlightSearchUseCase
@@ -15,6 +17,8 @@ import (
 const (
 	DefaultGlobalTimeout   = 5 * time.Second
 	DefaultProviderTimeout = 2 * time.Second
+	DefaultSoftTimeout     = 1500 * time.Millisecond
+	DefaultCacheTTL        = 5 * time.Minute
 )
 
 // FlightSearchUseCase defines the interface for flight search operations.
@@ -26,15 +30,121 @@ type FlightSearchUseCase interface {
 
 // flightSearchUseCase implements FlightSearchUseCase using the Scatter-Gather pattern.
 type flightSearchUseCase struct {
-	providers       []domain.FlightProvider
-	globalTimeout   time.Duration
-	providerTimeout time.Duration
+	providers            []domain.FlightProvider
+	globalTimeout        time.Duration
+	providerTimeout      time.Duration
+	providerTimeouts     map[string]time.Duration
+	classTimeouts        map[domain.RequestClass]time.Duration
+	shadowProviders      []domain.FlightProvider
+	shadowRecorder       ShadowRecorder
+	routing              domain.RoutingPolicy
+	softTimeout          time.Duration
+	continuations        *ContinuationBuffer
+	latencies            LatencyHistory
+	cache                domain.SearchCache
+	cacheTTL             time.Duration
+	cacheDriftSampleRate float64
+	cacheDriftObserver   func(CacheDrift)
+	inflight             *searchGroup
+	retry                retry.Config
+	retryAttempts        map[string]int
+	retryObserver        func(provider string)
+	rankingObserver      func(RankingViolation)
+	priceBasis           domain.PriceBasis
+	strategy             Strategy
+	strategies           map[domain.FanOutStrategy]Strategy
 }
 
 // Config contains configuration options for the use case.
 type Config struct {
 	GlobalTimeout   time.Duration
 	ProviderTimeout time.Duration
+
+	// ProviderTimeouts overrides ProviderTimeout for the named providers
+	ProviderTimeouts map[string]time.Duration
+
+	// ClassTimeouts overrides GlobalTimeout for searches whose context carries
+	// the given request class (see WithRequestClass)
+	ClassTimeouts map[domain.RequestClass]time.Duration
+
+	// ShadowProviders are queried on every search, but their results are
+	// excluded from responses and only compared with the live results
+	ShadowProviders []domain.FlightProvider
+
+	// ShadowRecorder receives the comparisons (nil = shadow results are discarded)
+	ShadowRecorder ShadowRecorder
+
+	// Routing rejects itineraries with absurd routings for their route
+	Routing domain.RoutingPolicy
+
+	// SoftTimeout is how long searches accepting partial results wait before
+	// returning what the providers answered so far (0 = DefaultSoftTimeout)
+	SoftTimeout time.Duration
+
+	// Continuations holds partial searches so the results of their pending
+	// providers can be fetched later (nil = pending providers are cancelled)
+	Continuations *ContinuationBuffer
+
+	// Latencies skips providers whose p95 latency exceeds the budget a search
+	// has left (nil = every provider is queried)
+	Latencies LatencyHistory
+
+	// Cache answers repeated searches with the results of an earlier search
+	// with the same criteria (nil = every search queries the providers)
+	Cache domain.SearchCache
+
+	// CacheTTL is how long cached results are used (0 = DefaultCacheTTL)
+	CacheTTL time.Duration
+
+	// CacheDriftSampleRate is the share of searches answered from the cache
+	// that are searched live as well, in the background, to measure how far
+	// cached results drift from live ones (0 = never)
+	CacheDriftSampleRate float64
+
+	// CacheDriftObserver receives the drift of each sampled search
+	// (nil = searches are not sampled)
+	CacheDriftObserver func(CacheDrift)
+
+	// DisableCoalescing makes concurrent identical searches query the
+	// providers separately instead of sharing one fan-out
+	DisableCoalescing bool
+
+	// Retry retries provider searches failing with an error the provider
+	// marked retryable, within the provider timeout (zero value = no
+	// retries). Its RetryIf and OnRetry are replaced by the use case.
+	Retry retry.Config
+
+	// RetryAttempts overrides Retry.MaxAttempts for the named providers
+	RetryAttempts map[string]int
+
+	// RetryObserver is notified of each provider search retry (optional)
+	RetryObserver func(provider string)
+
+	// RankingObserver is notified of each ranking score breaking a ranking
+	// invariant, after the score was clamped (optional)
+	RankingObserver func(RankingViolation)
+
+	// FanOutStrategy is how the providers of searches not selecting a
+	// strategy are queried (empty = domain.FanOutAllParallel)
+	FanOutStrategy domain.FanOutStrategy
+
+	// FastestFirstProviders and FastestFirstResults are the thresholds of
+	// the fastest-first-return strategy: the number of providers answering
+	// successfully, or of flights, enough to respond (0 =
+	// DefaultFastestFirstProviders and DefaultFastestFirstResults)
+	FastestFirstProviders int
+	FastestFirstResults   int
+
+	// PriceBasis is the price the price filters compare: the price per
+	// passenger or the total for all the passengers (empty =
+	// domain.PriceBasisPerPerson)
+	PriceBasis domain.PriceBasis
+}
+
+// LatencyHistory reports the historical latency of providers.
+type LatencyHistory interface {
+	// P95 returns the provider's p95 search latency; ok is false when unknown
+	P95(provider string) (p95 time.Duration, ok bool)
 }
 
 // DefaultConfig returns the default configuration.
@@ -42,6 +152,12 @@ func DefaultConfig() Config {
 	return Config{
 		GlobalTimeout:   DefaultGlobalTimeout,
 		ProviderTimeout: DefaultProviderTimeout,
+		SoftTimeout:     DefaultSoftTimeout,
+		CacheTTL:        DefaultCacheTTL,
+
+		FanOutStrategy:        domain.FanOutAllParallel,
+		FastestFirstProviders: DefaultFastestFirstProviders,
+		FastestFirstResults:   DefaultFastestFirstResults,
 	}
 }
 
@@ -56,13 +172,69 @@ func NewFlightSearchUseCase(providers []domain.FlightProvider, config *Config) F
 		if config.ProviderTimeout > 0 {
 			cfg.ProviderTimeout = config.ProviderTimeout
 		}
+		cfg.ProviderTimeouts = config.ProviderTimeouts
+		cfg.ClassTimeouts = config.ClassTimeouts
+		cfg.ShadowProviders = config.ShadowProviders
+		cfg.ShadowRecorder = config.ShadowRecorder
+		cfg.Routing = config.Routing
+		if config.SoftTimeout > 0 {
+			cfg.SoftTimeout = config.SoftTimeout
+		}
+		cfg.Continuations = config.Continuations
+		cfg.Latencies = config.Latencies
+		cfg.Cache = config.Cache
+		if config.CacheTTL > 0 {
+			cfg.CacheTTL = config.CacheTTL
+		}
+		cfg.CacheDriftSampleRate = config.CacheDriftSampleRate
+		cfg.CacheDriftObserver = config.CacheDriftObserver
+		cfg.DisableCoalescing = config.DisableCoalescing
+		cfg.Retry = config.Retry
+		cfg.RetryAttempts = config.RetryAttempts
+		cfg.RetryObserver = config.RetryObserver
+		cfg.RankingObserver = config.RankingObserver
+		if config.FanOutStrategy.IsValid() {
+			cfg.FanOutStrategy = config.FanOutStrategy
+		}
+		if config.FastestFirstProviders > 0 {
+			cfg.FastestFirstProviders = config.FastestFirstProviders
+		}
+		if config.FastestFirstResults > 0 {
+			cfg.FastestFirstResults = config.FastestFirstResults
+		}
+		if config.PriceBasis.IsValid() {
+			cfg.PriceBasis = config.PriceBasis
+		}
 	}
 
-	return &flightSearchUseCase{
-		providers:       providers,
-		globalTimeout:   cfg.GlobalTimeout,
-		providerTimeout: cfg.ProviderTimeout,
+	uc := &flightSearchUseCase{
+		providers:            providers,
+		globalTimeout:        cfg.GlobalTimeout,
+		providerTimeout:      cfg.ProviderTimeout,
+		providerTimeouts:     cfg.ProviderTimeouts,
+		classTimeouts:        cfg.ClassTimeouts,
+		shadowProviders:      cfg.ShadowProviders,
+		shadowRecorder:       cfg.ShadowRecorder,
+		routing:              cfg.Routing,
+		softTimeout:          cfg.SoftTimeout,
+		continuations:        cfg.Continuations,
+		latencies:            cfg.Latencies,
+		cache:                cfg.Cache,
+		cacheTTL:             cfg.CacheTTL,
+		cacheDriftSampleRate: cfg.CacheDriftSampleRate,
+		cacheDriftObserver:   cfg.CacheDriftObserver,
+		retry:                cfg.Retry,
+		retryAttempts:        cfg.RetryAttempts,
+		retryObserver:        cfg.RetryObserver,
+		rankingObserver:      cfg.RankingObserver,
+		priceBasis:           cfg.PriceBasis,
+		strategies:           strategies(cfg.FastestFirstProviders, cfg.FastestFirstResults),
+	}
+	uc.strategy = uc.strategies[cfg.FanOutStrategy]
+	if !cfg.DisableCoalescing {
+		uc.inflight = newSearchGroup()
 	}
+	return uc
 }
 
 // providerResult holds the result from a single provider query.
@@ -82,108 +254,438 @@ func (uc *flightSearchUseCase) Search(ctx context.Context, criteria domain.Searc
 		return nil, domain.ErrAllProvidersFailed
 	}
 
-	// Create context with global timeout
-	ctx, cancel := context.WithTimeout(ctx, uc.globalTimeout)
-	defer cancel()
+	// Some providers ignore the class searched for: drop the flights of
+	// other classes with the other filters
+	opts.Filters = opts.Filters.WithClass(criteria.Class)
+
+	// Compare prices per passenger or for all of them, as configured
+	opts.Filters = opts.Filters.WithPriceBasis(uc.priceBasis)
+
+	// Answer from the cache when the same criteria were searched recently.
+	// Providers may filter on their side, so results fetched with filtering
+	// hints are cached under a key of their own; results fetched without any
+	// hold every flight and answer filtered searches as well.
+	hints := opts.ProviderOptions()
+	cacheKey := domain.ProviderSearchHash(criteria, hints)
+	cached, cachedHints := uc.cached(ctx, domain.CriteriaHash(criteria)), domain.ProviderSearchOptions{}
+	if cached == nil && !hints.IsZero() {
+		cached, cachedHints = uc.cached(ctx, cacheKey), hints
+	}
+	if cached != nil {
+		features := uc.features(ctx, opts, false)
+		if uc.checkCacheDrift(ctx, criteria, cachedHints, cached) {
+			features = append(features, domain.FeatureCacheDrift)
+		}
+		outcome := searchOutcome{flights: slices.Clone(cached.Flights), elapsed: time.Since(startTime)}
+		response := buildResponse(criteria, opts, uc.routing, uc.rankingObserver, cached.Providers, outcome)
+		response.Metadata.CacheHit = true
+		response.Metadata.Features = features
+		return response, nil
+	}
 
-	// Buffered channel to prevent goroutine blocking
-	resultsChan := make(chan providerResult, len(uc.providers))
+	// Concurrent identical searches share one provider fan-out. Searches
+	// accepting partial results are not shared, as their soft deadline and
+	// continuation belong to the request, nor are searches with a deadline of
+	// their own, as it sets which providers can answer in time, nor searches
+	// reporting their progress. Each fan-out strategy has fan-outs of its own.
+	var fanned fanOutResult
+	var err error
+	features := uc.features(ctx, opts, true)
+	if _, hasDeadline := ctx.Deadline(); uc.inflight != nil && !opts.PartialResultsOK && !hasDeadline && opts.Progress == nil {
+		features = append(features, domain.FeatureCoalescing)
+		strategy := uc.strategyFor(opts).Name()
+		key := cacheKey + "|" + string(RequestClassFromContext(ctx)) + "|" + string(strategy)
+		fanned, err = uc.inflight.do(ctx, key, func(ctx context.Context) (fanOutResult, error) {
+			return uc.fanOut(ctx, criteria, SearchOptions{Filters: opts.Filters, FanOutStrategy: strategy}, cacheKey, startTime)
+		})
+	} else {
+		fanned, err = uc.fanOut(ctx, criteria, opts, cacheKey, startTime)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := fanned.outcome
+	outcome.elapsed = time.Since(startTime)
+	response := buildResponse(criteria, opts, uc.routing, uc.rankingObserver, fanned.providers, outcome)
+	response.Metadata.ContinuationToken = fanned.token
+	response.Metadata.Features = features
+	return response, nil
+}
 
-	// WaitGroup to track goroutine completion
-	var wg sync.WaitGroup
+// fanOutResult is what a provider fan-out returned.
+type fanOutResult struct {
+	outcome   searchOutcome
+	providers int
 
-	// Scatter: launch goroutines for each provider
-	for _, provider := range uc.providers {
-		wg.Add(1)
-		go func(p domain.FlightProvider) {
-			defer wg.Done()
-			uc.queryProvider(ctx, p, criteria, resultsChan)
-		}(provider)
+	// token is the continuation token of a held partial search
+	token string
+}
+
+// fanOut queries the providers as the search's fan-out strategy orchestrates
+// them and gathers their results, scatter-gather style. Searches accepting
+// partial results stop gathering at the soft deadline, and any search once
+// the strategy has enough answers. Complete results are cached under cacheKey.
+func (uc *flightSearchUseCase) fanOut(ctx context.Context, criteria domain.SearchCriteria, opts SearchOptions, cacheKey string, startTime time.Time) (fanOutResult, error) {
+	// Query shadow providers alongside, comparing once the live results are in
+	live := make(chan []domain.Flight, 1)
+	hints := opts.ProviderOptions()
+	uc.shadowSearch(ctx, criteria, hints, live)
+
+	// Create context with the global timeout for the request class. Searches
+	// that may be continued detach it from the request, so providers still
+	// running at the soft deadline can finish after the partial response.
+	request, requestDone := ctx, ctx.Done()
+	parent := ctx
+	continuable := opts.PartialResultsOK && uc.continuations != nil
+	if continuable {
+		parent = context.WithoutCancel(ctx)
+	}
+	ctx, cancel := context.WithTimeout(parent, uc.globalTimeoutFor(ctx))
+	held := false
+	defer func() {
+		if !held {
+			cancel()
+		}
+	}()
+
+	// Skip providers that cannot answer within the remaining budget
+	providers, skippedProviders := uc.providersWithinBudget(ctx)
+	if len(providers) == 0 {
+		return fanOutResult{}, fmt.Errorf("no provider answers within the remaining budget: %w", context.DeadlineExceeded)
 	}
 
-	// Close channel when all goroutines complete
+	// Buffered channel to prevent goroutine blocking
+	resultsChan := make(chan providerResult, len(providers))
+
+	// Scatter: the strategy queries the providers, recording those queried
+	// until gathering stops, and the channel is closed once they all answered
+	strategy := uc.strategyFor(opts)
+	var scattered scatterLog
+	scatterCtx, stopScatter := context.WithCancel(ctx)
+	defer stopScatter()
 	go func() {
-		wg.Wait()
+		strategy.Scatter(scatterCtx, providers, func(p domain.FlightProvider) (int, error) {
+			if !scattered.start(p) {
+				return 0, errScatterStopped
+			}
+			result := uc.searchProvider(ctx, p, criteria, hints)
+			resultsChan <- result
+			return len(result.Flights), result.Error
+		})
 		close(resultsChan)
 	}()
 
+	// Searches accepting partial results stop gathering at the soft deadline
+	var softDeadline <-chan time.Time
+	if opts.PartialResultsOK {
+		timer := time.NewTimer(uc.softTimeout)
+		defer timer.Stop()
+		softDeadline = timer.C
+	}
+
 	// Gather: collect results
 	var allFlights []domain.Flight
 	var failedProviders []string
-	queriedProviders := make([]string, 0, len(uc.providers))
-
-	for result := range resultsChan {
-		queriedProviders = append(queriedProviders, result.Provider)
-		if result.Error != nil {
-			failedProviders = append(failedProviders, result.Provider)
-			continue
-		}
-		allFlights = append(allFlights, result.Flights...)
-	}
-
-	// Check if context was cancelled before we got all results
-	if ctx.Err() != nil && len(queriedProviders) < len(uc.providers) {
-		// Record remaining providers as failed
-		for _, p := range uc.providers {
-			found := false
-			for _, q := range queriedProviders {
-				if q == p.Name() {
-					found = true
-					break
-				}
+	queriedProviders := make([]string, 0, len(providers))
+	deadlinePassed := false
+	partial := false
+
+gather:
+	for {
+		select {
+		case result, ok := <-resultsChan:
+			if !ok {
+				break gather
+			}
+			queriedProviders = append(queriedProviders, result.Provider)
+			uc.reportProgress(criteria, opts, result, len(queriedProviders), len(providers))
+			if result.Error != nil {
+				failedProviders = append(failedProviders, result.Provider)
+				continue
+			}
+			allFlights = append(allFlights, result.Flights...)
+
+			// Past the soft deadline, the first answer is enough, and the
+			// strategy may not wait for the slowest providers
+			succeeded := len(queriedProviders) - len(failedProviders)
+			if len(queriedProviders) < len(providers) && (deadlinePassed || strategy.Enough(succeeded, len(allFlights))) {
+				partial = true
+				break gather
 			}
-			if !found {
-				queriedProviders = append(queriedProviders, p.Name())
-				failedProviders = append(failedProviders, p.Name())
+		case <-softDeadline:
+			softDeadline = nil
+			if len(queriedProviders) > len(failedProviders) {
+				partial = true
+				break gather
 			}
+			// Nothing to return yet: wait for the first provider to answer
+			deadlinePassed = true
+		case <-requestDone:
+			// The client went away: stop the providers still running, even
+			// those of a continuable search, instead of spending their budget
+			if errors.Is(request.Err(), context.Canceled) {
+				close(live)
+				return fanOutResult{}, fmt.Errorf("%w: %w", domain.ErrSearchAborted, request.Err())
+			}
+			// A request deadline cancels the providers of searches that are
+			// not continuable, which then answer as failed
+			requestDone = nil
 		}
 	}
+	live <- allFlights
+
+	// The strategy queries no more providers: those it did not query are
+	// neither counted nor reported
+	candidates := len(providers)
+	providers = scattered.stop()
+	stopScatter()
+
+	// Record providers that did not answer in time
+	var pendingProviders []string
+	if partial {
+		pendingProviders = unansweredProviders(providers, queriedProviders)
+	} else if ctx.Err() != nil && len(queriedProviders) < len(providers) {
+		// Context cancelled before we got all results: record remaining providers as failed
+		remaining := unansweredProviders(providers, queriedProviders)
+		queriedProviders = append(queriedProviders, remaining...)
+		failedProviders = append(failedProviders, remaining...)
+	}
 
 	// Check if all providers failed
-	if len(failedProviders) == len(uc.providers) {
-		return nil, domain.ErrAllProvidersFailed
+	if len(failedProviders) == len(providers) {
+		return fanOutResult{}, domain.ErrAllProvidersFailed
+	}
+
+	outcome := searchOutcome{
+		flights: allFlights,
+		failed:  failedProviders,
+		pending: pendingProviders,
+		skipped: skippedProviders,
+	}
+
+	// Hold partial searches until their pending providers finish
+	var token string
+	if partial && continuable {
+		token = uc.continuations.hold(&continuation{
+			criteria:  criteria,
+			opts:      opts,
+			routing:   uc.routing,
+			ranking:   uc.rankingObserver,
+			providers: len(providers),
+			features:  uc.features(request, opts, true),
+			started:   startTime,
+			outcome:   outcome.clone(),
+		}, resultsChan, cancel)
+		held = true
 	}
 
+	// Cache complete results only, so a provider outage is not served from
+	// the cache after the provider recovers, nor a single provider's flights
+	// to searches querying every provider
+	if !partial && len(failedProviders) == 0 && len(skippedProviders) == 0 && len(providers) == candidates {
+		uc.store(ctx, cacheKey, &domain.CachedSearch{
+			Flights:   slices.Clone(allFlights),
+			Providers: len(providers),
+			CachedAt:  startTime,
+		})
+	}
+
+	return fanOutResult{outcome: outcome, providers: len(providers), token: token}, nil
+}
+
+// reportProgress passes a provider's answer to the search's Progress callback,
+// with its flights screened like those of the final response.
+func (uc *flightSearchUseCase) reportProgress(criteria domain.SearchCriteria, opts SearchOptions, result providerResult, completed, total int) {
+	if opts.Progress == nil {
+		return
+	}
+	progress := ProviderProgress{Provider: result.Provider, Err: result.Error, Completed: completed, Total: total}
+	if result.Error == nil {
+		plausible, _ := RejectAbsurdRoutings(result.Flights, uc.routing)
+		plausible = PriceForPassengers(plausible, criteria.Passengers)
+		plausible = EnrichAirlines(EnrichAirports(plausible))
+		progress.Flights, _ = GroupFareFamilies(slices.Clone(ApplyFilters(plausible, opts.Filters)))
+	}
+	opts.Progress(progress)
+}
+
+// searchOutcome is what the providers of a search returned.
+type searchOutcome struct {
+	flights []domain.Flight
+	failed  []string
+	pending []string
+	skipped []string
+	elapsed time.Duration
+}
+
+// clone returns a copy of the outcome that can be appended to independently.
+func (o searchOutcome) clone() searchOutcome {
+	return searchOutcome{
+		flights: slices.Clone(o.flights),
+		failed:  slices.Clone(o.failed),
+		pending: slices.Clone(o.pending),
+		skipped: o.skipped,
+		elapsed: o.elapsed,
+	}
+}
+
+// buildResponse turns the flights returned by the providers into a response:
+// absurd routings are rejected, then the flights are filtered, ranked, sorted,
+// prioritized by special assistance and truncated, per provider then overall,
+// according to opts. Ranking scores breaking the ranking invariants are
+// clamped and reported to rankingObserver, if set.
+func buildResponse(criteria domain.SearchCriteria, opts SearchOptions, routing domain.RoutingPolicy, rankingObserver func(RankingViolation), providers int, outcome searchOutcome) *domain.SearchResponse {
+	// Drop itineraries with absurd routings for their route
+	plausible, rejectedRoutings := RejectAbsurdRoutings(outcome.flights, routing)
+
+	// Price the flights for all the passengers, providers pricing per passenger
+	plausible = PriceForPassengers(plausible, criteria.Passengers)
+
+	// Fill the airport and airline details providers left blank from the
+	// reference data
+	plausible = EnrichAirlines(EnrichAirports(plausible))
+
+	// Merge the same flight returned by several providers into its cheapest offer
+	plausible, duplicatesMerged := MergeDuplicates(plausible)
+
 	// Apply filtering using the dedicated filter module
-	filtered := ApplyFilters(allFlights, opts.Filters)
+	filtered, filterRejections := FilterFlights(plausible, opts.Filters)
+
+	// Group a provider's fare families of the same flight into one entry,
+	// once filtered, so fare options only list the fares matching the filters
+	filtered, _ = GroupFareFamilies(filtered)
 
 	// Calculate ranking scores using the dedicated ranking module
-	ranked := CalculateRankingScores(filtered)
+	preference := WithDeparturePreference(opts.PreferredDepartureTime)
+	ranked := CalculateRankingScores(filtered, preference)
+
+	// Clamp scores broken by pathological provider values, so sorts stay sane
+	ranked, violations := EnforceRankingInvariants(ranked, preference)
+	if rankingObserver != nil {
+		for _, v := range violations {
+			rankingObserver(v)
+		}
+	}
 
 	// Sort results using the dedicated sorting module
-	sorted := SortFlights(ranked, opts.SortBy)
+	sorted := SortFlights(ranked, opts.SortBy, opts.SortOrder)
+
+	// List the flights offering the requested special assistance first
+	sorted = PrioritizeServices(sorted, opts.SpecialServices)
+
+	// Keep each provider's best flights, so one provider cannot fill the
+	// results on its own, then truncate to the requested number of results
+	sorted = LimitPerProvider(sorted, opts.MaxResultsPerProvider)
+	if opts.MaxResults > 0 && len(sorted) > opts.MaxResults {
+		sorted = sorted[:opts.MaxResults]
+	}
 
 	// Build response with new format
-	successfulProviders := len(uc.providers) - len(failedProviders)
+	successfulProviders := providers - len(outcome.failed) - len(outcome.pending)
 	response := domain.NewSearchResponse(
 		&criteria,
 		sorted,
 		domain.SearchMetadata{
 			TotalResults:       len(sorted),
-			ProvidersQueried:   len(uc.providers),
+			ProvidersQueried:   providers,
 			ProvidersSucceeded: successfulProviders,
-			ProvidersFailed:    len(failedProviders),
-			SearchTimeMs:       time.Since(startTime).Milliseconds(),
-			CacheHit:           false, // Not implemented yet
+			ProvidersFailed:    len(outcome.failed),
+			SearchTimeMs:       outcome.elapsed.Milliseconds(),
+			RejectedRoutings:   rejectedRoutings,
+			DuplicatesMerged:   duplicatesMerged,
+			FilterRejections:   filterRejections,
+			FailedProviders:    outcome.failed,
+			PendingProviders:   outcome.pending,
+			SkippedProviders:   outcome.skipped,
 		},
 	)
 
-	return &response, nil
+	return &response
 }
 
-// queryProvider queries a single provider with timeout and panic recovery.
-func (uc *flightSearchUseCase) queryProvider(ctx context.Context, provider domain.FlightProvider, criteria domain.SearchCriteria, results chan<- providerResult) {
+// cached returns the cached results for key, or nil on a miss. Cache errors
+// are treated as misses: the cache never fails a search.
+func (uc *flightSearchUseCase) cached(ctx context.Context, key string) *domain.CachedSearch {
+	if uc.cache == nil {
+		return nil
+	}
+	entry, ok, err := uc.cache.Get(ctx, key)
+	if err != nil || !ok {
+		return nil
+	}
+	return entry
+}
+
+// store caches entry under key, ignoring cache errors.
+func (uc *flightSearchUseCase) store(ctx context.Context, key string, entry *domain.CachedSearch) {
+	if uc.cache == nil {
+		return
+	}
+	_ = uc.cache.Set(context.WithoutCancel(ctx), key, entry, uc.cacheTTL)
+}
+
+// providersWithinBudget splits the providers into those to query and those
+// whose p95 latency exceeds the budget left until ctx's deadline, e.g. after
+// retries consumed part of it. Providers without latency history are queried.
+func (uc *flightSearchUseCase) providersWithinBudget(ctx context.Context) (within []domain.FlightProvider, skipped []string) {
+	deadline, ok := ctx.Deadline()
+	if uc.latencies == nil || !ok {
+		return uc.providers, nil
+	}
+
+	remaining := time.Until(deadline)
+	for _, p := range uc.providers {
+		if p95, ok := uc.latencies.P95(p.Name()); ok && p95 > remaining {
+			skipped = append(skipped, p.Name())
+			continue
+		}
+		within = append(within, p)
+	}
+	return within, skipped
+}
+
+// unansweredProviders returns the names of the providers missing from answered.
+func unansweredProviders(providers []domain.FlightProvider, answered []string) []string {
+	var names []string
+	for _, p := range providers {
+		if !slices.Contains(answered, p.Name()) {
+			names = append(names, p.Name())
+		}
+	}
+	return names
+}
+
+// globalTimeoutFor returns the global timeout budget for the request class carried by ctx.
+// Classes without a configured budget use the default global timeout.
+func (uc *flightSearchUseCase) globalTimeoutFor(ctx context.Context) time.Duration {
+	if timeout, ok := uc.classTimeouts[RequestClassFromContext(ctx)]; ok && timeout > 0 {
+		return timeout
+	}
+	return uc.globalTimeout
+}
+
+// queryProvider queries a single provider with searchProvider and sends its
+// result on results.
+func (uc *flightSearchUseCase) queryProvider(ctx context.Context, provider domain.FlightProvider, criteria domain.SearchCriteria, hints domain.ProviderSearchOptions, results chan<- providerResult) {
+	results <- uc.searchProvider(ctx, provider, criteria, hints)
+}
+
+// searchProvider queries a single provider, with the filtering hints it
+// supports, with timeout, retries of transient errors and panic recovery.
+func (uc *flightSearchUseCase) searchProvider(ctx context.Context, provider domain.FlightProvider, criteria domain.SearchCriteria, hints domain.ProviderSearchOptions) (result providerResult) {
+	providerName := provider.Name()
+
 	// Per-provider timeout
-	ctx, cancel := context.WithTimeout(ctx, uc.providerTimeout)
+	ctx, cancel := context.WithTimeout(ctx, uc.providerTimeoutFor(providerName))
 	defer cancel()
 
 	start := time.Now()
-	providerName := provider.Name()
 
 	// Panic recovery to prevent one provider from crashing the whole search
 	defer func() {
 		if r := recover(); r != nil {
-			results <- providerResult{
+			result = providerResult{
 				Provider: providerName,
 				Error:    fmt.Errorf("provider panic: %v", r),
 				Duration: time.Since(start),
@@ -191,9 +693,14 @@ func (uc *flightSearchUseCase) queryProvider(ctx context.Context, provider domai
 		}
 	}()
 
-	flights, err := provider.Search(ctx, criteria)
+	var flights []domain.Flight
+	err := retry.Do(ctx, uc.retryConfig(providerName), func(ctx context.Context) error {
+		var err error
+		flights, err = domain.SearchWithOptions(ctx, provider, criteria, hints)
+		return err
+	})
 
-	results <- providerResult{
+	return providerResult{
 		Provider: providerName,
 		Flights:  flights,
 		Error:    err,
@@ -201,5 +708,43 @@ func (uc *flightSearchUseCase) queryProvider(ctx context.Context, provider domai
 	}
 }
 
+// strategyFor returns the fan-out strategy selected by opts, or the
+// configured one.
+func (uc *flightSearchUseCase) strategyFor(opts SearchOptions) Strategy {
+	if s, ok := uc.strategies[opts.FanOutStrategy]; ok {
+		return s
+	}
+	return uc.strategy
+}
+
+// providerTimeoutFor returns the timeout of searches of the named provider.
+func (uc *flightSearchUseCase) providerTimeoutFor(provider string) time.Duration {
+	if timeout, ok := uc.providerTimeouts[provider]; ok {
+		return timeout
+	}
+	return uc.providerTimeout
+}
+
+// retryConfig returns how searches of the named provider are retried.
+func (uc *flightSearchUseCase) retryConfig(provider string) retry.Config {
+	cfg := uc.retry
+	if attempts, ok := uc.retryAttempts[provider]; ok {
+		cfg.MaxAttempts = attempts
+	}
+	cfg.RetryIf = isRetryable
+	cfg.OnRetry = nil
+	if uc.retryObserver != nil {
+		cfg.OnRetry = func(int, error) { uc.retryObserver(provider) }
+	}
+	return cfg
+}
+
+// isRetryable reports whether a provider search failed with an error the
+// provider marked as transient.
+func isRetryable(err error) bool {
+	var providerErr *domain.ProviderError
+	return errors.As(err, &providerErr) && providerErr.Retryable
+}
+
 // Ensure flightSearchUseCase implements FlightSearchUseCase at compile time.
 var _ FlightSearchUseCase = (*flightSearchUseCase)(nil)
@@ -3,10 +3,12 @@ package usecase
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/retry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -282,6 +284,178 @@ func TestSearch_ProviderTimeout(t *testing.T) {
 	assert.Equal(t, 2, response.Metadata.ProvidersQueried)
 	assert.Equal(t, 1, response.Metadata.ProvidersSucceeded)
 	assert.Equal(t, 1, response.Metadata.ProvidersFailed)
+	assert.Equal(t, []string{"slow"}, response.Metadata.FailedProviders)
+}
+
+// TestSearch_ProviderTimeoutOverride tests that a provider's own timeout
+// replaces the default one.
+func TestSearch_ProviderTimeoutOverride(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	providers := []domain.FlightProvider{
+		setupMockProviderWithDelay(ctrl, "patient", []domain.Flight{createTestFlight("1", "patient", 1000000, 120, 0)}, 200*time.Millisecond),
+		setupMockProviderWithDelay(ctrl, "slow", []domain.Flight{createTestFlight("2", "slow", 1000000, 120, 0)}, 200*time.Millisecond),
+		setupMockProviderWithDelay(ctrl, "hasty", []domain.Flight{createTestFlight("3", "hasty", 1000000, 120, 0)}, 50*time.Millisecond),
+	}
+
+	uc := NewFlightSearchUseCase(providers, &Config{
+		GlobalTimeout:    time.Second,
+		ProviderTimeout:  100 * time.Millisecond,
+		ProviderTimeouts: map[string]time.Duration{"patient": 500 * time.Millisecond, "hasty": 10 * time.Millisecond},
+	})
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+	require.NoError(t, err)
+	require.Len(t, response.Flights, 1)
+	assert.Equal(t, "patient", response.Flights[0].Provider)
+	assert.Equal(t, 1, response.Metadata.ProvidersSucceeded)
+	assert.Equal(t, 2, response.Metadata.ProvidersFailed)
+}
+
+// TestSearch_PartialResults tests that searches accepting partial results
+// return at the soft deadline with the slow providers pending.
+func TestSearch_PartialResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers := []domain.FlightProvider{
+		setupMockProviderWithDelay(ctrl, "fast", []domain.Flight{createTestFlight("1", "fast", 1000000, 120, 0)}, 10*time.Millisecond),
+		setupMockProvider(ctrl, "broken", nil, errors.New("provider error")),
+		setupMockProviderWithDelay(ctrl, "slow", nil, 5*time.Second),
+	}
+
+	uc := NewFlightSearchUseCase(providers, &Config{
+		GlobalTimeout:   3 * time.Second,
+		ProviderTimeout: 2 * time.Second,
+		SoftTimeout:     100 * time.Millisecond,
+	})
+
+	start := time.Now()
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{PartialResultsOK: true})
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	assert.Less(t, elapsed, time.Second, "returns at the soft deadline")
+	assert.Len(t, response.Flights, 1)
+	assert.Equal(t, 3, response.Metadata.ProvidersQueried)
+	assert.Equal(t, 1, response.Metadata.ProvidersSucceeded)
+	assert.Equal(t, 1, response.Metadata.ProvidersFailed)
+	assert.Equal(t, []string{"slow"}, response.Metadata.PendingProviders)
+	assert.Empty(t, response.Metadata.ContinuationToken, "no continuation buffer configured")
+}
+
+// TestSearch_PartialResultsWaitsForFirstAnswer tests that a partial search
+// keeps waiting past the soft deadline until a provider has answered.
+func TestSearch_PartialResultsWaitsForFirstAnswer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers := []domain.FlightProvider{
+		setupMockProviderWithDelay(ctrl, "late", []domain.Flight{createTestFlight("1", "late", 1000000, 120, 0)}, 200*time.Millisecond),
+		setupMockProviderWithDelay(ctrl, "slow", nil, 5*time.Second),
+	}
+
+	uc := NewFlightSearchUseCase(providers, &Config{
+		GlobalTimeout:   3 * time.Second,
+		ProviderTimeout: 2 * time.Second,
+		SoftTimeout:     50 * time.Millisecond,
+	})
+
+	start := time.Now()
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{PartialResultsOK: true})
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, elapsed, 200*time.Millisecond)
+	assert.Less(t, elapsed, time.Second)
+	assert.Len(t, response.Flights, 1)
+	assert.Equal(t, []string{"slow"}, response.Metadata.PendingProviders)
+}
+
+// TestSearch_PartialResultsAllAnswered tests that a partial search answered by
+// every provider before the soft deadline is a complete response.
+func TestSearch_PartialResultsAllAnswered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers := []domain.FlightProvider{
+		setupMockProvider(ctrl, "p1", []domain.Flight{createTestFlight("1", "p1", 1000000, 120, 0)}, nil),
+		setupMockProvider(ctrl, "p2", []domain.Flight{createTestFlight("2", "p2", 1100000, 120, 0)}, nil),
+	}
+
+	uc := NewFlightSearchUseCase(providers, &Config{SoftTimeout: time.Second})
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{PartialResultsOK: true})
+
+	require.NoError(t, err)
+	assert.Len(t, response.Flights, 2)
+	assert.Equal(t, 2, response.Metadata.ProvidersSucceeded)
+	assert.Empty(t, response.Metadata.PendingProviders)
+}
+
+// latencyHistory is a fixed LatencyHistory.
+type latencyHistory map[string]time.Duration
+
+func (h latencyHistory) P95(provider string) (time.Duration, bool) {
+	p95, ok := h[provider]
+	return p95, ok
+}
+
+// TestSearch_SkipsProvidersOverBudget tests that providers whose p95 latency
+// exceeds the remaining budget are not queried.
+func TestSearch_SkipsProvidersOverBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	slow := domain.NewMockFlightProvider(ctrl)
+	slow.EXPECT().Name().Return("slow").AnyTimes()
+	slow.EXPECT().Search(gomock.Any(), gomock.Any()).Times(0)
+
+	providers := []domain.FlightProvider{
+		setupMockProvider(ctrl, "fast", []domain.Flight{createTestFlight("1", "fast", 1000000, 120, 0)}, nil),
+		setupMockProvider(ctrl, "new", []domain.Flight{createTestFlight("2", "new", 1100000, 120, 0)}, nil),
+		slow,
+	}
+	uc := NewFlightSearchUseCase(providers, &Config{
+		Latencies: latencyHistory{"fast": 50 * time.Millisecond, "slow": 2 * time.Second},
+	})
+
+	// Earlier attempts consumed most of the budget
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	response, err := uc.Search(ctx, domain.SearchCriteria{}, SearchOptions{})
+	require.NoError(t, err)
+	assert.Len(t, response.Flights, 2, "providers without history are queried")
+	assert.Equal(t, 2, response.Metadata.ProvidersQueried)
+	assert.Equal(t, 2, response.Metadata.ProvidersSucceeded)
+	assert.Equal(t, []string{"slow"}, response.Metadata.SkippedProviders)
+
+	// With the full budget, every provider is queried
+	response, err = NewFlightSearchUseCase(providers[:2], &Config{
+		Latencies: latencyHistory{"fast": 50 * time.Millisecond, "new": 2 * time.Second},
+	}).Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, response.Metadata.SkippedProviders)
+}
+
+// TestSearch_AllProvidersOverBudget tests that a search fails with a timeout
+// when no provider can answer within the remaining budget.
+func TestSearch_AllProvidersOverBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	slow := domain.NewMockFlightProvider(ctrl)
+	slow.EXPECT().Name().Return("slow").AnyTimes()
+
+	uc := NewFlightSearchUseCase([]domain.FlightProvider{slow}, &Config{
+		Latencies: latencyHistory{"slow": 2 * time.Second},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := uc.Search(ctx, domain.SearchCriteria{}, SearchOptions{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
 // TestSearch_GlobalTimeout tests global timeout behavior.
@@ -314,6 +488,53 @@ func TestSearch_GlobalTimeout(t *testing.T) {
 	assert.Less(t, elapsed, 500*time.Millisecond)
 }
 
+// TestSearch_RequestClassTimeout tests that the global timeout follows the request class.
+func TestSearch_RequestClassTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	flight := createTestFlight("1", "slow", 1000000, 120, 0)
+	providers := []domain.FlightProvider{
+		setupMockProviderWithDelay(ctrl, "slow", []domain.Flight{flight}, 200*time.Millisecond),
+	}
+
+	uc := NewFlightSearchUseCase(providers, &Config{
+		GlobalTimeout:   50 * time.Millisecond,
+		ProviderTimeout: 5 * time.Second,
+		ClassTimeouts: map[domain.RequestClass]time.Duration{
+			domain.RequestClassBatch: 2 * time.Second,
+		},
+	})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr bool
+	}{
+		{name: "no class uses global timeout", ctx: context.Background(), wantErr: true},
+		{name: "interactive uses global timeout", ctx: WithRequestClass(context.Background(), domain.RequestClassInteractive), wantErr: true},
+		{name: "batch uses its own budget", ctx: WithRequestClass(context.Background(), domain.RequestClassBatch)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := uc.Search(tt.ctx, domain.SearchCriteria{}, SearchOptions{})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrAllProvidersFailed)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, result.Flights, 1)
+		})
+	}
+}
+
+func TestRequestClassFromContext(t *testing.T) {
+	assert.Equal(t, domain.RequestClassInteractive, RequestClassFromContext(context.Background()))
+	ctx := WithRequestClass(context.Background(), domain.RequestClassBatch)
+	assert.Equal(t, domain.RequestClassBatch, RequestClassFromContext(ctx))
+}
+
 // TestSearch_ContextCancellation tests context cancellation handling.
 func TestSearch_ContextCancellation(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -337,9 +558,52 @@ func TestSearch_ContextCancellation(t *testing.T) {
 	elapsed := time.Since(start)
 
 	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrSearchAborted)
+	assert.ErrorIs(t, err, context.Canceled)
 	assert.Less(t, elapsed, 500*time.Millisecond)
 }
 
+// TestSearch_ClientDisconnectStopsProviders tests that a cancelled request
+// cancels the providers still running, even for continuable searches whose
+// providers otherwise outlive the request.
+func TestSearch_ClientDisconnectStopsProviders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stopped := make(chan struct{})
+	slow := domain.NewMockFlightProvider(ctrl)
+	slow.EXPECT().Name().Return("slow").AnyTimes()
+	slow.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+			<-ctx.Done()
+			close(stopped)
+			return nil, ctx.Err()
+		},
+	)
+	providers := []domain.FlightProvider{
+		setupMockProvider(ctrl, "fast", []domain.Flight{createTestFlight("1", "fast", 1000000, 120, 0)}, nil),
+		slow,
+	}
+
+	uc := NewFlightSearchUseCase(providers, &Config{
+		GlobalTimeout:   5 * time.Second,
+		ProviderTimeout: 5 * time.Second,
+		SoftTimeout:     time.Second,
+		Continuations:   NewContinuationBuffer(time.Minute, nil),
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := uc.Search(ctx, domain.SearchCriteria{}, SearchOptions{PartialResultsOK: true})
+	require.ErrorIs(t, err, domain.ErrSearchAborted)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("provider still running after the client disconnected")
+	}
+}
+
 // TestSearch_ProviderPanic tests panic recovery in provider calls.
 func TestSearch_ProviderPanic(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -367,6 +631,65 @@ func TestSearch_ProviderPanic(t *testing.T) {
 	assert.Equal(t, 1, response.Metadata.ProvidersFailed)
 }
 
+// TestSearch_RetriesTransientProviderErrors tests that provider errors marked
+// retryable are retried, up to the attempts configured for the provider.
+func TestSearch_RetriesTransientProviderErrors(t *testing.T) {
+	transient := domain.NewRetryableProviderError("flaky", errors.New("connection reset"))
+	permanent := domain.NewProviderError("flaky", domain.ErrProviderUnavailable)
+
+	tests := []struct {
+		name          string
+		errs          []error
+		attempts      map[string]int
+		wantCalls     int
+		wantRetries   int
+		wantSucceeded int
+	}{
+		{name: "transient error retried", errs: []error{transient}, wantCalls: 2, wantRetries: 1, wantSucceeded: 1},
+		{name: "attempts exhausted", errs: []error{transient, transient}, wantCalls: 2, wantRetries: 1},
+		{name: "per-provider attempts", errs: []error{transient, transient}, attempts: map[string]int{"flaky": 3}, wantCalls: 3, wantRetries: 2, wantSucceeded: 1},
+		{name: "retries disabled for provider", errs: []error{transient}, attempts: map[string]int{"flaky": 1}, wantCalls: 1},
+		{name: "permanent error not retried", errs: []error{permanent}, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			calls := 0
+			provider := domain.NewMockFlightProvider(ctrl)
+			provider.EXPECT().Name().Return("flaky").AnyTimes()
+			provider.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+					calls++
+					if calls <= len(tt.errs) {
+						return nil, tt.errs[calls-1]
+					}
+					return []domain.Flight{createTestFlight("1", "flaky", 1000000, 120, 0)}, nil
+				},
+			).AnyTimes()
+
+			var retried []string
+			uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, &Config{
+				Retry:         retry.Config{MaxAttempts: 2, InitialBackoff: time.Millisecond},
+				RetryAttempts: tt.attempts,
+				RetryObserver: func(provider string) { retried = append(retried, provider) },
+			})
+
+			response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Len(t, retried, tt.wantRetries)
+			if tt.wantSucceeded == 0 {
+				assert.ErrorIs(t, err, domain.ErrAllProvidersFailed)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSucceeded, response.Metadata.ProvidersSucceeded)
+			assert.Len(t, response.Flights, 1)
+		})
+	}
+}
+
 // TestSearch_SingleProvider tests the scatter-gather pattern with a single provider.
 func TestSearch_SingleProvider(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -428,6 +751,56 @@ func TestSearch_WithFiltering(t *testing.T) {
 	assert.Len(t, response.Flights, 2) // Only flights 1 and 4
 }
 
+// TestSearch_Progress tests that each provider's answer is reported as it arrives.
+func TestSearch_Progress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers := []domain.FlightProvider{
+		setupMockProviderWithDelay(ctrl, "fast", []domain.Flight{
+			createTestFlight("1", "fast", 500000, 120, 0),
+			createTestFlight("2", "fast", 1500000, 100, 0), // Above max price
+		}, 0),
+		setupMockProviderWithDelay(ctrl, "slow", []domain.Flight{
+			createTestFlight("3", "slow", 600000, 130, 0),
+		}, 50*time.Millisecond),
+		setupMockProvider(ctrl, "broken", nil, errors.New("provider error")),
+	}
+
+	uc := NewFlightSearchUseCase(providers, nil)
+
+	// Progress is reported from the gathering goroutine, before Search returns
+	var progress []ProviderProgress
+	maxPrice := float64(1000000)
+	opts := SearchOptions{
+		Filters:  &domain.FilterOptions{MaxPrice: &maxPrice},
+		Progress: func(p ProviderProgress) { progress = append(progress, p) },
+	}
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, opts)
+	require.NoError(t, err)
+	assert.Len(t, response.Flights, 2)
+
+	require.Len(t, progress, 3)
+	for i, p := range progress {
+		assert.Equal(t, i+1, p.Completed)
+		assert.Equal(t, 3, p.Total)
+	}
+	last := progress[2]
+	assert.Equal(t, "slow", last.Provider)
+	require.Len(t, last.Flights, 1)
+	assert.Equal(t, "3", last.Flights[0].ID)
+
+	byProvider := make(map[string]ProviderProgress)
+	for _, p := range progress {
+		byProvider[p.Provider] = p
+	}
+	assert.Error(t, byProvider["broken"].Err)
+	assert.Empty(t, byProvider["broken"].Flights)
+	require.Len(t, byProvider["fast"].Flights, 1, "filtered like the final response")
+	assert.Equal(t, "1", byProvider["fast"].Flights[0].ID)
+}
+
 // TestSearch_SortByPrice tests sorting by price.
 func TestSearch_SortByPrice(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -462,6 +835,72 @@ func TestSearch_SortByPrice(t *testing.T) {
 	assert.Equal(t, float64(1500000), response.Flights[2].Price.Amount)
 }
 
+// TestSearch_MaxResults tests that results are truncated after sorting.
+func TestSearch_MaxResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	flights := []domain.Flight{
+		createTestFlight("1", "test", 1500000, 120, 0),
+		createTestFlight("2", "test", 500000, 100, 0),
+		createTestFlight("3", "test", 1000000, 110, 0),
+	}
+
+	providers := []domain.FlightProvider{
+		setupMockProvider(ctrl, "test", flights, nil),
+	}
+
+	uc := NewFlightSearchUseCase(providers, nil)
+
+	opts := SearchOptions{
+		SortBy:     domain.SortByPrice,
+		MaxResults: 2,
+	}
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, opts)
+
+	require.NoError(t, err)
+	require.Len(t, response.Flights, 2)
+	assert.Equal(t, 2, response.Metadata.TotalResults)
+	assert.Equal(t, "2", response.Flights[0].ID)
+	assert.Equal(t, "3", response.Flights[1].ID)
+}
+
+// TestSearch_MaxResultsPerProvider tests that each provider's best flights are
+// kept before the results are truncated.
+func TestSearch_MaxResultsPerProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers := []domain.FlightProvider{
+		setupMockProvider(ctrl, "verbose", []domain.Flight{
+			createTestFlight("v1", "verbose", 500000, 100, 0),
+			createTestFlight("v2", "verbose", 600000, 100, 0),
+			createTestFlight("v3", "verbose", 700000, 100, 0),
+		}, nil),
+		setupMockProvider(ctrl, "terse", []domain.Flight{
+			createTestFlight("t1", "terse", 900000, 100, 0),
+		}, nil),
+	}
+
+	uc := NewFlightSearchUseCase(providers, nil)
+
+	opts := SearchOptions{
+		SortBy:                domain.SortByPrice,
+		MaxResults:            3,
+		MaxResultsPerProvider: 2,
+	}
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, opts)
+
+	require.NoError(t, err)
+	require.Len(t, response.Flights, 3)
+	assert.Equal(t, 3, response.Metadata.TotalResults)
+	assert.Equal(t, "v1", response.Flights[0].ID)
+	assert.Equal(t, "v2", response.Flights[1].ID)
+	assert.Equal(t, "t1", response.Flights[2].ID)
+}
+
 // TestSearch_SortByDuration tests sorting by duration.
 func TestSearch_SortByDuration(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -577,6 +1016,31 @@ func TestSearch_SortByBestValue(t *testing.T) {
 	}
 }
 
+func TestSearch_PreferredDepartureTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	// Identical flights departing at 08:00 and 18:00
+	morning := createTestFlight("morning", "test", 1000000, 120, 0)
+	evening := createTestFlight("evening", "test", 1000000, 120, 0)
+	evening.Departure.DateTime = evening.Departure.DateTime.Add(10 * time.Hour)
+
+	uc := NewFlightSearchUseCase([]domain.FlightProvider{
+		setupMockProvider(ctrl, "test", []domain.Flight{morning, evening}, nil),
+	}, nil)
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{
+		PreferredDepartureTime: &domain.TimeRange{
+			Start: time.Date(0, 1, 1, 17, 0, 0, 0, time.UTC),
+			End:   time.Date(0, 1, 1, 20, 0, 0, 0, time.UTC),
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, response.Flights, 2, "the preference excludes no flights")
+	assert.Equal(t, "evening", response.Flights[0].ID)
+	assert.Greater(t, response.Flights[1].RankingScore, response.Flights[0].RankingScore)
+}
+
 // TestSearch_RankingScoresCalculated tests that ranking scores are calculated.
 func TestSearch_RankingScoresCalculated(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -662,6 +1126,153 @@ func TestDefaultConfig(t *testing.T) {
 
 	assert.Equal(t, DefaultGlobalTimeout, cfg.GlobalTimeout)
 	assert.Equal(t, DefaultProviderTimeout, cfg.ProviderTimeout)
+	assert.Equal(t, DefaultSoftTimeout, cfg.SoftTimeout)
+	assert.Equal(t, DefaultCacheTTL, cfg.CacheTTL)
+}
+
+// searchCache is an in-memory domain.SearchCache recording the TTLs it is given.
+type searchCache struct {
+	mu      sync.Mutex
+	entries map[string]*domain.CachedSearch
+	ttls    []time.Duration
+	getErr  error
+}
+
+func (c *searchCache) Get(_ context.Context, key string) (*domain.CachedSearch, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.getErr != nil {
+		return nil, false, c.getErr
+	}
+	entry, ok := c.entries[key]
+	return entry, ok, nil
+}
+
+func (c *searchCache) Set(_ context.Context, key string, entry *domain.CachedSearch, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]*domain.CachedSearch)
+	}
+	c.entries[key] = entry
+	c.ttls = append(c.ttls, ttl)
+	return nil
+}
+
+func TestSearch_Cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	provider := domain.NewMockFlightProvider(ctrl)
+	provider.EXPECT().Name().Return("provider1").AnyTimes()
+	provider.EXPECT().Search(gomock.Any(), gomock.Any()).Return([]domain.Flight{
+		createTestFlight("1", "provider1", 1000000, 120, 0),
+		createTestFlight("2", "provider1", 2000000, 120, 0),
+	}, nil).Times(1)
+
+	cache := &searchCache{}
+	uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, &Config{Cache: cache, CacheTTL: time.Minute})
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15"}
+
+	first, err := uc.Search(context.Background(), criteria, SearchOptions{})
+	require.NoError(t, err)
+	assert.False(t, first.Metadata.CacheHit)
+	assert.Equal(t, []time.Duration{time.Minute}, cache.ttls)
+
+	// Equivalent criteria hit the cache; filters still apply to cached results
+	maxPrice := 1500000.0
+	criteria.Origin = "cgk"
+	second, err := uc.Search(context.Background(), criteria, SearchOptions{Filters: &domain.FilterOptions{MaxPrice: &maxPrice}})
+	require.NoError(t, err)
+	assert.True(t, second.Metadata.CacheHit)
+	assert.Equal(t, 1, second.Metadata.ProvidersQueried)
+	assert.Equal(t, 1, second.Metadata.ProvidersSucceeded)
+	require.Len(t, second.Flights, 1)
+	assert.Equal(t, "1", second.Flights[0].ID)
+}
+
+func TestSearch_CacheSkipsIncompleteResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	providers := []domain.FlightProvider{
+		setupMockProvider(ctrl, "provider1", []domain.Flight{createTestFlight("1", "provider1", 1000000, 120, 0)}, nil),
+		setupMockProvider(ctrl, "provider2", nil, errors.New("provider error")),
+	}
+	cache := &searchCache{}
+	uc := NewFlightSearchUseCase(providers, &Config{Cache: cache})
+
+	_, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, cache.entries, "results with a failed provider are not cached")
+}
+
+func TestSearch_CacheErrorIsMiss(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	providers := []domain.FlightProvider{
+		setupMockProvider(ctrl, "provider1", []domain.Flight{createTestFlight("1", "provider1", 1000000, 120, 0)}, nil),
+	}
+	cache := &searchCache{getErr: errors.New("connection refused")}
+	uc := NewFlightSearchUseCase(providers, &Config{Cache: cache})
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+	require.NoError(t, err)
+	assert.False(t, response.Metadata.CacheHit)
+	assert.Len(t, response.Flights, 1)
+}
+
+// filteringProvider records the hints it is searched with and, like a
+// provider trusting its upstream, returns every flight regardless.
+type filteringProvider struct {
+	mu      sync.Mutex
+	flights []domain.Flight
+	hints   []domain.ProviderSearchOptions
+}
+
+func (p *filteringProvider) Name() string { return "filtering" }
+
+func (p *filteringProvider) Search(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	return p.SearchWithOptions(ctx, criteria, domain.ProviderSearchOptions{})
+}
+
+func (p *filteringProvider) SearchWithOptions(_ context.Context, _ domain.SearchCriteria, opts domain.ProviderSearchOptions) ([]domain.Flight, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hints = append(p.hints, opts)
+	return p.flights, nil
+}
+
+func TestSearch_ProviderHints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	filtering := &filteringProvider{flights: []domain.Flight{
+		createTestFlight("1", "filtering", 1000000, 120, 0),
+		createTestFlight("2", "filtering", 2000000, 120, 0),
+	}}
+	plain := setupMockProvider(ctrl, "plain", []domain.Flight{createTestFlight("3", "plain", 3000000, 120, 0)}, nil)
+	cache := &searchCache{}
+	uc := NewFlightSearchUseCase([]domain.FlightProvider{filtering, plain}, &Config{Cache: cache})
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15"}
+
+	maxPrice := 1500000.0
+	response, err := uc.Search(context.Background(), criteria, SearchOptions{Filters: &domain.FilterOptions{MaxPrice: &maxPrice}})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ProviderSearchOptions{{MaxPrice: maxPrice}}, filtering.hints)
+	require.Len(t, response.Flights, 1, "filters are applied to what providers return")
+	assert.Equal(t, "1", response.Flights[0].ID)
+
+	// Results fetched with hints may be incomplete and are cached apart
+	assert.Contains(t, cache.entries, domain.ProviderSearchHash(criteria, domain.ProviderSearchOptions{MaxPrice: maxPrice}))
+	assert.NotContains(t, cache.entries, domain.CriteriaHash(criteria))
+
+	cached, err := uc.Search(context.Background(), criteria, SearchOptions{Filters: &domain.FilterOptions{MaxPrice: &maxPrice}})
+	require.NoError(t, err)
+	assert.True(t, cached.Metadata.CacheHit, "the same hints hit the cache")
+
+	otherPrice := 2500000.0
+	other, err := uc.Search(context.Background(), criteria, SearchOptions{Filters: &domain.FilterOptions{MaxPrice: &otherPrice}})
+	require.NoError(t, err)
+	assert.False(t, other.Metadata.CacheHit, "other hints miss the cache")
+	assert.Len(t, filtering.hints, 2)
 }
 
 // TestApplyFilters_Basic tests the filter application function directly.
@@ -863,7 +1474,7 @@ func TestSortFlights(t *testing.T) {
 				originalFirst = tt.flights[0].ID
 			}
 
-			result := SortFlights(tt.flights, tt.sortBy)
+			result := SortFlights(tt.flights, tt.sortBy, domain.SortAscending)
 
 			assert.Len(t, result, tt.expectedLen)
 			if tt.expectedFirst != "" && len(result) > 0 {
@@ -921,3 +1532,76 @@ func TestSearch_VerifySearchCalledOnce(t *testing.T) {
 
 	// gomock will automatically verify expectations when ctrl.Finish() is called
 }
+
+// TestSearch_FiltersClass verifies flights of another class than the one
+// searched for are filtered out, even when providers return them.
+func TestSearch_FiltersClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	economy := createTestFlight("1", "provider1", 1000000, 120, 0)
+	business := createTestFlight("2", "provider1", 3000000, 120, 0)
+	business.Class = "business"
+	provider := setupMockProvider(ctrl, "provider1", []domain.Flight{economy, business}, nil)
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Class: "economy"}
+
+	tests := []struct {
+		name    string
+		opts    SearchOptions
+		wantIDs []string
+	}{
+		{"searched class only", SearchOptions{}, []string{"1"}},
+		{"higher classes included", SearchOptions{Filters: &domain.FilterOptions{IncludeHigherClasses: true}}, []string{"1", "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, &Config{DisableCoalescing: true})
+			response, err := uc.Search(context.Background(), criteria, tt.opts)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, f := range response.Flights {
+				ids = append(ids, f.ID)
+			}
+			assert.ElementsMatch(t, tt.wantIDs, ids)
+			assert.Equal(t, 2-len(tt.wantIDs), response.Metadata.FilterRejections[domain.FilterClass])
+		})
+	}
+}
+
+func TestSearch_PriceBasis(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	provider := setupMockProvider(ctrl, "provider1", []domain.Flight{
+		createTestFlight("1", "provider1", 1000000, 120, 0),
+		createTestFlight("2", "provider1", 1500000, 120, 0),
+	}, nil)
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 3}
+	maxPrice := float64(4000000)
+
+	tests := []struct {
+		name    string
+		basis   domain.PriceBasis
+		wantIDs []string
+	}{
+		{"per person by default", "", []string{"1", "2"}},
+		{"total", domain.PriceBasisTotal, []string{"1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, &Config{DisableCoalescing: true, PriceBasis: tt.basis})
+			response, err := uc.Search(context.Background(), criteria, SearchOptions{
+				SortBy:  domain.SortByPrice,
+				Filters: &domain.FilterOptions{MaxPrice: &maxPrice},
+			})
+			require.NoError(t, err)
+
+			var ids []string
+			for _, f := range response.Flights {
+				ids = append(ids, f.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, 3, response.Flights[0].Price.Passengers)
+			assert.Equal(t, float64(3000000), response.Flights[0].Price.Total)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// GroupQuoteUseCase defines the interface for group fare quoting.
+type GroupQuoteUseCase interface {
+	// Quote asks every group quote provider for an indicative fare for a party
+	// larger than domain.MaxPassengers and returns the quotes, cheapest first.
+	Quote(ctx context.Context, criteria domain.SearchCriteria) (*domain.GroupQuoteResponse, error)
+}
+
+// groupQuoteUseCase implements GroupQuoteUseCase by querying the providers
+// concurrently.
+type groupQuoteUseCase struct {
+	providers       []domain.GroupQuoteProvider
+	globalTimeout   time.Duration
+	providerTimeout time.Duration
+}
+
+// NewGroupQuoteUseCase creates a GroupQuoteUseCase querying the given
+// providers with the timeouts of config (nil = DefaultConfig).
+func NewGroupQuoteUseCase(providers []domain.GroupQuoteProvider, config *Config) GroupQuoteUseCase {
+	cfg := DefaultConfig()
+	if config != nil {
+		if config.GlobalTimeout > 0 {
+			cfg.GlobalTimeout = config.GlobalTimeout
+		}
+		if config.ProviderTimeout > 0 {
+			cfg.ProviderTimeout = config.ProviderTimeout
+		}
+	}
+
+	return &groupQuoteUseCase{
+		providers:       providers,
+		globalTimeout:   cfg.GlobalTimeout,
+		providerTimeout: cfg.ProviderTimeout,
+	}
+}
+
+// groupQuoteResult holds the answer of a single provider.
+type groupQuoteResult struct {
+	quote *domain.GroupQuote
+	err   error
+}
+
+// Quote implements GroupQuoteUseCase.Quote. Providers that do not serve the
+// route (domain.ErrNoFlightsFound) succeed without a quote; the request fails
+// with domain.ErrAllProvidersFailed only when every provider failed.
+func (uc *groupQuoteUseCase) Quote(ctx context.Context, criteria domain.SearchCriteria) (*domain.GroupQuoteResponse, error) {
+	start := time.Now()
+
+	if err := criteria.ValidateGroup(); err != nil {
+		return nil, err
+	}
+	if len(uc.providers) == 0 {
+		return nil, domain.ErrAllProvidersFailed
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.globalTimeout)
+	defer cancel()
+
+	results := make([]groupQuoteResult, len(uc.providers))
+	var wg sync.WaitGroup
+	for i, p := range uc.providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = uc.queryProvider(ctx, p, criteria)
+		}()
+	}
+	wg.Wait()
+
+	var quotes []domain.GroupQuote
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.err == nil && r.quote != nil:
+			quotes = append(quotes, *r.quote)
+		case r.err != nil && !errors.Is(r.err, domain.ErrNoFlightsFound):
+			failed++
+		}
+	}
+	if failed == len(uc.providers) {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, domain.ErrAllProvidersFailed
+	}
+
+	slices.SortStableFunc(quotes, func(a, b domain.GroupQuote) int {
+		return cmp.Compare(a.TotalFare.Amount, b.TotalFare.Amount)
+	})
+
+	response := domain.NewGroupQuoteResponse(&criteria, quotes, domain.GroupQuoteMetadata{
+		ProvidersQueried:   len(uc.providers),
+		ProvidersSucceeded: len(uc.providers) - failed,
+		ProvidersFailed:    failed,
+		SearchTimeMs:       time.Since(start).Milliseconds(),
+	})
+	return &response, nil
+}
+
+// queryProvider asks one provider for a quote within the provider timeout.
+func (uc *groupQuoteUseCase) queryProvider(ctx context.Context, provider domain.GroupQuoteProvider, criteria domain.SearchCriteria) (result groupQuoteResult) {
+	ctx, cancel := context.WithTimeout(ctx, uc.providerTimeout)
+	defer cancel()
+
+	// Panic recovery to prevent one provider from failing the whole request
+	defer func() {
+		if r := recover(); r != nil {
+			result = groupQuoteResult{err: fmt.Errorf("provider panic: %v", r)}
+		}
+	}()
+
+	quote, err := provider.Quote(ctx, criteria)
+	return groupQuoteResult{quote: quote, err: err}
+}
+
+// Ensure groupQuoteUseCase implements GroupQuoteUseCase at compile time.
+var _ GroupQuoteUseCase = (*groupQuoteUseCase)(nil)
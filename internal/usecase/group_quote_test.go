@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// groupQuoter is a domain.GroupQuoteProvider returning a fixed answer.
+type groupQuoter struct {
+	name  string
+	total float64
+	err   error
+}
+
+func (q groupQuoter) Name() string { return q.name }
+
+func (q groupQuoter) Quote(_ context.Context, criteria domain.SearchCriteria) (*domain.GroupQuote, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	return &domain.GroupQuote{
+		Provider:  q.name,
+		TotalFare: domain.PriceInfo{Amount: q.total, Currency: "IDR"},
+	}, nil
+}
+
+var groupCriteria = domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 12, Class: "economy"}
+
+func TestGroupQuote_CheapestFirst(t *testing.T) {
+	uc := NewGroupQuoteUseCase([]domain.GroupQuoteProvider{
+		groupQuoter{name: "garuda", total: 18000000},
+		groupQuoter{name: "lion_air", total: 9000000},
+		groupQuoter{name: "batik_air", err: errors.New("down")},
+		groupQuoter{name: "airasia", err: domain.ErrNoFlightsFound},
+	}, nil)
+
+	result, err := uc.Quote(context.Background(), groupCriteria)
+	require.NoError(t, err)
+
+	require.Len(t, result.Quotes, 2)
+	assert.Equal(t, "lion_air", result.Quotes[0].Provider)
+	assert.Equal(t, "garuda", result.Quotes[1].Provider)
+	assert.Equal(t, 12, result.SearchCriteria.Passengers)
+	assert.Equal(t, 4, result.Metadata.ProvidersQueried)
+	assert.Equal(t, 3, result.Metadata.ProvidersSucceeded, "a route not served is not a failure")
+	assert.Equal(t, 1, result.Metadata.ProvidersFailed)
+}
+
+func TestGroupQuote_AllProvidersFailed(t *testing.T) {
+	uc := NewGroupQuoteUseCase([]domain.GroupQuoteProvider{
+		groupQuoter{name: "garuda", err: errors.New("down")},
+	}, nil)
+
+	_, err := uc.Quote(context.Background(), groupCriteria)
+	assert.ErrorIs(t, err, domain.ErrAllProvidersFailed)
+}
+
+func TestGroupQuote_NoQuotes(t *testing.T) {
+	uc := NewGroupQuoteUseCase([]domain.GroupQuoteProvider{
+		groupQuoter{name: "garuda", err: domain.ErrNoFlightsFound},
+	}, nil)
+
+	result, err := uc.Quote(context.Background(), groupCriteria)
+	require.NoError(t, err)
+	assert.NotNil(t, result.Quotes)
+	assert.Empty(t, result.Quotes)
+}
+
+func TestGroupQuote_InvalidCriteria(t *testing.T) {
+	uc := NewGroupQuoteUseCase([]domain.GroupQuoteProvider{groupQuoter{name: "garuda"}}, nil)
+
+	small := groupCriteria
+	small.Passengers = 9
+	_, err := uc.Quote(context.Background(), small)
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+}
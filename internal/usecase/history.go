@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// Limits of the searches listed by SearchHistoryUseCase.List.
+const (
+	DefaultSearchHistoryLimit = 20
+	MaxSearchHistoryLimit     = 100
+)
+
+// SearchHistoryUseCase gives clients access to their own stored searches,
+// so they can go back to a recent search's criteria and results.
+type SearchHistoryUseCase interface {
+	// List returns up to limit of the client's most recent searches, newest
+	// first. Limits outside 1-MaxSearchHistoryLimit use the closest bound.
+	List(ctx context.Context, client string, limit int) ([]domain.SearchRecord, error)
+
+	// Get returns the client's stored search with the given ID.
+	// Returns domain.ErrSearchNotFound if the search is unknown or was run by
+	// another client.
+	Get(ctx context.Context, client, searchID string) (*domain.SearchRecord, error)
+}
+
+// searchHistoryUseCase implements SearchHistoryUseCase.
+type searchHistoryUseCase struct {
+	store domain.SearchHistoryStore
+}
+
+// NewSearchHistoryUseCase creates a SearchHistoryUseCase serving the
+// searches of store.
+func NewSearchHistoryUseCase(store domain.SearchHistoryStore) SearchHistoryUseCase {
+	return &searchHistoryUseCase{store: store}
+}
+
+// List implements SearchHistoryUseCase.List.
+func (uc *searchHistoryUseCase) List(ctx context.Context, client string, limit int) ([]domain.SearchRecord, error) {
+	limit = max(1, min(limit, MaxSearchHistoryLimit))
+	return uc.store.ListByClient(ctx, client, limit)
+}
+
+// Get implements SearchHistoryUseCase.Get.
+func (uc *searchHistoryUseCase) Get(ctx context.Context, client, searchID string) (*domain.SearchRecord, error) {
+	return clientRecord(ctx, uc.store, client, searchID)
+}
+
+// clientRecord returns the stored search with the given ID if client ran it.
+func clientRecord(ctx context.Context, store domain.SearchRecordStore, client, searchID string) (*domain.SearchRecord, error) {
+	record, err := store.Get(ctx, searchID)
+	if err != nil {
+		return nil, err
+	}
+	// Other clients' searches are reported as unknown, not forbidden, so
+	// search IDs cannot be probed
+	if record.Client != client {
+		return nil, domain.ErrSearchNotFound
+	}
+	return record, nil
+}
+
+// Ensure searchHistoryUseCase implements SearchHistoryUseCase at compile time.
+var _ SearchHistoryUseCase = (*searchHistoryUseCase)(nil)
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/store/memory"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+func TestSearchHistoryUseCase(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewSearchStore(200)
+	start := time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC)
+	for i := range 150 {
+		require.NoError(t, store.Save(ctx, domain.SearchRecord{
+			ID:        fmt.Sprintf("search-%d", i),
+			Client:    "partner-app",
+			CreatedAt: start.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "ops-1", Client: "ops"}))
+	uc := NewSearchHistoryUseCase(store)
+
+	records, err := uc.List(ctx, "partner-app", 0)
+	require.NoError(t, err)
+	assert.Len(t, records, 1, "limits below 1 list one search")
+
+	records, err = uc.List(ctx, "partner-app", 1000)
+	require.NoError(t, err)
+	assert.Len(t, records, MaxSearchHistoryLimit)
+	assert.Equal(t, start.Add(149*time.Minute), records[0].CreatedAt, "newest first")
+
+	records, err = uc.List(ctx, "ops", DefaultSearchHistoryLimit)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "ops-1", records[0].ID)
+
+	record, err := uc.Get(ctx, "ops", "ops-1")
+	require.NoError(t, err)
+	assert.Equal(t, "ops", record.Client)
+
+	_, err = uc.Get(ctx, "partner-app", "ops-1")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound, "other clients' searches are not found")
+
+	_, err = uc.Get(ctx, "ops", "missing")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+}
@@ -11,6 +11,82 @@ type SearchOptions struct {
 
 	// SortBy specifies how to sort the results (default: best value)
 	SortBy domain.SortOption
+
+	// SortOrder is the direction of the sort (default: ascending)
+	SortOrder domain.SortOrder
+
+	// MaxResults limits the number of flights returned after sorting (0 = unlimited)
+	MaxResults int
+
+	// MaxResultsPerProvider limits the number of flights of each provider,
+	// keeping its best ones in sort order, before MaxResults applies
+	// (0 = unlimited)
+	MaxResultsPerProvider int
+
+	// PartialResultsOK returns the results of the providers that answered by
+	// the soft timeout instead of waiting for all of them
+	PartialResultsOK bool
+
+	// FanOutStrategy selects how the providers are queried (empty = the
+	// configured strategy)
+	FanOutStrategy domain.FanOutStrategy
+
+	// PreferredDepartureTime is the time of day the passengers prefer to
+	// depart; best value ranking favors flights departing within it (nil = no
+	// preference). Unlike filters.departureTimeRange, it excludes no flights.
+	PreferredDepartureTime *domain.TimeRange
+
+	// SpecialServices are the special assistance services the passengers need.
+	// Flights supporting all of them are listed first, in sort order.
+	SpecialServices []domain.SpecialService
+
+	// SearchID is the ID the search is recorded under, e.g. one announced to
+	// the client before the search ran (empty = a new ID)
+	SearchID string
+
+	// Progress is called as each provider answers the search (nil = no
+	// progress is reported). It is called from the goroutine gathering the
+	// results and must not block. Searches reporting progress do not share
+	// their provider fan-out with concurrent identical searches, and cache
+	// hits report none.
+	Progress func(ProviderProgress)
+}
+
+// ProviderProgress is a provider's answer to a search in progress.
+type ProviderProgress struct {
+	// Provider is the name of the provider that answered
+	Provider string
+
+	// Flights are the provider's flights passing the routing policy and the
+	// search's filters, unranked: the final response ranks all flights together
+	Flights []domain.Flight
+
+	// Err is the provider's error, if it failed
+	Err error
+
+	// Completed is the number of providers that answered so far
+	Completed int
+
+	// Total is the number of providers the search may query; strategies
+	// querying them one at a time may stop before all of them answered
+	Total int
+
+	// Return reports an answer for the return leg of a round trip; each leg
+	// counts its providers separately
+	Return bool
+
+	// DepartureDate is the departure date the answer is for, set for
+	// flexible-date searches; each date counts its providers separately
+	DepartureDate string
+}
+
+// ProviderOptions returns the filtering hints passed to the providers able to
+// filter on their side.
+func (o SearchOptions) ProviderOptions() domain.ProviderSearchOptions {
+	if o.Filters == nil || o.Filters.MaxPrice == nil {
+		return domain.ProviderSearchOptions{}
+	}
+	return domain.ProviderSearchOptions{MaxPrice: *o.Filters.MaxPrice}
 }
 
 // DefaultSearchOptions returns SearchOptions with sensible defaults.
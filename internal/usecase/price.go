@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// PriceForPassengers sets the total price of each flight for the number of
+// passengers searched, providers pricing fares per passenger.
+// Does NOT mutate the original flights slice.
+func PriceForPassengers(flights []domain.Flight, passengers int) []domain.Flight {
+	result := make([]domain.Flight, len(flights))
+	for i, f := range flights {
+		f.Price = f.Price.ForPassengers(passengers)
+		result[i] = f
+	}
+	return result
+}
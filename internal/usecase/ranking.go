@@ -2,8 +2,13 @@
 package usecase
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
 	"math"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
 )
@@ -23,8 +28,76 @@ const (
 	// weightStops is the weight for number of stops in ranking calculation.
 	// Stops has the lowest impact on ranking (20%).
 	weightStops = 0.2
+
+	// weightDeparturePreference is the weight of the departure time-of-day
+	// preference, when one is given. The other weights are scaled down by
+	// (1 - weightDeparturePreference) so scores stay in [0, 1].
+	weightDeparturePreference = 0.2
 )
 
+// RankingWeights are the weights of price, duration and stops in ranking
+// scores. Weights are relative: they are scaled to sum 1, so scores stay in
+// [0, 1].
+type RankingWeights struct {
+	Price    float64
+	Duration float64
+	Stops    float64
+}
+
+// DefaultRankingWeights returns the weights ranking search results.
+func DefaultRankingWeights() RankingWeights {
+	return RankingWeights{Price: weightPrice, Duration: weightDuration, Stops: weightStops}
+}
+
+// Validate checks the weights are finite, non-negative and not all zero.
+func (w RankingWeights) Validate() error {
+	for _, v := range []float64{w.Price, w.Duration, w.Stops} {
+		if !isFinite(v) || v < 0 {
+			return fmt.Errorf("ranking weight %v must be a non-negative number", v)
+		}
+	}
+	if w.Price+w.Duration+w.Stops == 0 {
+		return errors.New("ranking weights must not all be zero")
+	}
+	return nil
+}
+
+// normalized returns the weights scaled to sum 1.
+func (w RankingWeights) normalized() RankingWeights {
+	sum := w.Price + w.Duration + w.Stops
+	return RankingWeights{Price: w.Price / sum, Duration: w.Duration / sum, Stops: w.Stops / sum}
+}
+
+// rankingOptions holds the optional ranking components.
+type rankingOptions struct {
+	preferredDeparture *domain.TimeRange
+	weights            *RankingWeights
+}
+
+// RankingOption configures CalculateRankingScores.
+type RankingOption func(*rankingOptions)
+
+// WithDeparturePreference ranks flights departing within the window higher:
+// flights departing outside it are penalized by how far from it they depart.
+// A nil window adds no preference.
+func WithDeparturePreference(window *domain.TimeRange) RankingOption {
+	return func(o *rankingOptions) {
+		o.preferredDeparture = window
+	}
+}
+
+// WithWeights replaces the weights of price, duration and stops, e.g. to
+// see how results would rank with other weights. Invalid weights (see
+// RankingWeights.Validate) are ignored.
+func WithWeights(w RankingWeights) RankingOption {
+	return func(o *rankingOptions) {
+		if w.Validate() == nil {
+			normalized := w.normalized()
+			o.weights = &normalized
+		}
+	}
+}
+
 // CalculateRankingScores calculates the ranking score for each flight using a weighted formula.
 //
 // The ranking algorithm uses normalization to ensure fair comparison across different value ranges:
@@ -35,7 +108,17 @@ const (
 //   - 0 = best (lowest price, shortest duration, fewest stops)
 //   - 1 = worst (highest price, longest duration, most stops)
 //
-// Lower score = better value flight.
+// Lower score = better value flight. WithWeights replaces the weights.
+//
+// With WithDeparturePreference, a time-of-day component is added:
+//
+//	Score = (0.8 × WeightedScore) + (0.2 × NormalizedMinutesOutsideWindow)
+//
+// where flights departing within the preferred window have 0 minutes outside it.
+//
+// Prices are only compared within a currency: the currency most flights are
+// priced in is the ranking currency, and flights priced in another currency
+// get the worst normalized price rather than being compared by raw amount.
 //
 // Behavior:
 //   - Returns empty slice for empty input
@@ -43,28 +126,49 @@ const (
 //   - All equal values result in equal scores of 0
 //   - Does NOT mutate the original flights slice
 //   - Performance is O(n) where n = number of flights
-func CalculateRankingScores(flights []domain.Flight) []domain.Flight {
+func CalculateRankingScores(flights []domain.Flight, opts ...RankingOption) []domain.Flight {
 	if len(flights) == 0 {
 		return flights
 	}
 
+	var o rankingOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	weights := DefaultRankingWeights()
+	if o.weights != nil {
+		weights = *o.weights
+	}
+
 	// Find min/max for normalization
+	currency := rankingCurrency(flights)
 	minPrice, maxPrice := findPriceRange(flights)
 	minDuration, maxDuration := findDurationRange(flights)
 	minStops, maxStops := findStopsRange(flights)
+	minOutside, maxOutside := findDepartureDistanceRange(flights, o.preferredDeparture)
 
 	// Calculate scores - create a copy to avoid mutating input
 	result := make([]domain.Flight, len(flights))
 	for i, f := range flights {
 		result[i] = f
 
-		normPrice := normalizeValue(f.Price.Amount, minPrice, maxPrice)
+		normPrice := 1.0 // Not comparable with the ranking currency
+		if strings.EqualFold(f.Price.Currency, currency) {
+			normPrice = normalizeValue(f.Price.Amount, minPrice, maxPrice)
+		}
 		normDuration := normalizeValue(float64(f.Duration.TotalMinutes), float64(minDuration), float64(maxDuration))
 		normStops := normalizeValue(float64(f.Stops), float64(minStops), float64(maxStops))
 
-		result[i].RankingScore = (weightPrice * normPrice) +
-			(weightDuration * normDuration) +
-			(weightStops * normStops)
+		result[i].RankingScore = (weights.Price * normPrice) +
+			(weights.Duration * normDuration) +
+			(weights.Stops * normStops)
+
+		if o.preferredDeparture != nil {
+			outside := o.preferredDeparture.MinutesOutside(f.Departure.DateTime)
+			normOutside := normalizeValue(float64(outside), float64(minOutside), float64(maxOutside))
+			result[i].RankingScore = (1-weightDeparturePreference)*result[i].RankingScore +
+				weightDeparturePreference*normOutside
+		}
 	}
 
 	return result
@@ -80,24 +184,64 @@ func normalizeValue(value, min, max float64) float64 {
 	return (value - min) / (max - min)
 }
 
-// findPriceRange finds the minimum and maximum price across all flights.
+// findPriceRange finds the minimum and maximum price across the flights
+// priced in the ranking currency.
 func findPriceRange(flights []domain.Flight) (min, max float64) {
 	if len(flights) == 0 {
 		return 0, 0
 	}
 
-	min = math.MaxFloat64
-	max = 0
-
+	currency := rankingCurrency(flights)
+	var lowest, highest *domain.PriceInfo
 	for _, f := range flights {
-		if f.Price.Amount < min {
-			min = f.Price.Amount
+		if !strings.EqualFold(f.Price.Currency, currency) {
+			continue
+		}
+		if lowest == nil {
+			lowest, highest = &f.Price, &f.Price
+			continue
 		}
-		if f.Price.Amount > max {
-			max = f.Price.Amount
+		if cheaper, _ := f.Price.Less(*lowest); cheaper {
+			lowest = &f.Price
+		}
+		if pricier, _ := highest.Less(f.Price); pricier {
+			highest = &f.Price
 		}
 	}
-	return min, max
+	return lowest.Amount, highest.Amount
+}
+
+// rankingCurrency returns the currency most flights are priced in, the
+// earliest seen on ties, so a few flights priced in another currency cannot
+// skew the price component of the ranking.
+func rankingCurrency(flights []domain.Flight) string {
+	counts := make(map[string]int)
+	var currency string
+	for _, f := range flights {
+		c := strings.ToUpper(f.Price.Currency)
+		counts[c]++
+		if counts[c] > counts[currency] {
+			currency = c
+		}
+	}
+	return currency
+}
+
+// comparePrices orders a and b cheapest first. Prices in different
+// currencies are not compared by amount: the ranking currency comes first,
+// then the other currencies in alphabetical order.
+func comparePrices(a, b domain.PriceInfo, currency string) int {
+	if c, err := a.Compare(b); err == nil {
+		return c
+	}
+	switch {
+	case strings.EqualFold(a.Currency, currency):
+		return -1
+	case strings.EqualFold(b.Currency, currency):
+		return 1
+	default:
+		return strings.Compare(strings.ToUpper(a.Currency), strings.ToUpper(b.Currency))
+	}
 }
 
 // findDurationRange finds the minimum and maximum duration (in minutes) across all flights.
@@ -140,21 +284,52 @@ func findStopsRange(flights []domain.Flight) (min, max int) {
 	return min, max
 }
 
-// SortFlights sorts flights according to the specified sort option.
+// findDepartureDistanceRange finds the minimum and maximum number of minutes
+// flights depart outside the preferred window (0, 0 without a window).
+func findDepartureDistanceRange(flights []domain.Flight, window *domain.TimeRange) (min, max int) {
+	if window == nil || len(flights) == 0 {
+		return 0, 0
+	}
+
+	min = math.MaxInt
+	max = 0
+
+	for _, f := range flights {
+		outside := window.MinutesOutside(f.Departure.DateTime)
+		if outside < min {
+			min = outside
+		}
+		if outside > max {
+			max = outside
+		}
+	}
+	return min, max
+}
+
+// SortFlights sorts flights according to the specified sort option and order.
 // Uses stable sorting to maintain consistent order for equal values.
 //
-// Sort options:
+// Sort options, in ascending order:
 //   - SortByBestValue (default): ascending by RankingScore (lower = better)
-//   - SortByPrice: ascending by Price.Amount (cheapest first)
+//   - SortByPrice: ascending by Price.Amount (cheapest first), flights priced
+//     in the ranking currency first (see CalculateRankingScores)
 //   - SortByDuration: ascending by Duration.TotalMinutes (shortest first)
 //   - SortByDeparture: ascending by Departure.DateTime (earliest first)
+//   - SortByArrival: ascending by Arrival.DateTime (earliest first)
+//
+// SortDescending reverses each of them. Prices in different currencies are
+// never compared by amount: flights priced in the ranking currency come first
+// in both orders. Prices are compared per passenger; as the flights of a
+// search are priced for the same passengers, comparing their totals would
+// give the same order, so the price basis does not change it.
 //
 // Behavior:
 //   - Returns empty slice for empty input
 //   - Single flight returns as-is
 //   - Empty or invalid sortBy defaults to SortByBestValue
+//   - Empty or invalid order defaults to SortAscending
 //   - Does NOT mutate the original flights slice
-func SortFlights(flights []domain.Flight, sortBy domain.SortOption) []domain.Flight {
+func SortFlights(flights []domain.Flight, sortBy domain.SortOption, order domain.SortOrder) []domain.Flight {
 	if len(flights) == 0 {
 		return flights
 	}
@@ -173,25 +348,78 @@ func SortFlights(flights []domain.Flight, sortBy domain.SortOption) []domain.Fli
 		sortBy = domain.SortByBestValue
 	}
 
+	// Descending order flips each comparison
+	dir := 1
+	if order == domain.SortDescending {
+		dir = -1
+	}
+
 	switch sortBy {
 	case domain.SortByBestValue:
 		// Lower score = better value
-		sort.SliceStable(result, func(i, j int) bool {
-			return result[i].RankingScore < result[j].RankingScore
+		slices.SortStableFunc(result, func(a, b domain.Flight) int {
+			return dir * cmp.Compare(a.RankingScore, b.RankingScore)
 		})
 	case domain.SortByPrice:
-		sort.SliceStable(result, func(i, j int) bool {
-			return result[i].Price.Amount < result[j].Price.Amount
+		currency := rankingCurrency(result)
+		slices.SortStableFunc(result, func(a, b domain.Flight) int {
+			if !a.Price.SameCurrency(b.Price) {
+				// Currencies stay grouped in both orders
+				return comparePrices(a.Price, b.Price, currency)
+			}
+			return dir * comparePrices(a.Price, b.Price, currency)
 		})
 	case domain.SortByDuration:
-		sort.SliceStable(result, func(i, j int) bool {
-			return result[i].Duration.TotalMinutes < result[j].Duration.TotalMinutes
+		slices.SortStableFunc(result, func(a, b domain.Flight) int {
+			return dir * cmp.Compare(a.Duration.TotalMinutes, b.Duration.TotalMinutes)
 		})
 	case domain.SortByDeparture:
-		sort.SliceStable(result, func(i, j int) bool {
-			return result[i].Departure.DateTime.Before(result[j].Departure.DateTime)
+		slices.SortStableFunc(result, func(a, b domain.Flight) int {
+			return dir * a.Departure.DateTime.Compare(b.Departure.DateTime)
+		})
+	case domain.SortByArrival:
+		slices.SortStableFunc(result, func(a, b domain.Flight) int {
+			return dir * a.Arrival.DateTime.Compare(b.Arrival.DateTime)
 		})
 	}
 
 	return result
 }
+
+// PrioritizeServices moves the flights supporting all the special assistance
+// services ahead of the others, keeping the sort order within both groups, so
+// accessible options are not buried. Returns flights as-is if no services are
+// requested; otherwise does NOT mutate the original flights slice.
+func PrioritizeServices(flights []domain.Flight, services []domain.SpecialService) []domain.Flight {
+	if len(services) == 0 {
+		return flights
+	}
+
+	result := make([]domain.Flight, len(flights))
+	copy(result, flights)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Accessibility.SupportsAll(services) && !result[j].Accessibility.SupportsAll(services)
+	})
+	return result
+}
+
+// LimitPerProvider keeps at most limit flights of each provider, the first in
+// sort order, so a provider returning many flights cannot crowd the others
+// out of a truncated result. Returns flights as-is if limit is not positive;
+// otherwise does NOT mutate the original flights slice.
+func LimitPerProvider(flights []domain.Flight, limit int) []domain.Flight {
+	if limit <= 0 {
+		return flights
+	}
+
+	kept := make(map[string]int)
+	result := make([]domain.Flight, 0, len(flights))
+	for _, f := range flights {
+		if kept[f.Provider] == limit {
+			continue
+		}
+		kept[f.Provider]++
+		result = append(result, f)
+	}
+	return result
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// Ranking invariants, reported by RankingViolation.Invariant.
+const (
+	// RankingFinite requires scores, and the factors they are calculated
+	// from, to be numbers: not NaN nor infinite
+	RankingFinite = "finite"
+
+	// RankingRange requires scores to be within [0, 1]
+	RankingRange = "range"
+
+	// RankingMonotonic requires a flight worse in one ranking factor, all the
+	// others being equal, not to score better
+	RankingMonotonic = "monotonic"
+)
+
+// Ranking factors, reported by RankingViolation.Factor for monotonicity violations.
+const (
+	RankingFactorPrice               = "price"
+	RankingFactorDuration            = "duration"
+	RankingFactorStops               = "stops"
+	RankingFactorDeparturePreference = "departure_preference"
+)
+
+// RankingViolation is a ranking score that broke a ranking invariant and was
+// clamped, usually because a provider sent pathological values.
+type RankingViolation struct {
+	// FlightID and Provider identify the flight
+	FlightID string
+	Provider string
+
+	// Invariant is the broken invariant (RankingFinite, RankingRange or RankingMonotonic)
+	Invariant string
+
+	// Factor is the ranking factor of a monotonicity violation (empty otherwise)
+	Factor string
+
+	// Score is the score as calculated, Clamped the score it was replaced with
+	Score   float64
+	Clamped float64
+}
+
+// EnforceRankingInvariants checks the ranking scores calculated by
+// CalculateRankingScores, with the same options, against the ranking
+// invariants and clamps the offending scores:
+//   - NaN and infinite scores, and the scores of flights with a NaN or
+//     infinite price, become 1, the worst score
+//   - scores outside [0, 1] are clamped to the range
+//   - a flight worse than another in one factor (price in the same currency,
+//     duration, stops, or minutes outside the preferred departure window) and
+//     equal in the others is raised to the other flight's score
+//
+// Returns the flights with clamped scores and the violations found, in order.
+// Does NOT mutate the original flights slice.
+func EnforceRankingInvariants(flights []domain.Flight, opts ...RankingOption) ([]domain.Flight, []RankingViolation) {
+	var o rankingOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	result := make([]domain.Flight, len(flights))
+	copy(result, flights)
+
+	var violations []RankingViolation
+	clamp := func(i int, invariant, factor string, score float64) {
+		violations = append(violations, RankingViolation{
+			FlightID:  result[i].ID,
+			Provider:  result[i].Provider,
+			Invariant: invariant,
+			Factor:    factor,
+			Score:     result[i].RankingScore,
+			Clamped:   score,
+		})
+		result[i].RankingScore = score
+	}
+
+	factors := rankingFactorValues(result, o.preferredDeparture)
+	for i, f := range result {
+		switch {
+		case !isFinite(f.RankingScore) || slices.ContainsFunc(factors[i], isNotFinite):
+			clamp(i, RankingFinite, "", 1)
+		case f.RankingScore < 0:
+			clamp(i, RankingRange, "", 0)
+		case f.RankingScore > 1:
+			clamp(i, RankingRange, "", 1)
+		}
+	}
+
+	for factor, name := range rankingFactorNames {
+		for _, group := range groupByOtherFactors(result, factors, factor) {
+			enforceMonotonic(result, group, factors, factor, func(i int, floor float64) {
+				clamp(i, RankingMonotonic, name, floor)
+			})
+		}
+	}
+
+	return result, violations
+}
+
+// rankingFactorNames names the ranking factors in the order of rankingFactorValues.
+var rankingFactorNames = [...]string{
+	RankingFactorPrice,
+	RankingFactorDuration,
+	RankingFactorStops,
+	RankingFactorDeparturePreference,
+}
+
+// rankingFactorValues returns the value of each ranking factor of each flight,
+// in the order of rankingFactorNames; lower is better. The departure
+// preference is 0 for every flight without a preferred window.
+func rankingFactorValues(flights []domain.Flight, window *domain.TimeRange) [][]float64 {
+	values := make([][]float64, len(flights))
+	for i, f := range flights {
+		values[i] = []float64{
+			f.Price.Amount,
+			float64(f.Duration.TotalMinutes),
+			float64(f.Stops),
+			float64(window.MinutesOutside(f.Departure.DateTime)),
+		}
+	}
+	return values
+}
+
+// isFinite reports whether v is neither NaN nor infinite.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// isNotFinite reports whether v is NaN or infinite.
+func isNotFinite(v float64) bool {
+	return !isFinite(v)
+}
+
+// groupByOtherFactors groups the indexes of the flights sharing the values of
+// every ranking factor but factor, and their price currency. Flights with a
+// non-finite factor value are left out: their order is meaningless.
+func groupByOtherFactors(flights []domain.Flight, factors [][]float64, factor int) [][]int {
+	type groupKey struct {
+		currency string
+		others   [len(rankingFactorNames) - 1]float64
+	}
+
+	index := make(map[groupKey]int)
+	var groups [][]int
+	for i, f := range flights {
+		if slices.ContainsFunc(factors[i], isNotFinite) {
+			continue
+		}
+		key := groupKey{currency: strings.ToUpper(f.Price.Currency)}
+		n := 0
+		for j, v := range factors[i] {
+			if j != factor {
+				key.others[n] = v
+				n++
+			}
+		}
+		g, ok := index[key]
+		if !ok {
+			g = len(groups)
+			index[key] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// enforceMonotonic calls raise for each flight of group scoring better than a
+// flight of the group with a lower value of factor, with the score it must be
+// raised to. raise must update the flight's score.
+func enforceMonotonic(flights []domain.Flight, group []int, factors [][]float64, factor int, raise func(i int, floor float64)) {
+	slices.SortStableFunc(group, func(a, b int) int {
+		return cmp.Compare(factors[a][factor], factors[b][factor])
+	})
+
+	// floor is the highest score of the flights with a lower factor value
+	floor := math.Inf(-1)
+	for start := 0; start < len(group); {
+		end := start
+		for end < len(group) && factors[group[end]][factor] == factors[group[start]][factor] {
+			end++
+		}
+
+		highest := floor
+		for _, i := range group[start:end] {
+			if flights[i].RankingScore < floor {
+				raise(i, floor)
+			}
+			highest = max(highest, flights[i].RankingScore)
+		}
+		floor = highest
+		start = end
+	}
+}
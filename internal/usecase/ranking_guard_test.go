@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+func TestEnforceRankingInvariants_ClampsScores(t *testing.T) {
+	scored := func(id string, score float64) domain.Flight {
+		f := createRankingTestFlight(id, 1000000, 120, 0, 8)
+		f.RankingScore = score
+		return f
+	}
+	flights := []domain.Flight{
+		scored("nan", math.NaN()),
+		scored("inf", math.Inf(-1)),
+		scored("negative", -0.25),
+		scored("above", 1.5),
+		scored("ok", 1),
+	}
+
+	result, violations := EnforceRankingInvariants(flights)
+
+	require.Len(t, result, 5)
+	assert.Equal(t, []float64{1, 1, 0, 1, 1}, []float64{
+		result[0].RankingScore, result[1].RankingScore, result[2].RankingScore, result[3].RankingScore, result[4].RankingScore,
+	})
+	assert.True(t, math.IsNaN(flights[0].RankingScore), "input is not mutated")
+
+	type found struct{ id, invariant string }
+	var got []found
+	for _, v := range violations {
+		got = append(got, found{v.FlightID, v.Invariant})
+	}
+	assert.Equal(t, []found{
+		{"nan", RankingFinite},
+		{"inf", RankingFinite},
+		{"negative", RankingRange},
+		{"above", RankingRange},
+	}, got, "identical flights may score differently")
+}
+
+func TestEnforceRankingInvariants_Monotonic(t *testing.T) {
+	cheap := createRankingTestFlight("cheap", 500000, 120, 0, 8)
+	cheap.RankingScore = 0.6
+	pricey := createRankingTestFlight("pricey", 900000, 120, 0, 8)
+	pricey.RankingScore = 0.4
+	usd := createRankingTestFlight("usd", 40, 120, 0, 8)
+	usd.Price.Currency = "USD"
+	usd.RankingScore = 0.1
+	slow := createRankingTestFlight("slow", 900000, 300, 0, 8)
+	slow.RankingScore = 0.5
+
+	result, violations := EnforceRankingInvariants([]domain.Flight{cheap, pricey, usd, slow})
+
+	assert.Equal(t, []float64{0.6, 0.6, 0.1, 0.6}, []float64{
+		result[0].RankingScore, result[1].RankingScore, result[2].RankingScore, result[3].RankingScore,
+	})
+	assert.Equal(t, []RankingViolation{{
+		FlightID:  "pricey",
+		Provider:  "test",
+		Invariant: RankingMonotonic,
+		Factor:    RankingFactorPrice,
+		Score:     0.4,
+		Clamped:   0.6,
+	}, {
+		FlightID:  "slow",
+		Provider:  "test",
+		Invariant: RankingMonotonic,
+		Factor:    RankingFactorDuration,
+		Score:     0.5,
+		Clamped:   0.6,
+	}}, violations, "prices in another currency are not compared; slow is checked against the clamped pricey score")
+}
+
+func TestEnforceRankingInvariants_DeparturePreference(t *testing.T) {
+	window := &domain.TimeRange{
+		Start: time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC),
+		End:   time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+	inWindow := createRankingTestFlight("in", 500000, 120, 0, 9)
+	inWindow.RankingScore = 0.3
+	late := createRankingTestFlight("late", 500000, 120, 0, 20)
+	late.RankingScore = 0.1
+
+	_, violations := EnforceRankingInvariants([]domain.Flight{inWindow, late})
+	assert.Empty(t, violations, "departure times are not ranked without a preference")
+
+	_, violations = EnforceRankingInvariants([]domain.Flight{inWindow, late}, WithDeparturePreference(window))
+	require.Len(t, violations, 1)
+	assert.Equal(t, RankingFactorDeparturePreference, violations[0].Factor)
+}
+
+// TestCalculateRankingScores_Invariants checks that the ranking scores of
+// random flights never need clamping.
+func TestCalculateRankingScores_Invariants(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	window := &domain.TimeRange{
+		Start: time.Date(0, 1, 1, 6, 0, 0, 0, time.UTC),
+		End:   time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	for run := 0; run < 200; run++ {
+		flights := make([]domain.Flight, 1+rng.Intn(30))
+		for i := range flights {
+			// Few distinct values, so many flights differ in a single factor
+			flights[i] = createRankingTestFlight(intToStr(i), float64(1+rng.Intn(4))*250000, 60*(1+rng.Intn(3)), rng.Intn(3), rng.Intn(20))
+			if rng.Intn(10) == 0 {
+				flights[i].Price.Currency = "USD"
+			}
+		}
+
+		var opts []RankingOption
+		if run%2 == 1 {
+			opts = append(opts, WithDeparturePreference(window))
+		}
+		ranked := CalculateRankingScores(flights, opts...)
+		_, violations := EnforceRankingInvariants(ranked, opts...)
+		require.Empty(t, violations, "run %d", run)
+	}
+}
+
+func TestSearch_ClampsPathologicalScores(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	broken := createTestFlight("broken", "test", math.NaN(), 120, 0)
+	provider := setupMockProvider(ctrl, "test", []domain.Flight{
+		createTestFlight("1", "test", 1000000, 120, 0),
+		broken,
+	}, nil)
+
+	var violations []RankingViolation
+	uc := NewFlightSearchUseCase([]domain.FlightProvider{provider}, &Config{
+		RankingObserver: func(v RankingViolation) { violations = append(violations, v) },
+	})
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+
+	require.NoError(t, err)
+	require.Len(t, response.Flights, 2)
+	assert.Equal(t, "1", response.Flights[0].ID)
+	assert.Equal(t, 1.0, response.Flights[1].RankingScore)
+	require.NotEmpty(t, violations)
+	assert.Equal(t, "broken", violations[0].FlightID)
+	assert.Equal(t, RankingFinite, violations[0].Invariant)
+}
@@ -275,6 +275,88 @@ func TestCalculateRankingScores_ZeroValues(t *testing.T) {
 	assert.False(t, math.IsNaN(result[1].RankingScore))
 }
 
+func TestCalculateRankingScores_DeparturePreference(t *testing.T) {
+	// Same price, duration and stops: only the departure time differs
+	flights := []domain.Flight{
+		createRankingTestFlight("early", 1000000, 120, 0, 5),
+		createRankingTestFlight("morning", 1000000, 120, 0, 9),
+		createRankingTestFlight("evening", 1000000, 120, 0, 19),
+	}
+	window := &domain.TimeRange{
+		Start: time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC),
+		End:   time.Date(0, 1, 1, 11, 0, 0, 0, time.UTC),
+	}
+
+	result := CalculateRankingScores(flights, WithDeparturePreference(window))
+
+	require.Len(t, result, 3)
+	assert.Equal(t, 0.0, result[1].RankingScore, "flight within the window")
+	assert.InDelta(t, 0.2*3.0/8.0, result[0].RankingScore, 0.0001, "3 hours before the window")
+	assert.InDelta(t, 0.2, result[2].RankingScore, 0.0001, "8 hours after the window")
+
+	t.Run("preference weighs less than price", func(t *testing.T) {
+		flights := []domain.Flight{
+			createRankingTestFlight("cheap", 500000, 120, 0, 19),
+			createRankingTestFlight("pricey", 1500000, 120, 0, 9),
+		}
+		result := CalculateRankingScores(flights, WithDeparturePreference(window))
+		assert.Less(t, result[0].RankingScore, result[1].RankingScore)
+	})
+
+	t.Run("nil window", func(t *testing.T) {
+		result := CalculateRankingScores(flights, WithDeparturePreference(nil))
+		for _, f := range result {
+			assert.Equal(t, 0.0, f.RankingScore)
+		}
+	})
+}
+
+func TestCalculateRankingScores_MixedCurrencies(t *testing.T) {
+	usd := createRankingTestFlight("usd", 60, 120, 0, 8)
+	usd.Price.Currency = "USD"
+	flights := []domain.Flight{
+		createRankingTestFlight("cheap", 500000, 120, 0, 8),
+		createRankingTestFlight("pricey", 1500000, 120, 0, 8),
+		usd,
+	}
+
+	result := CalculateRankingScores(flights)
+
+	require.Len(t, result, 3)
+	assert.Equal(t, 0.0, result[0].RankingScore)
+	assert.InDelta(t, 0.5, result[1].RankingScore, 0.0001)
+	assert.InDelta(t, 0.5, result[2].RankingScore, 0.0001, "a fare in another currency is not cheapest by amount")
+
+	min, max := findPriceRange(flights)
+	assert.Equal(t, 500000.0, min)
+	assert.Equal(t, 1500000.0, max)
+}
+
+func TestCalculateRankingScores_WithWeights(t *testing.T) {
+	flights := []domain.Flight{
+		createRankingTestFlight("cheap", 500000, 240, 1, 8),
+		createRankingTestFlight("fast", 1500000, 90, 0, 8),
+	}
+
+	result := CalculateRankingScores(flights)
+	assert.InDelta(t, 0.5, result[0].RankingScore, 0.0001)
+	assert.InDelta(t, 0.5, result[1].RankingScore, 0.0001)
+
+	// Weights are relative: 1, 3 and 0 weigh 25%, 75% and 0%
+	result = CalculateRankingScores(flights, WithWeights(RankingWeights{Price: 1, Duration: 3}))
+	assert.InDelta(t, 0.75, result[0].RankingScore, 0.0001)
+	assert.InDelta(t, 0.25, result[1].RankingScore, 0.0001)
+
+	t.Run("invalid weights are ignored", func(t *testing.T) {
+		for _, w := range []RankingWeights{{}, {Price: -1, Duration: 2}, {Price: math.NaN()}} {
+			assert.Error(t, w.Validate())
+			result := CalculateRankingScores(flights, WithWeights(w))
+			assert.InDelta(t, 0.5, result[0].RankingScore, 0.0001)
+		}
+		assert.NoError(t, DefaultRankingWeights().Validate())
+	})
+}
+
 // =====================================================
 // Normalization Tests
 // =====================================================
@@ -449,7 +531,7 @@ func TestFindStopsRange(t *testing.T) {
 // =====================================================
 
 func TestSortFlights_Empty(t *testing.T) {
-	result := SortFlights([]domain.Flight{}, domain.SortByPrice)
+	result := SortFlights([]domain.Flight{}, domain.SortByPrice, domain.SortAscending)
 	assert.Empty(t, result)
 }
 
@@ -458,7 +540,7 @@ func TestSortFlights_SingleFlight(t *testing.T) {
 		createRankingTestFlight("1", 1000000, 120, 0, 8),
 	}
 
-	result := SortFlights(flights, domain.SortByPrice)
+	result := SortFlights(flights, domain.SortByPrice, domain.SortAscending)
 
 	require.Len(t, result, 1)
 	assert.Equal(t, "1", result[0].ID)
@@ -471,7 +553,7 @@ func TestSortFlights_ByPrice(t *testing.T) {
 		createRankingTestFlight("medium", 1000000, 120, 0, 8),
 	}
 
-	result := SortFlights(flights, domain.SortByPrice)
+	result := SortFlights(flights, domain.SortByPrice, domain.SortAscending)
 
 	require.Len(t, result, 3)
 	assert.Equal(t, "cheap", result[0].ID)
@@ -479,6 +561,56 @@ func TestSortFlights_ByPrice(t *testing.T) {
 	assert.Equal(t, "expensive", result[2].ID)
 }
 
+func TestSortFlights_Descending(t *testing.T) {
+	flights := []domain.Flight{
+		createRankingTestFlight("1", 800000, 90, 0, 6),
+		createRankingTestFlight("2", 1500000, 180, 0, 14),
+		createRankingTestFlight("3", 500000, 120, 0, 10),
+		createRankingTestFlight("4", 500000, 150, 0, 8),
+	}
+
+	ids := func(flights []domain.Flight) []string {
+		var ids []string
+		for _, f := range flights {
+			ids = append(ids, f.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"2", "1", "3", "4"}, ids(SortFlights(flights, domain.SortByPrice, domain.SortDescending)),
+		"equal prices keep their order")
+	assert.Equal(t, []string{"2", "4", "3", "1"}, ids(SortFlights(flights, domain.SortByDuration, domain.SortDescending)))
+	assert.Equal(t, []string{"2", "3", "4", "1"}, ids(SortFlights(flights, domain.SortByDeparture, domain.SortDescending)))
+	assert.Equal(t, []string{"3", "4", "1", "2"}, ids(SortFlights(flights, domain.SortByPrice, "")),
+		"empty order is ascending")
+
+	usd := createRankingTestFlight("usd", 60, 120, 0, 8)
+	usd.Price.Currency = "USD"
+	assert.Equal(t, []string{"2", "1", "3", "4", "usd"}, ids(SortFlights(append(flights, usd), domain.SortByPrice, domain.SortDescending)),
+		"flights priced in the ranking currency stay first")
+}
+
+func TestSortFlights_ByPriceMixedCurrencies(t *testing.T) {
+	usd := createRankingTestFlight("usd", 60, 120, 0, 8)
+	usd.Price.Currency = "USD"
+	eur := createRankingTestFlight("eur", 50, 120, 0, 8)
+	eur.Price.Currency = "EUR"
+	flights := []domain.Flight{
+		usd,
+		createRankingTestFlight("pricey", 1500000, 120, 0, 8),
+		eur,
+		createRankingTestFlight("cheap", 500000, 120, 0, 8),
+	}
+
+	result := SortFlights(flights, domain.SortByPrice, domain.SortAscending)
+
+	var ids []string
+	for _, f := range result {
+		ids = append(ids, f.ID)
+	}
+	assert.Equal(t, []string{"cheap", "pricey", "eur", "usd"}, ids)
+}
+
 func TestSortFlights_ByDuration(t *testing.T) {
 	flights := []domain.Flight{
 		createRankingTestFlight("long", 1000000, 180, 0, 8),
@@ -486,7 +618,7 @@ func TestSortFlights_ByDuration(t *testing.T) {
 		createRankingTestFlight("medium", 1000000, 120, 0, 8),
 	}
 
-	result := SortFlights(flights, domain.SortByDuration)
+	result := SortFlights(flights, domain.SortByDuration, domain.SortAscending)
 
 	require.Len(t, result, 3)
 	assert.Equal(t, "short", result[0].ID)
@@ -501,7 +633,7 @@ func TestSortFlights_ByDeparture(t *testing.T) {
 		createRankingTestFlight("noon", 1000000, 120, 0, 12),
 	}
 
-	result := SortFlights(flights, domain.SortByDeparture)
+	result := SortFlights(flights, domain.SortByDeparture, domain.SortAscending)
 
 	require.Len(t, result, 3)
 	assert.Equal(t, "morning", result[0].ID)
@@ -509,6 +641,24 @@ func TestSortFlights_ByDeparture(t *testing.T) {
 	assert.Equal(t, "evening", result[2].ID)
 }
 
+func TestSortFlights_ByArrival(t *testing.T) {
+	flights := []domain.Flight{
+		createRankingTestFlight("late", 1000000, 60, 0, 12),   // Arrives 13:00
+		createRankingTestFlight("early", 1000000, 300, 1, 6),  // Arrives 11:00
+		createRankingTestFlight("middle", 1000000, 60, 0, 11), // Arrives 12:00
+	}
+
+	result := SortFlights(flights, domain.SortByArrival, domain.SortAscending)
+
+	require.Len(t, result, 3)
+	assert.Equal(t, "early", result[0].ID, "the earliest arrival comes first, not the earliest departure")
+	assert.Equal(t, "middle", result[1].ID)
+	assert.Equal(t, "late", result[2].ID)
+
+	result = SortFlights(flights, domain.SortByArrival, domain.SortDescending)
+	assert.Equal(t, "late", result[0].ID)
+}
+
 func TestSortFlights_ByBestValue(t *testing.T) {
 	// Pre-calculate ranking scores
 	flights := []domain.Flight{
@@ -521,7 +671,7 @@ func TestSortFlights_ByBestValue(t *testing.T) {
 	scored := CalculateRankingScores(flights)
 
 	// Sort by best value
-	result := SortFlights(scored, domain.SortByBestValue)
+	result := SortFlights(scored, domain.SortByBestValue, domain.SortAscending)
 
 	require.Len(t, result, 3)
 	// Best (lowest score) should be first
@@ -541,7 +691,7 @@ func TestSortFlights_DefaultSort(t *testing.T) {
 	flights[1].RankingScore = 0.2
 
 	// Empty string should default to SortByBestValue
-	result := SortFlights(flights, "")
+	result := SortFlights(flights, "", domain.SortAscending)
 
 	require.Len(t, result, 2)
 	// Lower score should be first
@@ -559,7 +709,7 @@ func TestSortFlights_InvalidSortOption(t *testing.T) {
 	flights[1].RankingScore = 0.2
 
 	// Invalid option should default to SortByBestValue
-	result := SortFlights(flights, domain.SortOption("invalid"))
+	result := SortFlights(flights, domain.SortOption("invalid"), domain.SortAscending)
 
 	require.Len(t, result, 2)
 	assert.Equal(t, "low-score", result[0].ID)
@@ -574,8 +724,8 @@ func TestSortFlights_StableSort(t *testing.T) {
 	}
 
 	// Sort multiple times - order should be preserved for equal values
-	result1 := SortFlights(flights, domain.SortByPrice)
-	result2 := SortFlights(flights, domain.SortByPrice)
+	result1 := SortFlights(flights, domain.SortByPrice, domain.SortAscending)
+	result2 := SortFlights(flights, domain.SortByPrice, domain.SortAscending)
 
 	assert.Equal(t, result1[0].ID, result2[0].ID)
 	assert.Equal(t, result1[1].ID, result2[1].ID)
@@ -595,7 +745,7 @@ func TestSortFlights_DoesNotMutateOriginal(t *testing.T) {
 
 	originalFirstID := flights[0].ID
 
-	result := SortFlights(flights, domain.SortByPrice)
+	result := SortFlights(flights, domain.SortByPrice, domain.SortAscending)
 
 	// Original slice should not be modified
 	assert.Equal(t, originalFirstID, flights[0].ID)
@@ -644,7 +794,7 @@ func TestSortFlights_AllSortOptions(t *testing.T) {
 			// Calculate scores for best value sorting
 			scored := CalculateRankingScores(flights)
 
-			result := SortFlights(scored, tt.sortBy)
+			result := SortFlights(scored, tt.sortBy, domain.SortAscending)
 
 			require.Len(t, result, 5)
 			assert.Equal(t, tt.expectedFirst, result[0].ID)
@@ -652,10 +802,56 @@ func TestSortFlights_AllSortOptions(t *testing.T) {
 	}
 }
 
+func TestLimitPerProvider(t *testing.T) {
+	flights := []domain.Flight{
+		{ID: "1", Provider: "garuda"},
+		{ID: "2", Provider: "lion_air"},
+		{ID: "3", Provider: "garuda"},
+		{ID: "4", Provider: "garuda"},
+		{ID: "5", Provider: "lion_air"},
+	}
+	ids := func(flights []domain.Flight) []string {
+		var ids []string
+		for _, f := range flights {
+			ids = append(ids, f.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, ids(LimitPerProvider(flights, 0)))
+	assert.Equal(t, []string{"1", "2"}, ids(LimitPerProvider(flights, 1)))
+	assert.Equal(t, []string{"1", "2", "3", "5"}, ids(LimitPerProvider(flights, 2)))
+	assert.Len(t, flights, 5, "input not mutated")
+}
+
 // =====================================================
 // Integration Test: Calculate + Sort
 // =====================================================
 
+func TestPrioritizeServices(t *testing.T) {
+	wheelchair := &domain.AccessibilityInfo{Services: []domain.SpecialService{domain.ServiceWheelchair}}
+	full := &domain.AccessibilityInfo{Services: []domain.SpecialService{domain.ServiceWheelchair, domain.ServiceWheelchairOnboard}}
+
+	flights := []domain.Flight{
+		{ID: "1"},
+		{ID: "2", Accessibility: wheelchair},
+		{ID: "3", Accessibility: full},
+		{ID: "4", Accessibility: wheelchair},
+	}
+	ids := func(flights []domain.Flight) []string {
+		var ids []string
+		for _, f := range flights {
+			ids = append(ids, f.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"1", "2", "3", "4"}, ids(PrioritizeServices(flights, nil)))
+	assert.Equal(t, []string{"2", "3", "4", "1"}, ids(PrioritizeServices(flights, []domain.SpecialService{domain.ServiceWheelchair})))
+	assert.Equal(t, []string{"3", "1", "2", "4"}, ids(PrioritizeServices(flights, []domain.SpecialService{domain.ServiceWheelchair, domain.ServiceWheelchairOnboard})))
+	assert.Equal(t, "1", flights[0].ID, "input not mutated")
+}
+
 func TestCalculateAndSort_Integration(t *testing.T) {
 	flights := []domain.Flight{
 		createRankingTestFlight("A", 800000, 105, 0, 8),
@@ -667,7 +863,7 @@ func TestCalculateAndSort_Integration(t *testing.T) {
 	scored := CalculateRankingScores(flights)
 
 	// Sort by best value
-	sorted := SortFlights(scored, domain.SortByBestValue)
+	sorted := SortFlights(scored, domain.SortByBestValue, domain.SortAscending)
 
 	require.Len(t, sorted, 3)
 
@@ -721,7 +917,7 @@ func TestSortFlights_Performance(t *testing.T) {
 	}
 
 	start := time.Now()
-	result := SortFlights(flights, domain.SortByBestValue)
+	result := SortFlights(flights, domain.SortByBestValue, domain.SortAscending)
 	elapsed := time.Since(start)
 
 	assert.Len(t, result, n)
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+// recordingUseCase decorates a FlightSearchUseCase, storing every successful
+// search so it can be inspected or replayed later.
+type recordingUseCase struct {
+	next  FlightSearchUseCase
+	store domain.SearchRecordStore
+	clock timeutil.Clock
+}
+
+// NewRecordingUseCase wraps next so successful searches are saved to store.
+// The stored record's ID is returned to clients in the response metadata.
+// Failing to save a record never fails the search; the search ID is then omitted.
+func NewRecordingUseCase(next FlightSearchUseCase, store domain.SearchRecordStore, clock timeutil.Clock) FlightSearchUseCase {
+	if clock == nil {
+		clock = timeutil.NewRealClock()
+	}
+	return &recordingUseCase{
+		next:  next,
+		store: store,
+		clock: clock,
+	}
+}
+
+// Search executes the search and records the result with the caller.
+func (uc *recordingUseCase) Search(ctx context.Context, criteria domain.SearchCriteria, opts SearchOptions) (*domain.SearchResponse, error) {
+	result, err := uc.next.Search(ctx, criteria, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	record := domain.SearchRecord{
+		ID:                     opts.SearchID,
+		Client:                 rbac.Anonymous,
+		Criteria:               criteria,
+		Filters:                opts.Filters,
+		SortBy:                 opts.SortBy,
+		SortOrder:              opts.SortOrder,
+		MaxResults:             opts.MaxResults,
+		MaxResultsPerProvider:  opts.MaxResultsPerProvider,
+		PreferredDepartureTime: opts.PreferredDepartureTime,
+		CreatedAt:              uc.clock.Now(),
+	}
+	if record.ID == "" {
+		record.ID = uuid.NewString()
+	}
+	if principal, ok := rbac.FromContext(ctx); ok {
+		record.Client = principal.Name
+	}
+	record.Response = *result
+	record.Response.Metadata.SearchID = record.ID
+
+	// Detach from request cancellation: the search already succeeded
+	if err := uc.store.Save(context.WithoutCancel(ctx), record); err != nil {
+		return result, nil
+	}
+
+	result.Metadata.SearchID = record.ID
+	return result, nil
+}
+
+// Ensure recordingUseCase implements FlightSearchUseCase at compile time.
+var _ FlightSearchUseCase = (*recordingUseCase)(nil)
@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// ReplayUseCase re-executes stored searches for debugging.
+type ReplayUseCase interface {
+	// Replay re-runs the stored search with its original criteria, filters and
+	// sort option, and compares the new result with the stored one.
+	// Returns domain.ErrSearchNotFound if the search is unknown.
+	Replay(ctx context.Context, searchID string, opts ReplayOptions) (*ReplayResult, error)
+}
+
+// ReplayOptions contains optional parameters for a replay.
+type ReplayOptions struct {
+	// Debug additionally queries each provider directly and reports what it
+	// returned and which flights the original filters removed
+	Debug bool
+}
+
+// ReplayResult contains a stored search next to its re-execution.
+type ReplayResult struct {
+	// Record is the stored search
+	Record *domain.SearchRecord
+
+	// Replayed is the result of re-executing the search
+	Replayed *domain.SearchResponse
+
+	// Diff compares the stored flights with the replayed flights
+	Diff SearchDiff
+
+	// Providers contains per-provider details (only in debug mode)
+	Providers []ProviderDebug
+}
+
+// SearchDiff describes how two search results differ.
+// Flights are matched by provider and flight ID.
+type SearchDiff struct {
+	// Added contains flights only present in the replayed result
+	Added []domain.Flight
+
+	// Removed contains flights only present in the stored result
+	Removed []domain.Flight
+
+	// Changed contains flights present in both results with differing details
+	Changed []FlightChange
+
+	// Unchanged is the number of flights identical in both results
+	Unchanged int
+}
+
+// FlightChange describes a flight whose details changed between two results.
+type FlightChange struct {
+	// Flight is the replayed flight
+	Flight domain.Flight
+
+	// Fields maps changed field names (e.g., "price") to their values
+	Fields map[string]FieldChange
+}
+
+// FieldChange holds the stored and replayed value of a changed field.
+type FieldChange struct {
+	Original string
+	Replayed string
+}
+
+// ProviderDebug reports what a single provider returned during a debug replay.
+type ProviderDebug struct {
+	// Provider is the provider name
+	Provider string
+
+	// FlightsReturned is the number of flights returned before filtering
+	FlightsReturned int
+
+	// FilteredOut contains the IDs of returned flights removed by the search filters
+	FilteredOut []string
+
+	// Duration is how long the provider query took
+	Duration time.Duration
+
+	// Error is set if the provider query failed
+	Error error
+}
+
+// replayUseCase implements ReplayUseCase.
+type replayUseCase struct {
+	search           FlightSearchUseCase
+	store            domain.SearchRecordStore
+	providers        []domain.FlightProvider
+	providerTimeout  time.Duration
+	providerTimeouts map[string]time.Duration
+}
+
+// NewReplayUseCase creates a ReplayUseCase.
+// search should not record searches itself, so replays do not pollute the store.
+// providers are queried directly in debug mode, using the configured provider timeouts.
+func NewReplayUseCase(search FlightSearchUseCase, store domain.SearchRecordStore, providers []domain.FlightProvider, config *Config) ReplayUseCase {
+	cfg := DefaultConfig()
+	if config != nil {
+		if config.ProviderTimeout > 0 {
+			cfg.ProviderTimeout = config.ProviderTimeout
+		}
+		cfg.ProviderTimeouts = config.ProviderTimeouts
+	}
+
+	return &replayUseCase{
+		search:           search,
+		store:            store,
+		providers:        providers,
+		providerTimeout:  cfg.ProviderTimeout,
+		providerTimeouts: cfg.ProviderTimeouts,
+	}
+}
+
+// Replay implements ReplayUseCase.Replay.
+func (uc *replayUseCase) Replay(ctx context.Context, searchID string, opts ReplayOptions) (*ReplayResult, error) {
+	record, err := uc.store.Get(ctx, searchID)
+	if err != nil {
+		return nil, err
+	}
+
+	replayed, err := uc.search.Search(ctx, record.Criteria, SearchOptions{
+		Filters:                record.Filters,
+		SortBy:                 record.SortBy,
+		SortOrder:              record.SortOrder,
+		MaxResults:             record.MaxResults,
+		MaxResultsPerProvider:  record.MaxResultsPerProvider,
+		PreferredDepartureTime: record.PreferredDepartureTime,
+	})
+	if err != nil && !(opts.Debug && errors.Is(err, domain.ErrAllProvidersFailed)) {
+		return nil, err
+	}
+	if replayed == nil {
+		// All providers failed; in debug mode the per-provider errors explain why
+		empty := domain.NewSearchResponse(&record.Criteria, nil, domain.SearchMetadata{
+			ProvidersQueried: len(uc.providers),
+			ProvidersFailed:  len(uc.providers),
+		})
+		replayed = &empty
+	}
+
+	result := &ReplayResult{
+		Record:   record,
+		Replayed: replayed,
+		Diff:     DiffFlights(record.Response.Flights, replayed.Flights),
+	}
+
+	if opts.Debug {
+		result.Providers = uc.debugProviders(ctx, record)
+	}
+
+	return result, nil
+}
+
+// debugProviders queries each provider directly with the stored criteria.
+func (uc *replayUseCase) debugProviders(ctx context.Context, record *domain.SearchRecord) []ProviderDebug {
+	results := make([]ProviderDebug, len(uc.providers))
+
+	var wg sync.WaitGroup
+	for i, p := range uc.providers {
+		wg.Add(1)
+		go func(i int, p domain.FlightProvider) {
+			defer wg.Done()
+			results[i] = uc.debugProvider(ctx, p, record)
+		}(i, p)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// debugProvider queries a single provider and reports which flights the filters removed.
+func (uc *replayUseCase) debugProvider(ctx context.Context, p domain.FlightProvider, record *domain.SearchRecord) (debug ProviderDebug) {
+	timeout := uc.providerTimeout
+	if t, ok := uc.providerTimeouts[p.Name()]; ok {
+		timeout = t
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	debug.Provider = p.Name()
+	defer func() {
+		if r := recover(); r != nil {
+			debug.Error = errors.New("provider panic")
+		}
+		debug.Duration = time.Since(start)
+	}()
+
+	flights, err := p.Search(ctx, record.Criteria)
+	if err != nil {
+		debug.Error = err
+		return debug
+	}
+
+	debug.FlightsReturned = len(flights)
+	kept := make(map[string]struct{}, len(flights))
+	for _, f := range ApplyFilters(flights, record.Filters) {
+		kept[f.ID] = struct{}{}
+	}
+	for _, f := range flights {
+		if _, ok := kept[f.ID]; !ok {
+			debug.FilteredOut = append(debug.FilteredOut, f.ID)
+		}
+	}
+
+	return debug
+}
+
+// DiffFlights compares two flight lists, matching flights by provider and ID.
+func DiffFlights(original, replayed []domain.Flight) SearchDiff {
+	originals := make(map[string]domain.Flight, len(original))
+	for _, f := range original {
+		originals[flightKey(f)] = f
+	}
+
+	var diff SearchDiff
+	seen := make(map[string]struct{}, len(replayed))
+	for _, f := range replayed {
+		key := flightKey(f)
+		seen[key] = struct{}{}
+
+		orig, ok := originals[key]
+		if !ok {
+			diff.Added = append(diff.Added, f)
+			continue
+		}
+		if fields := changedFields(orig, f); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, FlightChange{Flight: f, Fields: fields})
+			continue
+		}
+		diff.Unchanged++
+	}
+
+	for _, f := range original {
+		if _, ok := seen[flightKey(f)]; !ok {
+			diff.Removed = append(diff.Removed, f)
+		}
+	}
+
+	return diff
+}
+
+// changedFields returns the compared fields that differ between two versions of a flight.
+func changedFields(original, replayed domain.Flight) map[string]FieldChange {
+	fields := make(map[string]FieldChange)
+
+	compare := func(name, a, b string) {
+		if a != b {
+			fields[name] = FieldChange{Original: a, Replayed: b}
+		}
+	}
+
+	compare("price", formatAmount(original.Price.Amount), formatAmount(replayed.Price.Amount))
+	compare("currency", original.Price.Currency, replayed.Price.Currency)
+	compare("departure", original.Departure.DateTime.Format(time.RFC3339), replayed.Departure.DateTime.Format(time.RFC3339))
+	compare("arrival", original.Arrival.DateTime.Format(time.RFC3339), replayed.Arrival.DateTime.Format(time.RFC3339))
+	compare("stops", strconv.Itoa(original.Stops), strconv.Itoa(replayed.Stops))
+	compare("class", original.Class, replayed.Class)
+
+	return fields
+}
+
+// flightKey identifies a flight across results.
+func flightKey(f domain.Flight) string {
+	return f.Provider + "/" + f.ID
+}
+
+// formatAmount formats a price amount without trailing zeros.
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Ensure replayUseCase implements ReplayUseCase at compile time.
+var _ ReplayUseCase = (*replayUseCase)(nil)
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/store/memory"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+func TestRecordingUseCase_StoresSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	provider := setupMockProvider(ctrl, "garuda", []domain.Flight{createTestFlight("1", "garuda", 1000000, 120, 0)}, nil)
+
+	store := memory.NewSearchStore(10)
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	uc := NewRecordingUseCase(NewFlightSearchUseCase([]domain.FlightProvider{provider}, nil), store, clock)
+
+	maxStops := 0
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1}
+	opts := SearchOptions{Filters: &domain.FilterOptions{MaxStops: &maxStops}, SortBy: domain.SortByPrice}
+
+	result, err := uc.Search(context.Background(), criteria, opts)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Metadata.SearchID)
+
+	record, err := store.Get(context.Background(), result.Metadata.SearchID)
+	require.NoError(t, err)
+	assert.Equal(t, criteria, record.Criteria)
+	assert.Equal(t, opts.Filters, record.Filters)
+	assert.Equal(t, domain.SortByPrice, record.SortBy)
+	assert.Equal(t, clock.Now(), record.CreatedAt)
+	assert.Len(t, record.Response.Flights, 1)
+	assert.Equal(t, result.Metadata.SearchID, record.Response.Metadata.SearchID)
+	assert.Equal(t, rbac.Anonymous, record.Client)
+
+	// Searches are recorded with their caller
+	ctx := rbac.WithPrincipal(context.Background(), rbac.Principal{Name: "partner-app"})
+	result, err = uc.Search(ctx, criteria, opts)
+	require.NoError(t, err)
+	record, err = store.Get(context.Background(), result.Metadata.SearchID)
+	require.NoError(t, err)
+	assert.Equal(t, "partner-app", record.Client)
+
+	// Searches may be recorded under an ID given in advance
+	opts.SearchID = "announced-id"
+	result, err = uc.Search(ctx, criteria, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "announced-id", result.Metadata.SearchID)
+	_, err = store.Get(context.Background(), "announced-id")
+	assert.NoError(t, err)
+}
+
+func TestRecordingUseCase_FailedSearchNotStored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	provider := setupMockProvider(ctrl, "garuda", nil, errors.New("down"))
+
+	store := memory.NewSearchStore(10)
+	uc := NewRecordingUseCase(NewFlightSearchUseCase([]domain.FlightProvider{provider}, nil), store, nil)
+
+	_, err := uc.Search(context.Background(), domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}, DefaultSearchOptions())
+	assert.ErrorIs(t, err, domain.ErrAllProvidersFailed)
+	assert.Zero(t, store.Len())
+}
+
+func TestReplayUseCase_Replay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	cheap := createTestFlight("1", "garuda", 900000, 120, 0)
+	direct := createTestFlight("2", "garuda", 1500000, 110, 0)
+	withStop := createTestFlight("3", "garuda", 800000, 200, 1)
+	provider := setupMockProvider(ctrl, "garuda", []domain.Flight{cheap, direct, withStop}, nil)
+	providers := []domain.FlightProvider{provider}
+
+	// Stored result: flight 1 was cheaper, flight 4 has since disappeared
+	storedCheap := cheap
+	storedCheap.Price.Amount = 850000
+	gone := createTestFlight("4", "garuda", 700000, 100, 0)
+
+	maxStops := 0
+	store := memory.NewSearchStore(10)
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{
+		ID:       "search-1",
+		Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1},
+		Filters:  &domain.FilterOptions{MaxStops: &maxStops},
+		SortBy:   domain.SortByPrice,
+		Response: domain.SearchResponse{Flights: []domain.Flight{gone, storedCheap}},
+	}))
+
+	uc := NewReplayUseCase(NewFlightSearchUseCase(providers, nil), store, providers, nil)
+
+	t.Run("diff", func(t *testing.T) {
+		result, err := uc.Replay(ctx, "search-1", ReplayOptions{})
+		require.NoError(t, err)
+
+		require.Len(t, result.Replayed.Flights, 2, "original filters should be applied")
+		assert.Equal(t, "1", result.Replayed.Flights[0].ID, "original sort should be applied")
+
+		require.Len(t, result.Diff.Added, 1)
+		assert.Equal(t, "2", result.Diff.Added[0].ID)
+		require.Len(t, result.Diff.Removed, 1)
+		assert.Equal(t, "4", result.Diff.Removed[0].ID)
+		require.Len(t, result.Diff.Changed, 1)
+		assert.Equal(t, FieldChange{Original: "850000", Replayed: "900000"}, result.Diff.Changed[0].Fields["price"])
+		assert.Zero(t, result.Diff.Unchanged)
+		assert.Nil(t, result.Providers)
+	})
+
+	t.Run("debug", func(t *testing.T) {
+		result, err := uc.Replay(ctx, "search-1", ReplayOptions{Debug: true})
+		require.NoError(t, err)
+
+		require.Len(t, result.Providers, 1)
+		assert.Equal(t, "garuda", result.Providers[0].Provider)
+		assert.Equal(t, 3, result.Providers[0].FlightsReturned)
+		assert.Equal(t, []string{"3"}, result.Providers[0].FilteredOut)
+		assert.NoError(t, result.Providers[0].Error)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := uc.Replay(ctx, "missing", ReplayOptions{})
+		assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+	})
+}
+
+func TestReplayUseCase_AllProvidersFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	provider := setupMockProvider(ctrl, "garuda", nil, errors.New("down"))
+	providers := []domain.FlightProvider{provider}
+
+	store := memory.NewSearchStore(10)
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{
+		ID:       "search-1",
+		Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"},
+		Response: domain.SearchResponse{Flights: []domain.Flight{createTestFlight("1", "garuda", 1000000, 120, 0)}},
+	}))
+	uc := NewReplayUseCase(NewFlightSearchUseCase(providers, nil), store, providers, nil)
+
+	_, err := uc.Replay(ctx, "search-1", ReplayOptions{})
+	assert.ErrorIs(t, err, domain.ErrAllProvidersFailed)
+
+	// Debug mode still explains what happened
+	result, err := uc.Replay(ctx, "search-1", ReplayOptions{Debug: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Replayed.Flights)
+	assert.Len(t, result.Diff.Removed, 1)
+	require.Len(t, result.Providers, 1)
+	assert.EqualError(t, result.Providers[0].Error, "down")
+}
+
+func TestDiffFlights_Unchanged(t *testing.T) {
+	flights := []domain.Flight{
+		createTestFlight("1", "garuda", 1000000, 120, 0),
+		createTestFlight("1", "lion_air", 1000000, 120, 0),
+	}
+
+	diff := DiffFlights(flights, flights)
+	assert.Equal(t, 2, diff.Unchanged, "same ID from different providers are distinct flights")
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Empty(t, diff.Changed)
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// requestClassKey is the context key for the request class.
+type requestClassKey struct{}
+
+// WithRequestClass returns a copy of ctx carrying the request class.
+// The search use case selects its global timeout budget from it.
+func WithRequestClass(ctx context.Context, class domain.RequestClass) context.Context {
+	return context.WithValue(ctx, requestClassKey{}, class)
+}
+
+// RequestClassFromContext returns the request class carried by ctx.
+// Requests without a class are interactive.
+func RequestClassFromContext(ctx context.Context) domain.RequestClass {
+	if class, ok := ctx.Value(requestClassKey{}).(domain.RequestClass); ok && class != "" {
+		return class
+	}
+	return domain.RequestClassInteractive
+}
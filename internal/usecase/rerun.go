@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// RerunUseCase searches again with the criteria of stored searches, so users
+// can check whether prices changed since they last searched.
+type RerunUseCase interface {
+	// Rerun re-executes the stored search with its original criteria, filters,
+	// sort option and limits, and summarizes how prices moved since.
+	// Returns domain.ErrSearchNotFound if the search is unknown or was run by
+	// another client.
+	Rerun(ctx context.Context, client, searchID string) (*RerunResult, error)
+}
+
+// RerunResult contains the fresh results of a stored search.
+type RerunResult struct {
+	// Previous is the stored search
+	Previous *domain.SearchRecord
+
+	// Current is the result of searching again
+	Current *domain.SearchResponse
+
+	// Delta summarizes the price changes since the stored search
+	Delta PriceDelta
+}
+
+// PriceDelta summarizes how the prices of a search moved between two runs.
+// Flights are matched by provider and flight ID, as in DiffFlights.
+type PriceDelta struct {
+	// PreviousLowest and CurrentLowest are the lowest fares of each run: the
+	// cheapest flight, or the cheapest total of a round trip (nil if none)
+	PreviousLowest *domain.PriceInfo
+	CurrentLowest  *domain.PriceInfo
+
+	// LowestChange is CurrentLowest minus PreviousLowest, set only when both
+	// are known and share a currency
+	LowestChange *float64
+
+	// Cheaper, Pricier and Unchanged count the flights of both runs whose
+	// price went down, up or stayed the same. Flights whose price changed
+	// currency are not compared.
+	Cheaper   int
+	Pricier   int
+	Unchanged int
+
+	// Added and Removed count the flights only in the current or previous run
+	Added   int
+	Removed int
+}
+
+// rerunUseCase implements RerunUseCase.
+type rerunUseCase struct {
+	search FlightSearchUseCase
+	store  domain.SearchRecordStore
+}
+
+// NewRerunUseCase creates a RerunUseCase searching again through search.
+// search usually records searches, so the fresh results get a search ID of
+// their own and can be rerun in turn.
+func NewRerunUseCase(search FlightSearchUseCase, store domain.SearchRecordStore) RerunUseCase {
+	return &rerunUseCase{search: search, store: store}
+}
+
+// Rerun implements RerunUseCase.Rerun.
+func (uc *rerunUseCase) Rerun(ctx context.Context, client, searchID string) (*RerunResult, error) {
+	record, err := clientRecord(ctx, uc.store, client, searchID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := uc.search.Search(ctx, record.Criteria, SearchOptions{
+		Filters:                record.Filters,
+		SortBy:                 record.SortBy,
+		SortOrder:              record.SortOrder,
+		MaxResults:             record.MaxResults,
+		MaxResultsPerProvider:  record.MaxResultsPerProvider,
+		PreferredDepartureTime: record.PreferredDepartureTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RerunResult{
+		Previous: record,
+		Current:  current,
+		Delta:    ComparePrices(&record.Response, current),
+	}, nil
+}
+
+// ComparePrices summarizes the price changes from the previous to the current
+// result of a search. Return flights of round trips are compared too.
+func ComparePrices(previous, current *domain.SearchResponse) PriceDelta {
+	delta := PriceDelta{
+		PreviousLowest: LowestFare(previous),
+		CurrentLowest:  LowestFare(current),
+	}
+	if p, c := delta.PreviousLowest, delta.CurrentLowest; p != nil && c != nil && p.Currency == c.Currency {
+		change := c.Amount - p.Amount
+		delta.LowestChange = &change
+	}
+
+	diff := DiffFlights(allFlights(previous), allFlights(current))
+	delta.Added = len(diff.Added)
+	delta.Removed = len(diff.Removed)
+	delta.Unchanged = diff.Unchanged
+
+	previousPrices := make(map[string]domain.PriceInfo, len(diff.Changed))
+	for _, f := range allFlights(previous) {
+		previousPrices[flightKey(f)] = f.Price
+	}
+	for _, change := range diff.Changed {
+		before, after := previousPrices[flightKey(change.Flight)], change.Flight.Price
+		switch {
+		case before.Currency != after.Currency:
+		case after.Amount < before.Amount:
+			delta.Cheaper++
+		case after.Amount > before.Amount:
+			delta.Pricier++
+		default:
+			// Only the schedule or class changed
+			delta.Unchanged++
+		}
+	}
+
+	return delta
+}
+
+// LowestFare returns the cheapest total of a round trip, or the price of the
+// cheapest flight of a one-way search (nil if there is none).
+func LowestFare(resp *domain.SearchResponse) *domain.PriceInfo {
+	if resp.Trip != nil {
+		return resp.Trip.LowestTotal
+	}
+	return domain.LowestPrice(resp.Flights)
+}
+
+// allFlights returns the outbound and return flights of a search result.
+func allFlights(resp *domain.SearchResponse) []domain.Flight {
+	if len(resp.ReturnFlights) == 0 {
+		return resp.Flights
+	}
+	return append(append([]domain.Flight(nil), resp.Flights...), resp.ReturnFlights...)
+}
+
+// Ensure rerunUseCase implements RerunUseCase at compile time.
+var _ RerunUseCase = (*rerunUseCase)(nil)
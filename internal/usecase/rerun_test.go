@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/store/memory"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/rbac"
+)
+
+func TestRerunUseCase_Rerun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	provider := setupMockProvider(ctrl, "garuda", []domain.Flight{
+		createTestFlight("1", "garuda", 900000, 120, 0),
+		createTestFlight("2", "garuda", 1500000, 110, 0),
+		createTestFlight("3", "garuda", 800000, 200, 1),
+	}, nil)
+
+	// Since the stored search, flight 1 got cheaper, flight 2 pricier and
+	// flight 4 disappeared; flight 3 is still filtered out
+	maxStops := 0
+	store := memory.NewSearchStore(10)
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{
+		ID:       "search-1",
+		Client:   rbac.Anonymous,
+		Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1},
+		Filters:  &domain.FilterOptions{MaxStops: &maxStops},
+		SortBy:   domain.SortByPrice,
+		Response: domain.SearchResponse{Flights: []domain.Flight{
+			createTestFlight("1", "garuda", 1000000, 120, 0),
+			createTestFlight("2", "garuda", 1400000, 110, 0),
+			createTestFlight("4", "garuda", 1100000, 100, 0),
+		}},
+	}))
+
+	search := NewRecordingUseCase(NewFlightSearchUseCase([]domain.FlightProvider{provider}, nil), store, nil)
+	uc := NewRerunUseCase(search, store)
+
+	result, err := uc.Rerun(ctx, rbac.Anonymous, "search-1")
+	require.NoError(t, err)
+	assert.Equal(t, "search-1", result.Previous.ID)
+	require.Len(t, result.Current.Flights, 2, "original filters should be applied")
+	assert.Equal(t, "1", result.Current.Flights[0].ID, "original sort should be applied")
+	assert.NotEqual(t, "search-1", result.Current.Metadata.SearchID, "the rerun is recorded on its own")
+	assert.Equal(t, 2, store.Len())
+
+	change := -100000.0
+	assert.Equal(t, PriceDelta{
+		PreviousLowest: &domain.PriceInfo{Amount: 1000000, Currency: "IDR"},
+		CurrentLowest:  &domain.PriceInfo{Amount: 900000, Currency: "IDR"},
+		LowestChange:   &change,
+		Cheaper:        1,
+		Pricier:        1,
+		Removed:        1,
+	}, result.Delta)
+
+	_, err = uc.Rerun(ctx, rbac.Anonymous, "missing")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+
+	_, err = uc.Rerun(ctx, "partner-app", "search-1")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound, "other clients' searches are not found")
+}
+
+func TestRerunUseCase_SearchFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	store := memory.NewSearchStore(10)
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "search-1", Client: "partner-app"}))
+	provider := setupMockProvider(ctrl, "garuda", nil, errors.New("down"))
+	uc := NewRerunUseCase(NewFlightSearchUseCase([]domain.FlightProvider{provider}, nil), store)
+
+	_, err := uc.Rerun(ctx, "partner-app", "search-1")
+	assert.ErrorIs(t, err, domain.ErrAllProvidersFailed)
+}
+
+func TestComparePrices(t *testing.T) {
+	t.Run("round trip totals", func(t *testing.T) {
+		previous := &domain.SearchResponse{
+			ReturnFlights: []domain.Flight{createTestFlight("r1", "garuda", 500000, 120, 0)},
+			Trip:          &domain.TripSummary{LowestTotal: &domain.PriceInfo{Amount: 1500000, Currency: "IDR"}},
+		}
+		current := &domain.SearchResponse{
+			ReturnFlights: []domain.Flight{createTestFlight("r1", "garuda", 600000, 120, 0)},
+			Trip:          &domain.TripSummary{LowestTotal: &domain.PriceInfo{Amount: 1600000, Currency: "IDR"}},
+		}
+
+		delta := ComparePrices(previous, current)
+		require.NotNil(t, delta.LowestChange)
+		assert.Equal(t, 100000.0, *delta.LowestChange)
+		assert.Equal(t, 1, delta.Pricier, "return flights are compared")
+	})
+
+	t.Run("currency change", func(t *testing.T) {
+		usd := createTestFlight("1", "garuda", 60, 120, 0)
+		usd.Price.Currency = "USD"
+		previous := &domain.SearchResponse{Flights: []domain.Flight{createTestFlight("1", "garuda", 1000000, 120, 0)}}
+		current := &domain.SearchResponse{Flights: []domain.Flight{usd}}
+
+		delta := ComparePrices(previous, current)
+		assert.Nil(t, delta.LowestChange)
+		assert.Zero(t, delta.Cheaper+delta.Pricier+delta.Unchanged)
+	})
+
+	t.Run("no flights", func(t *testing.T) {
+		delta := ComparePrices(&domain.SearchResponse{}, &domain.SearchResponse{})
+		assert.Equal(t, PriceDelta{}, delta)
+	})
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// roundTripUseCase decorates a FlightSearchUseCase, searching the legs of
+// round trips as independent one-way searches and pricing them together.
+type roundTripUseCase struct {
+	next FlightSearchUseCase
+}
+
+// NewRoundTripUseCase wraps next so searches with a return leg, open jaw
+// included, query both legs concurrently. Each leg is filtered, sorted and
+// truncated with the search options on its own; the response carries the
+// return flights, a trip summary combining the leg prices and the combined
+// itineraries, cheapest first and limited by MaxResults. One-way searches are
+// passed through unchanged.
+func NewRoundTripUseCase(next FlightSearchUseCase) FlightSearchUseCase {
+	return &roundTripUseCase{next: next}
+}
+
+// Search executes the search, one leg at a time for round trips.
+func (uc *roundTripUseCase) Search(ctx context.Context, criteria domain.SearchCriteria, opts SearchOptions) (*domain.SearchResponse, error) {
+	if criteria.Return == nil {
+		return uc.next.Search(ctx, criteria, opts)
+	}
+
+	// Progress of the return leg is reported as such
+	inboundOpts := opts
+	if progress := opts.Progress; progress != nil {
+		inboundOpts.Progress = func(p ProviderProgress) {
+			p.Return = true
+			progress(p)
+		}
+	}
+
+	var inbound *domain.SearchResponse
+	var inboundErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		inbound, inboundErr = uc.next.Search(ctx, criteria.Inbound(), inboundOpts)
+	}()
+	outbound, err := uc.next.Search(ctx, criteria.Outbound(), opts)
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+	if inboundErr != nil {
+		return nil, inboundErr
+	}
+
+	response := domain.NewSearchResponse(&criteria, outbound.Flights, mergeLegMetadata(outbound.Metadata, inbound.Metadata))
+	response.ReturnFlights = inbound.Flights
+	response.Trip = domain.NewTripSummary(&criteria, outbound.Flights, inbound.Flights)
+	response.Itineraries = domain.CombineItineraries(outbound.Flights, inbound.Flights, opts.MaxResults)
+	response.Metadata.TotalResults += len(inbound.Flights)
+	return &response, nil
+}
+
+// mergeLegMetadata combines the metadata of two searches answered together,
+// the legs of a round trip or the dates of a flexible-date search: provider
+// counts cover both searches, and the search time is that of the slower one.
+// Continuation tokens are dropped as they only cover one search.
+func mergeLegMetadata(outbound, inbound domain.SearchMetadata) domain.SearchMetadata {
+	return domain.SearchMetadata{
+		ProvidersQueried:   outbound.ProvidersQueried + inbound.ProvidersQueried,
+		ProvidersSucceeded: outbound.ProvidersSucceeded + inbound.ProvidersSucceeded,
+		ProvidersFailed:    outbound.ProvidersFailed + inbound.ProvidersFailed,
+		SearchTimeMs:       max(outbound.SearchTimeMs, inbound.SearchTimeMs),
+		CacheHit:           outbound.CacheHit && inbound.CacheHit,
+		RejectedRoutings:   outbound.RejectedRoutings + inbound.RejectedRoutings,
+		DuplicatesMerged:   outbound.DuplicatesMerged + inbound.DuplicatesMerged,
+		FilterRejections:   sumCounts(outbound.FilterRejections, inbound.FilterRejections),
+		FailedProviders:    unionNames(outbound.FailedProviders, inbound.FailedProviders),
+		PendingProviders:   unionNames(outbound.PendingProviders, inbound.PendingProviders),
+		SkippedProviders:   unionNames(outbound.SkippedProviders, inbound.SkippedProviders),
+		Features:           unionNames(outbound.Features, inbound.Features),
+	}
+}
+
+// sumCounts adds up the counts of a and b by key (nil if both are empty).
+func sumCounts(a, b map[string]int) map[string]int {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	sum := maps.Clone(a)
+	if sum == nil {
+		sum = make(map[string]int, len(b))
+	}
+	for key, n := range b {
+		sum[key] += n
+	}
+	return sum
+}
+
+// unionNames returns the names in a or b, in order of appearance.
+func unionNames(a, b []string) []string {
+	union := slices.Clone(a)
+	for _, name := range b {
+		if !slices.Contains(union, name) {
+			union = append(union, name)
+		}
+	}
+	return union
+}
+
+// Ensure roundTripUseCase implements FlightSearchUseCase at compile time.
+var _ FlightSearchUseCase = (*roundTripUseCase)(nil)
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+func TestRoundTripUseCase_OpenJaw(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := NewMockFlightSearchUseCase(ctrl)
+
+	criteria := domain.SearchCriteria{
+		Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1,
+		Return: &domain.ReturnLeg{Origin: "SUB", Destination: "CGK", DepartureDate: "2025-12-20"},
+	}
+	opts := DefaultSearchOptions()
+
+	outbound := domain.NewSearchResponse(&domain.SearchCriteria{}, []domain.Flight{
+		createTestFlight("out-1", "garuda", 1500000, 120, 0),
+		createTestFlight("out-2", "lion_air", 900000, 130, 0),
+	}, domain.SearchMetadata{ProvidersQueried: 2, ProvidersSucceeded: 2, SearchTimeMs: 120, SkippedProviders: []string{"airasia"}, FilterRejections: map[string]int{domain.FilterMaxPrice: 2}, Features: []string{"sort:best", domain.FeatureCache}})
+	returnFlight := createTestFlight("in-1", "garuda", 700000, 90, 0)
+	returnFlight.Departure.DateTime = returnFlight.Departure.DateTime.AddDate(0, 0, 5)
+	returnFlight.Arrival.DateTime = returnFlight.Arrival.DateTime.AddDate(0, 0, 5)
+	inbound := domain.NewSearchResponse(&domain.SearchCriteria{}, []domain.Flight{
+		returnFlight,
+	}, domain.SearchMetadata{ProvidersQueried: 2, ProvidersSucceeded: 1, ProvidersFailed: 1, SearchTimeMs: 300, SkippedProviders: []string{"airasia", "batik_air"}, FilterRejections: map[string]int{domain.FilterMaxPrice: 1, domain.FilterMaxStops: 3}, Features: []string{"sort:best", domain.FeatureCoalescing}})
+
+	next.EXPECT().Search(gomock.Any(), criteria.Outbound(), opts).Return(&outbound, nil)
+	next.EXPECT().Search(gomock.Any(), criteria.Inbound(), opts).Return(&inbound, nil)
+
+	result, err := NewRoundTripUseCase(next).Search(context.Background(), criteria, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, "CGK", result.SearchCriteria.Origin)
+	require.NotNil(t, result.SearchCriteria.Return)
+	assert.Equal(t, "SUB", result.SearchCriteria.Return.Origin)
+	assert.Len(t, result.Flights, 2)
+	assert.Len(t, result.ReturnFlights, 1)
+
+	require.NotNil(t, result.Trip)
+	assert.True(t, result.Trip.OpenJaw)
+	require.NotNil(t, result.Trip.LowestTotal)
+	assert.Equal(t, 1600000.0, result.Trip.LowestTotal.Amount)
+
+	require.Len(t, result.Itineraries, 2)
+	assert.Equal(t, "out-2", result.Itineraries[0].OutboundFlightID)
+	assert.Equal(t, "in-1", result.Itineraries[0].ReturnFlightID)
+	assert.Equal(t, domain.PriceInfo{Amount: 1600000, Currency: "IDR"}, result.Itineraries[0].TotalPrice)
+
+	assert.Equal(t, 4, result.Metadata.ProvidersQueried)
+	assert.Equal(t, 3, result.Metadata.ProvidersSucceeded)
+	assert.Equal(t, 1, result.Metadata.ProvidersFailed)
+	assert.Equal(t, 3, result.Metadata.TotalResults)
+	assert.Equal(t, int64(300), result.Metadata.SearchTimeMs)
+	assert.Equal(t, []string{"airasia", "batik_air"}, result.Metadata.SkippedProviders)
+	assert.Equal(t, map[string]int{domain.FilterMaxPrice: 3, domain.FilterMaxStops: 3}, result.Metadata.FilterRejections)
+	assert.Equal(t, []string{"sort:best", domain.FeatureCache, domain.FeatureCoalescing}, result.Metadata.Features)
+}
+
+func TestRoundTripUseCase_OneWayPassesThrough(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := NewMockFlightSearchUseCase(ctrl)
+
+	criteria := domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1}
+	want := domain.NewSearchResponse(&criteria, nil, domain.SearchMetadata{})
+	next.EXPECT().Search(gomock.Any(), criteria, gomock.Any()).Return(&want, nil)
+
+	result, err := NewRoundTripUseCase(next).Search(context.Background(), criteria, DefaultSearchOptions())
+	require.NoError(t, err)
+	assert.Same(t, &want, result)
+	assert.Nil(t, result.Trip)
+}
+
+func TestRoundTripUseCase_ProgressMarksReturnLeg(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := NewMockFlightSearchUseCase(ctrl)
+
+	criteria := domain.SearchCriteria{
+		Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1,
+		Return: &domain.ReturnLeg{DepartureDate: "2025-12-20"},
+	}
+	answer := func(_ context.Context, criteria domain.SearchCriteria, opts SearchOptions) (*domain.SearchResponse, error) {
+		opts.Progress(ProviderProgress{Provider: "garuda", Completed: 1, Total: 1})
+		response := domain.NewSearchResponse(&criteria, nil, domain.SearchMetadata{})
+		return &response, nil
+	}
+	next.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(answer).Times(2)
+
+	progress := make(chan ProviderProgress, 2)
+	opts := SearchOptions{Progress: func(p ProviderProgress) { progress <- p }}
+	_, err := NewRoundTripUseCase(next).Search(context.Background(), criteria, opts)
+	require.NoError(t, err)
+
+	close(progress)
+	var legs []bool
+	for p := range progress {
+		legs = append(legs, p.Return)
+	}
+	assert.ElementsMatch(t, []bool{false, true}, legs)
+}
+
+func TestRoundTripUseCase_LegFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := NewMockFlightSearchUseCase(ctrl)
+
+	criteria := domain.SearchCriteria{
+		Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1,
+		Return: &domain.ReturnLeg{Origin: "DPS", Destination: "CGK", DepartureDate: "2025-12-20"},
+	}
+	outbound := domain.NewSearchResponse(&criteria, nil, domain.SearchMetadata{})
+	next.EXPECT().Search(gomock.Any(), criteria.Outbound(), gomock.Any()).Return(&outbound, nil)
+	next.EXPECT().Search(gomock.Any(), criteria.Inbound(), gomock.Any()).Return(nil, domain.ErrAllProvidersFailed)
+
+	_, err := NewRoundTripUseCase(next).Search(context.Background(), criteria, DefaultSearchOptions())
+	assert.ErrorIs(t, err, domain.ErrAllProvidersFailed)
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/reference"
+)
+
+// routeKey identifies a route by its endpoints.
+type routeKey struct {
+	origin      string
+	destination string
+}
+
+// EstimateNonstopDuration estimates the scheduled time of a nonstop flight
+// between two airports from their distance: 30 minutes of taxi and climb plus
+// cruise at roughly 750 km/h. It returns false if either airport is unknown.
+func EstimateNonstopDuration(origin, destination string) (time.Duration, bool) {
+	km, ok := reference.DistanceKm(origin, destination)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration((30 + km/12.5) * float64(time.Minute)), true
+}
+
+// RejectAbsurdRoutings removes itineraries with absurd routings for their route,
+// such as three stops on a one-hour route, and returns the remaining flights
+// and the number rejected.
+//
+// A route's nonstop time is the duration of the fastest direct flight in the
+// results, or an estimate from the airports' distance when no direct flight
+// was returned. Routes whose nonstop time cannot be determined are not checked.
+// Does NOT mutate the original flights slice.
+func RejectAbsurdRoutings(flights []domain.Flight, policy domain.RoutingPolicy) ([]domain.Flight, int) {
+	if !policy.Enabled() || len(flights) == 0 {
+		return flights, 0
+	}
+
+	nonstop := nonstopDurations(flights)
+	result := make([]domain.Flight, 0, len(flights))
+	for _, f := range flights {
+		d, ok := nonstop[routeKey{f.Departure.AirportCode, f.Arrival.AirportCode}]
+		if ok && isAbsurdRouting(f, d, policy) {
+			continue
+		}
+		result = append(result, f)
+	}
+	return result, len(flights) - len(result)
+}
+
+// nonstopDurations returns the nonstop flight time of each route in flights.
+func nonstopDurations(flights []domain.Flight) map[routeKey]time.Duration {
+	durations := make(map[routeKey]time.Duration)
+	for _, f := range flights {
+		if f.Stops > 0 || f.Duration.TotalMinutes <= 0 {
+			continue
+		}
+		key := routeKey{f.Departure.AirportCode, f.Arrival.AirportCode}
+		d := time.Duration(f.Duration.TotalMinutes) * time.Minute
+		if current, ok := durations[key]; !ok || d < current {
+			durations[key] = d
+		}
+	}
+
+	for _, f := range flights {
+		key := routeKey{f.Departure.AirportCode, f.Arrival.AirportCode}
+		if _, ok := durations[key]; ok {
+			continue
+		}
+		if d, ok := EstimateNonstopDuration(key.origin, key.destination); ok {
+			durations[key] = d
+		}
+	}
+	return durations
+}
+
+// isAbsurdRouting reports whether a flight breaks the policy for a route with
+// the given nonstop time.
+func isAbsurdRouting(f domain.Flight, nonstop time.Duration, policy domain.RoutingPolicy) bool {
+	if maxStops, ok := policy.MaxStops(nonstop); ok && f.Stops > maxStops {
+		return true
+	}
+	if policy.MaxDurationFactor > 0 {
+		limit := time.Duration(float64(nonstop) * policy.MaxDurationFactor)
+		if time.Duration(f.Duration.TotalMinutes)*time.Minute > limit {
+			return true
+		}
+	}
+	return false
+}
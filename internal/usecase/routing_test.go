@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// routedFlight creates a flight on the given route.
+func routedFlight(id, origin, destination string, durationMin, stops int) domain.Flight {
+	f := createTestFlight(id, "test", 1000000, durationMin, stops)
+	f.Departure.AirportCode = origin
+	f.Arrival.AirportCode = destination
+	return f
+}
+
+var testRoutingPolicy = domain.RoutingPolicy{
+	MaxDurationFactor: 3,
+	StopLimits:        []domain.StopLimit{{UpTo: 2 * time.Hour, MaxStops: 1}, {UpTo: 6 * time.Hour, MaxStops: 2}},
+}
+
+func TestRejectAbsurdRoutings(t *testing.T) {
+	tests := []struct {
+		name         string
+		flights      []domain.Flight
+		wantIDs      []string
+		wantRejected int
+	}{
+		{
+			name: "too many stops for a short route",
+			flights: []domain.Flight{
+				routedFlight("direct", "CGK", "DPS", 110, 0),
+				routedFlight("one-stop", "CGK", "DPS", 240, 1),
+				routedFlight("three-stops", "CGK", "DPS", 300, 3),
+			},
+			wantIDs:      []string{"direct", "one-stop"},
+			wantRejected: 1,
+		},
+		{
+			name: "more than 3x the nonstop time",
+			flights: []domain.Flight{
+				routedFlight("direct", "CGK", "DPS", 110, 0),
+				routedFlight("detour", "CGK", "DPS", 340, 1),
+			},
+			wantIDs:      []string{"direct"},
+			wantRejected: 1,
+		},
+		{
+			name: "nonstop time estimated from distance",
+			flights: []domain.Flight{
+				// CGK-DPS is about 985 km, roughly 1h50m nonstop
+				routedFlight("one-stop", "CGK", "DPS", 240, 1),
+				routedFlight("two-stops", "CGK", "DPS", 300, 2),
+			},
+			wantIDs:      []string{"one-stop"},
+			wantRejected: 1,
+		},
+		{
+			name: "unknown route is not checked",
+			flights: []domain.Flight{
+				routedFlight("one-stop", "XXX", "YYY", 900, 3),
+			},
+			wantIDs: []string{"one-stop"},
+		},
+		{
+			name: "routes are judged separately",
+			flights: []domain.Flight{
+				routedFlight("short", "CGK", "BDO", 45, 0),
+				routedFlight("long", "CGK", "MDC", 500, 2),
+			},
+			wantIDs: []string{"short", "long"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, rejected := RejectAbsurdRoutings(tt.flights, testRoutingPolicy)
+
+			ids := make([]string, len(result))
+			for i, f := range result {
+				ids[i] = f.ID
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantRejected, rejected)
+		})
+	}
+}
+
+func TestRejectAbsurdRoutings_Disabled(t *testing.T) {
+	flights := []domain.Flight{routedFlight("three-stops", "CGK", "DPS", 900, 3)}
+
+	result, rejected := RejectAbsurdRoutings(flights, domain.RoutingPolicy{})
+	assert.Equal(t, flights, result)
+	assert.Zero(t, rejected)
+}
+
+func TestSearch_RejectedRoutingsInMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	flights := []domain.Flight{
+		routedFlight("direct", "CGK", "DPS", 110, 0),
+		routedFlight("three-stops", "CGK", "DPS", 300, 3),
+	}
+	uc := NewFlightSearchUseCase(
+		[]domain.FlightProvider{setupMockProvider(ctrl, "test", flights, nil)},
+		&Config{Routing: testRoutingPolicy},
+	)
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+	require.NoError(t, err)
+	assert.Len(t, response.Flights, 1)
+	assert.Equal(t, 1, response.Metadata.RejectedRoutings)
+}
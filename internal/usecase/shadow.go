@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+)
+
+// ShadowReport compares a shadow provider's results for a search with the
+// results of the live providers.
+type ShadowReport struct {
+	// Provider is the shadow provider's name
+	Provider string
+
+	// Criteria are the search criteria
+	Criteria domain.SearchCriteria
+
+	// Err is the shadow provider's error, if its search failed
+	Err error
+
+	// Duration is how long the shadow search took
+	Duration time.Duration
+
+	// Flights is the number of flights the shadow provider returned
+	Flights int
+
+	// LiveFlights is the number of flights the live providers returned, before filtering
+	LiveFlights int
+
+	// Matched is the number of shadow flights also offered by a live provider
+	// (same flight number and departure time)
+	Matched int
+
+	// Priced is the number of matched flights priced in the same currency as their live match
+	Priced int
+
+	// PriceDifference is the mean relative price difference of the priced
+	// flights, (shadow - live) / live; 0.05 means 5% more expensive
+	PriceDifference float64
+}
+
+// ShadowRecorder receives shadow comparisons. It is called from a background
+// goroutine after the live search completed and must be safe for concurrent use.
+type ShadowRecorder interface {
+	RecordShadow(ctx context.Context, report ShadowReport)
+}
+
+// shadowSearch queries the shadow providers alongside a live search, with the
+// same filtering hints as the live providers.
+// Results are compared once the live flights are sent on live, then dropped.
+// Shadow searches are skipped while the shadow feature is shed under load.
+func (uc *flightSearchUseCase) shadowSearch(ctx context.Context, criteria domain.SearchCriteria, hints domain.ProviderSearchOptions, live <-chan []domain.Flight) {
+	if len(uc.shadowProviders) == 0 || !loadshed.Allowed(ctx, loadshed.FeatureShadow) {
+		return
+	}
+
+	// Shadow searches must not be cut short when the live response is sent,
+	// and each is bounded by its own provider timeout
+	timeout := uc.providerTimeout
+	for _, provider := range uc.shadowProviders {
+		timeout = max(timeout, uc.providerTimeoutFor(provider.Name()))
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	results := make(chan providerResult, len(uc.shadowProviders))
+	for _, provider := range uc.shadowProviders {
+		go uc.queryProvider(ctx, provider, criteria, hints, results)
+	}
+
+	go func() {
+		defer cancel()
+		liveFlights, ok := <-live
+		if !ok {
+			// The live search was aborted: there is nothing to compare with
+			return
+		}
+		for range uc.shadowProviders {
+			result := <-results
+			if uc.shadowRecorder != nil {
+				uc.shadowRecorder.RecordShadow(ctx, compareShadow(criteria, result, liveFlights))
+			}
+		}
+	}()
+}
+
+// shadowKey identifies the same flight across providers.
+type shadowKey struct {
+	flightNumber string
+	departure    int64
+}
+
+// compareShadow builds the report of a shadow provider result.
+func compareShadow(criteria domain.SearchCriteria, result providerResult, live []domain.Flight) ShadowReport {
+	report := ShadowReport{
+		Provider:    result.Provider,
+		Criteria:    criteria,
+		Err:         result.Error,
+		Duration:    result.Duration,
+		Flights:     len(result.Flights),
+		LiveFlights: len(live),
+	}
+	if result.Error != nil {
+		return report
+	}
+
+	liveByKey := make(map[shadowKey]domain.Flight, len(live))
+	for _, f := range live {
+		key := shadowKey{f.FlightNumber, f.Departure.DateTime.Unix()}
+		if existing, ok := liveByKey[key]; !ok || f.Price.Amount < existing.Price.Amount {
+			liveByKey[key] = f
+		}
+	}
+
+	var diffSum float64
+	for _, f := range result.Flights {
+		match, ok := liveByKey[shadowKey{f.FlightNumber, f.Departure.DateTime.Unix()}]
+		if !ok {
+			continue
+		}
+		report.Matched++
+		if match.Price.Currency == f.Price.Currency && match.Price.Amount > 0 {
+			diffSum += (f.Price.Amount - match.Price.Amount) / match.Price.Amount
+			report.Priced++
+		}
+	}
+	if report.Priced > 0 {
+		report.PriceDifference = diffSum / float64(report.Priced)
+	}
+	return report
+}
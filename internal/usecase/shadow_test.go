@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/loadshed"
+)
+
+// shadowRecorderFunc records shadow reports on a channel.
+type shadowRecorderFunc chan ShadowReport
+
+func (r shadowRecorderFunc) RecordShadow(_ context.Context, report ShadowReport) {
+	r <- report
+}
+
+func TestSearch_ShadowProviders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	live := []domain.Flight{
+		createTestFlight("1", "live", 1000000, 120, 0),
+		createTestFlight("2", "live", 2000000, 120, 0),
+	}
+	shadowFlights := []domain.Flight{
+		createTestFlight("1", "candidate", 1100000, 120, 0),
+		createTestFlight("9", "candidate", 500000, 120, 0),
+	}
+
+	recorder := make(shadowRecorderFunc, 2)
+	uc := NewFlightSearchUseCase(
+		[]domain.FlightProvider{setupMockProvider(ctrl, "live", live, nil)},
+		&Config{
+			ShadowProviders: []domain.FlightProvider{
+				setupMockProviderWithDelay(ctrl, "candidate", shadowFlights, 20*time.Millisecond),
+				setupMockProvider(ctrl, "broken", nil, errors.New("bad gateway")),
+			},
+			ShadowRecorder: recorder,
+		},
+	)
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{Origin: "CGK"}, SearchOptions{})
+	require.NoError(t, err)
+
+	// Shadow results never reach the response
+	assert.Len(t, response.Flights, 2)
+	assert.Equal(t, 1, response.Metadata.ProvidersQueried)
+	for _, f := range response.Flights {
+		assert.Equal(t, "live", f.Provider)
+	}
+
+	var reports []ShadowReport
+	for range 2 {
+		select {
+		case r := <-recorder:
+			reports = append(reports, r)
+		case <-time.After(time.Second):
+			t.Fatal("shadow report not recorded")
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Provider < reports[j].Provider })
+
+	assert.Equal(t, "broken", reports[0].Provider)
+	assert.EqualError(t, reports[0].Err, "bad gateway")
+
+	candidate := reports[1]
+	assert.Equal(t, "candidate", candidate.Provider)
+	assert.Equal(t, "CGK", candidate.Criteria.Origin)
+	assert.NoError(t, candidate.Err)
+	assert.Equal(t, 2, candidate.Flights)
+	assert.Equal(t, 2, candidate.LiveFlights)
+	assert.Equal(t, 1, candidate.Matched)
+	assert.Equal(t, 1, candidate.Priced)
+	assert.InDelta(t, 0.1, candidate.PriceDifference, 1e-9)
+	assert.GreaterOrEqual(t, candidate.Duration, 20*time.Millisecond)
+}
+
+func TestSearch_ShadowWithoutRecorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewFlightSearchUseCase(
+		[]domain.FlightProvider{setupMockProvider(ctrl, "live", nil, errors.New("down"))},
+		&Config{ShadowProviders: []domain.FlightProvider{
+			setupMockProvider(ctrl, "candidate", []domain.Flight{createTestFlight("1", "candidate", 1, 60, 0)}, nil),
+		}},
+	)
+
+	// Shadow providers do not count towards availability
+	_, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+	assert.ErrorIs(t, err, domain.ErrAllProvidersFailed)
+}
+
+func TestSearch_ShadowShedUnderLoad(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The shadow provider must not be queried
+	shadow := domain.NewMockFlightProvider(ctrl)
+	shadow.EXPECT().Name().Return("candidate").AnyTimes()
+
+	reports := make(shadowRecorderFunc, 1)
+	uc := NewFlightSearchUseCase(
+		[]domain.FlightProvider{setupMockProvider(ctrl, "live", []domain.Flight{createTestFlight("1", "live", 1000000, 120, 0)}, nil)},
+		&Config{ShadowProviders: []domain.FlightProvider{shadow}, ShadowRecorder: reports},
+	)
+
+	ctx := loadshed.WithShed(context.Background(), []string{loadshed.FeatureShadow})
+	result, err := uc.Search(ctx, domain.SearchCriteria{}, SearchOptions{})
+	require.NoError(t, err)
+	assert.Len(t, result.Flights, 1)
+	assert.Empty(t, reports)
+}
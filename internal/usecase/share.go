@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/share"
+)
+
+// ShareUseCase shares stored search results through expiring links that do
+// not expose search IDs.
+type ShareUseCase interface {
+	// Share issues a share token for a stored search of client.
+	// Returns domain.ErrSearchNotFound if the search is unknown or was run by
+	// another client.
+	Share(ctx context.Context, client, searchID string) (*ShareLink, error)
+
+	// Open returns the stored search a share token refers to.
+	// Returns domain.ErrShareNotFound for invalid or expired tokens and for
+	// searches evicted since they were shared.
+	Open(ctx context.Context, token string) (*SharedSearch, error)
+}
+
+// ShareLink is an issued share token.
+type ShareLink struct {
+	// Token identifies the shared search
+	Token string
+
+	// ExpiresAt is when the token stops being accepted
+	ExpiresAt time.Time
+}
+
+// SharedSearch is a stored search opened through a share token.
+type SharedSearch struct {
+	// Record is the stored search
+	Record *domain.SearchRecord
+
+	// ExpiresAt is when the share token stops being accepted
+	ExpiresAt time.Time
+}
+
+// shareUseCase implements ShareUseCase.
+type shareUseCase struct {
+	store  domain.SearchRecordStore
+	issuer *share.Issuer
+}
+
+// NewShareUseCase creates a ShareUseCase sharing the searches of store with
+// tokens from issuer.
+func NewShareUseCase(store domain.SearchRecordStore, issuer *share.Issuer) ShareUseCase {
+	return &shareUseCase{store: store, issuer: issuer}
+}
+
+// Share implements ShareUseCase.Share.
+func (uc *shareUseCase) Share(ctx context.Context, client, searchID string) (*ShareLink, error) {
+	record, err := clientRecord(ctx, uc.store, client, searchID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, grant, err := uc.issuer.Issue(record.ID)
+	if err != nil {
+		return nil, fmt.Errorf("issue share token: %w", err)
+	}
+	return &ShareLink{Token: token, ExpiresAt: grant.ExpiresAt}, nil
+}
+
+// Open implements ShareUseCase.Open.
+func (uc *shareUseCase) Open(ctx context.Context, token string) (*SharedSearch, error) {
+	grant, err := uc.issuer.Open(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrShareNotFound, err)
+	}
+
+	record, err := uc.store.Get(ctx, grant.SearchID)
+	if errors.Is(err, domain.ErrSearchNotFound) {
+		return nil, fmt.Errorf("%w: search evicted", domain.ErrShareNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &SharedSearch{Record: record, ExpiresAt: grant.ExpiresAt}, nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/store/memory"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/share"
+)
+
+func newShareUseCase(t *testing.T, store domain.SearchRecordStore, clock timeutil.Clock) ShareUseCase {
+	t.Helper()
+	issuer, err := share.NewIssuer([]byte(strings.Repeat("s", share.MinSecretLength)), 24*time.Hour, share.WithClock(clock))
+	require.NoError(t, err)
+	return NewShareUseCase(store, issuer)
+}
+
+func TestShareUseCase_ShareAndOpen(t *testing.T) {
+	ctx := context.Background()
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	store := memory.NewSearchStore(10)
+	record := domain.SearchRecord{ID: "search-1", Client: "partner-app", Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS"}, CreatedAt: clock.Now()}
+	require.NoError(t, store.Save(ctx, record))
+	uc := newShareUseCase(t, store, clock)
+
+	link, err := uc.Share(ctx, "partner-app", "search-1")
+	require.NoError(t, err)
+	assert.Equal(t, clock.Now().Add(24*time.Hour), link.ExpiresAt.UTC())
+
+	shared, err := uc.Open(ctx, link.Token)
+	require.NoError(t, err)
+	assert.Equal(t, record, *shared.Record)
+	assert.True(t, link.ExpiresAt.Equal(shared.ExpiresAt))
+
+	clock.Advance(24 * time.Hour)
+	_, err = uc.Open(ctx, link.Token)
+	assert.ErrorIs(t, err, domain.ErrShareNotFound)
+}
+
+func TestShareUseCase_Errors(t *testing.T) {
+	ctx := context.Background()
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	store := memory.NewSearchStore(1)
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "search-1", Client: "partner-app", CreatedAt: clock.Now()}))
+	uc := newShareUseCase(t, store, clock)
+
+	_, err := uc.Share(ctx, "partner-app", "unknown")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+
+	_, err = uc.Share(ctx, "ops", "search-1")
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound, "other clients' searches are not found")
+
+	_, err = uc.Open(ctx, "not-a-token")
+	assert.ErrorIs(t, err, domain.ErrShareNotFound)
+
+	// The shared search is evicted by a newer one
+	link, err := uc.Share(ctx, "partner-app", "search-1")
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{ID: "search-2", CreatedAt: clock.Now()}))
+	_, err = uc.Open(ctx, link.Token)
+	assert.ErrorIs(t, err, domain.ErrShareNotFound)
+}
@@ -0,0 +1,457 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// MaxSimulationSamples is the largest number of samples a simulation replays.
+const MaxSimulationSamples = 500
+
+// SimulationUseCase replays recorded provider latencies and result counts
+// through the search orchestrator with hypothetical settings, so operators
+// can tune timeouts and ranking weights offline.
+type SimulationUseCase interface {
+	// Simulate replays the samples with the running settings and with
+	// settings, and compares the provider success rates and rankings of both.
+	// Returns domain.ErrSearchNotFound if the stored search of a sample is
+	// unknown.
+	Simulate(ctx context.Context, samples []SimulationSample, settings SimulationSettings) (*SimulationResult, error)
+}
+
+// SimulationSettings are the settings a simulation replays the samples with.
+type SimulationSettings struct {
+	// GlobalTimeout and ProviderTimeout are the search and per-provider
+	// timeouts (0 = the running value)
+	GlobalTimeout   time.Duration
+	ProviderTimeout time.Duration
+
+	// Weights rank the flights (zero value = DefaultRankingWeights)
+	Weights RankingWeights
+}
+
+// SimulationSample is the recorded behaviour of the providers of one search.
+type SimulationSample struct {
+	// SearchID identifies a stored search, optionally: its criteria and
+	// filters are searched again, and its flights returned by the providers,
+	// so the rankings of both settings can be compared
+	SearchID string
+
+	// Providers are the providers queried by the search
+	Providers []ProviderSample
+}
+
+// ProviderSample is how a provider answered one search.
+type ProviderSample struct {
+	Provider string
+
+	// Latency is how long the provider took to answer
+	Latency time.Duration
+
+	// Results is the number of flights the provider returned. Samples
+	// replaying a stored search count the stored flights of the provider.
+	Results int
+
+	// Failed reports that the provider answered with an error
+	Failed bool
+}
+
+// SimulationResult compares the outcomes of the samples replayed with the
+// running settings and with the hypothetical ones.
+type SimulationResult struct {
+	Current   SimulationOutcome
+	Simulated SimulationOutcome
+
+	// Ranking compares the rankings of the samples replaying a stored search,
+	// in the order of the samples
+	Ranking []RankingChange
+}
+
+// SimulationOutcome is the outcome of the samples replayed with one set of
+// settings.
+type SimulationOutcome struct {
+	// Settings are the settings replayed, defaults resolved
+	Settings SimulationSettings
+
+	// Searches is the number of samples, SearchesFailed those whose providers
+	// all failed or timed out
+	Searches       int
+	SearchesFailed int
+
+	// Results is the number of flights returned by the providers answering
+	// in time, over every sample
+	Results int
+
+	// Providers are the outcomes of each provider, by name
+	Providers []ProviderOutcome
+}
+
+// ProviderOutcome counts how a provider's searches ended in a simulation.
+type ProviderOutcome struct {
+	Provider string
+
+	// Queried is the number of searches querying the provider, of which
+	// Succeeded answered in time, TimedOut did not and Failed answered with
+	// an error
+	Queried   int
+	Succeeded int
+	TimedOut  int
+	Failed    int
+
+	// Results is the number of flights the provider returned in time,
+	// passing the filters of the stored search replayed
+	Results int
+}
+
+// SuccessRate returns the share of the provider's searches answered in time.
+func (o ProviderOutcome) SuccessRate() float64 {
+	if o.Queried == 0 {
+		return 0
+	}
+	return float64(o.Succeeded) / float64(o.Queried)
+}
+
+// RankingChange compares the ranking of a stored search replayed with the
+// running settings and with the hypothetical ones. Flights are matched by
+// provider and flight ID, as in DiffFlights.
+type RankingChange struct {
+	SearchID string
+
+	// CurrentBest and SimulatedBest are the best ranked flights of each
+	// replay (nil if none)
+	CurrentBest   *domain.Flight
+	SimulatedBest *domain.Flight
+
+	// Moved is the number of flights ranked by both replays at different
+	// positions, relative to the other flights of both
+	Moved int
+
+	// Added and Removed count the flights only ranked by the simulated or
+	// the current replay, e.g. because their provider timed out
+	Added   int
+	Removed int
+}
+
+// BestChanged reports whether both replays rank a different flight best.
+func (c RankingChange) BestChanged() bool {
+	if c.CurrentBest == nil || c.SimulatedBest == nil {
+		return c.CurrentBest != c.SimulatedBest
+	}
+	return flightKey(*c.CurrentBest) != flightKey(*c.SimulatedBest)
+}
+
+// errSimulatedFailure is the error of providers whose sample failed.
+var errSimulatedFailure = errors.New("simulated provider failure")
+
+// simulationUseCase implements SimulationUseCase.
+type simulationUseCase struct {
+	store   domain.SearchRecordStore
+	current SimulationSettings
+}
+
+// NewSimulationUseCase creates a SimulationUseCase loading stored searches
+// from store and comparing hypothetical settings with the running config.
+func NewSimulationUseCase(store domain.SearchRecordStore, config *Config) SimulationUseCase {
+	cfg := DefaultConfig()
+	if config != nil {
+		if config.GlobalTimeout > 0 {
+			cfg.GlobalTimeout = config.GlobalTimeout
+		}
+		if config.ProviderTimeout > 0 {
+			cfg.ProviderTimeout = config.ProviderTimeout
+		}
+	}
+
+	return &simulationUseCase{
+		store: store,
+		current: SimulationSettings{
+			GlobalTimeout:   cfg.GlobalTimeout,
+			ProviderTimeout: cfg.ProviderTimeout,
+			Weights:         DefaultRankingWeights(),
+		},
+	}
+}
+
+// Simulate implements SimulationUseCase.Simulate.
+//
+// Each sample is searched through a search use case of its own, whose
+// providers answer after their recorded latency, so the simulation takes as
+// long as the slowest sample. Samples and both settings are replayed
+// concurrently.
+func (uc *simulationUseCase) Simulate(ctx context.Context, samples []SimulationSample, settings SimulationSettings) (*SimulationResult, error) {
+	records := make([]*domain.SearchRecord, len(samples))
+	for i, s := range samples {
+		if s.SearchID == "" {
+			continue
+		}
+		record, err := uc.store.Get(ctx, s.SearchID)
+		if err != nil {
+			return nil, err
+		}
+		records[i] = record
+	}
+
+	simulated := uc.resolve(settings)
+	var current, hypothetical []sampleReplay
+	var currentErr, hypotheticalErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		current, currentErr = replaySamples(ctx, samples, records, uc.current)
+	}()
+	go func() {
+		defer wg.Done()
+		hypothetical, hypotheticalErr = replaySamples(ctx, samples, records, simulated)
+	}()
+	wg.Wait()
+	if err := errors.Join(currentErr, hypotheticalErr); err != nil {
+		return nil, err
+	}
+
+	result := &SimulationResult{
+		Current:   summarizeReplays(uc.current, current),
+		Simulated: summarizeReplays(simulated, hypothetical),
+	}
+	for i, record := range records {
+		if record != nil {
+			result.Ranking = append(result.Ranking, compareRankings(record.ID, current[i].ranked, hypothetical[i].ranked))
+		}
+	}
+	return result, nil
+}
+
+// resolve returns settings with the running values in place of unset ones.
+func (uc *simulationUseCase) resolve(settings SimulationSettings) SimulationSettings {
+	if settings.GlobalTimeout <= 0 {
+		settings.GlobalTimeout = uc.current.GlobalTimeout
+	}
+	if settings.ProviderTimeout <= 0 {
+		settings.ProviderTimeout = uc.current.ProviderTimeout
+	}
+	if settings.Weights == (RankingWeights{}) {
+		settings.Weights = uc.current.Weights
+	}
+	return settings
+}
+
+// sampleReplay is the outcome of replaying one sample.
+type sampleReplay struct {
+	// answers are the providers' answers, in the order they came in
+	answers []ProviderProgress
+
+	// failed reports that every provider failed or timed out
+	failed bool
+
+	// ranked are the flights of the response ranked with the settings'
+	// weights, best first
+	ranked []domain.Flight
+}
+
+// replaySamples replays every sample concurrently with settings. record is
+// the stored search of each sample, if any.
+func replaySamples(ctx context.Context, samples []SimulationSample, records []*domain.SearchRecord, settings SimulationSettings) ([]sampleReplay, error) {
+	replays := make([]sampleReplay, len(samples))
+	errs := make([]error, len(samples))
+
+	var wg sync.WaitGroup
+	for i := range samples {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			replays[i], errs[i] = replaySample(ctx, samples[i], records[i], settings)
+		}(i)
+	}
+	wg.Wait()
+
+	return replays, errors.Join(errs...)
+}
+
+// replaySample searches the sample's stored search, or an empty search,
+// through a search use case querying the sample's providers.
+func replaySample(ctx context.Context, sample SimulationSample, record *domain.SearchRecord, settings SimulationSettings) (sampleReplay, error) {
+	providers := make([]domain.FlightProvider, len(sample.Providers))
+	for i, p := range sample.Providers {
+		replayed := &replayedProvider{sample: p}
+		if record != nil {
+			replayed.flights = providerFlights(&record.Response, p.Provider)
+		}
+		providers[i] = replayed
+	}
+
+	search := NewFlightSearchUseCase(providers, &Config{
+		GlobalTimeout:     settings.GlobalTimeout,
+		ProviderTimeout:   settings.ProviderTimeout,
+		DisableCoalescing: true,
+	})
+
+	var criteria domain.SearchCriteria
+	var opts SearchOptions
+	if record != nil {
+		criteria = record.Criteria
+		opts.Filters = record.Filters
+		opts.PreferredDepartureTime = record.PreferredDepartureTime
+	}
+	var replay sampleReplay
+	opts.Progress = func(p ProviderProgress) {
+		replay.answers = append(replay.answers, p)
+	}
+
+	resp, err := search.Search(ctx, criteria, opts)
+	if errors.Is(err, domain.ErrAllProvidersFailed) {
+		replay.failed = true
+		return replay, nil
+	}
+	if err != nil {
+		return replay, err
+	}
+
+	preference := WithDeparturePreference(opts.PreferredDepartureTime)
+	ranked := CalculateRankingScores(resp.Flights, preference, WithWeights(settings.Weights))
+	ranked, _ = EnforceRankingInvariants(ranked, preference)
+	replay.ranked = SortFlights(ranked, domain.SortByBestValue, domain.SortAscending)
+	return replay, nil
+}
+
+// providerFlights returns the flights of resp returned by provider.
+func providerFlights(resp *domain.SearchResponse, provider string) []domain.Flight {
+	var flights []domain.Flight
+	for _, f := range allFlights(resp) {
+		if f.Provider == provider {
+			flights = append(flights, f)
+		}
+	}
+	return flights
+}
+
+// summarizeReplays counts the outcomes of the replays of every sample.
+func summarizeReplays(settings SimulationSettings, replays []sampleReplay) SimulationOutcome {
+	outcome := SimulationOutcome{Settings: settings, Searches: len(replays)}
+
+	byProvider := make(map[string]*ProviderOutcome)
+	for _, r := range replays {
+		if r.failed {
+			outcome.SearchesFailed++
+		}
+		for _, answer := range r.answers {
+			p, ok := byProvider[answer.Provider]
+			if !ok {
+				p = &ProviderOutcome{Provider: answer.Provider}
+				byProvider[answer.Provider] = p
+			}
+			p.Queried++
+			switch {
+			case answer.Err == nil:
+				p.Succeeded++
+				p.Results += len(answer.Flights)
+			case errors.Is(answer.Err, context.DeadlineExceeded):
+				p.TimedOut++
+			default:
+				p.Failed++
+			}
+		}
+	}
+
+	for _, p := range byProvider {
+		outcome.Results += p.Results
+		outcome.Providers = append(outcome.Providers, *p)
+	}
+	sort.Slice(outcome.Providers, func(i, j int) bool {
+		return outcome.Providers[i].Provider < outcome.Providers[j].Provider
+	})
+	return outcome
+}
+
+// compareRankings compares the flights of a stored search ranked by the
+// current and simulated replays, best first.
+func compareRankings(searchID string, current, simulated []domain.Flight) RankingChange {
+	change := RankingChange{SearchID: searchID}
+	if len(current) > 0 {
+		change.CurrentBest = &current[0]
+	}
+	if len(simulated) > 0 {
+		change.SimulatedBest = &simulated[0]
+	}
+
+	// Compare the positions of the flights ranked by both replays among
+	// themselves, so flights added or removed do not count as moves
+	inSimulated := make(map[string]struct{}, len(simulated))
+	for _, f := range simulated {
+		inSimulated[flightKey(f)] = struct{}{}
+	}
+	var both []string
+	inBoth := make(map[string]struct{}, len(current))
+	for _, f := range current {
+		key := flightKey(f)
+		if _, ok := inSimulated[key]; !ok {
+			change.Removed++
+			continue
+		}
+		both = append(both, key)
+		inBoth[key] = struct{}{}
+	}
+	change.Added = len(simulated) - len(both)
+
+	position := 0
+	for _, f := range simulated {
+		key := flightKey(f)
+		if _, ok := inBoth[key]; !ok {
+			continue
+		}
+		if both[position] != key {
+			change.Moved++
+		}
+		position++
+	}
+	return change
+}
+
+// replayedProvider answers searches as a provider sample recorded: after
+// its latency, with its flights or its failure.
+type replayedProvider struct {
+	sample  ProviderSample
+	flights []domain.Flight
+}
+
+// Name implements domain.FlightProvider.
+func (p *replayedProvider) Name() string {
+	return p.sample.Provider
+}
+
+// Search implements domain.FlightProvider. Without stored flights, it
+// returns as many placeholder flights as the sample recorded.
+func (p *replayedProvider) Search(ctx context.Context, _ domain.SearchCriteria) ([]domain.Flight, error) {
+	timer := time.NewTimer(p.sample.Latency)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+	}
+
+	if p.sample.Failed {
+		return nil, errSimulatedFailure
+	}
+	if p.flights != nil {
+		return slices.Clone(p.flights), nil
+	}
+	return placeholderFlights(p.sample.Provider, p.sample.Results), nil
+}
+
+// placeholderFlights returns n distinct flights of provider, identical but
+// for their flight number.
+func placeholderFlights(provider string, n int) []domain.Flight {
+	flights := make([]domain.Flight, n)
+	for i := range flights {
+		number := provider + "-" + strconv.Itoa(i+1)
+		flights[i] = domain.Flight{ID: number, Provider: provider, FlightNumber: number}
+	}
+	return flights
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/store/memory"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+func TestSimulationUseCase_Timeouts(t *testing.T) {
+	uc := NewSimulationUseCase(memory.NewSearchStore(10), &Config{ProviderTimeout: time.Second})
+
+	samples := []SimulationSample{
+		{Providers: []ProviderSample{
+			{Provider: "garuda", Latency: 5 * time.Millisecond, Results: 3},
+			{Provider: "lion", Latency: 150 * time.Millisecond, Results: 2},
+			{Provider: "batik", Latency: 5 * time.Millisecond, Failed: true},
+		}},
+		{Providers: []ProviderSample{
+			{Provider: "lion", Latency: 200 * time.Millisecond, Results: 4},
+		}},
+	}
+
+	result, err := uc.Simulate(context.Background(), samples, SimulationSettings{ProviderTimeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	assert.Equal(t, SimulationSettings{
+		GlobalTimeout:   DefaultGlobalTimeout,
+		ProviderTimeout: time.Second,
+		Weights:         DefaultRankingWeights(),
+	}, result.Current.Settings)
+	assert.Equal(t, 50*time.Millisecond, result.Simulated.Settings.ProviderTimeout)
+	assert.Equal(t, DefaultGlobalTimeout, result.Simulated.Settings.GlobalTimeout, "unset settings keep the running value")
+
+	assert.Equal(t, 2, result.Current.Searches)
+	assert.Zero(t, result.Current.SearchesFailed)
+	assert.Equal(t, 9, result.Current.Results)
+	assert.Equal(t, []ProviderOutcome{
+		{Provider: "batik", Queried: 1, Failed: 1},
+		{Provider: "garuda", Queried: 1, Succeeded: 1, Results: 3},
+		{Provider: "lion", Queried: 2, Succeeded: 2, Results: 6},
+	}, result.Current.Providers)
+
+	assert.Equal(t, 1, result.Simulated.SearchesFailed, "the second search has no provider answering in time")
+	assert.Equal(t, 3, result.Simulated.Results)
+	assert.Equal(t, []ProviderOutcome{
+		{Provider: "batik", Queried: 1, Failed: 1},
+		{Provider: "garuda", Queried: 1, Succeeded: 1, Results: 3},
+		{Provider: "lion", Queried: 2, TimedOut: 2},
+	}, result.Simulated.Providers)
+	assert.Zero(t, result.Simulated.Providers[2].SuccessRate())
+
+	assert.Empty(t, result.Ranking, "no sample replays a stored search")
+}
+
+func TestSimulationUseCase_Ranking(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewSearchStore(10)
+	require.NoError(t, store.Save(ctx, domain.SearchRecord{
+		ID:       "search-1",
+		Criteria: domain.SearchCriteria{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1},
+		Response: domain.SearchResponse{Flights: []domain.Flight{
+			createTestFlight("cheap", "garuda", 500000, 240, 1),
+			createTestFlight("fast", "garuda", 1500000, 90, 0),
+			createTestFlight("slow", "lion", 900000, 300, 1),
+		}},
+	}))
+	uc := NewSimulationUseCase(store, nil)
+
+	samples := []SimulationSample{{
+		SearchID: "search-1",
+		Providers: []ProviderSample{
+			{Provider: "garuda", Latency: time.Millisecond},
+			{Provider: "lion", Latency: 100 * time.Millisecond},
+		},
+	}}
+	settings := SimulationSettings{
+		ProviderTimeout: 50 * time.Millisecond,
+		Weights:         RankingWeights{Duration: 1},
+	}
+
+	result, err := uc.Simulate(ctx, samples, settings)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.Current.Results, "stored flights are replayed")
+	assert.Equal(t, 2, result.Simulated.Results)
+
+	require.Len(t, result.Ranking, 1)
+	change := result.Ranking[0]
+	assert.Equal(t, "search-1", change.SearchID)
+	require.NotNil(t, change.CurrentBest)
+	require.NotNil(t, change.SimulatedBest)
+	assert.Equal(t, "cheap", change.CurrentBest.ID)
+	assert.Equal(t, "fast", change.SimulatedBest.ID, "only duration counts")
+	assert.True(t, change.BestChanged())
+	assert.Equal(t, 2, change.Moved)
+	assert.Zero(t, change.Added)
+	assert.Equal(t, 1, change.Removed, "lion timed out")
+
+	_, err = uc.Simulate(ctx, []SimulationSample{{SearchID: "missing"}}, settings)
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+}
+
+func TestSimulationUseCase_Cancelled(t *testing.T) {
+	uc := NewSimulationUseCase(memory.NewSearchStore(10), nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := uc.Simulate(ctx, []SimulationSample{{Providers: []ProviderSample{
+		{Provider: "garuda", Latency: time.Second},
+	}}}, SimulationSettings{})
+	assert.ErrorIs(t, err, domain.ErrSearchAborted)
+}
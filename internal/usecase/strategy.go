@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// Default fastest-first-return thresholds.
+const (
+	DefaultFastestFirstProviders = 2
+	DefaultFastestFirstResults   = 50
+)
+
+// Strategy orchestrates the provider queries of a search: which providers are
+// queried, in which order and how many at once, and when the answers gathered
+// so far are enough to respond without waiting for the others.
+type Strategy interface {
+	// Name returns the strategy's name
+	Name() domain.FanOutStrategy
+
+	// Scatter queries providers by calling query, which blocks until the
+	// provider answers and returns the number of flights it returned, or its
+	// error. Scatter returns once every provider it queried answered. ctx is
+	// cancelled when the search stops gathering answers; query then fails
+	// without searching the provider.
+	Scatter(ctx context.Context, providers []domain.FlightProvider, query func(domain.FlightProvider) (flights int, err error))
+
+	// Enough reports whether the answers gathered so far, from succeeded
+	// providers returning flights flights in total, are enough to respond
+	// without waiting for the providers still running.
+	Enough(succeeded, flights int) bool
+}
+
+// errScatterStopped is returned by the query function of a Strategy once the
+// search stopped gathering answers.
+var errScatterStopped = errors.New("search stopped gathering provider answers")
+
+// AllParallel returns the all-parallel strategy: every provider is queried at
+// once and the search waits for all of them.
+func AllParallel() Strategy {
+	return allParallel{}
+}
+
+// allParallel implements the all-parallel strategy.
+type allParallel struct{}
+
+// Name implements Strategy.Name.
+func (allParallel) Name() domain.FanOutStrategy {
+	return domain.FanOutAllParallel
+}
+
+// Scatter implements Strategy.Scatter.
+func (allParallel) Scatter(_ context.Context, providers []domain.FlightProvider, query func(domain.FlightProvider) (int, error)) {
+	queryAll(providers, query)
+}
+
+// Enough implements Strategy.Enough.
+func (allParallel) Enough(int, int) bool {
+	return false
+}
+
+// FastestFirstReturn returns the fastest-first-return strategy: every
+// provider is queried at once, and the search responds as soon as providers
+// answered successfully or results flights arrived, whichever comes first.
+// A threshold of 0 or less is disabled. The providers still running are
+// reported as pending, like those of a partial response.
+func FastestFirstReturn(providers, results int) Strategy {
+	return fastestFirst{providers: providers, results: results}
+}
+
+// fastestFirst implements the fastest-first-return strategy.
+type fastestFirst struct {
+	providers int
+	results   int
+}
+
+// Name implements Strategy.Name.
+func (fastestFirst) Name() domain.FanOutStrategy {
+	return domain.FanOutFastestFirst
+}
+
+// Scatter implements Strategy.Scatter.
+func (fastestFirst) Scatter(_ context.Context, providers []domain.FlightProvider, query func(domain.FlightProvider) (int, error)) {
+	queryAll(providers, query)
+}
+
+// Enough implements Strategy.Enough.
+func (s fastestFirst) Enough(succeeded, flights int) bool {
+	return (s.providers > 0 && succeeded >= s.providers) || (s.results > 0 && flights >= s.results)
+}
+
+// SequentialFailover returns the sequential-failover strategy: providers are
+// queried one at a time, in their configured order, until one returns
+// flights. Providers failing or returning no flights fail over to the next
+// one; the providers after the one answering are never queried, which keeps
+// the cost of searches down for tenants paying per provider call.
+func SequentialFailover() Strategy {
+	return sequentialFailover{}
+}
+
+// sequentialFailover implements the sequential-failover strategy.
+type sequentialFailover struct{}
+
+// Name implements Strategy.Name.
+func (sequentialFailover) Name() domain.FanOutStrategy {
+	return domain.FanOutSequentialFailover
+}
+
+// Scatter implements Strategy.Scatter.
+func (sequentialFailover) Scatter(ctx context.Context, providers []domain.FlightProvider, query func(domain.FlightProvider) (int, error)) {
+	for _, p := range providers {
+		if ctx.Err() != nil {
+			return
+		}
+		if flights, err := query(p); err == nil && flights > 0 {
+			return
+		}
+	}
+}
+
+// Enough implements Strategy.Enough.
+func (sequentialFailover) Enough(int, int) bool {
+	return false
+}
+
+// queryAll queries every provider concurrently and waits for their answers.
+func queryAll(providers []domain.FlightProvider, query func(domain.FlightProvider) (int, error)) {
+	var wg sync.WaitGroup
+	for _, provider := range providers {
+		wg.Add(1)
+		go func(p domain.FlightProvider) {
+			defer wg.Done()
+			_, _ = query(p)
+		}(provider)
+	}
+	wg.Wait()
+}
+
+// scatterLog records the providers a strategy queried, until the search
+// stops gathering their answers. It is safe for concurrent use.
+type scatterLog struct {
+	mu       sync.Mutex
+	stopped  bool
+	launched []domain.FlightProvider
+}
+
+// start records that provider is queried; it returns false once the search
+// stopped gathering answers.
+func (l *scatterLog) start(provider domain.FlightProvider) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stopped {
+		return false
+	}
+	l.launched = append(l.launched, provider)
+	return true
+}
+
+// stop stops recording and returns the providers queried.
+func (l *scatterLog) stop() []domain.FlightProvider {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stopped = true
+	return l.launched
+}
+
+// strategies returns the strategies searches can select by name.
+func strategies(fastestProviders, fastestResults int) map[domain.FanOutStrategy]Strategy {
+	all := []Strategy{AllParallel(), FastestFirstReturn(fastestProviders, fastestResults), SequentialFailover()}
+	byName := make(map[domain.FanOutStrategy]Strategy, len(all))
+	for _, s := range all {
+		byName[s.Name()] = s
+	}
+	return byName
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// setupUnusedMockProvider creates a mock provider failing the test if searched.
+func setupUnusedMockProvider(ctrl *gomock.Controller, name string) *domain.MockFlightProvider {
+	mock := domain.NewMockFlightProvider(ctrl)
+	mock.EXPECT().Name().Return(name).AnyTimes()
+	return mock
+}
+
+func TestSearch_FastestFirstReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	providers := []domain.FlightProvider{
+		setupMockProviderWithDelay(ctrl, "fast", []domain.Flight{createTestFlight("1", "fast", 1000000, 120, 0)}, 10*time.Millisecond),
+		setupMockProvider(ctrl, "broken", nil, errors.New("provider error")),
+		setupMockProviderWithDelay(ctrl, "quick", []domain.Flight{createTestFlight("2", "quick", 900000, 120, 0)}, 30*time.Millisecond),
+		setupMockProviderWithDelay(ctrl, "slow", []domain.Flight{createTestFlight("3", "slow", 800000, 120, 0)}, 5*time.Second),
+	}
+	uc := NewFlightSearchUseCase(providers, &Config{
+		GlobalTimeout:         3 * time.Second,
+		ProviderTimeout:       2 * time.Second,
+		FastestFirstProviders: 2,
+	})
+
+	start := time.Now()
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{FanOutStrategy: domain.FanOutFastestFirst})
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	assert.Less(t, elapsed, time.Second, "responds once two providers answered")
+	assert.Len(t, response.Flights, 2)
+	assert.Equal(t, 4, response.Metadata.ProvidersQueried)
+	assert.Equal(t, 2, response.Metadata.ProvidersSucceeded)
+	assert.Equal(t, 1, response.Metadata.ProvidersFailed, "failures do not count as answers")
+	assert.Equal(t, []string{"slow"}, response.Metadata.PendingProviders)
+}
+
+func TestSearch_FastestFirstReturnResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	providers := []domain.FlightProvider{
+		setupMockProviderWithDelay(ctrl, "fast", []domain.Flight{
+			createTestFlight("1", "fast", 1000000, 120, 0),
+			createTestFlight("2", "fast", 1100000, 120, 0),
+		}, 10*time.Millisecond),
+		setupMockProviderWithDelay(ctrl, "slow", []domain.Flight{createTestFlight("3", "slow", 800000, 120, 0)}, 5*time.Second),
+	}
+	uc := NewFlightSearchUseCase(providers, &Config{
+		GlobalTimeout:         3 * time.Second,
+		ProviderTimeout:       2 * time.Second,
+		FanOutStrategy:        domain.FanOutFastestFirst,
+		FastestFirstProviders: 10,
+		FastestFirstResults:   2,
+	})
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+
+	require.NoError(t, err)
+	assert.Len(t, response.Flights, 2, "the configured strategy applies when the search selects none")
+	assert.Equal(t, []string{"slow"}, response.Metadata.PendingProviders)
+}
+
+func TestSearch_SequentialFailover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	cache := &searchCache{}
+	providers := []domain.FlightProvider{
+		setupMockProvider(ctrl, "broken", nil, errors.New("provider error")),
+		setupMockProvider(ctrl, "empty", nil, nil),
+		setupMockProvider(ctrl, "answering", []domain.Flight{createTestFlight("1", "answering", 1000000, 120, 0)}, nil),
+		setupUnusedMockProvider(ctrl, "expensive"),
+	}
+	uc := NewFlightSearchUseCase(providers, &Config{Cache: cache})
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{FanOutStrategy: domain.FanOutSequentialFailover})
+
+	require.NoError(t, err)
+	require.Len(t, response.Flights, 1)
+	assert.Equal(t, "answering", response.Flights[0].Provider)
+	assert.Equal(t, 3, response.Metadata.ProvidersQueried, "the providers after the answering one are not queried")
+	assert.Equal(t, 2, response.Metadata.ProvidersSucceeded)
+	assert.Equal(t, 1, response.Metadata.ProvidersFailed)
+	assert.Empty(t, response.Metadata.PendingProviders)
+	assert.Empty(t, cache.entries, "one provider's flights are not cached for searches querying all of them")
+}
+
+func TestSearch_SequentialFailoverAllFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	providers := []domain.FlightProvider{
+		setupMockProvider(ctrl, "broken", nil, errors.New("provider error")),
+		setupMockProviderWithPanic(ctrl, "panicking", "boom"),
+	}
+	uc := NewFlightSearchUseCase(providers, &Config{FanOutStrategy: domain.FanOutSequentialFailover})
+
+	_, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{})
+	assert.ErrorIs(t, err, domain.ErrAllProvidersFailed)
+}
+
+func TestSearch_SelectedStrategyOverridesConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	providers := []domain.FlightProvider{
+		setupMockProvider(ctrl, "first", []domain.Flight{createTestFlight("1", "first", 1000000, 120, 0)}, nil),
+		setupMockProvider(ctrl, "second", []domain.Flight{createTestFlight("2", "second", 900000, 120, 0)}, nil),
+	}
+	uc := NewFlightSearchUseCase(providers, &Config{FanOutStrategy: domain.FanOutSequentialFailover})
+
+	response, err := uc.Search(context.Background(), domain.SearchCriteria{}, SearchOptions{FanOutStrategy: domain.FanOutAllParallel})
+
+	require.NoError(t, err)
+	assert.Len(t, response.Flights, 2)
+	assert.Equal(t, 2, response.Metadata.ProvidersQueried)
+}
+
+func TestFastestFirstReturn_Enough(t *testing.T) {
+	tests := []struct {
+		name               string
+		providers, results int
+		succeeded, flights int
+		want               bool
+	}{
+		{"below both", 2, 10, 1, 5, false},
+		{"providers reached", 2, 10, 2, 0, true},
+		{"results reached", 2, 10, 1, 10, true},
+		{"providers disabled", 0, 10, 5, 5, false},
+		{"results disabled", 2, 0, 1, 100, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FastestFirstReturn(tt.providers, tt.results).Enough(tt.succeeded, tt.flights))
+		})
+	}
+}
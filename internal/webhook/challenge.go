@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ChallengeQueryParam carries the challenge of a registration handshake.
+const ChallengeQueryParam = "challenge"
+
+// ErrChallengeFailed is returned when a callback URL does not answer the challenge.
+var ErrChallengeFailed = errors.New("webhook challenge failed")
+
+// ValidateURL checks that a callback URL is an absolute http(s) URL.
+func ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return fmt.Errorf("callback URL must be an absolute http(s) URL, got %q", raw)
+	}
+	return nil
+}
+
+// Challenge proves that the endpoint at callbackURL belongs to the holder of
+// the registration secret before callbacks are sent to it. It sends a signed
+// GET with a random ?challenge= value, signed as if it were the body, and
+// expects a 200 response whose body is the challenge. Receivers answer with
+// Verifier.AnswerChallenge.
+func (s *Signer) Challenge(ctx context.Context, client *http.Client, callbackURL string) error {
+	if err := ValidateURL(callbackURL); err != nil {
+		return err
+	}
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return err
+	}
+	challenge := hex.EncodeToString(raw)
+
+	u, _ := url.Parse(callbackURL)
+	query := u.Query()
+	query.Set(ChallengeQueryParam, challenge)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	s.Sign(req, []byte(challenge))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrChallengeFailed, err)
+	}
+	defer resp.Body.Close()
+
+	answer, err := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrChallengeFailed, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: status %d", ErrChallengeFailed, resp.StatusCode)
+	}
+	if strings.TrimSpace(string(answer)) != challenge {
+		return fmt.Errorf("%w: response does not echo the challenge", ErrChallengeFailed)
+	}
+	return nil
+}
+
+// AnswerChallenge verifies a challenge request and returns the value the
+// receiver must respond with (status 200, plain text body).
+func (v *Verifier) AnswerChallenge(r *http.Request) (string, error) {
+	challenge := r.URL.Query().Get(ChallengeQueryParam)
+	if challenge == "" {
+		return "", ErrMissingSignature
+	}
+	if err := v.Verify(r.Header, []byte(challenge)); err != nil {
+		return "", err
+	}
+	return challenge, nil
+}
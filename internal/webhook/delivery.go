@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrDeliveryRejected is returned when a callback URL does not accept a delivery.
+var ErrDeliveryRejected = errors.New("webhook delivery rejected")
+
+// Post delivers body, a JSON document, to callbackURL as a signed POST. The
+// delivery is accepted when the endpoint answers with a 2xx status; callers
+// retry rejected deliveries, which receivers tell apart by their ID.
+func (s *Signer) Post(ctx context.Context, client *http.Client, callbackURL string, body []byte) error {
+	if err := ValidateURL(callbackURL); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.Sign(req, body)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDeliveryRejected, err)
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: status %d", ErrDeliveryRejected, resp.StatusCode)
+	}
+	return nil
+}
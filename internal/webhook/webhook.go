@@ -0,0 +1,240 @@
+// Package webhook signs outbound webhook callbacks and verifies them on the
+// receiving side.
+//
+// Each registration has its own secret. Deliveries carry a unique ID, a Unix
+// timestamp and an HMAC-SHA256 signature over "<timestamp>.<body>":
+//
+//	X-Webhook-ID: 3f0c...
+//	X-Webhook-Timestamp: 1764583200
+//	X-Webhook-Signature: v1=5d41402abc4b2a76b9719d911017c592...
+//
+// Receivers reject deliveries whose timestamp is outside a tolerance window,
+// and deliveries whose ID they have already accepted, so captured requests
+// cannot be replayed. Before a callback URL is accepted, the endpoint must
+// answer a signed GET challenge (see Challenge).
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+// Headers set on signed requests.
+const (
+	HeaderID        = "X-Webhook-ID"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// signatureVersion prefixes signatures so the scheme can evolve.
+const signatureVersion = "v1="
+
+// MinSecretLength is the minimum signing secret length in bytes.
+const MinSecretLength = 32
+
+// DefaultTolerance is the default maximum age of an accepted delivery.
+const DefaultTolerance = 5 * time.Minute
+
+// Sentinel errors returned by Verify.
+var (
+	// ErrMissingSignature is returned when a signature header is absent
+	ErrMissingSignature = errors.New("webhook signature headers missing")
+
+	// ErrInvalidSignature is returned when the signature does not match
+	ErrInvalidSignature = errors.New("webhook signature invalid")
+
+	// ErrStaleTimestamp is returned for deliveries outside the tolerance window
+	ErrStaleTimestamp = errors.New("webhook timestamp outside tolerance")
+
+	// ErrReplayed is returned for deliveries whose ID was already accepted
+	ErrReplayed = errors.New("webhook delivery already received")
+)
+
+// NewSecret returns a random secret for a new registration, encoded for
+// sharing with the partner.
+func NewSecret() (string, error) {
+	b := make([]byte, MinSecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Signer signs outbound webhook requests.
+type Signer struct {
+	secret []byte
+	clock  timeutil.Clock
+}
+
+// SignerOption configures a Signer.
+type SignerOption func(*Signer)
+
+// WithSignerClock sets the clock used for timestamps.
+func WithSignerClock(clock timeutil.Clock) SignerOption {
+	return func(s *Signer) {
+		s.clock = clock
+	}
+}
+
+// NewSigner creates a signer for a registration secret.
+func NewSigner(secret string, opts ...SignerOption) (*Signer, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("secret must be at least %d bytes", MinSecretLength)
+	}
+	s := &Signer{secret: []byte(secret), clock: timeutil.NewRealClock()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Sign sets the delivery ID, timestamp and signature headers of req for body.
+// body must be the exact bytes sent as the request body.
+func (s *Signer) Sign(req *http.Request, body []byte) {
+	timestamp := strconv.FormatInt(s.clock.Now().Unix(), 10)
+	req.Header.Set(HeaderID, uuid.NewString())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, signatureVersion+sign(s.secret, timestamp, body))
+}
+
+// Verifier checks inbound webhook requests. Partners can use it to validate
+// our callbacks; it is safe for concurrent use.
+type Verifier struct {
+	secret    []byte
+	tolerance time.Duration
+	clock     timeutil.Clock
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// VerifierOption configures a Verifier.
+type VerifierOption func(*Verifier)
+
+// WithTolerance sets the maximum difference between a delivery's timestamp
+// and the current time.
+func WithTolerance(d time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.tolerance = d
+	}
+}
+
+// WithVerifierClock sets the clock used for the tolerance check.
+func WithVerifierClock(clock timeutil.Clock) VerifierOption {
+	return func(v *Verifier) {
+		v.clock = clock
+	}
+}
+
+// NewVerifier creates a verifier for a registration secret.
+func NewVerifier(secret string, opts ...VerifierOption) *Verifier {
+	v := &Verifier{
+		secret:    []byte(secret),
+		tolerance: DefaultTolerance,
+		clock:     timeutil.NewRealClock(),
+		seen:      make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Verify checks the signature headers against body and rejects stale or
+// replayed deliveries. A delivery ID is remembered only once it verifies.
+func (v *Verifier) Verify(header http.Header, body []byte) error {
+	id, timestamp, signature := header.Get(HeaderID), header.Get(HeaderTimestamp), header.Get(HeaderSignature)
+	if id == "" || timestamp == "" || signature == "" {
+		return ErrMissingSignature
+	}
+
+	expected := sign(v.secret, timestamp, body)
+	if !matchesAny(signature, expected) {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	now := v.clock.Now()
+	sent := time.Unix(unix, 0)
+	if sent.Before(now.Add(-v.tolerance)) || sent.After(now.Add(v.tolerance)) {
+		return ErrStaleTimestamp
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.prune(now)
+	if _, ok := v.seen[id]; ok {
+		return ErrReplayed
+	}
+	v.seen[id] = sent
+	return nil
+}
+
+// VerifyRequest reads and verifies the body of r, leaving the body readable
+// for the next handler. It returns the body.
+func (v *Verifier) VerifyRequest(r *http.Request) ([]byte, error) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read webhook body: %w", err)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if err := v.Verify(r.Header, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// prune forgets delivery IDs old enough that their timestamp is rejected anyway.
+func (v *Verifier) prune(now time.Time) {
+	cutoff := now.Add(-v.tolerance)
+	for id, sent := range v.seen {
+		if sent.Before(cutoff) {
+			delete(v.seen, id)
+		}
+	}
+}
+
+// sign returns the hex HMAC-SHA256 of "<timestamp>.<body>".
+func sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// matchesAny reports whether a signature header, which may list several
+// comma-separated signatures during a secret rotation, contains expected.
+func matchesAny(header, expected string) bool {
+	for _, part := range strings.Split(header, ",") {
+		candidate, ok := strings.CutPrefix(strings.TrimSpace(part), signatureVersion)
+		if ok && hmac.Equal([]byte(candidate), []byte(expected)) {
+			return true
+		}
+	}
+	return false
+}
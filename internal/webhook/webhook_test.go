@@ -0,0 +1,198 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/infrastructure/timeutil"
+)
+
+var testSecret = strings.Repeat("w", MinSecretLength)
+
+func signedRequest(t *testing.T, signer *Signer, body string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body))
+	signer.Sign(req, []byte(body))
+	return req
+}
+
+func TestSignAndVerify(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	signer, err := NewSigner(testSecret, WithSignerClock(clock))
+	require.NoError(t, err)
+	verifier := NewVerifier(testSecret, WithVerifierClock(clock), WithTolerance(time.Minute))
+
+	req := signedRequest(t, signer, `{"searchId":"s1"}`)
+	assert.Equal(t, "1764583200", req.Header.Get(HeaderTimestamp))
+	assert.True(t, strings.HasPrefix(req.Header.Get(HeaderSignature), "v1="))
+
+	body, err := verifier.VerifyRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, `{"searchId":"s1"}`, string(body))
+
+	// The same delivery cannot be accepted twice
+	_, err = verifier.VerifyRequest(signedRequestCopy(req, body))
+	assert.ErrorIs(t, err, ErrReplayed)
+}
+
+func signedRequestCopy(req *http.Request, body []byte) *http.Request {
+	clone := httptest.NewRequest(req.Method, req.URL.String(), strings.NewReader(string(body)))
+	clone.Header = req.Header.Clone()
+	return clone
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	clock := timeutil.NewMockClock(time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC))
+	signer, err := NewSigner(testSecret, WithSignerClock(clock))
+	require.NoError(t, err)
+	other, err := NewSigner(strings.Repeat("x", MinSecretLength), WithSignerClock(clock))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     func() *http.Request
+		advance time.Duration
+		wantErr error
+	}{
+		{
+			name:    "unsigned",
+			req:     func() *http.Request { return httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}")) },
+			wantErr: ErrMissingSignature,
+		},
+		{
+			name:    "wrong secret",
+			req:     func() *http.Request { return signedRequest(t, other, "{}") },
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "tampered body",
+			req: func() *http.Request {
+				req := signedRequest(t, signer, `{"price":100}`)
+				return signedRequestCopy(req, []byte(`{"price":1}`))
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "tampered timestamp",
+			req: func() *http.Request {
+				req := signedRequest(t, signer, "{}")
+				req.Header.Set(HeaderTimestamp, "1764583260")
+				return req
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "stale",
+			req:     func() *http.Request { return signedRequest(t, signer, "{}") },
+			advance: 6 * time.Minute,
+			wantErr: ErrStaleTimestamp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifierClock := timeutil.NewMockClock(clock.Now().Add(tt.advance))
+			verifier := NewVerifier(testSecret, WithVerifierClock(verifierClock))
+
+			_, err := verifier.VerifyRequest(tt.req())
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestVerify_RotatedSecret(t *testing.T) {
+	signer, err := NewSigner(testSecret)
+	require.NoError(t, err)
+	req := signedRequest(t, signer, "{}")
+	req.Header.Set(HeaderSignature, "v1=0000,"+req.Header.Get(HeaderSignature))
+
+	_, err = NewVerifier(testSecret).VerifyRequest(req)
+	assert.NoError(t, err)
+}
+
+func TestNewSigner_ShortSecret(t *testing.T) {
+	_, err := NewSigner("short")
+	assert.ErrorContains(t, err, "secret must be at least 32 bytes")
+
+	secret, err := NewSecret()
+	require.NoError(t, err)
+	_, err = NewSigner(secret)
+	assert.NoError(t, err)
+}
+
+func TestChallenge(t *testing.T) {
+	signer, err := NewSigner(testSecret)
+	require.NoError(t, err)
+
+	verifier := NewVerifier(testSecret)
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		answer, err := verifier.AnswerChallenge(r)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(answer))
+	}))
+	defer good.Close()
+
+	wrongSecret := NewVerifier(strings.Repeat("x", MinSecretLength))
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := wrongSecret.AnswerChallenge(r); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}))
+	defer bad.Close()
+
+	ignoring := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ignoring.Close()
+
+	ctx := context.Background()
+	assert.NoError(t, signer.Challenge(ctx, good.Client(), good.URL+"/hooks?partner=1"))
+	assert.ErrorIs(t, signer.Challenge(ctx, bad.Client(), bad.URL), ErrChallengeFailed)
+	assert.ErrorIs(t, signer.Challenge(ctx, ignoring.Client(), ignoring.URL), ErrChallengeFailed)
+	assert.ErrorContains(t, signer.Challenge(ctx, http.DefaultClient, "ftp://example.com"), "absolute http(s) URL")
+}
+
+func TestPost(t *testing.T) {
+	signer, err := NewSigner(testSecret)
+	require.NoError(t, err)
+
+	verifier := NewVerifier(testSecret)
+	var received []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := verifier.VerifyRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received = body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	ctx := context.Background()
+	body := []byte(`{"search_id":"search-1"}`)
+	require.NoError(t, signer.Post(ctx, receiver.Client(), receiver.URL+"/callbacks", body))
+	assert.Equal(t, body, received)
+
+	err = signer.Post(ctx, failing.Client(), failing.URL, body)
+	assert.ErrorIs(t, err, ErrDeliveryRejected)
+	assert.ErrorContains(t, err, "status 502")
+	assert.ErrorContains(t, signer.Post(ctx, http.DefaultClient, "/relative", body), "absolute http(s) URL")
+}
@@ -0,0 +1,219 @@
+// Package workerpool isolates providers from one another with bounded worker
+// pools.
+//
+// Each provider gets its own Pool: a fixed number of workers, each running one
+// provider call at a time, and a bounded queue of calls waiting for a worker.
+// Calls arriving with the queue full are rejected at once. A slow provider
+// piling up searches, retries included, therefore holds at most its own
+// workers and queue, and cannot exhaust the goroutines and connections other
+// providers need.
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// Default settings.
+const (
+	DefaultSize  = 16
+	DefaultQueue = 32
+)
+
+// ErrQueueFull is returned for calls rejected because all workers were busy
+// and the queue was full.
+var ErrQueueFull = errors.New("worker pool queue full")
+
+// Stats is a snapshot of a pool's utilization.
+type Stats struct {
+	// Size is the number of workers
+	Size int
+
+	// Busy is the number of workers running a call
+	Busy int
+
+	// Queued is the number of calls waiting for a worker
+	Queued int
+}
+
+// Pool runs calls on a bounded number of workers. It is safe for concurrent use.
+type Pool struct {
+	workers  chan struct{}
+	maxQueue int
+
+	mu     sync.Mutex
+	queued int
+}
+
+// New creates a pool of size workers queuing at most queue calls.
+func New(size, queue int) (*Pool, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("pool size must be positive")
+	}
+	if queue < 0 {
+		return nil, fmt.Errorf("queue length must not be negative")
+	}
+	return &Pool{workers: make(chan struct{}, size), maxQueue: queue}, nil
+}
+
+// Do runs fn once a worker is free. It returns ErrQueueFull without running fn
+// when no worker is free and the queue is full, and ctx's error if ctx is done
+// before a worker frees up.
+func (p *Pool) Do(ctx context.Context, fn func() error) error {
+	// Take a free worker right away if there is one
+	select {
+	case p.workers <- struct{}{}:
+		defer p.release()
+		return fn()
+	default:
+	}
+
+	p.mu.Lock()
+	if p.queued >= p.maxQueue {
+		p.mu.Unlock()
+		return ErrQueueFull
+	}
+	p.queued++
+	p.mu.Unlock()
+
+	select {
+	case p.workers <- struct{}{}:
+		p.dequeue()
+		defer p.release()
+		return fn()
+	case <-ctx.Done():
+		p.dequeue()
+		return ctx.Err()
+	}
+}
+
+// Stats returns the pool's current utilization.
+func (p *Pool) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Stats{Size: cap(p.workers), Busy: len(p.workers), Queued: p.queued}
+}
+
+func (p *Pool) dequeue() {
+	p.mu.Lock()
+	p.queued--
+	p.mu.Unlock()
+}
+
+func (p *Pool) release() {
+	<-p.workers
+}
+
+// Set holds one pool per provider.
+type Set struct {
+	size     int
+	queue    int
+	sizes    map[string]int
+	onReject func(provider string)
+
+	mu    sync.Mutex
+	pools map[string]*Pool
+}
+
+// Option configures a Set.
+type Option func(*Set)
+
+// WithSizes overrides the pool size of the named providers.
+func WithSizes(sizes map[string]int) Option {
+	return func(s *Set) {
+		s.sizes = sizes
+	}
+}
+
+// WithRejectionObserver sets a function notified of each call rejected
+// because the provider's queue was full.
+func WithRejectionObserver(fn func(provider string)) Option {
+	return func(s *Set) {
+		s.onReject = fn
+	}
+}
+
+// NewSet creates a set giving each provider a pool of size workers queuing at
+// most queue calls.
+func NewSet(size, queue int, opts ...Option) (*Set, error) {
+	s := &Set{size: size, queue: queue, pools: make(map[string]*Pool)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if _, err := New(size, queue); err != nil {
+		return nil, err
+	}
+	for provider, size := range s.sizes {
+		if _, err := New(size, queue); err != nil {
+			return nil, fmt.Errorf("%s: %w", provider, err)
+		}
+	}
+	return s, nil
+}
+
+// Pool returns the named provider's pool, creating it on first use.
+func (s *Set) Pool(provider string) *Pool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pool, ok := s.pools[provider]; ok {
+		return pool
+	}
+	size := s.size
+	if override, ok := s.sizes[provider]; ok {
+		size = override
+	}
+	// Sizes were validated by NewSet
+	pool, _ := New(size, s.queue)
+	s.pools[provider] = pool
+	return pool
+}
+
+// isolatedProvider runs the searches of the wrapped provider on its pool.
+type isolatedProvider struct {
+	domain.FlightProvider
+	pool     *Pool
+	onReject func(provider string)
+}
+
+// Instrument wraps a provider so its searches run on its own pool.
+func (s *Set) Instrument(p domain.FlightProvider) domain.FlightProvider {
+	return isolatedProvider{FlightProvider: p, pool: s.Pool(p.Name()), onReject: s.onReject}
+}
+
+// InstrumentAll wraps each provider with Instrument.
+func (s *Set) InstrumentAll(providers []domain.FlightProvider) []domain.FlightProvider {
+	wrapped := make([]domain.FlightProvider, len(providers))
+	for i, p := range providers {
+		wrapped[i] = s.Instrument(p)
+	}
+	return wrapped
+}
+
+// Search implements domain.FlightProvider. Rejected searches fail with a
+// non-retryable ProviderError wrapping ErrQueueFull: retrying would only add
+// to the backlog.
+func (p isolatedProvider) Search(ctx context.Context, criteria domain.SearchCriteria) ([]domain.Flight, error) {
+	return p.SearchWithOptions(ctx, criteria, domain.ProviderSearchOptions{})
+}
+
+// SearchWithOptions implements domain.FilteringProvider, like Search.
+func (p isolatedProvider) SearchWithOptions(ctx context.Context, criteria domain.SearchCriteria, opts domain.ProviderSearchOptions) ([]domain.Flight, error) {
+	var flights []domain.Flight
+	err := p.pool.Do(ctx, func() error {
+		var err error
+		flights, err = domain.SearchWithOptions(ctx, p.FlightProvider, criteria, opts)
+		return err
+	})
+	if errors.Is(err, ErrQueueFull) {
+		if p.onReject != nil {
+			p.onReject(p.Name())
+		}
+		return nil, domain.NewProviderError(p.Name(), err)
+	}
+	return flights, err
+}
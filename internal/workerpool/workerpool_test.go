@@ -0,0 +1,150 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// blockingProvider answers once released, counting the searches it is running.
+type blockingProvider struct {
+	name    string
+	release chan struct{}
+	started chan struct{}
+}
+
+func newBlockingProvider(name string) *blockingProvider {
+	return &blockingProvider{name: name, release: make(chan struct{}), started: make(chan struct{}, 16)}
+}
+
+func (p *blockingProvider) Name() string { return p.name }
+
+func (p *blockingProvider) Search(ctx context.Context, _ domain.SearchCriteria) ([]domain.Flight, error) {
+	p.started <- struct{}{}
+	select {
+	case <-p.release:
+		return []domain.Flight{{ID: "GA400"}}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// waitFor waits until the pool reports the given utilization.
+func waitFor(t *testing.T, pool *Pool, want Stats) {
+	t.Helper()
+	require.Eventually(t, func() bool { return pool.Stats() == want }, time.Second, time.Millisecond)
+}
+
+func TestPool_QueuesAndRejects(t *testing.T) {
+	pool, err := New(1, 1)
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, pool.Do(context.Background(), func() error {
+				<-release
+				return nil
+			}))
+		}()
+	}
+	waitFor(t, pool, Stats{Size: 1, Busy: 1, Queued: 1})
+
+	ran := false
+	err = pool.Do(context.Background(), func() error {
+		ran = true
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrQueueFull)
+	assert.False(t, ran)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, Stats{Size: 1}, pool.Stats())
+}
+
+func TestPool_QueuedCallCancelled(t *testing.T) {
+	pool, err := New(1, 1)
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	go func() {
+		_ = pool.Do(context.Background(), func() error {
+			<-release
+			return nil
+		})
+	}()
+	waitFor(t, pool, Stats{Size: 1, Busy: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = pool.Do(ctx, func() error { return errors.New("must not run") })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, pool.Stats().Queued)
+
+	close(release)
+	waitFor(t, pool, Stats{Size: 1})
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, err := New(0, 1)
+	assert.Error(t, err)
+
+	_, err = New(1, -1)
+	assert.Error(t, err)
+
+	_, err = NewSet(1, 1, WithSizes(map[string]int{"lion_air": 0}))
+	assert.ErrorContains(t, err, "lion_air")
+}
+
+func TestSet_IsolatesProviders(t *testing.T) {
+	var rejected []string
+	set, err := NewSet(1, 0,
+		WithSizes(map[string]int{"airasia": 2}),
+		WithRejectionObserver(func(provider string) { rejected = append(rejected, provider) }),
+	)
+	require.NoError(t, err)
+
+	slow := newBlockingProvider("lion_air")
+	fast := newBlockingProvider("garuda_indonesia")
+	providers := set.InstrumentAll([]domain.FlightProvider{slow, fast})
+	assert.Equal(t, "lion_air", providers[0].Name())
+
+	// The slow provider holds its only worker...
+	done := make(chan error, 1)
+	go func() {
+		_, err := providers[0].Search(context.Background(), domain.SearchCriteria{})
+		done <- err
+	}()
+	<-slow.started
+
+	// ...so its next search is rejected...
+	_, err = providers[0].Search(context.Background(), domain.SearchCriteria{})
+	var providerErr *domain.ProviderError
+	require.ErrorAs(t, err, &providerErr)
+	assert.ErrorIs(t, err, ErrQueueFull)
+	assert.False(t, providerErr.Retryable)
+	assert.Equal(t, []string{"lion_air"}, rejected)
+
+	// ...while the other provider is unaffected
+	close(fast.release)
+	flights, err := providers[1].Search(context.Background(), domain.SearchCriteria{})
+	require.NoError(t, err)
+	assert.Len(t, flights, 1)
+
+	close(slow.release)
+	require.NoError(t, <-done)
+
+	assert.Same(t, set.Pool("lion_air"), set.Pool("lion_air"))
+	assert.Equal(t, 2, set.Pool("airasia").Stats().Size)
+}
@@ -11,6 +11,7 @@ import (
 
 	httpAdapter "github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
 	"github.com/flight-search/flight-search-and-aggregation-system/test/mock"
 )
 
@@ -51,9 +52,10 @@ func TestConcurrent_MultipleSearchRequests(t *testing.T) {
 		assert.Len(t, resp.Flights, 3, "request %d should have 3 flights", i)
 	}
 
-	// Each concurrent request should trigger provider calls
+	// Identical concurrent requests share provider calls
 	// (The mock provider tracks call count)
-	assert.GreaterOrEqual(t, provider.CallCount(), numRequests)
+	assert.GreaterOrEqual(t, provider.CallCount(), 1)
+	assert.Less(t, provider.CallCount(), numRequests)
 }
 
 // TestConcurrent_IndependentResults tests that each concurrent request
@@ -89,10 +91,12 @@ func TestConcurrent_IndependentResults(t *testing.T) {
 
 	wg.Wait()
 
-	// Assert - All requests should get same result structure
+	// Assert - All requests should get same result structure. Both providers
+	// return the first two sample flights, which are merged into one offer each.
 	for i := 0; i < numRequests; i++ {
 		require.NotNil(t, results[i], "request %d should have result", i)
-		assert.Len(t, results[i].Flights, 5, "request %d should have 5 flights (2+3)", i)
+		assert.Len(t, results[i].Flights, 3, "request %d should have 3 flights (2+3, 2 duplicates)", i)
+		assert.Equal(t, 2, results[i].Metadata.DuplicatesMerged)
 		assert.Equal(t, 2, results[i].Metadata.ProvidersQueried)
 	}
 }
@@ -175,7 +179,8 @@ func TestConcurrent_ProviderCallCountAccuracy(t *testing.T) {
 	// Arrange
 	provider := mock.NewProvider("garuda").WithFlights(mock.SampleFlights("garuda", 1))
 
-	uc := CreateUseCase([]domain.FlightProvider{provider})
+	// Identical searches would otherwise share provider calls
+	uc := CreateUseCaseWithConfig([]domain.FlightProvider{provider}, &usecase.Config{DisableCoalescing: true})
 	ts := NewTestServer(uc)
 
 	numRequests := 100
@@ -0,0 +1,42 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDemo_SearchFlights_EndToEnd runs a search through the HTTP stack against
+// the real provider mock data served from the in-memory demo store.
+func TestDemo_SearchFlights_EndToEnd(t *testing.T) {
+	// Arrange
+	providers, err := DemoProviders()
+	require.NoError(t, err)
+	ts := NewTestServer(CreateUseCase(providers))
+
+	req := DefaultSearchRequest()
+
+	// Act
+	resp := ts.SearchRequest(req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	searchResp, err := resp.ParseSearchResponse()
+	require.NoError(t, err)
+	require.NotEmpty(t, searchResp.Flights, "mock data should be shifted to the requested date")
+	assert.Equal(t, 4, searchResp.Metadata.ProvidersQueried)
+	assert.Equal(t, 4, searchResp.Metadata.ProvidersSucceeded)
+	assert.Zero(t, searchResp.Metadata.ProvidersFailed)
+
+	providerNames := make(map[string]bool)
+	for _, f := range searchResp.Flights {
+		assert.Equal(t, "CGK", f.Departure.Airport)
+		assert.Equal(t, "DPS", f.Arrival.Airport)
+		assert.Contains(t, f.Departure.DateTime, req.DepartureDate)
+		providerNames[f.Provider] = true
+	}
+	assert.Greater(t, len(providerNames), 1, "results should come from several providers")
+}
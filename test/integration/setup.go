@@ -5,6 +5,7 @@ package integration
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +14,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	httpAdapter "github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/http"
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/adapter/provider/demo"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
 	"github.com/flight-search/flight-search-and-aggregation-system/internal/usecase"
 )
@@ -149,6 +151,19 @@ func CreateUseCaseWithConfig(providers []domain.FlightProvider, config *usecase.
 	return usecase.NewFlightSearchUseCase(providers, config)
 }
 
+// MockDataDir is the provider mock data directory relative to this package.
+const MockDataDir = "../../docs/response-mock"
+
+// DemoProviders returns providers serving the real mock data from an in-memory
+// demo store. Dates are shifted so any requested departure date has flights.
+func DemoProviders() ([]domain.FlightProvider, error) {
+	store, err := demo.LoadMockDir(context.Background(), MockDataDir, demo.WithDateShift())
+	if err != nil {
+		return nil, err
+	}
+	return store.Providers(), nil
+}
+
 // FutureDate returns a date string 30 days in the future in YYYY-MM-DD format.
 func FutureDate() string {
 	return time.Now().AddDate(0, 0, 30).Format("2006-01-02")
@@ -0,0 +1,174 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Drift is a kind of provider schema drift applied by MutateJSON.
+type Drift int
+
+const (
+	// DriftMissingField removes a field from an object
+	DriftMissingField Drift = iota
+
+	// DriftNull replaces a value with null
+	DriftNull
+
+	// DriftExtraField adds an unknown field to an object
+	DriftExtraField
+
+	// DriftWrongType replaces a value with a value of a different JSON type
+	DriftWrongType
+
+	driftCount
+)
+
+// String returns the drift name.
+func (d Drift) String() string {
+	switch d {
+	case DriftMissingField:
+		return "missing_field"
+	case DriftNull:
+		return "null"
+	case DriftExtraField:
+		return "extra_field"
+	case DriftWrongType:
+		return "wrong_type"
+	default:
+		return fmt.Sprintf("drift(%d)", int(d))
+	}
+}
+
+// slot is a location in a decoded JSON document that can be replaced or deleted.
+type slot struct {
+	object map[string]interface{}
+	key    string
+	array  []interface{}
+	index  int
+}
+
+func (s slot) get() interface{} {
+	if s.object != nil {
+		return s.object[s.key]
+	}
+	return s.array[s.index]
+}
+
+func (s slot) set(v interface{}) {
+	if s.object != nil {
+		s.object[s.key] = v
+		return
+	}
+	s.array[s.index] = v
+}
+
+// MutateJSON simulates provider schema drift by applying n random mutations
+// (missing fields, nulls, extra fields, wrong types) to a JSON document.
+// The same rng state always produces the same output.
+func MutateJSON(data []byte, rng *rand.Rand, n int) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var root interface{}
+	if err := dec.Decode(&root); err != nil {
+		return nil, fmt.Errorf("decode document: %w", err)
+	}
+
+	for i := 0; i < n; i++ {
+		slots, objects := collect(root)
+		drift := Drift(rng.Intn(int(driftCount)))
+
+		switch {
+		case drift == DriftExtraField && len(objects) > 0:
+			obj := objects[rng.Intn(len(objects))]
+			obj[fmt.Sprintf("drift_%d", rng.Intn(1000))] = randomValue(rng)
+		case len(slots) == 0:
+			// Root is a scalar; replace it entirely
+			root = randomValue(rng)
+		case drift == DriftMissingField:
+			s := slots[rng.Intn(len(slots))]
+			if s.object != nil {
+				delete(s.object, s.key)
+			} else {
+				s.set(nil)
+			}
+		case drift == DriftNull:
+			slots[rng.Intn(len(slots))].set(nil)
+		default:
+			s := slots[rng.Intn(len(slots))]
+			s.set(wrongType(rng, s.get()))
+		}
+	}
+
+	return json.Marshal(root)
+}
+
+// collect returns every replaceable slot and every object in a decoded document.
+func collect(root interface{}) ([]slot, []map[string]interface{}) {
+	var slots []slot
+	var objects []map[string]interface{}
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			objects = append(objects, node)
+			// Visit keys in order so the same rng state yields the same mutations
+			keys := make([]string, 0, len(node))
+			for k := range node {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				slots = append(slots, slot{object: node, key: k})
+				walk(node[k])
+			}
+		case []interface{}:
+			for i, child := range node {
+				slots = append(slots, slot{array: node, index: i})
+				walk(child)
+			}
+		}
+	}
+	walk(root)
+
+	return slots, objects
+}
+
+// wrongType returns a value of a different JSON type than v.
+func wrongType(rng *rand.Rand, v interface{}) interface{} {
+	switch v.(type) {
+	case string:
+		return rng.Intn(100000)
+	case json.Number, float64:
+		return fmt.Sprintf("%d", rng.Intn(100000))
+	case bool:
+		return "true"
+	case map[string]interface{}:
+		return []interface{}{"drift"}
+	case []interface{}:
+		return map[string]interface{}{"drift": true}
+	default:
+		return randomValue(rng)
+	}
+}
+
+// randomValue returns a random JSON value.
+func randomValue(rng *rand.Rand) interface{} {
+	switch rng.Intn(5) {
+	case 0:
+		return "drift"
+	case 1:
+		return rng.Intn(1000)
+	case 2:
+		return rng.Intn(2) == 0
+	case 3:
+		return []interface{}{}
+	default:
+		return map[string]interface{}{}
+	}
+}
@@ -0,0 +1,58 @@
+package testutil
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const driftDocument = `{"status":"ok","flights":[{"id":"GA400","price":{"amount":1000000,"currency":"IDR"},"direct":true}]}`
+
+func TestMutateJSON_Deterministic(t *testing.T) {
+	a, err := MutateJSON([]byte(driftDocument), rand.New(rand.NewSource(42)), 5)
+	require.NoError(t, err)
+	b, err := MutateJSON([]byte(driftDocument), rand.New(rand.NewSource(42)), 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+	assert.True(t, json.Valid(a))
+}
+
+func TestMutateJSON_ChangesDocument(t *testing.T) {
+	changed := 0
+	for seed := int64(0); seed < 20; seed++ {
+		out, err := MutateJSON([]byte(driftDocument), rand.New(rand.NewSource(seed)), 1)
+		require.NoError(t, err)
+		require.True(t, json.Valid(out))
+
+		if !assert.ObjectsAreEqual(decode(t, []byte(driftDocument)), decode(t, out)) {
+			changed++
+		}
+	}
+	assert.Greater(t, changed, 15, "most single mutations should alter the document")
+}
+
+func TestMutateJSON_InvalidInput(t *testing.T) {
+	_, err := MutateJSON([]byte("{"), rand.New(rand.NewSource(1)), 1)
+	assert.Error(t, err)
+}
+
+func TestWrongType(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	assert.IsType(t, 0, wrongType(rng, "text"))
+	assert.IsType(t, "", wrongType(rng, json.Number("1")))
+	assert.IsType(t, "", wrongType(rng, true))
+	assert.IsType(t, []interface{}{}, wrongType(rng, map[string]interface{}{}))
+	assert.IsType(t, map[string]interface{}{}, wrongType(rng, []interface{}{}))
+}
+
+func decode(t *testing.T, data []byte) interface{} {
+	t.Helper()
+	var v interface{}
+	require.NoError(t, json.Unmarshal(data, &v))
+	return v
+}
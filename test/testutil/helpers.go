@@ -33,7 +33,7 @@ func LoadTestJSON(t *testing.T, filename string) []byte {
 
 // LoadMockJSON loads a JSON file from the docs/response-mock directory.
 // This is a convenience function for loading provider mock responses.
-func LoadMockJSON(t *testing.T, filename string) []byte {
+func LoadMockJSON(t testing.TB, filename string) []byte {
 	t.Helper()
 
 	_, currentFile, _, ok := runtime.Caller(0)
@@ -0,0 +1,55 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flight-search/flight-search-and-aggregation-system/internal/domain"
+)
+
+// providerErrorCategories are the sentinel errors a ProviderError must wrap
+// so callers can decide how to handle it.
+var providerErrorCategories = []error{
+	domain.ErrMalformedResponse,
+	domain.ErrProviderUnavailable,
+	domain.ErrProviderTimeout,
+	context.Canceled,
+	context.DeadlineExceeded,
+}
+
+// AssertProviderOutcome checks the contract every provider adapter must honor:
+// a search returns either valid flights attributed to the provider, or a
+// *domain.ProviderError for that provider wrapping a known error category.
+func AssertProviderOutcome(t testing.TB, provider string, flights []domain.Flight, err error) {
+	t.Helper()
+
+	if err != nil {
+		var providerErr *domain.ProviderError
+		if !errors.As(err, &providerErr) {
+			t.Fatalf("expected *domain.ProviderError, got %T: %v", err, err)
+		}
+		if providerErr.Provider != provider {
+			t.Errorf("error attributed to %q, want %q", providerErr.Provider, provider)
+		}
+		for _, category := range providerErrorCategories {
+			if errors.Is(err, category) {
+				return
+			}
+		}
+		t.Errorf("provider error is not categorized: %v", err)
+		return
+	}
+
+	if flights == nil {
+		t.Fatal("expected non-nil flights when no error is returned")
+	}
+	for _, f := range flights {
+		if f.Provider != provider {
+			t.Errorf("flight %s attributed to %q, want %q", f.ID, f.Provider, provider)
+		}
+		if verr := f.Validate(); verr != nil {
+			t.Errorf("adapter returned invalid flight %s: %v", f.ID, verr)
+		}
+	}
+}